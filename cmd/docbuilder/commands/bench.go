@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/bench"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// BenchCmd implements the 'bench' command.
+type BenchCmd struct {
+	Repos        int    `name:"repos" default:"10" help:"Number of synthetic repositories"`
+	Files        int    `name:"files" default:"50" help:"Markdown files per repository"`
+	Render       bool   `name:"render" help:"Also run Hugo to render the site (requires the hugo binary)"`
+	Dir          string `name:"dir" help:"Directory for the synthetic corpus and output (default: a temporary directory, removed afterwards)"`
+	Format       string `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+	ProfileFlags `embed:""`
+}
+
+func (b *BenchCmd) Run(_ *Global, _ *CLI) error {
+	dir := b.Dir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "docbuilder-bench-")
+		if err != nil {
+			return fmt.Errorf("create bench directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		dir = tmp
+	}
+
+	renderMode := config.RenderModeNever
+	if b.Render {
+		renderMode = config.RenderModeAlways
+	}
+
+	stopProfiling, err := b.ProfileFlags.start()
+	if err != nil {
+		return err
+	}
+	result, err := bench.Run(context.Background(), bench.Options{
+		Repositories: b.Repos,
+		Files:        b.Files,
+		Dir:          dir,
+		RenderMode:   renderMode,
+	})
+	stopProfiling()
+	if err != nil {
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+
+	if b.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	return writeBenchResult(result)
+}
+
+func writeBenchResult(r *bench.Result) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Repositories:\t%d\n", r.Repositories)
+	_, _ = fmt.Fprintf(w, "Files:\t%d\n", r.Files)
+	_, _ = fmt.Fprintf(w, "Pages:\t%d\n", r.Pages)
+	_, _ = fmt.Fprintf(w, "Discovery:\t%s\n", r.Discovery.Round(time.Microsecond))
+	_, _ = fmt.Fprintf(w, "Generation:\t%s\n", r.Generation.Round(time.Microsecond))
+	_, _ = fmt.Fprintf(w, "Total:\t%s\n", r.Total.Round(time.Microsecond))
+	_, _ = fmt.Fprintf(w, "Throughput:\t%.0f files/s\n", r.FilesPerSecond)
+	_, _ = fmt.Fprintf(w, "Allocations:\t%d (%.1f MiB)\n", r.Allocations, float64(r.AllocatedBytes)/(1<<20))
+	_, _ = fmt.Fprintf(w, "\nStage\tDuration\n")
+	for _, s := range r.Stages {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", s.Stage, s.Duration.Round(time.Microsecond))
+	}
+	return w.Flush()
+}
@@ -9,20 +9,26 @@ import (
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/workspace"
 )
 
 // BuildCmd implements the 'build' command.
 type BuildCmd struct {
-	Output        string `short:"o" default:"./site" help:"Output directory for generated site"`
-	Incremental   bool   `short:"i" help:"Use incremental updates instead of fresh clone"`
-	RenderMode    string `name:"render-mode" help:"Override build.render_mode (auto|always|never). Precedence: --render-mode > env vars (skip/run) > config."`
-	DocsDir       string `short:"d" name:"docs-dir" default:"./docs" help:"Path to local docs directory (used when no config file provided)"`
-	Title         string `name:"title" default:"Documentation" help:"Site title when no config provided"`
-	BaseURL       string `name:"base-url" help:"Override hugo.base_url from config"`
-	Relocatable   bool   `name:"relocatable" help:"Generate fully relocatable site with relative links (sets base_url to empty string)"`
-	EditURLBase   string `name:"edit-url-base" help:"Base URL for generating edit links (e.g., https://github.com/org/repo). If not provided, edit links are only generated for cloned repos with forge URLs."`
-	KeepWorkspace bool   `name:"keep-workspace" help:"Keep workspace and staging directories for debugging (do not clean up on exit)"`
+	Output        string   `short:"o" default:"./site" help:"Output directory for generated site"`
+	Incremental   bool     `short:"i" help:"Use incremental updates instead of fresh clone"`
+	Only          []string `name:"only" sep:"," placeholder:"REPO,..." help:"Only fetch these repositories or sections; others are rendered from the incremental workspace (implies --incremental)"`
+	RenderMode    string   `name:"render-mode" help:"Override build.render_mode (auto|always|never). Precedence: --render-mode > env vars (skip/run) > config."`
+	DocsDir       string   `short:"d" name:"docs-dir" default:"./docs" help:"Path to local docs directory (used when no config file provided)"`
+	Title         string   `name:"title" default:"Documentation" help:"Site title when no config provided"`
+	BaseURL       string   `name:"base-url" help:"Override hugo.base_url from config"`
+	Relocatable   bool     `name:"relocatable" help:"Generate fully relocatable site with relative links (sets base_url to empty string)"`
+	EditURLBase   string   `name:"edit-url-base" help:"Base URL for generating edit links (e.g., https://github.com/org/repo). If not provided, edit links are only generated for cloned repos with forge URLs."`
+	KeepWorkspace bool     `name:"keep-workspace" help:"Keep workspace and staging directories for debugging (do not clean up on exit)"`
+	Profile       string   `name:"profile" help:"Build profile to render, e.g. internal or public (overrides build.profile)"`
+	ProfileFlags  `embed:""`
 }
 
 func (b *BuildCmd) Run(_ *Global, root *CLI) error {
@@ -65,6 +71,14 @@ func (b *BuildCmd) Run(_ *Global, root *CLI) error {
 		slog.Info("Render mode overridden via CLI flag", "render_mode", cfg.Build.RenderMode)
 	}
 
+	if b.Profile != "" {
+		cfg.Build.Profile = b.Profile
+		if err := cfg.Build.CheckProfile(); err != nil {
+			return err
+		}
+		slog.Info("Build profile overridden via CLI flag", "profile", b.Profile)
+	}
+
 	// Apply edit-url-base override if provided
 	if b.EditURLBase != "" {
 		cfg.Build.EditURLBase = b.EditURLBase
@@ -74,21 +88,40 @@ func (b *BuildCmd) Run(_ *Global, root *CLI) error {
 	// Resolve output directory with base_directory support
 	outputDir := ResolveOutputDir(b.Output, cfg)
 
+	stopProfiling, err := b.ProfileFlags.start()
+	if err != nil {
+		return err
+	}
+	defer stopProfiling()
+
 	// Use different build paths for local vs remote
 	if useLocalMode {
+		if len(b.Only) > 0 {
+			return errors.ValidationError("--only requires a configuration file with repositories").Build()
+		}
 		return b.runLocalBuild(cfg, outputDir, root.Verbose, b.KeepWorkspace)
 	}
 
 	if err := ApplyAutoDiscovery(context.Background(), cfg); err != nil {
 		return err
 	}
-	return RunBuild(cfg, outputDir, b.Incremental, root.Verbose, b.KeepWorkspace)
+	if len(b.Only) > 0 {
+		scoped, err := config.ScopeRepositories(cfg.Repositories, b.Only)
+		if err != nil {
+			return err
+		}
+		cfg.Repositories = scoped
+		b.Incremental = true
+		slog.Info("Scoped build", "only", b.Only)
+	}
+	return RunBuild(cfg, outputDir, b.Incremental, len(b.Only) > 0, root.Verbose, b.KeepWorkspace)
 }
 
-// RunBuild executes the build pipeline using the unified generator pipeline.
+// RunBuild executes the build pipeline using the unified generator pipeline. Scoped
+// builds (--only) use the persistent scoped workspace; other builds a fresh one.
 //
 //nolint:forbidigo // fmt is used for user-facing messages
-func RunBuild(cfg *config.Config, outputDir string, incrementalMode, verbose, keepWorkspace bool) error {
+func RunBuild(cfg *config.Config, outputDir string, incrementalMode, scoped, verbose, keepWorkspace bool) error {
 	// Provide friendly user-facing messages on stdout for CLI integration tests.
 	fmt.Println("Starting DocBuilder build")
 
@@ -108,7 +141,7 @@ func RunBuild(cfg *config.Config, outputDir string, incrementalMode, verbose, ke
 		"keep_workspace", keepWorkspace)
 
 	// Create workspace manager
-	wsManager, err := CreateWorkspace(cfg)
+	wsManager, err := createBuildWorkspace(cfg, scoped)
 	if err != nil {
 		return err
 	}
@@ -132,7 +165,7 @@ func RunBuild(cfg *config.Config, outputDir string, incrementalMode, verbose, ke
 			fmt.Printf("\nError occurred. Workspace preserved at: %s\n", wsManager.GetPath())
 			fmt.Printf("Hugo staging directory: %s_stage\n", outputDir)
 		}
-		return err
+		return herrors.Classify(err)
 	}
 
 	if report.FailedRepositories > 0 {
@@ -150,6 +183,23 @@ func RunBuild(cfg *config.Config, outputDir string, incrementalMode, verbose, ke
 	return nil
 }
 
+// scopedWorkspaceDir is the persistent workspace subdirectory used by scoped builds.
+const scopedWorkspaceDir = "docbuilder-working"
+
+// createBuildWorkspace returns the build workspace. Scoped builds keep working copies in
+// a persistent directory under build.workspace_dir (or the system temp directory) so
+// later scoped builds can reuse them for out-of-scope repositories.
+func createBuildWorkspace(cfg *config.Config, scoped bool) (*workspace.Manager, error) {
+	if !scoped {
+		return CreateWorkspace(cfg)
+	}
+	wsManager := workspace.NewPersistentManager(cfg.Build.WorkspaceDir, scopedWorkspaceDir)
+	if err := wsManager.Create(); err != nil {
+		return nil, err
+	}
+	return wsManager, nil
+}
+
 // prepareLocalRepoConfig configures repository settings for local builds.
 // Returns the repository config and the actual path to use for discovery.
 func (b *BuildCmd) prepareLocalRepoConfig(cfg *config.Config, docsPath string) ([]config.Repository, string) {
@@ -222,7 +272,10 @@ func (b *BuildCmd) runLocalBuild(cfg *config.Config, outputDir string, verbose,
 
 	if len(docFiles) == 0 {
 		slog.Warn("No documentation files found in directory", "dir", docsPath)
-		return fmt.Errorf("no documentation files found in %s", docsPath)
+		return errors.NewError(errors.CategoryBuild, fmt.Sprintf("no documentation files found in %s", docsPath)).
+			WithCode(errors.CodeBuildNoDocumentation).
+			WithContext("dir", docsPath).
+			Build()
 	}
 
 	slog.Info("Documentation discovered", "files", len(docFiles))
@@ -239,7 +292,7 @@ func (b *BuildCmd) runLocalBuild(cfg *config.Config, outputDir string, verbose,
 		if keepWorkspace {
 			fmt.Printf("\nError occurred. Hugo staging directory: %s_stage\n", outputDir)
 		}
-		return fmt.Errorf("site generation failed: %w", err)
+		return herrors.Classify(fmt.Errorf("site generation failed: %w", err))
 	}
 
 	slog.Info("Hugo site generated successfully",
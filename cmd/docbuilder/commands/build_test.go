@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestCreateBuildWorkspace_PersistentOnlyWhenScoped(t *testing.T) {
+	cfg := &config.Config{Build: config.BuildConfig{WorkspaceDir: t.TempDir()}}
+	persistent := filepath.Join(cfg.Build.WorkspaceDir, scopedWorkspaceDir)
+
+	// Incremental builds without --only are not scoped.
+	fresh, err := createBuildWorkspace(cfg, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CleanupWorkspace(fresh)
+	if fresh.GetPath() == persistent {
+		t.Fatalf("unscoped build used the persistent workspace %q", persistent)
+	}
+
+	scoped, err := createBuildWorkspace(cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CleanupWorkspace(scoped)
+	if scoped.GetPath() != persistent {
+		t.Fatalf("scoped workspace = %q, want %q", scoped.GetPath(), persistent)
+	}
+}
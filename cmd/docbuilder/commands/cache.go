@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+)
+
+// CacheCmd groups repository cache commands.
+type CacheCmd struct {
+	Verify CacheVerifyCmd `cmd:"" help:"Verify the integrity of cached repositories"`
+}
+
+// CacheVerifyCmd implements 'docbuilder cache verify'.
+type CacheVerifyCmd struct {
+	Dir    string `name:"dir" help:"Repository cache directory (default: <daemon.storage.repo_cache_dir>/working, or the scoped build workspace)"`
+	Quick  bool   `name:"quick" help:"Only check references, HEAD and the index; skip reading blobs and git fsck"`
+	Repair bool   `name:"repair" help:"Remove corrupted repositories so the next build clones them again"`
+	Format string `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+}
+
+// cacheVerifyEntry is one line of 'cache verify' output.
+type cacheVerifyEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	Repaired bool   `json:"repaired,omitempty"`
+}
+
+func (c *CacheVerifyCmd) Run(_ *Global, root *CLI) error {
+	dir := c.Dir
+	if dir == "" {
+		var err error
+		if dir, err = cacheDirFromConfig(root.Config); err != nil {
+			return err
+		}
+	}
+	results, err := git.VerifyWorkspace(dir, !c.Quick)
+	if err != nil {
+		return err
+	}
+	entries, corrupted := verifyEntries(results, c.Repair)
+	if err := writeCacheVerify(os.Stdout, entries, c.Format); err != nil {
+		return err
+	}
+	if corrupted > 0 && !c.Repair {
+		return errors.NewError(errors.CategoryGit, fmt.Sprintf("%d cached repositories are corrupted", corrupted)).
+			WithCode(errors.CodeGitCacheCorrupted).
+			WithContext("path", dir).
+			WithContext("hint", "run with --repair to remove them; the next build clones them again").
+			Build()
+	}
+	return nil
+}
+
+// cacheDirFromConfig returns the daemon's persistent workspace when the configuration
+// has a daemon section, and the scoped build workspace otherwise.
+func cacheDirFromConfig(configPath string) (string, error) {
+	cfg := &config.Config{}
+	if configPath != "" && fileExists(configPath) {
+		loaded, err := config.Load(configPath)
+		if err != nil {
+			return "", fmt.Errorf("load config: %w", err)
+		}
+		cfg = loaded
+	}
+	if cfg.Daemon != nil && cfg.Daemon.Storage.RepoCacheDir != "" {
+		return filepath.Join(cfg.Daemon.Storage.RepoCacheDir, "working"), nil
+	}
+	base := cfg.Build.WorkspaceDir
+	if base == "" {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, scopedWorkspaceDir), nil
+}
+
+// verifyEntries converts verification results, removing corrupted repositories when
+// repair is set. It returns the entries and the number of corrupted repositories.
+func verifyEntries(results []git.VerifyResult, repair bool) ([]cacheVerifyEntry, int) {
+	entries := make([]cacheVerifyEntry, 0, len(results))
+	corrupted := 0
+	for _, r := range results {
+		e := cacheVerifyEntry{Name: r.Name, Path: r.Path, OK: r.Err == nil}
+		if r.Err != nil {
+			corrupted++
+			e.Error = r.Err.Error()
+			if repair {
+				e.Repaired = os.RemoveAll(r.Path) == nil
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, corrupted
+}
+
+// writeCacheVerify renders verification results as a table or JSON.
+func writeCacheVerify(out io.Writer, entries []cacheVerifyEntry, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(out, "No cached repositories found")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REPOSITORY\tSTATUS\tDETAILS")
+	for _, e := range entries {
+		status, details := "ok", ""
+		if !e.OK {
+			status, details = "corrupted", e.Error
+			if e.Repaired {
+				status = "removed"
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", e.Name, status, details)
+	}
+	return w.Flush()
+}
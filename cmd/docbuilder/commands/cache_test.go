@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+)
+
+func TestVerifyEntries_Repair(t *testing.T) {
+	broken := filepath.Join(t.TempDir(), "broken")
+	if err := os.MkdirAll(broken, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	results := []git.VerifyResult{
+		{Name: "good", Path: filepath.Join(t.TempDir(), "good")},
+		{Name: "broken", Path: broken, Err: os.ErrNotExist},
+	}
+
+	entries, corrupted := verifyEntries(results, false)
+	if corrupted != 1 || !entries[0].OK || entries[1].OK || entries[1].Repaired {
+		t.Fatalf("unexpected entries without repair: %+v", entries)
+	}
+	if _, err := os.Stat(broken); err != nil {
+		t.Fatalf("verification without --repair must not remove anything: %v", err)
+	}
+
+	entries, _ = verifyEntries(results, true)
+	if !entries[1].Repaired {
+		t.Fatalf("expected broken repository to be removed: %+v", entries)
+	}
+	if _, err := os.Stat(broken); !os.IsNotExist(err) {
+		t.Fatalf("broken repository still present (stat err %v)", err)
+	}
+}
+
+func TestWriteCacheVerify(t *testing.T) {
+	entries := []cacheVerifyEntry{
+		{Name: "docs", Path: "/cache/docs", OK: true},
+		{Name: "api", Path: "/cache/api", Error: "cached repository is corrupted: HEAD does not resolve", Repaired: true},
+	}
+
+	var text strings.Builder
+	if err := writeCacheVerify(&text, entries, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "docs") || !strings.Contains(text.String(), "removed") {
+		t.Fatalf("unexpected text output:\n%s", text.String())
+	}
+
+	var out strings.Builder
+	if err := writeCacheVerify(&out, entries, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var doc []cacheVerifyEntry
+	if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc) != 2 || doc[1].OK || !doc[1].Repaired {
+		t.Fatalf("unexpected json output %+v", doc)
+	}
+}
+
+func TestCacheDirFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	cfg := "version: \"2.0\"\nrepositories:\n  - name: docs\n    url: https://example.com/docs.git\n" +
+		"daemon:\n  storage:\n    repo_cache_dir: " + filepath.Join(dir, "repos") + "\n"
+	if err := os.WriteFile(cfgPath, []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cacheDirFromConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "repos", "working"); got != want {
+		t.Fatalf("cache dir = %q, want %q", got, want)
+	}
+
+	got, err = cacheDirFromConfig(filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(os.TempDir(), scopedWorkspaceDir); got != want {
+		t.Fatalf("cache dir without config = %q, want %q", got, want)
+	}
+}
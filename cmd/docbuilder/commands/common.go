@@ -16,6 +16,7 @@ import (
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/forge"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
 	"git.home.luguber.info/inful/docbuilder/internal/workspace"
 )
 
@@ -30,19 +31,33 @@ type CLI struct {
 	Verbose bool             `short:"v" env:"DOCBUILDER_VERBOSE" help:"Enable verbose logging"`
 	Version kong.VersionFlag `name:"version" help:"Show version and exit"`
 
-	Build    BuildCmd    `cmd:"" help:"Build documentation site from configured repositories"`
-	Init     InitCmd     `cmd:"" help:"Initialize a new configuration file"`
-	Discover DiscoverCmd `cmd:"" help:"Discover documentation files without building"`
-	Lint     LintCmd     `cmd:"" help:"Lint documentation files for errors and style issues"`
-	Daemon   DaemonCmd   `cmd:"" help:"Start daemon mode for continuous documentation updates"`
-	Preview  PreviewCmd  `cmd:"" help:"Preview local docs with live reload (no git polling)"`
-	Template TemplateCmd `cmd:"" help:"Create documentation from templates"`
+	Build     BuildCmd    `cmd:"" help:"Build documentation site from configured repositories"`
+	Init      InitCmd     `cmd:"" help:"Initialize a new configuration file"`
+	ConfigCmd ConfigCmd   `cmd:"" name:"config" help:"Generate configuration files for automated provisioning"`
+	Discover  DiscoverCmd `cmd:"" help:"Discover documentation files without building"`
+	Lint      LintCmd     `cmd:"" help:"Lint documentation files for errors and style issues"`
+	Daemon    DaemonCmd   `cmd:"" help:"Start daemon mode for continuous documentation updates"`
+	Preview   PreviewCmd  `cmd:"" help:"Preview local docs with live reload (no git polling)"`
+	Serve     ServeCmd    `cmd:"" help:"Serve an already-built site directory"`
+	Template  TemplateCmd `cmd:"" help:"Create documentation from templates"`
+	Scaffold  ScaffoldCmd `cmd:"" help:"Scaffold documentation structure for a repository"`
+	Migrate   MigrateCmd  `cmd:"" help:"Migrate an MkDocs or Docusaurus site to DocBuilder"`
+	Onboard   OnboardCmd  `cmd:"" help:"Check repositories before adding them to the portal"`
+	Bench     BenchCmd    `cmd:"" help:"Benchmark the build pipeline on a synthetic corpus"`
+	Status    StatusCmd   `cmd:"" help:"Show the status of a running daemon"`
+	Report    ReportCmd   `cmd:"" help:"Show the report of a daemon build"`
+	Errors    ErrorsCmd   `cmd:"" help:"Inspect the error code catalog"`
+	Token     TokenCmd    `cmd:"" help:"Issue scoped admin API tokens"`
+	Cache     CacheCmd    `cmd:"" help:"Inspect and repair the repository cache"`
+	Verify    VerifyCmd   `cmd:"" help:"Verify a published site against its checksum file and signature"`
+	Selftest  SelftestCmd `cmd:"" help:"Build bundled fixtures and compare the site with golden snapshots"`
+	Dev       DevCmd      `cmd:"" help:"Local development tools (fake forge API)"`
 }
 
 // AfterApply runs after flag parsing; setup logging once.
 func (c *CLI) AfterApply() error {
 	level := parseLogLevel(c.Verbose)
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	logger := slog.New(observability.NewContextHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
 	slog.SetDefault(logger)
 	return nil
 }
@@ -224,6 +239,18 @@ func ApplyAutoDiscovery(ctx context.Context, cfg *config.Config) error {
 
 // AutoDiscoverRepositories builds a forge manager from v2 config and returns converted repositories.
 func AutoDiscoverRepositories(ctx context.Context, v2cfg *config.Config) ([]config.Repository, error) {
+	service, manager := newForgeDiscovery(v2cfg)
+	result, err := service.DiscoverAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repos := service.ConvertToConfigRepositories(result.Repositories, manager)
+	slog.Info("Auto-discovery completed", "repositories", len(repos))
+	return repos, nil
+}
+
+// newForgeDiscovery creates a discovery service for the forges that support auto-discovery.
+func newForgeDiscovery(v2cfg *config.Config) (*forge.DiscoveryService, *forge.Manager) {
 	manager := forge.NewForgeManager()
 
 	// Instantiate forge clients
@@ -257,12 +284,5 @@ func AutoDiscoverRepositories(ctx context.Context, v2cfg *config.Config) ([]conf
 		filtering = &config.FilteringConfig{}
 	}
 
-	service := forge.NewDiscoveryService(manager, filtering)
-	result, err := service.DiscoverAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-	repos := service.ConvertToConfigRepositories(result.Repositories, manager)
-	slog.Info("Auto-discovery completed", "repositories", len(repos))
-	return repos, nil
+	return forge.NewDiscoveryService(manager, filtering), manager
 }
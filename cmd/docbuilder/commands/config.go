@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ConfigCmd groups configuration file commands.
+type ConfigCmd struct {
+	Generate ConfigGenerateCmd `cmd:"" help:"Generate a validated configuration file from flags and environment variables"`
+	Migrate  ConfigMigrateCmd  `cmd:"" help:"Upgrade a configuration file to the current schema version"`
+}
+
+// ConfigGenerateCmd implements the 'config generate' command.
+type ConfigGenerateCmd struct {
+	Forges       []string `name:"forge" sep:";" env:"DOCBUILDER_GENERATE_FORGES" placeholder:"TYPE=ORG[,ORG...][@URL]" help:"Forge to discover repositories from (repeatable; ';'-separated in the environment)"`
+	GitHubOrgs   []string `name:"from-github-org" env:"DOCBUILDER_GENERATE_GITHUB_ORGS" placeholder:"ORG" help:"GitHub (github.com) organization to discover repositories from (repeatable)"`
+	Repositories []string `name:"repository" env:"DOCBUILDER_GENERATE_REPOSITORIES" placeholder:"URL" help:"Repository clone URL to build explicitly (repeatable)"`
+	Title        string   `name:"title" env:"DOCBUILDER_GENERATE_TITLE" default:"Documentation" help:"Site title"`
+	BaseURL      string   `name:"base-url" env:"DOCBUILDER_GENERATE_BASE_URL" help:"Public URL of the site"`
+	Daemon       bool     `name:"daemon" help:"Include a daemon section (ports, sync schedule, storage, admin token)"`
+	Webhooks     bool     `name:"webhooks" help:"Configure a webhook for every forge"`
+	Output       string   `short:"o" name:"output" default:"-" help:"File to write ('-' for stdout)"`
+	Force        bool     `help:"Overwrite an existing output file"`
+}
+
+func (c *ConfigGenerateCmd) Run(_ *Global, _ *CLI) error {
+	opts := config.GenerateOptions{
+		Repositories: c.Repositories,
+		Title:        c.Title,
+		BaseURL:      c.BaseURL,
+		Daemon:       c.Daemon,
+		Webhooks:     c.Webhooks,
+	}
+	for _, spec := range c.Forges {
+		f, err := config.ParseForgeSpec(spec)
+		if err != nil {
+			return err
+		}
+		opts.Forges = append(opts.Forges, f)
+	}
+	if len(c.GitHubOrgs) > 0 {
+		opts.Forges = withGitHubOrgs(opts.Forges, c.GitHubOrgs)
+	}
+	data, err := config.Generate(opts)
+	if err != nil {
+		return err
+	}
+	return writeGeneratedConfig(c.Output, data, c.Force)
+}
+
+// withGitHubOrgs adds orgs to the github.com forge, creating it if no --forge names one.
+func withGitHubOrgs(forges []config.GenerateForge, orgs []string) []config.GenerateForge {
+	for i := range forges {
+		if forges[i].Type == config.ForgeGitHub && forges[i].URL == "" {
+			forges[i].Organizations = append(forges[i].Organizations, orgs...)
+			return forges
+		}
+	}
+	return append(forges, config.GenerateForge{Type: config.ForgeGitHub, Organizations: orgs})
+}
+
+//nolint:forbidigo // fmt is used for user-facing messages
+func writeGeneratedConfig(output string, data []byte, force bool) error {
+	if output == "" || output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if fileExists(output) && !force {
+		return errors.NewError(errors.CategoryConfig, "configuration file already exists").
+			WithContext("path", output).
+			WithContext("hint", "use --force to overwrite").
+			Build()
+	}
+	// #nosec G306 -- the configuration holds placeholders, not secrets
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "failed to write configuration file").
+			WithContext("path", output).
+			Build()
+	}
+	fmt.Fprintf(os.Stderr, "Wrote configuration to %s\n", output)
+	return nil
+}
+
+// ConfigMigrateCmd implements the 'config migrate' command.
+type ConfigMigrateCmd struct {
+	File   string `arg:"" optional:"" help:"Configuration file to migrate (default: the --config file)"`
+	Output string `short:"o" name:"output" help:"File to write (default: the input file, keeping the original as FILE.bak; '-' for stdout)"`
+	DryRun bool   `name:"dry-run" help:"Show the changes as a diff without writing anything"`
+}
+
+func (c *ConfigMigrateCmd) Run(_ *Global, root *CLI) error {
+	file := c.File
+	if file == "" {
+		file = root.Config
+	}
+	// #nosec G304 -- configuration file named by the user
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "failed to read config file").
+			WithCode(errors.CodeConfigParse).
+			WithContext("path", file).
+			Build()
+	}
+	res, err := config.MigrateYAML(data)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stderr
+	if c.DryRun {
+		out = os.Stdout
+	}
+	if len(res.Steps) == 0 {
+		_, _ = fmt.Fprintf(out, "%s is already at version %s; nothing to migrate\n", file, res.To)
+		return nil
+	}
+	if err := writeMigration(out, file, data, res); err != nil {
+		return err
+	}
+	if err := config.ValidateYAML(res.Output); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "migrated configuration does not validate; fix the reported problem and migrate again").
+			WithCode(errors.CodeConfigInvalid).
+			WithContext("path", file).
+			Build()
+	}
+	if c.DryRun {
+		return nil
+	}
+
+	output := c.Output
+	if output == "" {
+		output = file
+		backup := file + ".bak"
+		// #nosec G306 -- same permissions as a configuration file written by the user
+		if err := os.WriteFile(backup, data, 0o644); err != nil {
+			return errors.WrapError(err, errors.CategoryConfig, "failed to back up configuration file").
+				WithContext("path", backup).
+				Build()
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Saved the original as %s\n", backup)
+	}
+	return writeGeneratedConfig(output, res.Output, true)
+}
+
+// writeMigration lists the applied migrations and shows their effect as a unified diff.
+func writeMigration(w io.Writer, file string, before []byte, res *config.MigrationResult) error {
+	_, _ = fmt.Fprintf(w, "Migrating %s from version %s to %s:\n", file, res.From, res.To)
+	for _, step := range res.Steps {
+		_, _ = fmt.Fprintf(w, "  %s -> %s: %s\n", step.From, step.To, step.Description)
+		for _, change := range step.Changes {
+			_, _ = fmt.Fprintf(w, "    - %s\n", change)
+		}
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(res.Output)),
+		FromFile: file,
+		ToFile:   file + " (migrated)",
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("diff configuration: %w", err)
+	}
+	_, _ = fmt.Fprintf(w, "\n%s", diff)
+	return nil
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/daemon"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
 )
 
 // DaemonCmd implements the 'daemon' command.
 type DaemonCmd struct {
-	DataDir string `short:"d" default:"./daemon-data" help:"Data directory for daemon state"`
+	DataDir     string `short:"d" default:"./daemon-data" help:"Data directory for daemon state"`
+	Maintenance bool   `help:"Start in maintenance mode (builds are held until it is left via the admin API)"`
+	Role        string `default:"" help:"Daemon role: builder (discovery, builds and serving) or server (serve the shared output only); overrides daemon.role" enum:",builder,server"`
 }
 
 func (d *DaemonCmd) Run(_ *Global, root *CLI) error {
@@ -31,10 +35,33 @@ func (d *DaemonCmd) Run(_ *Global, root *CLI) error {
 	for _, w := range result.Warnings {
 		slog.Warn(w)
 	}
-	return RunDaemon(cfg, d.DataDir, root.Config)
+
+	if d.Role != "" && cfg.Daemon != nil {
+		cfg.Daemon.Role = d.Role
+	}
+	if d.Maintenance && cfg.Daemon != nil {
+		if cfg.Daemon.Maintenance == nil {
+			cfg.Daemon.Maintenance = &config.MaintenanceConfig{}
+		}
+		cfg.Daemon.Maintenance.Enabled = true
+	}
+
+	// Route daemon logs through monitoring.logging (component levels, file and syslog sinks)
+	var logCfg config.MonitoringLogging
+	if cfg.Monitoring != nil {
+		logCfg = cfg.Monitoring.Logging
+	}
+	loggers, err := logging.New(logCfg, os.Stderr, root.Verbose)
+	if err != nil {
+		return fmt.Errorf("configure logging: %w", err)
+	}
+	defer func() { _ = loggers.Close() }()
+	slog.SetDefault(loggers.Default())
+
+	return RunDaemon(cfg, d.DataDir, root.Config, loggers)
 }
 
-func RunDaemon(cfg *config.Config, dataDir, configPath string) error {
+func RunDaemon(cfg *config.Config, dataDir, configPath string, loggers *logging.Router) error {
 	slog.Info("Starting daemon mode", "data_dir", dataDir)
 
 	// Create main context for the daemon
@@ -42,7 +69,7 @@ func RunDaemon(cfg *config.Config, dataDir, configPath string) error {
 	defer cancel()
 
 	// Create and start the daemon with config file watching
-	d, err := daemon.NewDaemonWithConfigFile(cfg, configPath)
+	d, err := daemon.NewDaemonWithLogging(cfg, configPath, loggers)
 	if err != nil {
 		return fmt.Errorf("failed to create daemon: %w", err)
 	}
@@ -53,20 +80,39 @@ func RunDaemon(cfg *config.Config, dataDir, configPath string) error {
 		errChan <- d.Start(ctx)
 	}()
 
+	// SIGHUP reloads the configuration file without a restart
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+	defer signal.Stop(reloadChan)
+
 	slog.Info("Daemon started, waiting for shutdown signal...")
 
 	// Wait for either error or shutdown signal
-	select {
-	case err := <-errChan:
-		if err != nil {
-			return fmt.Errorf("daemon error: %w", err)
+wait:
+	for {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				return fmt.Errorf("daemon error: %w", err)
+			}
+			break wait
+		case <-reloadChan:
+			slog.Info("SIGHUP received, reloading configuration...")
+			if _, err := d.ReloadConfig(ctx); err != nil {
+				slog.Error("Configuration reload failed; keeping the current configuration", "error", err)
+			}
+		case <-ctx.Done():
+			slog.Info("Shutdown signal received, stopping daemon...")
+			break wait
 		}
-	case <-ctx.Done():
-		slog.Info("Shutdown signal received, stopping daemon...")
 	}
 
-	// Stop daemon gracefully
-	stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Stop daemon gracefully; HTTP servers drain for up to daemon.http.drain_timeout of it
+	stopTimeout := 30 * time.Second
+	if cfg.Daemon != nil {
+		stopTimeout = max(stopTimeout, cfg.Daemon.HTTP.DrainTimeoutValue()+15*time.Second)
+	}
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), stopTimeout)
 	defer stopCancel()
 
 	if err := d.Stop(stopCtx); err != nil {
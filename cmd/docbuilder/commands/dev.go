@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	testforge "git.home.luguber.info/inful/docbuilder/internal/testutil/testforge"
+)
+
+// DevCmd groups tools for developing against DocBuilder locally.
+type DevCmd struct {
+	FakeForge DevFakeForgeCmd `cmd:"" name:"fake-forge" help:"Serve a fake GitHub, GitLab or Forgejo API for local development"`
+}
+
+// DevFakeForgeCmd implements 'docbuilder dev fake-forge'.
+type DevFakeForgeCmd struct {
+	Type          string `name:"type" default:"github" enum:"github,gitlab,forgejo" help:"API to simulate (github, gitlab, forgejo)"`
+	Addr          string `name:"addr" default:"127.0.0.1:8090" help:"Listen address"`
+	Org           string `name:"org" default:"test-org" help:"Organization (group) owning the repositories from --repos"`
+	Repos         string `name:"repos" help:"Directory of local git repositories to serve instead of the built-in fixtures"`
+	Token         string `name:"token" default:"dev-token" help:"Token API clients must send (empty disables authentication)"`
+	WebhookURL    string `name:"webhook-url" help:"Also deliver simulated pushes to this URL (e.g. a local daemon's webhook endpoint)"`
+	WebhookSecret string `name:"webhook-secret" default:"dev-webhook-secret" help:"Secret used to sign webhook deliveries"`
+}
+
+func (d *DevFakeForgeCmd) Run(_ *Global, _ *CLI) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	tf := testforge.NewTestForge("fake-"+d.Type, config.ForgeType(d.Type))
+	if d.Repos != "" {
+		if err := d.addLocalRepositories(tf); err != nil {
+			return err
+		}
+	}
+	srv := testforge.NewServer(tf, d.Token)
+	if d.WebhookURL != "" {
+		srv.AddWebhook(testforge.Hook{URL: d.WebhookURL, Secret: d.WebhookSecret})
+	}
+
+	ln, err := net.Listen("tcp", d.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", d.Addr, err)
+	}
+	baseURL := "http://" + ln.Addr().String()
+	if err := d.printUsage(os.Stdout, srv, baseURL, tf); err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	server := &http.Server{Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve fake forge: %w", err)
+	}
+	return nil
+}
+
+// addLocalRepositories replaces the fixtures with the git repositories directly below
+// --repos. Their clone URLs are local paths, so builds clone them without a network.
+func (d *DevFakeForgeCmd) addLocalRepositories(tf *testforge.TestForge) error {
+	root, err := filepath.Abs(d.Repos)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", d.Repos, err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", root, err)
+	}
+	tf.ClearRepositories()
+	tf.ClearOrganizations()
+	tf.AddOrganization(d.Org)
+	for _, e := range entries {
+		dir := filepath.Join(root, e.Name())
+		if !e.IsDir() || !fileExists(filepath.Join(dir, ".git", "HEAD")) {
+			continue
+		}
+		_, branch := gitOriginAndBranch(dir)
+		tf.AddRepository(testforge.TestRepository{
+			Name:          e.Name(),
+			FullName:      d.Org + "/" + e.Name(),
+			CloneURL:      dir,
+			DefaultBranch: branch,
+			Description:   "Local repository " + dir,
+			HasDocs:       dirExists(filepath.Join(dir, "docs")),
+			HasDocIgnore:  fileExists(filepath.Join(dir, ".docignore")),
+			UpdatedAt:     time.Now(),
+		})
+	}
+	if len(tf.Repositories()) == 0 {
+		return fmt.Errorf("no git repositories found in %s", root)
+	}
+	return nil
+}
+
+// printUsage prints the forge configuration to point DocBuilder at the server and how
+// to simulate a push.
+func (d *DevFakeForgeCmd) printUsage(w io.Writer, srv *testforge.Server, baseURL string, tf *testforge.TestForge) error {
+	fc := srv.ForgeConfig(baseURL)
+	fc.Webhook.Secret = d.WebhookSecret
+	snippet, err := yaml.Marshal(map[string]any{"forges": []*config.ForgeConfig{fc}})
+	if err != nil {
+		return fmt.Errorf("marshal forge configuration: %w", err)
+	}
+	repo := d.Org + "/docs-repo"
+	if repos := tf.Repositories(); len(repos) > 0 {
+		repo = repos[0].FullName
+	}
+	_, _ = fmt.Fprintf(w, "Fake %s API listening on %s%s\n\n", d.Type, baseURL, srv.APIPath())
+	_, _ = fmt.Fprintf(w, "Forge configuration:\n\n%s\n", snippet)
+	_, _ = fmt.Fprintf(w, "Simulate a push (delivered to registered webhooks):\n\n  curl -X POST %s/_testforge/push -d '{\"repository\":%q,\"modified\":[\"docs/index.md\"]}'\n\n", baseURL, repo)
+	return nil
+}
+
+func dirExists(p string) bool {
+	st, err := os.Stat(p)
+	return err == nil && st.IsDir()
+}
@@ -2,17 +2,28 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
 )
 
 // DiscoverCmd implements the 'discover' command.
 type DiscoverCmd struct {
 	Repository string `short:"r" help:"Specific repository to discover (optional)"`
+	DryRun     bool   `name:"dry-run" help:"Preview forge discovery: list the repositories the filters include or exclude, and why, without cloning"`
+	Format     string `short:"f" default:"text" help:"Output format for --dry-run (text or json)" enum:"text,json"`
 }
 
 func (d *DiscoverCmd) Run(_ *Global, root *CLI) error {
@@ -29,12 +40,66 @@ func (d *DiscoverCmd) Run(_ *Global, root *CLI) error {
 	for _, w := range result.Warnings {
 		slog.Warn(w)
 	}
+	if d.DryRun {
+		return RunDiscoverPreview(context.Background(), cfg, d.Format, os.Stdout)
+	}
 	if err := ApplyAutoDiscovery(context.Background(), cfg); err != nil {
 		return err
 	}
 	return RunDiscover(cfg, d.Repository)
 }
 
+// RunDiscoverPreview runs forge discovery with the configured filters and writes the
+// decision for each repository. Nothing is cloned or cached.
+func RunDiscoverPreview(ctx context.Context, cfg *config.Config, format string, out io.Writer) error {
+	if len(cfg.Forges) == 0 {
+		return errors.New("--dry-run previews forge discovery, but no forges are configured")
+	}
+	service, _ := newForgeDiscovery(cfg)
+	preview, err := service.Preview(ctx)
+	if err != nil {
+		return fmt.Errorf("discovery preview: %w", err)
+	}
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(preview)
+	}
+	return writeDiscoveryPreview(out, preview)
+}
+
+func writeDiscoveryPreview(out io.Writer, p *forge.DiscoveryPreview) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FORGE\tREPOSITORY\tDECISION\tREASON\tRULE")
+	for _, d := range p.Repositories {
+		decision := "exclude"
+		if d.Included {
+			decision = "include"
+		}
+		rule := d.Rule
+		if rule == "" {
+			rule = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Forge, d.Repository, decision, d.Reason, rule)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "\n%d included, %d excluded", p.Included, p.Excluded)
+	if len(p.ExcludedBy) > 0 {
+		reasons := make([]string, 0, len(p.ExcludedBy))
+		for _, reason := range slices.Sorted(maps.Keys(p.ExcludedBy)) {
+			reasons = append(reasons, fmt.Sprintf("%s %d", reason, p.ExcludedBy[reason]))
+		}
+		_, _ = fmt.Fprintf(out, " (%s)", strings.Join(reasons, ", "))
+	}
+	_, _ = fmt.Fprintln(out)
+	for _, name := range slices.Sorted(maps.Keys(p.Errors)) {
+		_, _ = fmt.Fprintf(out, "forge %s: %s\n", name, p.Errors[name])
+	}
+	return nil
+}
+
 func RunDiscover(cfg *config.Config, specificRepo string) error {
 	slog.Info("Starting documentation discovery", "repositories", len(cfg.Repositories))
 
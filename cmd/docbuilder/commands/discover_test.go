@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+)
+
+func TestWriteDiscoveryPreview(t *testing.T) {
+	preview := &forge.DiscoveryPreview{
+		Included:   1,
+		Excluded:   1,
+		ExcludedBy: map[string]int{"exclude_patterns_match": 1},
+		Repositories: []forge.RepositoryDecision{
+			{Forge: "gh", Repository: "acme/api", Included: true, Reason: "included"},
+			{Forge: "gh", Repository: "acme/legacy", Reason: "exclude_patterns_match", Rule: "filtering.exclude_patterns: legacy*"},
+		},
+		Errors: map[string]string{"gitlab": "unauthorized"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeDiscoveryPreview(&buf, preview); err != nil {
+		t.Fatalf("writeDiscoveryPreview: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"acme/api     include",
+		"acme/legacy  exclude   exclude_patterns_match  filtering.exclude_patterns: legacy*",
+		"1 included, 1 excluded (exclude_patterns_match 1)",
+		"forge gitlab: unauthorized",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunDiscoverPreview_RequiresForges(t *testing.T) {
+	err := RunDiscoverPreview(t.Context(), &config.Config{}, "text", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "no forges are configured") {
+		t.Fatalf("expected missing forges error, got %v", err)
+	}
+}
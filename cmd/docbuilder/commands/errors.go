@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ErrorsCmd implements the 'errors' command.
+type ErrorsCmd struct {
+	List ErrorsListCmd `cmd:"" help:"List error codes with their category and exit status"`
+}
+
+// ErrorsListCmd implements 'docbuilder errors list'.
+type ErrorsListCmd struct {
+	Format string `short:"f" default:"text" help:"Output format (text, json or markdown)" enum:"text,json,markdown"`
+}
+
+func (e *ErrorsListCmd) Run(_ *Global, _ *CLI) error {
+	return writeErrorCatalog(os.Stdout, errors.Catalog(), e.Format)
+}
+
+// writeErrorCatalog renders the error catalog as a table, JSON or a markdown table.
+func writeErrorCatalog(out io.Writer, entries []errors.CatalogEntry, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case "markdown":
+		_, _ = fmt.Fprintln(out, "| Code | Category | Exit | Summary |")
+		_, _ = fmt.Fprintln(out, "|------|----------|------|---------|")
+		for _, e := range entries {
+			_, _ = fmt.Fprintf(out, "| `%s` | %s | %d | %s |\n", e.Code, e.Category, e.ExitCode, e.Summary)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CODE\tCATEGORY\tEXIT\tSUMMARY")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", e.Code, e.Category, e.ExitCode, e.Summary)
+	}
+	return w.Flush()
+}
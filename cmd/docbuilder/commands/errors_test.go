@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestWriteErrorCatalog(t *testing.T) {
+	entries := errors.Catalog()
+
+	var text bytes.Buffer
+	if err := writeErrorCatalog(&text, entries, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(text.String(), "CODE") || !strings.Contains(text.String(), string(errors.CodeGitNetwork)) {
+		t.Errorf("unexpected text output:\n%s", text.String())
+	}
+
+	var js bytes.Buffer
+	if err := writeErrorCatalog(&js, entries, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []errors.CatalogEntry
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(decoded) != len(entries) {
+		t.Errorf("json entries = %d, want %d", len(decoded), len(entries))
+	}
+
+	var md bytes.Buffer
+	if err := writeErrorCatalog(&md, entries, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md.String(), "| `DB-CFG-001` | config | 7 |") {
+		t.Errorf("unexpected markdown output:\n%s", md.String())
+	}
+}
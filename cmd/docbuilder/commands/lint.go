@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 
+	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/lint"
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
 )
 
 // LintCmd implements the 'lint' command.
@@ -38,6 +42,21 @@ func (lp *LintPathCmd) Run(parent *LintCmd, _ *Global, root *CLI) error {
 	path := lp.Path
 	wasAutoDetected := false
 
+	spelling, err := lintSpelling(root.Config)
+	if err != nil {
+		return err
+	}
+
+	// Monorepo sections are linted as separate scopes
+	if path == "" {
+		if scopes := sectionLintScopes(root.Config); len(scopes) > 0 {
+			if root.Verbose {
+				fmt.Fprintf(os.Stderr, "Linting repository sections from %s: %v\n", root.Config, scopes)
+			}
+			return lintScopes(parent, scopes, lintContentPolicy(root.Config), spelling)
+		}
+	}
+
 	if path == "" {
 		var found bool
 		path, found = lint.DetectDefaultPath()
@@ -60,11 +79,13 @@ func (lp *LintPathCmd) Run(parent *LintCmd, _ *Global, root *CLI) error {
 
 	// Create linter configuration
 	cfg := &lint.Config{
-		Quiet:  parent.Quiet,
-		Format: parent.Format,
-		Fix:    parent.Fix,
-		DryRun: parent.DryRun,
-		Yes:    parent.Yes,
+		Quiet:         parent.Quiet,
+		Format:        parent.Format,
+		Fix:           parent.Fix,
+		DryRun:        parent.DryRun,
+		Yes:           parent.Yes,
+		ContentPolicy: lintContentPolicy(root.Config),
+		Spelling:      spelling,
 	}
 
 	// Create linter
@@ -100,6 +121,107 @@ func (lp *LintPathCmd) Run(parent *LintCmd, _ *Global, root *CLI) error {
 	return nil
 }
 
+// sectionLintScopes returns the docs paths of monorepo sections defined in the
+// configuration file that exist below the current directory.
+func sectionLintScopes(configPath string) []string {
+	cfg := loadLintConfig(configPath)
+	if cfg == nil {
+		return nil
+	}
+	var scopes []string
+	for i := range cfg.Repositories {
+		for _, section := range cfg.Repositories[i].Sections {
+			if st, err := os.Stat(section.Path); err == nil && st.IsDir() && !slices.Contains(scopes, section.Path) {
+				scopes = append(scopes, section.Path)
+			}
+		}
+	}
+	return scopes
+}
+
+// lintContentPolicy returns the sanitize policy checked by the content-policy rule: the
+// repository's effective policy when the configuration defines a single repository,
+// otherwise the global one. Without a configuration file nothing is checked.
+func lintContentPolicy(configPath string) sanitize.Resolver {
+	cfg := loadLintConfig(configPath)
+	if cfg == nil {
+		return nil
+	}
+	if len(cfg.Repositories) == 1 {
+		return sanitize.FromConfig(cfg.Repositories[0].SanitizePolicy(cfg.Sanitize))
+	}
+	return sanitize.FromConfig(cfg.Sanitize)
+}
+
+// lintSpelling returns the dictionary of the spelling rule, or nil when the configuration
+// does not enable spellcheck. Word lists and project dictionaries are resolved against
+// the directory of the configuration file. With a single configured repository, lint runs
+// in its working copy, so that repository's dictionaries are added too.
+func lintSpelling(configPath string) (*lint.SpellDictionary, error) {
+	cfg := loadLintConfig(configPath)
+	if cfg == nil {
+		return nil, nil
+	}
+	var repo *config.RepositorySpellcheck
+	if len(cfg.Repositories) == 1 {
+		repo = cfg.Repositories[0].Spellcheck
+	}
+	return lint.SpellDictionaryFromConfig(cfg.Spellcheck, filepath.Dir(configPath), repo, "")
+}
+
+// loadLintConfig loads the configuration file used to scope linting, or returns nil.
+func loadLintConfig(configPath string) *config.Config {
+	if configPath == "" || !fileExists(configPath) {
+		return nil
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// lintScopes lints (or fixes) each scope independently and exits with the
+// most severe result across all of them.
+func lintScopes(parent *LintCmd, scopes []string, policy sanitize.Resolver, spelling *lint.SpellDictionary) error {
+	linter := lint.NewLinter(&lint.Config{
+		Quiet:         parent.Quiet,
+		Format:        parent.Format,
+		Fix:           parent.Fix,
+		DryRun:        parent.DryRun,
+		Yes:           parent.Yes,
+		ContentPolicy: policy,
+		Spelling:      spelling,
+	})
+
+	hasErrors, hasWarnings := false, false
+	formatter := lint.NewFormatter(parent.Format, isColorSupported())
+	for _, scope := range scopes {
+		if parent.Fix {
+			if err := runFixer(linter, scope, parent.DryRun); err != nil {
+				return err
+			}
+			continue
+		}
+		result, err := linter.LintPath(scope)
+		if err != nil {
+			return fmt.Errorf("linting %s failed: %w", scope, err)
+		}
+		if err := formatter.Format(os.Stdout, result, scope, false); err != nil {
+			return fmt.Errorf("formatting output: %w", err)
+		}
+		hasErrors = hasErrors || result.HasErrors()
+		hasWarnings = hasWarnings || result.HasWarnings()
+	}
+
+	if hasErrors {
+		os.Exit(2)
+	} else if hasWarnings && !parent.Quiet {
+		os.Exit(1)
+	}
+	return nil
+}
+
 // isColorSupported checks if the terminal supports color output.
 func isColorSupported() bool {
 	// Check if stdout is a terminal
@@ -168,6 +290,14 @@ func runFixer(linter *lint.Linter, path string, dryRun bool) error {
 		_, _ = fmt.Fprintf(os.Stdout, "\n")
 	}
 
+	if len(fixResult.SpellingFixes) > 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "Misspellings corrected:\n")
+		for _, sf := range fixResult.SpellingFixes {
+			_, _ = fmt.Fprintf(os.Stdout, "  %s:%d: %s → %s\n", sf.FilePath, sf.Line, sf.Word, sf.Replacement)
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "\n")
+	}
+
 	// Display fix summary
 	_, _ = fmt.Fprintf(os.Stdout, "%s\n", fixResult.Summary())
 
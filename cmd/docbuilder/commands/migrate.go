@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"git.home.luguber.info/inful/docbuilder/internal/migrate"
+)
+
+// MigrateCmd groups commands that migrate other documentation tools to DocBuilder.
+type MigrateCmd struct {
+	MkDocs     MigrateMkDocsCmd     `cmd:"" name:"mkdocs" help:"Migrate an MkDocs site (mkdocs.yml)"`
+	Docusaurus MigrateDocusaurusCmd `cmd:"" name:"docusaurus" help:"Migrate a Docusaurus site"`
+}
+
+// MigrateFlags are shared by the migrate subcommands.
+type MigrateFlags struct {
+	Name   string `help:"Repository name (default: directory name)"`
+	URL    string `name:"url" help:"Repository clone URL (default: git remote 'origin', then the site configuration)"`
+	Branch string `help:"Default branch (default: current branch or main)"`
+	Output string `short:"o" name:"output" default:"docbuilder.yaml" help:"Site configuration file to write"`
+	DryRun bool   `name:"dry-run" help:"Print the configuration and planned page changes without writing anything"`
+	Force  bool   `help:"Overwrite existing configuration files"`
+}
+
+// MigrateMkDocsCmd implements 'docbuilder migrate mkdocs'.
+type MigrateMkDocsCmd struct {
+	File         string `arg:"" optional:"" default:"mkdocs.yml" help:"MkDocs configuration file"`
+	MigrateFlags `embed:""`
+}
+
+// MigrateDocusaurusCmd implements 'docbuilder migrate docusaurus'.
+type MigrateDocusaurusCmd struct {
+	Dir          string `arg:"" optional:"" default:"." help:"Docusaurus site directory (containing docusaurus.config.js)"`
+	MigrateFlags `embed:""`
+}
+
+func (m *MigrateMkDocsCmd) Run(_ *Global, _ *CLI) error {
+	dir, err := filepath.Abs(filepath.Dir(m.File))
+	if err != nil {
+		return fmt.Errorf("resolve site directory: %w", err)
+	}
+	result, err := migrate.MkDocs(m.File, m.options(dir))
+	if err != nil {
+		return err
+	}
+	return m.apply(os.Stdout, dir, result)
+}
+
+func (m *MigrateDocusaurusCmd) Run(_ *Global, _ *CLI) error {
+	dir, err := filepath.Abs(m.Dir)
+	if err != nil {
+		return fmt.Errorf("resolve site directory: %w", err)
+	}
+	result, err := migrate.Docusaurus(m.options(dir))
+	if err != nil {
+		return err
+	}
+	return m.apply(os.Stdout, dir, result)
+}
+
+// options fills in the repository details not given as flags from the git repository.
+func (f *MigrateFlags) options(dir string) migrate.Options {
+	opts := migrate.Options{Dir: dir, Name: f.Name, RepoURL: f.URL, Branch: f.Branch}
+	url, branch := gitOriginAndBranch(dir)
+	if opts.RepoURL == "" {
+		opts.RepoURL = url
+	}
+	if opts.Branch == "" {
+		opts.Branch = branch
+	}
+	return opts
+}
+
+// apply writes the site configuration, the repository's .docbuilder.yaml and the page
+// changes, then reports what could not be translated.
+func (f *MigrateFlags) apply(w io.Writer, dir string, result *migrate.Result) error {
+	siteYAML, err := result.SiteYAML()
+	if err != nil {
+		return err
+	}
+	repoYAML, err := result.RepoYAML()
+	if err != nil {
+		return err
+	}
+	repoConfig := filepath.Join(dir, migrate.RepoConfigFile)
+
+	if f.DryRun {
+		_, _ = fmt.Fprintf(w, "# %s\n%s\n# %s\n%s\n", f.Output, siteYAML, repoConfig, repoYAML)
+		for _, p := range result.Pages {
+			_, _ = fmt.Fprintf(w, "Would update %s: %s\n", p.Path, describePageUpdate(p))
+		}
+	} else {
+		for _, file := range []struct {
+			path string
+			data []byte
+		}{{f.Output, siteYAML}, {repoConfig, repoYAML}} {
+			if fileExists(file.path) && !f.Force {
+				_, _ = fmt.Fprintf(w, "Skipped %s (already exists; use --force to overwrite)\n", file.path)
+				continue
+			}
+			// #nosec G306 -- configuration files are meant to be committed and shared
+			if err := os.WriteFile(file.path, file.data, 0o644); err != nil {
+				return fmt.Errorf("write %s: %w", file.path, err)
+			}
+			_, _ = fmt.Fprintf(w, "Wrote %s\n", file.path)
+		}
+		written, err := result.ApplyPages(dir)
+		for _, p := range written {
+			_, _ = fmt.Fprintf(w, "Updated %s\n", p)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(result.Unsupported) > 0 {
+		_, _ = fmt.Fprintf(w, "\nNot migrated (%d):\n", len(result.Unsupported))
+		for _, u := range result.Unsupported {
+			_, _ = fmt.Fprintf(w, "  - %s\n", u)
+		}
+	}
+	return nil
+}
+
+func describePageUpdate(p migrate.PageUpdate) string {
+	keys := make([]string, 0, len(p.Set))
+	for k := range p.Set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	desc := ""
+	if p.Create {
+		desc = "create, "
+	}
+	for i, k := range keys {
+		if i > 0 {
+			desc += ", "
+		}
+		desc += fmt.Sprintf("%s=%v", k, p.Set[k])
+	}
+	for _, k := range p.Remove {
+		desc += ", remove " + k
+	}
+	return desc
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/lint"
+	"git.home.luguber.info/inful/docbuilder/internal/onboard"
+)
+
+// OnboardCmd groups repository onboarding commands.
+type OnboardCmd struct {
+	Check OnboardCheckCmd `cmd:"" help:"Check whether a repository is ready to be added to the portal"`
+}
+
+// OnboardCheckCmd implements 'docbuilder onboard check'.
+type OnboardCheckCmd struct {
+	Repository string   `arg:"" help:"Repository clone URL, or a local working copy"`
+	Branch     string   `help:"Branch to check (default: the remote's default branch)"`
+	Paths      []string `name:"path" help:"Docs path to check (repeatable; default: from .docbuilder.yaml, else docs)"`
+	Format     string   `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+	MinScore   int      `name:"min-score" help:"Fail when the score is below this value (0-100)"`
+}
+
+func (o *OnboardCheckCmd) Run(_ *Global, root *CLI) error {
+	cfg := loadLintConfig(root.Config)
+	opts := onboard.Options{
+		DocsPaths: o.Paths,
+		Linter:    lint.NewLinter(&lint.Config{Format: "json", ContentPolicy: lintContentPolicy(root.Config)}),
+	}
+
+	var (
+		report *onboard.Report
+		err    error
+	)
+	if st, statErr := os.Stat(o.Repository); statErr == nil && st.IsDir() {
+		report, err = onboard.Analyze(o.Repository, opts)
+	} else {
+		repo := config.Repository{
+			Name:   "candidate",
+			URL:    o.Repository,
+			Branch: o.Branch,
+			Auth:   onboard.RepositoryAuth(cfg, o.Repository),
+		}
+		report, err = onboard.CheckRemote(repo, filepath.Join(os.TempDir(), "docbuilder-onboard"), opts)
+	}
+	if err != nil {
+		return fmt.Errorf("onboarding check: %w", err)
+	}
+
+	if err := writeOnboardReport(os.Stdout, report, o.Format); err != nil {
+		return err
+	}
+	if !report.Ready {
+		return errors.New("repository is not ready to be added: a check failed")
+	}
+	if report.Score < o.MinScore {
+		return fmt.Errorf("onboarding score %d is below --min-score %d", report.Score, o.MinScore)
+	}
+	return nil
+}
+
+// writeOnboardReport renders a report as indented JSON or as a checklist table.
+func writeOnboardReport(out io.Writer, report *onboard.Report, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	_, _ = fmt.Fprintf(out, "Repository: %s\n", report.Repository)
+	if report.Commit != "" {
+		_, _ = fmt.Fprintf(out, "Commit:     %s\n", report.Commit)
+	}
+	_, _ = fmt.Fprintf(out, "Score:      %d/100\n", report.Score)
+	_, _ = fmt.Fprintf(out, "Ready:      %t\n\n", report.Ready)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CHECK\tSTATUS\tPOINTS\tDETAIL")
+	for _, c := range report.Checks {
+		points := 0
+		switch c.Status {
+		case onboard.StatusPass:
+			points = c.Weight
+		case onboard.StatusWarn:
+			points = c.Weight / 2
+		case onboard.StatusFail:
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\n", c.Title, c.Status, points, c.Weight, c.Detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, c := range report.Checks {
+		if c.Hint != "" && c.Status != onboard.StatusPass {
+			_, _ = fmt.Fprintf(out, "\n%s: %s", c.Title, c.Hint)
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+	return nil
+}
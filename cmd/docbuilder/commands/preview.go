@@ -7,11 +7,13 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/preview"
+	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
 )
 
 const configVersion = "2.0"
@@ -36,14 +38,17 @@ func deriveTitleFromDocsDir(docsDir string) string {
 
 // PreviewCmd starts a local server watching a docs directory without forge polling.
 type PreviewCmd struct {
-	DocsDir        string `short:"d" name:"docs-dir" default:"./docs" help:"Path to local docs directory to watch."`
-	OutputDir      string `short:"o" name:"output" default:"" help:"Output directory for the generated site (defaults to temp)."`
-	Title          string `name:"title" default:"" help:"Site title (defaults to parent directory name)."`
-	BaseURL        string `name:"base-url" default:"http://localhost:1316" help:"Base URL used in Hugo config."`
-	Port           int    `name:"port" default:"1316" help:"Docs server port."`
-	LiveReloadPort int    `name:"livereload-port" default:"0" help:"LiveReload server port (defaults to port+3)."`
-	NoLiveReload   bool   `name:"no-live-reload" help:"Disable LiveReload SSE and script injection for preview."`
-	VSCode         bool   `name:"vscode" help:"Enable VS Code edit links (opens files in editor via /_edit/ handler)."`
+	DocsDir        string   `short:"d" name:"docs-dir" default:"./docs" help:"Path to local docs directory to watch."`
+	OutputDir      string   `short:"o" name:"output" default:"" help:"Output directory for the generated site (defaults to temp)."`
+	Title          string   `name:"title" default:"" help:"Site title (defaults to parent directory name)."`
+	BaseURL        string   `name:"base-url" default:"http://localhost:1316" help:"Base URL used in Hugo config."`
+	Port           int      `name:"port" default:"1316" help:"Docs server port."`
+	LiveReloadPort int      `name:"livereload-port" default:"0" help:"LiveReload server port (defaults to port+3)."`
+	NoLiveReload   bool     `name:"no-live-reload" help:"Disable LiveReload SSE and script injection for preview."`
+	VSCode         bool     `name:"vscode" help:"Enable VS Code edit links (opens files in editor via /_edit/ handler)."`
+	EditWith       string   `name:"edit-with" help:"Enable local edit links opening files in the named editor (vscode, jetbrains, emacs, sublime or an --editor-command name)."`
+	EditorCommands []string `name:"editor-command" help:"Define an editor for edit links as NAME=TEMPLATE; {path} is the file path. URL templates (idea://open?file={path}) redirect the browser, others run as a command."`
+	Editor         bool     `name:"editor" help:"Enable the in-browser markdown editor (edit links open /_editor/; saves trigger a rebuild)."`
 }
 
 //nolint:forbidigo // fmt is used for user-facing messages
@@ -108,7 +113,17 @@ func (p *PreviewCmd) Run(_ *Global, _ *CLI) error {
 	cfg.Build.RenderMode = config.RenderModeAlways
 	cfg.Build.NamespaceForges = config.NamespacingNever // Prevent "Locals" navigation section
 	cfg.Build.IsPreview = true                          // Enable preview mode features
-	cfg.Build.VSCodeEditLinks = p.VSCode                // Enable VS Code edit links when --vscode flag is set
+	editors, err := parseEditorCommands(p.EditorCommands)
+	if err != nil {
+		return err
+	}
+	if p.EditWith != "" && !slices.Contains(httpserver.EditorNames(editors), p.EditWith) {
+		return fmt.Errorf("unknown editor %q (available: %s)", p.EditWith, strings.Join(httpserver.EditorNames(editors), ", "))
+	}
+	cfg.Build.VSCodeEditLinks = p.VSCode || p.EditWith != "" // Enable local /_edit/ links for --vscode or --edit-with
+	cfg.Build.Editor = p.EditWith
+	cfg.Build.EditorCommands = editors
+	cfg.Build.BrowserEditor = p.Editor // Enable in-browser editor when --editor flag is set
 	// Enable LiveReload by default for preview, unless explicitly disabled.
 	cfg.Build.LiveReload = !p.NoLiveReload
 
@@ -122,3 +137,20 @@ func (p *PreviewCmd) Run(_ *Global, _ *CLI) error {
 
 	return preview.StartLocalPreview(sigctx, cfg, p.Port, tempOut)
 }
+
+// parseEditorCommands parses --editor-command NAME=TEMPLATE values and validates each template.
+func parseEditorCommands(values []string) (map[string]string, error) {
+	editors := make(map[string]string, len(values))
+	for _, entry := range values {
+		name, template, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --editor-command value: %s", entry)
+		}
+		if _, err := httpserver.ParseEditorCommand(template); err != nil {
+			return nil, fmt.Errorf("invalid --editor-command %s: %w", name, err)
+		}
+		editors[name] = strings.TrimSpace(template)
+	}
+	return editors, nil
+}
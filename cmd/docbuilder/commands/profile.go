@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// ProfileFlags adds pprof output flags to a command.
+type ProfileFlags struct {
+	CPUProfile string `name:"cpuprofile" help:"Write a CPU profile to this file (inspect with go tool pprof)"`
+	MemProfile string `name:"memprofile" help:"Write a heap profile to this file when the command finishes"`
+}
+
+// start begins CPU profiling when requested. The returned function stops it and writes
+// the heap profile; it must be called once the profiled work is done.
+func (p ProfileFlags) start() (func(), error) {
+	stopCPU := func() {}
+	if p.CPUProfile != "" {
+		f, err := os.Create(p.CPUProfile)
+		if err != nil {
+			return nil, fmt.Errorf("create cpu profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("start cpu profile: %w", err)
+		}
+		stopCPU = func() {
+			pprof.StopCPUProfile()
+			_ = f.Close()
+			slog.Info("CPU profile written", "path", p.CPUProfile)
+		}
+	}
+
+	return func() {
+		stopCPU()
+		if p.MemProfile == "" {
+			return
+		}
+		f, err := os.Create(p.MemProfile)
+		if err != nil {
+			slog.Warn("Failed to create memory profile", "path", p.MemProfile, "error", err)
+			return
+		}
+		defer func() { _ = f.Close() }()
+		runtime.GC() // up-to-date statistics for the heap profile
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			slog.Warn("Failed to write memory profile", "path", p.MemProfile, "error", err)
+			return
+		}
+		slog.Info("Memory profile written", "path", p.MemProfile)
+	}, nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// ReportCmd implements the 'report' command.
+type ReportCmd struct {
+	ID      string        `arg:"" help:"Build job ID"`
+	URL     string        `name:"url" env:"DOCBUILDER_ADMIN_URL" help:"Admin API base URL of the daemon (default: http://localhost:<daemon.http.admin_port>)"`
+	Token   string        `name:"token" env:"DOCBUILDER_ADMIN_TOKEN" help:"Admin API token (default: daemon.http.admin_token)"`
+	Format  string        `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+	Timeout time.Duration `name:"timeout" default:"5s" help:"Request timeout"`
+}
+
+func (r *ReportCmd) Run(_ *Global, root *CLI) error {
+	baseURL, token, err := resolveAdminEndpoint(r.URL, r.Token, root.Config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+	document, err := fetchBuildReport(ctx, http.DefaultClient, baseURL, token, r.ID)
+	if err != nil {
+		return err
+	}
+	return writeBuildReport(os.Stdout, r.ID, document, r.Format)
+}
+
+// fetchBuildReport reads the stored report of a build job from the daemon admin API.
+func fetchBuildReport(ctx context.Context, client *http.Client, baseURL, token, buildID string) ([]byte, error) {
+	body, err := adminGet(ctx, client, baseURL, token, "/api/builds/"+url.PathEscape(buildID)+"/report")
+	if errors.Is(err, errAdminNotFound) {
+		return nil, fmt.Errorf("no report stored for build %q", buildID)
+	}
+	return body, err
+}
+
+// writeBuildReport renders a report document as indented JSON or as a summary table.
+func writeBuildReport(out io.Writer, buildID string, document []byte, format string) error {
+	report, err := models.DecodeReport(document)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, document, "", "  "); err != nil {
+			return fmt.Errorf("format build report: %w", err)
+		}
+		buf.WriteByte('\n')
+		_, err := buf.WriteTo(out)
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Build:\t%s\n", buildID)
+	_, _ = fmt.Fprintf(w, "Outcome:\t%s\n", report.Outcome)
+	_, _ = fmt.Fprintf(w, "Started:\t%s\n", report.Start.UTC().Format("2006-01-02 15:04:05 UTC"))
+	_, _ = fmt.Fprintf(w, "Duration:\t%s\n", report.End.Sub(report.Start).Round(time.Millisecond))
+	_, _ = fmt.Fprintf(w, "Repositories:\t%d (cloned %d, failed %d, skipped %d)\n",
+		report.Repositories, report.ClonedRepositories, report.FailedRepositories, report.SkippedRepositories)
+	_, _ = fmt.Fprintf(w, "Files:\t%d\n", report.Files)
+	_, _ = fmt.Fprintf(w, "Rendered pages:\t%d\n", report.RenderedPages)
+	_, _ = fmt.Fprintf(w, "Static rendered:\t%t\n", report.StaticRendered)
+	if report.SkipReason != "" {
+		_, _ = fmt.Fprintf(w, "Skip reason:\t%s\n", report.SkipReason)
+	}
+	if report.DocBuilderVersion != "" || report.HugoVersion != "" {
+		_, _ = fmt.Fprintf(w, "Versions:\tdocbuilder %s, hugo %s\n", report.DocBuilderVersion, report.HugoVersion)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(report.StageDurations) > 0 {
+		stages := make([]string, 0, len(report.StageDurations))
+		for stage := range report.StageDurations {
+			stages = append(stages, stage)
+		}
+		sort.Strings(stages)
+		_, _ = fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "STAGE\tDURATION\tRESULT")
+		for _, stage := range stages {
+			result := report.StageErrorKinds[stage]
+			if result == "" {
+				result = "ok"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", stage, report.StageDurations[stage].Round(time.Millisecond), result)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(report.Errors)+len(report.Warnings) > 0 {
+		_, _ = fmt.Fprintln(out)
+		for _, e := range report.Errors {
+			_, _ = fmt.Fprintf(out, "error: %s\n", e)
+		}
+		for _, warn := range report.Warnings {
+			_, _ = fmt.Fprintf(out, "warning: %s\n", warn)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testReportDocument = `{"schema_version":1,"repositories":2,"files":12,` +
+	`"start":"2026-01-02T10:00:00Z","end":"2026-01-02T10:00:03.5Z","errors":[],` +
+	`"warnings":["repo b: clone failed"],"stage_durations":{"clone_repos":2000000000,"run_hugo":1500000000},` +
+	`"stage_error_kinds":{"clone_repos":"warning"},"cloned_repositories":1,"failed_repositories":1,` +
+	`"skipped_repositories":0,"rendered_pages":12,"stage_counts":{},"outcome":"warning","static_rendered":true,` +
+	`"retries":0,"retries_exhausted":false,"issues":[]}`
+
+func TestFetchAndWriteBuildReport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/builds/job-1/report" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testReportDocument))
+	}))
+	defer srv.Close()
+
+	if _, err := fetchBuildReport(context.Background(), srv.Client(), srv.URL, "", "job-2"); err == nil || !strings.Contains(err.Error(), `no report stored for build "job-2"`) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+
+	document, err := fetchBuildReport(context.Background(), srv.Client(), srv.URL, "", "job-1")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeBuildReport(&out, "job-1", document, "text"); err != nil {
+		t.Fatalf("write text: %v", err)
+	}
+	for _, want := range []string{
+		"Build:            job-1",
+		"Outcome:          warning",
+		"Duration:         3.5s",
+		"Repositories:     2 (cloned 1, failed 1, skipped 0)",
+		"clone_repos  2s        warning",
+		"run_hugo     1.5s      ok",
+		"warning: repo b: clone failed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := writeBuildReport(&out, "job-1", document, "json"); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if !strings.Contains(out.String(), "\n  \"schema_version\": 1,\n") {
+		t.Errorf("expected indented JSON, got:\n%s", out.String())
+	}
+
+	if err := writeBuildReport(&out, "job-1", []byte(`{"schema_version":2}`), "text"); err == nil {
+		t.Fatal("expected unsupported schema error")
+	}
+}
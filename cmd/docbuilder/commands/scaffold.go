@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+
+	"git.home.luguber.info/inful/docbuilder/internal/scaffold"
+)
+
+// ScaffoldCmd groups scaffolding commands.
+type ScaffoldCmd struct {
+	Repo ScaffoldRepoCmd `cmd:"" help:"Generate a ready-to-aggregate docs structure in a repository"`
+}
+
+// ScaffoldRepoCmd implements 'docbuilder scaffold repo'.
+type ScaffoldRepoCmd struct {
+	Dir        string `arg:"" optional:"" default:"." help:"Target repository directory"`
+	Name       string `help:"Repository name (default: directory name)"`
+	Title      string `help:"Documentation site title"`
+	URL        string `name:"url" help:"Repository clone URL (default: git remote 'origin')"`
+	Branch     string `help:"Default branch (default: current branch or main)"`
+	CI         string `name:"ci" enum:"none,github,gitlab,forgejo" default:"none" help:"Generate a CI snippet (none, github, gitlab, forgejo)"`
+	NoExamples bool   `name:"no-examples" help:"Do not generate the example ADR and guide"`
+	Force      bool   `help:"Overwrite existing files"`
+	Yes        bool   `short:"y" help:"Accept defaults without prompting"`
+}
+
+func (s *ScaffoldRepoCmd) Run(_ *Global, _ *CLI) error {
+	dir, err := filepath.Abs(s.Dir)
+	if err != nil {
+		return fmt.Errorf("resolve target directory: %w", err)
+	}
+
+	opts := scaffoldDefaults(dir)
+	reader := bufio.NewReader(os.Stdin)
+	ask := func(label, flag, def string) (string, error) {
+		if flag != "" {
+			return flag, nil
+		}
+		if s.Yes {
+			return def, nil
+		}
+		return promptWithDefault(reader, os.Stdout, label, def)
+	}
+
+	if opts.Name, err = ask("Repository name", s.Name, opts.Name); err != nil {
+		return err
+	}
+	if opts.Title, err = ask("Site title", s.Title, opts.Name+" Documentation"); err != nil {
+		return err
+	}
+	if opts.RepoURL, err = ask("Repository URL", s.URL, opts.RepoURL); err != nil {
+		return err
+	}
+	if opts.Branch, err = ask("Default branch", s.Branch, opts.Branch); err != nil {
+		return err
+	}
+	if s.CI != "none" {
+		opts.CI = s.CI
+	}
+	opts.Examples = !s.NoExamples
+	opts.Force = s.Force
+
+	result, err := scaffold.Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	docsDir := filepath.Join(dir, "docs")
+	if err := runLintFix(docsDir); err != nil {
+		return fmt.Errorf("lint generated docs: %w", err)
+	}
+
+	for _, p := range result.Written {
+		_, _ = fmt.Fprintf(os.Stdout, "Created %s\n", p)
+	}
+	for _, p := range result.Skipped {
+		_, _ = fmt.Fprintf(os.Stdout, "Skipped %s (already exists)\n", p)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "Scaffolded documentation in %s\n", dir)
+	return nil
+}
+
+// scaffoldDefaults derives default answers from the target directory and its git repository.
+func scaffoldDefaults(dir string) scaffold.Options {
+	opts := scaffold.Options{
+		Dir:    dir,
+		Name:   filepath.Base(dir),
+		Branch: "main",
+	}
+	opts.RepoURL = "https://git.example.com/your-org/" + opts.Name + ".git"
+	url, branch := gitOriginAndBranch(dir)
+	if url != "" {
+		opts.RepoURL = url
+	}
+	if branch != "" {
+		opts.Branch = branch
+	}
+	return opts
+}
+
+// gitOriginAndBranch returns the URL of the remote 'origin' and the current branch of the
+// git repository containing dir; either is empty when unknown.
+func gitOriginAndBranch(dir string) (url, branch string) {
+	repo, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", ""
+	}
+	if remote, err := repo.Remote("origin"); err == nil && len(remote.Config().URLs) > 0 {
+		url = remote.Config().URLs[0]
+	}
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		branch = head.Name().Short()
+	}
+	return url, branch
+}
+
+func promptWithDefault(reader *bufio.Reader, w io.Writer, label, def string) (string, error) {
+	_, _ = fmt.Fprintf(w, "%s [%s]: ", label, def)
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
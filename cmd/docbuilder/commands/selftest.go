@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/selftest"
+)
+
+// SelftestCmd implements the 'selftest' command: it builds the bundled fixture
+// repositories and compares the generated site with the snapshots shipped in the binary.
+type SelftestCmd struct {
+	Fixture []string      `name:"fixture" help:"Run only this fixture (repeatable)"`
+	List    bool          `name:"list" help:"List the bundled fixtures and exit"`
+	WorkDir string        `name:"work-dir" help:"Keep repositories and generated sites in this directory (default: a removed temporary directory)"`
+	Update  string        `name:"update" placeholder:"DIR" help:"Write this binary's snapshots to DIR instead of comparing"`
+	Format  string        `short:"f" name:"format" default:"text" enum:"text,json" help:"Output format: text or json"`
+	Timeout time.Duration `name:"timeout" default:"5m" help:"Overall timeout"`
+}
+
+func (s *SelftestCmd) Run(_ *Global, _ *CLI) error {
+	if s.List {
+		for _, name := range selftest.Fixtures() {
+			_, _ = fmt.Fprintln(os.Stdout, name)
+		}
+		return nil
+	}
+
+	sigctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	ctx, cancelTimeout := context.WithTimeout(sigctx, s.Timeout)
+	defer cancelTimeout()
+
+	res, err := selftest.Run(ctx, selftest.Options{Fixtures: s.Fixture, WorkDir: s.WorkDir, UpdateDir: s.Update})
+	if err != nil {
+		return err
+	}
+	if err := writeSelftest(os.Stdout, res, s.Format); err != nil {
+		return err
+	}
+	if !res.Passed() {
+		failed := 0
+		for i := range res.Fixtures {
+			if !res.Fixtures[i].Passed() {
+				failed++
+			}
+		}
+		return errors.NewError(errors.CategoryBuild, "self-test failed").
+			WithCode(errors.CodeBuildSelftest).
+			WithContext("failed", failed).
+			WithContext("fixtures", len(res.Fixtures)).
+			Build()
+	}
+	return nil
+}
+
+// writeSelftest renders a self-test result as text or JSON.
+func writeSelftest(out io.Writer, res *selftest.Result, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			OK       bool                     `json:"ok"`
+			Fixtures []selftest.FixtureResult `json:"fixtures"`
+		}{res.Passed(), res.Fixtures})
+	}
+	for i := range res.Fixtures {
+		f := &res.Fixtures[i]
+		status := "PASS"
+		switch {
+		case f.Updated != "" && f.Error == "":
+			status = "UPDATED"
+		case !f.Passed():
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(out, "%-7s %s (%s)\n", status, f.Name, f.Duration.Round(time.Millisecond))
+		if f.Updated != "" {
+			_, _ = fmt.Fprintf(out, "        wrote %s\n", f.Updated)
+		}
+		if f.Error != "" {
+			_, _ = fmt.Fprintf(out, "        error: %s\n", f.Error)
+		}
+		for _, p := range f.Missing {
+			_, _ = fmt.Fprintf(out, "        missing:    %s\n", p)
+		}
+		for _, p := range f.Unexpected {
+			_, _ = fmt.Fprintf(out, "        unexpected: %s\n", p)
+		}
+		for _, c := range f.Changed {
+			_, _ = fmt.Fprintf(out, "        changed:    %s:%d\n          want: %s\n          got:  %s\n", c.Path, c.Line, c.Want, c.Got)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/preview"
+)
+
+// ServeCmd serves an already-built site directory without building, webhooks or admin endpoints.
+type ServeCmd struct {
+	Dir            string `arg:"" optional:"" default:"./site" help:"Site directory to serve (a Hugo output directory or its public/ folder)."`
+	Port           int    `name:"port" default:"1316" help:"Docs server port."`
+	Watch          string `name:"watch" default:"" help:"Source directory to watch; changes trigger a LiveReload in connected browsers."`
+	LiveReloadPort int    `name:"livereload-port" default:"0" help:"LiveReload server port (defaults to port+3)."`
+}
+
+func (s *ServeCmd) Run(_ *Global, _ *CLI) error {
+	sigctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	liveReloadPort := s.LiveReloadPort
+	if liveReloadPort == 0 {
+		liveReloadPort = s.Port + 3
+	}
+	cfg := &config.Config{Version: configVersion}
+	cfg.Output.Directory = s.Dir
+	cfg.Daemon = &config.DaemonConfig{
+		HTTP: config.HTTPConfig{
+			DocsPort:       s.Port,
+			LiveReloadPort: liveReloadPort,
+		},
+	}
+
+	return preview.ServeStatic(sigctx, cfg, s.Watch)
+}
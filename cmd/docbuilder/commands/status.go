@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
+)
+
+// defaultAdminPort is the daemon admin port used when no configuration is available.
+const defaultAdminPort = 8082
+
+// StatusCmd implements the 'status' command.
+type StatusCmd struct {
+	URL     string        `name:"url" env:"DOCBUILDER_ADMIN_URL" help:"Admin API base URL of the daemon (default: http://localhost:<daemon.http.admin_port>)"`
+	Token   string        `name:"token" env:"DOCBUILDER_ADMIN_TOKEN" help:"Admin API token (default: daemon.http.admin_token)"`
+	Format  string        `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+	Timeout time.Duration `name:"timeout" default:"5s" help:"Request timeout"`
+}
+
+// daemonStatusSummary is the JSON output of 'docbuilder status'.
+type daemonStatusSummary struct {
+	URL           string            `json:"url"`
+	Status        string            `json:"status"`
+	Version       string            `json:"version"`
+	StartTime     time.Time         `json:"start_time"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	QueueLength   int32             `json:"queue_length"`
+	ActiveJobs    int32             `json:"active_jobs"`
+	LastBuild     *lastBuildSummary `json:"last_build,omitempty"`
+	LastDiscovery *time.Time        `json:"last_discovery,omitempty"`
+	NextDiscovery *time.Time        `json:"next_discovery,omitempty"`
+	// DiscoveryRestored is set while the repository list comes from the discovery
+	// result persisted before the daemon's last restart.
+	DiscoveryRestored bool `json:"discovery_restored,omitempty"`
+}
+
+// lastBuildSummary describes the daemon's most recent build.
+type lastBuildSummary struct {
+	Time    *time.Time `json:"time,omitempty"`
+	Outcome string     `json:"outcome,omitempty"`
+	Summary string     `json:"summary,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
+
+func (s *StatusCmd) Run(_ *Global, root *CLI) error {
+	baseURL, token, err := resolveAdminEndpoint(s.URL, s.Token, root.Config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+	defer cancel()
+	data, err := fetchDaemonStatus(ctx, http.DefaultClient, baseURL, token)
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeDaemonStatus(baseURL, data)
+	if s.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	return writeDaemonStatus(os.Stdout, summary, time.Now())
+}
+
+// resolveAdminEndpoint fills in the admin URL and token from the configuration file
+// when they were not given on the command line.
+func resolveAdminEndpoint(baseURL, token, configPath string) (string, string, error) {
+	if baseURL != "" && token != "" {
+		return baseURL, token, nil
+	}
+	cfgURL, cfgToken, err := adminEndpointFromConfig(configPath)
+	if err != nil {
+		return "", "", err
+	}
+	if baseURL == "" {
+		baseURL = cfgURL
+	}
+	if token == "" {
+		token = cfgToken
+	}
+	return baseURL, token, nil
+}
+
+// adminEndpointFromConfig derives the admin URL and token from the configuration file, if it exists.
+func adminEndpointFromConfig(configPath string) (string, string, error) {
+	port := defaultAdminPort
+	token := ""
+	if configPath != "" && fileExists(configPath) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return "", "", fmt.Errorf("load config: %w", err)
+		}
+		if cfg.Daemon != nil {
+			if cfg.Daemon.HTTP.AdminPort != 0 {
+				port = cfg.Daemon.HTTP.AdminPort
+			}
+			token = cfg.Daemon.HTTP.AdminToken
+		}
+	}
+	return "http://localhost:" + strconv.Itoa(port), token, nil
+}
+
+// errAdminNotFound reports a 404 from the daemon admin API.
+var errAdminNotFound = errors.New("not found")
+
+// adminGet performs a GET against the daemon admin API and returns the body of a 200 response.
+func adminGet(ctx context.Context, client *http.Client, baseURL, token, path string) ([]byte, error) {
+	endpoint := strings.TrimRight(baseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create admin request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query daemon at %s (is it running?): %w", baseURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("daemon at %s rejected the admin token (set --token or daemon.http.admin_token)", baseURL)
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", errAdminNotFound, endpoint)
+		}
+		return nil, fmt.Errorf("daemon at %s returned %s: %s", baseURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read daemon response: %w", err)
+	}
+	return body, nil
+}
+
+// fetchDaemonStatus reads the JSON status of a running daemon from its admin API.
+func fetchDaemonStatus(ctx context.Context, client *http.Client, baseURL, token string) (*handlers.StatusPageData, error) {
+	body, err := adminGet(ctx, client, baseURL, token, "/status?format=json")
+	if err != nil {
+		return nil, err
+	}
+	var data handlers.StatusPageData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("decode daemon status: %w", err)
+	}
+	return &data, nil
+}
+
+func summarizeDaemonStatus(baseURL string, data *handlers.StatusPageData) *daemonStatusSummary {
+	summary := &daemonStatusSummary{
+		URL:           baseURL,
+		Status:        data.DaemonInfo.Status,
+		Version:       data.DaemonInfo.Version,
+		StartTime:     data.DaemonInfo.StartTime,
+		UptimeSeconds: int64(data.LastUpdated.Sub(data.DaemonInfo.StartTime).Seconds()),
+		QueueLength:   data.BuildStatus.QueueLength,
+		ActiveJobs:    data.BuildStatus.ActiveJobs,
+		LastDiscovery: data.LastDiscovery,
+		NextDiscovery: data.NextDiscovery,
+
+		DiscoveryRestored: data.DiscoveryRestored,
+	}
+	if bs := data.BuildStatus; bs.LastBuildTime != nil || bs.LastBuildOutcome != "" {
+		summary.LastBuild = &lastBuildSummary{
+			Time:    bs.LastBuildTime,
+			Outcome: bs.LastBuildOutcome,
+			Summary: bs.LastBuildSummary,
+			Errors:  bs.LastBuildErrors,
+		}
+	}
+	return summary
+}
+
+func writeDaemonStatus(out io.Writer, s *daemonStatusSummary, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Daemon:\t%s\n", s.URL)
+	_, _ = fmt.Fprintf(w, "Status:\t%s\n", s.Status)
+	_, _ = fmt.Fprintf(w, "Version:\t%s\n", s.Version)
+	_, _ = fmt.Fprintf(w, "Uptime:\t%s\n", time.Duration(s.UptimeSeconds)*time.Second)
+	_, _ = fmt.Fprintf(w, "Queue length:\t%d\n", s.QueueLength)
+	_, _ = fmt.Fprintf(w, "Active jobs:\t%d\n", s.ActiveJobs)
+	if s.LastBuild == nil {
+		_, _ = fmt.Fprintf(w, "Last build:\tnone\n")
+	} else {
+		outcome := s.LastBuild.Outcome
+		if outcome == "" {
+			outcome = "unknown"
+		}
+		_, _ = fmt.Fprintf(w, "Last build:\t%s (%s)\n", outcome, formatStatusTime(s.LastBuild.Time, now))
+		if s.LastBuild.Summary != "" {
+			_, _ = fmt.Fprintf(w, "Build summary:\t%s\n", s.LastBuild.Summary)
+		}
+		for _, e := range s.LastBuild.Errors {
+			_, _ = fmt.Fprintf(w, "Build error:\t%s\n", e)
+		}
+	}
+	if s.DiscoveryRestored {
+		_, _ = fmt.Fprintf(w, "Last discovery:\t%s (restored from state)\n", formatStatusTime(s.LastDiscovery, now))
+	} else {
+		_, _ = fmt.Fprintf(w, "Last discovery:\t%s\n", formatStatusTime(s.LastDiscovery, now))
+	}
+	_, _ = fmt.Fprintf(w, "Next discovery:\t%s\n", formatStatusTime(s.NextDiscovery, now))
+	return w.Flush()
+}
+
+// formatStatusTime renders t with its distance from now ("5m ago", "in 2h").
+func formatStatusTime(t *time.Time, now time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+	d := t.Sub(now).Round(time.Second)
+	rel := "in " + d.String()
+	if d < 0 {
+		rel = (-d).String() + " ago"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC") + ", " + rel
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
+)
+
+func TestFetchDaemonStatus(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	lastBuild := start.Add(90 * time.Minute)
+	next := start.Add(4 * time.Hour)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" || r.URL.Query().Get("format") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data := handlers.StatusPageData{
+			DaemonInfo:    handlers.Info{Status: "running", Version: "1.2.3", StartTime: start},
+			BuildStatus:   handlers.BuildStatusInfo{QueueLength: 2, ActiveJobs: 1, LastBuildTime: &lastBuild, LastBuildOutcome: "warning"},
+			LastUpdated:   start.Add(2 * time.Hour),
+			NextDiscovery: &next,
+		}
+		_ = json.NewEncoder(w).Encode(data)
+	}))
+	defer srv.Close()
+
+	if _, err := fetchDaemonStatus(context.Background(), srv.Client(), srv.URL, "wrong"); err == nil || !strings.Contains(err.Error(), "admin token") {
+		t.Fatalf("expected token error, got %v", err)
+	}
+
+	data, err := fetchDaemonStatus(context.Background(), srv.Client(), srv.URL+"/", "secret")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	summary := summarizeDaemonStatus(srv.URL, data)
+	if summary.UptimeSeconds != 7200 || summary.QueueLength != 2 || summary.ActiveJobs != 1 || summary.LastBuild == nil || summary.LastBuild.Outcome != "warning" {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+
+	var out strings.Builder
+	if err := writeDaemonStatus(&out, summary, start.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Uptime:          2h0m0s",
+		"Queue length:    2",
+		"Last build:      warning (2026-01-02 11:30:00 UTC, 30m0s ago)",
+		"Last discovery:  never",
+		"Next discovery:  2026-01-02 14:00:00 UTC, in 2h0m0s",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -24,7 +24,11 @@ type TemplateCmd struct {
 
 // TemplateListCmd implements 'docbuilder template list'.
 type TemplateListCmd struct {
-	BaseURL string `name:"base-url" help:"Base URL for template discovery"`
+	BaseURL      string `name:"base-url" help:"Base URL for template discovery"`
+	DaemonURL    string `name:"daemon-url" help:"Daemon admin URL serving the template API (preferred over HTML discovery)"`
+	TemplatesDir string `name:"templates-dir" help:"Local directory containing *.template.md files"`
+	TemplatesGit string `name:"templates-git" help:"Git repository URL containing *.template.md files"`
+	TemplatesRef string `name:"templates-ref" help:"Branch to clone for --templates-git (default: remote HEAD)"`
 }
 
 func (t *TemplateListCmd) Run(_ *Global, root *CLI) error {
@@ -37,13 +41,19 @@ func (t *TemplateListCmd) Run(_ *Global, root *CLI) error {
 		return err
 	}
 
-	baseURL, err := ResolveTemplateBaseURL(t.BaseURL, cfg)
+	source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{
+		BaseURL:      t.BaseURL,
+		DaemonURL:    t.DaemonURL,
+		TemplatesDir: t.TemplatesDir,
+		TemplatesGit: t.TemplatesGit,
+		TemplatesRef: t.TemplatesRef,
+	}, cfg)
+	defer cleanup()
 	if err != nil {
 		return err
 	}
 
-	client := templating.NewTemplateHTTPClient()
-	templates, err := templating.FetchTemplateDiscovery(context.Background(), baseURL, client)
+	templates, err := source.Discover(context.Background())
 	if err != nil {
 		return err
 	}
@@ -56,10 +66,14 @@ func (t *TemplateListCmd) Run(_ *Global, root *CLI) error {
 
 // TemplateNewCmd implements 'docbuilder template new'.
 type TemplateNewCmd struct {
-	BaseURL  string   `name:"base-url" help:"Base URL for template discovery"`
-	Set      []string `name:"set" help:"Override template fields (key=value)"`
-	Defaults bool     `help:"Use defaults and skip prompts"`
-	Yes      bool     `short:"y" help:"Auto-confirm output path and file creation"`
+	BaseURL      string   `name:"base-url" help:"Base URL for template discovery"`
+	DaemonURL    string   `name:"daemon-url" help:"Daemon admin URL serving the template API (preferred over HTML discovery)"`
+	TemplatesDir string   `name:"templates-dir" help:"Local directory containing *.template.md files"`
+	TemplatesGit string   `name:"templates-git" help:"Git repository URL containing *.template.md files"`
+	TemplatesRef string   `name:"templates-ref" help:"Branch to clone for --templates-git (default: remote HEAD)"`
+	Set          []string `name:"set" help:"Override template fields (key=value)"`
+	Defaults     bool     `help:"Use defaults and skip prompts"`
+	Yes          bool     `short:"y" help:"Auto-confirm output path and file creation"`
 }
 
 func (t *TemplateNewCmd) Run(_ *Global, root *CLI) error {
@@ -72,13 +86,19 @@ func (t *TemplateNewCmd) Run(_ *Global, root *CLI) error {
 		return err
 	}
 
-	baseURL, err := ResolveTemplateBaseURL(t.BaseURL, cfg)
+	source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{
+		BaseURL:      t.BaseURL,
+		DaemonURL:    t.DaemonURL,
+		TemplatesDir: t.TemplatesDir,
+		TemplatesGit: t.TemplatesGit,
+		TemplatesRef: t.TemplatesRef,
+	}, cfg)
+	defer cleanup()
 	if err != nil {
 		return err
 	}
 
-	client := templating.NewTemplateHTTPClient()
-	templates, err := templating.FetchTemplateDiscovery(context.Background(), baseURL, client)
+	templates, err := source.Discover(context.Background())
 	if err != nil {
 		return err
 	}
@@ -88,7 +108,7 @@ func (t *TemplateNewCmd) Run(_ *Global, root *CLI) error {
 		return err
 	}
 
-	page, err := templating.FetchTemplatePage(context.Background(), selected.URL, client)
+	page, err := source.Fetch(context.Background(), selected)
 	if err != nil {
 		return err
 	}
@@ -234,11 +254,21 @@ func (c *cliPrompter) Prompt(field templating.SchemaField) (string, error) {
 	if field.Required {
 		label += " (required)"
 	}
-	if field.Type == templating.FieldTypeStringEnum && len(field.Options) > 0 {
-		_, _ = fmt.Fprintf(c.writer, "%s [%s]: ", label, strings.Join(field.Options, ", "))
-	} else {
-		_, _ = fmt.Fprintf(c.writer, "%s: ", label)
-	}
+	switch {
+	case field.Type == templating.FieldTypeStringEnum && len(field.Options) > 0:
+		label += " [" + strings.Join(field.Options, ", ") + "]"
+	case field.Type == templating.FieldTypeMultiSelect && len(field.Options) > 0:
+		label += " [" + strings.Join(field.Options, ", ") + "] (comma-separated)"
+	case field.Type == templating.FieldTypeStringList || field.Type == templating.FieldTypeMultiSelect:
+		label += " (comma-separated)"
+	case field.Type == templating.FieldTypeDate:
+		label += " (YYYY-MM-DD or today)"
+	case field.Type == templating.FieldTypeInt:
+		label += " (integer)"
+	case field.Type == templating.FieldTypeBool:
+		label += " (true/false)"
+	}
+	_, _ = fmt.Fprintf(c.writer, "%s: ", label)
 
 	line, err := c.reader.ReadString('\n')
 	if err != nil {
@@ -256,28 +286,9 @@ func resolveDocsDir() (string, error) {
 }
 
 func buildSequenceResolver(page *templating.TemplatePage, docsDir string) (func(string) (int, error), error) {
-	defs := make(map[string]templating.SequenceDefinition)
-
-	if page.Meta.Sequence != "" {
-		def, err := templating.ParseSequenceDefinition(page.Meta.Sequence)
-		if err != nil {
-			if !errors.Is(err, templating.ErrNoSequenceDefinition) {
-				return nil, err
-			}
-		} else if def != nil {
-			defs[def.Name] = *def
-		}
-	}
-
-	if _, ok := defs["adr"]; !ok && strings.EqualFold(page.Meta.Type, "adr") {
-		defs["adr"] = templating.SequenceDefinition{
-			Name:  "adr",
-			Dir:   "adr",
-			Glob:  "adr-*.md",
-			Regex: "^adr-(\\d{3})-",
-			Width: 3,
-			Start: 1,
-		}
+	defs, err := templating.PageSequences(page)
+	if err != nil {
+		return nil, err
 	}
 
 	return func(name string) (int, error) {
@@ -3,11 +3,28 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
 )
 
-const templateBaseURLEnv = "DOCBUILDER_TEMPLATE_BASE_URL"
+const (
+	templateBaseURLEnv  = "DOCBUILDER_TEMPLATE_BASE_URL"
+	templatesDirEnv     = "DOCBUILDER_TEMPLATES_DIR"
+	templateDaemonEnv   = "DOCBUILDER_DAEMON_URL"
+	templateAPITokenEnv = "DOCBUILDER_TEMPLATE_API_TOKEN"
+)
+
+// TemplateSourceFlags holds the template source flag values shared by template commands.
+type TemplateSourceFlags struct {
+	BaseURL      string
+	DaemonURL    string
+	TemplatesDir string
+	TemplatesGit string
+	TemplatesRef string
+}
 
 // ResolveTemplateBaseURL resolves the template base URL based on flags, env, and config.
 func ResolveTemplateBaseURL(flagBaseURL string, cfg *config.Config) (string, error) {
@@ -22,3 +39,98 @@ func ResolveTemplateBaseURL(flagBaseURL string, cfg *config.Config) (string, err
 	}
 	return "", fmt.Errorf("template base URL is required (set --base-url, %s, or hugo.base_url)", templateBaseURLEnv)
 }
+
+// ResolveTemplateSource selects the template source based on flags, env, and config.
+//
+// Precedence: --templates-dir, --templates-git, then the daemon template API when
+// --daemon-url or DOCBUILDER_DAEMON_URL is set (falling back to HTML discovery from the
+// resolved base URL), then --base-url, DOCBUILDER_TEMPLATES_DIR, DOCBUILDER_TEMPLATE_BASE_URL,
+// templates.dir, templates.repository, hugo.base_url.
+// The returned cleanup function removes any temporary clone and must always be called.
+func ResolveTemplateSource(flags TemplateSourceFlags, cfg *config.Config) (templating.Source, func(), error) {
+	noop := func() {}
+	switch {
+	case flags.TemplatesDir != "":
+		return templating.NewDirSource(flags.TemplatesDir), noop, nil
+	case flags.TemplatesGit != "":
+		return cloneTemplateSource(config.Repository{URL: flags.TemplatesGit, Branch: flags.TemplatesRef})
+	}
+	daemonURL := flags.DaemonURL
+	if daemonURL == "" {
+		daemonURL = os.Getenv(templateDaemonEnv)
+	}
+	if daemonURL != "" {
+		source := &templating.APISource{
+			DaemonURL: daemonURL,
+			Token:     resolveTemplateAPIToken(cfg),
+			Client:    templating.NewTemplateHTTPClient(),
+		}
+		if baseURL, err := ResolveTemplateBaseURL(flags.BaseURL, cfg); err == nil {
+			source.Fallback = &templating.HTTPSource{BaseURL: baseURL, Client: source.Client}
+		}
+		return source, noop, nil
+	}
+	switch {
+	case flags.BaseURL != "":
+		return &templating.HTTPSource{BaseURL: flags.BaseURL, Client: templating.NewTemplateHTTPClient()}, noop, nil
+	}
+	if env := os.Getenv(templatesDirEnv); env != "" {
+		return templating.NewDirSource(env), noop, nil
+	}
+	if env := os.Getenv(templateBaseURLEnv); env != "" {
+		return &templating.HTTPSource{BaseURL: env, Client: templating.NewTemplateHTTPClient()}, noop, nil
+	}
+	if cfg != nil && cfg.Templates != nil {
+		if cfg.Templates.Dir != "" {
+			return templating.NewDirSource(cfg.Templates.Dir), noop, nil
+		}
+		if cfg.Templates.Repository != nil {
+			return cloneTemplateSource(*cfg.Templates.Repository)
+		}
+	}
+	if cfg != nil && cfg.Hugo.BaseURL != "" {
+		return &templating.HTTPSource{BaseURL: cfg.Hugo.BaseURL, Client: templating.NewTemplateHTTPClient()}, noop, nil
+	}
+	return nil, noop, fmt.Errorf("template source is required (set --templates-dir, --templates-git, --base-url, %s, %s, templates, or hugo.base_url)", templatesDirEnv, templateBaseURLEnv)
+}
+
+// resolveTemplateAPIToken returns the template API token from env or daemon.template_api.token.
+func resolveTemplateAPIToken(cfg *config.Config) string {
+	if env := os.Getenv(templateAPITokenEnv); env != "" {
+		return env
+	}
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.TemplateAPI != nil {
+		return cfg.Daemon.TemplateAPI.Token
+	}
+	return ""
+}
+
+// cloneTemplateSource shallow-clones a template repository into a temporary directory
+// and returns a directory source over it (or over repo.Paths within it).
+func cloneTemplateSource(repo config.Repository) (templating.Source, func(), error) {
+	noop := func() {}
+	workspace, err := os.MkdirTemp("", "docbuilder-templates-")
+	if err != nil {
+		return nil, noop, fmt.Errorf("create template workspace: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(workspace) }
+
+	if repo.Name == "" {
+		repo.Name = "templates"
+	}
+	client := git.NewClient(workspace).WithBuildConfig(&config.BuildConfig{ShallowDepth: 1})
+	repoPath, err := client.CloneRepo(repo)
+	if err != nil {
+		cleanup()
+		return nil, noop, fmt.Errorf("clone templates from %s: %w", repo.URL, err)
+	}
+
+	dirs := []string{repoPath}
+	if len(repo.Paths) > 0 {
+		dirs = dirs[:0]
+		for _, p := range repo.Paths {
+			dirs = append(dirs, filepath.Join(repoPath, filepath.Clean("/"+p)))
+		}
+	}
+	return templating.NewDirSource(dirs...), cleanup, nil
+}
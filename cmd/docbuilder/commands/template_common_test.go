@@ -1,11 +1,18 @@
 package commands
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/require"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
 )
 
 func TestResolveTemplateBaseURL(t *testing.T) {
@@ -43,3 +50,93 @@ func TestResolveTemplateBaseURL(t *testing.T) {
 		require.Empty(t, url)
 	})
 }
+
+func TestResolveTemplateSource(t *testing.T) {
+	t.Setenv("DOCBUILDER_TEMPLATE_BASE_URL", "")
+	t.Setenv("DOCBUILDER_TEMPLATES_DIR", "")
+	t.Setenv("DOCBUILDER_DAEMON_URL", "")
+	t.Setenv("DOCBUILDER_TEMPLATE_API_TOKEN", "")
+
+	t.Run("dir flag wins over base URL", func(t *testing.T) {
+		source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{TemplatesDir: "tpl", BaseURL: "https://flag.example.com"}, nil)
+		defer cleanup()
+		require.NoError(t, err)
+		require.IsType(t, &templating.DirSource{}, source)
+	})
+
+	t.Run("env dir wins over config", func(t *testing.T) {
+		t.Setenv("DOCBUILDER_TEMPLATES_DIR", "env-tpl")
+		source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{}, &config.Config{Hugo: config.HugoConfig{BaseURL: "https://docs.example.com"}})
+		defer cleanup()
+		require.NoError(t, err)
+		require.Equal(t, []string{"env-tpl"}, source.(*templating.DirSource).Dirs)
+	})
+
+	t.Run("config dir wins over hugo base URL", func(t *testing.T) {
+		cfg := &config.Config{
+			Hugo:      config.HugoConfig{BaseURL: "https://docs.example.com"},
+			Templates: &config.TemplatesConfig{Dir: "cfg-tpl"},
+		}
+		source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{}, cfg)
+		defer cleanup()
+		require.NoError(t, err)
+		require.Equal(t, []string{"cfg-tpl"}, source.(*templating.DirSource).Dirs)
+	})
+
+	t.Run("hugo base URL fallback", func(t *testing.T) {
+		source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{}, &config.Config{Hugo: config.HugoConfig{BaseURL: "https://docs.example.com"}})
+		defer cleanup()
+		require.NoError(t, err)
+		require.Equal(t, "https://docs.example.com", source.(*templating.HTTPSource).BaseURL)
+	})
+
+	t.Run("daemon API preferred with HTML fallback", func(t *testing.T) {
+		cfg := &config.Config{
+			Hugo:   config.HugoConfig{BaseURL: "https://docs.example.com"},
+			Daemon: &config.DaemonConfig{TemplateAPI: &config.TemplateAPIConfig{Enabled: true, Token: "cfg-token"}},
+		}
+		source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{DaemonURL: "http://daemon:8081"}, cfg)
+		defer cleanup()
+		require.NoError(t, err)
+		api := source.(*templating.APISource)
+		require.Equal(t, "cfg-token", api.Token)
+		require.Equal(t, "https://docs.example.com", api.Fallback.(*templating.HTTPSource).BaseURL)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		_, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{}, &config.Config{})
+		defer cleanup()
+		require.Error(t, err)
+	})
+}
+
+func TestResolveTemplateSource_Git(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "templates-repo")
+	repo, err := gogit.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "templates"), 0o750))
+	content := "---\nparams:\n  docbuilder:\n    template:\n      type: note\n      name: Note\n      output_path: notes/{{ .Slug }}.md\n---\n\n```markdown\n# {{ .Slug }}\n```\n"
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "templates", "note.template.md"), []byte(content), 0o600))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("templates/note.template.md")
+	require.NoError(t, err)
+	sig := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	_, err = wt.Commit("add template", &gogit.CommitOptions{Author: sig, Committer: sig})
+	require.NoError(t, err)
+
+	source, cleanup, err := ResolveTemplateSource(TemplateSourceFlags{TemplatesGit: repoPath}, nil)
+	require.NoError(t, err)
+
+	links, err := source.Discover(context.Background())
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	page, err := source.Fetch(context.Background(), links[0])
+	require.NoError(t, err)
+	require.Equal(t, "# {{ .Slug }}", page.Body)
+
+	clonePath := filepath.Dir(filepath.Dir(links[0].URL))
+	cleanup()
+	_, statErr := os.Stat(clonePath)
+	require.True(t, os.IsNotExist(statErr), "expected temporary clone to be removed")
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/apitoken"
+)
+
+func TestWriteIssuedToken(t *testing.T) {
+	key := []byte(strings.Repeat("k", apitoken.MinKeyLength))
+	token, claims, err := apitoken.Issue(key, "ci", []string{apitoken.RepositoryScope("handbook")}, time.Hour, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var text strings.Builder
+	if err := writeIssuedToken(&text, token, claims, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if text.String() != token+"\n" {
+		t.Fatalf("text output should be the token alone, got %q", text.String())
+	}
+
+	var out strings.Builder
+	if err := writeIssuedToken(&out, token, claims, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Token  string   `json:"token"`
+		ID     string   `json:"id"`
+		Scopes []string `json:"scopes"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Token != token || doc.ID != claims.ID || len(doc.Scopes) != 1 || doc.Scopes[0] != "build:trigger:handbook" {
+		t.Fatalf("unexpected json output %+v", doc)
+	}
+	if _, err := apitoken.Verify(key, doc.Token, time.Now()); err != nil {
+		t.Fatalf("issued token does not verify: %v", err)
+	}
+}
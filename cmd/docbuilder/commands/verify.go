@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/integrity"
+)
+
+// VerifyCmd implements the 'verify' command.
+type VerifyCmd struct {
+	Target    string        `arg:"" optional:"" help:"Site directory or http(s) URL of the deployed site (default: <output directory>/public)"`
+	PublicKey string        `name:"public-key" help:"Public key file; verifies the signature of SHA256SUMS"`
+	Tool      string        `name:"tool" help:"Signing tool (default: post_process.integrity.sign.tool, else minisign)" enum:"minisign,cosign," default:""`
+	Format    string        `short:"f" default:"text" help:"Output format (text or json)" enum:"text,json"`
+	Timeout   time.Duration `name:"timeout" default:"5m" help:"Verification timeout"`
+}
+
+// verifyOutput is the JSON output of 'docbuilder verify'.
+type verifyOutput struct {
+	Target string `json:"target"`
+	OK     bool   `json:"ok"`
+	*integrity.Result
+}
+
+func (v *VerifyCmd) Run(_ *Global, root *CLI) error {
+	cfg := &config.Config{}
+	if root.Config != "" && fileExists(root.Config) {
+		loaded, err := config.Load(root.Config)
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		cfg = loaded
+	}
+	target := v.Target
+	if target == "" {
+		target = filepath.Join(ResolveOutputDir("", cfg), "public")
+	}
+
+	var sig *integrity.SignatureCheck
+	if v.PublicKey != "" {
+		sig = &integrity.SignatureCheck{Tool: config.SignTool(v.Tool), PublicKey: v.PublicKey}
+		if i := cfg.SiteIntegrity(); i != nil && i.Sign != nil {
+			if sig.Tool == "" {
+				sig.Tool = i.Sign.Tool
+			}
+			if sig.Tool == i.Sign.Tool {
+				sig.Command = i.Sign.Command
+			}
+		}
+		if sig.Tool == "" {
+			sig.Tool = config.SignMinisign
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), v.Timeout)
+	defer cancel()
+	res, err := integrity.Verify(ctx, http.DefaultClient, target, sig)
+	if err != nil {
+		return errors.NewError(errors.CategoryBuild, "site verification failed").
+			WithCode(errors.CodeBuildIntegrity).
+			WithContext("target", target).
+			WithCause(err).
+			Build()
+	}
+	if err := writeVerify(os.Stdout, target, res, v.Format); err != nil {
+		return err
+	}
+	if !res.OK() {
+		return errors.NewError(errors.CategoryBuild, "site does not match its checksum file").
+			WithCode(errors.CodeBuildIntegrity).
+			WithContext("target", target).
+			WithContext("missing", len(res.Missing)).
+			WithContext("modified", len(res.Modified)).
+			WithContext("untracked", len(res.Untracked)).
+			Build()
+	}
+	return nil
+}
+
+// writeVerify renders a verification result as text or JSON.
+func writeVerify(out io.Writer, target string, res *integrity.Result, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(verifyOutput{Target: target, OK: res.OK(), Result: res})
+	}
+	if res.Signature != "" {
+		_, _ = fmt.Fprintf(out, "Signature %s: ok\n", res.Signature)
+	}
+	for _, group := range []struct {
+		label string
+		files []string
+	}{{"missing", res.Missing}, {"modified", res.Modified}, {"untracked", res.Untracked}} {
+		for _, f := range group.files {
+			_, _ = fmt.Fprintf(out, "%-9s  %s\n", group.label, f)
+		}
+	}
+	status := "ok"
+	if !res.OK() {
+		status = "FAILED"
+	}
+	_, err := fmt.Fprintf(out, "%s: %d files checked, %d missing, %d modified, %d untracked: %s\n",
+		target, res.Files, len(res.Missing), len(res.Modified), len(res.Untracked), status)
+	return err
+}
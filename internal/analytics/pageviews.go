@@ -0,0 +1,122 @@
+// Package analytics provides privacy-preserving page view counters for the docs server.
+//
+// Only the request path is recorded. No cookies, referrers, client addresses or user
+// agents are inspected or stored, and counters live in memory only.
+package analytics
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherPath aggregates views of paths beyond the tracking limit.
+const OtherPath = "(other)"
+
+// PageCount is the number of views of a single page.
+type PageCount struct {
+	Path  string `json:"path"`
+	Views int64  `json:"views"`
+}
+
+// PageViews counts views per normalized page path, bounded to a maximum number of paths.
+type PageViews struct {
+	mu       sync.RWMutex
+	maxPaths int
+	counts   map[string]int64
+	other    int64
+	total    int64
+	since    time.Time
+}
+
+// NewPageViews creates a counter tracking at most maxPaths distinct paths.
+func NewPageViews(maxPaths int) *PageViews {
+	return &PageViews{
+		maxPaths: maxPaths,
+		counts:   make(map[string]int64),
+		since:    time.Now().UTC(),
+	}
+}
+
+// Record counts a view of the given URL path.
+func (p *PageViews) Record(urlPath string) {
+	key := NormalizePath(urlPath)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total++
+	if _, ok := p.counts[key]; !ok && len(p.counts) >= p.maxPaths {
+		p.other++
+		return
+	}
+	p.counts[key]++
+}
+
+// Top returns the n most viewed pages (all pages when n <= 0), most viewed first.
+func (p *PageViews) Top(n int) []PageCount {
+	p.mu.RLock()
+	out := make([]PageCount, 0, len(p.counts))
+	for k, v := range p.counts {
+		out = append(out, PageCount{Path: k, Views: v})
+	}
+	p.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Views != out[j].Views {
+			return out[i].Views > out[j].Views
+		}
+		return out[i].Path < out[j].Path
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Totals returns the total number of views, views not attributed to a tracked path,
+// and the time counting started.
+func (p *PageViews) Totals() (total, other int64, since time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.total, p.other, p.since
+}
+
+// NormalizePath maps equivalent URLs of a page ("/a/", "/a", "/a/index.html") to one key.
+func NormalizePath(urlPath string) string {
+	p := path.Clean("/" + urlPath)
+	p = strings.TrimSuffix(p, "/index.html")
+	if p == "" || p == "/" {
+		return "/"
+	}
+	if path.Ext(p) == "" {
+		p += "/"
+	}
+	return p
+}
+
+var pageViewsDesc = prom.NewDesc(
+	"docbuilder_page_views_total",
+	"Page views served by the docs server, by page path",
+	[]string{"path"}, nil,
+)
+
+// Collector exports page view counters as Prometheus metrics.
+type Collector struct{ views *PageViews }
+
+// NewCollector returns a Prometheus collector for the given counters.
+func NewCollector(views *PageViews) *Collector { return &Collector{views: views} }
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) { ch <- pageViewsDesc }
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	for _, pc := range c.views.Top(0) {
+		ch <- prom.MustNewConstMetric(pageViewsDesc, prom.CounterValue, float64(pc.Views), pc.Path)
+	}
+	if _, other, _ := c.views.Totals(); other > 0 {
+		ch <- prom.MustNewConstMetric(pageViewsDesc, prom.CounterValue, float64(other), OtherPath)
+	}
+}
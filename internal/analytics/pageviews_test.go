@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPageViews_TopAndLimit(t *testing.T) {
+	pv := NewPageViews(2)
+	for _, p := range []string{"/guide/", "/guide", "/guide/index.html", "/api/", "/api/", "/extra/"} {
+		pv.Record(p)
+	}
+
+	top := pv.Top(0)
+	if len(top) != 2 || top[0] != (PageCount{Path: "/guide/", Views: 3}) || top[1] != (PageCount{Path: "/api/", Views: 2}) {
+		t.Fatalf("unexpected top pages: %+v", top)
+	}
+	if got := pv.Top(1); len(got) != 1 || got[0].Path != "/guide/" {
+		t.Fatalf("unexpected limited top pages: %+v", got)
+	}
+	total, other, _ := pv.Totals()
+	if total != 6 || other != 1 {
+		t.Fatalf("expected total=6 other=1, got total=%d other=%d", total, other)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"":                 "/",
+		"/":                "/",
+		"/index.html":      "/",
+		"/a/b":             "/a/b/",
+		"/a/b/index.html":  "/a/b/",
+		"/a/../b/page.pdf": "/b/page.pdf",
+	}
+	for in, want := range tests {
+		if got := NormalizePath(in); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCollector(t *testing.T) {
+	pv := NewPageViews(1)
+	pv.Record("/a/")
+	pv.Record("/b/")
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(NewCollector(pv))
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	if len(families) != 1 || families[0].GetName() != "docbuilder_page_views_total" {
+		t.Fatalf("unexpected metric families: %v", families)
+	}
+	got := map[string]float64{}
+	for _, m := range families[0].GetMetric() {
+		got[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+	}
+	if len(got) != 2 || got["/a/"] != 1 || got[OtherPath] != 1 {
+		t.Fatalf("unexpected page view counters: %v", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Package apitoken issues and verifies scoped, expiring tokens for the daemon admin API.
+//
+// Tokens are JSON Web Tokens signed with HMAC-SHA256 (HS256) using
+// daemon.http.token_signing_key. They carry a list of scopes naming the operations
+// they allow, so CI systems can trigger builds without the full admin token.
+package apitoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Scopes understood by the admin API.
+const (
+	// ScopeBuildTrigger allows triggering any build.
+	ScopeBuildTrigger = "build:trigger"
+	// ScopeDiscoveryTrigger allows triggering repository discovery.
+	ScopeDiscoveryTrigger = "discovery:trigger"
+	// ScopeBuildStatus allows reading the build queue status.
+	ScopeBuildStatus = "build:status"
+	// ScopeLint allows linting documentation (POST /api/lint).
+	ScopeLint = "lint"
+)
+
+// repositoryScopePrefix prefixes scopes limited to one repository: "build:trigger:<name>"
+// allows builds scoped to that repository only.
+const repositoryScopePrefix = ScopeBuildTrigger + ":"
+
+// MinKeyLength is the minimum signing key length in bytes.
+const MinKeyLength = 32
+
+var (
+	// ErrInvalid reports a malformed token or a bad signature.
+	ErrInvalid = errors.New("invalid token")
+	// ErrExpired reports a token past its expiry time.
+	ErrExpired = errors.New("token expired")
+)
+
+// RepositoryScope returns the scope allowing builds scoped to repository.
+func RepositoryScope(repository string) string { return repositoryScopePrefix + repository }
+
+// ValidScope reports whether scope is one the admin API understands.
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeBuildTrigger, ScopeDiscoveryTrigger, ScopeBuildStatus, ScopeLint:
+		return true
+	}
+	repo, ok := strings.CutPrefix(scope, repositoryScopePrefix)
+	return ok && strings.TrimSpace(repo) != ""
+}
+
+// Claims is the payload of a token.
+type Claims struct {
+	Subject   string   `json:"sub,omitempty"`
+	Scopes    []string `json:"scopes"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+	ID        string   `json:"jti"`
+}
+
+// Allows reports whether the claims grant scope.
+func (c *Claims) Allows(scope string) bool { return slices.Contains(c.Scopes, scope) }
+
+// AllowsBuild reports whether the claims allow a build limited to repositories. An
+// empty list is a full build, which only ScopeBuildTrigger allows.
+func (c *Claims) AllowsBuild(repositories []string) bool {
+	if c.Allows(ScopeBuildTrigger) {
+		return true
+	}
+	if len(repositories) == 0 {
+		return false
+	}
+	for _, repo := range repositories {
+		if !c.Allows(RepositoryScope(repo)) {
+			return false
+		}
+	}
+	return true
+}
+
+// header is the fixed JOSE header of issued tokens.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Issue signs a token for subject granting scopes until now+ttl.
+func Issue(key []byte, subject string, scopes []string, ttl time.Duration, now time.Time) (string, *Claims, error) {
+	if len(key) < MinKeyLength {
+		return "", nil, fmt.Errorf("signing key must be at least %d bytes", MinKeyLength)
+	}
+	if ttl <= 0 {
+		return "", nil, errors.New("token lifetime must be positive")
+	}
+	if len(scopes) == 0 {
+		return "", nil, errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !ValidScope(scope) {
+			return "", nil, fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("generate token id: %w", err)
+	}
+	claims := &Claims{
+		Subject:   subject,
+		Scopes:    scopes,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		ID:        hex.EncodeToString(id),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, fmt.Errorf("encode token claims: %w", err)
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + sign(key, signingInput), claims, nil
+}
+
+// Verify checks the signature and expiry of token and returns its claims.
+func Verify(key []byte, token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return nil, ErrInvalid
+	}
+	signingInput := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(sign(key, signingInput))) {
+		return nil, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return nil, ErrInvalid
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpired
+	}
+	return &claims, nil
+}
+
+// LooksLikeToken reports whether value has the shape of a signed token.
+func LooksLikeToken(value string) bool { return strings.Count(value, ".") == 2 }
+
+func sign(key []byte, signingInput string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
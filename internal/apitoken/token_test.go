@@ -0,0 +1,81 @@
+package apitoken
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte(strings.Repeat("k", MinKeyLength))
+
+func TestIssueVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	token, issued, err := Issue(testKey, "ci", []string{RepositoryScope("handbook")}, time.Hour, now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	claims, err := Verify(testKey, token, now.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "ci" || claims.ID != issued.ID || claims.ExpiresAt != now.Add(time.Hour).Unix() {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	if _, err := Verify(testKey, token, now.Add(time.Hour)); !errors.Is(err, ErrExpired) {
+		t.Fatalf("expected ErrExpired, got %v", err)
+	}
+	if _, err := Verify([]byte(strings.Repeat("x", MinKeyLength)), token, now); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for another key, got %v", err)
+	}
+	parts := strings.Split(token, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	payload = []byte(strings.Replace(string(payload), "build:trigger:handbook", "build:trigger", 1))
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	if _, err := Verify(testKey, forged, now); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a modified payload, got %v", err)
+	}
+}
+
+func TestIssue_Rejects(t *testing.T) {
+	now := time.Now()
+	for name, tc := range map[string]struct {
+		key    []byte
+		scopes []string
+		ttl    time.Duration
+	}{
+		"short key":     {[]byte("short"), []string{ScopeBuildTrigger}, time.Hour},
+		"no scopes":     {testKey, nil, time.Hour},
+		"unknown scope": {testKey, []string{"admin"}, time.Hour},
+		"empty repo":    {testKey, []string{"build:trigger:"}, time.Hour},
+		"no lifetime":   {testKey, []string{ScopeBuildTrigger}, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := Issue(tc.key, "ci", tc.scopes, tc.ttl, now); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestClaims_AllowsBuild(t *testing.T) {
+	repoOnly := &Claims{Scopes: []string{RepositoryScope("a"), RepositoryScope("b")}}
+	for name, tc := range map[string]struct {
+		claims *Claims
+		repos  []string
+		want   bool
+	}{
+		"full build with full scope":   {&Claims{Scopes: []string{ScopeBuildTrigger}}, nil, true},
+		"full build with repo scope":   {repoOnly, nil, false},
+		"scoped build of allowed repo": {repoOnly, []string{"a", "b"}, true},
+		"scoped build of other repo":   {repoOnly, []string{"a", "c"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.claims.AllowsBuild(tc.repos); got != tc.want {
+				t.Fatalf("AllowsBuild(%v) = %v, want %v", tc.repos, got, tc.want)
+			}
+		})
+	}
+}
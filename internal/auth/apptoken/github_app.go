@@ -0,0 +1,122 @@
+package apptoken
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// appJWTLifetime is the lifetime of the app JWT; GitHub allows at most ten minutes.
+const appJWTLifetime = 9 * time.Minute
+
+// NewGitHubApp returns a source of installation access tokens for a GitHub App.
+// Tokens are requested from POST <apiURL>/app/installations/<id>/access_tokens with a
+// JWT signed by the app's private key, and last one hour.
+func NewGitHubApp(apiURL string, appID, installationID int64, key *rsa.PrivateKey, client *http.Client) Source {
+	endpoint := strings.TrimSuffix(apiURL, "/") + "/app/installations/" + strconv.FormatInt(installationID, 10) + "/access_tokens"
+	src := newCachedSource(nil)
+	src.fetch = func(ctx context.Context) (string, time.Time, error) {
+		jwt, err := appJWT(appID, key, src.now())
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("create installation token request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+jwt)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		req.Header.Set("User-Agent", "DocBuilder/1.0")
+
+		var body struct {
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := doTokenRequest(client, req, http.StatusCreated, &body); err != nil {
+			return "", time.Time{}, fmt.Errorf("GitHub App installation token: %w", err)
+		}
+		if body.Token == "" {
+			return "", time.Time{}, errors.New("GitHub App installation token: empty token in response")
+		}
+		return body.Token, body.ExpiresAt, nil
+	}
+	return src
+}
+
+// appJWT returns the RS256 JWT authenticating as the app. iat is backdated a minute
+// to allow for clock drift.
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign GitHub App JWT: %w", err)
+	}
+	return signingInput + "." + enc.EncodeToString(sig), nil
+}
+
+// loadPrivateKey reads a PEM RSA private key in PKCS#1 (as GitHub issues them) or PKCS#8 form.
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from the configuration
+	if err != nil {
+		return nil, fmt.Errorf("read GitHub App private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("GitHub App private key %s: no PEM block", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub App private key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("GitHub App private key %s: not an RSA key", path)
+	}
+	return key, nil
+}
+
+// doTokenRequest sends req and decodes a JSON response with status want into out.
+func doTokenRequest(client *http.Client, req *http.Request, want int, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != want {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
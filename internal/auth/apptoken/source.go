@@ -0,0 +1,140 @@
+// Package apptoken obtains short-lived access tokens for GitHub App installations and
+// OAuth client credentials, renewing them before they expire.
+//
+// Sources are shared per credential, so forge API clients and git operations using the
+// same app installation or OAuth client reuse one token.
+package apptoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// renewBefore is how long before expiry a cached token is replaced.
+const renewBefore = 5 * time.Minute
+
+// defaultGitHubAPIURL is used for github_app auth when no forge API URL is known.
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// Source returns a valid access token, fetching a new one when needed.
+type Source interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// fetchFunc requests a new token and reports when it expires.
+type fetchFunc func(ctx context.Context) (string, time.Time, error)
+
+// cachedSource caches a fetched token until shortly before it expires.
+type cachedSource struct {
+	fetch fetchFunc
+	now   func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newCachedSource(fetch fetchFunc) *cachedSource {
+	return &cachedSource{fetch: fetch, now: time.Now}
+}
+
+// Token returns the cached token, renewing it within renewBefore of expiry.
+func (s *cachedSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Before(s.expiry.Add(-renewBefore)) {
+		return s.token, nil
+	}
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.expiry = token, expiry
+	return token, nil
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Source{}
+)
+
+// For returns the shared token source for auth. endpoint is the GitHub API URL for
+// github_app auth or the token URL for oauth auth; when empty, auth.TokenURL or the
+// public GitHub API is used. The first caller for a credential fixes its endpoint.
+func For(auth *config.AuthConfig, endpoint string) (Source, error) {
+	key, err := credentialKey(auth)
+	if err != nil {
+		return nil, err
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if src, ok := registry[key]; ok {
+		return src, nil
+	}
+
+	var src Source
+	switch auth.Type {
+	case config.AuthTypeGitHubApp:
+		if endpoint == "" {
+			endpoint = defaultGitHubAPIURL
+		}
+		privateKey, err := loadPrivateKey(auth.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		src = NewGitHubApp(endpoint, auth.AppID, auth.InstallationID, privateKey, newHTTPClient())
+	case config.AuthTypeOAuth:
+		if auth.TokenURL != "" {
+			endpoint = auth.TokenURL
+		}
+		if endpoint == "" {
+			return nil, errors.New("oauth auth requires token_url")
+		}
+		src = NewClientCredentials(endpoint, auth.ClientID, auth.ClientSecret, auth.Scopes, newHTTPClient())
+	}
+	registry[key] = src
+	return src, nil
+}
+
+// GitUsername returns the HTTPS username that goes with tokens of authType.
+func GitUsername(authType config.AuthType) string {
+	if authType == config.AuthTypeGitHubApp {
+		return "x-access-token"
+	}
+	return "oauth2"
+}
+
+// credentialKey identifies the credential behind auth.
+func credentialKey(auth *config.AuthConfig) (string, error) {
+	if auth == nil {
+		return "", errors.New("no auth configured")
+	}
+	switch auth.Type {
+	case config.AuthTypeGitHubApp:
+		return "github_app|" + strconv.FormatInt(auth.AppID, 10) + "|" + strconv.FormatInt(auth.InstallationID, 10), nil
+	case config.AuthTypeOAuth:
+		return "oauth|" + auth.ClientID + "|" + auth.TokenURL + "|" + strings.Join(auth.Scopes, " "), nil
+	default:
+		return "", fmt.Errorf("auth type %q has no token source", auth.Type)
+	}
+}
+
+// newHTTPClient returns the client used for token requests.
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy:       http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package apptoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGitHubApp_IssuesAndRenewsInstallationTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		jwt, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if err := verifyAppJWT(jwt, &key.PublicKey, "7"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		issued++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      fmt.Sprintf("ghs_%d", issued),
+			"expires_at": time.Now().Add(time.Hour).UTC(),
+		})
+	}))
+	defer srv.Close()
+
+	src := NewGitHubApp(srv.URL+"/api/v3/", 7, 42, key, srv.Client()).(*cachedSource)
+	for range 2 {
+		token, err := src.Token(t.Context())
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+		if token != "ghs_1" {
+			t.Fatalf("expected the cached token, got %q", token)
+		}
+	}
+
+	// Close to expiry, the next call renews the token.
+	src.now = func() time.Time { return time.Now().Add(56 * time.Minute) }
+	token, err := src.Token(t.Context())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token != "ghs_2" {
+		t.Fatalf("expected a renewed token, got %q", token)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "client" || pass != "s3cret" {
+			http.Error(w, "bad client", http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "read_api read_repository" {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "gl-token", "token_type": "Bearer", "expires_in": 7200})
+	}))
+	defer srv.Close()
+
+	src := NewClientCredentials(srv.URL+"/oauth/token", "client", "s3cret", []string{"read_api", "read_repository"}, srv.Client()).(*cachedSource)
+	token, err := src.Token(t.Context())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token != "gl-token" || time.Until(src.expiry) < 119*time.Minute {
+		t.Fatalf("unexpected token %q expiring %s", token, src.expiry)
+	}
+
+	bad := NewClientCredentials(srv.URL+"/oauth/token", "client", "wrong", nil, srv.Client())
+	if _, err := bad.Token(t.Context()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected a 401 error, got %v", err)
+	}
+}
+
+func TestFor_SharesSourcesPerCredential(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, pemData, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	auth := &config.AuthConfig{Type: config.AuthTypeGitHubApp, AppID: 991, InstallationID: 1, PrivateKeyPath: keyFile}
+	a, err := For(auth, "https://ghe.example.com/api/v3")
+	if err != nil {
+		t.Fatalf("For: %v", err)
+	}
+	b, err := For(&config.AuthConfig{Type: config.AuthTypeGitHubApp, AppID: 991, InstallationID: 1, PrivateKeyPath: keyFile}, "")
+	if err != nil {
+		t.Fatalf("For: %v", err)
+	}
+	if a != b {
+		t.Fatal("expected one shared source per app installation")
+	}
+
+	if _, err := For(&config.AuthConfig{Type: config.AuthTypeToken, Token: "t"}, ""); err == nil {
+		t.Fatal("token auth has no token source")
+	}
+	if _, err := For(&config.AuthConfig{Type: config.AuthTypeOAuth, ClientID: "c", ClientSecret: "s"}, ""); err == nil {
+		t.Fatal("oauth without a token URL must fail")
+	}
+}
+
+// verifyAppJWT checks the RS256 signature and issuer of a GitHub App JWT.
+func verifyAppJWT(jwt string, pub *rsa.PublicKey, issuer string) error {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed JWT")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		return err
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	var claims struct {
+		Iss string `json:"iss"`
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return err
+	}
+	if claims.Iss != issuer || claims.Exp-claims.Iat > 600 || time.Now().Unix() >= claims.Exp {
+		return fmt.Errorf("unexpected claims %+v", claims)
+	}
+	return nil
+}
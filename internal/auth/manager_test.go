@@ -1,10 +1,20 @@
 package auth
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 )
@@ -86,6 +96,17 @@ func TestManager_CreateAuth(t *testing.T) {
 			expectError: true,
 			description: "basic auth without username should fail",
 		},
+		{
+			name: "github app - missing private key",
+			authConfig: &config.AuthConfig{
+				Type:           config.AuthTypeGitHubApp,
+				AppID:          1,
+				InstallationID: 2,
+			},
+			expectNil:   true,
+			expectError: true,
+			description: "github_app auth without a private key should fail",
+		},
 		{
 			name: "unsupported auth type",
 			authConfig: &config.AuthConfig{
@@ -202,3 +223,143 @@ func TestConvenienceFunctions(t *testing.T) {
 		t.Errorf("CreateAuth() convenience function returned nil")
 	}
 }
+
+func TestManager_CreateAuth_OAuthClientCredentials(t *testing.T) {
+	srv := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"gl-oauth-token","expires_in":7200}`))
+	}))
+	defer srv.Close()
+
+	auth, err := NewManager().CreateAuth(&config.AuthConfig{
+		Type:         config.AuthTypeOAuth,
+		ClientID:     "client-for-manager-test",
+		ClientSecret: "secret",
+		TokenURL:     srv.URL + "/oauth/token",
+	})
+	if err != nil {
+		t.Fatalf("CreateAuth() unexpected error: %v", err)
+	}
+	basic, ok := auth.(*http.BasicAuth)
+	if !ok {
+		t.Fatalf("expected *http.BasicAuth, got %T", auth)
+	}
+	if basic.Username != "oauth2" || basic.Password != "gl-oauth-token" {
+		t.Fatalf("unexpected credentials %q/%q", basic.Username, basic.Password)
+	}
+}
+
+func newTestSSHKey(t *testing.T) (ed25519.PrivateKey, ssh.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, sshPub
+}
+
+func TestManager_CreateAuth_SSHDeployKeyFromEnv(t *testing.T) {
+	priv, _ := newTestSSHKey(t)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCBUILDER_TEST_DEPLOY_KEY", string(pem.EncodeToMemory(block)))
+
+	_, hostKey := newTestSSHKey(t)
+	_, otherKey := newTestSSHKey(t)
+	hostLine := "git.example.com " + string(ssh.MarshalAuthorizedKey(hostKey))
+
+	auth, err := NewManager().CreateAuth(&config.AuthConfig{
+		Type:     config.AuthTypeSSH,
+		KeyEnv:   "DOCBUILDER_TEST_DEPLOY_KEY",
+		HostKeys: []string{hostLine},
+	})
+	if err != nil {
+		t.Fatalf("CreateAuth() unexpected error: %v", err)
+	}
+	keys, ok := auth.(*gitssh.PublicKeys)
+	if !ok {
+		t.Fatalf("expected *ssh.PublicKeys, got %T", auth)
+	}
+	if keys.User != "git" {
+		t.Errorf("expected user git, got %q", keys.User)
+	}
+
+	cfg, err := keys.ClientConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+	if err := cfg.HostKeyCallback("git.example.com:22", addr, hostKey); err != nil {
+		t.Errorf("pinned host key rejected: %v", err)
+	}
+	if err := cfg.HostKeyCallback("git.example.com:22", addr, otherKey); err == nil {
+		t.Error("expected mismatched host key to be rejected")
+	}
+	if err := cfg.HostKeyCallback("other.example.com:22", addr, hostKey); err == nil {
+		t.Error("expected unknown host to be rejected")
+	}
+	if len(cfg.HostKeyAlgorithms) != 1 || cfg.HostKeyAlgorithms[0] != ssh.KeyAlgoED25519 {
+		t.Errorf("unexpected host key algorithms %v", cfg.HostKeyAlgorithms)
+	}
+}
+
+func TestManager_CreateAuth_SSHAgentSocket(t *testing.T) {
+	priv, pub := newTestSSHKey(t)
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: priv}); err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _ = agent.ServeAgent(keyring, conn) }()
+		}
+	}()
+
+	auth, err := NewManager().CreateAuth(&config.AuthConfig{
+		Type:        config.AuthTypeSSH,
+		Username:    "deploy",
+		AgentSocket: socket,
+	})
+	if err != nil {
+		t.Fatalf("CreateAuth() unexpected error: %v", err)
+	}
+	callback, ok := auth.(*gitssh.PublicKeysCallback)
+	if !ok {
+		t.Fatalf("expected *ssh.PublicKeysCallback, got %T", auth)
+	}
+	if callback.User != "deploy" {
+		t.Errorf("expected user deploy, got %q", callback.User)
+	}
+	signers, err := callback.Callback()
+	if err != nil {
+		t.Fatalf("agent signers: %v", err)
+	}
+	if len(signers) != 1 || string(signers[0].PublicKey().Marshal()) != string(pub.Marshal()) {
+		t.Fatalf("expected the agent's key, got %d signers", len(signers))
+	}
+}
+
+func TestManager_CreateAuth_SSHMissingKeyEnv(t *testing.T) {
+	_, err := NewManager().CreateAuth(&config.AuthConfig{
+		Type:   config.AuthTypeSSH,
+		KeyEnv: "DOCBUILDER_TEST_UNSET_DEPLOY_KEY",
+	})
+	if err == nil {
+		t.Fatal("expected error for empty key environment variable")
+	}
+}
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/auth/apptoken"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// appTokenTimeout bounds a token request made while creating git auth.
+const appTokenTimeout = 30 * time.Second
+
+// AppTokenProvider handles GitHub App and OAuth client credentials authentication. Each
+// git operation gets a current access token from the credential's shared token source.
+type AppTokenProvider struct {
+	authType config.AuthType
+}
+
+// NewAppTokenProvider creates a provider for authType (github_app or oauth).
+func NewAppTokenProvider(authType config.AuthType) *AppTokenProvider {
+	return &AppTokenProvider{authType: authType}
+}
+
+// Type returns the authentication type this provider handles.
+func (p *AppTokenProvider) Type() config.AuthType {
+	return p.authType
+}
+
+// CreateAuth fetches or reuses an access token and uses it as the HTTPS password.
+func (p *AppTokenProvider) CreateAuth(authCfg *config.AuthConfig) (transport.AuthMethod, error) {
+	src, err := apptoken.For(authCfg, "")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), appTokenTimeout)
+	defer cancel()
+	token, err := src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	username := authCfg.Username
+	if username == "" {
+		username = apptoken.GitUsername(authCfg.Type)
+	}
+	return &http.BasicAuth{
+		Username: username,
+		Password: token,
+	}, nil
+}
+
+// ValidateConfig validates the app or client credentials configuration.
+func (p *AppTokenProvider) ValidateConfig(authCfg *config.AuthConfig) error {
+	switch authCfg.Type {
+	case config.AuthTypeGitHubApp:
+		if authCfg.AppID <= 0 || authCfg.InstallationID <= 0 || authCfg.PrivateKeyPath == "" {
+			return errors.New("github_app authentication requires app_id, installation_id and private_key_path")
+		}
+	case config.AuthTypeOAuth:
+		if authCfg.ClientID == "" || authCfg.ClientSecret == "" {
+			return errors.New("oauth authentication requires client_id and client_secret")
+		}
+	}
+	return nil
+}
+
+// Name returns a human-readable name for this provider.
+func (p *AppTokenProvider) Name() string {
+	return "AppTokenProvider"
+}
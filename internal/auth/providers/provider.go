@@ -42,6 +42,8 @@ func NewAuthProviderRegistry() *AuthProviderRegistry {
 	registry.Register(NewSSHProvider())
 	registry.Register(NewTokenProvider())
 	registry.Register(NewBasicProvider())
+	registry.Register(NewAppTokenProvider(config.AuthTypeGitHubApp))
+	registry.Register(NewAppTokenProvider(config.AuthTypeOAuth))
 
 	return registry
 }
@@ -1,17 +1,32 @@
 package providers
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing/transport"
-	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 )
 
+// defaultSSHUser is the SSH user for git remotes when auth.username is unset.
+const defaultSSHUser = "git"
+
 // SSHProvider handles SSH key authentication.
+//
+// The key comes from key_path, from the environment variable named by key_env (so
+// deploy keys can be injected by a secret store without touching disk), or from an
+// ssh-agent listening on agent_socket. Host keys are checked against known_hosts or
+// the pinned host_keys lines, falling back to the user's known_hosts files.
 type SSHProvider struct{}
 
 // NewSSHProvider creates a new SSH authentication provider.
@@ -26,31 +41,55 @@ func (p *SSHProvider) Type() config.AuthType {
 
 // CreateAuth creates SSH authentication from the configuration.
 func (p *SSHProvider) CreateAuth(authCfg *config.AuthConfig) (transport.AuthMethod, error) {
-	keyPath := authCfg.KeyPath
-	if keyPath == "" {
-		keyPath = filepath.Join(os.Getenv("HOME"), ".ssh", "id_rsa")
+	user := authCfg.Username
+	if user == "" {
+		user = defaultSSHUser
 	}
 
-	publicKeys, err := ssh.NewPublicKeysFromFile("git", keyPath, "")
+	hostKeys, err := hostKeyCallback(authCfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load SSH key from %s: %w", keyPath, err)
+		return nil, err
+	}
+
+	if authCfg.AgentSocket != "" {
+		conn, err := net.Dial("unix", authCfg.AgentSocket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SSH agent at %s: %w", authCfg.AgentSocket, err)
+		}
+		auth := &gitssh.PublicKeysCallback{User: user, Callback: agent.NewClient(conn).Signers}
+		auth.HostKeyCallbackHelper = hostKeys
+		return auth, nil
 	}
 
-	return publicKeys, nil
+	pem, source, err := privateKey(authCfg)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := gitssh.NewPublicKeys(user, pem, authCfg.KeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SSH key from %s: %w", source, err)
+	}
+	auth.HostKeyCallbackHelper = hostKeys
+	return auth, nil
 }
 
 // ValidateConfig validates the SSH authentication configuration.
 func (p *SSHProvider) ValidateConfig(authCfg *config.AuthConfig) error {
-	keyPath := authCfg.KeyPath
-	if keyPath == "" {
-		keyPath = filepath.Join(os.Getenv("HOME"), ".ssh", "id_rsa")
+	if authCfg.AgentSocket != "" {
+		if _, err := os.Stat(authCfg.AgentSocket); err != nil {
+			return fmt.Errorf("SSH agent socket does not exist: %s", authCfg.AgentSocket)
+		}
+	} else if authCfg.KeyEnv != "" {
+		if os.Getenv(authCfg.KeyEnv) == "" {
+			return fmt.Errorf("SSH key environment variable %s is empty", authCfg.KeyEnv)
+		}
+	} else if _, err := os.Stat(keyPath(authCfg)); os.IsNotExist(err) {
+		return fmt.Errorf("SSH key file does not exist: %s", keyPath(authCfg))
 	}
 
-	// Check if the key file exists
-	if _, err := os.Stat(keyPath); os.IsNotExist(err) {
-		return fmt.Errorf("SSH key file does not exist: %s", keyPath)
+	if _, err := hostKeyCallback(authCfg); err != nil {
+		return err
 	}
-
 	return nil
 }
 
@@ -58,3 +97,118 @@ func (p *SSHProvider) ValidateConfig(authCfg *config.AuthConfig) error {
 func (p *SSHProvider) Name() string {
 	return "SSHProvider"
 }
+
+// keyPath returns the configured key file, defaulting to ~/.ssh/id_rsa.
+func keyPath(authCfg *config.AuthConfig) string {
+	if authCfg.KeyPath != "" {
+		return authCfg.KeyPath
+	}
+	return filepath.Join(os.Getenv("HOME"), ".ssh", "id_rsa")
+}
+
+// privateKey returns the PEM key material and a description of where it came from.
+func privateKey(authCfg *config.AuthConfig) ([]byte, string, error) {
+	if authCfg.KeyEnv != "" {
+		pem := os.Getenv(authCfg.KeyEnv)
+		if pem == "" {
+			return nil, "", fmt.Errorf("SSH key environment variable %s is empty", authCfg.KeyEnv)
+		}
+		return []byte(pem), "$" + authCfg.KeyEnv, nil
+	}
+	path := keyPath(authCfg)
+	pem, err := os.ReadFile(path) // #nosec G304 -- path comes from the configuration
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load SSH key from %s: %w", path, err)
+	}
+	return pem, path, nil
+}
+
+// hostKeyCallback builds host key verification from known_hosts or host_keys. An
+// empty helper leaves go-git to use the default known_hosts files.
+func hostKeyCallback(authCfg *config.AuthConfig) (gitssh.HostKeyCallbackHelper, error) {
+	switch {
+	case authCfg.KnownHosts != "":
+		db, err := gitssh.NewKnownHostsDb(authCfg.KnownHosts)
+		if err != nil {
+			return gitssh.HostKeyCallbackHelper{}, fmt.Errorf("failed to load known_hosts %s: %w", authCfg.KnownHosts, err)
+		}
+		return gitssh.HostKeyCallbackHelper{HostKeyCallback: db.HostKeyCallback()}, nil
+	case len(authCfg.HostKeys) > 0:
+		pins, err := parseHostKeys(authCfg.HostKeys)
+		if err != nil {
+			return gitssh.HostKeyCallbackHelper{}, err
+		}
+		return gitssh.HostKeyCallbackHelper{HostKeyCallback: pins.check, HostKeyAlgorithms: pins.algorithms()}, nil
+	}
+	return gitssh.HostKeyCallbackHelper{}, nil
+}
+
+// pinnedHostKey is one parsed host_keys line.
+type pinnedHostKey struct {
+	hosts []string
+	key   ssh.PublicKey
+}
+
+// pinnedHostKeys checks servers against host keys given in known_hosts line format.
+type pinnedHostKeys []pinnedHostKey
+
+// parseHostKeys parses known_hosts lines ("host[,host...] key-type base64-key"). Host
+// names must be literal; hashed entries and wildcards are not supported.
+func parseHostKeys(lines []string) (pinnedHostKeys, error) {
+	var pins pinnedHostKeys
+	for _, line := range lines {
+		_, hosts, key, _, _, err := ssh.ParseKnownHosts([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("invalid host_keys entry %q: %w", line, err)
+		}
+		for i, host := range hosts {
+			if strings.HasPrefix(host, "|") || strings.ContainsAny(host, "*?!") {
+				return nil, fmt.Errorf("invalid host_keys entry %q: hashed and wildcard hosts are not supported", line)
+			}
+			hosts[i] = knownhosts.Normalize(host)
+		}
+		pins = append(pins, pinnedHostKey{hosts: hosts, key: key})
+	}
+	return pins, nil
+}
+
+// check implements ssh.HostKeyCallback.
+func (pins pinnedHostKeys) check(hostname string, _ net.Addr, key ssh.PublicKey) error {
+	host := knownhosts.Normalize(hostname)
+	known := false
+	for _, pin := range pins {
+		for _, h := range pin.hosts {
+			if h != host {
+				continue
+			}
+			known = true
+			if bytes.Equal(pin.key.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+	}
+	if !known {
+		return fmt.Errorf("ssh: host %s is not in host_keys", hostname)
+	}
+	return errors.New("ssh: host key mismatch for " + hostname)
+}
+
+// algorithms returns the pinned key types so the server presents a key we can check.
+func (pins pinnedHostKeys) algorithms() []string {
+	var algos []string
+	seen := map[string]bool{}
+	for _, pin := range pins {
+		keyType := pin.key.Type()
+		candidates := []string{keyType}
+		if keyType == ssh.KeyAlgoRSA {
+			candidates = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+		for _, algo := range candidates {
+			if !seen[algo] {
+				seen[algo] = true
+				algos = append(algos, algo)
+			}
+		}
+	}
+	return algos
+}
@@ -0,0 +1,173 @@
+// Package bench measures the build pipeline against a synthetic corpus.
+//
+// The corpus mirrors the repositories produced by the test forge: each repository
+// gets a docs/ tree with an index page and pages spread over a few sections, with
+// cross links and an image so the link and asset transforms do real work.
+package bench
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+// sections distributes synthetic pages over a few nested directories per repository.
+var sections = []string{"", "guides", "reference", "reference/api"}
+
+// Options configures a benchmark run.
+type Options struct {
+	Repositories int    // Number of synthetic repositories
+	Files        int    // Markdown files per repository (in addition to the index page)
+	Dir          string // Working directory for the corpus and output
+	RenderMode   config.RenderMode
+}
+
+// StageTiming is the duration of one pipeline stage.
+type StageTiming struct {
+	Stage    string        `json:"stage"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Result holds the measurements of a benchmark run.
+type Result struct {
+	Repositories   int           `json:"repositories"`
+	Files          int           `json:"files"`
+	Pages          int           `json:"pages"`
+	Discovery      time.Duration `json:"discovery_ns"`
+	Generation     time.Duration `json:"generation_ns"`
+	Total          time.Duration `json:"total_ns"`
+	Stages         []StageTiming `json:"stages"`
+	Allocations    uint64        `json:"allocations"`
+	AllocatedBytes uint64        `json:"allocated_bytes"`
+	FilesPerSecond float64       `json:"files_per_second"`
+}
+
+// Run synthesizes the corpus below opts.Dir and runs discovery and site generation over it.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Repositories < 1 || opts.Files < 1 {
+		return nil, fmt.Errorf("repositories and files must be at least 1")
+	}
+	repos, repoPaths, err := Synthesize(filepath.Join(opts.Dir, "repos"), opts.Repositories, opts.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config.Config{Version: "2.0", Repositories: repos}
+	cfg.Hugo.Title = "DocBuilder Benchmark"
+	cfg.Build.RenderMode = opts.RenderMode
+	outputDir := filepath.Join(opts.Dir, "site")
+	cfg.Output.Directory = outputDir
+	cfg.Output.Clean = true
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	files, err := docs.NewDiscovery(repos, &cfg.Build).DiscoverDocs(repoPaths)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+	discovered := time.Now()
+
+	report, err := hugo.NewGenerator(cfg, outputDir).GenerateSiteWithReportContext(ctx, files)
+	if err != nil {
+		return nil, fmt.Errorf("generation: %w", err)
+	}
+	end := time.Now()
+	runtime.ReadMemStats(&after)
+
+	res := &Result{
+		Repositories:   len(repos),
+		Files:          len(files),
+		Pages:          report.RenderedPages,
+		Discovery:      discovered.Sub(start),
+		Generation:     end.Sub(discovered),
+		Total:          end.Sub(start),
+		Allocations:    after.Mallocs - before.Mallocs,
+		AllocatedBytes: after.TotalAlloc - before.TotalAlloc,
+	}
+	if secs := res.Total.Seconds(); secs > 0 {
+		res.FilesPerSecond = float64(res.Files) / secs
+	}
+	for stage, d := range report.StageDurations {
+		res.Stages = append(res.Stages, StageTiming{Stage: stage, Duration: d})
+	}
+	slices.SortFunc(res.Stages, func(a, b StageTiming) int { return cmp.Compare(b.Duration, a.Duration) })
+	return res, nil
+}
+
+// Synthesize writes repoCount repositories with filesPerRepo pages each below dir and
+// returns their configuration and the repository-name to path mapping used by discovery.
+func Synthesize(dir string, repoCount, filesPerRepo int) ([]config.Repository, map[string]string, error) {
+	repos := make([]config.Repository, 0, repoCount)
+	repoPaths := make(map[string]string, repoCount)
+	for r := range repoCount {
+		name := fmt.Sprintf("bench-repo-%d", r+1)
+		repoDir := filepath.Join(dir, name)
+		docsDir := filepath.Join(repoDir, "docs")
+
+		pages := map[string]string{
+			"index.md":        fmt.Sprintf("# %s\n\nOverview of %s. Start with the [first page](page-1.md).\n", name, name),
+			"images/arch.png": "\x89PNG\r\n\x1a\n",
+		}
+		for f := range filesPerRepo {
+			section := sections[f%len(sections)]
+			pages[filepath.Join(section, fmt.Sprintf("page-%d.md", f+1))] = syntheticPage(name, section, f+1)
+		}
+		for rel, content := range pages {
+			path := filepath.Join(docsDir, rel)
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return nil, nil, fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				return nil, nil, fmt.Errorf("write %s: %w", path, err)
+			}
+		}
+
+		repos = append(repos, config.Repository{
+			Name:   name,
+			URL:    fmt.Sprintf("https://bench.example.com/bench-org/%s.git", name),
+			Branch: "main",
+			Paths:  []string{"docs"},
+			Tags:   map[string]string{"forge_type": "github", "full_name": "bench-org/" + name},
+		})
+		repoPaths[name] = repoDir
+	}
+	return repos, repoPaths, nil
+}
+
+func syntheticPage(repo, section string, n int) string {
+	up := ""
+	if section != "" {
+		up = strings.Repeat("../", strings.Count(section, "/")+1)
+	}
+	return fmt.Sprintf(`---
+title: "Page %[1]d"
+tags: [bench, %[2]s]
+---
+
+# Page %[1]d
+
+Synthetic page %[1]d of %[2]s. See the [overview](%[3]sindex.md) and the
+[architecture diagram](%[3]simages/arch.png).
+
+## Details
+
+| Key | Value |
+|-----|-------|
+| repository | %[2]s |
+| page | %[1]d |
+
+`+"```go\nfunc page%[1]d() int { return %[1]d }\n```\n", n, repo, up)
+}
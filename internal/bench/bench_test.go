@@ -0,0 +1,31 @@
+package bench
+
+import (
+	"context"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestRun(t *testing.T) {
+	res, err := Run(context.Background(), Options{Repositories: 2, Files: 5, Dir: t.TempDir(), RenderMode: config.RenderModeNever})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Repositories != 2 {
+		t.Errorf("repositories = %d, want 2", res.Repositories)
+	}
+	// 5 pages + index + image per repository
+	if res.Files != 14 {
+		t.Errorf("files = %d, want 14", res.Files)
+	}
+	if res.Pages == 0 || res.Total <= 0 || len(res.Stages) == 0 || res.Allocations == 0 {
+		t.Errorf("incomplete result: %+v", res)
+	}
+}
+
+func TestRun_RejectsEmptyCorpus(t *testing.T) {
+	if _, err := Run(context.Background(), Options{Repositories: 0, Files: 5, Dir: t.TempDir()}); err == nil {
+		t.Fatal("expected error for zero repositories")
+	}
+}
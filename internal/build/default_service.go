@@ -84,9 +84,10 @@ func (s *DefaultBuildService) Run(ctx context.Context, req BuildRequest) (*Build
 		OutputPath: req.OutputDir,
 	}
 
-	// Add build context for observability
-	buildID := startTime.Format("20060102-150405")
-	ctx = observability.WithBuildID(ctx, buildID)
+	// Add build context for observability (keep the queue job ID when present)
+	if observability.BuildID(ctx) == "" {
+		ctx = observability.WithBuildID(ctx, startTime.Format("20060102-150405"))
+	}
 
 	// Validate request
 	if req.Config == nil {
@@ -26,6 +26,9 @@ type BuildJobMetadata struct {
 	V2Config     *config.Config      `json:"v2_config,omitempty"`
 	Repositories []config.Repository `json:"repositories,omitempty"`
 
+	// Site names the daemon site (daemon.sites) the job builds; empty for the main site.
+	Site string `json:"site,omitempty"`
+
 	// RepoSnapshot optionally pins repositories to specific commits for this build.
 	// Keys are repository URLs.
 	RepoSnapshot map[string]string `json:"repo_snapshot,omitempty"`
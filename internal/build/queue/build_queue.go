@@ -5,14 +5,17 @@ import (
 	stdErrors "errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"strconv"
 	"sync"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
 	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 	"git.home.luguber.info/inful/docbuilder/internal/metrics"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
 	"git.home.luguber.info/inful/docbuilder/internal/retry"
 )
 
@@ -239,13 +242,31 @@ func (bq *BuildQueue) worker(ctx context.Context, workerID string) {
 			return
 		case job := <-bq.jobs:
 			if job != nil {
-				bq.processJob(ctx, job, workerID)
+				bq.processJobRecovering(ctx, job, workerID)
 			}
 		}
 	}
 }
 
+// processJobRecovering runs processJob and keeps the worker alive if it panics outside
+// the builder (builder panics are already converted into build failures).
+func (bq *BuildQueue) processJobRecovering(ctx context.Context, job *BuildJob, workerID string) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := crash.Default().Recovered("build_queue", job.ID, rec, debug.Stack())
+			bq.mu.Lock()
+			_, active := bq.active[job.ID]
+			bq.mu.Unlock()
+			if active {
+				bq.markJobCompleted(job, err)
+			}
+		}
+	}()
+	bq.processJob(ctx, job, workerID)
+}
+
 func (bq *BuildQueue) processJob(ctx context.Context, job *BuildJob, workerID string) {
+	ctx = observability.WithBuildID(ctx, job.ID)
 	jobCtx, cancel := context.WithCancel(ctx)
 	job.cancel = cancel
 	defer cancel()
@@ -368,7 +389,7 @@ func (bq *BuildQueue) executeBuild(ctx context.Context, job *BuildJob) error {
 
 	for {
 		attempts++
-		report, err := bq.builder.Build(ctx, job)
+		report, err := bq.build(ctx, job)
 		if report != nil {
 			meta := EnsureTypedMeta(job)
 			meta.BuildReport = report
@@ -408,6 +429,16 @@ func (bq *BuildQueue) executeBuild(ctx context.Context, job *BuildJob) error {
 	}
 }
 
+// build runs the builder, converting a panic into a failed (non-retryable) build.
+func (bq *BuildQueue) build(ctx context.Context, job *BuildJob) (report *models.BuildReport, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			report, err = nil, crash.Default().Recovered("builder", job.ID, rec, debug.Stack())
+		}
+	}()
+	return bq.builder.Build(ctx, job)
+}
+
 func shouldStopRetrying(transient bool, totalRetries, maxRetries int) bool {
 	return !transient || totalRetries >= maxRetries
 }
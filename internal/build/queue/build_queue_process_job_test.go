@@ -3,6 +3,7 @@ package queue
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -216,3 +217,33 @@ func TestProcessJob_EventEmitterErrors(t *testing.T) {
 		t.Fatalf("expected 1 buildCompleted call, got %d", emitter.buildCompletedCalls)
 	}
 }
+
+type panickingBuilder struct{}
+
+func (panickingBuilder) Build(context.Context, *BuildJob) (*models.BuildReport, error) {
+	panic("builder exploded")
+}
+
+func TestProcessJob_BuilderPanicFailsJob(t *testing.T) {
+	emitter := &mockEventEmitter{}
+	bq := &BuildQueue{
+		eventEmitter: emitter,
+		builder:      panickingBuilder{},
+		active:       make(map[string]*BuildJob),
+		history:      make([]*BuildJob, 0),
+		historySize:  10,
+	}
+
+	job := &BuildJob{ID: "test-job-panic", Type: BuildTypeManual, Priority: PriorityNormal, Status: BuildStatusQueued}
+	bq.processJobRecovering(t.Context(), job, "worker-1")
+
+	if job.Status != BuildStatusFailed {
+		t.Fatalf("expected status %s, got %s", BuildStatusFailed, job.Status)
+	}
+	if !strings.Contains(job.Error, "panic in builder: builder exploded") {
+		t.Fatalf("unexpected job error %q", job.Error)
+	}
+	if emitter.buildFailedCalls != 1 {
+		t.Fatalf("expected 1 buildFailed call, got %d", emitter.buildFailedCalls)
+	}
+}
@@ -0,0 +1,191 @@
+// Package cdnpurge purges changed pages from CDN caches after a site is published,
+// through a generic webhook or the Cloudflare and Fastly purge APIs.
+package cdnpurge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+const (
+	defaultCloudflareAPI   = "https://api.cloudflare.com/client/v4"
+	defaultFastlyAPI       = "https://api.fastly.com"
+	defaultCloudflareBatch = 30 // files per purge_cache request
+	maxResponseBody        = 4 << 10
+)
+
+// Result is the outcome of running one hook.
+type Result struct {
+	Hook     string
+	Type     config.PurgeHookType
+	URLs     int
+	Requests int
+	DryRun   bool
+	Duration time.Duration
+	Err      error
+}
+
+// Run calls every configured hook with urls, one hook after the other. A failing hook
+// does not stop the others. Nothing is sent when there are no URLs or in a dry run; a
+// dry run logs the requests that would be made.
+func Run(ctx context.Context, client *http.Client, cfg *config.PurgeConfig, urls []string) []Result {
+	if cfg == nil || len(cfg.Hooks) == 0 || len(urls) == 0 {
+		return nil
+	}
+	results := make([]Result, 0, len(cfg.Hooks))
+	for i := range cfg.Hooks {
+		h := &cfg.Hooks[i]
+		hookCtx, cancel := context.WithTimeout(ctx, cfg.TimeoutDuration())
+		start := time.Now()
+		res := Result{Hook: h.HookName(), Type: h.Type, URLs: len(urls), DryRun: cfg.DryRun}
+		res.Requests, res.Err = run(hookCtx, client, h, urls, cfg.DryRun)
+		res.Duration = time.Since(start)
+		cancel()
+		results = append(results, res)
+	}
+	return results
+}
+
+// request is one planned purge API call.
+type request struct {
+	method string
+	url    string
+	header http.Header
+	body   []byte
+	urls   int
+}
+
+func run(ctx context.Context, client *http.Client, h *config.PurgeHook, urls []string, dryRun bool) (int, error) {
+	reqs, err := plan(h, urls)
+	if err != nil {
+		return 0, err
+	}
+	for i, r := range reqs {
+		if dryRun {
+			slog.Info("Purge dry run",
+				slog.String("hook", h.HookName()),
+				slog.String("method", r.method),
+				slog.String("url", r.url),
+				slog.Int("urls", r.urls))
+			continue
+		}
+		if err := send(ctx, client, h, r); err != nil {
+			return i, err
+		}
+	}
+	return len(reqs), nil
+}
+
+// plan returns the API calls that purge urls through hook h.
+func plan(h *config.PurgeHook, urls []string) ([]request, error) {
+	var reqs []request
+	switch h.Type {
+	case config.PurgeWebhook:
+		for _, batch := range batches(urls, h.BatchSize) {
+			body, err := json.Marshal(map[string][]string{"urls": batch})
+			if err != nil {
+				return nil, err
+			}
+			header := http.Header{"Content-Type": {"application/json"}}
+			for k, v := range h.Headers {
+				header.Set(k, v)
+			}
+			reqs = append(reqs, request{method: http.MethodPost, url: h.URL, header: header, body: body, urls: len(batch)})
+		}
+	case config.PurgeCloudflare:
+		size := h.BatchSize
+		if size == 0 {
+			size = defaultCloudflareBatch
+		}
+		endpoint := apiBase(h, defaultCloudflareAPI) + "/zones/" + url.PathEscape(h.ZoneID) + "/purge_cache"
+		for _, batch := range batches(urls, size) {
+			body, err := json.Marshal(map[string][]string{"files": batch})
+			if err != nil {
+				return nil, err
+			}
+			header := http.Header{"Content-Type": {"application/json"}, "Authorization": {"Bearer " + h.Token}}
+			reqs = append(reqs, request{method: http.MethodPost, url: endpoint, header: header, body: body, urls: len(batch)})
+		}
+	case config.PurgeFastly:
+		// Fastly purges one URL per request: POST /purge/<host and path>.
+		for _, u := range urls {
+			cached := strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+			header := http.Header{"Fastly-Key": {h.Token}, "Accept": {"application/json"}}
+			reqs = append(reqs, request{method: http.MethodPost, url: apiBase(h, defaultFastlyAPI) + "/purge/" + cached, header: header, urls: 1})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported purge hook type %q", h.Type)
+	}
+	return reqs, nil
+}
+
+func send(ctx context.Context, client *http.Client, h *config.PurgeHook, r request) error {
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, bytes.NewReader(r.body))
+	if err != nil {
+		return err
+	}
+	req.Header = r.header
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", h.HookName(), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s: %s", h.HookName(), resp.Status, strings.TrimSpace(string(body)))
+	}
+	if h.Type == config.PurgeCloudflare {
+		return cloudflareError(h, body)
+	}
+	return nil
+}
+
+// cloudflareError reports the errors of a Cloudflare API response with success=false.
+func cloudflareError(h *config.PurgeHook, body []byte) error {
+	var resp struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("%s: decode response: %w", h.HookName(), err)
+	}
+	if resp.Success {
+		return nil
+	}
+	msgs := make([]string, 0, len(resp.Errors))
+	for _, e := range resp.Errors {
+		msgs = append(msgs, e.Message)
+	}
+	return fmt.Errorf("%s: purge failed: %s", h.HookName(), strings.Join(msgs, "; "))
+}
+
+func apiBase(h *config.PurgeHook, fallback string) string {
+	if h.APIURL != "" {
+		return strings.TrimSuffix(h.APIURL, "/")
+	}
+	return fallback
+}
+
+// batches splits urls into slices of at most size entries (all in one when size is 0).
+func batches(urls []string, size int) [][]string {
+	if size <= 0 || size >= len(urls) {
+		return [][]string{urls}
+	}
+	var out [][]string
+	for start := 0; start < len(urls); start += size {
+		out = append(out, urls[start:min(start+size, len(urls))])
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package cdnpurge
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+type capturedRequest struct {
+	Path   string
+	Header http.Header
+	Body   map[string][]string
+}
+
+// recorder is a fake purge API that records requests and answers with status and body.
+func recorder(t *testing.T, status int, body string) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []capturedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		c := capturedRequest{Path: r.URL.Path, Header: r.Header}
+		if len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &c.Body))
+		}
+		mu.Lock()
+		got = append(got, c)
+		mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return got
+	}
+}
+
+var changed = []string{
+	"https://docs.example.com/a/",
+	"https://docs.example.com/b/",
+	"https://docs.example.com/c/",
+}
+
+func TestRun_Webhook(t *testing.T) {
+	srv, requests := recorder(t, http.StatusAccepted, "")
+	cfg := &config.PurgeConfig{Hooks: []config.PurgeHook{{
+		Type: config.PurgeWebhook, URL: srv.URL + "/purge", BatchSize: 2,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	}}}
+
+	results := Run(t.Context(), srv.Client(), cfg, changed)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "webhook", results[0].Hook)
+	assert.Equal(t, 3, results[0].URLs)
+	assert.Equal(t, 2, results[0].Requests)
+
+	got := requests()
+	require.Len(t, got, 2)
+	assert.Equal(t, "/purge", got[0].Path)
+	assert.Equal(t, "Bearer secret", got[0].Header.Get("Authorization"))
+	assert.Equal(t, changed[:2], got[0].Body["urls"])
+	assert.Equal(t, changed[2:], got[1].Body["urls"])
+}
+
+func TestRun_Cloudflare(t *testing.T) {
+	srv, requests := recorder(t, http.StatusOK, `{"success":true,"errors":[]}`)
+	cfg := &config.PurgeConfig{Hooks: []config.PurgeHook{{
+		Name: "cf", Type: config.PurgeCloudflare, ZoneID: "zone1", Token: "tok", APIURL: srv.URL + "/",
+	}}}
+
+	results := Run(t.Context(), srv.Client(), cfg, changed)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, 1, results[0].Requests)
+	got := requests()
+	require.Len(t, got, 1)
+	assert.Equal(t, "/zones/zone1/purge_cache", got[0].Path)
+	assert.Equal(t, "Bearer tok", got[0].Header.Get("Authorization"))
+	assert.Equal(t, changed, got[0].Body["files"])
+}
+
+func TestRun_CloudflareReportsAPIErrors(t *testing.T) {
+	srv, _ := recorder(t, http.StatusOK, `{"success":false,"errors":[{"message":"Invalid zone"}]}`)
+	cfg := &config.PurgeConfig{Hooks: []config.PurgeHook{{Type: config.PurgeCloudflare, ZoneID: "z", Token: "t", APIURL: srv.URL}}}
+
+	results := Run(t.Context(), srv.Client(), cfg, changed)
+	require.ErrorContains(t, results[0].Err, "Invalid zone")
+	assert.Equal(t, 0, results[0].Requests)
+}
+
+func TestRun_FastlyPurgesEachURL(t *testing.T) {
+	srv, requests := recorder(t, http.StatusOK, `{"status":"ok"}`)
+	cfg := &config.PurgeConfig{Hooks: []config.PurgeHook{{Type: config.PurgeFastly, Token: "key", APIURL: srv.URL}}}
+
+	results := Run(t.Context(), srv.Client(), cfg, changed)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, 3, results[0].Requests)
+	got := requests()
+	require.Len(t, got, 3)
+	assert.Equal(t, "/purge/docs.example.com/a/", got[0].Path)
+	assert.Equal(t, "key", got[0].Header.Get("Fastly-Key"))
+}
+
+func TestRun_DryRunSendsNothing(t *testing.T) {
+	srv, requests := recorder(t, http.StatusOK, "")
+	cfg := &config.PurgeConfig{DryRun: true, Hooks: []config.PurgeHook{{Type: config.PurgeFastly, Token: "key", APIURL: srv.URL}}}
+
+	results := Run(t.Context(), srv.Client(), cfg, changed)
+	require.NoError(t, results[0].Err)
+	assert.True(t, results[0].DryRun)
+	assert.Equal(t, 3, results[0].Requests)
+	assert.Empty(t, requests())
+}
+
+func TestRun_FailingHookDoesNotStopOthers(t *testing.T) {
+	failing, _ := recorder(t, http.StatusInternalServerError, "boom")
+	ok, requests := recorder(t, http.StatusOK, "")
+	cfg := &config.PurgeConfig{Hooks: []config.PurgeHook{
+		{Name: "first", Type: config.PurgeWebhook, URL: failing.URL},
+		{Name: "second", Type: config.PurgeWebhook, URL: ok.URL},
+	}}
+
+	results := Run(t.Context(), http.DefaultClient, cfg, changed)
+	require.Len(t, results, 2)
+	require.ErrorContains(t, results[0].Err, "500")
+	require.ErrorContains(t, results[0].Err, "boom")
+	require.NoError(t, results[1].Err)
+	assert.Len(t, requests(), 1)
+
+	assert.Nil(t, Run(t.Context(), http.DefaultClient, cfg, nil), "no changed URLs, no hook runs")
+}
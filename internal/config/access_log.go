@@ -0,0 +1,149 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// AccessLogFormat enumerates the access log line formats.
+type AccessLogFormat string
+
+const (
+	AccessLogFormatJSON AccessLogFormat = "json" // one JSON object per request
+	AccessLogFormatCLF  AccessLogFormat = "clf"  // NCSA Common Log Format
+)
+
+// AccessLogServers lists the servers that write access logs.
+var AccessLogServers = []string{"docs", "webhook", "admin"}
+
+// AccessLogFields lists the fields of JSON access log records, in output order.
+var AccessLogFields = []string{
+	"time", "server", "remote_addr", "method", "host", "path", "query", "protocol",
+	"status", "bytes", "duration_ms", "referer", "user_agent", "request_id",
+}
+
+// defaultAccessLogAssetExtensions are the site assets subject to asset_sample_rate.
+var defaultAccessLogAssetExtensions = []string{
+	".css", ".js", ".map", ".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".ico",
+	".woff", ".woff2", ".ttf",
+}
+
+// AccessLogConfig configures per-request access logs of the docs, webhook and admin
+// servers. Servers with an entry in Servers write to that rotated file; the others log
+// each request as an "HTTP access" record of the http component.
+type AccessLogConfig struct {
+	Enabled bool            `yaml:"enabled"`
+	Format  AccessLogFormat `yaml:"format,omitempty"` // json or clf (default: json); applies to access log files
+	Fields  []string        `yaml:"fields,omitempty"` // JSON record fields (default: all)
+	// AssetSampleRate is the fraction of successful asset requests that is logged
+	// (default: 1). Requests answered with an error status are always logged.
+	AssetSampleRate *float64 `yaml:"asset_sample_rate,omitempty"`
+	// AssetExtensions are the file extensions counted as assets (default: styles,
+	// scripts, images and fonts).
+	AssetExtensions []string                  `yaml:"asset_extensions,omitempty"`
+	Servers         map[string]*LogFileConfig `yaml:"servers,omitempty"` // access log file per server (docs, webhook, admin)
+}
+
+// IsEnabled reports whether access logs are written.
+func (a *AccessLogConfig) IsEnabled() bool { return a != nil && a.Enabled }
+
+// EffectiveFormat returns the access log line format.
+func (a *AccessLogConfig) EffectiveFormat() AccessLogFormat {
+	if a == nil || a.Format == "" {
+		return AccessLogFormatJSON
+	}
+	return AccessLogFormat(strings.ToLower(string(a.Format)))
+}
+
+// EffectiveFields returns the JSON record fields.
+func (a *AccessLogConfig) EffectiveFields() []string {
+	if a == nil || len(a.Fields) == 0 {
+		return AccessLogFields
+	}
+	return a.Fields
+}
+
+// SampleRate returns the fraction of successful asset requests that is logged.
+func (a *AccessLogConfig) SampleRate() float64 {
+	if a == nil || a.AssetSampleRate == nil {
+		return 1
+	}
+	return *a.AssetSampleRate
+}
+
+// EffectiveAssetExtensions returns the file extensions counted as assets.
+func (a *AccessLogConfig) EffectiveAssetExtensions() []string {
+	if a == nil || len(a.AssetExtensions) == 0 {
+		return defaultAccessLogAssetExtensions
+	}
+	return a.AssetExtensions
+}
+
+// validateAccessLog validates access logs; logFile is the path of the main log file, if any.
+func validateAccessLog(a *AccessLogConfig, logFile string) error {
+	switch a.EffectiveFormat() {
+	case AccessLogFormatJSON, AccessLogFormatCLF:
+	default:
+		return errors.NewError(errors.CategoryValidation, "invalid access log format").
+			WithContext("format", a.Format).
+			WithContext("valid_formats", "json, clf").
+			Build()
+	}
+	for _, field := range a.Fields {
+		if !slices.Contains(AccessLogFields, field) {
+			return errors.NewError(errors.CategoryValidation, "unknown access log field").
+				WithContext("field", field).
+				WithContext("valid_fields", strings.Join(AccessLogFields, ", ")).
+				Build()
+		}
+	}
+	if rate := a.SampleRate(); rate < 0 || rate > 1 {
+		return errors.NewError(errors.CategoryValidation, "access log asset_sample_rate must be between 0 and 1").
+			WithContext("asset_sample_rate", rate).
+			Build()
+	}
+	for _, ext := range a.AssetExtensions {
+		if !strings.HasPrefix(ext, ".") {
+			return errors.NewError(errors.CategoryValidation, "access log asset extensions must start with a dot").
+				WithContext("extension", ext).
+				Build()
+		}
+	}
+	paths := map[string]string{}
+	if logFile != "" {
+		paths[filepath.Clean(logFile)] = "monitoring.logging.file"
+	}
+	for server, file := range a.Servers {
+		if !slices.Contains(AccessLogServers, server) {
+			return errors.NewError(errors.CategoryValidation, "unknown access log server").
+				WithContext("server", server).
+				WithContext("valid_servers", strings.Join(AccessLogServers, ", ")).
+				Build()
+		}
+		if file == nil || strings.TrimSpace(file.Path) == "" {
+			return errors.NewError(errors.CategoryValidation, "access log server path is required").
+				WithContext("server", server).
+				Build()
+		}
+		if file.MaxSizeMB < 0 || file.MaxBackups < 0 {
+			return errors.NewError(errors.CategoryValidation, "log file rotation limits must not be negative").
+				WithContext("server", server).
+				WithContext("max_size_mb", file.MaxSizeMB).
+				WithContext("max_backups", file.MaxBackups).
+				Build()
+		}
+		path := filepath.Clean(file.Path)
+		if other, ok := paths[path]; ok {
+			return errors.NewError(errors.CategoryValidation, "access log file is already used by another log").
+				WithContext("server", server).
+				WithContext("path", file.Path).
+				WithContext("used_by", other).
+				Build()
+		}
+		paths[path] = "access log of " + server
+	}
+	return nil
+}
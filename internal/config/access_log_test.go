@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_AccessLog(t *testing.T) {
+	rate := func(v float64) *float64 { return &v }
+	cases := map[string]struct {
+		access  AccessLogConfig
+		logFile string
+		wantErr bool
+	}{
+		"defaults":           {access: AccessLogConfig{Enabled: true}},
+		"clf with files":     {access: AccessLogConfig{Enabled: true, Format: "CLF", Servers: map[string]*LogFileConfig{"docs": {Path: "/var/log/docs.log"}, "admin": {Path: "/var/log/admin.log"}}}},
+		"unknown format":     {access: AccessLogConfig{Enabled: true, Format: "combined"}, wantErr: true},
+		"unknown field":      {access: AccessLogConfig{Enabled: true, Fields: []string{"status", "cookie"}}, wantErr: true},
+		"sample rate > 1":    {access: AccessLogConfig{Enabled: true, AssetSampleRate: rate(1.5)}, wantErr: true},
+		"sample rate zero":   {access: AccessLogConfig{Enabled: true, AssetSampleRate: rate(0)}},
+		"extension w/o dot":  {access: AccessLogConfig{Enabled: true, AssetExtensions: []string{"css"}}, wantErr: true},
+		"unknown server":     {access: AccessLogConfig{Enabled: true, Servers: map[string]*LogFileConfig{"livereload": {Path: "/tmp/lr.log"}}}, wantErr: true},
+		"missing path":       {access: AccessLogConfig{Enabled: true, Servers: map[string]*LogFileConfig{"docs": {}}}, wantErr: true},
+		"shared with logs":   {access: AccessLogConfig{Enabled: true, Servers: map[string]*LogFileConfig{"docs": {Path: "/var/log/db.log"}}}, logFile: "/var/log/./db.log", wantErr: true},
+		"disabled unchecked": {access: AccessLogConfig{Format: "combined"}},
+	}
+	for name, tc := range cases {
+		cfg := Config{Version: "2.0", Repositories: []Repository{{Name: "r"}}}
+		if err := applyDefaults(&cfg); err != nil {
+			t.Fatalf("%s: defaults: %v", name, err)
+		}
+		cfg.Monitoring.Logging.Access = &tc.access
+		if tc.logFile != "" {
+			cfg.Monitoring.Logging.File = &LogFileConfig{Path: tc.logFile}
+		}
+		if err := ValidateConfig(&cfg); (err != nil) != tc.wantErr {
+			t.Fatalf("%s: error = %v, wantErr %v", name, err, tc.wantErr)
+		}
+	}
+
+	var a *AccessLogConfig
+	if a.EffectiveFormat() != AccessLogFormatJSON || a.SampleRate() != 1 || len(a.EffectiveFields()) != len(AccessLogFields) {
+		t.Fatal("unexpected access log defaults")
+	}
+}
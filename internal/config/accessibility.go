@@ -0,0 +1,95 @@
+package config
+
+import (
+	"slices"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// AccessibilityRule names one rule of the accessibility checker.
+type AccessibilityRule string
+
+const (
+	// AccessibilityImageAlt flags images without alternative text.
+	AccessibilityImageAlt AccessibilityRule = "image_alt"
+	// AccessibilityHeadingOrder flags headings that skip a level (h2 followed by h4).
+	AccessibilityHeadingOrder AccessibilityRule = "heading_order"
+	// AccessibilityLinkText flags links without text or with text that does not
+	// describe the target ("click here").
+	AccessibilityLinkText AccessibilityRule = "link_text"
+)
+
+// AccessibilityRules lists all rules of the accessibility checker.
+var AccessibilityRules = []AccessibilityRule{AccessibilityImageAlt, AccessibilityHeadingOrder, AccessibilityLinkText}
+
+// AccessibilitySeverity is the severity of an accessibility violation.
+type AccessibilitySeverity string
+
+const (
+	// AccessibilityError marks violations that make content unusable with assistive technology.
+	AccessibilityError AccessibilitySeverity = "error"
+	// AccessibilityWarning marks violations that make content harder to use.
+	AccessibilityWarning AccessibilitySeverity = "warning"
+)
+
+// AccessibilityConfig enables the check_accessibility stage, which scans the rendered
+// HTML for accessibility violations after Hugo ran.
+type AccessibilityConfig struct {
+	Enabled       bool                  `yaml:"enabled"`
+	FailOn        AccessibilitySeverity `yaml:"fail_on,omitempty"`        // error|warning; empty never fails the build
+	MaxViolations int                   `yaml:"max_violations,omitempty"` // violations at or above fail_on tolerated before failing
+	IgnoreRules   []AccessibilityRule   `yaml:"ignore_rules,omitempty"`
+}
+
+// ChecksAccessibility reports whether the check_accessibility stage runs.
+func (a *AccessibilityConfig) ChecksAccessibility() bool {
+	return a != nil && a.Enabled
+}
+
+// RuleEnabled reports whether rule is checked.
+func (a *AccessibilityConfig) RuleEnabled(rule AccessibilityRule) bool {
+	return a == nil || !slices.Contains(a.IgnoreRules, rule)
+}
+
+// CountsTowardFailure reports whether a violation of severity counts against
+// max_violations.
+func (a *AccessibilityConfig) CountsTowardFailure(severity AccessibilitySeverity) bool {
+	if a == nil {
+		return false
+	}
+	switch a.FailOn {
+	case AccessibilityError:
+		return severity == AccessibilityError
+	case AccessibilityWarning:
+		return true
+	}
+	return false
+}
+
+func validateAccessibility(a *AccessibilityConfig) error {
+	if a == nil {
+		return nil
+	}
+	switch a.FailOn {
+	case "", AccessibilityError, AccessibilityWarning:
+	default:
+		return errors.NewError(errors.CategoryValidation, "invalid build.accessibility.fail_on").
+			WithContext("actual", string(a.FailOn)).
+			WithContext("allowed", "error|warning").
+			Build()
+	}
+	if a.MaxViolations < 0 {
+		return errors.NewError(errors.CategoryValidation, "build.accessibility.max_violations must not be negative").
+			WithContext("max_violations", a.MaxViolations).
+			Build()
+	}
+	for _, rule := range a.IgnoreRules {
+		if !slices.Contains(AccessibilityRules, rule) {
+			return errors.NewError(errors.CategoryValidation, "unknown rule in build.accessibility.ignore_rules").
+				WithContext("rule", string(rule)).
+				WithContext("valid_values", AccessibilityRules).
+				Build()
+		}
+	}
+	return nil
+}
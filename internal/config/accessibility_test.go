@@ -0,0 +1,44 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_Accessibility(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a       AccessibilityConfig
+		wantErr bool
+	}{
+		"defaults":           {AccessibilityConfig{Enabled: true}, false},
+		"fail on warnings":   {AccessibilityConfig{Enabled: true, FailOn: AccessibilityWarning, MaxViolations: 10}, false},
+		"ignore rule":        {AccessibilityConfig{Enabled: true, IgnoreRules: []AccessibilityRule{AccessibilityLinkText}}, false},
+		"unknown severity":   {AccessibilityConfig{Enabled: true, FailOn: "critical"}, true},
+		"negative threshold": {AccessibilityConfig{Enabled: true, FailOn: AccessibilityError, MaxViolations: -1}, true},
+		"unknown rule":       {AccessibilityConfig{Enabled: true, IgnoreRules: []AccessibilityRule{"color_contrast"}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := tc.a
+			cfg := Config{Version: "2.0", Repositories: []Repository{{Name: "r", URL: "https://example.com/org/r.git"}}}
+			cfg.Build.Accessibility = &a
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			if err := ValidateConfig(&cfg); (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccessibilityConfig_CountsTowardFailure(t *testing.T) {
+	var nilCfg *AccessibilityConfig
+	if nilCfg.CountsTowardFailure(AccessibilityError) || !nilCfg.RuleEnabled(AccessibilityImageAlt) {
+		t.Fatalf("nil config must check every rule and never fail")
+	}
+	a := &AccessibilityConfig{FailOn: AccessibilityError}
+	if !a.CountsTowardFailure(AccessibilityError) || a.CountsTowardFailure(AccessibilityWarning) {
+		t.Fatalf("fail_on error must count errors only")
+	}
+	a.FailOn = AccessibilityWarning
+	if !a.CountsTowardFailure(AccessibilityWarning) {
+		t.Fatalf("fail_on warning must count warnings")
+	}
+}
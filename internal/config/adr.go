@@ -0,0 +1,102 @@
+package config
+
+import (
+	"path"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+const (
+	defaultADRIndexSection = "architecture-decisions"
+	defaultADRIndexTitle   = "Architecture Decisions"
+)
+
+// defaultADRPatterns match the layouts of the adr template and of adr-tools/MADR.
+var defaultADRPatterns = []string{"adr/*.md", "adrs/*.md", "decisions/*.md", "adr-*.md"}
+
+// ADRIndexConfig controls the site-wide index of architecture decision records (ADRs)
+// collected from all repositories.
+type ADRIndexConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Section string `yaml:"section,omitempty"` // Content directory of the index (default "architecture-decisions")
+	Title   string `yaml:"title,omitempty"`   // Title of the index page (default "Architecture Decisions")
+	// Patterns are path.Match globs identifying ADR files. A pattern matches the file name
+	// or any trailing part of the repository-relative path, so "adr/*.md" matches
+	// docs/adr/0001-use-go.md. Defaults to adr/*.md, adrs/*.md, decisions/*.md and adr-*.md.
+	Patterns []string `yaml:"patterns,omitempty"`
+}
+
+// IsEnabled reports whether the ADR index is generated.
+func (a *ADRIndexConfig) IsEnabled() bool { return a != nil && a.Enabled }
+
+// SectionName returns the effective content directory of the index.
+func (a *ADRIndexConfig) SectionName() string {
+	if a == nil || a.Section == "" {
+		return defaultADRIndexSection
+	}
+	return a.Section
+}
+
+// IndexTitle returns the effective title of the index page.
+func (a *ADRIndexConfig) IndexTitle() string {
+	if a == nil || a.Title == "" {
+		return defaultADRIndexTitle
+	}
+	return a.Title
+}
+
+// Matches reports whether the repository-relative, slash-separated path of a file is an ADR.
+func (a *ADRIndexConfig) Matches(relPath string) bool {
+	patterns := defaultADRPatterns
+	if a != nil && len(a.Patterns) > 0 {
+		patterns = a.Patterns
+	}
+	parts := strings.Split(strings.ToLower(path.Clean(relPath)), "/")
+	for i := range parts {
+		suffix := strings.Join(parts[i:], "/")
+		for _, p := range patterns {
+			if ok, _ := path.Match(strings.ToLower(p), suffix); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (cv *configurationValidator) validateADRIndex() error {
+	a := cv.config.ADRIndex
+	if !a.IsEnabled() {
+		return nil
+	}
+	section := a.SectionName()
+	if strings.ContainsAny(section, `/\`) || strings.HasPrefix(section, "_") || section == "." || section == ".." {
+		return errors.NewError(errors.CategoryValidation, "adr_index.section must be a single directory name").
+			WithContext("section", section).
+			Build()
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if strings.EqualFold(repo.Name, section) {
+			return errors.NewError(errors.CategoryValidation, "adr_index.section conflicts with a repository name").
+				WithContext("section", section).
+				Build()
+		}
+		for _, s := range repo.Sections {
+			if strings.EqualFold(s.Name, section) {
+				return errors.NewError(errors.CategoryValidation, "adr_index.section conflicts with a repository section").
+					WithContext("section", section).
+					WithContext("repository", repo.Name).
+					Build()
+			}
+		}
+	}
+	for _, p := range a.Patterns {
+		if _, err := path.Match(p, ""); err != nil || strings.TrimSpace(p) == "" {
+			return errors.NewError(errors.CategoryValidation, "invalid adr_index pattern").
+				WithContext("pattern", p).
+				Build()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestADRIndexConfig(t *testing.T) {
+	var nilCfg *ADRIndexConfig
+	if nilCfg.IsEnabled() || nilCfg.SectionName() != "architecture-decisions" || nilCfg.IndexTitle() != "Architecture Decisions" {
+		t.Fatalf("unexpected defaults")
+	}
+	for p, want := range map[string]bool{
+		"docs/adr/0001-record.md":  true,
+		"docs/adr-004-forge.md":    true,
+		"docs/ADR/0002-caching.md": true,
+		"docs/guide.md":            false,
+	} {
+		if got := nilCfg.Matches(p); got != want {
+			t.Fatalf("Matches(%q) = %v, want %v", p, got, want)
+		}
+	}
+	custom := &ADRIndexConfig{Patterns: []string{"rfcs/*.md"}}
+	if !custom.Matches("rfcs/0001.md") || custom.Matches("docs/adr/0001-record.md") {
+		t.Fatalf("custom patterns should replace the defaults")
+	}
+
+	base := func(a *ADRIndexConfig) *Config {
+		cfg := &Config{Version: "2.0", ADRIndex: a, Repositories: []Repository{
+			{Name: "decisions", URL: "https://example.com/decisions.git"},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+	if err := ValidateConfig(base(&ADRIndexConfig{Enabled: true})); err != nil {
+		t.Fatalf("expected valid adr_index, got %v", err)
+	}
+	cases := map[string]*ADRIndexConfig{
+		"must be a single directory name":  {Enabled: true, Section: "a/b"},
+		"conflicts with a repository name": {Enabled: true, Section: "Decisions"},
+		"invalid adr_index pattern":        {Enabled: true, Patterns: []string{"[adr"}},
+	}
+	for want, a := range cases {
+		if err := ValidateConfig(base(a)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// defaultAnalyticsMaxPages bounds the number of distinct page paths tracked when max_pages is unset.
+const defaultAnalyticsMaxPages = 5000
+
+// AnalyticsConfig controls self-hosted page view counting on the daemon docs server.
+// Only the request path is counted: no cookies, referrers, IP addresses or user agents are kept.
+type AnalyticsConfig struct {
+	Enabled    bool `yaml:"enabled"`
+	Prometheus bool `yaml:"prometheus,omitempty"` // Export per-page view counters on /metrics/prometheus
+	MaxPages   int  `yaml:"max_pages,omitempty"`  // Distinct paths tracked; views of further paths are counted as "other" (default 5000)
+}
+
+// IsEnabled reports whether page view analytics are active.
+func (a *AnalyticsConfig) IsEnabled() bool { return a != nil && a.Enabled }
+
+// PageLimit returns the effective number of distinct paths tracked.
+func (a *AnalyticsConfig) PageLimit() int {
+	if a == nil || a.MaxPages <= 0 {
+		return defaultAnalyticsMaxPages
+	}
+	return a.MaxPages
+}
+
+func validateDaemonAnalytics(a *AnalyticsConfig) error {
+	if a.MaxPages < 0 {
+		return errors.NewError(errors.CategoryValidation, "daemon analytics max_pages must be >= 0").
+			WithContext("value", a.MaxPages).
+			Build()
+	}
+	return nil
+}
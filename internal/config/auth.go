@@ -1,6 +1,7 @@
 package config
 
 import (
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/normalization"
 )
 
@@ -12,14 +13,20 @@ const (
 	AuthTypeSSH   AuthType = "ssh"
 	AuthTypeToken AuthType = "token"
 	AuthTypeBasic AuthType = "basic"
+	// AuthTypeGitHubApp authenticates as a GitHub App installation.
+	AuthTypeGitHubApp AuthType = "github_app"
+	// AuthTypeOAuth uses the OAuth 2.0 client credentials grant (e.g. a GitLab OAuth application).
+	AuthTypeOAuth AuthType = "oauth"
 )
 
 // NormalizeAuthType canonicalizes an auth type string (case-insensitive) or returns empty if unknown.
 var authTypeNormalizer = normalization.NewNormalizer(map[string]AuthType{
-	"none":  AuthTypeNone,
-	"ssh":   AuthTypeSSH,
-	"token": AuthTypeToken,
-	"basic": AuthTypeBasic,
+	"none":       AuthTypeNone,
+	"ssh":        AuthTypeSSH,
+	"token":      AuthTypeToken,
+	"basic":      AuthTypeBasic,
+	"github_app": AuthTypeGitHubApp,
+	"oauth":      AuthTypeOAuth,
 }, "")
 
 // NormalizeAuthType canonicalizes an auth type string (case-insensitive) or returns empty if unknown.
@@ -34,12 +41,80 @@ func (a AuthType) IsValid() bool {
 
 // AuthConfig represents authentication configuration.
 type AuthConfig struct {
-	Type     AuthType `yaml:"type"` // ssh|token|basic|none
+	Type     AuthType `yaml:"type"` // ssh|token|basic|none|github_app|oauth
 	Username string   `yaml:"username,omitempty"`
 	Password string   `yaml:"password,omitempty"`
 	Token    string   `yaml:"token,omitempty"`
 	KeyPath  string   `yaml:"key_path,omitempty"`
+
+	// SSH deploy keys (type ssh). The private key is read from KeyPath or from the
+	// environment variable named by KeyEnv; AgentSocket selects an ssh-agent instead.
+	// KnownHosts and HostKeys pin the server host keys; without them ~/.ssh/known_hosts is used.
+	KeyEnv        string   `yaml:"key_env,omitempty"`
+	KeyPassphrase string   `yaml:"key_passphrase,omitempty"`
+	AgentSocket   string   `yaml:"agent_socket,omitempty"`
+	KnownHosts    string   `yaml:"known_hosts,omitempty"`
+	HostKeys      []string `yaml:"host_keys,omitempty"`
+
+	// GitHub App (type github_app): installation tokens are requested with a JWT signed by
+	// the app's private key and renewed before they expire.
+	AppID          int64  `yaml:"app_id,omitempty"`
+	InstallationID int64  `yaml:"installation_id,omitempty"`
+	PrivateKeyPath string `yaml:"private_key_path,omitempty"`
+
+	// OAuth client credentials (type oauth). TokenURL defaults to <forge base_url>/oauth/token.
+	ClientID     string   `yaml:"client_id,omitempty"`
+	ClientSecret string   `yaml:"client_secret,omitempty"`
+	TokenURL     string   `yaml:"token_url,omitempty"`
+	Scopes       []string `yaml:"scopes,omitempty"`
 }
 
 // IsZero reports whether no auth method specified.
 func (a *AuthConfig) IsZero() bool { return a == nil || a.Type == "" || a.Type == AuthTypeNone }
+
+// UsesTokenSource reports whether tokens are obtained and renewed at runtime
+// (GitHub App or OAuth client credentials) rather than configured.
+func (a *AuthConfig) UsesTokenSource() bool {
+	return a != nil && (a.Type == AuthTypeGitHubApp || a.Type == AuthTypeOAuth)
+}
+
+// validateTokenSourceAuth checks the fields required by github_app and oauth auth.
+// field names the auth block in error context (e.g. "forges.github.auth").
+func validateTokenSourceAuth(field string, a *AuthConfig) error {
+	switch a.Type {
+	case AuthTypeGitHubApp:
+		if a.AppID <= 0 || a.InstallationID <= 0 || a.PrivateKeyPath == "" {
+			return errors.NewError(errors.CategoryValidation, "github_app auth requires app_id, installation_id and private_key_path").
+				WithContext("field", field).
+				Build()
+		}
+	case AuthTypeOAuth:
+		if a.ClientID == "" || a.ClientSecret == "" {
+			return errors.NewError(errors.CategoryValidation, "oauth auth requires client_id and client_secret").
+				WithContext("field", field).
+				Build()
+		}
+	}
+	return nil
+}
+
+// validateSSHAuth checks that ssh auth names at most one key source.
+func validateSSHAuth(field string, a *AuthConfig) error {
+	sources := 0
+	for _, v := range []string{a.KeyPath, a.KeyEnv, a.AgentSocket} {
+		if v != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.NewError(errors.CategoryValidation, "ssh auth accepts only one of key_path, key_env and agent_socket").
+			WithContext("field", field).
+			Build()
+	}
+	if a.KnownHosts != "" && len(a.HostKeys) > 0 {
+		return errors.NewError(errors.CategoryValidation, "ssh auth accepts known_hosts or host_keys, not both").
+			WithContext("field", field).
+			Build()
+	}
+	return nil
+}
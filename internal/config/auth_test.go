@@ -15,6 +15,8 @@ func TestNormalizeAuthType(t *testing.T) {
 		{"Basic", AuthTypeBasic},
 		{"none", AuthTypeNone},
 		{"NONE", AuthTypeNone},
+		{"github_app", AuthTypeGitHubApp},
+		{"OAuth", AuthTypeOAuth},
 		{"  ssh  ", AuthTypeSSH}, // trimming
 		{"invalid", ""},
 		{"", ""},
@@ -68,3 +70,72 @@ func TestForgeType_IsValid(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateConfig_TokenSourceAuth(t *testing.T) {
+	app := &AuthConfig{Type: AuthTypeGitHubApp, AppID: 1, InstallationID: 2, PrivateKeyPath: "app.pem"}
+	oauth := &AuthConfig{Type: AuthTypeOAuth, ClientID: "id", ClientSecret: "secret"}
+	for name, tc := range map[string]struct {
+		forgeType ForgeType
+		auth      *AuthConfig
+		wantErr   bool
+	}{
+		"github app":                  {ForgeGitHub, app, false},
+		"github app without key":      {ForgeGitHub, &AuthConfig{Type: AuthTypeGitHubApp, AppID: 1, InstallationID: 2}, true},
+		"github app on gitlab":        {ForgeGitLab, app, true},
+		"gitlab oauth":                {ForgeGitLab, oauth, false},
+		"gitlab oauth without secret": {ForgeGitLab, &AuthConfig{Type: AuthTypeOAuth, ClientID: "id"}, true},
+		"forgejo oauth needs url":     {ForgeForgejo, oauth, true},
+		"forgejo oauth with url":      {ForgeForgejo, &AuthConfig{Type: AuthTypeOAuth, ClientID: "id", ClientSecret: "s", TokenURL: "https://git.example.com/login/oauth/access_token"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{
+				Version: "2.0",
+				Forges: []*ForgeConfig{{
+					Name:          "f",
+					Type:          tc.forgeType,
+					APIURL:        "https://git.example.com/api",
+					BaseURL:       "https://git.example.com",
+					Auth:          tc.auth,
+					Organizations: []string{"org"},
+					Groups:        []string{"org"},
+				}},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_SSHAuth(t *testing.T) {
+	for name, tc := range map[string]struct {
+		auth    *AuthConfig
+		wantErr bool
+	}{
+		"deploy key path":      {&AuthConfig{Type: AuthTypeSSH, KeyPath: "deploy_key"}, false},
+		"key env with pin":     {&AuthConfig{Type: AuthTypeSSH, KeyEnv: "DEPLOY_KEY", HostKeys: []string{"git.example.com ssh-ed25519 AAAA"}}, false},
+		"agent socket":         {&AuthConfig{Type: AuthTypeSSH, AgentSocket: "/run/agent.sock", KnownHosts: "known_hosts"}, false},
+		"key path and agent":   {&AuthConfig{Type: AuthTypeSSH, KeyPath: "deploy_key", AgentSocket: "/run/agent.sock"}, true},
+		"key path and key env": {&AuthConfig{Type: AuthTypeSSH, KeyPath: "deploy_key", KeyEnv: "DEPLOY_KEY"}, true},
+		"known hosts and pins": {&AuthConfig{Type: AuthTypeSSH, KnownHosts: "known_hosts", HostKeys: []string{"x"}}, true},
+		"default key, no pins": {&AuthConfig{Type: AuthTypeSSH}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r", URL: "git@git.example.com:acme/r.git", Auth: tc.auth}},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
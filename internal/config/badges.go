@@ -0,0 +1,68 @@
+package config
+
+import (
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+const (
+	defaultBadgeFreshDays          = 30
+	defaultBadgeStaleDays          = 180
+	defaultBadgeSourceFilesPerPage = 10
+)
+
+// BadgesConfig controls the README badges served on the public docs server
+// (/api/badges/<repo>/freshness and /api/badges/<repo>/coverage).
+type BadgesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// FreshDays is the age of the last docs update up to which the freshness badge is green (default 30).
+	FreshDays int `yaml:"fresh_days,omitempty"`
+	// StaleDays is the age from which the freshness badge is red (default 180).
+	StaleDays int `yaml:"stale_days,omitempty"`
+	// SourceFilesPerPage is the number of source files one docs page is expected to cover;
+	// a repository with that many source files per page or fewer has 100% coverage (default 10).
+	SourceFilesPerPage int `yaml:"source_files_per_page,omitempty"`
+}
+
+// IsEnabled reports whether badges are collected and served.
+func (b *BadgesConfig) IsEnabled() bool { return b != nil && b.Enabled }
+
+// FreshThreshold returns the age in days up to which docs count as fresh.
+func (b *BadgesConfig) FreshThreshold() int {
+	if b == nil || b.FreshDays <= 0 {
+		return defaultBadgeFreshDays
+	}
+	return b.FreshDays
+}
+
+// StaleThreshold returns the age in days from which docs count as stale.
+func (b *BadgesConfig) StaleThreshold() int {
+	if b == nil || b.StaleDays <= 0 {
+		return max(defaultBadgeStaleDays, b.FreshThreshold())
+	}
+	return b.StaleDays
+}
+
+// FilesPerPage returns the number of source files one docs page is expected to cover.
+func (b *BadgesConfig) FilesPerPage() int {
+	if b == nil || b.SourceFilesPerPage <= 0 {
+		return defaultBadgeSourceFilesPerPage
+	}
+	return b.SourceFilesPerPage
+}
+
+func validateDaemonBadges(b *BadgesConfig) error {
+	if b.FreshDays < 0 || b.StaleDays < 0 || b.SourceFilesPerPage < 0 {
+		return errors.NewError(errors.CategoryValidation, "daemon badges fresh_days, stale_days and source_files_per_page must be >= 0").
+			WithContext("fresh_days", b.FreshDays).
+			WithContext("stale_days", b.StaleDays).
+			WithContext("source_files_per_page", b.SourceFilesPerPage).
+			Build()
+	}
+	if b.StaleDays > 0 && b.StaleDays < b.FreshThreshold() {
+		return errors.NewError(errors.CategoryValidation, "daemon badges stale_days must not be lower than fresh_days").
+			WithContext("fresh_days", b.FreshThreshold()).
+			WithContext("stale_days", b.StaleDays).
+			Build()
+	}
+	return nil
+}
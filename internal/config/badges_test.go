@@ -0,0 +1,50 @@
+package config
+
+import "testing"
+
+func TestBadgesConfig_Defaults(t *testing.T) {
+	var nilCfg *BadgesConfig
+	if nilCfg.IsEnabled() {
+		t.Fatalf("nil badges config must be disabled")
+	}
+	if nilCfg.FreshThreshold() != defaultBadgeFreshDays || nilCfg.StaleThreshold() != defaultBadgeStaleDays ||
+		nilCfg.FilesPerPage() != defaultBadgeSourceFilesPerPage {
+		t.Fatalf("expected defaults")
+	}
+	cfg := &BadgesConfig{FreshDays: 365}
+	if cfg.StaleThreshold() != 365 {
+		t.Fatalf("default stale threshold must not be below fresh_days, got %d", cfg.StaleThreshold())
+	}
+}
+
+func TestValidateConfig_DaemonBadges(t *testing.T) {
+	for name, tc := range map[string]struct {
+		badges  BadgesConfig
+		wantErr bool
+	}{
+		"valid":                {BadgesConfig{Enabled: true, FreshDays: 14, StaleDays: 90, SourceFilesPerPage: 5}, false},
+		"defaults":             {BadgesConfig{Enabled: true}, false},
+		"negative fresh_days":  {BadgesConfig{Enabled: true, FreshDays: -1}, true},
+		"stale before fresh":   {BadgesConfig{Enabled: true, FreshDays: 60, StaleDays: 30}, true},
+		"negative files ratio": {BadgesConfig{Enabled: true, SourceFilesPerPage: -3}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			badges := tc.badges
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:   SyncConfig{Schedule: "0 */4 * * *"},
+					Badges: &badges,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
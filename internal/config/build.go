@@ -6,27 +6,39 @@ import (
 
 // BuildConfig holds build performance tuning knobs and retry/cleanup options.
 type BuildConfig struct {
-	CloneConcurrency   int              `yaml:"clone_concurrency,omitempty"`
-	CloneStrategy      CloneStrategy    `yaml:"clone_strategy,omitempty"`
-	NamespaceForges    NamespacingMode  `yaml:"namespace_forges,omitempty"` // auto|always|never (governs forge directory prefixing)
-	ShallowDepth       int              `yaml:"shallow_depth,omitempty"`
-	PruneNonDocPaths   bool             `yaml:"prune_non_doc_paths,omitempty"`
-	PruneAllow         []string         `yaml:"prune_allow,omitempty"`
-	PruneDeny          []string         `yaml:"prune_deny,omitempty"`
-	MaxRetries         int              `yaml:"max_retries,omitempty"`
-	RetryBackoff       RetryBackoffMode `yaml:"retry_backoff,omitempty"`
-	RetryInitialDelay  string           `yaml:"retry_initial_delay,omitempty"`
-	RetryMaxDelay      string           `yaml:"retry_max_delay,omitempty"`
-	HardResetOnDiverge bool             `yaml:"hard_reset_on_diverge,omitempty"`
-	CleanUntracked     bool             `yaml:"clean_untracked,omitempty"`
-	WorkspaceDir       string           `yaml:"workspace_dir,omitempty"`
-	SkipIfUnchanged    bool             `yaml:"skip_if_unchanged,omitempty"`
-	RenderMode         RenderMode       `yaml:"render_mode,omitempty"`      // auto|always|never (source of truth for Hugo execution)
-	DetectDeletions    bool             `yaml:"detect_deletions,omitempty"` // enable unchanged repo deletion scan during partial recomposition
-	LiveReload         bool             `yaml:"live_reload,omitempty"`      // enable SSE livereload endpoint & script (development only)
-	IsPreview          bool             `yaml:"-"`                          // true when running in preview/daemon mode
-	VSCodeEditLinks    bool             `yaml:"-"`                          // enable VS Code edit links with /_edit/ handler (set via --vscode flag)
-	EditURLBase        string           `yaml:"-"`                          // base URL for edit links (CLI override, not persisted)
+	CloneConcurrency   int               `yaml:"clone_concurrency,omitempty"`
+	Concurrency        int               `yaml:"concurrency,omitempty"` // content transform workers (0 = GOMAXPROCS)
+	CloneStrategy      CloneStrategy     `yaml:"clone_strategy,omitempty"`
+	CopyStrategy       CopyStrategy      `yaml:"copy_strategy,omitempty"`       // copy|hardlink|reflink|auto (how assets are placed in the site)
+	NamespaceForges    NamespacingMode   `yaml:"namespace_forges,omitempty"`    // auto|always|never (governs forge directory prefixing)
+	NamespaceOrgDepth  int               `yaml:"namespace_org_depth,omitempty"` // organization/group levels placed between forge and repository (0 disables)
+	ShallowDepth       int               `yaml:"shallow_depth,omitempty"`
+	PruneNonDocPaths   bool              `yaml:"prune_non_doc_paths,omitempty"`
+	PruneAllow         []string          `yaml:"prune_allow,omitempty"`
+	PruneDeny          []string          `yaml:"prune_deny,omitempty"`
+	MaxRetries         int               `yaml:"max_retries,omitempty"`
+	RetryBackoff       RetryBackoffMode  `yaml:"retry_backoff,omitempty"`
+	RetryInitialDelay  string            `yaml:"retry_initial_delay,omitempty"`
+	RetryMaxDelay      string            `yaml:"retry_max_delay,omitempty"`
+	HardResetOnDiverge bool              `yaml:"hard_reset_on_diverge,omitempty"`
+	CleanUntracked     bool              `yaml:"clean_untracked,omitempty"`
+	WorkspaceDir       string            `yaml:"workspace_dir,omitempty"`
+	SkipIfUnchanged    bool              `yaml:"skip_if_unchanged,omitempty"`
+	RenderMode         RenderMode        `yaml:"render_mode,omitempty"`           // auto|always|never (source of truth for Hugo execution)
+	DetectDeletions    bool              `yaml:"detect_deletions,omitempty"`      // enable unchanged repo deletion scan during partial recomposition
+	LiveReload         bool              `yaml:"live_reload,omitempty"`           // enable SSE livereload endpoint & script (development only)
+	MaxFileSizeMB      int               `yaml:"max_file_size_mb,omitempty"`      // largest markdown file buffered by the content pipeline (0 = unlimited; assets are streamed)
+	MaxContentMemoryMB int               `yaml:"max_content_memory_mb,omitempty"` // total markdown bytes buffered per build (0 = unlimited)
+	IsPreview          bool              `yaml:"-"`                               // true when running in preview/daemon mode
+	VSCodeEditLinks    bool              `yaml:"-"`                               // enable local editor links with /_edit/ handler (set via --vscode or --edit-with flag)
+	Editor             string            `yaml:"-"`                               // default editor for /_edit/ links (empty means vscode; set via --edit-with flag)
+	EditorCommands     map[string]string `yaml:"-"`                               // custom editor templates by name (set via --editor-command flag)
+	BrowserEditor      bool              `yaml:"-"`                               // enable in-browser markdown editor at /_editor/ (set via --editor flag)
+	EditURLBase        string            `yaml:"-"`                               // base URL for edit links (CLI override, not persisted)
+	EditURLTemplate    string            `yaml:"edit_url_template,omitempty"`     // Go template for edit links ({{.SourceURL}}, {{.Branch}}, {{.Path}}); empty uses forge patterns
+	RepositoryMeta     bool              `yaml:"repository_meta,omitempty"`       // generate hidden /_meta/<repo>/ build metadata pages linked from repository indexes
+	Profile            string            `yaml:"profile,omitempty"`               // active build profile (e.g. internal|public); selects profile-specific pages and blocks
+	Profiles           []string          `yaml:"profiles,omitempty"`              // declared build profiles; when set, content may only be marked for these
 	// detectDeletionsSpecified is set internally during load when the YAML explicitly sets detect_deletions.
 	// This lets defaults apply (true) only when user omitted the field entirely.
 	detectDeletionsSpecified bool `yaml:"-"`
@@ -36,6 +48,12 @@ type BuildConfig struct {
 	// hardResetOnDivergeSpecified is set internally during load when the YAML explicitly sets hard_reset_on_diverge.
 	// This lets defaults apply (true) only when user omitted the field entirely.
 	hardResetOnDivergeSpecified bool `yaml:"-"`
+	// Resources limits memory, CPU weight and priority of the hugo and git processes.
+	Resources *ResourceLimitsConfig `yaml:"resources,omitempty"`
+	// OutputValidation checks the rendered site against the content handed to Hugo.
+	OutputValidation *OutputValidationConfig `yaml:"output_validation,omitempty"`
+	// Accessibility checks the rendered HTML for accessibility violations.
+	Accessibility *AccessibilityConfig `yaml:"accessibility,omitempty"`
 }
 
 // UnmarshalYAML is a custom unmarshal to detect if detect_deletions was explicitly set by user.
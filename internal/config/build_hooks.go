@@ -0,0 +1,144 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// HookPoint names the place in the build pipeline where hook commands run.
+type HookPoint string
+
+const (
+	HookPreBuild  HookPoint = "pre_build"  // Before any other stage
+	HookPostClone HookPoint = "post_clone" // After repositories are cloned or updated
+	HookPreHugo   HookPoint = "pre_hugo"   // Before Hugo renders the generated project
+	HookPostBuild HookPoint = "post_build" // After the new site is published
+)
+
+// HookPoints lists the hook points in pipeline order.
+var HookPoints = []HookPoint{HookPreBuild, HookPostClone, HookPreHugo, HookPostBuild}
+
+// defaultHookTimeout bounds a hook command when neither it nor hooks.timeout sets a limit.
+const defaultHookTimeout = 5 * time.Minute
+
+// BuildHooksConfig runs user commands at fixed points of the build pipeline.
+type BuildHooksConfig struct {
+	Timeout   string        `yaml:"timeout,omitempty"` // Default per command (5m)
+	PreBuild  []HookCommand `yaml:"pre_build,omitempty"`
+	PostClone []HookCommand `yaml:"post_clone,omitempty"`
+	PreHugo   []HookCommand `yaml:"pre_hugo,omitempty"`
+	PostBuild []HookCommand `yaml:"post_build,omitempty"`
+}
+
+// HookCommand is one command run at a hook point. It is executed directly, not through
+// a shell; use `command: sh` with `args: ["-c", "..."]` for shell syntax.
+type HookCommand struct {
+	Name            string            `yaml:"name,omitempty"` // Label in logs and the build report (default: the command)
+	Command         string            `yaml:"command"`
+	Args            []string          `yaml:"args,omitempty"`
+	Env             map[string]string `yaml:"env,omitempty"`               // Added to the documented DOCBUILDER_* variables
+	Dir             string            `yaml:"dir,omitempty"`               // Working directory (default: the docbuilder working directory)
+	Timeout         string            `yaml:"timeout,omitempty"`           // Overrides hooks.timeout
+	ContinueOnError bool              `yaml:"continue_on_error,omitempty"` // Record a failure as a warning instead of failing the build
+}
+
+// BuildHooks returns the commands of a hook point, or nil when none are configured.
+func (c *Config) BuildHooks(point HookPoint) []HookCommand {
+	if c == nil {
+		return nil
+	}
+	return c.Hooks.Commands(point)
+}
+
+// Commands returns the commands of a hook point.
+func (b *BuildHooksConfig) Commands(point HookPoint) []HookCommand {
+	if b == nil {
+		return nil
+	}
+	switch point {
+	case HookPreBuild:
+		return b.PreBuild
+	case HookPostClone:
+		return b.PostClone
+	case HookPreHugo:
+		return b.PreHugo
+	case HookPostBuild:
+		return b.PostBuild
+	}
+	return nil
+}
+
+// HookName returns the name of the command, defaulting to the command itself.
+func (h *HookCommand) HookName() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return h.Command
+}
+
+// TimeoutDuration returns the time limit of the command: its own timeout, else
+// hooks.timeout, else the default.
+func (h *HookCommand) TimeoutDuration(hooks *BuildHooksConfig) time.Duration {
+	for _, value := range []string{h.Timeout, hooksTimeout(hooks)} {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultHookTimeout
+}
+
+func hooksTimeout(hooks *BuildHooksConfig) string {
+	if hooks == nil {
+		return ""
+	}
+	return hooks.Timeout
+}
+
+// snapshotValue renders the commands for config hashing; hooks may change the generated site.
+func (b *BuildHooksConfig) snapshotValue() string {
+	var parts []string
+	for _, point := range HookPoints {
+		for _, h := range b.Commands(point) {
+			parts = append(parts, string(point)+"="+h.Command+" "+strings.Join(h.Args, " "))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+func (cv *configurationValidator) validateBuildHooks() error {
+	hooks := cv.config.Hooks
+	if hooks == nil {
+		return nil
+	}
+	if err := validateHookTimeout("hooks.timeout", hooks.Timeout); err != nil {
+		return err
+	}
+	for _, point := range HookPoints {
+		for _, h := range cv.config.BuildHooks(point) {
+			if strings.TrimSpace(h.Command) == "" {
+				return errors.NewError(errors.CategoryValidation, "hook command is required").
+					WithContext("hook_point", point).
+					WithContext("name", h.Name).
+					Build()
+			}
+			if err := validateHookTimeout("hooks."+string(point)+".timeout", h.Timeout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateHookTimeout(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		return errors.NewError(errors.CategoryValidation, field+" must be a positive duration").
+			WithContext("value", value).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHookCommand_TimeoutDuration(t *testing.T) {
+	hooks := &BuildHooksConfig{}
+	h := HookCommand{Command: "make"}
+	if got := h.TimeoutDuration(hooks); got != 5*time.Minute {
+		t.Fatalf("expected default timeout, got %v", got)
+	}
+	hooks.Timeout = "1m"
+	if got := h.TimeoutDuration(hooks); got != time.Minute {
+		t.Fatalf("expected hooks.timeout, got %v", got)
+	}
+	h.Timeout = "10s"
+	if got := h.TimeoutDuration(hooks); got != 10*time.Second {
+		t.Fatalf("expected command timeout, got %v", got)
+	}
+	if h.HookName() != "make" {
+		t.Fatalf("expected the command as name, got %q", h.HookName())
+	}
+}
+
+func TestValidateConfig_BuildHooks(t *testing.T) {
+	base := func(h *BuildHooksConfig) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}},
+			Hooks:        h,
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(&BuildHooksConfig{Timeout: "2m", PreHugo: []HookCommand{{Command: "./scripts/diagrams.sh", Timeout: "30s"}}})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid hooks, got %v", err)
+	}
+	if len(cfg.BuildHooks(HookPreHugo)) != 1 || cfg.BuildHooks(HookPostBuild) != nil {
+		t.Fatal("unexpected hook commands per point")
+	}
+	if err := ValidateConfig(base(&BuildHooksConfig{PostBuild: []HookCommand{{Name: "notify"}}})); err == nil || !strings.Contains(err.Error(), "hook command is required") {
+		t.Fatalf("expected command error, got %v", err)
+	}
+	if err := ValidateConfig(base(&BuildHooksConfig{PreBuild: []HookCommand{{Command: "x", Timeout: "-1s"}}})); err == nil || !strings.Contains(err.Error(), "hooks.pre_build.timeout") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
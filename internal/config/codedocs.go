@@ -0,0 +1,102 @@
+package config
+
+import (
+	"path"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// CodeDocsTool names a language documentation generator.
+type CodeDocsTool string
+
+const (
+	// CodeDocsGomarkdoc generates markdown for Go packages with gomarkdoc.
+	CodeDocsGomarkdoc CodeDocsTool = "gomarkdoc"
+	// CodeDocsTypedoc generates markdown for TypeScript with typedoc and typedoc-plugin-markdown.
+	CodeDocsTypedoc CodeDocsTool = "typedoc"
+)
+
+// CodeDocsGenerator runs a documentation generator inside a cloned repository. The
+// generated markdown is written below one of the repository's docs paths, so that it is
+// discovered like the hand-written documentation.
+type CodeDocsGenerator struct {
+	Tool CodeDocsTool `yaml:"tool"` // gomarkdoc|typedoc
+	// Packages lists Go package patterns (gomarkdoc, default "./...") or TypeScript entry
+	// points (typedoc, default: the project's typedoc/tsconfig settings).
+	Packages []string `yaml:"packages,omitempty"`
+	// Output is the directory, relative to the repository root, that receives the generated
+	// markdown. It must lie inside a docs path and is replaced on every build.
+	// Defaults to <first docs path>/reference/<go|ts>.
+	Output  string   `yaml:"output,omitempty"`
+	Command string   `yaml:"command,omitempty"` // Executable to run (default: the tool name on PATH)
+	Args    []string `yaml:"args,omitempty"`    // Extra arguments appended to the generated command line
+}
+
+// defaultCodeDocsOutput returns the default output directory of a generator.
+func defaultCodeDocsOutput(tool CodeDocsTool, docsPath string) string {
+	lang := "go"
+	if tool == CodeDocsTypedoc {
+		lang = "ts"
+	}
+	return path.Join(docsPath, "reference", lang)
+}
+
+// applyCodeDocsDefaults fills in default output directories; Paths must already be set.
+func applyCodeDocsDefaults(repo *Repository) {
+	for i := range repo.CodeDocs {
+		if repo.CodeDocs[i].Output == "" && len(repo.Paths) > 0 {
+			repo.CodeDocs[i].Output = defaultCodeDocsOutput(repo.CodeDocs[i].Tool, repo.Paths[0])
+		}
+	}
+}
+
+// validateCodeDocs checks the generators of a repository and that their output lies
+// inside one of its docs paths.
+func validateCodeDocs(repo *Repository) error {
+	if len(repo.CodeDocs) == 0 {
+		return nil
+	}
+	if repo.IsStatic() {
+		return errors.NewError(errors.CategoryValidation, "code_docs is not supported for static repositories").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	outputs := make(map[string]bool, len(repo.CodeDocs))
+	for _, g := range repo.CodeDocs {
+		switch g.Tool {
+		case CodeDocsGomarkdoc, CodeDocsTypedoc:
+		default:
+			return errors.NewError(errors.CategoryValidation, "unsupported code_docs tool").
+				WithContext("repository", repo.Name).
+				WithContext("tool", string(g.Tool)).
+				Build()
+		}
+		out := path.Clean(g.Output)
+		if g.Output == "" || out == "." || path.IsAbs(out) || out == ".." || strings.HasPrefix(out, "../") || !insideDocsPath(out, repo.Paths) {
+			return errors.NewError(errors.CategoryValidation, "code_docs output must be a directory inside one of the repository paths").
+				WithContext("repository", repo.Name).
+				WithContext("output", g.Output).
+				Build()
+		}
+		if outputs[out] {
+			return errors.NewError(errors.CategoryValidation, "duplicate code_docs output").
+				WithContext("repository", repo.Name).
+				WithContext("output", out).
+				Build()
+		}
+		outputs[out] = true
+	}
+	return nil
+}
+
+// insideDocsPath reports whether dir is strictly below one of the docs paths.
+func insideDocsPath(dir string, docsPaths []string) bool {
+	for _, p := range docsPaths {
+		p = path.Clean(p)
+		if p == "." || strings.HasPrefix(dir, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCodeDocsConfig(t *testing.T) {
+	base := func(repo Repository) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{repo}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(Repository{Name: "svc", URL: "https://example.com/svc.git", CodeDocs: []CodeDocsGenerator{
+		{Tool: CodeDocsGomarkdoc, Packages: []string{"./pkg/..."}},
+		{Tool: CodeDocsTypedoc},
+	}})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid code_docs, got %v", err)
+	}
+	gens := cfg.Repositories[0].CodeDocs
+	if gens[0].Output != "docs/reference/go" || gens[1].Output != "docs/reference/ts" {
+		t.Fatalf("unexpected default outputs: %q, %q", gens[0].Output, gens[1].Output)
+	}
+
+	cases := map[string]Repository{
+		"unsupported code_docs tool": {Name: "a", URL: "https://example.com/a.git",
+			CodeDocs: []CodeDocsGenerator{{Tool: "sphinx"}}},
+		"inside one of the repository paths": {Name: "a", URL: "https://example.com/a.git",
+			CodeDocs: []CodeDocsGenerator{{Tool: CodeDocsGomarkdoc, Output: "generated/go"}}},
+		"duplicate code_docs output": {Name: "a", URL: "https://example.com/a.git", CodeDocs: []CodeDocsGenerator{
+			{Tool: CodeDocsGomarkdoc, Output: "docs/api"}, {Tool: CodeDocsTypedoc, Output: "docs/api/"},
+		}},
+		"not supported for static repositories": {Name: "a", Type: RepositoryTypeStatic,
+			Static:   &StaticSource{Archive: "https://example.com/a.zip"},
+			CodeDocs: []CodeDocsGenerator{{Tool: CodeDocsGomarkdoc, Output: "api"}}},
+	}
+	for want, repo := range cases {
+		if err := ValidateConfig(base(repo)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
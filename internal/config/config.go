@@ -25,6 +25,29 @@ type Config struct {
 	Hugo       HugoConfig        `yaml:"hugo"`
 	Monitoring *MonitoringConfig `yaml:"monitoring,omitempty"`
 	Output     OutputConfig      `yaml:"output"`
+	Redirects  *RedirectsConfig  `yaml:"redirects,omitempty"`
+	Staleness  *StalenessConfig  `yaml:"staleness,omitempty"`
+	Templates  *TemplatesConfig  `yaml:"templates,omitempty"`
+	// FrontMatter configures how generated front matter merges with source front matter.
+	FrontMatter *FrontMatterConfig `yaml:"front_matter,omitempty"`
+	// Sanitize sets the compatibility policy for shortcodes and raw HTML in source markdown.
+	Sanitize *SanitizeConfig `yaml:"sanitize,omitempty"`
+	// Guardrails limit the pages and bytes a single repository may contribute to the site.
+	Guardrails *GuardrailsConfig `yaml:"guardrails,omitempty"`
+	// ADRIndex generates a site-wide index of architecture decision records.
+	ADRIndex *ADRIndexConfig `yaml:"adr_index,omitempty"`
+	// Spellcheck configures the spelling rule of `docbuilder lint`.
+	Spellcheck *SpellcheckConfig `yaml:"spellcheck,omitempty"`
+	// PageMetadata adds reading time and table of contents params to page front matter.
+	PageMetadata *PageMetadataConfig `yaml:"page_metadata,omitempty"`
+	// Export renders repositories into downloadable PDF/EPUB files.
+	Export *ExportConfig `yaml:"export,omitempty"`
+	// PostProcess configures work on the rendered site, such as offline archives.
+	PostProcess *PostProcessConfig `yaml:"post_process,omitempty"`
+	// Hooks runs user commands at fixed points of the build pipeline.
+	Hooks *BuildHooksConfig `yaml:"hooks,omitempty"`
+	// Purge calls CDN purge hooks with the pages that changed after a build is published.
+	Purge *PurgeConfig `yaml:"purge,omitempty"`
 	// Optional explicit repository list (direct mode) – replaces legacy v1 top‑level repositories.
 	// When present, these are used directly for build/discover operations. If empty and forges are
 	// configured, auto‑discovery can populate repositories dynamically.
@@ -48,6 +71,16 @@ type ForgeConfig struct {
 	Auth          *AuthConfig    `yaml:"auth"`          // Authentication config
 	Webhook       *WebhookConfig `yaml:"webhook"`       // Webhook configuration
 	Options       map[string]any `yaml:"options"`       // Forge-specific options
+	// Repositories and RepositoriesFile list the repositories to discover by full name.
+	// When set, discovery looks each one up through the API instead of listing
+	// organizations and groups.
+	Repositories     []string `yaml:"repositories,omitempty"`
+	RepositoriesFile string   `yaml:"repositories_file,omitempty"`
+	// Discovery policy: forks, archived repositories and repositories without a push
+	// within MinPushedWithin (e.g. "90d" or "720h") are skipped.
+	SkipForks       bool   `yaml:"skip_forks,omitempty"`
+	SkipArchived    *bool  `yaml:"skip_archived,omitempty"` // default: true
+	MinPushedWithin string `yaml:"min_pushed_within,omitempty"`
 }
 
 // WebhookConfig represents webhook configuration for a forge, including secret, path, and events.
@@ -56,16 +89,43 @@ type WebhookConfig struct {
 	Path         string   `yaml:"path"`          // Webhook endpoint path
 	Events       []string `yaml:"events"`        // Events to listen for
 	RegisterAuto bool     `yaml:"register_auto"` // Auto-register webhooks
+	// BuildAllPushes disables changed-file filtering so every push on the tracked
+	// branch requests a build, even when no file under the docs paths changed.
+	BuildAllPushes bool `yaml:"build_all_pushes,omitempty"`
+	// Branches lists glob patterns of branches whose pushes may trigger builds
+	// (for example "main" or "release/*"). Empty allows every branch.
+	Branches []string `yaml:"branches,omitempty"`
+	// AllowedIPs restricts deliveries to these addresses or CIDR ranges. Empty allows any source.
+	AllowedIPs []string `yaml:"allowed_ips,omitempty"`
+	// AllowForgeRanges also allows the forge's published webhook source ranges
+	// (GitHub meta API "hooks"), refreshed periodically.
+	AllowForgeRanges bool `yaml:"allow_forge_ranges,omitempty"`
+	// ClientCA is a PEM bundle; deliveries must present a client certificate it signed.
+	ClientCA string `yaml:"client_ca,omitempty"`
+	// Cooldown is the minimum time between webhook builds of each repository of the
+	// forge (for example "10m"). Zero or empty disables it.
+	Cooldown string `yaml:"cooldown,omitempty"`
 }
 
 // DaemonConfig represents daemon-specific configuration, including HTTP, sync, and storage settings.
 type DaemonConfig struct {
+	Role             string                  `yaml:"role,omitempty"` // builder (default) or server
 	HTTP             HTTPConfig              `yaml:"http"`
 	Sync             SyncConfig              `yaml:"sync"`
 	Storage          StorageConfig           `yaml:"storage"`
 	Content          DaemonContentConfig     `yaml:"content,omitempty"`
 	BuildDebounce    *BuildDebounceConfig    `yaml:"build_debounce,omitempty"`
 	LinkVerification *LinkVerificationConfig `yaml:"link_verification,omitempty"`
+	Feedback         *FeedbackConfig         `yaml:"feedback,omitempty"`
+	Analytics        *AnalyticsConfig        `yaml:"analytics,omitempty"`
+	TemplateAPI      *TemplateAPIConfig      `yaml:"template_api,omitempty"`
+	Watch            *WatchConfig            `yaml:"watch,omitempty"`
+	Maintenance      *MaintenanceConfig      `yaml:"maintenance,omitempty"`
+	LeaderElection   *LeaderElectionConfig   `yaml:"leader_election,omitempty"`
+	LinkCheck        *LinkCheckConfig        `yaml:"link_check,omitempty"`
+	Badges           *BadgesConfig           `yaml:"badges,omitempty"`
+	Reconciliation   *ReconciliationConfig   `yaml:"reconciliation,omitempty"`
+	Sites            []SiteConfig            `yaml:"sites,omitempty"`
 }
 
 // BuildDebounceConfig controls debouncing/coalescing behavior for build requests.
@@ -90,10 +150,22 @@ type DaemonContentConfig struct {
 
 // HTTPConfig represents HTTP server configuration for the daemon, including ports for docs, webhooks, and admin endpoints.
 type HTTPConfig struct {
-	DocsPort       int `yaml:"docs_port"`       // Documentation serving port
-	WebhookPort    int `yaml:"webhook_port"`    // Webhook reception port
-	AdminPort      int `yaml:"admin_port"`      // Admin/status endpoints port
-	LiveReloadPort int `yaml:"livereload_port"` // LiveReload SSE endpoint port (separate to avoid HTTP/1.1 blocking)
+	DocsPort       int    `yaml:"docs_port"`             // Documentation serving port
+	WebhookPort    int    `yaml:"webhook_port"`          // Webhook reception port
+	AdminPort      int    `yaml:"admin_port"`            // Admin/status endpoints port
+	LiveReloadPort int    `yaml:"livereload_port"`       // LiveReload SSE endpoint port (separate to avoid HTTP/1.1 blocking)
+	AdminToken     string `yaml:"admin_token,omitempty"` // Bearer token required by admin API and status endpoints (empty = open)
+	// TLS serves the docs port over HTTPS with HTTP/2 (optional).
+	TLS *HTTPTLSConfig `yaml:"tls,omitempty"`
+	// DrainTimeout bounds how long in-flight requests and streams may finish on shutdown (default: 15s).
+	DrainTimeout string `yaml:"drain_timeout,omitempty"`
+	// RateLimit throttles clients of the webhook and admin ports (optional).
+	RateLimit *RateLimitConfig `yaml:"rate_limit,omitempty"`
+	// TokenSigningKey signs scoped admin API tokens issued with `docbuilder token issue`.
+	// Empty disables scoped tokens; requires AdminToken.
+	TokenSigningKey string `yaml:"token_signing_key,omitempty"`
+	// RPC serves the control API (gRPC, gRPC-Web and Connect; see proto/) on the admin port.
+	RPC bool `yaml:"rpc,omitempty"`
 }
 
 // SyncConfig represents synchronization configuration for repository discovery and build queueing.
@@ -104,6 +176,12 @@ type SyncConfig struct {
 	// BuildOnDiscovery controls whether a forge discovery run should enqueue a
 	// build for discovered repositories. When unset, defaults to true.
 	BuildOnDiscovery *bool `yaml:"build_on_discovery,omitempty"`
+	// Jitter delays each scheduled sync by a random duration up to this value (e.g. "5m"),
+	// so that several instances with the same schedule do not hit the forges at once.
+	Jitter string `yaml:"jitter,omitempty"`
+	// BlackoutWindows are recurring periods in which scheduled syncs are skipped and
+	// other builds are held until the window ends.
+	BlackoutWindows []BlackoutWindow `yaml:"blackout_windows,omitempty"`
 }
 
 // StorageConfig represents storage configuration for state, repository cache, and output directories.
@@ -111,6 +189,8 @@ type StorageConfig struct {
 	StateFile    string `yaml:"state_file"`     // Path to state file
 	RepoCacheDir string `yaml:"repo_cache_dir"` // Directory for cached repositories
 	OutputDir    string `yaml:"output_dir"`     // Output directory for generated site
+	// Limits raise health warnings when the repository cache or output grows too large (optional).
+	Limits *StorageLimitsConfig `yaml:"limits,omitempty"`
 }
 
 // FilteringConfig represents repository filtering configuration, including required paths, ignore files, and name patterns.
@@ -119,6 +199,8 @@ type FilteringConfig struct {
 	IgnoreFiles     []string `yaml:"ignore_files"`     // Files that exclude repo (e.g., ".docignore")
 	IncludePatterns []string `yaml:"include_patterns"` // Repository name patterns to include
 	ExcludePatterns []string `yaml:"exclude_patterns"` // Repository name patterns to exclude
+	// TopicMappings assign site tags and categories to discovered repositories by forge topic.
+	TopicMappings []TopicMapping `yaml:"topic_mappings,omitempty"`
 }
 
 // VersioningConfig represents multi-version documentation configuration, including strategy and version limits.
@@ -129,6 +211,8 @@ type VersioningConfig struct {
 	BranchPatterns     []string           `yaml:"branch_patterns"`       // Branch patterns to include
 	TagPatterns        []string           `yaml:"tag_patterns"`          // Tag patterns to include
 	MaxVersionsPerRepo int                `yaml:"max_versions_per_repo"` // Maximum versions to keep per repo
+	Concurrency        int                `yaml:"concurrency,omitempty"` // Versions discovered and fetched in parallel (0 = serial)
+	Worktrees          bool               `yaml:"worktrees,omitempty"`   // Check versions out as worktrees of one shared clone per repository
 }
 
 // MonitoringConfig represents monitoring and observability configuration, including metrics, health, and logging.
@@ -149,10 +233,29 @@ type MonitoringHealth struct {
 	Path string `yaml:"path"`
 }
 
-// MonitoringLogging represents configuration for logging level and format.
+// MonitoringLogging represents configuration for logging level, format and sinks.
 type MonitoringLogging struct {
-	Level  LogLevel  `yaml:"level"`
-	Format LogFormat `yaml:"format"`
+	Level      LogLevel            `yaml:"level"`
+	Format     LogFormat           `yaml:"format"`
+	Components map[string]LogLevel `yaml:"components,omitempty"` // per-component level overrides (git, hugo, daemon, http)
+	File       *LogFileConfig      `yaml:"file,omitempty"`       // additionally write logs to a rotated file
+	Syslog     *LogSyslogConfig    `yaml:"syslog,omitempty"`     // additionally send logs to syslog (journald reads the local socket)
+	Access     *AccessLogConfig    `yaml:"access,omitempty"`     // per-request access logs of the HTTP servers
+}
+
+// LogFileConfig configures the rotated log file sink.
+type LogFileConfig struct {
+	Path       string `yaml:"path"`
+	MaxSizeMB  int    `yaml:"max_size_mb,omitempty"` // rotate once the file exceeds this size (0 = 100)
+	MaxBackups int    `yaml:"max_backups,omitempty"` // rotated files to keep (0 = 5)
+}
+
+// LogSyslogConfig configures the syslog sink.
+type LogSyslogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Network string `yaml:"network,omitempty"` // "udp", "tcp" or "unixgram"; empty uses the local syslog socket
+	Address string `yaml:"address,omitempty"` // remote syslog address (required with network)
+	Tag     string `yaml:"tag,omitempty"`     // syslog tag (default "docbuilder")
 }
 
 // LinkVerificationConfig represents configuration for automated link validation in daemon mode.
@@ -188,6 +291,7 @@ func Load(configPath string) (*Config, error) {
 func LoadWithResult(configPath string) (*LoadResult, *Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return nil, nil, errors.NewError(errors.CategoryConfig, "configuration file not found").
+			WithCode(errors.CodeConfigNotFound).
 			WithContext("path", configPath).
 			Build()
 	}
@@ -196,6 +300,7 @@ func LoadWithResult(configPath string) (*LoadResult, *Config, error) {
 	data, err := os.ReadFile(filepath.Clean(configPath))
 	if err != nil {
 		return nil, nil, errors.WrapError(err, errors.CategoryConfig, "failed to read config file").
+			WithCode(errors.CodeConfigParse).
 			WithContext("path", configPath).
 			Build()
 	}
@@ -205,14 +310,18 @@ func LoadWithResult(configPath string) (*LoadResult, *Config, error) {
 
 	var config Config
 	if err := yaml.Unmarshal([]byte(expandedData), &config); err != nil {
-		return nil, nil, errors.WrapError(err, errors.CategoryConfig, "failed to unmarshal v2 config").Build()
+		return nil, nil, errors.WrapError(err, errors.CategoryConfig, "failed to unmarshal v2 config").
+			WithCode(errors.CodeConfigParse).
+			Build()
 	}
 
 	// Validate version
 	if config.Version != configVersion {
 		return nil, nil, errors.NewError(errors.CategoryConfig, "unsupported configuration version").
+			WithCode(errors.CodeConfigVersion).
 			WithContext("actual", config.Version).
 			WithContext("expected", configVersion).
+			WithContext("hint", "run 'docbuilder config migrate' to upgrade the file").
 			Build()
 	}
 
@@ -233,7 +342,9 @@ func LoadWithResult(configPath string) (*LoadResult, *Config, error) {
 
 	// Validate configuration
 	if err := validateConfig(&config); err != nil {
-		return nil, nil, errors.WrapError(err, errors.CategoryConfig, "configuration validation failed").Build()
+		return nil, nil, errors.WrapError(err, errors.CategoryConfig, "configuration validation failed").
+			WithCode(errors.CodeConfigInvalid).
+			Build()
 	}
 
 	return result, &config, nil
@@ -0,0 +1,43 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// CopyStrategy selects how asset files (images and other non-markdown files) are
+// placed from the repository working copies into the generated site.
+type CopyStrategy string
+
+const (
+	// CopyStrategyCopy writes a full copy of every asset (the default).
+	CopyStrategyCopy CopyStrategy = "copy"
+	// CopyStrategyHardlink hard links assets to the working copy file. Git replaces
+	// changed files instead of rewriting them, so earlier builds keep their content.
+	CopyStrategyHardlink CopyStrategy = "hardlink"
+	// CopyStrategyReflink clones assets copy-on-write (Linux filesystems such as
+	// Btrfs and XFS).
+	CopyStrategyReflink CopyStrategy = "reflink"
+	// CopyStrategyAuto tries a reflink, then a hard link.
+	CopyStrategyAuto CopyStrategy = "auto"
+)
+
+// AssetCopyStrategy returns the configured copy strategy, defaulting to full copies.
+// Links need the working copy and the output on the same filesystem; assets that
+// cannot be linked are copied.
+func (b *BuildConfig) AssetCopyStrategy() CopyStrategy {
+	if b == nil || b.CopyStrategy == "" {
+		return CopyStrategyCopy
+	}
+	return b.CopyStrategy
+}
+
+// validateCopyStrategy checks build.copy_strategy.
+func (cv *configurationValidator) validateCopyStrategy() error {
+	switch cv.config.Build.CopyStrategy {
+	case "", CopyStrategyCopy, CopyStrategyHardlink, CopyStrategyReflink, CopyStrategyAuto:
+		return nil
+	default:
+		return errors.NewError(errors.CategoryValidation, "invalid copy_strategy").
+			WithContext("actual", string(cv.config.Build.CopyStrategy)).
+			WithContext("allowed", "copy|hardlink|reflink|auto").
+			Build()
+	}
+}
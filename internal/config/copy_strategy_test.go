@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_CopyStrategy(t *testing.T) {
+	for strategy, wantErr := range map[CopyStrategy]bool{
+		"":                   false,
+		CopyStrategyCopy:     false,
+		CopyStrategyHardlink: false,
+		CopyStrategyReflink:  false,
+		CopyStrategyAuto:     false,
+		"symlink":            true,
+	} {
+		cfg := Config{Version: "2.0", Repositories: []Repository{{Name: "r"}}, Build: BuildConfig{CopyStrategy: strategy}}
+		if err := applyDefaults(&cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		if err := ValidateConfig(&cfg); (err != nil) != wantErr {
+			t.Fatalf("copy_strategy %q: error = %v, wantErr %v", strategy, err, wantErr)
+		}
+	}
+	var b BuildConfig
+	if b.AssetCopyStrategy() != CopyStrategyCopy {
+		t.Fatalf("default copy strategy = %q", b.AssetCopyStrategy())
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchConfig_DebounceDuration(t *testing.T) {
+	var nilCfg *WatchConfig
+	if nilCfg.IsEnabled() {
+		t.Fatalf("nil watch config must be disabled")
+	}
+	if got := nilCfg.DebounceDuration(); got != defaultWatchDebounce {
+		t.Fatalf("expected default debounce, got %s", got)
+	}
+	if got := (&WatchConfig{Debounce: "500ms"}).DebounceDuration(); got != 500*time.Millisecond {
+		t.Fatalf("expected 500ms, got %s", got)
+	}
+}
+
+func TestValidateConfig_DaemonWatch(t *testing.T) {
+	for name, tc := range map[string]struct {
+		watch   WatchConfig
+		wantErr bool
+	}{
+		"valid":            {WatchConfig{Enabled: true, Debounce: "1s", Ignore: []string{"*.tmp", "node_modules"}}, false},
+		"invalid debounce": {WatchConfig{Enabled: true, Debounce: "soon"}, true},
+		"zero debounce":    {WatchConfig{Enabled: true, Debounce: "0s"}, true},
+		"invalid pattern":  {WatchConfig{Enabled: true, Ignore: []string{"[abc"}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			watch := tc.watch
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:  SyncConfig{Schedule: "0 */4 * * *"},
+					Watch: &watch,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
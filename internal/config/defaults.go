@@ -362,14 +362,47 @@ type RepositoryDefaultApplier struct{}
 func (r *RepositoryDefaultApplier) Domain() string { return "repositories" }
 
 func (r *RepositoryDefaultApplier) ApplyDefaults(cfg *Config) error {
-	for i := range cfg.Repositories {
-		if len(cfg.Repositories[i].Paths) == 0 {
-			cfg.Repositories[i].Paths = []string{"docs"}
-		}
-		if cfg.Repositories[i].Branch == "" {
-			cfg.Repositories[i].Branch = "main"
+	applyRepositoryDefaults(cfg.Repositories)
+	if cfg.Daemon != nil {
+		for i := range cfg.Daemon.Sites {
+			applyRepositoryDefaults(cfg.Daemon.Sites[i].Repositories)
 		}
 	}
-
 	return nil
 }
+
+func applyRepositoryDefaults(repos []Repository) {
+	for i := range repos {
+		if sections := repos[i].Sections; len(sections) > 0 {
+			paths := make([]string, 0, len(sections))
+			for _, s := range sections {
+				paths = append(paths, s.Path)
+			}
+			repos[i].Paths = paths
+		}
+		if repos[i].IsStatic() {
+			// Static sources are downloaded docs: aggregate the whole working copy and
+			// identify the repository (state, reports) by its download URL.
+			if len(repos[i].Paths) == 0 {
+				repos[i].Paths = []string{"."}
+			}
+			if repos[i].URL == "" {
+				repos[i].URL = repos[i].Static.SourceURL()
+			}
+			continue
+		}
+		if len(repos[i].Paths) == 0 {
+			repos[i].Paths = []string{"docs"}
+		}
+		applyCodeDocsDefaults(&repos[i])
+		if tag := repos[i].Tag; tag != "" {
+			if repos[i].Branch == "" {
+				repos[i].Branch = tag
+			}
+			repos[i].IsTag = repos[i].Branch == tag
+		}
+		if repos[i].Branch == "" {
+			repos[i].Branch = "main"
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"io"
+	"text/template"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// EditURLTemplateData holds the variables available to edit URL templates.
+type EditURLTemplateData struct {
+	SourceURL string // Repository web URL without a trailing .git (or the --edit-url-base override)
+	Branch    string // Branch the documentation was built from
+	Path      string // File path relative to the repository root, including the docs path
+}
+
+// ParseEditURLTemplate parses an edit URL template such as
+// "{{.SourceURL}}/src/edit/{{.Branch}}/{{.Path}}". Unknown fields are parse errors.
+func ParseEditURLTemplate(text string) (*template.Template, error) {
+	return template.New("edit_url").Option("missingkey=error").Parse(text)
+}
+
+// EditURLTemplateFor returns the repository's edit URL template, falling back to the global one.
+func (r *Repository) EditURLTemplateFor(global string) string {
+	if r.EditURLTemplate != "" {
+		return r.EditURLTemplate
+	}
+	return global
+}
+
+// validateEditURLTemplates checks that the global and per-repository edit URL templates
+// parse and only reference the supported variables.
+func (cv *configurationValidator) validateEditURLTemplates() error {
+	if err := checkEditURLTemplate(cv.config.Build.EditURLTemplate); err != nil {
+		return errors.NewError(errors.CategoryValidation, "invalid build.edit_url_template").
+			WithContext("error", err.Error()).
+			Build()
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if err := checkEditURLTemplate(repo.EditURLTemplate); err != nil {
+			return errors.NewError(errors.CategoryValidation, "invalid repository edit_url_template").
+				WithContext("repository", repo.Name).
+				WithContext("error", err.Error()).
+				Build()
+		}
+	}
+	return nil
+}
+
+func checkEditURLTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	tmpl, err := ParseEditURLTemplate(text)
+	if err != nil {
+		return err
+	}
+	// Execute against sample data so references to unknown fields are caught at load time.
+	return tmpl.Execute(io.Discard, EditURLTemplateData{SourceURL: "https://example.com/org/repo", Branch: "main", Path: "docs/index.md"})
+}
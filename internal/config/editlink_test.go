@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditURLTemplates(t *testing.T) {
+	base := func(global, perRepo string) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{
+			{Name: "app", URL: "https://example.com/app.git", EditURLTemplate: perRepo},
+		}}
+		cfg.Build.EditURLTemplate = global
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base("{{.SourceURL}}/edit/{{.Branch}}/{{.Path}}", "{{.SourceURL}}/src/{{.Branch}}/{{.Path}}?mode=edit")
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid templates, got %v", err)
+	}
+	if got := cfg.Repositories[0].EditURLTemplateFor(cfg.Build.EditURLTemplate); !strings.Contains(got, "?mode=edit") {
+		t.Fatalf("repository template should take precedence, got %q", got)
+	}
+	repo := Repository{}
+	if got := repo.EditURLTemplateFor("global"); got != "global" {
+		t.Fatalf("EditURLTemplateFor fallback = %q", got)
+	}
+
+	cases := map[string]*Config{
+		"invalid build.edit_url_template":      base("{{.SourceURL", ""),
+		"invalid repository edit_url_template": base("", "{{.Repo}}/edit"),
+	}
+	for want, c := range cases {
+		if err := ValidateConfig(c); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"path"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ExportFormat names a downloadable document format.
+type ExportFormat string
+
+const (
+	// ExportPDF renders a PDF (pandoc needs a PDF engine such as LaTeX or weasyprint).
+	ExportPDF ExportFormat = "pdf"
+	// ExportEPUB renders an EPUB e-book.
+	ExportEPUB ExportFormat = "epub"
+)
+
+// ExportConfig renders the documentation of each repository into downloadable files
+// (/downloads/<repo>.<format>) with pandoc, linked from the repository index.
+type ExportConfig struct {
+	Enabled bool           `yaml:"enabled"`           // Export every repository (repositories may opt out)
+	Formats []ExportFormat `yaml:"formats,omitempty"` // Formats to render (default [pdf])
+	Command string         `yaml:"command,omitempty"` // Executable to run (default: pandoc on PATH)
+	Args    []string       `yaml:"args,omitempty"`    // Extra pandoc arguments, e.g. --pdf-engine=weasyprint
+}
+
+// RepositoryExport overrides the export of one repository.
+type RepositoryExport struct {
+	Enabled *bool          `yaml:"enabled,omitempty"` // Opt in or out regardless of export.enabled
+	Formats []ExportFormat `yaml:"formats,omitempty"` // Formats for this repository (default: the global ones)
+	// Pages restricts the export to matching pages: globs on the path relative to the
+	// repository root (e.g. "docs/guide/*.md"). Empty exports every page.
+	Pages []string `yaml:"pages,omitempty"`
+}
+
+// ExportFormats returns the formats the repository is exported to, or nil when it is
+// not exported.
+func (r *Repository) ExportFormats(global *ExportConfig) []ExportFormat {
+	enabled := global != nil && global.Enabled
+	if r.Export != nil && r.Export.Enabled != nil {
+		enabled = *r.Export.Enabled
+	}
+	if !enabled {
+		return nil
+	}
+	switch {
+	case r.Export != nil && len(r.Export.Formats) > 0:
+		return r.Export.Formats
+	case global != nil && len(global.Formats) > 0:
+		return global.Formats
+	}
+	return []ExportFormat{ExportPDF}
+}
+
+// ExportPages returns the page globs that restrict the export of the repository.
+func (r *Repository) ExportPages() []string {
+	if r.Export == nil {
+		return nil
+	}
+	return r.Export.Pages
+}
+
+// ExportCommand returns the executable that renders exports.
+func (e *ExportConfig) ExportCommand() string {
+	if e == nil || e.Command == "" {
+		return "pandoc"
+	}
+	return e.Command
+}
+
+// snapshotValue renders the settings for config hashing.
+func (e *ExportConfig) snapshotValue() string {
+	formats := make([]string, len(e.Formats))
+	for i, f := range e.Formats {
+		formats[i] = string(f)
+	}
+	return strings.Join([]string{
+		boolToString(e.Enabled), strings.Join(formats, "|"), e.ExportCommand(), strings.Join(e.Args, " "),
+	}, ";")
+}
+
+func validExportFormats(formats []ExportFormat) bool {
+	for _, f := range formats {
+		if f != ExportPDF && f != ExportEPUB {
+			return false
+		}
+	}
+	return true
+}
+
+func (cv *configurationValidator) validateExport() error {
+	if e := cv.config.Export; e != nil && !validExportFormats(e.Formats) {
+		return errors.NewError(errors.CategoryValidation, "unsupported export format").
+			WithContext("formats", e.Formats).
+			WithContext("valid_values", []ExportFormat{ExportPDF, ExportEPUB}).
+			Build()
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if repo.Export == nil {
+			continue
+		}
+		if !validExportFormats(repo.Export.Formats) {
+			return errors.NewError(errors.CategoryValidation, "unsupported export format").
+				WithContext("repository", repo.Name).
+				WithContext("formats", repo.Export.Formats).
+				WithContext("valid_values", []ExportFormat{ExportPDF, ExportEPUB}).
+				Build()
+		}
+		for _, pattern := range repo.Export.Pages {
+			if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+				return errors.NewError(errors.CategoryValidation, "invalid export page pattern").
+					WithContext("repository", repo.Name).
+					WithContext("pattern", pattern).
+					Build()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepository_ExportFormats(t *testing.T) {
+	on, off := true, false
+	repo := Repository{Name: "r"}
+	if got := repo.ExportFormats(nil); got != nil {
+		t.Fatalf("expected no export without configuration, got %v", got)
+	}
+	global := &ExportConfig{Enabled: true}
+	if got := repo.ExportFormats(global); !reflect.DeepEqual(got, []ExportFormat{ExportPDF}) {
+		t.Fatalf("expected default [pdf], got %v", got)
+	}
+	global.Formats = []ExportFormat{ExportEPUB}
+	repo.Export = &RepositoryExport{Formats: []ExportFormat{ExportPDF, ExportEPUB}}
+	if got := repo.ExportFormats(global); len(got) != 2 {
+		t.Fatalf("expected repository formats, got %v", got)
+	}
+	repo.Export.Enabled = &off
+	if got := repo.ExportFormats(global); got != nil {
+		t.Fatalf("expected opt-out, got %v", got)
+	}
+	repo.Export = &RepositoryExport{Enabled: &on}
+	if got := repo.ExportFormats(&ExportConfig{Formats: []ExportFormat{ExportEPUB}}); !reflect.DeepEqual(got, []ExportFormat{ExportEPUB}) {
+		t.Fatalf("expected opt-in with global formats, got %v", got)
+	}
+}
+
+func TestValidateConfig_Export(t *testing.T) {
+	base := func(global *ExportConfig, repo *RepositoryExport) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git", Export: repo}},
+			Export:       global,
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(&ExportConfig{Enabled: true, Formats: []ExportFormat{ExportPDF}}, &RepositoryExport{Pages: []string{"docs/guide/*.md"}})); err != nil {
+		t.Fatalf("expected valid export, got %v", err)
+	}
+	if err := ValidateConfig(base(&ExportConfig{Formats: []ExportFormat{"docx"}}, nil)); err == nil || !strings.Contains(err.Error(), "unsupported export format") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+	if err := ValidateConfig(base(nil, &RepositoryExport{Pages: []string{"docs/[guide"}})); err == nil || !strings.Contains(err.Error(), "invalid export page pattern") {
+		t.Fatalf("expected pattern error, got %v", err)
+	}
+}
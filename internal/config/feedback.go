@@ -0,0 +1,33 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// defaultFeedbackMaxCommentLength bounds free-text feedback when max_comment_length is unset.
+const defaultFeedbackMaxCommentLength = 2000
+
+// FeedbackConfig controls the optional "Was this page helpful?" widget served by the daemon.
+type FeedbackConfig struct {
+	Enabled          bool   `yaml:"enabled"`
+	DatabasePath     string `yaml:"database_path,omitempty"`      // SQLite file (default: <repo_cache_dir>/feedback.db)
+	MaxCommentLength int    `yaml:"max_comment_length,omitempty"` // Longer comments are rejected (default 2000)
+}
+
+// IsEnabled reports whether the feedback subsystem is active.
+func (f *FeedbackConfig) IsEnabled() bool { return f != nil && f.Enabled }
+
+// CommentLimit returns the effective maximum comment length in bytes.
+func (f *FeedbackConfig) CommentLimit() int {
+	if f == nil || f.MaxCommentLength <= 0 {
+		return defaultFeedbackMaxCommentLength
+	}
+	return f.MaxCommentLength
+}
+
+func validateDaemonFeedback(f *FeedbackConfig) error {
+	if f.MaxCommentLength < 0 {
+		return errors.NewError(errors.CategoryValidation, "daemon feedback max_comment_length must be >= 0").
+			WithContext("value", f.MaxCommentLength).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// FetchMode selects how the content of a git repository is downloaded.
+type FetchMode string
+
+const (
+	// FetchModeClone clones the repository with git (the default).
+	FetchModeClone FetchMode = "clone"
+	// FetchModeArchive downloads an archive of the commit from the forge (GitHub or
+	// GitLab) and extracts the docs paths. Repositories the forge cannot serve as an
+	// archive, or that need git features, are cloned instead.
+	FetchModeArchive FetchMode = "archive"
+)
+
+// FetchesArchive reports whether the repository is downloaded as an archive when possible.
+func (r *Repository) FetchesArchive() bool {
+	return r.Fetch == FetchModeArchive
+}
+
+// validateRepoFetch checks the fetch mode of a repository.
+func validateRepoFetch(repo *Repository) error {
+	switch repo.Fetch {
+	case "", FetchModeClone:
+		return nil
+	case FetchModeArchive:
+		if repo.IsStatic() {
+			return errors.NewError(errors.CategoryValidation, "repository fetch mode archive requires a git repository").
+				WithContext("repository", repo.Name).
+				Build()
+		}
+		return nil
+	default:
+		return errors.NewError(errors.CategoryValidation, "unsupported repository fetch mode").
+			WithContext("repository", repo.Name).
+			WithContext("fetch", string(repo.Fetch)).
+			WithContext("allowed", "clone, archive").
+			Build()
+	}
+}
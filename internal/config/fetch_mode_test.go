@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepositoryFetchMode(t *testing.T) {
+	base := func(repo Repository) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{repo}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	for _, mode := range []FetchMode{"", FetchModeClone, FetchModeArchive} {
+		cfg := base(Repository{Name: "docs", URL: "https://github.com/org/docs.git", Fetch: mode})
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatalf("fetch %q: expected valid config, got %v", mode, err)
+		}
+		if got := cfg.Repositories[0].FetchesArchive(); got != (mode == FetchModeArchive) {
+			t.Fatalf("fetch %q: FetchesArchive() = %v", mode, got)
+		}
+	}
+
+	cases := map[string]Repository{
+		"unsupported repository fetch mode": {Name: "a", URL: "https://github.com/org/a.git", Fetch: "mirror"},
+		"fetch mode archive requires a git repository": {Name: "a", Type: RepositoryTypeStatic, Fetch: FetchModeArchive,
+			Static: &StaticSource{Archive: "https://example.com/a.zip"}},
+	}
+	for want, repo := range cases {
+		if err := ValidateConfig(base(repo)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// HasRepositoryAllowlist reports whether the forge discovers a fixed list of
+// repositories (repositories or repositories_file) instead of listing organizations.
+func (f *ForgeConfig) HasRepositoryAllowlist() bool {
+	return f != nil && (len(f.Repositories) > 0 || f.RepositoriesFile != "")
+}
+
+// RepositoryAllowlist returns the full names (owner/name, or group/subgroup/name on
+// GitLab) listed in repositories and repositories_file, in order and without
+// duplicates. The file is read on every call so that edits apply at the next
+// discovery run. It holds either a list of names, or a mapping from forge name to a
+// list of names so one file can serve several forges.
+func (f *ForgeConfig) RepositoryAllowlist() ([]string, error) {
+	names := append([]string(nil), f.Repositories...)
+	if f.RepositoriesFile != "" {
+		listed, err := readRepositoryAllowlistFile(f.RepositoriesFile, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, listed...)
+	}
+
+	seen := make(map[string]bool, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.Trim(strings.TrimSpace(name), "/")
+		if name == "" || seen[name] {
+			continue
+		}
+		if err := validateAllowlistName(f.Name, name); err != nil {
+			return nil, err
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+func readRepositoryAllowlistFile(path, forgeName string) ([]string, error) {
+	// #nosec G304 -- path comes from the operator's configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.CategoryConfig, "failed to read repositories_file").
+			WithContext("forge", forgeName).
+			WithContext("path", path).
+			Build()
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.WrapError(err, errors.CategoryConfig, "failed to parse repositories_file").
+			WithCode(errors.CodeConfigParse).
+			WithContext("path", path).
+			Build()
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var names []string
+	root := doc.Content[0]
+	switch root.Kind {
+	case yaml.SequenceNode:
+		err = root.Decode(&names)
+	case yaml.MappingNode:
+		var byForge map[string][]string
+		if err = root.Decode(&byForge); err == nil {
+			names = byForge[forgeName]
+		}
+	default:
+		return nil, errors.NewError(errors.CategoryConfig, "repositories_file must hold a list of repositories or a mapping of forge names to lists").
+			WithContext("path", path).
+			Build()
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.CategoryConfig, "failed to parse repositories_file").
+			WithCode(errors.CodeConfigParse).
+			WithContext("path", path).
+			Build()
+	}
+	return names, nil
+}
+
+func validateAllowlistName(forgeName, name string) error {
+	if !strings.Contains(name, "/") || strings.Contains(name, "//") {
+		return errors.NewError(errors.CategoryValidation, "forge repositories must be full names (owner/name)").
+			WithContext("forge", forgeName).
+			WithContext("repository", name).
+			Build()
+	}
+	return nil
+}
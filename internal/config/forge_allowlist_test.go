@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestForgeConfig_RepositoryAllowlist(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+
+	for name, tc := range map[string]struct {
+		forge   ForgeConfig
+		want    []string
+		wantErr bool
+	}{
+		"inline": {
+			forge: ForgeConfig{Name: "gh", Repositories: []string{"acme/docs", " acme/api/ ", "acme/docs"}},
+			want:  []string{"acme/docs", "acme/api"},
+		},
+		"list file": {
+			forge: ForgeConfig{Name: "gh", Repositories: []string{"acme/docs"}, RepositoriesFile: write("list.yaml", "- acme/api\n- acme/docs\n")},
+			want:  []string{"acme/docs", "acme/api"},
+		},
+		"mapping file": {
+			forge: ForgeConfig{Name: "gl", RepositoriesFile: write("map.yaml", "gh: [acme/docs]\ngl: [platform/sub/ops]\n")},
+			want:  []string{"platform/sub/ops"},
+		},
+		"mapping without forge": {
+			forge: ForgeConfig{Name: "other", RepositoriesFile: write("map2.yaml", "gh: [acme/docs]\n")},
+			want:  []string{},
+		},
+		"missing file": {
+			forge:   ForgeConfig{Name: "gh", RepositoriesFile: filepath.Join(dir, "absent.yaml")},
+			wantErr: true,
+		},
+		"scalar file": {
+			forge:   ForgeConfig{Name: "gh", RepositoriesFile: write("scalar.yaml", "acme/docs\n")},
+			wantErr: true,
+		},
+		"name without owner": {
+			forge:   ForgeConfig{Name: "gh", Repositories: []string{"docs"}},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.forge.RepositoryAllowlist()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RepositoryAllowlist() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !slices.Equal(got, tc.want) {
+				t.Fatalf("RepositoryAllowlist() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_ForgeRepositoryAllowlist(t *testing.T) {
+	for name, tc := range map[string]struct {
+		repos   []string
+		file    string
+		wantErr bool
+	}{
+		"listed repositories": {repos: []string{"acme/docs"}},
+		"repositories file":   {file: "repositories.yaml"},
+		"invalid name":        {repos: []string{"docs"}, wantErr: true},
+		"no scope":            {wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{
+				Version: "2.0",
+				Forges: []*ForgeConfig{{
+					Name: "gh", Type: ForgeGitHub,
+					Auth:         &AuthConfig{Type: AuthTypeToken, Token: "t"},
+					Repositories: tc.repos, RepositoriesFile: tc.file,
+				}},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// SkipsArchived reports whether discovery skips archived repositories of the forge.
+func (f *ForgeConfig) SkipsArchived() bool {
+	return f == nil || f.SkipArchived == nil || *f.SkipArchived
+}
+
+// PushedWithin returns the age of the last push beyond which discovery skips a
+// repository, or 0 when repositories are not skipped for inactivity.
+func (f *ForgeConfig) PushedWithin() time.Duration {
+	if f == nil {
+		return 0
+	}
+	d, err := parseDayDuration(f.MinPushedWithin)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// parseDayDuration parses a Go duration, or a whole number of days such as "90d".
+func parseDayDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func validateForgeDiscoveryPolicy(forge *ForgeConfig) error {
+	if strings.TrimSpace(forge.MinPushedWithin) == "" {
+		return nil
+	}
+	if d, err := parseDayDuration(forge.MinPushedWithin); err != nil || d <= 0 {
+		return errors.NewError(errors.CategoryValidation, "forge min_pushed_within must be a positive duration (e.g. 90d or 720h)").
+			WithContext("forge", forge.Name).
+			WithContext("value", forge.MinPushedWithin).
+			Build()
+	}
+	return nil
+}
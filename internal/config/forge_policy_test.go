@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForgeConfig_DiscoveryPolicy(t *testing.T) {
+	var unset *ForgeConfig
+	if !unset.SkipsArchived() || unset.PushedWithin() != 0 {
+		t.Fatalf("nil forge: skips archived %v, pushed within %v", unset.SkipsArchived(), unset.PushedWithin())
+	}
+
+	keep := false
+	forge := &ForgeConfig{Name: "gh", SkipArchived: &keep, MinPushedWithin: "90d"}
+	if forge.SkipsArchived() {
+		t.Fatalf("skip_archived: false must keep archived repositories")
+	}
+	if got, want := forge.PushedWithin(), 90*24*time.Hour; got != want {
+		t.Fatalf("PushedWithin = %v, want %v", got, want)
+	}
+	forge.MinPushedWithin = "720h"
+	if got, want := forge.PushedWithin(), 720*time.Hour; got != want {
+		t.Fatalf("PushedWithin = %v, want %v", got, want)
+	}
+}
+
+func TestValidateForgeDiscoveryPolicy(t *testing.T) {
+	for value, wantErr := range map[string]bool{
+		"":      false,
+		"30d":   false,
+		"36h":   false,
+		"0d":    true,
+		"-5d":   true,
+		"month": true,
+		"1.5d":  true,
+	} {
+		err := validateForgeDiscoveryPolicy(&ForgeConfig{Name: "gh", MinPushedWithin: value})
+		if (err != nil) != wantErr {
+			t.Errorf("min_pushed_within %q: err = %v, want error %v", value, err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// FrontMatterMergeStrategy decides how front matter generated by DocBuilder combines
+// with a value the source file already sets for the same key.
+type FrontMatterMergeStrategy string
+
+const (
+	// FrontMatterSourceWins keeps the source value.
+	FrontMatterSourceWins FrontMatterMergeStrategy = "source-wins"
+	// FrontMatterBuilderWins replaces the source value with the generated one.
+	FrontMatterBuilderWins FrontMatterMergeStrategy = "builder-wins"
+	// FrontMatterDeepMerge combines lists (source entries first, no duplicates) and maps
+	// (recursively); for scalars the source value is kept.
+	FrontMatterDeepMerge FrontMatterMergeStrategy = "deep-merge"
+)
+
+// FrontMatterConfig overrides the built-in merge behavior for generated front matter keys.
+// Unset keys keep their built-in behavior: descriptive metadata (type, date, lastmod, owners,
+// editURL, ...) never overwrites source values, provenance keys (repository, forge, section,
+// source_commit, stale) are always set by the builder and aliases are merged.
+type FrontMatterConfig struct {
+	Merge FrontMatterMergeStrategy            `yaml:"merge,omitempty"` // Strategy for every generated key
+	Keys  map[string]FrontMatterMergeStrategy `yaml:"keys,omitempty"`  // Per-key strategies (take precedence over merge)
+	Dates *FrontMatterDatesConfig             `yaml:"dates,omitempty"` // Date normalization
+}
+
+// FrontMatterDatesConfig controls how source front matter dates are normalized to RFC3339.
+type FrontMatterDatesConfig struct {
+	// Normalize rewrites recognized date formats to RFC3339 and drops unparseable dates.
+	// When unset, defaults to true.
+	Normalize *bool `yaml:"normalize,omitempty"`
+	// Timezone is the IANA zone applied to dates without an offset (default UTC).
+	Timezone string `yaml:"timezone,omitempty"`
+	// Keys are the front matter keys holding dates (default date, publishDate, lastmod, expiryDate).
+	Keys []string `yaml:"keys,omitempty"`
+}
+
+// DefaultFrontMatterDateKeys are the Hugo date keys normalized when none are configured.
+var DefaultFrontMatterDateKeys = []string{"date", "publishDate", "lastmod", "expiryDate"}
+
+// NormalizeDates reports whether source front matter dates are normalized (default true).
+func (f *FrontMatterConfig) NormalizeDates() bool {
+	if f == nil || f.Dates == nil || f.Dates.Normalize == nil {
+		return true
+	}
+	return *f.Dates.Normalize
+}
+
+// DateLocation returns the zone applied to dates without an offset (UTC when unset or invalid).
+func (f *FrontMatterConfig) DateLocation() *time.Location {
+	if f == nil || f.Dates == nil || f.Dates.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(f.Dates.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DateKeys returns the front matter keys normalized as dates.
+func (f *FrontMatterConfig) DateKeys() []string {
+	if f == nil || f.Dates == nil || len(f.Dates.Keys) == 0 {
+		return DefaultFrontMatterDateKeys
+	}
+	return f.Dates.Keys
+}
+
+// StrategyFor returns the strategy for key: the per-key setting, then the global one, then fallback.
+func (f *FrontMatterConfig) StrategyFor(key string, fallback FrontMatterMergeStrategy) FrontMatterMergeStrategy {
+	if f == nil {
+		return fallback
+	}
+	if s, ok := f.Keys[key]; ok && s != "" {
+		return s
+	}
+	if f.Merge != "" {
+		return f.Merge
+	}
+	return fallback
+}
+
+// snapshotValue renders the configured strategies in a stable order for config hashing.
+func (f *FrontMatterConfig) snapshotValue() string {
+	parts := []string{"merge:" + string(f.Merge)}
+	for key, s := range f.Keys {
+		parts = append(parts, key+":"+string(s))
+	}
+	sort.Strings(parts[1:])
+	if d := f.Dates; d != nil {
+		parts = append(parts, "dates:"+strings.Join([]string{
+			boolToString(f.NormalizeDates()), d.Timezone, strings.Join(d.Keys, "|"),
+		}, ";"))
+	}
+	return strings.Join(parts, ",")
+}
+
+func validFrontMatterMergeStrategy(s FrontMatterMergeStrategy) bool {
+	switch s {
+	case FrontMatterSourceWins, FrontMatterBuilderWins, FrontMatterDeepMerge:
+		return true
+	}
+	return false
+}
+
+func (cv *configurationValidator) validateFrontMatter() error {
+	f := cv.config.FrontMatter
+	if f == nil {
+		return nil
+	}
+	if f.Merge != "" && !validFrontMatterMergeStrategy(f.Merge) {
+		return errors.NewError(errors.CategoryValidation, "invalid front_matter merge strategy").
+			WithContext("value", f.Merge).
+			WithContext("valid_values", []FrontMatterMergeStrategy{FrontMatterSourceWins, FrontMatterBuilderWins, FrontMatterDeepMerge}).
+			Build()
+	}
+	for key, s := range f.Keys {
+		if !validFrontMatterMergeStrategy(s) {
+			return errors.NewError(errors.CategoryValidation, "invalid front_matter merge strategy").
+				WithContext("key", key).
+				WithContext("value", s).
+				WithContext("valid_values", []FrontMatterMergeStrategy{FrontMatterSourceWins, FrontMatterBuilderWins, FrontMatterDeepMerge}).
+				Build()
+		}
+	}
+	if d := f.Dates; d != nil {
+		if d.Timezone != "" {
+			if _, err := time.LoadLocation(d.Timezone); err != nil {
+				return errors.NewError(errors.CategoryValidation, "invalid front_matter dates timezone").
+					WithContext("value", d.Timezone).
+					Build()
+			}
+		}
+		for _, key := range d.Keys {
+			if strings.TrimSpace(key) == "" {
+				return errors.NewError(errors.CategoryValidation, "front_matter dates keys must not be empty").Build()
+			}
+		}
+	}
+	return nil
+}
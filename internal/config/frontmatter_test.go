@@ -0,0 +1,75 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig_FrontMatter(t *testing.T) {
+	base := func(f *FrontMatterConfig) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}}, FrontMatter: f}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(&FrontMatterConfig{
+		Merge: FrontMatterSourceWins,
+		Keys:  map[string]FrontMatterMergeStrategy{"aliases": FrontMatterDeepMerge, "date": FrontMatterBuilderWins},
+	})); err != nil {
+		t.Fatalf("expected valid front_matter config, got %v", err)
+	}
+	for _, f := range []*FrontMatterConfig{
+		{Merge: "last-wins"},
+		{Keys: map[string]FrontMatterMergeStrategy{"weight": "merge"}},
+	} {
+		if err := ValidateConfig(base(f)); err == nil || !strings.Contains(err.Error(), "invalid front_matter merge strategy") {
+			t.Fatalf("expected invalid strategy error for %+v, got %v", f, err)
+		}
+	}
+}
+
+func TestFrontMatterConfig_StrategyFor(t *testing.T) {
+	var unset *FrontMatterConfig
+	if got := unset.StrategyFor("date", FrontMatterSourceWins); got != FrontMatterSourceWins {
+		t.Fatalf("nil config: got %s", got)
+	}
+	f := &FrontMatterConfig{Merge: FrontMatterBuilderWins, Keys: map[string]FrontMatterMergeStrategy{"weight": FrontMatterSourceWins}}
+	if got := f.StrategyFor("weight", FrontMatterDeepMerge); got != FrontMatterSourceWins {
+		t.Fatalf("per-key: got %s", got)
+	}
+	if got := f.StrategyFor("date", FrontMatterSourceWins); got != FrontMatterBuilderWins {
+		t.Fatalf("global: got %s", got)
+	}
+}
+
+func TestValidateConfig_FrontMatterDates(t *testing.T) {
+	base := func(d *FrontMatterDatesConfig) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}}, FrontMatter: &FrontMatterConfig{Dates: d}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(&FrontMatterDatesConfig{Timezone: "Europe/Oslo", Keys: []string{"date", "reviewed"}})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid dates config, got %v", err)
+	}
+	if loc := cfg.FrontMatter.DateLocation(); loc.String() != "Europe/Oslo" {
+		t.Fatalf("location: got %s", loc)
+	}
+	if err := ValidateConfig(base(&FrontMatterDatesConfig{Timezone: "Mars/Olympus"})); err == nil || !strings.Contains(err.Error(), "invalid front_matter dates timezone") {
+		t.Fatalf("expected timezone error, got %v", err)
+	}
+	if err := ValidateConfig(base(&FrontMatterDatesConfig{Keys: []string{" "}})); err == nil {
+		t.Fatal("expected empty key error")
+	}
+
+	var unset *FrontMatterConfig
+	if !unset.NormalizeDates() || unset.DateLocation() != time.UTC || len(unset.DateKeys()) != 4 {
+		t.Fatal("nil config: expected normalization in UTC for the Hugo date keys")
+	}
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// GenerateOptions describes the configuration written by `docbuilder config generate`.
+type GenerateOptions struct {
+	Forges       []GenerateForge
+	Repositories []string // Clone URLs of explicitly listed repositories
+	Title        string
+	BaseURL      string
+	Daemon       bool // Include a daemon section (ports, schedule, storage, admin token)
+	Webhooks     bool // Configure a webhook for every forge
+}
+
+// GenerateForge is one forge of a generated configuration.
+type GenerateForge struct {
+	Type ForgeType
+	// URL is the web URL of a self-hosted instance; empty uses github.com or gitlab.com.
+	URL string
+	// Organizations are organizations (GitHub, Forgejo) or groups (GitLab).
+	Organizations []string
+}
+
+// ParseForgeSpec parses a forge given as TYPE=ORG[,ORG...][@URL], for example
+// "github=acme,acme-labs" or "gitlab=platform@https://gitlab.example.com".
+func ParseForgeSpec(spec string) (GenerateForge, error) {
+	typ, rest, ok := strings.Cut(strings.TrimSpace(spec), "=")
+	if !ok {
+		return GenerateForge{}, errors.NewError(errors.CategoryValidation, "forge must be given as TYPE=ORG[,ORG...][@URL]").
+			WithContext("forge", spec).
+			Build()
+	}
+	orgs, url, _ := strings.Cut(rest, "@")
+	f := GenerateForge{Type: ForgeType(strings.ToLower(strings.TrimSpace(typ))), URL: strings.TrimSpace(url)}
+	for _, org := range strings.Split(orgs, ",") {
+		if org = strings.TrimSpace(org); org != "" {
+			f.Organizations = append(f.Organizations, org)
+		}
+	}
+	return f, nil
+}
+
+// Generate builds a configuration from opts, validates it the way Load does and returns
+// it as YAML. Secrets are written as ${VAR} placeholders that Load expands from the
+// environment; a comment header lists the variables to set.
+func Generate(opts GenerateOptions) ([]byte, error) {
+	cfg := Config{
+		Version: "2.0",
+		Hugo:    HugoConfig{Title: opts.Title, BaseURL: opts.BaseURL},
+		Output:  OutputConfig{Directory: "./site", Clean: true},
+	}
+	placeholders := []string{}
+	names := map[string]int{}
+	for _, f := range opts.Forges {
+		fc, err := generateForge(f, names)
+		if err != nil {
+			return nil, err
+		}
+		placeholders = append(placeholders, placeholderVar(fc.Auth.Token))
+		if opts.Webhooks {
+			fc.Webhook = &WebhookConfig{
+				Secret: "${" + envVarName(fc.Name) + "_WEBHOOK_SECRET}",
+				Path:   "/webhooks/" + fc.Name,
+				Events: []string{"push"},
+			}
+			placeholders = append(placeholders, placeholderVar(fc.Webhook.Secret))
+		}
+		cfg.Forges = append(cfg.Forges, fc)
+	}
+	for _, url := range opts.Repositories {
+		cfg.Repositories = append(cfg.Repositories, Repository{URL: url, Name: repositoryNameFromURL(url)})
+	}
+	if opts.Daemon {
+		cfg.Daemon = &DaemonConfig{
+			HTTP: HTTPConfig{
+				DocsPort:       8080,
+				WebhookPort:    8081,
+				AdminPort:      8082,
+				LiveReloadPort: 8083,
+				AdminToken:     "${DOCBUILDER_ADMIN_TOKEN}",
+			},
+			Sync: SyncConfig{Schedule: "0 */4 * * *", ConcurrentBuilds: 3, QueueSize: 100},
+			Storage: StorageConfig{
+				StateFile:    "./docbuilder-state.json",
+				RepoCacheDir: "./repositories",
+				OutputDir:    "./site",
+			},
+		}
+		placeholders = append(placeholders, "DOCBUILDER_ADMIN_TOKEN")
+	}
+
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.CategoryConfig, "failed to marshal generated config").Build()
+	}
+	if err := ValidateYAML(data); err != nil {
+		return nil, err
+	}
+	return append([]byte(generatedHeader(placeholders)), data...), nil
+}
+
+// generateForge builds the forge section for f; names counts the forges per type so a
+// second forge of the same type gets a distinct name and token variable.
+func generateForge(f GenerateForge, names map[string]int) (*ForgeConfig, error) {
+	base := strings.TrimRight(f.URL, "/")
+	fc := &ForgeConfig{Type: f.Type, BaseURL: base}
+	switch f.Type {
+	case ForgeGitHub:
+		fc.Organizations = f.Organizations
+		fc.APIURL = base + "/api/v3"
+		if base == "" {
+			fc.BaseURL, fc.APIURL = "https://github.com", "https://api.github.com"
+		}
+	case ForgeGitLab:
+		fc.Groups = f.Organizations
+		if base == "" {
+			fc.BaseURL = "https://gitlab.com"
+		}
+		fc.APIURL = fc.BaseURL + "/api/v4"
+	case ForgeForgejo:
+		if base == "" {
+			return nil, errors.NewError(errors.CategoryValidation, "forgejo forge requires a URL (TYPE=ORG@URL)").Build()
+		}
+		fc.Organizations = f.Organizations
+		fc.APIURL = base + "/api/v1"
+	default:
+		return nil, errors.NewError(errors.CategoryValidation, "unsupported forge type for generation").
+			WithContext("type", f.Type).
+			WithContext("valid_types", []string{string(ForgeGitHub), string(ForgeGitLab), string(ForgeForgejo)}).
+			Build()
+	}
+	if len(f.Organizations) == 0 {
+		return nil, errors.NewError(errors.CategoryValidation, "forge requires at least one organization or group").
+			WithContext("type", f.Type).
+			Build()
+	}
+	names[string(f.Type)]++
+	fc.Name = string(f.Type)
+	if n := names[string(f.Type)]; n > 1 {
+		fc.Name = fmt.Sprintf("%s-%d", f.Type, n)
+	}
+	fc.Auth = &AuthConfig{Type: AuthTypeToken, Token: "${" + envVarName(fc.Name) + "_TOKEN}"}
+	return fc, nil
+}
+
+// ValidateYAML checks configuration YAML the way Load does, minus environment expansion,
+// so ${VAR} placeholders count as set values. Tools that write configuration files use it.
+func ValidateYAML(data []byte) error {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "failed to parse config").Build()
+	}
+	if _, err := NormalizeConfig(&cfg); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "failed to normalize config").Build()
+	}
+	if err := applyDefaults(&cfg); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "failed to apply defaults").Build()
+	}
+	if err := ValidateConfig(&cfg); err != nil {
+		return errors.WrapError(err, errors.CategoryConfig, "configuration validation failed").
+			WithCode(errors.CodeConfigInvalid).
+			Build()
+	}
+	return nil
+}
+
+func generatedHeader(placeholders []string) string {
+	sort.Strings(placeholders)
+	var b strings.Builder
+	b.WriteString("# Generated by `docbuilder config generate`.\n")
+	if len(placeholders) > 0 {
+		b.WriteString("# Set these environment variables before running docbuilder:\n")
+		for _, p := range placeholders {
+			b.WriteString("#   " + p + "\n")
+		}
+	}
+	return b.String()
+}
+
+// envVarName turns a forge name such as "github-2" into an environment variable prefix.
+func envVarName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+func placeholderVar(placeholder string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(placeholder, "${"), "}")
+}
+
+// repositoryNameFromURL returns the last path element of a clone URL without ".git".
+func repositoryNameFromURL(url string) string {
+	url = strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if i := strings.LastIndexAny(url, ":/"); i >= 0 {
+		url = url[i+1:]
+	}
+	return path.Clean(url)
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_LoadsWithPlaceholdersSet(t *testing.T) {
+	gitlab, err := ParseForgeSpec("gitlab=platform,docs@https://gitlab.example.com/")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	data, err := Generate(GenerateOptions{
+		Forges: []GenerateForge{
+			{Type: ForgeGitHub, Organizations: []string{"acme"}},
+			{Type: ForgeGitHub, URL: "https://ghe.example.com", Organizations: []string{"internal"}},
+			gitlab,
+		},
+		Repositories: []string{"git@github.com:acme/handbook.git"},
+		Title:        "Docs",
+		Daemon:       true,
+		Webhooks:     true,
+	})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, want := range []string{"#   GITHUB_TOKEN", "#   GITHUB_2_WEBHOOK_SECRET", "#   GITLAB_TOKEN", "#   DOCBUILDER_ADMIN_TOKEN"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("header missing %q:\n%s", want, data)
+		}
+	}
+
+	for _, v := range []string{"GITHUB_TOKEN", "GITHUB_2_TOKEN", "GITLAB_TOKEN", "GITHUB_WEBHOOK_SECRET", "GITHUB_2_WEBHOOK_SECRET", "GITLAB_WEBHOOK_SECRET", "DOCBUILDER_ADMIN_TOKEN"} {
+		t.Setenv(v, "secret-"+v)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load generated config: %v\n%s", err, data)
+	}
+	if len(cfg.Forges) != 3 {
+		t.Fatalf("expected 3 forges, got %d", len(cfg.Forges))
+	}
+	ghe := cfg.Forges[1]
+	if ghe.Name != "github-2" || ghe.APIURL != "https://ghe.example.com/api/v3" || ghe.Auth.Token != "secret-GITHUB_2_TOKEN" {
+		t.Fatalf("unexpected GitHub Enterprise forge: %+v", ghe)
+	}
+	gl := cfg.Forges[2]
+	if gl.APIURL != "https://gitlab.example.com/api/v4" || len(gl.Groups) != 2 || gl.Webhook.Path != "/webhooks/gitlab" {
+		t.Fatalf("unexpected GitLab forge: %+v", gl)
+	}
+	if cfg.Repositories[0].Name != "handbook" {
+		t.Fatalf("expected repository name handbook, got %q", cfg.Repositories[0].Name)
+	}
+	if cfg.Daemon == nil || cfg.Daemon.HTTP.AdminToken != "secret-DOCBUILDER_ADMIN_TOKEN" {
+		t.Fatalf("expected daemon admin token from the environment, got %+v", cfg.Daemon)
+	}
+}
+
+func TestGenerate_Rejects(t *testing.T) {
+	cases := map[string]GenerateOptions{
+		"either forges or repositories":      {},
+		"forgejo forge requires a URL":       {Forges: []GenerateForge{{Type: ForgeForgejo, Organizations: []string{"o"}}}},
+		"at least one organization or group": {Forges: []GenerateForge{{Type: ForgeGitHub}}},
+		"unsupported forge type":             {Forges: []GenerateForge{{Type: ForgeLocal, Organizations: []string{"o"}}}},
+	}
+	for want, opts := range cases {
+		if _, err := Generate(opts); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+	if _, err := ParseForgeSpec("github"); err == nil {
+		t.Fatal("expected error for a forge without organizations")
+	}
+}
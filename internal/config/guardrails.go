@@ -0,0 +1,105 @@
+package config
+
+import (
+	"strconv"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// GuardrailAction decides what happens when a repository exceeds a content guardrail.
+type GuardrailAction string
+
+const (
+	// GuardrailWarn records the violation as a build warning (default).
+	GuardrailWarn GuardrailAction = "warn"
+	// GuardrailFail aborts the build.
+	GuardrailFail GuardrailAction = "fail"
+)
+
+// GuardrailsConfig limits how much content a single repository may contribute to the site,
+// protecting shared build times. Zero limits are not enforced.
+type GuardrailsConfig struct {
+	MaxPages       int             `yaml:"max_pages,omitempty"`         // markdown pages per repository
+	MaxTotalSizeMB int             `yaml:"max_total_size_mb,omitempty"` // markdown and assets per repository
+	MaxAssetSizeMB int             `yaml:"max_asset_size_mb,omitempty"` // largest single asset
+	Action         GuardrailAction `yaml:"action,omitempty"`            // warn|fail (default: warn)
+}
+
+// Enabled reports whether any limit is configured.
+func (g *GuardrailsConfig) Enabled() bool {
+	return g != nil && (g.MaxPages > 0 || g.MaxTotalSizeMB > 0 || g.MaxAssetSizeMB > 0)
+}
+
+// Fails reports whether violations abort the build.
+func (g *GuardrailsConfig) Fails() bool {
+	return g != nil && g.Action == GuardrailFail
+}
+
+// GuardrailLimits returns the repository's effective guardrails: each limit (and the action)
+// it sets overrides the global value.
+func (r *Repository) GuardrailLimits(global *GuardrailsConfig) *GuardrailsConfig {
+	if r.Guardrails == nil {
+		return global
+	}
+	limits := *r.Guardrails
+	if global != nil {
+		if limits.MaxPages == 0 {
+			limits.MaxPages = global.MaxPages
+		}
+		if limits.MaxTotalSizeMB == 0 {
+			limits.MaxTotalSizeMB = global.MaxTotalSizeMB
+		}
+		if limits.MaxAssetSizeMB == 0 {
+			limits.MaxAssetSizeMB = global.MaxAssetSizeMB
+		}
+		if limits.Action == "" {
+			limits.Action = global.Action
+		}
+	}
+	return &limits
+}
+
+// snapshotValue renders the limits in a stable order for config hashing.
+func (g *GuardrailsConfig) snapshotValue() string {
+	return "pages=" + strconv.Itoa(g.MaxPages) +
+		";total_mb=" + strconv.Itoa(g.MaxTotalSizeMB) +
+		";asset_mb=" + strconv.Itoa(g.MaxAssetSizeMB) +
+		";action=" + string(g.Action)
+}
+
+func (cv *configurationValidator) validateGuardrails() error {
+	if err := validateGuardrailsConfig(cv.config.Guardrails, ""); err != nil {
+		return err
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if err := validateGuardrailsConfig(repo.Guardrails, repo.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateGuardrailsConfig(g *GuardrailsConfig, repository string) error {
+	if g == nil {
+		return nil
+	}
+	if g.MaxPages < 0 || g.MaxTotalSizeMB < 0 || g.MaxAssetSizeMB < 0 {
+		return errors.NewError(errors.CategoryValidation, "guardrail limits must be >= 0").
+			WithContext("repository", repository).
+			WithContext("max_pages", g.MaxPages).
+			WithContext("max_total_size_mb", g.MaxTotalSizeMB).
+			WithContext("max_asset_size_mb", g.MaxAssetSizeMB).
+			Build()
+	}
+	switch g.Action {
+	case "", GuardrailWarn, GuardrailFail:
+	default:
+		return errors.NewError(errors.CategoryValidation, "invalid guardrail action").
+			WithContext("repository", repository).
+			WithContext("value", string(g.Action)).
+			WithContext("valid_values", []string{string(GuardrailWarn), string(GuardrailFail)}).
+			Build()
+	}
+	return nil
+}
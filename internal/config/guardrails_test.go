@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuardrailsConfig(t *testing.T) {
+	base := func(global, perRepo *GuardrailsConfig) *Config {
+		cfg := &Config{Version: "2.0", Guardrails: global, Repositories: []Repository{
+			{Name: "app", URL: "https://example.com/app.git", Guardrails: perRepo},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	global := &GuardrailsConfig{MaxPages: 500, MaxTotalSizeMB: 200, Action: GuardrailFail}
+	perRepo := &GuardrailsConfig{MaxAssetSizeMB: 50, Action: GuardrailWarn}
+	cfg := base(global, perRepo)
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid guardrails config, got %v", err)
+	}
+
+	limits := cfg.Repositories[0].GuardrailLimits(cfg.Guardrails)
+	if limits.MaxPages != 500 || limits.MaxTotalSizeMB != 200 || limits.MaxAssetSizeMB != 50 {
+		t.Fatalf("unexpected merged limits: %+v", limits)
+	}
+	if limits.Fails() {
+		t.Fatalf("repository action should override the global one")
+	}
+	if !cfg.Guardrails.Fails() || (*GuardrailsConfig)(nil).Enabled() || (&GuardrailsConfig{Action: GuardrailFail}).Enabled() {
+		t.Fatalf("unexpected Enabled/Fails results")
+	}
+
+	cases := map[string]*GuardrailsConfig{
+		"guardrail limits must be >= 0": {MaxPages: -1},
+		"invalid guardrail action":      {MaxPages: 10, Action: "block"},
+	}
+	for want, c := range cases {
+		if err := ValidateConfig(base(nil, c)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
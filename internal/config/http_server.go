@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/apitoken"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// defaultDrainTimeout is the shutdown grace period for in-flight requests and streams.
+const defaultDrainTimeout = 15 * time.Second
+
+// Default token buckets per client and endpoint.
+var (
+	defaultWebhookRateLimit = RateLimit{Rate: 10, Burst: 20}
+	defaultAdminRateLimit   = RateLimit{Rate: 5, Burst: 20}
+)
+
+// HTTPTLSConfig points the docs server at a certificate and key (PEM files).
+type HTTPTLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// TLSEnabled reports whether the docs server is served over TLS.
+func (h *HTTPConfig) TLSEnabled() bool {
+	return h.TLS != nil && h.TLS.CertFile != "" && h.TLS.KeyFile != ""
+}
+
+// DrainTimeoutValue returns the shutdown grace period, or the default when unset or invalid.
+func (h *HTTPConfig) DrainTimeoutValue() time.Duration {
+	if h.DrainTimeout == "" {
+		return defaultDrainTimeout
+	}
+	d, err := time.ParseDuration(h.DrainTimeout)
+	if err != nil || d < 0 {
+		return defaultDrainTimeout
+	}
+	return d
+}
+
+// RateLimitConfig throttles requests per client IP and endpoint on the webhook and admin ports.
+type RateLimitConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	Webhook RateLimit `yaml:"webhook,omitempty"` // default: 10/s, burst 20
+	Admin   RateLimit `yaml:"admin,omitempty"`   // default: 5/s, burst 20
+	// TrustProxyHeaders identifies clients by the last X-Forwarded-For entry / X-Real-IP
+	// instead of the connection address. Only enable behind a proxy that sets these headers.
+	TrustProxyHeaders bool `yaml:"trust_proxy_headers,omitempty"`
+}
+
+// RateLimit is a token bucket: Rate requests per second on average, bursts of up to Burst.
+type RateLimit struct {
+	Rate  float64 `yaml:"rate,omitempty"`
+	Burst int     `yaml:"burst,omitempty"`
+}
+
+// IsEnabled reports whether rate limiting is enabled.
+func (r *RateLimitConfig) IsEnabled() bool { return r != nil && r.Enabled }
+
+// WebhookLimit returns the webhook port bucket with defaults applied.
+func (r *RateLimitConfig) WebhookLimit() RateLimit {
+	return r.Webhook.orDefault(defaultWebhookRateLimit)
+}
+
+// AdminLimit returns the admin port bucket with defaults applied.
+func (r *RateLimitConfig) AdminLimit() RateLimit { return r.Admin.orDefault(defaultAdminRateLimit) }
+
+func (l RateLimit) orDefault(def RateLimit) RateLimit {
+	if l.Rate <= 0 {
+		l.Rate = def.Rate
+	}
+	if l.Burst <= 0 {
+		l.Burst = max(def.Burst, int(l.Rate))
+	}
+	return l
+}
+
+func validateDaemonHTTP(h *HTTPConfig) error {
+	if h.TLS != nil && (h.TLS.CertFile == "") != (h.TLS.KeyFile == "") {
+		return errors.NewError(errors.CategoryValidation, "daemon http tls requires both cert_file and key_file").
+			WithContext("cert_file", h.TLS.CertFile).
+			WithContext("key_file", h.TLS.KeyFile).
+			Build()
+	}
+	if h.DrainTimeout != "" {
+		if d, err := time.ParseDuration(h.DrainTimeout); err != nil || d < 0 {
+			return errors.NewError(errors.CategoryValidation, "invalid daemon http drain_timeout").
+				WithContext("value", h.DrainTimeout).
+				Build()
+		}
+	}
+	if h.TokenSigningKey != "" {
+		if len(h.TokenSigningKey) < apitoken.MinKeyLength {
+			return errors.NewError(errors.CategoryValidation, fmt.Sprintf("daemon http token_signing_key must be at least %d bytes", apitoken.MinKeyLength)).
+				WithContext("length", len(h.TokenSigningKey)).
+				Build()
+		}
+		if h.AdminToken == "" {
+			return errors.NewError(errors.CategoryValidation, "daemon http token_signing_key requires admin_token; without it the admin API is open").
+				Build()
+		}
+	}
+	if h.RateLimit != nil {
+		for server, limit := range map[string]RateLimit{"webhook": h.RateLimit.Webhook, "admin": h.RateLimit.Admin} {
+			if limit.Rate < 0 || limit.Burst < 0 {
+				return errors.NewError(errors.CategoryValidation, "daemon http rate_limit rate and burst must not be negative").
+					WithContext("server", server).
+					WithContext("rate", limit.Rate).
+					WithContext("burst", limit.Burst).
+					Build()
+			}
+		}
+	}
+	return nil
+}
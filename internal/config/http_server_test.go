@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig_DaemonHTTP(t *testing.T) {
+	for name, tc := range map[string]struct {
+		http    HTTPConfig
+		wantErr bool
+	}{
+		"defaults":               {HTTPConfig{}, false},
+		"tls with cert and key":  {HTTPConfig{TLS: &HTTPTLSConfig{CertFile: "c.pem", KeyFile: "k.pem"}}, false},
+		"tls without key":        {HTTPConfig{TLS: &HTTPTLSConfig{CertFile: "c.pem"}}, true},
+		"drain timeout":          {HTTPConfig{DrainTimeout: "45s"}, false},
+		"invalid drain timeout":  {HTTPConfig{DrainTimeout: "soon"}, true},
+		"negative drain timeout": {HTTPConfig{DrainTimeout: "-1s"}, true},
+		"rate limit":             {HTTPConfig{RateLimit: &RateLimitConfig{Enabled: true, Admin: RateLimit{Rate: 1, Burst: 5}}}, false},
+		"token signing key":      {HTTPConfig{AdminToken: "a", TokenSigningKey: strings.Repeat("k", 32)}, false},
+		"short signing key":      {HTTPConfig{AdminToken: "a", TokenSigningKey: "short"}, true},
+		"signing key no admin":   {HTTPConfig{TokenSigningKey: strings.Repeat("k", 32)}, true},
+		"negative rate limit":    {HTTPConfig{RateLimit: &RateLimitConfig{Enabled: true, Webhook: RateLimit{Rate: -1}}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{Version: "2.0", Repositories: []Repository{{Name: "r"}}, Daemon: &DaemonConfig{
+				HTTP: tc.http,
+				Sync: SyncConfig{Schedule: "0 */4 * * *"},
+			}}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPConfig_DrainTimeoutValue(t *testing.T) {
+	if got := (&HTTPConfig{}).DrainTimeoutValue(); got != 15*time.Second {
+		t.Fatalf("default drain timeout = %s, want 15s", got)
+	}
+	if got := (&HTTPConfig{DrainTimeout: "0s"}).DrainTimeoutValue(); got != 0 {
+		t.Fatalf("explicit zero drain timeout = %s, want 0", got)
+	}
+}
+
+func TestRateLimitConfig_Defaults(t *testing.T) {
+	rl := &RateLimitConfig{Enabled: true, Admin: RateLimit{Rate: 50}}
+	if got := rl.WebhookLimit(); got != (RateLimit{Rate: 10, Burst: 20}) {
+		t.Fatalf("default webhook limit = %+v", got)
+	}
+	if got := rl.AdminLimit(); got != (RateLimit{Rate: 50, Burst: 50}) {
+		t.Fatalf("admin limit with rate only = %+v, want burst to cover the rate", got)
+	}
+}
@@ -10,6 +10,7 @@ type HugoConfig struct {
 	Menu                  map[string][]Menu `yaml:"menu,omitempty"`
 	Taxonomies            map[string]string `yaml:"taxonomies,omitempty"` // custom taxonomies (e.g., "category": "categories", "tag": "tags")
 	Transforms            *HugoTransforms   `yaml:"transforms,omitempty"` // optional transform filtering
+	SEO                   *SEOConfig        `yaml:"seo,omitempty"`        // sitemap/robots/canonical post-processing
 }
 
 // HugoTransforms allows users to enable/disable specific named content transforms.
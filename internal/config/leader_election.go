@@ -0,0 +1,113 @@
+package config
+
+import (
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// Leader election backends.
+const (
+	LeaderElectionKubernetes = "kubernetes"
+	LeaderElectionFile       = "file"
+)
+
+// Leader election defaults (the same timings as Kubernetes controllers).
+const (
+	defaultLeaseName     = "docbuilder"
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElectionConfig lets several daemon replicas share one site: only the replica
+// holding the lease runs discovery and builds, every replica serves the docs.
+type LeaderElectionConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Backend       string `yaml:"backend,omitempty"`        // kubernetes (Lease object) or file (lease file on shared storage)
+	Identity      string `yaml:"identity,omitempty"`       // Replica identity (default: hostname, the pod name in Kubernetes)
+	LeaseName     string `yaml:"lease_name,omitempty"`     // Lease object name (default: docbuilder)
+	Namespace     string `yaml:"namespace,omitempty"`      // Lease namespace (default: the pod's namespace)
+	Path          string `yaml:"path,omitempty"`           // Lease file for the file backend
+	LeaseDuration string `yaml:"lease_duration,omitempty"` // How long followers wait before taking over (default: 15s)
+	RenewDeadline string `yaml:"renew_deadline,omitempty"` // How long the leader retries renewing before stepping down (default: 10s)
+	RetryPeriod   string `yaml:"retry_period,omitempty"`   // Interval between acquire/renew attempts (default: 2s)
+}
+
+// IsEnabled reports whether leader election is active.
+func (l *LeaderElectionConfig) IsEnabled() bool { return l != nil && l.Enabled }
+
+// LeaseNameOrDefault returns the lease name, or "docbuilder" when unset.
+func (l *LeaderElectionConfig) LeaseNameOrDefault() string {
+	if l == nil || l.LeaseName == "" {
+		return defaultLeaseName
+	}
+	return l.LeaseName
+}
+
+// LeaseDurationValue returns the lease duration, or the default when unset or invalid.
+func (l *LeaderElectionConfig) LeaseDurationValue() time.Duration {
+	return leaderDuration(l, func(c *LeaderElectionConfig) string { return c.LeaseDuration }, defaultLeaseDuration)
+}
+
+// RenewDeadlineValue returns the renew deadline, or the default when unset or invalid.
+func (l *LeaderElectionConfig) RenewDeadlineValue() time.Duration {
+	return leaderDuration(l, func(c *LeaderElectionConfig) string { return c.RenewDeadline }, defaultRenewDeadline)
+}
+
+// RetryPeriodValue returns the retry period, or the default when unset or invalid.
+func (l *LeaderElectionConfig) RetryPeriodValue() time.Duration {
+	return leaderDuration(l, func(c *LeaderElectionConfig) string { return c.RetryPeriod }, defaultRetryPeriod)
+}
+
+func leaderDuration(l *LeaderElectionConfig, field func(*LeaderElectionConfig) string, def time.Duration) time.Duration {
+	if l == nil || field(l) == "" {
+		return def
+	}
+	d, err := time.ParseDuration(field(l))
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+func validateDaemonLeaderElection(l *LeaderElectionConfig) error {
+	if !l.Enabled {
+		return nil
+	}
+	switch l.Backend {
+	case LeaderElectionKubernetes:
+	case LeaderElectionFile:
+		if l.Path == "" {
+			return errors.NewError(errors.CategoryValidation, "daemon leader_election.path is required for the file backend").
+				Build()
+		}
+	default:
+		return errors.NewError(errors.CategoryValidation, "daemon leader_election.backend must be kubernetes or file").
+			WithContext("value", l.Backend).
+			Build()
+	}
+	for field, value := range map[string]string{
+		"lease_duration": l.LeaseDuration,
+		"renew_deadline": l.RenewDeadline,
+		"retry_period":   l.RetryPeriod,
+	} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return errors.NewError(errors.CategoryValidation, "daemon leader_election durations must be positive").
+				WithContext("field", field).
+				WithContext("value", value).
+				Build()
+		}
+	}
+	if !(l.LeaseDurationValue() > l.RenewDeadlineValue() && l.RenewDeadlineValue() > l.RetryPeriodValue()) {
+		return errors.NewError(errors.CategoryValidation, "daemon leader_election requires lease_duration > renew_deadline > retry_period").
+			WithContext("lease_duration", l.LeaseDurationValue().String()).
+			WithContext("renew_deadline", l.RenewDeadlineValue().String()).
+			WithContext("retry_period", l.RetryPeriodValue().String()).
+			Build()
+	}
+	return nil
+}
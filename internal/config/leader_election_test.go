@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaderElectionConfig_Defaults(t *testing.T) {
+	var nilCfg *LeaderElectionConfig
+	if nilCfg.IsEnabled() {
+		t.Fatalf("nil leader election config must be disabled")
+	}
+	if got := nilCfg.LeaseNameOrDefault(); got != "docbuilder" {
+		t.Fatalf("expected default lease name, got %q", got)
+	}
+	if got := nilCfg.LeaseDurationValue(); got != 15*time.Second {
+		t.Fatalf("expected 15s lease duration, got %s", got)
+	}
+	if got := (&LeaderElectionConfig{RetryPeriod: "500ms"}).RetryPeriodValue(); got != 500*time.Millisecond {
+		t.Fatalf("expected 500ms retry period, got %s", got)
+	}
+}
+
+func TestValidateConfig_DaemonLeaderElection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		election LeaderElectionConfig
+		wantErr  bool
+	}{
+		"disabled":            {LeaderElectionConfig{Backend: "zookeeper"}, false},
+		"kubernetes":          {LeaderElectionConfig{Enabled: true, Backend: LeaderElectionKubernetes}, false},
+		"file":                {LeaderElectionConfig{Enabled: true, Backend: LeaderElectionFile, Path: "/shared/leader.json"}, false},
+		"file without path":   {LeaderElectionConfig{Enabled: true, Backend: LeaderElectionFile}, true},
+		"unknown backend":     {LeaderElectionConfig{Enabled: true, Backend: "zookeeper"}, true},
+		"invalid duration":    {LeaderElectionConfig{Enabled: true, Backend: LeaderElectionKubernetes, LeaseDuration: "soon"}, true},
+		"deadline over lease": {LeaderElectionConfig{Enabled: true, Backend: LeaderElectionKubernetes, LeaseDuration: "5s"}, true},
+		"custom timings": {LeaderElectionConfig{
+			Enabled: true, Backend: LeaderElectionKubernetes,
+			LeaseDuration: "30s", RenewDeadline: "20s", RetryPeriod: "5s",
+		}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			election := tc.election
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:           SyncConfig{Schedule: "0 */4 * * *"},
+					LeaderElection: &election,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
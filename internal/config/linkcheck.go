@@ -0,0 +1,101 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+const (
+	defaultLinkCheckInterval      = 24 * time.Hour
+	defaultLinkCheckCacheTTL      = 24 * time.Hour
+	defaultLinkCheckTimeout       = 10 * time.Second
+	defaultLinkCheckMaxConcurrent = 8
+	defaultLinkCheckPerHost       = 2
+	minLinkCheckInterval          = time.Minute
+)
+
+// LinkCheckConfig controls the periodic external link check of the published site.
+// Unlike link_verification it needs no NATS server: results are kept in the daemon's
+// state directory and served on the admin API.
+type LinkCheckConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	Interval       string   `yaml:"interval,omitempty"`        // Time between runs (default 24h)
+	MaxConcurrent  int      `yaml:"max_concurrent,omitempty"`  // Links checked in parallel (default 8)
+	PerHost        int      `yaml:"per_host,omitempty"`        // Links checked in parallel per host (default 2)
+	RequestTimeout string   `yaml:"request_timeout,omitempty"` // Per request (default 10s)
+	CacheTTL       string   `yaml:"cache_ttl,omitempty"`       // Working links are not rechecked for this long (default 24h)
+	Ignore         []string `yaml:"ignore,omitempty"`          // URL prefixes that are not checked
+}
+
+// IsEnabled reports whether the periodic link check runs.
+func (l *LinkCheckConfig) IsEnabled() bool { return l != nil && l.Enabled }
+
+// IntervalDuration returns the time between runs.
+func (l *LinkCheckConfig) IntervalDuration() time.Duration {
+	return linkCheckDuration(l, func(c *LinkCheckConfig) string { return c.Interval }, defaultLinkCheckInterval)
+}
+
+// Timeout returns the per-request timeout.
+func (l *LinkCheckConfig) Timeout() time.Duration {
+	return linkCheckDuration(l, func(c *LinkCheckConfig) string { return c.RequestTimeout }, defaultLinkCheckTimeout)
+}
+
+// CacheDuration returns how long a working link is not rechecked.
+func (l *LinkCheckConfig) CacheDuration() time.Duration {
+	return linkCheckDuration(l, func(c *LinkCheckConfig) string { return c.CacheTTL }, defaultLinkCheckCacheTTL)
+}
+
+// Concurrency returns the number of links checked in parallel.
+func (l *LinkCheckConfig) Concurrency() int {
+	if l == nil || l.MaxConcurrent <= 0 {
+		return defaultLinkCheckMaxConcurrent
+	}
+	return l.MaxConcurrent
+}
+
+// HostConcurrency returns the number of links of one host checked in parallel.
+func (l *LinkCheckConfig) HostConcurrency() int {
+	if l == nil || l.PerHost <= 0 {
+		return defaultLinkCheckPerHost
+	}
+	return l.PerHost
+}
+
+func linkCheckDuration(l *LinkCheckConfig, field func(*LinkCheckConfig) string, def time.Duration) time.Duration {
+	if l == nil || strings.TrimSpace(field(l)) == "" {
+		return def
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(field(l)))
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+func validateDaemonLinkCheck(l *LinkCheckConfig) error {
+	for name, value := range map[string]string{"interval": l.Interval, "request_timeout": l.RequestTimeout, "cache_ttl": l.CacheTTL} {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return errors.NewError(errors.CategoryValidation, "daemon link_check "+name+" must be a positive duration").
+				WithContext("value", value).
+				Build()
+		}
+		if name == "interval" && d < minLinkCheckInterval {
+			return errors.NewError(errors.CategoryValidation, "daemon link_check interval must be at least 1m").
+				WithContext("value", value).
+				Build()
+		}
+	}
+	if l.MaxConcurrent < 0 || l.PerHost < 0 {
+		return errors.NewError(errors.CategoryValidation, "daemon link_check max_concurrent and per_host must be >= 0").
+			WithContext("max_concurrent", l.MaxConcurrent).
+			WithContext("per_host", l.PerHost).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLinkCheckConfig_Defaults(t *testing.T) {
+	var nilCfg *LinkCheckConfig
+	if nilCfg.IsEnabled() {
+		t.Fatalf("nil link_check config must be disabled")
+	}
+	if nilCfg.IntervalDuration() != defaultLinkCheckInterval || nilCfg.Timeout() != defaultLinkCheckTimeout ||
+		nilCfg.CacheDuration() != defaultLinkCheckCacheTTL {
+		t.Fatalf("expected default durations")
+	}
+	if nilCfg.Concurrency() != defaultLinkCheckMaxConcurrent || nilCfg.HostConcurrency() != defaultLinkCheckPerHost {
+		t.Fatalf("expected default concurrency")
+	}
+	cfg := &LinkCheckConfig{Interval: "6h", CacheTTL: "72h", MaxConcurrent: 3}
+	if cfg.IntervalDuration() != 6*time.Hour || cfg.CacheDuration() != 72*time.Hour || cfg.Concurrency() != 3 {
+		t.Fatalf("configured values not used: %+v", cfg)
+	}
+}
+
+func TestValidateConfig_DaemonLinkCheck(t *testing.T) {
+	for name, tc := range map[string]struct {
+		check   LinkCheckConfig
+		wantErr bool
+	}{
+		"valid":             {LinkCheckConfig{Enabled: true, Interval: "12h", RequestTimeout: "5s", CacheTTL: "48h", PerHost: 1}, false},
+		"defaults":          {LinkCheckConfig{Enabled: true}, false},
+		"invalid interval":  {LinkCheckConfig{Enabled: true, Interval: "daily"}, true},
+		"interval too low":  {LinkCheckConfig{Enabled: true, Interval: "10s"}, true},
+		"invalid timeout":   {LinkCheckConfig{Enabled: true, RequestTimeout: "0s"}, true},
+		"negative per_host": {LinkCheckConfig{Enabled: true, PerHost: -1}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			check := tc.check
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:      SyncConfig{Schedule: "0 */4 * * *"},
+					LinkCheck: &check,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
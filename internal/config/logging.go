@@ -41,3 +41,44 @@ var logFormatNormalizer = normalization.NewNormalizer(map[string]LogFormat{
 func NormalizeLogFormat(raw string) LogFormat {
 	return logFormatNormalizer.Normalize(raw)
 }
+
+// LogComponents lists the components whose log level can be set in monitoring.logging.components.
+var LogComponents = []string{"daemon", "git", "hugo", "http"}
+
+const (
+	defaultLogFileMaxSizeMB  = 100
+	defaultLogFileMaxBackups = 5
+	defaultSyslogTag         = "docbuilder"
+)
+
+// ComponentLevel returns the level of a component, falling back to the global level.
+func (l MonitoringLogging) ComponentLevel(component string) LogLevel {
+	if lvl, ok := l.Components[component]; ok && lvl != "" {
+		return lvl
+	}
+	return l.Level
+}
+
+// MaxSizeBytes returns the size at which the log file is rotated.
+func (f *LogFileConfig) MaxSizeBytes() int64 {
+	if f == nil || f.MaxSizeMB <= 0 {
+		return defaultLogFileMaxSizeMB << 20
+	}
+	return int64(f.MaxSizeMB) << 20
+}
+
+// Backups returns how many rotated log files are kept.
+func (f *LogFileConfig) Backups() int {
+	if f == nil || f.MaxBackups <= 0 {
+		return defaultLogFileMaxBackups
+	}
+	return f.MaxBackups
+}
+
+// EffectiveTag returns the syslog tag.
+func (s *LogSyslogConfig) EffectiveTag() string {
+	if s == nil || s.Tag == "" {
+		return defaultSyslogTag
+	}
+	return s.Tag
+}
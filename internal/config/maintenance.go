@@ -0,0 +1,21 @@
+package config
+
+// DefaultMaintenanceBanner is shown on documentation pages while the daemon is in
+// maintenance mode and no banner is configured.
+const DefaultMaintenanceBanner = "Documentation updates are paused for maintenance. Some pages may be out of date."
+
+// MaintenanceConfig controls the daemon's maintenance mode. While active, webhooks
+// are accepted but builds are held, scheduled discovery pauses and the docs server
+// shows a banner. Leaving maintenance mode runs one build for the held requests.
+type MaintenanceConfig struct {
+	Enabled bool   `yaml:"enabled"`          // Start the daemon in maintenance mode
+	Banner  string `yaml:"banner,omitempty"` // Message shown on documentation pages
+}
+
+// BannerText returns the configured banner, or the default when unset.
+func (m *MaintenanceConfig) BannerText() string {
+	if m == nil || m.Banner == "" {
+		return DefaultMaintenanceBanner
+	}
+	return m.Banner
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// legacyVersion is the schema version reported for files without a version field, which
+// is how version 1 configurations were written.
+const legacyVersion = "1"
+
+// Migration upgrades a configuration document from one schema version to the next.
+// Migrations edit the YAML node tree, so comments and key order survive.
+type Migration struct {
+	From        string // Schema version the migration applies to
+	To          string // Schema version it produces
+	Description string
+	// Apply edits the document's top-level mapping and returns a note per change.
+	Apply func(root *yaml.Node) []string
+}
+
+// migrations is the registry of schema upgrades, applied in sequence until the current
+// version is reached. A change to the schema adds an entry here (From: the previous
+// version) and bumps configVersion.
+var migrations = []Migration{
+	{
+		From:        legacyVersion,
+		To:          "2.0",
+		Description: "Version 1 to the unified 2.0 schema",
+		Apply:       migrateV1,
+	},
+}
+
+// MigrationStep records one applied migration.
+type MigrationStep struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Description string   `json:"description"`
+	Changes     []string `json:"changes"`
+}
+
+// MigrationResult is the outcome of MigrateYAML.
+type MigrationResult struct {
+	From   string          // Schema version of the input
+	To     string          // Schema version of the output (the current one)
+	Steps  []MigrationStep // Migrations applied; empty when the input was current
+	Output []byte          // Migrated document
+}
+
+// CurrentVersion returns the configuration schema version this build reads.
+func CurrentVersion() string {
+	return configVersion
+}
+
+// SchemaVersion returns the schema version of a configuration document: its version
+// field, or "1" when the field is missing.
+func SchemaVersion(data []byte) (string, error) {
+	var v struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return "", errors.WrapError(err, errors.CategoryConfig, "failed to parse configuration").
+			WithCode(errors.CodeConfigParse).
+			Build()
+	}
+	if v.Version == "" || strings.HasPrefix(v.Version, "1.") {
+		return legacyVersion, nil
+	}
+	return v.Version, nil
+}
+
+// MigrateYAML upgrades a configuration document to the current schema version by
+// applying the registered migrations in sequence. A document that is already current is
+// returned unchanged.
+func MigrateYAML(data []byte) (*MigrationResult, error) {
+	from, err := SchemaVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	res := &MigrationResult{From: from, To: configVersion, Output: data}
+	if from == configVersion {
+		return res, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.WrapError(err, errors.CategoryConfig, "failed to parse configuration").
+			WithCode(errors.CodeConfigParse).
+			Build()
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.NewError(errors.CategoryConfig, "configuration must be a YAML mapping").
+			WithCode(errors.CodeConfigParse).
+			Build()
+	}
+	root := doc.Content[0]
+
+	for version := from; version != configVersion; {
+		m := findMigration(version)
+		if m == nil && newerVersion(version, configVersion) {
+			return nil, errors.NewError(errors.CategoryConfig, "configuration version is newer than this DocBuilder supports").
+				WithCode(errors.CodeConfigVersion).
+				WithContext("version", version).
+				WithContext("current", configVersion).
+				Build()
+		}
+		if m == nil {
+			return nil, errors.NewError(errors.CategoryConfig, "no migration from this configuration version").
+				WithCode(errors.CodeConfigVersion).
+				WithContext("version", version).
+				WithContext("current", configVersion).
+				Build()
+		}
+		changes := m.Apply(root)
+		setVersion(root, m.To)
+		changes = append(changes, fmt.Sprintf("set version to %q", m.To))
+		res.Steps = append(res.Steps, MigrationStep{From: m.From, To: m.To, Description: m.Description, Changes: changes})
+		version = m.To
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encode migrated configuration: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode migrated configuration: %w", err)
+	}
+	res.Output = buf.Bytes()
+	return res, nil
+}
+
+// newerVersion reports whether the major.minor version a is greater than b.
+func newerVersion(a, b string) bool {
+	var aMajor, aMinor, bMajor, bMinor int
+	if _, err := fmt.Sscanf(a, "%d.%d", &aMajor, &aMinor); err != nil {
+		return false
+	}
+	if _, err := fmt.Sscanf(b, "%d.%d", &bMajor, &bMinor); err != nil {
+		return false
+	}
+	return aMajor > bMajor || aMajor == bMajor && aMinor > bMinor
+}
+
+func findMigration(from string) *Migration {
+	for i := range migrations {
+		if migrations[i].From == from {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// migrateV1 upgrades a version 1 document: the schema gains its version field, and the
+// forge flag options.auto_discover becomes the auto_discover setting.
+func migrateV1(root *yaml.Node) []string {
+	var changes []string
+	forges := mappingValue(root, "forges")
+	if forges == nil || forges.Kind != yaml.SequenceNode {
+		return changes
+	}
+	for i, forge := range forges.Content {
+		if forge.Kind != yaml.MappingNode {
+			continue
+		}
+		options := mappingValue(forge, "options")
+		if options == nil || options.Kind != yaml.MappingNode {
+			continue
+		}
+		flag := mappingValue(options, "auto_discover")
+		if flag == nil {
+			continue
+		}
+		name := fmt.Sprintf("forges[%d]", i)
+		if n := mappingValue(forge, "name"); n != nil && n.Value != "" {
+			name = "forge " + n.Value
+		}
+		if mappingValue(forge, "auto_discover") == nil {
+			forge.Content = append(forge.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "auto_discover"}, flag)
+		}
+		removeKey(options, "auto_discover")
+		if len(options.Content) == 0 {
+			removeKey(forge, "options")
+		}
+		changes = append(changes, name+": moved options.auto_discover to auto_discover")
+	}
+	return changes
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setVersion sets the version field, adding it as the first key when missing.
+func setVersion(m *yaml.Node, version string) {
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: version, Style: yaml.DoubleQuotedStyle}
+	if existing := mappingValue(m, "version"); existing != nil {
+		*existing = *v
+		return
+	}
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	if len(m.Content) > 0 {
+		// Keep a comment heading the file at the top.
+		k.HeadComment, m.Content[0].HeadComment = m.Content[0].HeadComment, ""
+	}
+	m.Content = append([]*yaml.Node{k, v}, m.Content...)
+}
+
+func removeKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrateYAML_V1(t *testing.T) {
+	input := `# Site configuration
+forges:
+  - name: company
+    type: github
+    api_url: https://api.github.com
+    base_url: https://github.com
+    auth:
+      type: token
+      token: ${GITHUB_TOKEN}
+    options:
+      auto_discover: true
+hugo:
+  title: "Docs" # site title
+output:
+  directory: ./site
+`
+	res, err := MigrateYAML([]byte(input))
+	if err != nil {
+		t.Fatalf("MigrateYAML: %v", err)
+	}
+	if res.From != "1" || res.To != CurrentVersion() {
+		t.Fatalf("versions = %s -> %s, want 1 -> %s", res.From, res.To, CurrentVersion())
+	}
+	if len(res.Steps) != 1 || len(res.Steps[0].Changes) != 2 {
+		t.Fatalf("steps = %+v, want one step with two changes", res.Steps)
+	}
+
+	out := string(res.Output)
+	for _, want := range []string{"# Site configuration\nversion: \"2.0\"\n", "    auto_discover: true\n", "# site title"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "options:") {
+		t.Errorf("empty options mapping kept:\n%s", out)
+	}
+	if err := ValidateYAML(res.Output); err != nil {
+		t.Fatalf("migrated configuration does not validate: %v", err)
+	}
+}
+
+func TestMigrateYAML_Current(t *testing.T) {
+	input := []byte("version: \"2.0\"\nhugo:\n  title: Docs\n")
+	res, err := MigrateYAML(input)
+	if err != nil {
+		t.Fatalf("MigrateYAML: %v", err)
+	}
+	if len(res.Steps) != 0 || string(res.Output) != string(input) {
+		t.Fatalf("current configuration changed: %+v", res)
+	}
+}
+
+func TestMigrateYAML_UnsupportedVersions(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{"version: \"3.0\"\n", "newer than this DocBuilder supports"},
+		{"version: \"0.5\"\n", "no migration from this configuration version"},
+		{"- not\n- a mapping\n", "failed to parse configuration"},
+	} {
+		_, err := MigrateYAML([]byte(tc.input))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("MigrateYAML(%q) error = %v, want %q", tc.input, err, tc.want)
+		}
+	}
+}
+
+func TestMigrations_Chain(t *testing.T) {
+	// Every migration must lead to a version another migration starts from, ending at the
+	// current version, so that any registered version reaches the current schema.
+	for _, m := range migrations {
+		for version, hops := m.To, 0; version != configVersion; hops++ {
+			next := findMigration(version)
+			if next == nil || hops > len(migrations) {
+				t.Fatalf("migration %s -> %s does not reach %s", m.From, m.To, configVersion)
+			}
+			version = next.To
+		}
+	}
+}
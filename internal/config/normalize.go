@@ -22,6 +22,7 @@ func NormalizeConfig(c *Config) (*NormalizationResult, error) {
 	normalizeVersioning(c.Versioning, res)
 	normalizeOutput(&c.Output, res)
 	normalizeFiltering(c.Filtering, res)
+	normalizeRedirects(c.Redirects, res)
 
 	// Cross-domain normalization and warnings
 	normalizeCrossDomain(c, res)
@@ -42,7 +43,7 @@ func normalizeCrossDomain(cfg *Config, res *NormalizationResult) {
 
 // Domain-specific normalization functions live in separate files for maintainability.
 // (build: normalize_build.go, monitoring: normalize_monitoring.go, versioning: normalize_versioning.go,
-//  output: normalize_output.go, filtering: normalize_filtering.go)
+//  output: normalize_output.go, filtering: normalize_filtering.go, redirects: redirects.go)
 
 // Helper constructors retained for existing warning string formats expected by tests.
 func warnChanged(field string, from, to any) string {
@@ -41,6 +41,9 @@ func normalizeBuildConfig(b *BuildConfig, res *NormalizationResult) {
 	if b.CloneConcurrency < 0 {
 		b.CloneConcurrency = 0
 	}
+	if b.Concurrency < 0 {
+		b.Concurrency = 0
+	}
 	if b.ShallowDepth < 0 {
 		b.ShallowDepth = 0
 	}
@@ -25,4 +25,11 @@ func normalizeMonitoring(m **MonitoringConfig, res *NormalizationResult) {
 		res.Warnings = append(res.Warnings, warnUnknown("monitoring.logging.format", string(cfg.Logging.Format), string(LogFormatText)))
 		cfg.Logging.Format = LogFormatText
 	}
+	for name, raw := range cfg.Logging.Components {
+		lvl := NormalizeLogLevel(string(raw))
+		if lvl != raw {
+			res.Warnings = append(res.Warnings, warnChanged("monitoring.logging.components."+name, raw, lvl))
+			cfg.Logging.Components[name] = lvl
+		}
+	}
 }
@@ -35,3 +35,46 @@ func TestNormalizeMonitoringUnknowns(t *testing.T) {
 		t.Fatalf("expected >=2 warnings, got %d", len(res.Warnings))
 	}
 }
+
+func TestMonitoringLoggingComponents(t *testing.T) {
+	cfg := &Config{Version: "2.0", Monitoring: &MonitoringConfig{Logging: MonitoringLogging{
+		Level:      LogLevelInfo,
+		Components: map[string]LogLevel{"git": "DEBUG"},
+	}}}
+	if _, err := NormalizeConfig(cfg); err != nil {
+		t.Fatalf("NormalizeConfig error: %v", err)
+	}
+	logging := cfg.Monitoring.Logging
+	if got := logging.ComponentLevel("git"); got != LogLevelDebug {
+		t.Fatalf("git level = %s, want debug", got)
+	}
+	if got := logging.ComponentLevel("hugo"); got != LogLevelInfo {
+		t.Fatalf("hugo level = %s, want global info", got)
+	}
+
+	valid := Config{
+		Version:    "2.0",
+		Output:     OutputConfig{Directory: "./out", Clean: true},
+		Build:      BuildConfig{CloneConcurrency: 1, MaxRetries: 1, RetryBackoff: RetryBackoffLinear, RetryInitialDelay: "1s", RetryMaxDelay: "2s", CloneStrategy: CloneStrategyFresh},
+		Forges:     []*ForgeConfig{{Name: "f1", Type: ForgeGitHub, Auth: &AuthConfig{Type: AuthTypeToken, Token: "x"}, AutoDiscover: true}},
+		Monitoring: cfg.Monitoring,
+	}
+	if err := validateConfig(&valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, mutate := range map[string]func(*MonitoringLogging){
+		"unknown component": func(l *MonitoringLogging) { l.Components = map[string]LogLevel{"forge": LogLevelDebug} },
+		"file without path": func(l *MonitoringLogging) { l.File = &LogFileConfig{} },
+		"negative backups":  func(l *MonitoringLogging) { l.File = &LogFileConfig{Path: "d.log", MaxBackups: -1} },
+		"remote syslog without address": func(l *MonitoringLogging) {
+			l.Syslog = &LogSyslogConfig{Enabled: true, Network: "udp"}
+		},
+	} {
+		bad := valid
+		bad.Monitoring = &MonitoringConfig{Logging: MonitoringLogging{Level: LogLevelInfo}}
+		mutate(&bad.Monitoring.Logging)
+		if err := validateConfig(&bad); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -1,8 +1,94 @@
 package config
 
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
 // OutputConfig represents output configuration.
 type OutputConfig struct {
 	BaseDirectory string `yaml:"base_directory"` // Optional base directory for output and staging, defaults to "."
 	Directory     string `yaml:"directory"`      // Output directory (relative to base if base is set)
 	Clean         bool   `yaml:"clean"`          // Clean output directory before build
+	// FileMode and DirMode are octal modes (for example "0644") applied to every generated
+	// file and directory. Unset keeps the modes the build wrote them with.
+	FileMode string `yaml:"file_mode,omitempty"`
+	DirMode  string `yaml:"dir_mode,omitempty"`
+	// UID and GID change the owner of the generated site, for example to the user of the
+	// web server serving it. Changing the owner usually requires root.
+	UID *int `yaml:"uid,omitempty"`
+	GID *int `yaml:"gid,omitempty"`
+}
+
+// FilePerm returns the configured mode of generated files.
+func (o OutputConfig) FilePerm() (fs.FileMode, bool) { return parseOutputMode(o.FileMode) }
+
+// DirPerm returns the configured mode of generated directories.
+func (o OutputConfig) DirPerm() (fs.FileMode, bool) { return parseOutputMode(o.DirMode) }
+
+// Owner returns the uid and gid generated files are changed to; -1 leaves one unchanged.
+// ok is false when neither is configured.
+func (o OutputConfig) Owner() (uid, gid int, ok bool) {
+	uid, gid = -1, -1
+	if o.UID != nil {
+		uid = *o.UID
+	}
+	if o.GID != nil {
+		gid = *o.GID
+	}
+	return uid, gid, o.UID != nil || o.GID != nil
+}
+
+// SetsPermissions reports whether generated files get configured modes or an owner.
+func (o OutputConfig) SetsPermissions() bool {
+	_, _, chown := o.Owner()
+	return strings.TrimSpace(o.FileMode) != "" || strings.TrimSpace(o.DirMode) != "" || chown
+}
+
+func parseOutputMode(value string) (fs.FileMode, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, false
+	}
+	return fs.FileMode(mode), true
+}
+
+// validateOutputPermissions checks the modes and owner of output. The owner of the
+// process must keep read/write access to files and full access to directories, or
+// later builds could not replace the site.
+func validateOutputPermissions(o OutputConfig) error {
+	for _, m := range []struct {
+		field, value string
+		required     fs.FileMode
+	}{
+		{"file_mode", o.FileMode, 0o600},
+		{"dir_mode", o.DirMode, 0o700},
+	} {
+		if strings.TrimSpace(m.value) == "" {
+			continue
+		}
+		mode, ok := parseOutputMode(m.value)
+		if !ok {
+			return errors.NewError(errors.CategoryValidation, "output "+m.field+" must be an octal permission mode such as 0644").
+				WithContext("value", m.value).
+				Build()
+		}
+		if mode&m.required != m.required {
+			return errors.NewError(errors.CategoryValidation, "output "+m.field+" must keep owner access").
+				WithContext("value", m.value).
+				WithContext("required", "0"+strconv.FormatUint(uint64(m.required), 8)).
+				Build()
+		}
+	}
+	if (o.UID != nil && *o.UID < 0) || (o.GID != nil && *o.GID < 0) {
+		return errors.NewError(errors.CategoryValidation, "output uid and gid must be >= 0").Build()
+	}
+	return nil
 }
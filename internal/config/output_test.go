@@ -0,0 +1,57 @@
+package config
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestOutputConfig_Permissions(t *testing.T) {
+	var out OutputConfig
+	if out.SetsPermissions() {
+		t.Fatalf("unset output config must not set permissions")
+	}
+	uid := 101
+	out = OutputConfig{FileMode: "644", DirMode: "0755", UID: &uid}
+	if mode, ok := out.FilePerm(); !ok || mode != fs.FileMode(0o644) {
+		t.Fatalf("FilePerm() = %v, %v", mode, ok)
+	}
+	if mode, ok := out.DirPerm(); !ok || mode != fs.FileMode(0o755) {
+		t.Fatalf("DirPerm() = %v, %v", mode, ok)
+	}
+	if u, g, ok := out.Owner(); !ok || u != 101 || g != -1 {
+		t.Fatalf("Owner() = %d, %d, %v", u, g, ok)
+	}
+}
+
+func TestValidateConfig_OutputPermissions(t *testing.T) {
+	negative := -1
+	for name, tc := range map[string]struct {
+		out     OutputConfig
+		wantErr string
+	}{
+		"valid":             {OutputConfig{FileMode: "0644", DirMode: "0755"}, ""},
+		"not octal":         {OutputConfig{FileMode: "rw-r--r--"}, "file_mode must be an octal permission mode"},
+		"too large":         {OutputConfig{DirMode: "01777"}, "dir_mode must be an octal permission mode"},
+		"owner cannot read": {OutputConfig{FileMode: "0244"}, "file_mode must keep owner access"},
+		"owner cannot list": {OutputConfig{DirMode: "0655"}, "dir_mode must keep owner access"},
+		"negative uid":      {OutputConfig{UID: &negative}, "uid and gid must be >= 0"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{Version: "2.0", Repositories: []Repository{{Name: "r"}}, Output: tc.out}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("ValidateConfig() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
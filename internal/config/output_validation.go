@@ -0,0 +1,89 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// OutputCheck names one check of the output validation stage.
+type OutputCheck string
+
+const (
+	// OutputCheckMissingPages flags content pages that rendered no HTML file.
+	OutputCheckMissingPages OutputCheck = "missing_pages"
+	// OutputCheckEmptyPages flags pages with an empty body or an empty HTML file.
+	OutputCheckEmptyPages OutputCheck = "empty_pages"
+	// OutputCheckMissingSections flags content directories without an _index.md.
+	OutputCheckMissingSections OutputCheck = "missing_sections"
+	// OutputCheckHugoWarnings flags WARN lines in the hugo output.
+	OutputCheckHugoWarnings OutputCheck = "hugo_warnings"
+)
+
+// OutputCheckAction decides what a problem found by an output check does.
+type OutputCheckAction string
+
+const (
+	// OutputCheckWarn records the problem as a build warning (default).
+	OutputCheckWarn OutputCheckAction = "warn"
+	// OutputCheckFail aborts the build; the previous site stays published.
+	OutputCheckFail OutputCheckAction = "fail"
+	// OutputCheckIgnore skips the check.
+	OutputCheckIgnore OutputCheckAction = "ignore"
+)
+
+// OutputValidationConfig enables the validate_output stage, which compares the rendered
+// site with the content handed to Hugo to catch silently dropped pages.
+type OutputValidationConfig struct {
+	Enabled         bool              `yaml:"enabled"`
+	MissingPages    OutputCheckAction `yaml:"missing_pages,omitempty"`    // warn|fail|ignore (default: warn)
+	EmptyPages      OutputCheckAction `yaml:"empty_pages,omitempty"`      // warn|fail|ignore (default: warn)
+	MissingSections OutputCheckAction `yaml:"missing_sections,omitempty"` // warn|fail|ignore (default: warn)
+	HugoWarnings    OutputCheckAction `yaml:"hugo_warnings,omitempty"`    // warn|fail|ignore (default: warn)
+}
+
+// ValidatesOutput reports whether the validate_output stage runs.
+func (v *OutputValidationConfig) ValidatesOutput() bool {
+	return v != nil && v.Enabled
+}
+
+// Action returns the configured action of check, defaulting to warn.
+func (v *OutputValidationConfig) Action(check OutputCheck) OutputCheckAction {
+	if v == nil {
+		return OutputCheckWarn
+	}
+	var action OutputCheckAction
+	switch check {
+	case OutputCheckMissingPages:
+		action = v.MissingPages
+	case OutputCheckEmptyPages:
+		action = v.EmptyPages
+	case OutputCheckMissingSections:
+		action = v.MissingSections
+	case OutputCheckHugoWarnings:
+		action = v.HugoWarnings
+	}
+	if action == "" {
+		return OutputCheckWarn
+	}
+	return action
+}
+
+func validateOutputValidation(v *OutputValidationConfig) error {
+	if v == nil {
+		return nil
+	}
+	for check, action := range map[OutputCheck]OutputCheckAction{
+		OutputCheckMissingPages:    v.MissingPages,
+		OutputCheckEmptyPages:      v.EmptyPages,
+		OutputCheckMissingSections: v.MissingSections,
+		OutputCheckHugoWarnings:    v.HugoWarnings,
+	} {
+		switch action {
+		case "", OutputCheckWarn, OutputCheckFail, OutputCheckIgnore:
+		default:
+			return errors.NewError(errors.CategoryValidation, "invalid build.output_validation action").
+				WithContext("check", string(check)).
+				WithContext("actual", string(action)).
+				WithContext("allowed", "warn|fail|ignore").
+				Build()
+		}
+	}
+	return nil
+}
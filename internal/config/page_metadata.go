@@ -0,0 +1,108 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+const (
+	// defaultWordsPerMinute is the reading speed used for reading time estimates.
+	defaultWordsPerMinute = 200
+	// defaultTOCMaxLevel is the deepest heading level listed in the table of contents.
+	defaultTOCMaxLevel = 3
+)
+
+// PageMetadataConfig adds computed page metadata to front matter params, so that any
+// theme can render it: an estimated reading time and a table of contents built from
+// the page headings. Repositories override individual fields.
+type PageMetadataConfig struct {
+	ReadingTime    *bool `yaml:"reading_time,omitempty"`     // Add params.reading_time (minutes) and params.word_count
+	TOC            *bool `yaml:"toc,omitempty"`              // Add params.toc (nested headings)
+	WordsPerMinute int   `yaml:"words_per_minute,omitempty"` // Reading speed (default 200)
+	TOCMaxLevel    int   `yaml:"toc_max_level,omitempty"`    // Deepest heading level in the TOC (default 3)
+}
+
+// ReadingTimeEnabled reports whether pages get a reading time estimate.
+func (p *PageMetadataConfig) ReadingTimeEnabled() bool {
+	return p != nil && p.ReadingTime != nil && *p.ReadingTime
+}
+
+// TOCEnabled reports whether pages get a table of contents.
+func (p *PageMetadataConfig) TOCEnabled() bool {
+	return p != nil && p.TOC != nil && *p.TOC
+}
+
+// Speed returns the effective reading speed in words per minute.
+func (p *PageMetadataConfig) Speed() int {
+	if p == nil || p.WordsPerMinute <= 0 {
+		return defaultWordsPerMinute
+	}
+	return p.WordsPerMinute
+}
+
+// MaxTOCLevel returns the deepest heading level listed in the table of contents.
+func (p *PageMetadataConfig) MaxTOCLevel() int {
+	if p == nil || p.TOCMaxLevel <= 0 {
+		return defaultTOCMaxLevel
+	}
+	return p.TOCMaxLevel
+}
+
+// PageMetadataPolicy returns the repository's effective page metadata settings: its own
+// fields where set, the global ones otherwise.
+func (r *Repository) PageMetadataPolicy(global *PageMetadataConfig) *PageMetadataConfig {
+	if r.PageMetadata == nil {
+		return global
+	}
+	policy := *r.PageMetadata
+	if global != nil {
+		if policy.ReadingTime == nil {
+			policy.ReadingTime = global.ReadingTime
+		}
+		if policy.TOC == nil {
+			policy.TOC = global.TOC
+		}
+		if policy.WordsPerMinute == 0 {
+			policy.WordsPerMinute = global.WordsPerMinute
+		}
+		if policy.TOCMaxLevel == 0 {
+			policy.TOCMaxLevel = global.TOCMaxLevel
+		}
+	}
+	return &policy
+}
+
+// snapshotValue renders the settings for config hashing.
+func (p *PageMetadataConfig) snapshotValue() string {
+	return "reading_time:" + boolToString(p.ReadingTimeEnabled()) +
+		",toc:" + boolToString(p.TOCEnabled()) +
+		",words_per_minute:" + intToString(p.Speed()) +
+		",toc_max_level:" + intToString(p.MaxTOCLevel())
+}
+
+func (cv *configurationValidator) validatePageMetadata() error {
+	check := func(p *PageMetadataConfig, repo string) error {
+		if p == nil {
+			return nil
+		}
+		if p.WordsPerMinute < 0 {
+			return errors.NewError(errors.CategoryValidation, "page_metadata words_per_minute must be >= 0").
+				WithContext("repository", repo).
+				WithContext("value", p.WordsPerMinute).
+				Build()
+		}
+		if p.TOCMaxLevel < 0 || p.TOCMaxLevel > 6 {
+			return errors.NewError(errors.CategoryValidation, "page_metadata toc_max_level must be between 1 and 6").
+				WithContext("repository", repo).
+				WithContext("value", p.TOCMaxLevel).
+				Build()
+		}
+		return nil
+	}
+	if err := check(cv.config.PageMetadata, ""); err != nil {
+		return err
+	}
+	for i := range cv.config.Repositories {
+		if err := check(cv.config.Repositories[i].PageMetadata, cv.config.Repositories[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepository_PageMetadataPolicy(t *testing.T) {
+	on, off := true, false
+	global := &PageMetadataConfig{ReadingTime: &on, TOC: &on, WordsPerMinute: 250}
+
+	repo := Repository{Name: "r"}
+	if got := repo.PageMetadataPolicy(global); got != global {
+		t.Fatalf("expected global settings without override, got %+v", got)
+	}
+	repo.PageMetadata = &PageMetadataConfig{TOC: &off, TOCMaxLevel: 4}
+	got := repo.PageMetadataPolicy(global)
+	if !got.ReadingTimeEnabled() || got.TOCEnabled() || got.Speed() != 250 || got.MaxTOCLevel() != 4 {
+		t.Fatalf("unexpected merged policy: %+v", got)
+	}
+
+	var unset *PageMetadataConfig
+	if unset.ReadingTimeEnabled() || unset.TOCEnabled() || unset.Speed() != 200 || unset.MaxTOCLevel() != 3 {
+		t.Fatal("nil config: expected everything disabled with default speed and depth")
+	}
+}
+
+func TestValidateConfig_PageMetadata(t *testing.T) {
+	base := func(global, repo *PageMetadataConfig) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git", PageMetadata: repo}},
+			PageMetadata: global,
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(&PageMetadataConfig{WordsPerMinute: 180, TOCMaxLevel: 4}, nil)); err != nil {
+		t.Fatalf("expected valid page_metadata, got %v", err)
+	}
+	if err := ValidateConfig(base(nil, &PageMetadataConfig{TOCMaxLevel: 7})); err == nil || !strings.Contains(err.Error(), "toc_max_level") {
+		t.Fatalf("expected toc_max_level error, got %v", err)
+	}
+	if err := ValidateConfig(base(&PageMetadataConfig{WordsPerMinute: -1}, nil)); err == nil || !strings.Contains(err.Error(), "words_per_minute") {
+		t.Fatalf("expected words_per_minute error, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ArchiveFormat names the file format of an offline site archive.
+type ArchiveFormat string
+
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+// defaultArchiveRetain is the number of archives kept when retain is unset.
+const defaultArchiveRetain = 5
+
+// SignTool names the tool that signs the checksum file of the published site.
+type SignTool string
+
+const (
+	SignMinisign SignTool = "minisign"
+	SignCosign   SignTool = "cosign"
+)
+
+// PostProcessConfig configures work done on the rendered site after Hugo runs.
+type PostProcessConfig struct {
+	// Integrity adds subresource integrity hashes and a (signed) checksum file.
+	Integrity *IntegrityConfig `yaml:"integrity,omitempty"`
+	// Archive packages public/ into a downloadable offline archive after each build.
+	Archive *ArchiveConfig `yaml:"archive,omitempty"`
+}
+
+// IntegrityConfig protects the published site for supply-chain-sensitive deployments.
+// `docbuilder verify` checks the checksum file (and its signature) after deployment.
+type IntegrityConfig struct {
+	SRI       bool           `yaml:"sri"`            // Add integrity="sha384-..." to local scripts and stylesheets
+	Checksums bool           `yaml:"checksums"`      // Write SHA256SUMS over every published file
+	Sign      *SigningConfig `yaml:"sign,omitempty"` // Sign SHA256SUMS (implies checksums)
+}
+
+// SigningConfig signs the checksum file with minisign or cosign.
+type SigningConfig struct {
+	Tool    SignTool `yaml:"tool"`
+	Key     string   `yaml:"key"`               // Private key file
+	Command string   `yaml:"command,omitempty"` // Executable (default: the tool name on PATH)
+	Args    []string `yaml:"args,omitempty"`    // Extra arguments for the signing run
+}
+
+// SiteIntegrity returns the integrity settings, or nil when none are enabled.
+func (c *Config) SiteIntegrity() *IntegrityConfig {
+	if c == nil || c.PostProcess == nil || c.PostProcess.Integrity == nil {
+		return nil
+	}
+	i := c.PostProcess.Integrity
+	if !i.SRI && !i.ChecksumsEnabled() {
+		return nil
+	}
+	return i
+}
+
+// ChecksumsEnabled reports whether SHA256SUMS is written.
+func (i *IntegrityConfig) ChecksumsEnabled() bool {
+	return i != nil && (i.Checksums || i.Sign != nil)
+}
+
+// SignCommand returns the executable that signs and verifies checksum files.
+func (s *SigningConfig) SignCommand() string {
+	if s.Command != "" {
+		return s.Command
+	}
+	return string(s.Tool)
+}
+
+// ArchiveConfig packages the rendered site into timestamped archives for offline
+// (air-gapped) consumers. Archives live outside the output directory so that they
+// survive the atomic output swap of the next build.
+type ArchiveConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Format    ArchiveFormat `yaml:"format,omitempty"`    // zip (default) or tar.gz
+	Retain    int           `yaml:"retain,omitempty"`    // Archives to keep (default 5)
+	Directory string        `yaml:"directory,omitempty"` // Default: {base_directory}/archives or <output>_archives
+}
+
+// SiteArchive returns the archive settings, or nil when archiving is disabled.
+func (c *Config) SiteArchive() *ArchiveConfig {
+	if c == nil || c.PostProcess == nil || c.PostProcess.Archive == nil || !c.PostProcess.Archive.Enabled {
+		return nil
+	}
+	return c.PostProcess.Archive
+}
+
+// ArchiveFormatOrDefault returns the configured format (default zip).
+func (a *ArchiveConfig) ArchiveFormatOrDefault() ArchiveFormat {
+	if a == nil || a.Format == "" {
+		return ArchiveZip
+	}
+	return a.Format
+}
+
+// RetainCount returns how many archives are kept.
+func (a *ArchiveConfig) RetainCount() int {
+	if a == nil || a.Retain <= 0 {
+		return defaultArchiveRetain
+	}
+	return a.Retain
+}
+
+// ArchiveDirectory returns where archives are written. Like the staging directory it
+// defaults to a sibling of the output directory.
+func (c *Config) ArchiveDirectory() string {
+	if a := c.SiteArchive(); a != nil && a.Directory != "" {
+		return a.Directory
+	}
+	if c.Output.BaseDirectory != "" {
+		return filepath.Join(c.Output.BaseDirectory, "archives")
+	}
+	out := c.Output.Directory
+	if out == "" {
+		out = "site"
+	}
+	return filepath.Clean(out) + "_archives"
+}
+
+// snapshotValue renders the settings for config hashing.
+func (a *ArchiveConfig) snapshotValue() string {
+	return boolToString(a.Enabled) + ";" + string(a.ArchiveFormatOrDefault()) + ";" + strconv.Itoa(a.RetainCount())
+}
+
+// snapshotValue renders the settings for config hashing (the signing key is excluded).
+func (i *IntegrityConfig) snapshotValue() string {
+	tool := ""
+	if i.Sign != nil {
+		tool = string(i.Sign.Tool)
+	}
+	return boolToString(i.SRI) + ";" + boolToString(i.ChecksumsEnabled()) + ";" + tool
+}
+
+func (cv *configurationValidator) validatePostProcess() error {
+	if cv.config.PostProcess == nil {
+		return nil
+	}
+	if i := cv.config.PostProcess.Integrity; i != nil && i.Sign != nil {
+		if i.Sign.Tool != SignMinisign && i.Sign.Tool != SignCosign {
+			return errors.NewError(errors.CategoryValidation, "unsupported signing tool").
+				WithContext("tool", i.Sign.Tool).
+				WithContext("valid_values", []SignTool{SignMinisign, SignCosign}).
+				Build()
+		}
+		if i.Sign.Key == "" {
+			return errors.NewError(errors.CategoryValidation, "post_process.integrity.sign.key is required").
+				WithContext("tool", i.Sign.Tool).
+				Build()
+		}
+	}
+	a := cv.config.PostProcess.Archive
+	if a == nil {
+		return nil
+	}
+	if a.Format != "" && a.Format != ArchiveZip && a.Format != ArchiveTarGz {
+		return errors.NewError(errors.CategoryValidation, "unsupported archive format").
+			WithContext("format", a.Format).
+			WithContext("valid_values", []ArchiveFormat{ArchiveZip, ArchiveTarGz}).
+			Build()
+	}
+	if a.Retain < 0 {
+		return errors.NewError(errors.CategoryValidation, "post_process.archive.retain must not be negative").
+			WithContext("retain", a.Retain).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_ArchiveDirectory(t *testing.T) {
+	cfg := &Config{Output: OutputConfig{Directory: "./site"}}
+	if cfg.SiteArchive() != nil {
+		t.Fatal("expected archiving disabled without post_process")
+	}
+	if got := cfg.ArchiveDirectory(); got != "site_archives" {
+		t.Fatalf("expected sibling of the output directory, got %q", got)
+	}
+	cfg.Output.BaseDirectory = "/srv/docs"
+	if got := cfg.ArchiveDirectory(); got != filepath.Join("/srv/docs", "archives") {
+		t.Fatalf("expected archives below base_directory, got %q", got)
+	}
+	cfg.PostProcess = &PostProcessConfig{Archive: &ArchiveConfig{Enabled: true, Directory: "/var/archives"}}
+	if got := cfg.ArchiveDirectory(); got != "/var/archives" {
+		t.Fatalf("expected configured directory, got %q", got)
+	}
+	a := cfg.SiteArchive()
+	if a.ArchiveFormatOrDefault() != ArchiveZip || a.RetainCount() != 5 {
+		t.Fatalf("unexpected defaults: %s %d", a.ArchiveFormatOrDefault(), a.RetainCount())
+	}
+}
+
+func TestValidateConfig_PostProcessArchive(t *testing.T) {
+	base := func(a *ArchiveConfig) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}},
+			PostProcess:  &PostProcessConfig{Archive: a},
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(&ArchiveConfig{Enabled: true, Format: ArchiveTarGz, Retain: 3})); err != nil {
+		t.Fatalf("expected valid archive config, got %v", err)
+	}
+	if err := ValidateConfig(base(&ArchiveConfig{Format: "rar"})); err == nil || !strings.Contains(err.Error(), "unsupported archive format") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+	if err := ValidateConfig(base(&ArchiveConfig{Retain: -1})); err == nil || !strings.Contains(err.Error(), "retain") {
+		t.Fatalf("expected retain error, got %v", err)
+	}
+}
+
+func TestValidateConfig_PostProcessIntegrity(t *testing.T) {
+	base := func(i *IntegrityConfig) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}},
+			PostProcess:  &PostProcessConfig{Integrity: i},
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(&IntegrityConfig{SRI: true, Sign: &SigningConfig{Tool: SignMinisign, Key: "docs.key"}})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid integrity config, got %v", err)
+	}
+	if i := cfg.SiteIntegrity(); i == nil || !i.ChecksumsEnabled() || i.Sign.SignCommand() != "minisign" {
+		t.Fatalf("expected signing to imply checksums, got %+v", i)
+	}
+	if base(&IntegrityConfig{}).SiteIntegrity() != nil {
+		t.Fatal("expected integrity disabled without sri or checksums")
+	}
+	if err := ValidateConfig(base(&IntegrityConfig{Sign: &SigningConfig{Tool: "gpg", Key: "k"}})); err == nil || !strings.Contains(err.Error(), "unsupported signing tool") {
+		t.Fatalf("expected tool error, got %v", err)
+	}
+	if err := ValidateConfig(base(&IntegrityConfig{Sign: &SigningConfig{Tool: SignCosign}})); err == nil || !strings.Contains(err.Error(), "key is required") {
+		t.Fatalf("expected key error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// profileNamePattern restricts build profile names to lowercase identifiers.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ProfileDeclared reports whether content may be marked for the named profile: any valid
+// name when build.profiles is empty, otherwise one of the declared profiles.
+func (b *BuildConfig) ProfileDeclared(name string) bool {
+	if !profileNamePattern.MatchString(name) {
+		return false
+	}
+	if len(b.Profiles) == 0 {
+		return true
+	}
+	for _, p := range b.Profiles {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// InProfile reports whether content marked for the given profiles is part of the build.
+// Unmarked content always is; marked content only when the active profile is listed.
+func (b *BuildConfig) InProfile(profiles []string) bool {
+	if len(profiles) == 0 {
+		return true
+	}
+	for _, p := range profiles {
+		if p == b.Profile {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckProfile validates the active and declared build profiles. It is part of config
+// validation and is called again when --profile overrides the active profile.
+func (b *BuildConfig) CheckProfile() error {
+	for _, p := range b.Profiles {
+		if !profileNamePattern.MatchString(p) {
+			return errors.NewError(errors.CategoryValidation, "invalid build profile name").
+				WithContext("value", p).
+				WithContext("pattern", profileNamePattern.String()).
+				Build()
+		}
+	}
+	if b.Profile != "" && !b.ProfileDeclared(b.Profile) {
+		return errors.NewError(errors.CategoryValidation, "unknown build profile").
+			WithContext("value", b.Profile).
+			WithContext("valid_values", strings.Join(b.Profiles, ", ")).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildConfig_Profiles(t *testing.T) {
+	b := &BuildConfig{Profile: "internal"}
+	if !b.InProfile(nil) || !b.InProfile([]string{"public", "internal"}) || b.InProfile([]string{"public"}) {
+		t.Fatal("unexpected profile matching for the internal profile")
+	}
+	if (&BuildConfig{}).InProfile([]string{"internal"}) {
+		t.Fatal("marked content must be excluded when no profile is active")
+	}
+	if !b.ProfileDeclared("anything") || b.ProfileDeclared("Not Valid") {
+		t.Fatal("without declared profiles any valid name is accepted")
+	}
+	b.Profiles = []string{"internal", "public"}
+	if b.ProfileDeclared("partners") {
+		t.Fatal("expected undeclared profile to be rejected")
+	}
+}
+
+func TestValidateConfig_BuildProfiles(t *testing.T) {
+	base := func(b BuildConfig) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}}, Build: b}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(BuildConfig{Profile: "public", Profiles: []string{"internal", "public"}})); err != nil {
+		t.Fatalf("expected valid profiles, got %v", err)
+	}
+	if err := ValidateConfig(base(BuildConfig{Profile: "partners", Profiles: []string{"internal", "public"}})); err == nil || !strings.Contains(err.Error(), "unknown build profile") {
+		t.Fatalf("expected unknown profile error, got %v", err)
+	}
+	if err := ValidateConfig(base(BuildConfig{Profiles: []string{"Internal Docs"}})); err == nil || !strings.Contains(err.Error(), "invalid build profile name") {
+		t.Fatalf("expected invalid name error, got %v", err)
+	}
+}
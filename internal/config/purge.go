@@ -0,0 +1,142 @@
+package config
+
+import (
+	"net/url"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// PurgeHookType names the API a purge hook calls.
+type PurgeHookType string
+
+const (
+	PurgeWebhook    PurgeHookType = "webhook"
+	PurgeCloudflare PurgeHookType = "cloudflare"
+	PurgeFastly     PurgeHookType = "fastly"
+)
+
+// defaultPurgeTimeout bounds all requests of one hook run.
+const defaultPurgeTimeout = 30 * time.Second
+
+// PurgeConfig purges the pages that changed between two published builds from CDN
+// caches. The changed pages are found by comparing the sitemap (and the rendered file
+// behind each entry) of the new build with the site it replaces.
+type PurgeConfig struct {
+	DryRun  bool        `yaml:"dry_run,omitempty"` // Log the requests instead of sending them
+	Timeout string      `yaml:"timeout,omitempty"` // Per hook (default: 30s)
+	Hooks   []PurgeHook `yaml:"hooks"`
+}
+
+// PurgeHook is one purge target.
+type PurgeHook struct {
+	Name      string            `yaml:"name,omitempty"`       // Label in logs, reports and metrics (default: the type)
+	Type      PurgeHookType     `yaml:"type"`                 // webhook | cloudflare | fastly
+	URL       string            `yaml:"url,omitempty"`        // Webhook endpoint
+	Headers   map[string]string `yaml:"headers,omitempty"`    // Extra webhook request headers
+	ZoneID    string            `yaml:"zone_id,omitempty"`    // Cloudflare zone
+	Token     string            `yaml:"token,omitempty"`      // Cloudflare API token or Fastly API key
+	APIURL    string            `yaml:"api_url,omitempty"`    // Override of the Cloudflare/Fastly API base URL
+	BatchSize int               `yaml:"batch_size,omitempty"` // URLs per request (default: all for webhooks, 30 for Cloudflare)
+}
+
+// PurgeHooks returns the configured purge hooks, or nil when there are none.
+func (c *Config) PurgeHooks() []PurgeHook {
+	if c == nil || c.Purge == nil {
+		return nil
+	}
+	return c.Purge.Hooks
+}
+
+// TimeoutDuration returns the per-hook timeout, or the default when unset or invalid.
+func (p *PurgeConfig) TimeoutDuration() time.Duration {
+	if p == nil || p.Timeout == "" {
+		return defaultPurgeTimeout
+	}
+	d, err := time.ParseDuration(p.Timeout)
+	if err != nil || d <= 0 {
+		return defaultPurgeTimeout
+	}
+	return d
+}
+
+// HookName returns the name of the hook, defaulting to its type.
+func (h *PurgeHook) HookName() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return string(h.Type)
+}
+
+func (cv *configurationValidator) validatePurge() error {
+	p := cv.config.Purge
+	if p == nil {
+		return nil
+	}
+	if p.Timeout != "" {
+		if d, err := time.ParseDuration(p.Timeout); err != nil || d <= 0 {
+			return errors.NewError(errors.CategoryValidation, "purge timeout must be a positive duration").
+				WithContext("value", p.Timeout).
+				Build()
+		}
+	}
+	names := make(map[string]bool, len(p.Hooks))
+	for i := range p.Hooks {
+		h := &p.Hooks[i]
+		if err := validatePurgeHook(h); err != nil {
+			return err
+		}
+		if names[h.HookName()] {
+			return errors.NewError(errors.CategoryValidation, "duplicate purge hook name").
+				WithContext("name", h.HookName()).
+				Build()
+		}
+		names[h.HookName()] = true
+	}
+	return nil
+}
+
+func validatePurgeHook(h *PurgeHook) error {
+	missing := func(field string) error {
+		return errors.NewError(errors.CategoryValidation, "purge hook "+field+" is required").
+			WithContext("hook", h.HookName()).
+			WithContext("type", h.Type).
+			Build()
+	}
+	switch h.Type {
+	case PurgeWebhook:
+		if h.URL == "" {
+			return missing("url")
+		}
+		if u, err := url.Parse(h.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NewError(errors.CategoryValidation, "purge hook url must be an absolute http(s) URL").
+				WithContext("hook", h.HookName()).
+				WithContext("url", h.URL).
+				Build()
+		}
+	case PurgeCloudflare:
+		if h.ZoneID == "" {
+			return missing("zone_id")
+		}
+		if h.Token == "" {
+			return missing("token")
+		}
+	case PurgeFastly:
+		if h.Token == "" {
+			return missing("token")
+		}
+	default:
+		return errors.NewError(errors.CategoryValidation, "unsupported purge hook type").
+			WithContext("hook", h.HookName()).
+			WithContext("type", h.Type).
+			WithContext("valid_values", []PurgeHookType{PurgeWebhook, PurgeCloudflare, PurgeFastly}).
+			Build()
+	}
+	if h.BatchSize < 0 {
+		return errors.NewError(errors.CategoryValidation, "purge hook batch_size must not be negative").
+			WithContext("hook", h.HookName()).
+			WithContext("batch_size", h.BatchSize).
+			Build()
+	}
+	return nil
+}
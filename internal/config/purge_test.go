@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig_Purge(t *testing.T) {
+	base := func(p *PurgeConfig) *Config {
+		cfg := &Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}},
+			Purge:        p,
+		}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	valid := &PurgeConfig{Timeout: "10s", Hooks: []PurgeHook{
+		{Type: PurgeWebhook, URL: "https://cdn.example.com/purge"},
+		{Type: PurgeCloudflare, ZoneID: "zone", Token: "token"},
+		{Name: "fastly-eu", Type: PurgeFastly, Token: "key"},
+	}}
+	if err := ValidateConfig(base(valid)); err != nil {
+		t.Fatalf("expected valid purge config, got %v", err)
+	}
+	if got := valid.TimeoutDuration(); got != 10*time.Second {
+		t.Fatalf("expected 10s timeout, got %v", got)
+	}
+
+	cases := map[string]*PurgeConfig{
+		"unsupported purge hook type":         {Hooks: []PurgeHook{{Type: "akamai"}}},
+		"purge hook url is required":          {Hooks: []PurgeHook{{Type: PurgeWebhook}}},
+		"absolute http(s) URL":                {Hooks: []PurgeHook{{Type: PurgeWebhook, URL: "/purge"}}},
+		"purge hook zone_id is required":      {Hooks: []PurgeHook{{Type: PurgeCloudflare, Token: "t"}}},
+		"purge hook token is required":        {Hooks: []PurgeHook{{Type: PurgeFastly}}},
+		"duplicate purge hook name":           {Hooks: []PurgeHook{{Type: PurgeFastly, Token: "a"}, {Type: PurgeFastly, Token: "b"}}},
+		"batch_size must not be negative":     {Hooks: []PurgeHook{{Type: PurgeFastly, Token: "a", BatchSize: -1}}},
+		"timeout must be a positive duration": {Timeout: "soon"},
+	}
+	for want, p := range cases {
+		if err := ValidateConfig(base(p)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q error, got %v", want, err)
+		}
+	}
+}
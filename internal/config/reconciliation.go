@@ -0,0 +1,89 @@
+package config
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// defaultReconciliationSchedule runs the reconciliation build nightly.
+const defaultReconciliationSchedule = "0 3 * * *"
+
+// ReconciliationConfig controls the scheduled full rebuild that checks the
+// incrementally built site for drift. The rebuild ignores every incremental cache
+// (fresh clones, no skip evaluation) at the commits of the published build, and its
+// rendered files are compared with the published site.
+type ReconciliationConfig struct {
+	Enabled       bool              `yaml:"enabled"`
+	Schedule      string            `yaml:"schedule,omitempty"`       // Cron expression (default: 0 3 * * *)
+	Ignore        []string          `yaml:"ignore,omitempty"`         // Site path globs left out of the comparison
+	NotifyURL     string            `yaml:"notify_url,omitempty"`     // Receives a JSON POST when drift is detected
+	NotifyHeaders map[string]string `yaml:"notify_headers,omitempty"` // Extra notification request headers
+}
+
+// IsEnabled reports whether the reconciliation build runs.
+func (r *ReconciliationConfig) IsEnabled() bool { return r != nil && r.Enabled }
+
+// ScheduleExpr returns the cron expression of the reconciliation build.
+func (r *ReconciliationConfig) ScheduleExpr() string {
+	if r == nil || strings.TrimSpace(r.Schedule) == "" {
+		return defaultReconciliationSchedule
+	}
+	return strings.TrimSpace(r.Schedule)
+}
+
+// Ignored reports whether the site path (slash separated, relative to the site
+// root) is left out of the comparison.
+func (r *ReconciliationConfig) Ignored(sitePath string) bool {
+	if r == nil {
+		return false
+	}
+	for _, pattern := range r.Ignore {
+		if ok, _ := path.Match(pattern, sitePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDaemonReconciliation(r *ReconciliationConfig) error {
+	if err := validateCronExpression(r.ScheduleExpr(), "daemon-reconciliation-validation"); err != nil {
+		return errors.WrapError(err, errors.CategoryValidation, "invalid daemon reconciliation schedule").
+			WithContext("schedule", r.Schedule).
+			Build()
+	}
+	for _, pattern := range r.Ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.NewError(errors.CategoryValidation, "invalid daemon reconciliation ignore pattern").
+				WithContext("pattern", pattern).
+				Build()
+		}
+	}
+	if r.NotifyURL != "" {
+		if u, err := url.Parse(r.NotifyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NewError(errors.CategoryValidation, "daemon reconciliation notify_url must be an absolute http(s) URL").
+				WithContext("notify_url", r.NotifyURL).
+				Build()
+		}
+	}
+	return nil
+}
+
+// validateCronExpression parses expr by creating a job on a scheduler that is never started.
+func validateCronExpression(expr, jobName string) error {
+	scheduler, err := gocron.NewScheduler()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = scheduler.Shutdown() }()
+	_, err = scheduler.NewJob(
+		gocron.CronJob(expr, false),
+		gocron.NewTask(func() {}),
+		gocron.WithName(jobName),
+	)
+	return err
+}
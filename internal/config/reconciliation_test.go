@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestReconciliationConfig_Defaults(t *testing.T) {
+	var nilCfg *ReconciliationConfig
+	if nilCfg.IsEnabled() || nilCfg.Ignored("index.html") {
+		t.Fatalf("nil reconciliation config must be disabled and ignore nothing")
+	}
+	if nilCfg.ScheduleExpr() != defaultReconciliationSchedule {
+		t.Fatalf("expected default schedule, got %q", nilCfg.ScheduleExpr())
+	}
+	cfg := &ReconciliationConfig{Schedule: " 30 1 * * 0 ", Ignore: []string{"sitemap.xml", "tags/*"}}
+	if cfg.ScheduleExpr() != "30 1 * * 0" {
+		t.Fatalf("configured schedule not used: %q", cfg.ScheduleExpr())
+	}
+	if !cfg.Ignored("sitemap.xml") || !cfg.Ignored("tags/index.html") || cfg.Ignored("guide/index.html") {
+		t.Fatalf("unexpected ignore matches for %v", cfg.Ignore)
+	}
+}
+
+func TestValidateConfig_DaemonReconciliation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rc      ReconciliationConfig
+		wantErr bool
+	}{
+		"defaults":            {ReconciliationConfig{Enabled: true}, false},
+		"valid":               {ReconciliationConfig{Enabled: true, Schedule: "0 2 * * 6", Ignore: []string{"*.xml"}, NotifyURL: "https://hooks.example.com/drift"}, false},
+		"invalid schedule":    {ReconciliationConfig{Enabled: true, Schedule: "nightly"}, true},
+		"invalid ignore glob": {ReconciliationConfig{Enabled: true, Ignore: []string{"["}}, true},
+		"relative notify url": {ReconciliationConfig{Enabled: true, NotifyURL: "/drift"}, true},
+		"unsupported scheme":  {ReconciliationConfig{Enabled: true, NotifyURL: "ftp://example.com/drift"}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rc := tc.rc
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:           SyncConfig{Schedule: "0 */4 * * *"},
+					Reconciliation: &rc,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/normalization"
+)
+
+// RedirectsConfig controls redirect generation for moved/renamed pages and explicit redirect rules.
+type RedirectsConfig struct {
+	// TrackMoves enables automatic Hugo aliases for pages whose URL changed between builds
+	// (pages are matched by their front matter uid). When unset, defaults to true.
+	TrackMoves *bool `yaml:"track_moves,omitempty"`
+	// Files lists additional redirect map files to emit into the site root (netlify|nginx).
+	Files []RedirectFileFormat `yaml:"files,omitempty"`
+	// Rules declares explicit redirects from an old URL path to a new one. The daemon docs
+	// server answers them with an HTTP redirect before serving files.
+	Rules []RedirectRule `yaml:"rules,omitempty"`
+	// VanityPrefix enables short repository URLs on the docs server: <prefix><repo>
+	// redirects to the repository's index page (e.g. "/r/"). Empty disables them.
+	VanityPrefix string `yaml:"vanity_prefix,omitempty"`
+}
+
+// RedirectRule is an explicit redirect from one site URL path to another.
+type RedirectRule struct {
+	From   string `yaml:"from"`             // Old URL path (e.g. "/repo/old-page/")
+	To     string `yaml:"to"`               // New URL path or absolute URL
+	Status int    `yaml:"status,omitempty"` // HTTP status code: 301 (default), 302, 307 or 308
+}
+
+// StatusCode returns the HTTP status code of the rule (default 301).
+func (r RedirectRule) StatusCode() int {
+	if r.Status == 0 {
+		return http.StatusMovedPermanently
+	}
+	return r.Status
+}
+
+// VanityEnabled reports whether vanity repository URLs are served.
+func (r *RedirectsConfig) VanityEnabled() bool { return r != nil && r.VanityPrefix != "" }
+
+// MoveTrackingEnabled reports whether moved-page alias generation is enabled (default true).
+func (r *RedirectsConfig) MoveTrackingEnabled() bool {
+	if r == nil || r.TrackMoves == nil {
+		return true
+	}
+	return *r.TrackMoves
+}
+
+// RedirectFileFormat enumerates supported redirect map file formats.
+type RedirectFileFormat string
+
+const (
+	RedirectFileNetlify RedirectFileFormat = "netlify" // static/_redirects
+	RedirectFileNginx   RedirectFileFormat = "nginx"   // static/redirects.map (nginx map syntax)
+)
+
+var redirectFileFormatNormalizer = normalization.NewNormalizer(map[string]RedirectFileFormat{
+	"netlify": RedirectFileNetlify,
+	"nginx":   RedirectFileNginx,
+}, "")
+
+// NormalizeRedirectFileFormat canonicalizes user input returning empty string if unknown.
+func NormalizeRedirectFileFormat(raw string) RedirectFileFormat {
+	return redirectFileFormatNormalizer.Normalize(raw)
+}
+
+func normalizeRedirects(r *RedirectsConfig, res *NormalizationResult) {
+	if r == nil {
+		return
+	}
+	for i, f := range r.Files {
+		nf := NormalizeRedirectFileFormat(string(f))
+		if nf != "" && nf != f {
+			res.Warnings = append(res.Warnings, warnChanged("redirects.files", f, nf))
+			r.Files[i] = nf
+		}
+	}
+	for i := range r.Rules {
+		r.Rules[i].From = strings.TrimSpace(r.Rules[i].From)
+		r.Rules[i].To = strings.TrimSpace(r.Rules[i].To)
+	}
+	r.VanityPrefix = strings.TrimSpace(r.VanityPrefix)
+}
+
+// validateRedirects validates redirect file formats and explicit rules.
+func (cv *configurationValidator) validateRedirects() error {
+	r := cv.config.Redirects
+	if r == nil {
+		return nil
+	}
+	for _, f := range r.Files {
+		if NormalizeRedirectFileFormat(string(f)) == "" {
+			return errors.NewError(errors.CategoryValidation, "invalid redirects file format").
+				WithContext("format", string(f)).
+				WithContext("valid_values", "netlify, nginx").
+				Build()
+		}
+	}
+	if err := cv.validateVanityPrefix(r.VanityPrefix); err != nil {
+		return err
+	}
+	targets := make(map[string]string, len(r.Rules))
+	for _, rule := range r.Rules {
+		if rule.From == "" || rule.To == "" {
+			return errors.NewError(errors.CategoryValidation, "redirect rule requires both from and to").
+				WithContext("from", rule.From).
+				WithContext("to", rule.To).
+				Build()
+		}
+		if !strings.HasPrefix(rule.From, "/") {
+			return errors.NewError(errors.CategoryValidation, "redirect rule from must be an absolute URL path").
+				WithContext("from", rule.From).
+				Build()
+		}
+		if _, dup := targets[rule.From]; dup {
+			return errors.NewError(errors.CategoryValidation, "duplicate redirect rule").
+				WithContext("from", rule.From).
+				Build()
+		}
+		switch rule.StatusCode() {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		default:
+			return errors.NewError(errors.CategoryValidation, "invalid redirect rule status").
+				WithContext("from", rule.From).
+				WithContext("status", rule.Status).
+				WithContext("valid_values", "301, 302, 307, 308").
+				Build()
+		}
+		if strings.HasPrefix(rule.From, "/api/") {
+			return errors.NewError(errors.CategoryValidation, "redirect rule conflicts with the docs server API").
+				WithContext("from", rule.From).
+				Build()
+		}
+		if r.VanityEnabled() && strings.HasPrefix(rule.From, r.VanityPrefix) {
+			return errors.NewError(errors.CategoryValidation, "redirect rule conflicts with vanity_prefix").
+				WithContext("from", rule.From).
+				WithContext("vanity_prefix", r.VanityPrefix).
+				Build()
+		}
+		targets[rule.From] = rule.To
+	}
+	return validateRedirectLoops(targets)
+}
+
+// validateVanityPrefix requires an absolute path prefix that does not shadow repository content.
+func (cv *configurationValidator) validateVanityPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if prefix == "/" || !strings.HasPrefix(prefix, "/") || !strings.HasSuffix(prefix, "/") {
+		return errors.NewError(errors.CategoryValidation, "redirects vanity_prefix must be an absolute path ending in /").
+			WithContext("vanity_prefix", prefix).
+			Build()
+	}
+	if strings.HasPrefix(prefix, "/api/") {
+		return errors.NewError(errors.CategoryValidation, "redirects vanity_prefix conflicts with the docs server API").
+			WithContext("vanity_prefix", prefix).
+			Build()
+	}
+	top := strings.SplitN(strings.Trim(prefix, "/"), "/", 2)[0]
+	for _, repo := range cv.config.Repositories {
+		if strings.EqualFold(repo.Name, top) {
+			return errors.NewError(errors.CategoryValidation, "redirects vanity_prefix conflicts with repository content").
+				WithContext("vanity_prefix", prefix).
+				WithContext("repository", repo.Name).
+				Build()
+		}
+	}
+	return nil
+}
+
+// validateRedirectLoops rejects rules that redirect back to themselves through a chain of rules.
+func validateRedirectLoops(targets map[string]string) error {
+	for from := range targets {
+		seen := map[string]struct{}{from: {}}
+		for next, ok := targets[from]; ok; next, ok = targets[next] {
+			if _, loop := seen[next]; loop {
+				return errors.NewError(errors.CategoryValidation, "redirect rules form a loop").
+					WithContext("from", from).
+					Build()
+			}
+			seen[next] = struct{}{}
+		}
+	}
+	return nil
+}
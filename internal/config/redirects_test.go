@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig_Redirects(t *testing.T) {
+	base := func(r *RedirectsConfig) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{{Name: "r", URL: "https://example.com/r.git"}}, Redirects: r}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(&RedirectsConfig{
+		Files:        []RedirectFileFormat{RedirectFileNetlify, RedirectFileNginx},
+		Rules:        []RedirectRule{{From: "/old/", To: "/new/"}, {From: "/tmp/", To: "/new/", Status: 307}},
+		VanityPrefix: "/go/",
+	})); err != nil {
+		t.Fatalf("expected valid redirects config, got %v", err)
+	}
+
+	cases := map[string]*RedirectsConfig{
+		"invalid redirects file format":     {Files: []RedirectFileFormat{"apache"}},
+		"requires both from and to":         {Rules: []RedirectRule{{From: "/old/"}}},
+		"must be an absolute URL path":      {Rules: []RedirectRule{{From: "old/", To: "/new/"}}},
+		"duplicate redirect rule":           {Rules: []RedirectRule{{From: "/a/", To: "/b/"}, {From: "/a/", To: "/c/"}}},
+		"invalid redirect rule status":      {Rules: []RedirectRule{{From: "/a/", To: "/b/", Status: 200}}},
+		"conflicts with the docs server":    {Rules: []RedirectRule{{From: "/api/status", To: "/b/"}}},
+		"conflicts with vanity_prefix":      {Rules: []RedirectRule{{From: "/go/x/", To: "/b/"}}, VanityPrefix: "/go/"},
+		"redirect rules form a loop":        {Rules: []RedirectRule{{From: "/a/", To: "/b/"}, {From: "/b/", To: "/a/"}}},
+		"must be an absolute path ending":   {VanityPrefix: "r"},
+		"conflicts with repository content": {VanityPrefix: "/R/"},
+	}
+	for want, rc := range cases {
+		err := ValidateConfig(base(rc))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestRedirectsConfig_MoveTrackingDefault(t *testing.T) {
+	var nilCfg *RedirectsConfig
+	if !nilCfg.MoveTrackingEnabled() {
+		t.Fatalf("expected move tracking enabled by default")
+	}
+	off := false
+	if (&RedirectsConfig{TrackMoves: &off}).MoveTrackingEnabled() {
+		t.Fatalf("expected move tracking disabled when track_moves=false")
+	}
+}
@@ -1,7 +1,14 @@
 package config
 
+import (
+	"regexp"
+	"strings"
+)
+
 // Repository represents a Git repository to process (shared between config and generator logic).
 type Repository struct {
+	// Type is "git" (default) or "static" for content downloaded over HTTP(S); see Static.
+	Type        RepositoryType    `yaml:"type,omitempty"`
 	URL         string            `yaml:"url"`
 	Name        string            `yaml:"name"`
 	Branch      string            `yaml:"branch,omitempty"`
@@ -10,6 +17,54 @@ type Repository struct {
 	Paths       []string          `yaml:"paths,omitempty"`   // Specific paths to docs, defaults applied elsewhere
 	Tags        map[string]string `yaml:"tags,omitempty"`    // Additional metadata (forge discovery, etc.)
 	Version     string            `yaml:"version,omitempty"` // Version label when expanded from versioning discovery
+	// GitMetadata controls whether last-modified/contributor front matter is derived from git history.
+	// When unset, defaults to true.
+	GitMetadata *bool `yaml:"git_metadata,omitempty"`
+	// Sections splits a monorepo into independent site areas, one per docs path.
+	// When set, Paths is derived from the section paths.
+	Sections []RepositorySection `yaml:"sections,omitempty"`
+	// EditURLTemplate overrides build.edit_url_template for this repository.
+	EditURLTemplate string `yaml:"edit_url_template,omitempty"`
+	// Sanitize overrides the global shortcode/HTML policy (per kind) for this repository.
+	Sanitize *SanitizeConfig `yaml:"sanitize,omitempty"`
+	// Guardrails overrides the global content limits (per limit) for this repository.
+	Guardrails *GuardrailsConfig `yaml:"guardrails,omitempty"`
+	// PageMetadata overrides the global reading time / table of contents settings (per field).
+	PageMetadata *PageMetadataConfig `yaml:"page_metadata,omitempty"`
+	// Export overrides whether and how this repository is exported to PDF/EPUB.
+	Export *RepositoryExport `yaml:"export,omitempty"`
+	// PageTags and PageCategories are added to the tags and categories of every page of
+	// the repository. Discovery sets them from forge topics (filtering.topic_mappings).
+	PageTags       []string `yaml:"page_tags,omitempty"`
+	PageCategories []string `yaml:"page_categories,omitempty"`
+	// WebhookBranches lists glob patterns of branches whose webhook pushes may trigger
+	// builds of this repository. Empty allows every branch (subject to the forge allowlist).
+	WebhookBranches []string `yaml:"webhook_branches,omitempty"`
+	// WebhookCooldown is the minimum time between webhook builds of this repository
+	// (for example "10m"). Pushes within the window are coalesced into one build at its
+	// end. Overrides the forge's webhook.cooldown.
+	WebhookCooldown string `yaml:"webhook_cooldown,omitempty"`
+	// Submodules initializes and updates git submodules (recursively) on clone and update.
+	Submodules bool `yaml:"submodules,omitempty"`
+	// LFS downloads git-LFS objects after clone and update. It needs the git-lfs
+	// extension; without it, LFS files stay pointer files and a warning is logged.
+	LFS bool `yaml:"lfs,omitempty"`
+	// Fetch is "clone" (default) or "archive" to download a tarball of the commit
+	// instead of cloning, falling back to a clone where that is not possible.
+	Fetch FetchMode `yaml:"fetch,omitempty"`
+	// CodeDocs runs documentation generators (gomarkdoc, typedoc) in the working copy
+	// before discovery; their markdown output is aggregated with the other docs.
+	CodeDocs []CodeDocsGenerator `yaml:"code_docs,omitempty"`
+	// Spellcheck adds custom dictionaries for this repository to the spelling rule of `docbuilder lint`.
+	Spellcheck *RepositorySpellcheck `yaml:"spellcheck,omitempty"`
+	// Static lists the downloads of a static repository (type static).
+	Static *StaticSource `yaml:"static,omitempty"`
+
+	// Commit pins the repository to an exact commit (full 40-character SHA). Branch is
+	// still fetched first; the commit is fetched by SHA when it is not reachable from it.
+	Commit string `yaml:"commit,omitempty"`
+	// Tag pins the repository to a tag instead of a branch.
+	Tag string `yaml:"tag,omitempty"`
 
 	// PinnedCommit optionally pins the repository to a specific commit SHA for this run.
 	//
@@ -17,6 +72,57 @@ type Repository struct {
 	// by orchestration flows (ADR-021 snapshot builds).
 	PinnedCommit string `json:"pinned_commit,omitempty" yaml:"-"`
 
+	// ReuseWorkingCopy builds the repository from its existing working copy without
+	// fetching. Like PinnedCommit it is injected at runtime, by scoped builds
+	// (see ScopeRepositories); a missing working copy is fetched as usual.
+	ReuseWorkingCopy bool `json:"reuse_working_copy,omitempty" yaml:"-"`
+
 	IsVersioned bool `yaml:"-"` // Internal flag indicating this repo was created from version expansion
 	IsTag       bool `yaml:"-"` // Internal flag indicating this is a tag reference (not a branch)
+
+	// WorktreeOf names the base repository whose shared clone this version is checked
+	// out from as a git worktree. Set by version expansion when versioning.worktrees is on.
+	WorktreeOf string `yaml:"-"`
+}
+
+// RepositorySection maps a docs path inside a repository to its own top-level site area.
+type RepositorySection struct {
+	Name   string `yaml:"name"`             // Content directory of the area; unique across repositories and sections
+	Path   string `yaml:"path"`             // Docs path relative to the repository root
+	Title  string `yaml:"title,omitempty"`  // Title of the area's index page (defaults to the name)
+	Weight int    `yaml:"weight,omitempty"` // Navigation weight of the area's index page
+}
+
+// SectionFor returns the section whose path is docsPath, or nil.
+func (r *Repository) SectionFor(docsPath string) *RepositorySection {
+	for i := range r.Sections {
+		if r.Sections[i].Path == docsPath {
+			return &r.Sections[i]
+		}
+	}
+	return nil
+}
+
+// GitMetadataEnabled reports whether git history metadata should be added to this repository's pages.
+// Static sources have no git history.
+func (r *Repository) GitMetadataEnabled() bool {
+	if r.IsStatic() {
+		return false
+	}
+	return r.GitMetadata == nil || *r.GitMetadata
+}
+
+// commitSHAPattern matches a full hexadecimal SHA-1 commit id.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// IsCommitSHA reports whether s is a full 40-character commit SHA.
+func IsCommitSHA(s string) bool { return commitSHAPattern.MatchString(s) }
+
+// TargetCommit returns the commit this run must build: a runtime pin (snapshot or
+// requested SHA) takes precedence over the configured commit. Empty means the branch head.
+func (r *Repository) TargetCommit() string {
+	if r.PinnedCommit != "" {
+		return strings.ToLower(r.PinnedCommit)
+	}
+	return strings.ToLower(r.Commit)
 }
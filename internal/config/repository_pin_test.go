@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepositoryPins(t *testing.T) {
+	sha := strings.Repeat("0123456789", 4)
+	for name, tc := range map[string]struct {
+		repo    Repository
+		wantErr string
+	}{
+		"commit":             {repo: Repository{Commit: sha}},
+		"commit with branch": {repo: Repository{Branch: "release", Commit: sha}},
+		"tag":                {repo: Repository{Tag: "v1.2.0"}},
+		"short commit":       {repo: Repository{Commit: "0123456"}, wantErr: "full 40-character SHA"},
+		"commit and tag":     {repo: Repository{Commit: sha, Tag: "v1.2.0"}, wantErr: "commit and tag are mutually exclusive"},
+		"branch and tag":     {repo: Repository{Branch: "main", Tag: "v1.2.0"}, wantErr: "branch and tag are mutually exclusive"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := tc.repo
+			repo.Name, repo.URL = "docs", "https://example.com/docs.git"
+			cfg := &Config{Version: "2.0", Repositories: []Repository{repo}}
+			if err := applyDefaults(cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(cfg)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateConfig() error = %v", err)
+			}
+			if tc.repo.Tag != "" && (!cfg.Repositories[0].IsTag || cfg.Repositories[0].Branch != tc.repo.Tag) {
+				t.Fatalf("tag not applied: %+v", cfg.Repositories[0])
+			}
+		})
+	}
+
+	pinned := Repository{Commit: strings.ToUpper(sha)}
+	if got := pinned.TargetCommit(); got != sha {
+		t.Fatalf("TargetCommit() = %q, want configured commit", got)
+	}
+	pinned.PinnedCommit = strings.Repeat("f", 40)
+	if got := pinned.TargetCommit(); got != pinned.PinnedCommit {
+		t.Fatalf("TargetCommit() = %q, want runtime pin", got)
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRepositorySections(t *testing.T) {
+	base := func(sections ...RepositorySection) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{
+			{Name: "mono", URL: "https://example.com/mono.git", Paths: []string{"ignored"}, Sections: sections},
+			{Name: "web", URL: "https://example.com/web.git"},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(RepositorySection{Name: "api", Path: "services/api/docs"}, RepositorySection{Name: "cli", Path: "cli/docs"})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid sections config, got %v", err)
+	}
+	if got := cfg.Repositories[0].Paths; !slices.Equal(got, []string{"services/api/docs", "cli/docs"}) {
+		t.Fatalf("paths = %v, want section paths", got)
+	}
+	if s := cfg.Repositories[0].SectionFor("cli/docs"); s == nil || s.Name != "cli" {
+		t.Fatalf("SectionFor(cli/docs) = %+v", s)
+	}
+
+	cases := map[string][]RepositorySection{
+		"requires name and path":            {{Name: "api"}},
+		"must not contain path separators":  {{Name: "a/b", Path: "docs"}},
+		"duplicate repository section name": {{Name: "api", Path: "a"}, {Name: "API", Path: "b"}},
+	}
+	for want, sections := range cases {
+		if err := ValidateConfig(base(sections...)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+	if err := ValidateConfig(base(RepositorySection{Name: "Web", Path: "docs"})); err == nil ||
+		!strings.Contains(err.Error(), "duplicate repository section name") {
+		t.Fatalf("expected collision with repository name, got %v", err)
+	}
+}
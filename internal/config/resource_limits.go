@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ResourceLimitsConfig limits the external processes of a build: the hugo renderer
+// and git CLI commands (worktree checkouts, LFS pulls). Clones and fetches run in the
+// DocBuilder process and are not limited.
+type ResourceLimitsConfig struct {
+	MaxMemoryMB  int    `yaml:"max_memory_mb,omitempty"` // Memory cap per process; 0 = unlimited
+	CPUShares    int    `yaml:"cpu_shares,omitempty"`    // Relative CPU weight (cgroup v1 scale, default 1024); 0 = unchanged
+	Nice         int    `yaml:"nice,omitempty"`          // Scheduling priority, -20 (highest) to 19 (lowest)
+	CgroupParent string `yaml:"cgroup_parent,omitempty"` // Delegated cgroup v2 directory for per-process groups (default: the daemon's own)
+}
+
+// Enabled reports whether any limit is set.
+func (r *ResourceLimitsConfig) Enabled() bool {
+	return r != nil && (r.MaxMemoryMB > 0 || r.CPUShares > 0 || r.Nice != 0)
+}
+
+// MaxMemoryBytes returns the memory cap in bytes, or 0 when unlimited.
+func (r *ResourceLimitsConfig) MaxMemoryBytes() int64 {
+	if r == nil || r.MaxMemoryMB <= 0 {
+		return 0
+	}
+	return int64(r.MaxMemoryMB) << 20
+}
+
+// CPUWeight converts CPUShares to a cgroup v2 cpu.weight (1-10000), or 0 when unset.
+func (r *ResourceLimitsConfig) CPUWeight() int {
+	if r == nil || r.CPUShares <= 0 {
+		return 0
+	}
+	shares := min(max(r.CPUShares, 2), 262144)
+	return 1 + ((shares-2)*9999)/262142
+}
+
+func validateBuildResources(r *ResourceLimitsConfig) error {
+	if r == nil {
+		return nil
+	}
+	if r.MaxMemoryMB < 0 || r.CPUShares < 0 {
+		return errors.NewError(errors.CategoryValidation, "build.resources limits cannot be negative").
+			WithContext("max_memory_mb", r.MaxMemoryMB).
+			WithContext("cpu_shares", r.CPUShares).
+			Build()
+	}
+	if r.CPUShares > 262144 {
+		return errors.NewError(errors.CategoryValidation, "build.resources.cpu_shares must be between 2 and 262144").
+			WithContext("value", r.CPUShares).
+			Build()
+	}
+	if r.Nice < -20 || r.Nice > 19 {
+		return errors.NewError(errors.CategoryValidation, "build.resources.nice must be between -20 and 19").
+			WithContext("value", r.Nice).
+			Build()
+	}
+	if r.CgroupParent != "" && !strings.HasPrefix(r.CgroupParent, "/") {
+		return errors.NewError(errors.CategoryValidation, "build.resources.cgroup_parent must be an absolute path").
+			WithContext("value", r.CgroupParent).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import "testing"
+
+func TestResourceLimitsConfig_Values(t *testing.T) {
+	var nilLimits *ResourceLimitsConfig
+	if nilLimits.Enabled() || nilLimits.MaxMemoryBytes() != 0 || nilLimits.CPUWeight() != 0 {
+		t.Fatalf("nil limits must be disabled")
+	}
+	if (&ResourceLimitsConfig{CgroupParent: "/builds"}).Enabled() {
+		t.Fatalf("cgroup_parent alone sets no limit")
+	}
+	limits := &ResourceLimitsConfig{MaxMemoryMB: 512, CPUShares: 1024, Nice: 10}
+	if !limits.Enabled() {
+		t.Fatalf("expected limits to be enabled")
+	}
+	if got := limits.MaxMemoryBytes(); got != 512<<20 {
+		t.Fatalf("expected 512 MiB, got %d", got)
+	}
+	for shares, want := range map[int]int{2: 1, 1024: 39, 262144: 10000} {
+		if got := (&ResourceLimitsConfig{CPUShares: shares}).CPUWeight(); got != want {
+			t.Fatalf("cpu_shares %d: expected cpu.weight %d, got %d", shares, want, got)
+		}
+	}
+}
+
+func TestValidateConfig_BuildResources(t *testing.T) {
+	for name, tc := range map[string]struct {
+		limits  ResourceLimitsConfig
+		wantErr bool
+	}{
+		"unset":                {ResourceLimitsConfig{}, false},
+		"limits":               {ResourceLimitsConfig{MaxMemoryMB: 2048, CPUShares: 512, Nice: 10, CgroupParent: "/docbuilder.slice/builds"}, false},
+		"negative nice":        {ResourceLimitsConfig{Nice: -5}, false},
+		"negative memory":      {ResourceLimitsConfig{MaxMemoryMB: -1}, true},
+		"cpu shares too large": {ResourceLimitsConfig{CPUShares: 300000}, true},
+		"nice out of range":    {ResourceLimitsConfig{Nice: 20}, true},
+		"relative cgroup":      {ResourceLimitsConfig{MaxMemoryMB: 1, CgroupParent: "builds"}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			limits := tc.limits
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Build:        BuildConfig{Resources: &limits},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
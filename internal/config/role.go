@@ -0,0 +1,31 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// Daemon roles.
+const (
+	// DaemonRoleBuilder runs discovery, builds and every HTTP server (the default).
+	DaemonRoleBuilder = "builder"
+	// DaemonRoleServer only serves the rendered site from a shared output directory.
+	DaemonRoleServer = "server"
+)
+
+// IsServerRole reports whether the daemon only serves the site (daemon.role: server).
+func (d *DaemonConfig) IsServerRole() bool { return d != nil && d.Role == DaemonRoleServer }
+
+func validateDaemonRole(d *DaemonConfig) error {
+	switch d.Role {
+	case "", DaemonRoleBuilder:
+		return nil
+	case DaemonRoleServer:
+		if d.LeaderElection.IsEnabled() {
+			return errors.NewError(errors.CategoryValidation, "daemon leader_election cannot be enabled for the server role").
+				Build()
+		}
+		return nil
+	default:
+		return errors.NewError(errors.CategoryValidation, "daemon role must be builder or server").
+			WithContext("value", d.Role).
+			Build()
+	}
+}
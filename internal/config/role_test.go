@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_DaemonRole(t *testing.T) {
+	for name, tc := range map[string]struct {
+		daemon  DaemonConfig
+		repos   []Repository
+		wantErr bool
+	}{
+		"default role":               {DaemonConfig{}, []Repository{{Name: "r"}}, false},
+		"builder needs repositories": {DaemonConfig{Role: DaemonRoleBuilder}, nil, true},
+		"server without sources":     {DaemonConfig{Role: DaemonRoleServer}, nil, false},
+		"unknown role":               {DaemonConfig{Role: "worker"}, []Repository{{Name: "r"}}, true},
+		"server with leader election": {DaemonConfig{
+			Role:           DaemonRoleServer,
+			LeaderElection: &LeaderElectionConfig{Enabled: true, Backend: LeaderElectionKubernetes},
+		}, nil, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			daemon := tc.daemon
+			daemon.Sync = SyncConfig{Schedule: "0 */4 * * *"}
+			cfg := Config{Version: "2.0", Repositories: tc.repos, Daemon: &daemon}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
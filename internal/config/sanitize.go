@@ -0,0 +1,150 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// SanitizeAction is the compatibility policy applied to a shortcode or raw HTML tag.
+type SanitizeAction string
+
+const (
+	// SanitizeAllow keeps the markup unchanged.
+	SanitizeAllow SanitizeAction = "allow"
+	// SanitizeEscape renders the markup literally instead of interpreting it.
+	SanitizeEscape SanitizeAction = "escape"
+	// SanitizeStrip removes the markup (for <script> and <style>, including their content).
+	SanitizeStrip SanitizeAction = "strip"
+)
+
+// SanitizeRules assigns actions to shortcode or HTML tag names. Names not listed get Default.
+type SanitizeRules struct {
+	Default SanitizeAction `yaml:"default,omitempty"` // Action for unlisted names (default: allow)
+	Allow   []string       `yaml:"allow,omitempty"`
+	Escape  []string       `yaml:"escape,omitempty"`
+	Strip   []string       `yaml:"strip,omitempty"`
+}
+
+// ActionFor returns the action for name (case-insensitive). A nil rule set allows everything.
+func (r *SanitizeRules) ActionFor(name string) SanitizeAction {
+	if r == nil {
+		return SanitizeAllow
+	}
+	match := func(names []string) bool {
+		return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) })
+	}
+	switch {
+	case match(r.Allow):
+		return SanitizeAllow
+	case match(r.Escape):
+		return SanitizeEscape
+	case match(r.Strip):
+		return SanitizeStrip
+	case r.Default != "":
+		return r.Default
+	}
+	return SanitizeAllow
+}
+
+// SanitizeConfig is the compatibility policy for Hugo shortcodes and raw HTML in source
+// markdown. It is set globally and can be overridden per repository.
+type SanitizeConfig struct {
+	Shortcodes *SanitizeRules `yaml:"shortcodes,omitempty"`
+	HTML       *SanitizeRules `yaml:"html,omitempty"`
+}
+
+// Enabled reports whether any rules are configured.
+func (s *SanitizeConfig) Enabled() bool {
+	return s != nil && (s.Shortcodes != nil || s.HTML != nil)
+}
+
+// SanitizePolicy returns the repository's effective policy: its own shortcode and HTML
+// rules where set, the global ones otherwise.
+func (r *Repository) SanitizePolicy(global *SanitizeConfig) *SanitizeConfig {
+	if r.Sanitize == nil {
+		return global
+	}
+	policy := *r.Sanitize
+	if global != nil {
+		if policy.Shortcodes == nil {
+			policy.Shortcodes = global.Shortcodes
+		}
+		if policy.HTML == nil {
+			policy.HTML = global.HTML
+		}
+	}
+	return &policy
+}
+
+// snapshotValue renders the policy in a stable order for config hashing.
+func (s *SanitizeConfig) snapshotValue() string {
+	rules := func(kind string, r *SanitizeRules) string {
+		if r == nil {
+			return kind + ":-"
+		}
+		sorted := func(names []string) string {
+			n := slices.Clone(names)
+			slices.Sort(n)
+			return strings.Join(n, "|")
+		}
+		return kind + ":" + string(r.Default) + ";allow=" + sorted(r.Allow) + ";escape=" + sorted(r.Escape) + ";strip=" + sorted(r.Strip)
+	}
+	return rules("shortcodes", s.Shortcodes) + "," + rules("html", s.HTML)
+}
+
+func (cv *configurationValidator) validateSanitize() error {
+	if err := validateSanitizeConfig(cv.config.Sanitize, ""); err != nil {
+		return err
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if err := validateSanitizeConfig(repo.Sanitize, repo.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSanitizeConfig(s *SanitizeConfig, repository string) error {
+	if s == nil {
+		return nil
+	}
+	for kind, r := range map[string]*SanitizeRules{"shortcodes": s.Shortcodes, "html": s.HTML} {
+		if r == nil {
+			continue
+		}
+		switch r.Default {
+		case "", SanitizeAllow, SanitizeEscape, SanitizeStrip:
+		default:
+			return errors.NewError(errors.CategoryValidation, "invalid sanitize default action").
+				WithContext("repository", repository).
+				WithContext("kind", kind).
+				WithContext("value", string(r.Default)).
+				WithContext("valid_values", []string{string(SanitizeAllow), string(SanitizeEscape), string(SanitizeStrip)}).
+				Build()
+		}
+		seen := make(map[string]string)
+		for action, names := range map[string][]string{"allow": r.Allow, "escape": r.Escape, "strip": r.Strip} {
+			for _, name := range names {
+				key := strings.ToLower(strings.TrimSpace(name))
+				if key == "" {
+					return errors.NewError(errors.CategoryValidation, "sanitize rule names cannot be empty").
+						WithContext("repository", repository).
+						WithContext("kind", kind).
+						Build()
+				}
+				if other, ok := seen[key]; ok && other != action {
+					return errors.NewError(errors.CategoryValidation, "sanitize rule name listed under multiple actions").
+						WithContext("repository", repository).
+						WithContext("kind", kind).
+						WithContext("name", name).
+						Build()
+				}
+				seen[key] = action
+			}
+		}
+	}
+	return nil
+}
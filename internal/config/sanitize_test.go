@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeConfig(t *testing.T) {
+	base := func(global, perRepo *SanitizeConfig) *Config {
+		cfg := &Config{Version: "2.0", Sanitize: global, Repositories: []Repository{
+			{Name: "app", URL: "https://example.com/app.git", Sanitize: perRepo},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	global := &SanitizeConfig{
+		Shortcodes: &SanitizeRules{Default: SanitizeEscape, Allow: []string{"ref", "relref"}},
+		HTML:       &SanitizeRules{Strip: []string{"script", "style"}},
+	}
+	perRepo := &SanitizeConfig{HTML: &SanitizeRules{Default: SanitizeStrip, Allow: []string{"details"}}}
+	cfg := base(global, perRepo)
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid sanitize config, got %v", err)
+	}
+
+	policy := cfg.Repositories[0].SanitizePolicy(cfg.Sanitize)
+	if got := policy.Shortcodes.ActionFor("Tabs"); got != SanitizeEscape {
+		t.Fatalf("shortcodes fall back to global rules, got %q", got)
+	}
+	if got := policy.Shortcodes.ActionFor("REF"); got != SanitizeAllow {
+		t.Fatalf("allowed shortcode = %q", got)
+	}
+	if got := policy.HTML.ActionFor("script"); got != SanitizeStrip {
+		t.Fatalf("repository html default = %q", got)
+	}
+	if got := policy.HTML.ActionFor("details"); got != SanitizeAllow {
+		t.Fatalf("repository html allow = %q", got)
+	}
+	if got := (*SanitizeRules)(nil).ActionFor("script"); got != SanitizeAllow {
+		t.Fatalf("nil rules = %q", got)
+	}
+
+	cases := map[string]*SanitizeConfig{
+		"invalid sanitize default action":                  {HTML: &SanitizeRules{Default: "drop"}},
+		"sanitize rule names cannot be empty":              {HTML: &SanitizeRules{Strip: []string{" "}}},
+		"sanitize rule name listed under multiple actions": {Shortcodes: &SanitizeRules{Allow: []string{"tabs"}, Strip: []string{"Tabs"}}},
+	}
+	for want, c := range cases {
+		if err := ValidateConfig(base(nil, c)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// blackoutTimeLayout is the layout of blackout window start and end times.
+const blackoutTimeLayout = "15:04"
+
+// weekdayNames maps the day names accepted in blackout windows to weekdays.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// BlackoutWindow is a recurring period in which no builds run, in the daemon's local
+// time. A window whose end is before its start ends on the next day; its days are the
+// days it starts on.
+type BlackoutWindow struct {
+	Days  []string `yaml:"days,omitempty"` // Weekdays ("mon" … "sun"); empty means every day
+	Start string   `yaml:"start"`          // Start time ("HH:MM")
+	End   string   `yaml:"end"`            // End time ("HH:MM"), exclusive
+}
+
+// Contains reports whether t falls within the window.
+func (w BlackoutWindow) Contains(t time.Time) bool {
+	_, ok := w.endAfter(t)
+	return ok
+}
+
+// endAfter returns the end of the window occurrence containing t, if any.
+func (w BlackoutWindow) endAfter(t time.Time) (time.Time, bool) {
+	start, err1 := time.Parse(blackoutTimeLayout, w.Start)
+	end, err2 := time.Parse(blackoutTimeLayout, w.End)
+	if err1 != nil || err2 != nil {
+		return time.Time{}, false
+	}
+	// Check the windows starting on the day of t and, for overnight windows, the day before.
+	for _, offset := range []int{0, -1} {
+		day := time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, t.Location())
+		if !w.onDay(day.Weekday()) {
+			continue
+		}
+		from := day.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+		to := day.Add(time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute)
+		if !to.After(from) {
+			to = to.AddDate(0, 0, 1)
+		}
+		if !t.Before(from) && t.Before(to) {
+			return to, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (w BlackoutWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(w.Days, func(name string) bool {
+		wd, ok := weekdayNames[strings.ToLower(name)]
+		return ok && wd == day
+	})
+}
+
+// JitterDuration returns the maximum random delay of scheduled syncs, or 0.
+func (s SyncConfig) JitterDuration() time.Duration {
+	if s.Jitter == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s.Jitter)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// InBlackout reports whether t falls within one of the blackout windows.
+func (s SyncConfig) InBlackout(t time.Time) bool {
+	return slices.ContainsFunc(s.BlackoutWindows, func(w BlackoutWindow) bool { return w.Contains(t) })
+}
+
+// maxBlackoutSpan bounds BlackoutEnd when the blackout windows cover every hour of the week.
+const maxBlackoutSpan = 8 * 24 * time.Hour
+
+// BlackoutEnd returns the first time at or after t that is outside every blackout window,
+// following windows that overlap or adjoin. It returns t when t is outside them.
+func (s SyncConfig) BlackoutEnd(t time.Time) time.Time {
+	end := t
+	for end.Sub(t) < maxBlackoutSpan {
+		next := end
+		for _, w := range s.BlackoutWindows {
+			if to, ok := w.endAfter(end); ok && to.After(next) {
+				next = to
+			}
+		}
+		if next.Equal(end) {
+			return end
+		}
+		end = next
+	}
+	return end
+}
+
+func validateDaemonSyncTiming(sync *SyncConfig) error {
+	if sync.Jitter != "" {
+		d, err := time.ParseDuration(sync.Jitter)
+		if err != nil || d < 0 {
+			return errors.NewError(errors.CategoryValidation, "invalid daemon sync jitter").
+				WithContext("jitter", sync.Jitter).
+				Build()
+		}
+	}
+	for i, w := range sync.BlackoutWindows {
+		start, err1 := time.Parse(blackoutTimeLayout, w.Start)
+		end, err2 := time.Parse(blackoutTimeLayout, w.End)
+		if err1 != nil || err2 != nil {
+			return errors.NewError(errors.CategoryValidation, "daemon sync blackout window times must be HH:MM").
+				WithContext("window", i).
+				WithContext("start", w.Start).
+				WithContext("end", w.End).
+				Build()
+		}
+		if start.Equal(end) {
+			return errors.NewError(errors.CategoryValidation, "daemon sync blackout window start and end must differ").
+				WithContext("window", i).
+				Build()
+		}
+		for _, day := range w.Days {
+			if _, ok := weekdayNames[strings.ToLower(day)]; !ok {
+				return errors.NewError(errors.CategoryValidation, "invalid daemon sync blackout window day").
+					WithContext("window", i).
+					WithContext("day", day).
+					WithContext("allowed_days", "mon, tue, wed, thu, fri, sat, sun").
+					Build()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateConfig_DaemonSyncTiming(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sync    SyncConfig
+		wantErr bool
+	}{
+		"valid": {SyncConfig{Jitter: "5m", BlackoutWindows: []BlackoutWindow{
+			{Days: []string{"mon", "Fri"}, Start: "09:00", End: "17:00"},
+			{Start: "22:00", End: "02:00"},
+		}}, false},
+		"invalid jitter":  {SyncConfig{Jitter: "soon"}, true},
+		"negative jitter": {SyncConfig{Jitter: "-1m"}, true},
+		"invalid start":   {SyncConfig{BlackoutWindows: []BlackoutWindow{{Start: "9am", End: "17:00"}}}, true},
+		"missing end":     {SyncConfig{BlackoutWindows: []BlackoutWindow{{Start: "09:00"}}}, true},
+		"empty window":    {SyncConfig{BlackoutWindows: []BlackoutWindow{{Start: "09:00", End: "09:00"}}}, true},
+		"invalid day":     {SyncConfig{BlackoutWindows: []BlackoutWindow{{Days: []string{"monday"}, Start: "09:00", End: "17:00"}}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.sync.Schedule = "0 */4 * * *"
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon:       &DaemonConfig{Sync: tc.sync},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSyncConfig_InBlackout(t *testing.T) {
+	sync := SyncConfig{BlackoutWindows: []BlackoutWindow{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00"},
+		{Days: []string{"sat"}, Start: "22:00", End: "02:00"},
+	}}
+	at := func(day, hour, minute int) time.Time {
+		// 2026-10-12 is a Monday.
+		return time.Date(2026, 10, 12+day, hour, minute, 0, 0, time.UTC)
+	}
+
+	for name, tc := range map[string]struct {
+		t    time.Time
+		want bool
+	}{
+		"weekday morning":       {at(0, 8, 59), false},
+		"weekday start":         {at(0, 9, 0), true},
+		"weekday afternoon":     {at(4, 16, 59), true},
+		"weekday end":           {at(2, 17, 0), false},
+		"saturday business day": {at(5, 12, 0), false},
+		"saturday night":        {at(5, 23, 30), true},
+		"sunday after midnight": {at(6, 1, 59), true},
+		"sunday night":          {at(6, 23, 0), false},
+	} {
+		if got := sync.InBlackout(tc.t); got != tc.want {
+			t.Errorf("%s: InBlackout(%s) = %v, want %v", name, tc.t.Format(time.RFC1123), got, tc.want)
+		}
+	}
+
+	if got := (SyncConfig{Jitter: "90s"}).JitterDuration(); got != 90*time.Second {
+		t.Errorf("JitterDuration() = %v, want 90s", got)
+	}
+}
+
+func TestSyncConfig_BlackoutEnd(t *testing.T) {
+	sync := SyncConfig{BlackoutWindows: []BlackoutWindow{
+		{Start: "09:00", End: "12:00"},
+		{Start: "11:00", End: "13:00"},
+		{Days: []string{"sat"}, Start: "22:00", End: "02:00"},
+	}}
+	at := func(day, hour, minute int) time.Time {
+		// 2026-10-12 is a Monday.
+		return time.Date(2026, 10, 12+day, hour, minute, 0, 0, time.UTC)
+	}
+
+	for name, tc := range map[string]struct {
+		t, want time.Time
+	}{
+		"outside":         {at(0, 8, 0), at(0, 8, 0)},
+		"overlapping":     {at(0, 10, 0), at(0, 13, 0)},
+		"second window":   {at(0, 12, 30), at(0, 13, 0)},
+		"across midnight": {at(5, 23, 0), at(6, 2, 0)},
+	} {
+		if got := sync.BlackoutEnd(tc.t); !got.Equal(tc.want) {
+			t.Errorf("%s: BlackoutEnd(%s) = %s, want %s", name, tc.t.Format(time.RFC1123), got.Format(time.RFC1123), tc.want.Format(time.RFC1123))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// ScopeRepositories prepares repos for a scoped build that only fetches the named
+// repositories. Names may be repository names or monorepo section names; a section
+// scopes its whole repository. Every other repository is marked ReuseWorkingCopy so
+// its cached content is rendered unchanged.
+//
+// The returned slice is a copy. An empty scope returns the repositories unchanged,
+// and names that match no repository or section are a validation error.
+func ScopeRepositories(repos []Repository, names []string) ([]Repository, error) {
+	scoped := slices.Clone(repos)
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = false
+		}
+	}
+	if len(wanted) == 0 {
+		return scoped, nil
+	}
+
+	inScope := make([]bool, len(scoped))
+	for i := range scoped {
+		for _, name := range scopeNames(&scoped[i]) {
+			if _, ok := wanted[name]; ok {
+				wanted[name] = true
+				inScope[i] = true
+			}
+		}
+	}
+
+	var unknown []string
+	for name, matched := range wanted {
+		if !matched {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, errors.NewError(errors.CategoryValidation, "build scope names unknown repositories").
+			WithContext("unknown", strings.Join(unknown, ", ")).
+			Build()
+	}
+
+	for i := range scoped {
+		scoped[i].ReuseWorkingCopy = !inScope[i]
+	}
+	return scoped, nil
+}
+
+// scopeNames returns the names that select repo in a build scope.
+func scopeNames(repo *Repository) []string {
+	names := []string{repo.Name}
+	for _, s := range repo.Sections {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// CommitSnapshot validates commits requested for a build (repository name -> commit SHA)
+// and returns them keyed by repository URL, as build snapshots are. Repositories marked
+// ReuseWorkingCopy are outside the build scope and cannot be pinned.
+func CommitSnapshot(repos []Repository, commits map[string]string) (map[string]string, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	snapshot := make(map[string]string, len(commits))
+	for name, sha := range commits {
+		if !IsCommitSHA(sha) {
+			return nil, errors.NewError(errors.CategoryValidation, "requested commit must be a full 40-character SHA").
+				WithContext("repository", name).
+				WithContext("commit", sha).
+				Build()
+		}
+		idx := slices.IndexFunc(repos, func(r Repository) bool { return r.Name == name })
+		if idx < 0 {
+			return nil, errors.NewError(errors.CategoryValidation, "requested commit names an unknown repository").
+				WithContext("repository", name).
+				Build()
+		}
+		if repos[idx].ReuseWorkingCopy {
+			return nil, errors.NewError(errors.CategoryValidation, "requested commit names a repository outside the build scope").
+				WithContext("repository", name).
+				Build()
+		}
+		snapshot[repos[idx].URL] = strings.ToLower(sha)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScopeRepositories(t *testing.T) {
+	repos := []Repository{
+		{Name: "repo-a"},
+		{Name: "repo-b"},
+		{Name: "mono", Sections: []RepositorySection{{Name: "api", Path: "api/docs"}, {Name: "cli", Path: "cli/docs"}}},
+	}
+
+	for name, tc := range map[string]struct {
+		scope []string
+		reuse []bool
+	}{
+		"empty scope":           {nil, []bool{false, false, false}},
+		"single repository":     {[]string{"repo-b"}, []bool{true, false, true}},
+		"section selects repo":  {[]string{"repo-a", " api "}, []bool{false, true, false}},
+		"blank names are empty": {[]string{" "}, []bool{false, false, false}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scoped, err := ScopeRepositories(repos, tc.scope)
+			if err != nil {
+				t.Fatalf("ScopeRepositories() error = %v", err)
+			}
+			got := make([]bool, len(scoped))
+			for i := range scoped {
+				got[i] = scoped[i].ReuseWorkingCopy
+			}
+			if !slices.Equal(got, tc.reuse) {
+				t.Fatalf("ReuseWorkingCopy = %v, want %v", got, tc.reuse)
+			}
+		})
+	}
+
+	if repos[0].ReuseWorkingCopy || repos[2].ReuseWorkingCopy {
+		t.Fatalf("input repositories must not be modified")
+	}
+
+	_, err := ScopeRepositories(repos, []string{"repo-a", "nope", "missing"})
+	if err == nil || !strings.Contains(err.Error(), "unknown repositories") {
+		t.Fatalf("expected unknown repositories error, got %v", err)
+	}
+}
+
+func TestCommitSnapshot(t *testing.T) {
+	sha := strings.Repeat("ab", 20)
+	repos := []Repository{
+		{Name: "repo-a", URL: "https://git.example.com/a.git"},
+		{Name: "repo-b", URL: "https://git.example.com/b.git", ReuseWorkingCopy: true},
+	}
+
+	snapshot, err := CommitSnapshot(repos, map[string]string{"repo-a": strings.ToUpper(sha)})
+	if err != nil {
+		t.Fatalf("CommitSnapshot() error = %v", err)
+	}
+	if snapshot["https://git.example.com/a.git"] != sha || len(snapshot) != 1 {
+		t.Fatalf("unexpected snapshot %v", snapshot)
+	}
+
+	for name, commits := range map[string]map[string]string{
+		"short sha":     {"repo-a": "abc123"},
+		"unknown repo":  {"repo-c": sha},
+		"outside scope": {"repo-b": sha},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := CommitSnapshot(repos, commits); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// SEOConfig controls search-engine oriented post-processing of the rendered site.
+// All features require hugo.base_url to produce absolute URLs.
+type SEOConfig struct {
+	Sitemap   bool          `yaml:"sitemap,omitempty"`   // Validate/rewrite public/sitemap.xml against base_url (generate when missing)
+	Canonical bool          `yaml:"canonical,omitempty"` // Inject <link rel="canonical"> into pages lacking one
+	Robots    *RobotsConfig `yaml:"robots,omitempty"`    // Write public/robots.txt when set
+}
+
+// RobotsConfig describes the generated robots.txt.
+type RobotsConfig struct {
+	UserAgent string   `yaml:"user_agent,omitempty"` // Defaults to "*"
+	Allow     []string `yaml:"allow,omitempty"`      // Path prefixes explicitly allowed
+	Disallow  []string `yaml:"disallow,omitempty"`   // Path prefixes to block (e.g. "/previews/")
+}
+
+// validateSEO validates robots path prefixes.
+func (cv *configurationValidator) validateSEO() error {
+	seo := cv.config.Hugo.SEO
+	if seo == nil || seo.Robots == nil {
+		return nil
+	}
+	for _, p := range append(append([]string{}, seo.Robots.Allow...), seo.Robots.Disallow...) {
+		if !strings.HasPrefix(strings.TrimSpace(p), "/") {
+			return errors.NewError(errors.CategoryValidation, "robots allow/disallow entries must be absolute URL paths").
+				WithContext("path", p).
+				Build()
+		}
+	}
+	return nil
+}
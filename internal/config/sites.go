@@ -0,0 +1,228 @@
+package config
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// SiteConfig is a named site built by the daemon next to the main site, for example
+// the documentation of another department. It shares the daemon's forges, workspace
+// and build queue but has its own repositories, output directory and base URL.
+type SiteConfig struct {
+	Name            string       `yaml:"name"`                   // Lowercase identifier used in admin endpoints and job IDs
+	Title           string       `yaml:"title,omitempty"`        // Site title (defaults to hugo.title)
+	BaseURL         string       `yaml:"base_url,omitempty"`     // Site base URL; its host routes docs server requests to the site
+	OutputDirectory string       `yaml:"output_directory"`       // Output directory (relative to output.base_directory if set)
+	Profile         string       `yaml:"profile,omitempty"`      // Build profile of the site (defaults to build.profile)
+	Repositories    []Repository `yaml:"repositories,omitempty"` // Explicit repositories of the site
+	// Filtering selects the site's repositories among the discovered (or configured)
+	// repositories instead of the top-level filtering. Without repositories and filtering
+	// the site builds the same repositories as the main site.
+	Filtering *FilteringConfig `yaml:"filtering,omitempty"`
+	// Domains are additional host names the docs server serves the site on, next to
+	// the host of base_url (e.g. "platform.docs.internal").
+	Domains []string `yaml:"domains,omitempty"`
+	// CacheRules override the default Cache-Control headers for matching paths of the site.
+	CacheRules []CacheRule `yaml:"cache_rules,omitempty"`
+}
+
+// CacheRule sets the Cache-Control header of the paths matching a pattern. Patterns
+// containing "/" match the URL path (e.g. "/api/*"); other patterns match the last path
+// element (e.g. "*.pdf"). The first matching rule of a site wins.
+type CacheRule struct {
+	Path         string `yaml:"path"`
+	CacheControl string `yaml:"cache_control"`
+}
+
+// Matches reports whether the rule applies to a URL path.
+func (r CacheRule) Matches(urlPath string) bool {
+	name := urlPath
+	if !strings.Contains(r.Path, "/") {
+		name = path.Base(urlPath)
+	}
+	ok, err := path.Match(r.Path, name)
+	return ok && err == nil
+}
+
+// Site returns the named site of daemon.sites, or nil.
+func (c *Config) Site(name string) *SiteConfig {
+	if c == nil || c.Daemon == nil {
+		return nil
+	}
+	for i := range c.Daemon.Sites {
+		if c.Daemon.Sites[i].Name == name {
+			return &c.Daemon.Sites[i]
+		}
+	}
+	return nil
+}
+
+// SiteBuildConfig returns the configuration a site is built with: the main configuration
+// with the site's output directory, base URL, title, profile and repositories. The
+// returned configuration shares unchanged sections with c and must not be modified.
+func (c *Config) SiteBuildConfig(site *SiteConfig) *Config {
+	derived := *c
+	derived.Output.Directory = site.OutputDirectory
+	if site.BaseURL != "" {
+		derived.Hugo.BaseURL = site.BaseURL
+	}
+	if site.Title != "" {
+		derived.Hugo.Title = site.Title
+	}
+	if site.Profile != "" {
+		derived.Build.Profile = site.Profile
+	}
+	// Skip evaluation compares against the state of the main site's builds.
+	derived.Build.SkipIfUnchanged = false
+	if len(site.Repositories) > 0 {
+		derived.Repositories = slices.Clone(site.Repositories)
+	}
+	if site.Filtering != nil {
+		derived.Filtering = site.Filtering
+	}
+	return &derived
+}
+
+// SiteHost returns the lowercase host name of the site's base URL, or "" without one.
+func (s *SiteConfig) SiteHost() string {
+	u, err := url.Parse(s.BaseURL)
+	if s.BaseURL == "" || err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// Hosts returns the lowercase host names the site is served on: the host of its base
+// URL followed by its domains.
+func (s *SiteConfig) Hosts() []string {
+	var hosts []string
+	for _, host := range append([]string{s.SiteHost()}, s.Domains...) {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// outputPath resolves an output directory against output.base_directory for comparison.
+func outputPath(out OutputConfig, dir string) string {
+	if dir == "" {
+		dir = defaultOutputDir
+	}
+	if out.BaseDirectory != "" && !filepath.IsAbs(dir) {
+		dir = filepath.Join(out.BaseDirectory, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+func validateDaemonSites(cfg *Config) error {
+	names := make(map[string]bool, len(cfg.Daemon.Sites))
+	outputs := map[string]string{outputPath(cfg.Output, cfg.Output.Directory): "main site"}
+	mainHost := (&SiteConfig{BaseURL: cfg.Hugo.BaseURL}).SiteHost()
+	for i := range cfg.Daemon.Sites {
+		site := &cfg.Daemon.Sites[i]
+		if !profileNamePattern.MatchString(site.Name) {
+			return errors.NewError(errors.CategoryValidation, "invalid daemon site name").
+				WithContext("site", site.Name).
+				WithContext("pattern", profileNamePattern.String()).
+				Build()
+		}
+		if names[site.Name] {
+			return errors.NewError(errors.CategoryValidation, "duplicate daemon site name").
+				WithContext("site", site.Name).
+				Build()
+		}
+		names[site.Name] = true
+
+		if strings.TrimSpace(site.OutputDirectory) == "" {
+			return errors.NewError(errors.CategoryValidation, "daemon site output_directory is required").
+				WithContext("site", site.Name).
+				Build()
+		}
+		out := outputPath(cfg.Output, site.OutputDirectory)
+		if owner, taken := outputs[out]; taken {
+			return errors.NewError(errors.CategoryValidation, "daemon site output_directory is already used").
+				WithContext("site", site.Name).
+				WithContext("output_directory", site.OutputDirectory).
+				WithContext("conflicts_with", owner).
+				Build()
+		}
+		outputs[out] = site.Name
+
+		if site.BaseURL != "" {
+			u, err := url.Parse(site.BaseURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.NewError(errors.CategoryValidation, "daemon site base_url must be an absolute http(s) URL").
+					WithContext("site", site.Name).
+					WithContext("base_url", site.BaseURL).
+					Build()
+			}
+		}
+		for _, domain := range site.Domains {
+			if strings.TrimSpace(domain) == "" || strings.ContainsAny(domain, "/:@ ") {
+				return errors.NewError(errors.CategoryValidation, "daemon site domain must be a host name").
+					WithContext("site", site.Name).
+					WithContext("domain", domain).
+					Build()
+			}
+			if owner := siteHostOwner(cfg, site, mainHost, strings.ToLower(domain)); owner != "" {
+				return errors.NewError(errors.CategoryValidation, "daemon site domain is already used").
+					WithContext("site", site.Name).
+					WithContext("domain", domain).
+					WithContext("conflicts_with", owner).
+					Build()
+			}
+		}
+		for _, rule := range site.CacheRules {
+			if _, err := path.Match(rule.Path, ""); err != nil || rule.Path == "" {
+				return errors.NewError(errors.CategoryValidation, "invalid daemon site cache rule path").
+					WithContext("site", site.Name).
+					WithContext("path", rule.Path).
+					Build()
+			}
+			if strings.TrimSpace(rule.CacheControl) == "" {
+				return errors.NewError(errors.CategoryValidation, "daemon site cache rule cache_control is required").
+					WithContext("site", site.Name).
+					WithContext("path", rule.Path).
+					Build()
+			}
+		}
+		if site.Profile != "" && !cfg.Build.ProfileDeclared(site.Profile) {
+			return errors.NewError(errors.CategoryValidation, "unknown daemon site profile").
+				WithContext("site", site.Name).
+				WithContext("profile", site.Profile).
+				Build()
+		}
+		if len(site.Repositories) > 0 && site.Filtering != nil {
+			return errors.NewError(errors.CategoryValidation, "daemon site repositories and filtering are mutually exclusive").
+				WithContext("site", site.Name).
+				Build()
+		}
+		if len(site.Repositories) > 0 {
+			if err := newConfigurationValidator(cfg.SiteBuildConfig(site)).validateRepositories(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// siteHostOwner returns the main site or the other site served on host, or "".
+func siteHostOwner(cfg *Config, site *SiteConfig, mainHost, host string) string {
+	if host == mainHost {
+		return "main site"
+	}
+	for i := range cfg.Daemon.Sites {
+		other := &cfg.Daemon.Sites[i]
+		if other != site && slices.Contains(other.Hosts(), host) {
+			return other.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_DaemonSites(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sites   []SiteConfig
+		wantErr bool
+	}{
+		"valid": {[]SiteConfig{
+			{Name: "finance", OutputDirectory: "./site-finance", BaseURL: "https://finance-docs.example.com/", Repositories: []Repository{{Name: "ledger", URL: "https://git.example.com/finance/ledger.git"}}},
+			{Name: "hr", OutputDirectory: "./site-hr", Filtering: &FilteringConfig{IncludePatterns: []string{"hr-*"}}},
+		}, false},
+		"invalid name":          {[]SiteConfig{{Name: "Finance Docs", OutputDirectory: "./a"}}, true},
+		"duplicate name":        {[]SiteConfig{{Name: "a", OutputDirectory: "./a"}, {Name: "a", OutputDirectory: "./b"}}, true},
+		"missing output":        {[]SiteConfig{{Name: "a"}}, true},
+		"output of main site":   {[]SiteConfig{{Name: "a", OutputDirectory: "site"}}, true},
+		"shared output":         {[]SiteConfig{{Name: "a", OutputDirectory: "./x"}, {Name: "b", OutputDirectory: "x/"}}, true},
+		"relative base_url":     {[]SiteConfig{{Name: "a", OutputDirectory: "./a", BaseURL: "/docs/"}}, true},
+		"undeclared profile":    {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Profile: "external"}}, true},
+		"repos and filtering":   {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Repositories: []Repository{{Name: "r2"}}, Filtering: &FilteringConfig{}}}, true},
+		"invalid site repo pin": {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Repositories: []Repository{{Name: "r2", Commit: "abc"}}}}, true},
+		"domains and cache rules": {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Domains: []string{"a.docs.internal"},
+			CacheRules: []CacheRule{{Path: "*.pdf", CacheControl: "no-store"}}}}, false},
+		"domain with scheme":  {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Domains: []string{"https://a.example.com"}}}, true},
+		"domain of main site": {[]SiteConfig{{Name: "a", OutputDirectory: "./a", Domains: []string{"Docs.example.com"}}}, true},
+		"domain of other site": {[]SiteConfig{
+			{Name: "a", OutputDirectory: "./a", BaseURL: "https://a.example.com/"},
+			{Name: "b", OutputDirectory: "./b", Domains: []string{"a.example.com"}},
+		}, true},
+		"invalid cache rule path":  {[]SiteConfig{{Name: "a", OutputDirectory: "./a", CacheRules: []CacheRule{{Path: "[", CacheControl: "no-store"}}}}, true},
+		"cache rule without value": {[]SiteConfig{{Name: "a", OutputDirectory: "./a", CacheRules: []CacheRule{{Path: "*.pdf"}}}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Build:        BuildConfig{Profiles: []string{"internal"}},
+				Output:       OutputConfig{Directory: "./site"},
+				Hugo:         HugoConfig{BaseURL: "https://docs.example.com/"},
+				Daemon: &DaemonConfig{
+					Sync:  SyncConfig{Schedule: "0 */4 * * *"},
+					Sites: tc.sites,
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SiteBuildConfig(t *testing.T) {
+	cfg := &Config{
+		Version:      "2.0",
+		Repositories: []Repository{{Name: "main-repo"}},
+		Hugo:         HugoConfig{Title: "Docs", BaseURL: "https://docs.example.com/"},
+		Build:        BuildConfig{SkipIfUnchanged: true, Profiles: []string{"internal", "external"}, Profile: "internal"},
+		Output:       OutputConfig{Directory: "./site"},
+		Daemon: &DaemonConfig{Sites: []SiteConfig{{
+			Name:            "finance",
+			Title:           "Finance Docs",
+			BaseURL:         "https://finance-docs.example.com/",
+			OutputDirectory: "./site-finance",
+			Profile:         "external",
+			Repositories:    []Repository{{Name: "ledger"}},
+		}}},
+	}
+	if err := applyDefaults(cfg); err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+	site := cfg.Site("finance")
+	if site == nil || cfg.Site("hr") != nil {
+		t.Fatalf("site lookup failed")
+	}
+	if site.Repositories[0].Branch != "main" || site.Repositories[0].Paths[0] != "docs" {
+		t.Fatalf("expected repository defaults for site repositories, got %+v", site.Repositories[0])
+	}
+	if site.SiteHost() != "finance-docs.example.com" {
+		t.Fatalf("unexpected site host %q", site.SiteHost())
+	}
+
+	derived := cfg.SiteBuildConfig(site)
+	if derived.Output.Directory != "./site-finance" || derived.Hugo.BaseURL != site.BaseURL || derived.Hugo.Title != "Finance Docs" ||
+		derived.Build.Profile != "external" || derived.Build.SkipIfUnchanged {
+		t.Fatalf("site overrides not applied: %+v", derived)
+	}
+	if len(derived.Repositories) != 1 || derived.Repositories[0].Name != "ledger" {
+		t.Fatalf("expected site repositories, got %+v", derived.Repositories)
+	}
+	if cfg.Output.Directory != "./site" || cfg.Hugo.Title != "Docs" || cfg.Repositories[0].Name != "main-repo" || !cfg.Build.SkipIfUnchanged {
+		t.Fatalf("main configuration must not change")
+	}
+}
+
+func TestSiteConfig_HostsAndCacheRules(t *testing.T) {
+	site := &SiteConfig{BaseURL: "https://Platform.example.com:8443/docs/", Domains: []string{"platform.docs.internal", "PLATFORM.example.com"}}
+	hosts := site.Hosts()
+	if len(hosts) != 2 || hosts[0] != "platform.example.com" || hosts[1] != "platform.docs.internal" {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+
+	for _, tc := range []struct {
+		rule CacheRule
+		path string
+		want bool
+	}{
+		{CacheRule{Path: "*.pdf"}, "/guides/manual.pdf", true},
+		{CacheRule{Path: "*.pdf"}, "/guides/manual.html", false},
+		{CacheRule{Path: "/api/*"}, "/api/index.json", true},
+		{CacheRule{Path: "/api/*"}, "/guides/api/index.json", false},
+	} {
+		if got := tc.rule.Matches(tc.path); got != tc.want {
+			t.Errorf("%q.Matches(%q) = %v, want %v", tc.rule.Path, tc.path, got, tc.want)
+		}
+	}
+}
@@ -22,11 +22,33 @@ func (c *Config) Snapshot() string {
 	// Hugo essentials
 	w("hugo.base_url", c.Hugo.BaseURL)
 	w("hugo.title", c.Hugo.Title)
+	if c.Hugo.SEO != nil {
+		w("hugo.seo.sitemap", boolToString(c.Hugo.SEO.Sitemap))
+		w("hugo.seo.canonical", boolToString(c.Hugo.SEO.Canonical))
+		if r := c.Hugo.SEO.Robots; r != nil {
+			w("hugo.seo.robots.user_agent", r.UserAgent)
+			w("hugo.seo.robots.allow", strings.Join(r.Allow, ","))
+			w("hugo.seo.robots.disallow", strings.Join(r.Disallow, ","))
+		}
+	}
 	// Build flags
 	w("build.render_mode", string(c.Build.RenderMode))
 	w("build.namespace_forges", string(c.Build.NamespaceForges))
+	if c.Build.NamespaceOrgDepth > 0 {
+		w("build.namespace_org_depth", intToString(c.Build.NamespaceOrgDepth))
+	}
 	w("build.clone_strategy", string(c.Build.CloneStrategy))
 	w("build.retry_backoff", string(c.Build.RetryBackoff))
+	if c.Build.EditURLTemplate != "" {
+		w("build.edit_url_template", c.Build.EditURLTemplate)
+	}
+	if c.Build.RepositoryMeta {
+		w("build.repository_meta", "true")
+	}
+	if c.Build.Profile != "" || len(c.Build.Profiles) > 0 {
+		w("build.profile", c.Build.Profile)
+		w("build.profiles", strings.Join(c.Build.Profiles, ","))
+	}
 	// Versioning
 	if c.Versioning != nil {
 		w("versioning.strategy", string(c.Versioning.Strategy))
@@ -42,6 +64,15 @@ func (c *Config) Snapshot() string {
 			w("versioning.tag_patterns", strings.Join(tp, ","))
 		}
 	}
+	if c.FrontMatter != nil {
+		w("front_matter", c.FrontMatter.snapshotValue())
+	}
+	if c.Sanitize.Enabled() {
+		w("sanitize", c.Sanitize.snapshotValue())
+	}
+	if c.Guardrails.Enabled() {
+		w("guardrails", c.Guardrails.snapshotValue())
+	}
 	// Output
 	w("output.directory", c.Output.Directory)
 	// Daemon content policies (build-affecting when daemon config is present)
@@ -70,6 +101,68 @@ func (c *Config) Snapshot() string {
 			sort.Strings(ig)
 			w("filtering.ignore_files", strings.Join(ig, ","))
 		}
+		if len(c.Filtering.TopicMappings) > 0 {
+			tm := make([]string, 0, len(c.Filtering.TopicMappings))
+			for _, m := range c.Filtering.TopicMappings {
+				tm = append(tm, m.snapshotValue())
+			}
+			w("filtering.topic_mappings", strings.Join(tm, ","))
+		}
+	}
+	// Redirects (aliases and redirect map files are written into the site)
+	if c.Redirects != nil {
+		w("redirects.track_moves", boolToString(c.Redirects.MoveTrackingEnabled()))
+		if len(c.Redirects.Files) > 0 {
+			rf := make([]string, 0, len(c.Redirects.Files))
+			for _, f := range c.Redirects.Files {
+				rf = append(rf, string(f))
+			}
+			sort.Strings(rf)
+			w("redirects.files", strings.Join(rf, ","))
+		}
+		if len(c.Redirects.Rules) > 0 {
+			rr := make([]string, 0, len(c.Redirects.Rules))
+			for _, r := range c.Redirects.Rules {
+				rule := r.From + "->" + r.To
+				if r.Status != 0 {
+					rule += "@" + intToString(r.Status)
+				}
+				rr = append(rr, rule)
+			}
+			sort.Strings(rr)
+			w("redirects.rules", strings.Join(rr, ","))
+		}
+	}
+	// Staleness flags and banners change generated page content
+	if c.Staleness.IsEnabled() {
+		w("staleness.max_age_days", intToString(c.Staleness.Threshold()))
+		w("staleness.banner", boolToString(c.Staleness.Banner))
+	}
+	// The ADR index adds generated pages
+	if c.ADRIndex.IsEnabled() {
+		w("adr_index.section", c.ADRIndex.SectionName())
+		w("adr_index.title", c.ADRIndex.IndexTitle())
+		w("adr_index.patterns", strings.Join(c.ADRIndex.Patterns, ","))
+	}
+	// Reading time and table of contents params change page front matter
+	if c.PageMetadata != nil {
+		w("page_metadata", c.PageMetadata.snapshotValue())
+	}
+	// Exports add downloads and index links to the site
+	if c.Export != nil {
+		w("export", c.Export.snapshotValue())
+	}
+	// SRI attributes and checksum files change the published output
+	if i := c.SiteIntegrity(); i != nil {
+		w("post_process.integrity", i.snapshotValue())
+	}
+	// Archives are build artifacts; a newly enabled archive needs a build
+	if a := c.SiteArchive(); a != nil {
+		w("post_process.archive", a.snapshotValue())
+	}
+	// Hook commands can change the cloned sources and the generated project
+	if c.Hooks != nil {
+		w("hooks", c.Hooks.snapshotValue())
 	}
 	// Monitoring logging (affects runtime logging but not site content; included for completeness)
 	if c.Monitoring != nil {
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// SpellcheckConfig enables the spelling rule of `docbuilder lint`.
+type SpellcheckConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Wordlists are language word lists (one word per line, or hunspell .dic files).
+	// Without one, only common misspellings are reported. Relative paths are resolved
+	// against the directory of the configuration file.
+	Wordlists []string `yaml:"wordlists,omitempty"`
+	// Dictionaries are project-wide custom dictionaries: files of additional accepted
+	// words, resolved like Wordlists.
+	Dictionaries []string `yaml:"dictionaries,omitempty"`
+	Words        []string `yaml:"words,omitempty"` // Additional accepted words
+}
+
+// IsEnabled reports whether lint checks spelling.
+func (s *SpellcheckConfig) IsEnabled() bool { return s != nil && s.Enabled }
+
+// RepositorySpellcheck adds accepted words for the documentation of one repository.
+type RepositorySpellcheck struct {
+	// Dictionaries are custom dictionary files relative to the repository root.
+	Dictionaries []string `yaml:"dictionaries,omitempty"`
+	Words        []string `yaml:"words,omitempty"`
+}
+
+func (cv *configurationValidator) validateSpellcheck() error {
+	s := cv.config.Spellcheck
+	if s != nil {
+		for _, p := range append(append([]string{}, s.Wordlists...), s.Dictionaries...) {
+			if strings.TrimSpace(p) == "" {
+				return errors.NewError(errors.CategoryValidation, "spellcheck word list paths must not be empty").Build()
+			}
+		}
+	}
+	for i := range cv.config.Repositories {
+		repo := &cv.config.Repositories[i]
+		if repo.Spellcheck == nil {
+			continue
+		}
+		for _, p := range repo.Spellcheck.Dictionaries {
+			clean := path.Clean(filepath.ToSlash(p))
+			if strings.TrimSpace(p) == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+				return errors.NewError(errors.CategoryValidation, "spellcheck dictionaries must be relative to the repository root").
+					WithContext("repository", repo.Name).
+					WithContext("path", p).
+					Build()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSpellcheck(t *testing.T) {
+	base := func(global *SpellcheckConfig, repo *RepositorySpellcheck) *Config {
+		cfg := &Config{Version: "2.0", Spellcheck: global, Repositories: []Repository{
+			{Name: "docs", URL: "https://example.com/docs.git", Spellcheck: repo},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+	valid := base(
+		&SpellcheckConfig{Enabled: true, Wordlists: []string{"/usr/share/dict/words"}, Words: []string{"docbuilder"}},
+		&RepositorySpellcheck{Dictionaries: []string{"docs/.wordlist.txt"}},
+	)
+	if err := ValidateConfig(valid); err != nil {
+		t.Fatalf("expected valid spellcheck, got %v", err)
+	}
+
+	cases := []struct {
+		cfg  *Config
+		want string
+	}{
+		{base(&SpellcheckConfig{Enabled: true, Dictionaries: []string{" "}}, nil), "must not be empty"},
+		{base(nil, &RepositorySpellcheck{Dictionaries: []string{"/etc/words"}}), "relative to the repository root"},
+		{base(nil, &RepositorySpellcheck{Dictionaries: []string{"../words.txt"}}), "relative to the repository root"},
+	}
+	for _, tc := range cases {
+		err := ValidateConfig(tc.cfg)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected error containing %q, got %v", tc.want, err)
+		}
+	}
+}
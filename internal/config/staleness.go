@@ -0,0 +1,38 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// defaultStalenessMaxAgeDays is the page age threshold applied when staleness is enabled without max_age_days.
+const defaultStalenessMaxAgeDays = 180
+
+// StalenessConfig controls detection of pages that have not been modified for a long time.
+// Page age is derived from git history (see Repository.GitMetadata) or an explicit lastmod.
+type StalenessConfig struct {
+	Enabled    bool `yaml:"enabled"`
+	MaxAgeDays int  `yaml:"max_age_days,omitempty"` // Pages older than this are flagged (default 180)
+	Banner     bool `yaml:"banner,omitempty"`       // Prepend a warning notice to stale pages
+}
+
+// Threshold returns the effective max age in days.
+func (s *StalenessConfig) Threshold() int {
+	if s == nil || s.MaxAgeDays <= 0 {
+		return defaultStalenessMaxAgeDays
+	}
+	return s.MaxAgeDays
+}
+
+// IsEnabled reports whether staleness detection is active.
+func (s *StalenessConfig) IsEnabled() bool { return s != nil && s.Enabled }
+
+func (cv *configurationValidator) validateStaleness() error {
+	s := cv.config.Staleness
+	if s == nil {
+		return nil
+	}
+	if s.MaxAgeDays < 0 {
+		return errors.NewError(errors.CategoryValidation, "staleness max_age_days must be >= 0").
+			WithContext("value", s.MaxAgeDays).
+			Build()
+	}
+	return nil
+}
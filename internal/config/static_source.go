@@ -0,0 +1,176 @@
+package config
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// RepositoryType selects how the content of a repository is obtained.
+type RepositoryType string
+
+const (
+	// RepositoryTypeGit clones the repository with git (the default).
+	RepositoryTypeGit RepositoryType = "git"
+	// RepositoryTypeStatic downloads an archive or a set of files over HTTP(S).
+	RepositoryTypeStatic RepositoryType = "static"
+)
+
+// StaticSource describes content downloaded over HTTP(S) instead of cloned with git,
+// such as generated OpenAPI bundles or wiki exports. The downloads are cached in the
+// workspace and aggregated like the working copy of a repository.
+type StaticSource struct {
+	// Archive is the URL of a zip, tar or tar.gz archive extracted into the working copy.
+	Archive string `yaml:"archive,omitempty"`
+	// SHA256 is the expected checksum of the archive. When set, a cached archive is reused
+	// without contacting the server.
+	SHA256 string `yaml:"sha256,omitempty"`
+	// StripComponents removes leading path elements from archive entries (like tar --strip-components).
+	StripComponents int `yaml:"strip_components,omitempty"`
+	// Files are single files written into the working copy (after extracting the archive).
+	Files []StaticFile `yaml:"files,omitempty"`
+}
+
+// StaticFile is a single downloaded file of a static source.
+type StaticFile struct {
+	URL    string `yaml:"url"`
+	Path   string `yaml:"path,omitempty"`   // Destination relative to the working copy (defaults to the URL's file name)
+	SHA256 string `yaml:"sha256,omitempty"` // Expected checksum of the file
+}
+
+// IsStatic reports whether the repository is a static source downloaded over HTTP(S).
+func (r *Repository) IsStatic() bool {
+	return r.Type == RepositoryTypeStatic
+}
+
+// Destination returns the path of the file relative to the working copy.
+func (f StaticFile) Destination() string {
+	if f.Path != "" {
+		return path.Clean(f.Path)
+	}
+	if u, err := url.Parse(f.URL); err == nil {
+		return path.Base(u.Path)
+	}
+	return ""
+}
+
+// SourceURL returns the URL identifying a static source: its archive or its first file.
+func (s *StaticSource) SourceURL() string {
+	if s == nil {
+		return ""
+	}
+	if s.Archive != "" {
+		return s.Archive
+	}
+	if len(s.Files) > 0 {
+		return s.Files[0].URL
+	}
+	return ""
+}
+
+// sha256Pattern matches a hexadecimal SHA-256 checksum.
+var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// validateRepoType checks the repository type and, for static sources, their downloads.
+func validateRepoType(repo *Repository) error {
+	switch repo.Type {
+	case "", RepositoryTypeGit:
+		if repo.Static != nil {
+			return errors.NewError(errors.CategoryValidation, "repository static block requires type static").
+				WithContext("repository", repo.Name).
+				Build()
+		}
+		return nil
+	case RepositoryTypeStatic:
+		return validateStaticSource(repo)
+	default:
+		return errors.NewError(errors.CategoryValidation, "unsupported repository type").
+			WithContext("repository", repo.Name).
+			WithContext("type", string(repo.Type)).
+			Build()
+	}
+}
+
+// validateStaticSource checks the download URLs, checksums and destinations of a static
+// source and rejects git-only settings.
+func validateStaticSource(repo *Repository) error {
+	s := repo.Static
+	if s == nil || (s.Archive == "" && len(s.Files) == 0) {
+		return errors.NewError(errors.CategoryValidation, "static repository requires static.archive or static.files").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	if repo.Commit != "" || repo.Tag != "" || repo.Submodules || repo.LFS {
+		return errors.NewError(errors.CategoryValidation, "static repository does not support commit, tag, submodules or lfs").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	if repo.Auth != nil {
+		switch repo.Auth.Type {
+		case AuthTypeToken, AuthTypeBasic, AuthTypeNone, "":
+		default:
+			return errors.NewError(errors.CategoryValidation, "static repository auth must be token, basic or none").
+				WithContext("repository", repo.Name).
+				WithContext("type", string(repo.Auth.Type)).
+				Build()
+		}
+	}
+	if s.StripComponents < 0 {
+		return errors.NewError(errors.CategoryValidation, "static.strip_components must not be negative").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	if s.Archive != "" {
+		if err := validateStaticDownload(repo.Name, s.Archive, s.SHA256); err != nil {
+			return err
+		}
+	} else if s.SHA256 != "" {
+		return errors.NewError(errors.CategoryValidation, "static.sha256 requires static.archive").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	seen := make(map[string]bool, len(s.Files))
+	for _, f := range s.Files {
+		if err := validateStaticDownload(repo.Name, f.URL, f.SHA256); err != nil {
+			return err
+		}
+		dest := f.Destination()
+		if dest == "" || dest == "." || dest == "/" || path.IsAbs(dest) || dest == ".." || strings.HasPrefix(dest, "../") {
+			return errors.NewError(errors.CategoryValidation, "static file path must be relative to the working copy").
+				WithContext("repository", repo.Name).
+				WithContext("url", f.URL).
+				WithContext("path", f.Path).
+				Build()
+		}
+		if seen[dest] {
+			return errors.NewError(errors.CategoryValidation, "duplicate static file path").
+				WithContext("repository", repo.Name).
+				WithContext("path", dest).
+				Build()
+		}
+		seen[dest] = true
+	}
+	return nil
+}
+
+// validateStaticDownload checks that a download URL is http(s) and its checksum well-formed.
+func validateStaticDownload(repoName, raw, sum string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewError(errors.CategoryValidation, "static download URL must be an http or https URL").
+			WithContext("repository", repoName).
+			WithContext("url", raw).
+			Build()
+	}
+	if sum != "" && !sha256Pattern.MatchString(sum) {
+		return errors.NewError(errors.CategoryValidation, "static sha256 must be a 64-character hex checksum").
+			WithContext("repository", repoName).
+			WithContext("url", raw).
+			WithContext("sha256", sum).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStaticSourceConfig(t *testing.T) {
+	const sum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	base := func(repo Repository) *Config {
+		cfg := &Config{Version: "2.0", Repositories: []Repository{repo}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := base(Repository{Name: "api", Type: RepositoryTypeStatic, Static: &StaticSource{
+		Files: []StaticFile{{URL: "https://example.com/openapi/bundle.md", SHA256: sum}},
+	}})
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("expected valid static source, got %v", err)
+	}
+	repo := cfg.Repositories[0]
+	if repo.URL != "https://example.com/openapi/bundle.md" || len(repo.Paths) != 1 || repo.Paths[0] != "." || repo.Branch != "" {
+		t.Fatalf("unexpected static defaults: url=%q paths=%v branch=%q", repo.URL, repo.Paths, repo.Branch)
+	}
+	if repo.GitMetadataEnabled() {
+		t.Fatalf("static sources have no git metadata")
+	}
+	if got := repo.Static.Files[0].Destination(); got != "bundle.md" {
+		t.Fatalf("expected destination from the URL file name, got %q", got)
+	}
+
+	cases := map[string]Repository{
+		"unsupported repository type": {Name: "a", Type: "svn"},
+		"repository static block requires type static": {Name: "a", URL: "https://example.com/a.git",
+			Static: &StaticSource{Archive: "https://example.com/a.zip"}},
+		"requires static.archive or static.files": {Name: "a", Type: RepositoryTypeStatic, Static: &StaticSource{}},
+		"must be an http or https URL": {Name: "a", Type: RepositoryTypeStatic,
+			Static: &StaticSource{Archive: "file:///etc/docs.zip"}},
+		"64-character hex checksum": {Name: "a", Type: RepositoryTypeStatic,
+			Static: &StaticSource{Archive: "https://example.com/a.zip", SHA256: "abc"}},
+		"must be relative to the working copy": {Name: "a", Type: RepositoryTypeStatic,
+			Static: &StaticSource{Files: []StaticFile{{URL: "https://example.com/a.md", Path: "../a.md"}}}},
+		"duplicate static file path": {Name: "a", Type: RepositoryTypeStatic, Static: &StaticSource{Files: []StaticFile{
+			{URL: "https://example.com/v1/a.md"}, {URL: "https://example.com/v2/a.md"},
+		}}},
+		"does not support commit, tag, submodules or lfs": {Name: "a", Type: RepositoryTypeStatic, LFS: true,
+			Static: &StaticSource{Archive: "https://example.com/a.zip"}},
+		"auth must be token, basic or none": {Name: "a", Type: RepositoryTypeStatic, Auth: &AuthConfig{Type: AuthTypeSSH},
+			Static: &StaticSource{Archive: "https://example.com/a.zip"}},
+	}
+	for want, repo := range cases {
+		if err := ValidateConfig(base(repo)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
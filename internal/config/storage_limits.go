@@ -0,0 +1,53 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+const defaultStorageWarnPercent = 80
+
+// StorageLimitsConfig sets disk usage limits for the daemon's directories. The daemon
+// measures usage after each build; /health/detailed reports a warning once usage
+// reaches WarnPercent of a limit. Limits are advisory: nothing is deleted.
+type StorageLimitsConfig struct {
+	WorkspaceMB int `yaml:"workspace_mb,omitempty"` // Repository cache (repo_cache_dir); 0 = no limit
+	OutputMB    int `yaml:"output_mb,omitempty"`    // Output directory; 0 = no limit
+	WarnPercent int `yaml:"warn_percent,omitempty"` // Share of a limit that raises a warning (default 80)
+}
+
+// WorkspaceBytes returns the workspace limit in bytes, or 0 when unlimited.
+func (l *StorageLimitsConfig) WorkspaceBytes() int64 {
+	if l == nil || l.WorkspaceMB <= 0 {
+		return 0
+	}
+	return int64(l.WorkspaceMB) << 20
+}
+
+// OutputBytes returns the output directory limit in bytes, or 0 when unlimited.
+func (l *StorageLimitsConfig) OutputBytes() int64 {
+	if l == nil || l.OutputMB <= 0 {
+		return 0
+	}
+	return int64(l.OutputMB) << 20
+}
+
+// WarnRatio returns the share of a limit at which usage is reported as a warning.
+func (l *StorageLimitsConfig) WarnRatio() float64 {
+	if l == nil || l.WarnPercent <= 0 {
+		return defaultStorageWarnPercent / 100.0
+	}
+	return float64(l.WarnPercent) / 100
+}
+
+func validateDaemonStorageLimits(l *StorageLimitsConfig) error {
+	if l.WorkspaceMB < 0 || l.OutputMB < 0 {
+		return errors.NewError(errors.CategoryValidation, "daemon storage.limits sizes must not be negative").
+			WithContext("workspace_mb", l.WorkspaceMB).
+			WithContext("output_mb", l.OutputMB).
+			Build()
+	}
+	if l.WarnPercent < 0 || l.WarnPercent > 100 {
+		return errors.NewError(errors.CategoryValidation, "daemon storage.limits.warn_percent must be between 1 and 100").
+			WithContext("value", l.WarnPercent).
+			Build()
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestStorageLimitsConfig_Defaults(t *testing.T) {
+	var nilLimits *StorageLimitsConfig
+	if nilLimits.WorkspaceBytes() != 0 || nilLimits.OutputBytes() != 0 {
+		t.Fatalf("nil limits must be unlimited")
+	}
+	if got := nilLimits.WarnRatio(); got != 0.8 {
+		t.Fatalf("expected default warn ratio 0.8, got %v", got)
+	}
+	limits := &StorageLimitsConfig{WorkspaceMB: 2048, OutputMB: 1, WarnPercent: 90}
+	if got := limits.WorkspaceBytes(); got != 2<<30 {
+		t.Fatalf("expected 2 GiB workspace limit, got %d", got)
+	}
+	if got := limits.OutputBytes(); got != 1<<20 {
+		t.Fatalf("expected 1 MiB output limit, got %d", got)
+	}
+	if got := limits.WarnRatio(); got != 0.9 {
+		t.Fatalf("expected warn ratio 0.9, got %v", got)
+	}
+}
+
+func TestValidateConfig_DaemonStorageLimits(t *testing.T) {
+	for name, tc := range map[string]struct {
+		limits  StorageLimitsConfig
+		wantErr bool
+	}{
+		"unset":                 {StorageLimitsConfig{}, false},
+		"limits":                {StorageLimitsConfig{WorkspaceMB: 4096, OutputMB: 512, WarnPercent: 75}, false},
+		"negative size":         {StorageLimitsConfig{OutputMB: -1}, true},
+		"warn percent over 100": {StorageLimitsConfig{WorkspaceMB: 10, WarnPercent: 120}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			limits := tc.limits
+			cfg := Config{
+				Version:      "2.0",
+				Repositories: []Repository{{Name: "r"}},
+				Daemon: &DaemonConfig{
+					Sync:    SyncConfig{Schedule: "0 */4 * * *"},
+					Storage: StorageConfig{Limits: &limits},
+				},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
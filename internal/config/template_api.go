@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// TemplateAPIConfig controls the authenticated JSON template API on the daemon admin server.
+type TemplateAPIConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Token   string `yaml:"token"` // Bearer token required by clients (supports ${ENV} expansion)
+}
+
+// IsEnabled reports whether the template API is active.
+func (t *TemplateAPIConfig) IsEnabled() bool { return t != nil && t.Enabled }
+
+func validateDaemonTemplateAPI(t *TemplateAPIConfig) error {
+	if t.Enabled && strings.TrimSpace(t.Token) == "" {
+		return errors.NewError(errors.CategoryValidation, "daemon template_api token is required when enabled").Build()
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package config
+
+import "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+
+// TemplatesConfig selects where `docbuilder template` commands read templates from
+// when no rendered site is used. At most one of Dir and Repository may be set.
+type TemplatesConfig struct {
+	// Dir is a local directory scanned recursively for *.template.md files.
+	Dir string `yaml:"dir,omitempty"`
+	// Repository is a git repository containing *.template.md files. Paths optionally
+	// restricts scanning to subdirectories of the clone.
+	Repository *Repository `yaml:"repository,omitempty"`
+}
+
+func (cv *configurationValidator) validateTemplates() error {
+	t := cv.config.Templates
+	if t == nil {
+		return nil
+	}
+	if t.Dir != "" && t.Repository != nil {
+		return errors.NewError(errors.CategoryValidation, "templates dir and repository are mutually exclusive").Build()
+	}
+	if t.Repository != nil && t.Repository.URL == "" {
+		return errors.NewError(errors.CategoryValidation, "templates repository url is required").Build()
+	}
+	return nil
+}
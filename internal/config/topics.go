@@ -0,0 +1,75 @@
+package config
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// TopicMapping maps forge repository topics to site taxonomy terms. Pages of discovered
+// repositories with a matching topic get the terms in their tags and categories.
+type TopicMapping struct {
+	Topic      string   `yaml:"topic"`                // Topic name or glob pattern (case-insensitive)
+	Tags       []string `yaml:"tags,omitempty"`       // Tags to add; the matched topic itself when no tags or categories are set
+	Categories []string `yaml:"categories,omitempty"` // Categories to add
+}
+
+// ResolveTopics returns the tags and categories the topic mappings assign to a repository
+// with the given topics, in mapping order and without duplicates.
+func (f *FilteringConfig) ResolveTopics(topics []string) (tags, categories []string) {
+	if f == nil || len(f.TopicMappings) == 0 {
+		return nil, nil
+	}
+	for _, m := range f.TopicMappings {
+		pattern := strings.ToLower(m.Topic)
+		for _, topic := range topics {
+			if ok, _ := path.Match(pattern, strings.ToLower(topic)); !ok {
+				continue
+			}
+			if len(m.Tags) == 0 && len(m.Categories) == 0 {
+				tags = appendUnique(tags, topic)
+				continue
+			}
+			tags = appendUnique(tags, m.Tags...)
+			categories = appendUnique(categories, m.Categories...)
+		}
+	}
+	return tags, categories
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" || slices.Contains(list, v) {
+			continue
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+// snapshotValue renders a topic mapping for config hashing.
+func (m TopicMapping) snapshotValue() string {
+	return strings.ToLower(m.Topic) + "->" + strings.Join(m.Tags, "|") + "/" + strings.Join(m.Categories, "|")
+}
+
+func (cv *configurationValidator) validateTopicMappings() error {
+	if cv.config.Filtering == nil {
+		return nil
+	}
+	for i, m := range cv.config.Filtering.TopicMappings {
+		if strings.TrimSpace(m.Topic) == "" {
+			return errors.NewError(errors.CategoryValidation, "filtering topic_mappings entry requires a topic").
+				WithContext("index", i).
+				Build()
+		}
+		if _, err := path.Match(strings.ToLower(m.Topic), ""); err != nil {
+			return errors.NewError(errors.CategoryValidation, "invalid filtering topic_mappings pattern").
+				WithContext("topic", m.Topic).
+				WithCause(err).
+				Build()
+		}
+	}
+	return nil
+}
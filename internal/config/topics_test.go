@@ -0,0 +1,56 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilteringConfig_ResolveTopics(t *testing.T) {
+	f := &FilteringConfig{TopicMappings: []TopicMapping{
+		{Topic: "docs-platform", Tags: []string{"platform"}, Categories: []string{"Platform"}},
+		{Topic: "team-*"},
+		{Topic: "DOCS-*", Tags: []string{"platform", "docs"}},
+	}}
+
+	tags, categories := f.ResolveTopics([]string{"team-payments", "docs-platform", "golang"})
+	if want := []string{"platform", "team-payments", "docs"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+	if want := []string{"Platform"}; !reflect.DeepEqual(categories, want) {
+		t.Errorf("categories = %v, want %v", categories, want)
+	}
+
+	if tags, categories := f.ResolveTopics([]string{"golang"}); tags != nil || categories != nil {
+		t.Errorf("unmatched topics resolved to %v/%v", tags, categories)
+	}
+	var unset *FilteringConfig
+	if tags, _ := unset.ResolveTopics([]string{"docs-platform"}); tags != nil {
+		t.Errorf("nil filtering resolved tags %v", tags)
+	}
+}
+
+func TestValidateTopicMappings(t *testing.T) {
+	base := func(mappings ...TopicMapping) *Config {
+		cfg := &Config{Version: "2.0", Filtering: &FilteringConfig{TopicMappings: mappings}, Repositories: []Repository{
+			{Name: "app", URL: "https://example.com/app.git"},
+		}}
+		if err := applyDefaults(cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		return cfg
+	}
+
+	if err := ValidateConfig(base(TopicMapping{Topic: "team-*"})); err != nil {
+		t.Fatalf("expected valid topic mappings, got %v", err)
+	}
+	cases := map[string]TopicMapping{
+		"topic_mappings entry requires a topic":    {Topic: " ", Tags: []string{"x"}},
+		"invalid filtering topic_mappings pattern": {Topic: "team-["},
+	}
+	for want, m := range cases {
+		if err := ValidateConfig(base(m)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
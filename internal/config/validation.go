@@ -2,11 +2,10 @@ package config
 
 import (
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/go-co-op/gocron/v2"
-
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 )
 
@@ -57,6 +56,57 @@ func (cv *configurationValidator) validate() error {
 	if err := cv.validateVersioning(); err != nil {
 		return err
 	}
+	if err := cv.validateTopicMappings(); err != nil {
+		return err
+	}
+	if err := cv.validateRedirects(); err != nil {
+		return err
+	}
+	if err := cv.validateSEO(); err != nil {
+		return err
+	}
+	if err := cv.validateStaleness(); err != nil {
+		return err
+	}
+	if err := cv.validateADRIndex(); err != nil {
+		return err
+	}
+	if err := cv.validateSpellcheck(); err != nil {
+		return err
+	}
+	if err := cv.validatePageMetadata(); err != nil {
+		return err
+	}
+	if err := cv.validateExport(); err != nil {
+		return err
+	}
+	if err := cv.validatePostProcess(); err != nil {
+		return err
+	}
+	if err := cv.validatePurge(); err != nil {
+		return err
+	}
+	if err := cv.validateBuildHooks(); err != nil {
+		return err
+	}
+	if err := cv.validateTemplates(); err != nil {
+		return err
+	}
+	if err := cv.validateFrontMatter(); err != nil {
+		return err
+	}
+	if err := cv.validateEditURLTemplates(); err != nil {
+		return err
+	}
+	if err := cv.validateSanitize(); err != nil {
+		return err
+	}
+	if err := cv.validateGuardrails(); err != nil {
+		return err
+	}
+	if err := cv.validateMonitoringLogging(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -72,29 +122,90 @@ func (cv *configurationValidator) validateDaemon() error {
 
 	// Validate cron expression via gocron parser by attempting to create a cron job.
 	// Note: scheduler is not started; we only want parse/validation.
-	scheduler, err := gocron.NewScheduler()
-	if err != nil {
-		return errors.WrapError(err, errors.CategoryValidation, "failed to create scheduler for schedule validation").Build()
-	}
-	defer func() { _ = scheduler.Shutdown() }()
-
-	_, err = scheduler.NewJob(
-		gocron.CronJob(expr, false),
-		gocron.NewTask(func() {}),
-		gocron.WithName("daemon-sync-validation"),
-	)
-	if err != nil {
+	if err := validateCronExpression(expr, "daemon-sync-validation"); err != nil {
 		return errors.WrapError(err, errors.CategoryValidation, "invalid daemon sync schedule").
 			WithContext("schedule", cv.config.Daemon.Sync.Schedule).
 			Build()
 	}
 
+	if err := validateDaemonSyncTiming(&cv.config.Daemon.Sync); err != nil {
+		return err
+	}
+
 	if cv.config.Daemon.BuildDebounce != nil {
 		if err := validateDaemonBuildDebounce(cv.config.Daemon.BuildDebounce); err != nil {
 			return err
 		}
 	}
 
+	if cv.config.Daemon.Feedback != nil {
+		if err := validateDaemonFeedback(cv.config.Daemon.Feedback); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.Analytics != nil {
+		if err := validateDaemonAnalytics(cv.config.Daemon.Analytics); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.TemplateAPI != nil {
+		if err := validateDaemonTemplateAPI(cv.config.Daemon.TemplateAPI); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.Watch != nil {
+		if err := validateDaemonWatch(cv.config.Daemon.Watch); err != nil {
+			return err
+		}
+	}
+
+	if err := validateDaemonHTTP(&cv.config.Daemon.HTTP); err != nil {
+		return err
+	}
+
+	if err := validateDaemonRole(cv.config.Daemon); err != nil {
+		return err
+	}
+
+	if cv.config.Daemon.LeaderElection != nil {
+		if err := validateDaemonLeaderElection(cv.config.Daemon.LeaderElection); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.LinkCheck != nil {
+		if err := validateDaemonLinkCheck(cv.config.Daemon.LinkCheck); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.Badges != nil {
+		if err := validateDaemonBadges(cv.config.Daemon.Badges); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.Reconciliation != nil {
+		if err := validateDaemonReconciliation(cv.config.Daemon.Reconciliation); err != nil {
+			return err
+		}
+	}
+
+	if len(cv.config.Daemon.Sites) > 0 {
+		if err := validateDaemonSites(cv.config); err != nil {
+			return err
+		}
+	}
+
+	if cv.config.Daemon.Storage.Limits != nil {
+		if err := validateDaemonStorageLimits(cv.config.Daemon.Storage.Limits); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -165,8 +276,9 @@ func validateDaemonBuildDebounce(cfg *BuildDebounceConfig) error {
 
 // validateForges validates forge configuration.
 func (cv *configurationValidator) validateForges() error {
-	// If repositories are explicitly configured, forges are optional
-	if len(cv.config.Forges) == 0 && len(cv.config.Repositories) == 0 {
+	// If repositories are explicitly configured, forges are optional. Server-role
+	// daemons never build, so they need neither.
+	if len(cv.config.Forges) == 0 && len(cv.config.Repositories) == 0 && !cv.config.Daemon.IsServerRole() {
 		return errors.NewError(errors.CategoryValidation, "either forges or repositories must be configured").Build()
 	}
 
@@ -204,6 +316,22 @@ func (cv *configurationValidator) validateForges() error {
 		if err := cv.validateForgeScopes(forge); err != nil {
 			return err
 		}
+
+		if err := validateForgeDiscoveryPolicy(forge); err != nil {
+			return err
+		}
+
+		if forge.Webhook != nil {
+			if err := validateBranchPatterns("forges."+forge.Name+".webhook.branches", forge.Webhook.Branches); err != nil {
+				return err
+			}
+			if err := validateWebhookIngress(forge, cv.config.Daemon); err != nil {
+				return err
+			}
+			if err := validateCooldown("forges."+forge.Name+".webhook.cooldown", forge.Webhook.Cooldown); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -241,8 +369,26 @@ func (cv *configurationValidator) validateForgeAuth(forge *ForgeConfig) error {
 	}
 
 	switch forge.Auth.Type {
-	case AuthTypeToken, AuthTypeSSH, AuthTypeBasic, AuthTypeNone, "":
+	case AuthTypeToken, AuthTypeBasic, AuthTypeNone, "":
 		// Valid auth types - semantic checks done by individual clients
+	case AuthTypeSSH:
+		if err := validateSSHAuth("forges."+forge.Name+".auth", forge.Auth); err != nil {
+			return err
+		}
+	case AuthTypeGitHubApp:
+		if NormalizeForgeType(string(forge.Type)) != ForgeGitHub {
+			return errors.NewError(errors.CategoryValidation, "github_app auth is only supported for GitHub forges").
+				WithContext("forge", forge.Name).
+				WithContext("type", string(forge.Type)).
+				Build()
+		}
+	case AuthTypeOAuth:
+		if forge.Auth.TokenURL == "" && NormalizeForgeType(string(forge.Type)) != ForgeGitLab {
+			return errors.NewError(errors.CategoryValidation, "oauth auth requires token_url for forges other than GitLab").
+				WithContext("forge", forge.Name).
+				WithContext("type", string(forge.Type)).
+				Build()
+		}
 	default:
 		return errors.NewError(errors.CategoryValidation, "forge has unsupported auth type").
 			WithContext("forge", forge.Name).
@@ -250,12 +396,18 @@ func (cv *configurationValidator) validateForgeAuth(forge *ForgeConfig) error {
 			Build()
 	}
 
-	return nil
+	return validateTokenSourceAuth("forges."+forge.Name+".auth", forge.Auth)
 }
 
-// validateForgeScopes validates that forge has organizations/groups or auto-discovery enabled.
+// validateForgeScopes validates that forge has organizations/groups, a repository
+// allowlist or auto-discovery enabled.
 func (cv *configurationValidator) validateForgeScopes(forge *ForgeConfig) error {
-	emptyScopes := len(forge.Organizations) == 0 && len(forge.Groups) == 0
+	for _, name := range forge.Repositories {
+		if err := validateAllowlistName(forge.Name, strings.Trim(strings.TrimSpace(name), "/")); err != nil {
+			return err
+		}
+	}
+	emptyScopes := len(forge.Organizations) == 0 && len(forge.Groups) == 0 && !forge.HasRepositoryAllowlist()
 	if !emptyScopes {
 		return nil // Has scopes, no need to check auto-discovery
 	}
@@ -282,13 +434,95 @@ func (cv *configurationValidator) validateForgeScopes(forge *ForgeConfig) error
 
 // validateRepositories validates repository-specific configuration.
 func (cv *configurationValidator) validateRepositories() error {
+	areas := make(map[string]string) // site area name -> owning repository
 	for i := range cv.config.Repositories {
 		repo := &cv.config.Repositories[i]
+		if err := validateRepoType(repo); err != nil {
+			return err
+		}
+		if err := validateRepoFetch(repo); err != nil {
+			return err
+		}
 		if repo.Auth != nil {
 			if err := cv.validateRepoAuth(*repo); err != nil {
 				return err
 			}
 		}
+		if err := validateBranchPatterns("repositories."+repo.Name+".webhook_branches", repo.WebhookBranches); err != nil {
+			return err
+		}
+		if err := validateCooldown("repositories."+repo.Name+".webhook_cooldown", repo.WebhookCooldown); err != nil {
+			return err
+		}
+		if err := validateRepoPin(repo); err != nil {
+			return err
+		}
+		if err := validateCodeDocs(repo); err != nil {
+			return err
+		}
+		if len(repo.Sections) == 0 {
+			areas[strings.ToLower(repo.Name)] = repo.Name
+		}
+	}
+	for i := range cv.config.Repositories {
+		if err := validateRepoSections(&cv.config.Repositories[i], areas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRepoPin checks the commit and tag pins of a repository.
+func validateRepoPin(repo *Repository) error {
+	if repo.Commit != "" && !IsCommitSHA(repo.Commit) {
+		return errors.NewError(errors.CategoryValidation, "repository commit must be a full 40-character SHA").
+			WithContext("repository", repo.Name).
+			WithContext("commit", repo.Commit).
+			Build()
+	}
+	if repo.Tag == "" {
+		return nil
+	}
+	if repo.Commit != "" {
+		return errors.NewError(errors.CategoryValidation, "repository commit and tag are mutually exclusive").
+			WithContext("repository", repo.Name).
+			Build()
+	}
+	if repo.Branch != repo.Tag {
+		return errors.NewError(errors.CategoryValidation, "repository branch and tag are mutually exclusive").
+			WithContext("repository", repo.Name).
+			WithContext("branch", repo.Branch).
+			WithContext("tag", repo.Tag).
+			Build()
+	}
+	return nil
+}
+
+// validateRepoSections checks that monorepo sections have a name and path, and that
+// section names do not collide with each other or with other repositories' areas.
+func validateRepoSections(repo *Repository, areas map[string]string) error {
+	for _, s := range repo.Sections {
+		if strings.TrimSpace(s.Name) == "" || strings.TrimSpace(s.Path) == "" {
+			return errors.NewError(errors.CategoryValidation, "repository section requires name and path").
+				WithContext("repository", repo.Name).
+				WithContext("section", s.Name).
+				Build()
+		}
+		if strings.ContainsAny(s.Name, `/\`) {
+			return errors.NewError(errors.CategoryValidation, "repository section name must not contain path separators").
+				WithContext("repository", repo.Name).
+				WithContext("section", s.Name).
+				Build()
+		}
+		key := strings.ToLower(s.Name)
+		if owner, exists := areas[key]; exists {
+			return errors.NewError(errors.CategoryValidation, "duplicate repository section name").
+				WithContext("repository", repo.Name).
+				WithContext("section", s.Name).
+				WithContext("conflicts_with", owner).
+				Build()
+		}
+		areas[key] = repo.Name
 	}
 	return nil
 }
@@ -296,8 +530,25 @@ func (cv *configurationValidator) validateRepositories() error {
 // validateRepoAuth validates repository authentication configuration.
 func (cv *configurationValidator) validateRepoAuth(repo Repository) error {
 	switch repo.Auth.Type {
-	case AuthTypeToken, AuthTypeSSH, AuthTypeBasic, AuthTypeNone, "":
+	case AuthTypeToken, AuthTypeBasic, AuthTypeNone, "":
 		// Valid auth type
+	case AuthTypeSSH:
+		if err := validateSSHAuth("repositories."+repo.Name+".auth", repo.Auth); err != nil {
+			return err
+		}
+	case AuthTypeGitHubApp:
+		if err := validateTokenSourceAuth("repositories."+repo.Name+".auth", repo.Auth); err != nil {
+			return err
+		}
+	case AuthTypeOAuth:
+		if err := validateTokenSourceAuth("repositories."+repo.Name+".auth", repo.Auth); err != nil {
+			return err
+		}
+		if repo.Auth.TokenURL == "" {
+			return errors.NewError(errors.CategoryValidation, "oauth auth on a repository requires token_url").
+				WithContext("repository", repo.Name).
+				Build()
+		}
 	default:
 		return errors.NewError(errors.CategoryValidation, "unsupported auth type").
 			WithContext("repository", repo.Name).
@@ -326,12 +577,38 @@ func (cv *configurationValidator) validateBuild() error {
 	if err := cv.validateCloneStrategy(); err != nil {
 		return err
 	}
+	if err := cv.validateCopyStrategy(); err != nil {
+		return err
+	}
 	if err := cv.validateRetryDelays(); err != nil {
 		return err
 	}
 	if err := cv.validateMaxRetries(); err != nil {
 		return err
 	}
+	if cv.config.Build.NamespaceOrgDepth < 0 {
+		return errors.NewError(errors.CategoryValidation, "namespace_org_depth cannot be negative").
+			WithContext("value", cv.config.Build.NamespaceOrgDepth).
+			Build()
+	}
+	if cv.config.Build.MaxFileSizeMB < 0 || cv.config.Build.MaxContentMemoryMB < 0 {
+		return errors.NewError(errors.CategoryValidation, "content memory budgets cannot be negative").
+			WithContext("max_file_size_mb", cv.config.Build.MaxFileSizeMB).
+			WithContext("max_content_memory_mb", cv.config.Build.MaxContentMemoryMB).
+			Build()
+	}
+	if err := cv.config.Build.CheckProfile(); err != nil {
+		return err
+	}
+	if err := validateBuildResources(cv.config.Build.Resources); err != nil {
+		return err
+	}
+	if err := validateOutputValidation(cv.config.Build.OutputValidation); err != nil {
+		return err
+	}
+	if err := validateAccessibility(cv.config.Build.Accessibility); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -404,7 +681,11 @@ func (cv *configurationValidator) validateMaxRetries() error {
 
 // validatePaths ensures output directories are unified across config domains.
 // Canonical source is output.directory; daemon.storage.output_dir must match when set.
+// The modes and owner of generated output are validated with them.
 func (cv *configurationValidator) validatePaths() error {
+	if err := validateOutputPermissions(cv.config.Output); err != nil {
+		return err
+	}
 	out := cv.config.Output.Directory
 	if out == "" {
 		out = defaultOutputDir // default applied elsewhere, but keep guard for safety
@@ -425,6 +706,58 @@ func (cv *configurationValidator) validatePaths() error {
 	return nil
 }
 
+// validateMonitoringLogging validates component levels and log sinks.
+func (cv *configurationValidator) validateMonitoringLogging() error {
+	if cv.config.Monitoring == nil {
+		return nil
+	}
+	logging := cv.config.Monitoring.Logging
+	for name := range logging.Components {
+		if !slices.Contains(LogComponents, name) {
+			return errors.NewError(errors.CategoryValidation, "unknown logging component").
+				WithContext("component", name).
+				WithContext("valid_components", strings.Join(LogComponents, ", ")).
+				Build()
+		}
+	}
+	if f := logging.File; f != nil {
+		if strings.TrimSpace(f.Path) == "" {
+			return errors.NewError(errors.CategoryValidation, "monitoring.logging.file.path is required").Build()
+		}
+		if f.MaxSizeMB < 0 || f.MaxBackups < 0 {
+			return errors.NewError(errors.CategoryValidation, "log file rotation limits must not be negative").
+				WithContext("max_size_mb", f.MaxSizeMB).
+				WithContext("max_backups", f.MaxBackups).
+				Build()
+		}
+	}
+	if logging.Access.IsEnabled() {
+		logFile := ""
+		if logging.File != nil {
+			logFile = logging.File.Path
+		}
+		if err := validateAccessLog(logging.Access, logFile); err != nil {
+			return err
+		}
+	}
+	if s := logging.Syslog; s != nil && s.Enabled {
+		switch s.Network {
+		case "":
+		case "udp", "tcp", "unixgram":
+			if s.Address == "" {
+				return errors.NewError(errors.CategoryValidation, "monitoring.logging.syslog.address is required with a network").
+					WithContext("network", s.Network).
+					Build()
+			}
+		default:
+			return errors.NewError(errors.CategoryValidation, "invalid syslog network").
+				WithContext("network", s.Network).
+				Build()
+		}
+	}
+	return nil
+}
+
 // validateVersioning validates versioning configuration.
 func (cv *configurationValidator) validateVersioning() error {
 	// Only validate if versioning is configured
@@ -450,5 +783,11 @@ func (cv *configurationValidator) validateVersioning() error {
 		return errors.NewError(errors.CategoryValidation, "versioning strategy is required when versioning is enabled").Build()
 	}
 
+	if cv.config.Versioning.Concurrency < 0 {
+		return errors.NewError(errors.CategoryValidation, "versioning concurrency must not be negative").
+			WithContext("concurrency", cv.config.Versioning.Concurrency).
+			Build()
+	}
+
 	return nil
 }
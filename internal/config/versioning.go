@@ -1,6 +1,8 @@
 package config
 
 import (
+	"path"
+
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/normalization"
 )
 
@@ -23,3 +25,30 @@ var versioningStrategyNormalizer = normalization.NewNormalizer(map[string]Versio
 func NormalizeVersioningStrategy(raw string) VersioningStrategy {
 	return versioningStrategyNormalizer.Normalize(raw)
 }
+
+// EffectiveConcurrency returns how many versions may be discovered and fetched in
+// parallel. Unset (or nil) versioning config keeps version processing serial.
+func (v *VersioningConfig) EffectiveConcurrency() int {
+	if v == nil || v.Concurrency < 1 {
+		return 1
+	}
+	return v.Concurrency
+}
+
+// IncludesTag reports whether versioned builds publish tag as a version: versioning is
+// enabled beyond the default branch, the strategy includes tags and tag matches
+// tag_patterns (no patterns match every tag).
+func (v *VersioningConfig) IncludesTag(tag string) bool {
+	if v == nil || !v.Enabled || v.DefaultBranchOnly || v.Strategy == StrategyBranchesOnly {
+		return false
+	}
+	if len(v.TagPatterns) == 0 {
+		return true
+	}
+	for _, pattern := range v.TagPatterns {
+		if ok, err := path.Match(pattern, tag); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestVersioningConcurrency(t *testing.T) {
+	var unset *VersioningConfig
+	if got := unset.EffectiveConcurrency(); got != 1 {
+		t.Fatalf("nil versioning should be serial, got %d", got)
+	}
+	if got := (&VersioningConfig{Concurrency: 4}).EffectiveConcurrency(); got != 4 {
+		t.Fatalf("expected 4 workers, got %d", got)
+	}
+
+	cfg := Config{
+		Version:    "2.0",
+		Output:     OutputConfig{Directory: "./out", Clean: true},
+		Build:      BuildConfig{CloneConcurrency: 1, MaxRetries: 1, RetryBackoff: RetryBackoffLinear, RetryInitialDelay: "1s", RetryMaxDelay: "2s", CloneStrategy: CloneStrategyFresh},
+		Forges:     []*ForgeConfig{{Name: "f1", Type: ForgeGitHub, Auth: &AuthConfig{Type: AuthTypeToken, Token: "x"}, AutoDiscover: true}},
+		Versioning: &VersioningConfig{Enabled: true, Strategy: StrategyBranchesOnly, Concurrency: 2},
+	}
+	if err := validateConfig(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.Versioning.Concurrency = -1
+	if err := validateConfig(&cfg); err == nil {
+		t.Fatal("expected negative versioning concurrency to be rejected")
+	}
+}
+
+func TestVersioningIncludesTag(t *testing.T) {
+	var unset *VersioningConfig
+	if unset.IncludesTag("v1.0.0") {
+		t.Fatal("nil versioning should include no tags")
+	}
+	for _, tc := range []struct {
+		cfg  VersioningConfig
+		tag  string
+		want bool
+	}{
+		{VersioningConfig{Enabled: true, Strategy: StrategyBranchesAndTags}, "anything", true},
+		{VersioningConfig{Enabled: true, Strategy: StrategyTagsOnly, TagPatterns: []string{"v*.*.*"}}, "v1.2.3", true},
+		{VersioningConfig{Enabled: true, Strategy: StrategyTagsOnly, TagPatterns: []string{"v*.*.*"}}, "nightly", false},
+		{VersioningConfig{Enabled: true, Strategy: StrategyBranchesOnly}, "v1.2.3", false},
+		{VersioningConfig{Enabled: true, DefaultBranchOnly: true}, "v1.2.3", false},
+		{VersioningConfig{Strategy: StrategyTagsOnly}, "v1.2.3", false},
+	} {
+		if got := tc.cfg.IncludesTag(tc.tag); got != tc.want {
+			t.Errorf("%+v IncludesTag(%q) = %v, want %v", tc.cfg, tc.tag, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// defaultWatchDebounce is the per-repository quiet period before a watch-triggered build.
+const defaultWatchDebounce = 2 * time.Second
+
+// WatchConfig enables filesystem watching of the daemon's persistent workspace
+// (repo_cache_dir/working). Edits to a repository's working copy request an
+// incremental build for that repository.
+type WatchConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Debounce string   `yaml:"debounce,omitempty"` // Quiet period per repository (default: 2s)
+	Ignore   []string `yaml:"ignore,omitempty"`   // Glob patterns for paths that never trigger builds
+}
+
+// IsEnabled reports whether workspace watching is active.
+func (w *WatchConfig) IsEnabled() bool { return w != nil && w.Enabled }
+
+// DebounceDuration returns the configured debounce, or the default when unset or invalid.
+func (w *WatchConfig) DebounceDuration() time.Duration {
+	if w == nil || w.Debounce == "" {
+		return defaultWatchDebounce
+	}
+	d, err := time.ParseDuration(w.Debounce)
+	if err != nil || d <= 0 {
+		return defaultWatchDebounce
+	}
+	return d
+}
+
+func validateDaemonWatch(w *WatchConfig) error {
+	if w.Debounce != "" {
+		d, err := time.ParseDuration(w.Debounce)
+		if err != nil || d <= 0 {
+			return errors.NewError(errors.CategoryValidation, "daemon watch debounce must be a positive duration").
+				WithContext("value", w.Debounce).
+				Build()
+		}
+	}
+	for _, pattern := range w.Ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return errors.NewError(errors.CategoryValidation, "daemon watch ignore pattern is invalid").
+				WithContext("pattern", pattern).
+				Build()
+		}
+	}
+	return nil
+}
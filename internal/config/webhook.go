@@ -0,0 +1,163 @@
+package config
+
+import (
+	"net/netip"
+	"path"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// BranchAllowed reports whether pushes to branch may trigger a build from this forge.
+// An empty allowlist allows every branch.
+func (w *WebhookConfig) BranchAllowed(branch string) bool {
+	if w == nil {
+		return true
+	}
+	return MatchBranchPatterns(w.Branches, branch)
+}
+
+// WebhookBranchAllowed reports whether pushes to branch may trigger a build of this repository.
+// An empty allowlist allows every branch.
+func (r *Repository) WebhookBranchAllowed(branch string) bool {
+	return MatchBranchPatterns(r.WebhookBranches, branch)
+}
+
+// CooldownDuration returns the forge's per-repository webhook build cooldown, or 0.
+func (w *WebhookConfig) CooldownDuration() time.Duration {
+	if w == nil {
+		return 0
+	}
+	return parseCooldown(w.Cooldown)
+}
+
+// WebhookCooldownDuration returns the repository's webhook build cooldown and whether
+// it sets one (an explicit "0" disables the forge cooldown).
+func (r *Repository) WebhookCooldownDuration() (time.Duration, bool) {
+	if strings.TrimSpace(r.WebhookCooldown) == "" {
+		return 0, false
+	}
+	return parseCooldown(r.WebhookCooldown), true
+}
+
+func parseCooldown(raw string) time.Duration {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+func validateCooldown(field, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "0" {
+		return nil
+	}
+	if d, err := time.ParseDuration(raw); err != nil || d < 0 {
+		return errors.NewError(errors.CategoryValidation, "webhook cooldown must be a non-negative duration").
+			WithContext("field", field).
+			WithContext("value", raw).
+			Build()
+	}
+	return nil
+}
+
+// MatchBranchPatterns reports whether branch matches one of the glob patterns
+// (path.Match syntax, so "release/*" matches "release/1.2"). An empty pattern
+// list matches every branch.
+func MatchBranchPatterns(patterns []string, branch string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	branch = strings.TrimPrefix(strings.TrimSpace(branch), "refs/heads/")
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateBranchPatterns(field string, patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return errors.NewError(errors.CategoryValidation, "invalid webhook branch pattern").
+				WithContext("field", field).
+				WithContext("pattern", pattern).
+				Build()
+		}
+	}
+	return nil
+}
+
+// IPRestricted reports whether deliveries are limited to allowed source addresses.
+func (w *WebhookConfig) IPRestricted() bool {
+	return w != nil && (len(w.AllowedIPs) > 0 || w.AllowForgeRanges)
+}
+
+// MTLSEnabled reports whether deliveries must present a client certificate.
+func (w *WebhookConfig) MTLSEnabled() bool {
+	return w != nil && strings.TrimSpace(w.ClientCA) != ""
+}
+
+// AllowedPrefixes parses AllowedIPs; a bare address becomes a single-host prefix.
+func (w *WebhookConfig) AllowedPrefixes() ([]netip.Prefix, error) {
+	if w == nil {
+		return nil, nil
+	}
+	prefixes := make([]netip.Prefix, 0, len(w.AllowedIPs))
+	for _, entry := range w.AllowedIPs {
+		prefix, err := ParseIPPrefix(entry)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// ParseIPPrefix parses a CIDR range or a bare IP address.
+func ParseIPPrefix(entry string) (netip.Prefix, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
+
+// validateWebhookIngress checks the source restrictions of a forge webhook. Client
+// certificates need the webhook port on TLS, which uses the daemon.http.tls certificate.
+func validateWebhookIngress(forge *ForgeConfig, daemon *DaemonConfig) error {
+	w := forge.Webhook
+	field := "forges." + forge.Name + ".webhook"
+	for _, entry := range w.AllowedIPs {
+		if _, err := ParseIPPrefix(entry); err != nil {
+			return errors.NewError(errors.CategoryValidation, "invalid webhook allowed_ips entry").
+				WithContext("field", field+".allowed_ips").
+				WithContext("entry", entry).
+				Build()
+		}
+	}
+	if w.AllowForgeRanges && NormalizeForgeType(string(forge.Type)) != ForgeGitHub {
+		return errors.NewError(errors.CategoryValidation, "webhook allow_forge_ranges is only supported for GitHub forges").
+			WithContext("field", field+".allow_forge_ranges").
+			WithContext("type", string(forge.Type)).
+			Build()
+	}
+	if w.MTLSEnabled() && (daemon == nil || !daemon.HTTP.TLSEnabled()) {
+		return errors.NewError(errors.CategoryValidation, "webhook client_ca requires daemon.http.tls").
+			WithContext("field", field+".client_ca").
+			Build()
+	}
+	return nil
+}
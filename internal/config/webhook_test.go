@@ -0,0 +1,154 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchBranchPatterns(t *testing.T) {
+	for name, tc := range map[string]struct {
+		patterns []string
+		branch   string
+		want     bool
+	}{
+		"empty allows all":  {nil, "feature/x", true},
+		"exact":             {[]string{"main"}, "main", true},
+		"full ref":          {[]string{"main"}, "refs/heads/main", true},
+		"glob":              {[]string{"main", "release/*"}, "release/1.2", true},
+		"glob is one level": {[]string{"release/*"}, "release/1.2/hotfix", false},
+		"no match":          {[]string{"main"}, "feature/x", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := MatchBranchPatterns(tc.patterns, tc.branch); got != tc.want {
+				t.Fatalf("MatchBranchPatterns(%v, %q) = %v, want %v", tc.patterns, tc.branch, got, tc.want)
+			}
+		})
+	}
+
+	var nilCfg *WebhookConfig
+	if !nilCfg.BranchAllowed("any") {
+		t.Fatalf("nil webhook config must allow every branch")
+	}
+}
+
+func TestValidateConfig_WebhookBranches(t *testing.T) {
+	for name, tc := range map[string]struct {
+		forgeBranches []string
+		repoBranches  []string
+		wantErr       bool
+	}{
+		"valid":               {[]string{"main", "release/*"}, []string{"main"}, false},
+		"invalid forge":       {[]string{"[main"}, nil, true},
+		"invalid repository":  {nil, []string{"[main"}, true},
+		"empty forge pattern": {[]string{" "}, nil, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{
+				Version: "2.0",
+				Forges: []*ForgeConfig{{
+					Name:          "gh",
+					Type:          ForgeGitHub,
+					Auth:          &AuthConfig{Type: AuthTypeToken, Token: "t"},
+					Organizations: []string{"org"},
+					Webhook:       &WebhookConfig{Branches: tc.forgeBranches},
+				}},
+				Repositories: []Repository{{Name: "r", URL: "https://example.com/org/r.git", WebhookBranches: tc.repoBranches}},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_WebhookIngress(t *testing.T) {
+	tlsHTTP := HTTPConfig{TLS: &HTTPTLSConfig{CertFile: "c.pem", KeyFile: "k.pem"}}
+	for name, tc := range map[string]struct {
+		forgeType ForgeType
+		webhook   WebhookConfig
+		http      HTTPConfig
+		wantErr   bool
+	}{
+		"allowed ips":                  {ForgeGitHub, WebhookConfig{AllowedIPs: []string{"192.0.2.10", "2001:db8::/32"}}, HTTPConfig{}, false},
+		"invalid allowed ip":           {ForgeGitHub, WebhookConfig{AllowedIPs: []string{"192.0.2.300"}}, HTTPConfig{}, true},
+		"github forge ranges":          {ForgeGitHub, WebhookConfig{AllowForgeRanges: true}, HTTPConfig{}, false},
+		"forge ranges on gitlab":       {ForgeGitLab, WebhookConfig{AllowForgeRanges: true}, HTTPConfig{}, true},
+		"client ca with tls":           {ForgeGitHub, WebhookConfig{ClientCA: "ca.pem"}, tlsHTTP, false},
+		"client ca without daemon tls": {ForgeGitHub, WebhookConfig{ClientCA: "ca.pem"}, HTTPConfig{}, true},
+		"cooldown":                     {ForgeGitHub, WebhookConfig{Cooldown: "5m"}, HTTPConfig{}, false},
+		"negative cooldown":            {ForgeGitHub, WebhookConfig{Cooldown: "-1m"}, HTTPConfig{}, true},
+		"cooldown without unit":        {ForgeGitHub, WebhookConfig{Cooldown: "300"}, HTTPConfig{}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			webhook := tc.webhook
+			cfg := Config{
+				Version: "2.0",
+				Forges: []*ForgeConfig{{
+					Name:          "f",
+					Type:          tc.forgeType,
+					Auth:          &AuthConfig{Type: AuthTypeToken, Token: "t"},
+					Organizations: []string{"org"},
+					Groups:        []string{"org"},
+					Webhook:       &webhook,
+				}},
+				Daemon: &DaemonConfig{HTTP: tc.http, Sync: SyncConfig{Schedule: "0 */4 * * *"}},
+			}
+			if err := applyDefaults(&cfg); err != nil {
+				t.Fatalf("defaults: %v", err)
+			}
+			err := ValidateConfig(&cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookConfig_AllowedPrefixes(t *testing.T) {
+	w := &WebhookConfig{AllowedIPs: []string{"192.0.2.10", "198.51.100.7/24"}}
+	prefixes, err := w.AllowedPrefixes()
+	if err != nil {
+		t.Fatalf("AllowedPrefixes: %v", err)
+	}
+	if len(prefixes) != 2 || prefixes[0].String() != "192.0.2.10/32" || prefixes[1].String() != "198.51.100.0/24" {
+		t.Fatalf("unexpected prefixes %v", prefixes)
+	}
+}
+
+func TestRepository_WebhookCooldownDuration(t *testing.T) {
+	if got := (&WebhookConfig{Cooldown: "2m"}).CooldownDuration(); got != 2*time.Minute {
+		t.Fatalf("forge cooldown = %v, want 2m", got)
+	}
+	if got := (*WebhookConfig)(nil).CooldownDuration(); got != 0 {
+		t.Fatalf("nil webhook cooldown = %v, want 0", got)
+	}
+	if _, ok := (&Repository{}).WebhookCooldownDuration(); ok {
+		t.Fatalf("repository without webhook_cooldown should not override the forge")
+	}
+	if got, ok := (&Repository{WebhookCooldown: "0"}).WebhookCooldownDuration(); !ok || got != 0 {
+		t.Fatalf("webhook_cooldown 0 = (%v, %v), want (0, true)", got, ok)
+	}
+	if got, ok := (&Repository{WebhookCooldown: "90s"}).WebhookCooldownDuration(); !ok || got != 90*time.Second {
+		t.Fatalf("webhook_cooldown 90s = (%v, %v), want (90s, true)", got, ok)
+	}
+}
+
+func TestValidateConfig_RepositoryWebhookCooldown(t *testing.T) {
+	for raw, wantErr := range map[string]bool{"": false, "0": false, "10m": false, "soon": true} {
+		cfg := Config{
+			Version:      "2.0",
+			Repositories: []Repository{{Name: "docs", URL: "https://example.com/org/docs.git", Branch: "main", WebhookCooldown: raw}},
+		}
+		if err := applyDefaults(&cfg); err != nil {
+			t.Fatalf("defaults: %v", err)
+		}
+		err := ValidateConfig(&cfg)
+		if (err != nil) != wantErr {
+			t.Fatalf("webhook_cooldown %q: ValidateConfig() error = %v, wantErr %v", raw, err, wantErr)
+		}
+	}
+}
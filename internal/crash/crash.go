@@ -0,0 +1,160 @@
+// Package crash converts panics into classified errors and crash reports.
+//
+// Build queue workers, HTTP handlers and pipeline stages recover panics through the
+// process-wide Reporter so a single bad job or request cannot take the daemon down.
+// Each recovered panic is logged, counted and, when a directory is configured, written
+// as a JSON crash report (stack, build ID, config hash) for later inspection.
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/version"
+)
+
+// DirName is the directory below the daemon state directory that holds crash reports.
+const DirName = "crashes"
+
+// Report is the JSON document written for a recovered panic.
+type Report struct {
+	Time       time.Time `json:"time"`
+	Component  string    `json:"component"`
+	BuildID    string    `json:"build_id,omitempty"`
+	ConfigHash string    `json:"config_hash,omitempty"`
+	Panic      string    `json:"panic"`
+	Stack      string    `json:"stack"`
+	Version    string    `json:"docbuilder_version"`
+	GoVersion  string    `json:"go_version"`
+}
+
+// Reporter records recovered panics.
+type Reporter struct {
+	dir    string
+	panics atomic.Int64
+
+	mu         sync.RWMutex
+	configHash string
+}
+
+// NewReporter returns a reporter that writes crash reports to dir.
+// An empty dir disables report files; panics are still logged and counted.
+func NewReporter(dir string) *Reporter {
+	return &Reporter{dir: dir}
+}
+
+var defaultReporter atomic.Pointer[Reporter]
+
+func init() {
+	defaultReporter.Store(NewReporter(""))
+}
+
+// Default returns the process-wide reporter.
+func Default() *Reporter { return defaultReporter.Load() }
+
+// SetDefault replaces the process-wide reporter; nil is ignored.
+func SetDefault(r *Reporter) {
+	if r != nil {
+		defaultReporter.Store(r)
+	}
+}
+
+// Dir returns the crash report directory ("" when report files are disabled).
+func (r *Reporter) Dir() string { return r.dir }
+
+// SetConfigHash records the hash of the active configuration for subsequent reports.
+func (r *Reporter) SetConfigHash(hash string) {
+	r.mu.Lock()
+	r.configHash = hash
+	r.mu.Unlock()
+}
+
+// Panics returns the number of panics recovered by this reporter.
+func (r *Reporter) Panics() int64 { return r.panics.Load() }
+
+// Recovered records a value returned by recover() and returns the classified error that
+// replaces the panic. component names the recovering site (for example "build_queue" or
+// "stage:copy_content"); buildID may be empty.
+func (r *Reporter) Recovered(component, buildID string, value any, stack []byte) error {
+	r.panics.Add(1)
+
+	r.mu.RLock()
+	configHash := r.configHash
+	r.mu.RUnlock()
+
+	report := Report{
+		Time:       time.Now().UTC(),
+		Component:  component,
+		BuildID:    buildID,
+		ConfigHash: configHash,
+		Panic:      fmt.Sprint(value),
+		Stack:      string(stack),
+		Version:    version.Version,
+		GoVersion:  runtime.Version(),
+	}
+
+	path, err := r.write(report)
+	attrs := []any{
+		slog.String("component", component),
+		slog.String("build_id", buildID),
+		slog.String("panic", report.Panic),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("crash_report_error", err.Error()))
+	} else if path != "" {
+		attrs = append(attrs, slog.String("crash_report", path))
+	}
+	slog.Error("Recovered from panic", attrs...)
+
+	builder := errors.InternalError(fmt.Sprintf("panic in %s: %s", component, report.Panic)).
+		WithCode(errors.CodeInternalPanic).
+		WithContext("component", component)
+	if buildID != "" {
+		builder = builder.WithContext("build_id", buildID)
+	}
+	if path != "" {
+		builder = builder.WithContext("crash_report", path)
+	}
+	return builder.Build()
+}
+
+// write stores a report as crash-<time>-<component>.json and returns its path.
+func (r *Reporter) write(report Report) (string, error) {
+	if r.dir == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(r.dir, 0o750); err != nil {
+		return "", fmt.Errorf("create crash report dir: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode crash report: %w", err)
+	}
+	name := fmt.Sprintf("crash-%s-%s.json", report.Time.Format("20060102T150405.000000000"), fileSafe(report.Component))
+	path := filepath.Join(r.dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("write crash report: %w", err)
+	}
+	return path, nil
+}
+
+// fileSafe replaces characters that are awkward in file names.
+func fileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,61 @@
+package crash
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestReporterRecoveredWritesReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), DirName)
+	r := NewReporter(dir)
+	r.SetConfigHash("abc123")
+
+	err := r.Recovered("stage:copy_content", "job-1", "boom", []byte("goroutine 1 [running]"))
+
+	ce, ok := errors.AsClassified(err)
+	if !ok {
+		t.Fatalf("expected classified error, got %T", err)
+	}
+	if ce.Category() != errors.CategoryInternal || ce.Code() != errors.CodeInternalPanic {
+		t.Errorf("got %s/%s, want internal/%s", ce.Category(), ce.Code(), errors.CodeInternalPanic)
+	}
+	if r.Panics() != 1 {
+		t.Errorf("Panics() = %d, want 1", r.Panics())
+	}
+
+	path, _ := ce.Context()["crash_report"].(string)
+	if !strings.HasPrefix(filepath.Base(path), "crash-") || !strings.HasSuffix(path, "-stage_copy_content.json") {
+		t.Fatalf("unexpected crash report path %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.BuildID != "job-1" || report.ConfigHash != "abc123" || report.Panic != "boom" || report.Stack == "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestReporterWithoutDirOnlyCounts(t *testing.T) {
+	r := NewReporter("")
+	err := r.Recovered("http", "", "boom", nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	ce, _ := errors.AsClassified(err)
+	if _, ok := ce.Context()["crash_report"]; ok {
+		t.Error("no crash report expected without a directory")
+	}
+	if r.Panics() != 1 {
+		t.Errorf("Panics() = %d, want 1", r.Panics())
+	}
+}
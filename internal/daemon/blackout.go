@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// blackoutState holds the build requests made inside a sync blackout window. They are
+// merged like those held by maintenance mode and released when the window ends.
+type blackoutState struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	heldBuilds
+}
+
+// hold records a build request and arms release for the end of the window.
+func (b *blackoutState) hold(scope []string, now, end time.Time, release func(held int, scope []string)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.add(scope)
+	if b.timer != nil {
+		return
+	}
+	b.timer = time.AfterFunc(end.Sub(now), func() {
+		b.mu.Lock()
+		held, scope := b.take()
+		b.timer = nil
+		b.mu.Unlock()
+		if held > 0 {
+			release(held, scope)
+		}
+	})
+}
+
+// stop cancels held requests, for example when the daemon stops.
+func (b *blackoutState) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.take()
+}
+
+// holdForBlackout holds a debounced build requested inside a sync blackout window. One
+// build is requested for the held requests when the window ends; if a reload has
+// extended the window by then, that build is held again.
+func (d *Daemon) holdForBlackout(evt events.BuildNow) bool {
+	if d.config == nil || d.config.Daemon == nil {
+		return false
+	}
+	sync := d.config.Daemon.Sync
+	now := time.Now()
+	if !sync.InBlackout(now) {
+		return false
+	}
+	end := sync.BlackoutEnd(now)
+	d.blackout.hold(evt.Scope, now, end, func(held int, scope []string) {
+		if d.GetStatus() == StatusRunning {
+			d.flushHeldBuilds("blackout", scope, held)
+		}
+	})
+	d.log().Info("Build held for blackout window",
+		logfields.JobID(evt.JobID),
+		slog.String("reason", evt.LastReason),
+		slog.Any("scope", evt.Scope),
+		slog.Time("release_at", end))
+	return true
+}
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlackout_HoldsRequestedBuilds(t *testing.T) {
+	d := newMaintenanceTestDaemon(t)
+	defer d.blackout.stop()
+	now := time.Now()
+	d.config.Daemon.Sync.BlackoutWindows = []config.BlackoutWindow{{
+		Start: now.Add(-time.Minute).Format("15:04"),
+		End:   now.Add(2 * time.Minute).Format("15:04"),
+	}}
+
+	for i, reason := range []string{"webhook", "manual"} {
+		d.enqueueOrchestratedBuild(events.BuildNow{JobID: fmt.Sprintf("job-%d", i), LastReason: reason, Scope: []string{"repo-a"}})
+	}
+	require.Zero(t, atomic.LoadInt32(&d.queueLength))
+	d.blackout.mu.Lock()
+	require.Equal(t, 2, d.blackout.held)
+	require.NotNil(t, d.blackout.timer)
+	d.blackout.mu.Unlock()
+
+	d.config.Daemon.Sites = []config.SiteConfig{{Name: "platform", OutputDirectory: "platform"}}
+	_, err := d.TriggerSiteBuild("platform")
+	require.ErrorContains(t, err, "blackout window")
+
+	// Builds are enqueued again outside the window.
+	d.config.Daemon.Sites = nil
+	d.config.Daemon.Sync.BlackoutWindows = nil
+	d.enqueueOrchestratedBuild(events.BuildNow{JobID: "job-after"})
+	require.Equal(t, int32(1), atomic.LoadInt32(&d.queueLength))
+}
+
+func TestBlackout_ReleasesHeldBuildsWhenWindowEnds(t *testing.T) {
+	var b blackoutState
+	released := make(chan []string, 1)
+	release := func(held int, scope []string) {
+		require.Equal(t, 2, held)
+		released <- scope
+	}
+
+	now := time.Now()
+	b.hold([]string{"repo-b"}, now, now.Add(20*time.Millisecond), release)
+	b.hold([]string{"repo-a"}, now, now.Add(time.Hour), release)
+
+	select {
+	case scope := <-released:
+		require.Equal(t, []string{"repo-a", "repo-b"}, scope)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for held builds to be released")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	require.Zero(t, b.held)
+	require.Nil(t, b.timer)
+}
+
+func TestBlackout_StopCancelsHeldBuilds(t *testing.T) {
+	var b blackoutState
+	now := time.Now()
+	b.hold(nil, now, now.Add(20*time.Millisecond), func(int, []string) {
+		t.Error("held builds released after stop")
+	})
+	b.stop()
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, b.held)
+}
@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// RepoCooldown is the webhook build cooldown state of one repository.
+type RepoCooldown struct {
+	Repository  string    `json:"repository"` // Full name from the webhook (owner/repo)
+	RepoURL     string    `json:"repo_url"`
+	Cooldown    string    `json:"cooldown"`
+	LastBuildAt time.Time `json:"last_build_at"` // When the last webhook build was requested
+	// Pending reports webhooks held until the window ends; they are coalesced into one
+	// build requested at ReleaseAt.
+	Pending         bool       `json:"pending"`
+	PendingWebhooks int        `json:"pending_webhooks,omitempty"`
+	ReleaseAt       *time.Time `json:"release_at,omitempty"`
+}
+
+// buildCooldowns tracks webhook builds per repository URL and holds the requests that
+// arrive within a repository's cooldown window.
+type buildCooldowns struct {
+	mu    sync.Mutex
+	repos map[string]*cooldownEntry
+}
+
+type cooldownEntry struct {
+	fullName  string
+	cooldown  time.Duration
+	last      time.Time
+	pending   *events.RepoUpdateRequested
+	count     int
+	releaseAt time.Time
+	timer     *time.Timer
+}
+
+// admit records a webhook build request of a repository with the given cooldown. It
+// returns false when the request may proceed now. Otherwise the request replaces any
+// held one, and release is called with it when the window ends.
+func (c *buildCooldowns) admit(fullName string, req events.RepoUpdateRequested, cooldown time.Duration, now time.Time, release func(events.RepoUpdateRequested)) (bool, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.repos == nil {
+		c.repos = make(map[string]*cooldownEntry)
+	}
+	entry := c.repos[req.RepoURL]
+	if entry == nil {
+		entry = &cooldownEntry{}
+		c.repos[req.RepoURL] = entry
+	}
+	entry.fullName = fullName
+	entry.cooldown = cooldown
+
+	if entry.pending == nil && (entry.last.IsZero() || now.Sub(entry.last) >= cooldown) {
+		entry.last = now
+		return false, time.Time{}
+	}
+
+	held := req
+	entry.pending = &held
+	entry.count++
+	if entry.timer == nil {
+		entry.releaseAt = entry.last.Add(cooldown)
+		entry.timer = time.AfterFunc(entry.releaseAt.Sub(now), func() {
+			c.mu.Lock()
+			pending := entry.pending
+			entry.pending = nil
+			entry.count = 0
+			entry.timer = nil
+			entry.releaseAt = time.Time{}
+			entry.last = time.Now()
+			c.mu.Unlock()
+			if pending != nil {
+				release(*pending)
+			}
+		})
+	}
+	return true, entry.releaseAt
+}
+
+// snapshot returns the cooldown state of every repository seen, sorted by name.
+func (c *buildCooldowns) snapshot() []RepoCooldown {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]RepoCooldown, 0, len(c.repos))
+	for repoURL, entry := range c.repos {
+		rc := RepoCooldown{
+			Repository:  entry.fullName,
+			RepoURL:     repoURL,
+			Cooldown:    entry.cooldown.String(),
+			LastBuildAt: entry.last,
+			Pending:     entry.pending != nil,
+		}
+		if rc.Pending {
+			releaseAt := entry.releaseAt
+			rc.PendingWebhooks = entry.count
+			rc.ReleaseAt = &releaseAt
+		}
+		out = append(out, rc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Repository < out[j].Repository })
+	return out
+}
+
+// stop cancels held requests, for example when the daemon stops.
+func (c *buildCooldowns) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.repos {
+		if entry.timer != nil {
+			entry.timer.Stop()
+			entry.timer = nil
+		}
+		entry.pending = nil
+		entry.count = 0
+	}
+}
+
+// webhookCooldown returns the cooldown of a repository: the webhook_cooldown of the
+// configured repository matching repoFullName, or else the forge's webhook.cooldown.
+func (d *Daemon) webhookCooldown(forgeName, repoFullName string) time.Duration {
+	if d.config == nil {
+		return 0
+	}
+	for i := range d.config.Repositories {
+		repo := &d.config.Repositories[i]
+		if repoMatchesFullName(*repo, repoFullName) {
+			if cooldown, ok := repo.WebhookCooldownDuration(); ok {
+				return cooldown
+			}
+			break
+		}
+	}
+	if forgeCfg := d.forgeConfig(forgeName); forgeCfg != nil {
+		return forgeCfg.Webhook.CooldownDuration()
+	}
+	return 0
+}
+
+// requestWebhookRepoUpdate publishes the repository update of a webhook, or holds it
+// until the end of the repository's cooldown window.
+func (d *Daemon) requestWebhookRepoUpdate(ctx context.Context, evt events.WebhookReceived, req events.RepoUpdateRequested) {
+	if cooldown := d.webhookCooldown(evt.ForgeName, evt.RepoFullName); cooldown > 0 {
+		held, releaseAt := d.cooldowns.admit(evt.RepoFullName, req, cooldown, time.Now(), func(pending events.RepoUpdateRequested) {
+			d.releaseCooldownRequest(ctx, pending)
+		})
+		if held {
+			d.log().Info("Webhook build held by repository cooldown",
+				logfields.JobID(evt.JobID),
+				slog.String("repo", evt.RepoFullName),
+				slog.Duration("cooldown", cooldown),
+				slog.Time("release_at", releaseAt))
+			return
+		}
+	}
+	d.publishRepoUpdate(ctx, req)
+}
+
+func (d *Daemon) releaseCooldownRequest(ctx context.Context, req events.RepoUpdateRequested) {
+	if ctx.Err() != nil || d.GetStatus() != StatusRunning {
+		return
+	}
+	req.RequestedAt = time.Now()
+	d.log().Info("Repository cooldown ended; requesting held webhook build",
+		logfields.JobID(req.JobID),
+		slog.String("repo_url", req.RepoURL))
+	d.publishRepoUpdate(ctx, req)
+}
+
+func (d *Daemon) publishRepoUpdate(ctx context.Context, req events.RepoUpdateRequested) {
+	if err := d.publishOrchestrationEvent(ctx, req); err != nil {
+		d.log().Warn("Failed to publish repo update request",
+			logfields.JobID(req.JobID),
+			slog.String("repo_url", req.RepoURL),
+			logfields.Error(err))
+	}
+}
+
+// BuildCooldownsHandler serves GET /api/build/cooldowns: the webhook build cooldown of
+// each repository that received webhooks, including held (pending) builds.
+func (d *Daemon) BuildCooldownsHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"cooldowns": d.cooldowns.snapshot()}); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode build cooldowns").Build())
+	}
+}
@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+)
+
+func TestDaemon_WebhookCooldown(t *testing.T) {
+	d := &Daemon{config: &config.Config{
+		Forges: []*config.ForgeConfig{{
+			Name:    "forge-1",
+			Type:    config.ForgeGitHub,
+			Webhook: &config.WebhookConfig{Cooldown: "5m"},
+		}},
+		Repositories: []config.Repository{
+			{Name: "fast", URL: "https://github.com/org/fast.git", WebhookCooldown: "30s"},
+			{Name: "off", URL: "https://github.com/org/off.git", WebhookCooldown: "0"},
+			{Name: "plain", URL: "https://github.com/org/plain.git"},
+		},
+	}}
+
+	require.Equal(t, 30*time.Second, d.webhookCooldown("forge-1", "org/fast"))
+	require.Equal(t, time.Duration(0), d.webhookCooldown("forge-1", "org/off"))
+	require.Equal(t, 5*time.Minute, d.webhookCooldown("forge-1", "org/plain"))
+	require.Equal(t, 5*time.Minute, d.webhookCooldown("forge-1", "org/discovered"))
+	require.Equal(t, time.Duration(0), d.webhookCooldown("other", "org/discovered"))
+}
+
+func TestDaemon_WebhookCooldownCoalescesBuilds(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	const repoURL = "https://gitlab.example.com/org/repo.git"
+	cfg := &config.Config{
+		Version: "2.0",
+		Repositories: []config.Repository{{
+			Name:            "org/repo",
+			URL:             repoURL,
+			Branch:          "main",
+			WebhookCooldown: "300ms",
+		}},
+		Daemon: &config.DaemonConfig{Sync: config.SyncConfig{Schedule: "0 */4 * * *"}},
+		Forges: []*config.ForgeConfig{{
+			Name:    "forge-1",
+			Type:    config.ForgeGitLab,
+			BaseURL: "https://gitlab.example.com",
+		}},
+	}
+
+	forgeManager := forge.NewForgeManager()
+	forgeManager.AddForge(cfg.Forges[0], fakeForgeClient{})
+
+	bus := events.NewBus()
+	defer bus.Close()
+
+	d := &Daemon{
+		config:           cfg,
+		stopChan:         make(chan struct{}),
+		orchestrationBus: bus,
+		forgeManager:     forgeManager,
+		discovery:        forge.NewDiscoveryService(forgeManager, cfg.Filtering),
+		discoveryCache:   NewDiscoveryCache(),
+	}
+	d.status.Store(StatusRunning)
+	defer d.cooldowns.stop()
+
+	repoUpdateCh, unsubRepoUpdate := events.Subscribe[events.RepoUpdateRequested](bus, 10)
+	defer unsubRepoUpdate()
+
+	go d.runWebhookReceivedConsumer(ctx)
+	require.Eventually(t, func() bool {
+		return events.SubscriberCount[events.WebhookReceived](bus) > 0
+	}, 1*time.Second, 10*time.Millisecond)
+
+	first := d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", nil)
+	select {
+	case got := <-repoUpdateCh:
+		require.Equal(t, first, got.JobID)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timed out waiting for the first RepoUpdateRequested")
+	}
+
+	// Pushes within the window are held and coalesced into one build.
+	d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", nil)
+	last := d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", nil)
+	require.Eventually(t, func() bool {
+		states := d.cooldowns.snapshot()
+		return len(states) == 1 && states[0].PendingWebhooks == 2
+	}, 200*time.Millisecond, 5*time.Millisecond)
+
+	states := d.cooldowns.snapshot()
+	require.True(t, states[0].Pending)
+	require.Equal(t, "org/repo", states[0].Repository)
+	require.Equal(t, repoURL, states[0].RepoURL)
+	require.Equal(t, "300ms", states[0].Cooldown)
+	require.NotNil(t, states[0].ReleaseAt)
+
+	select {
+	case got := <-repoUpdateCh:
+		require.Equal(t, last, got.JobID)
+		require.Equal(t, repoURL, got.RepoURL)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the held RepoUpdateRequested")
+	}
+	select {
+	case got := <-repoUpdateCh:
+		t.Fatalf("expected one coalesced build, got another request %s", got.JobID)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.False(t, d.cooldowns.snapshot()[0].Pending)
+}
+
+func TestDaemon_BuildCooldownsHandler(t *testing.T) {
+	d := &Daemon{}
+	defer d.cooldowns.stop()
+
+	now := time.Now()
+	req := events.RepoUpdateRequested{JobID: "job-1", RepoURL: "https://github.com/org/repo.git"}
+	held, _ := d.cooldowns.admit("org/repo", req, time.Minute, now, func(events.RepoUpdateRequested) {})
+	require.False(t, held)
+	held, releaseAt := d.cooldowns.admit("org/repo", req, time.Minute, now.Add(10*time.Second), func(events.RepoUpdateRequested) {})
+	require.True(t, held)
+	require.WithinDuration(t, now.Add(time.Minute), releaseAt, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	d.BuildCooldownsHandler(rec, httptest.NewRequest(http.MethodGet, "/api/build/cooldowns", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Cooldowns []RepoCooldown `json:"cooldowns"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Cooldowns, 1)
+	require.Equal(t, "org/repo", body.Cooldowns[0].Repository)
+	require.Equal(t, "1m0s", body.Cooldowns[0].Cooldown)
+	require.True(t, body.Cooldowns[0].Pending)
+	require.Equal(t, 1, body.Cooldowns[0].PendingWebhooks)
+
+	rec = httptest.NewRecorder()
+	d.BuildCooldownsHandler(rec, httptest.NewRequest(http.MethodPost, "/api/build/cooldowns", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
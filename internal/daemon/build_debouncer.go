@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -57,6 +58,10 @@ type BuildDebouncer struct {
 	requestCount     int
 	pollingAfterRun  bool
 	snapshot         map[string]string
+	// scope is the union of the scopes of pending requests; an unscoped
+	// request clears scoped so the coalesced build covers every repository.
+	scope  map[string]struct{}
+	scoped bool
 }
 
 // PlannedJobID returns the JobID that will be used for the next BuildNow emission,
@@ -245,6 +250,8 @@ func (d *BuildDebouncer) onRequest(req events.BuildRequested) {
 		d.firstRequestAt = now
 		d.requestCount = 0
 		d.snapshot = nil
+		d.scope = nil
+		d.scoped = true
 	}
 
 	d.lastRequestAt = now
@@ -266,6 +273,17 @@ func (d *BuildDebouncer) onRequest(req events.BuildRequested) {
 		}
 	}
 
+	if len(req.Scope) == 0 {
+		d.scoped = false
+	} else if d.scoped {
+		if d.scope == nil {
+			d.scope = make(map[string]struct{}, len(req.Scope))
+		}
+		for _, name := range req.Scope {
+			d.scope[name] = struct{}{}
+		}
+	}
+
 	pendingAfterRun := d.pendingAfterRun
 	d.mu.Unlock()
 
@@ -304,6 +322,10 @@ func (d *BuildDebouncer) tryEmit(ctx context.Context, cause string) bool {
 	branch := d.lastBranch
 	jobID := d.lastJobID
 	snapshot := d.snapshot
+	var scope []string
+	if d.scoped {
+		scope = slices.Sorted(maps.Keys(d.scope))
+	}
 	if !pending {
 		d.mu.Unlock()
 		return true
@@ -337,6 +359,7 @@ func (d *BuildDebouncer) tryEmit(ctx context.Context, cause string) bool {
 		LastRepoURL:   repoURL,
 		LastBranch:    branch,
 		Snapshot:      snapshotCopy,
+		Scope:         scope,
 		FirstRequest:  first,
 		LastRequest:   last,
 		DebounceCause: cause,
@@ -372,6 +395,7 @@ func (d *BuildDebouncer) tryEmit(ctx context.Context, cause string) bool {
 	d.pollingAfterRun = false
 	d.lastEmittedJobID = jobID
 	d.snapshot = nil
+	d.scope = nil
 	d.mu.Unlock()
 
 	if d.metrics != nil {
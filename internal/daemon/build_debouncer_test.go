@@ -333,3 +333,40 @@ func TestBuildDebouncer_DedupesRequestsWithSameJobIDAfterEmit(t *testing.T) {
 		// ok
 	}
 }
+
+func TestBuildDebouncer_ScopeCoalescing(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests [][]string
+		want     []string
+	}{
+		{name: "scopes are merged", requests: [][]string{{"repo-b"}, {"repo-a", "repo-b"}}, want: []string{"repo-a", "repo-b"}},
+		{name: "unscoped request builds everything", requests: [][]string{{"repo-a"}, nil, {"repo-b"}}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := events.NewBus()
+			defer bus.Close()
+
+			debouncer, err := NewBuildDebouncer(bus, BuildDebouncerConfig{QuietWindow: time.Second, MaxDelay: time.Second})
+			require.NoError(t, err)
+
+			buildNowCh, unsub := events.Subscribe[events.BuildNow](bus, 10)
+			defer unsub()
+
+			for _, scope := range tt.requests {
+				debouncer.onRequest(events.BuildRequested{Reason: "manual", Scope: scope})
+			}
+			require.True(t, debouncer.tryEmit(t.Context(), "quiet"))
+
+			got := <-buildNowCh
+			require.Equal(t, tt.want, got.Scope)
+
+			// The next burst starts with a clean scope.
+			debouncer.onRequest(events.BuildRequested{Reason: "manual", Scope: []string{"repo-c"}})
+			require.True(t, debouncer.tryEmit(t.Context(), "quiet"))
+			require.Equal(t, []string{"repo-c"}, (<-buildNowCh).Scope)
+		})
+	}
+}
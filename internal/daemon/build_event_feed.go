@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"sync"
+
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+)
+
+// buildEventFeed fans build events out to live subscribers once they are persisted.
+// Publishing never blocks the build: a subscriber whose buffer is full is dropped and
+// its channel closed, so a stream ends instead of silently missing events.
+type buildEventFeed struct {
+	mu   sync.Mutex
+	subs map[chan eventstore.Event]struct{}
+}
+
+func newBuildEventFeed() *buildEventFeed {
+	return &buildEventFeed{subs: make(map[chan eventstore.Event]struct{})}
+}
+
+// subscribe returns a channel receiving every event published from now on and a
+// function that ends the subscription.
+func (f *buildEventFeed) subscribe(buffer int) (<-chan eventstore.Event, func()) {
+	ch := make(chan eventstore.Event, buffer)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+	return ch, func() { f.drop(ch) }
+}
+
+func (f *buildEventFeed) publish(evt eventstore.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		select {
+		case ch <- evt:
+		default:
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *buildEventFeed) drop(ch chan eventstore.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subs[ch]; ok {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"net/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// BuildReportHandler serves GET /api/builds/{id}/report: the complete build report of a
+// build job, as stored in the event store (build-report.json schema).
+func (d *Daemon) BuildReportHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := errors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build())
+		return
+	}
+
+	buildID := r.PathValue("id")
+	if buildID == "" {
+		adapter.WriteErrorResponse(w, r, errors.ValidationError("build id is required").Build())
+		return
+	}
+	if d.eventStore == nil {
+		adapter.WriteErrorResponse(w, r, errors.DaemonError("event store not initialized").Build())
+		return
+	}
+
+	document, err := eventstore.LoadBuildReport(r.Context(), d.eventStore, buildID)
+	if err != nil {
+		adapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(document)
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestDaemon_BuildReportHandler_ServesStoredReport(t *testing.T) {
+	store, err := eventstore.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	projection := eventstore.NewBuildHistoryProjection(store, 10)
+	d := &Daemon{eventStore: store}
+	emitter := NewEventEmitter(store, projection)
+
+	report := models.NewBuildReport(t.Context(), 2, 7)
+	report.RenderedPages = 7
+	report.Warnings = append(report.Warnings, errors.New("one repository skipped"))
+	report.DeriveOutcome()
+	report.Finish()
+	require.NoError(t, emitter.EmitBuildReport(t.Context(), "job-42", report))
+
+	summary, ok := projection.GetBuild("job-42")
+	require.True(t, ok)
+	require.NotNil(t, summary.ReportData)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/builds/{id}/report", d.BuildReportHandler)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/builds/job-42/report", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	decoded, err := models.DecodeReport(rec.Body.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, models.ReportSchemaVersion, decoded.SchemaVersion)
+	require.Equal(t, 7, decoded.Files)
+	require.Equal(t, "warning", decoded.Outcome)
+	require.Equal(t, []string{"one repository skipped"}, decoded.Warnings)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/builds/unknown/report", http.NoBody))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/builds/job-42/report", http.NoBody))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
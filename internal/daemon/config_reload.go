@@ -0,0 +1,264 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// ReloadSummary describes how a configuration reload was reconciled with the running daemon.
+type ReloadSummary struct {
+	ReloadedAt time.Time `json:"reloaded_at"`
+	// Repositories (by name) that entered, left or changed the build scope.
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Changed   []string `json:"changed"`
+	Unchanged int      `json:"unchanged"`
+	// BuildJobID is set when the scope changed and a rebuild was requested.
+	BuildJobID string `json:"build_job_id,omitempty"`
+	// RestartRequired lists changed settings that only take effect after a restart.
+	RestartRequired []string `json:"restart_required,omitempty"`
+}
+
+// ScopeChanged reports whether the reload added, removed or changed any repository.
+func (s *ReloadSummary) ScopeChanged() bool {
+	return len(s.Added) > 0 || len(s.Removed) > 0 || len(s.Changed) > 0
+}
+
+// newForgeManager creates a forge manager with a client for each configured forge.
+func newForgeManager(forges []*config.ForgeConfig) (*forge.Manager, error) {
+	forgeManager := forge.NewForgeManager()
+	for _, forgeConfig := range forges {
+		client, err := forge.NewForgeClient(forgeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create forge client %s: %w", forgeConfig.Name, err)
+		}
+		forgeManager.AddForge(forgeConfig, client)
+	}
+	return forgeManager, nil
+}
+
+// ReloadConfig re-reads the daemon's configuration file and applies its forges, filtering
+// and repository list without a restart.
+func (d *Daemon) ReloadConfig(ctx context.Context) (*ReloadSummary, error) {
+	if d.configFilePath == "" {
+		return nil, errors.New("daemon was not started from a configuration file")
+	}
+	cfg, err := config.Load(d.configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reload config: %w", err)
+	}
+	return d.applyConfig(ctx, cfg)
+}
+
+// applyConfig reconciles the running daemon with cfg: cached discovery results are
+// re-filtered, repositories that left the build scope are evicted from state and the repo
+// cache, and a rebuild is requested only if the set of repositories changed.
+func (d *Daemon) applyConfig(ctx context.Context, cfg *config.Config) (*ReloadSummary, error) {
+	if cfg == nil || cfg.Daemon == nil {
+		return nil, errors.New("daemon configuration is required")
+	}
+	forgeManager, err := newForgeManager(cfg.Forges)
+	if err != nil {
+		return nil, err
+	}
+	discovery := forge.NewDiscoveryService(forgeManager, cfg.Filtering)
+
+	d.mu.Lock()
+	before := d.currentReposForOrchestratedBuild()
+	oldCfg := d.config
+
+	// Drop cached repositories that no longer match the filters or whose forge was removed.
+	// The next scheduled discovery refreshes the rest.
+	if d.discoveryCache != nil {
+		forgeConfigs := forgeManager.GetForgeConfigs()
+		d.discoveryCache.Prune(func(repo *forge.Repository) bool {
+			if name := repo.Metadata["forge_name"]; name != "" {
+				if _, ok := forgeConfigs[name]; !ok {
+					return false
+				}
+			}
+			return discovery.Includes(repo)
+		})
+	}
+
+	d.config = cfg
+	crash.Default().SetConfigHash(cfg.Snapshot())
+	d.forgeManager = forgeManager
+	d.discovery = discovery
+	if d.discoveryRunner != nil {
+		d.discoveryRunner.UpdateConfig(cfg)
+		d.discoveryRunner.UpdateForgeManager(forgeManager)
+		d.discoveryRunner.UpdateDiscoveryService(discovery)
+	}
+	after := d.currentReposForOrchestratedBuild()
+	d.mu.Unlock()
+
+	summary, removed := diffRepositories(before, after)
+	summary.ReloadedAt = time.Now()
+	summary.RestartRequired = restartRequiredSettings(oldCfg, cfg)
+
+	for _, repo := range removed {
+		if err := d.publishOrchestrationEvent(ctx, events.RepoRemoved{
+			RepoURL:   repo.URL,
+			RepoName:  repo.Name,
+			RemovedAt: summary.ReloadedAt,
+		}); err != nil {
+			d.log().Warn("Failed to publish repo removed event after config reload",
+				logfields.Name(repo.Name),
+				slog.String("repo_url", repo.URL),
+				logfields.Error(err))
+		}
+	}
+
+	if summary.ScopeChanged() {
+		summary.BuildJobID = d.requestReloadBuild(ctx)
+	}
+
+	d.mu.Lock()
+	d.lastReload = summary
+	d.mu.Unlock()
+
+	d.log().Info("Configuration reloaded",
+		slog.Any("added", summary.Added),
+		slog.Any("removed", summary.Removed),
+		slog.Any("changed", summary.Changed),
+		slog.Int("unchanged", summary.Unchanged),
+		logfields.JobID(summary.BuildJobID))
+	if len(summary.RestartRequired) > 0 {
+		d.log().Warn("Some changed settings only take effect after a restart",
+			slog.Any("settings", summary.RestartRequired))
+	}
+	return summary, nil
+}
+
+// GetLastReload returns the summary of the most recent configuration reload, or nil.
+func (d *Daemon) GetLastReload() *ReloadSummary {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.lastReload
+}
+
+// requestReloadBuild publishes a build request for the reloaded scope and returns its job ID.
+func (d *Daemon) requestReloadBuild(ctx context.Context) string {
+	if d.orchestrationBus == nil {
+		return ""
+	}
+	jobID := ""
+	if d.buildDebouncer != nil {
+		if planned, ok := d.buildDebouncer.PlannedJobID(); ok {
+			jobID = planned
+		}
+	}
+	if jobID == "" {
+		jobID = fmt.Sprintf("config-reload-%d", time.Now().UnixNano())
+	}
+	if err := d.publishOrchestrationEvent(ctx, events.BuildRequested{
+		JobID:       jobID,
+		Reason:      "config reload",
+		RequestedAt: time.Now(),
+	}); err != nil {
+		d.log().Warn("Failed to publish build request after config reload",
+			logfields.JobID(jobID),
+			logfields.Error(err))
+		return ""
+	}
+	return jobID
+}
+
+// diffRepositories compares two build scopes by repository URL and returns the summary
+// along with the repositories that were removed.
+func diffRepositories(before, after []config.Repository) (*ReloadSummary, []config.Repository) {
+	summary := &ReloadSummary{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	old := make(map[string]config.Repository, len(before))
+	for _, repo := range before {
+		old[repo.URL] = repo
+	}
+	var removed []config.Repository
+	for _, repo := range after {
+		prev, ok := old[repo.URL]
+		switch {
+		case !ok:
+			summary.Added = append(summary.Added, repo.Name)
+		case !reflect.DeepEqual(prev, repo):
+			summary.Changed = append(summary.Changed, repo.Name)
+		default:
+			summary.Unchanged++
+		}
+		delete(old, repo.URL)
+	}
+	for _, repo := range old {
+		summary.Removed = append(summary.Removed, repo.Name)
+		removed = append(removed, repo)
+	}
+	sort.Strings(summary.Added)
+	sort.Strings(summary.Removed)
+	sort.Strings(summary.Changed)
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Name < removed[j].Name })
+	return summary, removed
+}
+
+// restartRequiredSettings lists the daemon settings that differ between old and updated but
+// are bound at startup (listeners, storage paths and the sync schedule).
+func restartRequiredSettings(old, updated *config.Config) []string {
+	if old == nil || old.Daemon == nil || updated.Daemon == nil {
+		return nil
+	}
+	var settings []string
+	if !reflect.DeepEqual(old.Daemon.HTTP, updated.Daemon.HTTP) {
+		settings = append(settings, "daemon.http")
+	}
+	if !reflect.DeepEqual(old.Daemon.Storage, updated.Daemon.Storage) {
+		settings = append(settings, "daemon.storage")
+	}
+	if !reflect.DeepEqual(old.Daemon.Sync, updated.Daemon.Sync) {
+		settings = append(settings, "daemon.sync")
+	}
+	return settings
+}
+
+// ReloadHandler serves the admin reload endpoint: POST reloads the configuration file and
+// returns the reconciliation summary, GET returns the summary of the last reload.
+func (d *Daemon) ReloadHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	var summary *ReloadSummary
+	switch r.Method {
+	case http.MethodGet:
+		summary = d.GetLastReload()
+		if summary == nil {
+			adapter.WriteErrorResponse(w, r, ferrors.NewError(ferrors.CategoryNotFound, "configuration has not been reloaded").Build())
+			return
+		}
+	case http.MethodPost:
+		var err error
+		summary, err = d.ReloadConfig(r.Context())
+		if err != nil {
+			adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryConfig, "configuration reload failed").Build())
+			return
+		}
+	default:
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET, POST").
+			Build())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode reload summary").Build())
+	}
+}
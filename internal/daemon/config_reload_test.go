@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+)
+
+func reloadTestConfig(repos ...config.Repository) *config.Config {
+	return &config.Config{
+		Daemon:       &config.DaemonConfig{},
+		Filtering:    &config.FilteringConfig{},
+		Repositories: repos,
+	}
+}
+
+func TestDaemon_applyConfig_ReconcilesExplicitRepositories(t *testing.T) {
+	bus := events.NewBus()
+	removedCh, unsubRemoved := events.Subscribe[events.RepoRemoved](bus, 4)
+	defer unsubRemoved()
+	buildCh, unsubBuild := events.Subscribe[events.BuildRequested](bus, 4)
+	defer unsubBuild()
+
+	keep := config.Repository{Name: "keep", URL: "https://example.com/keep.git", Branch: "main"}
+	changed := config.Repository{Name: "changed", URL: "https://example.com/changed.git", Branch: "main"}
+	gone := config.Repository{Name: "gone", URL: "https://example.com/gone.git", Branch: "main"}
+	d := &Daemon{config: reloadTestConfig(keep, changed, gone), orchestrationBus: bus, discoveryCache: NewDiscoveryCache()}
+
+	changedNow := changed
+	changedNow.Branch = "release"
+	added := config.Repository{Name: "added", URL: "https://example.com/added.git", Branch: "main"}
+	summary, err := d.applyConfig(t.Context(), reloadTestConfig(keep, changedNow, added))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"added"}, summary.Added)
+	require.Equal(t, []string{"gone"}, summary.Removed)
+	require.Equal(t, []string{"changed"}, summary.Changed)
+	require.Equal(t, 1, summary.Unchanged)
+	require.NotEmpty(t, summary.BuildJobID)
+	require.Same(t, summary, d.GetLastReload())
+
+	select {
+	case evt := <-removedCh:
+		require.Equal(t, gone.URL, evt.RepoURL)
+		require.Equal(t, gone.Name, evt.RepoName)
+	case <-time.After(time.Second):
+		t.Fatal("expected RepoRemoved event")
+	}
+	select {
+	case evt := <-buildCh:
+		require.Equal(t, summary.BuildJobID, evt.JobID)
+		require.Equal(t, "config reload", evt.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("expected BuildRequested event")
+	}
+}
+
+func TestDaemon_applyConfig_UnchangedScopeSkipsBuild(t *testing.T) {
+	bus := events.NewBus()
+	buildCh, unsub := events.Subscribe[events.BuildRequested](bus, 1)
+	defer unsub()
+
+	repo := config.Repository{Name: "docs", URL: "https://example.com/docs.git", Branch: "main"}
+	old := reloadTestConfig(repo)
+	d := &Daemon{config: old, orchestrationBus: bus}
+
+	updated := reloadTestConfig(repo)
+	updated.Daemon.HTTP.DocsPort = 9000
+	summary, err := d.applyConfig(t.Context(), updated)
+	require.NoError(t, err)
+
+	require.False(t, summary.ScopeChanged())
+	require.Empty(t, summary.BuildJobID)
+	require.Equal(t, []string{"daemon.http"}, summary.RestartRequired)
+	require.Len(t, buildCh, 0)
+}
+
+func TestDaemon_applyConfig_RefiltersDiscoveredRepositories(t *testing.T) {
+	forgeCfg := &config.ForgeConfig{
+		Name:    "gh",
+		Type:    config.ForgeGitHub,
+		APIURL:  "https://api.github.com",
+		BaseURL: "https://github.com",
+		Auth:    &config.AuthConfig{Type: config.AuthTypeToken, Token: "t"},
+	}
+	cfg := func(exclude ...string) *config.Config {
+		c := reloadTestConfig()
+		c.Forges = []*config.ForgeConfig{forgeCfg}
+		c.Filtering.ExcludePatterns = exclude
+		return c
+	}
+	repo := func(name string) *forge.Repository {
+		return &forge.Repository{
+			Name: name, FullName: "org/" + name, CloneURL: "https://github.com/org/" + name + ".git",
+			DefaultBranch: "main", HasDocs: true, Metadata: map[string]string{"forge_name": "gh"},
+		}
+	}
+
+	d := &Daemon{config: cfg(), orchestrationBus: events.NewBus(), discoveryCache: NewDiscoveryCache()}
+	forgeManager, err := newForgeManager(d.config.Forges)
+	require.NoError(t, err)
+	d.forgeManager = forgeManager
+	d.discovery = forge.NewDiscoveryService(forgeManager, d.config.Filtering)
+	d.discoveryCache.Update(&forge.DiscoveryResult{Repositories: []*forge.Repository{repo("api"), repo("legacy")}})
+
+	summary, err := d.applyConfig(t.Context(), cfg("legacy"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"legacy"}, summary.Removed)
+	require.Equal(t, 1, summary.Unchanged)
+	result := d.discoveryCache.GetResult()
+	require.Len(t, result.Repositories, 1)
+	require.Equal(t, "api", result.Repositories[0].Name)
+}
+
+func TestDaemon_ReloadHandler(t *testing.T) {
+	repo := config.Repository{Name: "docs", URL: "https://example.com/docs.git", Branch: "main"}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: "2.0"
+daemon:
+  sync:
+    schedule: "0 */4 * * *"
+repositories:
+  - name: docs
+    url: https://example.com/docs.git
+    branch: main
+`), 0o600))
+	d := &Daemon{config: reloadTestConfig(repo), configFilePath: path, orchestrationBus: events.NewBus()}
+
+	rec := httptest.NewRecorder()
+	d.ReloadHandler(rec, httptest.NewRequest(http.MethodGet, "/api/daemon/reload", http.NoBody))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	d.ReloadHandler(rec, httptest.NewRequest(http.MethodPost, "/api/daemon/reload", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var summary ReloadSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	require.Empty(t, summary.Added)
+	require.Empty(t, summary.Removed)
+
+	rec = httptest.NewRecorder()
+	d.ReloadHandler(rec, httptest.NewRequest(http.MethodGet, "/api/daemon/reload", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	d.configFilePath = filepath.Join(t.TempDir(), "missing.yaml")
+	rec = httptest.NewRecorder()
+	d.ReloadHandler(rec, httptest.NewRequest(http.MethodPost, "/api/daemon/reload", http.NoBody))
+	require.GreaterOrEqual(t, rec.Code, http.StatusBadRequest)
+}
@@ -13,18 +13,25 @@ import (
 	"sync/atomic"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
 	"git.home.luguber.info/inful/docbuilder/internal/build"
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
 	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
 	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/feedback"
 	"git.home.luguber.info/inful/docbuilder/internal/forge"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+	"git.home.luguber.info/inful/docbuilder/internal/leader"
 	"git.home.luguber.info/inful/docbuilder/internal/linkverify"
 	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
 	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
 	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
 	"git.home.luguber.info/inful/docbuilder/internal/state"
+	"git.home.luguber.info/inful/docbuilder/internal/watch"
 	"git.home.luguber.info/inful/docbuilder/internal/workspace"
 )
 
@@ -52,6 +59,10 @@ type Daemon struct {
 	runCancel      context.CancelFunc
 	mu             sync.RWMutex
 
+	// Component loggers (monitoring.logging); nil falls back to slog.Default
+	loggers *logging.Router
+	logger  *slog.Logger
+
 	// Core components
 	forgeManager *forge.Manager
 	discovery    *forge.DiscoveryService
@@ -72,6 +83,15 @@ type Daemon struct {
 	buildProjection *eventstore.BuildHistoryProjection
 	eventEmitter    *EventEmitter
 
+	// Page feedback storage (nil unless daemon.feedback is enabled)
+	feedbackStore *feedback.SQLiteStore
+
+	// Page view counters (nil unless daemon.analytics is enabled)
+	pageViews *analytics.PageViews
+
+	// Persistent workspace watcher (nil unless daemon.watch is enabled)
+	workspaceWatcher *watch.Watcher
+
 	// Runtime state
 	activeJobs  int32
 	queueLength int32
@@ -85,14 +105,49 @@ type Daemon struct {
 	statusJobID string
 	promJobID   string
 
+	// Planned random delay of the next scheduled sync in nanoseconds (daemon.sync.jitter)
+	syncJitter atomic.Int64
+
 	// Discovery cache for fast status queries
 	discoveryCache *DiscoveryCache
 
+	// Forge releases recorded from webhooks, rendered into release notes sections
+	releases *releaseNotes
+
 	// Discovery runner for forge discovery operations
 	discoveryRunner *DiscoveryRunner
 
 	// Link verification service
 	linkVerifier *linkverify.VerificationService
+
+	// Periodic external link check (nil unless daemon.link_check is enabled)
+	linkChecker      *linkverify.ExternalChecker
+	linkCheckJobID   string
+	linkCheckRunning atomic.Bool
+
+	// Scheduled full rebuild with drift detection (nil unless daemon.reconciliation is enabled)
+	reconciler     *reconciler
+	reconcileJobID string
+
+	// Outcome of the most recent configuration reload (nil until the first reload)
+	lastReload *ReloadSummary
+
+	// Maintenance mode flag and the build requests held while it is set
+	maintenance maintenanceState
+	blackout    blackoutState
+
+	// Latest disk usage of the repository cache and output directory
+	diskUsage diskUsageMonitor
+
+	// Webhook build cooldowns per repository and the requests held by them
+	cooldowns buildCooldowns
+
+	// Last build job of each named site (daemon.sites)
+	sites siteBuilds
+
+	// Leader election (nil unless daemon.leader_election is enabled; then only the
+	// leader runs discovery and builds)
+	leader *leader.Elector
 }
 
 // NewDaemon creates a new daemon instance
@@ -101,8 +156,22 @@ func NewDaemon(cfg *config.Config) (*Daemon, error) {
 	return NewDaemonWithConfigFile(cfg, "")
 }
 
+// log returns the daemon component logger.
+func (d *Daemon) log() *slog.Logger {
+	if d.logger == nil {
+		return slog.Default()
+	}
+	return d.logger
+}
+
 // NewDaemonWithConfigFile creates a new daemon instance with config file watching.
 func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon, error) {
+	return NewDaemonWithLogging(cfg, configFilePath, nil)
+}
+
+// NewDaemonWithLogging creates a daemon whose components (daemon, git, hugo, http) log
+// through the loggers of the given router. A nil router logs through slog.Default.
+func NewDaemonWithLogging(cfg *config.Config, configFilePath string, loggers *logging.Router) (*Daemon, error) {
 	if cfg == nil {
 		return nil, errors.New("configuration is required")
 	}
@@ -114,45 +183,62 @@ func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon
 	daemon := &Daemon{
 		config:           cfg,
 		configFilePath:   configFilePath,
+		loggers:          loggers,
+		logger:           loggers.Logger(logging.ComponentDaemon),
 		stopChan:         make(chan struct{}),
 		metrics:          NewMetricsCollector(),
 		discoveryCache:   NewDiscoveryCache(),
+		releases:         newReleaseNotes(),
 		orchestrationBus: events.NewBus(),
 	}
 
 	daemon.status.Store(StatusStopped)
-
-	// Initialize forge manager
-	forgeManager := forge.NewForgeManager()
-	for _, forgeConfig := range cfg.Forges {
-		client, err := forge.NewForgeClient(forgeConfig)
+	if cfg.Daemon.IsServerRole() {
+		return daemon.initServerRole(), nil
+	}
+	if cfg.Daemon.Maintenance != nil && cfg.Daemon.Maintenance.Enabled {
+		daemon.EnterMaintenance("")
+	}
+	if cfg.Daemon.LeaderElection.IsEnabled() {
+		elector, err := daemon.newLeaderElector(cfg.Daemon.LeaderElection)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create forge client %s: %w", forgeConfig.Name, err)
+			return nil, fmt.Errorf("failed to initialize leader election: %w", err)
 		}
-		forgeManager.AddForge(forgeConfig, client)
+		daemon.leader = elector
+	}
+
+	// Initialize forge manager
+	forgeManager, err := newForgeManager(cfg.Forges)
+	if err != nil {
+		return nil, err
 	}
 	daemon.forgeManager = forgeManager
 
 	// Initialize discovery service
 	daemon.discovery = forge.NewDiscoveryService(forgeManager, cfg.Filtering)
 
+	// Output storage is shared by the generator (publish) and the docs server (serve)
+	outputStorage := output.NewLocal()
+
+	newGenerator := func(cfg *config.Config, outputDir string) build.HugoGenerator {
+		return hugo.NewGenerator(cfg, outputDir).WithStorage(outputStorage).WithLogging(loggers).WithReleaseNotes(daemon)
+	}
+
 	// Create canonical BuildService (Phase D - Single Execution Pipeline)
 	buildService := build.NewBuildService().
 		WithWorkspaceFactory(func() *workspace.Manager {
 			// Use persistent workspace for incremental builds (repo_cache_dir/working)
 			return workspace.NewPersistentManager(cfg.Daemon.Storage.RepoCacheDir, "working")
 		}).
-		WithHugoGeneratorFactory(func(cfg *config.Config, outputDir string) build.HugoGenerator {
-			return hugo.NewGenerator(cfg, outputDir)
-		}).
+		WithHugoGeneratorFactory(newGenerator).
 		WithSkipEvaluatorFactory(func(outputDir string) build.SkipEvaluator {
 			// Create skip evaluator with state manager access
 			// Will be populated after state manager is initialized
 			if daemon.stateManager == nil {
-				slog.Warn("Skip evaluator factory called before state manager initialized - skipping evaluation")
+				daemon.log().Warn("Skip evaluator factory called before state manager initialized - skipping evaluation")
 				return nil
 			}
-			gen := hugo.NewGenerator(daemon.config, outputDir)
+			gen := hugo.NewGenerator(daemon.config, outputDir).WithStorage(outputStorage).WithLogging(loggers)
 			return NewSkipEvaluator(outputDir, daemon.stateManager, gen)
 		})
 	buildAdapter := NewBuildServiceAdapter(buildService)
@@ -181,6 +267,23 @@ func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon
 	}
 	daemon.stateManager = state.NewServiceAdapter(stateServiceResult.Unwrap())
 
+	// Serve the last discovery result from state until the first discovery completes
+	if restoreErr := daemon.discoveryCache.Restore(daemon.stateManager); restoreErr != nil {
+		daemon.log().Warn("Failed to restore discovery cache", logfields.Error(restoreErr))
+	} else if daemon.discoveryCache.Restored() {
+		daemon.log().Info("Restored discovery cache from state",
+			slog.Time("updated_at", daemon.discoveryCache.UpdatedAt()))
+	}
+
+	if restoreErr := daemon.releases.restore(daemon.stateManager); restoreErr != nil {
+		daemon.log().Warn("Failed to restore release notes", logfields.Error(restoreErr))
+	}
+
+	// Recovered panics (queue workers, HTTP handlers, stages) write crash reports to the state dir
+	crashReporter := crash.NewReporter(filepath.Join(stateDir, crash.DirName))
+	crashReporter.SetConfigHash(cfg.Snapshot())
+	crash.SetDefault(crashReporter)
+
 	// Initialize event store and build history projection (Phase B - Event Sourcing)
 	eventStorePath := filepath.Join(stateDir, "events.db")
 	eventStore, err := eventstore.NewSQLiteStore(eventStorePath)
@@ -194,14 +297,57 @@ func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon
 
 	// Rebuild projection from existing events
 	if rebuildErr := daemon.buildProjection.Rebuild(context.Background()); rebuildErr != nil {
-		slog.Warn("Failed to rebuild build history projection", logfields.Error(rebuildErr))
+		daemon.log().Warn("Failed to rebuild build history projection", logfields.Error(rebuildErr))
 		// Non-fatal: projection will start empty
 	}
 
+	// Initialize page feedback storage (opt-in)
+	if cfg.Daemon.Feedback.IsEnabled() {
+		feedbackPath := cfg.Daemon.Feedback.DatabasePath
+		if feedbackPath == "" {
+			feedbackPath = filepath.Join(stateDir, "feedback.db")
+		}
+		feedbackStore, feedbackErr := feedback.NewSQLiteStore(feedbackPath)
+		if feedbackErr != nil {
+			return nil, fmt.Errorf("failed to create feedback store: %w", feedbackErr)
+		}
+		daemon.feedbackStore = feedbackStore
+		daemon.log().Info("Page feedback enabled", slog.String("database", feedbackPath))
+	}
+
+	// Initialize page view analytics (opt-in)
+	if cfg.Daemon.Analytics.IsEnabled() {
+		daemon.pageViews = analytics.NewPageViews(cfg.Daemon.Analytics.PageLimit())
+		if cfg.Daemon.Analytics.Prometheus {
+			registerPageViewCollector(daemon.pageViews)
+		}
+		daemon.log().Info("Page view analytics enabled", slog.Bool("prometheus", cfg.Daemon.Analytics.Prometheus))
+	}
+
+	// Initialize the periodic external link check (opt-in)
+	if cfg.Daemon.LinkCheck.IsEnabled() {
+		daemon.linkChecker = newLinkChecker(cfg.Daemon.LinkCheck, stateDir)
+		daemon.log().Info("External link check enabled", slog.Duration("interval", cfg.Daemon.LinkCheck.IntervalDuration()))
+	}
+
+	// Initialize the scheduled reconciliation build (opt-in). Its build service keeps the
+	// default ephemeral workspace and has no skip evaluator, so nothing incremental is reused.
+	if cfg.Daemon.Reconciliation.IsEnabled() {
+		daemon.reconciler = newReconciler(build.NewBuildService().WithHugoGeneratorFactory(newGenerator), stateDir)
+		daemon.log().Info("Reconciliation build enabled", slog.String("schedule", cfg.Daemon.Reconciliation.ScheduleExpr()))
+	}
+
+	// Initialize persistent workspace watching (opt-in)
+	workspaceWatcher, err := newWorkspaceWatcher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	daemon.workspaceWatcher = workspaceWatcher
+
 	// Initialize livereload hub (opt-in)
 	if cfg.Build.LiveReload {
 		daemon.liveReload = NewLiveReloadHub(daemon.metrics)
-		slog.Info("LiveReload hub initialized")
+		daemon.log().Info("LiveReload hub initialized")
 	}
 
 	// Initialize HTTP server wiring (extracted package)
@@ -223,26 +369,66 @@ func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon
 		detailedMetrics = daemon.metrics.MetricsHandler
 	}
 	statusHandlers := handlers.NewStatusPageHandlers(daemon)
-	daemon.httpServer = httpserver.New(cfg, daemon, httpserver.Options{
-		ForgeClients:          forgeClients,
-		WebhookConfigs:        webhookConfigs,
-		LiveReloadHub:         daemon.liveReload,
-		EnhancedHealthHandle:  daemon.EnhancedHealthHandler,
-		DetailedMetricsHandle: detailedMetrics,
-		PrometheusHandler:     prometheusOptionalHandler(),
-		StatusHandle:          statusHandlers.HandleStatusPage,
-	})
+	serverOpts := httpserver.Options{
+		ForgeClients:           forgeClients,
+		WebhookConfigs:         webhookConfigs,
+		WebhookBranchFilter:    daemon,
+		WebhookEventTrigger:    daemon,
+		LiveReloadHub:          daemon.liveReload,
+		EnhancedHealthHandle:   daemon.EnhancedHealthHandler,
+		DetailedMetricsHandle:  detailedMetrics,
+		PrometheusHandler:      prometheusOptionalHandler(),
+		ThrottleRecorder:       promThrottleRecorder{},
+		StatusHandle:           statusHandlers.HandleStatusPage,
+		ReloadHandle:           daemon.ReloadHandler,
+		DiscoveryPreviewHandle: daemon.DiscoveryPreviewHandler,
+		BuildReportHandle:      daemon.BuildReportHandler,
+		MaintenanceHandle:      daemon.MaintenanceHandler,
+		BuildCooldownsHandle:   daemon.BuildCooldownsHandler,
+		Maintenance:            daemon,
+		LeaderStatus:           daemon,
+		OutputStorage:          outputStorage,
+		Logger:                 loggers.Logger(logging.ComponentHTTP),
+		AccessLogSink:          loggers.AccessLog,
+	}
+	if daemon.feedbackStore != nil {
+		serverOpts.FeedbackStore = daemon.feedbackStore
+	}
+	if daemon.pageViews != nil {
+		serverOpts.PageViews = daemon.pageViews
+	}
+	if len(cfg.Daemon.Sites) > 0 {
+		serverOpts.SitesHandle = daemon.SitesHandler
+		serverOpts.SiteHandle = daemon.SiteHandler
+		serverOpts.SiteBuildHandle = daemon.SiteBuildHandler
+	}
+	serverOpts.LintHandle = daemon.LintHandler
+	serverOpts.OnboardHandle = daemon.OnboardHandler
+	if daemon.linkChecker != nil {
+		serverOpts.LinkCheckHandle = daemon.LinkCheckHandler
+		serverOpts.BrokenLinksPageHandle = daemon.BrokenLinksPageHandler
+	}
+	if daemon.reconciler != nil {
+		serverOpts.ReconciliationHandle = daemon.ReconciliationHandler
+	}
+	if daemon.metrics != nil {
+		serverOpts.DrainRecorder = daemon.metrics
+	}
+	if cfg.Daemon.HTTP.RPC {
+		serverOpts.RPCPath, serverOpts.RPCHandler = daemon.RPCHandler()
+	}
+	daemon.httpServer = httpserver.New(cfg, daemon, serverOpts)
 
 	// Initialize link verification service if enabled
 	if cfg.Daemon.LinkVerification != nil && cfg.Daemon.LinkVerification.Enabled {
 		linkVerifier, linkVerifierErr := linkverify.NewVerificationService(cfg.Daemon.LinkVerification)
 		if linkVerifierErr != nil {
-			slog.Warn("Failed to initialize link verification service",
+			daemon.log().Warn("Failed to initialize link verification service",
 				logfields.Error(linkVerifierErr),
 				slog.Bool("enabled", false))
 		} else {
 			daemon.linkVerifier = linkVerifier
-			slog.Info("Link verification service initialized",
+			daemon.log().Info("Link verification service initialized",
 				"nats_url", cfg.Daemon.LinkVerification.NATSURL,
 				"kv_bucket", cfg.Daemon.LinkVerification.KVBucket)
 		}
@@ -288,10 +474,10 @@ func NewDaemonWithConfigFile(cfg *config.Config, configFilePath string) (*Daemon
 
 	remoteCache, cacheErr := git.NewRemoteHeadCache(cfg.Daemon.Storage.RepoCacheDir)
 	if cacheErr != nil {
-		slog.Warn("Failed to initialize remote HEAD cache; disabling persistence", logfields.Error(cacheErr))
+		daemon.log().Warn("Failed to initialize remote HEAD cache; disabling persistence", logfields.Error(cacheErr))
 		remoteCache, _ = git.NewRemoteHeadCache("")
 	}
-	gitClient := git.NewClient(cfg.Daemon.Storage.RepoCacheDir).WithRemoteHeadCache(remoteCache)
+	gitClient := git.NewClient(cfg.Daemon.Storage.RepoCacheDir).WithRemoteHeadCache(remoteCache).WithLogger(loggers.Logger(logging.ComponentGit))
 	daemon.repoUpdater = NewRepoUpdater(daemon.orchestrationBus, gitClient, remoteCache, daemon.currentReposForOrchestratedBuild)
 
 	return daemon, nil
@@ -341,11 +527,14 @@ func (d *Daemon) Start(ctx context.Context) error {
 
 	// Set global reference for metrics bridge (prometheus build only uses it).
 	defaultDaemonInstance = d
-	slog.Info("Starting DocBuilder daemon", slog.String("version", "2.0"))
+	if d.config.Daemon.IsServerRole() {
+		return d.startServerRole(ctx)
+	}
+	d.log().Info("Starting DocBuilder daemon", slog.String("version", "2.0"))
 
 	// Load persistent state
 	if err := d.stateManager.Load(); err != nil {
-		slog.Warn("Failed to load state", "error", err)
+		d.log().Warn("Failed to load state", "error", err)
 	}
 
 	// Create a derived run context that is canceled on daemon shutdown.
@@ -383,9 +572,12 @@ func (d *Daemon) Start(ctx context.Context) error {
 
 	d.status.Store(StatusRunning)
 	d.metrics.SetGauge("daemon_status", int64(2)) // 2 = running
+	if d.leader != nil {
+		d.goWorker("leader_election", func() { d.leader.Run(runCtx) })
+	}
 	d.metrics.IncrementCounter("daemon_successful_starts")
 
-	slog.Info("DocBuilder daemon started successfully",
+	d.log().Info("DocBuilder daemon started successfully",
 		slog.Int("forges", len(d.config.Forges)),
 		slog.Int("docs_port", d.config.Daemon.HTTP.DocsPort),
 		slog.Int("admin_port", d.config.Daemon.HTTP.AdminPort),
@@ -418,7 +610,7 @@ func (d *Daemon) Start(ctx context.Context) error {
 	default:
 		wsPredict = filepath.Clean(outDir+"-workspace") + " (persistent sibling)"
 	}
-	slog.Info("Storage paths summary",
+	d.log().Info("Storage paths summary",
 		slog.String("output_dir", outDir),
 		slog.String("repo_cache_dir", repoCache),
 		slog.String("workspace_resolved", wsPredict),
@@ -432,7 +624,7 @@ func (d *Daemon) Start(ctx context.Context) error {
 
 	// When mainLoop exits, we're stopping
 	d.status.Store(StatusStopping)
-	slog.Info("Main loop exited, daemon stopping")
+	d.log().Info("Main loop exited, daemon stopping")
 
 	return nil
 }
@@ -451,7 +643,11 @@ func (d *Daemon) schedulePeriodicJobs(ctx context.Context) error {
 		return errors.New("daemon sync schedule is empty")
 	}
 
+	d.planSyncJitter()
 	syncJobID, err := d.scheduler.ScheduleCron("daemon-sync", expr, func() {
+		if !d.waitSyncJitter(ctx) {
+			return
+		}
 		d.runScheduledSyncTick(ctx, expr)
 	})
 	if err != nil {
@@ -483,6 +679,26 @@ func (d *Daemon) schedulePeriodicJobs(ctx context.Context) error {
 	}
 	d.promJobID = promJobID
 
+	if d.linkChecker != nil {
+		linkCheckJobID, err := d.scheduler.ScheduleEvery("daemon-link-check", d.config.Daemon.LinkCheck.IntervalDuration(), func() {
+			d.runLinkCheck(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		d.linkCheckJobID = linkCheckJobID
+	}
+
+	if d.reconciler != nil {
+		reconcileJobID, err := d.scheduler.ScheduleCron("daemon-reconciliation", d.config.Daemon.Reconciliation.ScheduleExpr(), func() {
+			d.runReconciliation(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		d.reconcileJobID = reconcileJobID
+	}
+
 	return nil
 }
 
@@ -492,12 +708,25 @@ func (d *Daemon) runScheduledSyncTick(ctx context.Context, expression string) {
 		return
 	}
 
-	slog.Info("Scheduled sync tick", slog.String("expression", expression))
+	if d.InMaintenance() {
+		d.log().Info("Skipping scheduled sync tick: maintenance mode", slog.String("expression", expression))
+		return
+	}
+	if d.config.Daemon != nil && d.config.Daemon.Sync.InBlackout(time.Now()) {
+		d.log().Info("Skipping scheduled sync tick: blackout window", slog.String("expression", expression))
+		return
+	}
+	if !d.isLeader() {
+		d.log().Debug("Skipping scheduled sync tick: not the leader", slog.String("expression", expression))
+		return
+	}
+
+	d.log().Info("Scheduled sync tick", slog.String("expression", expression))
 
 	// For forge-based discovery, run discovery.
 	if len(d.config.Forges) > 0 {
 		if d.discoveryRunner == nil {
-			slog.Warn("Skipping scheduled discovery: discovery runner not initialized")
+			d.log().Warn("Skipping scheduled discovery: discovery runner not initialized")
 		} else {
 			workCtx, cancel := d.stopAwareContext(ctx)
 			defer cancel()
@@ -508,7 +737,7 @@ func (d *Daemon) runScheduledSyncTick(ctx context.Context, expression string) {
 	// For explicit repositories, trigger a build to check for updates.
 	if len(d.config.Repositories) > 0 {
 		if d.orchestrationBus == nil {
-			slog.Warn("Skipping scheduled build: orchestration bus not initialized")
+			d.log().Warn("Skipping scheduled build: orchestration bus not initialized")
 		} else {
 			d.triggerScheduledBuildForExplicitRepos(ctx)
 		}
@@ -525,7 +754,7 @@ func (d *Daemon) Stop(ctx context.Context) error {
 	}
 
 	d.status.Store(StatusStopping)
-	slog.Info("Stopping DocBuilder daemon")
+	d.log().Info("Stopping DocBuilder daemon")
 
 	// Snapshot pointers so we can stop without holding the daemon mutex.
 	runCancel := d.runCancel
@@ -539,8 +768,13 @@ func (d *Daemon) Stop(ctx context.Context) error {
 	linkVerifier := d.linkVerifier
 	stateManager := d.stateManager
 	eventStore := d.eventStore
+	feedbackStore := d.feedbackStore
+	workspaceWatcher := d.workspaceWatcher
 	d.mu.Unlock()
 
+	d.cooldowns.stop()
+	d.blackout.stop()
+
 	// Cancel the run context to stop all background workers.
 	if runCancel != nil {
 		runCancel()
@@ -563,7 +797,7 @@ func (d *Daemon) Stop(ctx context.Context) error {
 
 	if scheduler != nil {
 		if err := scheduler.Stop(ctx); err != nil {
-			slog.Error("Failed to stop scheduler", logfields.Error(err))
+			d.log().Error("Failed to stop scheduler", logfields.Error(err))
 		}
 	}
 
@@ -573,7 +807,7 @@ func (d *Daemon) Stop(ctx context.Context) error {
 
 	if httpServer != nil {
 		if err := httpServer.Stop(ctx); err != nil {
-			slog.Error("Failed to stop HTTP server", "error", err)
+			d.log().Error("Failed to stop HTTP server", "error", err)
 		}
 	}
 
@@ -581,29 +815,41 @@ func (d *Daemon) Stop(ctx context.Context) error {
 		liveReload.Shutdown()
 	}
 
+	if workspaceWatcher != nil {
+		if err := workspaceWatcher.Close(); err != nil {
+			d.log().Error("Failed to close workspace watcher", logfields.Error(err))
+		}
+	}
+
 	// Close link verification service
 	if linkVerifier != nil {
 		if err := linkVerifier.Close(); err != nil {
-			slog.Error("Failed to close link verifier", logfields.Error(err))
+			d.log().Error("Failed to close link verifier", logfields.Error(err))
 		}
 	}
 
 	// Save state
 	if stateManager != nil {
 		if err := stateManager.Save(); err != nil {
-			slog.Error("Failed to save state", "error", err)
+			d.log().Error("Failed to save state", "error", err)
 		}
 	}
 
 	// Close event store (Phase B)
 	if eventStore != nil {
 		if err := eventStore.Close(); err != nil {
-			slog.Error("Failed to close event store", logfields.Error(err))
+			d.log().Error("Failed to close event store", logfields.Error(err))
+		}
+	}
+
+	if feedbackStore != nil {
+		if err := feedbackStore.Close(); err != nil {
+			d.log().Error("Failed to close feedback store", logfields.Error(err))
 		}
 	}
 
 	if err := d.workers.StopAndWait(ctx); err != nil {
-		slog.Warn("Timed out waiting for daemon workers to stop", logfields.Error(err))
+		d.log().Warn("Timed out waiting for daemon workers to stop", logfields.Error(err))
 	}
 
 	d.mu.Lock()
@@ -611,7 +857,7 @@ func (d *Daemon) Stop(ctx context.Context) error {
 	d.mu.Unlock()
 
 	uptime := time.Since(d.startTime)
-	slog.Info("DocBuilder daemon stopped", slog.Duration("uptime", uptime))
+	d.log().Info("DocBuilder daemon stopped", slog.Duration("uptime", uptime))
 
 	return nil
 }
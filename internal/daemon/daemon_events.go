@@ -2,7 +2,6 @@ package daemon
 
 import (
 	"context"
-	"log/slog"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
@@ -51,11 +50,18 @@ func (d *Daemon) EmitBuildFailed(ctx context.Context, buildID, stage, errorMsg s
 // onBuildReportEmitted is called after a build report is emitted to the event store.
 // This is where we trigger post-build hooks like link verification and state updates.
 func (d *Daemon) onBuildReportEmitted(ctx context.Context, buildID string, report *models.BuildReport) error {
+	// Builds of named sites do not publish the main site: state, workspace watches, link
+	// verification and the link check all refer to the main site's output.
+	if site := d.siteOfJob(buildID); site != "" {
+		d.log().Debug("Site build report emitted", "build_id", buildID, "site", site)
+		return nil
+	}
+
 	// Decide whether to run link verification before updating state so the decision
 	// can be based on what actually happened in this build.
 	shouldVerify := report != nil && report.Outcome == models.OutcomeSuccess && d.linkVerifier != nil && shouldRunLinkVerification(report)
 	if report != nil && report.Outcome == models.OutcomeSuccess && d.linkVerifier != nil && !shouldVerify {
-		slog.Debug("Skipping post-build link verification",
+		d.log().Debug("Skipping post-build link verification",
 			"build_id", buildID,
 			"skip_reason", report.SkipReason)
 	}
@@ -66,8 +72,21 @@ func (d *Daemon) onBuildReportEmitted(ctx context.Context, buildID string, repor
 		d.updateStateAfterBuild(report)
 	}
 
+	if report != nil {
+		recordPurgeMetrics(report.Purges)
+	}
+
+	// Newly cloned working copies become watchable once a build has completed.
+	if report != nil && report.Outcome == models.OutcomeSuccess {
+		d.syncWorkspaceWatches()
+	}
+
+	// Builds clone, update and prune working copies and rewrite the output; measure
+	// disk usage off the event path.
+	go d.refreshDiskUsage()
+
 	// Trigger link verification after successful builds (low priority background task).
-	slog.Debug("onBuildReportEmitted called",
+	d.log().Debug("onBuildReportEmitted called",
 		"build_id", buildID,
 		"report_nil", report == nil,
 		"outcome", func() string {
@@ -81,6 +100,12 @@ func (d *Daemon) onBuildReportEmitted(ctx context.Context, buildID string, repor
 		go d.verifyLinksAfterBuild(ctx, buildID)
 	}
 
+	// The periodic external link check first runs once a site is published instead of
+	// waiting a full interval.
+	if report != nil && report.Outcome == models.OutcomeSuccess && d.linkChecker != nil && d.linkChecker.Last() == nil {
+		go d.runLinkCheck(ctx)
+	}
+
 	return nil
 }
 
@@ -18,19 +18,24 @@ func (d *Daemon) mainLoop(ctx context.Context) {
 	initialDiscoveryTimer := time.NewTimer(3 * time.Second)
 	defer initialDiscoveryTimer.Stop()
 
+	// With leader election, the initial discovery or build runs when the lease is acquired.
+	if d.leader != nil {
+		initialDiscoveryTimer.Stop()
+	}
+
 	// If explicit repositories are configured (no forges), trigger an immediate build
-	if len(d.config.Repositories) > 0 && len(d.config.Forges) == 0 {
-		slog.Info("Explicit repositories configured, triggering initial build", slog.Int("repositories", len(d.config.Repositories)))
+	if d.leader == nil && len(d.config.Repositories) > 0 && len(d.config.Forges) == 0 {
+		d.log().Info("Explicit repositories configured, triggering initial build", slog.Int("repositories", len(d.config.Repositories)))
 		d.goWorker("initial_build", func() { d.requestInitialBuild(ctx) })
 	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("Main loop stopped by context cancellation")
+			d.log().Info("Main loop stopped by context cancellation")
 			return
 		case <-d.stopChan:
-			slog.Info("Main loop stopped by stop signal")
+			d.log().Info("Main loop stopped by stop signal")
 			return
 		case <-initialDiscoveryTimer.C:
 			workCtx, cancel := d.stopAwareContext(ctx)
@@ -47,7 +52,7 @@ func (d *Daemon) requestInitialBuild(ctx context.Context) {
 		return
 	}
 	if d.orchestrationBus == nil {
-		slog.Warn("Skipping initial build: orchestration bus not initialized")
+		d.log().Warn("Skipping initial build: orchestration bus not initialized")
 		return
 	}
 
@@ -68,7 +73,7 @@ func (d *Daemon) requestInitialBuild(ctx context.Context) {
 		RequestedAt: time.Now(),
 	})
 	if err != nil {
-		slog.Error("Failed to request initial build", logfields.Error(err), logfields.JobID(jobID))
+		d.log().Error("Failed to request initial build", logfields.Error(err), logfields.JobID(jobID))
 	}
 }
 
@@ -93,7 +98,7 @@ func (d *Daemon) updateStatus() {
 	// Periodic state save
 	if d.stateManager != nil {
 		if err := d.stateManager.Save(); err != nil {
-			slog.Warn("Failed to save state", "error", err)
+			d.log().Warn("Failed to save state", "error", err)
 		}
 	}
 }
@@ -6,15 +6,13 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
-	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	ggit "github.com/go-git/go-git/v5"
-
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 	"git.home.luguber.info/inful/docbuilder/internal/linkverify"
 )
@@ -25,13 +23,13 @@ func (d *Daemon) updateStateAfterBuild(report *models.BuildReport) {
 	// Update config hash
 	if report.ConfigHash != "" {
 		d.stateManager.SetLastConfigHash(report.ConfigHash)
-		slog.Debug("Updated config hash in state", "hash", report.ConfigHash)
+		d.log().Debug("Updated config hash in state", "hash", report.ConfigHash)
 	}
 
 	// Update global doc files hash
 	if report.DocFilesHash != "" {
 		d.stateManager.SetLastGlobalDocFilesHash(report.DocFilesHash)
-		slog.Debug("Updated global doc files hash in state", "hash", report.DocFilesHash)
+		d.log().Debug("Updated global doc files hash in state", "hash", report.DocFilesHash)
 	}
 
 	// Update repository commits and hashes.
@@ -47,9 +45,9 @@ func (d *Daemon) updateStateAfterBuild(report *models.BuildReport) {
 		}
 
 		// Open git repository to get current commit
-		gitRepo, err := ggit.PlainOpen(repoPath)
+		gitRepo, err := git.OpenRepository(repoPath)
 		if err != nil {
-			slog.Warn("Failed to open git repository for state update",
+			d.log().Warn("Failed to open git repository for state update",
 				"repository", repo.Name,
 				"path", repoPath,
 				"error", err)
@@ -59,7 +57,7 @@ func (d *Daemon) updateStateAfterBuild(report *models.BuildReport) {
 		// Get HEAD reference
 		ref, err := gitRepo.Head()
 		if err != nil {
-			slog.Warn("Failed to get HEAD for state update",
+			d.log().Warn("Failed to get HEAD for state update",
 				"repository", repo.Name,
 				"error", err)
 			continue
@@ -72,14 +70,14 @@ func (d *Daemon) updateStateAfterBuild(report *models.BuildReport) {
 
 		// Update commit in state
 		d.stateManager.SetRepoLastCommit(repo.URL, repo.Name, repo.Branch, commit)
-		slog.Debug("Updated repository commit in state",
+		d.log().Debug("Updated repository commit in state",
 			"repository", repo.Name,
 			"commit", commit[:8])
 	}
 
 	// Save state to disk
 	if err := d.stateManager.Save(); err != nil {
-		slog.Warn("Failed to save state after build", "error", err)
+		d.log().Warn("Failed to save state after build", "error", err)
 	}
 }
 
@@ -90,12 +88,12 @@ func (d *Daemon) verifyLinksAfterBuild(ctx context.Context, buildID string) {
 	verifyCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
-	slog.Info("Starting link verification for build", "build_id", buildID)
+	d.log().Info("Starting link verification for build", "build_id", buildID)
 
 	// Collect page metadata from build report
 	pages, err := d.collectPageMetadata(buildID)
 	if err != nil {
-		slog.Error("Failed to collect page metadata for link verification",
+		d.log().Error("Failed to collect page metadata for link verification",
 			"build_id", buildID,
 			"error", err)
 		return
@@ -103,13 +101,13 @@ func (d *Daemon) verifyLinksAfterBuild(ctx context.Context, buildID string) {
 
 	// Verify links
 	if err := d.linkVerifier.VerifyPages(verifyCtx, pages); err != nil {
-		slog.Warn("Link verification encountered errors",
+		d.log().Warn("Link verification encountered errors",
 			"build_id", buildID,
 			"error", err)
 		return
 	}
 
-	slog.Info("Link verification completed successfully", "build_id", buildID)
+	d.log().Info("Link verification completed successfully", "build_id", buildID)
 }
 
 // shouldRunLinkVerification returns true when it makes sense to run link verification.
@@ -127,7 +125,7 @@ func (d *Daemon) collectPageMetadata(buildID string) ([]*linkverify.PageMetadata
 	outputDir := d.config.Daemon.Storage.OutputDir
 	publicDir, ok := resolvePublicDirForVerification(outputDir)
 	if !ok {
-		slog.Warn("No public directory available for link verification; skipping page metadata collection",
+		d.log().Warn("No public directory available for link verification; skipping page metadata collection",
 			"build_id", buildID,
 			"output_dir", outputDir,
 			"expected_public", filepath.Join(outputDir, "public"),
@@ -208,7 +206,7 @@ func (d *Daemon) collectPageMetadata(buildID string) ([]*linkverify.PageMetadata
 		return nil, fmt.Errorf("failed to walk public directory %s: %w", publicDir, err)
 	}
 
-	slog.Debug("Collected page metadata for link verification",
+	d.log().Debug("Collected page metadata for link verification",
 		"build_id", buildID,
 		"page_count", len(pages))
 
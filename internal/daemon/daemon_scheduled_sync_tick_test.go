@@ -130,6 +130,36 @@ func TestDaemon_runScheduledSyncTick(t *testing.T) {
 		require.Equal(t, "repo-1", jobs[0].TypedMeta.Repositories[0].Name)
 	})
 
+	t.Run("skips the tick in a blackout window", func(t *testing.T) {
+		now := time.Now()
+		cfg := &config.Config{
+			Daemon: &config.DaemonConfig{Sync: config.SyncConfig{
+				Schedule: "0 */4 * * *",
+				BlackoutWindows: []config.BlackoutWindow{{
+					Start: now.Add(-time.Minute).Format("15:04"),
+					End:   now.Add(2 * time.Minute).Format("15:04"),
+				}},
+			}},
+			Forges: []*config.ForgeConfig{{Name: "forge-1", Type: config.ForgeForgejo}},
+		}
+
+		fakeQ := &fakeBuildQueue{}
+		runner := NewDiscoveryRunner(DiscoveryRunnerConfig{
+			Discovery:      &fakeDiscovery{result: &forge.DiscoveryResult{Repositories: []*forge.Repository{{Name: "repo-1", CloneURL: "https://example.invalid/repo-1.git", DefaultBranch: "main"}}}},
+			DiscoveryCache: discoveryrunner.NewCache(),
+			BuildQueue:     fakeQ,
+			Config:         cfg,
+			NewJobID:       func() string { return "job-1" },
+		})
+
+		d := &Daemon{config: cfg, discoveryRunner: runner}
+		d.stopChan = make(chan struct{})
+		d.status.Store(StatusRunning)
+
+		d.runScheduledSyncTick(context.Background(), "0 */4 * * *")
+		require.Empty(t, fakeQ.Jobs())
+	})
+
 	t.Run("scheduler starts and stops cleanly with scheduled jobs", func(t *testing.T) {
 		s, err := NewScheduler()
 		require.NoError(t, err)
@@ -6,20 +6,57 @@ import (
 	"log/slog"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
 	"git.home.luguber.info/inful/docbuilder/internal/logfields"
 )
 
 // TriggerDiscovery manually triggers repository discovery.
 func (d *Daemon) TriggerDiscovery() string {
+	if !d.isLeader() {
+		d.log().Info("Ignoring discovery request: not the leader")
+		return ""
+	}
+	if d.discoveryRunner == nil {
+		return ""
+	}
 	return d.discoveryRunner.TriggerManual(func() bool { return d.GetStatus() == StatusRunning }, &d.activeJobs)
 }
 
 // TriggerBuild manually triggers a site build.
 func (d *Daemon) TriggerBuild() string {
+	return d.requestManualBuild(nil, nil)
+}
+
+// TriggerScopedBuild manually triggers a site build that only fetches the named
+// repositories (or monorepo sections); other repositories are rendered from their
+// cached working copies. commits optionally pins repositories (by name) to exact
+// SHAs. An empty scope and no commits behaves like TriggerBuild. Names that match
+// no known repository, and commits outside the scope, are rejected with a validation error.
+func (d *Daemon) TriggerScopedBuild(repositories []string, commits map[string]string) (string, error) {
+	repos := d.currentReposForOrchestratedBuild()
+	if len(repositories) > 0 {
+		scoped, err := config.ScopeRepositories(repos, repositories)
+		if err != nil {
+			return "", err
+		}
+		repos = scoped
+	}
+	snapshot, err := config.CommitSnapshot(repos, commits)
+	if err != nil {
+		return "", err
+	}
+	return d.requestManualBuild(repositories, snapshot), nil
+}
+
+func (d *Daemon) requestManualBuild(scope []string, snapshot map[string]string) string {
 	if d.GetStatus() != StatusRunning {
 		return ""
 	}
+	if !d.isLeader() {
+		d.log().Info("Ignoring manual build request: not the leader")
+		return ""
+	}
 	if d.orchestrationBus == nil {
 		return ""
 	}
@@ -38,15 +75,17 @@ func (d *Daemon) TriggerBuild() string {
 		JobID:       jobID,
 		Immediate:   true,
 		Reason:      "manual",
+		Snapshot:    snapshot,
+		Scope:       scope,
 		RequestedAt: time.Now(),
 	}); err != nil {
-		slog.Warn("Failed to publish manual build request",
+		d.log().Warn("Failed to publish manual build request",
 			logfields.JobID(jobID),
 			logfields.Error(err))
 		return ""
 	}
 
-	slog.Info("Manual build requested", logfields.JobID(jobID))
+	d.log().Info("Manual build requested", logfields.JobID(jobID), slog.Any("scope", scope))
 	return jobID
 }
 
@@ -57,9 +96,9 @@ func (d *Daemon) TriggerBuild() string {
 // the build remains a canonical full-site build.
 //
 // forgeName is optional; callers may pass an empty string when the webhook is not
-// scoped to a specific configured forge instance.
-
-func (d *Daemon) TriggerWebhookBuild(forgeName, repoFullName, branch string, changedFiles []string) string {
+// scoped to a specific configured forge instance. commit is the pushed head SHA when
+// the forge reports one; the build then uses exactly that commit.
+func (d *Daemon) TriggerWebhookBuild(forgeName, repoFullName, branch, commit string, changedFiles []string) string {
 	if d.GetStatus() != StatusRunning {
 		return ""
 	}
@@ -83,10 +122,11 @@ func (d *Daemon) TriggerWebhookBuild(forgeName, repoFullName, branch string, cha
 		ForgeName:    forgeName,
 		RepoFullName: repoFullName,
 		Branch:       branch,
+		CommitSHA:    commit,
 		ChangedFiles: filesCopy,
 		ReceivedAt:   time.Now(),
 	}); err != nil {
-		slog.Warn("Failed to publish webhook received event",
+		d.log().Warn("Failed to publish webhook received event",
 			logfields.JobID(jobID),
 			logfields.Error(err),
 			slog.String("forge", forgeName),
@@ -95,7 +135,7 @@ func (d *Daemon) TriggerWebhookBuild(forgeName, repoFullName, branch string, cha
 		return ""
 	}
 
-	slog.Info("Webhook received",
+	d.log().Info("Webhook received",
 		logfields.JobID(jobID),
 		slog.String("forge", forgeName),
 		slog.String("repo", repoFullName),
@@ -130,12 +170,12 @@ func (d *Daemon) triggerScheduledBuildForExplicitRepos(ctx context.Context) {
 		Reason:      "scheduled build",
 		RequestedAt: time.Now(),
 	}); err != nil {
-		slog.Warn("Failed to publish scheduled build request",
+		d.log().Warn("Failed to publish scheduled build request",
 			logfields.JobID(jobID),
 			logfields.Error(err))
 		return
 	}
-	slog.Info("Scheduled build requested",
+	d.log().Info("Scheduled build requested",
 		logfields.JobID(jobID),
 		slog.Int("repositories", len(d.config.Repositories)))
 }
@@ -135,7 +135,7 @@ func TestDaemon_TriggerWebhookBuild_MatchesDiscoveredRepo(t *testing.T) {
 			events.SubscriberCount[events.BuildNow](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", nil)
+	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", "", nil)
 	require.NotEmpty(t, jobID)
 
 	require.Eventually(t, func() bool {
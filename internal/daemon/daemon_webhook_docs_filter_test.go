@@ -61,7 +61,7 @@ func TestDaemon_TriggerWebhookBuild_IgnoresIrrelevantPushChanges(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 
 	// Change outside docs path should not trigger a build.
-	jobID := d.TriggerWebhookBuild("forge-1", "org/repo", "main", []string{"src/config.yaml"})
+	jobID := d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", []string{"src/config.yaml"})
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -72,7 +72,7 @@ func TestDaemon_TriggerWebhookBuild_IgnoresIrrelevantPushChanges(t *testing.T) {
 	}
 
 	// Change within docs path should request a repo update.
-	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", []string{"docs/README.md"})
+	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", []string{"docs/README.md"})
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -85,7 +85,7 @@ func TestDaemon_TriggerWebhookBuild_IgnoresIrrelevantPushChanges(t *testing.T) {
 	}
 
 	// Changes to .docignore must trigger a rebuild because they affect repo inclusion/exclusion.
-	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", []string{".docignore"})
+	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", []string{".docignore"})
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -98,7 +98,7 @@ func TestDaemon_TriggerWebhookBuild_IgnoresIrrelevantPushChanges(t *testing.T) {
 	}
 
 	// Also tolerate common webhook path formats (leading slash).
-	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", []string{"/.docignore"})
+	jobID = d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", []string{"/.docignore"})
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -50,7 +50,7 @@ func TestDaemon_Webhook_WhenNoReposYet_RetriesAfterDiscovery(t *testing.T) {
 		return events.SubscriberCount[events.WebhookReceived](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", []string{"docs/README.md"})
+	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", "", []string{"docs/README.md"})
 	require.NotEmpty(t, jobID)
 
 	// Simulate discovery completing after the webhook arrives.
@@ -109,7 +109,7 @@ func TestDaemon_TriggerWebhookBuild_Orchestrated_EnqueuesWebhookJobWithBranchOve
 			events.SubscriberCount[events.BuildNow](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", nil)
+	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "main", "", nil)
 	require.NotEmpty(t, jobID)
 
 	require.Eventually(t, func() bool {
@@ -185,7 +185,7 @@ func TestDaemon_TriggerWebhookBuild_Orchestrated_IgnoresNonDefaultBranch(t *test
 		return events.SubscriberCount[events.WebhookReceived](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "feature-branch", nil)
+	jobID := d.TriggerWebhookBuild("forge-1", "org/go-test-project", "feature-branch", "", nil)
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -267,8 +267,8 @@ func TestDaemon_TriggerWebhookBuild_Orchestrated_ReusesPlannedJobIDWhenBuildRunn
 		return ok && planned == "job-seeded"
 	}, 1*time.Second, 5*time.Millisecond)
 
-	jobID1 := d.TriggerWebhookBuild("", "org/go-test-project", "main", nil)
-	jobID2 := d.TriggerWebhookBuild("", "org/go-test-project", "main", nil)
+	jobID1 := d.TriggerWebhookBuild("", "org/go-test-project", "main", "", nil)
+	jobID2 := d.TriggerWebhookBuild("", "org/go-test-project", "main", "", nil)
 	require.NotEmpty(t, jobID1)
 	require.Equal(t, jobID1, jobID2)
 	require.Equal(t, "job-seeded", jobID1)
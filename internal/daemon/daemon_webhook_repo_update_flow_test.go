@@ -101,7 +101,7 @@ func TestDaemon_WebhookRepoUpdateFlow_RemoteChanged_EnqueuesBuild(t *testing.T)
 				return events.SubscriberCount[events.WebhookReceived](bus) > 0
 			}, 1*time.Second, 10*time.Millisecond)
 
-			jobID := d.TriggerWebhookBuild("", "org/repo", tc.branch, []string{"docs/README.md"})
+			jobID := d.TriggerWebhookBuild("", "org/repo", tc.branch, "", []string{"docs/README.md"})
 			require.NotEmpty(t, jobID)
 
 			select {
@@ -190,7 +190,7 @@ func TestDaemon_WebhookRepoUpdateFlow_RemoteUnchanged_DoesNotEnqueueBuild(t *tes
 		return events.SubscriberCount[events.WebhookReceived](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("", "org/repo", "main", nil)
+	jobID := d.TriggerWebhookBuild("", "org/repo", "main", "", nil)
 	require.NotEmpty(t, jobID)
 
 	select {
@@ -301,7 +301,7 @@ func TestDaemon_WebhookRepoUpdateFlow_DiscoveryMode_RemoteUnchanged_DoesNotEnque
 		return events.SubscriberCount[events.WebhookReceived](bus) > 0
 	}, 1*time.Second, 10*time.Millisecond)
 
-	jobID := d.TriggerWebhookBuild("forge-1", "org/repo", "main", nil)
+	jobID := d.TriggerWebhookBuild("forge-1", "org/repo", "main", "", nil)
 	require.NotEmpty(t, jobID)
 
 	select {
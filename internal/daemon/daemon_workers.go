@@ -14,7 +14,7 @@ func (d *Daemon) goWorker(name string, fn func()) {
 		if name == "" {
 			name = "(unnamed)"
 		}
-		slog.Warn("Worker not started (daemon stopping)", slog.String("worker", name))
+		d.log().Warn("Worker not started (daemon stopping)", slog.String("worker", name))
 	}
 }
 
@@ -36,4 +36,8 @@ func (d *Daemon) startWorkers(ctx context.Context) {
 	if d.repoUpdater != nil {
 		d.goWorker("repo_updater", func() { d.repoUpdater.Run(ctx) })
 	}
+
+	if d.workspaceWatcher != nil {
+		d.goWorker("workspace_watcher", func() { d.runWorkspaceWatcher(ctx) })
+	}
 }
@@ -28,7 +28,7 @@ func (d *Daemon) onDiscoveryBuildRequest(ctx context.Context, jobID, reason stri
 		RequestedAt: time.Now(),
 	})
 	if pubErr != nil {
-		slog.Warn("Failed to publish discovery build request",
+		d.log().Warn("Failed to publish discovery build request",
 			logfields.JobID(jobID),
 			slog.String("reason", reason),
 			logfields.Error(pubErr))
@@ -50,7 +50,7 @@ func (d *Daemon) onDiscoveryRepoRemoved(ctx context.Context, repoURL, repoName s
 		Discovered: true,
 	})
 	if pubErr != nil {
-		slog.Warn("Failed to publish repo removed event",
+		d.log().Warn("Failed to publish repo removed event",
 			slog.String("repo", repoName),
 			slog.String("repo_url", repoURL),
 			logfields.Error(pubErr))
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// DiscoveryPreviewHandler serves GET /api/discovery/preview: it runs discovery with the
+// current filters and returns the decision for each repository, without updating the
+// discovery cache or state and without requesting builds.
+func (d *Daemon) DiscoveryPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := errors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build())
+		return
+	}
+
+	d.mu.RLock()
+	discovery := d.discovery
+	d.mu.RUnlock()
+	if discovery == nil {
+		adapter.WriteErrorResponse(w, r, errors.DaemonError("discovery service not initialized").Build())
+		return
+	}
+
+	preview, err := discovery.Preview(r.Context())
+	if err != nil {
+		adapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryForge, "discovery preview failed").Build())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(preview); err != nil {
+		adapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to encode discovery preview").Build())
+	}
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+)
+
+func TestDaemon_DiscoveryPreviewHandler_DoesNotTouchCache(t *testing.T) {
+	client := forge.NewEnhancedMockForgeClient("gh", forge.TypeGitHub)
+	client.AddRepository(forge.CreateMockGitHubRepo("acme", "api-docs", true, false, false, false))
+	client.AddRepository(forge.CreateMockGitHubRepo("acme", "legacy-docs", true, false, false, false))
+	manager := forge.NewForgeManager()
+	manager.AddForge(&config.ForgeConfig{Name: "gh", Type: config.ForgeGitHub, Organizations: []string{"acme"}}, client)
+
+	d := &Daemon{
+		discovery:      forge.NewDiscoveryService(manager, &config.FilteringConfig{ExcludePatterns: []string{"legacy-*"}}),
+		discoveryCache: NewDiscoveryCache(),
+	}
+
+	rec := httptest.NewRecorder()
+	d.DiscoveryPreviewHandler(rec, httptest.NewRequest(http.MethodGet, "/api/discovery/preview", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var preview forge.DiscoveryPreview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &preview))
+	require.Equal(t, 1, preview.Included)
+	require.Equal(t, 1, preview.Excluded)
+	require.Equal(t, "acme/legacy-docs", preview.Repositories[1].Repository)
+	require.Equal(t, "exclude_patterns_match", preview.Repositories[1].Reason)
+	require.False(t, d.discoveryCache.HasResult())
+
+	rec = httptest.NewRecorder()
+	d.DiscoveryPreviewHandler(rec, httptest.NewRequest(http.MethodPost, "/api/discovery/preview", http.NoBody))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,176 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// DiskUsage is a measurement of the daemon's directories, taken after each build.
+type DiskUsage struct {
+	MeasuredAt     time.Time        `json:"measured_at"`
+	WorkspaceBytes int64            `json:"workspace_bytes"` // Whole repository cache (repo_cache_dir)
+	OutputBytes    int64            `json:"output_bytes"`
+	Repositories   map[string]int64 `json:"repositories"` // Working copy size by repository name
+}
+
+// diskUsageMonitor holds the latest measurement. Measuring walks the directories, so
+// at most one measurement runs at a time.
+type diskUsageMonitor struct {
+	running atomic.Bool
+	mu      sync.RWMutex
+	last    *DiskUsage
+}
+
+func (m *diskUsageMonitor) latest() *DiskUsage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+func (m *diskUsageMonitor) store(u *DiskUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.last = u
+}
+
+// DiskUsage returns the latest disk usage measurement, or nil before the first build.
+func (d *Daemon) DiskUsage() *DiskUsage {
+	return d.diskUsage.latest()
+}
+
+// refreshDiskUsage measures the repository cache, each repository's working copy and
+// the output directory, and updates the disk usage gauges. A refresh requested while
+// one is running is dropped.
+func (d *Daemon) refreshDiskUsage() {
+	if d.config == nil || d.config.Daemon == nil {
+		return
+	}
+	if !d.diskUsage.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer d.diskUsage.running.Store(false)
+
+	start := time.Now()
+	storage := d.config.Daemon.Storage
+	usage := &DiskUsage{Repositories: make(map[string]int64)}
+
+	if storage.RepoCacheDir != "" {
+		usage.WorkspaceBytes = d.measureDir(storage.RepoCacheDir)
+		entries, err := os.ReadDir(d.workspaceDir())
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			d.log().Warn("Failed to list workspace for disk usage", logfields.Path(d.workspaceDir()), logfields.Error(err))
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				usage.Repositories[entry.Name()] = d.measureDir(filepath.Join(d.workspaceDir(), entry.Name()))
+			}
+		}
+	}
+	outputDir := storage.OutputDir
+	if outputDir == "" {
+		outputDir = d.config.Output.Directory
+	}
+	if outputDir != "" {
+		usage.OutputBytes = d.measureDir(outputDir)
+	}
+	usage.MeasuredAt = time.Now()
+	d.diskUsage.store(usage)
+
+	if d.metrics != nil {
+		d.metrics.SetGauge("disk_workspace_bytes", usage.WorkspaceBytes)
+		d.metrics.SetGauge("disk_output_bytes", usage.OutputBytes)
+		d.metrics.RecordHistogram("disk_usage_scan_duration_seconds", time.Since(start).Seconds())
+	}
+	recordDiskUsageMetrics(usage)
+
+	if msg, warn := d.diskUsageStatus(usage); warn {
+		d.log().Warn("Disk usage is approaching its limit", slog.String("detail", msg))
+	}
+}
+
+// measureDir returns the total size of the regular files below path. A missing
+// directory measures zero; unreadable entries are skipped.
+func (d *Daemon) measureDir(path string) int64 {
+	size, err := dirSize(path)
+	if err != nil {
+		d.log().Warn("Failed to measure disk usage", logfields.Path(path), logfields.Error(err))
+	}
+	return size
+}
+
+func dirSize(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil //nolint:nilerr // file removed while walking
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// diskUsageStatus compares usage against daemon.storage.limits and describes the
+// directories at or above the warning threshold.
+func (d *Daemon) diskUsageStatus(usage *DiskUsage) (string, bool) {
+	limits := d.config.Daemon.Storage.Limits
+	ratio := limits.WarnRatio()
+	var warnings []string
+	for _, dir := range []struct {
+		name  string
+		used  int64
+		limit int64
+	}{
+		{"workspace", usage.WorkspaceBytes, limits.WorkspaceBytes()},
+		{"output", usage.OutputBytes, limits.OutputBytes()},
+	} {
+		if dir.limit <= 0 || float64(dir.used) < ratio*float64(dir.limit) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s uses %s of %s (%.0f%%)",
+			dir.name, formatBytes(dir.used), formatBytes(dir.limit), 100*float64(dir.used)/float64(dir.limit)))
+	}
+	if len(warnings) == 0 {
+		return fmt.Sprintf("workspace %s, output %s", formatBytes(usage.WorkspaceBytes), formatBytes(usage.OutputBytes)), false
+	}
+	sort.Strings(warnings)
+	msg := warnings[0]
+	for _, w := range warnings[1:] {
+		msg += "; " + w
+	}
+	return msg, true
+}
+
+// formatBytes renders a size with a binary unit ("1.5 GiB").
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func writeSizedFile(t *testing.T, path string, size int) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+}
+
+func TestDaemon_RefreshDiskUsage(t *testing.T) {
+	root := t.TempDir()
+	cacheDir := filepath.Join(root, "repos")
+	outputDir := filepath.Join(root, "site")
+	writeSizedFile(t, filepath.Join(cacheDir, "working", "api", "README.md"), 3000)
+	writeSizedFile(t, filepath.Join(cacheDir, "working", "api", ".git", "objects", "pack"), 5000)
+	writeSizedFile(t, filepath.Join(cacheDir, "working", "web", "docs", "index.md"), 1000)
+	writeSizedFile(t, filepath.Join(cacheDir, "remote-heads.json"), 100)
+	writeSizedFile(t, filepath.Join(outputDir, "public", "index.html"), 2000)
+
+	d := &Daemon{
+		config: &config.Config{Daemon: &config.DaemonConfig{Storage: config.StorageConfig{
+			RepoCacheDir: cacheDir,
+			OutputDir:    outputDir,
+			Limits:       &config.StorageLimitsConfig{OutputMB: 1},
+		}}},
+		metrics: NewMetricsCollector(),
+	}
+	assert.Nil(t, d.DiskUsage())
+	assert.Equal(t, "Disk usage not measured yet", d.checkDiskUsageHealth().Message)
+
+	d.refreshDiskUsage()
+	usage := d.DiskUsage()
+	require.NotNil(t, usage)
+	assert.Equal(t, int64(9100), usage.WorkspaceBytes)
+	assert.Equal(t, int64(2000), usage.OutputBytes)
+	assert.Equal(t, map[string]int64{"api": 8000, "web": 1000}, usage.Repositories)
+	assert.Equal(t, int64(9100), d.metrics.GetSnapshot().Gauges["disk_workspace_bytes"])
+
+	check := d.checkDiskUsageHealth()
+	assert.Equal(t, HealthStatusHealthy, check.Status)
+	assert.Contains(t, check.Message, "output 2.0 KiB")
+
+	// Grow the output past 80% of its 1 MiB limit.
+	writeSizedFile(t, filepath.Join(outputDir, "public", "large.bin"), 900<<10)
+	d.refreshDiskUsage()
+	check = d.checkDiskUsageHealth()
+	assert.Equal(t, HealthStatusDegraded, check.Status)
+	assert.True(t, strings.HasPrefix(check.Message, "Disk usage approaching limit: output uses 902.0 KiB of 1.0 MiB (88%)"), check.Message)
+}
+
+func TestDaemon_RefreshDiskUsage_MissingDirectories(t *testing.T) {
+	root := t.TempDir()
+	d := &Daemon{config: &config.Config{Daemon: &config.DaemonConfig{Storage: config.StorageConfig{
+		RepoCacheDir: filepath.Join(root, "repos"),
+		OutputDir:    filepath.Join(root, "site"),
+	}}}}
+
+	d.refreshDiskUsage()
+	usage := d.DiskUsage()
+	require.NotNil(t, usage)
+	assert.Zero(t, usage.WorkspaceBytes)
+	assert.Zero(t, usage.OutputBytes)
+	assert.Empty(t, usage.Repositories)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 GiB", formatBytes(2<<30))
+}
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 type EventEmitter struct {
 	store      eventstore.Store
 	projection *eventstore.BuildHistoryProjection
+	feed       *buildEventFeed
 	daemon     *Daemon // Reference back to daemon for hooks like link verification
 }
 
@@ -22,6 +24,7 @@ func NewEventEmitter(store eventstore.Store, projection *eventstore.BuildHistory
 	return &EventEmitter{
 		store:      store,
 		projection: projection,
+		feed:       newBuildEventFeed(),
 	}
 }
 
@@ -42,6 +45,11 @@ func (e *EventEmitter) EmitEvent(ctx context.Context, event eventstore.Event) er
 		e.projection.Apply(event)
 	}
 
+	// Notify live subscribers (control API event streams)
+	if e.feed != nil {
+		e.feed.publish(event)
+	}
+
 	return nil
 }
 
@@ -78,6 +86,19 @@ func (e *EventEmitter) EmitBuildReport(ctx context.Context, buildID string, repo
 		return nil
 	}
 
+	// Store the complete report first so it is retrievable once the summary is visible.
+	document, err := json.Marshal(report.SanitizedCopy())
+	if err != nil {
+		return fmt.Errorf("failed to encode build report: %w", err)
+	}
+	stored, err := eventstore.NewBuildReportStored(buildID, document)
+	if err != nil {
+		return err
+	}
+	if err := e.EmitEvent(ctx, stored); err != nil {
+		return err
+	}
+
 	reportData := convertBuildReportToEventData(report)
 
 	event, err := eventstore.NewBuildReportGenerated(buildID, reportData)
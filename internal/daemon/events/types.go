@@ -13,6 +13,7 @@ type BuildRequested struct {
 	RepoURL     string
 	Branch      string
 	Snapshot    map[string]string // optional: repoURL -> commitSHA
+	Scope       []string          // optional: repositories to fetch; others reuse their working copies
 	RequestedAt time.Time
 }
 
@@ -21,10 +22,13 @@ type BuildRequested struct {
 // This is an orchestration event used by the daemon's in-process control flow.
 // It is not durable and is not written to internal/eventstore.
 type RepoUpdateRequested struct {
-	JobID       string
-	Immediate   bool
-	RepoURL     string
-	Branch      string
+	JobID     string
+	Immediate bool
+	RepoURL   string
+	Branch    string
+	// CommitSHA, when set, is the commit to build (e.g. the head of a push event)
+	// instead of whatever the branch points at when the update runs.
+	CommitSHA   string
 	RequestedAt time.Time
 }
 
@@ -40,6 +44,7 @@ type WebhookReceived struct {
 	ForgeName    string
 	RepoFullName string
 	Branch       string
+	CommitSHA    string
 	ChangedFiles []string
 	ReceivedAt   time.Time
 }
@@ -92,6 +97,7 @@ type BuildNow struct {
 	LastRepoURL   string
 	LastBranch    string
 	Snapshot      map[string]string // optional: repoURL -> commitSHA
+	Scope         []string          // optional: set only when every coalesced request was scoped
 	FirstRequest  time.Time
 	LastRequest   time.Time
 	DebounceCause string // "quiet" or "max_delay" or "after_running"
@@ -41,7 +41,7 @@ type HealthResponse struct {
 func (d *Daemon) PerformHealthChecks() *HealthResponse {
 	startTime := time.Now()
 
-	checks := make([]HealthCheck, 0, 5)
+	checks := make([]HealthCheck, 0, 6)
 	overallStatus := HealthStatusHealthy
 
 	// Check daemon status
@@ -81,6 +81,13 @@ func (d *Daemon) PerformHealthChecks() *HealthResponse {
 		}
 	}
 
+	// Check disk usage against daemon.storage.limits
+	diskCheck := d.checkDiskUsageHealth()
+	checks = append(checks, diskCheck)
+	if diskCheck.Status != HealthStatusHealthy && overallStatus == HealthStatusHealthy {
+		overallStatus = HealthStatusDegraded
+	}
+
 	// Record health check metrics
 	d.metrics.IncrementCounter("health_checks_total")
 	d.metrics.RecordHistogram("health_check_duration_seconds", time.Since(startTime).Seconds())
@@ -264,6 +271,33 @@ func (d *Daemon) checkStorageHealth() HealthCheck {
 	return check
 }
 
+// checkDiskUsageHealth reports the latest disk usage measurement, degraded once the
+// repository cache or output directory approaches its configured limit.
+func (d *Daemon) checkDiskUsageHealth() HealthCheck {
+	start := time.Now()
+
+	check := HealthCheck{
+		Name:        "disk_usage",
+		Status:      HealthStatusHealthy,
+		LastChecked: time.Now(),
+		Duration:    time.Since(start),
+	}
+
+	usage := d.DiskUsage()
+	if usage == nil || d.config == nil || d.config.Daemon == nil {
+		check.Message = "Disk usage not measured yet"
+		return check
+	}
+	msg, warn := d.diskUsageStatus(usage)
+	if warn {
+		check.Status = HealthStatusDegraded
+		check.Message = "Disk usage approaching limit: " + msg
+	} else {
+		check.Message = "Disk usage within limits: " + msg
+	}
+	return check
+}
+
 // EnhancedHealthHandler serves detailed health information.
 func (d *Daemon) EnhancedHealthHandler(w http.ResponseWriter, _ *http.Request) {
 	health := d.PerformHealthChecks()
@@ -8,6 +8,9 @@ import (
 	prom "github.com/prometheus/client_golang/prometheus"
 	promcollect "github.com/prometheus/client_golang/prometheus/collectors"
 
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 	m "git.home.luguber.info/inful/docbuilder/internal/metrics"
 )
 
@@ -29,6 +32,20 @@ var (
 		}
 		return float64(atomic.LoadInt32(&defaultDaemonInstance.queueLength))
 	})
+	// Panics recovered by queue workers, HTTP handlers and stages (see internal/crash).
+	daemonPanicsTotal = prom.NewCounterFunc(prom.CounterOpts{Namespace: "docbuilder", Name: "panics_total", Help: "Panics recovered without stopping the daemon"}, func() float64 {
+		return float64(crash.Default().Panics())
+	})
+	// Requests rejected by daemon.http.rate_limit, by server (webhook|admin).
+	httpThrottledTotal = prom.NewCounterVec(prom.CounterOpts{Namespace: "docbuilder", Name: "http_throttled_requests_total", Help: "Requests rejected with 429 by rate limiting"}, []string{"server"})
+	// CDN purge hook runs after published builds, by hook and result (success|failed|dry_run).
+	cdnPurgeRunsTotal = prom.NewCounterVec(prom.CounterOpts{Namespace: "docbuilder", Name: "cdn_purge_runs_total", Help: "CDN purge hook runs after published builds"}, []string{"hook", "result"})
+	cdnPurgeURLsTotal = prom.NewCounterVec(prom.CounterOpts{Namespace: "docbuilder", Name: "cdn_purge_urls_total", Help: "Changed page URLs purged by CDN purge hooks"}, []string{"hook"})
+	cdnPurgeDuration  = prom.NewHistogramVec(prom.HistogramOpts{Namespace: "docbuilder", Name: "cdn_purge_duration_seconds", Help: "Duration of CDN purge hook runs", Buckets: prom.DefBuckets}, []string{"hook"})
+	// Disk usage measured after each build (see disk_usage.go).
+	diskWorkspaceBytes = prom.NewGauge(prom.GaugeOpts{Namespace: "docbuilder", Name: "workspace_bytes", Help: "Size of the repository cache directory"})
+	diskOutputBytes    = prom.NewGauge(prom.GaugeOpts{Namespace: "docbuilder", Name: "output_bytes", Help: "Size of the output directory"})
+	diskRepoCloneBytes = prom.NewGaugeVec(prom.GaugeOpts{Namespace: "docbuilder", Name: "repository_clone_bytes", Help: "Size of each repository working copy"}, []string{"repository"})
 	// Last build snapshot gauges.
 	daemonLastBuildRenderedPages = prom.NewGaugeFunc(prom.GaugeOpts{Namespace: "docbuilder", Name: "daemon_last_build_rendered_pages", Help: "Pages rendered in most recent completed build"}, func() float64 {
 		return float64(atomic.LoadInt64(&lastRenderedPages))
@@ -43,7 +60,9 @@ var registerMetricsOnce sync.Once
 // registerBaseCollectors registers base collectors once.
 func registerBaseCollectors() {
 	registerMetricsOnce.Do(func() {
-		promRegistry.MustRegister(daemonBuildsTotal, daemonBuildsFailedTotal)
+		promRegistry.MustRegister(daemonBuildsTotal, daemonBuildsFailedTotal, daemonPanicsTotal, httpThrottledTotal)
+		promRegistry.MustRegister(cdnPurgeRunsTotal, cdnPurgeURLsTotal, cdnPurgeDuration)
+		promRegistry.MustRegister(diskWorkspaceBytes, diskOutputBytes, diskRepoCloneBytes)
 		promRegistry.MustRegister(daemonActiveJobsGauge, daemonQueueLengthGauge, daemonLastBuildRenderedPages, daemonLastBuildRepositories)
 		promRegistry.MustRegister(promcollect.NewGoCollector(), promcollect.NewProcessCollector(promcollect.ProcessCollectorOpts{}))
 	})
@@ -78,6 +97,35 @@ func updateDaemonPromMetrics(d *Daemon) {
 	}
 }
 
+// recordPurgeMetrics exports the purge hook runs of a build report.
+func recordPurgeMetrics(results []models.PurgeResult) {
+	for _, r := range results {
+		result := "success"
+		switch {
+		case r.Error != "":
+			result = "failed"
+		case r.DryRun:
+			result = "dry_run"
+		}
+		cdnPurgeRunsTotal.WithLabelValues(r.Hook, result).Inc()
+		cdnPurgeDuration.WithLabelValues(r.Hook).Observe(r.Duration.Seconds())
+		if result == "success" {
+			cdnPurgeURLsTotal.WithLabelValues(r.Hook).Add(float64(r.URLs))
+		}
+	}
+}
+
+// recordDiskUsageMetrics exports a disk usage measurement. Repositories no longer in
+// the workspace drop out of the per-repository gauge.
+func recordDiskUsageMetrics(usage *DiskUsage) {
+	diskWorkspaceBytes.Set(float64(usage.WorkspaceBytes))
+	diskOutputBytes.Set(float64(usage.OutputBytes))
+	diskRepoCloneBytes.Reset()
+	for name, size := range usage.Repositories {
+		diskRepoCloneBytes.WithLabelValues(name).Set(float64(size))
+	}
+}
+
 var (
 	lastCompleted     int64
 	lastFailed        int64
@@ -88,8 +136,25 @@ var (
 func atomicLoadInt64(p *int64) int64     { return atomic.LoadInt64(p) }
 func atomicStoreInt64(p *int64, v int64) { atomic.StoreInt64(p, v) }
 
+var registerPageViewsOnce sync.Once
+
+// registerPageViewCollector exports page view counters on the Prometheus endpoint.
+// Only the first registered counter set is exported (one daemon per process).
+func registerPageViewCollector(views *analytics.PageViews) {
+	registerPageViewsOnce.Do(func() {
+		promRegistry.MustRegister(analytics.NewCollector(views))
+	})
+}
+
 // prometheusOptionalHandler returns handler and periodically syncs daemon metrics.
 func prometheusOptionalHandler() http.Handler {
 	registerBaseCollectors()
 	return m.HTTPHandler(promRegistry)
 }
+
+// promThrottleRecorder counts rate-limited requests on the Prometheus endpoint.
+type promThrottleRecorder struct{}
+
+func (promThrottleRecorder) RecordThrottled(server string) {
+	httpThrottledTotal.WithLabelValues(server).Inc()
+}
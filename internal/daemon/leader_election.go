@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/leader"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/server/responses"
+)
+
+// newLeaderElector builds the elector for an enabled daemon.leader_election.
+func (d *Daemon) newLeaderElector(cfg *config.LeaderElectionConfig) (*leader.Elector, error) {
+	identity := cfg.Identity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("leader election identity: %w", err)
+		}
+		identity = hostname
+	}
+
+	var lock leader.Lock
+	switch cfg.Backend {
+	case config.LeaderElectionFile:
+		lock = leader.NewFileLock(cfg.Path)
+	default:
+		k8sLock, err := leader.NewKubernetesLock(cfg.Namespace, cfg.LeaseNameOrDefault())
+		if err != nil {
+			return nil, err
+		}
+		lock = k8sLock
+	}
+
+	return leader.NewElector(leader.Config{
+		Lock:             lock,
+		Identity:         identity,
+		LeaseDuration:    cfg.LeaseDurationValue(),
+		RenewDeadline:    cfg.RenewDeadlineValue(),
+		RetryPeriod:      cfg.RetryPeriodValue(),
+		OnStartedLeading: d.onStartedLeading,
+		OnStoppedLeading: d.onStoppedLeading,
+		Logger:           d.log(),
+	})
+}
+
+// isLeader reports whether this replica may run discovery and builds. Without leader
+// election every daemon is its own leader.
+func (d *Daemon) isLeader() bool {
+	return d.leader == nil || d.leader.IsLeader()
+}
+
+// LeaderStatus implements handlers.LeaderStatusProvider.
+func (d *Daemon) LeaderStatus() *responses.LeaderStatus {
+	if d.leader == nil {
+		return nil
+	}
+	status := d.leader.Status()
+	out := &responses.LeaderStatus{
+		Identity:    status.Identity,
+		Leader:      status.Leader,
+		IsLeader:    status.IsLeader,
+		Transitions: status.Transitions,
+	}
+	if !status.RenewTime.IsZero() {
+		out.RenewTime = &status.RenewTime
+	}
+	return out
+}
+
+// onStartedLeading catches up on work a previous leader may not have finished:
+// forge discovery (which requests a build) or, for explicit repositories, a build.
+func (d *Daemon) onStartedLeading() {
+	ctx := context.Background()
+	if len(d.config.Forges) > 0 && d.discoveryRunner != nil {
+		d.goWorker("leader_discovery", func() {
+			workCtx, cancel := d.stopAwareContext(ctx)
+			defer cancel()
+			d.discoveryRunner.SafeRun(workCtx, func() bool { return d.GetStatus() == StatusRunning && d.isLeader() })
+		})
+		return
+	}
+	if len(d.config.Repositories) == 0 || d.orchestrationBus == nil {
+		return
+	}
+
+	jobID := fmt.Sprintf("leader-%d", time.Now().UnixNano())
+	d.goWorker("leader_build", func() {
+		if err := d.publishOrchestrationEvent(ctx, events.BuildRequested{
+			JobID:       jobID,
+			Immediate:   true,
+			Reason:      "leader election",
+			RequestedAt: time.Now(),
+		}); err != nil {
+			d.log().Warn("Failed to request build after becoming leader",
+				logfields.JobID(jobID),
+				logfields.Error(err))
+		}
+	})
+}
+
+// onStoppedLeading is called when the lease is lost. Builds already running finish;
+// new discovery and build requests are ignored until the lease is regained.
+func (d *Daemon) onStoppedLeading() {
+	d.log().Warn("Lost leadership; discovery and builds pause on this replica",
+		slog.Int("active_jobs", d.GetActiveJobs()))
+}
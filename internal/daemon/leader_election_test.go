@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/leader"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderElection_DisabledDaemonIsLeader(t *testing.T) {
+	d := newMaintenanceTestDaemon(t)
+	require.True(t, d.isLeader())
+	require.Nil(t, d.LeaderStatus())
+}
+
+func TestLeaderElection_OnlyLeaderBuilds(t *testing.T) {
+	leasePath := filepath.Join(t.TempDir(), "leader.json")
+	electionCfg := &config.LeaderElectionConfig{
+		Enabled:       true,
+		Backend:       config.LeaderElectionFile,
+		Path:          leasePath,
+		LeaseDuration: "5s",
+		RenewDeadline: "3s",
+		RetryPeriod:   "20ms",
+	}
+
+	// Another replica holds the lease.
+	_, held, err := leader.NewFileLock(leasePath).TryAcquireOrRenew(t.Context(), "replica-a", 5*time.Second, time.Now())
+	require.NoError(t, err)
+	require.True(t, held)
+
+	d := newMaintenanceTestDaemon(t)
+	electionCfg.Identity = "replica-b"
+	d.leader, err = d.newLeaderElector(electionCfg)
+	require.NoError(t, err)
+
+	requested, unsub := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+	defer unsub()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.leader.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.Eventually(t, func() bool { return d.LeaderStatus().Leader == "replica-a" }, time.Second, 10*time.Millisecond)
+	status := d.LeaderStatus()
+	require.Equal(t, "replica-b", status.Identity)
+	require.False(t, status.IsLeader)
+
+	d.enqueueOrchestratedBuild(events.BuildNow{JobID: "job-follower"})
+	require.Zero(t, atomic.LoadInt32(&d.queueLength))
+	require.Empty(t, d.TriggerBuild())
+
+	// The leader releases the lease; this replica takes over and requests a catch-up build.
+	require.NoError(t, leader.NewFileLock(leasePath).Release(t.Context(), "replica-a"))
+	require.Eventually(t, d.isLeader, time.Second, 10*time.Millisecond)
+
+	select {
+	case evt := <-requested:
+		require.Equal(t, "leader election", evt.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for catch-up build request")
+	}
+
+	d.enqueueOrchestratedBuild(events.BuildNow{JobID: "job-leader"})
+	require.Equal(t, int32(1), atomic.LoadInt32(&d.queueLength))
+}
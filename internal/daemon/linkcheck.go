@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/linkverify"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// External link check files, kept in the daemon state directory.
+const (
+	LinkCheckResultsFile = "linkcheck.json"
+	BrokenLinksPageFile  = "broken-links.html"
+)
+
+// linkCheckRunTimeout bounds a single run of the external link check.
+const linkCheckRunTimeout = 2 * time.Hour
+
+// newLinkChecker creates the external link checker for daemon.link_check.
+func newLinkChecker(cfg *config.LinkCheckConfig, stateDir string) *linkverify.ExternalChecker {
+	return linkverify.NewExternalChecker(linkverify.ExternalCheckerOptions{
+		MaxConcurrent:  cfg.Concurrency(),
+		PerHost:        cfg.HostConcurrency(),
+		RequestTimeout: cfg.Timeout(),
+		CacheTTL:       cfg.CacheDuration(),
+		Ignore:         cfg.Ignore,
+		ResultsPath:    filepath.Join(stateDir, LinkCheckResultsFile),
+		PagePath:       filepath.Join(stateDir, BrokenLinksPageFile),
+	})
+}
+
+// runLinkCheck checks the external links of the published site. It runs on the
+// daemon.link_check interval and after the first successful build; only the leader
+// checks, so replicas do not multiply the requests sent to external hosts.
+func (d *Daemon) runLinkCheck(ctx context.Context) {
+	if d.linkChecker == nil || d.GetStatus() != StatusRunning {
+		return
+	}
+	if !d.isLeader() {
+		d.log().Debug("Skipping external link check: not the leader")
+		return
+	}
+	if !d.linkCheckRunning.CompareAndSwap(false, true) {
+		return
+	}
+	defer d.linkCheckRunning.Store(false)
+	publicDir, ok := resolvePublicDirForVerification(d.config.Daemon.Storage.OutputDir)
+	if !ok {
+		d.log().Debug("Skipping external link check: no published site yet")
+		return
+	}
+
+	runCtx, cancel := d.stopAwareContext(ctx)
+	defer cancel()
+	runCtx, cancelTimeout := context.WithTimeout(runCtx, linkCheckRunTimeout)
+	defer cancelTimeout()
+
+	start := time.Now()
+	report, err := d.linkChecker.Run(runCtx, publicDir, d.config.Hugo.BaseURL)
+	if err != nil {
+		d.log().Warn("External link check failed", logfields.Error(err))
+		return
+	}
+	if d.metrics != nil {
+		d.metrics.RecordHistogram("link_check_duration_seconds", time.Since(start).Seconds())
+		d.metrics.SetGauge("link_check_broken_links", int64(report.Broken))
+	}
+	d.log().Info("External link check completed",
+		slog.Int("pages", report.Pages),
+		slog.Int("links", report.Links),
+		slog.Int("checked", report.Checked),
+		slog.Int("broken", report.Broken),
+		slog.Duration("duration", time.Since(start)))
+}
+
+// LinkCheckHandler serves the results of the last external link check
+// (GET /api/linkcheck). With ?broken=true only broken links are listed.
+func (d *Daemon) LinkCheckHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	var report *linkverify.ExternalLinkReport
+	if d.linkChecker != nil {
+		report = d.linkChecker.Last()
+	}
+	if report == nil {
+		adapter.WriteErrorResponse(w, r, ferrors.NotFoundError("external link report").
+			WithContext("hint", "the first check runs after the first successful build").
+			Build())
+		return
+	}
+	if r.URL.Query().Get("broken") == "true" {
+		filtered := *report
+		filtered.Results = report.BrokenResults()
+		report = &filtered
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode external link report").Build())
+	}
+}
+
+// BrokenLinksPageHandler serves the broken links page written by the last external
+// link check (GET /reports/broken-links).
+func (d *Daemon) BrokenLinksPageHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	var page []byte
+	err := os.ErrNotExist
+	if d.linkChecker != nil {
+		// #nosec G304 -- path is derived from the daemon state directory
+		page, err = os.ReadFile(d.linkChecker.PagePath())
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			adapter.WriteErrorResponse(w, r, ferrors.NotFoundError("broken links page").
+				WithContext("hint", "the first check runs after the first successful build").
+				Build())
+			return
+		}
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryFileSystem, "failed to read broken links page").Build())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
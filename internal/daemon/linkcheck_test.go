@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/linkverify"
+)
+
+func TestDaemon_LinkCheckHandlers(t *testing.T) {
+	stateDir := t.TempDir()
+	d := &Daemon{linkChecker: newLinkChecker(&config.LinkCheckConfig{Enabled: true}, stateDir)}
+
+	rec := httptest.NewRecorder()
+	d.LinkCheckHandler(rec, httptest.NewRequest(http.MethodGet, "/api/linkcheck", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	rec = httptest.NewRecorder()
+	d.BrokenLinksPageHandler(rec, httptest.NewRequest(http.MethodGet, "/reports/broken-links", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Results of an earlier run are reloaded from the state directory.
+	saved := linkverify.ExternalLinkReport{
+		FinishedAt: time.Now().UTC(),
+		Links:      2,
+		Broken:     1,
+		Results: []linkverify.ExternalLinkResult{
+			{URL: "https://gone.example.com/", Status: 404, Broken: true, Pages: []string{"/guide/"}},
+			{URL: "https://ok.example.com/", Status: 200, Pages: []string{"/"}},
+		},
+	}
+	data, err := json.Marshal(saved)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, LinkCheckResultsFile), data, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, BrokenLinksPageFile), []byte("<h1>Broken links</h1>"), 0o600))
+	d.linkChecker = newLinkChecker(&config.LinkCheckConfig{Enabled: true}, stateDir)
+
+	rec = httptest.NewRecorder()
+	d.LinkCheckHandler(rec, httptest.NewRequest(http.MethodGet, "/api/linkcheck?broken=true", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got linkverify.ExternalLinkReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, 2, got.Links)
+	require.Len(t, got.Results, 1)
+	require.Equal(t, "https://gone.example.com/", got.Results[0].URL)
+
+	rec = httptest.NewRecorder()
+	d.BrokenLinksPageHandler(rec, httptest.NewRequest(http.MethodGet, "/reports/broken-links", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), "Broken links")
+}
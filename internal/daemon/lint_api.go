@@ -0,0 +1,200 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/lint"
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
+)
+
+// maxLintRequestBytes bounds the body of a lint request.
+const maxLintRequestBytes = 5 << 20
+
+// defaultLintContentPath is the file name of linted content sent without a path.
+const defaultLintContentPath = "index.md"
+
+// LintRequest is the body of POST /api/lint. With content, the markdown is linted as
+// the file at path; the rules of repository apply when it is set. Without content,
+// path (default: the whole working copy) is linted in the working copy of repository.
+type LintRequest struct {
+	Content    string `json:"content,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// LintResponse lists the issues found by a lint request. File paths are relative to
+// the repository root, or are the request path for linted content.
+type LintResponse struct {
+	Repository   string           `json:"repository,omitempty"`
+	Path         string           `json:"path"`
+	FilesTotal   int              `json:"files_total"`
+	ErrorCount   int              `json:"error_count"`
+	WarningCount int              `json:"warning_count"`
+	Issues       []lint.JSONIssue `json:"issues"`
+}
+
+// LintHandler lints markdown sent in the request, or a path of a repository's working
+// copy, with the configured rules (POST /api/lint).
+func (d *Daemon) LintHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodPost {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodPost).
+			Build())
+		return
+	}
+	var req LintRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLintRequestBytes)).Decode(&req); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid lint request").
+			WithContext("error", err.Error()).
+			Build())
+		return
+	}
+	resp, err := d.lint(req)
+	if err != nil {
+		adapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode lint result").Build())
+	}
+}
+
+// lint runs the linter for a request.
+func (d *Daemon) lint(req LintRequest) (*LintResponse, error) {
+	if req.Content == "" && req.Repository == "" {
+		return nil, ferrors.ValidationError("lint request needs content or a repository").Build()
+	}
+	target := filepath.FromSlash(req.Path)
+	if target == "" {
+		target = "."
+		if req.Content != "" {
+			target = defaultLintContentPath
+		}
+	}
+	if !filepath.IsLocal(target) {
+		return nil, ferrors.ValidationError("lint path must be relative and stay within the repository").
+			WithContext("path", req.Path).
+			Build()
+	}
+	if req.Content != "" && !lint.IsDocFile(target) {
+		return nil, ferrors.ValidationError("lint content path must be a markdown file").
+			WithContext("path", req.Path).
+			Build()
+	}
+
+	var repo *config.Repository
+	if req.Repository != "" {
+		for _, candidate := range d.currentReposForOrchestratedBuild() {
+			if candidate.Name == req.Repository {
+				repo = &candidate
+				break
+			}
+		}
+		if repo == nil {
+			return nil, ferrors.NotFoundError("repository").WithContext("repository", req.Repository).Build()
+		}
+	}
+
+	// Content is linted in a scratch directory; repositories in their working copy.
+	root := ""
+	if req.Content == "" {
+		root = filepath.Join(d.workspaceDir(), repo.Name)
+		if _, err := os.Stat(filepath.Join(root, target)); err != nil {
+			return nil, ferrors.NotFoundError("lint path").
+				WithContext("repository", repo.Name).
+				WithContext("path", filepath.ToSlash(target)).
+				WithContext("hint", "the repository is linted in its working copy, which exists after it was built").
+				Build()
+		}
+	}
+	linter, err := d.newLinter(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *lint.Result
+	if req.Content != "" {
+		scratch, err := os.MkdirTemp("", "docbuilder-lint-*")
+		if err != nil {
+			return nil, ferrors.WrapError(err, ferrors.CategoryFileSystem, "failed to create lint directory").Build()
+		}
+		defer func() { _ = os.RemoveAll(scratch) }()
+		file := filepath.Join(scratch, target)
+		if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+			return nil, ferrors.WrapError(err, ferrors.CategoryFileSystem, "failed to create lint directory").Build()
+		}
+		if err := os.WriteFile(file, []byte(req.Content), 0o600); err != nil {
+			return nil, ferrors.WrapError(err, ferrors.CategoryFileSystem, "failed to write lint content").Build()
+		}
+		root = scratch
+		result, err = linter.LintFiles([]string{file})
+		if err != nil {
+			return nil, ferrors.WrapError(err, ferrors.CategoryInternal, "lint failed").Build()
+		}
+	} else {
+		result, err = linter.LintPath(filepath.Join(root, target))
+		if err != nil {
+			return nil, ferrors.WrapError(err, ferrors.CategoryInternal, "lint failed").Build()
+		}
+	}
+
+	resp := &LintResponse{
+		Repository:   req.Repository,
+		Path:         filepath.ToSlash(target),
+		FilesTotal:   result.FilesTotal,
+		ErrorCount:   result.ErrorCount(),
+		WarningCount: result.WarningCount(),
+		Issues:       make([]lint.JSONIssue, 0, len(result.Issues)),
+	}
+	for _, issue := range result.Issues {
+		path := issue.FilePath
+		if rel, relErr := filepath.Rel(root, path); relErr == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		resp.Issues = append(resp.Issues, lint.JSONIssue{
+			FilePath:    filepath.ToSlash(path),
+			Severity:    issue.Severity.String(),
+			Rule:        issue.Rule,
+			Message:     issue.Message,
+			Explanation: issue.Explanation,
+			Fix:         issue.Fix,
+			Line:        issue.Line,
+		})
+	}
+	return resp, nil
+}
+
+// newLinter returns a linter with the configured content policy and spellcheck, using
+// the settings of repo when it is set. Its dictionaries are read from its working copy.
+func (d *Daemon) newLinter(repo *config.Repository) (*lint.Linter, error) {
+	policy := d.config.Sanitize
+	var repoSpelling *config.RepositorySpellcheck
+	repoRoot := ""
+	if repo != nil {
+		policy = repo.SanitizePolicy(d.config.Sanitize)
+		repoSpelling = repo.Spellcheck
+		repoRoot = filepath.Join(d.workspaceDir(), repo.Name)
+		if _, err := os.Stat(repoRoot); err != nil && repoSpelling != nil {
+			// Not built yet: only the repository's words apply.
+			repoSpelling = &config.RepositorySpellcheck{Words: repoSpelling.Words}
+		}
+	}
+	spelling, err := lint.SpellDictionaryFromConfig(d.config.Spellcheck, filepath.Dir(d.configFilePath), repoSpelling, repoRoot)
+	if err != nil {
+		return nil, ferrors.WrapError(err, ferrors.CategoryConfig, "failed to load spellcheck dictionaries").Build()
+	}
+	return lint.NewLinter(&lint.Config{
+		Format:        "json",
+		ContentPolicy: sanitize.FromConfig(policy),
+		Spelling:      spelling,
+	}), nil
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func lintRequest(t *testing.T, d *Daemon, body string) (*httptest.ResponseRecorder, LintResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	d.LintHandler(rec, httptest.NewRequest(http.MethodPost, "/api/lint", strings.NewReader(body)))
+	var resp LintResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec, resp
+}
+
+func TestDaemon_LintHandler(t *testing.T) {
+	cacheDir := t.TempDir()
+	d := &Daemon{config: &config.Config{
+		Repositories: []config.Repository{{Name: "handbook", URL: "https://example.invalid/handbook.git", Paths: []string{"docs"}}},
+		Daemon:       &config.DaemonConfig{Storage: config.StorageConfig{RepoCacheDir: cacheDir}},
+		Spellcheck:   &config.SpellcheckConfig{Enabled: true},
+	}}
+
+	// Content is linted as a file at the given path.
+	rec, resp := lintRequest(t, d, `{"content": "# Guide\n\nThis is teh guide.\n", "path": "docs/Getting Started.md"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, 1, resp.FilesTotal)
+	require.Positive(t, resp.ErrorCount)
+	rules := map[string]bool{}
+	for _, issue := range resp.Issues {
+		require.Equal(t, "docs/Getting Started.md", issue.FilePath)
+		rules[issue.Rule] = true
+	}
+	require.True(t, rules["spelling"], "issues: %+v", resp.Issues)
+
+	// A path of a repository's working copy is linted in place.
+	docs := filepath.Join(cacheDir, "working", "handbook", "docs")
+	require.NoError(t, os.MkdirAll(docs, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(docs, "index.md"), []byte("# Home\n\nSee [setup](setup.md).\n"), 0o600))
+	rec, resp = lintRequest(t, d, `{"repository": "handbook", "path": "docs"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "handbook", resp.Repository)
+	require.Equal(t, 1, resp.FilesTotal)
+	var broken bool
+	for _, issue := range resp.Issues {
+		require.Equal(t, "docs/index.md", issue.FilePath)
+		broken = broken || issue.Rule == "broken-links"
+	}
+	require.True(t, broken, "issues: %+v", resp.Issues)
+
+	for body, status := range map[string]int{
+		`{}`:                                          http.StatusBadRequest,
+		`{"content": "# x", "path": "../x.md"}`:       http.StatusBadRequest,
+		`{"content": "# x", "path": "x.txt"}`:         http.StatusBadRequest,
+		`{"repository": "unknown"}`:                   http.StatusNotFound,
+		`{"repository": "handbook", "path": "guide"}`: http.StatusNotFound,
+	} {
+		rec, _ := lintRequest(t, d, body)
+		require.Equal(t, status, rec.Code, body)
+	}
+}
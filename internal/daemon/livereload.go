@@ -2,13 +2,17 @@ package daemon
 
 import (
 	"bufio"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"sync"
 	"time"
+
+	"golang.org/x/net/websocket"
 )
 
-// LiveReloadHub manages SSE clients for hash-change broadcasts.
+// LiveReloadHub manages SSE and WebSocket clients for change broadcasts.
 type LiveReloadHub struct {
 	mu       sync.RWMutex
 	nextID   int
@@ -20,14 +24,48 @@ type LiveReloadHub struct {
 
 type lrClient struct {
 	id   int
-	ch   chan string
+	ch   chan []byte
 	done chan struct{}
 }
 
+// lrMessage is the payload sent to clients. Paths lists the changed site paths
+// (pages as "/guide/", assets as "/css/main.css"); when empty, clients reload
+// whatever page they show.
+type lrMessage struct {
+	Hash  string   `json:"hash"`
+	Paths []string `json:"paths,omitempty"`
+}
+
 func NewLiveReloadHub(mc *MetricsCollector) *LiveReloadHub {
 	return &LiveReloadHub{clients: map[int]*lrClient{}, metrics: mc}
 }
 
+// addClient registers a client and returns the current hash message, if any.
+// It returns nil when the hub is shutting down.
+func (h *LiveReloadHub) addClient() (*lrClient, []byte) {
+	client := &lrClient{ch: make(chan []byte, 8), done: make(chan struct{})}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil, nil
+	}
+	client.id = h.nextID
+	h.nextID++
+	h.clients[client.id] = client
+	current := h.lastHash
+	count := len(h.clients)
+	h.mu.Unlock()
+	if h.metrics != nil {
+		h.metrics.IncrementCounter("livereload_connections_total")
+		h.metrics.SetGauge("livereload_clients", int64(count))
+	}
+	if current == "" {
+		return client, nil
+	}
+	msg, _ := json.Marshal(lrMessage{Hash: current})
+	return client, msg
+}
+
 // ServeHTTP implements the SSE endpoint at /livereload.
 func (h *LiveReloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.mu.RLock()
@@ -47,18 +85,10 @@ func (h *LiveReloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Register client
-	client := &lrClient{ch: make(chan string, 8), done: make(chan struct{})}
-	h.mu.Lock()
-	client.id = h.nextID
-	h.nextID++
-	h.clients[client.id] = client
-	current := h.lastHash
-	h.mu.Unlock()
-	if h.metrics != nil {
-		h.metrics.IncrementCounter("livereload_connections_total")
-	}
-	if h.metrics != nil {
-		h.metrics.SetGauge("livereload_clients", int64(len(h.clients)))
+	client, current := h.addClient()
+	if client == nil {
+		http.Error(w, "livereload shutting down", http.StatusServiceUnavailable)
+		return
 	}
 
 	// Initial comment / optional last hash event
@@ -67,8 +97,8 @@ func (h *LiveReloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		slog.Debug("livereload write", "error", err)
 		return
 	}
-	if current != "" {
-		if _, err := bw.WriteString("data: {\"hash\":\"" + current + "\"}\n\n"); err != nil {
+	if current != nil {
+		if _, err := bw.WriteString("data: " + string(current) + "\n\n"); err != nil {
 			slog.Debug("livereload write", "error", err)
 			return
 		}
@@ -103,8 +133,8 @@ func (h *LiveReloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			} else {
 				slog.Debug("livereload ping write", "error", writeErr)
 			}
-		case hash := <-client.ch:
-			if _, writeErr := bw.WriteString("data: {\"hash\":\"" + hash + "\"}\n\n"); writeErr == nil {
+		case msg := <-client.ch:
+			if _, writeErr := bw.WriteString("data: " + string(msg) + "\n\n"); writeErr == nil {
 				if flushErr := bw.Flush(); flushErr != nil {
 					slog.Debug("livereload broadcast flush", "error", flushErr)
 				}
@@ -116,6 +146,52 @@ func (h *LiveReloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// WebSocketHandler returns the WebSocket endpoint (served at /livereload/ws).
+// Messages are the same JSON documents as the SSE data lines.
+func (h *LiveReloadHub) WebSocketHandler() http.Handler {
+	// Accept any origin: the docs and LiveReload servers listen on different ports,
+	// and the stream only carries change notifications.
+	return websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   h.serveWebSocket,
+	}
+}
+
+func (h *LiveReloadHub) serveWebSocket(ws *websocket.Conn) {
+	client, current := h.addClient()
+	if client == nil {
+		return
+	}
+	defer h.removeClient(client.id)
+
+	// The client never sends data; a read error means it disconnected.
+	disconnected := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, ws)
+		close(disconnected)
+	}()
+
+	if current != nil {
+		if err := websocket.Message.Send(ws, string(current)); err != nil {
+			slog.Debug("livereload websocket write", "error", err)
+			return
+		}
+	}
+	for {
+		select {
+		case <-disconnected:
+			return
+		case <-client.done:
+			return
+		case msg := <-client.ch:
+			if err := websocket.Message.Send(ws, string(msg)); err != nil {
+				slog.Debug("livereload websocket write", "error", err)
+				return
+			}
+		}
+	}
+}
+
 func (h *LiveReloadHub) removeClient(id int) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -132,7 +208,15 @@ func (h *LiveReloadHub) removeClient(id int) {
 }
 
 // Broadcast new hash to all clients (drops clients whose channels are full / closed).
+// Clients reload whatever page they show.
 func (h *LiveReloadHub) Broadcast(hash string) {
+	h.BroadcastChanges(hash, nil)
+}
+
+// BroadcastChanges sends a new hash together with the changed site paths, so clients
+// only reload when their current page or one of its assets changed. A nil or empty
+// paths slice behaves like Broadcast.
+func (h *LiveReloadHub) BroadcastChanges(hash string, paths []string) {
 	h.mu.Lock()
 	if h.closed {
 		h.mu.Unlock()
@@ -148,10 +232,15 @@ func (h *LiveReloadHub) Broadcast(hash string) {
 		snapshot = append(snapshot, c)
 	}
 	h.mu.Unlock()
+	msg, err := json.Marshal(lrMessage{Hash: hash, Paths: paths})
+	if err != nil {
+		slog.Warn("livereload encode", "error", err)
+		return
+	}
 	dropped := 0
 	for _, c := range snapshot {
 		select {
-		case c.ch <- hash:
+		case c.ch <- msg:
 		default:
 			dropped++
 			h.removeClient(c.id)
@@ -165,7 +254,7 @@ func (h *LiveReloadHub) Broadcast(hash string) {
 			h.metrics.IncrementCounter("livereload_dropped_clients_total")
 		}
 	}
-	slog.Debug("livereload broadcast", "hash", hash, "clients", len(snapshot), "dropped", dropped)
+	slog.Debug("livereload broadcast", "hash", hash, "paths", len(paths), "clients", len(snapshot), "dropped", dropped)
 }
 
 // Shutdown closes all clients and prevents future broadcasts.
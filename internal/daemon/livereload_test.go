@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/net/websocket"
 )
 
 // TestLiveReload_InitialConnectReceivesNoReload ensures first event sets baseline but does not trigger reload logic client-side.
@@ -138,3 +140,47 @@ func TestLiveReload_DuplicateBroadcastIgnored(t *testing.T) {
 		}
 	}
 }
+
+// TestLiveReload_WebSocketReceivesChangedPaths ensures WebSocket clients get the hash and changed paths.
+func TestLiveReload_WebSocketReceivesChangedPaths(t *testing.T) {
+	hub := NewLiveReloadHub(NewMetricsCollector())
+	defer hub.Shutdown()
+
+	server := httptest.NewServer(hub.WebSocketHandler())
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(wsURL, "", "http://localhost/")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Wait for registration before broadcasting
+	deadline := time.Now().Add(time.Second)
+	for {
+		hub.mu.RLock()
+		n := len(hub.clients)
+		hub.mu.RUnlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("websocket client not registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	hub.BroadcastChanges("wshash", []string{"/guide/", "/css/main.css"})
+
+	if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("set deadline: %v", err)
+	}
+	var msg lrMessage
+	if err := websocket.JSON.Receive(conn, &msg); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if msg.Hash != "wshash" || len(msg.Paths) != 2 || msg.Paths[0] != "/guide/" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+}
@@ -0,0 +1,249 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// maxMaintenanceBodyBytes bounds the admin maintenance request body.
+const maxMaintenanceBodyBytes = 64 << 10
+
+// MaintenanceStatus describes the daemon's maintenance mode.
+type MaintenanceStatus struct {
+	Enabled bool       `json:"enabled"`
+	Since   *time.Time `json:"since,omitempty"`
+	Message string     `json:"message,omitempty"`
+	// HeldBuilds counts the build requests held since maintenance mode was entered.
+	HeldBuilds int `json:"held_builds"`
+	// FlushedJobID is the build requested for the held work when maintenance mode was left.
+	FlushedJobID string `json:"flushed_job_id,omitempty"`
+}
+
+// heldBuilds merges held build requests: one build runs when they are released, scoped
+// to the union of the held scopes (or unscoped when any held request was unscoped).
+type heldBuilds struct {
+	held   int
+	scope  map[string]struct{}
+	scoped bool
+}
+
+// add records a held build request.
+func (h *heldBuilds) add(scope []string) {
+	switch {
+	case len(scope) == 0:
+		h.scope = nil
+		h.scoped = false
+	case h.held == 0 || h.scoped:
+		if h.scope == nil {
+			h.scope = make(map[string]struct{}, len(scope))
+		}
+		for _, name := range scope {
+			h.scope[name] = struct{}{}
+		}
+		h.scoped = true
+	}
+	h.held++
+}
+
+// take returns the number of held requests and their merged scope, and clears them.
+func (h *heldBuilds) take() (int, []string) {
+	held := h.held
+	var scope []string
+	if h.scoped {
+		scope = slices.Sorted(maps.Keys(h.scope))
+	}
+	*h = heldBuilds{}
+	return held, scope
+}
+
+// maintenanceState holds the maintenance flag and the build requests held while it is set.
+type maintenanceState struct {
+	mu      sync.Mutex
+	enabled bool
+	since   time.Time
+	message string
+	heldBuilds
+}
+
+// hold records a build request. It returns false when maintenance mode is off.
+func (m *maintenanceState) hold(scope []string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.enabled {
+		return false
+	}
+	m.add(scope)
+	return true
+}
+
+// InMaintenance reports whether the daemon is in maintenance mode.
+func (d *Daemon) InMaintenance() bool {
+	d.maintenance.mu.Lock()
+	defer d.maintenance.mu.Unlock()
+	return d.maintenance.enabled
+}
+
+// MaintenanceBanner implements httpserver.MaintenanceStatus.
+func (d *Daemon) MaintenanceBanner() (string, bool) {
+	d.maintenance.mu.Lock()
+	defer d.maintenance.mu.Unlock()
+	return d.maintenance.message, d.maintenance.enabled
+}
+
+// GetMaintenanceStatus returns the current maintenance mode state.
+func (d *Daemon) GetMaintenanceStatus() MaintenanceStatus {
+	d.maintenance.mu.Lock()
+	defer d.maintenance.mu.Unlock()
+	status := MaintenanceStatus{
+		Enabled:    d.maintenance.enabled,
+		HeldBuilds: d.maintenance.held,
+	}
+	if d.maintenance.enabled {
+		since := d.maintenance.since
+		status.Since = &since
+		status.Message = d.maintenance.message
+	}
+	return status
+}
+
+// EnterMaintenance puts the daemon into maintenance mode. message replaces the banner;
+// an empty message uses daemon.maintenance.banner. Entering again only updates the banner.
+func (d *Daemon) EnterMaintenance(message string) {
+	if message == "" {
+		var cfg *config.MaintenanceConfig
+		if d.config != nil && d.config.Daemon != nil {
+			cfg = d.config.Daemon.Maintenance
+		}
+		message = cfg.BannerText()
+	}
+	d.maintenance.mu.Lock()
+	entered := !d.maintenance.enabled
+	if entered {
+		d.maintenance.enabled = true
+		d.maintenance.since = time.Now()
+	}
+	d.maintenance.message = message
+	d.maintenance.mu.Unlock()
+
+	if entered {
+		d.log().Info("Maintenance mode entered; builds are held and scheduled discovery is paused")
+	}
+}
+
+// ExitMaintenance leaves maintenance mode and requests one build for the requests held
+// while it was active. The returned status carries the number of held requests and the
+// job ID of the requested build (empty when nothing was held).
+func (d *Daemon) ExitMaintenance() MaintenanceStatus {
+	d.maintenance.mu.Lock()
+	if !d.maintenance.enabled {
+		d.maintenance.mu.Unlock()
+		return MaintenanceStatus{}
+	}
+	held, scope := d.maintenance.take()
+	d.maintenance.enabled = false
+	d.maintenance.since = time.Time{}
+	d.maintenance.message = ""
+	d.maintenance.mu.Unlock()
+
+	d.log().Info("Maintenance mode left", slog.Int("held_builds", held))
+	status := MaintenanceStatus{HeldBuilds: held}
+	if held > 0 {
+		status.FlushedJobID = d.flushHeldBuilds("maintenance", scope, held)
+	}
+	return status
+}
+
+// flushHeldBuilds requests one build for held build requests. reason names what held
+// them ("maintenance", "blackout") and prefixes the job ID.
+func (d *Daemon) flushHeldBuilds(reason string, scope []string, held int) string {
+	if d.orchestrationBus == nil {
+		return ""
+	}
+	jobID := fmt.Sprintf("%s-%d", reason, time.Now().UnixNano())
+	if err := d.publishOrchestrationEvent(context.Background(), events.BuildRequested{
+		JobID:       jobID,
+		Immediate:   true,
+		Reason:      reason,
+		Scope:       scope,
+		RequestedAt: time.Now(),
+	}); err != nil {
+		d.log().Warn("Failed to request build for held work",
+			logfields.JobID(jobID),
+			slog.String("reason", reason),
+			logfields.Error(err))
+		return ""
+	}
+	d.log().Info("Build requested for held work",
+		slog.String("reason", reason),
+		logfields.JobID(jobID),
+		slog.Int("held_builds", held),
+		slog.Any("scope", scope))
+	return jobID
+}
+
+// holdForMaintenance holds a debounced build while maintenance mode is active.
+func (d *Daemon) holdForMaintenance(evt events.BuildNow) bool {
+	if !d.maintenance.hold(evt.Scope) {
+		return false
+	}
+	d.log().Info("Build held for maintenance mode",
+		logfields.JobID(evt.JobID),
+		slog.String("reason", evt.LastReason),
+		slog.Any("scope", evt.Scope))
+	return true
+}
+
+// maintenanceRequest is the admin API body for POST /api/daemon/maintenance.
+type maintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// MaintenanceHandler serves the admin maintenance endpoint: GET returns the maintenance
+// state, POST enters ({"enabled":true}) or leaves ({"enabled":false}) maintenance mode.
+func (d *Daemon) MaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	var status MaintenanceStatus
+	switch r.Method {
+	case http.MethodGet:
+		status = d.GetMaintenanceStatus()
+	case http.MethodPost:
+		var req maintenanceRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxMaintenanceBodyBytes)).Decode(&req); err != nil {
+			adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid maintenance request body").
+				WithCause(err).
+				Build())
+			return
+		}
+		if req.Enabled {
+			d.EnterMaintenance(req.Message)
+			status = d.GetMaintenanceStatus()
+		} else {
+			status = d.ExitMaintenance()
+		}
+	default:
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET, POST").
+			Build())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode maintenance status").Build())
+	}
+}
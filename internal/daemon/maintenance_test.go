@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build/queue"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaintenanceTestDaemon(t *testing.T) *Daemon {
+	t.Helper()
+
+	bus := events.NewBus()
+	t.Cleanup(bus.Close)
+
+	d := &Daemon{
+		config: &config.Config{
+			Daemon: &config.DaemonConfig{Maintenance: &config.MaintenanceConfig{Banner: "Back soon"}},
+			Repositories: []config.Repository{
+				{Name: "repo-a", URL: "https://example.invalid/repo-a.git", Branch: "main", Paths: []string{"docs"}},
+				{Name: "repo-b", URL: "https://example.invalid/repo-b.git", Branch: "main", Paths: []string{"docs"}},
+			},
+		},
+		stopChan:         make(chan struct{}),
+		orchestrationBus: bus,
+		buildQueue:       queue.NewBuildQueue(10, 1, noOpBuilder{}),
+	}
+	d.status.Store(StatusRunning)
+	return d
+}
+
+func TestMaintenance_HoldsBuildsAndFlushesOnExit(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes [][]string
+		want   []string
+	}{
+		{name: "scopes are merged", scopes: [][]string{{"repo-b"}, {"repo-a"}}, want: []string{"repo-a", "repo-b"}},
+		{name: "unscoped request builds everything", scopes: [][]string{{"repo-a"}, nil, {"repo-b"}}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newMaintenanceTestDaemon(t)
+			requested, unsub := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+			defer unsub()
+
+			d.EnterMaintenance("")
+			message, active := d.MaintenanceBanner()
+			require.True(t, active)
+			require.Equal(t, "Back soon", message)
+
+			for i, scope := range tt.scopes {
+				d.enqueueOrchestratedBuild(events.BuildNow{JobID: fmt.Sprintf("job-%d", i), LastReason: "webhook", Scope: scope})
+			}
+			require.Zero(t, atomic.LoadInt32(&d.queueLength))
+			require.Equal(t, len(tt.scopes), d.GetMaintenanceStatus().HeldBuilds)
+
+			status := d.ExitMaintenance()
+			require.False(t, d.InMaintenance())
+			require.Equal(t, len(tt.scopes), status.HeldBuilds)
+			require.NotEmpty(t, status.FlushedJobID)
+
+			select {
+			case evt := <-requested:
+				require.Equal(t, status.FlushedJobID, evt.JobID)
+				require.Equal(t, "maintenance", evt.Reason)
+				require.True(t, evt.Immediate)
+				require.Equal(t, tt.want, evt.Scope)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for flushed build request")
+			}
+
+			// Builds are enqueued again once maintenance mode is left.
+			d.enqueueOrchestratedBuild(events.BuildNow{JobID: "job-after"})
+			require.Equal(t, int32(1), atomic.LoadInt32(&d.queueLength))
+		})
+	}
+}
+
+func TestMaintenance_ExitWithoutHeldBuilds(t *testing.T) {
+	d := newMaintenanceTestDaemon(t)
+	requested, unsub := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+	defer unsub()
+
+	d.EnterMaintenance("Upgrading")
+	status := d.ExitMaintenance()
+	require.Zero(t, status.HeldBuilds)
+	require.Empty(t, status.FlushedJobID)
+
+	select {
+	case evt := <-requested:
+		t.Fatalf("unexpected build request: %+v", evt)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMaintenance_PausesScheduledSync(t *testing.T) {
+	d := newMaintenanceTestDaemon(t)
+	requested, unsub := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+	defer unsub()
+
+	d.EnterMaintenance("")
+	d.runScheduledSyncTick(context.Background(), "0 */4 * * *")
+
+	select {
+	case evt := <-requested:
+		t.Fatalf("unexpected scheduled build request during maintenance: %+v", evt)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMaintenanceHandler(t *testing.T) {
+	d := newMaintenanceTestDaemon(t)
+
+	serve := func(method, body string) (int, MaintenanceStatus) {
+		rec := httptest.NewRecorder()
+		d.MaintenanceHandler(rec, httptest.NewRequest(method, "/api/daemon/maintenance", strings.NewReader(body)))
+		var status MaintenanceStatus
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec.Code, status
+	}
+
+	code, status := serve(http.MethodPost, `{"enabled":true,"message":"Upgrading storage"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Enabled)
+	require.NotNil(t, status.Since)
+	require.Equal(t, "Upgrading storage", status.Message)
+
+	d.enqueueOrchestratedBuild(events.BuildNow{JobID: "job-1"})
+	code, status = serve(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Enabled)
+	require.Equal(t, 1, status.HeldBuilds)
+
+	code, status = serve(http.MethodPost, `{"enabled":false}`)
+	require.Equal(t, http.StatusOK, code)
+	require.False(t, status.Enabled)
+	require.Equal(t, 1, status.HeldBuilds)
+	require.NotEmpty(t, status.FlushedJobID)
+
+	code, _ = serve(http.MethodPost, `{`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serve(http.MethodDelete, "")
+	require.NotEqual(t, http.StatusOK, code)
+}
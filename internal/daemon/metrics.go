@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
 )
 
 // CustomMetric represents user-defined metrics with constrained JSON-friendly types.
@@ -153,6 +154,14 @@ func (mc *MetricsCollector) SetCustomMetric(name string, value CustomMetric) {
 	mc.customMetrics[name] = value
 }
 
+// RecordDrain records how an HTTP server drained its connections on shutdown.
+func (mc *MetricsCollector) RecordDrain(result httpserver.DrainResult) {
+	mc.IncrementCounter("http_drain_total")
+	mc.AddCounter("http_drain_forced_connections_total", int64(result.Forced))
+	mc.SetGauge("http_drain_connections_"+result.Server, int64(result.Connections))
+	mc.RecordHistogram("http_drain_duration_seconds", result.Duration.Seconds())
+}
+
 // GetSnapshot returns a complete metrics snapshot.
 func (mc *MetricsCollector) GetSnapshot() *MetricSnapshot {
 	mc.mu.RLock()
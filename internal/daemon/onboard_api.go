@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/onboard"
+)
+
+// maxOnboardRequestBytes bounds the body of an onboarding request.
+const maxOnboardRequestBytes = 64 << 10
+
+// onboardURLPrefixes are the clone URLs the onboarding API accepts; local paths and
+// file:// URLs are rejected so the API cannot read the daemon's file system.
+var onboardURLPrefixes = []string{"https://", "http://", "ssh://", "git@"}
+
+// OnboardRequest is the body of POST /api/onboard/check.
+type OnboardRequest struct {
+	URL    string   `json:"url"`
+	Branch string   `json:"branch,omitempty"`
+	Paths  []string `json:"paths,omitempty"` // docs paths (default: from .docbuilder.yaml, else docs)
+}
+
+// OnboardHandler clones a candidate repository and returns its onboarding checklist
+// (POST /api/onboard/check). Credentials of a configured repository or forge with the
+// same host are used for the clone.
+func (d *Daemon) OnboardHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodPost {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodPost).
+			Build())
+		return
+	}
+	var req OnboardRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOnboardRequestBytes)).Decode(&req); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid onboarding request").
+			WithContext("error", err.Error()).
+			Build())
+		return
+	}
+	report, err := d.onboardCheck(req)
+	if err != nil {
+		adapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode onboarding report").Build())
+	}
+}
+
+// onboardCheck validates a request and runs the checklist against a fresh clone.
+func (d *Daemon) onboardCheck(req OnboardRequest) (*onboard.Report, error) {
+	repoURL := strings.TrimSpace(req.URL)
+	if repoURL == "" {
+		return nil, ferrors.ValidationError("onboarding request needs a repository url").Build()
+	}
+	if !slices.ContainsFunc(onboardURLPrefixes, func(prefix string) bool { return strings.HasPrefix(repoURL, prefix) }) {
+		return nil, ferrors.ValidationError("onboarding url must be an http(s) or ssh clone URL").
+			WithContext("url", repoURL).
+			Build()
+	}
+	for _, p := range req.Paths {
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			return nil, ferrors.ValidationError("docs paths must be relative and stay within the repository").
+				WithContext("path", p).
+				Build()
+		}
+	}
+	linter, err := d.newLinter(nil)
+	if err != nil {
+		return nil, err
+	}
+	repo := config.Repository{
+		Name:   "candidate",
+		URL:    repoURL,
+		Branch: req.Branch,
+		Auth:   onboard.RepositoryAuth(d.config, repoURL),
+	}
+	workDir := filepath.Join(d.config.Daemon.Storage.RepoCacheDir, "onboard")
+	report, err := onboard.CheckRemote(repo, workDir, onboard.Options{DocsPaths: req.Paths, Linter: linter})
+	if err != nil {
+		return nil, ferrors.WrapError(err, ferrors.CategoryGit, "failed to check repository").
+			WithContext("url", repoURL).
+			Build()
+	}
+	return report, nil
+}
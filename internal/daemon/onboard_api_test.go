@@ -0,0 +1,34 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestDaemon_OnboardHandler_Validation(t *testing.T) {
+	d := &Daemon{config: &config.Config{
+		Daemon: &config.DaemonConfig{Storage: config.StorageConfig{RepoCacheDir: t.TempDir()}},
+	}}
+
+	rec := httptest.NewRecorder()
+	d.OnboardHandler(rec, httptest.NewRequest(http.MethodGet, "/api/onboard/check", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	for name, body := range map[string]string{
+		"malformed":  `{"url":`,
+		"no url":     `{}`,
+		"local path": `{"url": "/srv/repos/docs"}`,
+		"file url":   `{"url": "file:///srv/repos/docs"}`,
+		"docs path":  `{"url": "https://git.example.com/team/docs.git", "paths": ["../etc"]}`,
+	} {
+		rec := httptest.NewRecorder()
+		d.OnboardHandler(rec, httptest.NewRequest(http.MethodPost, "/api/onboard/check", strings.NewReader(body)))
+		require.Equal(t, http.StatusBadRequest, rec.Code, "%s: %s", name, rec.Body.String())
+	}
+}
@@ -37,10 +37,23 @@ func (d *Daemon) enqueueOrchestratedBuild(evt events.BuildNow) {
 	if d == nil || d.GetStatus() != StatusRunning || d.buildQueue == nil {
 		return
 	}
+	if d.holdForMaintenance(evt) || d.holdForBlackout(evt) {
+		return
+	}
+	if !d.isLeader() {
+		d.log().Info("Ignoring build request: not the leader", logfields.JobID(evt.JobID))
+		return
+	}
+
+	jobType := orchestratedBuildType(evt.LastReason)
+	// Named sites are rebuilt with every build of the main site; they queue behind it.
+	if d.config != nil && d.config.Daemon != nil && len(d.config.Daemon.Sites) > 0 {
+		defer d.enqueueSiteBuilds(evt, jobType)
+	}
 
 	reposForBuild := d.currentReposForOrchestratedBuild()
 	if len(reposForBuild) == 0 {
-		slog.Warn("Skipping orchestrated build: no repositories available")
+		d.log().Warn("Skipping orchestrated build: no repositories available")
 		return
 	}
 
@@ -58,6 +71,19 @@ func (d *Daemon) enqueueOrchestratedBuild(evt events.BuildNow) {
 		jobID = fmt.Sprintf("orchestrated-build-%d", time.Now().UnixNano())
 	}
 
+	if len(evt.Scope) > 0 {
+		scoped, err := config.ScopeRepositories(reposForBuild, evt.Scope)
+		if err != nil {
+			// Repositories may have disappeared since the request was accepted.
+			d.log().Warn("Build scope no longer matches repositories; building all",
+				logfields.JobID(jobID),
+				slog.Any("scope", evt.Scope),
+				logfields.Error(err))
+		} else {
+			reposForBuild = scoped
+		}
+	}
+
 	meta := &BuildJobMetadata{
 		V2Config:      d.config,
 		Repositories:  reposForBuild,
@@ -75,16 +101,6 @@ func (d *Daemon) enqueueOrchestratedBuild(evt events.BuildNow) {
 		}
 	}
 
-	jobType := BuildTypeManual
-	switch evt.LastReason {
-	case "webhook":
-		jobType = BuildTypeWebhook
-	case "discovery":
-		jobType = BuildTypeDiscovery
-	case "scheduled build":
-		jobType = BuildTypeScheduled
-	}
-
 	job := &BuildJob{
 		ID:        jobID,
 		Type:      jobType,
@@ -94,16 +110,31 @@ func (d *Daemon) enqueueOrchestratedBuild(evt events.BuildNow) {
 	}
 
 	if err := d.buildQueue.Enqueue(job); err != nil {
-		slog.Error("Failed to enqueue orchestrated build",
+		d.log().Error("Failed to enqueue orchestrated build",
 			logfields.JobID(jobID),
 			logfields.Error(err))
 		return
 	}
 
 	atomic.AddInt32(&d.queueLength, 1)
-	slog.Info("Orchestrated build enqueued",
+	d.log().Info("Orchestrated build enqueued",
 		logfields.JobID(jobID),
-		slog.Int("repositories", len(reposForBuild)))
+		slog.Int("repositories", len(reposForBuild)),
+		slog.Any("scope", evt.Scope))
+}
+
+// orchestratedBuildType maps the reason of a build request to the job type.
+func orchestratedBuildType(reason string) BuildType {
+	switch reason {
+	case "webhook", webhookTagReason, webhookReleaseReason:
+		return BuildTypeWebhook
+	case "discovery":
+		return BuildTypeDiscovery
+	case "scheduled build":
+		return BuildTypeScheduled
+	default:
+		return BuildTypeManual
+	}
 }
 
 func (d *Daemon) currentReposForOrchestratedBuild() []config.Repository {
@@ -42,14 +42,14 @@ func (d *Daemon) handleRepoRemoved(evt events.RepoRemoved) {
 
 	if remover, ok := any(d.stateManager).(interface{ RemoveRepositoryState(string) }); ok {
 		remover.RemoveRepositoryState(evt.RepoURL)
-		slog.Info("Repository removed from state", slog.String("repo_url", evt.RepoURL), logfields.Name(evt.RepoName))
+		d.log().Info("Repository removed from state", slog.String("repo_url", evt.RepoURL), logfields.Name(evt.RepoName))
 	}
 
 	// Best-effort: prune any cached remote-head entries for the removed repository.
 	if d.repoUpdater != nil && d.repoUpdater.cache != nil {
 		d.repoUpdater.cache.DeleteByURL(evt.RepoURL)
 		if err := d.repoUpdater.cache.Save(); err != nil {
-			slog.Warn("Failed to persist remote HEAD cache after repo removal",
+			d.log().Warn("Failed to persist remote HEAD cache after repo removal",
 				slog.String("repo_url", evt.RepoURL),
 				logfields.Error(err))
 		}
@@ -68,7 +68,7 @@ func (d *Daemon) handleRepoRemoved(evt events.RepoRemoved) {
 	base := filepath.Clean(repoCacheDir)
 	target := filepath.Clean(filepath.Join(base, repoName))
 	if target == base {
-		slog.Warn("Skipping repo cache deletion: refusing to delete repo cache base dir",
+		d.log().Warn("Skipping repo cache deletion: refusing to delete repo cache base dir",
 			slog.String("repo_url", evt.RepoURL),
 			logfields.Name(evt.RepoName),
 			slog.String("repo_cache_dir", base),
@@ -76,7 +76,7 @@ func (d *Daemon) handleRepoRemoved(evt events.RepoRemoved) {
 		return
 	}
 	if !strings.HasPrefix(target, base+string(os.PathSeparator)) {
-		slog.Warn("Skipping repo cache deletion: path escapes repo cache dir",
+		d.log().Warn("Skipping repo cache deletion: path escapes repo cache dir",
 			slog.String("repo_url", evt.RepoURL),
 			logfields.Name(evt.RepoName),
 			slog.String("repo_cache_dir", base),
@@ -84,14 +84,14 @@ func (d *Daemon) handleRepoRemoved(evt events.RepoRemoved) {
 		return
 	}
 	if err := os.RemoveAll(target); err != nil {
-		slog.Warn("Failed to remove repo cache directory",
+		d.log().Warn("Failed to remove repo cache directory",
 			slog.String("repo_url", evt.RepoURL),
 			logfields.Name(evt.RepoName),
 			logfields.Path(target),
 			logfields.Error(err))
 		return
 	}
-	slog.Info("Repository cache directory removed",
+	d.log().Info("Repository cache directory removed",
 		slog.String("repo_url", evt.RepoURL),
 		logfields.Name(evt.RepoName),
 		logfields.Path(target))
@@ -0,0 +1,402 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// Reconciliation files, kept in the daemon state directory.
+const (
+	ReconciliationResultsFile = "reconciliation.json"
+	reconciliationOutputDir   = "reconcile"
+)
+
+const (
+	// reconciliationRunTimeout bounds a single reconciliation build and comparison.
+	reconciliationRunTimeout = 6 * time.Hour
+	// reconciliationNotifyTimeout bounds the drift notification request.
+	reconciliationNotifyTimeout = 30 * time.Second
+)
+
+// ReconciliationReport is the outcome of a reconciliation build: the files of the
+// published site that differ from a full rebuild at the same commits. Paths are
+// relative to the site root.
+type ReconciliationReport struct {
+	Start    time.Time                 `json:"start"`
+	End      time.Time                 `json:"end"`
+	Commits  []models.RepositoryCommit `json:"commits"`
+	Files    int                       `json:"files"` // files of the rebuilt site that were compared
+	Drift    bool                      `json:"drift"`
+	Added    []string                  `json:"added,omitempty"`   // only in the rebuilt site
+	Removed  []string                  `json:"removed,omitempty"` // only in the published site
+	Changed  []string                  `json:"changed,omitempty"` // content differs
+	Notified bool                      `json:"notified,omitempty"`
+}
+
+// DriftFiles returns the number of files that differ between the two sites.
+func (r *ReconciliationReport) DriftFiles() int {
+	return len(r.Added) + len(r.Removed) + len(r.Changed)
+}
+
+// reconciler runs the scheduled full rebuild of daemon.reconciliation. Its build service
+// uses an ephemeral workspace and no skip evaluation, so no incremental state is reused.
+type reconciler struct {
+	builder     build.BuildService
+	outputDir   string
+	resultsPath string
+	client      *http.Client
+	running     atomic.Bool
+
+	mu   sync.Mutex
+	last *ReconciliationReport
+}
+
+// newReconciler creates the reconciler for daemon.reconciliation. The rebuilt site is
+// kept below the state directory until the next run, so drift can be inspected.
+func newReconciler(builder build.BuildService, stateDir string) *reconciler {
+	r := &reconciler{
+		builder:     builder,
+		outputDir:   filepath.Join(stateDir, reconciliationOutputDir),
+		resultsPath: filepath.Join(stateDir, ReconciliationResultsFile),
+		client:      &http.Client{Timeout: reconciliationNotifyTimeout},
+	}
+	// #nosec G304 -- path is derived from the daemon state directory
+	if data, err := os.ReadFile(r.resultsPath); err == nil {
+		var report ReconciliationReport
+		if json.Unmarshal(data, &report) == nil {
+			r.last = &report
+		}
+	}
+	return r
+}
+
+// Last returns the report of the most recent reconciliation, or nil before the first.
+func (r *reconciler) Last() *ReconciliationReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+func (r *reconciler) store(report *ReconciliationReport) error {
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.resultsPath), 0o750); err != nil {
+		return err
+	}
+	tmp := r.resultsPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.resultsPath)
+}
+
+// runReconciliation rebuilds the site from scratch at the commits of the published
+// build and compares the result with the published site. It runs on the
+// daemon.reconciliation schedule; only the leader reconciles.
+func (d *Daemon) runReconciliation(ctx context.Context) {
+	if d.reconciler == nil || d.GetStatus() != StatusRunning {
+		return
+	}
+	if d.InMaintenance() {
+		d.log().Info("Skipping reconciliation build: maintenance mode")
+		return
+	}
+	if d.config.Daemon != nil && d.config.Daemon.Sync.InBlackout(time.Now()) {
+		d.log().Info("Skipping reconciliation build: blackout window")
+		return
+	}
+	if !d.isLeader() {
+		d.log().Debug("Skipping reconciliation build: not the leader")
+		return
+	}
+	if !d.reconciler.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer d.reconciler.running.Store(false)
+
+	runCtx, cancel := d.stopAwareContext(ctx)
+	defer cancel()
+	runCtx, cancelTimeout := context.WithTimeout(runCtx, reconciliationRunTimeout)
+	defer cancelTimeout()
+
+	report, err := d.reconcile(runCtx)
+	if err != nil {
+		d.log().Warn("Reconciliation build failed", logfields.Error(err))
+		if d.metrics != nil {
+			d.metrics.IncrementCounter("reconciliation_failures_total")
+		}
+		return
+	}
+	if report == nil {
+		return
+	}
+	duration := report.End.Sub(report.Start)
+	if d.metrics != nil {
+		d.metrics.RecordHistogram("reconciliation_duration_seconds", duration.Seconds())
+		d.metrics.SetGauge("reconciliation_drift_files", int64(report.DriftFiles()))
+		if report.Drift {
+			d.metrics.IncrementCounter("reconciliation_drift_total")
+		}
+	}
+	if report.Drift {
+		d.log().Warn("Reconciliation build detected drift in the published site",
+			slog.Int("added", len(report.Added)),
+			slog.Int("removed", len(report.Removed)),
+			slog.Int("changed", len(report.Changed)),
+			slog.Duration("duration", duration))
+		if url := d.config.Daemon.Reconciliation.NotifyURL; url != "" {
+			if err := d.reconciler.notify(runCtx, d.config.Daemon.Reconciliation, report); err != nil {
+				d.log().Warn("Failed to send reconciliation drift notification", logfields.Error(err), slog.String("url", url))
+			} else {
+				report.Notified = true
+			}
+		}
+	} else {
+		d.log().Info("Reconciliation build completed without drift",
+			slog.Int("files", report.Files),
+			slog.Duration("duration", duration))
+	}
+	if err := d.reconciler.store(report); err != nil {
+		d.log().Warn("Failed to store reconciliation report", logfields.Error(err))
+	}
+}
+
+// reconcile runs the full rebuild and the comparison. It returns nil without error when
+// there is no published build to compare with.
+func (d *Daemon) reconcile(ctx context.Context) (*ReconciliationReport, error) {
+	outputDir := d.config.Daemon.Storage.OutputDir
+	published, commits, ok, err := d.publishedSiteSnapshot(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		d.log().Debug("Skipping reconciliation build: no published build with recorded commits")
+		return nil, nil
+	}
+
+	report := &ReconciliationReport{Start: time.Now(), Commits: commits}
+	d.log().Info("Starting reconciliation build", slog.Int("repositories", len(commits)))
+	result, err := d.reconciler.builder.Run(ctx, build.BuildRequest{
+		Config:    reconciliationConfig(d.config, d.currentReposForOrchestratedBuild(), commits),
+		OutputDir: d.reconciler.outputDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rebuild: %w", err)
+	}
+	rebuilt, err := hashSiteTree(filepath.Join(result.OutputPath, "public"), d.config.Daemon.Reconciliation)
+	if err != nil {
+		return nil, fmt.Errorf("hash rebuilt site: %w", err)
+	}
+	report.Added, report.Removed, report.Changed = compareSiteTrees(published, rebuilt)
+	report.Files = len(rebuilt)
+	report.Drift = report.DriftFiles() > 0
+	report.End = time.Now()
+	return report, nil
+}
+
+// publishedSiteSnapshot hashes the published site and reads the commits it was built
+// from. A build that publishes while the site is hashed is detected by the changed
+// build report; the snapshot is then not usable.
+func (d *Daemon) publishedSiteSnapshot(outputDir string) (map[string]string, []models.RepositoryCommit, bool, error) {
+	reportPath := filepath.Join(outputDir, "build-report.json")
+	// #nosec G304 -- path is derived from the configured output directory
+	before, err := os.ReadFile(reportPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("read build report: %w", err)
+	}
+	buildReport, err := models.DecodeReport(before)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(buildReport.Commits) == 0 {
+		return nil, nil, false, nil
+	}
+	hashes, err := hashSiteTree(filepath.Join(outputDir, "public"), d.config.Daemon.Reconciliation)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("hash published site: %w", err)
+	}
+	// #nosec G304 -- path is derived from the configured output directory
+	after, err := os.ReadFile(reportPath)
+	if err != nil || !bytes.Equal(before, after) {
+		return nil, nil, false, errors.New("the published site changed while it was hashed")
+	}
+	return hashes, buildReport.Commits, true, nil
+}
+
+// reconciliationConfig returns the configuration of the reconciliation build: the
+// repositories of the published build pinned to its commits, fresh clones, and none
+// of the steps that act outside the output directory (post_build hooks, CDN purges).
+func reconciliationConfig(cfg *config.Config, repos []config.Repository, commits []models.RepositoryCommit) *config.Config {
+	pinned := make(map[string]string, len(commits))
+	for _, c := range commits {
+		pinned[c.Repository] = c.Commit
+	}
+	cfgCopy := *cfg
+	cfgCopy.Repositories = nil
+	for _, repo := range repos {
+		sha, ok := pinned[repo.Name]
+		if !ok || sha == "" {
+			continue
+		}
+		repo.PinnedCommit = sha
+		repo.ReuseWorkingCopy = false
+		cfgCopy.Repositories = append(cfgCopy.Repositories, repo)
+	}
+	cfgCopy.Build.CloneStrategy = config.CloneStrategyFresh
+	cfgCopy.Purge = nil
+	if cfg.Hooks != nil {
+		hooks := *cfg.Hooks
+		hooks.PostBuild = nil
+		cfgCopy.Hooks = &hooks
+	}
+	return &cfgCopy
+}
+
+// hashSiteTree returns the SHA-256 of every file below root by slash separated path,
+// leaving out the paths ignored by the reconciliation config.
+func hashSiteTree(root string, rc *config.ReconciliationConfig) (map[string]string, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rc.Ignored(rel) {
+			return nil
+		}
+		// #nosec G304 -- p is below the site root being hashed
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		hashes[rel] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// compareSiteTrees lists the sorted paths only in rebuilt, only in published, and in
+// both with different content.
+func compareSiteTrees(published, rebuilt map[string]string) (added, removed, changed []string) {
+	for p, sum := range rebuilt {
+		prev, ok := published[p]
+		switch {
+		case !ok:
+			added = append(added, p)
+		case prev != sum:
+			changed = append(changed, p)
+		}
+	}
+	for p := range published {
+		if _, ok := rebuilt[p]; !ok {
+			removed = append(removed, p)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.Sort(changed)
+	return added, removed, changed
+}
+
+// notify POSTs the drift report to the configured notify_url.
+func (r *reconciler) notify(ctx context.Context, rc *config.ReconciliationConfig, report *ReconciliationReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rc.NotifyURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range rc.NotifyHeaders {
+		req.Header.Set(name, value)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// ReconciliationHandler serves the report of the last reconciliation build
+// (GET /api/reconciliation).
+func (d *Daemon) ReconciliationHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	var report *ReconciliationReport
+	if d.reconciler != nil {
+		report = d.reconciler.Last()
+	}
+	if report == nil {
+		adapter.WriteErrorResponse(w, r, ferrors.NotFoundError("reconciliation report").
+			WithContext("hint", "the first reconciliation build runs on the daemon.reconciliation schedule").
+			Build())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode reconciliation report").Build())
+	}
+}
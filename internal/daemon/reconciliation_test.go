@@ -0,0 +1,205 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// siteWritingBuilder writes a fixed public/ tree and records the request it was given.
+type siteWritingBuilder struct {
+	t     *testing.T
+	files map[string]string
+	req   build.BuildRequest
+}
+
+func (b *siteWritingBuilder) Run(_ context.Context, req build.BuildRequest) (*build.BuildResult, error) {
+	b.req = req
+	writeSiteFiles(b.t, filepath.Join(req.OutputDir, "public"), b.files)
+	return &build.BuildResult{Status: build.BuildStatusSuccess, OutputPath: req.OutputDir}, nil
+}
+
+func writeSiteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompareSiteTrees(t *testing.T) {
+	added, removed, changed := compareSiteTrees(
+		map[string]string{"index.html": "a", "old.html": "b", "guide/index.html": "c"},
+		map[string]string{"index.html": "a", "new.html": "d", "guide/index.html": "e"},
+	)
+	require.Equal(t, []string{"new.html"}, added)
+	require.Equal(t, []string{"old.html"}, removed)
+	require.Equal(t, []string{"guide/index.html"}, changed)
+}
+
+func TestReconciliationConfig_PinsPublishedCommits(t *testing.T) {
+	cfg := &config.Config{
+		Build: config.BuildConfig{CloneStrategy: config.CloneStrategyUpdate},
+		Purge: &config.PurgeConfig{Hooks: []config.PurgeHook{{Type: config.PurgeWebhook, URL: "https://cdn.example.com"}}},
+		Hooks: &config.BuildHooksConfig{
+			PreHugo:   []config.HookCommand{{Command: "make"}},
+			PostBuild: []config.HookCommand{{Command: "deploy"}},
+		},
+	}
+	repos := []config.Repository{{Name: "a", ReuseWorkingCopy: true}, {Name: "b"}}
+	got := reconciliationConfig(cfg, repos, []models.RepositoryCommit{{Repository: "a", Commit: "abc123"}})
+
+	require.Len(t, got.Repositories, 1, "repositories missing from the published build are left out")
+	require.Equal(t, "abc123", got.Repositories[0].PinnedCommit)
+	require.False(t, got.Repositories[0].ReuseWorkingCopy)
+	require.Equal(t, config.CloneStrategyFresh, got.Build.CloneStrategy)
+	require.Nil(t, got.Purge)
+	require.Empty(t, got.Hooks.PostBuild)
+	require.Len(t, got.Hooks.PreHugo, 1)
+	// The daemon configuration is not modified.
+	require.Len(t, cfg.Hooks.PostBuild, 1)
+	require.Equal(t, config.CloneStrategyUpdate, cfg.Build.CloneStrategy)
+}
+
+func TestDaemon_RunReconciliation_DetectsDrift(t *testing.T) {
+	stateDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeSiteFiles(t, filepath.Join(outputDir, "public"), map[string]string{
+		"index.html":       "home",
+		"guide/index.html": "stale guide",
+		"removed.html":     "gone upstream",
+		"sitemap.xml":      "published sitemap",
+	})
+	report := models.BuildReportSerializable{
+		SchemaVersion: models.ReportSchemaVersion,
+		Commits:       []models.RepositoryCommit{{Repository: "docs", Commit: "abc123"}},
+	}
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "build-report.json"), data, 0o600))
+
+	var notified ReconciliationReport
+	notify := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &notified))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer notify.Close()
+
+	builder := &siteWritingBuilder{t: t, files: map[string]string{
+		"index.html":       "home",
+		"guide/index.html": "fresh guide",
+		"sitemap.xml":      "rebuilt sitemap",
+	}}
+	d := &Daemon{
+		config: &config.Config{
+			Repositories: []config.Repository{{Name: "docs"}},
+			Daemon: &config.DaemonConfig{
+				Storage: config.StorageConfig{OutputDir: outputDir},
+				Reconciliation: &config.ReconciliationConfig{
+					Enabled:       true,
+					Ignore:        []string{"sitemap.xml"},
+					NotifyURL:     notify.URL,
+					NotifyHeaders: map[string]string{"Authorization": "Bearer secret"},
+				},
+			},
+		},
+		metrics:    NewMetricsCollector(),
+		reconciler: newReconciler(builder, stateDir),
+	}
+	d.status.Store(StatusRunning)
+
+	d.runReconciliation(context.Background())
+
+	require.Equal(t, "abc123", builder.req.Config.Repositories[0].PinnedCommit)
+	require.False(t, builder.req.Incremental)
+	last := d.reconciler.Last()
+	require.NotNil(t, last)
+	require.True(t, last.Drift)
+	require.True(t, last.Notified)
+	require.Equal(t, []string{"removed.html"}, last.Removed)
+	require.Equal(t, []string{"guide/index.html"}, last.Changed)
+	require.Empty(t, last.Added)
+	require.Equal(t, []string{"guide/index.html"}, notified.Changed)
+	require.Equal(t, int64(2), d.metrics.GetSnapshot().Gauges["reconciliation_drift_files"])
+
+	// The report is served on the admin API and survives a restart.
+	d.reconciler = newReconciler(builder, stateDir)
+	rec := httptest.NewRecorder()
+	d.ReconciliationHandler(rec, httptest.NewRequest(http.MethodGet, "/api/reconciliation", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var served ReconciliationReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, []string{"removed.html"}, served.Removed)
+}
+
+func TestDaemon_RunReconciliation_NoPublishedBuild(t *testing.T) {
+	builder := &siteWritingBuilder{t: t}
+	d := &Daemon{
+		config: &config.Config{Daemon: &config.DaemonConfig{
+			Storage:        config.StorageConfig{OutputDir: t.TempDir()},
+			Reconciliation: &config.ReconciliationConfig{Enabled: true},
+		}},
+		reconciler: newReconciler(builder, t.TempDir()),
+	}
+	d.status.Store(StatusRunning)
+
+	d.runReconciliation(context.Background())
+
+	require.Nil(t, builder.req.Config, "nothing is rebuilt without a published build")
+	rec := httptest.NewRecorder()
+	d.ReconciliationHandler(rec, httptest.NewRequest(http.MethodGet, "/api/reconciliation", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDaemon_RunReconciliation_SkipsBlackoutWindow(t *testing.T) {
+	outputDir := t.TempDir()
+	writeSiteFiles(t, filepath.Join(outputDir, "public"), map[string]string{"index.html": "home"})
+	data, err := json.Marshal(models.BuildReportSerializable{
+		SchemaVersion: models.ReportSchemaVersion,
+		Commits:       []models.RepositoryCommit{{Repository: "docs", Commit: "abc123"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "build-report.json"), data, 0o600))
+
+	now := time.Now()
+	builder := &siteWritingBuilder{t: t}
+	d := &Daemon{
+		config: &config.Config{
+			Repositories: []config.Repository{{Name: "docs"}},
+			Daemon: &config.DaemonConfig{
+				Storage:        config.StorageConfig{OutputDir: outputDir},
+				Reconciliation: &config.ReconciliationConfig{Enabled: true},
+				Sync: config.SyncConfig{BlackoutWindows: []config.BlackoutWindow{{
+					Start: now.Add(-time.Minute).Format("15:04"),
+					End:   now.Add(2 * time.Minute).Format("15:04"),
+				}}},
+			},
+		},
+		reconciler: newReconciler(builder, t.TempDir()),
+	}
+	d.status.Store(StatusRunning)
+
+	d.runReconciliation(context.Background())
+
+	require.Nil(t, builder.req.Config, "no reconciliation build runs inside a blackout window")
+	require.Nil(t, d.reconciler.Last())
+}
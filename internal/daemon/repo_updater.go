@@ -112,7 +112,7 @@ func (u *RepoUpdater) handleRequest(ctx context.Context, req events.RepoUpdateRe
 			Reason:      "repo_update_failed",
 			RepoURL:     repo.URL,
 			Branch:      branch,
-			Snapshot:    nil,
+			Snapshot:    requestedSnapshot(repo.URL, req.CommitSHA),
 			RequestedAt: time.Now(),
 		}); berr != nil {
 			slog.Warn("Failed to publish BuildRequested after repo update failure",
@@ -124,6 +124,12 @@ func (u *RepoUpdater) handleRequest(ctx context.Context, req events.RepoUpdateRe
 		return
 	}
 
+	if req.CommitSHA != "" {
+		// Build the commit the event reported, even if the branch has moved since.
+		changed = changed || req.CommitSHA != sha
+		sha = req.CommitSHA
+	}
+
 	if err := publishOrchestrationEventOnBus(ctx, u.bus, events.RepoUpdated{
 		JobID:     req.JobID,
 		RepoURL:   repo.URL,
@@ -181,3 +187,11 @@ func (u *RepoUpdater) lookupRepo(repoURL string) (config.Repository, bool) {
 	}
 	return config.Repository{}, false
 }
+
+// requestedSnapshot pins repoURL to commit when the update request carried one.
+func requestedSnapshot(repoURL, commit string) map[string]string {
+	if commit == "" {
+		return nil
+	}
+	return map[string]string{repoURL: commit}
+}
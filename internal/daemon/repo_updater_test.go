@@ -211,3 +211,49 @@ func TestRepoUpdater_WhenRemoteCheckFails_PublishesRepoUpdateFailedAndBuildReque
 		// ok
 	}
 }
+
+func TestRepoUpdater_WhenCommitRequested_BuildsThatCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	bus := events.NewBus()
+	defer bus.Close()
+
+	cache, err := git.NewRemoteHeadCache("")
+	require.NoError(t, err)
+
+	// The branch has already moved past the pushed commit and the cache is current,
+	// but the pushed commit must still be built.
+	checker := fakeRemoteHeadChecker{changed: false, sha: "feedface"}
+	updater := NewRepoUpdater(bus, checker, cache, func() []config.Repository {
+		return []config.Repository{{
+			Name:   "repo-1",
+			URL:    "https://example.invalid/repo-1.git",
+			Branch: "main",
+		}}
+	})
+
+	buildRequestedCh, unsubBuildRequested := events.Subscribe[events.BuildRequested](bus, 10)
+	defer unsubBuildRequested()
+
+	go updater.Run(ctx)
+	select {
+	case <-updater.Ready():
+	case <-time.After(250 * time.Millisecond):
+		t.Fatal("timed out waiting for repo updater ready")
+	}
+
+	require.NoError(t, bus.Publish(context.Background(), events.RepoUpdateRequested{
+		JobID:     "job-1",
+		RepoURL:   "https://example.invalid/repo-1.git",
+		Branch:    "main",
+		CommitSHA: "deadbeef",
+	}))
+
+	select {
+	case got := <-buildRequestedCh:
+		require.Equal(t, map[string]string{"https://example.invalid/repo-1.git": "deadbeef"}, got.Snapshot)
+	case <-time.After(250 * time.Millisecond):
+		t.Fatal("timed out waiting for BuildRequested")
+	}
+}
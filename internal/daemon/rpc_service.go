@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	docbuilderv1 "git.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1"
+	"git.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1/docbuilderv1connect"
+)
+
+// watchEventBuffer is how many events a WatchBuildEvents stream may lag behind before it
+// is ended with ResourceExhausted.
+const watchEventBuffer = 256
+
+// RPCHandler returns the control API (docbuilder.v1.DaemonService) and the path prefix
+// it is mounted on. It serves the gRPC, gRPC-Web and Connect protocols.
+func (d *Daemon) RPCHandler() (string, http.Handler) {
+	return docbuilderv1connect.NewDaemonServiceHandler(&rpcService{daemon: d})
+}
+
+// rpcService implements the control API on top of the same daemon methods as the
+// admin HTTP handlers.
+type rpcService struct {
+	daemon *Daemon
+}
+
+var _ docbuilderv1connect.DaemonServiceHandler = (*rpcService)(nil)
+
+func (s *rpcService) GetStatus(_ context.Context, _ *connect.Request[docbuilderv1.GetStatusRequest]) (*connect.Response[docbuilderv1.GetStatusResponse], error) {
+	d := s.daemon
+	res := &docbuilderv1.GetStatusResponse{
+		Status:        string(d.GetStatus()),
+		StartTime:     timestamppb.New(d.GetStartTime()),
+		UptimeSeconds: time.Since(d.GetStartTime()).Seconds(),
+		ActiveJobs:    int32(d.GetActiveJobs()),  // #nosec G115 -- small counter
+		QueueLength:   int32(d.GetQueueLength()), // #nosec G115 -- bounded by the queue size
+		Leader:        d.isLeader(),
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *rpcService) TriggerBuild(_ context.Context, req *connect.Request[docbuilderv1.TriggerBuildRequest]) (*connect.Response[docbuilderv1.TriggerBuildResponse], error) {
+	jobID, err := s.daemon.TriggerScopedBuild(req.Msg.GetRepositories(), req.Msg.GetCommits())
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	if jobID == "" {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.DaemonError("build not accepted: daemon is not running or not the leader").Build())
+	}
+	return connect.NewResponse(&docbuilderv1.TriggerBuildResponse{JobId: jobID}), nil
+}
+
+func (s *rpcService) TriggerDiscovery(_ context.Context, _ *connect.Request[docbuilderv1.TriggerDiscoveryRequest]) (*connect.Response[docbuilderv1.TriggerDiscoveryResponse], error) {
+	jobID := s.daemon.TriggerDiscovery()
+	if jobID == "" {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.DaemonError("discovery not accepted: daemon is not running or not the leader").Build())
+	}
+	return connect.NewResponse(&docbuilderv1.TriggerDiscoveryResponse{JobId: jobID}), nil
+}
+
+func (s *rpcService) GetBuildReport(ctx context.Context, req *connect.Request[docbuilderv1.GetBuildReportRequest]) (*connect.Response[docbuilderv1.GetBuildReportResponse], error) {
+	buildID := req.Msg.GetBuildId()
+	if buildID == "" {
+		return nil, rpcError(errors.ValidationError("build id is required").Build())
+	}
+	if s.daemon.eventStore == nil {
+		return nil, rpcError(errors.DaemonError("event store not initialized").Build())
+	}
+	document, err := eventstore.LoadBuildReport(ctx, s.daemon.eventStore, buildID)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return connect.NewResponse(&docbuilderv1.GetBuildReportResponse{ReportJson: document}), nil
+}
+
+func (s *rpcService) WatchBuildEvents(ctx context.Context, req *connect.Request[docbuilderv1.WatchBuildEventsRequest], stream *connect.ServerStream[docbuilderv1.BuildEvent]) error {
+	if s.daemon.eventEmitter == nil || s.daemon.eventEmitter.feed == nil {
+		return rpcError(errors.DaemonError("event store not initialized").Build())
+	}
+	events, cancel := s.daemon.eventEmitter.feed.subscribe(watchEventBuffer)
+	defer cancel()
+	// Send the response headers now: the stream is live once the client sees them.
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+	buildID := req.Msg.GetBuildId()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-events:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errors.RuntimeError("event stream fell behind; reconnect").Build())
+			}
+			if buildID != "" && evt.BuildID() != buildID {
+				continue
+			}
+			if err := stream.Send(&docbuilderv1.BuildEvent{
+				BuildId:     evt.BuildID(),
+				Type:        evt.Type(),
+				Time:        timestamppb.New(evt.Timestamp()),
+				PayloadJson: evt.Payload(),
+				Metadata:    evt.Metadata(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rpcError maps a classified error onto the RPC status codes, like HTTPErrorAdapter does
+// for HTTP status codes.
+func rpcError(err error) error {
+	code := connect.CodeInternal
+	if c, ok := errors.AsClassified(err); ok {
+		switch c.Category() {
+		case errors.CategoryValidation, errors.CategoryConfig:
+			code = connect.CodeInvalidArgument
+		case errors.CategoryAuth:
+			code = connect.CodeUnauthenticated
+		case errors.CategoryNotFound:
+			code = connect.CodeNotFound
+		case errors.CategoryAlreadyExists:
+			code = connect.CodeAlreadyExists
+		case errors.CategoryRuntime, errors.CategoryDaemon, errors.CategoryNetwork:
+			code = connect.CodeUnavailable
+		}
+	}
+	return connect.NewError(code, err)
+}
@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	docbuilderv1 "git.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1"
+	"git.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1/docbuilderv1connect"
+)
+
+func newRPCTestDaemon(t *testing.T) (*Daemon, docbuilderv1connect.DaemonServiceClient) {
+	t.Helper()
+	store, err := eventstore.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	d := &Daemon{eventStore: store, startTime: time.Now().Add(-time.Minute)}
+	d.status.Store(StatusRunning)
+	d.eventEmitter = NewEventEmitter(store, eventstore.NewBuildHistoryProjection(store, 10))
+
+	mux := http.NewServeMux()
+	mux.Handle(d.RPCHandler())
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return d, docbuilderv1connect.NewDaemonServiceClient(srv.Client(), srv.URL)
+}
+
+func TestRPCService_StatusAndReport(t *testing.T) {
+	d, client := newRPCTestDaemon(t)
+
+	status, err := client.GetStatus(t.Context(), connect.NewRequest(&docbuilderv1.GetStatusRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, string(StatusRunning), status.Msg.GetStatus())
+	require.True(t, status.Msg.GetLeader())
+	require.GreaterOrEqual(t, status.Msg.GetUptimeSeconds(), 60.0)
+
+	report := models.NewBuildReport(t.Context(), 1, 3)
+	report.DeriveOutcome()
+	report.Finish()
+	require.NoError(t, d.eventEmitter.EmitBuildReport(t.Context(), "job-1", report))
+
+	res, err := client.GetBuildReport(t.Context(), connect.NewRequest(&docbuilderv1.GetBuildReportRequest{BuildId: "job-1"}))
+	require.NoError(t, err)
+	decoded, err := models.DecodeReport(res.Msg.GetReportJson())
+	require.NoError(t, err)
+	require.Equal(t, 3, decoded.Files)
+
+	_, err = client.GetBuildReport(t.Context(), connect.NewRequest(&docbuilderv1.GetBuildReportRequest{BuildId: "unknown"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = client.GetBuildReport(t.Context(), connect.NewRequest(&docbuilderv1.GetBuildReportRequest{}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestRPCService_TriggerBuildUnavailableWithoutOrchestration(t *testing.T) {
+	_, client := newRPCTestDaemon(t)
+
+	_, err := client.TriggerBuild(t.Context(), connect.NewRequest(&docbuilderv1.TriggerBuildRequest{}))
+	require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	_, err = client.TriggerBuild(t.Context(), connect.NewRequest(&docbuilderv1.TriggerBuildRequest{Repositories: []string{"missing"}}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestRPCService_WatchBuildEvents(t *testing.T) {
+	d, client := newRPCTestDaemon(t)
+
+	stream, err := client.WatchBuildEvents(t.Context(), connect.NewRequest(&docbuilderv1.WatchBuildEventsRequest{BuildId: "job-2"}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = stream.Close() })
+
+	require.NoError(t, d.eventEmitter.EmitBuildFailed(t.Context(), "job-other", "clone", "ignored"))
+	require.NoError(t, d.eventEmitter.EmitBuildFailed(t.Context(), "job-2", "run_hugo", "hugo exited with 1"))
+
+	require.True(t, stream.Receive(), "stream ended: %v", stream.Err())
+	evt := stream.Msg()
+	require.Equal(t, "job-2", evt.GetBuildId())
+	require.Equal(t, "BuildFailed", evt.GetType())
+	require.Contains(t, string(evt.GetPayloadJson()), "hugo exited with 1")
+}
+
+func TestBuildEventFeed_DropsSlowSubscriber(t *testing.T) {
+	feed := newBuildEventFeed()
+	events, cancel := feed.subscribe(1)
+	defer cancel()
+
+	evt := &eventstore.BaseEvent{EventBuildID: "job", EventType: "BuildStarted"}
+	feed.publish(evt)
+	feed.publish(evt)
+
+	_, ok := <-events
+	require.True(t, ok)
+	_, ok = <-events
+	require.False(t, ok, "a subscriber that fell behind must be closed")
+}
@@ -76,3 +76,33 @@ func (s *Scheduler) ScheduleCron(name, expression string, task func()) (string,
 
 	return job.ID().String(), nil
 }
+
+// NextRun returns the next scheduled run of the job with the given ID.
+func (s *Scheduler) NextRun(jobID string) (time.Time, bool) {
+	for _, job := range s.scheduler.Jobs() {
+		if job.ID().String() != jobID {
+			continue
+		}
+		next, err := job.NextRun()
+		if err != nil || next.IsZero() {
+			return time.Time{}, false
+		}
+		return next, true
+	}
+	return time.Time{}, false
+}
+
+// NextRuns returns up to count upcoming runs of the job with the given ID.
+func (s *Scheduler) NextRuns(jobID string, count int) []time.Time {
+	for _, job := range s.scheduler.Jobs() {
+		if job.ID().String() != jobID {
+			continue
+		}
+		runs, err := job.NextRuns(count)
+		if err != nil {
+			return nil
+		}
+		return runs
+	}
+	return nil
+}
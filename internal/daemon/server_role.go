@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
+	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
+)
+
+// initServerRole wires a server-role daemon (daemon.role: server): the docs and admin
+// servers over a shared, read-only output directory. Forge clients, the build queue,
+// the scheduler and daemon state are not created, so any number of server-role
+// replicas can run next to one builder.
+func (d *Daemon) initServerRole() *Daemon {
+	cfg := d.config
+	// Nothing is orchestrated: build and discovery requests have nowhere to go.
+	d.orchestrationBus = nil
+
+	if cfg.Daemon.Analytics.IsEnabled() {
+		d.pageViews = analytics.NewPageViews(cfg.Daemon.Analytics.PageLimit())
+		if cfg.Daemon.Analytics.Prometheus {
+			registerPageViewCollector(d.pageViews)
+		}
+	}
+	if cfg.Daemon.Feedback.IsEnabled() {
+		d.log().Warn("Page feedback is not available in the server role; the feedback widget is disabled")
+	}
+
+	serverOpts := httpserver.Options{
+		ServeOnly:             true,
+		DetailedMetricsHandle: d.metrics.MetricsHandler,
+		DrainRecorder:         d.metrics,
+		PrometheusHandler:     prometheusOptionalHandler(),
+		ThrottleRecorder:      promThrottleRecorder{},
+		OutputStorage:         output.NewLocal(),
+		Logger:                d.loggers.Logger(logging.ComponentHTTP),
+		AccessLogSink:         d.loggers.AccessLog,
+	}
+	if d.pageViews != nil {
+		serverOpts.PageViews = d.pageViews
+	}
+	d.httpServer = httpserver.New(cfg, d, serverOpts)
+	return d
+}
+
+// startServerRole starts the docs and admin servers and blocks until the daemon stops.
+// The caller holds d.mu; it is released once the servers are running.
+func (d *Daemon) startServerRole(ctx context.Context) error {
+	runCtx, runCancel := context.WithCancel(ctx)
+	d.runCancel = runCancel
+
+	if err := d.httpServer.Start(runCtx); err != nil {
+		d.status.Store(StatusError)
+		d.runCancel = nil
+		runCancel()
+		d.mu.Unlock()
+		return fmt.Errorf("failed to start HTTP server: %w", err)
+	}
+
+	d.status.Store(StatusRunning)
+	d.metrics.SetGauge("daemon_status", int64(2)) // 2 = running
+	d.log().Info("DocBuilder daemon started in server role",
+		slog.String("output_dir", d.config.Output.Directory),
+		slog.Int("docs_port", d.config.Daemon.HTTP.DocsPort),
+		slog.Int("admin_port", d.config.Daemon.HTTP.AdminPort))
+	d.mu.Unlock()
+
+	select {
+	case <-runCtx.Done():
+	case <-d.stopChan:
+	}
+
+	d.status.Store(StatusStopping)
+	d.log().Info("Server role stopped", slog.Duration("uptime", time.Since(d.startTime)))
+	return nil
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerRole_ServesWithoutBuildComponents(t *testing.T) {
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: t.TempDir()},
+		Daemon: &config.DaemonConfig{Role: config.DaemonRoleServer},
+		Monitoring: &config.MonitoringConfig{
+			Health: config.MonitoringHealth{Path: "/health"},
+		},
+	}
+
+	d, err := NewDaemonWithLogging(cfg, "", nil)
+	require.NoError(t, err)
+	require.NotNil(t, d.httpServer)
+	require.Nil(t, d.forgeManager)
+	require.Nil(t, d.buildQueue)
+	require.Nil(t, d.scheduler)
+	require.Nil(t, d.stateManager)
+	require.Nil(t, d.eventStore)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() { errCh <- d.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return d.GetStatus() == StatusRunning }, 2*time.Second, 10*time.Millisecond)
+	require.Empty(t, d.TriggerBuild())
+	require.Empty(t, d.TriggerDiscovery())
+
+	require.NoError(t, d.Stop(context.Background()))
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
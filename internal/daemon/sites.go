@@ -0,0 +1,278 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// SiteStatus describes a named site (daemon.sites) and its most recent build.
+type SiteStatus struct {
+	Name            string           `json:"name"`
+	Title           string           `json:"title,omitempty"`
+	BaseURL         string           `json:"base_url,omitempty"`
+	OutputDirectory string           `json:"output_directory"`
+	Repositories    int              `json:"repositories"`
+	LastBuild       *SiteBuildStatus `json:"last_build,omitempty"`
+}
+
+// SiteBuildStatus is the state of a site's build job in the shared build queue.
+type SiteBuildStatus struct {
+	JobID       string      `json:"job_id"`
+	Status      BuildStatus `json:"status"`
+	QueuedAt    time.Time   `json:"queued_at"`
+	StartedAt   *time.Time  `json:"started_at,omitempty"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// siteBuilds remembers the last build job enqueued for each site.
+type siteBuilds struct {
+	mu   sync.Mutex
+	last map[string]SiteBuildStatus
+}
+
+func (s *siteBuilds) record(site, jobID string, queuedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[string]SiteBuildStatus)
+	}
+	s.last[site] = SiteBuildStatus{JobID: jobID, Status: BuildStatusQueued, QueuedAt: queuedAt}
+}
+
+func (s *siteBuilds) lastBuild(site string) (SiteBuildStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.last[site]
+	return b, ok
+}
+
+// enqueueSiteBuilds enqueues a build of every named site for an orchestrated build of
+// the main site. Scoped requests only build the sites containing the scoped repositories.
+func (d *Daemon) enqueueSiteBuilds(evt events.BuildNow, jobType BuildType) {
+	for i := range d.config.Daemon.Sites {
+		site := &d.config.Daemon.Sites[i]
+		repos := d.siteRepositories(site)
+		if len(evt.Scope) > 0 {
+			scoped, err := config.ScopeRepositories(repos, evt.Scope)
+			if err != nil {
+				continue
+			}
+			repos = scoped
+		}
+		if len(repos) == 0 {
+			d.log().Warn("Skipping site build: no repositories available", slog.String("site", site.Name))
+			continue
+		}
+		if _, err := d.enqueueSiteBuild(site, repos, evt.Snapshot, jobType); err != nil {
+			d.log().Error("Failed to enqueue site build",
+				slog.String("site", site.Name),
+				logfields.Error(err))
+		}
+	}
+}
+
+// enqueueSiteBuild adds a build of one site to the shared build queue, tagged with the
+// site name. Site builds run after the main site's build of the same trigger.
+func (d *Daemon) enqueueSiteBuild(site *config.SiteConfig, repos []config.Repository, snapshot map[string]string, jobType BuildType) (string, error) {
+	now := time.Now()
+	jobID := fmt.Sprintf("site-%s-%d", site.Name, now.UnixNano())
+	job := &BuildJob{
+		ID:        jobID,
+		Type:      jobType,
+		Priority:  PriorityNormal,
+		CreatedAt: now,
+		TypedMeta: &BuildJobMetadata{
+			V2Config:     d.config.SiteBuildConfig(site),
+			Repositories: repos,
+			RepoSnapshot: snapshot,
+			Site:         site.Name,
+		},
+	}
+	if err := d.buildQueue.Enqueue(job); err != nil {
+		return "", err
+	}
+	atomic.AddInt32(&d.queueLength, 1)
+	d.sites.record(site.Name, jobID, now)
+	d.log().Info("Site build enqueued",
+		logfields.JobID(jobID),
+		slog.String("site", site.Name),
+		slog.Int("repositories", len(repos)))
+	return jobID, nil
+}
+
+// siteRepositories returns the repositories a site builds: its explicit repositories,
+// the repositories selected by its filtering, or else those of the main site.
+func (d *Daemon) siteRepositories(site *config.SiteConfig) []config.Repository {
+	if len(site.Repositories) > 0 {
+		return append([]config.Repository{}, site.Repositories...)
+	}
+	if site.Filtering == nil {
+		return d.currentReposForOrchestratedBuild()
+	}
+
+	filter := forge.NewDiscoveryService(d.forgeManager, site.Filtering)
+	if len(d.config.Repositories) > 0 {
+		// Configured repositories have no forge metadata; only name patterns apply.
+		var repos []config.Repository
+		for _, repo := range d.config.Repositories {
+			if filter.Includes(&forge.Repository{Name: repo.Name, FullName: repo.Name, HasDocs: true}) {
+				repos = append(repos, repo)
+			}
+		}
+		return repos
+	}
+
+	// The main site's filtering may have dropped repositories the site includes.
+	discovered, _ := d.GetDiscoveryResult()
+	if discovered == nil || d.forgeManager == nil {
+		return nil
+	}
+	var selected []*forge.Repository
+	for _, candidates := range [][]*forge.Repository{discovered.Repositories, discovered.Filtered} {
+		for _, repo := range candidates {
+			if filter.Includes(repo) {
+				selected = append(selected, repo)
+			}
+		}
+	}
+	return filter.ConvertToConfigRepositories(selected, d.forgeManager)
+}
+
+// TriggerSiteBuild enqueues a build of the named site.
+func (d *Daemon) TriggerSiteBuild(name string) (string, error) {
+	site := d.config.Site(name)
+	if site == nil {
+		return "", ferrors.NotFoundError("site").WithContext("site", name).Build()
+	}
+	if d.GetStatus() != StatusRunning || d.buildQueue == nil {
+		return "", ferrors.DaemonError("daemon is not running").WithContext("status", d.GetStatus()).Build()
+	}
+	if !d.isLeader() {
+		return "", ferrors.DaemonError("builds run on the leader only").Build()
+	}
+	if d.InMaintenance() {
+		return "", ferrors.DaemonError("daemon is in maintenance mode").Build()
+	}
+	if d.config.Daemon != nil && d.config.Daemon.Sync.InBlackout(time.Now()) {
+		return "", ferrors.DaemonError("daemon is in a sync blackout window").Build()
+	}
+	repos := d.siteRepositories(site)
+	if len(repos) == 0 {
+		return "", ferrors.ValidationError("site has no repositories").WithContext("site", name).Build()
+	}
+	return d.enqueueSiteBuild(site, repos, nil, BuildTypeManual)
+}
+
+// siteOfJob returns the site a build job belongs to, or "" for the main site.
+func (d *Daemon) siteOfJob(buildID string) string {
+	if d.buildQueue == nil {
+		return ""
+	}
+	job, ok := d.buildQueue.JobSnapshot(buildID)
+	if !ok || job.TypedMeta == nil {
+		return ""
+	}
+	return job.TypedMeta.Site
+}
+
+// siteStatus reports a site's configuration and the state of its last build job.
+func (d *Daemon) siteStatus(site *config.SiteConfig) SiteStatus {
+	status := SiteStatus{
+		Name:            site.Name,
+		Title:           site.Title,
+		BaseURL:         site.BaseURL,
+		OutputDirectory: site.OutputDirectory,
+		Repositories:    len(d.siteRepositories(site)),
+	}
+	last, ok := d.sites.lastBuild(site.Name)
+	if !ok {
+		return status
+	}
+	if d.buildQueue != nil {
+		if job, found := d.buildQueue.JobSnapshot(last.JobID); found {
+			last.Status = job.Status
+			last.StartedAt = job.StartedAt
+			last.CompletedAt = job.CompletedAt
+			last.Error = job.Error
+		}
+	}
+	status.LastBuild = &last
+	return status
+}
+
+// SitesHandler lists the named sites and their last builds (GET /api/sites).
+func (d *Daemon) SitesHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	sites := make([]SiteStatus, 0, len(d.config.Daemon.Sites))
+	for i := range d.config.Daemon.Sites {
+		sites = append(sites, d.siteStatus(&d.config.Daemon.Sites[i]))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"sites": sites}); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode sites").Build())
+	}
+}
+
+// SiteHandler serves one site and its last build (GET /api/sites/{site}).
+func (d *Daemon) SiteHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodGet {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodGet).
+			Build())
+		return
+	}
+	name := r.PathValue("site")
+	site := d.config.Site(name)
+	if site == nil {
+		adapter.WriteErrorResponse(w, r, ferrors.NotFoundError("site").WithContext("site", name).Build())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(d.siteStatus(site)); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode site").Build())
+	}
+}
+
+// SiteBuildHandler triggers a build of one site (POST /api/sites/{site}/build).
+func (d *Daemon) SiteBuildHandler(w http.ResponseWriter, r *http.Request) {
+	adapter := ferrors.NewHTTPErrorAdapter(nil)
+	if r.Method != http.MethodPost {
+		adapter.WriteErrorResponse(w, r, ferrors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", http.MethodPost).
+			Build())
+		return
+	}
+	name := r.PathValue("site")
+	jobID, err := d.TriggerSiteBuild(name)
+	if err != nil {
+		adapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": "triggered", "site": name, "job_id": jobID}); err != nil {
+		adapter.WriteErrorResponse(w, r, ferrors.WrapError(err, ferrors.CategoryInternal, "failed to encode site build response").Build())
+	}
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build/queue"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+)
+
+func newSitesTestDaemon(t *testing.T) *Daemon {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	bq := queue.NewBuildQueue(10, 1, noOpBuilder{})
+	bq.Start(ctx)
+	t.Cleanup(func() { bq.Stop(context.Background()) })
+
+	d := &Daemon{
+		config: &config.Config{
+			Repositories: []config.Repository{
+				{Name: "platform-api", URL: "https://example.invalid/platform-api.git", Branch: "main", Paths: []string{"docs"}},
+				{Name: "sales-portal", URL: "https://example.invalid/sales-portal.git", Branch: "main", Paths: []string{"docs"}},
+			},
+			Daemon: &config.DaemonConfig{Sites: []config.SiteConfig{
+				{
+					Name:            "platform",
+					BaseURL:         "https://platform.example.com/",
+					OutputDirectory: "./site-platform",
+					Filtering:       &config.FilteringConfig{IncludePatterns: []string{"platform-*"}},
+				},
+				{
+					Name:            "sales",
+					OutputDirectory: "./site-sales",
+					Repositories: []config.Repository{
+						{Name: "sales-portal", URL: "https://example.invalid/sales-portal.git", Branch: "main", Paths: []string{"docs"}},
+					},
+				},
+			}},
+		},
+		stopChan:   make(chan struct{}),
+		buildQueue: bq,
+	}
+	d.status.Store(StatusRunning)
+	return d
+}
+
+func TestDaemon_TriggerSiteBuild(t *testing.T) {
+	d := newSitesTestDaemon(t)
+
+	jobID, err := d.TriggerSiteBuild("platform")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		job, ok := d.buildQueue.JobSnapshot(jobID)
+		return ok && job.Status == BuildStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	job, _ := d.buildQueue.JobSnapshot(jobID)
+	require.Equal(t, "platform", job.TypedMeta.Site)
+	require.Len(t, job.TypedMeta.Repositories, 1)
+	require.Equal(t, "platform-api", job.TypedMeta.Repositories[0].Name)
+	require.Equal(t, "./site-platform", job.TypedMeta.V2Config.Output.Directory)
+	require.Equal(t, "https://platform.example.com/", job.TypedMeta.V2Config.Hugo.BaseURL)
+	require.Equal(t, "platform", d.siteOfJob(jobID))
+
+	_, err = d.TriggerSiteBuild("unknown")
+	require.Error(t, err)
+
+	d.status.Store(StatusStopped)
+	_, err = d.TriggerSiteBuild("platform")
+	require.Error(t, err)
+}
+
+func TestDaemon_EnqueueSiteBuildsRespectsScope(t *testing.T) {
+	d := newSitesTestDaemon(t)
+
+	d.enqueueSiteBuilds(events.BuildNow{Scope: []string{"sales-portal"}}, BuildTypeWebhook)
+
+	_, platformQueued := d.sites.lastBuild("platform")
+	require.False(t, platformQueued)
+	sales, ok := d.sites.lastBuild("sales")
+	require.True(t, ok)
+	require.Eventually(t, func() bool {
+		job, found := d.buildQueue.JobSnapshot(sales.JobID)
+		return found && job.Type == BuildTypeWebhook
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDaemon_SiteHandlers(t *testing.T) {
+	d := newSitesTestDaemon(t)
+
+	rec := httptest.NewRecorder()
+	d.SitesHandler(rec, httptest.NewRequest(http.MethodGet, "/api/sites", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Sites []SiteStatus `json:"sites"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Sites, 2)
+	require.Equal(t, "platform", list.Sites[0].Name)
+	require.Equal(t, 1, list.Sites[0].Repositories)
+	require.Nil(t, list.Sites[0].LastBuild)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sites/{site}", d.SiteHandler)
+	mux.HandleFunc("/api/sites/{site}/build", d.SiteBuildHandler)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/sites/sales/build", nil))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var triggered map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &triggered))
+	require.Equal(t, "sales", triggered["site"])
+	require.NotEmpty(t, triggered["job_id"])
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/sites/sales", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var site SiteStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &site))
+	require.NotNil(t, site.LastBuild)
+	require.Equal(t, triggered["job_id"], site.LastBuild.JobID)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/sites/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
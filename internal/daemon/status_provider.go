@@ -21,11 +21,32 @@ func (d *Daemon) GetLastBuildTime() *time.Time {
 }
 
 // GetLastDiscovery returns the last successful discovery time (if any).
+// Before the first discovery of this process it falls back to the time of the
+// discovery result restored from state.
 func (d *Daemon) GetLastDiscovery() *time.Time {
-	if d.discoveryRunner == nil {
+	if d.discoveryRunner != nil {
+		if last := d.discoveryRunner.GetLastDiscovery(); last != nil {
+			return last
+		}
+	}
+	if d.discoveryCache != nil && d.discoveryCache.Restored() {
+		restoredAt := d.discoveryCache.UpdatedAt()
+		return &restoredAt
+	}
+	return nil
+}
+
+// GetNextDiscovery returns when the scheduled sync (discovery and update checks) runs
+// next, after jitter and skipping runs in blackout windows.
+func (d *Daemon) GetNextDiscovery() *time.Time {
+	if d.scheduler == nil || d.syncJobID == "" {
 		return nil
 	}
-	return d.discoveryRunner.GetLastDiscovery()
+	next, ok := d.nextEffectiveSync()
+	if !ok {
+		return nil
+	}
+	return &next
 }
 
 // GetDiscoveryResult returns the cached discovery result and error.
@@ -36,3 +57,13 @@ func (d *Daemon) GetDiscoveryResult() (*forge.DiscoveryResult, error) {
 	}
 	return d.discoveryCache.Get()
 }
+
+// GetDiscoveryCachedAt returns when the cached discovery result was stored and
+// whether it was restored from state rather than produced by this process.
+func (d *Daemon) GetDiscoveryCachedAt() (*time.Time, bool) {
+	if d.discoveryCache == nil || !d.discoveryCache.HasResult() {
+		return nil, false
+	}
+	cachedAt := d.discoveryCache.UpdatedAt()
+	return &cachedAt, d.discoveryCache.Restored()
+}
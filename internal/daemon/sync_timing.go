@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// effectiveRunLookahead is how many upcoming cron runs are checked against the
+// blackout windows when computing the next effective sync.
+const effectiveRunLookahead = 500
+
+// planSyncJitter picks the random delay of the next scheduled sync (daemon.sync.jitter).
+// The delay is chosen ahead of the run so that the status page can report it.
+func (d *Daemon) planSyncJitter() {
+	maxJitter := d.config.Daemon.Sync.JitterDuration()
+	if maxJitter <= 0 {
+		d.syncJitter.Store(0)
+		return
+	}
+	// #nosec G404 -- spreading runs across instances does not need a cryptographic source
+	d.syncJitter.Store(rand.Int64N(int64(maxJitter) + 1))
+}
+
+// waitSyncJitter delays a scheduled sync by the planned jitter and plans the next one.
+// It returns false when the daemon stops while waiting.
+func (d *Daemon) waitSyncJitter(ctx context.Context) bool {
+	delay := time.Duration(d.syncJitter.Load())
+	d.planSyncJitter()
+	if delay <= 0 {
+		return true
+	}
+	d.log().Debug("Delaying scheduled sync tick", slog.Duration("jitter", delay))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-d.stopChan:
+		return false
+	}
+}
+
+// nextEffectiveSync returns the next scheduled sync that is not skipped by a blackout
+// window, including its planned jitter.
+func (d *Daemon) nextEffectiveSync() (time.Time, bool) {
+	jitter := time.Duration(d.syncJitter.Load())
+	if d.config == nil || d.config.Daemon == nil || len(d.config.Daemon.Sync.BlackoutWindows) == 0 {
+		next, ok := d.scheduler.NextRun(d.syncJobID)
+		return next.Add(jitter), ok
+	}
+	for _, run := range d.scheduler.NextRuns(d.syncJobID, effectiveRunLookahead) {
+		if at := run.Add(jitter); !d.config.Daemon.Sync.InBlackout(at) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func newSyncTimingTestDaemon(t *testing.T, sync config.SyncConfig) (*Daemon, time.Time) {
+	t.Helper()
+
+	s, err := NewScheduler()
+	require.NoError(t, err)
+	s.Start(t.Context())
+	t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+	sync.Schedule = "0 */4 * * *"
+	d := &Daemon{config: &config.Config{Daemon: &config.DaemonConfig{Sync: sync}}, scheduler: s, stopChan: make(chan struct{})}
+	require.NoError(t, d.schedulePeriodicJobs(t.Context()))
+
+	next, ok := s.NextRun(d.syncJobID)
+	require.True(t, ok)
+	return d, next
+}
+
+func TestDaemon_NextEffectiveSyncSkipsBlackoutWindows(t *testing.T) {
+	d, cronNext := newSyncTimingTestDaemon(t, config.SyncConfig{})
+	next := d.GetNextDiscovery()
+	require.NotNil(t, next)
+	require.Equal(t, cronNext, *next)
+
+	// A blackout window around the next cron run moves the effective run to the one after.
+	d.config.Daemon.Sync.BlackoutWindows = []config.BlackoutWindow{{
+		Start: cronNext.Add(-time.Minute).Format("15:04"),
+		End:   cronNext.Add(time.Minute).Format("15:04"),
+	}}
+	next = d.GetNextDiscovery()
+	require.NotNil(t, next)
+	require.Equal(t, cronNext.Add(4*time.Hour), *next)
+}
+
+func TestDaemon_SyncJitter(t *testing.T) {
+	d, cronNext := newSyncTimingTestDaemon(t, config.SyncConfig{Jitter: "10m"})
+
+	next := d.GetNextDiscovery()
+	require.NotNil(t, next)
+	require.False(t, next.Before(cronNext))
+	require.False(t, next.After(cronNext.Add(10*time.Minute)))
+
+	// The planned jitter is waited for, then a new one is planned.
+	d.syncJitter.Store(int64(20 * time.Millisecond))
+	start := time.Now()
+	require.True(t, d.waitSyncJitter(t.Context()))
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	require.LessOrEqual(t, time.Duration(d.syncJitter.Load()), 10*time.Minute)
+
+	// Stopping the daemon ends the wait.
+	d.syncJitter.Store(int64(time.Hour))
+	close(d.stopChan)
+	require.False(t, d.waitSyncJitter(t.Context()))
+}
@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"context"
+	"log/slog"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
+)
+
+// Reasons reported when a webhook push is skipped by a branch allowlist.
+const (
+	webhookSkipForgeBranch = "forge_branch_not_allowed"
+	webhookSkipRepoBranch  = "repository_branch_not_allowed"
+)
+
+// CheckWebhookBranch implements handlers.WebhookBranchFilter.
+//
+// The forge allowlist (forges[].webhook.branches) is checked first, then the
+// allowlist of the configured repository matching repoFullName
+// (repositories[].webhook_branches). Rejected pushes are recorded in the event store.
+func (d *Daemon) CheckWebhookBranch(ctx context.Context, forgeName, repoFullName, branch string) (bool, string) {
+	if d == nil || d.config == nil {
+		return true, ""
+	}
+	branch = normalizeGitBranchRef(branch)
+
+	reason := ""
+	if forgeCfg := d.forgeConfig(forgeName); forgeCfg != nil && !forgeCfg.Webhook.BranchAllowed(branch) {
+		reason = webhookSkipForgeBranch
+	} else {
+		for i := range d.config.Repositories {
+			repo := &d.config.Repositories[i]
+			if repoMatchesFullName(*repo, repoFullName) && !repo.WebhookBranchAllowed(branch) {
+				reason = webhookSkipRepoBranch
+				break
+			}
+		}
+	}
+	if reason == "" {
+		return true, ""
+	}
+
+	d.recordWebhookSkipped(ctx, eventstore.WebhookSkippedData{
+		Forge:      forgeName,
+		Repository: repoFullName,
+		Branch:     branch,
+		Reason:     reason,
+	})
+	return false, reason
+}
+
+// forgeConfig returns the configured forge named forgeName, or nil.
+func (d *Daemon) forgeConfig(forgeName string) *config.ForgeConfig {
+	if forgeName == "" {
+		return nil
+	}
+	for _, forgeCfg := range d.config.Forges {
+		if forgeCfg != nil && forgeCfg.Name == forgeName {
+			return forgeCfg
+		}
+	}
+	return nil
+}
+
+func (d *Daemon) recordWebhookSkipped(ctx context.Context, data eventstore.WebhookSkippedData) {
+	if d.eventEmitter == nil {
+		return
+	}
+	var metadata map[string]string
+	if requestID := observability.RequestID(ctx); requestID != "" {
+		metadata = map[string]string{"request_id": requestID}
+	}
+	event, err := eventstore.NewWebhookSkipped(data, metadata)
+	if err == nil {
+		err = d.eventEmitter.EmitEvent(ctx, event)
+	}
+	if err != nil {
+		d.log().Warn("Failed to record skipped webhook",
+			logfields.Error(err),
+			slog.String("repo", data.Repository),
+			slog.String("branch", data.Branch))
+	}
+}
+
+// Compile-time check that Daemon implements handlers.WebhookBranchFilter.
+var _ handlers.WebhookBranchFilter = (*Daemon)(nil)
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/eventstore"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+func TestDaemon_CheckWebhookBranch(t *testing.T) {
+	store, err := eventstore.NewSQLiteStore(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	d := &Daemon{
+		config: &config.Config{
+			Forges: []*config.ForgeConfig{{
+				Name:    "forge-1",
+				Type:    config.ForgeGitHub,
+				Webhook: &config.WebhookConfig{Branches: []string{"main", "release/*"}},
+			}},
+			Repositories: []config.Repository{{
+				Name:            "org/repo",
+				URL:             "https://github.com/org/repo.git",
+				WebhookBranches: []string{"main"},
+			}},
+		},
+		eventEmitter: NewEventEmitter(store, nil),
+	}
+
+	ctx := observability.WithRequestID(context.Background(), "req-1")
+
+	allowed, reason := d.CheckWebhookBranch(ctx, "forge-1", "org/other", "release/1.0")
+	require.True(t, allowed)
+	require.Empty(t, reason)
+
+	allowed, reason = d.CheckWebhookBranch(ctx, "forge-1", "org/repo", "refs/heads/main")
+	require.True(t, allowed)
+	require.Empty(t, reason)
+
+	allowed, reason = d.CheckWebhookBranch(ctx, "forge-1", "org/other", "feature/x")
+	require.False(t, allowed)
+	require.Equal(t, webhookSkipForgeBranch, reason)
+
+	allowed, reason = d.CheckWebhookBranch(ctx, "forge-1", "org/repo", "release/1.0")
+	require.False(t, allowed)
+	require.Equal(t, webhookSkipRepoBranch, reason)
+
+	// Unknown forges have no forge allowlist.
+	allowed, _ = d.CheckWebhookBranch(ctx, "", "org/other", "feature/x")
+	require.True(t, allowed)
+
+	recorded, err := store.GetRange(ctx, time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	for _, ev := range recorded {
+		require.Equal(t, "WebhookSkipped", ev.Type())
+		require.Equal(t, "req-1", ev.Metadata()["request_id"])
+	}
+	var data eventstore.WebhookSkippedData
+	require.NoError(t, json.Unmarshal(recorded[1].Payload(), &data))
+	require.Equal(t, eventstore.WebhookSkippedData{
+		Forge:      "forge-1",
+		Repository: "org/repo",
+		Branch:     "release/1.0",
+		Reason:     webhookSkipRepoBranch,
+	}, data)
+}
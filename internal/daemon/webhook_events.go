@@ -0,0 +1,303 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
+	"git.home.luguber.info/inful/docbuilder/internal/state"
+)
+
+// Build request reasons of webhook events other than pushes.
+const (
+	webhookTagReason     = "webhook tag"
+	webhookReleaseReason = "webhook release"
+)
+
+// releaseNotes holds the forge releases recorded from release webhooks, keyed by
+// repository full name (owner/repo), and persists them in daemon state.
+type releaseNotes struct {
+	mu       sync.RWMutex
+	releases map[string][]pipeline.Release
+	store    state.ReleaseNotesStore
+}
+
+func newReleaseNotes() *releaseNotes {
+	return &releaseNotes{releases: make(map[string][]pipeline.Release)}
+}
+
+// restore attaches store and loads the releases persisted in it.
+func (r *releaseNotes) restore(store state.ReleaseNotesStore) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store = store
+	if store == nil {
+		return nil
+	}
+	data := store.GetReleaseNotes()
+	if len(data) == 0 {
+		return nil
+	}
+	releases := make(map[string][]pipeline.Release)
+	if err := json.Unmarshal(data, &releases); err != nil {
+		return fmt.Errorf("decode release notes: %w", err)
+	}
+	r.releases = releases
+	return nil
+}
+
+// record adds a release, replacing an earlier release with the same tag.
+func (r *releaseNotes) record(fullName string, rel pipeline.Release) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.releases[fullName]
+	for i := range list {
+		if list[i].Tag == rel.Tag {
+			list[i] = rel
+			r.persistLocked()
+			return
+		}
+	}
+	r.releases[fullName] = append(list, rel)
+	r.persistLocked()
+}
+
+// remove deletes the release of tag and reports whether there was one.
+func (r *releaseNotes) remove(fullName, tag string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.releases[fullName]
+	for i := range list {
+		if list[i].Tag == tag {
+			list = append(list[:i], list[i+1:]...)
+			if len(list) == 0 {
+				delete(r.releases, fullName)
+			} else {
+				r.releases[fullName] = list
+			}
+			r.persistLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// rename moves the releases of a repository to its new full name.
+func (r *releaseNotes) rename(oldFullName, newFullName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list, ok := r.releases[oldFullName]
+	if !ok {
+		return
+	}
+	delete(r.releases, oldFullName)
+	r.releases[newFullName] = append(r.releases[newFullName], list...)
+	r.persistLocked()
+}
+
+// forRepository returns a copy of the releases recorded for repo.
+func (r *releaseNotes) forRepository(repo *config.Repository) []pipeline.Release {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for fullName, list := range r.releases {
+		if repoMatchesFullName(*repo, fullName) {
+			return append([]pipeline.Release(nil), list...)
+		}
+	}
+	return nil
+}
+
+func (r *releaseNotes) persistLocked() {
+	if r.store == nil {
+		return
+	}
+	data, err := json.Marshal(r.releases)
+	if err != nil {
+		slog.Warn("Failed to encode release notes", logfields.Error(err))
+		return
+	}
+	r.store.SetReleaseNotes(data)
+}
+
+// ReleaseNotes implements hugo.ReleaseNotesSource. Tag versions of versioned
+// repositories get no releases section of their own.
+func (d *Daemon) ReleaseNotes(repo *config.Repository) []pipeline.Release {
+	if d == nil || d.releases == nil || repo == nil || repo.IsTag {
+		return nil
+	}
+	return d.releases.forRepository(repo)
+}
+
+// TriggerWebhookTagBuild implements handlers.WebhookEventTrigger. Only tags that
+// versioning publishes as versions request a build; the build discovers them again.
+func (d *Daemon) TriggerWebhookTagBuild(forgeName, repoFullName, tag string) string {
+	if d.config == nil || !d.config.Versioning.IncludesTag(tag) {
+		d.log().Info("Webhook tag ignored (not a published version)",
+			slog.String("forge", forgeName),
+			slog.String("repo", repoFullName),
+			slog.String("tag", tag))
+		return ""
+	}
+	repo, ok := d.findWebhookRepository(forgeName, repoFullName)
+	if !ok {
+		d.log().Warn("Webhook tag did not match any known repository",
+			slog.String("forge", forgeName),
+			slog.String("repo", repoFullName),
+			slog.String("tag", tag))
+		return ""
+	}
+	return d.requestWebhookEventBuild("webhook-tag", webhookTagReason, repo.URL, tag)
+}
+
+// TriggerWebhookReleaseBuild implements handlers.WebhookEventTrigger. Published and
+// edited releases are recorded, deleted ones removed; both rebuild the site so the
+// repository's releases section reflects the change.
+func (d *Daemon) TriggerWebhookReleaseBuild(forgeName, repoFullName, action string, release forge.WebhookRelease) string {
+	repo, ok := d.findWebhookRepository(forgeName, repoFullName)
+	if !ok || release.Tag == "" {
+		d.log().Warn("Webhook release did not match any known repository",
+			slog.String("forge", forgeName),
+			slog.String("repo", repoFullName),
+			slog.String("tag", release.Tag))
+		return ""
+	}
+
+	switch action {
+	case "published", "released", "edited":
+		published := release.PublishedAt
+		if published.IsZero() {
+			published = time.Now().UTC()
+		}
+		d.releases.record(repoFullName, pipeline.Release{
+			Tag:         release.Tag,
+			Name:        release.Name,
+			Notes:       release.Notes,
+			URL:         release.URL,
+			PublishedAt: published,
+			Prerelease:  release.Prerelease,
+		})
+	case "deleted", "unpublished":
+		if !d.releases.remove(repoFullName, release.Tag) {
+			return ""
+		}
+	default:
+		return ""
+	}
+	return d.requestWebhookEventBuild("webhook-release", webhookReleaseReason, repo.URL, release.Tag)
+}
+
+// RenameWebhookRepository implements handlers.WebhookEventTrigger. The repository's
+// state and recorded releases move to the new name and URL. Discovered repositories
+// are picked up under their new name by a discovery run; configured repositories keep
+// their configured URL, which has to be updated by hand.
+func (d *Daemon) RenameWebhookRepository(forgeName, oldFullName string, repo *forge.Repository) string {
+	if repo == nil || repo.FullName == "" {
+		return ""
+	}
+	d.releases.rename(oldFullName, repo.FullName)
+
+	old, ok := d.findWebhookRepository(forgeName, oldFullName)
+	if !ok {
+		d.log().Info("Renamed repository is not part of the site",
+			slog.String("forge", forgeName),
+			slog.String("from", oldFullName),
+			slog.String("to", repo.FullName))
+		return ""
+	}
+	newURL := repo.CloneURL
+	if isSSHRepoURL(old.URL) && repo.SSHURL != "" {
+		newURL = repo.SSHURL
+	}
+	if newURL != "" && d.stateManager != nil {
+		d.stateManager.RenameRepositoryState(old.URL, newURL, repo.Name)
+	}
+	d.log().Info("Repository renamed on forge",
+		slog.String("forge", forgeName),
+		slog.String("from", oldFullName),
+		slog.String("to", repo.FullName),
+		slog.String("url", newURL))
+
+	if d.config != nil && len(d.config.Repositories) > 0 {
+		d.log().Warn("Configured repository was renamed; update its url in the configuration",
+			slog.String("repository", old.Name),
+			slog.String("configured_url", old.URL),
+			slog.String("new_url", newURL))
+		return ""
+	}
+	return d.TriggerDiscovery()
+}
+
+// findWebhookRepository returns the known repository named fullName, restricted to the
+// host of the forge when forgeName is set.
+func (d *Daemon) findWebhookRepository(forgeName, fullName string) (config.Repository, bool) {
+	forgeHost := ""
+	if cfg := d.forgeConfig(forgeName); cfg != nil {
+		forgeHost = extractHost(cfg.BaseURL)
+	}
+	for _, repo := range d.currentReposForOrchestratedBuild() {
+		if forgeHost != "" && extractRepoHost(repo.URL) != forgeHost {
+			continue
+		}
+		if repoMatchesFullName(repo, fullName) {
+			return repo, true
+		}
+	}
+	return config.Repository{}, false
+}
+
+// requestWebhookEventBuild publishes a full-site build request for a webhook event.
+func (d *Daemon) requestWebhookEventBuild(jobPrefix, reason, repoURL, ref string) string {
+	if d.GetStatus() != StatusRunning || d.orchestrationBus == nil {
+		return ""
+	}
+	jobID := ""
+	if d.buildDebouncer != nil {
+		if planned, ok := d.buildDebouncer.PlannedJobID(); ok {
+			jobID = planned
+		}
+	}
+	if jobID == "" {
+		jobID = fmt.Sprintf("%s-%d", jobPrefix, time.Now().UnixNano())
+	}
+	if err := d.publishOrchestrationEvent(context.Background(), events.BuildRequested{
+		JobID:       jobID,
+		Immediate:   d.webhookImmediate(),
+		Reason:      reason,
+		RepoURL:     repoURL,
+		Branch:      ref,
+		RequestedAt: time.Now(),
+	}); err != nil {
+		d.log().Warn("Failed to publish webhook build request",
+			logfields.JobID(jobID),
+			slog.String("reason", reason),
+			logfields.Error(err))
+		return ""
+	}
+	d.log().Info("Webhook build requested",
+		logfields.JobID(jobID),
+		slog.String("reason", reason),
+		slog.String("repo_url", repoURL),
+		slog.String("ref", ref))
+	return jobID
+}
+
+func isSSHRepoURL(u string) bool {
+	return strings.HasPrefix(u, "ssh://") || (!strings.Contains(u, "://") && strings.Contains(u, "@"))
+}
+
+// Compile-time checks that Daemon handles webhook events and supplies release notes.
+var (
+	_ handlers.WebhookEventTrigger = (*Daemon)(nil)
+	_ hugo.ReleaseNotesSource      = (*Daemon)(nil)
+)
@@ -0,0 +1,147 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+func newWebhookEventsDaemon(t *testing.T, versioning *config.VersioningConfig) (*Daemon, <-chan events.BuildRequested) {
+	t.Helper()
+	bus := events.NewBus()
+	t.Cleanup(bus.Close)
+	requests, unsubscribe := events.Subscribe[events.BuildRequested](bus, 4)
+	t.Cleanup(unsubscribe)
+
+	d := &Daemon{
+		config: &config.Config{
+			Version:    "2.0",
+			Versioning: versioning,
+			Repositories: []config.Repository{{
+				Name: "docs", URL: "https://github.com/acme/docs.git", Branch: "main",
+			}},
+		},
+		stopChan:         make(chan struct{}),
+		orchestrationBus: bus,
+		releases:         newReleaseNotes(),
+	}
+	d.status.Store(StatusRunning)
+	return d, requests
+}
+
+func receiveBuildRequest(t *testing.T, requests <-chan events.BuildRequested) events.BuildRequested {
+	t.Helper()
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for build request")
+		return events.BuildRequested{}
+	}
+}
+
+func TestDaemon_TriggerWebhookTagBuild(t *testing.T) {
+	t.Run("versioned tag", func(t *testing.T) {
+		d, requests := newWebhookEventsDaemon(t, &config.VersioningConfig{
+			Enabled: true, Strategy: config.StrategyBranchesAndTags, TagPatterns: []string{"v*"},
+		})
+		jobID := d.TriggerWebhookTagBuild("", "acme/docs", "v1.2.0")
+		require.NotEmpty(t, jobID)
+
+		req := receiveBuildRequest(t, requests)
+		assert.Equal(t, jobID, req.JobID)
+		assert.Equal(t, webhookTagReason, req.Reason)
+		assert.Equal(t, "https://github.com/acme/docs.git", req.RepoURL)
+		assert.Equal(t, "v1.2.0", req.Branch)
+	})
+
+	t.Run("tag not matching patterns", func(t *testing.T) {
+		d, _ := newWebhookEventsDaemon(t, &config.VersioningConfig{
+			Enabled: true, Strategy: config.StrategyBranchesAndTags, TagPatterns: []string{"v*"},
+		})
+		assert.Empty(t, d.TriggerWebhookTagBuild("", "acme/docs", "nightly"))
+	})
+
+	t.Run("versioning disabled", func(t *testing.T) {
+		d, _ := newWebhookEventsDaemon(t, nil)
+		assert.Empty(t, d.TriggerWebhookTagBuild("", "acme/docs", "v1.2.0"))
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		d, _ := newWebhookEventsDaemon(t, &config.VersioningConfig{Enabled: true})
+		assert.Empty(t, d.TriggerWebhookTagBuild("", "acme/other", "v1.2.0"))
+	})
+}
+
+func TestDaemon_TriggerWebhookReleaseBuild(t *testing.T) {
+	d, requests := newWebhookEventsDaemon(t, nil)
+	repo := &config.Repository{Name: "docs", URL: "https://github.com/acme/docs.git"}
+	published := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	jobID := d.TriggerWebhookReleaseBuild("", "acme/docs", "published", forge.WebhookRelease{
+		Tag: "v1.2.0", Name: "Version 1.2", Notes: "Faster builds", PublishedAt: published,
+	})
+	require.NotEmpty(t, jobID)
+	assert.Equal(t, webhookReleaseReason, receiveBuildRequest(t, requests).Reason)
+
+	releases := d.ReleaseNotes(repo)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "Version 1.2", releases[0].Name)
+	assert.Equal(t, published, releases[0].PublishedAt)
+	assert.Nil(t, d.ReleaseNotes(&config.Repository{Name: "docs", URL: repo.URL, IsTag: true}),
+		"tag versions get no releases section")
+
+	// Editing replaces the release of the same tag.
+	require.NotEmpty(t, d.TriggerWebhookReleaseBuild("", "acme/docs", "edited", forge.WebhookRelease{Tag: "v1.2.0", Notes: "Fixed notes"}))
+	receiveBuildRequest(t, requests)
+	releases = d.ReleaseNotes(repo)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "Fixed notes", releases[0].Notes)
+
+	require.NotEmpty(t, d.TriggerWebhookReleaseBuild("", "acme/docs", "deleted", forge.WebhookRelease{Tag: "v1.2.0"}))
+	receiveBuildRequest(t, requests)
+	assert.Empty(t, d.ReleaseNotes(repo))
+
+	assert.Empty(t, d.TriggerWebhookReleaseBuild("", "acme/docs", "deleted", forge.WebhookRelease{Tag: "v1.2.0"}),
+		"deleting an unknown release does not rebuild")
+}
+
+func TestDaemon_RenameWebhookRepository_MovesReleases(t *testing.T) {
+	d, _ := newWebhookEventsDaemon(t, nil)
+	d.releases.record("acme/docs", releaseFixture("v1.0.0"))
+
+	// Configured repositories keep their URL until the configuration is updated.
+	assert.Empty(t, d.RenameWebhookRepository("", "acme/docs", &forge.Repository{
+		Name: "handbook", FullName: "acme/handbook", CloneURL: "https://github.com/acme/handbook.git",
+	}))
+	assert.Empty(t, d.ReleaseNotes(&config.Repository{Name: "docs", URL: "https://github.com/acme/docs.git"}))
+	assert.Len(t, d.ReleaseNotes(&config.Repository{Name: "handbook", URL: "https://github.com/acme/handbook.git"}), 1)
+}
+
+func TestReleaseNotes_Restore(t *testing.T) {
+	store := &memoryReleaseNotesStore{}
+	notes := newReleaseNotes()
+	require.NoError(t, notes.restore(store))
+	notes.record("acme/docs", releaseFixture("v1.0.0"))
+	require.NotEmpty(t, store.data)
+
+	restored := newReleaseNotes()
+	require.NoError(t, restored.restore(store))
+	assert.Len(t, restored.forRepository(&config.Repository{URL: "https://github.com/acme/docs.git"}), 1)
+}
+
+func releaseFixture(tag string) pipeline.Release {
+	return pipeline.Release{Tag: tag, PublishedAt: time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)}
+}
+
+type memoryReleaseNotesStore struct{ data []byte }
+
+func (s *memoryReleaseNotesStore) SetReleaseNotes(data []byte) { s.data = data }
+func (s *memoryReleaseNotesStore) GetReleaseNotes() []byte     { return s.data }
@@ -55,7 +55,7 @@ func (d *Daemon) handleWebhookReceived(ctx context.Context, evt events.WebhookRe
 	matchedRepoURL, matchedBranch, matchedDocsPaths := d.matchWebhookRepo(evt, evtBranch, forgeHost, repos)
 
 	if matchedRepoURL == "" {
-		slog.Warn("Webhook did not match any known repository",
+		d.log().Warn("Webhook did not match any known repository",
 			logfields.JobID(evt.JobID),
 			slog.String("forge", evt.ForgeName),
 			slog.String("repo", evt.RepoFullName),
@@ -67,7 +67,7 @@ func (d *Daemon) handleWebhookReceived(ctx context.Context, evt events.WebhookRe
 	// branch. Ignore push events for other branches to avoid fetching refs we don't
 	// care about (and to prevent errors when feature branches are deleted).
 	if matchedBranch != "" && evtBranch != "" && evtBranch != matchedBranch {
-		slog.Info("Webhook push ignored (non-default branch)",
+		d.log().Info("Webhook push ignored (non-default branch)",
 			logfields.JobID(evt.JobID),
 			slog.String("forge", evt.ForgeName),
 			slog.String("repo", evt.RepoFullName),
@@ -78,7 +78,7 @@ func (d *Daemon) handleWebhookReceived(ctx context.Context, evt events.WebhookRe
 
 	if len(evt.ChangedFiles) > 0 {
 		if !hasDocsRelevantChange(evt.ChangedFiles, matchedDocsPaths) {
-			slog.Info("Webhook push ignored (no docs changes)",
+			d.log().Info("Webhook push ignored (no docs changes)",
 				logfields.JobID(evt.JobID),
 				slog.String("forge", evt.ForgeName),
 				slog.String("repo", evt.RepoFullName),
@@ -89,23 +89,23 @@ func (d *Daemon) handleWebhookReceived(ctx context.Context, evt events.WebhookRe
 		}
 	}
 
-	immediate := true
-	if d.config != nil && d.config.Daemon != nil && d.config.Daemon.BuildDebounce != nil && d.config.Daemon.BuildDebounce.WebhookImmediate != nil {
-		immediate = *d.config.Daemon.BuildDebounce.WebhookImmediate
-	}
-
-	if err := d.publishOrchestrationEvent(ctx, events.RepoUpdateRequested{
+	d.requestWebhookRepoUpdate(ctx, evt, events.RepoUpdateRequested{
 		JobID:       evt.JobID,
-		Immediate:   immediate,
+		Immediate:   d.webhookImmediate(),
 		RepoURL:     matchedRepoURL,
 		Branch:      strings.TrimSpace(firstNonEmpty(matchedBranch, evtBranch)),
+		CommitSHA:   evt.CommitSHA,
 		RequestedAt: time.Now(),
-	}); err != nil {
-		slog.Warn("Failed to publish repo update request",
-			logfields.JobID(evt.JobID),
-			slog.String("repo_url", matchedRepoURL),
-			logfields.Error(err))
+	})
+}
+
+// webhookImmediate reports whether webhook-triggered requests bypass the debounce quiet
+// window (daemon.build_debounce.webhook_immediate, default true).
+func (d *Daemon) webhookImmediate() bool {
+	if d.config != nil && d.config.Daemon != nil && d.config.Daemon.BuildDebounce != nil && d.config.Daemon.BuildDebounce.WebhookImmediate != nil {
+		return *d.config.Daemon.BuildDebounce.WebhookImmediate
 	}
+	return true
 }
 
 func (d *Daemon) handleWebhookWithNoRepos(ctx context.Context, evt events.WebhookReceived, evtBranch string, repos []config.Repository) bool {
@@ -115,7 +115,7 @@ func (d *Daemon) handleWebhookWithNoRepos(ctx context.Context, evt events.Webhoo
 
 	isForgeMode := d.config != nil && len(d.config.Repositories) == 0
 	if !isForgeMode {
-		slog.Warn("Webhook received but no repositories available",
+		d.log().Warn("Webhook received but no repositories available",
 			logfields.JobID(evt.JobID),
 			slog.String("forge", evt.ForgeName),
 			slog.String("repo", evt.RepoFullName),
@@ -129,7 +129,7 @@ func (d *Daemon) handleWebhookWithNoRepos(ctx context.Context, evt events.Webhoo
 		go d.discoveryRunner.SafeRun(ctx, func() bool { return d.GetStatus() == StatusRunning })
 	}
 
-	slog.Warn("Webhook received but no repositories available; will retry after discovery",
+	d.log().Warn("Webhook received but no repositories available; will retry after discovery",
 		logfields.JobID(evt.JobID),
 		slog.String("forge", evt.ForgeName),
 		slog.String("repo", evt.RepoFullName),
@@ -139,6 +139,7 @@ func (d *Daemon) handleWebhookWithNoRepos(ctx context.Context, evt events.Webhoo
 		ForgeName:    evt.ForgeName,
 		RepoFullName: evt.RepoFullName,
 		Branch:       evtBranch,
+		CommitSHA:    evt.CommitSHA,
 		ChangedFiles: append([]string(nil), evt.ChangedFiles...),
 		ReceivedAt:   evt.ReceivedAt,
 	})
@@ -199,7 +200,7 @@ func (d *Daemon) retryWebhookAfterDiscovery(ctx context.Context, evt events.Webh
 		case <-ctx.Done():
 			return
 		case <-timeout.C:
-			slog.Warn("Webhook retry timed out waiting for repositories",
+			d.log().Warn("Webhook retry timed out waiting for repositories",
 				logfields.JobID(evt.JobID),
 				slog.String("forge", evt.ForgeName),
 				slog.String("repo", evt.RepoFullName))
@@ -209,7 +210,7 @@ func (d *Daemon) retryWebhookAfterDiscovery(ctx context.Context, evt events.Webh
 			if len(repos) == 0 {
 				continue
 			}
-			slog.Info("Retrying webhook after discovery",
+			d.log().Info("Retrying webhook after discovery",
 				logfields.JobID(evt.JobID),
 				slog.String("forge", evt.ForgeName),
 				slog.String("repo", evt.RepoFullName),
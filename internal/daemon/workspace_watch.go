@@ -0,0 +1,120 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/watch"
+)
+
+// workspaceWatchReason is the BuildRequested reason for watch-triggered builds.
+const workspaceWatchReason = "workspace change"
+
+// newWorkspaceWatcher creates the persistent-workspace watcher when daemon.watch is enabled.
+func newWorkspaceWatcher(cfg *config.Config) (*watch.Watcher, error) {
+	if cfg.Daemon == nil || !cfg.Daemon.Watch.IsEnabled() {
+		return nil, nil
+	}
+	w, err := watch.New(watch.Options{
+		Debounce: cfg.Daemon.Watch.DebounceDuration(),
+		Ignore:   cfg.Daemon.Watch.Ignore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace watcher: %w", err)
+	}
+	return w, nil
+}
+
+// workspaceDir is the persistent workspace holding one working copy per repository.
+func (d *Daemon) workspaceDir() string {
+	return filepath.Join(d.config.Daemon.Storage.RepoCacheDir, "working")
+}
+
+// runWorkspaceWatcher watches repository working copies until ctx is cancelled.
+func (d *Daemon) runWorkspaceWatcher(ctx context.Context) {
+	d.syncWorkspaceWatches()
+	if err := d.workspaceWatcher.Run(ctx, func(change watch.Change) {
+		d.onWorkspaceChange(ctx, change)
+	}); err != nil {
+		d.log().Warn("Workspace watcher stopped", logfields.Error(err))
+	}
+}
+
+// syncWorkspaceWatches starts watching working copies that exist but are not yet watched,
+// e.g. repositories cloned by the latest build.
+func (d *Daemon) syncWorkspaceWatches() {
+	if d.workspaceWatcher == nil {
+		return
+	}
+	for _, repo := range d.currentReposForOrchestratedBuild() {
+		dir := filepath.Join(d.workspaceDir(), repo.Name)
+		if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+			continue
+		}
+		if err := d.workspaceWatcher.Add(dir); err != nil {
+			d.log().Warn("Failed to watch working copy", logfields.Name(repo.Name), logfields.Error(err))
+		}
+	}
+}
+
+// onWorkspaceChange requests a debounced build for the repository whose working copy changed.
+//
+// Changes observed while a build is running are dropped: they are the daemon's own
+// clone/update activity, and re-triggering on them would loop.
+func (d *Daemon) onWorkspaceChange(ctx context.Context, change watch.Change) {
+	if d.GetStatus() != StatusRunning {
+		return
+	}
+	if d.buildQueue != nil && len(d.buildQueue.GetActiveJobs()) > 0 {
+		d.log().Debug("Ignoring workspace change during build", slog.String("dir", change.Root))
+		return
+	}
+
+	name := filepath.Base(change.Root)
+	var repo *config.Repository
+	for _, r := range d.currentReposForOrchestratedBuild() {
+		if r.Name == name {
+			repo = &r
+			break
+		}
+	}
+	if repo == nil {
+		d.log().Debug("Ignoring change in unknown working copy", slog.String("dir", change.Root))
+		return
+	}
+
+	jobID := ""
+	if d.buildDebouncer != nil {
+		if planned, ok := d.buildDebouncer.PlannedJobID(); ok {
+			jobID = planned
+		}
+	}
+	if jobID == "" {
+		jobID = fmt.Sprintf("watch-%d", time.Now().UnixNano())
+	}
+
+	if err := d.publishOrchestrationEvent(ctx, events.BuildRequested{
+		JobID:       jobID,
+		Reason:      workspaceWatchReason,
+		RepoURL:     repo.URL,
+		Branch:      repo.Branch,
+		RequestedAt: time.Now(),
+	}); err != nil {
+		d.log().Warn("Failed to publish workspace build request",
+			logfields.JobID(jobID),
+			logfields.Name(repo.Name),
+			logfields.Error(err))
+		return
+	}
+	d.log().Info("Working copy changed; build requested",
+		logfields.JobID(jobID),
+		logfields.Name(repo.Name),
+		slog.Int("files", len(change.Paths)))
+}
@@ -0,0 +1,91 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon/events"
+	"git.home.luguber.info/inful/docbuilder/internal/watch"
+)
+
+func newWorkspaceWatchTestDaemon(t *testing.T) (*Daemon, string) {
+	t.Helper()
+	cacheDir := t.TempDir()
+	repoDir := filepath.Join(cacheDir, "working", "repo-1")
+	require.NoError(t, os.MkdirAll(repoDir, 0o750))
+
+	cfg := &config.Config{
+		Repositories: []config.Repository{{
+			Name:   "repo-1",
+			URL:    "https://example.invalid/repo-1.git",
+			Branch: "main",
+		}},
+		Daemon: &config.DaemonConfig{
+			Storage: config.StorageConfig{RepoCacheDir: cacheDir},
+			Watch:   &config.WatchConfig{Enabled: true, Debounce: "20ms"},
+		},
+	}
+	w, err := newWorkspaceWatcher(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, w)
+	t.Cleanup(func() { _ = w.Close() })
+
+	bus := events.NewBus()
+	t.Cleanup(bus.Close)
+
+	d := &Daemon{config: cfg, orchestrationBus: bus, workspaceWatcher: w}
+	d.status.Store(StatusRunning)
+	return d, repoDir
+}
+
+func TestNewWorkspaceWatcher_DisabledByDefault(t *testing.T) {
+	w, err := newWorkspaceWatcher(&config.Config{Daemon: &config.DaemonConfig{}})
+	require.NoError(t, err)
+	require.Nil(t, w)
+}
+
+func TestWorkspaceWatcher_ChangePublishesRepoBuildRequest(t *testing.T) {
+	d, repoDir := newWorkspaceWatchTestDaemon(t)
+
+	buildRequestedCh, unsubscribe := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go d.runWorkspaceWatcher(ctx)
+	require.Eventually(t, func() bool { return len(d.workspaceWatcher.Roots()) == 1 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# edited"), 0o600))
+
+	select {
+	case got := <-buildRequestedCh:
+		require.Equal(t, workspaceWatchReason, got.Reason)
+		require.Equal(t, "https://example.invalid/repo-1.git", got.RepoURL)
+		require.Equal(t, "main", got.Branch)
+		require.False(t, got.Immediate)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for BuildRequested")
+	}
+}
+
+func TestWorkspaceWatcher_IgnoresUnknownWorkingCopy(t *testing.T) {
+	d, repoDir := newWorkspaceWatchTestDaemon(t)
+
+	buildRequestedCh, unsubscribe := events.Subscribe[events.BuildRequested](d.orchestrationBus, 10)
+	defer unsubscribe()
+
+	other := filepath.Join(filepath.Dir(repoDir), "stale-repo")
+	d.onWorkspaceChange(t.Context(), watch.Change{Root: other, Paths: []string{filepath.Join(other, "a.md")}})
+
+	select {
+	case got := <-buildRequestedCh:
+		t.Fatalf("unexpected build request: %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
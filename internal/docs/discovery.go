@@ -22,14 +22,17 @@ type DocFile struct {
 	Path             string            // Absolute path to the file
 	RelativePath     string            // Path relative to the docs directory
 	DocsBase         string            // The configured docs base path for this repo (e.g., "docs" or ".")
-	Repository       string            // Repository name
+	Repository       string            // Repository name, or the section name for monorepo sections
 	Forge            string            // Optional forge namespace (empty when single or not namespaced)
+	Organization     string            // Optional organization/group namespace below the forge (e.g. "acme" or "acme/platform")
 	Section          string            // Documentation section/directory
 	Name             string            // File name without extension
 	Extension        string            // File extension
 	Content          []byte            // File content (loaded on demand)
-	TransformedBytes []byte            // Content after transform pipeline (populated during copyContentFiles)
+	TransformedBytes []byte            // Transformed content for README-based indexes (kept by the content pipeline for root README and index files)
 	Metadata         map[string]string // Additional metadata from config
+	Tags             []string          // Site tags added to the page (repository page_tags)
+	Categories       []string          // Site categories added to the page (repository page_categories)
 	IsAsset          bool              // True for images and other non-markdown files
 }
 
@@ -60,8 +63,13 @@ func NewDiscovery(repositories []config.Repository, buildCfg *config.BuildConfig
 func (d *Discovery) DiscoverDocs(repoPaths map[string]string) ([]DocFile, error) {
 	d.docFiles = make([]DocFile, 0)
 
-	// Determine if this is a single-repository build
-	d.isSingleRepo = len(repoPaths) == 1
+	// Determine if this is a single-repository build. Each monorepo section is its own
+	// site area, so a repository with several sections is not a single-repository build.
+	areas := 0
+	for repoName := range repoPaths {
+		areas += max(len(d.repositories[repoName].Sections), 1)
+	}
+	d.isSingleRepo = areas == 1
 
 	// Determine forge namespacing policy using global build config.
 	mode := config.NamespacingAuto
@@ -115,6 +123,10 @@ func (d *Discovery) DiscoverDocs(repoPaths map[string]string) ([]DocFile, error)
 		if namespaceForges {
 			forgeNS = repo.Tags["forge_type"]
 		}
+		orgNS := ""
+		if d.buildConfig != nil {
+			orgNS = OrganizationNamespace(repo, d.buildConfig.NamespaceOrgDepth)
+		}
 		missingDocsPaths := 0
 		for _, docsPath := range repo.Paths {
 			fullDocsPath := filepath.Join(repoPath, docsPath)
@@ -128,7 +140,11 @@ func (d *Discovery) DiscoverDocs(repoPaths map[string]string) ([]DocFile, error)
 				continue
 			}
 
-			files, err := d.walkDocsDirectory(fullDocsPath, repoName, forgeNS, docsPath, repo.Tags)
+			area := repoName
+			if section := repo.SectionFor(docsPath); section != nil {
+				area = section.Name
+			}
+			files, err := d.walkDocsDirectory(fullDocsPath, area, forgeNS, orgNS, docsPath, repo.Tags)
 			if err != nil {
 				return nil, errors.WrapError(err, errors.CategoryDocs, "documentation directory walk failed").
 					WithContext("path", docsPath).
@@ -137,6 +153,10 @@ func (d *Discovery) DiscoverDocs(repoPaths map[string]string) ([]DocFile, error)
 					Build()
 			}
 
+			for i := range files {
+				files[i].Tags = repo.PageTags
+				files[i].Categories = repo.PageCategories
+			}
 			d.docFiles = append(d.docFiles, files...)
 		}
 
@@ -166,7 +186,7 @@ func (d *Discovery) DiscoverDocs(repoPaths map[string]string) ([]DocFile, error)
 }
 
 // walkDocsDirectory recursively walks a documentation directory.
-func (d *Discovery) walkDocsDirectory(docsPath, repoName, forgeNS, relativePath string, metadata map[string]string) ([]DocFile, error) {
+func (d *Discovery) walkDocsDirectory(docsPath, repoName, forgeNS, orgNS, relativePath string, metadata map[string]string) ([]DocFile, error) {
 	var files []DocFile
 
 	err := filepath.Walk(docsPath, func(path string, info os.FileInfo, err error) error {
@@ -220,6 +240,7 @@ func (d *Discovery) walkDocsDirectory(docsPath, repoName, forgeNS, relativePath
 			DocsBase:     relativePath,
 			Repository:   repoName,
 			Forge:        forgeNS,
+			Organization: orgNS,
 			Section:      section,
 			Name:         strings.TrimSuffix(info.Name(), filepath.Ext(info.Name())),
 			Extension:    filepath.Ext(info.Name()),
@@ -269,9 +290,10 @@ func (df *DocFile) GetHugoPath(isSingleRepo bool) string {
 	//   Single repository:           content/{section}/{name}.md
 	//   Multiple repos, single forge: content/{repository}/{section}/{name}.md
 	//   Multiple forges:             content/{forge}/{repository}/{section}/{name}.md
+	//   Organization namespacing:    content/{forge}/{org}/{repository}/{section}/{name}.md
 	parts := []string{"content"}
-	if df.Forge != "" {
-		parts = append(parts, strings.ToLower(df.Forge))
+	if ns := df.Namespace(); ns != "" {
+		parts = append(parts, strings.ToLower(ns))
 	}
 
 	// Skip repository namespace for single-repository builds
@@ -294,6 +316,24 @@ func (df *DocFile) GetHugoPath(isSingleRepo bool) string {
 	return filepath.Join(parts...)
 }
 
+// Namespace returns the content path prefix placed before the repository
+// ("forge", "org", "forge/org", or empty).
+func (df *DocFile) Namespace() string {
+	return JoinNamespace(df.Forge, df.Organization)
+}
+
+// JoinNamespace joins non-empty forge and organization namespace segments with "/".
+func JoinNamespace(forge, organization string) string {
+	switch {
+	case forge == "":
+		return organization
+	case organization == "":
+		return forge
+	default:
+		return forge + "/" + organization
+	}
+}
+
 // isMarkdownFile checks if a file is a markdown file.
 func isMarkdownFile(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -357,8 +397,8 @@ func (d *Discovery) GetDocFilesByRepository() map[string][]DocFile {
 	for i := range d.docFiles {
 		file := &d.docFiles[i]
 		key := file.Repository
-		if file.Forge != "" {
-			key = file.Forge + "/" + key
+		if ns := file.Namespace(); ns != "" {
+			key = ns + "/" + key
 		}
 		result[key] = append(result[key], *file)
 	}
@@ -371,8 +411,8 @@ func (d *Discovery) GetDocFilesBySection() map[string][]DocFile {
 	for i := range d.docFiles {
 		file := &d.docFiles[i]
 		repoKey := file.Repository
-		if file.Forge != "" {
-			repoKey = file.Forge + "/" + repoKey
+		if ns := file.Namespace(); ns != "" {
+			repoKey = ns + "/" + repoKey
 		}
 		key := repoKey + "/" + file.Section
 		result[key] = append(result[key], *file)
@@ -247,6 +247,110 @@ func TestForgeNamespacingAutoSingleForge(t *testing.T) {
 	}
 }
 
+func TestOrganizationNamespacing(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mkRepo := func(repo config.Repository) (config.Repository, string) {
+		repoDir := filepath.Join(tempDir, repo.Name)
+		if err := os.MkdirAll(filepath.Join(repoDir, "docs"), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoDir, "docs", "page.md"), []byte("# Page"), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		repo.Paths = []string{"docs"}
+		return repo, repoDir
+	}
+
+	r1, p1 := mkRepo(config.Repository{Name: "api", Tags: map[string]string{"forge_type": "gitlab", "full_name": "Acme/platform/api"}})
+	r2, p2 := mkRepo(config.Repository{Name: "web", URL: "git@github.com:beta/web.git", Tags: map[string]string{"forge_type": "github"}})
+	repos := []config.Repository{r1, r2}
+	repoPaths := map[string]string{r1.Name: p1, r2.Name: p2}
+
+	for depth, want := range map[int]map[string]string{
+		1: {"api": filepath.Join("content", "gitlab", "acme", "api", "page.md"), "web": filepath.Join("content", "github", "beta", "web", "page.md")},
+		2: {"api": filepath.Join("content", "gitlab", "acme", "platform", "api", "page.md"), "web": filepath.Join("content", "github", "beta", "web", "page.md")},
+	} {
+		d := NewDiscovery(repos, &config.BuildConfig{NamespaceForges: config.NamespacingAuto, NamespaceOrgDepth: depth})
+		files, err := d.DiscoverDocs(repoPaths)
+		if err != nil {
+			t.Fatalf("DiscoverDocs: %v", err)
+		}
+		for _, f := range files {
+			if got := f.GetHugoPath(false); got != want[f.Repository] {
+				t.Errorf("depth %d: %s path = %s, want %s", depth, f.Repository, got, want[f.Repository])
+			}
+		}
+	}
+}
+
+func TestMonorepoSections(t *testing.T) {
+	repoDir := t.TempDir()
+	for _, dir := range []string{"services/api/docs", "web/docs"} {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoDir, dir, "guide.md"), []byte("# Guide"), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	repo := config.Repository{
+		Name:  "mono",
+		Paths: []string{"services/api/docs", "web/docs"},
+		Sections: []config.RepositorySection{
+			{Name: "api", Path: "services/api/docs"},
+			{Name: "frontend", Path: "web/docs"},
+		},
+	}
+	d := NewDiscovery([]config.Repository{repo}, &config.BuildConfig{})
+	files, err := d.DiscoverDocs(map[string]string{"mono": repoDir})
+	if err != nil {
+		t.Fatalf("DiscoverDocs: %v", err)
+	}
+	if d.IsSingleRepo() {
+		t.Error("repository with two sections should not be a single-repository build")
+	}
+
+	want := map[string]string{
+		"api":      filepath.Join("content", "api", "guide.md"),
+		"frontend": filepath.Join("content", "frontend", "guide.md"),
+	}
+	if len(files) != len(want) {
+		t.Fatalf("discovered %d files, want %d", len(files), len(want))
+	}
+	for _, f := range files {
+		if got := f.GetHugoPath(d.IsSingleRepo()); got != want[f.Repository] {
+			t.Errorf("%s path = %s, want %s", f.Repository, got, want[f.Repository])
+		}
+		if sec := repo.SectionFor(f.DocsBase); sec == nil || sec.Name != f.Repository {
+			t.Errorf("%s docs base = %s", f.Repository, f.DocsBase)
+		}
+	}
+}
+
+func TestOrganizationNamespace(t *testing.T) {
+	tests := []struct {
+		repo  config.Repository
+		depth int
+		want  string
+	}{
+		{config.Repository{Tags: map[string]string{"full_name": "acme/api"}}, 0, ""},
+		{config.Repository{Tags: map[string]string{"full_name": "acme/api"}}, 1, "acme"},
+		{config.Repository{Tags: map[string]string{"full_name": "acme/platform/api"}}, 1, "acme"},
+		{config.Repository{Tags: map[string]string{"full_name": "acme/platform/api"}}, 3, "acme/platform"},
+		{config.Repository{URL: "https://git.example.com/Org/repo.git"}, 1, "org"},
+		{config.Repository{URL: "ssh://git@git.example.com:2222/org/sub/repo.git"}, 2, "org/sub"},
+		{config.Repository{URL: "./docs"}, 1, ""},
+		{config.Repository{URL: "/home/user/repo"}, 1, ""},
+	}
+	for _, tt := range tests {
+		if got := OrganizationNamespace(tt.repo, tt.depth); got != tt.want {
+			t.Errorf("OrganizationNamespace(%+v, %d) = %q, want %q", tt.repo, tt.depth, got, tt.want)
+		}
+	}
+}
+
 func TestDiscoveryWithTestForgeIntegration(t *testing.T) {
 	t.Run("LargeScaleRepositoryDiscovery", testLargeScaleRepositoryDiscovery)
 	t.Run("MultiPlatformDiscoveryValidation", testMultiPlatformDiscoveryValidation)
@@ -0,0 +1,51 @@
+package docs
+
+import (
+	"net/url"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// OrganizationNamespace returns the first depth organization/group segments owning repo,
+// lowercased and joined with "/" (e.g. "acme/platform" for GitLab subgroups). The owner
+// path is taken from the forge "full_name" tag, falling back to the clone URL. Local
+// repositories and depth <= 0 yield an empty namespace.
+func OrganizationNamespace(repo config.Repository, depth int) string {
+	if depth <= 0 {
+		return ""
+	}
+	fullName := repo.Tags["full_name"]
+	if fullName == "" {
+		fullName = repositoryPathFromURL(repo.URL)
+	}
+	segments := strings.Split(strings.Trim(fullName, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	owner := segments[:len(segments)-1]
+	if len(owner) > depth {
+		owner = owner[:depth]
+	}
+	return strings.ToLower(strings.Join(owner, "/"))
+}
+
+// repositoryPathFromURL extracts "owner/.../repo" from a remote clone URL
+// (https://host/owner/repo.git or git@host:owner/repo.git). Local paths return "".
+func repositoryPathFromURL(raw string) string {
+	var p string
+	switch {
+	case strings.Contains(raw, "://"):
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "file" {
+			return ""
+		}
+		p = u.Path
+	case strings.Contains(raw, "@") && strings.Contains(raw, ":"):
+		// scp-like syntax: user@host:owner/repo.git
+		p = raw[strings.Index(raw, ":")+1:]
+	default:
+		return ""
+	}
+	return strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+}
@@ -335,3 +335,65 @@ func NewBuildReportGenerated(buildID string, report BuildReportData) (*BuildRepo
 		Report: report,
 	}, nil
 }
+
+// BuildReportStored carries the complete build report document (build-report.json schema)
+// so that reports remain retrievable after the output directory is replaced.
+type BuildReportStored struct {
+	BaseEvent
+}
+
+// NewBuildReportStored creates a BuildReportStored event from an encoded report document.
+func NewBuildReportStored(buildID string, document []byte) (*BuildReportStored, error) {
+	if !json.Valid(document) {
+		return nil, errors.EventStoreError("build report document is not valid JSON").
+			WithContext("build_id", buildID).
+			Build()
+	}
+
+	return &BuildReportStored{
+		BaseEvent: BaseEvent{
+			EventBuildID:   buildID,
+			EventType:      "BuildReportStored",
+			EventTimestamp: time.Now(),
+			EventPayload:   document,
+		},
+	}, nil
+}
+
+// WebhookSkippedData describes a webhook delivery that did not request a build.
+type WebhookSkippedData struct {
+	Forge      string `json:"forge,omitempty"`
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Reason     string `json:"reason"`
+}
+
+// WebhookSkipped records a webhook delivery rejected before any build was requested,
+// such as a push to a branch outside the configured allowlists. It is not tied to a
+// build, so its build ID is empty.
+type WebhookSkipped struct {
+	BaseEvent
+	Data WebhookSkippedData `json:"data"`
+}
+
+// NewWebhookSkipped creates a WebhookSkipped event. metadata may carry correlation
+// IDs such as the request ID of the delivery.
+func NewWebhookSkipped(data WebhookSkippedData, metadata map[string]string) (*WebhookSkipped, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.EventStoreError("failed to marshal WebhookSkipped payload").
+			WithCause(err).
+			WithContext("repository", data.Repository).
+			Build()
+	}
+
+	return &WebhookSkipped{
+		BaseEvent: BaseEvent{
+			EventType:      "WebhookSkipped",
+			EventTimestamp: time.Now(),
+			EventPayload:   payload,
+			EventMetadata:  metadata,
+		},
+		Data: data,
+	}, nil
+}
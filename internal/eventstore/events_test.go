@@ -196,3 +196,30 @@ func TestBuildFailedFields(t *testing.T) {
 		t.Errorf("expected error %s, got %s", errorMsg, event.Error)
 	}
 }
+
+func TestWebhookSkippedFields(t *testing.T) {
+	data := WebhookSkippedData{Forge: "github", Repository: "org/repo", Branch: "feature/x", Reason: "branch_not_allowed"}
+
+	event, err := NewWebhookSkipped(data, map[string]string{"request_id": "req-1"})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	if event.BuildID() != "" {
+		t.Errorf("expected empty build_id, got %s", event.BuildID())
+	}
+	if event.Type() != "WebhookSkipped" {
+		t.Errorf("expected event_type WebhookSkipped, got %s", event.Type())
+	}
+	if event.Metadata()["request_id"] != "req-1" {
+		t.Errorf("expected request_id metadata, got %v", event.Metadata())
+	}
+
+	var decoded WebhookSkippedData
+	if err := json.Unmarshal(event.Payload(), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if decoded != data {
+		t.Errorf("expected payload %+v, got %+v", data, decoded)
+	}
+}
@@ -0,0 +1,24 @@
+package eventstore
+
+import (
+	"context"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// LoadBuildReport returns the most recent report document stored for a build.
+// It returns a not-found error when the build has no stored report.
+func LoadBuildReport(ctx context.Context, store Store, buildID string) ([]byte, error) {
+	events, err := store.GetByBuildID(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type() == "BuildReportStored" {
+			return events[i].Payload(), nil
+		}
+	}
+	return nil, errors.NotFoundError("build report").
+		WithContext("build_id", buildID).
+		Build()
+}
@@ -0,0 +1,44 @@
+package eventstore
+
+import (
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestLoadBuildReport_ReturnsLatestDocument(t *testing.T) {
+	store, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := t.Context()
+
+	if _, err := LoadBuildReport(ctx, store, "build-1"); err == nil {
+		t.Fatal("expected not found for build without report")
+	} else if ce, ok := errors.AsClassified(err); !ok || ce.Category() != errors.CategoryNotFound {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+
+	if _, err := NewBuildReportStored("build-1", []byte("{broken")); err == nil {
+		t.Fatal("expected invalid document to be rejected")
+	}
+
+	for _, doc := range []string{`{"schema_version":1,"outcome":"failed"}`, `{"schema_version":1,"outcome":"success"}`} {
+		event, err := NewBuildReportStored("build-1", []byte(doc))
+		if err != nil {
+			t.Fatalf("new event: %v", err)
+		}
+		if err := store.Append(ctx, event.BuildID(), event.Type(), event.Payload(), nil); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	doc, err := LoadBuildReport(ctx, store, "build-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if string(doc) != `{"schema_version":1,"outcome":"success"}` {
+		t.Fatalf("expected latest report, got %s", doc)
+	}
+}
@@ -0,0 +1,207 @@
+// Package feedback stores "Was this page helpful?" ratings submitted from the docs site
+// and aggregates them per page and repository.
+package feedback
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	_ "modernc.org/sqlite"
+)
+
+// Entry is a single feedback submission.
+type Entry struct {
+	Page       string    `json:"page"`
+	Repository string    `json:"repository,omitempty"`
+	Helpful    bool      `json:"helpful"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PageSummary aggregates feedback for one page.
+type PageSummary struct {
+	Page           string    `json:"page"`
+	Repository     string    `json:"repository,omitempty"`
+	Helpful        int       `json:"helpful"`
+	NotHelpful     int       `json:"not_helpful"`
+	Comments       []Comment `json:"comments,omitempty"`
+	LastSubmission time.Time `json:"last_submission"`
+}
+
+// Comment is a free-text comment attached to a rating.
+type Comment struct {
+	Helpful   bool      `json:"helpful"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RepositorySummary aggregates feedback for all pages of one repository.
+type RepositorySummary struct {
+	Repository string        `json:"repository"`
+	Helpful    int           `json:"helpful"`
+	NotHelpful int           `json:"not_helpful"`
+	Pages      []PageSummary `json:"pages"`
+}
+
+// Report is the aggregated feedback report served to administrators.
+type Report struct {
+	GeneratedAt  time.Time           `json:"generated_at"`
+	Total        int                 `json:"total"`
+	Repositories []RepositorySummary `json:"repositories"`
+}
+
+// maxCommentsPerPage bounds the number of recent comments included per page in a report.
+const maxCommentsPerPage = 20
+
+// SQLiteStore persists feedback in SQLite.
+type SQLiteStore struct {
+	db *sql.DB
+	mu sync.RWMutex
+}
+
+// NewSQLiteStore opens (and initializes) a feedback database.
+// Use ":memory:" for an in-memory database, or a file path for persistent storage.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.CategoryInternal, "could not open feedback database").
+			WithContext("path", dbPath).
+			Build()
+	}
+	// Single connection keeps ":memory:" databases consistent across queries.
+	db.SetMaxOpenConns(1)
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS feedback (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		page TEXT NOT NULL,
+		repository TEXT NOT NULL DEFAULT '',
+		helpful INTEGER NOT NULL,
+		comment TEXT NOT NULL DEFAULT '',
+		created_at INTEGER NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_feedback_page ON feedback(page);
+	CREATE INDEX IF NOT EXISTS idx_feedback_repository ON feedback(repository);
+	`
+	if _, err := db.ExecContext(context.Background(), schema); err != nil {
+		_ = db.Close() // Best effort cleanup on initialization error
+		return nil, errors.WrapError(err, errors.CategoryInternal, "failed to initialize feedback schema").
+			WithContext("path", dbPath).
+			Build()
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Record stores a feedback entry. A zero CreatedAt is set to the current time.
+func (s *SQLiteStore) Record(ctx context.Context, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	helpful := 0
+	if e.Helpful {
+		helpful = 1
+	}
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO feedback (page, repository, helpful, comment, created_at) VALUES (?, ?, ?, ?, ?)",
+		e.Page, e.Repository, helpful, e.Comment, e.CreatedAt.Unix(),
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.CategoryInternal, "failed to record feedback").
+			WithContext("page", e.Page).
+			Build()
+	}
+	return nil
+}
+
+// Report aggregates all feedback, optionally restricted to one repository.
+// Pages are ordered by most "not helpful" ratings first.
+func (s *SQLiteStore) Report(ctx context.Context, repository string) (*Report, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query := "SELECT page, repository, helpful, comment, created_at FROM feedback"
+	var args []any
+	if repository != "" {
+		query += " WHERE repository = ?"
+		args = append(args, repository)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.CategoryInternal, "failed to query feedback").Build()
+	}
+	defer func() { _ = rows.Close() }()
+
+	report := &Report{GeneratedAt: time.Now().UTC()}
+	pages := make(map[string]*PageSummary)
+	for rows.Next() {
+		var (
+			e       Entry
+			helpful int
+			created int64
+		)
+		if err := rows.Scan(&e.Page, &e.Repository, &helpful, &e.Comment, &created); err != nil {
+			return nil, errors.WrapError(err, errors.CategoryInternal, "failed to scan feedback row").Build()
+		}
+		e.Helpful = helpful == 1
+		e.CreatedAt = time.Unix(created, 0).UTC()
+
+		ps, ok := pages[e.Page]
+		if !ok {
+			ps = &PageSummary{Page: e.Page, Repository: e.Repository, LastSubmission: e.CreatedAt}
+			pages[e.Page] = ps
+		}
+		if e.Helpful {
+			ps.Helpful++
+		} else {
+			ps.NotHelpful++
+		}
+		if e.Comment != "" && len(ps.Comments) < maxCommentsPerPage {
+			ps.Comments = append(ps.Comments, Comment{Helpful: e.Helpful, Text: e.Comment, CreatedAt: e.CreatedAt})
+		}
+		report.Total++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.CategoryInternal, "error during feedback iteration").Build()
+	}
+
+	byRepo := make(map[string]*RepositorySummary)
+	for _, ps := range pages {
+		rs, ok := byRepo[ps.Repository]
+		if !ok {
+			rs = &RepositorySummary{Repository: ps.Repository}
+			byRepo[ps.Repository] = rs
+		}
+		rs.Helpful += ps.Helpful
+		rs.NotHelpful += ps.NotHelpful
+		rs.Pages = append(rs.Pages, *ps)
+	}
+	for _, rs := range byRepo {
+		sort.Slice(rs.Pages, func(i, j int) bool {
+			if rs.Pages[i].NotHelpful != rs.Pages[j].NotHelpful {
+				return rs.Pages[i].NotHelpful > rs.Pages[j].NotHelpful
+			}
+			return rs.Pages[i].Page < rs.Pages[j].Page
+		})
+		report.Repositories = append(report.Repositories, *rs)
+	}
+	sort.Slice(report.Repositories, func(i, j int) bool {
+		return report.Repositories[i].Repository < report.Repositories[j].Repository
+	})
+	return report, nil
+}
+
+// Close closes the database connection.
+func (s *SQLiteStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
+}
@@ -0,0 +1,56 @@
+package feedback
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSQLiteStore_Report(t *testing.T) {
+	store, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ctx := t.Context()
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []Entry{
+		{Page: "/alpha/intro/", Repository: "alpha", Helpful: true, CreatedAt: base},
+		{Page: "/alpha/intro/", Repository: "alpha", Helpful: false, Comment: "missing example", CreatedAt: base.Add(time.Hour)},
+		{Page: "/alpha/setup/", Repository: "alpha", Helpful: false, CreatedAt: base},
+		{Page: "/alpha/setup/", Repository: "alpha", Helpful: false, CreatedAt: base},
+		{Page: "/beta/", Repository: "beta", Helpful: true, CreatedAt: base},
+	}
+	for _, e := range entries {
+		if err := store.Record(ctx, e); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	report, err := store.Report(ctx, "")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.Total != 5 || len(report.Repositories) != 2 {
+		t.Fatalf("unexpected report totals: %+v", report)
+	}
+	alpha := report.Repositories[0]
+	if alpha.Repository != "alpha" || alpha.Helpful != 1 || alpha.NotHelpful != 3 {
+		t.Fatalf("unexpected alpha summary: %+v", alpha)
+	}
+	if alpha.Pages[0].Page != "/alpha/setup/" {
+		t.Fatalf("expected least helpful page first, got %q", alpha.Pages[0].Page)
+	}
+	intro := alpha.Pages[1]
+	if len(intro.Comments) != 1 || intro.Comments[0].Text != "missing example" || !intro.LastSubmission.Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected intro summary: %+v", intro)
+	}
+
+	filtered, err := store.Report(ctx, "beta")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if filtered.Total != 1 || len(filtered.Repositories) != 1 || filtered.Repositories[0].Repository != "beta" {
+		t.Fatalf("unexpected filtered report: %+v", filtered)
+	}
+}
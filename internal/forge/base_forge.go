@@ -11,6 +11,7 @@ import (
 	"path"
 	"strings"
 
+	"git.home.luguber.info/inful/docbuilder/internal/auth/apptoken"
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 )
 
@@ -20,6 +21,8 @@ type BaseForge struct {
 	httpClient *http.Client
 	apiURL     string
 	token      string
+	// tokenSource, when set, supplies renewing access tokens instead of token.
+	tokenSource apptoken.Source
 
 	// Forge-specific customization hooks
 	authHeaderPrefix string // "Bearer " for GitHub/GitLab, "token " for Forgejo
@@ -107,7 +110,16 @@ func (b *BaseForge) NewRequest(ctx context.Context, method, endpoint string, bod
 	}
 
 	// Set common headers
-	req.Header.Set("Authorization", b.authHeaderPrefix+b.token)
+	token := b.token
+	if b.tokenSource != nil {
+		if token, err = b.tokenSource.Token(ctx); err != nil {
+			return nil, errors.AuthError("failed to obtain forge access token").
+				WithCause(err).
+				WithContext("url", u.String()).
+				Build()
+		}
+	}
+	req.Header.Set("Authorization", b.authHeaderPrefix+token)
 	req.Header.Set("User-Agent", "DocBuilder/1.0")
 
 	// Apply forge-specific custom headers
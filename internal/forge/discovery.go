@@ -118,65 +118,19 @@ func (ds *DiscoveryService) discoverForge(ctx context.Context, client Client) ([
 			Build()
 	}
 
-	// Determine which organizations/groups to scan.
-	// If none are configured, enter auto-discovery mode and enumerate all accessible organizations.
 	var (
-		targetOrgs       []string
-		organizations    []*Organization
-		hasPrelistedOrgs bool
-		organizationsErr error
-		repositories     []*Repository
-		repositoriesErr  error
+		organizations []*Organization
+		repositories  []*Repository
+		err           error
 	)
-
-	targetOrgs = append(targetOrgs, forgeConfig.Organizations...)
-	targetOrgs = append(targetOrgs, forgeConfig.Groups...)
-
-	if len(targetOrgs) == 0 {
-		slog.Info("Entering auto-discovery mode (no organizations/groups configured)", "forge", client.GetName())
-		orgs, err := client.ListOrganizations(ctx)
-		if err != nil {
-			return nil, nil, nil, errors.ForgeError("failed to list organizations during auto-discovery").
-				WithCause(err).
-				WithContext("forge", client.GetName()).
-				Build()
-		}
-		organizations = orgs
-		hasPrelistedOrgs = true
-		for _, org := range orgs {
-			targetOrgs = append(targetOrgs, org.Name)
-		}
-		slog.Info("Auto-discovered organizations", "forge", client.GetName(), "count", len(orgs))
-	}
-
-	// Fetch org metadata and repositories concurrently where possible.
-	// If we already listed orgs for auto-discovery, reuse that result.
-	var fetchWG sync.WaitGroup
-	if !hasPrelistedOrgs {
-		fetchWG.Add(1)
-		go func() {
-			defer fetchWG.Done()
-			organizations, organizationsErr = client.ListOrganizations(ctx)
-		}()
-	}
-
-	fetchWG.Add(1)
-	go func() {
-		defer fetchWG.Done()
-		repositories, repositoriesErr = client.ListRepositories(ctx, targetOrgs)
-	}()
-
-	fetchWG.Wait()
-
-	if organizationsErr != nil {
-		slog.Warn("Failed to get organization metadata", "forge", client.GetName(), "error", organizationsErr)
+	if forgeConfig.HasRepositoryAllowlist() {
 		organizations = make([]*Organization, 0)
+		repositories, err = ds.fetchListedRepositories(ctx, client, forgeConfig)
+	} else {
+		repositories, organizations, err = ds.listOrganizationRepositories(ctx, client, forgeConfig)
 	}
-	if repositoriesErr != nil {
-		return nil, organizations, nil, errors.ForgeError("failed to list repositories for forge").
-			WithCause(repositoriesErr).
-			WithContext("forge", client.GetName()).
-			Build()
+	if err != nil {
+		return nil, organizations, nil, err
 	}
 
 	// Ensure repository metadata includes forge identity for downstream conversion (auth, namespacing, edit links).
@@ -243,8 +197,10 @@ func (ds *DiscoveryService) discoverForge(ctx context.Context, client Client) ([
 			defer mu.Unlock()
 			decision := ds.filterDecision(r)
 			if decision.include {
+				r.SkipReason = ""
 				validRepos = append(validRepos, r)
 			} else {
+				r.SkipReason = decision.reason
 				filteredRepos = append(filteredRepos, r)
 				attrs := []any{
 					"forge", client.GetName(),
@@ -299,13 +255,88 @@ func (ds *DiscoveryService) discoverForge(ctx context.Context, client Client) ([
 	return validRepos, organizations, filteredRepos, nil
 }
 
+// listOrganizationRepositories lists the repositories of the forge's organizations and
+// groups, enumerating all accessible organizations when none are configured.
+func (ds *DiscoveryService) listOrganizationRepositories(ctx context.Context, client Client, forgeConfig *config.ForgeConfig) ([]*Repository, []*Organization, error) {
+	// Determine which organizations/groups to scan.
+	// If none are configured, enter auto-discovery mode and enumerate all accessible organizations.
+	var (
+		targetOrgs       []string
+		organizations    []*Organization
+		hasPrelistedOrgs bool
+		organizationsErr error
+		repositories     []*Repository
+		repositoriesErr  error
+	)
+
+	targetOrgs = append(targetOrgs, forgeConfig.Organizations...)
+	targetOrgs = append(targetOrgs, forgeConfig.Groups...)
+
+	if len(targetOrgs) == 0 {
+		slog.Info("Entering auto-discovery mode (no organizations/groups configured)", "forge", client.GetName())
+		orgs, err := client.ListOrganizations(ctx)
+		if err != nil {
+			return nil, nil, errors.ForgeError("failed to list organizations during auto-discovery").
+				WithCause(err).
+				WithContext("forge", client.GetName()).
+				Build()
+		}
+		organizations = orgs
+		hasPrelistedOrgs = true
+		for _, org := range orgs {
+			targetOrgs = append(targetOrgs, org.Name)
+		}
+		slog.Info("Auto-discovered organizations", "forge", client.GetName(), "count", len(orgs))
+	}
+
+	// Fetch org metadata and repositories concurrently where possible.
+	// If we already listed orgs for auto-discovery, reuse that result.
+	var fetchWG sync.WaitGroup
+	if !hasPrelistedOrgs {
+		fetchWG.Add(1)
+		go func() {
+			defer fetchWG.Done()
+			organizations, organizationsErr = client.ListOrganizations(ctx)
+		}()
+	}
+
+	fetchWG.Add(1)
+	go func() {
+		defer fetchWG.Done()
+		repositories, repositoriesErr = client.ListRepositories(ctx, targetOrgs)
+	}()
+
+	fetchWG.Wait()
+
+	if organizationsErr != nil {
+		slog.Warn("Failed to get organization metadata", "forge", client.GetName(), "error", organizationsErr)
+		organizations = make([]*Organization, 0)
+	}
+	if repositoriesErr != nil {
+		return nil, organizations, errors.ForgeError("failed to list repositories for forge").
+			WithCause(repositoriesErr).
+			WithContext("forge", client.GetName()).
+			Build()
+	}
+
+	return repositories, organizations, nil
+}
+
 // shouldIncludeRepository determines if a repository should be included based on filtering config.
 
 func (ds *DiscoveryService) filterDecision(repo *Repository) repoFilterDecision {
-	// Skip archived repositories
-	if repo.Archived {
+	// Apply the policy of the repository's forge; archived repositories are skipped by default
+	forgeConfig := ds.forgeConfig(repo.Metadata["forge_name"])
+	if repo.Archived && forgeConfig.SkipsArchived() {
 		return repoFilterDecision{include: false, reason: "archived"}
 	}
+	if repo.Fork && forgeConfig != nil && forgeConfig.SkipForks {
+		return repoFilterDecision{include: false, reason: "fork", detail: forgeConfig.Name}
+	}
+	// Repositories whose forge reports no push time are kept
+	if within := forgeConfig.PushedWithin(); within > 0 && !repo.PushedAt.IsZero() && time.Since(repo.PushedAt) > within {
+		return repoFilterDecision{include: false, reason: "inactive", detail: forgeConfig.Name}
+	}
 
 	// Check for .docignore file
 	if repo.HasDocIgnore {
@@ -318,15 +349,15 @@ func (ds *DiscoveryService) filterDecision(repo *Repository) repoFilterDecision
 	}
 
 	// Check include patterns
+	includedBy := ""
 	if len(ds.filtering.IncludePatterns) > 0 {
-		included := false
 		for _, pattern := range ds.filtering.IncludePatterns {
 			if matchesPattern(repo.Name, pattern) || matchesPattern(repo.FullName, pattern) {
-				included = true
+				includedBy = pattern
 				break
 			}
 		}
-		if !included {
+		if includedBy == "" {
 			return repoFilterDecision{include: false, reason: "include_patterns_miss"}
 		}
 	}
@@ -338,7 +369,21 @@ func (ds *DiscoveryService) filterDecision(repo *Repository) repoFilterDecision
 		}
 	}
 
-	return repoFilterDecision{include: true, reason: "included"}
+	return repoFilterDecision{include: true, reason: "included", detail: includedBy}
+}
+
+// forgeConfig returns the configuration of the named forge, or nil when it is unknown.
+func (ds *DiscoveryService) forgeConfig(name string) *config.ForgeConfig {
+	if ds.forgeManager == nil || name == "" {
+		return nil
+	}
+	return ds.forgeManager.GetForgeConfigs()[name]
+}
+
+// Includes reports whether repo passes the service's filtering configuration.
+// It is used to re-filter cached discovery results after a configuration reload.
+func (ds *DiscoveryService) Includes(repo *Repository) bool {
+	return ds.filterDecision(repo).include
 }
 
 // matchesPattern checks if a string matches a simple glob pattern
@@ -433,8 +478,32 @@ func (ds *DiscoveryService) ConvertToConfigRepositories(repos []*Repository, for
 		}
 
 		configRepo := repo.ToConfigRepository(auth)
+		configRepo.PageTags, configRepo.PageCategories = ds.filtering.ResolveTopics(repo.Topics)
 		configRepos = append(configRepos, configRepo)
 	}
 
+	disambiguateRepositoryNames(configRepos)
 	return configRepos
 }
+
+// disambiguateRepositoryNames renames repositories whose name is shared by another
+// discovered repository (e.g. "docs" in two organizations) to their full name with
+// "/" replaced by "-", since repository names key clone directories and content paths.
+func disambiguateRepositoryNames(repos []config.Repository) {
+	counts := make(map[string]int, len(repos))
+	for i := range repos {
+		counts[repos[i].Name]++
+	}
+	for i := range repos {
+		fullName := repos[i].Tags["full_name"]
+		if counts[repos[i].Name] < 2 || fullName == "" {
+			continue
+		}
+		renamed := strings.ReplaceAll(fullName, "/", "-")
+		slog.Info("Renamed discovered repository with duplicate name",
+			slog.String("name", repos[i].Name),
+			slog.String("full_name", fullName),
+			slog.String("renamed", renamed))
+		repos[i].Name = renamed
+	}
+}
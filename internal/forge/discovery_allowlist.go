@@ -0,0 +1,65 @@
+package forge
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// maxAllowlistLookups bounds concurrent repository lookups of one forge's allowlist.
+const maxAllowlistLookups = 8
+
+// fetchListedRepositories looks up each repository of the forge's allowlist
+// (repositories / repositories_file) through the API, skipping organization listing.
+// The forge supplies clone URLs, default branches and topics. Repositories that cannot
+// be fetched are logged and skipped; discovery fails only when none can be fetched.
+func (ds *DiscoveryService) fetchListedRepositories(ctx context.Context, client Client, forgeConfig *config.ForgeConfig) ([]*Repository, error) {
+	names, err := forgeConfig.RepositoryAllowlist()
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Discovering listed repositories", "forge", client.GetName(), "count", len(names))
+
+	repos := make([]*Repository, len(names))
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, maxAllowlistLookups)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			idx := strings.LastIndex(name, "/")
+			repos[i], errs[i] = client.GetRepository(ctx, name[:idx], name[idx+1:])
+		}()
+	}
+	wg.Wait()
+
+	found := make([]*Repository, 0, len(names))
+	var lastErr error
+	for i, repo := range repos {
+		if errs[i] != nil || repo == nil {
+			lastErr = errs[i]
+			slog.Warn("Listed repository not found on forge",
+				"forge", client.GetName(),
+				"repository", names[i],
+				"error", errs[i])
+			continue
+		}
+		found = append(found, repo)
+	}
+	if len(found) == 0 && lastErr != nil {
+		return nil, errors.ForgeError("failed to fetch listed repositories for forge").
+			WithCause(lastErr).
+			WithContext("forge", client.GetName()).
+			WithContext("repositories", len(names)).
+			Build()
+	}
+	return found, nil
+}
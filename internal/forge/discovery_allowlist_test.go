@@ -0,0 +1,94 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// allowlistOnlyClient fails organization-wide listing so tests prove the allowlist
+// path never uses it.
+type allowlistOnlyClient struct {
+	*EnhancedMockForgeClient
+}
+
+func (allowlistOnlyClient) ListOrganizations(context.Context) ([]*Organization, error) {
+	return nil, errors.New("organization listing must not be used")
+}
+
+func (allowlistOnlyClient) ListRepositories(context.Context, []string) ([]*Repository, error) {
+	return nil, errors.New("repository listing must not be used")
+}
+
+func TestDiscoveryService_RepositoryAllowlist(t *testing.T) {
+	dir := t.TempDir()
+	listFile := filepath.Join(dir, "repositories.yaml")
+	if err := os.WriteFile(listFile, []byte("github:\n  - acme/api\n  - acme/missing\ngitlab:\n  - platform/ops\n"), 0o600); err != nil {
+		t.Fatalf("write allowlist: %v", err)
+	}
+
+	forgeCfg := &config.ForgeConfig{
+		Name:             "github",
+		Type:             config.ForgeGitHub,
+		Repositories:     []string{"acme/docs", "acme/api"},
+		RepositoriesFile: listFile,
+		Auth:             &config.AuthConfig{Type: config.AuthTypeToken, Token: "t"},
+	}
+	mock := NewEnhancedMockForgeClient("github", TypeGitHub)
+	docs := CreateMockGitHubRepo("acme", "docs", true, false, false, false)
+	docs.Topics = []string{"handbook"}
+	mock.AddRepository(docs)
+	mock.AddRepository(CreateMockGitHubRepo("acme", "api", true, false, false, false))
+	mock.AddRepository(CreateMockGitHubRepo("acme", "unlisted", true, false, false, false))
+
+	manager := NewForgeManager()
+	manager.AddForge(forgeCfg, allowlistOnlyClient{mock})
+
+	result, err := NewDiscoveryService(manager, &config.FilteringConfig{}).DiscoverAll(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverAll() error: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected discovery errors: %v", result.Errors)
+	}
+	var names []string
+	for _, r := range result.Repositories {
+		names = append(names, r.FullName)
+		if r.Metadata["forge_name"] != "github" {
+			t.Errorf("%s: forge_name = %q, want github", r.FullName, r.Metadata["forge_name"])
+		}
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"acme/api", "acme/docs"}) {
+		t.Fatalf("discovered %v, want the listed repositories that exist", names)
+	}
+	for _, r := range result.Repositories {
+		if r.FullName == "acme/docs" && (r.CloneURL == "" || !slices.Equal(r.Topics, []string{"handbook"})) {
+			t.Errorf("listed repository not enriched from the forge: %+v", r)
+		}
+	}
+}
+
+func TestDiscoveryService_RepositoryAllowlist_NoneFound(t *testing.T) {
+	forgeCfg := &config.ForgeConfig{
+		Name:         "github",
+		Type:         config.ForgeGitHub,
+		Repositories: []string{"acme/missing"},
+		Auth:         &config.AuthConfig{Type: config.AuthTypeToken, Token: "t"},
+	}
+	manager := NewForgeManager()
+	manager.AddForge(forgeCfg, allowlistOnlyClient{NewEnhancedMockForgeClient("github", TypeGitHub)})
+
+	result, err := NewDiscoveryService(manager, &config.FilteringConfig{}).DiscoverAll(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverAll() error: %v", err)
+	}
+	if result.Errors["github"] == nil {
+		t.Fatalf("expected a forge error when no listed repository exists")
+	}
+}
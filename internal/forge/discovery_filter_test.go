@@ -2,6 +2,7 @@ package forge
 
 import (
 	"testing"
+	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 )
@@ -59,6 +60,7 @@ func TestDiscoveryService_FilterDecision(t *testing.T) {
 			repo:        &Repository{Name: "inc-ok", FullName: "g/inc-ok", HasDocs: true},
 			wantInclude: true,
 			wantReason:  "included",
+			wantDetail:  "inc-*",
 		},
 	}
 
@@ -78,3 +80,41 @@ func TestDiscoveryService_FilterDecision(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscoveryService_FilterDecisionForgePolicy(t *testing.T) {
+	t.Parallel()
+
+	keepArchived := false
+	manager := NewForgeManager()
+	manager.AddForge(&config.ForgeConfig{Name: "strict", Type: config.ForgeGitHub, SkipForks: true, MinPushedWithin: "30d"}, nil)
+	manager.AddForge(&config.ForgeConfig{Name: "lenient", Type: config.ForgeGitHub, SkipArchived: &keepArchived}, nil)
+	ds := NewDiscoveryService(manager, &config.FilteringConfig{})
+
+	recent := time.Now().Add(-24 * time.Hour)
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	cases := []struct {
+		name       string
+		repo       *Repository
+		wantReason string
+		wantDetail string
+	}{
+		{"fork skipped", &Repository{Name: "a", Fork: true, PushedAt: recent, Metadata: map[string]string{"forge_name": "strict"}}, "fork", "strict"},
+		{"fork kept", &Repository{Name: "a", Fork: true, Metadata: map[string]string{"forge_name": "lenient"}}, "included", ""},
+		{"inactive", &Repository{Name: "a", PushedAt: old, Metadata: map[string]string{"forge_name": "strict"}}, "inactive", "strict"},
+		{"recent push", &Repository{Name: "a", PushedAt: recent, Metadata: map[string]string{"forge_name": "strict"}}, "included", ""},
+		{"unknown push time", &Repository{Name: "a", Metadata: map[string]string{"forge_name": "strict"}}, "included", ""},
+		{"archived kept", &Repository{Name: "a", Archived: true, Metadata: map[string]string{"forge_name": "lenient"}}, "included", ""},
+		{"archived skipped by default", &Repository{Name: "a", Archived: true, Metadata: map[string]string{"forge_name": "strict"}}, "archived", ""},
+		{"unknown forge", &Repository{Name: "a", Fork: true, PushedAt: old}, "included", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ds.filterDecision(tc.repo)
+			if got.reason != tc.wantReason || got.detail != tc.wantDetail {
+				t.Fatalf("decision = %s/%s, want %s/%s", got.reason, got.detail, tc.wantReason, tc.wantDetail)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package forge
+
+import (
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestDisambiguateRepositoryNames(t *testing.T) {
+	repos := []config.Repository{
+		{Name: "docs", Tags: map[string]string{"full_name": "acme/docs"}},
+		{Name: "docs", Tags: map[string]string{"full_name": "beta/platform/docs"}},
+		{Name: "api", Tags: map[string]string{"full_name": "acme/api"}},
+	}
+	disambiguateRepositoryNames(repos)
+
+	want := []string{"acme-docs", "beta-platform-docs", "api"}
+	for i, r := range repos {
+		if r.Name != want[i] {
+			t.Errorf("repos[%d].Name = %q, want %q", i, r.Name, want[i])
+		}
+	}
+}
+
+func TestConvertToConfigRepositories_MapsTopicsToTaxonomies(t *testing.T) {
+	ds := NewDiscoveryService(NewForgeManager(), &config.FilteringConfig{
+		TopicMappings: []config.TopicMapping{{Topic: "docs-platform", Tags: []string{"platform"}, Categories: []string{"Platform"}}},
+	})
+	repos := ds.ConvertToConfigRepositories([]*Repository{
+		{Name: "api", FullName: "acme/api", Topics: []string{"docs-platform"}},
+		{Name: "web", FullName: "acme/web", Topics: []string{"frontend"}},
+	}, NewForgeManager())
+
+	if got := repos[0].PageTags; len(got) != 1 || got[0] != "platform" {
+		t.Errorf("api PageTags = %v", got)
+	}
+	if got := repos[0].PageCategories; len(got) != 1 || got[0] != "Platform" {
+		t.Errorf("api PageCategories = %v", got)
+	}
+	if repos[1].PageTags != nil || repos[1].PageCategories != nil {
+		t.Errorf("web taxonomies = %v/%v, want none", repos[1].PageTags, repos[1].PageCategories)
+	}
+}
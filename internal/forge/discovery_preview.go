@@ -0,0 +1,125 @@
+package forge
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RepositoryDecision explains why discovery included or excluded a repository.
+type RepositoryDecision struct {
+	Forge      string `json:"forge"`
+	Repository string `json:"repository"` // Full name (org/repo)
+	Included   bool   `json:"included"`
+	Reason     string `json:"reason"`         // Stable reason code (e.g. exclude_patterns_match)
+	Rule       string `json:"rule,omitempty"` // Filtering rule that decided, if any
+
+	// Topics are the repository's forge topics; Tags and Categories are the site terms
+	// filtering.topic_mappings resolves them to.
+	Topics     []string `json:"topics,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// DiscoveryPreview is the outcome of a discovery run that changes nothing: the decision
+// made for every repository found with the current filters.
+type DiscoveryPreview struct {
+	GeneratedAt  time.Time            `json:"generated_at"`
+	Duration     time.Duration        `json:"duration"`
+	Included     int                  `json:"included"`
+	Excluded     int                  `json:"excluded"`
+	ExcludedBy   map[string]int       `json:"excluded_by,omitempty"` // Excluded repositories per reason code
+	Repositories []RepositoryDecision `json:"repositories"`
+	Errors       map[string]string    `json:"errors,omitempty"` // Errors by forge name
+}
+
+// Preview runs discovery across all forges and explains each filtering decision.
+// Discovery only reads from the forges; the caller decides whether to act on the result.
+func (ds *DiscoveryService) Preview(ctx context.Context) (*DiscoveryPreview, error) {
+	result, err := ds.DiscoverAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	preview := &DiscoveryPreview{
+		GeneratedAt:  result.Timestamp,
+		Duration:     result.Duration,
+		Repositories: ds.Explain(result),
+	}
+	for _, d := range preview.Repositories {
+		if d.Included {
+			preview.Included++
+		} else {
+			preview.Excluded++
+			if preview.ExcludedBy == nil {
+				preview.ExcludedBy = make(map[string]int)
+			}
+			preview.ExcludedBy[d.Reason]++
+		}
+	}
+	if len(result.Errors) > 0 {
+		preview.Errors = make(map[string]string, len(result.Errors))
+		for name, forgeErr := range result.Errors {
+			preview.Errors[name] = forgeErr.Error()
+		}
+	}
+	return preview, nil
+}
+
+// Explain returns the filtering decision for each included and filtered repository of
+// result, ordered by forge and repository name.
+func (ds *DiscoveryService) Explain(result *DiscoveryResult) []RepositoryDecision {
+	decisions := make([]RepositoryDecision, 0, len(result.Repositories)+len(result.Filtered))
+	for _, repos := range [][]*Repository{result.Repositories, result.Filtered} {
+		for _, repo := range repos {
+			decision := ds.filterDecision(repo)
+			tags, categories := ds.filtering.ResolveTopics(repo.Topics)
+			decisions = append(decisions, RepositoryDecision{
+				Forge:      repo.Metadata["forge_name"],
+				Repository: repo.FullName,
+				Included:   decision.include,
+				Reason:     decision.reason,
+				Rule:       ds.filterRule(decision),
+				Topics:     repo.Topics,
+				Tags:       tags,
+				Categories: categories,
+			})
+		}
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		if decisions[i].Forge != decisions[j].Forge {
+			return decisions[i].Forge < decisions[j].Forge
+		}
+		return decisions[i].Repository < decisions[j].Repository
+	})
+	return decisions
+}
+
+// filterRule names the configuration (or repository property) behind a decision.
+func (ds *DiscoveryService) filterRule(decision repoFilterDecision) string {
+	switch decision.reason {
+	case "archived":
+		return "repository is archived"
+	case "fork":
+		return "forges." + decision.detail + ".skip_forks"
+	case "inactive":
+		rule := "forges." + decision.detail + ".min_pushed_within"
+		if forgeConfig := ds.forgeConfig(decision.detail); forgeConfig != nil {
+			rule += ": " + forgeConfig.MinPushedWithin
+		}
+		return rule
+	case "docignore_present":
+		return ".docignore"
+	case "missing_required_paths":
+		return "filtering.required_paths: " + strings.Join(ds.filtering.RequiredPaths, ", ")
+	case "include_patterns_miss":
+		return "filtering.include_patterns: " + strings.Join(ds.filtering.IncludePatterns, ", ")
+	case "exclude_patterns_match":
+		return "filtering.exclude_patterns: " + decision.detail
+	case "included":
+		if decision.detail != "" {
+			return "filtering.include_patterns: " + decision.detail
+		}
+	}
+	return ""
+}
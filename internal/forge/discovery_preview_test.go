@@ -0,0 +1,99 @@
+package forge
+
+import (
+	"reflect"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestDiscoveryService_Preview(t *testing.T) {
+	client := NewEnhancedMockForgeClient("gh", TypeGitHub)
+	client.AddRepository(CreateMockGitHubRepo("acme", "api-docs", true, false, false, false))
+	client.AddRepository(CreateMockGitHubRepo("acme", "legacy-docs", true, false, false, false))
+	client.AddRepository(CreateMockGitHubRepo("acme", "old", true, false, true, false))
+
+	manager := NewForgeManager()
+	manager.AddForge(&config.ForgeConfig{Name: "gh", Type: config.ForgeGitHub, Organizations: []string{"acme"}}, client)
+	ds := NewDiscoveryService(manager, &config.FilteringConfig{
+		ExcludePatterns: []string{"legacy-*"},
+		TopicMappings:   []config.TopicMapping{{Topic: "documentation", Categories: []string{"Platform"}}},
+	})
+
+	preview, err := ds.Preview(t.Context())
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if preview.Included != 1 || preview.Excluded != 2 {
+		t.Fatalf("included/excluded = %d/%d, want 1/2", preview.Included, preview.Excluded)
+	}
+
+	topics := []string{"github", "documentation", "mock"}
+	categories := []string{"Platform"}
+	want := []RepositoryDecision{
+		{Forge: "gh", Repository: "acme/api-docs", Included: true, Reason: "included", Topics: topics, Categories: categories},
+		{Forge: "gh", Repository: "acme/legacy-docs", Reason: "exclude_patterns_match", Rule: "filtering.exclude_patterns: legacy-*", Topics: topics, Categories: categories},
+		{Forge: "gh", Repository: "acme/old", Reason: "archived", Rule: "repository is archived", Topics: topics, Categories: categories},
+	}
+	if len(preview.Repositories) != len(want) {
+		t.Fatalf("got %d decisions, want %d: %+v", len(preview.Repositories), len(want), preview.Repositories)
+	}
+	for i, got := range preview.Repositories {
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("decision[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestDiscoveryService_ExplainIncludeRule(t *testing.T) {
+	ds := NewDiscoveryService(NewForgeManager(), &config.FilteringConfig{IncludePatterns: []string{"*-docs"}})
+	result := &DiscoveryResult{Repositories: []*Repository{{Name: "api-docs", FullName: "acme/api-docs", HasDocs: true}}}
+
+	got := ds.Explain(result)
+	if len(got) != 1 || got[0].Rule != "filtering.include_patterns: *-docs" {
+		t.Fatalf("Explain = %+v", got)
+	}
+}
+
+func TestDiscoveryService_PreviewForgePolicy(t *testing.T) {
+	client := NewEnhancedMockForgeClient("gh", TypeGitHub)
+	client.AddRepository(CreateMockGitHubRepo("acme", "docs", true, false, false, false))
+	client.AddRepository(CreateMockGitHubRepo("acme", "docs-fork", true, false, false, true))
+	stale := CreateMockGitHubRepo("acme", "stale", true, false, false, false)
+	stale.PushedAt = stale.PushedAt.AddDate(-1, 0, 0)
+	client.AddRepository(stale)
+
+	manager := NewForgeManager()
+	manager.AddForge(&config.ForgeConfig{
+		Name: "gh", Type: config.ForgeGitHub, Organizations: []string{"acme"},
+		SkipForks: true, MinPushedWithin: "180d",
+	}, client)
+	ds := NewDiscoveryService(manager, &config.FilteringConfig{})
+
+	result, err := ds.DiscoverAll(t.Context())
+	if err != nil {
+		t.Fatalf("DiscoverAll: %v", err)
+	}
+	reasons := map[string]string{}
+	for _, repo := range result.Filtered {
+		reasons[repo.FullName] = repo.SkipReason
+	}
+	if want := map[string]string{"acme/docs-fork": "fork", "acme/stale": "inactive"}; !reflect.DeepEqual(reasons, want) {
+		t.Fatalf("skip reasons = %v, want %v", reasons, want)
+	}
+
+	preview, err := ds.Preview(t.Context())
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if want := map[string]int{"fork": 1, "inactive": 1}; !reflect.DeepEqual(preview.ExcludedBy, want) {
+		t.Fatalf("ExcludedBy = %v, want %v", preview.ExcludedBy, want)
+	}
+	rules := map[string]string{}
+	for _, d := range preview.Repositories {
+		rules[d.Repository] = d.Rule
+	}
+	if rules["acme/docs-fork"] != "forges.gh.skip_forks" || rules["acme/stale"] != "forges.gh.min_pushed_within: 180d" {
+		t.Fatalf("rules = %v", rules)
+	}
+}
@@ -1,18 +1,43 @@
 package discoveryrunner
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/forge"
 )
 
+// SnapshotStore persists encoded cache snapshots so a restarted daemon can answer
+// status queries from the last discovery result before its first discovery run.
+//
+// The concrete implementation is typically *state.ServiceAdapter.
+type SnapshotStore interface {
+	SetDiscoverySnapshot(data []byte)
+	GetDiscoverySnapshot() []byte
+}
+
 // Cache caches the most recent repository discovery result.
 // This enables fast responses to status endpoint queries without
 // repeating expensive network operations.
 type Cache struct {
-	mu     sync.RWMutex
-	result *forge.DiscoveryResult
-	err    error
+	mu        sync.RWMutex
+	result    *forge.DiscoveryResult
+	err       error
+	updatedAt time.Time
+	restored  bool
+	store     SnapshotStore
+}
+
+// cacheSnapshot is the persisted form of the cache. Errors are stored as messages
+// because error values do not survive a JSON round trip.
+type cacheSnapshot struct {
+	Result      *forge.DiscoveryResult `json:"result,omitempty"`
+	ForgeErrors map[string]string      `json:"forge_errors,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at"`
 }
 
 // NewCache creates a new Cache.
@@ -23,16 +48,20 @@ func NewCache() *Cache {
 // Update stores the latest discovery result and clears any previous error.
 func (c *Cache) Update(result *forge.DiscoveryResult) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.result = result
 	c.err = nil
+	c.updatedAt = time.Now()
+	c.restored = false
+	c.mu.Unlock()
+	c.persist()
 }
 
 // SetError stores a discovery error, preserving the previous result (if any).
 func (c *Cache) SetError(err error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.err = err
+	c.mu.Unlock()
+	c.persist()
 }
 
 // Get returns the cached discovery result and any error.
@@ -64,10 +93,134 @@ func (c *Cache) HasResult() bool {
 	return c.result != nil
 }
 
+// UpdatedAt returns when the cached result was stored, or the zero time if no
+// result is cached. Restored results keep the time of the discovery that produced them.
+func (c *Cache) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updatedAt
+}
+
+// Restored reports whether the cached result was loaded from a persisted snapshot
+// and no discovery has completed since.
+func (c *Cache) Restored() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.restored
+}
+
 // Clear removes the cached result and error.
 func (c *Cache) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.result = nil
 	c.err = nil
+	c.updatedAt = time.Time{}
+	c.restored = false
+	c.mu.Unlock()
+	c.persist()
+}
+
+// Restore attaches store to the cache and loads its persisted snapshot, if any.
+// Subsequent updates are written back to store. A snapshot that cannot be decoded
+// is reported and ignored; the cache stays attached and empty.
+func (c *Cache) Restore(store SnapshotStore) error {
+	c.mu.Lock()
+	c.store = store
+	c.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+
+	data := store.GetDiscoverySnapshot()
+	if len(data) == 0 {
+		return nil
+	}
+	var snap cacheSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("decode discovery snapshot: %w", err)
+	}
+
+	if snap.Result != nil && len(snap.ForgeErrors) > 0 {
+		snap.Result.Errors = make(map[string]error, len(snap.ForgeErrors))
+		for forgeName, msg := range snap.ForgeErrors {
+			snap.Result.Errors[forgeName] = errors.New(msg)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.result = snap.Result
+	c.err = nil
+	if snap.Error != "" {
+		c.err = errors.New(snap.Error)
+	}
+	c.updatedAt = snap.UpdatedAt
+	c.restored = snap.Result != nil
+	return nil
+}
+
+// persist writes the current cache contents to the attached store (if any).
+func (c *Cache) persist() {
+	c.mu.RLock()
+	store := c.store
+	if store == nil {
+		c.mu.RUnlock()
+		return
+	}
+	snap := cacheSnapshot{UpdatedAt: c.updatedAt}
+	if c.err != nil {
+		snap.Error = c.err.Error()
+	}
+	if c.result != nil {
+		res := *c.result
+		res.Errors = nil
+		snap.Result = &res
+		for forgeName, ferr := range c.result.Errors {
+			if ferr == nil {
+				continue
+			}
+			if snap.ForgeErrors == nil {
+				snap.ForgeErrors = make(map[string]string, len(c.result.Errors))
+			}
+			snap.ForgeErrors[forgeName] = ferr.Error()
+		}
+	}
+	data, err := json.Marshal(snap)
+	c.mu.RUnlock()
+	if err != nil {
+		return
+	}
+	store.SetDiscoverySnapshot(data)
+}
+
+// Prune drops cached repositories for which keep returns false, moving them to the
+// result's filtered list, and returns the dropped repositories. It is used to reconcile
+// the cache with a reloaded configuration without repeating discovery.
+func (c *Cache) Prune(keep func(*forge.Repository) bool) []*forge.Repository {
+	c.mu.Lock()
+	if c.result == nil {
+		c.mu.Unlock()
+		return nil
+	}
+
+	pruned := *c.result
+	pruned.Repositories = make([]*forge.Repository, 0, len(c.result.Repositories))
+	pruned.Filtered = append([]*forge.Repository{}, c.result.Filtered...)
+	var removed []*forge.Repository
+	for _, repo := range c.result.Repositories {
+		if keep(repo) {
+			pruned.Repositories = append(pruned.Repositories, repo)
+			continue
+		}
+		removed = append(removed, repo)
+		pruned.Filtered = append(pruned.Filtered, repo)
+	}
+	if len(removed) > 0 {
+		c.result = &pruned
+	}
+	c.mu.Unlock()
+	if len(removed) > 0 {
+		c.persist()
+	}
+	return removed
 }
@@ -36,3 +36,60 @@ func TestCache_UpdateThenSetErrorPreservesResult(t *testing.T) {
 type forgeError string
 
 func (e forgeError) Error() string { return string(e) }
+
+func TestCache_PruneMovesDroppedReposToFiltered(t *testing.T) {
+	c := NewCache()
+	require.Nil(t, c.Prune(func(*forge.Repository) bool { return false }))
+
+	keep := &forge.Repository{Name: "keep"}
+	drop := &forge.Repository{Name: "drop"}
+	original := &forge.DiscoveryResult{Repositories: []*forge.Repository{keep, drop}}
+	c.Update(original)
+
+	removed := c.Prune(func(r *forge.Repository) bool { return r.Name == "keep" })
+	require.Equal(t, []*forge.Repository{drop}, removed)
+
+	res := c.GetResult()
+	require.Equal(t, []*forge.Repository{keep}, res.Repositories)
+	require.Equal(t, []*forge.Repository{drop}, res.Filtered)
+	require.Len(t, original.Repositories, 2, "the previous result must not be modified")
+}
+
+type memorySnapshotStore struct{ data []byte }
+
+func (m *memorySnapshotStore) SetDiscoverySnapshot(data []byte) { m.data = data }
+func (m *memorySnapshotStore) GetDiscoverySnapshot() []byte     { return m.data }
+
+func TestCache_RestoreLoadsPersistedSnapshot(t *testing.T) {
+	store := &memorySnapshotStore{}
+	c := NewCache()
+	require.NoError(t, c.Restore(store))
+	require.False(t, c.Restored())
+
+	c.Update(&forge.DiscoveryResult{
+		Repositories: []*forge.Repository{{Name: "api", CloneURL: "https://example.com/api.git"}},
+		Errors:       map[string]error{"gitlab": forgeError("unauthorized")},
+	})
+	c.SetError(forgeError("timeout"))
+	require.NotEmpty(t, store.data)
+
+	restored := NewCache()
+	require.NoError(t, restored.Restore(store))
+	require.True(t, restored.Restored())
+	require.Equal(t, c.UpdatedAt().Unix(), restored.UpdatedAt().Unix())
+
+	res, err := restored.Get()
+	require.EqualError(t, err, "timeout")
+	require.Len(t, res.Repositories, 1)
+	require.Equal(t, "api", res.Repositories[0].Name)
+	require.EqualError(t, res.Errors["gitlab"], "unauthorized")
+
+	restored.Update(&forge.DiscoveryResult{})
+	require.False(t, restored.Restored())
+}
+
+func TestCache_RestoreRejectsCorruptSnapshot(t *testing.T) {
+	c := NewCache()
+	require.Error(t, c.Restore(&memorySnapshotStore{data: []byte("{not json")}))
+	require.False(t, c.HasResult())
+}
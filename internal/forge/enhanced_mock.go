@@ -337,11 +337,12 @@ func (m *EnhancedMockForgeClient) ParseWebhookEvent(_ []byte, eventType string)
 		event.Branch = mockFeatureBranch
 		event.Action = "opened"
 		event.Metadata["pull_request_number"] = "42"
-	case "release":
-		event.Type = WebhookEventTag // Use available type
-		event.Branch = defaultMainBranch
+	case string(WebhookEventRelease):
+		event.Type = WebhookEventRelease
+		event.Branch = ""
 		event.Action = "published"
 		event.Metadata["tag"] = "v1.0.0"
+		event.Release = &WebhookRelease{Tag: "v1.0.0", Name: "v1.0.0", Notes: "Initial release", PublishedAt: time.Now()}
 	}
 
 	return event, nil
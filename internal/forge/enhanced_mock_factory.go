@@ -21,9 +21,11 @@ func CreateMockGitHubRepo(owner, name string, hasDocs, isPrivate, isArchived, is
 		Description:   fmt.Sprintf("Mock GitHub repository: %s", name),
 		Private:       isPrivate,
 		Archived:      isArchived,
+		Fork:          isFork,
 		HasDocs:       hasDocs,
 		HasDocIgnore:  false,
 		LastUpdated:   time.Now().Add(-time.Hour * 24),
+		PushedAt:      time.Now().Add(-time.Hour * 24),
 		Topics:        []string{"github", "documentation", "mock"},
 		Language:      "Markdown",
 		Metadata: map[string]string{
@@ -46,9 +48,11 @@ func CreateMockGitLabRepo(group, name string, hasDocs, isPrivate, isArchived, is
 		Description:   fmt.Sprintf("Mock GitLab repository: %s", name),
 		Private:       isPrivate,
 		Archived:      isArchived,
+		Fork:          isFork,
 		HasDocs:       hasDocs,
 		HasDocIgnore:  false,
 		LastUpdated:   time.Now().Add(-time.Hour * 48),
+		PushedAt:      time.Now().Add(-time.Hour * 48),
 		Topics:        []string{"gitlab", "documentation", "mock"},
 		Language:      "Markdown",
 		Metadata: map[string]string{
@@ -71,9 +75,11 @@ func CreateMockForgejoRepo(org, name string, hasDocs, isPrivate, isArchived, isF
 		Description:   fmt.Sprintf("Mock Forgejo repository: %s", name),
 		Private:       isPrivate,
 		Archived:      isArchived,
+		Fork:          isFork,
 		HasDocs:       hasDocs,
 		HasDocIgnore:  false,
 		LastUpdated:   time.Now().Add(-time.Hour * 12),
+		PushedAt:      time.Now().Add(-time.Hour * 12),
 		Topics:        []string{"forgejo", "documentation", "mock"},
 		Language:      "Markdown",
 		Metadata: map[string]string{
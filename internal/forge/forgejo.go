@@ -34,15 +34,12 @@ func NewForgejoClient(fg *Config) (*ForgejoClient, error) {
 			Build()
 	}
 
-	// Extract token from auth config
-	tok, err := tokenFromConfig(fg, "Forgejo")
+	// Create BaseForge with common HTTP operations and the configured credentials
+	baseForge, err := baseForgeFromConfig(fg, "Forgejo", fg.APIURL, "")
 	if err != nil {
 		return nil, err
 	}
 
-	// Create BaseForge with common HTTP operations
-	baseForge := NewBaseForge(newHTTPClient30s(), fg.APIURL, tok)
-
 	// Forgejo uses "token " auth prefix instead of "Bearer "
 	baseForge.SetAuthHeaderPrefix("token ")
 
@@ -337,6 +334,12 @@ func (c *ForgejoClient) ParseWebhookEvent(payload []byte, eventType string) (*We
 		return c.parsePushEvent(payload)
 	case string(WebhookEventRepository):
 		return c.parseRepositoryEvent(payload)
+	case "create":
+		return c.parseRefEvent(payload, "created")
+	case "delete":
+		return c.parseRefEvent(payload, "deleted")
+	case string(WebhookEventRelease):
+		return c.parseReleaseEvent(payload)
 	default:
 		return nil, errors.ForgeError("unsupported event type from Forgejo").
 			WithContext("type", eventType).
@@ -346,11 +349,12 @@ func (c *ForgejoClient) ParseWebhookEvent(payload []byte, eventType string) (*We
 
 // forgejoPushEvent represents a Forgejo push event.
 type forgejoPushEvent struct {
-	Ref        string          `json:"ref"`
-	Repository json.RawMessage `json:"repository"`
-	Commits    []forgejoCommit `json:"commits"`
-	HeadCommit forgejoCommit   `json:"head_commit"`
-	Pusher     forgejoUser     `json:"pusher"`
+	Ref          string          `json:"ref"`
+	Repository   json.RawMessage `json:"repository"`
+	Commits      []forgejoCommit `json:"commits"`
+	TotalCommits int             `json:"total_commits"`
+	HeadCommit   forgejoCommit   `json:"head_commit"`
+	Pusher       forgejoUser     `json:"pusher"`
 }
 
 // forgejoCommit represents a Forgejo commit.
@@ -384,28 +388,9 @@ func (c *ForgejoClient) parsePushEvent(payload []byte) (*WebhookEvent, error) {
 		return nil, errors.ForgeError("missing repository in Forgejo push event").Build()
 	}
 
-	var repoMap map[string]any
-	if err := json.Unmarshal(pushEvent.Repository, &repoMap); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal repository in Forgejo push event").
-			WithCause(err).
-			Build()
-	}
-	if rawID, ok := repoMap["id"].(string); ok {
-		if intID, convErr := strconv.Atoi(rawID); convErr == nil {
-			repoMap["id"] = intID
-		}
-	}
-	repoBytes, marshalErr := json.Marshal(repoMap)
-	if marshalErr != nil {
-		return nil, errors.ForgeError("failed to marshal normalized repository for Forgejo push event").
-			WithCause(marshalErr).
-			Build()
-	}
-	var repo forgejoRepo
-	if err := json.Unmarshal(repoBytes, &repo); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal normalized repository for Forgejo push event").
-			WithCause(err).
-			Build()
+	repo, err := c.decodeWebhookRepo(pushEvent.Repository, "push")
+	if err != nil {
+		return nil, err
 	}
 
 	branch := strings.TrimPrefix(pushEvent.Ref, "refs/heads/")
@@ -426,14 +411,15 @@ func (c *ForgejoClient) parsePushEvent(payload []byte) (*WebhookEvent, error) {
 
 	return &WebhookEvent{
 		Type:       WebhookEventPush,
-		Repository: c.convertForgejoRepo(&repo),
+		Repository: c.convertForgejoRepo(repo),
 		Branch:     branch,
 		Commits:    commits,
 		Timestamp:  time.Now(),
 		Metadata: map[string]string{
-			"ref":         pushEvent.Ref,
-			"head_commit": pushEvent.HeadCommit.ID,
-			"pusher":      pushEvent.Pusher.Username,
+			"ref":           pushEvent.Ref,
+			"head_commit":   pushEvent.HeadCommit.ID,
+			"pusher":        pushEvent.Pusher.Username,
+			"total_commits": strconv.Itoa(pushEvent.TotalCommits),
 		},
 	}, nil
 }
@@ -473,6 +459,132 @@ func (c *ForgejoClient) parseRepositoryEvent(payload []byte) (*WebhookEvent, err
 	return event, nil
 }
 
+// forgejoRefEvent represents a Forgejo create or delete event.
+type forgejoRefEvent struct {
+	Ref        string          `json:"ref"`
+	RefType    string          `json:"ref_type"` // tag or branch
+	SHA        string          `json:"sha"`
+	Repository json.RawMessage `json:"repository"`
+}
+
+// parseRefEvent parses a Forgejo create or delete event into a tag or branch event.
+func (c *ForgejoClient) parseRefEvent(payload []byte, action string) (*WebhookEvent, error) {
+	var refEvent forgejoRefEvent
+	if err := json.Unmarshal(payload, &refEvent); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal Forgejo ref event").
+			WithCause(err).
+			Build()
+	}
+	if len(refEvent.Repository) == 0 {
+		return nil, errors.ForgeError("missing repository in Forgejo ref event").Build()
+	}
+	repo, err := c.decodeWebhookRepo(refEvent.Repository, "ref")
+	if err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{
+		Type:       WebhookEventBranch,
+		Repository: c.convertForgejoRepo(repo),
+		Branch:     refEvent.Ref,
+		Action:     action,
+		Timestamp:  time.Now(),
+		Metadata:   map[string]string{"ref": refEvent.Ref, "ref_type": refEvent.RefType, "head_commit": refEvent.SHA},
+	}
+	if refEvent.RefType == "tag" {
+		event.Type = WebhookEventTag
+		event.Metadata["tag"] = refEvent.Ref
+	}
+	return event, nil
+}
+
+// forgejoReleaseEvent represents a Forgejo release event.
+type forgejoReleaseEvent struct {
+	Action  string `json:"action"` // published, updated or deleted
+	Release struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		Body        string    `json:"body"`
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+		Prerelease  bool      `json:"prerelease"`
+		Draft       bool      `json:"draft"`
+	} `json:"release"`
+	Repository json.RawMessage `json:"repository"`
+}
+
+// parseReleaseEvent parses a Forgejo release event.
+func (c *ForgejoClient) parseReleaseEvent(payload []byte) (*WebhookEvent, error) {
+	var releaseEvent forgejoReleaseEvent
+	if err := json.Unmarshal(payload, &releaseEvent); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal Forgejo release event").
+			WithCause(err).
+			Build()
+	}
+	if len(releaseEvent.Repository) == 0 {
+		return nil, errors.ForgeError("missing repository in Forgejo release event").Build()
+	}
+	repo, err := c.decodeWebhookRepo(releaseEvent.Repository, "release")
+	if err != nil {
+		return nil, err
+	}
+
+	// Forgejo reports edits as "updated"; use the GitHub-style action.
+	action := releaseEvent.Action
+	if action == "updated" {
+		action = "edited"
+	}
+	rel := &releaseEvent.Release
+	return &WebhookEvent{
+		Type:       WebhookEventRelease,
+		Repository: c.convertForgejoRepo(repo),
+		Action:     action,
+		Release: &WebhookRelease{
+			Tag:         rel.TagName,
+			Name:        rel.Name,
+			Notes:       rel.Body,
+			URL:         rel.HTMLURL,
+			PublishedAt: rel.PublishedAt,
+			Prerelease:  rel.Prerelease,
+		},
+		Timestamp: time.Now(),
+		Metadata: map[string]string{
+			"action": releaseEvent.Action,
+			"tag":    rel.TagName,
+			"draft":  strconv.FormatBool(rel.Draft),
+		},
+	}, nil
+}
+
+// decodeWebhookRepo decodes the repository object of a webhook payload, allowing its
+// id to be a string or a number.
+func (c *ForgejoClient) decodeWebhookRepo(raw json.RawMessage, eventName string) (*forgejoRepo, error) {
+	var repoMap map[string]any
+	if err := json.Unmarshal(raw, &repoMap); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal repository in Forgejo " + eventName + " event").
+			WithCause(err).
+			Build()
+	}
+	if rawID, ok := repoMap["id"].(string); ok {
+		if intID, convErr := strconv.Atoi(rawID); convErr == nil {
+			repoMap["id"] = intID
+		}
+	}
+	repoBytes, marshalErr := json.Marshal(repoMap)
+	if marshalErr != nil {
+		return nil, errors.ForgeError("failed to marshal normalized repository for Forgejo " + eventName + " event").
+			WithCause(marshalErr).
+			Build()
+	}
+	var repo forgejoRepo
+	if err := json.Unmarshal(repoBytes, &repo); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal normalized repository for Forgejo " + eventName + " event").
+			WithCause(err).
+			Build()
+	}
+	return &repo, nil
+}
+
 // RegisterWebhook registers a webhook for a repository.
 func (c *ForgejoClient) RegisterWebhook(ctx context.Context, repo *Repository, webhookURL string) error {
 	if c.config.Webhook == nil {
@@ -492,7 +604,7 @@ func (c *ForgejoClient) RegisterWebhook(ctx context.Context, repo *Repository, w
 
 	events := c.config.Webhook.Events
 	if len(events) == 0 {
-		events = []string{"push", "repository"}
+		events = []string{"push", "repository", "create", "release"}
 	}
 
 	payload := map[string]any{
@@ -533,7 +645,9 @@ func (c *ForgejoClient) convertForgejoRepo(fRepo *forgejoRepo) *Repository {
 		Description:   fRepo.Description,
 		Private:       fRepo.Private,
 		Archived:      fRepo.Archived,
+		Fork:          fRepo.Fork,
 		LastUpdated:   fRepo.UpdatedAt,
+		PushedAt:      fRepo.UpdatedAt, // Forgejo reports no push time; pushes update it
 		Topics:        fRepo.Topics,
 		Language:      fRepo.Language,
 		Metadata: map[string]string{
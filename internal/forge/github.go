@@ -36,15 +36,12 @@ func NewGitHubClient(fg *Config) (*GitHubClient, error) {
 	// Set default URLs if not provided
 	apiURL, baseURL := withDefaults(fg.APIURL, fg.BaseURL, "https://api.github.com", "https://github.com")
 
-	// Extract token from auth config
-	tok, err := tokenFromConfig(fg, "GitHub")
+	// Create BaseForge with common HTTP operations and the configured credentials
+	baseForge, err := baseForgeFromConfig(fg, "GitHub", apiURL, apiURL)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create BaseForge with common HTTP operations
-	baseForge := NewBaseForge(newHTTPClient30s(), apiURL, tok)
-
 	// GitHub-specific headers
 	baseForge.SetCustomHeader("Accept", "application/vnd.github+json")
 	baseForge.SetCustomHeader("X-GitHub-Api-Version", "2022-11-28")
@@ -85,7 +82,9 @@ type githubRepo struct {
 	DefaultBranch string    `json:"default_branch"`
 	Language      string    `json:"language"`
 	Archived      bool      `json:"archived"`
+	Fork          bool      `json:"fork"`
 	UpdatedAt     time.Time `json:"updated_at"`
+	PushedAt      time.Time `json:"pushed_at"`
 	Topics        []string  `json:"topics"`
 	Owner         githubOrg `json:"owner"`
 }
@@ -288,6 +287,12 @@ func (c *GitHubClient) ParseWebhookEvent(payload []byte, eventType string) (*Web
 		return c.parsePushEvent(payload)
 	case string(WebhookEventRepository):
 		return c.parseRepositoryEvent(payload)
+	case "create":
+		return c.parseRefEvent(payload, "created")
+	case "delete":
+		return c.parseRefEvent(payload, "deleted")
+	case string(WebhookEventRelease):
+		return c.parseReleaseEvent(payload)
 	default:
 		return nil, errors.ForgeError("unsupported event type from GitHub").
 			WithContext("type", eventType).
@@ -328,30 +333,9 @@ func (c *GitHubClient) parsePushEvent(payload []byte) (*WebhookEvent, error) {
 		return nil, errors.ForgeError("missing repository in push event from GitHub").Build()
 	}
 
-	// Decode repository allowing id to be string or int
-	var repoMap map[string]any
-	if err := json.Unmarshal(pushEvent.Repository, &repoMap); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal repository in GitHub push event").
-			WithCause(err).
-			Build()
-	}
-	// Normalize id to int if it's a string
-	if rawID, ok := repoMap["id"].(string); ok {
-		if intID, convErr := strconv.Atoi(rawID); convErr == nil {
-			repoMap["id"] = intID
-		}
-	}
-	repoBytes, marshalErr := json.Marshal(repoMap)
-	if marshalErr != nil {
-		return nil, errors.ForgeError("failed to marshal normalized repository for GitHub push event").
-			WithCause(marshalErr).
-			Build()
-	}
-	var repo githubRepo
-	if err := json.Unmarshal(repoBytes, &repo); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal normalized repository for GitHub push event").
-			WithCause(err).
-			Build()
+	repo, err := c.decodeWebhookRepo(pushEvent.Repository, "push")
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract branch name from ref (refs/heads/main -> main)
@@ -374,7 +358,7 @@ func (c *GitHubClient) parsePushEvent(payload []byte) (*WebhookEvent, error) {
 
 	return &WebhookEvent{
 		Type:       WebhookEventPush,
-		Repository: c.convertGitHubRepo(&repo),
+		Repository: c.convertGitHubRepo(repo),
 		Branch:     branch,
 		Commits:    commits,
 		Timestamp:  time.Now(),
@@ -395,6 +379,12 @@ type githubRepositoryEvent struct {
 				From string `json:"from"`
 			} `json:"name"`
 		} `json:"repository"`
+		Owner struct {
+			From struct {
+				User         *githubOrg `json:"user"`
+				Organization *githubOrg `json:"organization"`
+			} `json:"from"`
+		} `json:"owner"`
 	} `json:"changes"`
 }
 
@@ -409,9 +399,139 @@ func (c *GitHubClient) parseRepositoryEvent(payload []byte) (*WebhookEvent, erro
 		return nil, errors.ForgeError("missing repository in repository event from GitHub").Build()
 	}
 
+	repo, err := c.decodeWebhookRepo(repoEvent.Repository, "repository")
+	if err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{
+		Type:       WebhookEventRepository,
+		Repository: c.convertGitHubRepo(repo),
+		Action:     repoEvent.Action,
+		Timestamp:  time.Now(),
+		Changes:    make(map[string]string),
+		Metadata: map[string]string{
+			"action": repoEvent.Action,
+		},
+	}
+
+	switch repoEvent.Action {
+	case WebhookActionRenamed:
+		if from := repoEvent.Changes.Repository.Name.From; from != "" {
+			event.Changes["name_from"] = from
+			event.Changes["name_to"] = repo.Name
+			if owner, _ := c.splitFullName(repo.FullName); owner != "" {
+				event.Changes["full_name_from"] = owner + "/" + from
+				event.Changes["full_name_to"] = repo.FullName
+			}
+		}
+	case WebhookActionTransferred:
+		from := repoEvent.Changes.Owner.From.Organization
+		if from == nil {
+			from = repoEvent.Changes.Owner.From.User
+		}
+		if from != nil && from.Login != "" {
+			event.Changes["full_name_from"] = from.Login + "/" + repo.Name
+			event.Changes["full_name_to"] = repo.FullName
+		}
+	}
+
+	return event, nil
+}
+
+// githubRefEvent represents a GitHub create or delete event.
+type githubRefEvent struct {
+	Ref        string          `json:"ref"`
+	RefType    string          `json:"ref_type"` // tag or branch
+	Repository json.RawMessage `json:"repository"`
+}
+
+// parseRefEvent parses a GitHub create or delete event into a tag or branch event.
+func (c *GitHubClient) parseRefEvent(payload []byte, action string) (*WebhookEvent, error) {
+	var refEvent githubRefEvent
+	if err := json.Unmarshal(payload, &refEvent); err != nil {
+		return nil, err
+	}
+	if len(refEvent.Repository) == 0 {
+		return nil, errors.ForgeError("missing repository in ref event from GitHub").Build()
+	}
+	repo, err := c.decodeWebhookRepo(refEvent.Repository, "ref")
+	if err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{
+		Type:       WebhookEventBranch,
+		Repository: c.convertGitHubRepo(repo),
+		Branch:     refEvent.Ref,
+		Action:     action,
+		Timestamp:  time.Now(),
+		Metadata:   map[string]string{"ref": refEvent.Ref, "ref_type": refEvent.RefType},
+	}
+	if refEvent.RefType == "tag" {
+		event.Type = WebhookEventTag
+		event.Metadata["tag"] = refEvent.Ref
+	}
+	return event, nil
+}
+
+// githubReleaseEvent represents a GitHub release event.
+type githubReleaseEvent struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		Body        string    `json:"body"`
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+		Prerelease  bool      `json:"prerelease"`
+		Draft       bool      `json:"draft"`
+	} `json:"release"`
+	Repository json.RawMessage `json:"repository"`
+}
+
+// parseReleaseEvent parses a GitHub release event.
+func (c *GitHubClient) parseReleaseEvent(payload []byte) (*WebhookEvent, error) {
+	var releaseEvent githubReleaseEvent
+	if err := json.Unmarshal(payload, &releaseEvent); err != nil {
+		return nil, err
+	}
+	if len(releaseEvent.Repository) == 0 {
+		return nil, errors.ForgeError("missing repository in release event from GitHub").Build()
+	}
+	repo, err := c.decodeWebhookRepo(releaseEvent.Repository, "release")
+	if err != nil {
+		return nil, err
+	}
+
+	rel := &releaseEvent.Release
+	return &WebhookEvent{
+		Type:       WebhookEventRelease,
+		Repository: c.convertGitHubRepo(repo),
+		Action:     releaseEvent.Action,
+		Release: &WebhookRelease{
+			Tag:         rel.TagName,
+			Name:        rel.Name,
+			Notes:       rel.Body,
+			URL:         rel.HTMLURL,
+			PublishedAt: rel.PublishedAt,
+			Prerelease:  rel.Prerelease,
+		},
+		Timestamp: time.Now(),
+		Metadata: map[string]string{
+			"action": releaseEvent.Action,
+			"tag":    rel.TagName,
+			"draft":  strconv.FormatBool(rel.Draft),
+		},
+	}, nil
+}
+
+// decodeWebhookRepo decodes the repository object of a webhook payload, allowing its
+// id to be a string or a number.
+func (c *GitHubClient) decodeWebhookRepo(raw json.RawMessage, eventName string) (*githubRepo, error) {
 	var repoMap map[string]any
-	if err := json.Unmarshal(repoEvent.Repository, &repoMap); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal repository in GitHub repository event").
+	if err := json.Unmarshal(raw, &repoMap); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal repository in GitHub " + eventName + " event").
 			WithCause(err).
 			Build()
 	}
@@ -422,34 +542,17 @@ func (c *GitHubClient) parseRepositoryEvent(payload []byte) (*WebhookEvent, erro
 	}
 	repoBytes, marshalErr := json.Marshal(repoMap)
 	if marshalErr != nil {
-		return nil, errors.ForgeError("failed to marshal normalized repository for GitHub repository event").
+		return nil, errors.ForgeError("failed to marshal normalized repository for GitHub " + eventName + " event").
 			WithCause(marshalErr).
 			Build()
 	}
 	var repo githubRepo
 	if err := json.Unmarshal(repoBytes, &repo); err != nil {
-		return nil, errors.ForgeError("failed to unmarshal normalized repository for GitHub repository event").
+		return nil, errors.ForgeError("failed to unmarshal normalized repository for GitHub " + eventName + " event").
 			WithCause(err).
 			Build()
 	}
-
-	event := &WebhookEvent{
-		Type:       WebhookEventRepository,
-		Repository: c.convertGitHubRepo(&repo),
-		Action:     repoEvent.Action,
-		Timestamp:  time.Now(),
-		Changes:    make(map[string]string),
-		Metadata: map[string]string{
-			"action": repoEvent.Action,
-		},
-	}
-
-	if repoEvent.Action == "renamed" && repoEvent.Changes.Repository.Name.From != "" {
-		event.Changes["name_from"] = repoEvent.Changes.Repository.Name.From
-		event.Changes["name_to"] = repo.Name
-	}
-
-	return event, nil
+	return &repo, nil
 }
 
 // RegisterWebhook registers a webhook for a repository.
@@ -471,7 +574,7 @@ func (c *GitHubClient) RegisterWebhook(ctx context.Context, repo *Repository, we
 
 	events := c.config.Webhook.Events
 	if len(events) == 0 {
-		events = []string{"push", "repository"}
+		events = []string{"push", "repository", "create", "release"}
 	}
 
 	payload := map[string]any{
@@ -507,7 +610,9 @@ func (c *GitHubClient) convertGitHubRepo(gRepo *githubRepo) *Repository {
 		Description:   gRepo.Description,
 		Private:       gRepo.Private,
 		Archived:      gRepo.Archived,
+		Fork:          gRepo.Fork,
 		LastUpdated:   gRepo.UpdatedAt,
+		PushedAt:      gRepo.PushedAt,
 		Topics:        gRepo.Topics,
 		Language:      gRepo.Language,
 		Metadata: map[string]string{
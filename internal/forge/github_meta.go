@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	cfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// githubMeta is the part of GitHub's meta API (GET /meta) listing webhook source ranges.
+type githubMeta struct {
+	Hooks []string `json:"hooks"`
+}
+
+// FetchGitHubHookRanges returns the address ranges GitHub delivers webhooks from, as
+// published by the meta API at apiURL (https://api.github.com when empty). The endpoint
+// needs no authentication.
+func FetchGitHubHookRanges(ctx context.Context, apiURL string) ([]netip.Prefix, error) {
+	if apiURL == "" {
+		apiURL = "https://api.github.com"
+	}
+	metaURL := strings.TrimSuffix(apiURL, "/") + "/meta"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metaURL, http.NoBody)
+	if err != nil {
+		return nil, errors.ForgeError("failed to create GitHub meta request").
+			WithCause(err).
+			WithContext("url", metaURL).
+			Build()
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "DocBuilder/1.0")
+
+	resp, err := newHTTPClient30s().Do(req)
+	if err != nil {
+		return nil, errors.NetworkError("failed to fetch GitHub meta API").
+			WithCause(err).
+			WithContext("url", metaURL).
+			Build()
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.ForgeError(fmt.Sprintf("GitHub meta API error: %s", resp.Status)).
+			WithContext("url", metaURL).
+			Build()
+	}
+
+	var meta githubMeta
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, errors.ForgeError("failed to decode GitHub meta API response").
+			WithCause(err).
+			Build()
+	}
+	ranges := make([]netip.Prefix, 0, len(meta.Hooks))
+	for _, entry := range meta.Hooks {
+		prefix, err := cfg.ParseIPPrefix(entry)
+		if err != nil {
+			return nil, errors.ForgeError("invalid range in GitHub meta API response").
+				WithCause(err).
+				WithContext("range", entry).
+				Build()
+		}
+		ranges = append(ranges, prefix)
+	}
+	if len(ranges) == 0 {
+		return nil, errors.ForgeError("GitHub meta API response lists no webhook ranges").
+			WithContext("url", metaURL).
+			Build()
+	}
+	return ranges, nil
+}
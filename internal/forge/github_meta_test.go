@@ -0,0 +1,30 @@
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchGitHubHookRanges(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/meta" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"hooks":["192.30.252.0/22","2a0a:a440::/29"],"web":["140.82.112.0/20"]}`))
+	}))
+	defer ts.Close()
+
+	ranges, err := FetchGitHubHookRanges(t.Context(), ts.URL+"/api/v3/")
+	if err != nil {
+		t.Fatalf("FetchGitHubHookRanges: %v", err)
+	}
+	if len(ranges) != 2 || ranges[0].String() != "192.30.252.0/22" || ranges[1].String() != "2a0a:a440::/29" {
+		t.Fatalf("unexpected ranges %v", ranges)
+	}
+
+	if _, err := FetchGitHubHookRanges(t.Context(), ts.URL); err == nil {
+		t.Fatal("expected an error for a missing meta endpoint")
+	}
+}
@@ -36,14 +36,11 @@ func NewGitLabClient(fg *Config) (*GitLabClient, error) {
 	// Set default URLs if not provided
 	apiURL, baseURL := withDefaults(fg.APIURL, fg.BaseURL, "https://gitlab.com/api/v4", "https://gitlab.com")
 
-	// Extract token from auth config
-	tok, err := tokenFromConfig(fg, "GitLab")
+	// Create BaseForge with common HTTP operations and the configured credentials
+	baseForge, err := baseForgeFromConfig(fg, "GitLab", apiURL, strings.TrimSuffix(baseURL, "/")+"/oauth/token")
 	if err != nil {
 		return nil, err
 	}
-
-	// Create BaseForge with common HTTP operations
-	baseForge := NewBaseForge(newHTTPClient30s(), apiURL, tok)
 	// GitLab uses Bearer auth (default), no custom headers needed
 
 	return &GitLabClient{
@@ -84,11 +81,17 @@ type gitlabProject struct {
 	Visibility        string             `json:"visibility"`
 	Archived          bool               `json:"archived"`
 	LastActivityAt    time.Time          `json:"last_activity_at"`
+	ForkedFromProject *gitlabForkSource  `json:"forked_from_project,omitempty"`
 	Topics            []string           `json:"topics"`
 	Languages         map[string]float64 `json:"languages,omitempty"`
 	Namespace         gitlabNamespace    `json:"namespace"`
 }
 
+// gitlabForkSource is the project a GitLab fork was created from.
+type gitlabForkSource struct {
+	ID int `json:"id"`
+}
+
 // gitlabNamespace represents a GitLab namespace.
 type gitlabNamespace struct {
 	ID       int    `json:"id"`
@@ -382,6 +385,8 @@ func (c *GitLabClient) ParseWebhookEvent(payload []byte, eventType string) (*Web
 		return c.parsePushEvent(payload)
 	case "tag_push", "Tag Push Hook":
 		return c.parseTagPushEvent(payload)
+	case string(WebhookEventRelease), "Release Hook":
+		return c.parseReleaseEvent(payload)
 	case string(WebhookEventRepository), "Repository Update Hook":
 		return c.parseRepositoryEvent(payload)
 	default:
@@ -425,8 +430,12 @@ func (c *GitLabClient) parseSystemHookEvent(payload []byte, headerEventType stri
 		return c.parsePushEvent(payload)
 	case "tag_push":
 		return c.parseTagPushEvent(payload)
+	case string(WebhookEventRelease):
+		return c.parseReleaseEvent(payload)
 	case string(WebhookEventRepository), "repository_update":
 		return c.parseRepositoryEvent(payload)
+	case "project_rename", "project_transfer":
+		return c.parseProjectMoveEvent(payload, kind)
 	default:
 		return nil, errors.ForgeError("unsupported event type from GitLab").
 			WithContext("type", headerEventType).
@@ -439,10 +448,12 @@ func (c *GitLabClient) parseSystemHookEvent(payload []byte, headerEventType stri
 
 // gitlabPushEvent represents a GitLab push event.
 type gitlabPushEvent struct {
-	Ref        string           `json:"ref"`
-	Project    gitlabProject    `json:"project"`
-	Commits    []gitlabCommit   `json:"commits"`
-	Repository gitlabRepository `json:"repository"`
+	Ref          string           `json:"ref"`
+	CheckoutSHA  string           `json:"checkout_sha"`
+	Project      gitlabProject    `json:"project"`
+	Commits      []gitlabCommit   `json:"commits"`
+	TotalCommits int              `json:"total_commits_count"`
+	Repository   gitlabRepository `json:"repository"`
 }
 
 // gitlabCommit represents a GitLab commit.
@@ -538,7 +549,11 @@ func (c *GitLabClient) parsePushEvent(payload []byte) (*WebhookEvent, error) {
 		Branch:     branch,
 		Commits:    commits,
 		Timestamp:  time.Now(),
-		Metadata:   map[string]string{"ref": pushEvent.Ref},
+		Metadata: map[string]string{
+			"ref":           pushEvent.Ref,
+			"head_commit":   pushEvent.CheckoutSHA,
+			"total_commits": strconv.Itoa(pushEvent.TotalCommits),
+		},
 	}, nil
 }
 
@@ -555,12 +570,129 @@ func (c *GitLabClient) parseTagPushEvent(payload []byte) (*WebhookEvent, error)
 	}
 	// Extract tag name from ref (refs/tags/v1.0.0 -> v1.0.0)
 	tag := strings.TrimPrefix(pushEvent.Ref, "refs/tags/")
+	// A deleted tag has no checkout commit.
+	action := "created"
+	if pushEvent.CheckoutSHA == "" {
+		action = "deleted"
+	}
 	return &WebhookEvent{
 		Type:       WebhookEventTag,
 		Repository: c.convertGitLabProject(&pushEvent.Project),
 		Branch:     tag, // reuse Branch field to carry the tag reference (could extend struct later)
+		Action:     action,
+		Timestamp:  time.Now(),
+		Metadata:   map[string]string{"ref": pushEvent.Ref, "tag": tag, "head_commit": pushEvent.CheckoutSHA},
+	}, nil
+}
+
+// gitlabReleaseEvent represents a GitLab release event.
+type gitlabReleaseEvent struct {
+	Action      string        `json:"action"` // create, update or delete
+	Tag         string        `json:"tag"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	URL         string        `json:"url"`
+	ReleasedAt  string        `json:"released_at"`
+	Project     gitlabProject `json:"project"`
+}
+
+// gitlabReleaseActions maps GitLab release actions to the GitHub-style actions of
+// WebhookEventRelease.
+var gitlabReleaseActions = map[string]string{
+	"create": "published",
+	"update": "edited",
+	"delete": "deleted",
+}
+
+// parseReleaseEvent parses a GitLab release event.
+func (c *GitLabClient) parseReleaseEvent(payload []byte) (*WebhookEvent, error) {
+	var releaseEvent gitlabReleaseEvent
+	if err := json.Unmarshal(payload, &releaseEvent); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal GitLab release event").
+			WithCause(err).
+			Build()
+	}
+	if releaseEvent.Project.ID == 0 {
+		return nil, errors.ForgeError("missing project in GitLab release event").Build()
+	}
+	action := gitlabReleaseActions[releaseEvent.Action]
+	if action == "" {
+		action = releaseEvent.Action
+	}
+	// GitLab formats released_at as "2006-01-02 15:04:05 MST".
+	published, err := time.Parse("2006-01-02 15:04:05 MST", releaseEvent.ReleasedAt)
+	if err != nil {
+		published, _ = time.Parse(time.RFC3339, releaseEvent.ReleasedAt)
+	}
+	return &WebhookEvent{
+		Type:       WebhookEventRelease,
+		Repository: c.convertGitLabProject(&releaseEvent.Project),
+		Action:     action,
+		Release: &WebhookRelease{
+			Tag:         releaseEvent.Tag,
+			Name:        releaseEvent.Name,
+			Notes:       releaseEvent.Description,
+			URL:         releaseEvent.URL,
+			PublishedAt: published,
+		},
+		Timestamp: time.Now(),
+		Metadata:  map[string]string{"action": releaseEvent.Action, "tag": releaseEvent.Tag},
+	}, nil
+}
+
+// gitlabProjectMoveEvent represents the project_rename and project_transfer system hook events.
+type gitlabProjectMoveEvent struct {
+	ProjectID            int    `json:"project_id"`
+	Name                 string `json:"name"`
+	Path                 string `json:"path"`
+	PathWithNamespace    string `json:"path_with_namespace"`
+	OldPathWithNamespace string `json:"old_path_with_namespace"`
+	ProjectVisibility    string `json:"project_visibility"`
+}
+
+// parseProjectMoveEvent parses a project rename or transfer system hook event into a
+// repository event. The payload has no repository URLs, so they are derived from the
+// forge base URL.
+func (c *GitLabClient) parseProjectMoveEvent(payload []byte, kind string) (*WebhookEvent, error) {
+	var moveEvent gitlabProjectMoveEvent
+	if err := json.Unmarshal(payload, &moveEvent); err != nil {
+		return nil, errors.ForgeError("failed to unmarshal GitLab project event").
+			WithCause(err).
+			WithContext("type", kind).
+			Build()
+	}
+	if moveEvent.PathWithNamespace == "" {
+		return nil, errors.ForgeError("missing project in GitLab project event").
+			WithContext("type", kind).
+			Build()
+	}
+	action := WebhookActionRenamed
+	if kind == "project_transfer" {
+		action = WebhookActionTransferred
+	}
+	repo := &Repository{
+		ID:       strconv.Itoa(moveEvent.ProjectID),
+		Name:     moveEvent.Path,
+		FullName: moveEvent.PathWithNamespace,
+		Private:  moveEvent.ProjectVisibility != "public",
+		Metadata: map[string]string{
+			"gitlab_id":  strconv.Itoa(moveEvent.ProjectID),
+			"visibility": moveEvent.ProjectVisibility,
+		},
+	}
+	if base := strings.TrimSuffix(c.baseURL, "/"); base != "" {
+		repo.CloneURL = base + "/" + moveEvent.PathWithNamespace + ".git"
+	}
+	return &WebhookEvent{
+		Type:       WebhookEventRepository,
+		Repository: repo,
+		Action:     action,
 		Timestamp:  time.Now(),
-		Metadata:   map[string]string{"ref": pushEvent.Ref, "tag": tag},
+		Changes: map[string]string{
+			"full_name_from": moveEvent.OldPathWithNamespace,
+			"full_name_to":   moveEvent.PathWithNamespace,
+		},
+		Metadata: map[string]string{"action": action, "event_name": kind},
 	}, nil
 }
 
@@ -599,7 +731,7 @@ func (c *GitLabClient) RegisterWebhook(ctx context.Context, repo *Repository, we
 
 	events := c.config.Webhook.Events
 	if len(events) == 0 {
-		events = []string{"push_events", "repository_update_events"}
+		events = []string{"push_events", "repository_update_events", "tag_push_events", "releases_events"}
 	}
 
 	payload := map[string]any{
@@ -614,6 +746,10 @@ func (c *GitLabClient) RegisterWebhook(ctx context.Context, repo *Repository, we
 			payload["push_events"] = true
 		case string(WebhookEventRepository), "repository_update_events":
 			payload["repository_update_events"] = true
+		case string(WebhookEventTag), "tag_push_events":
+			payload["tag_push_events"] = true
+		case string(WebhookEventRelease), "releases_events":
+			payload["releases_events"] = true
 		}
 	}
 
@@ -654,7 +790,9 @@ func (c *GitLabClient) convertGitLabProject(gProject *gitlabProject) *Repository
 		Description:   gProject.Description,
 		Private:       gProject.Visibility != "public",
 		Archived:      gProject.Archived,
+		Fork:          gProject.ForkedFromProject != nil,
 		LastUpdated:   gProject.LastActivityAt,
+		PushedAt:      gProject.LastActivityAt, // GitLab reports no push time
 		Topics:        gProject.Topics,
 		Language:      primaryLanguage,
 		Metadata: map[string]string{
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/auth/apptoken"
 	cfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 )
 
 // newHTTPClient30s returns a shared HTTP client with a 30s timeout.
@@ -40,6 +42,29 @@ func withDefaults(apiURL, baseURL, defAPI, defBase string) (string, string) {
 	return apiURL, baseURL
 }
 
+// baseForgeFromConfig creates the BaseForge for fg with the configured token or, for
+// github_app and oauth auth, a token source renewing access tokens as requests need them.
+// tokenEndpoint is the GitHub API URL for GitHub Apps or the OAuth token URL.
+func baseForgeFromConfig(fg *Config, forgeName, apiURL, tokenEndpoint string) (*BaseForge, error) {
+	if fg != nil && fg.Auth.UsesTokenSource() {
+		src, err := apptoken.For(fg.Auth, tokenEndpoint)
+		if err != nil {
+			return nil, errors.AuthError(fmt.Sprintf("%s client cannot use %s authentication", forgeName, fg.Auth.Type)).
+				WithCause(err).
+				WithContext("forge", fg.Name).
+				Build()
+		}
+		base := NewBaseForge(newHTTPClient30s(), apiURL, "")
+		base.tokenSource = src
+		return base, nil
+	}
+	tok, err := tokenFromConfig(fg, forgeName)
+	if err != nil {
+		return nil, err
+	}
+	return NewBaseForge(newHTTPClient30s(), apiURL, tok), nil
+}
+
 // tokenFromConfig extracts token from forge config or returns an error.
 func tokenFromConfig(fg *Config, forgeName string) (string, error) {
 	if fg != nil && fg.Auth != nil && fg.Auth.Type == cfg.AuthTypeToken {
@@ -0,0 +1,56 @@
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cfg "git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGitLabClient_OAuthClientCredentials(t *testing.T) {
+	tokenRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" {
+			http.NotFound(w, r)
+			return
+		}
+		tokenRequests++
+		_, _ = w.Write([]byte(`{"access_token":"oauth-access-token","expires_in":7200}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewGitLabClient(&Config{
+		Name:    "gitlab-oauth-test",
+		Type:    cfg.ForgeGitLab,
+		APIURL:  srv.URL + "/api/v4",
+		BaseURL: srv.URL + "/",
+		Auth:    &cfg.AuthConfig{Type: cfg.AuthTypeOAuth, ClientID: "forge-token-source-test", ClientSecret: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewGitLabClient: %v", err)
+	}
+	for range 2 {
+		req, err := client.NewRequest(t.Context(), http.MethodGet, "/groups", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer oauth-access-token" {
+			t.Fatalf("Authorization = %q", got)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Fatalf("expected the token to be reused, got %d token requests", tokenRequests)
+	}
+}
+
+func TestGitHubClient_TokenSourceRequiresValidKey(t *testing.T) {
+	_, err := NewGitHubClient(&Config{
+		Name: "github-app-test",
+		Type: cfg.ForgeGitHub,
+		Auth: &cfg.AuthConfig{Type: cfg.AuthTypeGitHubApp, AppID: 1, InstallationID: 2, PrivateKeyPath: "/nonexistent/app.pem"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unreadable private key")
+	}
+}
@@ -28,12 +28,18 @@ type Repository struct {
 	Description   string            `json:"description"`    // Repository description
 	Private       bool              `json:"private"`        // Is repository private
 	Archived      bool              `json:"archived"`       // Is repository archived
+	Fork          bool              `json:"fork"`           // Is repository a fork
 	HasDocs       bool              `json:"has_docs"`       // Does repository have docs folder
 	HasDocIgnore  bool              `json:"has_docignore"`  // Does repository have .docignore
 	LastUpdated   time.Time         `json:"last_updated"`   // Last update timestamp
+	PushedAt      time.Time         `json:"pushed_at"`      // Last push (or activity, where the forge has no push time)
 	Topics        []string          `json:"topics"`         // Repository topics/tags
 	Language      string            `json:"language"`       // Primary programming language
 	Metadata      map[string]string `json:"metadata"`       // Additional forge-specific metadata
+
+	// SkipReason is the reason code discovery filtered the repository out for (e.g.
+	// "fork"); empty for included repositories.
+	SkipReason string `json:"skip_reason,omitempty"`
 }
 
 // Organization represents an organization/group on a forge.
@@ -95,6 +101,7 @@ type WebhookEvent struct {
 	Commits    []WebhookCommit   `json:"commits"`
 	Action     string            `json:"action"`  // For repository events
 	Changes    map[string]string `json:"changes"` // For rename events
+	Release    *WebhookRelease   `json:"release,omitempty"`
 	Timestamp  time.Time         `json:"timestamp"`
 	Metadata   map[string]string `json:"metadata"` // Platform-specific data
 }
@@ -107,8 +114,26 @@ const (
 	WebhookEventRepository WebhookEventType = "repository" // created, deleted, renamed, archived
 	WebhookEventBranch     WebhookEventType = "branch"     // created, deleted
 	WebhookEventTag        WebhookEventType = "tag"        // created, deleted
+	WebhookEventRelease    WebhookEventType = "release"    // published, edited, deleted
+)
+
+// Actions of repository events that move a repository to a new full name. Their
+// Changes carry "full_name_from" and "full_name_to".
+const (
+	WebhookActionRenamed     = "renamed"
+	WebhookActionTransferred = "transferred"
 )
 
+// WebhookRelease describes the release of a release event.
+type WebhookRelease struct {
+	Tag         string    `json:"tag"`
+	Name        string    `json:"name"`
+	Notes       string    `json:"notes"` // Release notes (markdown)
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+	Prerelease  bool      `json:"prerelease"`
+}
+
 // WebhookCommit represents commit information from a webhook.
 type WebhookCommit struct {
 	ID        string    `json:"id"`
@@ -0,0 +1,164 @@
+package forge
+
+import (
+	"testing"
+	"time"
+)
+
+// expectedEvent is the part of a parsed tag, release or rename event the tests check.
+type expectedEvent struct {
+	typ          WebhookEventType
+	repo         string
+	action       string
+	tag          string
+	release      *WebhookRelease
+	fullNameFrom string
+}
+
+func checkWebhookEvent(t *testing.T, client Client, eventType, payload string, want expectedEvent) {
+	t.Helper()
+	event, err := client.ParseWebhookEvent([]byte(payload), eventType)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent(%s) error: %v", eventType, err)
+	}
+	if event.Type != want.typ {
+		t.Errorf("Type = %q, want %q", event.Type, want.typ)
+	}
+	if event.Repository == nil || event.Repository.FullName != want.repo {
+		t.Fatalf("Repository = %+v, want full name %q", event.Repository, want.repo)
+	}
+	if event.Action != want.action {
+		t.Errorf("Action = %q, want %q", event.Action, want.action)
+	}
+	if want.tag != "" && event.Metadata["tag"] != want.tag {
+		t.Errorf("Metadata[tag] = %q, want %q", event.Metadata["tag"], want.tag)
+	}
+	if want.release != nil {
+		if event.Release == nil {
+			t.Fatal("Release is nil")
+		}
+		if *event.Release != *want.release {
+			t.Errorf("Release = %+v, want %+v", *event.Release, *want.release)
+		}
+	}
+	if want.fullNameFrom != "" {
+		if event.Changes["full_name_from"] != want.fullNameFrom || event.Changes["full_name_to"] != want.repo {
+			t.Errorf("Changes = %v, want %s -> %s", event.Changes, want.fullNameFrom, want.repo)
+		}
+	}
+}
+
+func TestGitHubWebhookEvents(t *testing.T) {
+	client := &GitHubClient{}
+	repo := `"repository": {"id": 1, "name": "docs", "full_name": "acme/docs", "clone_url": "https://github.com/acme/docs.git"}`
+
+	t.Run("tag created", func(t *testing.T) {
+		checkWebhookEvent(t, client, "create", `{"ref": "v1.2.0", "ref_type": "tag", `+repo+`}`,
+			expectedEvent{typ: WebhookEventTag, repo: "acme/docs", action: "created", tag: "v1.2.0"})
+	})
+	t.Run("tag deleted", func(t *testing.T) {
+		checkWebhookEvent(t, client, "delete", `{"ref": "v1.2.0", "ref_type": "tag", `+repo+`}`,
+			expectedEvent{typ: WebhookEventTag, repo: "acme/docs", action: "deleted", tag: "v1.2.0"})
+	})
+	t.Run("branch created", func(t *testing.T) {
+		checkWebhookEvent(t, client, "create", `{"ref": "feature", "ref_type": "branch", `+repo+`}`,
+			expectedEvent{typ: WebhookEventBranch, repo: "acme/docs", action: "created"})
+	})
+	t.Run("release published", func(t *testing.T) {
+		payload := `{"action": "published", "release": {"tag_name": "v1.2.0", "name": "Version 1.2", "body": "## Changes\n\n- Faster builds",
+			"html_url": "https://github.com/acme/docs/releases/tag/v1.2.0", "published_at": "2026-03-01T10:00:00Z", "prerelease": false}, ` + repo + `}`
+		checkWebhookEvent(t, client, "release", payload, expectedEvent{
+			typ: WebhookEventRelease, repo: "acme/docs", action: "published", tag: "v1.2.0",
+			release: &WebhookRelease{
+				Tag: "v1.2.0", Name: "Version 1.2", Notes: "## Changes\n\n- Faster builds",
+				URL:         "https://github.com/acme/docs/releases/tag/v1.2.0",
+				PublishedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			},
+		})
+	})
+	t.Run("repository renamed", func(t *testing.T) {
+		payload := `{"action": "renamed", "changes": {"repository": {"name": {"from": "old-docs"}}}, ` + repo + `}`
+		checkWebhookEvent(t, client, "repository", payload,
+			expectedEvent{typ: WebhookEventRepository, repo: "acme/docs", action: WebhookActionRenamed, fullNameFrom: "acme/old-docs"})
+	})
+	t.Run("repository transferred", func(t *testing.T) {
+		payload := `{"action": "transferred", "changes": {"owner": {"from": {"organization": {"login": "old-org"}}}}, ` + repo + `}`
+		checkWebhookEvent(t, client, "repository", payload,
+			expectedEvent{typ: WebhookEventRepository, repo: "acme/docs", action: WebhookActionTransferred, fullNameFrom: "old-org/docs"})
+	})
+}
+
+func TestGitLabWebhookEvents(t *testing.T) {
+	client := &GitLabClient{baseURL: "https://gitlab.example.com"}
+	project := `"project": {"id": 7, "path": "docs", "path_with_namespace": "acme/docs", "http_url_to_repo": "https://gitlab.example.com/acme/docs.git"}`
+
+	t.Run("tag pushed", func(t *testing.T) {
+		payload := `{"object_kind": "tag_push", "ref": "refs/tags/v1.2.0", "checkout_sha": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7", ` + project + `}`
+		checkWebhookEvent(t, client, "Tag Push Hook", payload,
+			expectedEvent{typ: WebhookEventTag, repo: "acme/docs", action: "created", tag: "v1.2.0"})
+	})
+	t.Run("tag deleted", func(t *testing.T) {
+		payload := `{"object_kind": "tag_push", "ref": "refs/tags/v1.2.0", "checkout_sha": null, ` + project + `}`
+		checkWebhookEvent(t, client, "Tag Push Hook", payload,
+			expectedEvent{typ: WebhookEventTag, repo: "acme/docs", action: "deleted", tag: "v1.2.0"})
+	})
+	t.Run("release created", func(t *testing.T) {
+		payload := `{"object_kind": "release", "action": "create", "tag": "v1.2.0", "name": "Version 1.2", "description": "Faster builds",
+			"url": "https://gitlab.example.com/acme/docs/-/releases/v1.2.0", "released_at": "2026-03-01 10:00:00 UTC", ` + project + `}`
+		checkWebhookEvent(t, client, "Release Hook", payload, expectedEvent{
+			typ: WebhookEventRelease, repo: "acme/docs", action: "published", tag: "v1.2.0",
+			release: &WebhookRelease{
+				Tag: "v1.2.0", Name: "Version 1.2", Notes: "Faster builds",
+				URL:         "https://gitlab.example.com/acme/docs/-/releases/v1.2.0",
+				PublishedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			},
+		})
+	})
+	t.Run("project renamed", func(t *testing.T) {
+		payload := `{"event_name": "project_rename", "project_id": 7, "name": "Docs", "path": "docs",
+			"path_with_namespace": "acme/docs", "old_path_with_namespace": "acme/old-docs", "project_visibility": "private"}`
+		checkWebhookEvent(t, client, "System Hook", payload,
+			expectedEvent{typ: WebhookEventRepository, repo: "acme/docs", action: WebhookActionRenamed, fullNameFrom: "acme/old-docs"})
+	})
+	t.Run("project transferred", func(t *testing.T) {
+		payload := `{"event_name": "project_transfer", "project_id": 7, "name": "Docs", "path": "docs",
+			"path_with_namespace": "platform/docs", "old_path_with_namespace": "acme/docs", "project_visibility": "public"}`
+		event, err := client.ParseWebhookEvent([]byte(payload), "System Hook")
+		if err != nil {
+			t.Fatalf("ParseWebhookEvent() error: %v", err)
+		}
+		if event.Action != WebhookActionTransferred || event.Changes["full_name_from"] != "acme/docs" {
+			t.Errorf("event = %+v, want transfer from acme/docs", event)
+		}
+		if event.Repository.CloneURL != "https://gitlab.example.com/platform/docs.git" {
+			t.Errorf("CloneURL = %q, want the URL below the forge base URL", event.Repository.CloneURL)
+		}
+	})
+}
+
+func TestForgejoWebhookEvents(t *testing.T) {
+	client := &ForgejoClient{}
+	repo := `"repository": {"id": 3, "name": "docs", "full_name": "acme/docs", "clone_url": "https://forgejo.example.com/acme/docs.git"}`
+
+	t.Run("tag created", func(t *testing.T) {
+		checkWebhookEvent(t, client, "create", `{"ref": "v1.2.0", "ref_type": "tag", "sha": "abc", `+repo+`}`,
+			expectedEvent{typ: WebhookEventTag, repo: "acme/docs", action: "created", tag: "v1.2.0"})
+	})
+	t.Run("release published", func(t *testing.T) {
+		payload := `{"action": "published", "release": {"tag_name": "v1.2.0", "name": "", "body": "Faster builds",
+			"html_url": "https://forgejo.example.com/acme/docs/releases/tag/v1.2.0", "published_at": "2026-03-01T10:00:00Z", "prerelease": true}, ` + repo + `}`
+		checkWebhookEvent(t, client, "release", payload, expectedEvent{
+			typ: WebhookEventRelease, repo: "acme/docs", action: "published", tag: "v1.2.0",
+			release: &WebhookRelease{
+				Tag: "v1.2.0", Notes: "Faster builds", Prerelease: true,
+				URL:         "https://forgejo.example.com/acme/docs/releases/tag/v1.2.0",
+				PublishedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			},
+		})
+	})
+	t.Run("release updated", func(t *testing.T) {
+		payload := `{"action": "updated", "release": {"tag_name": "v1.2.0"}, ` + repo + `}`
+		checkWebhookEvent(t, client, "release", payload,
+			expectedEvent{typ: WebhookEventRelease, repo: "acme/docs", action: "edited", tag: "v1.2.0"})
+	})
+}
@@ -311,6 +311,29 @@ func TestGitLabWebhookParsing(t *testing.T) {
 	}
 }
 
+func TestGitLabWebhookParsingTotalCommits(t *testing.T) {
+	client := &GitLabClient{}
+
+	payload := `{
+		"object_kind": "push",
+		"ref": "refs/heads/main",
+		"total_commits_count": 25,
+		"project": {"id": 1, "path_with_namespace": "org/repo"},
+		"commits": [{"id": "a1", "modified": ["docs/index.md"]}]
+	}`
+
+	event, err := client.ParseWebhookEvent([]byte(payload), "Push Hook")
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() unexpected error: %v", err)
+	}
+	if got := event.Metadata["total_commits"]; got != "25" {
+		t.Errorf("Metadata[total_commits] = %q, want 25", got)
+	}
+	if len(event.Commits) != 1 || len(event.Commits[0].Modified) != 1 {
+		t.Errorf("Commits = %+v, want one commit with one modified file", event.Commits)
+	}
+}
+
 func TestForgejoWebhookParsing(t *testing.T) {
 	client := &ForgejoClient{}
 
@@ -11,6 +11,7 @@ type ErrorBuilder struct {
 	message  string
 	cause    error
 	context  ErrorContext
+	code     ErrorCode
 }
 
 // NewError creates a new ErrorBuilder with the specified category and message.
@@ -54,6 +55,12 @@ func (b *ErrorBuilder) WithRetry(strategy RetryStrategy) *ErrorBuilder {
 	return b
 }
 
+// WithCode sets the stable error code (see Catalog).
+func (b *ErrorBuilder) WithCode(code ErrorCode) *ErrorBuilder {
+	b.code = code
+	return b
+}
+
 // WithCause sets the underlying cause error.
 func (b *ErrorBuilder) WithCause(err error) *ErrorBuilder {
 	b.cause = err
@@ -116,6 +123,7 @@ func (b *ErrorBuilder) Build() *ClassifiedError {
 		message:  b.message,
 		cause:    b.cause,
 		context:  b.context,
+		code:     b.code,
 	}
 }
 
@@ -14,6 +14,7 @@ type ClassifiedError struct {
 	message  string
 	cause    error
 	context  ErrorContext
+	code     ErrorCode
 }
 
 // Error implements the standard error interface.
@@ -54,6 +55,20 @@ func (e *ClassifiedError) Cause() error {
 	return e.cause
 }
 
+// Code returns the stable error code: the code set on this error, else the code of the
+// nearest classified cause, else the fallback code of the category.
+func (e *ClassifiedError) Code() ErrorCode {
+	if e.code != "" {
+		return e.code
+	}
+	for cause := e.cause; cause != nil; cause = stdErrors.Unwrap(cause) {
+		if c, ok := cause.(*ClassifiedError); ok && c.code != "" {
+			return c.code
+		}
+	}
+	return CategoryCode(e.category)
+}
+
 // Context returns the error context.
 func (e *ClassifiedError) Context() ErrorContext {
 	return e.context
@@ -69,6 +84,7 @@ func (e *ClassifiedError) WithContext(key string, value any) *ClassifiedError {
 		message:  e.message,
 		cause:    e.cause,
 		context:  newContext,
+		code:     e.code,
 	}
 }
 
@@ -82,6 +98,7 @@ func (e *ClassifiedError) WithContextMap(ctx ErrorContext) *ClassifiedError {
 		message:  e.message,
 		cause:    e.cause,
 		context:  newContext,
+		code:     e.code,
 	}
 }
 
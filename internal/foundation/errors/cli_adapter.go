@@ -39,30 +39,9 @@ func (a *CLIErrorAdapter) ExitCodeFor(err error) int {
 	return 1
 }
 
-// exitCodeFromClassified maps ClassifiedError to exit codes.
+// exitCodeFromClassified maps ClassifiedError to exit codes (see ExitCodeForCategory).
 func (a *CLIErrorAdapter) exitCodeFromClassified(err *ClassifiedError) int {
-	switch err.Category() {
-	case CategoryValidation:
-		return 2 // Invalid usage
-	case CategoryConfig:
-		return 7 // Configuration error
-	case CategoryAuth:
-		return 5 // Permission/auth error
-	case CategoryNotFound:
-		return 1 // General error (resource not found)
-	case CategoryAlreadyExists:
-		return 1 // General error (conflict)
-	case CategoryNetwork, CategoryGit, CategoryForge:
-		return 8 // External system error
-	case CategoryBuild, CategoryHugo, CategoryFileSystem, CategoryDocs, CategoryEventStore:
-		return 11 // Build error
-	case CategoryDaemon, CategoryRuntime:
-		return 12 // Runtime error
-	case CategoryInternal:
-		return 10 // Internal error
-	default:
-		return 1 // General error
-	}
+	return ExitCodeForCategory(err.Category())
 }
 
 // FormatError formats an error for user-friendly display.
@@ -80,15 +59,19 @@ func (a *CLIErrorAdapter) FormatError(err error) string {
 	return fmt.Sprintf("Error: %v", err)
 }
 
-// formatClassified formats a ClassifiedError for display.
+// formatClassified formats a ClassifiedError for display. The stable code is always
+// shown so scripts can branch on it; internal error details require verbose mode.
 func (a *CLIErrorAdapter) formatClassified(err *ClassifiedError) string {
-	// For now, treat all foundation errors as internal since we don't have user-facing flags
 	if a.verbose {
-		return err.Error()
+		return fmt.Sprintf("Error [%s]: %s", err.Code(), err.Error())
 	}
-
-	// Non-verbose mode for internal errors
-	return "Internal error occurred (use -v for details)"
+	if err.Category() == CategoryInternal {
+		return fmt.Sprintf("Error [%s]: Internal error occurred (use -v for details)", err.Code())
+	}
+	if err.Cause() != nil {
+		return fmt.Sprintf("Error [%s]: %s: %v", err.Code(), err.Message(), err.Cause())
+	}
+	return fmt.Sprintf("Error [%s]: %s", err.Code(), err.Message())
 }
 
 // HandleError processes an error and exits the program with appropriate code.
@@ -134,6 +117,7 @@ func (a *CLIErrorAdapter) logError(err error) {
 		level := a.slogLevelFromSeverity(classified.Severity())
 		attrs := []slog.Attr{
 			slog.String("category", string(classified.Category())),
+			slog.String("code", string(classified.Code())),
 		}
 		if classified.CanRetry() {
 			attrs = append(attrs, slog.Bool("retryable", true))
@@ -121,3 +121,16 @@ type customError struct {
 func (e *customError) Error() string {
 	return e.msg
 }
+
+func TestCLIErrorAdapter_FormatIncludesCode(t *testing.T) {
+	adapter := NewCLIErrorAdapter(false, slog.Default())
+	err := NewError(CategoryConfig, "config file not found").WithCode(CodeConfigNotFound).Build()
+
+	msg := adapter.FormatError(err)
+	if !strings.Contains(msg, "[DB-CFG-001]") || !strings.Contains(msg, "config file not found") {
+		t.Errorf("FormatError() = %q, want code and message", msg)
+	}
+	if got := adapter.ExitCodeFor(err); got != 7 {
+		t.Errorf("ExitCodeFor() = %d, want 7", got)
+	}
+}
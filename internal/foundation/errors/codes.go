@@ -0,0 +1,204 @@
+package errors
+
+import (
+	"slices"
+	"strings"
+)
+
+// ErrorCode is a stable, machine-readable identifier for a failure ("DB-<AREA>-<NNN>").
+// Codes are never reused or renumbered; CI pipelines and API clients may branch on them.
+type ErrorCode string
+
+// Category fallback codes, used when an error carries no specific code.
+const (
+	CodeConfig        ErrorCode = "DB-CFG-000"
+	CodeValidation    ErrorCode = "DB-VAL-000"
+	CodeAuth          ErrorCode = "DB-AUTH-000"
+	CodeNotFound      ErrorCode = "DB-NF-000"
+	CodeAlreadyExists ErrorCode = "DB-DUP-000"
+	CodeNetwork       ErrorCode = "DB-NET-000"
+	CodeGit           ErrorCode = "DB-GIT-000"
+	CodeForge         ErrorCode = "DB-FRG-000"
+	CodeBuild         ErrorCode = "DB-BLD-000"
+	CodeHugo          ErrorCode = "DB-HUGO-000"
+	CodeFileSystem    ErrorCode = "DB-FS-000"
+	CodeDocs          ErrorCode = "DB-DOCS-000"
+	CodeEventStore    ErrorCode = "DB-EVT-000"
+	CodeRuntime       ErrorCode = "DB-RT-000"
+	CodeDaemon        ErrorCode = "DB-DMN-000"
+	CodeInternal      ErrorCode = "DB-INT-000"
+)
+
+// Specific codes.
+const (
+	CodeConfigNotFound           ErrorCode = "DB-CFG-001"
+	CodeConfigParse              ErrorCode = "DB-CFG-002"
+	CodeConfigVersion            ErrorCode = "DB-CFG-003"
+	CodeConfigInvalid            ErrorCode = "DB-CFG-004"
+	CodeGitAuth                  ErrorCode = "DB-GIT-001"
+	CodeGitRepoNotFound          ErrorCode = "DB-GIT-002"
+	CodeGitNetwork               ErrorCode = "DB-GIT-003"
+	CodeGitRateLimit             ErrorCode = "DB-GIT-004"
+	CodeGitDiverged              ErrorCode = "DB-GIT-005"
+	CodeGitUnsupportedProtocol   ErrorCode = "DB-GIT-006"
+	CodeGitOperation             ErrorCode = "DB-GIT-007"
+	CodeGitCacheCorrupted        ErrorCode = "DB-GIT-008"
+	CodeHugoNotFound             ErrorCode = "DB-HUGO-001"
+	CodeHugoExecution            ErrorCode = "DB-HUGO-002"
+	CodeHugoGoToolchain          ErrorCode = "DB-HUGO-003"
+	CodeBuildGuardrail           ErrorCode = "DB-BLD-001"
+	CodeBuildContentBudget       ErrorCode = "DB-BLD-002"
+	CodeBuildContentTransform    ErrorCode = "DB-BLD-003"
+	CodeBuildStaging             ErrorCode = "DB-BLD-004"
+	CodeBuildNoDocumentation     ErrorCode = "DB-BLD-005"
+	CodeBuildIndexGeneration     ErrorCode = "DB-BLD-006"
+	CodeBuildHugoConfigWrite     ErrorCode = "DB-BLD-007"
+	CodeBuildLayoutCopy          ErrorCode = "DB-BLD-008"
+	CodeBuildContentWrite        ErrorCode = "DB-BLD-009"
+	CodeBuildReportPersistFailed ErrorCode = "DB-BLD-010"
+	CodeBuildIntegrity           ErrorCode = "DB-BLD-011"
+	CodeBuildSelftest            ErrorCode = "DB-BLD-012"
+	CodeBuildOutputValidation    ErrorCode = "DB-BLD-013"
+	CodeBuildAccessibility       ErrorCode = "DB-BLD-014"
+	CodeInternalPanic            ErrorCode = "DB-INT-001"
+	CodeRuntimeRateLimited       ErrorCode = "DB-RT-001"
+	CodeAuthForbiddenSource      ErrorCode = "DB-AUTH-001"
+	CodeAuthInsufficientScope    ErrorCode = "DB-AUTH-002"
+)
+
+// CatalogEntry describes one error code.
+type CatalogEntry struct {
+	Code     ErrorCode     `json:"code"`
+	Category ErrorCategory `json:"category"`
+	ExitCode int           `json:"exit_code"`
+	Summary  string        `json:"summary"`
+}
+
+// catalog is the source of the error catalog (`docbuilder errors list`).
+var catalog = []CatalogEntry{
+	{Code: CodeConfig, Category: CategoryConfig, Summary: "Configuration error"},
+	{Code: CodeConfigNotFound, Category: CategoryConfig, Summary: "Configuration file not found"},
+	{Code: CodeConfigParse, Category: CategoryConfig, Summary: "Configuration file could not be read or parsed"},
+	{Code: CodeConfigVersion, Category: CategoryConfig, Summary: "Unsupported configuration version"},
+	{Code: CodeConfigInvalid, Category: CategoryConfig, Summary: "Configuration failed validation"},
+	{Code: CodeValidation, Category: CategoryValidation, Summary: "Invalid input or request"},
+	{Code: CodeAuth, Category: CategoryAuth, Summary: "Authentication or authorization failed"},
+	{Code: CodeAuthForbiddenSource, Category: CategoryAuth, Summary: "Request source is not allowed: address outside the allowlist or missing client certificate"},
+	{Code: CodeAuthInsufficientScope, Category: CategoryAuth, Summary: "The API token is valid but its scopes do not allow this operation"},
+	{Code: CodeNotFound, Category: CategoryNotFound, Summary: "Resource not found"},
+	{Code: CodeAlreadyExists, Category: CategoryAlreadyExists, Summary: "Resource already exists"},
+	{Code: CodeNetwork, Category: CategoryNetwork, Summary: "Network error"},
+	{Code: CodeGit, Category: CategoryGit, Summary: "Git error"},
+	{Code: CodeGitAuth, Category: CategoryAuth, Summary: "Git authentication failed"},
+	{Code: CodeGitRepoNotFound, Category: CategoryNotFound, Summary: "Git repository or reference not found"},
+	{Code: CodeGitNetwork, Category: CategoryNetwork, Summary: "Git remote unreachable or connection interrupted (retryable)"},
+	{Code: CodeGitRateLimit, Category: CategoryNetwork, Summary: "Git remote rate limit reached (retryable)"},
+	{Code: CodeGitDiverged, Category: CategoryGit, Summary: "Local branch diverged from remote"},
+	{Code: CodeGitUnsupportedProtocol, Category: CategoryConfig, Summary: "Unsupported git protocol in repository URL"},
+	{Code: CodeGitOperation, Category: CategoryGit, Summary: "Git operation failed"},
+	{Code: CodeGitCacheCorrupted, Category: CategoryGit, Summary: "Cached repository failed integrity verification"},
+	{Code: CodeForge, Category: CategoryForge, Summary: "Forge API error"},
+	{Code: CodeBuild, Category: CategoryBuild, Summary: "Build error"},
+	{Code: CodeBuildGuardrail, Category: CategoryBuild, Summary: "Repository exceeded a content guardrail"},
+	{Code: CodeBuildContentBudget, Category: CategoryBuild, Summary: "Markdown content exceeded the memory budget"},
+	{Code: CodeBuildContentTransform, Category: CategoryBuild, Summary: "Content transform failed"},
+	{Code: CodeBuildStaging, Category: CategoryBuild, Summary: "Staging directory operation failed"},
+	{Code: CodeBuildNoDocumentation, Category: CategoryBuild, Summary: "No documentation files found"},
+	{Code: CodeBuildIndexGeneration, Category: CategoryBuild, Summary: "Index page generation failed"},
+	{Code: CodeBuildHugoConfigWrite, Category: CategoryBuild, Summary: "Hugo configuration could not be generated"},
+	{Code: CodeBuildLayoutCopy, Category: CategoryBuild, Summary: "Layout copy failed"},
+	{Code: CodeBuildContentWrite, Category: CategoryBuild, Summary: "Writing generated content failed"},
+	{Code: CodeBuildReportPersistFailed, Category: CategoryBuild, Summary: "Build report could not be written"},
+	{Code: CodeBuildIntegrity, Category: CategoryBuild, Summary: "Published site does not match its checksum file or signature"},
+	{Code: CodeBuildSelftest, Category: CategoryBuild, Summary: "Self-test site differs from the bundled golden snapshots"},
+	{Code: CodeBuildOutputValidation, Category: CategoryBuild, Summary: "Rendered site failed an output validation check"},
+	{Code: CodeBuildAccessibility, Category: CategoryBuild, Summary: "Rendered site exceeds the accessibility violation threshold"},
+	{Code: CodeHugo, Category: CategoryHugo, Summary: "Hugo error"},
+	{Code: CodeHugoNotFound, Category: CategoryHugo, Summary: "Hugo binary not found on PATH"},
+	{Code: CodeHugoExecution, Category: CategoryHugo, Summary: "Hugo exited with an error"},
+	{Code: CodeHugoGoToolchain, Category: CategoryHugo, Summary: "Go toolchain required by Hugo Modules not found"},
+	{Code: CodeFileSystem, Category: CategoryFileSystem, Summary: "Filesystem error"},
+	{Code: CodeDocs, Category: CategoryDocs, Summary: "Documentation discovery or processing error"},
+	{Code: CodeEventStore, Category: CategoryEventStore, Summary: "Event store error"},
+	{Code: CodeRuntime, Category: CategoryRuntime, Summary: "Runtime error"},
+	{Code: CodeRuntimeRateLimited, Category: CategoryRuntime, Summary: "Too many requests from this client; retry after the Retry-After delay"},
+	{Code: CodeDaemon, Category: CategoryDaemon, Summary: "Daemon error"},
+	{Code: CodeInternal, Category: CategoryInternal, Summary: "Internal error"},
+	{Code: CodeInternalPanic, Category: CategoryInternal, Summary: "Recovered from a panic; a crash report was written"},
+}
+
+// categoryCodes maps each category to its fallback code.
+var categoryCodes = map[ErrorCategory]ErrorCode{
+	CategoryConfig:        CodeConfig,
+	CategoryValidation:    CodeValidation,
+	CategoryAuth:          CodeAuth,
+	CategoryNotFound:      CodeNotFound,
+	CategoryAlreadyExists: CodeAlreadyExists,
+	CategoryNetwork:       CodeNetwork,
+	CategoryGit:           CodeGit,
+	CategoryForge:         CodeForge,
+	CategoryBuild:         CodeBuild,
+	CategoryHugo:          CodeHugo,
+	CategoryFileSystem:    CodeFileSystem,
+	CategoryDocs:          CodeDocs,
+	CategoryEventStore:    CodeEventStore,
+	CategoryRuntime:       CodeRuntime,
+	CategoryDaemon:        CodeDaemon,
+	CategoryInternal:      CodeInternal,
+}
+
+// CategoryCode returns the fallback code of a category.
+func CategoryCode(category ErrorCategory) ErrorCode {
+	if code, ok := categoryCodes[category]; ok {
+		return code
+	}
+	return CodeInternal
+}
+
+// ExitCodeForCategory returns the CLI exit status for errors of a category.
+func ExitCodeForCategory(category ErrorCategory) int {
+	switch category {
+	case CategoryValidation:
+		return 2 // Invalid usage
+	case CategoryConfig:
+		return 7 // Configuration error
+	case CategoryAuth:
+		return 5 // Permission/auth error
+	case CategoryNotFound:
+		return 1 // General error (resource not found)
+	case CategoryAlreadyExists:
+		return 1 // General error (conflict)
+	case CategoryNetwork, CategoryGit, CategoryForge:
+		return 8 // External system error
+	case CategoryBuild, CategoryHugo, CategoryFileSystem, CategoryDocs, CategoryEventStore:
+		return 11 // Build error
+	case CategoryDaemon, CategoryRuntime:
+		return 12 // Runtime error
+	case CategoryInternal:
+		return 10 // Internal error
+	default:
+		return 1 // General error
+	}
+}
+
+// Catalog returns every known error code, ordered by code.
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, len(catalog))
+	for i, e := range catalog {
+		e.ExitCode = ExitCodeForCategory(e.Category)
+		entries[i] = e
+	}
+	slices.SortFunc(entries, func(a, b CatalogEntry) int { return strings.Compare(string(a.Code), string(b.Code)) })
+	return entries
+}
+
+// LookupCode returns the catalog entry of a code.
+func LookupCode(code ErrorCode) (CatalogEntry, bool) {
+	for _, e := range catalog {
+		if e.Code == code {
+			e.ExitCode = ExitCodeForCategory(e.Category)
+			return e, true
+		}
+	}
+	return CatalogEntry{}, false
+}
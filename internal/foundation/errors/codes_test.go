@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestCatalog_CodesAreUniqueSortedAndWellFormed(t *testing.T) {
+	pattern := regexp.MustCompile(`^DB-[A-Z]+-\d{3}$`)
+	entries := Catalog()
+	seen := make(map[ErrorCode]bool, len(entries))
+	for i, e := range entries {
+		if !pattern.MatchString(string(e.Code)) {
+			t.Errorf("code %q does not match DB-<AREA>-<NNN>", e.Code)
+		}
+		if seen[e.Code] {
+			t.Errorf("duplicate code %q", e.Code)
+		}
+		seen[e.Code] = true
+		if i > 0 && entries[i-1].Code >= e.Code {
+			t.Errorf("catalog not sorted at %q", e.Code)
+		}
+		if e.Summary == "" {
+			t.Errorf("code %q has no summary", e.Code)
+		}
+		if e.ExitCode != ExitCodeForCategory(e.Category) {
+			t.Errorf("code %q exit = %d, want %d", e.Code, e.ExitCode, ExitCodeForCategory(e.Category))
+		}
+	}
+	for category, code := range categoryCodes {
+		entry, ok := LookupCode(code)
+		if !ok {
+			t.Errorf("fallback code %q of %s missing from catalog", code, category)
+			continue
+		}
+		if entry.Category != category {
+			t.Errorf("fallback code %q category = %s, want %s", code, entry.Category, category)
+		}
+	}
+}
+
+func TestClassifiedError_Code(t *testing.T) {
+	t.Run("category fallback", func(t *testing.T) {
+		err := NewError(CategoryNetwork, "timeout").Build()
+		if got := err.Code(); got != CodeNetwork {
+			t.Errorf("Code() = %q, want %q", got, CodeNetwork)
+		}
+	})
+
+	t.Run("explicit code survives WithContext", func(t *testing.T) {
+		err := NewError(CategoryGit, "clone failed").WithCode(CodeGitOperation).Build().WithContext("repo", "a")
+		if got := err.Code(); got != CodeGitOperation {
+			t.Errorf("Code() = %q, want %q", got, CodeGitOperation)
+		}
+	})
+
+	t.Run("inherits code of classified cause", func(t *testing.T) {
+		cause := NewError(CategoryAuth, "denied").WithCode(CodeGitAuth).Build()
+		err := WrapError(fmt.Errorf("clone: %w", cause), CategoryGit, "update failed").Build()
+		if got := err.Code(); got != CodeGitAuth {
+			t.Errorf("Code() = %q, want %q", got, CodeGitAuth)
+		}
+	})
+}
+
+func TestLookupCode_Unknown(t *testing.T) {
+	if _, ok := LookupCode("DB-XYZ-999"); ok {
+		t.Error("expected unknown code to be absent")
+	}
+}
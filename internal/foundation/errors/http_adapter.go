@@ -23,11 +23,16 @@ func NewHTTPErrorAdapter(logger *slog.Logger) *HTTPErrorAdapter {
 // HTTPErrorResponse represents a standard JSON error payload.
 type HTTPErrorResponse struct {
 	Error     string         `json:"error"`
-	Code      string         `json:"code,omitempty"`
+	Code      string         `json:"code,omitempty"`       // error category
+	ErrorCode string         `json:"error_code,omitempty"` // stable error code (see Catalog)
 	Details   map[string]any `json:"details,omitempty"`
 	Retryable bool           `json:"retryable,omitempty"`
+	RequestID string         `json:"request_id,omitempty"` // correlation ID (X-Request-ID)
 }
 
+// requestIDHeader is set on responses by the server request ID middleware.
+const requestIDHeader = "X-Request-ID"
+
 // StatusCodeFor determines the HTTP status code for a given error based on
 // its classification. Unknown errors map to 500.
 func (a *HTTPErrorAdapter) StatusCodeFor(err error) int {
@@ -37,6 +42,12 @@ func (a *HTTPErrorAdapter) StatusCodeFor(err error) int {
 
 	// Classified errors from the foundation package
 	if c, ok := AsClassified(err); ok {
+		switch c.Code() {
+		case CodeRuntimeRateLimited:
+			return http.StatusTooManyRequests
+		case CodeAuthForbiddenSource, CodeAuthInsufficientScope:
+			return http.StatusForbidden
+		}
 		switch c.Category() {
 		case CategoryValidation, CategoryConfig:
 			return http.StatusBadRequest
@@ -72,11 +83,9 @@ func (a *HTTPErrorAdapter) WriteErrorResponse(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	ctx := r.Context()
-	_ = ctx // Use context for future enhancements (tracing, etc.)
-
 	status := a.StatusCodeFor(err)
 	payload := a.FormatErrorResponse(err)
+	payload.RequestID = w.Header().Get(requestIDHeader)
 
 	b, jerr := json.Marshal(payload)
 	if jerr != nil {
@@ -106,7 +115,7 @@ func (a *HTTPErrorAdapter) FormatErrorResponse(err error) HTTPErrorResponse {
 		return HTTPErrorResponse{Error: ""}
 	}
 	if c, ok := AsClassified(err); ok {
-		resp := HTTPErrorResponse{Error: c.Message(), Code: string(c.Category())}
+		resp := HTTPErrorResponse{Error: c.Message(), Code: string(c.Category()), ErrorCode: string(c.Code())}
 		if len(c.Context()) > 0 {
 			resp.Details = map[string]any(c.Context())
 		}
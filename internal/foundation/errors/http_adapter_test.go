@@ -49,6 +49,27 @@ func TestHTTPErrorAdapter_StatusCodeFor(t *testing.T) {
 				Build(),
 			expected: http.StatusConflict,
 		},
+		{
+			name: "rate limited error",
+			err: NewError(CategoryRuntime, "too many requests").
+				WithCode(CodeRuntimeRateLimited).
+				Build(),
+			expected: http.StatusTooManyRequests,
+		},
+		{
+			name: "forbidden source error",
+			err: NewError(CategoryAuth, "source not allowed").
+				WithCode(CodeAuthForbiddenSource).
+				Build(),
+			expected: http.StatusForbidden,
+		},
+		{
+			name: "insufficient scope error",
+			err: NewError(CategoryAuth, "token scope does not allow this operation").
+				WithCode(CodeAuthInsufficientScope).
+				Build(),
+			expected: http.StatusForbidden,
+		},
 		{
 			name:     "unclassified error",
 			err:      &customHTTPError{msg: "unknown error"},
@@ -116,6 +137,10 @@ func TestHTTPErrorAdapter_WriteErrorResponse(t *testing.T) {
 					t.Error("WriteErrorResponse() missing error code")
 				}
 
+				if response.ErrorCode != string(CodeValidation) {
+					t.Errorf("WriteErrorResponse() error_code = %q, want %q", response.ErrorCode, CodeValidation)
+				}
+
 				// Check content type
 				contentType := w.Header().Get("Content-Type")
 				if contentType != "application/json" {
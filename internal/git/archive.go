@@ -0,0 +1,53 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// ErrArchiveUnsupported is returned by ArchiveURL for repositories whose forge cannot
+// serve archives of a commit; they are cloned instead.
+var ErrArchiveUnsupported = errors.New("archive download not supported for repository")
+
+// ArchiveURL returns the URL of a tar.gz archive of commit on the repository's forge.
+// GitHub (including GitHub Enterprise) and GitLab are supported; the forge is taken
+// from the forge_type tag set by discovery, or else recognized from the host name.
+// Only HTTP(S) repository URLs are supported.
+func ArchiveURL(repo appcfg.Repository, commit string) (string, error) {
+	u, err := url.Parse(repo.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("%w: %s is not an HTTP(S) URL", ErrArchiveUnsupported, repo.URL)
+	}
+	project := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if project == "" || !strings.Contains(project, "/") {
+		return "", fmt.Errorf("%w: no project path in %s", ErrArchiveUnsupported, repo.URL)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	forgeType := appcfg.ForgeType(strings.ToLower(repo.Tags["forge_type"]))
+	if forgeType == "" {
+		switch {
+		case host == "github.com" || strings.HasPrefix(host, "github."):
+			forgeType = appcfg.ForgeGitHub
+		case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+			forgeType = appcfg.ForgeGitLab
+		}
+	}
+
+	switch forgeType {
+	case appcfg.ForgeGitHub:
+		if host == "github.com" {
+			return fmt.Sprintf("https://api.github.com/repos/%s/tarball/%s", project, commit), nil
+		}
+		return fmt.Sprintf("%s://%s/api/v3/repos/%s/tarball/%s", u.Scheme, u.Host, project, commit), nil
+	case appcfg.ForgeGitLab:
+		return fmt.Sprintf("%s://%s/api/v4/projects/%s/repository/archive.tar.gz?sha=%s",
+			u.Scheme, u.Host, url.PathEscape(project), commit), nil
+	default:
+		return "", fmt.Errorf("%w: unknown forge for %s", ErrArchiveUnsupported, repo.URL)
+	}
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"errors"
+	"testing"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestArchiveURL(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name string
+		repo appcfg.Repository
+		want string
+	}{
+		{"github", appcfg.Repository{URL: "https://github.com/org/docs.git"},
+			"https://api.github.com/repos/org/docs/tarball/" + sha},
+		{"github enterprise", appcfg.Repository{URL: "https://github.example.com/org/docs"},
+			"https://github.example.com/api/v3/repos/org/docs/tarball/" + sha},
+		{"gitlab subgroup", appcfg.Repository{URL: "https://gitlab.com/group/sub/docs.git"},
+			"https://gitlab.com/api/v4/projects/group%2Fsub%2Fdocs/repository/archive.tar.gz?sha=" + sha},
+		{"forge tag", appcfg.Repository{URL: "https://code.example.com/team/docs.git", Tags: map[string]string{"forge_type": "gitlab"}},
+			"https://code.example.com/api/v4/projects/team%2Fdocs/repository/archive.tar.gz?sha=" + sha},
+	}
+	for _, tt := range tests {
+		got, err := ArchiveURL(tt.repo, sha)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for _, url := range []string{
+		"git@github.com:org/docs.git",
+		"https://git.example.com/org/docs.git",
+		"https://github.com/docs",
+	} {
+		if _, err := ArchiveURL(appcfg.Repository{URL: url}, sha); !errors.Is(err, ErrArchiveUnsupported) {
+			t.Fatalf("%s: expected ErrArchiveUnsupported, got %v", url, err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type Client struct {
 	buildCfg        *appcfg.BuildConfig // optional build config for strategy flags
 	inRetry         bool                // internal guard to avoid nested retry wrapping
 	remoteHeadCache *RemoteHeadCache    // cache for remote HEAD refs to skip unnecessary fetches
+	logger          *slog.Logger        // optional component logger (defaults to slog.Default)
 }
 
 // CloneResult contains the result of a clone or update operation.
@@ -26,6 +27,7 @@ type CloneResult struct {
 	Path       string    // local filesystem path
 	CommitSHA  string    // HEAD commit SHA
 	CommitDate time.Time // HEAD commit date
+	Repaired   error     // cache corruption found and repaired by cloning again, if any
 }
 
 // NewClient creates a new Git client with the specified workspace directory.
@@ -40,6 +42,19 @@ func (c *Client) WithRemoteHeadCache(cache *RemoteHeadCache) *Client {
 	return c
 }
 
+// WithLogger sets the logger used for git operations (fluent helper).
+func (c *Client) WithLogger(logger *slog.Logger) *Client { c.logger = logger; return c }
+
+// Logger returns the logger used for git operations.
+func (c *Client) Logger() *slog.Logger { return c.log() }
+
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
 // CloneRepo clones a repository to the workspace directory.
 // If retry is enabled, it wraps the operation with retry logic.
 // Returns the local filesystem path and any error.
@@ -77,7 +92,7 @@ func (c *Client) CloneRepoWithMetadata(repo appcfg.Repository) (CloneResult, err
 
 func (c *Client) cloneOnce(repo appcfg.Repository) (string, error) {
 	repoPath := filepath.Join(c.workspaceDir, repo.Name)
-	slog.Debug("Cloning repository", logfields.URL(repo.URL), logfields.Name(repo.Name), slog.String("branch", repo.Branch), logfields.Path(repoPath))
+	c.log().Debug("Cloning repository", logfields.URL(repo.URL), logfields.Name(repo.Name), slog.String("branch", repo.Branch), logfields.Path(repoPath))
 	if err := os.RemoveAll(repoPath); err != nil {
 		return "", GitError("failed to remove existing directory").
 			WithCause(err).
@@ -91,13 +106,13 @@ func (c *Client) cloneOnce(repo appcfg.Repository) (string, error) {
 	if repo.IsTag {
 		if repo.Branch != "" {
 			cloneOptions.ReferenceName = plumbing.ReferenceName("refs/tags/" + repo.Branch)
-			slog.Debug("Cloning tag reference", logfields.Name(repo.Name), slog.String("tag", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
+			c.log().Debug("Cloning tag reference", logfields.Name(repo.Name), slog.String("tag", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
 		}
 		cloneOptions.SingleBranch = true
 	} else {
 		if repo.Branch != "" {
 			cloneOptions.ReferenceName = plumbing.ReferenceName("refs/heads/" + repo.Branch)
-			slog.Debug("Cloning branch reference", logfields.Name(repo.Name), slog.String("branch", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
+			c.log().Debug("Cloning branch reference", logfields.Name(repo.Name), slog.String("branch", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
 		}
 		cloneOptions.SingleBranch = true
 	}
@@ -118,13 +133,14 @@ func (c *Client) cloneOnce(repo appcfg.Repository) (string, error) {
 		return "", ClassifyGitError(err, "clone", repo.URL)
 	}
 	if ref, herr := repository.Head(); herr == nil {
-		slog.Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), slog.String("commit", ref.Hash().String()[:8]), logfields.Path(repoPath))
+		c.log().Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), slog.String("commit", ref.Hash().String()[:8]), logfields.Path(repoPath))
 	} else {
-		slog.Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), logfields.Path(repoPath))
+		c.log().Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), logfields.Path(repoPath))
 	}
+	c.syncExtras(repository, repoPath, repo, cloneOptions.Auth)
 	if c.buildCfg != nil && c.buildCfg.PruneNonDocPaths {
 		if err := c.pruneNonDocTopLevel(repoPath, repo); err != nil {
-			slog.Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
+			c.log().Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
 		}
 	}
 	return repoPath, nil
@@ -132,7 +148,7 @@ func (c *Client) cloneOnce(repo appcfg.Repository) (string, error) {
 
 func (c *Client) cloneOnceWithMetadata(repo appcfg.Repository) (CloneResult, error) {
 	repoPath := filepath.Join(c.workspaceDir, repo.Name)
-	slog.Debug("Cloning repository", logfields.URL(repo.URL), logfields.Name(repo.Name), slog.String("branch", repo.Branch), logfields.Path(repoPath))
+	c.log().Debug("Cloning repository", logfields.URL(repo.URL), logfields.Name(repo.Name), slog.String("branch", repo.Branch), logfields.Path(repoPath))
 	if err := os.RemoveAll(repoPath); err != nil {
 		return CloneResult{}, GitError("failed to remove existing directory").
 			WithCause(err).
@@ -145,13 +161,13 @@ func (c *Client) cloneOnceWithMetadata(repo appcfg.Repository) (CloneResult, err
 	if repo.IsTag {
 		if repo.Branch != "" {
 			cloneOptions.ReferenceName = plumbing.ReferenceName("refs/tags/" + repo.Branch)
-			slog.Debug("Cloning tag reference", logfields.Name(repo.Name), slog.String("tag", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
+			c.log().Debug("Cloning tag reference", logfields.Name(repo.Name), slog.String("tag", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
 		}
 		cloneOptions.SingleBranch = true
 	} else {
 		if repo.Branch != "" {
 			cloneOptions.ReferenceName = plumbing.ReferenceName("refs/heads/" + repo.Branch)
-			slog.Debug("Cloning branch reference", logfields.Name(repo.Name), slog.String("branch", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
+			c.log().Debug("Cloning branch reference", logfields.Name(repo.Name), slog.String("branch", repo.Branch), slog.String("ref", string(cloneOptions.ReferenceName)))
 		}
 		cloneOptions.SingleBranch = true
 	}
@@ -180,26 +196,28 @@ func (c *Client) cloneOnceWithMetadata(repo appcfg.Repository) (CloneResult, err
 		// Get commit object to extract date
 		if commit, cerr := repository.CommitObject(ref.Hash()); cerr == nil {
 			result.CommitDate = commit.Author.When
-			slog.Info("Repository cloned successfully",
+			c.log().Info("Repository cloned successfully",
 				logfields.Name(repo.Name),
 				logfields.URL(repo.URL),
 				slog.String("commit", result.CommitSHA[:8]),
 				slog.Time("commit_date", result.CommitDate),
 				logfields.Path(repoPath))
 		} else {
-			slog.Info("Repository cloned successfully (commit metadata unavailable)",
+			c.log().Info("Repository cloned successfully (commit metadata unavailable)",
 				logfields.Name(repo.Name),
 				logfields.URL(repo.URL),
 				slog.String("commit", result.CommitSHA[:8]),
 				logfields.Path(repoPath))
 		}
 	} else {
-		slog.Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), logfields.Path(repoPath))
+		c.log().Info("Repository cloned successfully", logfields.Name(repo.Name), logfields.URL(repo.URL), logfields.Path(repoPath))
 	}
 
+	c.syncExtras(repository, repoPath, repo, cloneOptions.Auth)
+
 	if c.buildCfg != nil && c.buildCfg.PruneNonDocPaths {
 		if err := c.pruneNonDocTopLevel(repoPath, repo); err != nil {
-			slog.Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
+			c.log().Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
 		}
 	}
 	return result, nil
@@ -208,7 +226,7 @@ func (c *Client) cloneOnceWithMetadata(repo appcfg.Repository) (CloneResult, err
 func (c *Client) updateOnce(repo appcfg.Repository) (string, error) {
 	repoPath := filepath.Join(c.workspaceDir, repo.Name)
 	if _, err := os.Stat(filepath.Join(repoPath, ".git")); err != nil { // missing => clone
-		slog.Debug("Repository missing, cloning", logfields.Name(repo.Name))
+		c.log().Debug("Repository missing, cloning", logfields.Name(repo.Name))
 		return c.cloneOnce(repo)
 	}
 	return c.updateExistingRepo(repoPath, repo)
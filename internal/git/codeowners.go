@@ -0,0 +1,151 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnersFiles lists the ownership files looked up in a repository, in priority order.
+// A dedicated docs-owners file takes precedence over the repository-wide CODEOWNERS.
+var CodeOwnersFiles = []string{
+	"DOCS_OWNERS",
+	".github/DOCS_OWNERS",
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// CodeOwners holds parsed ownership rules. Like CODEOWNERS on GitHub and GitLab,
+// the last matching rule wins.
+type CodeOwners struct {
+	Source string // Repo-root relative path of the file the rules were read from
+	rules  []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern string
+	re      *regexp.Regexp
+	owners  []string
+}
+
+// LoadCodeOwners reads the first ownership file found in the repository at repoPath.
+// It returns nil without error when the repository has no ownership file.
+func LoadCodeOwners(repoPath string) (*CodeOwners, error) {
+	for _, name := range CodeOwnersFiles {
+		// #nosec G304 -- candidate paths are fixed names inside the cloned repository
+		f, err := os.Open(filepath.Join(repoPath, filepath.FromSlash(name)))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, GitError("failed to open ownership file").
+				WithCause(err).
+				WithContext("path", repoPath).
+				WithContext("file", name).
+				Build()
+		}
+		co, perr := ParseCodeOwners(f)
+		_ = f.Close()
+		if perr != nil {
+			return nil, GitError("failed to parse ownership file").
+				WithCause(perr).
+				WithContext("path", repoPath).
+				WithContext("file", name).
+				Build()
+		}
+		co.Source = name
+		return co, nil
+	}
+	return nil, nil
+}
+
+// ParseCodeOwners parses CODEOWNERS syntax: one "pattern owner..." rule per line,
+// '#' comments, and GitLab-style "[Section]" headers (ignored).
+func ParseCodeOwners(r io.Reader) (*CodeOwners, error) {
+	co := &CodeOwners{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		re, err := codeOwnersPatternRegexp(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		co.rules = append(co.rules, codeOwnersRule{pattern: fields[0], re: re, owners: fields[1:]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return co, nil
+}
+
+// OwnersFor returns the owners of a repo-root relative path, or nil when no rule matches
+// (or the matching rule explicitly clears ownership).
+func (c *CodeOwners) OwnersFor(p string) []string {
+	if c == nil {
+		return nil
+	}
+	p = strings.Trim(path.Clean(strings.ReplaceAll(p, "\\", "/")), "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].re.MatchString(p) {
+			if len(c.rules[i].owners) == 0 {
+				return nil
+			}
+			return append([]string{}, c.rules[i].owners...)
+		}
+	}
+	return nil
+}
+
+// codeOwnersPatternRegexp translates a gitignore-style CODEOWNERS pattern into a regexp.
+// Patterns containing a non-trailing slash are anchored at the repository root; a pattern
+// matching a directory also matches everything beneath it.
+func codeOwnersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimPrefix(p, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+	if dirOnly {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(sb.String())
+}
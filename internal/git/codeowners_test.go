@@ -0,0 +1,76 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwners_OwnersFor(t *testing.T) {
+	co, err := ParseCodeOwners(strings.NewReader(`# Default owners
+*                 @org/platform
+
+[Docs]
+docs/             @org/writers
+/docs/api/**      @org/api-team @alice # API reference
+*.png             @org/design
+docs/generated/
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/platform"}},
+		{"docs/guide/intro.md", []string{"@org/writers"}},
+		{"docs/api/v1/users.md", []string{"@org/api-team", "@alice"}},
+		{"docs/api/diagram.png", []string{"@org/design"}},
+		{"sub/docs/readme.md", []string{"@org/writers"}},
+		{"docs/generated/index.md", nil},
+	}
+	for _, tt := range tests {
+		if got := co.OwnersFor(tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("OwnersFor(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCodeOwners_PrefersDocsOwners(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoPath, ".github"), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, ".github", "CODEOWNERS"), []byte("* @org/devs\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	co, err := LoadCodeOwners(repoPath)
+	if err != nil || co == nil {
+		t.Fatalf("load: %v (nil=%v)", err, co == nil)
+	}
+	if co.Source != ".github/CODEOWNERS" {
+		t.Fatalf("unexpected source %q", co.Source)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoPath, "DOCS_OWNERS"), []byte("docs/ @org/writers\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	co, err = LoadCodeOwners(repoPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if co.Source != "DOCS_OWNERS" || !slices.Equal(co.OwnersFor("docs/a.md"), []string{"@org/writers"}) {
+		t.Fatalf("expected DOCS_OWNERS rules, got source %q owners %v", co.Source, co.OwnersFor("docs/a.md"))
+	}
+}
+
+func TestLoadCodeOwners_Missing(t *testing.T) {
+	co, err := LoadCodeOwners(t.TempDir())
+	if err != nil || co != nil {
+		t.Fatalf("expected nil, nil; got %v, %v", co, err)
+	}
+}
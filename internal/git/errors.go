@@ -26,23 +26,24 @@ func ClassifyGitError(err error, op string, url string) error {
 	l := strings.ToLower(msg)
 
 	builder := GitError("git operation failed").
+		WithCode(errors.CodeGitOperation).
 		WithCause(err).
 		WithContext("op", op).
 		WithContext("url", url)
 
 	switch {
 	case strings.Contains(l, "authentication failed") || strings.Contains(l, "not authorized") || strings.Contains(l, "could not read username") || strings.Contains(l, "invalid credentials"):
-		builder.WithCategory(errors.CategoryAuth)
+		builder.WithCategory(errors.CategoryAuth).WithCode(errors.CodeGitAuth)
 	case strings.Contains(l, "repository not found") || strings.Contains(l, "not found") || strings.Contains(l, "does not exist"):
-		builder.WithCategory(errors.CategoryNotFound)
+		builder.WithCategory(errors.CategoryNotFound).WithCode(errors.CodeGitRepoNotFound)
 	case strings.Contains(l, "remote hung up") || strings.Contains(l, "connection reset") || strings.Contains(l, "timeout") || strings.Contains(l, "i/o timeout") || strings.Contains(l, "no route to host"):
-		builder.WithCategory(errors.CategoryNetwork).Retryable()
+		builder.WithCategory(errors.CategoryNetwork).WithCode(errors.CodeGitNetwork).Retryable()
 	case strings.Contains(l, "rate limit") || strings.Contains(l, "too many requests"):
-		builder.WithCategory(errors.CategoryNetwork).RateLimit()
+		builder.WithCategory(errors.CategoryNetwork).WithCode(errors.CodeGitRateLimit).RateLimit()
 	case strings.Contains(l, "diverged") || strings.Contains(l, "non-fast-forward"):
-		builder.WithContext("diverged", true)
+		builder.WithCode(errors.CodeGitDiverged).WithContext("diverged", true)
 	case strings.Contains(l, "unsupported protocol") || strings.Contains(l, "protocol not supported"):
-		builder.WithCategory(errors.CategoryConfig)
+		builder.WithCategory(errors.CategoryConfig).WithCode(errors.CodeGitUnsupportedProtocol)
 	}
 
 	return builder.Build()
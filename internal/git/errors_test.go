@@ -0,0 +1,34 @@
+package git
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestClassifyGitError_Codes(t *testing.T) {
+	tests := []struct {
+		msg  string
+		code errors.ErrorCode
+	}{
+		{"authentication failed for 'https://x'", errors.CodeGitAuth},
+		{"repository not found", errors.CodeGitRepoNotFound},
+		{"read: connection reset by peer", errors.CodeGitNetwork},
+		{"429 too many requests", errors.CodeGitRateLimit},
+		{"branches have diverged", errors.CodeGitDiverged},
+		{"something unexpected", errors.CodeGitOperation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			err := ClassifyGitError(stdErrors.New(tt.msg), "clone", "https://example.com/repo.git")
+			ce, ok := errors.AsClassified(err)
+			if !ok {
+				t.Fatalf("expected classified error, got %T", err)
+			}
+			if got := ce.Code(); got != tt.code {
+				t.Errorf("Code() = %q, want %q", got, tt.code)
+			}
+		})
+	}
+}
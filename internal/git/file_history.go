@@ -0,0 +1,144 @@
+package git
+
+import (
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// defaultFileHistoryMaxCommits bounds how far back CollectFileHistory walks.
+const defaultFileHistoryMaxCommits = 2000
+
+// FileHistory summarizes the git history of a single file.
+type FileHistory struct {
+	LastModified time.Time // Author date of the most recent commit touching the file
+	LastAuthor   string    // Author of the most recent commit touching the file
+	Authors      []string  // Distinct authors, most recent first
+}
+
+// CollectFileHistory walks the history of the repository at repoPath once and returns
+// per-file history for files under any of the given path prefixes (repo-root relative,
+// slash separated; empty or "." matches everything). Keys are repo-root relative paths.
+//
+// The walk is bounded by maxCommits (<= 0 uses a default). Shallow clones are supported:
+// files only present in the oldest available commit are attributed to that commit.
+func CollectFileHistory(repoPath string, prefixes []string, maxCommits int) (map[string]FileHistory, error) {
+	if maxCommits <= 0 {
+		maxCommits = defaultFileHistoryMaxCommits
+	}
+	repo, err := OpenRepository(repoPath)
+	if err != nil {
+		return nil, GitError("failed to open repository").
+			WithCause(err).
+			WithContext("path", repoPath).
+			Build()
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, GitError("failed to resolve HEAD").
+			WithCause(err).
+			WithContext("path", repoPath).
+			Build()
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, GitError("failed to read git log").
+			WithCause(err).
+			WithContext("path", repoPath).
+			Build()
+	}
+	defer iter.Close()
+
+	matches := func(p string) bool {
+		for _, prefix := range prefixes {
+			prefix = strings.Trim(path.Clean(strings.ReplaceAll(prefix, "\\", "/")), "/")
+			if prefix == "" || prefix == "." || p == prefix || strings.HasPrefix(p, prefix+"/") {
+				return true
+			}
+		}
+		return len(prefixes) == 0
+	}
+
+	history := make(map[string]FileHistory)
+	record := func(p string, c *object.Commit) {
+		if !matches(p) {
+			return
+		}
+		h, seen := history[p]
+		if !seen {
+			h.LastModified = c.Author.When
+			h.LastAuthor = c.Author.Name
+		}
+		if c.Author.Name != "" && !slices.Contains(h.Authors, c.Author.Name) {
+			h.Authors = append(h.Authors, c.Author.Name)
+		}
+		history[p] = h
+	}
+
+	for range maxCommits {
+		c, nextErr := iter.Next()
+		if errors.Is(nextErr, io.EOF) {
+			break
+		}
+		if nextErr != nil {
+			return nil, GitError("failed to iterate git log").
+				WithCause(nextErr).
+				WithContext("path", repoPath).
+				Build()
+		}
+		changed, diffErr := changedPaths(c)
+		if diffErr != nil {
+			return nil, GitError("failed to diff commit").
+				WithCause(diffErr).
+				WithContext("commit", c.Hash.String()).
+				Build()
+		}
+		for _, p := range changed {
+			record(p, c)
+		}
+	}
+	return history, nil
+}
+
+// changedPaths lists paths touched by c relative to its first parent. When the parent is
+// unavailable (root commit or shallow boundary) every file in the tree is reported.
+func changedPaths(c *object.Commit) ([]string, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		if parent, perr := c.Parent(0); perr == nil {
+			parentTree, err = parent.Tree()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if parentTree == nil {
+		var paths []string
+		err = tree.Files().ForEach(func(f *object.File) error {
+			paths = append(paths, f.Name)
+			return nil
+		})
+		return paths, err
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(changes))
+	for _, ch := range changes {
+		if ch.To.Name != "" {
+			paths = append(paths, ch.To.Name)
+		}
+	}
+	return paths, nil
+}
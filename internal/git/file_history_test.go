@@ -0,0 +1,87 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func commitFileAs(t *testing.T, repo *git.Repository, repoPath, name, content, author string, when time.Time) {
+	t.Helper()
+	full := filepath.Join(repoPath, name)
+	if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	if _, err := w.Add(name); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	sig := &object.Signature{Name: author, Email: author + "@example.com", When: when}
+	if _, err := w.Commit("update "+name, &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+func TestCollectFileHistory(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo")
+	repo, err := git.PlainInit(repoPath, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	commitFileAs(t, repo, repoPath, "docs/a.md", "# A", "alice", t0)
+	commitFileAs(t, repo, repoPath, "docs/b.md", "# B", "bob", t0.Add(time.Hour))
+	commitFileAs(t, repo, repoPath, "docs/a.md", "# A v2", "carol", t0.Add(2*time.Hour))
+	commitFileAs(t, repo, repoPath, "src/main.go", "package main", "dave", t0.Add(3*time.Hour))
+
+	history, err := CollectFileHistory(repoPath, []string{"docs"}, 0)
+	if err != nil {
+		t.Fatalf("CollectFileHistory: %v", err)
+	}
+
+	if _, ok := history["src/main.go"]; ok {
+		t.Fatalf("expected files outside docs prefix to be excluded")
+	}
+	a, ok := history["docs/a.md"]
+	if !ok {
+		t.Fatalf("missing history for docs/a.md: %+v", history)
+	}
+	if a.LastAuthor != "carol" || !a.LastModified.Equal(t0.Add(2*time.Hour)) {
+		t.Fatalf("unexpected last modification for a.md: %+v", a)
+	}
+	if len(a.Authors) != 2 || a.Authors[0] != "carol" || a.Authors[1] != "alice" {
+		t.Fatalf("unexpected authors for a.md: %v", a.Authors)
+	}
+	if b := history["docs/b.md"]; b.LastAuthor != "bob" {
+		t.Fatalf("unexpected history for b.md: %+v", b)
+	}
+}
+
+func TestCollectFileHistory_MaxCommitsBounded(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo")
+	repo, err := git.PlainInit(repoPath, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	commitFileAs(t, repo, repoPath, "docs/a.md", "# A", "alice", t0)
+	commitFileAs(t, repo, repoPath, "docs/b.md", "# B", "bob", t0.Add(time.Hour))
+
+	history, err := CollectFileHistory(repoPath, nil, 1)
+	if err != nil {
+		t.Fatalf("CollectFileHistory: %v", err)
+	}
+	if _, ok := history["docs/a.md"]; ok {
+		t.Fatalf("expected walk to stop after one commit, got %+v", history)
+	}
+}
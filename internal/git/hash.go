@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
@@ -29,7 +28,7 @@ type RepoTree struct {
 //
 // This enables content-addressable caching: same commit + same paths = same hash.
 func ComputeRepoHash(repoPath, commit string, paths []string) (string, error) {
-	repo, err := git.PlainOpen(repoPath)
+	repo, err := OpenRepository(repoPath)
 	if err != nil {
 		return "", GitError("failed to open repository").
 			WithCause(err).
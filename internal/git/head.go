@@ -9,9 +9,11 @@ import (
 )
 
 // ReadRepoHead returns the current HEAD commit hash for a git repository.
-// It reads .git/HEAD and resolves symbolic references if needed.
+// It reads .git/HEAD and resolves symbolic references if needed. Linked worktrees,
+// whose .git is a file pointing into a shared clone, are followed.
 func ReadRepoHead(repoPath string) (string, error) {
-	headPath := filepath.Join(repoPath, ".git", "HEAD")
+	gitDir, commonDir := resolveGitDirs(repoPath)
+	headPath := filepath.Join(gitDir, "HEAD")
 	// #nosec G304 - headPath is internal git metadata, repoPath is controlled
 	data, err := os.ReadFile(headPath)
 	if err != nil {
@@ -26,7 +28,7 @@ func ReadRepoHead(repoPath string) (string, error) {
 	// If HEAD is a symbolic ref (e.g., "ref: refs/heads/main"), resolve it
 	if after, ok := strings.CutPrefix(line, "ref:"); ok {
 		ref := strings.TrimSpace(after)
-		refPath := filepath.Join(repoPath, ".git", filepath.FromSlash(ref))
+		refPath := filepath.Join(commonDir, filepath.FromSlash(ref))
 		// #nosec G304 - refPath is internal git metadata, repoPath is controlled
 		if refData, refErr := os.ReadFile(refPath); refErr == nil {
 			return strings.TrimSpace(string(refData)), nil
@@ -36,3 +38,31 @@ func ReadRepoHead(repoPath string) (string, error) {
 	// Otherwise, HEAD contains the commit hash directly
 	return line, nil
 }
+
+// resolveGitDirs returns the git directory of repoPath and the directory holding
+// shared refs. Both are repoPath/.git unless .git is a "gitdir:" file.
+func resolveGitDirs(repoPath string) (gitDir, commonDir string) {
+	gitDir = filepath.Join(repoPath, ".git")
+	// #nosec G304 - internal git metadata, repoPath is controlled
+	data, err := os.ReadFile(gitDir)
+	if err != nil { // a directory (or missing): a regular repository
+		return gitDir, gitDir
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return gitDir, gitDir
+	}
+	gitDir = strings.TrimSpace(target)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repoPath, gitDir)
+	}
+	commonDir = gitDir
+	// #nosec G304 - internal git metadata
+	if common, cerr := os.ReadFile(filepath.Join(gitDir, "commondir")); cerr == nil {
+		commonDir = strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+	return gitDir, commonDir
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	ggitcfg "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// pinnedRef is the local ref commits fetched by SHA are stored under.
+const pinnedRef = "refs/docbuilder/pinned"
+
+// FetchCommit fetches a single commit by SHA into the existing working copy of repo.
+// Pinned builds use it when the commit is not reachable from the fetched branch or lies
+// beyond the shallow clone depth. The remote must allow fetching commits by SHA, as
+// GitHub, GitLab and Forgejo do.
+func (c *Client) FetchCommit(repo appcfg.Repository, sha string) error {
+	repoPath := filepath.Join(c.workspaceDir, repo.Name)
+	repository, err := OpenRepository(repoPath)
+	if err != nil {
+		return GitError("failed to open repository").
+			WithCause(err).
+			WithContext("path", repoPath).
+			Build()
+	}
+	c.log().Debug("Fetching pinned commit", logfields.Name(repo.Name), slog.String("commit", sha))
+	return c.fetchRefSpecs(repository, repo, ggitcfg.RefSpec("+"+sha+":"+pinnedRef))
+}
+
+// updateTag fetches the tag a repository is pinned to and checks it out (detached HEAD).
+func (c *Client) updateTag(repository *git.Repository, wt *git.Worktree, repoPath string, repo appcfg.Repository) (string, error) {
+	tagRef := plumbing.NewTagReferenceName(repo.Branch)
+	if err := c.fetchRefSpecs(repository, repo, ggitcfg.RefSpec("+"+tagRef.String()+":"+tagRef.String())); err != nil {
+		return "", err
+	}
+	ref, err := repository.Reference(tagRef, true)
+	if err != nil {
+		return "", GitError("failed to resolve tag").
+			WithCause(err).
+			WithContext("tag", repo.Branch).
+			Build()
+	}
+	hash := ref.Hash()
+	if tag, terr := repository.TagObject(hash); terr == nil { // annotated tag
+		commit, cerr := tag.Commit()
+		if cerr != nil {
+			return "", GitError("failed to resolve tag commit").
+				WithCause(cerr).
+				WithContext("tag", repo.Branch).
+				Build()
+		}
+		hash = commit.Hash
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: hash, Force: true}); err != nil {
+		return "", GitError("failed to checkout tag").
+			WithCause(err).
+			WithContext("tag", repo.Branch).
+			Build()
+	}
+	c.log().Info("Repository updated to tag", logfields.Name(repo.Name), slog.String("tag", repo.Branch), slog.String("commit", hash.String()[:8]))
+
+	if err := c.syncUpdatedExtras(repository, repoPath, repo); err != nil {
+		return "", err
+	}
+	c.postUpdateCleanup(wt, repoPath, repo)
+	return repoPath, nil
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestUpdateRepo_Tag(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "a.txt", "a")
+	origin, err := git.PlainOpen(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := origin.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := origin.CreateTag("v1", head.Hash(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(t.TempDir())
+	repo := appcfg.Repository{Name: "docs", URL: src, Branch: "v1", IsTag: true}
+	if _, err := client.CloneRepo(repo); err != nil {
+		t.Fatalf("clone tag: %v", err)
+	}
+
+	// An annotated tag on a later commit; updating must peel it to the commit.
+	v2 := addSimpleCommit(t, origin, src, "b.txt")
+	sig := &object.Signature{Name: "tester", Email: "t@example.com", When: time.Now()}
+	if _, err := origin.CreateTag("v2", v2, &git.CreateTagOptions{Tagger: sig, Message: "v2"}); err != nil {
+		t.Fatal(err)
+	}
+	repo.Branch = "v2"
+	path, err := client.UpdateRepo(repo)
+	if err != nil {
+		t.Fatalf("update to tag: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "b.txt")); err != nil {
+		t.Fatalf("expected v2 content: %v", err)
+	}
+	local, err := git.PlainOpen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localHead, err := local.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localHead.Hash() != v2 {
+		t.Fatalf("HEAD = %s, want %s", localHead.Hash(), v2)
+	}
+}
+
+func TestFetchCommit_UnreachableFromBranch(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "a.txt", "a")
+	// Hosted forges allow fetching reachable commits by SHA; git-upload-pack needs opting in.
+	gitCLI(t, src, "config", "uploadpack.allowAnySHA1InWant", "true")
+	origin, err := git.PlainOpen(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := origin.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(t.TempDir())
+	repo := appcfg.Repository{Name: "docs", URL: src, Branch: head.Name().Short()}
+	path, err := client.CloneRepo(repo)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+
+	// Commit on a side branch that the clone never fetched.
+	wt, err := origin.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("side"), Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	side := addSimpleCommit(t, origin, src, "side.txt")
+
+	local, err := git.PlainOpen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.CommitObject(side); err == nil {
+		t.Fatal("side commit should not be present before FetchCommit")
+	}
+	if err := client.FetchCommit(repo, side.String()); err != nil {
+		t.Fatalf("fetch commit: %v", err)
+	}
+	if local, err = git.PlainOpen(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.CommitObject(side); err != nil {
+		t.Fatalf("side commit missing after FetchCommit: %v", err)
+	}
+}
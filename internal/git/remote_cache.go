@@ -118,7 +118,7 @@ func (c *Client) CheckRemoteChanged(cache *RemoteHeadCache, repo appcfg.Reposito
 	// Get current remote HEAD
 	currentSHA, err := c.GetRemoteHead(repo, branch)
 	if err != nil {
-		slog.Debug("Failed to check remote HEAD, will fetch",
+		c.log().Debug("Failed to check remote HEAD, will fetch",
 			logfields.Name(repo.Name),
 			slog.String("error", err.Error()))
 		return true, "", nil // On error, fetch anyway
@@ -127,19 +127,19 @@ func (c *Client) CheckRemoteChanged(cache *RemoteHeadCache, repo appcfg.Reposito
 	// Check cache
 	cached := cache.Get(repo.URL, branch)
 	if cached == nil {
-		slog.Debug("No cached remote HEAD, will fetch", logfields.Name(repo.Name))
+		c.log().Debug("No cached remote HEAD, will fetch", logfields.Name(repo.Name))
 		return true, currentSHA, nil
 	}
 
 	if cached.CommitSHA != currentSHA {
-		slog.Info("Remote HEAD changed",
+		c.log().Info("Remote HEAD changed",
 			logfields.Name(repo.Name),
 			slog.String("old", cached.CommitSHA[:8]),
 			slog.String("new", currentSHA[:8]))
 		return true, currentSHA, nil
 	}
 
-	slog.Info("Remote HEAD unchanged, skipping fetch",
+	c.log().Info("Remote HEAD unchanged, skipping fetch",
 		logfields.Name(repo.Name),
 		slog.String("commit", currentSHA[:8]))
 	return false, currentSHA, nil
@@ -36,7 +36,7 @@ func (c *Client) withRetry(op, repoName string, fn func() (string, error)) (stri
 	var lastErr error
 	for attempt := 0; attempt <= c.buildCfg.MaxRetries; attempt++ {
 		if attempt > 0 {
-			slog.Warn("retrying git operation", slog.String("operation", op), logfields.Name(repoName), slog.Int("attempt", attempt))
+			c.log().Warn("retrying git operation", slog.String("operation", op), logfields.Name(repoName), slog.Int("attempt", attempt))
 		}
 		c.inRetry = true
 		path, err := fn()
@@ -46,7 +46,7 @@ func (c *Client) withRetry(op, repoName string, fn func() (string, error)) (stri
 		}
 		lastErr = err
 		if isPermanentGitError(err) {
-			slog.Error("permanent git error", slog.String("operation", op), logfields.Name(repoName), slog.String("error", err.Error()))
+			c.log().Error("permanent git error", slog.String("operation", op), logfields.Name(repoName), slog.String("error", err.Error()))
 			return "", err
 		}
 		if attempt == c.buildCfg.MaxRetries {
@@ -96,7 +96,7 @@ func (c *Client) withRetryMetadata(op, repoName string, fn func() (CloneResult,
 	var lastErr error
 	for attempt := 0; attempt <= c.buildCfg.MaxRetries; attempt++ {
 		if attempt > 0 {
-			slog.Warn("retrying git operation", slog.String("operation", op), logfields.Name(repoName), slog.Int("attempt", attempt))
+			c.log().Warn("retrying git operation", slog.String("operation", op), logfields.Name(repoName), slog.Int("attempt", attempt))
 		}
 		c.inRetry = true
 		result, err := fn()
@@ -106,7 +106,7 @@ func (c *Client) withRetryMetadata(op, repoName string, fn func() (CloneResult,
 		}
 		lastErr = err
 		if isPermanentGitError(err) {
-			slog.Error("permanent git error", slog.String("operation", op), logfields.Name(repoName), slog.String("error", err.Error()))
+			c.log().Error("permanent git error", slog.String("operation", op), logfields.Name(repoName), slog.String("error", err.Error()))
 			return CloneResult{}, err
 		}
 		if attempt == c.buildCfg.MaxRetries {
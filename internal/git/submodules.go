@@ -0,0 +1,159 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/proclimit"
+)
+
+// lfsTimeout bounds a single git lfs pull.
+const lfsTimeout = 10 * time.Minute
+
+// runGit runs the git CLI in dir with extra environment under the optional
+// build.resources limits; replaced in tests.
+var runGit = func(ctx context.Context, limits *appcfg.ResourceLimitsConfig, dir string, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 -- fixed git subcommands
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	return proclimit.CombinedOutput(cmd, limits)
+}
+
+// syncExtras fetches submodules and LFS objects for repositories that enable them.
+// Failures are logged as warnings: the site is still built from the main repository.
+func (c *Client) syncExtras(repository *git.Repository, repoPath string, repo appcfg.Repository, auth transport.AuthMethod) {
+	if repo.Submodules {
+		if err := updateSubmodules(repository, auth, c.shallowDepth()); err != nil {
+			c.log().Warn("submodule update failed; submodule content will be missing",
+				logfields.Name(repo.Name), slog.String("error", err.Error()))
+		}
+	}
+	if repo.LFS {
+		c.pullLFS(repoPath, repo, auth)
+	}
+}
+
+// syncUpdatedExtras runs syncExtras after an update, resolving the repository's auth.
+func (c *Client) syncUpdatedExtras(repository *git.Repository, repoPath string, repo appcfg.Repository) error {
+	if !repo.Submodules && !repo.LFS {
+		return nil
+	}
+	var auth transport.AuthMethod
+	if repo.Auth != nil {
+		var err error
+		if auth, err = c.getAuth(repo.Auth); err != nil {
+			return err
+		}
+	}
+	c.syncExtras(repository, repoPath, repo, auth)
+	return nil
+}
+
+// shallowDepth returns the configured clone depth, or 0 for full history.
+// resourceLimits returns the build.resources limits for git processes, or nil.
+func (c *Client) resourceLimits() *appcfg.ResourceLimitsConfig {
+	if c.buildCfg == nil {
+		return nil
+	}
+	return c.buildCfg.Resources
+}
+
+func (c *Client) shallowDepth() int {
+	if c.buildCfg != nil && c.buildCfg.ShallowDepth > 0 {
+		return c.buildCfg.ShallowDepth
+	}
+	return 0
+}
+
+// updateSubmodules initializes and updates all submodules, recursing into nested ones.
+func updateSubmodules(repository *git.Repository, auth transport.AuthMethod, depth int) error {
+	wt, err := repository.Worktree()
+	if err != nil {
+		return err
+	}
+	subs, err := wt.Submodules()
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+	return subs.Update(&git.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		Auth:              auth,
+		Depth:             depth,
+	})
+}
+
+// pullLFS downloads LFS objects with the git-lfs extension. Repositories without LFS
+// attributes are skipped; a missing git-lfs only produces a warning.
+func (c *Client) pullLFS(repoPath string, repo appcfg.Repository, auth transport.AuthMethod) {
+	if !usesLFS(repoPath) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lfsTimeout)
+	defer cancel()
+
+	if _, err := runGit(ctx, nil, repoPath, nil, "lfs", "version"); err != nil {
+		c.log().Warn("git-lfs is not installed; LFS files are left as pointer files",
+			logfields.Name(repo.Name),
+			slog.String("hint", "install git-lfs or disable lfs for this repository"))
+		return
+	}
+	if out, err := runGit(ctx, c.resourceLimits(), repoPath, lfsEnv(repo.Auth, auth), "lfs", "pull"); err != nil {
+		c.log().Warn("git lfs pull failed; LFS files are left as pointer files",
+			logfields.Name(repo.Name),
+			slog.String("error", err.Error()),
+			slog.String("output", strings.TrimSpace(string(out))))
+		return
+	}
+	c.log().Debug("LFS objects downloaded", logfields.Name(repo.Name))
+}
+
+// usesLFS reports whether the repository's .gitattributes routes any path through the LFS filter.
+func usesLFS(repoPath string) bool {
+	data, err := os.ReadFile(filepath.Join(repoPath, ".gitattributes")) // #nosec G304 -- path inside the workspace
+	return err == nil && bytes.Contains(data, []byte("filter=lfs"))
+}
+
+// lfsEnv passes the repository credentials to git-lfs. HTTP credentials go through
+// GIT_CONFIG_* variables (as http.extraHeader) so they do not appear in the process list;
+// SSH uses the configured key file or agent socket.
+func lfsEnv(authCfg *appcfg.AuthConfig, auth transport.AuthMethod) []string {
+	var header string
+	switch a := auth.(type) {
+	case *githttp.BasicAuth:
+		header = "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+	case *githttp.TokenAuth:
+		header = "Authorization: Bearer " + a.Token
+	}
+	if header != "" {
+		return []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.extraHeader", "GIT_CONFIG_VALUE_0=" + header}
+	}
+	if authCfg == nil || authCfg.Type != appcfg.AuthTypeSSH {
+		return nil
+	}
+	if authCfg.AgentSocket != "" {
+		return []string{"SSH_AUTH_SOCK=" + authCfg.AgentSocket}
+	}
+	if authCfg.KeyPath != "" {
+		return []string{fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes", strconv.Quote(authCfg.KeyPath))}
+	}
+	return nil
+}
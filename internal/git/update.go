@@ -15,14 +15,14 @@ import (
 )
 
 func (c *Client) updateExistingRepo(repoPath string, repo appcfg.Repository) (string, error) {
-	repository, err := git.PlainOpen(repoPath)
+	repository, err := OpenRepository(repoPath)
 	if err != nil {
 		return "", GitError("failed to open repository").
 			WithCause(err).
 			WithContext("path", repoPath).
 			Build()
 	}
-	slog.Info("Updating repository", logfields.Name(repo.Name), slog.String("path", repoPath))
+	c.log().Info("Updating repository", logfields.Name(repo.Name), slog.String("path", repoPath))
 	wt, err := repository.Worktree()
 	if err != nil {
 		return "", GitError("failed to get worktree").
@@ -31,6 +31,10 @@ func (c *Client) updateExistingRepo(repoPath string, repo appcfg.Repository) (st
 			Build()
 	}
 
+	if repo.IsTag {
+		return c.updateTag(repository, wt, repoPath, repo)
+	}
+
 	// Resolve target branch early
 	branch := resolveTargetBranch(repository, repo)
 
@@ -51,7 +55,7 @@ func (c *Client) updateExistingRepo(repoPath string, repo appcfg.Repository) (st
 			return "", fetchErr
 		}
 	} else {
-		logSkippedFetch(repo.Name, branch, remoteSHA)
+		logSkippedFetch(c.log(), repo.Name, branch, remoteSHA)
 	}
 
 	// 2. Checkout/create local branch & obtain refs
@@ -69,7 +73,12 @@ func (c *Client) updateExistingRepo(repoPath string, repo appcfg.Repository) (st
 		return "", err
 	}
 
-	// 4. Post-update hygiene (clean/prune)
+	// 4. Submodules and LFS objects for the new revision
+	if err := c.syncUpdatedExtras(repository, repoPath, repo); err != nil {
+		return "", err
+	}
+
+	// 5. Post-update hygiene (clean/prune)
 	c.postUpdateCleanup(wt, repoPath, repo)
 
 	return repoPath, nil
@@ -80,11 +89,6 @@ func (c *Client) updateExistingRepo(repoPath string, repo appcfg.Repository) (st
 // Performance note: fetching "+refs/heads/*" can be very expensive for repositories with many branches.
 // DocBuilder generally only needs a single target branch, so we fetch only that branch when provided.
 func (c *Client) fetchOrigin(repository *git.Repository, repo appcfg.Repository, branch string) error {
-	depth := 0
-	if c.buildCfg != nil && c.buildCfg.ShallowDepth > 0 {
-		depth = c.buildCfg.ShallowDepth
-	}
-
 	refSpecs := []ggitcfg.RefSpec{"+refs/heads/*:refs/remotes/origin/*"}
 	if branch != "" {
 		// Fetch only the required branch.
@@ -92,9 +96,13 @@ func (c *Client) fetchOrigin(repository *git.Repository, repo appcfg.Repository,
 			fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branch, branch),
 		)}
 	}
+	return c.fetchRefSpecs(repository, repo, refSpecs...)
+}
 
+// fetchRefSpecs fetches refSpecs from origin with the configured depth and authentication.
+func (c *Client) fetchRefSpecs(repository *git.Repository, repo appcfg.Repository, refSpecs ...ggitcfg.RefSpec) error {
 	fetchOpts := &git.FetchOptions{RemoteName: "origin", Tags: git.NoTags, RefSpecs: refSpecs}
-	if depth > 0 {
+	if depth := c.shallowDepth(); depth > 0 {
 		fetchOpts.Depth = depth
 	}
 	if repo.Auth != nil {
@@ -160,7 +168,7 @@ func checkoutAndGetRefs(repository *git.Repository, wt *git.Worktree, branch str
 func (c *Client) syncWithRemote(repository *git.Repository, wt *git.Worktree, repo appcfg.Repository, branch string, localRef, remoteRef *plumbing.Reference) error {
 	fastForwardPossible, ffErr := isAncestor(repository, localRef.Hash(), remoteRef.Hash())
 	if ffErr != nil {
-		slog.Warn("ancestor check failed", slog.String("error", ffErr.Error()))
+		c.log().Warn("ancestor check failed", slog.String("error", ffErr.Error()))
 	}
 	if fastForwardPossible {
 		currentHead, _ := repository.Head()
@@ -170,15 +178,15 @@ func (c *Client) syncWithRemote(repository *git.Repository, wt *git.Worktree, re
 				Build()
 		}
 		if currentHead != nil && currentHead.Hash() == remoteRef.Hash() {
-			slog.Info("Repository already up-to-date", logfields.Name(repo.Name), slog.String("branch", branch), slog.String("commit", remoteRef.Hash().String()[:8]))
+			c.log().Info("Repository already up-to-date", logfields.Name(repo.Name), slog.String("branch", branch), slog.String("commit", remoteRef.Hash().String()[:8]))
 		} else {
-			slog.Info("Fast-forwarded repository", logfields.Name(repo.Name), slog.String("branch", branch), slog.String("from", currentHead.Hash().String()[:8]), slog.String("to", remoteRef.Hash().String()[:8]))
+			c.log().Info("Fast-forwarded repository", logfields.Name(repo.Name), slog.String("branch", branch), slog.String("from", currentHead.Hash().String()[:8]), slog.String("to", remoteRef.Hash().String()[:8]))
 		}
 		return nil
 	}
 	hardReset := c.buildCfg != nil && c.buildCfg.HardResetOnDiverge
 	if hardReset {
-		slog.Warn("diverged branch, hard resetting", logfields.Name(repo.Name), slog.String("branch", branch))
+		c.log().Warn("diverged branch, hard resetting", logfields.Name(repo.Name), slog.String("branch", branch))
 		if err := wt.Reset(&git.ResetOptions{Commit: remoteRef.Hash(), Mode: git.HardReset}); err != nil {
 			return GitError("failed to reset for hard-reset").
 				WithCause(err).
@@ -195,12 +203,12 @@ func (c *Client) syncWithRemote(repository *git.Repository, wt *git.Worktree, re
 func (c *Client) postUpdateCleanup(wt *git.Worktree, repoPath string, repo appcfg.Repository) {
 	if c.buildCfg != nil && c.buildCfg.CleanUntracked {
 		if err := wt.Clean(&git.CleanOptions{Dir: true}); err != nil {
-			slog.Warn("clean untracked failed", slog.String("error", err.Error()))
+			c.log().Warn("clean untracked failed", slog.String("error", err.Error()))
 		}
 	}
 	if c.buildCfg != nil && c.buildCfg.PruneNonDocPaths {
 		if err := c.pruneNonDocTopLevel(repoPath, repo); err != nil {
-			slog.Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
+			c.log().Warn("prune non-doc paths failed", logfields.Name(repo.Name), slog.String("error", err.Error()))
 		}
 	}
 }
@@ -244,7 +252,7 @@ func isAncestor(repo *git.Repository, a, b plumbing.Hash) (bool, error) {
 
 // performFetch executes fetch operation and updates cache.
 func (c *Client) performFetch(repository *git.Repository, repo appcfg.Repository, branch, remoteSHA string) error {
-	logFetchOperation(repo.Name, branch, remoteSHA)
+	logFetchOperation(c.log(), repo.Name, branch, remoteSHA)
 
 	if fetchErr := c.fetchOrigin(repository, repo, branch); fetchErr != nil {
 		return ClassifyGitError(fetchErr, "fetch", repo.URL)
@@ -259,22 +267,22 @@ func (c *Client) performFetch(repository *git.Repository, repo appcfg.Repository
 }
 
 // logFetchOperation logs appropriate message based on whether remote SHA is known.
-func logFetchOperation(name, branch, remoteSHA string) {
+func logFetchOperation(logger *slog.Logger, name, branch, remoteSHA string) {
 	if remoteSHA != "" {
-		slog.Info("Repository changed, fetching updates",
+		logger.Info("Repository changed, fetching updates",
 			logfields.Name(name),
 			slog.String("branch", branch),
 			slog.String("remote_commit", remoteSHA[:8]))
 	} else {
-		slog.Info("Fetching repository updates",
+		logger.Info("Fetching repository updates",
 			logfields.Name(name),
 			slog.String("branch", branch))
 	}
 }
 
 // logSkippedFetch logs when fetch is skipped due to unchanged remote.
-func logSkippedFetch(name, branch, remoteSHA string) {
-	slog.Info("Repository unchanged, skipping fetch",
+func logSkippedFetch(logger *slog.Logger, name, branch, remoteSHA string) {
+	logger.Info("Repository unchanged, skipping fetch",
 		logfields.Name(name),
 		slog.String("branch", branch),
 		slog.String("commit", remoteSHA[:8]))
@@ -0,0 +1,146 @@
+package git
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// VerifyResult is the verification outcome of one cached repository.
+type VerifyResult struct {
+	Name string // directory name in the workspace (mirrors are prefixed with .mirrors/)
+	Path string
+	Err  error // nil when the repository passed verification
+}
+
+// VerifyRepository checks that the cached repository at path can be reused: it opens,
+// its index is readable, every reference resolves to a stored object and HEAD's commit
+// and tree are readable. With full set, every blob of HEAD's tree is read as well and,
+// when the git binary is available, `git fsck --connectivity-only` must pass.
+// Failures are classified with errors.CodeGitCacheCorrupted.
+func VerifyRepository(path string, full bool) error {
+	repo, err := OpenRepository(path)
+	if err != nil {
+		return corruptionError(path, "repository cannot be opened", err)
+	}
+	if err := verifyRefs(repo); err != nil {
+		return corruptionError(path, "broken reference", err)
+	}
+	if !isBare(repo) {
+		if _, err := repo.Storer.Index(); err != nil {
+			return corruptionError(path, "index is unreadable", err)
+		}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		if isBare(repo) && stdErrors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil // shared clones have no HEAD commit of their own
+		}
+		return corruptionError(path, "HEAD does not resolve", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return corruptionError(path, "HEAD commit is missing", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return corruptionError(path, "HEAD tree is missing", err)
+	}
+	if !full {
+		return nil
+	}
+	if err := tree.Files().ForEach(func(f *object.File) error {
+		if _, err := repo.Storer.EncodedObject(plumbing.BlobObject, f.Hash); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+		return nil
+	}); err != nil {
+		return corruptionError(path, "blob is missing", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), worktreeTimeout)
+	defer cancel()
+	if _, err := runGit(ctx, nil, "", nil, "version"); err == nil {
+		if out, err := runGit(ctx, nil, path, nil, "fsck", "--connectivity-only", "--no-dangling", "--no-progress"); err != nil {
+			return corruptionError(path, "git fsck failed: "+strings.TrimSpace(string(out)), err)
+		}
+	}
+	return nil
+}
+
+// VerifyWorkspace verifies every cached repository in workspaceDir, including the
+// shared clones used for version worktrees. Results are sorted by name.
+func VerifyWorkspace(workspaceDir string, full bool) ([]VerifyResult, error) {
+	var results []VerifyResult
+	for _, dir := range []string{workspaceDir, filepath.Join(workspaceDir, mirrorsDir)} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.NewError(errors.CategoryFileSystem, "failed to read workspace directory").
+				WithCause(err).
+				WithContext("path", dir).
+				Build()
+		}
+		for _, e := range entries {
+			path := filepath.Join(dir, e.Name())
+			if !e.IsDir() || !looksLikeRepository(path) {
+				continue
+			}
+			name, _ := filepath.Rel(workspaceDir, path)
+			results = append(results, VerifyResult{Name: filepath.ToSlash(name), Path: path, Err: VerifyRepository(path, full)})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results, nil
+}
+
+// looksLikeRepository reports whether path is a working copy (.git directory or file)
+// or a bare repository.
+func looksLikeRepository(path string) bool {
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(path, "HEAD"))
+	return err == nil && strings.HasSuffix(path, ".git")
+}
+
+// verifyRefs checks that every reference resolves to an object in the store.
+func verifyRefs(repo *git.Repository) error {
+	refs, err := repo.References()
+	if err != nil {
+		return err
+	}
+	return refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil // symbolic refs are checked through their target
+		}
+		if _, err := repo.Storer.EncodedObject(plumbing.AnyObject, ref.Hash()); err != nil {
+			return fmt.Errorf("%s -> %s: %w", ref.Name(), ref.Hash(), err)
+		}
+		return nil
+	})
+}
+
+func isBare(repo *git.Repository) bool {
+	_, err := repo.Worktree()
+	return stdErrors.Is(err, git.ErrIsBareRepository)
+}
+
+func corruptionError(path, reason string, cause error) error {
+	return GitError("cached repository is corrupted: "+reason).
+		WithCode(errors.CodeGitCacheCorrupted).
+		WithCause(cause).
+		WithContext("path", path).
+		Build()
+}
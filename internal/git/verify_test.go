@@ -0,0 +1,89 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestVerifyRepository(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "docs/index.md", "# Docs")
+
+	for name, corrupt := range map[string]func(t *testing.T, path string){
+		"healthy": nil,
+		"dangling ref": func(t *testing.T, path string) {
+			ref := filepath.Join(path, ".git", "refs", "heads", "broken")
+			if err := os.WriteFile(ref, []byte("0123456789abcdef0123456789abcdef01234567\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"truncated pack": func(t *testing.T, path string) {
+			packs, _ := filepath.Glob(filepath.Join(path, ".git", "objects", "pack", "*.pack"))
+			if len(packs) == 0 {
+				t.Fatal("expected pack files")
+			}
+			for _, p := range packs {
+				if err := os.Truncate(p, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+		},
+		"missing HEAD": func(t *testing.T, path string) {
+			if err := os.Remove(filepath.Join(path, ".git", "HEAD")); err != nil {
+				t.Fatal(err)
+			}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, err := NewClient(t.TempDir()).CloneRepo(appcfg.Repository{Name: "docs", URL: src})
+			if err != nil {
+				t.Fatalf("clone: %v", err)
+			}
+			if corrupt == nil {
+				if err := VerifyRepository(path, true); err != nil {
+					t.Fatalf("healthy repository failed verification: %v", err)
+				}
+				return
+			}
+			corrupt(t, path)
+			err = VerifyRepository(path, false)
+			ce, ok := errors.AsClassified(err)
+			if !ok || ce.Code() != errors.CodeGitCacheCorrupted {
+				t.Fatalf("expected %s, got %v", errors.CodeGitCacheCorrupted, err)
+			}
+		})
+	}
+}
+
+func TestVerifyWorkspace(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "index.md", "# Docs")
+	workspace := t.TempDir()
+	client := NewClient(workspace)
+	for _, name := range []string{"a", "b"} {
+		if _, err := client.CloneRepo(appcfg.Repository{Name: name, URL: src}); err != nil {
+			t.Fatalf("clone %s: %v", name, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(workspace, "not-a-repo"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(workspace, "b", ".git", "HEAD")); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := VerifyWorkspace(workspace, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Name != "a" || results[1].Name != "b" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Fatalf("expected only b to fail, got %+v", results)
+	}
+}
@@ -1,7 +1,6 @@
 package git
 
 import (
-	"log/slog"
 	"os"
 	"path/filepath"
 
@@ -39,7 +38,7 @@ func (c *Client) CleanWorkspace() error {
 				Build()
 		}
 	}
-	slog.Info("Workspace cleaned", logfields.Path(c.workspaceDir))
+	c.log().Info("Workspace cleaned", logfields.Path(c.workspaceDir))
 	return nil
 }
 
@@ -47,7 +46,7 @@ func (c *Client) CheckDocIgnore(repoPath string) (bool, error) {
 	path := filepath.Join(repoPath, ".docignore")
 	_, err := os.Stat(path)
 	if err == nil {
-		slog.Debug("Found .docignore file", logfields.Path(path))
+		c.log().Debug("Found .docignore file", logfields.Path(path))
 		return true, nil
 	}
 	if os.IsNotExist(err) {
@@ -0,0 +1,272 @@
+package git
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	ggitcfg "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// mirrorsDir holds the shared bare clones that version worktrees are checked out from.
+const mirrorsDir = ".mirrors"
+
+// worktreeTimeout bounds a single git worktree command.
+const worktreeTimeout = 5 * time.Minute
+
+// mirrorLocks serializes fetches and worktree changes per shared clone; versions of
+// one repository are fetched in parallel by separate clients.
+var mirrorLocks sync.Map // mirror path -> *sync.Mutex
+
+func lockMirror(path string) func() {
+	v, _ := mirrorLocks.LoadOrStore(path, &sync.Mutex{})
+	mu, _ := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// OpenRepository opens the repository at path, including linked worktrees whose
+// objects live in a shared clone.
+func OpenRepository(path string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(path, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+}
+
+// MirrorPath returns the shared bare clone used for the worktrees of repository base.
+func (c *Client) MirrorPath(base string) string {
+	return filepath.Join(c.workspaceDir, mirrorsDir, base+".git")
+}
+
+// CheckoutWorktree checks out repo as a git worktree of the shared bare clone of
+// repo.WorktreeOf, so every version of a repository shares one object store and
+// only fetches its own ref. The worktree is detached at the branch head, the peeled
+// tag or the pinned commit. Without the git binary it falls back to a regular clone.
+func (c *Client) CheckoutWorktree(repo appcfg.Repository) (CloneResult, error) {
+	if c.inRetry {
+		return c.checkoutWorktreeOnce(repo)
+	}
+	return c.withRetryMetadata("worktree", repo.Name, func() (CloneResult, error) {
+		return c.checkoutWorktreeOnce(repo)
+	})
+}
+
+func (c *Client) checkoutWorktreeOnce(repo appcfg.Repository) (CloneResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), worktreeTimeout)
+	defer cancel()
+	if _, err := runGit(ctx, nil, "", nil, "version"); err != nil {
+		c.log().Warn("git binary not available; cloning version separately",
+			logfields.Name(repo.Name), slog.String("error", err.Error()))
+		return c.cloneOnceWithMetadata(repo)
+	}
+
+	mirrorPath := c.MirrorPath(repo.WorktreeOf)
+	defer lockMirror(mirrorPath)()
+
+	repoPath := filepath.Join(c.workspaceDir, repo.Name)
+	repaired := c.repairCorrupted(mirrorPath, repo)
+	if repaired != nil {
+		// The worktree's objects lived in the removed clone.
+		_ = os.RemoveAll(repoPath)
+	} else if isLinkedWorktree(repoPath) {
+		repaired = c.repairCorrupted(repoPath, repo)
+	}
+
+	mirror, err := c.openMirror(mirrorPath, repo)
+	if err != nil {
+		return CloneResult{}, err
+	}
+	hash, err := c.fetchWorktreeTarget(mirror, repo)
+	if err != nil {
+		return CloneResult{}, err
+	}
+
+	if isLinkedWorktree(repoPath) {
+		out, gerr := runGit(ctx, c.resourceLimits(), repoPath, nil, "checkout", "--force", "--detach", hash.String())
+		if gerr != nil {
+			return CloneResult{}, worktreeError("failed to checkout worktree", gerr, out, repoPath)
+		}
+	} else {
+		// A regular clone from before worktrees were enabled, or a leftover directory.
+		if err := os.RemoveAll(repoPath); err != nil {
+			return CloneResult{}, GitError("failed to remove existing directory").
+				WithCause(err).
+				WithContext("path", repoPath).
+				Build()
+		}
+		out, gerr := runGit(ctx, c.resourceLimits(), mirrorPath, nil, "worktree", "add", "--force", "--detach", repoPath, hash.String())
+		if gerr != nil {
+			return CloneResult{}, worktreeError("failed to add worktree", gerr, out, repoPath)
+		}
+	}
+
+	result := CloneResult{Path: repoPath, CommitSHA: hash.String(), Repaired: repaired}
+	if commit, cerr := mirror.CommitObject(hash); cerr == nil {
+		result.CommitDate = commit.Author.When
+	}
+	c.log().Info("Worktree checked out",
+		logfields.Name(repo.Name),
+		slog.String("ref", repo.Branch),
+		slog.String("commit", hash.String()[:8]),
+		logfields.Path(repoPath))
+
+	if wtRepo, oerr := OpenRepository(repoPath); oerr == nil {
+		if err := c.syncUpdatedExtras(wtRepo, repoPath, repo); err != nil {
+			return CloneResult{}, err
+		}
+		if wt, werr := wtRepo.Worktree(); werr == nil {
+			c.postUpdateCleanup(wt, repoPath, repo)
+		}
+	}
+	return result, nil
+}
+
+// repairCorrupted verifies the repository at path, if present, and removes it when
+// it is corrupted. It returns the verification error.
+func (c *Client) repairCorrupted(path string, repo appcfg.Repository) error {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	verr := VerifyRepository(path, false)
+	if verr == nil {
+		return nil
+	}
+	c.log().Warn("Cached repository is corrupted; cloning it again",
+		logfields.Name(repo.Name), logfields.Path(path), slog.String("error", verr.Error()))
+	if err := os.RemoveAll(path); err != nil {
+		c.log().Warn("Failed to remove corrupted repository", logfields.Path(path), slog.String("error", err.Error()))
+	}
+	return verr
+}
+
+// openMirror opens the shared bare clone, creating it on first use.
+func (c *Client) openMirror(mirrorPath string, repo appcfg.Repository) (*git.Repository, error) {
+	mirror, err := git.PlainOpen(mirrorPath)
+	if err == nil {
+		return mirror, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(mirrorPath), 0o750); err != nil {
+		return nil, GitError("failed to create mirrors directory").
+			WithCause(err).
+			WithContext("path", mirrorPath).
+			Build()
+	}
+	if mirror, err = git.PlainInit(mirrorPath, true); err != nil {
+		return nil, GitError("failed to create shared clone").
+			WithCause(err).
+			WithContext("path", mirrorPath).
+			Build()
+	}
+	if _, err := mirror.CreateRemote(&ggitcfg.RemoteConfig{Name: "origin", URLs: []string{repo.URL}}); err != nil {
+		return nil, GitError("failed to configure shared clone remote").
+			WithCause(err).
+			WithContext("path", mirrorPath).
+			Build()
+	}
+	c.log().Debug("Created shared clone", logfields.Name(repo.WorktreeOf), logfields.Path(mirrorPath))
+	return mirror, nil
+}
+
+// fetchWorktreeTarget fetches the ref (or pinned commit) of repo into the shared
+// clone and returns the commit to check out.
+func (c *Client) fetchWorktreeTarget(mirror *git.Repository, repo appcfg.Repository) (plumbing.Hash, error) {
+	if pinned := repo.TargetCommit(); pinned != "" {
+		hash := plumbing.NewHash(pinned)
+		if _, err := mirror.CommitObject(hash); err == nil {
+			return hash, nil
+		}
+		ref := ggitcfg.RefSpec("+" + pinned + ":" + pinnedRef + "/" + repo.Name)
+		if err := c.fetchRefSpecs(mirror, repo, ref); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return hash, nil
+	}
+
+	ref := plumbing.NewBranchReferenceName(repo.Branch)
+	if repo.IsTag {
+		ref = plumbing.NewTagReferenceName(repo.Branch)
+	}
+	if err := c.fetchRefSpecs(mirror, repo, ggitcfg.RefSpec("+"+ref.String()+":"+ref.String())); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	resolved, err := mirror.Reference(ref, true)
+	if err != nil {
+		return plumbing.ZeroHash, GitError("failed to resolve ref").
+			WithCause(err).
+			WithContext("ref", ref.String()).
+			Build()
+	}
+	hash := resolved.Hash()
+	if tag, terr := mirror.TagObject(hash); terr == nil { // annotated tag
+		commit, cerr := tag.Commit()
+		if cerr != nil {
+			return plumbing.ZeroHash, GitError("failed to resolve tag commit").
+				WithCause(cerr).
+				WithContext("tag", repo.Branch).
+				Build()
+		}
+		hash = commit.Hash
+	}
+	return hash, nil
+}
+
+// PruneWorktrees removes the worktrees of the shared clone of base that are not in
+// keep (repository names, i.e. versions no longer built) and drops the bookkeeping
+// of worktrees whose directories were deleted.
+func (c *Client) PruneWorktrees(base string, keep []string) error {
+	mirrorPath := c.MirrorPath(base)
+	if _, err := os.Stat(mirrorPath); err != nil {
+		return nil
+	}
+	defer lockMirror(mirrorPath)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), worktreeTimeout)
+	defer cancel()
+	out, err := runGit(ctx, c.resourceLimits(), mirrorPath, nil, "worktree", "list", "--porcelain")
+	if err != nil {
+		return worktreeError("failed to list worktrees", err, out, mirrorPath)
+	}
+	wanted := make(map[string]bool, len(keep))
+	for _, name := range keep {
+		wanted[filepath.Clean(filepath.Join(c.workspaceDir, name))] = true
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		path, ok := strings.CutPrefix(line, "worktree ")
+		if !ok || filepath.Clean(path) == filepath.Clean(mirrorPath) || wanted[filepath.Clean(path)] {
+			continue
+		}
+		if rout, rerr := runGit(ctx, c.resourceLimits(), mirrorPath, nil, "worktree", "remove", "--force", path); rerr != nil {
+			c.log().Warn("failed to remove stale worktree",
+				logfields.Path(path),
+				slog.String("error", rerr.Error()),
+				slog.String("output", strings.TrimSpace(string(rout))))
+			continue
+		}
+		c.log().Info("Removed stale worktree", logfields.Name(base), logfields.Path(path))
+	}
+	if out, err := runGit(ctx, c.resourceLimits(), mirrorPath, nil, "worktree", "prune"); err != nil {
+		return worktreeError("failed to prune worktrees", err, out, mirrorPath)
+	}
+	return nil
+}
+
+// isLinkedWorktree reports whether path is a linked worktree (.git is a file).
+func isLinkedWorktree(path string) bool {
+	fi, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func worktreeError(msg string, err error, out []byte, path string) error {
+	return GitError(msg).
+		WithCause(err).
+		WithContext("path", path).
+		WithContext("output", strings.TrimSpace(string(out))).
+		Build()
+}
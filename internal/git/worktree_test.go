@@ -0,0 +1,106 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	appcfg "git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestCheckoutWorktree_SharesOneClone(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "docs/index.md", "# v1")
+	gitCLI(t, src, "branch", "-M", "main")
+	gitCLI(t, src, "tag", "v1.0.0")
+	origin, err := git.PlainOpen(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := addSimpleCommit(t, origin, src, "next.md")
+
+	workspace := t.TempDir()
+	client := NewClient(workspace)
+	mainRepo := appcfg.Repository{Name: "docs-main", URL: src, Branch: "main", WorktreeOf: "docs", IsVersioned: true}
+	tagRepo := appcfg.Repository{Name: "docs-v1.0.0", URL: src, Branch: "v1.0.0", IsTag: true, WorktreeOf: "docs", IsVersioned: true}
+
+	mainRes, err := client.CheckoutWorktree(mainRepo)
+	if err != nil {
+		t.Fatalf("checkout main: %v", err)
+	}
+	if mainRes.CommitSHA != head.String() {
+		t.Fatalf("main commit = %s, want %s", mainRes.CommitSHA, head)
+	}
+	tagRes, err := client.CheckoutWorktree(tagRepo)
+	if err != nil {
+		t.Fatalf("checkout tag: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tagRes.Path, "next.md")); !os.IsNotExist(err) {
+		t.Fatalf("tag worktree should not contain later commits (stat err %v)", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(workspace, mirrorsDir))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one shared clone, got %v (%v)", entries, err)
+	}
+	if got, err := ReadRepoHead(mainRes.Path); err != nil || got != head.String() {
+		t.Fatalf("ReadRepoHead on worktree = %q, %v", got, err)
+	}
+	if _, err := OpenRepository(tagRes.Path); err != nil {
+		t.Fatalf("open worktree: %v", err)
+	}
+	results, err := VerifyWorkspace(workspace, true)
+	if err != nil || len(results) != 3 || results[0].Name != ".mirrors/docs.git" {
+		t.Fatalf("unexpected verification results %+v (%v)", results, err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Fatalf("%s failed verification: %v", r.Name, r.Err)
+		}
+	}
+
+	// A new commit moves the existing worktree instead of re-adding it.
+	next := addSimpleCommit(t, origin, src, "later.md")
+	if mainRes, err = client.CheckoutWorktree(mainRepo); err != nil {
+		t.Fatalf("update main: %v", err)
+	}
+	if mainRes.CommitSHA != next.String() {
+		t.Fatalf("updated commit = %s, want %s", mainRes.CommitSHA, next)
+	}
+	if _, err := os.Stat(filepath.Join(mainRes.Path, "later.md")); err != nil {
+		t.Fatalf("expected new file in worktree: %v", err)
+	}
+
+	// The tag is no longer built: its worktree is removed.
+	if err := client.PruneWorktrees("docs", []string{"docs-main"}); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if _, err := os.Stat(tagRes.Path); !os.IsNotExist(err) {
+		t.Fatalf("stale worktree still present (stat err %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(mainRes.Path, "later.md")); err != nil {
+		t.Fatalf("kept worktree was touched: %v", err)
+	}
+}
+
+func TestCheckoutWorktree_ReplacesRegularClone(t *testing.T) {
+	src := t.TempDir()
+	initRepoWithFile(t, src, "index.md", "# Docs")
+	gitCLI(t, src, "branch", "-M", "main")
+
+	client := NewClient(t.TempDir())
+	repo := appcfg.Repository{Name: "docs-main", URL: src, Branch: "main"}
+	path, err := client.CloneRepo(repo)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	repo.WorktreeOf = "docs"
+	if _, err := client.CheckoutWorktree(repo); err != nil {
+		t.Fatalf("checkout worktree: %v", err)
+	}
+	if !isLinkedWorktree(path) {
+		t.Fatal("expected the clone to be replaced by a worktree")
+	}
+}
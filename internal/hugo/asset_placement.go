@@ -0,0 +1,84 @@
+package hugo
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// Methods an asset was placed into the site with.
+const (
+	assetCopied     = "copy"
+	assetHardlinked = "hardlink"
+	assetReflinked  = "reflink"
+)
+
+// errReflinkUnsupported is returned by reflinkFile where copy-on-write clones are not available.
+var errReflinkUnsupported = errors.New("reflinks are not supported on this platform")
+
+// assetCopyStrategy returns build.copy_strategy, without hard links when output
+// permissions are set: a hard link shares its inode with the working copy file, so
+// setting the output mode or owner would change the working copy too. Reflinks are
+// separate files and are kept.
+func (g *Generator) assetCopyStrategy() config.CopyStrategy {
+	strategy := g.config.Build.AssetCopyStrategy()
+	if !g.config.Output.SetsPermissions() {
+		return strategy
+	}
+	switch strategy {
+	case config.CopyStrategyHardlink:
+		return config.CopyStrategyCopy
+	case config.CopyStrategyAuto:
+		return config.CopyStrategyReflink
+	default:
+		return strategy
+	}
+}
+
+// placeAsset puts the asset src at dst with the copy strategy and returns the method
+// used. Links are only made to regular files; symbolic links in the working copy are
+// copied by content. Whatever cannot be linked (another filesystem, no reflink
+// support) is copied. An existing dst is removed first, so a file linked by an
+// earlier build is never written through.
+func placeAsset(strategy config.CopyStrategy, src, dst string) (string, error) {
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if strategy != config.CopyStrategyCopy && strategy != "" {
+		if info, err := os.Lstat(src); err == nil && info.Mode().IsRegular() {
+			if strategy == config.CopyStrategyReflink || strategy == config.CopyStrategyAuto {
+				if reflinkFile(src, dst) == nil {
+					return assetReflinked, nil
+				}
+			}
+			if strategy == config.CopyStrategyHardlink || strategy == config.CopyStrategyAuto {
+				if os.Link(src, dst) == nil {
+					return assetHardlinked, nil
+				}
+			}
+		}
+	}
+	return assetCopied, copyFile(src, dst)
+}
+
+// copyFile streams src to a new file at dst, so large assets never have to fit in memory.
+func copyFile(src, dst string) error {
+	// #nosec G304 -- asset files are public documentation resources
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	// #nosec G302,G304 -- asset files are public documentation resources
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
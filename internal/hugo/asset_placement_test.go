@@ -0,0 +1,148 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+)
+
+func TestPlaceAsset(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "diagram.png")
+	if err := os.WriteFile(src, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		strategy config.CopyStrategy
+		methods  []string // accepted methods; reflinks depend on the filesystem
+		shared   bool     // dst is the same file as src
+	}{
+		{config.CopyStrategyCopy, []string{assetCopied}, false},
+		{config.CopyStrategyHardlink, []string{assetHardlinked}, true},
+		{config.CopyStrategyReflink, []string{assetReflinked, assetCopied}, false},
+		{config.CopyStrategyAuto, []string{assetReflinked, assetHardlinked}, false},
+	} {
+		dst := filepath.Join(dir, string(tc.strategy)+".png")
+		method, err := placeAsset(tc.strategy, src, dst)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.strategy, err)
+		}
+		accepted := false
+		for _, m := range tc.methods {
+			accepted = accepted || m == method
+		}
+		if !accepted {
+			t.Fatalf("%s: placed with %s, want one of %v", tc.strategy, method, tc.methods)
+		}
+		info, err := os.Stat(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.shared && !os.SameFile(srcInfo, info) {
+			t.Fatalf("%s: expected a hard link", tc.strategy)
+		}
+		if method == assetCopied && os.SameFile(srcInfo, info) {
+			t.Fatalf("%s: copy shares the source file", tc.strategy)
+		}
+		if got, _ := os.ReadFile(dst); string(got) != "png" {
+			t.Fatalf("%s: content = %q", tc.strategy, got)
+		}
+	}
+}
+
+func TestPlaceAsset_ReplacesLinkedFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "logo.svg")
+	dst := filepath.Join(dir, "site-logo.svg")
+	if err := os.WriteFile(src, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// An earlier build linked the output to the working copy file.
+	if err := os.Link(src, dst); err != nil {
+		t.Skipf("hard links unsupported: %v", err)
+	}
+	other := filepath.Join(dir, "other.svg")
+	if err := os.WriteFile(other, []byte("new"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := placeAsset(config.CopyStrategyCopy, other, dst); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(src); string(got) != "old" {
+		t.Fatalf("copy wrote through the link into the working copy: %q", got)
+	}
+	if got, _ := os.ReadFile(dst); string(got) != "new" {
+		t.Fatalf("dst content = %q", got)
+	}
+}
+
+func TestPlaceAsset_CopiesSymlinkedSource(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "real.png")
+	if err := os.WriteFile(target, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(dir, "alias.png")
+	if err := os.Symlink("real.png", src); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	dst := filepath.Join(t.TempDir(), "alias.png")
+
+	method, err := placeAsset(config.CopyStrategyHardlink, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Lstat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != assetCopied || !info.Mode().IsRegular() {
+		t.Fatalf("symlinked asset placed with %s as %v, want a copied regular file", method, info.Mode())
+	}
+}
+
+func TestGenerateSite_OutputPermissionsDoNotChangeLinkedSources(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "diagram.png")
+	if err := os.WriteFile(src, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		Hugo:   config.HugoConfig{Title: "Test", BaseURL: "/"},
+		Build:  config.BuildConfig{CopyStrategy: config.CopyStrategyHardlink},
+		Output: config.OutputConfig{Directory: outputDir, FileMode: "0644"},
+	}
+	gen := NewGenerator(cfg, outputDir)
+	asset := docs.DocFile{Path: src, Repository: "r", RelativePath: "diagram.png", Name: "diagram", Extension: ".png", IsAsset: true}
+
+	if err := gen.GenerateSite([]docs.DocFile{asset}); err != nil {
+		t.Fatalf("GenerateSite: %v", err)
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srcInfo.Mode().Perm() != 0o600 {
+		t.Fatalf("source mode = %v, want 0600: output permissions reached the working copy", srcInfo.Mode().Perm())
+	}
+	outInfo, err := os.Stat(filepath.Join(outputDir, asset.GetHugoPath(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if os.SameFile(srcInfo, outInfo) {
+		t.Fatalf("asset was hard-linked although output permissions are set")
+	}
+	if outInfo.Mode().Perm() != 0o644 {
+		t.Fatalf("output mode = %v, want 0644", outInfo.Mode().Perm())
+	}
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+package hugo
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflinkFile clones src to a new file at dst copy-on-write (FICLONE). Filesystems
+// without reflink support, and src and dst on different filesystems, return an error
+// and leave no file behind.
+func reflinkFile(src, dst string) error {
+	// #nosec G304 -- asset files are public documentation resources
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	// #nosec G302,G304 -- asset files are public documentation resources
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package hugo
+
+// reflinkFile is only implemented on Linux; assets are linked or copied elsewhere.
+func reflinkFile(_, _ string) error {
+	return errReflinkUnsupported
+}
@@ -0,0 +1,27 @@
+package hugo
+
+import (
+	"context"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+// runPostBuildHooks runs the post_build hook commands once the new site is published.
+// The site stays in place when a command fails; the failure is recorded with the run in
+// the report.
+func (g *Generator) runPostBuildHooks(ctx context.Context, report *models.BuildReport, workspace string) {
+	if !stages.HasHooks(g.config, config.HookPostBuild) {
+		return
+	}
+	env := stages.HookEnv{
+		BuildID:   observability.BuildID(ctx),
+		Workspace: workspace,
+		OutputDir: g.outputDir,
+		SiteDir:   g.outputDir,
+		Outcome:   string(report.Outcome),
+	}
+	_, _ = stages.RunHooks(ctx, g.config, config.HookPostBuild, env, report)
+}
@@ -0,0 +1,69 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+)
+
+func TestGenerateSite_RunsBuildHooks(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "site")
+	src := filepath.Join(t.TempDir(), "a.md")
+	if err := os.WriteFile(src, []byte("# A"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hook := func(script string) config.HookCommand {
+		return config.HookCommand{Command: "sh", Args: []string{"-c", script}}
+	}
+	cfg := &config.Config{
+		Hugo: config.HugoConfig{Title: "Site"},
+		Hooks: &config.BuildHooksConfig{
+			PreBuild:  []config.HookCommand{hook(`echo "$DOCBUILDER_HOOK"`)},
+			PreHugo:   []config.HookCommand{hook(`test -d "$DOCBUILDER_SITE_DIR/content" && echo "$DOCBUILDER_SITE_DIR"`)},
+			PostBuild: []config.HookCommand{hook(`echo "$DOCBUILDER_OUTCOME $DOCBUILDER_SITE_DIR"`)},
+		},
+	}
+	g := NewGenerator(cfg, out).WithRenderer(&stages.NoopRenderer{})
+	report, err := g.GenerateSiteWithReportContext(t.Context(), []docs.DocFile{
+		{Repository: "repo1", Name: "a", Path: src, RelativePath: "a.md", DocsBase: ".", Extension: ".md"},
+	})
+	if err != nil {
+		t.Fatalf("build errored: %v", err)
+	}
+	if len(report.Hooks) != 3 {
+		t.Fatalf("expected three hook runs, got %+v", report.Hooks)
+	}
+	want := []struct{ point, output string }{
+		{"pre_build", "pre_build"},
+		{"pre_hugo", out + "_stage"},
+		{"post_build", "success " + out},
+	}
+	for i, w := range want {
+		if h := report.Hooks[i]; h.Point != w.point || h.Output != w.output || h.Error != "" {
+			t.Fatalf("hook %d: expected %s with output %q, got %+v", i, w.point, w.output, h)
+		}
+	}
+	if _, ok := report.StageDurations[string(models.StagePreHugoHooks)]; !ok {
+		t.Fatalf("expected pre_hugo_hooks stage timing, got %v", report.StageDurations)
+	}
+}
+
+func TestGenerateSite_FailingHookAbortsBuild(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "site")
+	cfg := &config.Config{
+		Hugo:  config.HugoConfig{Title: "Site"},
+		Hooks: &config.BuildHooksConfig{PreBuild: []config.HookCommand{{Command: "false"}}},
+	}
+	g := NewGenerator(cfg, out).WithRenderer(&stages.NoopRenderer{})
+	if _, err := g.GenerateSiteWithReportContext(t.Context(), nil); err == nil {
+		t.Fatal("expected the failing pre_build hook to fail the build")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Fatalf("expected no published output, got %v", err)
+	}
+}
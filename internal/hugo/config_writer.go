@@ -2,7 +2,6 @@ package hugo
 
 import (
 	"fmt"
-	"log/slog"
 	"os"
 	"path/filepath"
 	"time"
@@ -54,7 +53,7 @@ func (g *Generator) GenerateHugoConfig() error {
 		versionInfo := g.collectVersionMetadata()
 		if len(versionInfo) > 0 {
 			params["versions"] = versionInfo
-			slog.Debug("Added version metadata to Hugo config", "repo_count", len(versionInfo))
+			g.log().Debug("Added version metadata to Hugo config", "repo_count", len(versionInfo))
 		}
 	}
 
@@ -125,11 +124,11 @@ func (g *Generator) GenerateHugoConfig() error {
 
 	// Ensure go.mod for Hugo Modules (Relearn requires this)
 	if err := g.ensureGoModForModules(); err != nil {
-		slog.Warn("Failed to ensure go.mod for Hugo Modules", "error", err)
+		g.log().Warn("Failed to ensure go.mod for Hugo Modules", "error", err)
 	}
 
-	slog.Info("Generated Hugo configuration with Relearn theme", logfields.Path(configPath))
-	slog.Debug("Hugo configuration content:\n" + string(data))
+	g.log().Info("Generated Hugo configuration with Relearn theme", logfields.Path(configPath))
+	g.log().Debug("Hugo configuration content:\n" + string(data))
 
 	return nil
 }
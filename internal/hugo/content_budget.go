@@ -0,0 +1,52 @@
+package hugo
+
+import (
+	"fmt"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+)
+
+const bytesPerMB = 1 << 20
+
+// contentBudget bounds the markdown bytes the content pipeline holds in memory.
+// Assets are streamed to disk and never count against it.
+type contentBudget struct {
+	maxFile  int64 // 0 = unlimited
+	maxTotal int64 // 0 = unlimited
+	used     int64
+}
+
+func newContentBudget(cfg config.BuildConfig) *contentBudget {
+	return &contentBudget{
+		maxFile:  int64(cfg.MaxFileSizeMB) * bytesPerMB,
+		maxTotal: int64(cfg.MaxContentMemoryMB) * bytesPerMB,
+	}
+}
+
+// admit reserves room for a markdown file before its content is loaded.
+func (b *contentBudget) admit(file *docs.DocFile) error {
+	if b.maxFile == 0 && b.maxTotal == 0 {
+		return nil
+	}
+	path := file.Path
+	size, err := docFileSize(file)
+	if err != nil {
+		return err
+	}
+	if b.maxFile > 0 && size > b.maxFile {
+		return fmt.Errorf("%w: %s is %s, larger than build.max_file_size_mb (%d MB)",
+			herrors.ErrContentBudgetExceeded, path, formatMB(size), b.maxFile/bytesPerMB)
+	}
+	if b.maxTotal > 0 && b.used+size > b.maxTotal {
+		return fmt.Errorf("%w: loading %s would hold %s of markdown, more than build.max_content_memory_mb (%d MB)",
+			herrors.ErrContentBudgetExceeded, path, formatMB(b.used+size), b.maxTotal/bytesPerMB)
+	}
+	b.used += size
+	return nil
+}
+
+func formatMB(n int64) string {
+	return fmt.Sprintf("%.1f MB", float64(n)/bytesPerMB)
+}
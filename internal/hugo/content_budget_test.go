@@ -0,0 +1,64 @@
+package hugo
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+)
+
+func TestContentBudget(t *testing.T) {
+	dir := t.TempDir()
+	big := filepath.Join(dir, "big.md")
+	if err := os.WriteFile(big, bytes.Repeat([]byte("x"), 2*bytesPerMB), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unlimited := newContentBudget(config.BuildConfig{})
+	if err := unlimited.admit(&docs.DocFile{Path: big}); err != nil {
+		t.Fatalf("unlimited budget rejected file: %v", err)
+	}
+
+	perFile := newContentBudget(config.BuildConfig{MaxFileSizeMB: 1})
+	err := perFile.admit(&docs.DocFile{Path: big})
+	if !errors.Is(err, herrors.ErrContentBudgetExceeded) || !strings.Contains(err.Error(), "max_file_size_mb") {
+		t.Fatalf("expected per-file budget error, got %v", err)
+	}
+
+	total := newContentBudget(config.BuildConfig{MaxContentMemoryMB: 3})
+	if err := total.admit(&docs.DocFile{Path: big}); err != nil {
+		t.Fatalf("first file within total budget rejected: %v", err)
+	}
+	err = total.admit(&docs.DocFile{Path: "loaded.md", Content: bytes.Repeat([]byte("y"), 2*bytesPerMB)})
+	if !errors.Is(err, herrors.ErrContentBudgetExceeded) || !strings.Contains(err.Error(), "max_content_memory_mb") {
+		t.Fatalf("expected total budget error, got %v", err)
+	}
+}
+
+func TestCopyAssetFileStreamsContent(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "diagram.png")
+	payload := bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 1<<18)
+	if err := os.WriteFile(src, payload, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	gen := NewGenerator(&config.Config{Hugo: config.HugoConfig{Title: "Test", BaseURL: "/"}}, out).WithRenderer(&stages.NoopRenderer{})
+	file := docs.DocFile{Path: src, Repository: "r", RelativePath: "diagram.png", Name: "diagram", Extension: ".png", IsAsset: true}
+	if err := gen.copyAssetFile(file, true); err != nil {
+		t.Fatalf("copyAssetFile: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(gen.BuildRoot(), file.GetHugoPath(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("copied asset differs: %d bytes, want %d", len(got), len(payload))
+	}
+}
@@ -9,15 +9,18 @@ import (
 
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 
+	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
 	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
 )
 
 // copyContentFilesPipeline copies documentation files using the new fixed transform pipeline.
 // This is the new implementation that replaces the registry-based transform system.
 func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []docs.DocFile, bs *models.BuildState) error {
-	slog.Info("Using new fixed transform pipeline for content processing")
+	g.log().Info("Using new fixed transform pipeline for content processing")
 	publicOnly := g.config.IsDaemonPublicOnlyEnabled()
 
 	// Compute isSingleRepo flag
@@ -33,8 +36,17 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 		isSingleRepo = len(repoSet) == 1
 	}
 
+	var report *models.BuildReport
+	if bs != nil {
+		report = bs.Report
+	}
+	if err := g.checkGuardrails(docFiles, report); err != nil {
+		return err
+	}
+
 	// Separate markdown files from assets
 	var markdownFiles []docs.DocFile
+	var markdownSources []int // index of each markdown file in docFiles
 	var assetFiles []docs.DocFile
 
 	for i := range docFiles {
@@ -43,6 +55,7 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 			assetFiles = append(assetFiles, *file)
 		} else {
 			markdownFiles = append(markdownFiles, *file)
+			markdownSources = append(markdownSources, i)
 		}
 	}
 
@@ -60,11 +73,19 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 		}
 	}
 
-	// Convert DocFiles to pipeline Documents
+	// Convert DocFiles to pipeline Documents. Markdown is buffered for the transforms,
+	// within the configured memory budget.
+	budget := newContentBudget(g.config.Build)
 	discovered := make([]*pipeline.Document, 0, len(markdownFiles))
+	// Root README/index documents keep their transformed bytes in docFiles for the
+	// README-as-index fallback of the index stage.
+	indexSources := make(map[*pipeline.Document]int)
 	excluded := 0
 	for i := range markdownFiles {
 		file := &markdownFiles[i]
+		if err := budget.admit(file); err != nil {
+			return err
+		}
 		// Load content
 		if err := file.LoadContent(); err != nil {
 			return fmt.Errorf("%w: failed to load content for %s: %w",
@@ -78,35 +99,99 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 
 		// Convert to pipeline Document
 		doc := pipeline.NewDocumentFromDocFile(*file, isSingleRepo, g.config.Build.IsPreview, g.config.Build.VSCodeEditLinks, g.config.Build.EditURLBase)
+		doc.BrowserEditor = g.config.Build.BrowserEditor
 		discovered = append(discovered, doc)
+		if isRepositoryIndexCandidate(file) {
+			indexSources[doc] = markdownSources[i]
+		}
+		file.Content = nil // the document holds its own copy
 	}
 
-	slog.Info("Converted discovered files to pipeline documents",
+	g.log().Info("Converted discovered files to pipeline documents",
 		slog.Int("markdown", len(discovered)),
 		slog.Int("assets", len(assetFiles)))
 	if publicOnly {
-		slog.Info("Daemon public-only filter applied",
+		g.log().Info("Daemon public-only filter applied",
 			slog.Int("excluded_markdown", excluded),
 			slog.Int("included_markdown", len(discovered)))
 	}
 
 	// Build repository metadata for generators
 	repoMetadata := g.buildRepositoryMetadata(bs)
+	g.addLintSummaries(docFiles, repoMetadata)
 
 	// Create and run pipeline processor
-	processor := pipeline.NewProcessor(g.config)
+	prevManifest := g.loadPageManifest()
+	processor := pipeline.NewProcessor(g.config).WithRedirects(g.buildRedirectIndex(prevManifest))
 	processedDocs, err := processor.ProcessContent(discovered, repoMetadata, isSingleRepo)
 	if err != nil {
 		return fmt.Errorf("%w: pipeline processing failed: %w",
 			herrors.ErrContentTransformFailed, err)
 	}
 
-	slog.Info("Pipeline processing complete",
+	g.log().Info("Pipeline processing complete",
 		slog.Int("input", len(discovered)),
-		slog.Int("output", len(processedDocs)))
+		slog.Int("output", len(processedDocs)),
+		slog.Int("workers", len(processor.WorkerTimings())))
+	if bs != nil && bs.Report != nil {
+		for _, t := range processor.WorkerTimings() {
+			bs.Report.TransformWorkers = append(bs.Report.TransformWorkers, models.TransformWorkerTiming{
+				Worker:    t.Worker,
+				Documents: t.Documents,
+				Busy:      t.Busy,
+			})
+		}
+	}
+	unresolved, duplicates, invalidDates := 0, 0, 0
+	for _, doc := range processedDocs {
+		unresolved += len(doc.UnresolvedLinks)
+		duplicates += len(doc.DuplicateAnchors)
+		invalidDates += len(doc.InvalidDates)
+		if bs == nil || bs.Report == nil {
+			continue
+		}
+		for _, l := range doc.UnresolvedLinks {
+			bs.Report.UnresolvedLinks = append(bs.Report.UnresolvedLinks, models.UnresolvedLink{
+				Repository: l.Repository,
+				Source:     l.Source,
+				Target:     l.Target,
+				Reason:     l.Reason,
+			})
+		}
+		for _, a := range doc.DuplicateAnchors {
+			bs.Report.DuplicateAnchors = append(bs.Report.DuplicateAnchors, models.DuplicateAnchor{
+				Repository: a.Repository,
+				Source:     a.Source,
+				Line:       a.Line,
+				FirstLine:  a.FirstLine,
+				Anchor:     a.Anchor,
+				Suggested:  a.Suggested,
+			})
+		}
+		for _, d := range doc.InvalidDates {
+			bs.Report.InvalidDates = append(bs.Report.InvalidDates, models.InvalidDate{
+				Repository: d.Repository,
+				Source:     d.Source,
+				Key:        d.Key,
+				Value:      d.Value,
+			})
+		}
+	}
+	if unresolved > 0 {
+		g.log().Warn("Unresolved relative links found; see unresolved_links in the build report",
+			slog.Int("count", unresolved))
+	}
+	if duplicates > 0 {
+		g.log().Warn("Duplicate heading anchors found; see duplicate_anchors in the build report",
+			slog.Int("count", duplicates))
+	}
+	if invalidDates > 0 {
+		g.log().Warn("Unparseable front matter dates dropped; see invalid_dates in the build report",
+			slog.Int("count", invalidDates))
+	}
 
 	// Write processed documents to Hugo content directory
-	for i, doc := range processedDocs {
+	for _, doc := range processedDocs {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -131,7 +216,7 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 				herrors.ErrContentWriteFailed, outputPath, err)
 		}
 
-		slog.Debug("Wrote processed document",
+		g.log().Debug("Wrote processed document",
 			slog.String("path", doc.Path),
 			slog.Int("bytes", len(contentBytes)),
 			slog.Bool("generated", doc.Generated))
@@ -141,16 +226,40 @@ func (g *Generator) copyContentFilesPipeline(ctx context.Context, docFiles []doc
 			g.onPageRendered()
 		}
 
-		// Store transformed bytes for index generation
-		// Find corresponding DocFile and update it
-		if i < len(markdownFiles) && !doc.Generated {
-			markdownFiles[i].TransformedBytes = contentBytes
+		if src, ok := indexSources[doc]; ok {
+			docFiles[src].TransformedBytes = contentBytes
 		}
+		// The rendered bytes are on disk; later steps only need front matter and content.
+		doc.Raw = nil
 	}
 
-	slog.Info("Copied all content files using pipeline",
+	g.log().Info("Copied all content files using pipeline",
 		slog.Int("count", len(processedDocs)))
 
+	if bs != nil {
+		bs.Docs.ExportPages = exportPages(stages.ExportedRepositories(g.config, bs.Git.Repositories), processedDocs)
+	}
+
+	if err := g.writeRedirects(prevManifest, processedDocs); err != nil {
+		return fmt.Errorf("failed to write redirects: %w", err)
+	}
+
+	if err := g.writeStalenessReport(processedDocs); err != nil {
+		return fmt.Errorf("failed to write staleness report: %w", err)
+	}
+
+	if err := g.writeOwnershipMap(processedDocs); err != nil {
+		return fmt.Errorf("failed to write ownership map: %w", err)
+	}
+
+	if err := g.writeRepositoryStats(processedDocs, bs); err != nil {
+		return fmt.Errorf("failed to write repository stats: %w", err)
+	}
+
+	if err := g.writeTemplateIndex(processedDocs); err != nil {
+		return fmt.Errorf("failed to write template index: %w", err)
+	}
+
 	// Generate and write static assets (e.g., View Transitions)
 	if err := g.generateStaticAssets(processor); err != nil {
 		return fmt.Errorf("failed to generate static assets: %w", err)
@@ -170,7 +279,7 @@ func (g *Generator) generateStaticAssets(processor *pipeline.Processor) error {
 		return nil
 	}
 
-	slog.Info("Writing static assets", slog.Int("count", len(assets)))
+	g.log().Info("Writing static assets", slog.Int("count", len(assets)))
 
 	for _, asset := range assets {
 		outputPath := filepath.Join(g.BuildRoot(), asset.Path)
@@ -188,7 +297,7 @@ func (g *Generator) generateStaticAssets(processor *pipeline.Processor) error {
 				herrors.ErrContentWriteFailed, outputPath, err)
 		}
 
-		slog.Debug("Wrote static asset",
+		g.log().Debug("Wrote static asset",
 			slog.String("path", asset.Path),
 			slog.Int("bytes", len(asset.Content)))
 	}
@@ -215,11 +324,18 @@ func (g *Generator) buildRepositoryMetadata(bs *models.BuildState) map[string]pi
 			DocsBase:  "docs", // Default
 			DocsPaths: []string{"docs"},
 		}
+		info.EditURLTemplate = repo.EditURLTemplateFor(g.config.Build.EditURLTemplate)
+		info.Static = repo.IsStatic()
+		info.Sanitize = repo.SanitizePolicy(g.config.Sanitize)
+		info.PageMetadata = repo.PageMetadataPolicy(g.config.PageMetadata)
 
 		// Get forge type from tags
 		if forgeType, ok := repo.Tags["forge_type"]; ok {
 			info.Forge = forgeType
 		}
+		if g.releaseNotes != nil {
+			info.Releases = g.releaseNotes.ReleaseNotes(repo)
+		}
 
 		// Get docs base from paths (use first path if multiple)
 		if len(repo.Paths) > 0 {
@@ -235,23 +351,48 @@ func (g *Generator) buildRepositoryMetadata(bs *models.BuildState) map[string]pi
 			if commitDate, ok := bs.Git.GetCommitDate(repo.Name); ok {
 				info.CommitDate = commitDate
 			}
+			if repoPath, ok := bs.Git.RepoPaths[repo.Name]; ok {
+				// Working copies downloaded as archives (fetch: archive) have no history.
+				if _, statErr := os.Stat(filepath.Join(repoPath, ".git")); repo.GitMetadataEnabled() && statErr == nil {
+					history, err := git.CollectFileHistory(repoPath, info.DocsPaths, 0)
+					if err != nil {
+						g.log().Warn("Failed to collect git history metadata",
+							slog.String("repository", repo.Name),
+							slog.String("error", err.Error()))
+					} else {
+						info.FileHistory = history
+					}
+				}
+				owners, err := git.LoadCodeOwners(repoPath)
+				if err != nil {
+					g.log().Warn("Failed to load CODEOWNERS",
+						slog.String("repository", repo.Name),
+						slog.String("error", err.Error()))
+				}
+				info.Owners = owners
+			}
 		}
 
 		metadata[repo.Name] = info
+
+		// Monorepo sections are site areas of their own, sharing the repository's git metadata.
+		for _, section := range repo.Sections {
+			sectionInfo := info
+			sectionInfo.Name = section.Name
+			sectionInfo.DocsBase = section.Path
+			sectionInfo.DocsPaths = []string{section.Path}
+			sectionInfo.Title = section.Title
+			sectionInfo.Weight = section.Weight
+			metadata[section.Name] = sectionInfo
+		}
 	}
 
 	return metadata
 }
 
-// copyAssetFile copies an asset file (image, etc.) to Hugo content directory without processing.
+// copyAssetFile places an asset file (image, etc.) in the Hugo content directory without
+// processing, as a copy or a link to the working copy file (build.copy_strategy).
 func (g *Generator) copyAssetFile(file docs.DocFile, isSingleRepo bool) error {
-	// Read the asset file
-	content, err := os.ReadFile(file.Path)
-	if err != nil {
-		return fmt.Errorf("%w: failed to read asset %s: %w",
-			herrors.ErrContentWriteFailed, file.Path, err)
-	}
-
 	// Calculate output path - assets go in same location as markdown files
 	outputPath := filepath.Join(g.BuildRoot(), file.GetHugoPath(isSingleRepo))
 
@@ -261,17 +402,45 @@ func (g *Generator) copyAssetFile(file docs.DocFile, isSingleRepo bool) error {
 			herrors.ErrContentWriteFailed, outputPath, err)
 	}
 
-	// Copy asset file as-is
-	// #nosec G306 -- asset files are public documentation resources
-	if err := os.WriteFile(outputPath, content, 0o644); err != nil {
+	method, err := placeAsset(g.assetCopyStrategy(), file.Path, outputPath)
+	if err != nil {
 		return fmt.Errorf("%w: failed to write asset %s: %w",
 			herrors.ErrContentWriteFailed, outputPath, err)
 	}
 
-	slog.Debug("Copied asset file",
+	g.log().Debug("Copied asset file",
 		slog.String("source", file.RelativePath),
 		slog.String("destination", file.GetHugoPath(isSingleRepo)),
-		slog.String("type", file.Extension))
+		slog.String("type", file.Extension),
+		slog.String("method", method))
 
 	return nil
 }
+
+// exportPages lists the written pages of exported repositories for the export stage.
+// Generated pages are only kept when they are indexes (download links are added to them).
+func exportPages(exported map[string]*config.Repository, processedDocs []*pipeline.Document) []models.ExportPage {
+	if len(exported) == 0 {
+		return nil
+	}
+	var pages []models.ExportPage
+	for _, doc := range processedDocs {
+		if exported[doc.Repository] == nil || (doc.Generated && !doc.IsIndex) {
+			continue
+		}
+		page := models.ExportPage{
+			Repository: doc.Repository,
+			Path:       filepath.ToSlash(doc.Path),
+			Generated:  doc.Generated,
+			Index:      doc.IsIndex,
+		}
+		if !doc.Generated {
+			page.Source = doc.RepoRelativePath()
+		}
+		if title, ok := doc.FrontMatter["title"].(string); ok {
+			page.Title = title
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
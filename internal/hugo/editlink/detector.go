@@ -81,7 +81,8 @@ func PrepareDetectionContext(file docs.DocFile, cfg *config.Config) (DetectionCo
 			break
 		}
 	}
-	if repoCfg == nil {
+	// Static sources are not hosted on a forge; they have no edit links.
+	if repoCfg == nil || repoCfg.IsStatic() {
 		return DetectionContext{}, false
 	}
 
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	stdErrors "errors"
+
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// classifications maps pipeline sentinels to their category and stable error code.
+var classifications = []struct {
+	sentinel error
+	category ferrors.ErrorCategory
+	code     ferrors.ErrorCode
+}{
+	{ErrHugoBinaryNotFound, ferrors.CategoryHugo, ferrors.CodeHugoNotFound},
+	{ErrGoBinaryNotFound, ferrors.CategoryHugo, ferrors.CodeHugoGoToolchain},
+	{ErrHugoExecutionFailed, ferrors.CategoryHugo, ferrors.CodeHugoExecution},
+	{ErrGuardrailExceeded, ferrors.CategoryBuild, ferrors.CodeBuildGuardrail},
+	{ErrContentBudgetExceeded, ferrors.CategoryBuild, ferrors.CodeBuildContentBudget},
+	{ErrOutputValidationFailed, ferrors.CategoryBuild, ferrors.CodeBuildOutputValidation},
+	{ErrAccessibilityThresholdExceeded, ferrors.CategoryBuild, ferrors.CodeBuildAccessibility},
+	{ErrContentTransformFailed, ferrors.CategoryBuild, ferrors.CodeBuildContentTransform},
+	{ErrContentWriteFailed, ferrors.CategoryBuild, ferrors.CodeBuildContentWrite},
+	{ErrStagingFailed, ferrors.CategoryBuild, ferrors.CodeBuildStaging},
+	{ErrIndexGenerationFailed, ferrors.CategoryBuild, ferrors.CodeBuildIndexGeneration},
+	{ErrConfigMarshalFailed, ferrors.CategoryBuild, ferrors.CodeBuildHugoConfigWrite},
+	{ErrConfigWriteFailed, ferrors.CategoryBuild, ferrors.CodeBuildHugoConfigWrite},
+	{ErrLayoutCopyFailed, ferrors.CategoryBuild, ferrors.CodeBuildLayoutCopy},
+	{ErrReportPersistFailed, ferrors.CategoryBuild, ferrors.CodeBuildReportPersistFailed},
+}
+
+// Classify attaches a category and stable error code to a build pipeline failure caused
+// by one of the sentinels above, so the CLI can report the code and exit status.
+// Errors that are already classified, or not recognized, are returned unchanged.
+func Classify(err error) error {
+	if err == nil || ferrors.IsClassified(err) {
+		return err
+	}
+	for _, c := range classifications {
+		if stdErrors.Is(err, c.sentinel) {
+			return ferrors.WrapError(err, c.category, c.sentinel.Error()).
+				WithCode(c.code).
+				Build()
+		}
+	}
+	return err
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestClassify(t *testing.T) {
+	err := Classify(fmt.Errorf("run hugo: %w", ErrHugoBinaryNotFound))
+	ce, ok := ferrors.AsClassified(err)
+	if !ok {
+		t.Fatalf("expected classified error, got %T", err)
+	}
+	if ce.Category() != ferrors.CategoryHugo || ce.Code() != ferrors.CodeHugoNotFound {
+		t.Errorf("got %s/%s, want hugo/%s", ce.Category(), ce.Code(), ferrors.CodeHugoNotFound)
+	}
+
+	plain := fmt.Errorf("unrelated")
+	if got := Classify(plain); got != plain {
+		t.Errorf("unrecognized error should be returned unchanged, got %v", got)
+	}
+	if Classify(nil) != nil {
+		t.Error("Classify(nil) should be nil")
+	}
+}
@@ -23,6 +23,14 @@ var (
 	ErrContentTransformFailed = errors.New("content transform failed")
 	// ErrContentWriteFailed indicates writing processed content to the Hugo content directory failed.
 	ErrContentWriteFailed = errors.New("content write failed")
+	// ErrContentBudgetExceeded indicates a markdown file or the build's markdown total exceeded the configured memory budget.
+	ErrContentBudgetExceeded = errors.New("content memory budget exceeded")
+	// ErrGuardrailExceeded indicates a repository exceeded a content guardrail configured to fail the build.
+	ErrGuardrailExceeded = errors.New("content guardrail exceeded")
+	// ErrOutputValidationFailed indicates the rendered site failed an output check configured to fail the build.
+	ErrOutputValidationFailed = errors.New("output validation failed")
+	// ErrAccessibilityThresholdExceeded indicates the rendered site has more accessibility violations than allowed.
+	ErrAccessibilityThresholdExceeded = errors.New("accessibility violation threshold exceeded")
 	// ErrIndexGenerationFailed indicates generating index files (main, repository, section) failed.
 	ErrIndexGenerationFailed = errors.New("index generation failed")
 	// ErrLayoutCopyFailed indicates copying theme layouts to the Hugo site failed.
@@ -13,12 +13,16 @@ import (
 	"strings"
 
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
 	"git.home.luguber.info/inful/docbuilder/internal/metrics"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
 	"git.home.luguber.info/inful/docbuilder/internal/state"
 	"git.home.luguber.info/inful/docbuilder/internal/version"
 	"git.home.luguber.info/inful/docbuilder/internal/versioning"
@@ -44,6 +48,19 @@ type Generator struct {
 	stateManager state.RepositoryMetadataWriter
 	// keepStaging preserves staging directory on failure for debugging (set via WithKeepStaging)
 	keepStaging bool
+	// storage backs the output and staging directories (local filesystem unless WithStorage is used).
+	storage output.Storage
+	// loggers provides the hugo and git component loggers (slog.Default unless WithLogging is used).
+	loggers *logging.Router
+	// buildID correlates pipeline log lines with the build job (set from the build context).
+	buildID string
+	// releaseNotes (optional) supplies the forge releases rendered into repository release notes sections.
+	releaseNotes ReleaseNotesSource
+}
+
+// ReleaseNotesSource supplies the published releases of a repository.
+type ReleaseNotesSource interface {
+	ReleaseNotes(repo *config.Repository) []pipeline.Release
 }
 
 // NewGenerator creates a new Hugo site generator.
@@ -75,12 +92,55 @@ func (g *Generator) WithStateManager(sm state.RepositoryMetadataWriter) *Generat
 	return g
 }
 
+// WithReleaseNotes sets the source of repository releases; repositories with releases
+// get a generated releases/ section.
+func (g *Generator) WithReleaseNotes(src ReleaseNotesSource) *Generator {
+	g.releaseNotes = src
+	return g
+}
+
+// WithStorage sets the backend the output and staging directories are written to.
+func (g *Generator) WithStorage(s output.Storage) *Generator {
+	g.storage = s
+	return g
+}
+
+// Storage returns the output storage backend, defaulting to the local filesystem.
+func (g *Generator) Storage() output.Storage {
+	return output.Or(g.storage)
+}
+
+// WithLogging routes generator output through the hugo component logger and git
+// operations started by the pipeline through the git component logger.
+func (g *Generator) WithLogging(r *logging.Router) *Generator {
+	g.loggers = r
+	return g
+}
+
+// Logger returns a component logger tagged with the ID of the running build.
+func (g *Generator) Logger(c logging.Component) *slog.Logger {
+	logger := g.loggers.Logger(c)
+	if g.buildID != "" {
+		logger = logger.With(slog.String("build.id", g.buildID))
+	}
+	return logger
+}
+
+func (g *Generator) log() *slog.Logger { return g.Logger(logging.ComponentHugo) }
+
+// bindBuild tags subsequent pipeline log lines with the build ID carried by ctx.
+func (g *Generator) bindBuild(ctx context.Context) {
+	if id := observability.BuildID(ctx); id != "" {
+		g.buildID = id
+	}
+}
+
 // WithKeepStaging enables preservation of staging directory on failure for debugging.
 // When enabled, staging directory will not be cleaned up if Hugo build fails.
 func (g *Generator) WithKeepStaging(keep bool) *Generator {
 	g.keepStaging = keep
 	if keep {
-		slog.Debug("Staging directory preservation enabled for debugging")
+		g.log().Debug("Staging directory preservation enabled for debugging")
 	}
 	return g
 }
@@ -259,7 +319,8 @@ func (g *Generator) GenerateSiteWithReport(docFiles []docs.DocFile) (*models.Bui
 
 // GenerateSiteWithReportContext performs site generation honoring the provided context for cancellation.
 func (g *Generator) GenerateSiteWithReportContext(ctx context.Context, docFiles []docs.DocFile) (*models.BuildReport, error) {
-	slog.Info("Starting Hugo site generation", slog.String("output", g.outputDir), slog.Int("files", len(docFiles)))
+	g.bindBuild(ctx)
+	g.log().Info("Starting Hugo site generation", slog.String("output", g.outputDir), slog.Int("files", len(docFiles)))
 	if err := g.beginStaging(); err != nil {
 		return nil, err
 	}
@@ -281,11 +342,16 @@ func (g *Generator) GenerateSiteWithReportContext(ctx context.Context, docFiles
 
 	pipeline := models.NewPipeline().
 		Add(models.StagePrepareOutput, stages.StagePrepareOutput).
+		AddIf(stages.HasHooks(g.config, config.HookPreBuild), models.StagePreBuildHooks, stages.StagePreBuildHooks).
 		Add(models.StageGenerateConfig, stages.StageGenerateConfig).
 		Add(models.StageLayouts, stages.StageLayouts).
 		Add(models.StageCopyContent, stages.StageCopyContent).
 		Add(models.StageIndexes, stages.StageIndexes).
+		AddIf(stages.HasExports(g.config, nil), models.StageExport, stages.StageExport).
+		AddIf(stages.HasHooks(g.config, config.HookPreHugo), models.StagePreHugoHooks, stages.StagePreHugoHooks).
 		Add(models.StageRunHugo, stages.StageRunHugo).
+		AddIf(stages.ValidatesOutput(g.config), models.StageValidateOutput, stages.StageValidateOutput).
+		AddIf(stages.ChecksAccessibility(g.config), models.StageAccessibility, stages.StageCheckAccessibility).
 		Add(models.StagePostProcess, stages.StagePostProcess).
 		Build()
 
@@ -336,25 +402,21 @@ func (g *Generator) GenerateSiteWithReportContext(ctx context.Context, docFiles
 	if err := g.finalizeStaging(); err != nil {
 		return nil, fmt.Errorf("finalize staging: %w", err)
 	}
+	g.purgeChangedPages(ctx, report)
+	g.runPostBuildHooks(ctx, report, "")
 
 	// Verify public directory exists and log details
 	publicDir := filepath.Join(g.outputDir, "public")
-	if stat, err := os.Stat(publicDir); err == nil && stat.IsDir() {
+	if stat, err := g.Storage().Stat(publicDir); err == nil && stat.IsDir() {
 		// Count files in public directory
-		var fileCount int
-		_ = filepath.Walk(publicDir, func(path string, info os.FileInfo, err error) error {
-			if err == nil && !info.IsDir() {
-				fileCount++
-			}
-			return nil
-		})
-		slog.Info("Public directory verified after finalization",
+		fileCount, _ := g.Storage().CountFiles(publicDir)
+		g.log().Info("Public directory verified after finalization",
 			slog.String("path", publicDir),
 			slog.Int("files", fileCount),
 			slog.Time("modified", stat.ModTime()),
 			slog.Bool("static_rendered", report.StaticRendered))
 	} else {
-		slog.Warn("Public directory not found after finalization",
+		g.log().Warn("Public directory not found after finalization",
 			slog.String("expected_path", publicDir),
 			slog.String("output_dir", g.outputDir),
 			slog.Bool("static_rendered", report.StaticRendered),
@@ -366,14 +428,14 @@ func (g *Generator) GenerateSiteWithReportContext(ctx context.Context, docFiles
 	}
 	// Persist report (best effort) inside final output directory
 	if err := report.Persist(g.outputDir); err != nil {
-		slog.Warn("Failed to persist build report", "error", err)
+		g.log().Warn("Failed to persist build report", "error", err)
 	}
 	// record build-level metrics
 	if g.recorder != nil {
 		g.recorder.ObserveBuildDuration(report.End.Sub(report.Start))
 		g.recorder.IncBuildOutcome(metrics.BuildOutcomeLabel(report.Outcome))
 	}
-	slog.Info("Hugo site generation completed",
+	g.log().Info("Hugo site generation completed",
 		slog.String("output", g.outputDir),
 		slog.Int("repos", report.Repositories),
 		slog.Int("files", report.Files),
@@ -387,6 +449,7 @@ func (g *Generator) GenerateSiteWithReportContext(ctx context.Context, docFiles
 // GenerateFullSite clones repositories, discovers documentation, then executes the standard generation stages.
 // repositories: list of repositories to process. workspaceDir: directory for git operations (created if missing).
 func (g *Generator) GenerateFullSite(ctx context.Context, repositories []config.Repository, workspaceDir string) (*models.BuildReport, error) {
+	g.bindBuild(ctx)
 	report := models.NewBuildReport(ctx, 0, 0) // counts filled after discovery
 	report.PipelineVersion = 1
 	report.EffectiveRenderMode = string(config.ResolveEffectiveRenderMode(g.config))
@@ -407,13 +470,13 @@ func (g *Generator) GenerateFullSite(ctx context.Context, repositories []config.
 	// Step 2.5: Expand repositories with versioning if enabled
 	if g.config.Versioning != nil && !g.config.Versioning.DefaultBranchOnly {
 		// Create Git client for version discovery (uses standard workspace)
-		gitClient := git.NewClient(bs.Git.WorkspaceDir)
+		gitClient := git.NewClient(bs.Git.WorkspaceDir).WithLogger(g.Logger(logging.ComponentGit))
 		expanded, err := versioning.ExpandRepositoriesWithVersions(gitClient, g.config)
 		if err != nil {
-			slog.Warn("Failed to expand repositories with versions, using original list", "error", err)
+			g.log().Warn("Failed to expand repositories with versions, using original list", "error", err)
 		} else {
 			bs.Git.Repositories = expanded
-			slog.Info("Using expanded repository list with versions", "count", len(expanded))
+			g.log().Info("Using expanded repository list with versions", "count", len(expanded))
 		}
 	}
 
@@ -431,13 +494,20 @@ func (g *Generator) GenerateFullSite(ctx context.Context, repositories []config.
 
 	pipeline := models.NewPipeline().
 		Add(models.StagePrepareOutput, stages.StagePrepareOutput).
+		AddIf(stages.HasHooks(g.config, config.HookPreBuild), models.StagePreBuildHooks, stages.StagePreBuildHooks).
 		Add(models.StageCloneRepos, stages.StageCloneRepos).
+		AddIf(stages.HasHooks(g.config, config.HookPostClone), models.StagePostCloneHooks, stages.StagePostCloneHooks).
+		AddIf(stages.HasCodeDocs(bs.Git.Repositories), models.StageCodeDocs, stages.StageCodeDocs).
 		Add(models.StageDiscoverDocs, stages.StageDiscoverDocs).
 		Add(models.StageGenerateConfig, stages.StageGenerateConfig).
 		Add(models.StageLayouts, stages.StageLayouts).
 		Add(models.StageCopyContent, stages.StageCopyContent).
 		Add(models.StageIndexes, stages.StageIndexes).
+		AddIf(stages.HasExports(g.config, bs.Git.Repositories), models.StageExport, stages.StageExport).
+		AddIf(stages.HasHooks(g.config, config.HookPreHugo), models.StagePreHugoHooks, stages.StagePreHugoHooks).
 		Add(models.StageRunHugo, stages.StageRunHugo).
+		AddIf(stages.ValidatesOutput(g.config), models.StageValidateOutput, stages.StageValidateOutput).
+		AddIf(stages.ChecksAccessibility(g.config), models.StageAccessibility, stages.StageCheckAccessibility).
 		Add(models.StagePostProcess, stages.StagePostProcess).
 		Build()
 	if err := stages.RunStages(ctx, bs, pipeline); err != nil {
@@ -455,7 +525,7 @@ func (g *Generator) GenerateFullSite(ctx context.Context, repositories []config.
 		g.abortStaging()
 		// best-effort: persist updated report into existing output dir
 		if err := report.Persist(g.outputDir); err != nil {
-			slog.Warn("Failed to persist build report", "error", err)
+			g.log().Warn("Failed to persist build report", "error", err)
 		}
 		if g.recorder != nil {
 			g.recorder.ObserveBuildDuration(report.End.Sub(report.Start))
@@ -469,8 +539,10 @@ func (g *Generator) GenerateFullSite(ctx context.Context, repositories []config.
 	if err := g.finalizeStaging(); err != nil {
 		return report, fmt.Errorf("finalize staging: %w", err)
 	}
+	g.purgeChangedPages(ctx, report)
+	g.runPostBuildHooks(ctx, report, bs.Git.WorkspaceDir)
 	if err := report.Persist(g.outputDir); err != nil {
-		slog.Warn("Failed to persist build report", "error", err)
+		g.log().Warn("Failed to persist build report", "error", err)
 	}
 	if g.recorder != nil {
 		g.recorder.ObserveBuildDuration(report.End.Sub(report.Start))
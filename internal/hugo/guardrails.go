@@ -0,0 +1,223 @@
+package hugo
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// GuardrailReportJSON is written to the final output root (not the staging directory), so the
+// violations that failed a build remain available to the admin API.
+const GuardrailReportJSON = "guardrail-report.json"
+
+// GuardrailReport lists the content guardrail violations of the last build.
+type GuardrailReport struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Violations  []models.GuardrailViolation `json:"violations"`
+}
+
+// Guardrail limit names, as configured under guardrails.
+const (
+	limitMaxPages     = "max_pages"
+	limitMaxTotalSize = "max_total_size_mb"
+	limitMaxAssetSize = "max_asset_size_mb"
+)
+
+// repositoryUsage is what one repository contributes to the site.
+type repositoryUsage struct {
+	pages int
+	bytes int64
+}
+
+// checkGuardrails measures each repository's discovered content against its guardrails
+// before anything is copied. Violations are recorded on the report (warnings for action
+// "warn") and in the guardrail report; if any repository's action is "fail" the build aborts.
+func (g *Generator) checkGuardrails(docFiles []docs.DocFile, report *models.BuildReport) error {
+	limits, owners := g.repositoryGuardrails()
+	if len(limits) == 0 && !g.config.Guardrails.Enabled() {
+		return nil
+	}
+	limitsFor := func(repo string) *config.GuardrailsConfig {
+		if l, ok := limits[repo]; ok {
+			return l
+		}
+		return g.config.Guardrails
+	}
+
+	usage := make(map[string]*repositoryUsage)
+	var violations []models.GuardrailViolation
+	for i := range docFiles {
+		file := &docFiles[i]
+		repo := file.Repository
+		if owner, ok := owners[repo]; ok {
+			repo = owner
+		}
+		l := limitsFor(repo)
+		if !l.Enabled() {
+			continue
+		}
+		size, err := docFileSize(file)
+		if err != nil {
+			return err
+		}
+		u := usage[repo]
+		if u == nil {
+			u = &repositoryUsage{}
+			usage[repo] = u
+		}
+		u.bytes += size
+		if !file.IsAsset {
+			u.pages++
+		} else if maxAsset := int64(l.MaxAssetSizeMB) * bytesPerMB; maxAsset > 0 && size > maxAsset {
+			violations = append(violations, models.GuardrailViolation{
+				Repository: repo,
+				Limit:      limitMaxAssetSize,
+				Path:       path.Join(file.DocsBase, file.RelativePath),
+				Value:      size,
+				Threshold:  maxAsset,
+				Action:     guardrailAction(l),
+			})
+		}
+	}
+
+	repos := make([]string, 0, len(usage))
+	for repo := range usage {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		u, l := usage[repo], limitsFor(repo)
+		if l.MaxPages > 0 && u.pages > l.MaxPages {
+			violations = append(violations, models.GuardrailViolation{
+				Repository: repo, Limit: limitMaxPages, Value: int64(u.pages), Threshold: int64(l.MaxPages), Action: guardrailAction(l),
+			})
+		}
+		if maxTotal := int64(l.MaxTotalSizeMB) * bytesPerMB; maxTotal > 0 && u.bytes > maxTotal {
+			violations = append(violations, models.GuardrailViolation{
+				Repository: repo, Limit: limitMaxTotalSize, Value: u.bytes, Threshold: maxTotal, Action: guardrailAction(l),
+			})
+		}
+	}
+
+	if err := g.writeGuardrailReport(violations); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, v := range violations {
+		msg := guardrailMessage(v)
+		g.log().Warn("Content guardrail exceeded",
+			slog.String("repository", v.Repository),
+			slog.String("limit", v.Limit),
+			slog.String("action", v.Action),
+			slog.String("detail", msg))
+		if report != nil {
+			report.GuardrailViolations = append(report.GuardrailViolations, v)
+		}
+		if v.Action == string(config.GuardrailFail) {
+			failed++
+			continue
+		}
+		if report != nil {
+			err := ferrors.NewError(ferrors.CategoryBuild, msg).
+				Warning().
+				WithContext("repository", v.Repository).
+				WithContext("limit", v.Limit).
+				Build()
+			report.AddIssue(models.IssueGuardrailExceeded, models.StageCopyContent, models.SeverityWarning, msg, false, err)
+		}
+	}
+	if failed > 0 {
+		return ferrors.WrapError(herrors.ErrGuardrailExceeded, ferrors.CategoryBuild,
+			fmt.Sprintf("%d content guardrail violation(s) configured to fail the build; see guardrail_violations in the build report", failed)).
+			WithContext("violations", failed).
+			Build()
+	}
+	return nil
+}
+
+// repositoryGuardrails returns the effective guardrails of each configured repository that
+// overrides them, and maps monorepo section names to their repository so that sections
+// count against the repository as a whole.
+func (g *Generator) repositoryGuardrails() (map[string]*config.GuardrailsConfig, map[string]string) {
+	limits := make(map[string]*config.GuardrailsConfig)
+	owners := make(map[string]string)
+	for i := range g.config.Repositories {
+		repo := &g.config.Repositories[i]
+		if repo.Guardrails != nil {
+			limits[repo.Name] = repo.GuardrailLimits(g.config.Guardrails)
+		}
+		for _, section := range repo.Sections {
+			owners[section.Name] = repo.Name
+		}
+	}
+	return limits, owners
+}
+
+func (g *Generator) writeGuardrailReport(violations []models.GuardrailViolation) error {
+	report := GuardrailReport{
+		GeneratedAt: time.Now().UTC(),
+		Violations:  violations,
+	}
+	if report.Violations == nil {
+		report.Violations = []models.GuardrailViolation{}
+	}
+	jb, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal guardrail report: %w", err)
+	}
+	root := g.finalRoot()
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return fmt.Errorf("%w: failed to create output directory: %w", herrors.ErrContentWriteFailed, err)
+	}
+	// #nosec G306 -- report contains repository names and sizes only
+	if err := os.WriteFile(filepath.Join(root, GuardrailReportJSON), jb, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write guardrail report: %w", herrors.ErrContentWriteFailed, err)
+	}
+	return nil
+}
+
+func guardrailAction(l *config.GuardrailsConfig) string {
+	if l.Fails() {
+		return string(config.GuardrailFail)
+	}
+	return string(config.GuardrailWarn)
+}
+
+// guardrailMessage describes a violation for logs and report issues.
+func guardrailMessage(v models.GuardrailViolation) string {
+	switch v.Limit {
+	case limitMaxPages:
+		return fmt.Sprintf("repository %s has %d pages, more than guardrails.max_pages (%d)",
+			v.Repository, v.Value, v.Threshold)
+	case limitMaxAssetSize:
+		return fmt.Sprintf("asset %s in repository %s is %s, larger than guardrails.max_asset_size_mb (%d MB)",
+			v.Path, v.Repository, formatMB(v.Value), v.Threshold/bytesPerMB)
+	default:
+		return fmt.Sprintf("repository %s has %s of content, more than guardrails.max_total_size_mb (%d MB)",
+			v.Repository, formatMB(v.Value), v.Threshold/bytesPerMB)
+	}
+}
+
+// docFileSize returns the size of a discovered file, using loaded content when present.
+func docFileSize(file *docs.DocFile) (int64, error) {
+	if file.Content != nil {
+		return int64(len(file.Content)), nil
+	}
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to stat %s: %w", herrors.ErrContentTransformFailed, file.Path, err)
+	}
+	return info.Size(), nil
+}
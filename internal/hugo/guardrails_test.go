@@ -0,0 +1,95 @@
+package hugo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+)
+
+func TestCheckGuardrails(t *testing.T) {
+	dir := t.TempDir()
+	diagram := filepath.Join(dir, "diagram.png")
+	if err := os.WriteFile(diagram, bytes.Repeat([]byte{0x89}, 2*bytesPerMB), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	page := func(repo, name string) docs.DocFile {
+		return docs.DocFile{Repository: repo, DocsBase: "docs", RelativePath: name + ".md", Content: []byte("# " + name)}
+	}
+	files := []docs.DocFile{
+		page("api", "a"), page("api", "b"), page("api-v2", "c"),
+		page("web", "d"),
+		{Repository: "web", DocsBase: "docs", RelativePath: "img/diagram.png", Path: diagram, IsAsset: true},
+	}
+
+	newGen := func(cfg *config.Config) (*Generator, string) {
+		out := t.TempDir()
+		cfg.Hugo = config.HugoConfig{Title: "Test", BaseURL: "/"}
+		return NewGenerator(cfg, out).WithRenderer(&stages.NoopRenderer{}), out
+	}
+
+	// "api-v2" is a section of "api", so api counts 3 pages; web's asset exceeds its override.
+	cfg := &config.Config{
+		Guardrails: &config.GuardrailsConfig{MaxPages: 2},
+		Repositories: []config.Repository{
+			{Name: "api", Sections: []config.RepositorySection{{Name: "api-v2", Path: "v2/docs"}}},
+			{Name: "web", Guardrails: &config.GuardrailsConfig{MaxAssetSizeMB: 1, Action: config.GuardrailWarn}},
+		},
+	}
+	gen, out := newGen(cfg)
+	report := models.NewBuildReport(t.Context(), 0, 0)
+	if err := gen.checkGuardrails(files, report); err != nil {
+		t.Fatalf("warn guardrails must not fail the build: %v", err)
+	}
+	want := []models.GuardrailViolation{
+		{Repository: "web", Limit: "max_asset_size_mb", Path: "docs/img/diagram.png", Value: 2 * bytesPerMB, Threshold: bytesPerMB, Action: "warn"},
+		{Repository: "api", Limit: "max_pages", Value: 3, Threshold: 2, Action: "warn"},
+	}
+	if len(report.GuardrailViolations) != len(want) {
+		t.Fatalf("violations = %+v", report.GuardrailViolations)
+	}
+	for i := range want {
+		if report.GuardrailViolations[i] != want[i] {
+			t.Fatalf("violation %d = %+v, want %+v", i, report.GuardrailViolations[i], want[i])
+		}
+	}
+	if len(report.Warnings) != 2 || len(report.Issues) != 2 || report.Issues[0].Code != models.IssueGuardrailExceeded {
+		t.Fatalf("expected two guardrail warnings, got %v / %+v", report.Warnings, report.Issues)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, GuardrailReportJSON))
+	if err != nil {
+		t.Fatalf("guardrail report not written: %v", err)
+	}
+	var persisted GuardrailReport
+	if err := json.Unmarshal(data, &persisted); err != nil || len(persisted.Violations) != 2 {
+		t.Fatalf("unexpected guardrail report %s (%v)", data, err)
+	}
+
+	cfg.Guardrails.Action = config.GuardrailFail
+	gen, _ = newGen(cfg)
+	report = models.NewBuildReport(t.Context(), 0, 0)
+	err = gen.checkGuardrails(files, report)
+	if !errors.Is(err, herrors.ErrGuardrailExceeded) {
+		t.Fatalf("expected guardrail failure, got %v", err)
+	}
+	if len(report.GuardrailViolations) != 2 || len(report.Warnings) != 1 {
+		t.Fatalf("web keeps its warn action: violations=%+v warnings=%v", report.GuardrailViolations, report.Warnings)
+	}
+
+	gen, out = newGen(&config.Config{})
+	if err := gen.checkGuardrails(files, nil); err != nil {
+		t.Fatalf("no guardrails configured: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, GuardrailReportJSON)); !os.IsNotExist(err) {
+		t.Fatalf("guardrail report written without guardrails: %v", err)
+	}
+}
@@ -101,7 +101,7 @@ func (g *Generator) generateMainIndex(docFiles []docs.DocFile) error {
 	// If a user-provided index already exists (e.g., README.md normalized to _index.md
 	// in single-repo/preview mode), do not overwrite it with the auto-generated landing page.
 	if st, err := os.Stat(indexPath); err == nil && !st.IsDir() {
-		slog.Info("Main index already exists; skipping generation", logfields.Path(indexPath))
+		g.log().Info("Main index already exists; skipping generation", logfields.Path(indexPath))
 		return nil
 	} else if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("stat main index at %s: %w", indexPath, err)
@@ -136,7 +136,7 @@ func (g *Generator) generateMainIndex(docFiles []docs.DocFile) error {
 	if err := os.WriteFile(indexPath, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("failed to write index at %s: %w", indexPath, err)
 	}
-	slog.Info("Generated main index page", logfields.Path(indexPath))
+	g.log().Info("Generated main index page", logfields.Path(indexPath))
 	return nil
 }
 
@@ -169,12 +169,13 @@ func (g *Generator) generateRepositoryIndexes(docFiles []docs.DocFile) error {
 		var readmeFile *docs.DocFile
 
 		for i := range files {
-			if files[i].Section == "" && files[i].Extension == ".md" {
-				if strings.EqualFold(files[i].Name, "index") {
-					userIndexFile = &files[i]
-				} else if strings.EqualFold(files[i].Name, "README") {
-					readmeFile = &files[i]
-				}
+			if !isRepositoryIndexCandidate(&files[i]) {
+				continue
+			}
+			if strings.EqualFold(files[i].Name, "index") {
+				userIndexFile = &files[i]
+			} else {
+				readmeFile = &files[i]
 			}
 		}
 
@@ -241,16 +242,23 @@ func (g *Generator) generateRepositoryIndexes(docFiles []docs.DocFile) error {
 		if err := os.WriteFile(indexPath, []byte(content), 0o644); err != nil {
 			return fmt.Errorf("failed to write repository index: %w", err)
 		}
-		slog.Debug("Generated repository index", logfields.Repository(repoName), logfields.Path(indexPath))
+		g.log().Debug("Generated repository index", logfields.Repository(repoName), logfields.Path(indexPath))
 	}
 	return nil
 }
 
+// isRepositoryIndexCandidate reports whether file is a root-level index.md or README.md,
+// which becomes the repository index.
+func isRepositoryIndexCandidate(file *docs.DocFile) bool {
+	return file.Section == "" && file.Extension == ".md" &&
+		(strings.EqualFold(file.Name, "index") || strings.EqualFold(file.Name, "README"))
+}
+
 // handleUserIndexFile processes user-provided index.md file for repository index.
 func (g *Generator) handleUserIndexFile(userIndexFile *docs.DocFile, indexPath, repoName string) error {
 	// Check if already written by copyContentFiles as _index.md
 	if _, err := os.Stat(indexPath); err == nil {
-		slog.Debug("Using user-provided index.md as repository index (already processed)",
+		g.log().Debug("Using user-provided index.md as repository index (already processed)",
 			logfields.Repository(repoName),
 			logfields.Path(indexPath))
 		return nil
@@ -260,7 +268,7 @@ func (g *Generator) handleUserIndexFile(userIndexFile *docs.DocFile, indexPath,
 		return err
 	}
 
-	slog.Debug("Using user-provided index.md as repository index",
+	g.log().Debug("Using user-provided index.md as repository index",
 		logfields.Repository(repoName),
 		logfields.Path(indexPath))
 	return nil
@@ -270,7 +278,7 @@ func (g *Generator) handleUserIndexFile(userIndexFile *docs.DocFile, indexPath,
 func (g *Generator) handleReadmeFile(readmeFile *docs.DocFile, indexPath, repoName string) error {
 	// Check if README was already written as _index.md by copyContentFiles
 	if _, err := os.Stat(indexPath); err == nil {
-		slog.Debug("Using README.md as repository index (already processed)",
+		g.log().Debug("Using README.md as repository index (already processed)",
 			logfields.Repository(repoName),
 			logfields.Path(indexPath))
 		return nil
@@ -281,7 +289,7 @@ func (g *Generator) handleReadmeFile(readmeFile *docs.DocFile, indexPath, repoNa
 		return err
 	}
 
-	slog.Debug("Using README.md as repository index",
+	g.log().Debug("Using README.md as repository index",
 		logfields.Repository(repoName),
 		logfields.Path(indexPath))
 	return nil
@@ -299,7 +307,7 @@ func (g *Generator) useReadmeAsIndex(readmeFile *docs.DocFile, indexPath, repoNa
 			herrors.ErrContentTransformFailed, readmeFile.Path)
 	}
 
-	slog.Debug("Using transformed README as index",
+	g.log().Debug("Using transformed README as index",
 		slog.String("source", readmeFile.RelativePath),
 		slog.String("index", indexPath),
 		slog.Int("bytes", len(readmeFile.TransformedBytes)))
@@ -348,7 +356,7 @@ func (g *Generator) useReadmeAsIndex(readmeFile *docs.DocFile, indexPath, repoNa
 	if readmeFile.Repository != "" && readmeFile.Name != "" && readmeFile.Extension != "" {
 		transformedPath := filepath.Join(g.BuildRoot(), "content", readmeFile.Repository, strings.ToLower(readmeFile.Name+readmeFile.Extension))
 		if err := os.Remove(transformedPath); err != nil && !os.IsNotExist(err) {
-			slog.Warn("Failed to remove original readme.md after promoting to _index.md", "path", transformedPath, "error", err)
+			g.log().Warn("Failed to remove original readme.md after promoting to _index.md", "path", transformedPath, "error", err)
 		}
 	}
 
@@ -429,7 +437,7 @@ func (g *Generator) generateSectionIndexesForRepo(repoName string, sections map[
 func (g *Generator) generateSectionIndex(repoName, sectionName string, files []docs.DocFile, allSections map[string]bool) error {
 	// Check if section should be skipped
 	if shouldSkip, reason := g.shouldSkipSectionIndex(files, sectionName); shouldSkip {
-		slog.Debug(reason, logfields.Repository(repoName), logfields.Section(sectionName))
+		g.log().Debug(reason, logfields.Repository(repoName), logfields.Section(sectionName))
 		return nil
 	}
 
@@ -454,7 +462,7 @@ func (g *Generator) generateSectionIndex(repoName, sectionName string, files []d
 	if err := os.WriteFile(indexPath, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("failed to write section index: %w", err)
 	}
-	slog.Debug("Generated section index", logfields.Repository(repoName), logfields.Section(sectionName), logfields.Path(indexPath))
+	g.log().Debug("Generated section index", logfields.Repository(repoName), logfields.Section(sectionName), logfields.Path(indexPath))
 	return nil
 }
 
@@ -579,7 +587,7 @@ func (g *Generator) generateIntermediateSectionIndex(repoName, sectionName strin
 	if err := os.WriteFile(indexPath, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("failed to write intermediate section index: %w", err)
 	}
-	slog.Debug("Generated intermediate section index", logfields.Repository(repoName), logfields.Section(sectionName), logfields.Path(indexPath))
+	g.log().Debug("Generated intermediate section index", logfields.Repository(repoName), logfields.Section(sectionName), logfields.Path(indexPath))
 	return nil
 }
 
@@ -622,7 +630,7 @@ func (g *Generator) loadIndexTemplate(kind string) (string, error) {
 		// #nosec G304 - p is from predefined template paths, base is controlled
 		b, err := os.ReadFile(p)
 		if err == nil {
-			slog.Debug("Loaded index template override", slog.String("kind", kind), logfields.Path(p))
+			g.log().Debug("Loaded index template override", slog.String("kind", kind), logfields.Path(p))
 			if g != nil && g.indexTemplateUsage != nil {
 				g.indexTemplateUsage[kind] = models.IndexTemplateInfo{Source: "file", Path: p}
 			}
@@ -0,0 +1,76 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+)
+
+// TestGenerateSite_ReadmeOnlyRepositoryIndex is a regression test: the README of a
+// repository without index.md must become its _index.md after the content pipeline
+// released its buffers.
+func TestGenerateSite_ReadmeOnlyRepositoryIndex(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		Hugo:   config.HugoConfig{Title: "Test", BaseURL: "/"},
+		Output: config.OutputConfig{Directory: outputDir},
+	}
+	gen := NewGenerator(cfg, outputDir)
+	files := []docs.DocFile{
+		{Repository: "alpha", Name: "README", Extension: ".md", RelativePath: "README.md", Content: []byte("# Alpha\n\nReadme body for alpha.\n")},
+		{Repository: "beta", Name: "guide", Extension: ".md", RelativePath: "guide.md", Content: []byte("# Guide\n")},
+	}
+
+	if err := gen.GenerateSite(files); err != nil {
+		t.Fatalf("GenerateSite: %v", err)
+	}
+
+	// #nosec G304 -- test output
+	index, err := os.ReadFile(filepath.Join(outputDir, "content", "alpha", "_index.md"))
+	if err != nil {
+		t.Fatalf("repository index missing: %v", err)
+	}
+	if !strings.Contains(string(index), "Readme body for alpha.") {
+		t.Fatalf("repository index does not use the README:\n%s", index)
+	}
+}
+
+// TestReadmeFallbackAfterContentCopy is a regression test: the content pipeline keeps
+// the transformed README bytes on the DocFile, so the README-as-index fallback works
+// when the repository index was not written by the pipeline.
+func TestReadmeFallbackAfterContentCopy(t *testing.T) {
+	gen := NewGenerator(&config.Config{Hugo: config.HugoConfig{Title: "Test", BaseURL: "/"}}, t.TempDir())
+	files := []docs.DocFile{
+		{Repository: "alpha", Name: "README", Extension: ".md", RelativePath: "README.md", Content: []byte("# Alpha\n\nReadme body for alpha.\n")},
+		{Repository: "beta", Name: "guide", Extension: ".md", RelativePath: "guide.md", Content: []byte("# Guide\n")},
+	}
+	if err := gen.copyContentFiles(t.Context(), files); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if len(files[0].TransformedBytes) == 0 {
+		t.Fatal("README transformed bytes were not kept")
+	}
+	if len(files[1].TransformedBytes) != 0 {
+		t.Fatal("transformed bytes kept for a regular page")
+	}
+
+	indexPath := filepath.Join(gen.BuildRoot(), "content", "alpha", "_index.md")
+	if err := os.Remove(indexPath); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if err := gen.handleReadmeFile(&files[0], indexPath, "alpha"); err != nil {
+		t.Fatalf("README fallback: %v", err)
+	}
+	// #nosec G304 -- test output
+	index, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(index), "Readme body for alpha.") {
+		t.Fatalf("repository index does not use the README:\n%s", index)
+	}
+}
@@ -2,10 +2,12 @@ package models
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
 	"git.home.luguber.info/inful/docbuilder/internal/metrics"
 	"git.home.luguber.info/inful/docbuilder/internal/state"
 )
@@ -25,6 +27,7 @@ type Generator interface {
 	Observer() BuildObserver
 	ExistingSiteValidForSkip() bool
 	Renderer() Renderer
+	Logger(c logging.Component) *slog.Logger
 }
 
 // GitState manages git repository operations and state tracking.
@@ -86,6 +89,18 @@ type DocsState struct {
 	FilesByRepo    map[string][]docs.DocFile
 	FilesBySection map[string][]docs.DocFile
 	IsSingleRepo   bool
+	// ExportPages lists the written pages of repositories exported to PDF/EPUB (export stage input).
+	ExportPages []ExportPage
+}
+
+// ExportPage is a content page written by the copy stage that belongs to an exported repository.
+type ExportPage struct {
+	Repository string // Site repository (or monorepo section) of the page
+	Source     string // Source file relative to the repository root (empty for generated pages)
+	Path       string // Content file relative to the build root (e.g. "content/repo/guide.md")
+	Title      string
+	Generated  bool
+	Index      bool // Section index page; the shortest one is the repository index
 }
 
 // BuildIndexes populates the repository and section indexes.
@@ -100,8 +115,8 @@ func (ds *DocsState) BuildIndexes() {
 	for i := range ds.Files {
 		file := &ds.Files[i]
 		repoKey := file.Repository
-		if file.Forge != "" {
-			repoKey = file.Forge + "/" + repoKey
+		if ns := file.Namespace(); ns != "" {
+			repoKey = ns + "/" + repoKey
 		}
 		ds.FilesByRepo[repoKey] = append(ds.FilesByRepo[repoKey], *file)
 
@@ -113,6 +128,11 @@ func (ds *DocsState) BuildIndexes() {
 	}
 }
 
+// RenderState records the outcome of the run_hugo stage for later stages.
+type RenderState struct {
+	Warnings []string // renderer warnings (hugo WARN lines)
+}
+
 // PipelineState tracks execution state and metadata across stages.
 type PipelineState struct {
 	ConfigHash string
@@ -127,6 +147,7 @@ type BuildState struct {
 	Git      GitState
 	Docs     DocsState
 	Pipeline PipelineState
+	Render   RenderState
 }
 
 // NewBuildState constructs a BuildState with sub-state initialization.
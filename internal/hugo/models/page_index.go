@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PageIndexFile lists the rendered pages of the last build. It is written to the output
+// root next to build-report.json and backs the page suggestions of the docs server's 404 page.
+const PageIndexFile = "page-index.json"
+
+// PageIndex is the page list of a rendered site.
+type PageIndex struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Pages       []PageIndexEntry `json:"pages"`
+}
+
+// PageIndexEntry is a rendered page: its site path ("/guide/install/") and title.
+type PageIndexEntry struct {
+	Path  string `json:"path"`
+	Title string `json:"title,omitempty"`
+}
@@ -19,3 +19,10 @@ import (
 type Renderer interface {
 	Execute(ctx context.Context, rootDir string) error
 }
+
+// WarningRenderer is a Renderer that reports the warnings of its last Execute call
+// (for the hugo binary, its WARN log lines).
+type WarningRenderer interface {
+	Renderer
+	Warnings() []string
+}
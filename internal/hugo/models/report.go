@@ -45,10 +45,15 @@ func ParseHugoVersion(output string) string {
 	return ""
 }
 
+// ReportSchemaVersion is the version of the build report JSON schema (BuildReportSerializable).
+// Fields may be added within a version; renaming, removing or changing the meaning of a
+// field requires a new version.
+const ReportSchemaVersion = 1
+
 // NewBuildReport constructs a new BuildReport.
 func NewBuildReport(ctx context.Context, repos, files int) *BuildReport {
 	return &BuildReport{
-		SchemaVersion:     1,
+		SchemaVersion:     ReportSchemaVersion,
 		Repositories:      repos,
 		Files:             files,
 		Start:             time.Now(),
@@ -98,6 +103,36 @@ type BuildReport struct {
 	SkipReason string
 	// IndexTemplates records which source was used for each index template kind (main, repository, section)
 	IndexTemplates map[string]IndexTemplateInfo
+	// TransformWorkers records per-worker load of the parallel content transform pipeline.
+	TransformWorkers []TransformWorkerTiming
+	// UnresolvedLinks lists relative links whose target page or anchor is not part of the site.
+	UnresolvedLinks []UnresolvedLink
+	// DuplicateAnchors lists headings whose anchor is already used by an earlier heading of the page.
+	DuplicateAnchors []DuplicateAnchor
+	// InvalidDates lists source front matter dates that could not be parsed and were dropped.
+	InvalidDates []InvalidDate
+	// GuardrailViolations lists repositories that exceeded a configured content guardrail.
+	GuardrailViolations []GuardrailViolation
+	// OutputProblems lists pages and sections the validate_output stage found missing or empty,
+	// and the hugo warnings it recorded.
+	OutputProblems []OutputProblem
+	// Accessibility summarizes the check_accessibility stage (nil when it did not run).
+	Accessibility *AccessibilitySummary
+	// Exports lists the PDF/EPUB downloads rendered for repositories (including failed ones).
+	Exports []ExportArtifact
+	// Archive is the offline site archive written by post-processing (empty when disabled).
+	Archive string
+	// ChangedURLs lists the sitemap URLs whose page differs from the previously published site
+	// (added, modified or removed). Only computed when purge hooks are configured.
+	ChangedURLs []string
+	// Purges records the CDN purge hook runs made after the site was published.
+	Purges []PurgeResult
+	// Hooks records the pipeline hook commands run during the build, with their output.
+	Hooks []HookRun
+	// Versions aggregates per-version results when versioning expanded repositories into multiple builds.
+	Versions []VersionBuild
+	// Commits records the exact commit each repository was built from.
+	Commits []RepositoryCommit
 	// CloneStageSkipped is true when the pipeline did not include the clone_repos stage (direct generation path)
 	// and false when the clone stage was part of the pipeline (even if it processed zero repositories).
 	CloneStageSkipped bool
@@ -154,6 +189,10 @@ const (
 	IssueRemoteDiverged    ReportIssueCode = "REMOTE_DIVERGED"
 	IssueRateLimit         ReportIssueCode = "RATE_LIMIT"
 	IssueNetworkTimeout    ReportIssueCode = "NETWORK_TIMEOUT"
+	IssueGuardrailExceeded ReportIssueCode = "GUARDRAIL_EXCEEDED"
+	IssueCacheCorrupted    ReportIssueCode = "CACHE_CORRUPTED"
+	IssueOutputValidation  ReportIssueCode = "OUTPUT_VALIDATION"
+	IssueAccessibility     ReportIssueCode = "ACCESSIBILITY"
 )
 
 // IssueSeverity represents normalized severity levels.
@@ -179,6 +218,123 @@ type IndexTemplateInfo struct {
 	Path   string `json:"path,omitempty"`
 }
 
+// TransformWorkerTiming captures how many documents one transform worker processed and its busy time.
+type TransformWorkerTiming struct {
+	Worker    int           `json:"worker"`
+	Documents int           `json:"documents"`
+	Busy      time.Duration `json:"busy"`
+}
+
+// UnresolvedLink is a relative link in a source page that does not resolve to a page
+// (or heading anchor) of the generated site.
+type UnresolvedLink struct {
+	Repository string `json:"repository,omitempty"`
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	Reason     string `json:"reason"`
+}
+
+// DuplicateAnchor is a heading whose anchor collides with an earlier heading of the same
+// page. Hugo numbers the second anchor ("setup-1"), so deep links land on the first heading.
+type DuplicateAnchor struct {
+	Repository string `json:"repository,omitempty"`
+	Source     string `json:"source"`
+	Line       int    `json:"line"`
+	FirstLine  int    `json:"first_line"`
+	Anchor     string `json:"anchor"`
+	Suggested  string `json:"suggested"`
+}
+
+// InvalidDate is a source front matter date DocBuilder could not parse. The key is
+// dropped from the page instead of failing the Hugo render.
+type InvalidDate struct {
+	Repository string `json:"repository,omitempty"`
+	Source     string `json:"source"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+}
+
+// ExportArtifact is a downloadable PDF/EPUB rendering of a repository.
+type ExportArtifact struct {
+	Repository string `json:"repository"`
+	Format     string `json:"format"`
+	Path       string `json:"path"`            // Site path, e.g. /downloads/repo.pdf
+	Pages      int    `json:"pages"`           // Pages included in the document
+	Bytes      int64  `json:"bytes,omitempty"` // Size of the rendered file
+	Error      string `json:"error,omitempty"` // Render failure (the download is missing)
+}
+
+// HookRun is one pipeline hook command run.
+type HookRun struct {
+	Point    string        `json:"point"` // pre_build | post_clone | pre_hugo | post_build
+	Name     string        `json:"name"`
+	Command  string        `json:"command"`
+	ExitCode int           `json:"exit_code"` // -1 when the command did not start or was killed
+	Duration time.Duration `json:"duration"`
+	Output   string        `json:"output,omitempty"` // Combined stdout and stderr (tail, truncated)
+	Error    string        `json:"error,omitempty"`
+}
+
+// PurgeResult is the outcome of one CDN purge hook run.
+type PurgeResult struct {
+	Hook     string        `json:"hook"`
+	Type     string        `json:"type"`
+	URLs     int           `json:"urls"`     // URLs purged (or that would be, in a dry run)
+	Requests int           `json:"requests"` // API requests made (or planned)
+	DryRun   bool          `json:"dry_run,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// GuardrailViolation records a repository exceeding a content guardrail.
+type GuardrailViolation struct {
+	Repository string `json:"repository"`
+	Limit      string `json:"limit"`          // max_pages | max_total_size_mb | max_asset_size_mb
+	Path       string `json:"path,omitempty"` // offending asset (max_asset_size_mb only)
+	Value      int64  `json:"value"`          // pages or bytes
+	Threshold  int64  `json:"threshold"`      // pages or bytes
+	Action     string `json:"action"`         // warn | fail
+}
+
+// OutputProblem records a problem the validate_output stage found in the rendered site.
+type OutputProblem struct {
+	Check  string `json:"check"`            // missing_pages | empty_pages | missing_sections | hugo_warnings
+	Path   string `json:"path,omitempty"`   // content file or directory relative to the build root
+	Output string `json:"output,omitempty"` // expected HTML file relative to public/
+	Detail string `json:"detail,omitempty"`
+	Action string `json:"action"` // warn | fail
+}
+
+// AccessibilitySummary totals the violations the check_accessibility stage found. The
+// per-page findings are written to accessibility-report.json.
+type AccessibilitySummary struct {
+	PagesChecked        int            `json:"pages_checked"`
+	PagesWithViolations int            `json:"pages_with_violations"`
+	Errors              int            `json:"errors"`
+	Warnings            int            `json:"warnings"`
+	Rules               map[string]int `json:"rules,omitempty"` // rule -> violations
+	Failed              bool           `json:"failed,omitempty"`
+}
+
+// RepositoryCommit records the commit a repository was built from.
+type RepositoryCommit struct {
+	Repository string `json:"repository"`
+	URL        string `json:"url,omitempty"`
+	Ref        string `json:"ref,omitempty"` // branch or tag fetched
+	Commit     string `json:"commit"`
+	Pinned     bool   `json:"pinned,omitempty"` // commit pinned by config, snapshot or build request
+}
+
+// VersionBuild summarizes the result of building one version of a repository.
+type VersionBuild struct {
+	Repository string `json:"repository"` // base repository name
+	Version    string `json:"version"`
+	Ref        string `json:"ref"` // branch or tag checked out
+	Tag        bool   `json:"tag,omitempty"`
+	Cloned     bool   `json:"cloned"`
+	Files      int    `json:"files"`
+}
+
 // StageCount aggregates counts of outcomes for a stage.
 type StageCount struct {
 	Success  int
@@ -326,6 +482,20 @@ func (r *BuildReport) SanitizedCopy() *BuildReportSerializable {
 		Issues:              r.Issues,
 		SkipReason:          r.SkipReason,
 		IndexTemplates:      r.IndexTemplates,
+		TransformWorkers:    r.TransformWorkers,
+		UnresolvedLinks:     r.UnresolvedLinks,
+		DuplicateAnchors:    r.DuplicateAnchors,
+		InvalidDates:        r.InvalidDates,
+		GuardrailViolations: r.GuardrailViolations,
+		OutputProblems:      r.OutputProblems,
+		Accessibility:       r.Accessibility,
+		Exports:             r.Exports,
+		Archive:             r.Archive,
+		ChangedURLs:         r.ChangedURLs,
+		Purges:              r.Purges,
+		Hooks:               r.Hooks,
+		Versions:            r.Versions,
+		Commits:             r.Commits,
 		CloneStageSkipped:   r.CloneStageSkipped,
 		DocFilesHash:        r.DocFilesHash,
 		DeltaDecision:       r.DeltaDecision,
@@ -346,7 +516,21 @@ func (r *BuildReport) SanitizedCopy() *BuildReportSerializable {
 	return s
 }
 
+// DecodeReport parses a build report document, rejecting schema versions newer than
+// this binary understands.
+func DecodeReport(data []byte) (*BuildReportSerializable, error) {
+	var report BuildReportSerializable
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("decode build report: %w", err)
+	}
+	if report.SchemaVersion < 1 || report.SchemaVersion > ReportSchemaVersion {
+		return nil, fmt.Errorf("unsupported build report schema version %d (supported: 1-%d)", report.SchemaVersion, ReportSchemaVersion)
+	}
+	return &report, nil
+}
+
 // BuildReportSerializable mirrors BuildReport but with string errors for JSON output.
+// It is the stable JSON schema of build-report.json and of reports stored in the event store.
 type BuildReportSerializable struct {
 	SchemaVersion       int                          `json:"schema_version"`
 	Repositories        int                          `json:"repositories"`
@@ -369,6 +553,20 @@ type BuildReportSerializable struct {
 	Issues              []ReportIssue                `json:"issues"`
 	SkipReason          string                       `json:"skip_reason,omitempty"`
 	IndexTemplates      map[string]IndexTemplateInfo `json:"index_templates,omitempty"`
+	TransformWorkers    []TransformWorkerTiming      `json:"transform_workers,omitempty"`
+	UnresolvedLinks     []UnresolvedLink             `json:"unresolved_links,omitempty"`
+	DuplicateAnchors    []DuplicateAnchor            `json:"duplicate_anchors,omitempty"`
+	InvalidDates        []InvalidDate                `json:"invalid_dates,omitempty"`
+	GuardrailViolations []GuardrailViolation         `json:"guardrail_violations,omitempty"`
+	OutputProblems      []OutputProblem              `json:"output_problems,omitempty"`
+	Accessibility       *AccessibilitySummary        `json:"accessibility,omitempty"`
+	Exports             []ExportArtifact             `json:"exports,omitempty"`
+	Archive             string                       `json:"archive,omitempty"`
+	ChangedURLs         []string                     `json:"changed_urls,omitempty"`
+	Purges              []PurgeResult                `json:"purges,omitempty"`
+	Hooks               []HookRun                    `json:"hooks,omitempty"`
+	Versions            []VersionBuild               `json:"versions,omitempty"`
+	Commits             []RepositoryCommit           `json:"commits,omitempty"`
 	CloneStageSkipped   bool                         `json:"clone_stage_skipped,omitempty"`
 	DocFilesHash        string                       `json:"doc_files_hash,omitempty"`
 	DeltaDecision       string                       `json:"delta_decision,omitempty"`
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecodeReport_SchemaVersion(t *testing.T) {
+	report := NewBuildReport(t.Context(), 1, 3)
+	report.DeriveOutcome()
+	report.Finish()
+	data, err := json.Marshal(report.SanitizedCopy())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded, err := DecodeReport(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.SchemaVersion != ReportSchemaVersion || decoded.Files != 3 || decoded.Outcome != string(OutcomeSuccess) {
+		t.Fatalf("unexpected round trip: %+v", decoded)
+	}
+
+	if _, err := DecodeReport([]byte(`{"schema_version": 99}`)); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Fatalf("expected newer schema to be rejected, got %v", err)
+	}
+	if _, err := DecodeReport([]byte(`{"files": 3}`)); err == nil {
+		t.Fatal("expected document without schema_version to be rejected")
+	}
+}
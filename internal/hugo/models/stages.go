@@ -17,13 +17,20 @@ type StageName string
 // Canonical stage names.
 const (
 	StagePrepareOutput  StageName = "prepare_output"
+	StagePreBuildHooks  StageName = "pre_build_hooks"
 	StageCloneRepos     StageName = "clone_repos"
+	StagePostCloneHooks StageName = "post_clone_hooks"
+	StageCodeDocs       StageName = "code_docs"
 	StageDiscoverDocs   StageName = "discover_docs"
 	StageGenerateConfig StageName = "generate_config"
 	StageLayouts        StageName = "layouts"
 	StageCopyContent    StageName = "copy_content"
 	StageIndexes        StageName = "indexes"
+	StageExport         StageName = "export"
+	StagePreHugoHooks   StageName = "pre_hugo_hooks"
 	StageRunHugo        StageName = "run_hugo"
+	StageValidateOutput StageName = "validate_output"
+	StageAccessibility  StageName = "check_accessibility"
 	StagePostProcess    StageName = "post_process"
 )
 
@@ -73,7 +80,8 @@ func (e *StageError) Transient() bool {
 		if isSentinel(ErrDiscovery) {
 			return e.Kind == StageErrorWarning
 		}
-	case StagePrepareOutput, StageGenerateConfig, StageLayouts, StageCopyContent, StageIndexes, StagePostProcess:
+	case StagePrepareOutput, StageCodeDocs, StageGenerateConfig, StageLayouts, StageCopyContent, StageIndexes, StageExport, StagePostProcess,
+		StagePreBuildHooks, StagePostCloneHooks, StagePreHugoHooks, StageValidateOutput, StageAccessibility:
 		return false
 	}
 	return false
@@ -94,11 +94,11 @@ func (g *Generator) addGoVersionToExisting(goModPath, existingContent string) er
 	newContent := strings.Join(newLines, "\n")
 	// #nosec G306 -- go.mod is a module configuration file
 	if err := os.WriteFile(goModPath, []byte(newContent), 0o644); err != nil {
-		slog.Warn("Failed to add go version to go.mod", "error", err)
+		g.log().Warn("Failed to add go version to go.mod", "error", err)
 		return g.ensureThemeVersionRequires(goModPath)
 	}
 
-	slog.Debug("Added go version directive to existing go.mod", logfields.Path(goModPath))
+	g.log().Debug("Added go version directive to existing go.mod", logfields.Path(goModPath))
 	return g.ensureThemeVersionRequires(goModPath)
 }
 
@@ -119,11 +119,11 @@ func (g *Generator) sanitizeGoMod(goModPath string, lines []string) error {
 
 	// #nosec G306 -- go.mod is a module configuration file
 	if writeErr := os.WriteFile(goModPath, []byte(newContent), 0o644); writeErr != nil {
-		slog.Warn("Failed to rewrite invalid go.mod module line", "error", writeErr)
+		g.log().Warn("Failed to rewrite invalid go.mod module line", "error", writeErr)
 		return g.ensureThemeVersionRequires(goModPath)
 	}
 
-	slog.Debug("Rewrote go.mod with sanitized module name", logfields.Path(goModPath), slog.String("module", sanitized))
+	g.log().Debug("Rewrote go.mod with sanitized module name", logfields.Path(goModPath), slog.String("module", sanitized))
 	return g.ensureThemeVersionRequires(goModPath)
 }
 
@@ -137,7 +137,7 @@ func (g *Generator) createNewGoMod(goModPath string) error {
 		return fmt.Errorf("failed to write go.mod: %w", err)
 	}
 
-	slog.Debug("Created go.mod for Hugo Modules", logfields.Path(goModPath))
+	g.log().Debug("Created go.mod for Hugo Modules", logfields.Path(goModPath))
 	return g.ensureThemeVersionRequires(goModPath)
 }
 
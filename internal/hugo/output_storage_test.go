@@ -0,0 +1,51 @@
+package hugo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
+)
+
+// recordingStorage delegates to the local filesystem and records promotions.
+type recordingStorage struct {
+	output.Local
+	mkdirs  int
+	renames [][2]string
+}
+
+func (r *recordingStorage) MkdirAll(path string) error {
+	r.mkdirs++
+	return r.Local.MkdirAll(path)
+}
+
+func (r *recordingStorage) Rename(from, to string) error {
+	r.renames = append(r.renames, [2]string{from, to})
+	return r.Local.Rename(from, to)
+}
+
+func TestGenerator_PublishesThroughStorage(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "site")
+	storage := &recordingStorage{}
+	gen := NewGenerator(&config.Config{Hugo: config.HugoConfig{Title: "Test", BaseURL: "/"}}, outDir).
+		WithRenderer(&stages.NoopRenderer{}).
+		WithStorage(storage)
+
+	files := []docs.DocFile{{Repository: "repo", Name: "page", RelativePath: "page.md", DocsBase: "docs", Extension: ".md", Content: []byte("# Page\n")}}
+	if err := gen.GenerateSite(files); err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	if storage.mkdirs == 0 {
+		t.Fatal("expected output structure to be created through storage")
+	}
+	if len(storage.renames) != 1 || storage.renames[0] != [2]string{outDir + "_stage", outDir} {
+		t.Fatalf("expected staging promotion through storage, got %v", storage.renames)
+	}
+	if _, err := storage.Stat(filepath.Join(outDir, "content", "page.md")); err != nil {
+		t.Fatalf("expected promoted content: %v", err)
+	}
+}
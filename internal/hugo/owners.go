@@ -0,0 +1,116 @@
+package hugo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+// OwnershipMapFile is the section -> owners map written to the output root.
+const OwnershipMapFile = "owners.json"
+
+// OwnershipMap maps site sections to the teams owning their content.
+type OwnershipMap struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Sections    []SectionOwners `json:"sections"`
+}
+
+// SectionOwners lists the owners of every page within one site section.
+type SectionOwners struct {
+	Section    string   `json:"section"`
+	Repository string   `json:"repository,omitempty"`
+	Owners     []string `json:"owners"`
+}
+
+// Lookup returns the most specific section containing the site path, or nil.
+func (m *OwnershipMap) Lookup(sitePath string) *SectionOwners {
+	sitePath = "/" + strings.Trim(sitePath, "/") + "/"
+	if sitePath == "//" {
+		sitePath = "/"
+	}
+	var best *SectionOwners
+	for i := range m.Sections {
+		s := &m.Sections[i]
+		if strings.HasPrefix(sitePath, s.Section) && (best == nil || len(s.Section) > len(best.Section)) {
+			best = s
+		}
+	}
+	return best
+}
+
+// writeOwnershipMap aggregates page owners per section and persists the map.
+// Nothing is written when no page carries owner metadata.
+func (g *Generator) writeOwnershipMap(processed []*pipeline.Document) error {
+	bySection := make(map[string]*SectionOwners)
+	for _, doc := range processed {
+		owners := frontMatterOwners(doc.FrontMatter["owners"])
+		if len(owners) == 0 {
+			continue
+		}
+		section := pipeline.ContentURL(doc.Path)
+		if !doc.IsIndex {
+			section = path.Dir(strings.TrimSuffix(section, "/"))
+			if section != "/" {
+				section += "/"
+			}
+		}
+		entry, ok := bySection[section]
+		if !ok {
+			entry = &SectionOwners{Section: section, Repository: doc.Repository}
+			bySection[section] = entry
+		}
+		for _, o := range owners {
+			if !slices.Contains(entry.Owners, o) {
+				entry.Owners = append(entry.Owners, o)
+			}
+		}
+	}
+	if len(bySection) == 0 {
+		return nil
+	}
+
+	m := OwnershipMap{GeneratedAt: time.Now().UTC()}
+	for _, entry := range bySection {
+		m.Sections = append(m.Sections, *entry)
+	}
+	sort.Slice(m.Sections, func(i, j int) bool { return m.Sections[i].Section < m.Sections[j].Section })
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal ownership map: %w", err)
+	}
+	// #nosec G306 -- ownership map contains public page metadata only
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), OwnershipMapFile), b, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write ownership map: %w", herrors.ErrContentWriteFailed, err)
+	}
+	return nil
+}
+
+// frontMatterOwners normalizes an "owners" front matter value (string or list) to a string slice.
+func frontMatterOwners(v any) []string {
+	switch owners := v.(type) {
+	case string:
+		return strings.Fields(owners)
+	case []string:
+		return owners
+	case []any:
+		out := make([]string, 0, len(owners))
+		for _, o := range owners {
+			if s, ok := o.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, strings.TrimSpace(s))
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+package hugo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+func TestWriteOwnershipMap(t *testing.T) {
+	out := t.TempDir()
+	g := NewGenerator(&config.Config{}, out)
+	processed := []*pipeline.Document{
+		{Path: "content/repo/_index.md", IsIndex: true, Repository: "repo", FrontMatter: map[string]any{"owners": []string{"@org/writers"}}},
+		{Path: "content/repo/api/users.md", Repository: "repo", FrontMatter: map[string]any{"owners": []string{"@org/api-team"}}},
+		{Path: "content/repo/api/groups.md", Repository: "repo", FrontMatter: map[string]any{"owners": []any{"@org/api-team", "@alice"}}},
+		{Path: "content/repo/misc.md", Repository: "repo", FrontMatter: map[string]any{}},
+	}
+	if err := g.writeOwnershipMap(processed); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	b, err := os.ReadFile(filepath.Join(g.BuildRoot(), OwnershipMapFile))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var m OwnershipMap
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(m.Sections) != 2 {
+		t.Fatalf("expected 2 sections, got %+v", m.Sections)
+	}
+	if s := m.Lookup("/repo/api/users/"); s == nil || s.Section != "/repo/api/" || len(s.Owners) != 2 {
+		t.Fatalf("unexpected api section: %+v", s)
+	}
+	if s := m.Lookup("/repo/misc/"); s == nil || s.Section != "/repo/" {
+		t.Fatalf("unexpected fallback section: %+v", s)
+	}
+	if s := m.Lookup("/other/"); s != nil {
+		t.Fatalf("expected no owners for unrelated path, got %+v", s)
+	}
+}
@@ -0,0 +1,261 @@
+package pipeline
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docmodel"
+)
+
+// adrStatusUnknown is the status of ADRs that do not declare one.
+const adrStatusUnknown = "Unknown"
+
+// adrRecord is an architecture decision record listed in the site-wide ADR index.
+type adrRecord struct {
+	ID         string // e.g. "ADR-008"; empty when neither title nor file name is numbered
+	Title      string
+	Status     string // first word of the declared status, title-cased
+	Date       string // YYYY-MM-DD when parseable, otherwise as written
+	Repository string
+	URL        string
+	sortKey    string
+}
+
+var (
+	adrTitleIDPattern = regexp.MustCompile(`(?i)^(adr)[-_ ]?(\d+)\s*[:.\-–—]?\s*(.*)$`)
+	adrFileIDPattern  = regexp.MustCompile(`(?i)^(?:adr[-_]?)?(\d+)\b`)
+	// adrFieldPattern matches "Status: Accepted", "**Status**: Accepted" and "* Status: Accepted".
+	adrFieldPattern = regexp.MustCompile(`(?i)^[\s*_\-]*(status|date)[\s*_]*:[\s*_]*(.*?)[\s*_]*$`)
+)
+
+// generateADRIndex creates a site-wide "Architecture Decisions" section listing the ADRs of
+// all repositories, with one filtered page per status and per repository. Enabled by adr_index.
+func generateADRIndex(ctx *GenerationContext) ([]*Document, error) {
+	if ctx.Config == nil || !ctx.Config.ADRIndex.IsEnabled() {
+		return nil, nil
+	}
+	cfg := ctx.Config.ADRIndex
+
+	var records []adrRecord
+	for _, doc := range ctx.Discovered {
+		if doc.IsIndex || doc.Generated || doc.Repository == "" || !cfg.Matches(doc.RepoRelativePath()) {
+			continue
+		}
+		records = append(records, extractADR(doc))
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Repository != records[j].Repository {
+			return records[i].Repository < records[j].Repository
+		}
+		return records[i].sortKey < records[j].sortKey
+	})
+
+	section := cfg.SectionName()
+	base := "/" + strings.ToLower(section) + "/"
+	byStatus := groupADRs(records, func(r adrRecord) string { return r.Status })
+	byRepo := groupADRs(records, func(r adrRecord) string { return r.Repository })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", cfg.IndexTitle())
+	fmt.Fprintf(&b, "%d decisions from %d repositories.\n\n", len(records), len(byRepo.keys))
+	fmt.Fprintf(&b, "**Status:** %s\n\n", adrFilterLinks(byStatus, base+"status/"))
+	fmt.Fprintf(&b, "**Repository:** %s\n\n", adrFilterLinks(byRepo, base+"repositories/"))
+	writeADRTable(&b, records)
+
+	newPage := func(dir, title, content string, weight int) *Document {
+		doc := &Document{
+			Path:      path.Join("content", section, dir, "_index.md"),
+			IsIndex:   true,
+			Generated: true,
+			Section:   path.Join(section, dir),
+			Content:   content,
+			FrontMatter: map[string]any{
+				"title": title,
+				"type":  "docs",
+			},
+		}
+		if weight != 0 {
+			doc.FrontMatter["weight"] = weight
+		}
+		if ctx.Config.IsDaemonPublicOnlyEnabled() {
+			doc.FrontMatter["public"] = true
+		}
+		return doc
+	}
+
+	generated := []*Document{newPage("", cfg.IndexTitle(), b.String(), 0)}
+	for _, g := range []struct {
+		dir, title string
+		groups     adrGroups
+	}{
+		{"status", "By status", byStatus},
+		{"repositories", "By repository", byRepo},
+	} {
+		generated = append(generated, newPage(g.dir, g.title,
+			fmt.Sprintf("# %s\n\n%s\n", g.title, adrFilterLinks(g.groups, base+g.dir+"/")), 0))
+		for i, key := range g.groups.keys {
+			var page strings.Builder
+			fmt.Fprintf(&page, "# %s\n\n", key)
+			writeADRTable(&page, g.groups.records[key])
+			generated = append(generated, newPage(path.Join(g.dir, adrSlug(key)), key, page.String(), i+1))
+		}
+	}
+	return generated, nil
+}
+
+// extractADR reads the ID, title, status and date of an ADR from its front matter,
+// falling back to its first heading and "Status:"/"Date:" lines (or "## Status" sections).
+func extractADR(doc *Document) adrRecord {
+	rec := adrRecord{Repository: doc.Repository, URL: ContentURL(doc.Path), sortKey: doc.RepoRelativePath()}
+
+	body := doc.Content
+	fm := map[string]any{}
+	if parsed, err := docmodel.Parse([]byte(doc.Content), docmodel.Options{}); err == nil {
+		body = string(parsed.Body())
+		if fields, ferr := parsed.FrontmatterFields(); ferr == nil {
+			fm = fields
+		}
+	}
+	rec.Title = frontMatterString(fm["title"])
+	rec.Status = frontMatterString(fm["status"])
+	rec.Date = frontMatterString(fm["date"])
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if rec.Title == "" && strings.HasPrefix(trimmed, "# ") {
+			rec.Title = strings.TrimSpace(trimmed[2:])
+			continue
+		}
+		if m := adrFieldPattern.FindStringSubmatch(trimmed); m != nil {
+			if strings.EqualFold(m[1], "status") && rec.Status == "" {
+				rec.Status = m[2]
+			} else if strings.EqualFold(m[1], "date") && rec.Date == "" {
+				rec.Date = m[2]
+			}
+			continue
+		}
+		// MADR/adr-tools: "## Status" followed by the status paragraph.
+		if rec.Status == "" && strings.HasPrefix(trimmed, "#") && strings.EqualFold(strings.TrimSpace(strings.TrimLeft(trimmed, "#")), "status") {
+			for _, next := range lines[i+1:] {
+				if next = strings.TrimSpace(next); next != "" {
+					if !strings.HasPrefix(next, "#") {
+						rec.Status = next
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if m := adrTitleIDPattern.FindStringSubmatch(rec.Title); m != nil {
+		rec.ID = "ADR-" + m[2]
+		if m[3] != "" {
+			rec.Title = m[3]
+		}
+	} else if m := adrFileIDPattern.FindStringSubmatch(path.Base(doc.RepoRelativePath())); m != nil {
+		rec.ID = "ADR-" + m[1]
+	}
+	if rec.Title == "" {
+		rec.Title = titleCase(strings.TrimSuffix(path.Base(doc.RepoRelativePath()), path.Ext(doc.RepoRelativePath())))
+	}
+	rec.Status = normalizeADRStatus(rec.Status)
+	rec.Date = normalizeADRDate(rec.Date)
+	return rec
+}
+
+// frontMatterString returns a front matter scalar as a string (dates as YYYY-MM-DD).
+func frontMatterString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case time.Time:
+		return t.Format("2006-01-02")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// normalizeADRStatus reduces a status such as "Superseded by ADR-12" to "Superseded".
+func normalizeADRStatus(s string) string {
+	s = strings.Trim(s, " *_`[]")
+	word := strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-')
+	})
+	if len(word) == 0 {
+		return adrStatusUnknown
+	}
+	w := strings.ToLower(word[0])
+	return strings.ToUpper(w[:1]) + w[1:]
+}
+
+// normalizeADRDate returns the leading YYYY-MM-DD of a date, or the date as written.
+func normalizeADRDate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 10 {
+		if _, err := time.Parse("2006-01-02", s[:10]); err == nil {
+			return s[:10]
+		}
+	}
+	return s
+}
+
+// adrGroups groups ADR records by a key in sorted key order.
+type adrGroups struct {
+	keys    []string
+	records map[string][]adrRecord
+}
+
+func groupADRs(records []adrRecord, key func(adrRecord) string) adrGroups {
+	g := adrGroups{records: make(map[string][]adrRecord)}
+	for _, r := range records {
+		k := key(r)
+		if _, ok := g.records[k]; !ok {
+			g.keys = append(g.keys, k)
+		}
+		g.records[k] = append(g.records[k], r)
+	}
+	sort.Strings(g.keys)
+	return g
+}
+
+// adrFilterLinks renders links to the per-key pages with their record counts.
+func adrFilterLinks(g adrGroups, base string) string {
+	links := make([]string, 0, len(g.keys))
+	for _, k := range g.keys {
+		links = append(links, fmt.Sprintf("[%s (%d)](%s%s/)", k, len(g.records[k]), base, adrSlug(k)))
+	}
+	return strings.Join(links, " · ")
+}
+
+func writeADRTable(b *strings.Builder, records []adrRecord) {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	b.WriteString("| ADR | Title | Status | Date | Repository |\n")
+	b.WriteString("|-----|-------|--------|------|------------|\n")
+	for _, r := range records {
+		fmt.Fprintf(b, "| %s | [%s](%s) | %s | %s | %s |\n",
+			orDash(r.ID), escapeTableCell(r.Title), r.URL, r.Status, orDash(escapeTableCell(r.Date)), r.Repository)
+	}
+}
+
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func adrSlug(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", "-"))
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGenerateADRIndex(t *testing.T) {
+	ctx := &GenerationContext{
+		Config: &config.Config{ADRIndex: &config.ADRIndexConfig{Enabled: true}},
+		Discovered: []*Document{
+			{
+				Repository: "api", Path: "content/api/adr/adr-002-use-nats.md", DocsBase: "docs", RelativePath: "adr/adr-002-use-nats.md",
+				Content: "---\ntitle: Use NATS for events\nstatus: superseded by ADR-005\ndate: 2025-03-01\n---\n\nBody\n",
+			},
+			{
+				Repository: "api", Path: "content/api/adr/adr-001-staged.md", DocsBase: "docs", RelativePath: "adr/adr-001-staged.md",
+				Content: "# ADR-001: Staged Pipeline\n\n**Status**: Accepted  \n**Date**: 2026-01-14  \n",
+			},
+			{
+				Repository: "web", Path: "content/web/decisions/0003-react.md", DocsBase: "docs", RelativePath: "decisions/0003-react.md",
+				Content: "# Use React\n\n## Status\n\nProposed\n\n## Context\n",
+			},
+			{Repository: "web", Path: "content/web/adr/_index.md", DocsBase: "docs", RelativePath: "adr/_index.md", IsIndex: true},
+			{Repository: "web", Path: "content/web/guide.md", DocsBase: "docs", RelativePath: "guide.md", Content: "**Status**: Draft"},
+		},
+	}
+
+	docs, err := generateADRIndex(ctx)
+	require.NoError(t, err)
+	paths := make([]string, 0, len(docs))
+	for _, d := range docs {
+		paths = append(paths, d.Path)
+	}
+	assert.Equal(t, []string{
+		"content/architecture-decisions/_index.md",
+		"content/architecture-decisions/status/_index.md",
+		"content/architecture-decisions/status/accepted/_index.md",
+		"content/architecture-decisions/status/proposed/_index.md",
+		"content/architecture-decisions/status/superseded/_index.md",
+		"content/architecture-decisions/repositories/_index.md",
+		"content/architecture-decisions/repositories/api/_index.md",
+		"content/architecture-decisions/repositories/web/_index.md",
+	}, paths)
+
+	index := docs[0].Content
+	assert.Contains(t, index, "3 decisions from 2 repositories.")
+	assert.Contains(t, index, "[Accepted (1)](/architecture-decisions/status/accepted/)")
+	assert.Contains(t, index, "| ADR-001 | [Staged Pipeline](/api/adr/adr-001-staged/) | Accepted | 2026-01-14 | api |\n"+
+		"| ADR-002 | [Use NATS for events](/api/adr/adr-002-use-nats/) | Superseded | 2025-03-01 | api |\n"+
+		"| ADR-0003 | [Use React](/web/decisions/0003-react/) | Proposed | - | web |")
+	assert.NotContains(t, index, "guide")
+	assert.NotContains(t, docs[3].Content, "Staged Pipeline")
+
+	ctx.Config.ADRIndex.Enabled = false
+	docs, err = generateADRIndex(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
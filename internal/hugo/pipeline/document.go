@@ -1,11 +1,14 @@
 package pipeline
 
 import (
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
 )
 
 const indexFileSuffix = "_index"
@@ -27,21 +30,40 @@ type Document struct {
 	HadFrontMatter bool
 
 	// Metadata for transforms to use (read-only during transform phase)
-	Path            string         // Hugo content path (e.g., "repo-name/section/file.md")
-	IsIndex         bool           // True if this is _index.md or README.md
-	Repository      string         // Source repository name
-	Forge           string         // Optional forge namespace
-	Section         string         // Documentation section
-	IsSingleRepo    bool           // True if this is a single-repository build (skip repo namespace in links)
-	IsPreviewMode   bool           // True if running in preview/daemon mode
-	VSCodeEditLinks bool           // True if VS Code edit links are enabled (via --vscode flag)
-	EditURLBase     string         // Base URL override for edit links (from --edit-url-base flag)
-	SourceCommit    string         // Git commit SHA
-	CommitDate      time.Time      // Git commit date
-	SourceURL       string         // Repository URL for edit links
-	SourceBranch    string         // Git branch name
-	Generated       bool           // True if this was generated (not discovered)
-	CustomMetadata  map[string]any // Generic metadata from discovery phase (e.g., tags)
+	Path            string           // Hugo content path (e.g., "repo-name/section/file.md")
+	IsIndex         bool             // True if this is _index.md or README.md
+	Repository      string           // Source repository name
+	Forge           string           // Optional forge namespace
+	Organization    string           // Optional organization/group namespace below the forge
+	Section         string           // Documentation section
+	IsSingleRepo    bool             // True if this is a single-repository build (skip repo namespace in links)
+	IsPreviewMode   bool             // True if running in preview/daemon mode
+	VSCodeEditLinks bool             // True if VS Code edit links are enabled (via --vscode flag)
+	BrowserEditor   bool             // True if the in-browser editor is enabled (via --editor flag)
+	EditURLBase     string           // Base URL override for edit links (from --edit-url-base flag)
+	SourceCommit    string           // Git commit SHA
+	CommitDate      time.Time        // Git commit date
+	SourceURL       string           // Repository URL for edit links
+	SourceBranch    string           // Git branch name
+	EditURLTemplate string           // Edit URL template of the repository (empty = forge patterns)
+	Generated       bool             // True if this was generated (not discovered)
+	CustomMetadata  map[string]any   // Generic metadata from discovery phase (e.g., tags)
+	GitHistory      *git.FileHistory // Last-modified/author history of the source file (optional)
+	Owners          []string         // Owning teams/users from the repository's CODEOWNERS (optional)
+	Tags            []string         // Site tags of the repository (e.g. mapped from forge topics)
+	Categories      []string         // Site categories of the repository (e.g. mapped from forge topics)
+	// FrontMatterPolicy overrides how generated front matter merges with source values (nil = built-in behavior).
+	FrontMatterPolicy *config.FrontMatterConfig
+	// SanitizePolicy is the repository's shortcode/raw HTML policy (nil = keep everything).
+	SanitizePolicy *config.SanitizeConfig
+	// PageMetadataPolicy is the repository's reading time / TOC settings (nil = none added).
+	PageMetadataPolicy *config.PageMetadataConfig
+	// UnresolvedLinks lists relative links whose target page or anchor is not part of the build.
+	UnresolvedLinks []UnresolvedLink
+	// DuplicateAnchors lists headings whose anchor is already used on the same page.
+	DuplicateAnchors []DuplicateAnchor
+	// InvalidDates lists source front matter dates that could not be parsed (and were dropped).
+	InvalidDates []InvalidDate
 
 	// Internal fields (used by pipeline, not by transforms)
 	FilePath     string // Absolute path to source file (for discovered docs)
@@ -50,6 +72,9 @@ type Document struct {
 	DocsBase     string // Configured docs base path
 	Name         string // File name without extension
 
+	// links is the build's link index used to resolve relative links (nil disables checking)
+	links *linkIndex
+
 	// Raw is the serialized output (front matter + content)
 	// Set by Serialize transform at the end of pipeline
 	Raw []byte
@@ -74,6 +99,7 @@ func NewDocumentFromDocFile(file docs.DocFile, isSingleRepo bool, isPreviewMode
 		IsIndex:             isIndex,
 		Repository:          file.Repository,
 		Forge:               file.Forge,
+		Organization:        file.Organization,
 		Section:             file.Section,
 		IsSingleRepo:        isSingleRepo,
 		IsPreviewMode:       isPreviewMode,
@@ -84,6 +110,8 @@ func NewDocumentFromDocFile(file docs.DocFile, isSingleRepo bool, isPreviewMode
 		SourceBranch:        "", // Will be set by repository metadata injector
 		Generated:           false,
 		CustomMetadata:      customMetadata,
+		Tags:                file.Tags,
+		Categories:          file.Categories,
 		FilePath:            file.Path,
 		RelativePath:        file.RelativePath,
 		Extension:           file.Extension,
@@ -93,6 +121,17 @@ func NewDocumentFromDocFile(file docs.DocFile, isSingleRepo bool, isPreviewMode
 	}
 }
 
+// RepoRelativePath returns the source file path relative to the repository root (slash separated).
+func (d *Document) RepoRelativePath() string {
+	return path.Join(filepath.ToSlash(d.DocsBase), filepath.ToSlash(d.RelativePath))
+}
+
+// Namespace returns the content path prefix placed before the repository
+// ("forge", "org", "forge/org", or empty).
+func (d *Document) Namespace() string {
+	return docs.JoinNamespace(d.Forge, d.Organization)
+}
+
 // isIndexFileName checks if a file name represents an index file.
 func isIndexFileName(name string) bool {
 	lowerName := strings.ToLower(name)
@@ -150,4 +189,29 @@ type RepositoryInfo struct {
 	DocsBase   string
 	DocsPaths  []string // All configured documentation paths
 	Namespace  string   // For namespaced repos
+	Title      string   // Index page title override (monorepo sections)
+	Weight     int      // Index page navigation weight (monorepo sections)
+	// EditURLTemplate is the resolved edit URL template (repository override or global).
+	EditURLTemplate string
+	// Static marks a downloaded static source; its pages only get edit links from EditURLTemplate.
+	Static bool
+	// Sanitize is the resolved shortcode/raw HTML policy (repository override or global).
+	Sanitize *config.SanitizeConfig
+	// PageMetadata is the resolved reading time / TOC settings (repository override or global).
+	PageMetadata *config.PageMetadataConfig
+	// FileHistory maps repo-root relative file paths to git history (nil when unavailable/disabled).
+	FileHistory map[string]git.FileHistory
+	// Owners holds the repository's CODEOWNERS rules (nil when the repository has none).
+	Owners *git.CodeOwners
+	// Lint summarizes lint findings in the repository's source files (nil when not collected).
+	Lint *LintSummary
+	// Releases are the forge releases recorded for the repository (see generateReleaseNotes).
+	Releases []Release
+}
+
+// LintSummary counts the lint findings of a repository's documentation files.
+type LintSummary struct {
+	Files    int `json:"files"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
 }
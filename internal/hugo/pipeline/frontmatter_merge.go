@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"reflect"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// mergeFrontMatter sets a generated front matter value, resolving conflicts with a value
+// already present (from the source file) using the document's merge policy for key,
+// or fallback when the policy does not configure it.
+func (d *Document) mergeFrontMatter(key string, value any, fallback config.FrontMatterMergeStrategy) {
+	existing, exists := d.FrontMatter[key]
+	if !exists || existing == nil {
+		d.FrontMatter[key] = value
+		return
+	}
+	switch d.FrontMatterPolicy.StrategyFor(key, fallback) {
+	case config.FrontMatterBuilderWins:
+		d.FrontMatter[key] = value
+	case config.FrontMatterDeepMerge:
+		d.FrontMatter[key] = deepMergeValues(existing, value)
+	case config.FrontMatterSourceWins:
+	}
+}
+
+// deepMergeValues combines a source value with a generated one: lists are concatenated
+// without duplicates (source entries first), maps are merged recursively and for any other
+// combination the source value is kept.
+func deepMergeValues(source, generated any) any {
+	if srcMap, ok := toStringMap(source); ok {
+		genMap, ok := toStringMap(generated)
+		if !ok {
+			return source
+		}
+		merged := make(map[string]any, len(srcMap)+len(genMap))
+		for k, v := range genMap {
+			merged[k] = v
+		}
+		for k, v := range srcMap {
+			if g, ok := genMap[k]; ok {
+				merged[k] = deepMergeValues(v, g)
+			} else {
+				merged[k] = v
+			}
+		}
+		return merged
+	}
+
+	srcList, srcIsList := toList(source)
+	genList, genIsList := toList(generated)
+	if !srcIsList || !genIsList {
+		return source
+	}
+	merged := append([]any{}, srcList...)
+	for _, g := range genList {
+		dup := false
+		for _, m := range merged {
+			if reflect.DeepEqual(m, g) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			merged = append(merged, g)
+		}
+	}
+	return merged
+}
+
+func toStringMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case map[string]string:
+		out := make(map[string]any, len(m))
+		for k, s := range m {
+			out[k] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func toList(v any) ([]any, bool) {
+	switch l := v.(type) {
+	case []any:
+		return l, true
+	case []string:
+		out := make([]any, len(l))
+		for i, s := range l {
+			out[i] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
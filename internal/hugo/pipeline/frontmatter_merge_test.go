@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestMergeFrontMatter(t *testing.T) {
+	newDoc := func(policy *config.FrontMatterConfig) *Document {
+		return &Document{
+			FrontMatterPolicy: policy,
+			FrontMatter: map[string]any{
+				"repository": "custom",
+				"owners":     []any{"@docs"},
+				"params":     map[string]any{"weight": 5, "tags": []any{"a"}},
+			},
+		}
+	}
+
+	// Built-in defaults: provenance keys are builder-owned, descriptive keys keep the source value.
+	doc := newDoc(nil)
+	doc.mergeFrontMatter("repository", "api", config.FrontMatterBuilderWins)
+	doc.mergeFrontMatter("owners", []string{"@platform"}, config.FrontMatterSourceWins)
+	doc.mergeFrontMatter("type", "docs", config.FrontMatterSourceWins)
+	assert.Equal(t, "api", doc.FrontMatter["repository"])
+	assert.Equal(t, []any{"@docs"}, doc.FrontMatter["owners"])
+	assert.Equal(t, "docs", doc.FrontMatter["type"], "absent keys are always set")
+
+	// A global strategy applies to every key, per-key settings take precedence.
+	doc = newDoc(&config.FrontMatterConfig{
+		Merge: config.FrontMatterSourceWins,
+		Keys:  map[string]config.FrontMatterMergeStrategy{"owners": config.FrontMatterDeepMerge},
+	})
+	doc.mergeFrontMatter("repository", "api", config.FrontMatterBuilderWins)
+	doc.mergeFrontMatter("owners", []string{"@platform", "@docs"}, config.FrontMatterSourceWins)
+	assert.Equal(t, "custom", doc.FrontMatter["repository"])
+	assert.Equal(t, []any{"@docs", "@platform"}, doc.FrontMatter["owners"])
+
+	// Deep merge recurses into maps; scalar conflicts keep the source value.
+	doc = newDoc(&config.FrontMatterConfig{Merge: config.FrontMatterDeepMerge})
+	doc.mergeFrontMatter("params", map[string]any{"weight": 10, "tags": []string{"b"}, "menu": "main"}, config.FrontMatterSourceWins)
+	assert.Equal(t, map[string]any{"weight": 5, "tags": []any{"a", "b"}, "menu": "main"}, doc.FrontMatter["params"])
+}
+
+func TestAddRepositoryMetadata_SourceWinsPolicy(t *testing.T) {
+	cfg := &config.Config{FrontMatter: &config.FrontMatterConfig{
+		Keys: map[string]config.FrontMatterMergeStrategy{"section": config.FrontMatterSourceWins},
+	}}
+	doc := &Document{
+		Repository:        "api",
+		Section:           "guides",
+		FrontMatterPolicy: cfg.FrontMatter,
+		FrontMatter:       map[string]any{"section": "Getting Started", "repository": "legacy"},
+	}
+	_, err := addRepositoryMetadata(cfg)(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, "Getting Started", doc.FrontMatter["section"])
+	assert.Equal(t, "api", doc.FrontMatter["repository"])
+}
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -83,27 +84,36 @@ func generateRepositoryIndex(ctx *GenerationContext) ([]*Document, error) {
 			repoMeta := ctx.RepositoryMetadata[repo]
 			title := titleCase(repo)
 			description := fmt.Sprintf("Documentation for %s", repo)
+			if repoMeta.Title != "" {
+				title = repoMeta.Title
+				description = fmt.Sprintf("Documentation for %s", title)
+			}
 
-			// Build repository path (handle forge namespacing)
+			// Build repository path (handle forge/organization namespacing)
 			repoPath := repo
-			if repoMeta.Namespace != "" {
-				repoPath = filepath.Join(repoMeta.Namespace, repo)
+			if ns := repositoryNamespace(repoMeta, docs); ns != "" {
+				repoPath = filepath.Join(strings.ToLower(ns), repo)
 			}
 
 			doc := &Document{
-				Path:       filepath.Join("content", repoPath, "_index.md"),
-				IsIndex:    true,
-				Generated:  true,
-				Repository: repo,
-				Forge:      repoMeta.Forge,
-				Section:    "",
-				Content:    fmt.Sprintf("# %s\n\n%s\n\n{{%% children description=\"true\" %%}}\n", title, description),
+				Path:         filepath.Join("content", repoPath, "_index.md"),
+				IsIndex:      true,
+				Generated:    true,
+				Repository:   repo,
+				Forge:        docs[0].Forge,
+				Organization: docs[0].Organization,
+				Owners:       repoMeta.Owners.OwnersFor(path.Join(repoMeta.DocsBase, "_index.md")),
+				Section:      "",
+				Content:      fmt.Sprintf("# %s\n\n%s\n\n{{%% children description=\"true\" %%}}\n", title, description),
 				FrontMatter: map[string]any{
 					"title":       title,
 					"description": description,
 					"type":        "docs",
 				},
 			}
+			if repoMeta.Weight != 0 {
+				doc.FrontMatter["weight"] = repoMeta.Weight
+			}
 			if ctx.Config.IsDaemonPublicOnlyEnabled() {
 				doc.FrontMatter["public"] = true
 			}
@@ -119,8 +129,12 @@ func generateRepositoryIndex(ctx *GenerationContext) ([]*Document, error) {
 func generateSectionIndex(ctx *GenerationContext) ([]*Document, error) {
 	// Collect all unique section paths (including intermediate directories)
 	allSections := make(map[string]bool)
+	repoDocs := make(map[string][]*Document)
 
 	for _, doc := range ctx.Discovered {
+		if doc.Repository != "" {
+			repoDocs[doc.Repository] = append(repoDocs[doc.Repository], doc)
+		}
 		if doc.Section != "" {
 			section := filepath.Join(doc.Repository, doc.Section)
 
@@ -183,8 +197,8 @@ func generateSectionIndex(ctx *GenerationContext) ([]*Document, error) {
 		} else {
 			// Multiple repositories: include repository in path
 			sectionPath = filepath.Join(repo, sectionName)
-			if repoMeta.Namespace != "" {
-				sectionPath = filepath.Join(repoMeta.Namespace, repo, sectionName)
+			if ns := repositoryNamespace(repoMeta, repoDocs[repo]); ns != "" {
+				sectionPath = filepath.Join(strings.ToLower(ns), repo, sectionName)
 			}
 		}
 
@@ -211,6 +225,65 @@ func generateSectionIndex(ctx *GenerationContext) ([]*Document, error) {
 	return generated, nil
 }
 
+// generateNamespaceIndex creates _index.md for every forge and organization directory
+// (content/{forge}, content/{forge}/{org}, ...) above the repositories, so that nested
+// organization directories render as Hugo sections listing their repositories.
+func generateNamespaceIndex(ctx *GenerationContext) ([]*Document, error) {
+	if ctx.IsSingleRepo {
+		return nil, nil
+	}
+
+	namespaces := make(map[string]bool)
+	existing := make(map[string]bool)
+	for _, doc := range ctx.Discovered {
+		existing[filepath.ToSlash(doc.Path)] = true
+		ns := strings.ToLower(doc.Namespace())
+		if ns == "" {
+			continue
+		}
+		parts := strings.Split(ns, "/")
+		for i := 1; i <= len(parts); i++ {
+			namespaces[strings.Join(parts[:i], "/")] = true
+		}
+	}
+
+	generated := make([]*Document, 0, len(namespaces))
+	for ns := range namespaces {
+		if existing["content/"+ns+"/_index.md"] {
+			continue
+		}
+		title := path.Base(ns)
+		description := fmt.Sprintf("Documentation for %s", ns)
+		doc := &Document{
+			Path:      filepath.Join("content", filepath.FromSlash(ns), "_index.md"),
+			IsIndex:   true,
+			Generated: true,
+			Content:   fmt.Sprintf("# %s\n\n%s\n\n{{%% children description=\"true\" %%}}\n", title, description),
+			FrontMatter: map[string]any{
+				"title":       title,
+				"description": description,
+				"type":        "docs",
+			},
+		}
+		if ctx.Config.IsDaemonPublicOnlyEnabled() {
+			doc.FrontMatter["public"] = true
+		}
+		generated = append(generated, doc)
+	}
+	return generated, nil
+}
+
+// repositoryNamespace returns the namespace a repository's documents were discovered under,
+// falling back to the namespace recorded in the repository metadata.
+func repositoryNamespace(meta RepositoryInfo, docs []*Document) string {
+	if len(docs) > 0 {
+		if ns := docs[0].Namespace(); ns != "" {
+			return ns
+		}
+	}
+	return meta.Namespace
+}
+
 // titleCase converts a string to title case (simple version).
 // Replaces dashes and underscores with spaces and capitalizes words.
 func titleCase(s string) string {
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGenerateNamespaceIndex(t *testing.T) {
+	ctx := &GenerationContext{
+		Config: &config.Config{},
+		Discovered: []*Document{
+			{Path: "content/github/acme/api/page.md", Repository: "api", Forge: "github", Organization: "acme"},
+			{Path: "content/github/acme/web/page.md", Repository: "web", Forge: "github", Organization: "acme"},
+			{Path: "content/gitlab/beta/platform/ops/page.md", Repository: "ops", Forge: "gitlab", Organization: "beta/platform"},
+			{Path: "content/gitlab/_index.md", Repository: "", IsIndex: true},
+		},
+	}
+
+	docs, err := generateNamespaceIndex(ctx)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, d := range docs {
+		paths = append(paths, d.Path)
+		assert.True(t, d.Generated)
+	}
+	slices.Sort(paths)
+	assert.Equal(t, []string{
+		filepath.Join("content", "github", "_index.md"),
+		filepath.Join("content", "github", "acme", "_index.md"),
+		filepath.Join("content", "gitlab", "beta", "_index.md"),
+		filepath.Join("content", "gitlab", "beta", "platform", "_index.md"),
+	}, paths, "existing content/gitlab/_index.md must not be regenerated")
+}
+
+func TestGenerateRepositoryIndex_OrganizationNamespace(t *testing.T) {
+	ctx := &GenerationContext{
+		Config:             &config.Config{},
+		RepositoryMetadata: map[string]RepositoryInfo{"api": {Name: "api", Forge: "github"}},
+		Discovered: []*Document{
+			{Path: "content/github/acme/api/guide.md", Repository: "api", Forge: "github", Organization: "acme", Section: ""},
+		},
+	}
+
+	docs, err := generateRepositoryIndex(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, filepath.Join("content", "github", "acme", "api", "_index.md"), docs[0].Path)
+}
+
+func TestGenerateRepositoryIndex_SectionTitleAndWeight(t *testing.T) {
+	ctx := &GenerationContext{
+		Config: &config.Config{},
+		RepositoryMetadata: map[string]RepositoryInfo{
+			"api": {Name: "api", DocsBase: "services/api/docs", Title: "API Reference", Weight: 20},
+		},
+		Discovered: []*Document{
+			{Path: "content/api/guide.md", Repository: "api", DocsBase: "services/api/docs"},
+		},
+	}
+
+	docs, err := generateRepositoryIndex(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, filepath.Join("content", "api", "_index.md"), docs[0].Path)
+	assert.Equal(t, "API Reference", docs[0].FrontMatter["title"])
+	assert.Equal(t, 20, docs[0].FrontMatter["weight"])
+}
@@ -0,0 +1,186 @@
+package pipeline
+
+import (
+	"path"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/markdown"
+)
+
+// UnresolvedLink is a relative link whose target page or anchor does not exist in the build,
+// or whose anchor is shared by several headings of the target page.
+type UnresolvedLink struct {
+	Repository string // Repository of the linking page
+	Source     string // Source file of the linking page (repository relative)
+	Target     string // Link as written in the source
+	Reason     string // "page not found", "anchor not found" or "duplicate anchor"
+}
+
+// DuplicateAnchor is a heading whose anchor is already used by an earlier heading of the
+// same page. Hugo numbers the second anchor, so deep links to it break silently.
+type DuplicateAnchor struct {
+	Repository string
+	Source     string // Source file of the page (repository relative)
+	Line       int    // Line of the duplicate heading
+	FirstLine  int    // Line of the heading that owns the anchor
+	Anchor     string
+	Suggested  string // Unique anchor to set as an explicit {#id}
+}
+
+const (
+	unresolvedPage            = "page not found"
+	unresolvedAnchor          = "anchor not found"
+	unresolvedDuplicateAnchor = "duplicate anchor"
+)
+
+// pageAnchors are the heading anchors of a page.
+type pageAnchors struct {
+	ids        map[string]struct{}
+	duplicated map[string]struct{} // anchors of headings that share their text: "setup", "setup-1"
+}
+
+// linkIndex records every page of a build by site URL, with its heading anchors, so that
+// rewritten links can be checked against real targets.
+type linkIndex struct {
+	pages    map[string]*pageAnchors // site URL -> heading anchors
+	sections map[string]bool         // site URLs served by a section index page
+}
+
+// newLinkIndex indexes docs (discovered and generated) by the URL Hugo serves them at.
+// Duplicate heading anchors of discovered docs are recorded on the docs.
+func newLinkIndex(docs []*Document) *linkIndex {
+	ix := &linkIndex{
+		pages:    make(map[string]*pageAnchors, len(docs)),
+		sections: make(map[string]bool),
+	}
+	for _, doc := range docs {
+		url := ContentURL(doc.Path)
+		page := ix.pages[url]
+		if page == nil {
+			page = &pageAnchors{ids: make(map[string]struct{}), duplicated: make(map[string]struct{})}
+			ix.pages[url] = page
+		}
+		headings := documentHeadings(doc.Content)
+		for _, h := range headings {
+			page.ids[h.ID] = struct{}{}
+		}
+		for _, dup := range markdown.DuplicateAnchors(headings) {
+			page.duplicated[dup.Base] = struct{}{}
+			page.duplicated[dup.ID] = struct{}{}
+			if !doc.Generated {
+				doc.DuplicateAnchors = append(doc.DuplicateAnchors, DuplicateAnchor{
+					Repository: doc.Repository,
+					Source:     doc.reportSource(),
+					Line:       dup.Line,
+					FirstLine:  dup.FirstLine,
+					Anchor:     dup.Base,
+					Suggested:  dup.Suggested,
+				})
+			}
+		}
+		if doc.IsIndex {
+			ix.sections[url] = true
+		}
+	}
+	return ix
+}
+
+// resolveLink implements the link resolution step: a rewritten site-absolute link is
+// looked up in the build's link index. Root-relative links that do not exist in the
+// document's repository are retried as site paths (links to other repositories), links to
+// sections gain a trailing slash, and links whose page or anchor is missing, or whose
+// anchor is duplicated on the target page, are recorded on the document. Asset and
+// external links are not checked.
+func (d *Document) resolveLink(raw, rewritten string) string {
+	if d.links == nil || !strings.HasPrefix(rewritten, "/") {
+		return rewritten
+	}
+	resolved, page, ok := d.links.lookup(rewritten)
+	if !ok && strings.HasPrefix(raw, "/") {
+		resolved, page, ok = d.links.lookup(rewriteLinkPath(raw, "", "", false, "", true))
+	}
+	if !ok {
+		if resolved != "" {
+			d.addUnresolvedLink(raw, unresolvedPage)
+		}
+		return rewritten
+	}
+	if i := strings.IndexByte(resolved, '#'); i >= 0 {
+		anchor := strings.ToLower(resolved[i+1:])
+		if _, exists := page.ids[anchor]; !exists {
+			d.addUnresolvedLink(raw, unresolvedAnchor)
+		} else if _, dup := page.duplicated[anchor]; dup {
+			d.addUnresolvedLink(raw, unresolvedDuplicateAnchor)
+		}
+	}
+	return resolved
+}
+
+// lookup finds the page a site-absolute link points to. It returns the link (with a
+// trailing slash added for sections) and the page's anchors. Links to assets are not
+// pages and yield an empty link.
+func (ix *linkIndex) lookup(link string) (string, *pageAnchors, bool) {
+	target, suffix := link, ""
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target, suffix = target[:i], target[i:]
+	}
+	if path.Ext(target) != "" {
+		return "", nil, false
+	}
+	url := normalizeSiteURL(strings.ToLower(target))
+	page, ok := ix.pages[url]
+	if !ok {
+		return link, nil, false
+	}
+	if ix.sections[url] && !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+	return target + suffix, page, true
+}
+
+func (d *Document) addUnresolvedLink(raw, reason string) {
+	d.UnresolvedLinks = append(d.UnresolvedLinks, UnresolvedLink{
+		Repository: d.Repository,
+		Source:     d.reportSource(),
+		Target:     raw,
+		Reason:     reason,
+	})
+}
+
+// reportSource returns the path of the document's source file used in build reports.
+func (d *Document) reportSource() string {
+	if d.RelativePath == "" {
+		return d.Path
+	}
+	return d.RepoRelativePath()
+}
+
+// headingAnchors returns the anchors Hugo generates for the ATX headings of a markdown
+// document (see markdown.HeadingAnchors). Front matter is skipped.
+func headingAnchors(content string) map[string]struct{} {
+	anchors := make(map[string]struct{})
+	for _, h := range documentHeadings(content) {
+		anchors[h.ID] = struct{}{}
+	}
+	return anchors
+}
+
+// documentHeadings returns the headings of a markdown document with line numbers relative
+// to the whole document, front matter included.
+func documentHeadings(content string) []markdown.HeadingAnchor {
+	lines := strings.Split(content, "\n")
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				start = i + 1
+				break
+			}
+		}
+	}
+	headings := markdown.HeadingAnchors(strings.Join(lines[start:], "\n"))
+	for i := range headings {
+		headings[i].Line += start
+	}
+	return headings
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadingAnchors(t *testing.T) {
+	content := "---\n# not a heading\ntitle: x\n---\n# Getting Started\n\n## Install `docbuilder` (v2)\n\n```sh\n# comment\n```\n\n## Usage\n## Usage\n### Custom {#my-id}\n#hashtag\n"
+	anchors := headingAnchors(content)
+
+	for _, want := range []string{"getting-started", "install-docbuilder-v2", "usage", "usage-1", "my-id"} {
+		assert.Contains(t, anchors, want)
+	}
+	assert.NotContains(t, anchors, "comment")
+	assert.NotContains(t, anchors, "not-a-heading")
+	assert.NotContains(t, anchors, "hashtag")
+}
+
+func TestRewriteRelativeLinks_ResolvesAgainstLinkIndex(t *testing.T) {
+	pages := []*Document{
+		{Path: "content/docs/_index.md", IsIndex: true, Content: "# Docs\n"},
+		{Path: "content/docs/guide/readme.md", IsIndex: true, Content: "# Guide\n\n## Setup\n"},
+		{Path: "content/docs/api.md", Content: "# API\n\n## Errors\n"},
+		{Path: "content/other/intro.md", Content: "# Intro\n"},
+	}
+	doc := &Document{
+		Path:         "content/docs/page.md",
+		Repository:   "docs",
+		DocsBase:     "docs",
+		RelativePath: "page.md",
+		Content: "[dir](guide) [dir2](guide/) [anchor](api.md#errors) [bad anchor](api.md#nope) " +
+			"[missing](gone.md) [asset](files/spec.pdf) [cross repo](/other/intro.md) [readme](guide/README.md#setup)",
+	}
+	doc.links = newLinkIndex(append(pages, doc))
+
+	_, err := rewriteRelativeLinks(nil)(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[dir](/docs/guide/) [dir2](/docs/guide/) [anchor](/docs/api#errors) [bad anchor](/docs/api#nope) "+
+		"[missing](/docs/gone) [asset](/docs/files/spec.pdf) [cross repo](/other/intro) [readme](/docs/guide/#setup)", doc.Content)
+	assert.Equal(t, []UnresolvedLink{
+		{Repository: "docs", Source: "docs/page.md", Target: "api.md#nope", Reason: unresolvedAnchor},
+		{Repository: "docs", Source: "docs/page.md", Target: "gone.md", Reason: unresolvedPage},
+	}, doc.UnresolvedLinks)
+}
+
+func TestLinkIndex_DuplicateAnchors(t *testing.T) {
+	api := &Document{
+		Path:         "content/docs/api.md",
+		Repository:   "docs",
+		RelativePath: "api.md",
+		DocsBase:     "docs",
+		Content:      "---\ntitle: API\n---\n# API\n\n## Errors\n\n## Retries\n\n### Errors\n",
+	}
+	doc := &Document{
+		Path:         "content/docs/page.md",
+		Repository:   "docs",
+		DocsBase:     "docs",
+		RelativePath: "page.md",
+		Content:      "[a](api.md#errors) [b](api.md#errors-1) [c](api.md#retries)",
+	}
+	generated := &Document{Path: "content/docs/_index.md", IsIndex: true, Generated: true, Content: "# Docs\n## X\n## X\n"}
+	doc.links = newLinkIndex([]*Document{api, doc, generated})
+
+	assert.Equal(t, []DuplicateAnchor{
+		{Repository: "docs", Source: "docs/api.md", Line: 10, FirstLine: 6, Anchor: "errors", Suggested: "retries-errors"},
+	}, api.DuplicateAnchors)
+	assert.Empty(t, generated.DuplicateAnchors, "generated pages are not reported")
+
+	_, err := rewriteRelativeLinks(nil)(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []UnresolvedLink{
+		{Repository: "docs", Source: "docs/page.md", Target: "api.md#errors", Reason: unresolvedDuplicateAnchor},
+		{Repository: "docs", Source: "docs/page.md", Target: "api.md#errors-1", Reason: unresolvedDuplicateAnchor},
+	}, doc.UnresolvedLinks)
+}
@@ -3,6 +3,9 @@ package pipeline
 import (
 	"fmt"
 	"log/slog"
+	"runtime"
+	"sync"
+	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 )
@@ -14,6 +17,16 @@ type Processor struct {
 	generators            []FileGenerator
 	transforms            []FileTransform
 	staticAssetGenerators []StaticAssetGenerator
+	redirects             *RedirectIndex
+	links                 *linkIndex
+	workerTimings         []WorkerTiming
+}
+
+// WorkerTiming records the documents one transform worker processed and the time it spent on them.
+type WorkerTiming struct {
+	Worker    int
+	Documents int
+	Busy      time.Duration
 }
 
 // NewProcessor creates a new pipeline processor with default generators and transforms.
@@ -21,9 +34,9 @@ func NewProcessor(cfg *config.Config) *Processor {
 	p := &Processor{
 		config:                cfg,
 		generators:            defaultGenerators(),
-		transforms:            defaultTransforms(cfg),
 		staticAssetGenerators: defaultStaticAssetGenerators(),
 	}
+	p.transforms = defaultTransforms(cfg, p.addRedirectAliases)
 	return p
 }
 
@@ -31,6 +44,7 @@ func NewProcessor(cfg *config.Config) *Processor {
 // This is the main entry point for content transformation.
 //
 // Pipeline phases:
+// 0. Preparation - Apply the build profile and expand include directives
 // 1. Generation - Create missing files (indexes, etc.)
 // 2. Transformation - Process all documents (discovered + generated)
 //
@@ -41,14 +55,30 @@ func (p *Processor) ProcessContent(documents []*Document, repoMetadata map[strin
 	for _, doc := range documents {
 		if doc.Repository != "" {
 			if repoInfo, ok := repoMetadata[doc.Repository]; ok {
-				doc.SourceURL = repoInfo.URL
+				if !repoInfo.Static || repoInfo.EditURLTemplate != "" {
+					doc.SourceURL = repoInfo.URL
+				}
 				doc.SourceCommit = repoInfo.Commit
 				doc.CommitDate = repoInfo.CommitDate
 				doc.SourceBranch = repoInfo.Branch
+				doc.EditURLTemplate = repoInfo.EditURLTemplate
+				doc.SanitizePolicy = repoInfo.Sanitize
+				doc.PageMetadataPolicy = repoInfo.PageMetadata
+				if h, ok := repoInfo.FileHistory[doc.RepoRelativePath()]; ok {
+					doc.GitHistory = &h
+				}
+				doc.Owners = repoInfo.Owners.OwnersFor(doc.RepoRelativePath())
 			}
 		}
 	}
 
+	// Apply the build profile and expand include directives first, so generators and
+	// the link index only see the content that is part of the site.
+	documents, err := p.prepareDocuments(documents)
+	if err != nil {
+		return nil, err
+	}
+
 	// Phase 1: Generation - Create missing files
 	slog.Info("Pipeline: Starting generation phase", slog.Int("discovered", len(documents)))
 
@@ -75,6 +105,7 @@ func (p *Processor) ProcessContent(documents []*Document, repoMetadata map[strin
 
 	// Phase 2: Transformation - Process all documents, including generated ones
 	documents = append(documents, generated...)
+	p.links = newLinkIndex(documents)
 	slog.Info("Pipeline: Starting transformation phase", slog.Int("total_docs", len(documents)))
 
 	processedDocs, err := p.processTransforms(documents)
@@ -88,53 +119,136 @@ func (p *Processor) ProcessContent(documents []*Document, repoMetadata map[strin
 }
 
 // processTransforms runs all transforms on documents, handling dynamic document generation.
-// New documents generated by transforms are queued and processed through the full pipeline.
+// Documents are transformed in parallel; output order matches the sequential order
+// (inputs first, then documents created by transforms, in creation order).
 func (p *Processor) processTransforms(docs []*Document) ([]*Document, error) {
 	processedDocs := make([]*Document, 0, len(docs))
-	queue := append([]*Document{}, docs...)
-	processedCount := 0
-
-	for len(queue) > 0 {
-		doc := queue[0]
-		queue = queue[1:]
-
-		// Run all transforms on this document
-		for i, transform := range p.transforms {
-			newDocs, err := transform(doc)
-			if err != nil {
-				return nil, fmt.Errorf("transform %d failed for %s: %w", i, doc.Path, err)
-			}
+	workers := p.workerCount()
+	timings := make([]WorkerTiming, workers)
+	for i := range timings {
+		timings[i].Worker = i
+	}
+
+	// Each round transforms a batch of documents; documents created by transforms form the next batch.
+	batch := docs
+	for len(batch) > 0 {
+		results := make([]transformResult, len(batch))
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := range min(workers, len(batch)) {
+			wg.Add(1)
+			go func(timing *WorkerTiming) {
+				defer wg.Done()
+				for i := range jobs {
+					start := time.Now()
+					results[i] = p.transformDocument(batch[i])
+					timing.Busy += time.Since(start)
+					timing.Documents++
+				}
+			}(&timings[w])
+		}
+		for i := range batch {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
 
-			// Prevent generated documents from creating new documents (infinite loop protection)
-			if len(newDocs) > 0 && doc.Generated {
-				return nil, fmt.Errorf(
-					"generated document %s attempted to create new documents (transforms should not generate from generated docs)",
-					doc.Path,
-				)
+		var next []*Document
+		for i, res := range results {
+			if res.err != nil {
+				return nil, res.err
 			}
+			processedDocs = append(processedDocs, batch[i])
+			next = append(next, res.created...)
+		}
+		slog.Debug("Pipeline: Transform progress",
+			slog.Int("processed", len(processedDocs)),
+			slog.Int("queued", len(next)))
+		batch = next
+	}
 
-			// Queue new documents for full transform pipeline
-			if len(newDocs) > 0 {
-				slog.Debug("Transform generated new documents",
-					slog.Int("count", len(newDocs)),
-					slog.String("source", doc.Path),
-					slog.Int("transform", i))
-				queue = append(queue, newDocs...)
+	p.workerTimings = timings
+	return processedDocs, nil
+}
+
+// prepareDocuments drops discovered documents outside the active build profile and
+// resolves profile blocks and includes in the rest.
+func (p *Processor) prepareDocuments(documents []*Document) ([]*Document, error) {
+	build := &config.BuildConfig{}
+	if p.config != nil {
+		build = &p.config.Build
+	}
+	prepare := []FileTransform{filterProfileBlocks(p.config), resolveIncludes(p.config)}
+	kept := make([]*Document, 0, len(documents))
+	for _, doc := range documents {
+		if !inBuildProfile(doc, build) {
+			continue
+		}
+		for _, transform := range prepare {
+			if _, err := transform(doc); err != nil {
+				return nil, fmt.Errorf("preparing %s failed: %w", doc.Path, err)
 			}
 		}
+		kept = append(kept, doc)
+	}
+	if excluded := len(documents) - len(kept); excluded > 0 {
+		slog.Info("Pipeline: Build profile applied",
+			slog.String("profile", build.Profile),
+			slog.Int("excluded", excluded))
+	}
+	return kept, nil
+}
+
+// transformResult is the outcome of running the transform chain on one document.
+type transformResult struct {
+	created []*Document
+	err     error
+}
+
+// transformDocument runs all transforms on doc and returns the documents they created.
+func (p *Processor) transformDocument(doc *Document) transformResult {
+	if p.config != nil {
+		doc.FrontMatterPolicy = p.config.FrontMatter
+	}
+	doc.links = p.links
+	var created []*Document
+	for i, transform := range p.transforms {
+		newDocs, err := transform(doc)
+		if err != nil {
+			return transformResult{err: fmt.Errorf("transform %d failed for %s: %w", i, doc.Path, err)}
+		}
 
-		processedDocs = append(processedDocs, doc)
-		processedCount++
+		// Prevent generated documents from creating new documents (infinite loop protection)
+		if len(newDocs) > 0 && doc.Generated {
+			return transformResult{err: fmt.Errorf(
+				"generated document %s attempted to create new documents (transforms should not generate from generated docs)",
+				doc.Path,
+			)}
+		}
 
-		// Log progress periodically
-		if processedCount%100 == 0 {
-			slog.Debug("Pipeline: Transform progress",
-				slog.Int("processed", processedCount),
-				slog.Int("queued", len(queue)))
+		// Queue new documents for full transform pipeline
+		if len(newDocs) > 0 {
+			slog.Debug("Transform generated new documents",
+				slog.Int("count", len(newDocs)),
+				slog.String("source", doc.Path),
+				slog.Int("transform", i))
+			created = append(created, newDocs...)
 		}
 	}
+	return transformResult{created: created}
+}
 
-	return processedDocs, nil
+// workerCount returns the transform worker pool size from build.concurrency.
+func (p *Processor) workerCount() int {
+	if p.config != nil && p.config.Build.Concurrency > 0 {
+		return p.config.Build.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// WorkerTimings reports how the last ProcessContent call spread work over the worker pool.
+func (p *Processor) WorkerTimings() []WorkerTiming {
+	return p.workerTimings
 }
 
 // WithGenerators replaces the default generators with custom ones.
@@ -151,6 +265,12 @@ func (p *Processor) WithTransforms(transforms []FileTransform) *Processor {
 	return p
 }
 
+// WithRedirects sets the redirect index used to inject Hugo aliases for moved pages.
+func (p *Processor) WithRedirects(redirects *RedirectIndex) *Processor {
+	p.redirects = redirects
+	return p
+}
+
 // GenerateStaticAssets generates all static assets based on configuration.
 // Returns a list of assets to be written to the Hugo site root.
 func (p *Processor) GenerateStaticAssets() ([]*StaticAsset, error) {
@@ -193,34 +313,46 @@ func (p *Processor) GenerateStaticAssets() ([]*StaticAsset, error) {
 }
 
 // defaultGenerators returns the standard set of file generators.
-// Order matters: main index → repository indexes → section indexes.
+// Order matters: main index → namespace indexes → repository indexes → section indexes.
 func defaultGenerators() []FileGenerator {
 	return []FileGenerator{
 		generateMainIndex,       // 1. Create site _index.md
-		generateRepositoryIndex, // 2. Create repo _index.md files
-		generateSectionIndex,    // 3. Create section _index.md files
+		generateNamespaceIndex,  // 2. Create forge/organization _index.md files
+		generateRepositoryIndex, // 3. Create repo _index.md files
+		generateSectionIndex,    // 4. Create section _index.md files
+		generateRepositoryMeta,  // 5. Create hidden /_meta/<repo>/ build metadata pages
+		generateADRIndex,        // 6. Create the site-wide architecture decisions index
+		generateReleaseNotes,    // 7. Create repository release notes sections
 	}
 }
 
 // defaultTransforms returns the standard set of content transforms.
 // Order matters: this is the explicit, fixed pipeline execution order.
-func defaultTransforms(cfg *config.Config) []FileTransform {
+func defaultTransforms(cfg *config.Config, redirectAliases FileTransform) []FileTransform {
 	return []FileTransform{
 		parseFrontMatter,                  // 1. Parse YAML front matter from content
 		normalizeIndexFiles,               // 2. Rename README to _index
-		buildBaseFrontMatter,              // 3. Build base front matter structure
-		extractIndexTitle,                 // 4. Extract H1 title from index files
-		extractH1AsTitle,                  // 5. Extract H1 as title for all files (if no title)
-		stripHeading,                      // 6. Strip H1 if appropriate
-		escapeShortcodesInCodeBlocks,      // 7. Escape Hugo shortcodes in code blocks
-		rewriteRelativeLinks(cfg),         // 8. Fix markdown links
-		rewriteImageLinks,                 // 9. Fix image paths
-		generateFromKeywords,              // 10. Create new files based on keywords (e.g., @glossary)
-		addRepositoryMetadata(cfg),        // 11. Add repo/commit/source metadata
-		addEditLink(cfg),                  // 12. Generate edit URL
-		injectPermalink(cfg.Hugo.BaseURL), // 13. Append stable permalink badge
-		serializeDocument,                 // 14. Serialize to final bytes (FM + content)
-		fingerprintContent,                // 15. Add content fingerprint (must be last)
+		normalizeDates,                    // 3. Normalize source front matter dates to RFC3339
+		buildBaseFrontMatter,              // 4. Build base front matter structure
+		extractIndexTitle,                 // 5. Extract H1 title from index files
+		extractH1AsTitle,                  // 6. Extract H1 as title for all files (if no title)
+		stripHeading,                      // 7. Strip H1 if appropriate
+		escapeShortcodesInCodeBlocks,      // 8. Escape Hugo shortcodes in code blocks
+		sanitizeMarkup,                    // 9. Apply the shortcode/raw HTML policy
+		addPageMetadata,                   // 10. Add reading time and table of contents params
+		rewriteRelativeLinks(cfg),         // 11. Fix markdown links
+		rewriteImageLinks,                 // 12. Fix image paths
+		generateFromKeywords,              // 13. Create new files based on keywords (e.g., @glossary)
+		addRepositoryMetadata(cfg),        // 14. Add repo/commit/source metadata
+		addGitHistoryMetadata,             // 15. Add last-modified/contributors from git log
+		addOwnerMetadata,                  // 16. Add owners from CODEOWNERS
+		markStaleContent(cfg),             // 17. Flag pages older than the staleness threshold
+		addEditLink(cfg),                  // 18. Generate edit URL
+		linkRepositoryMeta(cfg),           // 19. Link repository indexes to their build metadata page
+		injectPermalink(cfg.Hugo.BaseURL), // 20. Append stable permalink badge
+		redirectAliases,                   // 21. Add aliases for moved/redirected pages
+		serializeDocument,                 // 22. Serialize to final bytes (FM + content)
+		fingerprintContent,                // 23. Add content fingerprint (must be last)
 	}
 }
 
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -439,10 +440,10 @@ func TestDefaultTransforms_Order(t *testing.T) {
 		Hugo: config.HugoConfig{Title: "Test"},
 	}
 
-	transforms := defaultTransforms(cfg)
+	transforms := NewProcessor(cfg).transforms
 
 	// Verify we have all expected transforms
-	assert.Len(t, transforms, 15, "should have 15 transforms in pipeline")
+	assert.Len(t, transforms, 23, "should have 23 transforms in pipeline")
 
 	// Verify order by testing a document through the pipeline
 	doc := &Document{
@@ -472,3 +473,46 @@ Content with [link](./other.md) and ![image](./img.png).
 	assert.NotNil(t, doc.Raw, "should have serialized output")
 	assert.NotEmpty(t, doc.FrontMatter, "should have front matter")
 }
+
+func TestProcessTransforms_ParallelDeterministicOrder(t *testing.T) {
+	cfg := &config.Config{Hugo: config.HugoConfig{Title: "Test"}, Build: config.BuildConfig{Concurrency: 4}}
+	processor := NewProcessor(cfg)
+
+	// Every third document spawns a generated companion.
+	processor.WithTransforms([]FileTransform{func(doc *Document) ([]*Document, error) {
+		doc.FrontMatter["seen"] = true
+		if n, ok := doc.FrontMatter["n"].(int); ok && n%3 == 0 {
+			return []*Document{{Path: "gen-" + doc.Path, FrontMatter: map[string]any{}, Generated: true}}, nil
+		}
+		return nil, nil
+	}})
+
+	var docs []*Document
+	var want, generated []string
+	for i := range 50 {
+		path := fmt.Sprintf("doc-%02d.md", i)
+		docs = append(docs, &Document{Path: path, FrontMatter: map[string]any{"n": i}})
+		want = append(want, path)
+		if i%3 == 0 {
+			generated = append(generated, "gen-"+path)
+		}
+	}
+	want = append(want, generated...)
+
+	processed, err := processor.processTransforms(docs)
+	require.NoError(t, err)
+	got := make([]string, len(processed))
+	for i, doc := range processed {
+		got[i] = doc.Path
+		assert.Equal(t, true, doc.FrontMatter["seen"])
+	}
+	assert.Equal(t, want, got, "output must keep sequential order")
+
+	timings := processor.WorkerTimings()
+	require.Len(t, timings, 4)
+	total := 0
+	for _, wt := range timings {
+		total += wt.Documents
+	}
+	assert.Equal(t, len(want), total)
+}
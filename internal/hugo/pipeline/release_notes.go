@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// releasesSection is the content directory of a repository's release notes.
+const releasesSection = "releases"
+
+// Release is a published forge release of a repository, rendered into the repository's
+// release notes section.
+type Release struct {
+	Tag         string    `json:"tag"`
+	Name        string    `json:"name,omitempty"`
+	Notes       string    `json:"notes,omitempty"` // Release notes (markdown)
+	URL         string    `json:"url,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	Prerelease  bool      `json:"prerelease,omitempty"`
+}
+
+// Title returns the release name, or its tag when the release is unnamed.
+func (r *Release) Title() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Tag
+}
+
+var releaseSlugUnsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// releaseSlug returns the page name of a release tag ("Release/1.0" -> "release-1.0").
+func releaseSlug(tag string) string {
+	return strings.Trim(releaseSlugUnsafe.ReplaceAllString(strings.ToLower(tag), "-"), "-.")
+}
+
+// generateReleaseNotes creates a releases/ section for every repository with recorded
+// releases: an index listing them newest first and a page per release. Releases are
+// recorded by the daemon from forge release webhooks. Pages the repository ships itself
+// at the same paths are kept.
+func generateReleaseNotes(ctx *GenerationContext) ([]*Document, error) {
+	repoDocs := make(map[string][]*Document)
+	existing := make(map[string]bool)
+	for _, doc := range ctx.Discovered {
+		existing[filepath.ToSlash(doc.Path)] = true
+		if doc.Repository != "" {
+			repoDocs[doc.Repository] = append(repoDocs[doc.Repository], doc)
+		}
+	}
+	repos := make([]string, 0, len(repoDocs))
+	for repo := range repoDocs {
+		if len(ctx.RepositoryMetadata[repo].Releases) > 0 {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+
+	var generated []*Document
+	for _, repo := range repos {
+		info := ctx.RepositoryMetadata[repo]
+		docs := repoDocs[repo]
+		dir := "content"
+		if ns := repositoryNamespace(info, docs); ns != "" {
+			dir = path.Join(dir, strings.ToLower(ns))
+		}
+		if !ctx.IsSingleRepo {
+			dir = path.Join(dir, strings.ToLower(repo))
+		}
+		dir = path.Join(dir, releasesSection)
+
+		releases := append([]Release(nil), info.Releases...)
+		sort.SliceStable(releases, func(i, j int) bool {
+			return releases[i].PublishedAt.After(releases[j].PublishedAt)
+		})
+
+		add := func(doc *Document) {
+			if existing[doc.Path] {
+				return
+			}
+			doc.Generated = true
+			doc.Repository = repo
+			doc.Forge = docs[0].Forge
+			doc.Organization = docs[0].Organization
+			doc.Section = releasesSection
+			if ctx.Config.IsDaemonPublicOnlyEnabled() {
+				doc.FrontMatter["public"] = true
+			}
+			generated = append(generated, doc)
+		}
+
+		title := info.Title
+		if title == "" {
+			title = titleCase(repo)
+		}
+		var index strings.Builder
+		index.WriteString("# Releases\n\n")
+		for i := range releases {
+			rel := &releases[i]
+			slug := releaseSlug(rel.Tag)
+			if slug == "" {
+				continue
+			}
+			fmt.Fprintf(&index, "- [%s](%s/)", rel.Title(), slug)
+			if !rel.PublishedAt.IsZero() {
+				fmt.Fprintf(&index, " (%s)", rel.PublishedAt.UTC().Format(time.DateOnly))
+			}
+			index.WriteString("\n")
+			add(&Document{
+				Path:        path.Join(dir, slug+".md"),
+				Content:     releaseContent(rel),
+				FrontMatter: releaseFrontMatter(rel, len(releases)-i),
+			})
+		}
+		add(&Document{
+			Path:    path.Join(dir, "_index.md"),
+			IsIndex: true,
+			Content: index.String(),
+			FrontMatter: map[string]any{
+				"title":       "Releases",
+				"description": "Release notes for " + title,
+				"type":        "docs",
+			},
+		})
+	}
+	return generated, nil
+}
+
+func releaseFrontMatter(rel *Release, rank int) map[string]any {
+	fm := map[string]any{
+		"title": rel.Title(),
+		"type":  "docs",
+		"tag":   rel.Tag,
+		// Newest first in the navigation.
+		"weight": -rank,
+	}
+	if !rel.PublishedAt.IsZero() {
+		fm["date"] = rel.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	if rel.URL != "" {
+		fm["release_url"] = rel.URL
+	}
+	if rel.Prerelease {
+		fm["prerelease"] = true
+	}
+	return fm
+}
+
+func releaseContent(rel *Release) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", rel.Title())
+	if notes := strings.TrimSpace(rel.Notes); notes != "" {
+		sb.WriteString(notes)
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString("No release notes were published for this release.\n")
+	}
+	if rel.URL != "" {
+		fmt.Fprintf(&sb, "\n[View release](%s)\n", rel.URL)
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGenerateReleaseNotes(t *testing.T) {
+	ctx := &GenerationContext{
+		Config: &config.Config{},
+		RepositoryMetadata: map[string]RepositoryInfo{
+			"api": {Name: "api", Title: "API", Releases: []Release{
+				{Tag: "v1.0.0", PublishedAt: time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)},
+				{Tag: "v1.1.0", Name: "Spring release", Notes: "- Faster builds", URL: "https://github.com/acme/api/releases/tag/v1.1.0",
+					PublishedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), Prerelease: true},
+			}},
+			"web": {Name: "web"},
+		},
+		Discovered: []*Document{
+			{Path: "content/github/api/guide.md", Repository: "api", Forge: "github"},
+			{Path: "content/github/web/guide.md", Repository: "web", Forge: "github"},
+		},
+	}
+
+	docs, err := generateReleaseNotes(ctx)
+	require.NoError(t, err)
+	paths := make([]string, 0, len(docs))
+	for _, d := range docs {
+		paths = append(paths, d.Path)
+		assert.True(t, d.Generated)
+		assert.Equal(t, "api", d.Repository)
+		assert.Equal(t, "github", d.Forge)
+	}
+	require.Equal(t, []string{
+		"content/github/api/releases/v1.1.0.md",
+		"content/github/api/releases/v1.0.0.md",
+		"content/github/api/releases/_index.md",
+	}, paths, "repositories without releases get no section")
+
+	latest := docs[0]
+	assert.Equal(t, "Spring release", latest.FrontMatter["title"])
+	assert.Equal(t, "v1.1.0", latest.FrontMatter["tag"])
+	assert.Equal(t, "2026-03-01T09:00:00Z", latest.FrontMatter["date"])
+	assert.Equal(t, true, latest.FrontMatter["prerelease"])
+	assert.Less(t, latest.FrontMatter["weight"], docs[1].FrontMatter["weight"], "newest release sorts first")
+	assert.Contains(t, latest.Content, "- Faster builds")
+	assert.Contains(t, latest.Content, "[View release](https://github.com/acme/api/releases/tag/v1.1.0)")
+	assert.Contains(t, docs[1].Content, "No release notes were published")
+
+	index := docs[2]
+	assert.True(t, index.IsIndex)
+	assert.Equal(t, "Release notes for API", index.FrontMatter["description"])
+	assert.Equal(t, "# Releases\n\n- [Spring release](v1.1.0/) (2026-03-01)\n- [v1.0.0](v1.0.0/) (2026-01-10)\n", index.Content)
+}
+
+func TestGenerateReleaseNotes_KeepsRepositoryPages(t *testing.T) {
+	ctx := &GenerationContext{
+		Config:       &config.Config{},
+		IsSingleRepo: true,
+		RepositoryMetadata: map[string]RepositoryInfo{
+			"api": {Name: "api", Releases: []Release{{Tag: "Release/2.0"}}},
+		},
+		Discovered: []*Document{
+			{Path: "content/guide.md", Repository: "api"},
+			{Path: "content/releases/_index.md", Repository: "api", IsIndex: true},
+		},
+	}
+
+	docs, err := generateReleaseNotes(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "content/releases/release-2.0.md", docs[0].Path)
+	assert.NotContains(t, docs[0].FrontMatter, "date")
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// metaNow is the clock used for build metadata pages (overridden in tests).
+var metaNow = time.Now
+
+// repositoryMetaURL returns the site URL of a repository's build metadata page.
+func repositoryMetaURL(repo string) string {
+	return "/_meta/" + strings.ToLower(repo) + "/"
+}
+
+// generateRepositoryMeta creates a hidden /_meta/<repo>/ page per repository with the
+// source commit, branch, build time, page count and lint summary, so readers can check
+// how fresh the published documentation is. Enabled by build.repository_meta.
+func generateRepositoryMeta(ctx *GenerationContext) ([]*Document, error) {
+	if ctx.Config == nil || !ctx.Config.Build.RepositoryMeta {
+		return nil, nil
+	}
+
+	pages := make(map[string]int)
+	for _, doc := range ctx.Discovered {
+		if doc.Repository != "" {
+			pages[doc.Repository]++
+		}
+	}
+	repos := make([]string, 0, len(pages))
+	for repo := range pages {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	builtAt := metaNow().UTC()
+	generated := make([]*Document, 0, len(repos))
+	for _, repo := range repos {
+		info := ctx.RepositoryMetadata[repo]
+		title := info.Title
+		if title == "" {
+			title = titleCase(repo)
+		}
+		meta := map[string]any{
+			"repository": repo,
+			"url":        info.URL,
+			"branch":     info.Branch,
+			"commit":     info.Commit,
+			"built_at":   builtAt.Format(time.RFC3339),
+			"pages":      pages[repo],
+		}
+		if !info.CommitDate.IsZero() {
+			meta["commit_date"] = info.CommitDate.UTC().Format(time.RFC3339)
+		}
+		if info.Lint != nil {
+			meta["lint"] = map[string]any{
+				"files":    info.Lint.Files,
+				"errors":   info.Lint.Errors,
+				"warnings": info.Lint.Warnings,
+			}
+		}
+
+		doc := &Document{
+			Path:      path.Join("content", "_meta", strings.ToLower(repo), "_index.md"),
+			IsIndex:   true,
+			Generated: true,
+			Section:   "_meta",
+			Content:   repositoryMetaContent(title, info, pages[repo], builtAt),
+			FrontMatter: map[string]any{
+				"title":      "Build information: " + title,
+				"type":       "docs",
+				"url":        repositoryMetaURL(repo),
+				"hidden":     true,
+				"build":      map[string]any{"list": "never", "render": "always"},
+				"sitemap":    map[string]any{"disable": true},
+				"build_meta": meta,
+			},
+		}
+		if ctx.Config.IsDaemonPublicOnlyEnabled() {
+			doc.FrontMatter["public"] = true
+		}
+		generated = append(generated, doc)
+	}
+	return generated, nil
+}
+
+// repositoryMetaContent renders the build metadata table of one repository.
+func repositoryMetaContent(title string, info RepositoryInfo, pages int, builtAt time.Time) string {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Build information: %s\n\n", title)
+	sb.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&sb, "| Repository | %s |\n", orDash(info.URL))
+	fmt.Fprintf(&sb, "| Branch | %s |\n", orDash(info.Branch))
+	commit := "-"
+	if info.Commit != "" {
+		commit = "`" + info.Commit + "`"
+	}
+	fmt.Fprintf(&sb, "| Commit | %s |\n", commit)
+	if !info.CommitDate.IsZero() {
+		fmt.Fprintf(&sb, "| Commit date | %s |\n", info.CommitDate.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(&sb, "| Built | %s |\n", builtAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "| Pages | %d |\n", pages)
+	if info.Lint != nil {
+		fmt.Fprintf(&sb, "| Lint | %d errors, %d warnings in %d files |\n", info.Lint.Errors, info.Lint.Warnings, info.Lint.Files)
+	}
+	return sb.String()
+}
+
+// linkRepositoryMeta appends a link to the repository's build metadata page to its index.
+func linkRepositoryMeta(cfg *config.Config) FileTransform {
+	return func(doc *Document) ([]*Document, error) {
+		if cfg == nil || !cfg.Build.RepositoryMeta {
+			return nil, nil
+		}
+		if !doc.IsIndex || doc.Section != "" || doc.Repository == "" || (doc.Extension != "" && doc.Extension != ".md") {
+			return nil, nil
+		}
+		if strings.Contains(doc.Content, "[Build information](") {
+			return nil, nil
+		}
+		// Relative to the index page, so the link also works when the site is served below a base path.
+		depth := 0
+		if dir := strings.Trim(ContentURL(doc.Path), "/"); dir != "" {
+			depth = strings.Count(dir, "/") + 1
+		}
+		link := strings.Repeat("../", depth) + strings.TrimPrefix(repositoryMetaURL(doc.Repository), "/")
+		doc.Content = strings.TrimRight(doc.Content, "\r\n") + "\n\n---\n\n[Build information](" + link + ")\n"
+		return nil, nil
+	}
+}
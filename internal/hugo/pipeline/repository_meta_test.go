@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestGenerateRepositoryMeta(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	orig := metaNow
+	metaNow = func() time.Time { return now }
+	t.Cleanup(func() { metaNow = orig })
+
+	ctx := &GenerationContext{
+		Config: &config.Config{Build: config.BuildConfig{RepositoryMeta: true}},
+		Discovered: []*Document{
+			{Repository: "api", Path: "content/api/guide.md"},
+			{Repository: "api", Path: "content/api/ref.md"},
+			{Repository: "web", Path: "content/web/intro.md"},
+		},
+		RepositoryMetadata: map[string]RepositoryInfo{
+			"api": {
+				URL: "https://example.com/api.git", Branch: "main", Commit: "abc123",
+				Lint: &LintSummary{Files: 2, Errors: 1, Warnings: 3},
+			},
+		},
+	}
+
+	docs, err := generateRepositoryMeta(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	api := docs[0]
+	assert.Equal(t, "content/_meta/api/_index.md", api.Path)
+	assert.True(t, api.Generated)
+	assert.Equal(t, "/_meta/api/", api.FrontMatter["url"])
+	assert.Equal(t, true, api.FrontMatter["hidden"])
+	meta := api.FrontMatter["build_meta"].(map[string]any)
+	assert.Equal(t, "abc123", meta["commit"])
+	assert.Equal(t, 2, meta["pages"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", meta["built_at"])
+	assert.Contains(t, api.Content, "| Commit | `abc123` |")
+	assert.Contains(t, api.Content, "| Lint | 1 errors, 3 warnings in 2 files |")
+
+	assert.Equal(t, "content/_meta/web/_index.md", docs[1].Path)
+	assert.Contains(t, docs[1].Content, "| Commit | - |")
+	assert.NotContains(t, docs[1].Content, "| Lint |")
+
+	ctx.Config.Build.RepositoryMeta = false
+	docs, err = generateRepositoryMeta(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
+
+func TestLinkRepositoryMeta(t *testing.T) {
+	transform := linkRepositoryMeta(&config.Config{Build: config.BuildConfig{RepositoryMeta: true}})
+
+	t.Run("namespaced repository index", func(t *testing.T) {
+		doc := &Document{Path: "content/github/api/_index.md", IsIndex: true, Repository: "api", Content: "# API\n"}
+		_, err := transform(doc)
+		require.NoError(t, err)
+		assert.Equal(t, "# API\n\n---\n\n[Build information](../../_meta/api/)\n", doc.Content)
+
+		_, err = transform(doc)
+		require.NoError(t, err)
+		assert.Equal(t, "# API\n\n---\n\n[Build information](../../_meta/api/)\n", doc.Content, "transform must be idempotent")
+	})
+
+	t.Run("single repository site root", func(t *testing.T) {
+		doc := &Document{Path: "content/_index.md", IsIndex: true, Repository: "api", Extension: ".md", Content: "# Docs"}
+		_, err := transform(doc)
+		require.NoError(t, err)
+		assert.Contains(t, doc.Content, "[Build information](_meta/api/)")
+	})
+
+	t.Run("section indexes and pages are left alone", func(t *testing.T) {
+		section := &Document{Path: "content/api/guides/_index.md", IsIndex: true, Repository: "api", Section: "guides", Content: "x"}
+		page := &Document{Path: "content/api/page.md", Repository: "api", Content: "x"}
+		for _, doc := range []*Document{section, page} {
+			_, err := transform(doc)
+			require.NoError(t, err)
+			assert.Equal(t, "x", doc.Content)
+		}
+	})
+}
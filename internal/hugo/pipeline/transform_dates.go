@@ -0,0 +1,129 @@
+package pipeline
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvalidDate is a source front matter date that could not be parsed. The key is removed
+// from the page so Hugo does not fail on it.
+type InvalidDate struct {
+	Repository string
+	Source     string // Source file of the page (repository relative)
+	Key        string // Front matter key as written in the source
+	Value      string
+}
+
+// zonedDateLayouts carry their own offset.
+var zonedDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05.999999999 -0700",
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+}
+
+// localDateLayouts are interpreted in the configured default timezone. Day/month order
+// is only accepted where it is unambiguous (ISO order, dotted European dates, month names).
+var localDateLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"20060102",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"2.1.2006",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"Monday, January 2, 2006",
+	"Mon, 2 Jan 2006",
+}
+
+// normalizeDates rewrites the source front matter dates to RFC3339 so that Hugo sorts
+// pages consistently. Dates without an offset get the configured default timezone;
+// unparseable dates are dropped with a warning (a missing date falls back to the commit date).
+func normalizeDates(doc *Document) ([]*Document, error) {
+	policy := doc.FrontMatterPolicy
+	if doc.Generated || len(doc.FrontMatter) == 0 || !policy.NormalizeDates() {
+		return nil, nil
+	}
+	loc := policy.DateLocation()
+	for _, want := range policy.DateKeys() {
+		for key, value := range doc.FrontMatter {
+			if !strings.EqualFold(key, want) || value == nil {
+				continue
+			}
+			t, ok := parseFrontMatterDate(value, loc)
+			if !ok {
+				raw := fmt.Sprint(value)
+				slog.Warn("Dropping unparseable front matter date",
+					slog.String("path", doc.Path),
+					slog.String("key", key),
+					slog.String("value", raw))
+				doc.InvalidDates = append(doc.InvalidDates, InvalidDate{
+					Repository: doc.Repository,
+					Source:     doc.reportSource(),
+					Key:        key,
+					Value:      raw,
+				})
+				delete(doc.FrontMatter, key)
+				continue
+			}
+			doc.FrontMatter[key] = t.Format(time.RFC3339)
+		}
+	}
+	return nil, nil
+}
+
+// parseFrontMatterDate parses a front matter date value: a YAML timestamp, a unix
+// timestamp in seconds or a string in one of the supported layouts.
+func parseFrontMatterDate(value any, loc *time.Location) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case int:
+		return parseDateString(strconv.Itoa(v), loc)
+	case int64:
+		return parseDateString(strconv.FormatInt(v, 10), loc)
+	case string:
+		return parseDateString(strings.TrimSpace(v), loc)
+	}
+	return time.Time{}, false
+}
+
+func parseDateString(s string, loc *time.Location) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range zonedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range localDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true
+		}
+	}
+	if len(s) == 9 || len(s) == 10 { // unix seconds (1973-2286)
+		if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(secs, 0).In(loc), true
+		}
+	}
+	return time.Time{}, false
+}
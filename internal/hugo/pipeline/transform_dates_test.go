@@ -0,0 +1,99 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestNormalizeDates(t *testing.T) {
+	berlin := &config.FrontMatterConfig{Dates: &config.FrontMatterDatesConfig{Timezone: "Europe/Berlin"}}
+
+	tests := []struct {
+		name   string
+		value  any
+		policy *config.FrontMatterConfig
+		want   string
+	}{
+		{"rfc3339 kept", "2024-03-05T10:30:00+02:00", nil, "2024-03-05T10:30:00+02:00"},
+		{"date only in utc", "2024-03-05", nil, "2024-03-05T00:00:00Z"},
+		{"date only in default timezone", "2024-03-05", berlin, "2024-03-05T00:00:00+01:00"},
+		{"space separated time", "2024-07-05 14:00", berlin, "2024-07-05T14:00:00+02:00"},
+		{"explicit offset wins", "2024-07-05 14:00:00 -0500", berlin, "2024-07-05T14:00:00-05:00"},
+		{"month name", "March 5, 2024", nil, "2024-03-05T00:00:00Z"},
+		{"day month name", "5 Mar 2024", nil, "2024-03-05T00:00:00Z"},
+		{"dotted european", "05.03.2024", nil, "2024-03-05T00:00:00Z"},
+		{"slashes in iso order", "2024/03/05", nil, "2024-03-05T00:00:00Z"},
+		{"compact integer", 20240305, nil, "2024-03-05T00:00:00Z"},
+		{"unix seconds", 1709632800, nil, "2024-03-05T10:00:00Z"},
+		{"yaml timestamp", time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), nil, "2024-03-05T10:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{FrontMatter: map[string]any{"date": tt.value}, FrontMatterPolicy: tt.policy}
+			_, err := normalizeDates(doc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, doc.FrontMatter["date"])
+			assert.Empty(t, doc.InvalidDates)
+		})
+	}
+}
+
+func TestNormalizeDates_Keys(t *testing.T) {
+	doc := &Document{FrontMatter: map[string]any{
+		"title":       "2024-03-05",
+		"lastMod":     "2024-03-06",
+		"publishDate": "2024-03-04",
+		"reviewed":    "2024-03-07",
+	}}
+	_, err := normalizeDates(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-05", doc.FrontMatter["title"], "only date keys are touched")
+	assert.Equal(t, "2024-03-06T00:00:00Z", doc.FrontMatter["lastMod"], "keys match case-insensitively like Hugo")
+	assert.Equal(t, "2024-03-04T00:00:00Z", doc.FrontMatter["publishDate"])
+	assert.Equal(t, "2024-03-07", doc.FrontMatter["reviewed"])
+
+	doc.FrontMatterPolicy = &config.FrontMatterConfig{Dates: &config.FrontMatterDatesConfig{Keys: []string{"reviewed"}}}
+	_, err = normalizeDates(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-07T00:00:00Z", doc.FrontMatter["reviewed"])
+}
+
+func TestNormalizeDates_Unparseable(t *testing.T) {
+	doc := &Document{
+		Repository:   "docs",
+		RelativePath: "docs/page.md",
+		DocsBase:     "docs",
+		FrontMatter:  map[string]any{"date": "next tuesday", "lastmod": "03/05/2024"},
+	}
+	_, err := normalizeDates(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, doc.FrontMatter, "date")
+	assert.NotContains(t, doc.FrontMatter, "lastmod", "ambiguous day/month order is not guessed")
+	require.Len(t, doc.InvalidDates, 2)
+	assert.ElementsMatch(t, []string{"next tuesday", "03/05/2024"},
+		[]string{doc.InvalidDates[0].Value, doc.InvalidDates[1].Value})
+	assert.Equal(t, "docs", doc.InvalidDates[0].Repository)
+
+	// The commit date takes over for a dropped date.
+	doc.CommitDate = time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	_, err = buildBaseFrontMatter(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-05T00:00:00+00:00", doc.FrontMatter["date"])
+}
+
+func TestNormalizeDates_Disabled(t *testing.T) {
+	off := false
+	doc := &Document{
+		FrontMatter:       map[string]any{"date": "next tuesday"},
+		FrontMatterPolicy: &config.FrontMatterConfig{Dates: &config.FrontMatterDatesConfig{Normalize: &off}},
+	}
+	_, err := normalizeDates(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "next tuesday", doc.FrontMatter["date"])
+	assert.Empty(t, doc.InvalidDates)
+}
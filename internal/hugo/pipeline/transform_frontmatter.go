@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/docmodel"
 	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
 )
@@ -110,21 +111,15 @@ func buildBaseFrontMatter(doc *Document) ([]*Document, error) {
 	}
 
 	// Set type=docs for Relearn theme (ensures proper layout)
-	if _, hasType := doc.FrontMatter["type"]; !hasType {
-		doc.FrontMatter["type"] = "docs"
-	}
+	doc.mergeFrontMatter("type", "docs", config.FrontMatterSourceWins)
 
 	// Add date if not present (required by Hugo for proper sorting/display)
 	// Use git commit date if available, otherwise fall back to current time
-	if _, hasDate := doc.FrontMatter["date"]; !hasDate {
-		var dateStr string
-		if !doc.CommitDate.IsZero() {
-			dateStr = doc.CommitDate.Format("2006-01-02T15:04:05-07:00")
-		} else {
-			dateStr = time.Now().Format("2006-01-02T15:04:05-07:00")
-		}
-		doc.FrontMatter["date"] = dateStr
+	dateStr := time.Now().Format("2006-01-02T15:04:05-07:00")
+	if !doc.CommitDate.IsZero() {
+		dateStr = doc.CommitDate.Format("2006-01-02T15:04:05-07:00")
 	}
+	doc.mergeFrontMatter("date", dateStr, config.FrontMatterSourceWins)
 
 	return nil, nil
 }
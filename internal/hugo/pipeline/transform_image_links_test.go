@@ -179,6 +179,19 @@ func TestRewriteImageLinks_HTMLImgTags(t *testing.T) {
 	assert.Equal(t, expected, doc.Content)
 }
 
+func TestRewriteImageLinks_HTMLImgSingleQuotesAndCase(t *testing.T) {
+	doc := &Document{
+		Repository: "test-repo",
+		Section:    "guides",
+		Content:    "<img src='images/Banner.JPG' alt='Banner'>\n<IMG SRC=\"./diagram.svg\">\n",
+	}
+
+	_, err := rewriteImageLinks(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "<img src='/test-repo/guides/images/banner.jpg' alt='Banner'>\n<img src=\"/test-repo/guides/diagram.svg\">\n", doc.Content)
+}
+
 func TestRewriteImageLinks_MixedMarkdownAndHTML(t *testing.T) {
 	doc := &Document{
 		Repository: "docs",
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+)
+
+// includeDirectivePattern matches an include directive on a line of its own:
+// <!-- docbuilder:include path="../shared/warning.md" -->
+var includeDirectivePattern = regexp.MustCompile(`^<!--\s*docbuilder:include\s+path="([^"]+)"\s*-->$`)
+
+// resolveIncludes replaces include directives with the body of the referenced file.
+// Paths are relative to the including file, or to the repository root when they start
+// with "/"; targets outside the repository, missing targets and include cycles fail the
+// build. Directives in fenced code blocks are left alone, and the active build profile
+// is applied to included files. It runs on the raw source, so error line numbers match
+// the file.
+func resolveIncludes(cfg *config.Config) FileTransform {
+	build := &config.BuildConfig{}
+	if cfg != nil {
+		build = &cfg.Build
+	}
+	return func(doc *Document) ([]*Document, error) {
+		if doc.Generated || doc.FilePath == "" || !strings.Contains(doc.Content, "docbuilder:include") {
+			return nil, nil
+		}
+		file := filepath.Clean(doc.FilePath)
+		content, err := expandIncludes(doc.Content, 0, file, doc.repoRoot(), []string{file}, build)
+		if err != nil {
+			return nil, err
+		}
+		doc.Content = content
+		return nil, nil
+	}
+}
+
+// expandIncludes resolves the directives of body, which starts after lineOffset lines
+// of file. stack holds the files being expanded, outermost first, for cycle detection.
+func expandIncludes(body string, lineOffset int, file, root string, stack []string, build *config.BuildConfig) (string, error) {
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			out = append(out, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			out = append(out, line)
+			continue
+		}
+		m := includeDirectivePattern.FindStringSubmatch(trimmed)
+		if m == nil {
+			out = append(out, line)
+			continue
+		}
+
+		target := m[1]
+		where := fmt.Sprintf("include %q on line %d of %s", target, lineOffset+i+1, relativeToRoot(root, file))
+		resolved, err := resolveIncludeTarget(target, file, root)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", where, err)
+		}
+		for j, f := range stack {
+			if f == resolved {
+				cycle := make([]string, 0, len(stack)-j+1)
+				for _, c := range stack[j:] {
+					cycle = append(cycle, relativeToRoot(root, c))
+				}
+				cycle = append(cycle, relativeToRoot(root, resolved))
+				return "", fmt.Errorf("%s: include cycle %s", where, strings.Join(cycle, " -> "))
+			}
+		}
+		// #nosec G304 -- resolved is confined to the repository working copy
+		data, err := os.ReadFile(resolved)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return "", fmt.Errorf("%s: file not found", where)
+			}
+			return "", fmt.Errorf("%s: %w", where, err)
+		}
+		fm, included, had, _, err := frontmatter.Split(data)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", where, err)
+		}
+		offset := 0
+		if had {
+			offset = 2 + strings.Count(string(fm), "\n")
+		}
+		filtered, err := stripProfileBlocks(strings.TrimRight(string(included), "\r\n"), offset, relativeToRoot(root, resolved), build)
+		if err != nil {
+			return "", err
+		}
+		expanded, err := expandIncludes(filtered, offset, resolved, root, append(stack, resolved), build)
+		if err != nil {
+			return "", err
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		for _, l := range strings.Split(expanded, "\n") {
+			if strings.TrimSpace(l) == "" {
+				out = append(out, "")
+				continue
+			}
+			out = append(out, indent+l)
+		}
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// resolveIncludeTarget returns the absolute path of an include target; it must stay
+// inside the repository, also after following symbolic links.
+func resolveIncludeTarget(target, file, root string) (string, error) {
+	var resolved string
+	if strings.HasPrefix(target, "/") {
+		resolved = filepath.Join(root, filepath.FromSlash(target))
+	} else {
+		resolved = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+	}
+	errOutside := errors.New("target is outside the repository")
+	if !withinDir(root, resolved) {
+		return "", errOutside
+	}
+	realTarget, err := filepath.EvalSymlinks(resolved)
+	if errors.Is(err, fs.ErrNotExist) {
+		return resolved, nil // reported as not found when it is read
+	}
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	if !withinDir(realRoot, realTarget) {
+		return "", errOutside
+	}
+	return resolved, nil
+}
+
+// withinDir reports whether path is dir or below it.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func relativeToRoot(root, file string) string {
+	if rel, err := filepath.Rel(root, file); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
+
+// repoRoot returns the repository working copy directory of a discovered document.
+func (d *Document) repoRoot() string {
+	file := filepath.Clean(d.FilePath)
+	suffix := string(filepath.Separator) + filepath.FromSlash(d.RepoRelativePath())
+	if !strings.HasSuffix(file, suffix) {
+		return filepath.Dir(file)
+	}
+	return strings.TrimSuffix(file, suffix)
+}
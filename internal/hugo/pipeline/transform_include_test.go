@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// includeRepo writes files below a temporary repository root and returns the root.
+func includeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func includeDoc(root, relative string) *Document {
+	// #nosec G304 -- test file in temp directory
+	content, _ := os.ReadFile(filepath.Join(root, "docs", filepath.FromSlash(relative)))
+	return &Document{
+		Content:      string(content),
+		FilePath:     filepath.Join(root, "docs", filepath.FromSlash(relative)),
+		RelativePath: relative,
+		DocsBase:     "docs",
+	}
+}
+
+func TestResolveIncludes(t *testing.T) {
+	root := includeRepo(t, map[string]string{
+		"docs/guide/install.md":  "---\ntitle: Install\n---\n# Install\n\n<!-- docbuilder:include path=\"../shared/warning.md\" -->\n\n- Step\n  <!-- docbuilder:include path=\"/snippets/note.md\" -->\n\n```markdown\n<!-- docbuilder:include path=\"missing.md\" -->\n```\n",
+		"docs/shared/warning.md": "---\ntitle: Shared\n---\n> **Warning:** back up first.\n<!-- docbuilder:include path=\"/snippets/note.md\" -->\n",
+		"snippets/note.md":       "Note line one.\n\nNote line two.\n",
+	})
+
+	doc := includeDoc(root, "guide/install.md")
+	_, err := resolveIncludes(nil)(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: Install\n---\n# Install\n\n"+
+		"> **Warning:** back up first.\nNote line one.\n\nNote line two.\n\n"+
+		"- Step\n  Note line one.\n\n  Note line two.\n\n"+
+		"```markdown\n<!-- docbuilder:include path=\"missing.md\" -->\n```\n", doc.Content)
+}
+
+func TestResolveIncludes_Errors(t *testing.T) {
+	root := includeRepo(t, map[string]string{
+		"docs/missing.md": "# Page\n\n<!-- docbuilder:include path=\"shared/gone.md\" -->\n",
+		"docs/outside.md": "<!-- docbuilder:include path=\"../../etc/passwd\" -->\n",
+		"docs/a.md":       "---\ntitle: A\n---\n<!-- docbuilder:include path=\"b.md\" -->\n",
+		"docs/b.md":       "---\ntitle: B\n---\nB\n<!-- docbuilder:include path=\"a.md\" -->\n",
+	})
+
+	tests := map[string]string{
+		"missing.md": `include "shared/gone.md" on line 3 of docs/missing.md: file not found`,
+		"outside.md": `include "../../etc/passwd" on line 1 of docs/outside.md: target is outside the repository`,
+		"a.md":       `include "a.md" on line 5 of docs/b.md: include cycle docs/a.md -> docs/b.md -> docs/a.md`,
+	}
+	for file, want := range tests {
+		t.Run(file, func(t *testing.T) {
+			_, err := resolveIncludes(nil)(includeDoc(root, file))
+			require.Error(t, err)
+			assert.Equal(t, want, err.Error())
+		})
+	}
+}
+
+func TestResolveIncludes_SymlinkEscape(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "secret.md")
+	require.NoError(t, os.WriteFile(outside, []byte("secret\n"), 0o600))
+	root := includeRepo(t, map[string]string{
+		"docs/page.md":     "<!-- docbuilder:include path=\"link.md\" -->\n",
+		"docs/dir.md":      "<!-- docbuilder:include path=\"/linked/secret.md\" -->\n",
+		"docs/internal.md": "<!-- docbuilder:include path=\"alias.md\" -->\n",
+		"snippets/note.md": "Note.\n",
+	})
+	if err := os.Symlink(outside, filepath.Join(root, "docs", "link.md")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Dir(outside), filepath.Join(root, "linked")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "snippets", "note.md"), filepath.Join(root, "docs", "alias.md")))
+
+	for file, want := range map[string]string{
+		"page.md": `include "link.md" on line 1 of docs/page.md: target is outside the repository`,
+		"dir.md":  `include "/linked/secret.md" on line 1 of docs/dir.md: target is outside the repository`,
+	} {
+		_, err := resolveIncludes(nil)(includeDoc(root, file))
+		require.Error(t, err, file)
+		assert.Equal(t, want, err.Error())
+	}
+
+	// Symbolic links that stay inside the repository are followed.
+	doc := includeDoc(root, "internal.md")
+	_, err := resolveIncludes(nil)(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "Note.\n", doc.Content)
+}
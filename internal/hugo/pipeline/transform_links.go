@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"fmt"
+	pathpkg "path"
 	"regexp"
 	"strings"
 
@@ -13,13 +14,18 @@ func rewriteRelativeLinks(cfg *config.Config) FileTransform {
 	return func(doc *Document) ([]*Document, error) {
 		// Use an iterative approach instead of regex to avoid catastrophic backtracking
 		// This processes the content character-by-character to find valid markdown links
-		doc.Content = rewriteLinksIterative(doc.Content, doc.Repository, doc.Forge, doc.IsIndex, doc.Path, doc.IsSingleRepo)
+		doc.Content = rewriteLinksIterative(doc.Content, doc.Repository, doc.Namespace(), doc.IsIndex, doc.Path, doc.IsSingleRepo, doc.resolveLink)
 		return nil, nil
 	}
 }
 
+// linkResolver checks a rewritten link against the pages of the build and returns the
+// link to emit. raw is the link as written in the source.
+type linkResolver func(raw, rewritten string) string
+
 // rewriteLinksIterative processes markdown content iteratively to avoid regex backtracking.
-func rewriteLinksIterative(content, repository, forge string, isIndex bool, docPath string, isSingleRepo bool) string {
+// resolve is optional.
+func rewriteLinksIterative(content, repository, forge string, isIndex bool, docPath string, isSingleRepo bool, resolve linkResolver) string {
 	var result strings.Builder
 	result.Grow(len(content))
 
@@ -28,7 +34,7 @@ func rewriteLinksIterative(content, repository, forge string, isIndex bool, docP
 		// Check if we're at the start of a potential link
 		if i < len(content)-1 && content[i] == '[' {
 			// Try to process as a link; if successful, advance i and continue
-			if newI, processed := tryProcessLink(content, i, repository, forge, isIndex, docPath, isSingleRepo, &result); processed {
+			if newI, processed := tryProcessLink(content, i, repository, forge, isIndex, docPath, isSingleRepo, resolve, &result); processed {
 				i = newI
 				continue
 			}
@@ -43,7 +49,7 @@ func rewriteLinksIterative(content, repository, forge string, isIndex bool, docP
 
 // tryProcessLink attempts to process a markdown link starting at position i.
 // Returns the new position and whether a link was successfully processed.
-func tryProcessLink(content string, i int, repository, forge string, isIndex bool, docPath string, isSingleRepo bool, result *strings.Builder) (int, bool) {
+func tryProcessLink(content string, i int, repository, forge string, isIndex bool, docPath string, isSingleRepo bool, resolve linkResolver, result *strings.Builder) (int, bool) {
 	// Check if it's an image link (preceded by !)
 	isImage := i > 0 && content[i-1] == '!'
 
@@ -81,6 +87,9 @@ func tryProcessLink(content string, i int, repository, forge string, isIndex boo
 
 	// Rewrite the relative link
 	newPath := rewriteLinkPath(path, repository, forge, isIndex, docPath, isSingleRepo)
+	if resolve != nil {
+		newPath = resolve(path, newPath)
+	}
 	result.WriteByte('[')
 	result.WriteString(text)
 	result.WriteString("](")
@@ -170,12 +179,12 @@ func rewriteImageLinks(doc *Document) ([]*Document, error) {
 		}
 
 		// Rewrite relative image path accounting for document's section
-		newPath := rewriteImagePath(path, doc.Repository, doc.Forge, doc.Section)
+		newPath := rewriteImagePath(path, doc.Repository, doc.Namespace(), doc.Section)
 		return fmt.Sprintf("![%s](%s)", alt, newPath)
 	})
 
-	// Also handle HTML img tags: <img src="path" ...>
-	htmlImgPattern := regexp.MustCompile(`<img\s+([^>]*\s+)?src="([^"]+)"([^>]*)>`)
+	// Also handle HTML img tags: <img src="path" ...> (either quote style, any case)
+	htmlImgPattern := regexp.MustCompile(`(?i)<img\s+([^>]*\s+)?src=("[^"]+"|'[^']+')([^>]*)>`)
 
 	doc.Content = htmlImgPattern.ReplaceAllStringFunc(doc.Content, func(match string) string {
 		submatches := htmlImgPattern.FindStringSubmatch(match)
@@ -184,7 +193,8 @@ func rewriteImageLinks(doc *Document) ([]*Document, error) {
 		}
 
 		beforeSrc := submatches[1]
-		path := submatches[2]
+		quote := submatches[2][:1]
+		path := submatches[2][1 : len(submatches[2])-1]
 		afterSrc := submatches[3]
 
 		// Skip absolute URLs
@@ -196,12 +206,12 @@ func rewriteImageLinks(doc *Document) ([]*Document, error) {
 		// Normalize root-relative paths to lowercase
 		if strings.HasPrefix(path, "/") {
 			newPath := lowerPathPreserveQueryAndFragment(path)
-			return fmt.Sprintf("<img %ssrc=\"%s\"%s>", beforeSrc, newPath, afterSrc)
+			return fmt.Sprintf("<img %ssrc=%s%s%s%s>", beforeSrc, quote, newPath, quote, afterSrc)
 		}
 
 		// Rewrite relative image path
-		newPath := rewriteImagePath(path, doc.Repository, doc.Forge, doc.Section)
-		return fmt.Sprintf("<img %ssrc=\"%s\"%s>", beforeSrc, newPath, afterSrc)
+		newPath := rewriteImagePath(path, doc.Repository, doc.Namespace(), doc.Section)
+		return fmt.Sprintf("<img %ssrc=%s%s%s%s>", beforeSrc, quote, newPath, quote, afterSrc)
 	})
 
 	return nil, nil
@@ -235,15 +245,19 @@ func rewriteLinkPath(path, repository, forge string, isIndex bool, docPath strin
 		lowerPath = lowerPath[:len(lowerPath)-9]
 	}
 
-	// Handle README/index special case - these become section URLs with trailing slash
-	if strings.HasSuffix(lowerPath, "/readme") {
-		path = path[:len(path)-len("/README")]
-		lowerPath = lowerPath[:len(lowerPath)-len("/readme")]
-		path += "/"
-		lowerPath += "/"
-	}
-	if before, ok := strings.CutSuffix(lowerPath, "/index"); ok {
-		path = path[:len(before)] + "/"
+	// Handle README/index/_index special case - these become section URLs with trailing slash.
+	// A bare index file name links to the section of the current directory.
+	sectionSelf := false
+	for _, name := range []string{"readme", "index", indexFileSuffix} {
+		if lowerPath == name {
+			path, lowerPath, sectionSelf = "", "", true
+			break
+		}
+		if before, ok := strings.CutSuffix(lowerPath, "/"+name); ok {
+			path = path[:len(before)] + "/"
+			lowerPath = before + "/"
+			break
+		}
 	}
 
 	// Handle repository-root-relative links (start with /): normalize case and namespace.
@@ -260,13 +274,13 @@ func rewriteLinkPath(path, repository, forge string, isIndex bool, docPath strin
 	}
 
 	// Skip empty paths (pure anchors)
-	if path == "" {
+	if path == "" && !sectionSelf {
 		return suffix
 	}
 
 	// Handle relative paths that navigate up directories (../)
-	if after, ok := strings.CutPrefix(path, "../"); ok {
-		path = handleParentDirNavigation(after, repository, forge, isSingleRepo)
+	if strings.HasPrefix(path, "../") {
+		path = handleParentDirNavigation(path, extractDirectory(docPath, isSingleRepo, forge), repository, forge, isSingleRepo)
 		return path + suffix
 	}
 
@@ -285,11 +299,18 @@ func rewriteLinkPath(path, repository, forge string, isIndex bool, docPath strin
 	return path + suffix
 }
 
-// handleParentDirNavigation handles links with ../ navigation.
-func handleParentDirNavigation(path, repository, forge string, isSingleRepo bool) string {
-	// Strip all leading ../ sequences
-	for strings.HasPrefix(path, "../") {
-		path = strings.TrimPrefix(path, "../")
+// handleParentDirNavigation handles links with ../ navigation by resolving them against
+// the document's directory. Links climbing above the repository root are clamped to it.
+func handleParentDirNavigation(link, docDir, repository, forge string, isSingleRepo bool) string {
+	path := pathpkg.Join(docDir, link)
+	for path == ".." || strings.HasPrefix(path, "../") {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, ".."), "/")
+	}
+	if path == "." {
+		path = ""
+	}
+	if strings.HasSuffix(link, "/") && path != "" {
+		path += "/"
 	}
 
 	// Prepend repository path (skip if single-repo build)
@@ -373,12 +394,13 @@ func extractDirectory(hugoPath string, isSingleRepo bool, forge string) string {
 		return strings.Join(segments, "/")
 	}
 
-	// Check if forge namespace is present
+	// Check if a forge (or forge/org) namespace is present
 	if forge != "" {
-		// forge/repo/section... format
-		// Return everything after repo (index 1)
-		if len(segments) > 2 {
-			return strings.Join(segments[2:], "/")
+		// namespace.../repo/section... format
+		// Return everything after repo
+		skip := strings.Count(forge, "/") + 2
+		if len(segments) > skip {
+			return strings.Join(segments[skip:], "/")
 		}
 		return ""
 	}
@@ -408,7 +430,7 @@ func rewriteImagePath(path, repository, forge, section string) string {
 	return path
 }
 
-// buildFullPath constructs a full path with forge, repository, and section components.
+// buildFullPath constructs a full path with forge (or forge/org namespace), repository, and section components.
 func buildFullPath(forge, repository, section, path string) string {
 	parts := make([]string, 0, 5)
 	parts = append(parts, "")
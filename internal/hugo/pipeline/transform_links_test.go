@@ -104,6 +104,34 @@ func TestRewriteLinkPath(t *testing.T) {
 			docPath:    "content/servejs/_index.md",
 			want:       "/servejs/tutorials/",
 		},
+		{
+			name:       "Directory link via _index.md",
+			path:       "guide/_index.md#setup",
+			repository: "docs",
+			docPath:    "docs/page.md",
+			want:       "/docs/guide/#setup",
+		},
+		{
+			name:       "Bare README links to the current section",
+			path:       "README.md",
+			repository: "docs",
+			docPath:    "docs/guides/tutorial.md",
+			want:       "/docs/guides/",
+		},
+		{
+			name:       "Parent navigation resolves against the document directory",
+			path:       "../../setup.md#install",
+			repository: "docs",
+			docPath:    "docs/a/b/c/page.md",
+			want:       "/docs/a/setup#install",
+		},
+		{
+			name:       "Parent navigation above the repository root is clamped",
+			path:       "../../../CONTRIBUTING.md",
+			repository: "docs",
+			docPath:    "docs/guides/page.md",
+			want:       "/docs/CONTRIBUTING",
+		},
 		{
 			name:       "Index file with content/ prefix in subdirectory",
 			path:       "configure.md",
@@ -160,6 +188,12 @@ func TestExtractDirectory(t *testing.T) {
 			forge:    "gitlab",
 			want:     "how-to",
 		},
+		{
+			name:     "File with forge and organization namespace",
+			hugoPath: "github/acme/platform/myrepo/how-to/setup.md",
+			forge:    "github/acme/platform",
+			want:     "how-to",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,8 +2,11 @@ package pipeline
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"sync"
+	"text/template"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 )
@@ -13,35 +16,70 @@ func addRepositoryMetadata(cfg *config.Config) FileTransform {
 	return func(doc *Document) ([]*Document, error) {
 		// Add repository name
 		if doc.Repository != "" {
-			doc.FrontMatter["repository"] = doc.Repository
+			doc.mergeFrontMatter("repository", doc.Repository, config.FrontMatterBuilderWins)
 		}
 
 		// Add forge namespace if present
 		if doc.Forge != "" {
-			doc.FrontMatter["forge"] = doc.Forge
+			doc.mergeFrontMatter("forge", doc.Forge, config.FrontMatterBuilderWins)
 		}
 
 		// Add section if present
 		if doc.Section != "" {
-			doc.FrontMatter["section"] = doc.Section
+			doc.mergeFrontMatter("section", doc.Section, config.FrontMatterBuilderWins)
 		}
 
 		// Add source commit if present
 		if doc.SourceCommit != "" {
-			doc.FrontMatter["source_commit"] = doc.SourceCommit
+			doc.mergeFrontMatter("source_commit", doc.SourceCommit, config.FrontMatterBuilderWins)
+		}
+
+		// Repository taxonomy terms extend the page's own tags and categories
+		if len(doc.Tags) > 0 {
+			doc.mergeFrontMatter("tags", append([]string{}, doc.Tags...), config.FrontMatterDeepMerge)
+		}
+		if len(doc.Categories) > 0 {
+			doc.mergeFrontMatter("categories", append([]string{}, doc.Categories...), config.FrontMatterDeepMerge)
 		}
 
 		// Metadata passthrough from discovery phase (if not already set in frontmatter)
 		for k, v := range doc.CustomMetadata {
-			if _, exists := doc.FrontMatter[k]; !exists {
-				doc.FrontMatter[k] = v
-			}
+			doc.mergeFrontMatter(k, v, config.FrontMatterSourceWins)
 		}
 
 		return nil, nil
 	}
 }
 
+// addGitHistoryMetadata adds last-modified date and contributors derived from git log.
+// Values already present in the source front matter are never overwritten.
+func addGitHistoryMetadata(doc *Document) ([]*Document, error) {
+	if doc.Generated || doc.GitHistory == nil {
+		return nil, nil
+	}
+	h := doc.GitHistory
+	if !h.LastModified.IsZero() {
+		doc.mergeFrontMatter("lastmod", h.LastModified.UTC().Format("2006-01-02"), config.FrontMatterSourceWins)
+	}
+	if h.LastAuthor != "" {
+		doc.mergeFrontMatter("last_modified_by", h.LastAuthor, config.FrontMatterSourceWins)
+	}
+	if len(h.Authors) > 0 {
+		doc.mergeFrontMatter("contributors", append([]string{}, h.Authors...), config.FrontMatterSourceWins)
+	}
+	return nil, nil
+}
+
+// addOwnerMetadata exposes CODEOWNERS ownership as an "owners" front matter list.
+// Explicit owners in the source front matter are never overwritten.
+func addOwnerMetadata(doc *Document) ([]*Document, error) {
+	if len(doc.Owners) == 0 {
+		return nil, nil
+	}
+	doc.mergeFrontMatter("owners", append([]string{}, doc.Owners...), config.FrontMatterSourceWins)
+	return nil, nil
+}
+
 // addEditLink generates edit URL for the document using forge-specific patterns.
 func addEditLink(cfg *config.Config) FileTransform {
 	return func(doc *Document) ([]*Document, error) {
@@ -52,8 +90,9 @@ func addEditLink(cfg *config.Config) FileTransform {
 			return nil, nil
 		}
 
-		// Skip if edit URL already exists
-		if _, exists := doc.FrontMatter["editURL"]; exists {
+		// Skip if edit URL already exists (unless configured to be replaced)
+		if _, exists := doc.FrontMatter["editURL"]; exists &&
+			doc.FrontMatterPolicy.StrategyFor("editURL", config.FrontMatterSourceWins) == config.FrontMatterSourceWins {
 			return nil, nil
 		}
 
@@ -64,12 +103,18 @@ func addEditLink(cfg *config.Config) FileTransform {
 
 		// For VS Code preview mode, generate edit URL even without SourceURL
 		if doc.VSCodeEditLinks && doc.IsSingleRepo && doc.RelativePath != "" {
-			doc.FrontMatter["editURL"] = fmt.Sprintf("/_edit/%s", doc.RelativePath)
+			doc.mergeFrontMatter("editURL", fmt.Sprintf("/_edit/%s", doc.RelativePath), config.FrontMatterSourceWins)
 			fmt.Fprintf(os.Stderr, "DEBUG: Generated VS Code edit URL: /_edit/%s (VSCodeEditLinks=%v, IsSingleRepo=%v, RelativePath=%q)\n",
 				doc.RelativePath, doc.VSCodeEditLinks, doc.IsSingleRepo, doc.RelativePath)
 			return nil, nil
 		}
 
+		// Preview mode with --editor: link to the in-browser editor
+		if doc.BrowserEditor && doc.IsSingleRepo && doc.RelativePath != "" {
+			doc.mergeFrontMatter("editURL", "/_editor/"+doc.RelativePath, config.FrontMatterSourceWins)
+			return nil, nil
+		}
+
 		// Debug: Log why VS Code edit URL was not generated
 		if doc.RelativePath != "" && !doc.Generated {
 			fmt.Fprintf(os.Stderr, "DEBUG: VS Code edit URL NOT generated for %s: VSCodeEditLinks=%v, IsSingleRepo=%v, RelativePath=%q\n",
@@ -80,7 +125,7 @@ func addEditLink(cfg *config.Config) FileTransform {
 		if doc.SourceURL != "" && doc.RelativePath != "" {
 			editURL := generateEditURL(doc)
 			if editURL != "" {
-				doc.FrontMatter["editURL"] = editURL
+				doc.mergeFrontMatter("editURL", editURL, config.FrontMatterSourceWins)
 			}
 		}
 
@@ -128,6 +173,14 @@ func generateEditURL(doc *Document) string {
 		filePath = doc.DocsBase + "/" + filePath
 	}
 
+	if doc.EditURLTemplate != "" {
+		return renderEditURLTemplate(doc.EditURLTemplate, config.EditURLTemplateData{
+			SourceURL: baseURL,
+			Branch:    branch,
+			Path:      filePath,
+		})
+	}
+
 	// Determine forge type from the Forge field or URL patterns
 	forgeType := detectForgeType(doc.Forge, baseURL)
 
@@ -149,6 +202,29 @@ func generateEditURL(doc *Document) string {
 	}
 }
 
+// editURLTemplates caches parsed edit URL templates by their text.
+var editURLTemplates sync.Map // string -> *template.Template
+
+// renderEditURLTemplate executes an edit URL template. Templates are validated when the
+// configuration is loaded, so failures here are logged and yield no edit link.
+func renderEditURLTemplate(text string, data config.EditURLTemplateData) string {
+	tmpl, ok := editURLTemplates.Load(text)
+	if !ok {
+		parsed, err := config.ParseEditURLTemplate(text)
+		if err != nil {
+			slog.Warn("Invalid edit URL template", slog.String("template", text), slog.String("error", err.Error()))
+			return ""
+		}
+		tmpl, _ = editURLTemplates.LoadOrStore(text, parsed)
+	}
+	var b strings.Builder
+	if err := tmpl.(*template.Template).Execute(&b, data); err != nil {
+		slog.Warn("Failed to render edit URL template", slog.String("template", text), slog.String("error", err.Error()))
+		return ""
+	}
+	return b.String()
+}
+
 // detectForgeType determines the forge type from metadata or URL patterns.
 func detectForgeType(forgeField, baseURL string) config.ForgeType {
 	// First check if we have explicit forge metadata
@@ -2,12 +2,15 @@ package pipeline
 
 import (
 	"maps"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
 )
 
 // TestAddRepositoryMetadata_Idempotent verifies that applying the transform twice
@@ -106,6 +109,20 @@ func TestAddRepositoryMetadata_Idempotent(t *testing.T) {
 	}
 }
 
+func TestAddRepositoryMetadata_MergesRepositoryTaxonomies(t *testing.T) {
+	doc := &Document{
+		FrontMatter: map[string]any{"tags": []any{"guide"}},
+		Repository:  "api",
+		Tags:        []string{"platform", "guide"},
+		Categories:  []string{"Platform"},
+	}
+
+	_, err := addRepositoryMetadata(&config.Config{})(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"guide", "platform"}, doc.FrontMatter["tags"])
+	assert.Equal(t, []string{"Platform"}, doc.FrontMatter["categories"])
+}
+
 // TestAddEditLink_Idempotent verifies that edit link generation is idempotent.
 func TestAddEditLink_Idempotent(t *testing.T) {
 	cfg := &config.Config{}
@@ -275,6 +292,23 @@ func TestEditLinkGeneration_GeneratedDocuments(t *testing.T) {
 	assert.False(t, exists, "generated documents should not get editURL")
 }
 
+// TestEditLinkGeneration_BrowserEditor verifies preview documents link to the in-browser editor.
+func TestEditLinkGeneration_BrowserEditor(t *testing.T) {
+	transform := addEditLink(&config.Config{})
+
+	doc := &Document{
+		FrontMatter:   make(map[string]any),
+		IsSingleRepo:  true,
+		BrowserEditor: true,
+		SourceURL:     "https://github.com/org/repo",
+		RelativePath:  "guides/setup.md",
+	}
+
+	_, err := transform(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "/_editor/guides/setup.md", doc.FrontMatter["editURL"])
+}
+
 // TestGenerateEditURL_ForgeTypes verifies correct URL patterns for different forges.
 func TestGenerateEditURL_ForgeTypes(t *testing.T) {
 	tests := []struct {
@@ -465,6 +499,26 @@ func TestGenerateEditURL_WithEditURLBase(t *testing.T) {
 	}
 }
 
+func TestGenerateEditURL_Template(t *testing.T) {
+	doc := &Document{
+		Forge:           "gitlab",
+		SourceURL:       "https://git.example.com/platform/mono.git",
+		SourceBranch:    "release",
+		RelativePath:    "guides/setup.md",
+		DocsBase:        "services/api/docs",
+		EditURLTemplate: "{{.SourceURL}}/src/edit/{{.Branch}}/{{.Path}}?mode=edit",
+	}
+	assert.Equal(t, "https://git.example.com/platform/mono/src/edit/release/services/api/docs/guides/setup.md?mode=edit", generateEditURL(doc))
+
+	// The --edit-url-base override feeds .SourceURL.
+	doc.EditURLBase = "https://mirror.example.com/mono"
+	assert.Equal(t, "https://mirror.example.com/mono/src/edit/release/services/api/docs/guides/setup.md?mode=edit", generateEditURL(doc))
+
+	// Templates that fail to execute produce no edit link.
+	doc.EditURLTemplate = "{{.Unknown}}"
+	assert.Empty(t, generateEditURL(doc))
+}
+
 // TestBuildBaseFrontMatter_Idempotent verifies that base frontmatter building is idempotent.
 func TestBuildBaseFrontMatter_Idempotent(t *testing.T) {
 	tests := []struct {
@@ -792,3 +846,66 @@ func captureDocumentStateWithFrontMatterParsed(doc *Document) documentStateWithP
 
 	return state
 }
+
+func TestAddGitHistoryMetadata(t *testing.T) {
+	when := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	doc := &Document{
+		FrontMatter: map[string]any{"contributors": []string{"curated"}},
+		GitHistory:  &git.FileHistory{LastModified: when, LastAuthor: "alice", Authors: []string{"alice", "bob"}},
+	}
+
+	_, err := addGitHistoryMetadata(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-04", doc.FrontMatter["lastmod"])
+	assert.Equal(t, "alice", doc.FrontMatter["last_modified_by"])
+	assert.Equal(t, []string{"curated"}, doc.FrontMatter["contributors"], "existing contributors must be preserved")
+}
+
+func TestAddGitHistoryMetadata_NoHistory(t *testing.T) {
+	doc := &Document{FrontMatter: map[string]any{}}
+
+	_, err := addGitHistoryMetadata(doc)
+	require.NoError(t, err)
+	assert.Empty(t, doc.FrontMatter)
+}
+
+func TestAddOwnerMetadata(t *testing.T) {
+	doc := &Document{FrontMatter: map[string]any{}, Owners: []string{"@org/writers"}}
+	_, err := addOwnerMetadata(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@org/writers"}, doc.FrontMatter["owners"])
+
+	explicit := &Document{FrontMatter: map[string]any{"owners": "@alice"}, Owners: []string{"@org/writers"}}
+	_, err = addOwnerMetadata(explicit)
+	require.NoError(t, err)
+	assert.Equal(t, "@alice", explicit.FrontMatter["owners"], "explicit owners must be preserved")
+}
+
+func TestProcessContent_OwnersFromCodeOwners(t *testing.T) {
+	co, err := git.ParseCodeOwners(strings.NewReader("docs/ @org/writers\n/docs/api/ @org/api-team\n"))
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	docs := []*Document{{
+		Content:      "# Users\n",
+		FrontMatter:  map[string]any{},
+		Path:         "content/repo/api/users.md",
+		Repository:   "repo",
+		Section:      "api",
+		DocsBase:     "docs",
+		RelativePath: "api/users.md",
+		Name:         "users",
+		Extension:    ".md",
+	}}
+	out, err := NewProcessor(cfg).ProcessContent(docs, map[string]RepositoryInfo{
+		"repo": {Name: "repo", DocsBase: "docs", Owners: co},
+	}, false)
+	require.NoError(t, err)
+
+	owners := map[string]any{}
+	for _, d := range out {
+		owners[d.Path] = d.FrontMatter["owners"]
+	}
+	assert.Equal(t, []string{"@org/api-team"}, owners["content/repo/api/users.md"])
+	assert.Equal(t, []string{"@org/writers"}, owners["content/repo/_index.md"], "generated repository index carries docs root owners")
+}
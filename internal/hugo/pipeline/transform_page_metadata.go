@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"git.home.luguber.info/inful/docbuilder/internal/markdown"
+)
+
+// inlineLinkPattern matches [text](destination) so TOC titles keep only the link text.
+var inlineLinkPattern = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+
+// addPageMetadata computes the reading time and table of contents of a page and stores
+// them as front matter params (params.reading_time, params.word_count, params.toc), so
+// any theme can render them. Generated pages are skipped, and params set in the source
+// front matter are kept.
+func addPageMetadata(doc *Document) ([]*Document, error) {
+	policy := doc.PageMetadataPolicy
+	if doc.Generated || (!policy.ReadingTimeEnabled() && !policy.TOCEnabled()) {
+		return nil, nil
+	}
+
+	params, ok := doc.FrontMatter["params"].(map[string]any)
+	if !ok {
+		if doc.FrontMatter["params"] != nil {
+			return nil, nil // not a map; leave the source value alone
+		}
+		params = make(map[string]any)
+	}
+	setParam := func(key string, value any) {
+		if _, exists := params[key]; !exists {
+			params[key] = value
+		}
+	}
+
+	if policy.ReadingTimeEnabled() {
+		words := countProseWords(doc.Content)
+		setParam("word_count", words)
+		setParam("reading_time", readingMinutes(words, policy.Speed()))
+	}
+	if policy.TOCEnabled() {
+		if toc := tableOfContents(doc.Content, policy.MaxTOCLevel()); len(toc) > 0 {
+			setParam("toc", toc)
+		}
+	}
+	if len(params) > 0 {
+		doc.FrontMatter["params"] = params
+	}
+	return nil, nil
+}
+
+// readingMinutes rounds the reading time up to whole minutes (at least one for non-empty pages).
+func readingMinutes(words, wordsPerMinute int) int {
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// countProseWords counts the words of a markdown body outside fenced code blocks.
+// Tokens without letters or digits (list markers, table pipes, heading hashes) are ignored.
+func countProseWords(body string) int {
+	words := 0
+	fence := ""
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		for _, token := range strings.Fields(line) {
+			if strings.IndexFunc(token, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+				words++
+			}
+		}
+	}
+	return words
+}
+
+// tableOfContents returns the headings from level 2 to maxLevel as nested entries
+// (title, anchor, level and children). Anchors match the ones Hugo generates.
+func tableOfContents(body string, maxLevel int) []map[string]any {
+	var toc []map[string]any
+	var open []map[string]any // innermost entry last
+	for _, h := range markdown.HeadingAnchors(body) {
+		if h.Level < 2 || h.Level > maxLevel {
+			continue
+		}
+		entry := map[string]any{"title": plainHeadingText(h.Text), "anchor": h.ID, "level": h.Level}
+		for len(open) > 0 && open[len(open)-1]["level"].(int) >= h.Level {
+			open = open[:len(open)-1]
+		}
+		if len(open) == 0 {
+			toc = append(toc, entry)
+		} else {
+			parent := open[len(open)-1]
+			children, _ := parent["children"].([]map[string]any)
+			parent["children"] = append(children, entry)
+		}
+		open = append(open, entry)
+	}
+	return toc
+}
+
+// plainHeadingText removes inline markdown (links, code and emphasis markers) from heading text.
+func plainHeadingText(text string) string {
+	text = inlineLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("`", "", "**", "", "__", "").Replace(text)
+	return strings.TrimSpace(strings.Trim(text, "*_"))
+}
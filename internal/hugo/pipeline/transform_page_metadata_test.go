@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestAddPageMetadata(t *testing.T) {
+	on := true
+	policy := &config.PageMetadataConfig{ReadingTime: &on, TOC: &on, WordsPerMinute: 10}
+	body := strings.Join([]string{
+		"Intro with five words here.",
+		"",
+		"## Install `docbuilder`",
+		"",
+		"- one two three",
+		"",
+		"```bash",
+		"go install example.com/docbuilder@latest and more words",
+		"```",
+		"",
+		"### From [source](https://example.com)",
+		"",
+		"#### Too deep",
+		"",
+		"## Install docbuilder",
+		"",
+		"| a | b |",
+	}, "\n")
+
+	doc := &Document{Content: body, FrontMatter: map[string]any{"title": "Guide"}, PageMetadataPolicy: policy}
+	_, err := addPageMetadata(doc)
+	require.NoError(t, err)
+
+	params, ok := doc.FrontMatter["params"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, 18, params["word_count"], "code blocks and markup tokens are not counted")
+	assert.Equal(t, 2, params["reading_time"])
+
+	toc, ok := params["toc"].([]map[string]any)
+	require.True(t, ok)
+	require.Len(t, toc, 2)
+	assert.Equal(t, "Install docbuilder", toc[0]["title"])
+	assert.Equal(t, "install-docbuilder", toc[0]["anchor"])
+	assert.Equal(t, "install-docbuilder-1", toc[1]["anchor"], "anchors match Hugo's numbering")
+	children, ok := toc[0]["children"].([]map[string]any)
+	require.True(t, ok)
+	require.Len(t, children, 1, "headings below toc_max_level are left out")
+	assert.Equal(t, "From source", children[0]["title"])
+	assert.Equal(t, 3, children[0]["level"])
+}
+
+func TestAddPageMetadata_KeepsSourceParamsAndSkipsGenerated(t *testing.T) {
+	on := true
+	policy := &config.PageMetadataConfig{ReadingTime: &on}
+
+	doc := &Document{
+		Content:            "Some words.\n",
+		FrontMatter:        map[string]any{"params": map[string]any{"reading_time": 7, "theme": "dark"}},
+		PageMetadataPolicy: policy,
+	}
+	_, err := addPageMetadata(doc)
+	require.NoError(t, err)
+	params := doc.FrontMatter["params"].(map[string]any)
+	assert.Equal(t, 7, params["reading_time"])
+	assert.Equal(t, 2, params["word_count"])
+	assert.Equal(t, "dark", params["theme"])
+	assert.NotContains(t, params, "toc", "toc is disabled")
+
+	generated := &Document{Content: "Index page.\n", FrontMatter: map[string]any{}, Generated: true, PageMetadataPolicy: policy}
+	_, err = addPageMetadata(generated)
+	require.NoError(t, err)
+	assert.NotContains(t, generated.FrontMatter, "params")
+
+	unset := &Document{Content: "Words.\n", FrontMatter: map[string]any{}}
+	_, err = addPageMetadata(unset)
+	require.NoError(t, err)
+	assert.NotContains(t, unset.FrontMatter, "params")
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+)
+
+// profileDirectivePattern matches the start and end of a profile-specific block:
+//
+//	<!-- docbuilder:profile only="internal,partners" -->
+//	...
+//	<!-- docbuilder:end-profile -->
+var profileDirectivePattern = regexp.MustCompile(`^<!--\s*docbuilder:(?:profile\s+only="([^"]*)"|(end-profile))\s*-->$`)
+
+// inBuildProfile reports whether a discovered page is part of the active build profile,
+// based on its `profiles` front matter (a list or a comma-separated string). Pages
+// without profiles, or with unreadable front matter, are always included.
+func inBuildProfile(doc *Document, build *config.BuildConfig) bool {
+	if doc.Generated {
+		return true
+	}
+	raw, _, had, _, err := frontmatter.Split([]byte(doc.Content))
+	if err != nil || !had {
+		return true
+	}
+	fields, err := frontmatter.ParseYAML(raw)
+	if err != nil {
+		return true
+	}
+	return build.InProfile(profileNames(fields["profiles"]))
+}
+
+// filterProfileBlocks removes the profile-specific blocks of a page that do not match the
+// active build profile, and the directives of the blocks that do. It runs on the raw
+// source, so error line numbers match the file.
+func filterProfileBlocks(cfg *config.Config) FileTransform {
+	build := &config.BuildConfig{}
+	if cfg != nil {
+		build = &cfg.Build
+	}
+	return func(doc *Document) ([]*Document, error) {
+		if doc.Generated || !strings.Contains(doc.Content, "docbuilder:") {
+			return nil, nil
+		}
+		content, err := stripProfileBlocks(doc.Content, 0, doc.reportSource(), build)
+		if err != nil {
+			return nil, err
+		}
+		doc.Content = content
+		return nil, nil
+	}
+}
+
+// stripProfileBlocks applies the active profile to body, which starts after lineOffset
+// lines of source. Blocks may be nested; content is kept when every enclosing block
+// matches. Directives in fenced code blocks are left alone.
+func stripProfileBlocks(body string, lineOffset int, source string, build *config.BuildConfig) (string, error) {
+	type block struct {
+		line int
+		keep bool
+	}
+	var open []block
+	keep := func() bool {
+		for _, b := range open {
+			if !b.keep {
+				return false
+			}
+		}
+		return true
+	}
+
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fence = trimmed[:3]
+			} else if m := profileDirectivePattern.FindStringSubmatch(trimmed); m != nil {
+				lineNo := lineOffset + i + 1
+				if m[2] != "" {
+					if len(open) == 0 {
+						return "", fmt.Errorf("end-profile on line %d of %s has no matching profile block", lineNo, source)
+					}
+					open = open[:len(open)-1]
+					continue
+				}
+				names := profileNames(m[1])
+				if len(names) == 0 {
+					return "", fmt.Errorf("profile block on line %d of %s names no profile", lineNo, source)
+				}
+				for _, name := range names {
+					if !build.ProfileDeclared(name) {
+						return "", fmt.Errorf("profile block on line %d of %s: unknown profile %q", lineNo, source, name)
+					}
+				}
+				open = append(open, block{line: lineNo, keep: build.InProfile(names)})
+				continue
+			}
+		} else if strings.HasPrefix(trimmed, fence) {
+			fence = ""
+		}
+		if keep() {
+			out = append(out, line)
+		}
+	}
+	if len(open) > 0 {
+		return "", fmt.Errorf("profile block on line %d of %s is not closed", open[len(open)-1].line, source)
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// profileNames reads a profile list: a YAML list or a comma-separated string.
+func profileNames(v any) []string {
+	var names []string
+	switch t := v.(type) {
+	case string:
+		for _, name := range strings.Split(t, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				names = append(names, strings.TrimSpace(s))
+			}
+		}
+	case []string:
+		for _, s := range t {
+			if strings.TrimSpace(s) != "" {
+				names = append(names, strings.TrimSpace(s))
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestFilterProfileBlocks(t *testing.T) {
+	source := strings.Join([]string{
+		"# Deploy",
+		"<!-- docbuilder:profile only=\"internal\" -->",
+		"Use the staging cluster.",
+		"<!-- docbuilder:profile only=\"ops\" -->",
+		"Page the on-call engineer.",
+		"<!-- docbuilder:end-profile -->",
+		"<!-- docbuilder:end-profile -->",
+		"<!-- docbuilder:profile only=\"public, partners\" -->",
+		"Contact support.",
+		"<!-- docbuilder:end-profile -->",
+		"```markdown",
+		"<!-- docbuilder:profile only=\"internal\" -->",
+		"```",
+	}, "\n")
+
+	tests := map[string]string{
+		"internal": "# Deploy\nUse the staging cluster.\n```markdown\n<!-- docbuilder:profile only=\"internal\" -->\n```",
+		"public":   "# Deploy\nContact support.\n```markdown\n<!-- docbuilder:profile only=\"internal\" -->\n```",
+		"":         "# Deploy\n```markdown\n<!-- docbuilder:profile only=\"internal\" -->\n```",
+	}
+	for profile, want := range tests {
+		t.Run("profile "+profile, func(t *testing.T) {
+			doc := &Document{Content: source}
+			_, err := filterProfileBlocks(&config.Config{Build: config.BuildConfig{Profile: profile}})(doc)
+			require.NoError(t, err)
+			assert.Equal(t, want, doc.Content)
+		})
+	}
+}
+
+func TestFilterProfileBlocks_Errors(t *testing.T) {
+	cfg := &config.Config{Build: config.BuildConfig{Profile: "public", Profiles: []string{"internal", "public"}}}
+	tests := map[string]string{
+		"a\n<!-- docbuilder:profile only=\"internal\" -->\nb":                            "profile block on line 2 of docs/page.md is not closed",
+		"a\n<!-- docbuilder:end-profile -->":                                             "end-profile on line 2 of docs/page.md has no matching profile block",
+		"<!-- docbuilder:profile only=\"intenral\" -->\n<!-- docbuilder:end-profile -->": `profile block on line 1 of docs/page.md: unknown profile "intenral"`,
+		"<!-- docbuilder:profile only=\" \" -->":                                         "profile block on line 1 of docs/page.md names no profile",
+	}
+	for content, want := range tests {
+		doc := &Document{Content: content, RelativePath: "page.md", DocsBase: "docs"}
+		_, err := filterProfileBlocks(cfg)(doc)
+		require.Error(t, err, content)
+		assert.Equal(t, want, err.Error())
+	}
+}
+
+func TestProcessContent_BuildProfile(t *testing.T) {
+	cfg := &config.Config{
+		Hugo:  config.HugoConfig{Title: "Test"},
+		Build: config.BuildConfig{Profile: "public"},
+	}
+	discovered := []*Document{
+		{Content: "---\ntitle: Runbook\nprofiles: [internal]\n---\n# Runbook\n", FrontMatter: map[string]any{}, Path: "repo/runbook.md", Repository: "repo", Name: "runbook", Extension: ".md"},
+		{Content: "---\ntitle: FAQ\nprofiles: internal, public\n---\n# FAQ\n", FrontMatter: map[string]any{}, Path: "repo/faq.md", Repository: "repo", Name: "faq", Extension: ".md"},
+		{Content: "# Guide\n", FrontMatter: map[string]any{}, Path: "repo/guide.md", Repository: "repo", Name: "guide", Extension: ".md"},
+	}
+
+	docs, err := NewProcessor(cfg).ProcessContent(discovered, map[string]RepositoryInfo{"repo": {Name: "repo"}}, false)
+	require.NoError(t, err)
+	var paths []string
+	for _, doc := range docs {
+		paths = append(paths, doc.Path)
+	}
+	assert.NotContains(t, paths, "repo/runbook.md")
+	assert.Contains(t, paths, "repo/faq.md")
+	assert.Contains(t, paths, "repo/guide.md")
+}
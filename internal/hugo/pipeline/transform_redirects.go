@@ -0,0 +1,139 @@
+package pipeline
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// RedirectIndex maps page identities to historical URLs that should redirect to them.
+// Pages are matched either by front matter uid (moved/renamed pages) or by their
+// current site URL (explicit redirect rules).
+type RedirectIndex struct {
+	byUID map[string][]string
+	byURL map[string][]string
+}
+
+// NewRedirectIndex creates an empty RedirectIndex.
+func NewRedirectIndex() *RedirectIndex {
+	return &RedirectIndex{
+		byUID: make(map[string][]string),
+		byURL: make(map[string][]string),
+	}
+}
+
+// AddForUID registers old URLs that should redirect to the page carrying uid.
+func (r *RedirectIndex) AddForUID(uid string, from ...string) {
+	uid = strings.TrimSpace(uid)
+	if uid == "" {
+		return
+	}
+	r.byUID[uid] = appendUniqueURLs(r.byUID[uid], from...)
+}
+
+// AddForURL registers old URLs that should redirect to the page served at url.
+func (r *RedirectIndex) AddForURL(url string, from ...string) {
+	url = normalizeSiteURL(url)
+	if url == "" {
+		return
+	}
+	r.byURL[url] = appendUniqueURLs(r.byURL[url], from...)
+}
+
+// AliasesFor returns the historical URLs for doc, excluding the document's own URL.
+func (r *RedirectIndex) AliasesFor(doc *Document) []string {
+	if r == nil || doc == nil {
+		return nil
+	}
+	self := ContentURL(doc.Path)
+	var out []string
+	if uid, ok := doc.FrontMatter["uid"].(string); ok {
+		out = appendUniqueURLs(out, r.byUID[strings.TrimSpace(uid)]...)
+	}
+	out = appendUniqueURLs(out, r.byURL[self]...)
+	return slices.DeleteFunc(out, func(u string) bool { return u == self })
+}
+
+// ContentURL derives the site URL path Hugo serves for a content path
+// (e.g. "repo/guide/intro.md" -> "/repo/guide/intro/", "repo/_index.md" -> "/repo/").
+// README files are section indexes as well.
+// Hugo lowercases paths by default, so the result is lowercased as well.
+func ContentURL(contentPath string) string {
+	p := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(contentPath, "\\", "/")), "/content")
+	p = strings.TrimSuffix(p, path.Ext(p))
+	if base := path.Base(p); base == indexFileSuffix || strings.EqualFold(base, "index") || strings.EqualFold(base, "readme") {
+		p = path.Dir(p)
+	}
+	return normalizeSiteURL(strings.ToLower(p))
+}
+
+// normalizeSiteURL ensures a site-relative URL path starts and ends with a slash.
+func normalizeSiteURL(u string) string {
+	u = strings.TrimSpace(u)
+	if u == "" {
+		return ""
+	}
+	if strings.Contains(u, "://") {
+		return u
+	}
+	if !strings.HasPrefix(u, "/") {
+		u = "/" + u
+	}
+	if !strings.HasSuffix(u, "/") && path.Ext(u) == "" {
+		u += "/"
+	}
+	return u
+}
+
+func appendUniqueURLs(dst []string, urls ...string) []string {
+	for _, u := range urls {
+		u = normalizeSiteURL(u)
+		if u == "" || slices.Contains(dst, u) {
+			continue
+		}
+		dst = append(dst, u)
+	}
+	return dst
+}
+
+// addRedirectAliases merges historical URLs from the processor's redirect index into
+// the document's Hugo "aliases" front matter so old links keep resolving.
+func (p *Processor) addRedirectAliases(doc *Document) ([]*Document, error) {
+	if p.redirects == nil {
+		return nil, nil
+	}
+	aliases := p.redirects.AliasesFor(doc)
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	if strategy := doc.FrontMatterPolicy.StrategyFor("aliases", config.FrontMatterDeepMerge); strategy != config.FrontMatterDeepMerge {
+		doc.mergeFrontMatter("aliases", aliases, strategy)
+		return nil, nil
+	}
+
+	var existing []string
+	switch v := doc.FrontMatter["aliases"].(type) {
+	case string:
+		existing = []string{v}
+	case []string:
+		existing = append(existing, v...)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				existing = append(existing, s)
+			}
+		}
+	}
+	merged := append([]string{}, existing...)
+	for _, a := range aliases {
+		if !slices.Contains(merged, a) {
+			merged = append(merged, a)
+		}
+	}
+	if len(merged) != len(existing) {
+		doc.FrontMatter["aliases"] = merged
+	}
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentURL(t *testing.T) {
+	cases := map[string]string{
+		"repo/guide/intro.md":   "/repo/guide/intro/",
+		"content/repo/Intro.md": "/repo/intro/",
+		"repo/_index.md":        "/repo/",
+		"_index.md":             "/",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, ContentURL(in), in)
+	}
+}
+
+func TestAddRedirectAliases(t *testing.T) {
+	idx := NewRedirectIndex()
+	idx.AddForUID("abc", "/repo/old-name/", "/repo/new-name/")
+	idx.AddForURL("/repo/new-name", "/legacy/page")
+
+	p := &Processor{redirects: idx}
+	doc := &Document{
+		Path: "repo/new-name.md",
+		FrontMatter: map[string]any{
+			"uid":     "abc",
+			"aliases": []any{"/_uid/abc/"},
+		},
+	}
+
+	_, err := p.addRedirectAliases(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/_uid/abc/", "/repo/old-name/", "/legacy/page/"}, doc.FrontMatter["aliases"])
+}
+
+func TestAddRedirectAliases_NoIndex(t *testing.T) {
+	p := &Processor{}
+	doc := &Document{Path: "repo/page.md", FrontMatter: map[string]any{"uid": "abc"}}
+
+	_, err := p.addRedirectAliases(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, doc.FrontMatter, "aliases")
+}
@@ -0,0 +1,24 @@
+package pipeline
+
+import (
+	"log/slog"
+
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
+)
+
+// sanitizeMarkup applies the repository's shortcode/raw HTML policy to the document body.
+// Escaped and stripped markup is logged; `docbuilder lint` reports it as warnings.
+func sanitizeMarkup(doc *Document) ([]*Document, error) {
+	resolve := sanitize.FromConfig(doc.SanitizePolicy)
+	if resolve == nil || doc.Generated {
+		return nil, nil
+	}
+	content, violations := sanitize.Apply(doc.Content, resolve)
+	if len(violations) > 0 {
+		doc.Content = content
+		slog.Debug("Sanitized shortcodes/HTML",
+			slog.String("path", doc.Path),
+			slog.Int("count", len(violations)))
+	}
+	return nil, nil
+}
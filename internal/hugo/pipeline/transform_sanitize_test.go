@@ -0,0 +1,24 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestSanitizeMarkup(t *testing.T) {
+	policy := &config.SanitizeConfig{Shortcodes: &config.SanitizeRules{Strip: []string{"tabs"}}}
+	doc := &Document{Content: "{{< tabs >}}\nText\n{{< /tabs >}}\n", SanitizePolicy: policy}
+
+	_, err := sanitizeMarkup(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "Text\n", doc.Content)
+
+	unchanged := &Document{Content: "{{< tabs >}}\n"}
+	_, err = sanitizeMarkup(unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, "{{< tabs >}}\n", unchanged.Content, "no policy keeps content")
+}
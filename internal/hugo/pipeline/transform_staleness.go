@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// stalenessNow is the clock used for page age computation (overridden in tests).
+var stalenessNow = time.Now
+
+const staleBannerMarker = `{{% notice style="warning" title="Possibly outdated" %}}`
+
+// markStaleContent flags pages whose last modification is older than the configured threshold.
+// Stale pages get `stale: true` and `stale_days` front matter and, optionally, a warning banner.
+func markStaleContent(cfg *config.Config) FileTransform {
+	return func(doc *Document) ([]*Document, error) {
+		if cfg == nil || !cfg.Staleness.IsEnabled() || doc.Generated {
+			return nil, nil
+		}
+		modified, ok := PageLastModified(doc)
+		if !ok {
+			return nil, nil
+		}
+		days := int(stalenessNow().Sub(modified).Hours() / 24)
+		if days < cfg.Staleness.Threshold() {
+			return nil, nil
+		}
+		doc.mergeFrontMatter("stale", true, config.FrontMatterBuilderWins)
+		doc.mergeFrontMatter("stale_days", days, config.FrontMatterBuilderWins)
+
+		if cfg.Staleness.Banner && !strings.Contains(doc.Content, staleBannerMarker) {
+			banner := fmt.Sprintf("%s\nThis page was last updated %d days ago and may be outdated.\n{{%% /notice %%}}\n\n", staleBannerMarker, days)
+			doc.Content = banner + strings.TrimLeft(doc.Content, "\r\n")
+		}
+		return nil, nil
+	}
+}
+
+// PageLastModified returns the best known modification time of a document:
+// git history first, then an explicit lastmod front matter value.
+func PageLastModified(doc *Document) (time.Time, bool) {
+	if doc.GitHistory != nil && !doc.GitHistory.LastModified.IsZero() {
+		return doc.GitHistory.LastModified, true
+	}
+	switch v := doc.FrontMatter["lastmod"].(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
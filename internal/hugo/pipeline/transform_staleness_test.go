@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+)
+
+func TestMarkStaleContent(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	orig := stalenessNow
+	stalenessNow = func() time.Time { return now }
+	t.Cleanup(func() { stalenessNow = orig })
+
+	cfg := &config.Config{Staleness: &config.StalenessConfig{Enabled: true, MaxAgeDays: 30, Banner: true}}
+	transform := markStaleContent(cfg)
+
+	t.Run("flags old pages from git history", func(t *testing.T) {
+		doc := &Document{
+			Content:     "# Title\n",
+			FrontMatter: map[string]any{},
+			GitHistory:  &git.FileHistory{LastModified: now.AddDate(0, 0, -45)},
+		}
+		_, err := transform(doc)
+		require.NoError(t, err)
+		assert.Equal(t, true, doc.FrontMatter["stale"])
+		assert.Equal(t, 45, doc.FrontMatter["stale_days"])
+		assert.Contains(t, doc.Content, staleBannerMarker)
+
+		// Idempotent banner
+		_, err = transform(doc)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(doc.Content, staleBannerMarker))
+	})
+
+	t.Run("falls back to lastmod front matter", func(t *testing.T) {
+		doc := &Document{FrontMatter: map[string]any{"lastmod": "2025-05-20"}}
+		_, err := transform(doc)
+		require.NoError(t, err)
+		assert.NotContains(t, doc.FrontMatter, "stale")
+	})
+
+	t.Run("disabled config is a no-op", func(t *testing.T) {
+		doc := &Document{FrontMatter: map[string]any{"lastmod": "2020-01-01"}}
+		_, err := markStaleContent(&config.Config{})(doc)
+		require.NoError(t, err)
+		assert.NotContains(t, doc.FrontMatter, "stale")
+	})
+}
@@ -0,0 +1,44 @@
+package hugo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/cdnpurge"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// purgeChangedPages calls the configured purge hooks with the pages that changed in the
+// build just published and records each hook run in the report. The site is already in
+// place, so a failed hook is only logged and recorded; it does not change the outcome.
+func (g *Generator) purgeChangedPages(ctx context.Context, report *models.BuildReport) {
+	if len(report.ChangedURLs) == 0 {
+		return
+	}
+	for _, r := range cdnpurge.Run(ctx, http.DefaultClient, g.config.Purge, report.ChangedURLs) {
+		res := models.PurgeResult{
+			Hook:     r.Hook,
+			Type:     string(r.Type),
+			URLs:     r.URLs,
+			Requests: r.Requests,
+			DryRun:   r.DryRun,
+			Duration: r.Duration,
+		}
+		if r.Err != nil {
+			res.Error = r.Err.Error()
+			g.log().Warn("CDN purge failed",
+				slog.String("hook", r.Hook),
+				slog.Int("urls", r.URLs),
+				slog.String("error", r.Err.Error()))
+		} else {
+			g.log().Info("Purged changed pages from CDN",
+				slog.String("hook", r.Hook),
+				slog.Int("urls", r.URLs),
+				slog.Int("requests", r.Requests),
+				slog.Bool("dry_run", r.DryRun),
+				slog.Duration("duration", r.Duration))
+		}
+		report.Purges = append(report.Purges, res)
+	}
+}
@@ -0,0 +1,223 @@
+package hugo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+// PageManifestFile records the URL of every uid-bearing page and every repository index from
+// the last successful build. It lives in the output root next to build-report.json and is used
+// to detect moved pages and to resolve vanity repository URLs.
+const PageManifestFile = "page-manifest.json"
+
+// pageManifest is the persisted uid -> URL history used for moved-page redirects.
+type pageManifest struct {
+	Version      int                          `json:"version"`
+	Pages        map[string]pageManifestEntry `json:"pages"`
+	Repositories map[string]string            `json:"repositories,omitempty"` // repository name -> index URL
+}
+
+// pageManifestEntry stores the current URL of a page and every URL it was previously served at.
+type pageManifestEntry struct {
+	URL     string   `json:"url"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// loadPageManifest reads the page manifest from the final output directory.
+// A missing or unreadable manifest yields an empty manifest (first build or legacy output).
+func (g *Generator) loadPageManifest() *pageManifest {
+	m := &pageManifest{Version: 1, Pages: make(map[string]pageManifestEntry)}
+	// #nosec G304 -- path is derived from the configured output directory.
+	b, err := os.ReadFile(filepath.Join(g.finalRoot(), PageManifestFile))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			g.log().Warn("Failed to read page manifest; moved-page redirects disabled for this build", "error", err)
+		}
+		return m
+	}
+	if err := json.Unmarshal(b, m); err != nil {
+		g.log().Warn("Failed to parse page manifest; moved-page redirects disabled for this build", "error", err)
+		return &pageManifest{Version: 1, Pages: make(map[string]pageManifestEntry)}
+	}
+	if m.Pages == nil {
+		m.Pages = make(map[string]pageManifestEntry)
+	}
+	return m
+}
+
+// buildRedirectIndex combines moved-page history from the previous manifest with explicit config rules.
+func (g *Generator) buildRedirectIndex(prev *pageManifest) *pipeline.RedirectIndex {
+	idx := pipeline.NewRedirectIndex()
+	rc := g.config.Redirects
+	if rc.MoveTrackingEnabled() && prev != nil {
+		for uid, entry := range prev.Pages {
+			idx.AddForUID(uid, entry.URL)
+			idx.AddForUID(uid, entry.Aliases...)
+		}
+	}
+	if rc != nil {
+		for _, rule := range rc.Rules {
+			if !strings.Contains(rule.To, "://") {
+				idx.AddForURL(rule.To, rule.From)
+			}
+		}
+	}
+	return idx
+}
+
+// writeRedirects persists the page manifest for the next build and emits any configured
+// redirect map files (Netlify _redirects, nginx map) into the Hugo static directory.
+func (g *Generator) writeRedirects(prev *pageManifest, processed []*pipeline.Document) error {
+	next := &pageManifest{Version: 1, Pages: make(map[string]pageManifestEntry), Repositories: repositoryURLs(processed)}
+	if g.config.Redirects.MoveTrackingEnabled() {
+		for _, doc := range processed {
+			if doc.Generated {
+				continue
+			}
+			uid, ok := doc.FrontMatter["uid"].(string)
+			if !ok || strings.TrimSpace(uid) == "" {
+				continue
+			}
+			uid = strings.TrimSpace(uid)
+			entry := pageManifestEntry{URL: pipeline.ContentURL(doc.Path)}
+			if old, seen := prev.Pages[uid]; seen {
+				entry.Aliases = append(entry.Aliases, old.Aliases...)
+				if old.URL != "" && old.URL != entry.URL {
+					entry.Aliases = append(entry.Aliases, old.URL)
+					g.log().Info("Detected moved page; adding redirect",
+						slog.String("uid", uid),
+						slog.String("from", old.URL),
+						slog.String("to", entry.URL))
+				}
+				entry.Aliases = slices.DeleteFunc(entry.Aliases, func(a string) bool { return a == entry.URL })
+				slices.Sort(entry.Aliases)
+				entry.Aliases = slices.Compact(entry.Aliases)
+			}
+			next.Pages[uid] = entry
+		}
+	}
+
+	b, err := json.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal page manifest: %w", err)
+	}
+	// #nosec G306 -- manifest is public site metadata
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), PageManifestFile), b, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write page manifest: %w", herrors.ErrContentWriteFailed, err)
+	}
+
+	rc := g.config.Redirects
+	if rc == nil || len(rc.Files) == 0 {
+		return nil
+	}
+	pairs := collectRedirectPairs(next, rc.Rules)
+	for _, format := range rc.Files {
+		if err := g.writeRedirectFile(format, pairs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repositoryURLs maps every repository with content to the URL of its index page.
+func repositoryURLs(processed []*pipeline.Document) map[string]string {
+	urls := make(map[string]string)
+	for _, doc := range processed {
+		if doc.Generated || doc.Repository == "" {
+			continue
+		}
+		if _, ok := urls[doc.Repository]; ok {
+			continue
+		}
+		if doc.IsSingleRepo {
+			urls[doc.Repository] = "/"
+			continue
+		}
+		urls[doc.Repository] = pipeline.ContentURL(path.Join("content", docs.JoinNamespace(doc.Forge, doc.Organization), doc.Repository, "_index.md"))
+	}
+	return urls
+}
+
+// ReadRepositoryURLs returns the repository name -> index URL map recorded by the last
+// successful build in outputDir.
+func ReadRepositoryURLs(outputDir string) (map[string]string, error) {
+	// #nosec G304 -- path is derived from the configured output directory.
+	b, err := os.ReadFile(filepath.Join(outputDir, PageManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m pageManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("parse page manifest: %w", err)
+	}
+	return m.Repositories, nil
+}
+
+// redirectPair is a single from -> to redirect with its HTTP status code.
+type redirectPair struct {
+	From, To string
+	Status   int
+}
+
+// collectRedirectPairs flattens manifest aliases and explicit rules into a sorted, de-duplicated list.
+func collectRedirectPairs(m *pageManifest, rules []config.RedirectRule) []redirectPair {
+	byFrom := make(map[string]redirectPair)
+	for _, entry := range m.Pages {
+		for _, alias := range entry.Aliases {
+			byFrom[alias] = redirectPair{From: alias, To: entry.URL, Status: http.StatusMovedPermanently}
+		}
+	}
+	// Explicit rules win over automatically detected moves.
+	for _, rule := range rules {
+		byFrom[rule.From] = redirectPair{From: rule.From, To: rule.To, Status: rule.StatusCode()}
+	}
+	pairs := make([]redirectPair, 0, len(byFrom))
+	for _, p := range byFrom {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].From < pairs[j].From })
+	return pairs
+}
+
+func (g *Generator) writeRedirectFile(format config.RedirectFileFormat, pairs []redirectPair) error {
+	var name string
+	var sb strings.Builder
+	switch format {
+	case config.RedirectFileNetlify:
+		name = "_redirects"
+		for _, p := range pairs {
+			fmt.Fprintf(&sb, "%s %s %d\n", p.From, p.To, p.Status)
+		}
+	case config.RedirectFileNginx:
+		name = "redirects.map"
+		for _, p := range pairs {
+			fmt.Fprintf(&sb, "%s %s;\n", p.From, p.To)
+		}
+	default:
+		return fmt.Errorf("unsupported redirect file format: %s", format)
+	}
+	outPath := filepath.Join(g.BuildRoot(), "static", name)
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o750); err != nil {
+		return fmt.Errorf("%w: failed to create directory for %s: %w", herrors.ErrContentWriteFailed, outPath, err)
+	}
+	// #nosec G306 -- redirect maps are public site files
+	if err := os.WriteFile(outPath, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write %s: %w", herrors.ErrContentWriteFailed, outPath, err)
+	}
+	g.log().Debug("Wrote redirect map", slog.String("format", string(format)), slog.Int("redirects", len(pairs)))
+	return nil
+}
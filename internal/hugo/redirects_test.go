@@ -0,0 +1,97 @@
+package hugo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/stages"
+)
+
+// TestRedirects_MovedPageGetsAlias verifies a page renamed between builds keeps its old URL as an alias.
+func TestRedirects_MovedPageGetsAlias(t *testing.T) {
+	out := t.TempDir()
+	cfg := &config.Config{
+		Build:     config.BuildConfig{RenderMode: "never"},
+		Redirects: &config.RedirectsConfig{Files: []config.RedirectFileFormat{config.RedirectFileNetlify}},
+	}
+	content := []byte("---\nuid: page-1\n---\n# Page\n")
+
+	first := []docs.DocFile{{Repository: "r", Name: "old", RelativePath: "old.md", DocsBase: "docs", Extension: ".md", Content: content}}
+	if err := NewGenerator(cfg, out).WithRenderer(&stages.NoopRenderer{}).GenerateSite(first); err != nil {
+		t.Fatalf("first build failed: %v", err)
+	}
+
+	second := []docs.DocFile{{Repository: "r", Name: "new", RelativePath: "new.md", DocsBase: "docs", Extension: ".md", Content: content}}
+	if err := NewGenerator(cfg, out).WithRenderer(&stages.NoopRenderer{}).GenerateSite(second); err != nil {
+		t.Fatalf("second build failed: %v", err)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	page, err := os.ReadFile(filepath.Join(out, "content", "new.md"))
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+	if !strings.Contains(string(page), "/old/") {
+		t.Fatalf("expected alias for old URL in front matter, got:\n%s", page)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	b, err := os.ReadFile(filepath.Join(out, PageManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var m pageManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	entry := m.Pages["page-1"]
+	if entry.URL != "/new/" || len(entry.Aliases) != 1 || entry.Aliases[0] != "/old/" {
+		t.Fatalf("unexpected manifest entry: %+v", entry)
+	}
+	urls, err := ReadRepositoryURLs(out)
+	if err != nil || urls["r"] != "/" {
+		t.Fatalf("expected single repository index at /, got %v (err=%v)", urls, err)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	redirects, err := os.ReadFile(filepath.Join(out, "static", "_redirects"))
+	if err != nil {
+		t.Fatalf("read _redirects: %v", err)
+	}
+	if got := string(redirects); got != "/old/ /new/ 301\n" {
+		t.Fatalf("unexpected _redirects content: %q", got)
+	}
+}
+
+func TestCollectRedirectPairs_RulesOverrideMoves(t *testing.T) {
+	m := &pageManifest{Pages: map[string]pageManifestEntry{
+		"a": {URL: "/new/", Aliases: []string{"/old/"}},
+	}}
+	pairs := collectRedirectPairs(m, []config.RedirectRule{{From: "/old/", To: "https://example.com/", Status: 302}, {From: "/x/", To: "/y/"}})
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %+v", pairs)
+	}
+	if pairs[0].From != "/old/" || pairs[0].To != "https://example.com/" || pairs[0].Status != 302 {
+		t.Fatalf("expected explicit rule to override move, got %+v", pairs[0])
+	}
+	if pairs[1].Status != 301 {
+		t.Fatalf("expected default status 301, got %+v", pairs[1])
+	}
+}
+
+func TestRepositoryURLs_Namespaced(t *testing.T) {
+	urls := repositoryURLs([]*pipeline.Document{
+		{Repository: "API", Forge: "GitHub", Path: "github/api/guide.md"},
+		{Repository: "web", Path: "web/_index.md", IsIndex: true},
+		{Repository: "", Path: "_index.md", Generated: true},
+	})
+	if len(urls) != 2 || urls["API"] != "/github/api/" || urls["web"] != "/web/" {
+		t.Fatalf("unexpected repository URLs: %v", urls)
+	}
+}
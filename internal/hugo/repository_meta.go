@@ -0,0 +1,44 @@
+package hugo
+
+import (
+	"log/slog"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	"git.home.luguber.info/inful/docbuilder/internal/lint"
+)
+
+// addLintSummaries lints each repository's markdown sources and records the counts on its
+// metadata for the /_meta/<repo>/ build information pages. It only runs when
+// build.repository_meta is enabled; lint failures are logged and leave the summary out.
+func (g *Generator) addLintSummaries(docFiles []docs.DocFile, metadata map[string]pipeline.RepositoryInfo) {
+	if !g.config.Build.RepositoryMeta {
+		return
+	}
+	files := make(map[string][]string)
+	for i := range docFiles {
+		if !docFiles[i].IsAsset {
+			files[docFiles[i].Repository] = append(files[docFiles[i].Repository], docFiles[i].Path)
+		}
+	}
+	linter := lint.NewLinter(nil)
+	for repo, paths := range files {
+		info, ok := metadata[repo]
+		if !ok {
+			continue
+		}
+		result, err := linter.LintFiles(paths)
+		if err != nil {
+			g.log().Warn("Failed to lint repository for build information page",
+				slog.String("repository", repo),
+				slog.String("error", err.Error()))
+			continue
+		}
+		info.Lint = &pipeline.LintSummary{
+			Files:    result.FilesTotal,
+			Errors:   result.ErrorCount(),
+			Warnings: result.WarningCount(),
+		}
+		metadata[repo] = info
+	}
+}
@@ -0,0 +1,46 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+func TestAddLintSummaries(t *testing.T) {
+	src := t.TempDir()
+	good := filepath.Join(src, "guide.md")
+	bad := filepath.Join(src, "Bad Name.md")
+	for _, p := range []string{good, bad} {
+		if err := os.WriteFile(p, []byte("# Title\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	docFiles := []docs.DocFile{
+		{Repository: "api", Path: good},
+		{Repository: "api", Path: bad},
+		{Repository: "api", Path: filepath.Join(src, "logo.png"), IsAsset: true},
+	}
+
+	g := NewGenerator(&config.Config{Build: config.BuildConfig{RepositoryMeta: true}}, t.TempDir())
+	metadata := map[string]pipeline.RepositoryInfo{"api": {Name: "api"}}
+	g.addLintSummaries(docFiles, metadata)
+
+	summary := metadata["api"].Lint
+	if summary == nil {
+		t.Fatal("expected lint summary")
+	}
+	if summary.Files != 2 || summary.Errors == 0 {
+		t.Fatalf("unexpected lint summary: %+v", summary)
+	}
+
+	g.config.Build.RepositoryMeta = false
+	metadata = map[string]pipeline.RepositoryInfo{"api": {Name: "api"}}
+	g.addLintSummaries(docFiles, metadata)
+	if metadata["api"].Lint != nil {
+		t.Fatal("lint summary must not be collected when disabled")
+	}
+}
@@ -0,0 +1,166 @@
+package hugo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+// RepositoryStatsFile holds the per-repository docs statistics behind the README badges
+// (written to the output root next to build-report.json).
+const RepositoryStatsFile = "repository-stats.json"
+
+// RepositoryStats holds docs statistics per repository (or monorepo section).
+type RepositoryStats struct {
+	GeneratedAt  time.Time                     `json:"generated_at"`
+	Repositories map[string]RepositoryDocStats `json:"repositories"`
+}
+
+// RepositoryDocStats describes the documentation of one repository.
+type RepositoryDocStats struct {
+	Pages       int       `json:"pages"`
+	LastUpdated time.Time `json:"last_updated,omitzero"`
+	// SourceFiles counts the source code files outside the docs paths; nil when the
+	// working copy was not available to scan.
+	SourceFiles *int `json:"source_files,omitempty"`
+}
+
+// Coverage estimates the docs coverage in percent, expecting one page per filesPerPage
+// source files. ok is false when the source files were not counted.
+func (s RepositoryDocStats) Coverage(filesPerPage int) (percent int, ok bool) {
+	if s.SourceFiles == nil {
+		return 0, false
+	}
+	if *s.SourceFiles == 0 {
+		if s.Pages > 0 {
+			return 100, true
+		}
+		return 0, true
+	}
+	return min(100, s.Pages*max(filesPerPage, 1)*100 / *s.SourceFiles), true
+}
+
+// sourceFileExtensions are the file extensions counted as source code for the coverage badge.
+var sourceFileExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".scala": true, ".rs": true, ".c": true, ".h": true,
+	".cc": true, ".cpp": true, ".hpp": true, ".cs": true, ".rb": true, ".php": true,
+	".swift": true, ".sh": true, ".ex": true, ".erl": true, ".hs": true,
+}
+
+// skippedSourceDirs are directories holding dependencies or build output rather than
+// the repository's own code.
+var skippedSourceDirs = map[string]bool{
+	"vendor": true, "node_modules": true, "third_party": true, "dist": true, "build": true, "target": true,
+}
+
+// writeRepositoryStats persists the docs statistics used by the daemon's README badges.
+// It is a no-op unless daemon.badges is enabled.
+func (g *Generator) writeRepositoryStats(processed []*pipeline.Document, bs *models.BuildState) error {
+	if g.config.Daemon == nil || !g.config.Daemon.Badges.IsEnabled() {
+		return nil
+	}
+	stats := RepositoryStats{
+		GeneratedAt:  time.Now().UTC(),
+		Repositories: make(map[string]RepositoryDocStats),
+	}
+	for _, doc := range processed {
+		if doc.Generated || doc.Repository == "" {
+			continue
+		}
+		entry := stats.Repositories[doc.Repository]
+		entry.Pages++
+		modified, ok := pipeline.PageLastModified(doc)
+		if !ok {
+			modified = doc.CommitDate
+		}
+		if modified.After(entry.LastUpdated) {
+			entry.LastUpdated = modified.UTC()
+		}
+		stats.Repositories[doc.Repository] = entry
+	}
+
+	if bs != nil {
+		for i := range g.config.Repositories {
+			repo := &g.config.Repositories[i]
+			repoPath, ok := bs.Git.RepoPaths[repo.Name]
+			if !ok {
+				continue
+			}
+			docsPaths := slices.Clone(repo.Paths)
+			for _, section := range repo.Sections {
+				docsPaths = append(docsPaths, section.Path)
+			}
+			g.countRepositorySources(stats.Repositories, repo.Name, repoPath, ".", docsPaths)
+			// A monorepo section covers the code next to its docs path.
+			for _, section := range repo.Sections {
+				g.countRepositorySources(stats.Repositories, section.Name, repoPath, path.Dir(section.Path), []string{section.Path})
+			}
+		}
+	}
+
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal repository stats: %w", err)
+	}
+	// #nosec G306 -- statistics are served publicly as badges
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), RepositoryStatsFile), b, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write repository stats: %w", herrors.ErrContentWriteFailed, err)
+	}
+	return nil
+}
+
+// countRepositorySources records the number of source files below codeDir of a
+// repository's working copy for a repository that has docs.
+func (g *Generator) countRepositorySources(repos map[string]RepositoryDocStats, name, repoPath, codeDir string, docsPaths []string) {
+	entry, ok := repos[name]
+	if !ok {
+		return
+	}
+	n, err := countSourceFiles(filepath.Join(repoPath, filepath.FromSlash(codeDir)), repoPath, docsPaths)
+	if err != nil {
+		g.log().Warn("Failed to count source files for badges",
+			slog.String("repository", name),
+			slog.String("error", err.Error()))
+		return
+	}
+	entry.SourceFiles = &n
+	repos[name] = entry
+}
+
+// countSourceFiles counts the source code files below root, skipping hidden and
+// dependency directories as well as the docs paths (relative to repoPath).
+func countSourceFiles(root, repoPath string, docsPaths []string) (int, error) {
+	skip := make(map[string]bool, len(docsPaths))
+	for _, p := range docsPaths {
+		skip[filepath.Join(repoPath, filepath.FromSlash(p))] = true
+	}
+	count := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(d.Name(), ".") || skippedSourceDirs[d.Name()] || skip[p]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && sourceFileExtensions[strings.ToLower(filepath.Ext(p))] {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,87 @@
+package hugo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+func TestWriteRepositoryStats(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, f := range []string{"main.go", "pkg/util.go", "pkg/util_test.go", "scripts/run.sh", "README.md",
+		"docs/example.go", "vendor/dep/dep.go", ".github/tool.py", "services/api/server.go", "services/api/docs/tool.go"} {
+		p := filepath.Join(repoPath, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{
+		Daemon: &config.DaemonConfig{Badges: &config.BadgesConfig{Enabled: true}},
+		Repositories: []config.Repository{{
+			Name:     "repo",
+			Paths:    []string{"docs"},
+			Sections: []config.RepositorySection{{Name: "api", Path: "services/api/docs"}},
+		}},
+	}
+	g := NewGenerator(cfg, t.TempDir())
+	bs := &models.BuildState{}
+	bs.Git.RepoPaths = map[string]string{"repo": repoPath}
+
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	processed := []*pipeline.Document{
+		{Path: "content/repo/a.md", Repository: "repo", CommitDate: older, FrontMatter: map[string]any{}},
+		{Path: "content/repo/b.md", Repository: "repo", GitHistory: &git.FileHistory{LastModified: newer}, FrontMatter: map[string]any{}},
+		{Path: "content/api/c.md", Repository: "api", CommitDate: older, FrontMatter: map[string]any{}},
+		{Path: "content/repo/_index.md", Repository: "repo", Generated: true, FrontMatter: map[string]any{}},
+	}
+	if err := g.writeRepositoryStats(processed, bs); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	b, err := os.ReadFile(filepath.Join(g.BuildRoot(), RepositoryStatsFile))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var stats RepositoryStats
+	if err := json.Unmarshal(b, &stats); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	repo := stats.Repositories["repo"]
+	if repo.Pages != 2 || !repo.LastUpdated.Equal(newer) {
+		t.Fatalf("unexpected repo stats: %+v", repo)
+	}
+	// main.go, pkg/util.go, pkg/util_test.go, scripts/run.sh, services/api/server.go
+	if repo.SourceFiles == nil || *repo.SourceFiles != 5 {
+		t.Fatalf("expected 5 source files outside docs, vendor and hidden dirs, got %+v", repo)
+	}
+	api := stats.Repositories["api"]
+	if api.Pages != 1 || api.SourceFiles == nil || *api.SourceFiles != 1 {
+		t.Fatalf("expected the section to cover its own code only, got %+v", api)
+	}
+	if pct, ok := repo.Coverage(2); !ok || pct != 80 {
+		t.Fatalf("expected 80%% coverage, got %d (%v)", pct, ok)
+	}
+}
+
+func TestWriteRepositoryStats_Disabled(t *testing.T) {
+	g := NewGenerator(&config.Config{}, t.TempDir())
+	if err := g.writeRepositoryStats([]*pipeline.Document{{Repository: "repo"}}, nil); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(g.BuildRoot(), RepositoryStatsFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected no stats file when badges are disabled, got %v", err)
+	}
+}
@@ -80,7 +80,8 @@ func classifyIssueCode(se *models.StageError, bs *models.BuildState) models.Repo
 		return classifyDiscoveryIssue(se, bs)
 	case models.StageRunHugo:
 		return classifyHugoIssue(se)
-	case models.StagePrepareOutput, models.StageGenerateConfig, models.StageLayouts, models.StageCopyContent, models.StageIndexes, models.StagePostProcess:
+	case models.StagePrepareOutput, models.StageCodeDocs, models.StageGenerateConfig, models.StageLayouts, models.StageCopyContent, models.StageIndexes, models.StageExport, models.StagePostProcess,
+		models.StagePreBuildHooks, models.StagePostCloneHooks, models.StagePreHugoHooks, models.StageValidateOutput, models.StageAccessibility:
 		// These stages use generic issue codes
 		return models.IssueGenericStageError
 	default:
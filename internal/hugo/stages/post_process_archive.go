@@ -0,0 +1,44 @@
+package stages
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/sitearchive"
+)
+
+// archiveSite packages the rendered public/ tree into a new offline archive, records it
+// in the report and prunes archives beyond the retention count.
+func archiveSite(cfg *config.Config, publicDir string, report *models.BuildReport) error {
+	a := cfg.SiteArchive()
+	if a == nil {
+		return nil
+	}
+	dir := cfg.ArchiveDirectory()
+	start := time.Now()
+	file, err := sitearchive.Write(publicDir, dir, sitearchive.Options{
+		Format:            a.ArchiveFormatOrDefault(),
+		Created:           start,
+		DocBuilderVersion: report.DocBuilderVersion,
+		ConfigHash:        report.ConfigHash,
+	})
+	if err != nil {
+		return fmt.Errorf("archive site: %w", err)
+	}
+	report.Archive = file
+	slog.Info("Archived site",
+		slog.String("archive", file),
+		slog.Duration("duration", time.Since(start)))
+
+	removed, err := sitearchive.Prune(dir, a.RetainCount())
+	if len(removed) > 0 {
+		slog.Info("Pruned site archives", slog.Int("removed", len(removed)), slog.Int("retain", a.RetainCount()))
+	}
+	if err != nil {
+		return fmt.Errorf("prune site archives: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package stages
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/integrity"
+)
+
+// protectSite adds subresource integrity attributes and writes (and signs) the checksum
+// file of the rendered public/ tree. It runs after every other change to the files.
+func protectSite(ctx context.Context, cfg *config.Config, publicDir string) error {
+	i := cfg.SiteIntegrity()
+	if i == nil {
+		return nil
+	}
+	if i.SRI {
+		basePath := "/"
+		if u, err := url.Parse(cfg.Hugo.BaseURL); err == nil && u.Path != "" {
+			basePath = u.Path
+		}
+		n, err := integrity.InjectSRI(publicDir, basePath)
+		if err != nil {
+			return err
+		}
+		slog.Info("Subresource integrity attributes added", slog.Int("tags", n))
+	}
+	if !i.ChecksumsEnabled() {
+		return nil
+	}
+	if err := integrity.WriteChecksums(publicDir); err != nil {
+		return err
+	}
+	if i.Sign != nil {
+		if err := integrity.Sign(ctx, publicDir, i.Sign); err != nil {
+			return err
+		}
+		slog.Info("Signed site checksums",
+			slog.String("tool", string(i.Sign.Tool)),
+			slog.String("signature", integrity.SignatureFile(i.Sign.Tool)))
+	}
+	return nil
+}
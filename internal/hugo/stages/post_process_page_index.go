@@ -0,0 +1,78 @@
+package stages
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// maxTitleScanBytes bounds how much of a page is read to find its <title>.
+const maxTitleScanBytes = 64 << 10
+
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// writePageIndex lists the rendered pages of publicDir (every index.html) with their
+// titles in root/page-index.json.
+func writePageIndex(publicDir, root string) error {
+	index := models.PageIndex{GeneratedAt: time.Now().UTC(), Pages: []models.PageIndexEntry{}}
+	err := filepath.WalkDir(publicDir, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "index.html" {
+			return nil
+		}
+		rel, err := filepath.Rel(publicDir, filepath.Dir(fp))
+		if err != nil {
+			return err
+		}
+		sitePath := "/"
+		if rel != "." {
+			sitePath = "/" + filepath.ToSlash(rel) + "/"
+		}
+		index.Pages = append(index.Pages, models.PageIndexEntry{Path: sitePath, Title: pageTitle(fp)})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("page index: %w", err)
+	}
+	sort.Slice(index.Pages, func(i, j int) bool { return index.Pages[i].Path < index.Pages[j].Path })
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("page index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, models.PageIndexFile), data, 0o644); err != nil {
+		return fmt.Errorf("page index: %w", err)
+	}
+	return nil
+}
+
+// pageTitle returns the <title> of a rendered page, or "" when it has none.
+func pageTitle(file string) string {
+	// #nosec G304 -- page of the rendered site
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	head, err := io.ReadAll(io.LimitReader(f, maxTitleScanBytes))
+	if err != nil {
+		return ""
+	}
+	m := htmlTitlePattern.FindSubmatch(head)
+	if m == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " ")
+}
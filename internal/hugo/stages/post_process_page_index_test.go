@@ -0,0 +1,34 @@
+package stages
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestWritePageIndex(t *testing.T) {
+	root := t.TempDir()
+	public := filepath.Join(root, "public")
+	writeFile(t, filepath.Join(public, "index.html"), "<html><head><title>Docs</title></head></html>")
+	writeFile(t, filepath.Join(public, "guide", "install", "index.html"), "<html><head><title>\n  Install &amp; Upgrade :: Docs\n</title></head></html>")
+	writeFile(t, filepath.Join(public, "404.html"), "<html><head><title>404</title></head></html>")
+	writeFile(t, filepath.Join(public, "css", "site.css"), "body{}")
+	writeFile(t, filepath.Join(public, "untitled", "index.html"), "<html><body>no title</body></html>")
+
+	require.NoError(t, writePageIndex(public, root))
+
+	data, err := os.ReadFile(filepath.Join(root, models.PageIndexFile))
+	require.NoError(t, err)
+	var index models.PageIndex
+	require.NoError(t, json.Unmarshal(data, &index))
+	require.Equal(t, []models.PageIndexEntry{
+		{Path: "/", Title: "Docs"},
+		{Path: "/guide/install/", Title: "Install & Upgrade :: Docs"},
+		{Path: "/untitled/"},
+	}, index.Pages)
+}
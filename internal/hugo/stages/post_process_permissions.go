@@ -0,0 +1,46 @@
+package stages
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// applyOutputPermissions sets the configured modes and owner (output.file_mode,
+// output.dir_mode, output.uid and output.gid) on root and everything below it.
+// Symbolic links are left alone.
+func applyOutputPermissions(out config.OutputConfig, root string) error {
+	if !out.SetsPermissions() {
+		return nil
+	}
+	fileMode, setFileMode := out.FilePerm()
+	dirMode, setDirMode := out.DirPerm()
+	uid, gid, chown := out.Owner()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if chown {
+			if err := os.Lchown(path, uid, gid); err != nil {
+				return err
+			}
+		}
+		switch {
+		case d.IsDir() && setDirMode:
+			return os.Chmod(path, dirMode)
+		case d.Type().IsRegular() && setFileMode:
+			return os.Chmod(path, fileMode)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("output permissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package stages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestApplyOutputPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX permissions")
+	}
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "public", "guide"), 0o700))
+	page := filepath.Join(root, "public", "guide", "index.html")
+	require.NoError(t, os.WriteFile(page, []byte("<h1>Guide</h1>"), 0o600))
+	require.NoError(t, os.Symlink(page, filepath.Join(root, "public", "latest.html")))
+
+	// Changing the owner to the current user needs no privileges.
+	uid, gid := os.Getuid(), os.Getgid()
+	out := config.OutputConfig{FileMode: "0644", DirMode: "0755", UID: &uid, GID: &gid}
+	bs := models.NewBuildState(validateGenerator{cfg: &config.Config{Output: out}, root: root}, nil, &models.BuildReport{})
+	require.NoError(t, StagePostProcess(context.Background(), bs))
+
+	for path, want := range map[string]os.FileMode{
+		root:                                   0o755,
+		filepath.Join(root, "public", "guide"): 0o755,
+		page:                                   0o644,
+	} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, want, info.Mode().Perm(), path)
+	}
+}
+
+func TestApplyOutputPermissions_Unset(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "index.html")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o600))
+
+	require.NoError(t, applyOutputPermissions(config.OutputConfig{}, root))
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
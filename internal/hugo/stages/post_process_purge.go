@@ -0,0 +1,142 @@
+package stages
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// sitemapDocument reads both a <urlset> and a <sitemapindex> (multilingual sites).
+type sitemapDocument struct {
+	URLs     []sitemapURL `xml:"url"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// changedURLs compares the sitemap of the new site with the one of the published site it
+// replaces and returns the URLs of pages that were added, removed or whose rendered file
+// differs. It returns nil when there is nothing to compare against.
+func changedURLs(cfg *config.Config, publicDir, previousDir string) ([]string, error) {
+	if len(cfg.PurgeHooks()) == 0 {
+		return nil, nil
+	}
+	basePath := "/"
+	if u, err := url.Parse(strings.TrimSpace(cfg.Hugo.BaseURL)); err == nil && u.Path != "" {
+		basePath = u.Path
+	}
+	next, err := sitemapPages(publicDir, basePath)
+	if err != nil {
+		return nil, fmt.Errorf("read sitemap: %w", err)
+	}
+	if next == nil {
+		slog.Warn("No sitemap.xml in the rendered site; skipping purge of changed pages")
+		return nil, nil
+	}
+	prev, err := sitemapPages(previousDir, basePath)
+	if err != nil {
+		slog.Warn("Cannot read the previously published sitemap; nothing to purge", slog.String("error", err.Error()))
+		return nil, nil //nolint:nilerr // a broken previous site must not fail the new build
+	}
+	if prev == nil {
+		slog.Info("No previously published sitemap; nothing to purge", slog.String("previous", previousDir))
+		return nil, nil
+	}
+
+	var changed []string
+	for loc, rel := range next {
+		if prevRel, ok := prev[loc]; !ok || !sameFile(filepath.Join(publicDir, rel), filepath.Join(previousDir, prevRel)) {
+			changed = append(changed, loc)
+		}
+	}
+	for loc := range prev {
+		if _, ok := next[loc]; !ok {
+			changed = append(changed, loc)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// sitemapPages maps the page URLs of the sitemap in dir to their rendered files (relative
+// to dir). Child sitemaps of a sitemap index are read from dir as well. It returns nil
+// when dir has no sitemap.
+func sitemapPages(dir, basePath string) (map[string]string, error) {
+	// #nosec G304 -- dir is the rendered site
+	data, err := os.ReadFile(filepath.Join(dir, "sitemap.xml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var doc sitemapDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	pages := make(map[string]string)
+	addPages(pages, doc.URLs, basePath)
+	for _, child := range doc.Sitemaps {
+		rel := siteFileForLoc(child.Loc, basePath)
+		if rel == "" {
+			continue
+		}
+		// #nosec G304 -- rel is confined to the rendered site by siteFileForLoc
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			continue
+		}
+		var sub sitemapDocument
+		if err := xml.Unmarshal(data, &sub); err != nil {
+			return nil, fmt.Errorf("%s: %w", rel, err)
+		}
+		addPages(pages, sub.URLs, basePath)
+	}
+	return pages, nil
+}
+
+func addPages(pages map[string]string, urls []sitemapURL, basePath string) {
+	for _, u := range urls {
+		loc := strings.TrimSpace(u.Loc)
+		if rel := siteFileForLoc(loc, basePath); rel != "" {
+			pages[loc] = rel
+		}
+	}
+}
+
+// siteFileForLoc returns the file below public/ that serves a sitemap URL: index.html for
+// directory URLs, the file itself otherwise. It returns "" for URLs outside the site.
+func siteFileForLoc(loc, basePath string) string {
+	u, err := url.Parse(strings.TrimSpace(loc))
+	if err != nil {
+		return ""
+	}
+	p := u.Path
+	if base := "/" + strings.Trim(basePath, "/"); base != "/" {
+		if p != base && !strings.HasPrefix(p, base+"/") {
+			return ""
+		}
+		p = strings.TrimPrefix(p, base)
+	}
+	rel := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if strings.HasSuffix(p, "/") || p == "" || path.Ext(rel) == "" {
+		rel = path.Join(rel, "index.html")
+	}
+	return filepath.FromSlash(rel)
+}
+
+// sameFile reports whether both files exist and have the same content.
+func sameFile(a, b string) bool {
+	// #nosec G304 -- both paths are below rendered sites
+	da, errA := os.ReadFile(a)
+	// #nosec G304 -- both paths are below rendered sites
+	db, errB := os.ReadFile(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
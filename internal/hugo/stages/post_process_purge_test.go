@@ -0,0 +1,77 @@
+package stages
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func sitemapOf(locs ...string) string {
+	s := `<?xml version="1.0" encoding="utf-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`
+	for _, loc := range locs {
+		s += "<url><loc>" + loc + "</loc></url>"
+	}
+	return s + "</urlset>"
+}
+
+func TestChangedURLs(t *testing.T) {
+	const base = "https://docs.example.com/docs/"
+	prev, next := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(prev, "sitemap.xml"), sitemapOf(base, base+"same/", base+"edited/", base+"gone/", base+"feed.xml"))
+	writeFile(t, filepath.Join(prev, "index.html"), "home")
+	writeFile(t, filepath.Join(prev, "same", "index.html"), "same")
+	writeFile(t, filepath.Join(prev, "edited", "index.html"), "old")
+	writeFile(t, filepath.Join(prev, "gone", "index.html"), "gone")
+	writeFile(t, filepath.Join(prev, "feed.xml"), "<rss/>")
+
+	writeFile(t, filepath.Join(next, "sitemap.xml"), sitemapOf(base, base+"same/", base+"edited/", base+"new/", base+"feed.xml"))
+	writeFile(t, filepath.Join(next, "index.html"), "home")
+	writeFile(t, filepath.Join(next, "same", "index.html"), "same")
+	writeFile(t, filepath.Join(next, "edited", "index.html"), "new")
+	writeFile(t, filepath.Join(next, "new", "index.html"), "new")
+	writeFile(t, filepath.Join(next, "feed.xml"), "<rss><item/></rss>")
+
+	cfg := &config.Config{
+		Hugo:  config.HugoConfig{BaseURL: "https://docs.example.com/docs"},
+		Purge: &config.PurgeConfig{Hooks: []config.PurgeHook{{Type: config.PurgeWebhook, URL: "https://cdn.example.com/purge"}}},
+	}
+	changed, err := changedURLs(cfg, next, prev)
+	require.NoError(t, err)
+	assert.Equal(t, []string{base + "edited/", base + "feed.xml", base + "gone/", base + "new/"}, changed)
+
+	changed, err = changedURLs(cfg, next, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Nil(t, changed, "nothing to compare against on the first publish")
+
+	cfg.Purge = nil
+	changed, err = changedURLs(cfg, next, prev)
+	require.NoError(t, err)
+	assert.Nil(t, changed, "no diff without purge hooks")
+}
+
+func TestChangedURLs_FollowsSitemapIndex(t *testing.T) {
+	const base = "https://docs.example.com/"
+	index := `<?xml version="1.0" encoding="utf-8"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<sitemap><loc>https://docs.example.com/en/sitemap.xml</loc></sitemap>
+<sitemap><loc>https://docs.example.com/de/sitemap.xml</loc></sitemap></sitemapindex>`
+	prev, next := t.TempDir(), t.TempDir()
+	for dir, de := range map[string]string{prev: "alt", next: "neu"} {
+		writeFile(t, filepath.Join(dir, "sitemap.xml"), index)
+		writeFile(t, filepath.Join(dir, "en", "sitemap.xml"), sitemapOf(base+"en/guide/"))
+		writeFile(t, filepath.Join(dir, "de", "sitemap.xml"), sitemapOf(base+"de/guide/"))
+		writeFile(t, filepath.Join(dir, "en", "guide", "index.html"), "guide")
+		writeFile(t, filepath.Join(dir, "de", "guide", "index.html"), de)
+	}
+
+	cfg := &config.Config{
+		Hugo:  config.HugoConfig{BaseURL: base},
+		Purge: &config.PurgeConfig{Hooks: []config.PurgeHook{{Type: config.PurgeFastly, Token: "k"}}},
+	}
+	changed, err := changedURLs(cfg, next, prev)
+	require.NoError(t, err)
+	assert.Equal(t, []string{base + "de/guide/"}, changed)
+}
@@ -0,0 +1,276 @@
+package stages
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+const sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// sitemapURLSet mirrors the <urlset> document Hugo emits for a single-language site.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// seoPostProcessor applies sitemap, robots.txt and canonical link policies to a rendered public/ tree.
+type seoPostProcessor struct {
+	publicDir string
+	base      *url.URL
+	seo       *config.SEOConfig
+}
+
+// newSEOPostProcessor returns nil when SEO post-processing is not configured or impossible
+// (no base URL to anchor absolute URLs).
+func newSEOPostProcessor(cfg *config.Config, publicDir string) *seoPostProcessor {
+	if cfg == nil || cfg.Hugo.SEO == nil {
+		return nil
+	}
+	raw := strings.TrimSpace(cfg.Hugo.BaseURL)
+	base, err := url.Parse(raw)
+	if raw == "" || err != nil || base.Scheme == "" || base.Host == "" {
+		slog.Warn("SEO post-processing requires an absolute hugo.base_url; skipping", slog.String("base_url", raw))
+		return nil
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return &seoPostProcessor{publicDir: publicDir, base: base, seo: cfg.Hugo.SEO}
+}
+
+func (p *seoPostProcessor) run() error {
+	if p.seo.Sitemap {
+		if err := p.processSitemap(); err != nil {
+			return fmt.Errorf("sitemap: %w", err)
+		}
+	}
+	if p.seo.Robots != nil {
+		if err := p.writeRobots(); err != nil {
+			return fmt.Errorf("robots.txt: %w", err)
+		}
+	}
+	if p.seo.Canonical {
+		if err := p.injectCanonicalLinks(); err != nil {
+			return fmt.Errorf("canonical links: %w", err)
+		}
+	}
+	return nil
+}
+
+// absolute resolves a site path (as served from public/) against the base URL.
+func (p *seoPostProcessor) absolute(sitePath string) string {
+	u := *p.base
+	u.Path = path.Join(p.base.Path, sitePath)
+	if strings.HasSuffix(sitePath, "/") && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u.String()
+}
+
+// sitePathFromLoc extracts the path relative to the base URL from a sitemap <loc>,
+// tolerating locs generated against another environment's base URL.
+func (p *seoPostProcessor) sitePathFromLoc(loc string) string {
+	u, err := url.Parse(strings.TrimSpace(loc))
+	if err != nil {
+		return ""
+	}
+	sp := u.Path
+	if strings.HasPrefix(sp, p.base.Path) {
+		sp = "/" + strings.TrimPrefix(sp, p.base.Path)
+	}
+	if sp == "" {
+		sp = "/"
+	}
+	return sp
+}
+
+func (p *seoPostProcessor) disallowed(sitePath string) bool {
+	if p.seo.Robots == nil {
+		return false
+	}
+	for _, prefix := range p.seo.Robots.Disallow {
+		if strings.HasPrefix(sitePath, strings.TrimSpace(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// processSitemap rewrites <loc> entries to the configured base URL and drops disallowed paths.
+// When Hugo did not emit a sitemap, one is generated from the rendered index.html files.
+func (p *seoPostProcessor) processSitemap() error {
+	sitemapPath := filepath.Join(p.publicDir, "sitemap.xml")
+	set := sitemapURLSet{XMLNS: sitemapXMLNS}
+
+	// #nosec G304 -- path is inside the build output directory
+	data, err := os.ReadFile(sitemapPath)
+	switch {
+	case err == nil:
+		if uerr := xml.Unmarshal(data, &set); uerr != nil {
+			return fmt.Errorf("parse %s: %w", sitemapPath, uerr)
+		}
+	case os.IsNotExist(err):
+		pages, werr := p.renderedPages()
+		if werr != nil {
+			return werr
+		}
+		for _, sp := range pages {
+			set.URLs = append(set.URLs, sitemapURL{Loc: sp})
+		}
+	default:
+		return err
+	}
+
+	kept := set.URLs[:0]
+	rewritten := 0
+	for _, u := range set.URLs {
+		sp := p.sitePathFromLoc(u.Loc)
+		if sp == "" || p.disallowed(sp) {
+			continue
+		}
+		loc := p.absolute(sp)
+		if loc != u.Loc {
+			rewritten++
+		}
+		u.Loc = loc
+		kept = append(kept, u)
+	}
+	dropped := len(set.URLs) - len(kept)
+	set.URLs = kept
+	set.XMLNS = sitemapXMLNS
+
+	out, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append([]byte(xml.Header), out...)
+	// #nosec G306 -- sitemap is a public site file
+	if err := os.WriteFile(sitemapPath, out, 0o644); err != nil {
+		return err
+	}
+	slog.Info("Sitemap post-processed",
+		slog.Int("urls", len(set.URLs)),
+		slog.Int("rewritten", rewritten),
+		slog.Int("dropped", dropped))
+	return nil
+}
+
+// renderedPages lists site paths for every index.html under public/.
+func (p *seoPostProcessor) renderedPages() ([]string, error) {
+	var pages []string
+	err := filepath.WalkDir(p.publicDir, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "index.html" {
+			return nil
+		}
+		rel, rerr := filepath.Rel(p.publicDir, filepath.Dir(fp))
+		if rerr != nil {
+			return rerr
+		}
+		sp := "/"
+		if rel != "." {
+			sp = "/" + filepath.ToSlash(rel) + "/"
+		}
+		pages = append(pages, sp)
+		return nil
+	})
+	sort.Strings(pages)
+	return pages, err
+}
+
+func (p *seoPostProcessor) writeRobots() error {
+	r := p.seo.Robots
+	ua := strings.TrimSpace(r.UserAgent)
+	if ua == "" {
+		ua = "*"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "User-agent: %s\n", ua)
+	for _, a := range r.Allow {
+		fmt.Fprintf(&sb, "Allow: %s\n", strings.TrimSpace(a))
+	}
+	for _, d := range r.Disallow {
+		fmt.Fprintf(&sb, "Disallow: %s\n", strings.TrimSpace(d))
+	}
+	if p.seo.Sitemap {
+		fmt.Fprintf(&sb, "\nSitemap: %s\n", p.absolute("/sitemap.xml"))
+	}
+	// #nosec G306 -- robots.txt is a public site file
+	return os.WriteFile(filepath.Join(p.publicDir, "robots.txt"), []byte(sb.String()), 0o644)
+}
+
+var (
+	canonicalMarker = []byte(`rel="canonical"`)
+	headCloseTag    = []byte("</head>")
+)
+
+// injectCanonicalLinks adds a canonical link to every rendered page that does not declare one.
+// This pins namespaced repository paths (and any duplicate routes) to a single absolute URL.
+func (p *seoPostProcessor) injectCanonicalLinks() error {
+	injected := 0
+	err := filepath.WalkDir(p.publicDir, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".html") {
+			return nil
+		}
+		// #nosec G304 -- path is inside the build output directory
+		data, rerr := os.ReadFile(fp)
+		if rerr != nil {
+			return rerr
+		}
+		if bytes.Contains(data, canonicalMarker) {
+			return nil
+		}
+		idx := bytes.Index(data, headCloseTag)
+		if idx < 0 {
+			return nil
+		}
+		rel, rerr := filepath.Rel(p.publicDir, fp)
+		if rerr != nil {
+			return rerr
+		}
+		sp := "/" + filepath.ToSlash(rel)
+		if d.Name() == "index.html" {
+			sp = strings.TrimSuffix(sp, "index.html")
+		}
+		tag := fmt.Sprintf(`<link rel="canonical" href="%s">`, p.absolute(sp))
+		out := make([]byte, 0, len(data)+len(tag))
+		out = append(out, data[:idx]...)
+		out = append(out, tag...)
+		out = append(out, data[idx:]...)
+		info, serr := d.Info()
+		if serr != nil {
+			return serr
+		}
+		if werr := os.WriteFile(fp, out, info.Mode().Perm()); werr != nil {
+			return werr
+		}
+		injected++
+		return nil
+	})
+	if err == nil {
+		slog.Info("Canonical links injected", slog.Int("pages", injected))
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package stages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestSEOPostProcessor_RewritesSitemapAndWritesRobots(t *testing.T) {
+	public := t.TempDir()
+	writeFile(t, filepath.Join(public, "sitemap.xml"), `<?xml version="1.0" encoding="utf-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>http://localhost:1313/docs/repo/guide/</loc><lastmod>2025-01-01</lastmod></url>
+  <url><loc>http://localhost:1313/docs/previews/pr-1/</loc></url>
+</urlset>`)
+
+	cfg := &config.Config{Hugo: config.HugoConfig{
+		BaseURL: "https://docs.example.com/docs",
+		SEO: &config.SEOConfig{
+			Sitemap: true,
+			Robots:  &config.RobotsConfig{Disallow: []string{"/previews/"}},
+		},
+	}}
+	p := newSEOPostProcessor(cfg, public)
+	require.NotNil(t, p)
+	require.NoError(t, p.run())
+
+	sitemap, err := os.ReadFile(filepath.Join(public, "sitemap.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sitemap), "<loc>https://docs.example.com/docs/repo/guide/</loc>")
+	assert.Contains(t, string(sitemap), "<lastmod>2025-01-01</lastmod>")
+	assert.NotContains(t, string(sitemap), "previews")
+
+	robots, err := os.ReadFile(filepath.Join(public, "robots.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "User-agent: *\nDisallow: /previews/\n\nSitemap: https://docs.example.com/docs/sitemap.xml\n", string(robots))
+}
+
+func TestSEOPostProcessor_GeneratesSitemapAndCanonical(t *testing.T) {
+	public := t.TempDir()
+	writeFile(t, filepath.Join(public, "index.html"), "<html><head><title>x</title></head></html>")
+	writeFile(t, filepath.Join(public, "repo", "page", "index.html"), "<html><head></head><body></body></html>")
+	writeFile(t, filepath.Join(public, "repo", "alias", "index.html"), `<html><head><link rel="canonical" href="https://docs.example.com/repo/page/"></head></html>`)
+
+	cfg := &config.Config{Hugo: config.HugoConfig{
+		BaseURL: "https://docs.example.com/",
+		SEO:     &config.SEOConfig{Sitemap: true, Canonical: true},
+	}}
+	p := newSEOPostProcessor(cfg, public)
+	require.NotNil(t, p)
+	require.NoError(t, p.run())
+
+	sitemap, err := os.ReadFile(filepath.Join(public, "sitemap.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sitemap), "<loc>https://docs.example.com/</loc>")
+	assert.Contains(t, string(sitemap), "<loc>https://docs.example.com/repo/page/</loc>")
+
+	page, err := os.ReadFile(filepath.Join(public, "repo", "page", "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `<link rel="canonical" href="https://docs.example.com/repo/page/"></head>`)
+
+	alias, err := os.ReadFile(filepath.Join(public, "repo", "alias", "index.html"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(alias), "canonical"))
+}
+
+func TestNewSEOPostProcessor_RequiresBaseURL(t *testing.T) {
+	cfg := &config.Config{Hugo: config.HugoConfig{SEO: &config.SEOConfig{Sitemap: true}}}
+	assert.Nil(t, newSEOPostProcessor(cfg, t.TempDir()))
+	assert.Nil(t, newSEOPostProcessor(&config.Config{}, t.TempDir()))
+}
@@ -10,7 +10,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"git.home.luguber.info/inful/docbuilder/internal/config"
 	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/proclimit"
 )
 
 // Renderer abstracts how the final static site rendering step is performed after
@@ -27,7 +29,28 @@ import (
 // Errors returned are surfaced as warnings (non-fatal) unless future policy changes.
 
 // BinaryRenderer invokes the `hugo` binary present on PATH.
-type BinaryRenderer struct{}
+type BinaryRenderer struct {
+	Limits   *config.ResourceLimitsConfig // optional build.resources limits for the hugo process
+	warnings []string                     // WARN lines of the last run
+}
+
+// Warnings returns the WARN lines hugo logged during the last Execute.
+func (b *BinaryRenderer) Warnings() []string {
+	return b.warnings
+}
+
+// hugoWarnings extracts hugo's WARN log lines (without the level prefix) from its output.
+func hugoWarnings(outputs ...string) []string {
+	var warnings []string
+	for _, out := range outputs {
+		for line := range strings.SplitSeq(out, "\n") {
+			if msg, ok := strings.CutPrefix(strings.TrimSpace(line), "WARN"); ok {
+				warnings = append(warnings, strings.TrimSpace(msg))
+			}
+		}
+	}
+	return warnings
+}
 
 // getEnvValue returns the value of the environment variable identified by key
 // from the provided env slice, which contains entries in "KEY=VALUE" form.
@@ -135,11 +158,12 @@ func (b *BinaryRenderer) Execute(ctx context.Context, rootDir string) error {
 	cmd.Stderr = &stderr
 	slog.Debug("BinaryRenderer invoking hugo", "dir", rootDir)
 
-	err = cmd.Run()
+	err = proclimit.Run(cmd, b.Limits)
 
 	// Always log Hugo output when non-empty to diagnose issues
 	outStr := stdout.String()
 	errStr := stderr.String()
+	b.warnings = hugoWarnings(outStr, errStr)
 	if outStr != "" {
 		// Log each line separately to avoid escaped newlines
 		for line := range strings.SplitSeq(strings.TrimSpace(outStr), "\n") {
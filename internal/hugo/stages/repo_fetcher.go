@@ -13,6 +13,7 @@ import (
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
+	"git.home.luguber.info/inful/docbuilder/internal/staticsource"
 )
 
 // RepoFetchResult captures the outcome path and (optional) pre/post head commits for change detection.
@@ -23,7 +24,8 @@ type RepoFetchResult struct {
 	PostHead   string    // empty if clone/update failed to resolve
 	CommitDate time.Time // commit date of PostHead
 	Err        error
-	Updated    bool // true if repository contents potentially changed (clone, new commits, or forced reset)
+	Updated    bool  // true if repository contents potentially changed (clone, new commits, or forced reset)
+	Repaired   error // cache corruption found before reuse; the repository was cloned again
 }
 
 // RepoFetcher defines cloning/updating behavior abstracted from stage logic so future
@@ -39,6 +41,7 @@ type RepoFetcher interface {
 type defaultRepoFetcher struct {
 	workspace string
 	buildCfg  *config.BuildConfig
+	logger    *slog.Logger // git component logger (nil uses slog.Default)
 }
 
 // NewDefaultRepoFetcher creates a new default repository fetcher (exported for commands package).
@@ -46,16 +49,58 @@ func NewDefaultRepoFetcher(workspace string, buildCfg *config.BuildConfig) RepoF
 	return &defaultRepoFetcher{workspace: workspace, buildCfg: buildCfg}
 }
 
-func (f *defaultRepoFetcher) Fetch(_ context.Context, strategy config.CloneStrategy, repo config.Repository) RepoFetchResult {
-	res := RepoFetchResult{Name: repo.Name}
-	client := git.NewClient(f.workspace)
+// newLoggingRepoFetcher creates a default repository fetcher whose git operations log through logger.
+func newLoggingRepoFetcher(workspace string, buildCfg *config.BuildConfig, logger *slog.Logger) RepoFetcher {
+	return &defaultRepoFetcher{workspace: workspace, buildCfg: buildCfg, logger: logger}
+}
+
+func (f *defaultRepoFetcher) Fetch(ctx context.Context, strategy config.CloneStrategy, repo config.Repository) RepoFetchResult {
+	if repo.IsStatic() {
+		return f.fetchStatic(ctx, repo)
+	}
+	client := git.NewClient(f.workspace).WithLogger(f.logger)
 	if f.buildCfg != nil {
 		client = client.WithBuildConfig(f.buildCfg)
 	}
+	if repo.FetchesArchive() {
+		if res, ok := f.fetchArchive(ctx, client, repo); ok {
+			return res
+		}
+	}
+	// Working copies that are about to be reused are verified first; a corrupted
+	// one is removed and cloned again. Worktrees are verified by the git client.
+	var repaired error
+	if repo.WorktreeOf == "" && (strategy != config.CloneStrategyFresh || repo.ReuseWorkingCopy || repo.TargetCommit() != "") {
+		repaired = verifyCachedRepo(client, filepath.Join(f.workspace, repo.Name), repo)
+	}
+	res := f.fetch(ctx, client, strategy, repo)
+	if res.Repaired == nil {
+		res.Repaired = repaired
+	}
+	return res
+}
+
+func (f *defaultRepoFetcher) fetch(_ context.Context, client *git.Client, strategy config.CloneStrategy, repo config.Repository) RepoFetchResult {
+	res := RepoFetchResult{Name: repo.Name}
+
+	// Scoped builds: out-of-scope repositories are rendered from the cached working copy.
+	if repo.ReuseWorkingCopy {
+		if reused, ok := reuseWorkingCopy(f.workspace, repo); ok {
+			return reused
+		}
+		client.Logger().Info("No cached working copy for out-of-scope repository; fetching",
+			slog.String("repo", repo.Name))
+	}
 
-	// Snapshot builds: if a specific commit SHA is pinned for this repo, ensure the
-	// working copy is checked out at that exact commit.
-	if repo.PinnedCommit != "" {
+	// Versions sharing one clone: check out (or move) the version's worktree. The
+	// worktree path handles pinned commits itself.
+	if repo.WorktreeOf != "" {
+		return f.fetchWorktree(client, repo)
+	}
+
+	// Snapshot builds, requested SHAs and configured commit pins: ensure the working
+	// copy is checked out at that exact commit.
+	if repo.TargetCommit() != "" {
 		return f.fetchPinnedCommit(client, strategy, repo)
 	}
 
@@ -104,18 +149,145 @@ func (f *defaultRepoFetcher) Fetch(_ context.Context, strategy config.CloneStrat
 	return res
 }
 
+// fetchStatic downloads a static source. Its content digest stands in for the head commit
+// and the download time for the commit date.
+func (f *defaultRepoFetcher) fetchStatic(ctx context.Context, repo config.Repository) RepoFetchResult {
+	res := RepoFetchResult{Name: repo.Name}
+	fetched, err := staticsource.NewFetcher(f.workspace).WithLogger(f.logger).Fetch(ctx, repo)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.Path = fetched.Path
+	res.PreHead = fetched.PreDigest
+	res.PostHead = fetched.Digest
+	res.CommitDate = fetched.FetchedAt
+	res.Updated = fetched.Updated
+	return res
+}
+
+// fetchArchive downloads a forge archive of the repository's commit instead of cloning
+// (fetch: archive). It reports false when the repository has to be cloned instead:
+// the forge does not serve archives, the repository needs git features (submodules,
+// LFS, code docs generators, version worktrees, tags), or the download failed.
+func (f *defaultRepoFetcher) fetchArchive(ctx context.Context, client *git.Client, repo config.Repository) (RepoFetchResult, bool) {
+	fallback := func(reason string) (RepoFetchResult, bool) {
+		client.Logger().Info("Cloning repository instead of downloading an archive",
+			slog.String("repo", repo.Name),
+			slog.String("reason", reason))
+		return RepoFetchResult{}, false
+	}
+	if repo.Submodules || repo.LFS || len(repo.CodeDocs) > 0 || repo.WorktreeOf != "" || (repo.Tag != "" && repo.TargetCommit() == "") {
+		return fallback("repository needs a git working copy")
+	}
+
+	commit := repo.TargetCommit()
+	if commit == "" {
+		head, err := client.GetRemoteHead(repo, repo.Branch)
+		if err != nil {
+			return fallback("resolve branch head: " + err.Error())
+		}
+		commit = head
+	}
+	archiveURL, err := git.ArchiveURL(repo, commit)
+	if err != nil {
+		return fallback(err.Error())
+	}
+	fetched, err := staticsource.NewFetcher(f.workspace).WithLogger(f.logger).FetchGitArchive(ctx, repo, staticsource.GitArchive{
+		URL:    archiveURL,
+		Commit: commit,
+		Paths:  repo.Paths,
+	})
+	if err != nil {
+		return fallback(err.Error())
+	}
+
+	res := RepoFetchResult{
+		Name:       repo.Name,
+		Path:       fetched.Path,
+		PreHead:    fetched.PreDigest,
+		PostHead:   commit,
+		CommitDate: fetched.Modified,
+		Updated:    fetched.Updated,
+	}
+	if res.CommitDate.IsZero() {
+		res.CommitDate = fetched.FetchedAt
+	}
+	return res, true
+}
+
+// verifyCachedRepo checks an existing working copy before reuse. A corrupted copy is
+// removed so that it is cloned again; the verification error is returned for the report.
+func verifyCachedRepo(client *git.Client, repoPath string, repo config.Repository) error {
+	if gitStatRepo(repoPath) != nil {
+		return nil
+	}
+	verr := git.VerifyRepository(repoPath, false)
+	if verr == nil {
+		return nil
+	}
+	client.Logger().Warn("Cached repository is corrupted; cloning it again",
+		slog.String("repo", repo.Name),
+		slog.String("path", repoPath),
+		slog.String("error", verr.Error()))
+	if err := os.RemoveAll(repoPath); err != nil {
+		client.Logger().Warn("Failed to remove corrupted repository",
+			slog.String("repo", repo.Name),
+			slog.String("error", err.Error()))
+	}
+	return verr
+}
+
+// reuseWorkingCopy returns the existing working copy of repo without fetching.
+// It reports false when there is no usable working copy.
+func reuseWorkingCopy(workspace string, repo config.Repository) (RepoFetchResult, bool) {
+	repoPath := filepath.Join(workspace, repo.Name)
+	if gitStatRepo(repoPath) != nil {
+		return RepoFetchResult{}, false
+	}
+	head, err := readRepoHead(repoPath)
+	if err != nil {
+		return RepoFetchResult{}, false
+	}
+	return RepoFetchResult{
+		Name:       repo.Name,
+		Path:       repoPath,
+		PreHead:    head,
+		PostHead:   head,
+		CommitDate: getCommitDate(repoPath, head),
+	}, true
+}
+
+// fetchWorktree checks out a version as a worktree of its repository's shared clone.
+func (f *defaultRepoFetcher) fetchWorktree(client *git.Client, repo config.Repository) RepoFetchResult {
+	res := RepoFetchResult{Name: repo.Name}
+	res.PreHead, _ = readRepoHead(filepath.Join(f.workspace, repo.Name))
+	result, err := client.CheckoutWorktree(repo)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.Path = result.Path
+	res.PostHead = result.CommitSHA
+	res.CommitDate = result.CommitDate
+	res.Repaired = result.Repaired
+	res.Updated = res.PreHead == "" || res.PreHead != res.PostHead
+	return res
+}
+
 func (f *defaultRepoFetcher) fetchPinnedCommit(client *git.Client, strategy config.CloneStrategy, repo config.Repository) RepoFetchResult {
 	res := RepoFetchResult{Name: repo.Name}
 	repoPath := filepath.Join(f.workspace, repo.Name)
+	pinned := repo.TargetCommit()
 
 	preHead, _ := readRepoHead(repoPath)
 	res.PreHead = preHead
 
 	// If we already have the desired commit checked out, skip fetch/update entirely.
-	if preHead != "" && preHead == repo.PinnedCommit {
+	if preHead != "" && preHead == pinned {
 		res.Path = repoPath
-		res.PostHead = repo.PinnedCommit
-		res.CommitDate = getCommitDate(repoPath, repo.PinnedCommit)
+		res.PostHead = pinned
+		res.CommitDate = getCommitDate(repoPath, pinned)
 		res.Updated = false
 		return res
 	}
@@ -124,17 +296,17 @@ func (f *defaultRepoFetcher) fetchPinnedCommit(client *git.Client, strategy conf
 	// commit object available, we can skip any clone/update and just force-checkout
 	// the desired commit.
 	if err := gitStatRepo(repoPath); err == nil {
-		if checkedOutAt, cerr := checkoutExactCommit(repoPath, repo.PinnedCommit); cerr == nil {
+		if checkedOutAt, cerr := checkoutExactCommit(repoPath, pinned); cerr == nil {
 			res.Path = repoPath
-			res.PostHead = repo.PinnedCommit
+			res.PostHead = pinned
 			res.CommitDate = checkedOutAt
-			res.Updated = preHead == "" || preHead != repo.PinnedCommit
+			res.Updated = preHead == "" || preHead != pinned
 			return res
 		} else {
-			slog.Debug("Pinned commit checkout fast-path failed; falling back to clone/update",
+			client.Logger().Debug("Pinned commit checkout fast-path failed; falling back to clone/update",
 				slog.String("repo", repo.Name),
 				slog.String("path", repoPath),
-				slog.String("pinned_commit", repo.PinnedCommit),
+				slog.String("pinned_commit", pinned),
 				slog.Any("error", cerr))
 		}
 	}
@@ -170,16 +342,23 @@ func (f *defaultRepoFetcher) fetchPinnedCommit(client *git.Client, strategy conf
 		return res
 	}
 
-	// Checkout exact pinned SHA (detached HEAD).
-	checkedOutAt, cerr := checkoutExactCommit(path, repo.PinnedCommit)
+	// Checkout exact pinned SHA (detached HEAD). A commit that is not on the fetched
+	// branch (or beyond the shallow depth) is fetched by SHA.
+	checkedOutAt, cerr := checkoutExactCommit(path, pinned)
 	if cerr != nil {
-		res.Err = cerr
-		return res
+		if ferr := client.FetchCommit(repo, pinned); ferr != nil {
+			res.Err = fmt.Errorf("%w (fetch by SHA: %w)", cerr, ferr)
+			return res
+		}
+		if checkedOutAt, cerr = checkoutExactCommit(path, pinned); cerr != nil {
+			res.Err = cerr
+			return res
+		}
 	}
 
-	res.PostHead = repo.PinnedCommit
+	res.PostHead = pinned
 	res.CommitDate = checkedOutAt
-	res.Updated = preHead == "" || preHead != repo.PinnedCommit
+	res.Updated = preHead == "" || preHead != pinned
 	return res
 }
 
@@ -229,7 +408,7 @@ func gitStatRepo(path string) error {
 // getCommitDate retrieves the commit date for a given commit hash in a repository.
 // Returns zero time if the commit date cannot be determined.
 func getCommitDate(repoPath, commitSHA string) time.Time {
-	repo, err := ggit.PlainOpen(repoPath)
+	repo, err := git.OpenRepository(repoPath)
 	if err != nil {
 		return time.Time{}
 	}
@@ -242,7 +421,7 @@ func getCommitDate(repoPath, commitSHA string) time.Time {
 }
 
 func checkoutExactCommit(repoPath, commitSHA string) (time.Time, error) {
-	repo, err := ggit.PlainOpen(repoPath)
+	repo, err := git.OpenRepository(repoPath)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("open repo for checkout: %w", err)
 	}
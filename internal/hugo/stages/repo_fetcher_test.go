@@ -1,6 +1,8 @@
 package stages
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -73,6 +75,35 @@ func TestDefaultRepoFetcher_FetchPinnedCommit_ChecksOutExactSHAAndSkipsWhenAlrea
 	require.Equal(t, commit1, head3)
 }
 
+func TestDefaultRepoFetcher_ReuseWorkingCopy_SkipsFetch(t *testing.T) {
+	remotePath, commit1, commit2 := initGitRepoWithTwoCommits(t)
+
+	workspace := t.TempDir()
+	fetcher := NewDefaultRepoFetcher(workspace, nil)
+
+	// Seed the working copy at the older commit.
+	seeded := fetcher.Fetch(t.Context(), config.CloneStrategyFresh, config.Repository{
+		Name: "repo-1", URL: remotePath, Branch: "master", PinnedCommit: commit1,
+	})
+	require.NoError(t, seeded.Err)
+
+	// An out-of-scope repository keeps its cached commit even though the remote moved on.
+	reused := fetcher.Fetch(t.Context(), config.CloneStrategyUpdate, config.Repository{
+		Name: "repo-1", URL: remotePath, Branch: "master", ReuseWorkingCopy: true,
+	})
+	require.NoError(t, reused.Err)
+	require.Equal(t, seeded.Path, reused.Path)
+	require.Equal(t, commit1, reused.PostHead)
+	require.False(t, reused.Updated)
+
+	// Without a working copy the repository is fetched as usual.
+	fetched := fetcher.Fetch(t.Context(), config.CloneStrategyUpdate, config.Repository{
+		Name: "repo-2", URL: remotePath, Branch: "master", ReuseWorkingCopy: true,
+	})
+	require.NoError(t, fetched.Err)
+	require.Equal(t, commit2, fetched.PostHead)
+}
+
 func initGitRepoWithTwoCommits(t *testing.T) (repoPath, commit1, commit2 string) {
 	t.Helper()
 
@@ -108,3 +139,60 @@ func initGitRepoWithTwoCommits(t *testing.T) (repoPath, commit1, commit2 string)
 
 	return repoPath, commit1, commit2
 }
+
+func TestDefaultRepoFetcher_CorruptedCache_ClonesAgain(t *testing.T) {
+	remotePath, _, commit2 := initGitRepoWithTwoCommits(t)
+
+	workspace := t.TempDir()
+	fetcher := NewDefaultRepoFetcher(workspace, nil)
+	repoCfg := config.Repository{Name: "repo-1", URL: remotePath, Branch: "master"}
+
+	res1 := fetcher.Fetch(t.Context(), config.CloneStrategyAuto, repoCfg)
+	require.NoError(t, res1.Err)
+	require.NoError(t, res1.Repaired)
+
+	// Simulate an interrupted fetch that left truncated pack files behind.
+	packs, err := filepath.Glob(filepath.Join(res1.Path, ".git", "objects", "pack", "*.pack"))
+	require.NoError(t, err)
+	require.NotEmpty(t, packs)
+	for _, p := range packs {
+		require.NoError(t, os.Truncate(p, 0))
+	}
+
+	res2 := fetcher.Fetch(t.Context(), config.CloneStrategyAuto, repoCfg)
+	require.NoError(t, res2.Err)
+	require.Error(t, res2.Repaired)
+	require.Equal(t, commit2, res2.PostHead)
+	require.NoError(t, gitpkg.VerifyRepository(res2.Path, true))
+}
+
+func TestDefaultRepoFetcher_FetchStaticSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# API\n"))
+	}))
+	defer ts.Close()
+
+	workspace := t.TempDir()
+	repoCfg := config.Repository{
+		Name:   "api",
+		Type:   config.RepositoryTypeStatic,
+		Static: &config.StaticSource{Files: []config.StaticFile{{URL: ts.URL + "/openapi.md"}}},
+	}
+	res := NewDefaultRepoFetcher(workspace, nil).Fetch(t.Context(), config.CloneStrategyAuto, repoCfg)
+	require.NoError(t, res.Err)
+	require.True(t, res.Updated)
+	require.NotEmpty(t, res.PostHead)
+	require.False(t, res.CommitDate.IsZero())
+	require.FileExists(t, filepath.Join(workspace, "api", "openapi.md"))
+}
+
+func TestDefaultRepoFetcher_ArchiveFetchFallsBackToClone(t *testing.T) {
+	remotePath, _, commit2 := initGitRepoWithTwoCommits(t)
+
+	workspace := t.TempDir()
+	repoCfg := config.Repository{Name: "repo-1", URL: remotePath, Branch: "master", Fetch: config.FetchModeArchive}
+	res := NewDefaultRepoFetcher(workspace, nil).Fetch(t.Context(), config.CloneStrategyAuto, repoCfg)
+	require.NoError(t, res.Err)
+	require.Equal(t, commit2, res.PostHead)
+	require.DirExists(t, filepath.Join(res.Path, ".git"))
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
 )
 
 // RunStages executes stages in order, recording timing and stopping on first fatal error.
@@ -31,7 +34,7 @@ func RunStages(ctx context.Context, bs *models.BuildState, stages []models.Stage
 		}
 
 		t0 := time.Now()
-		err := st.Fn(ctx, bs)
+		err := runStage(ctx, bs, st)
 		dur := time.Since(t0)
 
 		bs.Report.StageDurations[string(st.Name)] = dur
@@ -74,3 +77,14 @@ func RunStages(ctx context.Context, bs *models.BuildState, stages []models.Stage
 
 	return nil
 }
+
+// runStage executes one stage, converting a panic into a fatal stage error so the
+// build fails cleanly instead of crashing the process.
+func runStage(ctx context.Context, bs *models.BuildState, st models.StageDef) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = crash.Default().Recovered("stage:"+string(st.Name), observability.BuildID(ctx), rec, debug.Stack())
+		}
+	}()
+	return st.Fn(ctx, bs)
+}
@@ -0,0 +1,358 @@
+package stages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+)
+
+// AccessibilityReportJSON is the per-page accessibility report, written to the output
+// root next to build-report.json.
+const AccessibilityReportJSON = "accessibility-report.json"
+
+// accessibilitySnippetLen bounds the element text quoted in a violation.
+const accessibilitySnippetLen = 60
+
+// genericLinkTexts are link texts that do not describe the link target.
+var genericLinkTexts = []string{
+	"click", "click here", "here", "link", "this", "this link", "more", "read more", "learn more", "continue", "details",
+}
+
+// AccessibilityReport lists the accessibility violations of the rendered site per page.
+type AccessibilityReport struct {
+	GeneratedAt  time.Time           `json:"generated_at"`
+	PagesChecked int                 `json:"pages_checked"`
+	Errors       int                 `json:"errors"`
+	Warnings     int                 `json:"warnings"`
+	Pages        []AccessibilityPage `json:"pages"` // pages with violations, by path
+}
+
+// AccessibilityPage lists the violations found on one page.
+type AccessibilityPage struct {
+	Path       string                   `json:"path"` // HTML file relative to public/
+	Errors     int                      `json:"errors"`
+	Warnings   int                      `json:"warnings"`
+	Violations []AccessibilityViolation `json:"violations"`
+}
+
+// AccessibilityViolation is a single finding of an accessibility rule.
+type AccessibilityViolation struct {
+	Rule     string `json:"rule"`     // image_alt | heading_order | link_text
+	Severity string `json:"severity"` // error | warning
+	Element  string `json:"element"`  // offending element, abbreviated
+	Detail   string `json:"detail"`
+}
+
+// ChecksAccessibility reports whether the check_accessibility stage runs.
+func ChecksAccessibility(cfg *config.Config) bool {
+	return cfg != nil && cfg.Build.Accessibility.ChecksAccessibility()
+}
+
+// StageCheckAccessibility scans the rendered HTML for missing alternative text,
+// skipped heading levels and links without meaningful text. Findings are written to
+// accessibility-report.json and summarized in the build report; the build fails when
+// the violations counted by build.accessibility.fail_on exceed max_violations.
+func StageCheckAccessibility(_ context.Context, bs *models.BuildState) error {
+	if !bs.Report.StaticRendered {
+		return nil
+	}
+	policy := bs.Generator.Config().Build.Accessibility
+	root := bs.Generator.BuildRoot()
+
+	report, err := auditAccessibility(filepath.Join(root, "public"), policy, time.Now())
+	if err != nil {
+		return models.NewWarnStageError(models.StageAccessibility, err)
+	}
+	jb, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return models.NewWarnStageError(models.StageAccessibility, fmt.Errorf("marshal accessibility report: %w", err))
+	}
+	// #nosec G306 -- report contains public page structure only
+	if err := os.WriteFile(filepath.Join(root, AccessibilityReportJSON), jb, 0o644); err != nil {
+		return models.NewWarnStageError(models.StageAccessibility, fmt.Errorf("write accessibility report: %w", err))
+	}
+
+	summary := &models.AccessibilitySummary{
+		PagesChecked:        report.PagesChecked,
+		PagesWithViolations: len(report.Pages),
+		Errors:              report.Errors,
+		Warnings:            report.Warnings,
+	}
+	counted := 0
+	for _, page := range report.Pages {
+		for _, v := range page.Violations {
+			if summary.Rules == nil {
+				summary.Rules = make(map[string]int)
+			}
+			summary.Rules[v.Rule]++
+			if policy.CountsTowardFailure(config.AccessibilitySeverity(v.Severity)) {
+				counted++
+			}
+		}
+	}
+	bs.Report.Accessibility = summary
+	if report.Errors+report.Warnings == 0 {
+		return nil
+	}
+
+	msg := accessibilityMessage(report)
+	bs.Generator.Logger(logging.ComponentHugo).Warn("Accessibility check found violations",
+		slog.Int("pages", len(report.Pages)),
+		slog.Int("errors", report.Errors),
+		slog.Int("warnings", report.Warnings),
+		slog.String("detail", msg))
+
+	if policy.FailOn != "" && counted > policy.MaxViolations {
+		summary.Failed = true
+		return models.NewFatalStageError(models.StageAccessibility,
+			ferrors.WrapError(herrors.ErrAccessibilityThresholdExceeded, ferrors.CategoryBuild,
+				fmt.Sprintf("%d accessibility violation(s) at or above %s exceed max_violations %d; see %s",
+					counted, policy.FailOn, policy.MaxViolations, AccessibilityReportJSON)).
+				WithContext("violations", counted).
+				WithContext("max_violations", policy.MaxViolations).
+				Build())
+	}
+	issue := ferrors.NewError(ferrors.CategoryBuild, msg).
+		Warning().
+		WithContext("errors", report.Errors).
+		WithContext("warnings", report.Warnings).
+		Build()
+	bs.Report.AddIssue(models.IssueAccessibility, models.StageAccessibility, models.SeverityWarning, msg, false, issue)
+	return nil
+}
+
+// auditAccessibility checks every HTML file below publicDir with the rules enabled in policy.
+func auditAccessibility(publicDir string, policy *config.AccessibilityConfig, now time.Time) (*AccessibilityReport, error) {
+	report := &AccessibilityReport{GeneratedAt: now.UTC(), Pages: []AccessibilityPage{}}
+	err := filepath.WalkDir(publicDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".html") {
+			return nil
+		}
+		// #nosec G304 -- p is a file found below the rendered site
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		doc, err := html.Parse(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", p, err)
+		}
+		report.PagesChecked++
+
+		var violations []AccessibilityViolation
+		for _, v := range checkAccessibility(doc) {
+			if policy.RuleEnabled(config.AccessibilityRule(v.Rule)) {
+				violations = append(violations, v)
+			}
+		}
+		if len(violations) == 0 {
+			return nil
+		}
+		rel, _ := filepath.Rel(publicDir, p)
+		page := AccessibilityPage{Path: filepath.ToSlash(rel), Violations: violations}
+		for _, v := range violations {
+			if v.Severity == string(config.AccessibilityError) {
+				page.Errors++
+			} else {
+				page.Warnings++
+			}
+		}
+		report.Errors += page.Errors
+		report.Warnings += page.Warnings
+		report.Pages = append(report.Pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan rendered site: %w", err)
+	}
+	return report, nil
+}
+
+// checkAccessibility applies all rules to a parsed page, in document order. Subtrees
+// hidden from assistive technology (aria-hidden="true") are skipped.
+func checkAccessibility(doc *html.Node) []AccessibilityViolation {
+	var violations []AccessibilityViolation
+	add := func(rule config.AccessibilityRule, severity config.AccessibilitySeverity, n *html.Node, detail string) {
+		violations = append(violations, AccessibilityViolation{
+			Rule:     string(rule),
+			Severity: string(severity),
+			Element:  describeElement(n),
+			Detail:   detail,
+		})
+	}
+
+	lastHeading := 0
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if htmlAttr(n, "aria-hidden") == "true" {
+				return
+			}
+			switch n.DataAtom {
+			case atom.Img, atom.Area:
+				if !htmlHasAttr(n, "alt") && !hasAccessibleLabel(n) && !isPresentational(n) {
+					add(config.AccessibilityImageAlt, config.AccessibilityError, n, "image has no alt attribute; use alt=\"\" for decorative images")
+				}
+			case atom.Input:
+				if strings.EqualFold(htmlAttr(n, "type"), "image") && strings.TrimSpace(htmlAttr(n, "alt")) == "" && !hasAccessibleLabel(n) {
+					add(config.AccessibilityImageAlt, config.AccessibilityError, n, "image button has no alt text")
+				}
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				level := int(n.Data[1] - '0')
+				if lastHeading > 0 && level > lastHeading+1 {
+					add(config.AccessibilityHeadingOrder, config.AccessibilityWarning, n,
+						fmt.Sprintf("h%d follows h%d; heading levels should increase by one", level, lastHeading))
+				}
+				lastHeading = level
+			case atom.A:
+				// Names from aria-labelledby are not resolved; such links are assumed labelled.
+				if htmlHasAttr(n, "href") && strings.TrimSpace(htmlAttr(n, "aria-labelledby")) == "" {
+					switch name := linkName(n); {
+					case name == "":
+						add(config.AccessibilityLinkText, config.AccessibilityError, n, "link has no text")
+					case slices.Contains(genericLinkTexts, normalizeLinkText(name)):
+						add(config.AccessibilityLinkText, config.AccessibilityWarning, n,
+							fmt.Sprintf("link text %q does not describe the target", name))
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return violations
+}
+
+// linkName returns the accessible name of a link: its aria-label, else its text
+// (including the alt text of contained images), else its title.
+func linkName(n *html.Node) string {
+	if label := strings.TrimSpace(htmlAttr(n, "aria-label")); label != "" {
+		return label
+	}
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(c *html.Node) {
+		switch {
+		case c.Type == html.TextNode:
+			sb.WriteString(c.Data)
+			sb.WriteByte(' ')
+		case c.Type == html.ElementNode && htmlAttr(c, "aria-hidden") == "true":
+			return
+		case c.Type == html.ElementNode && c.DataAtom == atom.Img:
+			sb.WriteString(htmlAttr(c, "alt"))
+			sb.WriteByte(' ')
+		}
+		for cc := c.FirstChild; cc != nil; cc = cc.NextSibling {
+			collect(cc)
+		}
+	}
+	collect(n)
+	if text := strings.Join(strings.Fields(sb.String()), " "); text != "" {
+		return text
+	}
+	return strings.TrimSpace(htmlAttr(n, "title"))
+}
+
+// normalizeLinkText lower-cases link text and strips surrounding punctuation.
+func normalizeLinkText(s string) string {
+	return strings.Trim(strings.ToLower(s), " .:…»›→>")
+}
+
+func hasAccessibleLabel(n *html.Node) bool {
+	return strings.TrimSpace(htmlAttr(n, "aria-label")) != "" || strings.TrimSpace(htmlAttr(n, "aria-labelledby")) != ""
+}
+
+func isPresentational(n *html.Node) bool {
+	role := htmlAttr(n, "role")
+	return role == "presentation" || role == "none"
+}
+
+func htmlHasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// describeElement abbreviates n for the report, keeping the attribute that identifies it.
+func describeElement(n *html.Node) string {
+	var key string
+	switch n.DataAtom {
+	case atom.Img, atom.Input:
+		key = "src"
+	case atom.Area, atom.A:
+		key = "href"
+	}
+	var sb strings.Builder
+	sb.WriteString("<" + n.Data)
+	if v := htmlAttr(n, key); key != "" && v != "" {
+		sb.WriteString(" " + key + "=" + strconv.Quote(truncateSnippet(v)))
+	}
+	sb.WriteString(">")
+	if n.DataAtom != atom.Img && n.DataAtom != atom.Input && n.DataAtom != atom.Area {
+		var text strings.Builder
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				text.WriteString(c.Data)
+			}
+		}
+		sb.WriteString(truncateSnippet(strings.Join(strings.Fields(text.String()), " ")))
+		sb.WriteString("</" + n.Data + ">")
+	}
+	return sb.String()
+}
+
+func truncateSnippet(s string) string {
+	if r := []rune(s); len(r) > accessibilitySnippetLen {
+		return string(r[:accessibilitySnippetLen]) + "…"
+	}
+	return s
+}
+
+// accessibilityMessage summarizes the report for logs and report issues.
+func accessibilityMessage(report *AccessibilityReport) string {
+	samples := make([]string, 0, outputIssueSamples)
+	for _, p := range report.Pages[:min(len(report.Pages), outputIssueSamples)] {
+		samples = append(samples, p.Path)
+	}
+	more := ""
+	if len(report.Pages) > outputIssueSamples {
+		more = fmt.Sprintf(" and %d more", len(report.Pages)-outputIssueSamples)
+	}
+	return fmt.Sprintf("%d accessibility error(s) and %d warning(s) on %d page(s): %s%s",
+		report.Errors, report.Warnings, len(report.Pages), strings.Join(samples, "; "), more)
+}
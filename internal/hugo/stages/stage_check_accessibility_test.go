@@ -0,0 +1,102 @@
+package stages
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestCheckAccessibility(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+<h1>Guide</h1>
+<img src="/logo.png">
+<img src="/spacer.gif" alt="">
+<img src="/icon.svg" role="presentation">
+<h3>Install</h3>
+<p><a href="/install/">Click here</a> or <a href="/docs/">read the docs</a>.</p>
+<a href="/home/"><img src="/home.png"></a>
+<a href="/x/"><img src="/x.png" alt="Close"></a>
+<a href="/y/" aria-label="Settings"><span class="icon"></span></a>
+<a id="no-href"></a>
+<nav aria-hidden="true"><a href="/hidden/"></a></nav>
+<h2>Next</h2>
+</body></html>`))
+	require.NoError(t, err)
+
+	var got []string
+	for _, v := range checkAccessibility(doc) {
+		got = append(got, v.Rule+"|"+v.Severity+"|"+v.Element)
+	}
+	assert.Equal(t, []string{
+		`image_alt|error|<img src="/logo.png">`,
+		`heading_order|warning|<h3>Install</h3>`,
+		`link_text|warning|<a href="/install/">Click here</a>`,
+		`link_text|error|<a href="/home/"></a>`,
+		`image_alt|error|<img src="/home.png">`,
+	}, got)
+}
+
+func TestStageCheckAccessibility_Threshold(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "public", "index.html"), `<h1>Home</h1><a href="/a/">here</a>`)
+	writeFile(t, filepath.Join(root, "public", "svc", "index.html"), `<h1>Svc</h1><img src="a.png"><h4>Deep</h4>`)
+	writeFile(t, filepath.Join(root, "public", "clean", "index.html"), `<h1>Clean</h1>`)
+
+	cfg := &config.Config{Build: config.BuildConfig{Accessibility: &config.AccessibilityConfig{
+		Enabled:       true,
+		FailOn:        config.AccessibilityError,
+		MaxViolations: 1,
+	}}}
+	require.True(t, ChecksAccessibility(cfg))
+
+	bs := models.NewBuildState(validateGenerator{cfg: cfg, root: root}, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Report.StaticRendered = true
+	require.NoError(t, StageCheckAccessibility(t.Context(), bs))
+	assert.Equal(t, &models.AccessibilitySummary{
+		PagesChecked:        3,
+		PagesWithViolations: 2,
+		Errors:              1,
+		Warnings:            2,
+		Rules:               map[string]int{"image_alt": 1, "heading_order": 1, "link_text": 1},
+	}, bs.Report.Accessibility)
+	require.Len(t, bs.Report.Issues, 1)
+	assert.Equal(t, models.IssueAccessibility, bs.Report.Issues[0].Code)
+
+	data, err := os.ReadFile(filepath.Join(root, AccessibilityReportJSON))
+	require.NoError(t, err)
+	var report AccessibilityReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Pages, 2)
+	assert.Equal(t, "index.html", report.Pages[0].Path)
+	assert.Equal(t, "svc/index.html", report.Pages[1].Path)
+	assert.Equal(t, 1, report.Pages[1].Errors)
+
+	// Counting warnings too exceeds the threshold and fails the build.
+	cfg.Build.Accessibility.FailOn = config.AccessibilityWarning
+	bs = models.NewBuildState(validateGenerator{cfg: cfg, root: root}, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Report.StaticRendered = true
+	err = StageCheckAccessibility(t.Context(), bs)
+	var se *models.StageError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, models.StageErrorFatal, se.Kind)
+	require.ErrorIs(t, err, herrors.ErrAccessibilityThresholdExceeded)
+	assert.True(t, bs.Report.Accessibility.Failed)
+
+	// Ignored rules are neither reported nor counted.
+	cfg.Build.Accessibility.IgnoreRules = []config.AccessibilityRule{config.AccessibilityHeadingOrder, config.AccessibilityLinkText}
+	bs = models.NewBuildState(validateGenerator{cfg: cfg, root: root}, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Report.StaticRendered = true
+	require.NoError(t, StageCheckAccessibility(t.Context(), bs))
+	assert.Equal(t, 1, bs.Report.Accessibility.Errors)
+	assert.Zero(t, bs.Report.Accessibility.Warnings)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 	gitpkg "git.home.luguber.info/inful/docbuilder/internal/git"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
 )
 
 func StageCloneRepos(ctx context.Context, bs *models.BuildState) error {
@@ -23,7 +25,7 @@ func StageCloneRepos(ctx context.Context, bs *models.BuildState) error {
 	if bs.Git.WorkspaceDir == "" {
 		return models.NewFatalStageError(models.StageCloneRepos, stdErrors.New("workspace directory not set"))
 	}
-	fetcher := NewDefaultRepoFetcher(bs.Git.WorkspaceDir, &bs.Generator.Config().Build)
+	fetcher := newLoggingRepoFetcher(bs.Git.WorkspaceDir, &bs.Generator.Config().Build, bs.Generator.Logger(logging.ComponentGit))
 	// Ensure workspace directory structure (previously via git client)
 	if err := os.MkdirAll(bs.Git.WorkspaceDir, 0o750); err != nil {
 		return models.NewFatalStageError(models.StageCloneRepos, fmt.Errorf("ensure workspace: %w", err))
@@ -37,10 +39,7 @@ func StageCloneRepos(ctx context.Context, bs *models.BuildState) error {
 	bs.Git.RepoPaths = make(map[string]string, len(bs.Git.Repositories))
 	bs.Git.PreHeads = make(map[string]string, len(bs.Git.Repositories))
 	bs.Git.PostHeads = make(map[string]string, len(bs.Git.Repositories))
-	concurrency := 1
-	if bs.Generator != nil && bs.Generator.Config().Build.CloneConcurrency > 0 {
-		concurrency = bs.Generator.Config().Build.CloneConcurrency
-	}
+	concurrency := cloneConcurrency(bs)
 	if concurrency > len(bs.Git.Repositories) {
 		concurrency = len(bs.Git.Repositories)
 	}
@@ -101,6 +100,10 @@ func StageCloneRepos(ctx context.Context, bs *models.BuildState) error {
 		return models.NewCanceledStageError(models.StageCloneRepos, ctx.Err())
 	default:
 	}
+	pruneStaleWorktrees(bs)
+	slices.SortFunc(bs.Report.Commits, func(a, b models.RepositoryCommit) int {
+		return strings.Compare(a.Repository, b.Repository)
+	})
 	bs.Git.AllReposUnchanged = bs.Git.AllReposUnchangedComputed()
 	if bs.Git.AllReposUnchanged {
 		slog.Info("No repository head changes detected", slog.Int("repos", len(bs.Git.PostHeads)))
@@ -114,6 +117,53 @@ func StageCloneRepos(ctx context.Context, bs *models.BuildState) error {
 	return nil
 }
 
+// pruneStaleWorktrees removes worktrees of versions that are no longer built from
+// the shared clones used by versioning.worktrees. Failures are logged only.
+func pruneStaleWorktrees(bs *models.BuildState) {
+	keep := map[string][]string{}
+	var bases []string
+	for i := range bs.Git.Repositories {
+		r := &bs.Git.Repositories[i]
+		if r.WorktreeOf == "" {
+			continue
+		}
+		if _, ok := keep[r.WorktreeOf]; !ok {
+			bases = append(bases, r.WorktreeOf)
+		}
+		keep[r.WorktreeOf] = append(keep[r.WorktreeOf], r.Name)
+	}
+	if len(bases) == 0 {
+		return
+	}
+	client := gitpkg.NewClient(bs.Git.WorkspaceDir)
+	if bs.Generator != nil {
+		client = client.WithLogger(bs.Generator.Logger(logging.ComponentGit))
+	}
+	for _, base := range bases {
+		if err := client.PruneWorktrees(base, keep[base]); err != nil {
+			client.Logger().Warn("Failed to prune stale worktrees", slog.String("repo", base), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// cloneConcurrency returns the number of parallel fetch workers. Versioned builds may
+// raise it to versioning.concurrency: every version clones into its own workspace
+// directory, so versions are fetched independently.
+func cloneConcurrency(bs *models.BuildState) int {
+	concurrency := 1
+	if bs.Generator == nil {
+		return concurrency
+	}
+	cfg := bs.Generator.Config()
+	if cfg.Build.CloneConcurrency > 0 {
+		concurrency = cfg.Build.CloneConcurrency
+	}
+	if slices.ContainsFunc(bs.Git.Repositories, func(r config.Repository) bool { return r.IsVersioned }) {
+		concurrency = max(concurrency, cfg.Versioning.EffectiveConcurrency())
+	}
+	return concurrency
+}
+
 // classifyGitFailure inspects an error string for permanent git failure signatures.
 func classifyGitFailure(err error) models.ReportIssueCode {
 	if err == nil {
@@ -171,10 +221,21 @@ func recordCloneSuccess(bs *models.BuildState, repo config.Repository, res RepoF
 	bs.Git.RepoPaths[repo.Name] = res.Path
 	if res.PostHead != "" {
 		bs.Git.SetPostHead(repo.Name, res.PostHead)
+		bs.Report.Commits = append(bs.Report.Commits, models.RepositoryCommit{
+			Repository: repo.Name,
+			URL:        repo.URL,
+			Ref:        repo.Branch,
+			Commit:     res.PostHead,
+			Pinned:     repo.TargetCommit() != "",
+		})
 	}
 	if res.PreHead != "" {
 		bs.Git.SetPreHead(repo.Name, res.PreHead)
 	}
+	if res.Repaired != nil {
+		bs.Report.AddIssue(models.IssueCacheCorrupted, models.StageCloneRepos, models.SeverityWarning,
+			fmt.Sprintf("%s: corrupted cache was cloned again: %v", repo.Name, res.Repaired), false, res.Repaired)
+	}
 }
 
 // recordCloneFailure updates build state after a failed repository clone.
@@ -0,0 +1,129 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// codeDocsTimeout bounds a single documentation generator run.
+const codeDocsTimeout = 10 * time.Minute
+
+// HasCodeDocs reports whether any repository declares code_docs generators.
+func HasCodeDocs(repos []config.Repository) bool {
+	for i := range repos {
+		if len(repos[i].CodeDocs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StageCodeDocs runs the code_docs generators of the cloned repositories so that their
+// markdown output is picked up by discovery. A failing generator is reported as a warning;
+// the repository is still built with its other documentation.
+func StageCodeDocs(ctx context.Context, bs *models.BuildState) error {
+	var failed []string
+	for i := range bs.Git.Repositories {
+		repo := &bs.Git.Repositories[i]
+		repoPath, ok := bs.Git.RepoPaths[repo.Name]
+		if len(repo.CodeDocs) == 0 || !ok {
+			continue
+		}
+		// Scoped builds reuse the working copy of out-of-scope repositories, including
+		// the output generated by an earlier build.
+		if repo.ReuseWorkingCopy {
+			continue
+		}
+		for _, g := range repo.CodeDocs {
+			select {
+			case <-ctx.Done():
+				return models.NewCanceledStageError(models.StageCodeDocs, ctx.Err())
+			default:
+			}
+			start := time.Now()
+			if err := runCodeDocsGenerator(ctx, repoPath, g); err != nil {
+				slog.Warn("Code docs generator failed",
+					slog.String("repo", repo.Name),
+					slog.String("tool", string(g.Tool)),
+					slog.String("error", err.Error()))
+				failed = append(failed, fmt.Sprintf("%s (%s): %v", repo.Name, g.Tool, err))
+				continue
+			}
+			slog.Info("Generated code docs",
+				slog.String("repo", repo.Name),
+				slog.String("tool", string(g.Tool)),
+				slog.String("output", g.Output),
+				slog.Duration("duration", time.Since(start)))
+		}
+	}
+	if len(failed) > 0 {
+		return models.NewWarnStageError(models.StageCodeDocs,
+			fmt.Errorf("%d code docs generator(s) failed: %s", len(failed), strings.Join(failed, "; ")))
+	}
+	return nil
+}
+
+// runCodeDocsGenerator replaces the generator's output directory with freshly generated markdown.
+func runCodeDocsGenerator(ctx context.Context, repoPath string, g config.CodeDocsGenerator) error {
+	outDir := filepath.Join(repoPath, filepath.FromSlash(g.Output))
+	if err := os.RemoveAll(outDir); err != nil {
+		return fmt.Errorf("clear output: %w", err)
+	}
+	if err := os.MkdirAll(outDir, 0o750); err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+
+	name, args := codeDocsCommand(g)
+	runCtx, cancel := context.WithTimeout(ctx, codeDocsTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, name, args...) // #nosec G204 -- generator command comes from the configuration
+	cmd.Dir = repoPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", codeDocsTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// codeDocsCommand returns the executable and arguments of a generator run. Paths are
+// relative to the repository root, which is the working directory.
+func codeDocsCommand(g config.CodeDocsGenerator) (string, []string) {
+	name := g.Command
+	if name == "" {
+		name = string(g.Tool)
+	}
+	var args []string
+	switch g.Tool {
+	case config.CodeDocsGomarkdoc:
+		// One README.md per package directory; discovery turns READMEs into section indexes.
+		args = []string{"--output", g.Output + "/{{.Dir}}/README.md"}
+		args = append(args, g.Args...)
+		if len(g.Packages) == 0 {
+			args = append(args, "./...")
+		}
+		args = append(args, g.Packages...)
+	case config.CodeDocsTypedoc:
+		args = []string{"--plugin", "typedoc-plugin-markdown", "--out", g.Output}
+		args = append(args, g.Args...)
+		args = append(args, g.Packages...)
+	}
+	return name, args
+}
@@ -0,0 +1,55 @@
+package stages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestCodeDocsCommand(t *testing.T) {
+	name, args := codeDocsCommand(config.CodeDocsGenerator{Tool: config.CodeDocsGomarkdoc, Output: "docs/reference/go"})
+	assert.Equal(t, "gomarkdoc", name)
+	assert.Equal(t, []string{"--output", "docs/reference/go/{{.Dir}}/README.md", "./..."}, args)
+
+	name, args = codeDocsCommand(config.CodeDocsGenerator{
+		Tool: config.CodeDocsTypedoc, Command: "npx", Output: "docs/api", Packages: []string{"src/index.ts"}, Args: []string{"--readme", "none"},
+	})
+	assert.Equal(t, "npx", name)
+	assert.Equal(t, []string{"--plugin", "typedoc-plugin-markdown", "--out", "docs/api", "--readme", "none", "src/index.ts"}, args)
+}
+
+func TestStageCodeDocs_RunsGeneratorsAndWarnsOnFailure(t *testing.T) {
+	repoPath := t.TempDir()
+	stale := filepath.Join(repoPath, "docs", "reference", "go", "old.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o750))
+	require.NoError(t, os.WriteFile(stale, []byte("old"), 0o600))
+
+	// A stand-in for gomarkdoc: writes the README named by its --output argument.
+	script := filepath.Join(t.TempDir(), "gen.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nout=$(echo \"$2\" | sed 's#/{{.Dir}}##')\necho '# pkg' > \"$out\"\n"), 0o700)) // #nosec G306 -- test script must be executable
+
+	bs := models.NewBuildState(nil, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Git.Repositories = []config.Repository{{Name: "svc", CodeDocs: []config.CodeDocsGenerator{
+		{Tool: config.CodeDocsGomarkdoc, Command: script, Output: "docs/reference/go"},
+		{Tool: config.CodeDocsTypedoc, Command: filepath.Join(t.TempDir(), "missing"), Output: "docs/reference/ts"},
+	}}}
+	bs.Git.RepoPaths = map[string]string{"svc": repoPath}
+	require.True(t, HasCodeDocs(bs.Git.Repositories))
+
+	err := StageCodeDocs(t.Context(), bs)
+	var se *models.StageError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, models.StageErrorWarning, se.Kind)
+	assert.Contains(t, err.Error(), "svc (typedoc)")
+
+	assert.NoFileExists(t, stale)
+	data, err := os.ReadFile(filepath.Join(repoPath, "docs", "reference", "go", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# pkg\n", string(data))
+}
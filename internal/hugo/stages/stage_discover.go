@@ -49,6 +49,7 @@ func StageDiscoverDocs(ctx context.Context, bs *models.BuildState) error {
 	}
 	bs.Report.Repositories = len(repoSet)
 	bs.Report.Files = len(docFiles)
+	bs.Report.Versions = summarizeVersions(bs, docFiles)
 	persistDiscoveredDocsToState(bs, docFiles)
 	if bs.Report != nil {
 		paths := make([]string, 0, len(docFiles))
@@ -67,6 +68,36 @@ func StageDiscoverDocs(ctx context.Context, bs *models.BuildState) error {
 	return nil
 }
 
+// summarizeVersions aggregates clone and discovery results per repository version,
+// in repository order. It returns nil when no repository was expanded by versioning.
+func summarizeVersions(bs *models.BuildState, docFiles []docs.DocFile) []models.VersionBuild {
+	files := make(map[string]int)
+	for i := range docFiles {
+		files[docFiles[i].Repository]++
+	}
+	var versions []models.VersionBuild
+	for i := range bs.Git.Repositories {
+		r := &bs.Git.Repositories[i]
+		if !r.IsVersioned {
+			continue
+		}
+		base := r.Name
+		if b, ok := r.Tags["base_repo"]; ok {
+			base = b
+		}
+		_, cloned := bs.Git.RepoPaths[r.Name]
+		versions = append(versions, models.VersionBuild{
+			Repository: base,
+			Version:    r.Version,
+			Ref:        r.Branch,
+			Tag:        r.IsTag,
+			Cloned:     cloned,
+			Files:      files[r.Name],
+		})
+	}
+	return versions
+}
+
 func persistDiscoveredDocsToState(bs *models.BuildState, docFiles []docs.DocFile) {
 	if bs.Generator == nil {
 		return
@@ -81,6 +112,14 @@ func persistDiscoveredDocsToState(bs *models.BuildState, docFiles []docs.DocFile
 		r := &bs.Git.Repositories[i]
 		repoCfgByName[r.Name] = *r
 	}
+	// Monorepo section docs are tracked under the repository that owns them.
+	repoBySection := make(map[string]string)
+	for i := range bs.Git.Repositories {
+		r := &bs.Git.Repositories[i]
+		for _, section := range r.Sections {
+			repoBySection[section.Name] = r.Name
+		}
+	}
 	init, _ := sm.(interface {
 		EnsureRepositoryState(url, name, branch string)
 	})
@@ -88,7 +127,11 @@ func persistDiscoveredDocsToState(bs *models.BuildState, docFiles []docs.DocFile
 	for i := range docFiles {
 		f := &docFiles[i]
 		p := f.GetHugoPath(bs.Docs.IsSingleRepo)
-		pathsByRepo[f.Repository] = append(pathsByRepo[f.Repository], p)
+		repoName := f.Repository
+		if owner, ok := repoBySection[repoName]; ok {
+			repoName = owner
+		}
+		pathsByRepo[repoName] = append(pathsByRepo[repoName], p)
 	}
 	for repoName, paths := range pathsByRepo {
 		sort.Strings(paths)
@@ -0,0 +1,30 @@
+package stages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestSummarizeVersions_AggregatesPerVersion(t *testing.T) {
+	bs := models.NewBuildState(nil, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Git.Repositories = []config.Repository{
+		{Name: "plain"},
+		{Name: "api-v2", Branch: "v2.0.0", Version: "v2", IsVersioned: true, IsTag: true, Tags: map[string]string{"base_repo": "api"}},
+		{Name: "api-main", Branch: "main", Version: "main", IsVersioned: true, Tags: map[string]string{"base_repo": "api"}},
+	}
+	bs.Git.RepoPaths = map[string]string{"plain": "/ws/plain", "api-v2": "/ws/api-v2"}
+	files := []docs.DocFile{{Repository: "api-v2"}, {Repository: "api-v2"}, {Repository: "plain"}}
+
+	assert.Equal(t, []models.VersionBuild{
+		{Repository: "api", Version: "v2", Ref: "v2.0.0", Tag: true, Cloned: true, Files: 2},
+		{Repository: "api", Version: "main", Ref: "main", Cloned: false, Files: 0},
+	}, summarizeVersions(bs, files))
+
+	bs.Git.Repositories = bs.Git.Repositories[:1]
+	assert.Nil(t, summarizeVersions(bs, files))
+}
@@ -0,0 +1,365 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// exportTimeout bounds a single pandoc run.
+const exportTimeout = 10 * time.Minute
+
+var (
+	// exportShortcodePattern matches Hugo shortcode tags, which pandoc cannot render.
+	exportShortcodePattern = regexp.MustCompile(`\{\{[<%].*?[%>]\}\}`)
+	// exportImagePattern matches the destination of a markdown image.
+	exportImagePattern = regexp.MustCompile(`(!\[[^\]]*\]\()([^)\s]+)`)
+	// exportHeadingPattern matches an ATX heading.
+	exportHeadingPattern = regexp.MustCompile(`^(#{1,6})(\s)`)
+)
+
+// ExportedRepositories maps the site repositories (and monorepo sections) that are
+// exported to PDF/EPUB to their configuration. Without build repositories (direct
+// generation) the configured ones are used.
+func ExportedRepositories(cfg *config.Config, repos []config.Repository) map[string]*config.Repository {
+	exported := make(map[string]*config.Repository)
+	if cfg == nil {
+		return exported
+	}
+	if len(repos) == 0 {
+		repos = cfg.Repositories
+	}
+	for i := range repos {
+		repo := &repos[i]
+		if len(repo.ExportFormats(cfg.Export)) == 0 {
+			continue
+		}
+		exported[repo.Name] = repo
+		for _, s := range repo.Sections {
+			exported[s.Name] = repo
+		}
+	}
+	return exported
+}
+
+// HasExports reports whether any repository is exported to PDF/EPUB.
+func HasExports(cfg *config.Config, repos []config.Repository) bool {
+	return len(ExportedRepositories(cfg, repos)) > 0
+}
+
+// StageExport renders the written pages of each exported repository into downloadable
+// files (static/downloads/<repo>.<format>) and links them from the repository index. A
+// failing render is reported as a warning; the site is still built without that download.
+func StageExport(ctx context.Context, bs *models.BuildState) error {
+	cfg := bs.Generator.Config()
+	e := &exporter{cfg: cfg, root: bs.Generator.BuildRoot()}
+	artifacts, err := e.run(ctx, ExportedRepositories(cfg, bs.Git.Repositories), bs.Docs.ExportPages)
+	if bs.Report != nil {
+		bs.Report.Exports = append(bs.Report.Exports, artifacts...)
+	}
+	if err != nil {
+		return models.NewCanceledStageError(models.StageExport, err)
+	}
+	var failed []string
+	for _, a := range artifacts {
+		if a.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s (%s): %s", a.Repository, a.Format, a.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return models.NewWarnStageError(models.StageExport,
+			fmt.Errorf("%d export(s) failed: %s", len(failed), strings.Join(failed, "; ")))
+	}
+	return nil
+}
+
+// exporter renders repository exports below a build root.
+type exporter struct {
+	cfg  *config.Config
+	root string
+}
+
+// run exports every repository of pages that is in exported. It only returns an error
+// when ctx is canceled; render failures are recorded on the artifacts.
+func (e *exporter) run(ctx context.Context, exported map[string]*config.Repository, pages []models.ExportPage) ([]models.ExportArtifact, error) {
+	byRepo := make(map[string][]models.ExportPage)
+	for _, p := range pages {
+		byRepo[p.Repository] = append(byRepo[p.Repository], p)
+	}
+	names := make([]string, 0, len(byRepo))
+	for name := range byRepo {
+		if exported[name] != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var artifacts []models.ExportArtifact
+	for _, name := range names {
+		repo := exported[name]
+		repoPages := byRepo[name]
+		index := repositoryIndex(repoPages)
+		title := name
+		if index != nil && index.Title != "" {
+			title = index.Title
+		}
+		selected := selectExportPages(repoPages, repo.ExportPages())
+		document, docErr := e.document(selected)
+		if docErr == nil && len(selected) == 0 {
+			docErr = errors.New("no pages to export")
+		}
+
+		var files []string
+		for _, format := range repo.ExportFormats(e.cfg.Export) {
+			if err := ctx.Err(); err != nil {
+				return artifacts, err
+			}
+			file := strings.ToLower(name) + "." + string(format)
+			a := models.ExportArtifact{Repository: name, Format: string(format), Path: "/downloads/" + file, Pages: len(selected)}
+			out := filepath.Join(e.root, "static", "downloads", file)
+			start := time.Now()
+			err := docErr
+			if err == nil {
+				err = e.render(ctx, title, document, out)
+			}
+			if err != nil {
+				a.Error = err.Error()
+				slog.Warn("Export failed",
+					slog.String("repo", name),
+					slog.String("format", string(format)),
+					slog.String("error", a.Error))
+				artifacts = append(artifacts, a)
+				continue
+			}
+			if info, statErr := os.Stat(out); statErr == nil {
+				a.Bytes = info.Size()
+			}
+			slog.Info("Exported repository",
+				slog.String("repo", name),
+				slog.String("format", string(format)),
+				slog.Int("pages", a.Pages),
+				slog.Duration("duration", time.Since(start)))
+			artifacts = append(artifacts, a)
+			files = append(files, file)
+		}
+		if index != nil && len(files) > 0 {
+			if err := e.linkDownloads(index, files); err != nil {
+				slog.Warn("Failed to link exports from repository index",
+					slog.String("repo", name),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+	return artifacts, nil
+}
+
+// render runs pandoc with the markdown document on stdin.
+func (e *exporter) render(ctx context.Context, title, document, out string) error {
+	if err := os.MkdirAll(filepath.Dir(out), 0o750); err != nil {
+		return fmt.Errorf("create downloads directory: %w", err)
+	}
+	_ = os.Remove(out)
+
+	args := []string{
+		"--from=markdown",
+		"--standalone",
+		"--toc",
+		"--metadata=title:" + title,
+		"--resource-path=" + filepath.Join(e.root, "content"),
+		"--output=" + out,
+	}
+	name := "pandoc"
+	if e.cfg.Export != nil {
+		name = e.cfg.Export.ExportCommand()
+		args = append(args, e.cfg.Export.Args...)
+	}
+	runCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, name, args...) // #nosec G204 -- export command comes from the configuration
+	cmd.Dir = e.root
+	cmd.Stdin = strings.NewReader(document)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", exportTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	if _, err := os.Stat(out); err != nil {
+		return fmt.Errorf("%s did not write %s", name, filepath.Base(out))
+	}
+	return nil
+}
+
+// document joins the pages into one markdown document: each page becomes a chapter
+// titled with its page title, with its own headings one level down. Shortcodes are
+// dropped and image paths point at the files in the build root.
+func (e *exporter) document(pages []models.ExportPage) (string, error) {
+	var b strings.Builder
+	for _, p := range pages {
+		// #nosec G304 -- page paths are content files written by this build
+		data, err := os.ReadFile(filepath.Join(e.root, filepath.FromSlash(p.Path)))
+		if err != nil {
+			return "", err
+		}
+		_, body, _, _, err := frontmatter.Split(data)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", p.Path, err)
+		}
+		title := p.Title
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(p.Path), path.Ext(p.Path))
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("# " + title + "\n\n")
+		b.WriteString(strings.TrimSpace(e.exportBody(string(body), path.Dir(p.Path))))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// exportBody rewrites a page body for pandoc; dir is the page's directory relative to
+// the build root.
+func (e *exporter) exportBody(body, dir string) string {
+	lines := strings.Split(body, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				continue
+			}
+			// Shortcodes in code blocks are escaped for Hugo; show them as written.
+			lines[i] = strings.NewReplacer("{{</*", "{{<", "*/>}}", ">}}", "{{%/*", "{{%", "*/%}}", "%}}").Replace(line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		line = exportShortcodePattern.ReplaceAllString(line, "")
+		line = exportHeadingPattern.ReplaceAllStringFunc(line, func(h string) string {
+			if strings.HasPrefix(h, "######") {
+				return h
+			}
+			return "#" + h
+		})
+		lines[i] = exportImagePattern.ReplaceAllStringFunc(line, func(m string) string {
+			sub := exportImagePattern.FindStringSubmatch(m)
+			if file := e.imageFile(sub[2], dir); file != "" {
+				return sub[1] + filepath.ToSlash(file)
+			}
+			return m
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// imageFile returns the file an image destination refers to, or "" when it is not in
+// the build root (remote images are left to pandoc).
+func (e *exporter) imageFile(dest, dir string) string {
+	if strings.Contains(dest, "://") || strings.HasPrefix(dest, "data:") {
+		return ""
+	}
+	if i := strings.IndexAny(dest, "?#"); i >= 0 {
+		dest = dest[:i]
+	}
+	var candidates []string
+	if strings.HasPrefix(dest, "/") {
+		candidates = []string{path.Join("content", dest), path.Join("static", dest)}
+	} else {
+		candidates = []string{path.Join(dir, dest)}
+	}
+	for _, c := range candidates {
+		file := filepath.Join(e.root, filepath.FromSlash(c))
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// linkDownloads appends links to the downloaded files to the repository index page. Links
+// are relative to the page, so they also work when the site is served below a base path.
+func (e *exporter) linkDownloads(index *models.ExportPage, files []string) error {
+	file := filepath.Join(e.root, filepath.FromSlash(index.Path))
+	// #nosec G304 -- index path is a content file written by this build
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	prefix := ""
+	if dir := path.Dir(strings.TrimPrefix(index.Path, "content/")); dir != "." {
+		prefix = strings.Repeat("../", strings.Count(dir, "/")+1)
+	}
+	links := make([]string, len(files))
+	for i, f := range files {
+		links[i] = fmt.Sprintf("[%s](%sdownloads/%s)", strings.ToUpper(strings.TrimPrefix(path.Ext(f), ".")), prefix, f)
+	}
+	content := strings.TrimRight(string(data), "\r\n") + "\n\n**Downloads:** " + strings.Join(links, " · ") + "\n"
+	// #nosec G306 -- content files are public documentation
+	return os.WriteFile(file, []byte(content), 0o644)
+}
+
+// repositoryIndex returns the top-level index page of a repository's pages.
+func repositoryIndex(pages []models.ExportPage) *models.ExportPage {
+	var index *models.ExportPage
+	for i := range pages {
+		p := &pages[i]
+		if p.Index && (index == nil || strings.Count(p.Path, "/") < strings.Count(index.Path, "/")) {
+			index = p
+		}
+	}
+	return index
+}
+
+// selectExportPages returns the source pages matching the repository's export globs
+// (all when there are none) in reading order: each directory's index first, then its
+// pages and subdirectories by path.
+func selectExportPages(pages []models.ExportPage, patterns []string) []models.ExportPage {
+	var selected []models.ExportPage
+	for _, p := range pages {
+		if p.Generated {
+			continue
+		}
+		if len(patterns) == 0 {
+			selected = append(selected, p)
+			continue
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p.Source); ok {
+				selected = append(selected, p)
+				break
+			}
+		}
+	}
+	key := func(p models.ExportPage) string {
+		if p.Index {
+			return path.Dir(p.Path) + "/"
+		}
+		return p.Path
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return key(selected[i]) < key(selected[j]) })
+	return selected
+}
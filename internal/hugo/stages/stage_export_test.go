@@ -0,0 +1,100 @@
+package stages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestSelectExportPages_FiltersAndOrders(t *testing.T) {
+	pages := []models.ExportPage{
+		{Repository: "svc", Source: "docs/zeta.md", Path: "content/svc/zeta.md"},
+		{Repository: "svc", Source: "docs/guide/setup.md", Path: "content/svc/guide/setup.md"},
+		{Repository: "svc", Source: "docs/guide/README.md", Path: "content/svc/guide/_index.md", Index: true},
+		{Repository: "svc", Path: "content/svc/_index.md", Index: true, Generated: true},
+		{Repository: "svc", Source: "docs/alpha.md", Path: "content/svc/alpha.md"},
+	}
+	var paths []string
+	for _, p := range selectExportPages(pages, nil) {
+		paths = append(paths, p.Path)
+	}
+	assert.Equal(t, []string{"content/svc/alpha.md", "content/svc/guide/_index.md", "content/svc/guide/setup.md", "content/svc/zeta.md"}, paths)
+
+	selected := selectExportPages(pages, []string{"docs/guide/*.md"})
+	require.Len(t, selected, 2)
+	assert.Equal(t, "content/svc/guide/_index.md", selected[0].Path)
+
+	assert.Equal(t, "content/svc/_index.md", repositoryIndex(pages).Path)
+}
+
+func TestExporter_RendersAndLinksDownloads(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "content", "svc", "_index.md"), "---\ntitle: Service\n---\n\nWelcome.\n")
+	writeFile(t, filepath.Join(root, "content", "svc", "guide.md"), "---\ntitle: Guide\n---\n\n"+
+		"## Install\n\n{{< note >}}Careful{{< /note >}}\n\n![diagram](/svc/img/flow.png)\n\n"+
+		"```text\n# not a heading {{</* note */>}}\n```\n")
+	writeFile(t, filepath.Join(root, "content", "svc", "img", "flow.png"), "png")
+
+	// A stand-in for pandoc: writes the markdown it receives to the --output file.
+	script := filepath.Join(t.TempDir(), "pandoc.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nfor a; do case $a in --output=*) out=${a#--output=};; esac; done\ncat > \"$out\"\n"), 0o700)) // #nosec G306 -- test script must be executable
+
+	cfg := &config.Config{Export: &config.ExportConfig{
+		Enabled: true,
+		Formats: []config.ExportFormat{config.ExportPDF, config.ExportEPUB},
+		Command: script,
+	}}
+	repos := []config.Repository{{Name: "svc"}, {Name: "other", Export: &config.RepositoryExport{Enabled: new(bool)}}}
+	exported := ExportedRepositories(cfg, repos)
+	require.Contains(t, exported, "svc")
+	require.NotContains(t, exported, "other")
+
+	e := &exporter{cfg: cfg, root: root}
+	artifacts, err := e.run(t.Context(), exported, []models.ExportPage{
+		{Repository: "svc", Source: "docs/README.md", Path: "content/svc/_index.md", Title: "Service", Index: true},
+		{Repository: "svc", Source: "docs/guide.md", Path: "content/svc/guide.md", Title: "Guide"},
+		{Repository: "other", Source: "docs/x.md", Path: "content/other/x.md"},
+	})
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "/downloads/svc.pdf", artifacts[0].Path)
+	assert.Equal(t, 2, artifacts[0].Pages)
+	assert.Positive(t, artifacts[0].Bytes)
+	assert.Empty(t, artifacts[1].Error)
+
+	data, err := os.ReadFile(filepath.Join(root, "static", "downloads", "svc.pdf"))
+	require.NoError(t, err)
+	doc := string(data)
+	assert.Contains(t, doc, "# Service\n\nWelcome.\n\n# Guide\n\n### Install")
+	assert.NotContains(t, doc, "Careful{{")
+	assert.Contains(t, doc, "![diagram]("+filepath.ToSlash(filepath.Join(root, "content", "svc", "img", "flow.png"))+")")
+	assert.Contains(t, doc, "# not a heading {{< note >}}")
+
+	index, err := os.ReadFile(filepath.Join(root, "content", "svc", "_index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "**Downloads:** [PDF](../downloads/svc.pdf) · [EPUB](../downloads/svc.epub)\n")
+}
+
+func TestExporter_ReportsRenderFailures(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "content", "svc", "_index.md"), "Welcome.\n")
+	cfg := &config.Config{Export: &config.ExportConfig{Enabled: true, Command: filepath.Join(t.TempDir(), "missing")}}
+
+	e := &exporter{cfg: cfg, root: root}
+	artifacts, err := e.run(t.Context(), ExportedRepositories(cfg, []config.Repository{{Name: "svc"}}), []models.ExportPage{
+		{Repository: "svc", Source: "docs/README.md", Path: "content/svc/_index.md", Index: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+	assert.NotEmpty(t, artifacts[0].Error)
+
+	index, err := os.ReadFile(filepath.Join(root, "content", "svc", "_index.md"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(index), "Downloads")
+}
@@ -0,0 +1,185 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+const (
+	// maxHookOutput is how much of a hook's combined output the build report keeps (the tail).
+	maxHookOutput = 16 << 10
+	// hookWaitDelay bounds the wait for output pipes after a timed-out command is killed.
+	hookWaitDelay = 5 * time.Second
+)
+
+// HookEnv describes the build to hook commands through DOCBUILDER_* environment variables.
+type HookEnv struct {
+	BuildID   string // DOCBUILDER_BUILD_ID (daemon builds)
+	Workspace string // DOCBUILDER_WORKSPACE: directory of the cloned repositories
+	OutputDir string // DOCBUILDER_OUTPUT_DIR: final output directory
+	SiteDir   string // DOCBUILDER_SITE_DIR: Hugo project being built (staging until published)
+	Outcome   string // DOCBUILDER_OUTCOME (post_build only)
+}
+
+// HasHooks reports whether commands are configured for a hook point.
+func HasHooks(cfg *config.Config, point config.HookPoint) bool {
+	return len(cfg.BuildHooks(point)) > 0
+}
+
+// StagePreBuildHooks runs the pre_build hook commands.
+func StagePreBuildHooks(ctx context.Context, bs *models.BuildState) error {
+	return runStageHooks(ctx, bs, config.HookPreBuild, models.StagePreBuildHooks)
+}
+
+// StagePostCloneHooks runs the post_clone hook commands.
+func StagePostCloneHooks(ctx context.Context, bs *models.BuildState) error {
+	return runStageHooks(ctx, bs, config.HookPostClone, models.StagePostCloneHooks)
+}
+
+// StagePreHugoHooks runs the pre_hugo hook commands.
+func StagePreHugoHooks(ctx context.Context, bs *models.BuildState) error {
+	return runStageHooks(ctx, bs, config.HookPreHugo, models.StagePreHugoHooks)
+}
+
+func runStageHooks(ctx context.Context, bs *models.BuildState, point config.HookPoint, stage models.StageName) error {
+	env := HookEnv{
+		BuildID:   observability.BuildID(ctx),
+		Workspace: bs.Git.WorkspaceDir,
+		OutputDir: bs.Generator.OutputDir(),
+		SiteDir:   bs.Generator.BuildRoot(),
+	}
+	fatal, warn := RunHooks(ctx, bs.Generator.Config(), point, env, bs.Report)
+	switch {
+	case fatal != nil && ctx.Err() != nil:
+		return models.NewCanceledStageError(stage, ctx.Err())
+	case fatal != nil:
+		return models.NewFatalStageError(stage, fatal)
+	case warn != nil:
+		return models.NewWarnStageError(stage, warn)
+	}
+	return nil
+}
+
+// RunHooks runs the commands of a hook point in order and records each run in the report.
+// It stops at the first failing command and returns its error as fatal, unless the command
+// continues on error; those failures are joined into warn.
+func RunHooks(ctx context.Context, cfg *config.Config, point config.HookPoint, env HookEnv, report *models.BuildReport) (fatal, warn error) {
+	var warnings []error
+	for _, h := range cfg.BuildHooks(point) {
+		if err := ctx.Err(); err != nil {
+			return err, nil
+		}
+		run := runHook(ctx, point, &h, h.TimeoutDuration(cfg.Hooks), env)
+		report.Hooks = append(report.Hooks, run)
+		if run.Error == "" {
+			slog.Info("Hook command completed",
+				slog.String("hook_point", string(point)),
+				slog.String("name", run.Name),
+				slog.Duration("duration", run.Duration))
+			continue
+		}
+		err := fmt.Errorf("%s hook %q: %s", point, run.Name, run.Error)
+		slog.Warn("Hook command failed",
+			slog.String("hook_point", string(point)),
+			slog.String("name", run.Name),
+			slog.Bool("continue_on_error", h.ContinueOnError),
+			slog.String("error", run.Error))
+		if !h.ContinueOnError {
+			return err, errors.Join(warnings...)
+		}
+		warnings = append(warnings, err)
+	}
+	return nil, errors.Join(warnings...)
+}
+
+func runHook(ctx context.Context, point config.HookPoint, h *config.HookCommand, timeout time.Duration, env HookEnv) models.HookRun {
+	run := models.HookRun{Point: string(point), Name: h.HookName(), Command: strings.Join(append([]string{h.Command}, h.Args...), " ")}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, h.Command, h.Args...) // #nosec G204 -- hook commands come from the configuration
+	cmd.Dir = h.Dir
+	cmd.Env = hookEnviron(point, env, h.Env)
+	cmd.WaitDelay = hookWaitDelay
+	out := &tailBuffer{max: maxHookOutput}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	start := time.Now()
+	err := cmd.Run()
+	run.Duration = time.Since(start)
+	run.Output = out.String()
+	run.ExitCode = -1
+	if cmd.ProcessState != nil {
+		run.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	switch {
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		run.Error = fmt.Sprintf("timed out after %s", timeout)
+	case err != nil:
+		run.Error = err.Error()
+	}
+	if run.Output != "" {
+		slog.Debug("Hook command output", slog.String("name", run.Name), slog.String("output", run.Output))
+	}
+	return run
+}
+
+// hookEnviron returns the process environment plus the documented DOCBUILDER_* variables
+// and the command's own variables (which win).
+func hookEnviron(point config.HookPoint, env HookEnv, extra map[string]string) []string {
+	vars := os.Environ()
+	vars = append(vars,
+		"DOCBUILDER_HOOK="+string(point),
+		"DOCBUILDER_BUILD_ID="+env.BuildID,
+		"DOCBUILDER_WORKSPACE="+env.Workspace,
+		"DOCBUILDER_OUTPUT_DIR="+env.OutputDir,
+		"DOCBUILDER_SITE_DIR="+env.SiteDir,
+	)
+	if env.Outcome != "" {
+		vars = append(vars, "DOCBUILDER_OUTCOME="+env.Outcome)
+	}
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		vars = append(vars, k+"="+extra[k])
+	}
+	return vars
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	max       int
+	buf       []byte
+	truncated bool
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.max; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	s := strings.TrimRight(string(t.buf), "\n")
+	if t.truncated {
+		return "[output truncated]\n" + s
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func shHook(name, script string) config.HookCommand {
+	return config.HookCommand{Name: name, Command: "sh", Args: []string{"-c", script}}
+}
+
+func TestRunHooks_EnvironmentAndOutput(t *testing.T) {
+	cfg := &config.Config{Hooks: &config.BuildHooksConfig{PreHugo: []config.HookCommand{
+		shHook("env", `echo "$DOCBUILDER_HOOK $DOCBUILDER_BUILD_ID $DOCBUILDER_WORKSPACE $DOCBUILDER_OUTPUT_DIR $DOCBUILDER_SITE_DIR $EXTRA"; echo oops >&2`),
+	}}}
+	cfg.Hooks.PreHugo[0].Env = map[string]string{"EXTRA": "x"}
+	report := &models.BuildReport{}
+	env := HookEnv{BuildID: "build-1", Workspace: "/ws", OutputDir: "/out", SiteDir: "/stage"}
+
+	fatal, warn := RunHooks(t.Context(), cfg, config.HookPreHugo, env, report)
+	require.NoError(t, fatal)
+	require.NoError(t, warn)
+	require.Len(t, report.Hooks, 1)
+	run := report.Hooks[0]
+	assert.Equal(t, "pre_hugo", run.Point)
+	assert.Equal(t, "env", run.Name)
+	assert.Equal(t, 0, run.ExitCode)
+	assert.Equal(t, "pre_hugo build-1 /ws /out /stage x\noops", run.Output)
+	assert.Empty(t, run.Error)
+}
+
+func TestRunHooks_FailuresAndContinueOnError(t *testing.T) {
+	tolerated := shHook("lint", "echo broken; exit 3")
+	tolerated.ContinueOnError = true
+	cfg := &config.Config{Hooks: &config.BuildHooksConfig{PostClone: []config.HookCommand{
+		tolerated,
+		shHook("fetch", "exit 2"),
+		shHook("never", "true"),
+	}}}
+	report := &models.BuildReport{}
+
+	fatal, warn := RunHooks(t.Context(), cfg, config.HookPostClone, HookEnv{}, report)
+	require.ErrorContains(t, fatal, `post_clone hook "fetch"`)
+	require.ErrorContains(t, warn, `post_clone hook "lint"`)
+	require.Len(t, report.Hooks, 2, "commands after a fatal failure do not run")
+	assert.Equal(t, 3, report.Hooks[0].ExitCode)
+	assert.Equal(t, "broken", report.Hooks[0].Output)
+	assert.Equal(t, 2, report.Hooks[1].ExitCode)
+}
+
+func TestRunHooks_Timeout(t *testing.T) {
+	slow := shHook("slow", "exec sleep 5")
+	slow.Timeout = "50ms"
+	cfg := &config.Config{Hooks: &config.BuildHooksConfig{PreBuild: []config.HookCommand{slow}}}
+	report := &models.BuildReport{}
+
+	fatal, _ := RunHooks(context.Background(), cfg, config.HookPreBuild, HookEnv{}, report)
+	require.ErrorContains(t, fatal, "timed out after 50ms")
+	assert.Equal(t, -1, report.Hooks[0].ExitCode)
+}
+
+func TestTailBuffer_KeepsTheEnd(t *testing.T) {
+	b := &tailBuffer{max: 8}
+	_, _ = b.Write([]byte("0123456789"))
+	_, _ = b.Write([]byte("ab\n"))
+	assert.Equal(t, "[output truncated]\n56789ab", b.String())
+	assert.Empty(t, (&tailBuffer{max: 8}).String())
+}
@@ -2,15 +2,59 @@ package stages
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 )
 
-func StagePostProcess(_ context.Context, _ *models.BuildState) error {
+func StagePostProcess(ctx context.Context, bs *models.BuildState) error {
 	start := time.Now()
 	// Brief spin to ensure distinguishable timestamps for build stages
 	for time.Since(start) == 0 {
 	}
+
+	err := postProcessPublic(ctx, bs)
+	// Files written by any stage, including post-processing, get the configured modes and owner.
+	if permErr := applyOutputPermissions(bs.Generator.Config().Output, bs.Generator.BuildRoot()); permErr != nil {
+		if err != nil {
+			permErr = errors.Join(err, permErr)
+		}
+		return models.NewWarnStageError(models.StagePostProcess, permErr)
+	}
+	return err
+}
+
+// postProcessPublic rewrites, protects, indexes and archives the rendered public/ tree.
+func postProcessPublic(ctx context.Context, bs *models.BuildState) error {
+	if !bs.Report.StaticRendered {
+		return nil
+	}
+	publicDir := filepath.Join(bs.Generator.BuildRoot(), "public")
+	if fi, err := os.Stat(publicDir); err != nil || !fi.IsDir() {
+		return nil
+	}
+	if p := newSEOPostProcessor(bs.Generator.Config(), publicDir); p != nil {
+		if err := p.run(); err != nil {
+			return models.NewWarnStageError(models.StagePostProcess, err)
+		}
+	}
+	if err := protectSite(ctx, bs.Generator.Config(), publicDir); err != nil {
+		return models.NewWarnStageError(models.StagePostProcess, err)
+	}
+	changed, err := changedURLs(bs.Generator.Config(), publicDir, filepath.Join(bs.Generator.OutputDir(), "public"))
+	if err != nil {
+		return models.NewWarnStageError(models.StagePostProcess, fmt.Errorf("changed pages: %w", err))
+	}
+	bs.Report.ChangedURLs = changed
+	if err := writePageIndex(publicDir, bs.Generator.BuildRoot()); err != nil {
+		return models.NewWarnStageError(models.StagePostProcess, err)
+	}
+	if err := archiveSite(bs.Generator.Config(), publicDir, bs.Report); err != nil {
+		return models.NewWarnStageError(models.StagePostProcess, err)
+	}
 	return nil
 }
@@ -6,7 +6,10 @@ import (
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
 )
 
-// StagePrepareOutput creates the Hugo structure.
+// StagePrepareOutput creates the Hugo structure with the configured output modes and owner.
 func StagePrepareOutput(_ context.Context, bs *models.BuildState) error {
-	return bs.Generator.CreateHugoStructure()
+	if err := bs.Generator.CreateHugoStructure(); err != nil {
+		return err
+	}
+	return applyOutputPermissions(bs.Generator.Config().Output, bs.Generator.BuildRoot())
 }
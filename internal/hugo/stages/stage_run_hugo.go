@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
@@ -32,20 +33,28 @@ func StageRunHugo(ctx context.Context, bs *models.BuildState) error {
 	root := bs.Generator.BuildRoot()
 	renderer := bs.Generator.Renderer()
 	if renderer == nil {
-		renderer = &BinaryRenderer{}
+		binary := &BinaryRenderer{}
+		if cfg != nil {
+			binary.Limits = cfg.Build.Resources
+		}
+		renderer = binary
 	}
-	slog.Info("Executing Hugo renderer",
+	logger := bs.Generator.Logger(logging.ComponentHugo)
+	logger.Info("Executing Hugo renderer",
 		slog.String("root", root),
 		slog.String("renderer_type", fmt.Sprintf("%T", renderer)))
 	if err := renderer.Execute(ctx, root); err != nil {
-		slog.Error("Renderer execution failed",
+		logger.Error("Renderer execution failed",
 			slog.String("error", err.Error()),
 			slog.String("root", root))
 		// Return error regardless of mode - let caller decide how to handle
 		return models.NewFatalStageError(models.StageRunHugo, fmt.Errorf("%w: %w", herrors.ErrHugoExecutionFailed, err))
 	}
 	bs.Report.StaticRendered = true
-	slog.Info("Hugo renderer completed successfully",
+	if wr, ok := renderer.(models.WarningRenderer); ok {
+		bs.Render.Warnings = wr.Warnings()
+	}
+	logger.Info("Hugo renderer completed successfully",
 		slog.String("root", root),
 		slog.Bool("static_rendered", true))
 	return nil
@@ -0,0 +1,349 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	ferrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+)
+
+// outputIssueSamples bounds the paths quoted in one report issue.
+const outputIssueSamples = 5
+
+// ValidatesOutput reports whether the validate_output stage runs.
+func ValidatesOutput(cfg *config.Config) bool {
+	return cfg != nil && cfg.Build.OutputValidation.ValidatesOutput()
+}
+
+// StageValidateOutput compares the rendered site with the content handed to Hugo:
+// every page must have produced an HTML file with content, every content directory
+// must have a section index, and hugo must not have logged warnings. Problems are
+// recorded under output_problems in the build report and handled per the action of
+// their check in build.output_validation.
+func StageValidateOutput(_ context.Context, bs *models.BuildState) error {
+	if !bs.Report.StaticRendered {
+		return nil
+	}
+	cfg := bs.Generator.Config()
+	policy := cfg.Build.OutputValidation
+	root := bs.Generator.BuildRoot()
+
+	problems, err := validateOutput(root, bs.Render.Warnings, time.Now())
+	if err != nil {
+		return models.NewWarnStageError(models.StageValidateOutput, err)
+	}
+
+	byCheck := make(map[config.OutputCheck][]models.OutputProblem)
+	var failed []config.OutputCheck
+	for _, p := range problems {
+		check := config.OutputCheck(p.Check)
+		action := policy.Action(check)
+		if action == config.OutputCheckIgnore {
+			continue
+		}
+		p.Action = string(action)
+		bs.Report.OutputProblems = append(bs.Report.OutputProblems, p)
+		if len(byCheck[check]) == 0 && action == config.OutputCheckFail {
+			failed = append(failed, check)
+		}
+		byCheck[check] = append(byCheck[check], p)
+	}
+
+	logger := bs.Generator.Logger(logging.ComponentHugo)
+	for _, check := range []config.OutputCheck{config.OutputCheckMissingPages, config.OutputCheckEmptyPages, config.OutputCheckMissingSections, config.OutputCheckHugoWarnings} {
+		found := byCheck[check]
+		if len(found) == 0 {
+			continue
+		}
+		msg := outputProblemMessage(check, found)
+		logger.Warn("Output validation found problems",
+			slog.String("check", string(check)),
+			slog.Int("count", len(found)),
+			slog.String("action", found[0].Action),
+			slog.String("detail", msg))
+		if found[0].Action == string(config.OutputCheckWarn) {
+			issue := ferrors.NewError(ferrors.CategoryBuild, msg).
+				Warning().
+				WithContext("check", string(check)).
+				WithContext("count", len(found)).
+				Build()
+			bs.Report.AddIssue(models.IssueOutputValidation, models.StageValidateOutput, models.SeverityWarning, msg, false, issue)
+		}
+	}
+
+	if len(failed) > 0 {
+		checks := make([]string, len(failed))
+		for i, c := range failed {
+			checks[i] = string(c)
+		}
+		return models.NewFatalStageError(models.StageValidateOutput,
+			ferrors.WrapError(herrors.ErrOutputValidationFailed, ferrors.CategoryBuild,
+				fmt.Sprintf("output checks configured to fail the build found problems: %s; see output_problems in the build report", strings.Join(checks, ", "))).
+				WithContext("checks", strings.Join(checks, ",")).
+				Build())
+	}
+	return nil
+}
+
+// validateOutput checks the content/ and public/ trees below root. Content paths in
+// the problems are relative to root, outputs relative to public/.
+func validateOutput(root string, hugoWarnings []string, now time.Time) ([]models.OutputProblem, error) {
+	contentDir := filepath.Join(root, "content")
+	publicDir := filepath.Join(root, "public")
+
+	var problems []models.OutputProblem
+	sections := make(map[string]bool) // content directory -> has _index.md
+	bundles := make(map[string]bool)  // leaf bundle directories (index.md)
+	var pages []string
+	err := filepath.WalkDir(contentDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(contentDir, p)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if _, ok := sections[rel]; !ok {
+				sections[rel] = false
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(p), ".md") {
+			return nil
+		}
+		switch path.Base(rel) {
+		case "_index.md":
+			sections[path.Dir(rel)] = true
+		case "index.md":
+			bundles[path.Dir(rel)] = true
+		}
+		pages = append(pages, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk content: %w", err)
+	}
+
+	// Files below a leaf bundle, other than the bundle's own index.md, are page resources.
+	isBundleResource := func(rel string) bool {
+		for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+			if bundles[dir] && rel != dir+"/index.md" {
+				return true
+			}
+		}
+		return false
+	}
+
+	hasPages := make(map[string]bool)
+	for _, rel := range pages {
+		if isBundleResource(rel) {
+			continue
+		}
+		for dir := path.Dir(rel); ; dir = path.Dir(dir) {
+			hasPages[dir] = true
+			if dir == "." {
+				break
+			}
+		}
+		problems = append(problems, checkPageOutput(contentDir, publicDir, rel, now)...)
+	}
+
+	dirs := make([]string, 0, len(sections))
+	for dir := range sections {
+		dirs = append(dirs, dir)
+	}
+	slices.Sort(dirs)
+	for _, dir := range dirs {
+		if sections[dir] || dir == "." || bundles[dir] || !hasPages[dir] || isBundleResource(dir) {
+			continue
+		}
+		problems = append(problems, models.OutputProblem{
+			Check:  string(config.OutputCheckMissingSections),
+			Path:   path.Join("content", dir),
+			Detail: "directory has pages but no _index.md; Hugo does not list it as a section",
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, w := range hugoWarnings {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		problems = append(problems, models.OutputProblem{Check: string(config.OutputCheckHugoWarnings), Detail: w})
+	}
+	return problems, nil
+}
+
+// checkPageOutput checks that the content page rel rendered a non-empty HTML file.
+func checkPageOutput(contentDir, publicDir, rel string, now time.Time) []models.OutputProblem {
+	// #nosec G304 -- rel is a file found below the build root
+	data, err := os.ReadFile(filepath.Join(contentDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	fmRaw, body, _, _, err := frontmatter.Split(data)
+	if err != nil {
+		return nil
+	}
+	fm, err := frontmatter.ParseYAML(fmRaw)
+	if err != nil || fm == nil {
+		fm = map[string]any{}
+	}
+	if !pageIsRendered(fm, now) {
+		return nil
+	}
+
+	contentPath := path.Join("content", rel)
+	output := expectedPageOutput(rel, fm)
+	info, err := os.Stat(filepath.Join(publicDir, filepath.FromSlash(output)))
+	switch {
+	case err != nil:
+		return []models.OutputProblem{{
+			Check:  string(config.OutputCheckMissingPages),
+			Path:   contentPath,
+			Output: output,
+			Detail: "page produced no HTML file",
+		}}
+	case info.Size() == 0:
+		return []models.OutputProblem{{
+			Check:  string(config.OutputCheckEmptyPages),
+			Path:   contentPath,
+			Output: output,
+			Detail: "HTML file is empty",
+		}}
+	case path.Base(rel) != "_index.md" && len(bytes.TrimSpace(body)) == 0:
+		return []models.OutputProblem{{
+			Check:  string(config.OutputCheckEmptyPages),
+			Path:   contentPath,
+			Output: output,
+			Detail: "page has no content",
+		}}
+	}
+	return nil
+}
+
+// pageIsRendered reports whether Hugo renders a page with the given front matter under
+// its default settings: drafts, headless pages, build.render never, future publish
+// dates and past expiry dates are not rendered.
+func pageIsRendered(fm map[string]any, now time.Time) bool {
+	if fmBool(fm["draft"]) || fmBool(fm["headless"]) {
+		return false
+	}
+	for _, key := range []string{"build", "_build"} {
+		if opts, ok := fm[key].(map[string]any); ok {
+			switch render := opts["render"].(type) {
+			case bool:
+				if !render {
+					return false
+				}
+			case string:
+				if render == "never" || render == "false" {
+					return false
+				}
+			}
+		}
+	}
+	publish, ok := fmTime(fm["publishDate"])
+	if !ok {
+		publish, ok = fmTime(fm["date"])
+	}
+	if ok && publish.After(now) {
+		return false
+	}
+	if expiry, ok := fmTime(fm["expiryDate"]); ok && !expiry.After(now) {
+		return false
+	}
+	return true
+}
+
+// expectedPageOutput returns the HTML file Hugo writes for content page rel, relative
+// to public/, honoring url and slug front matter.
+func expectedPageOutput(rel string, fm map[string]any) string {
+	if u, ok := fm["url"].(string); ok && strings.TrimSpace(u) != "" {
+		u = strings.Trim(strings.TrimSpace(u), "/")
+		if strings.HasSuffix(u, ".html") {
+			return urlizePath(u)
+		}
+		return urlizePath(path.Join(u, "index.html"))
+	}
+	dir, file := path.Split(rel)
+	name := strings.TrimSuffix(file, path.Ext(file))
+	if name == "_index" || name == "index" {
+		return urlizePath(path.Join(dir, "index.html"))
+	}
+	if slug, ok := fm["slug"].(string); ok && strings.TrimSpace(slug) != "" {
+		name = strings.TrimSpace(slug)
+	}
+	return urlizePath(path.Join(dir, name, "index.html"))
+}
+
+// urlizePath applies Hugo's default path normalization: lower case, spaces as hyphens.
+func urlizePath(p string) string {
+	return strings.ReplaceAll(strings.ToLower(p), " ", "-")
+}
+
+func fmBool(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return b == "true"
+	}
+	return false
+}
+
+func fmTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly} {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(t)); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// outputProblemMessage summarizes the problems of one check for logs and report issues.
+func outputProblemMessage(check config.OutputCheck, problems []models.OutputProblem) string {
+	samples := make([]string, 0, outputIssueSamples)
+	for _, p := range problems[:min(len(problems), outputIssueSamples)] {
+		if p.Path != "" {
+			samples = append(samples, p.Path)
+		} else {
+			samples = append(samples, p.Detail)
+		}
+	}
+	more := ""
+	if len(problems) > outputIssueSamples {
+		more = fmt.Sprintf(" and %d more", len(problems)-outputIssueSamples)
+	}
+	var what string
+	switch check {
+	case config.OutputCheckMissingPages:
+		what = "page(s) produced no HTML"
+	case config.OutputCheckEmptyPages:
+		what = "empty page(s)"
+	case config.OutputCheckMissingSections:
+		what = "content director(ies) without _index.md"
+	case config.OutputCheckHugoWarnings:
+		what = "hugo warning(s)"
+	}
+	return fmt.Sprintf("%d %s: %s%s", len(problems), what, strings.Join(samples, "; "), more)
+}
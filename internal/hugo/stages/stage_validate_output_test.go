@@ -0,0 +1,128 @@
+package stages
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+	"git.home.luguber.info/inful/docbuilder/internal/logging"
+)
+
+// validateGenerator provides the generator methods the validate_output stage uses.
+type validateGenerator struct {
+	models.Generator
+	cfg  *config.Config
+	root string
+}
+
+func (g validateGenerator) Config() *config.Config                { return g.cfg }
+func (g validateGenerator) BuildRoot() string                     { return g.root }
+func (g validateGenerator) Logger(logging.Component) *slog.Logger { return slog.Default() }
+
+// writeRenderedSite writes a small content tree and the HTML Hugo would render for it.
+func writeRenderedSite(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	content := map[string]string{
+		"_index.md":                   "---\ntitle: Home\n---\n",
+		"svc/_index.md":               "---\ntitle: Service\n---\n",
+		"svc/guide.md":                "---\ntitle: Guide\n---\n\nText.\n",
+		"svc/dropped.md":              "---\ntitle: Dropped\n---\n\nLost.\n",
+		"svc/empty.md":                "---\ntitle: Empty\n---\n\n",
+		"svc/draft.md":                "---\ndraft: true\n---\n\nLater.\n",
+		"svc/renamed.md":              "---\nslug: other-name\n---\n\nText.\n",
+		"svc/moved.md":                "---\nurl: /elsewhere/\n---\n\nText.\n",
+		"svc/future.md":               "---\npublishDate: 2999-01-01\n---\n\nSoon.\n",
+		"svc/api/endpoints.md":        "---\ntitle: Endpoints\n---\n\nText.\n",
+		"svc/bundle/index.md":         "---\ntitle: Bundle\n---\n\nText.\n",
+		"svc/bundle/notes/extra.md":   "resource\n",
+		"svc/bundle/included.md":      "resource\n",
+		"svc/Upper Case/_index.md":    "---\ntitle: Upper\n---\n",
+		"svc/Upper Case/Some Page.md": "---\ntitle: Upper page\n---\n\nText.\n",
+	}
+	for rel, body := range content {
+		writeFile(t, filepath.Join(root, "content", filepath.FromSlash(rel)), body)
+	}
+	for _, rel := range []string{
+		"index.html", "svc/index.html", "svc/guide/index.html", "svc/empty/index.html",
+		"svc/other-name/index.html", "elsewhere/index.html", "svc/api/endpoints/index.html",
+		"svc/bundle/index.html", "svc/upper-case/index.html", "svc/upper-case/some-page/index.html",
+	} {
+		writeFile(t, filepath.Join(root, "public", filepath.FromSlash(rel)), "<html>page</html>")
+	}
+	return root
+}
+
+func TestValidateOutput_FindsProblems(t *testing.T) {
+	root := writeRenderedSite(t)
+
+	problems, err := validateOutput(root, []string{"deprecated: .Site.Author", "deprecated: .Site.Author"}, time.Now())
+	require.NoError(t, err)
+
+	byCheck := map[string][]string{}
+	for _, p := range problems {
+		byCheck[p.Check] = append(byCheck[p.Check], p.Path+"|"+p.Output+"|"+p.Detail)
+	}
+	assert.Equal(t, map[string][]string{
+		"missing_pages":    {"content/svc/dropped.md|svc/dropped/index.html|page produced no HTML file"},
+		"empty_pages":      {"content/svc/empty.md|svc/empty/index.html|page has no content"},
+		"missing_sections": {"content/svc/api||directory has pages but no _index.md; Hugo does not list it as a section"},
+		"hugo_warnings":    {"||deprecated: .Site.Author"},
+	}, byCheck)
+}
+
+func TestExpectedPageOutput(t *testing.T) {
+	assert.Equal(t, "svc/guide/index.html", expectedPageOutput("svc/guide.md", nil))
+	assert.Equal(t, "svc/index.html", expectedPageOutput("svc/_index.md", nil))
+	assert.Equal(t, "svc/bundle/index.html", expectedPageOutput("svc/bundle/index.md", nil))
+	assert.Equal(t, "svc/short/index.html", expectedPageOutput("svc/guide.md", map[string]any{"slug": "short"}))
+	assert.Equal(t, "a/b/index.html", expectedPageOutput("svc/guide.md", map[string]any{"url": "/a/b/"}))
+	assert.Equal(t, "a/page.html", expectedPageOutput("svc/guide.md", map[string]any{"url": "/a/page.html"}))
+}
+
+func TestStageValidateOutput_Policy(t *testing.T) {
+	root := writeRenderedSite(t)
+	cfg := &config.Config{Build: config.BuildConfig{OutputValidation: &config.OutputValidationConfig{
+		Enabled:         true,
+		MissingSections: config.OutputCheckIgnore,
+		HugoWarnings:    config.OutputCheckIgnore,
+	}}}
+	require.True(t, ValidatesOutput(cfg))
+
+	bs := models.NewBuildState(validateGenerator{cfg: cfg, root: root}, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Report.StaticRendered = true
+	bs.Render.Warnings = []string{"ignored"}
+
+	require.NoError(t, StageValidateOutput(t.Context(), bs))
+	require.Len(t, bs.Report.OutputProblems, 2)
+	for _, p := range bs.Report.OutputProblems {
+		assert.Equal(t, "warn", p.Action)
+	}
+	require.Len(t, bs.Report.Issues, 2)
+	assert.Equal(t, models.IssueOutputValidation, bs.Report.Issues[0].Code)
+	assert.Contains(t, bs.Report.Issues[0].Message, "1 page(s) produced no HTML: content/svc/dropped.md")
+
+	// Missing pages configured to fail abort the build.
+	cfg.Build.OutputValidation.MissingPages = config.OutputCheckFail
+	bs = models.NewBuildState(validateGenerator{cfg: cfg, root: root}, nil, models.NewBuildReport(t.Context(), 0, 0))
+	bs.Report.StaticRendered = true
+	err := StageValidateOutput(t.Context(), bs)
+	var se *models.StageError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, models.StageErrorFatal, se.Kind)
+	require.ErrorIs(t, err, herrors.ErrOutputValidationFailed)
+	assert.Contains(t, err.Error(), "missing_pages")
+	require.Len(t, bs.Report.Issues, 1) // only the warned empty page
+}
+
+func TestHugoWarnings(t *testing.T) {
+	out := "INFO  build start\nWARN  found no layout file for \"json\"\nDEBUG x\n"
+	assert.Equal(t, []string{`found no layout file for "json"`, "deprecated"}, hugoWarnings(out, "WARN deprecated\n"))
+}
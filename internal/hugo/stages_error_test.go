@@ -91,3 +91,22 @@ func TestRunStages_TimingRecordedOnWarning(t *testing.T) {
 		t.Fatalf("unexpected duration range: %v", report.StageDurations["warn_stage"])
 	}
 }
+
+func TestRunStages_PanicBecomesFatalError(t *testing.T) {
+	cfg := &config.Config{}
+	gen := NewGenerator(cfg, t.TempDir())
+	report := models.NewBuildReport(t.Context(), 0, 0)
+	bs := models.NewBuildState(gen, nil, report)
+
+	panicking := func(context.Context, *models.BuildState) error { panic("stage exploded") }
+	err := stages.RunStages(t.Context(), bs, []models.StageDef{{Name: models.StageName("panic_stage"), Fn: panicking}})
+	if err == nil {
+		t.Fatalf("expected fatal error")
+	}
+	if report.StageErrorKinds[models.StageName("panic_stage")] != models.StageErrorFatal {
+		t.Fatalf("expected fatal kind recorded for panicking stage")
+	}
+	if len(report.Errors) != 1 {
+		t.Fatalf("expected 1 fatal error, got %d", len(report.Errors))
+	}
+}
@@ -0,0 +1,119 @@
+package hugo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+)
+
+// Staleness report file names (written to the output root next to build-report.json).
+const (
+	StalenessReportJSON     = "staleness-report.json"
+	StalenessReportMarkdown = "staleness-report.md"
+)
+
+// StalenessReport lists pages older than the configured threshold, grouped by repository.
+type StalenessReport struct {
+	GeneratedAt  time.Time              `json:"generated_at"`
+	MaxAgeDays   int                    `json:"max_age_days"`
+	TotalPages   int                    `json:"total_pages"`
+	StalePages   int                    `json:"stale_pages"`
+	Repositories map[string][]StalePage `json:"repositories"`
+}
+
+// StalePage describes a single stale page.
+type StalePage struct {
+	URL          string `json:"url"`
+	Source       string `json:"source"`
+	LastModified string `json:"last_modified"`
+	AgeDays      int    `json:"age_days"`
+	LastAuthor   string `json:"last_author,omitempty"`
+}
+
+// writeStalenessReport collects pages flagged by the staleness transform and persists
+// JSON and Markdown reports. It is a no-op when staleness detection is disabled.
+func (g *Generator) writeStalenessReport(processed []*pipeline.Document) error {
+	if !g.config.Staleness.IsEnabled() {
+		return nil
+	}
+	report := StalenessReport{
+		GeneratedAt:  time.Now().UTC(),
+		MaxAgeDays:   g.config.Staleness.Threshold(),
+		Repositories: make(map[string][]StalePage),
+	}
+	for _, doc := range processed {
+		if doc.Generated {
+			continue
+		}
+		report.TotalPages++
+		if stale, _ := doc.FrontMatter["stale"].(bool); !stale {
+			continue
+		}
+		modified, _ := pipeline.PageLastModified(doc)
+		days, _ := doc.FrontMatter["stale_days"].(int)
+		page := StalePage{
+			URL:          pipeline.ContentURL(doc.Path),
+			Source:       doc.RepoRelativePath(),
+			LastModified: modified.UTC().Format("2006-01-02"),
+			AgeDays:      days,
+		}
+		if doc.GitHistory != nil {
+			page.LastAuthor = doc.GitHistory.LastAuthor
+		}
+		report.Repositories[doc.Repository] = append(report.Repositories[doc.Repository], page)
+		report.StalePages++
+	}
+	for repo := range report.Repositories {
+		pages := report.Repositories[repo]
+		sort.Slice(pages, func(i, j int) bool {
+			if pages[i].AgeDays != pages[j].AgeDays {
+				return pages[i].AgeDays > pages[j].AgeDays
+			}
+			return pages[i].URL < pages[j].URL
+		})
+	}
+
+	jb, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal staleness report: %w", err)
+	}
+	// #nosec G306 -- report contains public page metadata only
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), StalenessReportJSON), jb, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write staleness report: %w", herrors.ErrContentWriteFailed, err)
+	}
+	// #nosec G306 -- report contains public page metadata only
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), StalenessReportMarkdown), []byte(report.Markdown()), 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write staleness report: %w", herrors.ErrContentWriteFailed, err)
+	}
+	return nil
+}
+
+// Markdown renders the report as a Markdown document with one table per repository.
+func (r *StalenessReport) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Stale Documentation Report\n\n")
+	fmt.Fprintf(&sb, "Generated %s. Pages not modified for %d days or more: %d of %d.\n",
+		r.GeneratedAt.Format(time.RFC3339), r.MaxAgeDays, r.StalePages, r.TotalPages)
+
+	repos := make([]string, 0, len(r.Repositories))
+	for repo := range r.Repositories {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		fmt.Fprintf(&sb, "\n## %s\n\n", repo)
+		sb.WriteString("| Page | Source | Last modified | Age (days) | Last author |\n")
+		sb.WriteString("|------|--------|---------------|------------|-------------|\n")
+		for _, p := range r.Repositories[repo] {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %d | %s |\n", p.URL, p.Source, p.LastModified, p.AgeDays, p.LastAuthor)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package hugo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	herrors "git.home.luguber.info/inful/docbuilder/internal/hugo/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
+)
+
+// writeTemplateIndex collects template pages (those carrying docbuilder.template metadata)
+// into templates.json for the daemon template API. Invalid templates are logged and
+// skipped; nothing is written when the build contains no templates.
+func (g *Generator) writeTemplateIndex(processed []*pipeline.Document) error {
+	var entries []templating.IndexedTemplate
+	for _, doc := range processed {
+		page, err := templating.TemplatePageFromFrontMatter(doc.FrontMatter, doc.Content)
+		if errors.Is(err, templating.ErrNoTemplateMetadata) {
+			continue
+		}
+		if err == nil {
+			var entry templating.IndexedTemplate
+			entry, err = templating.NewIndexedTemplate(page, pipeline.ContentURL(doc.Path), doc.Repository)
+			if err == nil {
+				entries = append(entries, entry)
+				continue
+			}
+		}
+		g.log().Warn("Skipping invalid template page", logfields.Path(doc.Path), logfields.Error(err))
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Type != entries[j].Type {
+			return entries[i].Type < entries[j].Type
+		}
+		return entries[i].URL < entries[j].URL
+	})
+
+	b, err := json.MarshalIndent(templating.TemplateIndex{GeneratedAt: time.Now().UTC(), Templates: entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal template index: %w", err)
+	}
+	// #nosec G306 -- template index contains public page content only
+	if err := os.WriteFile(filepath.Join(g.BuildRoot(), templating.TemplateIndexFile), b, 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write template index: %w", herrors.ErrContentWriteFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package hugo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/pipeline"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
+)
+
+func TestWriteTemplateIndex(t *testing.T) {
+	g := NewGenerator(&config.Config{}, t.TempDir())
+	templateMeta := func(typ string, schema any) map[string]any {
+		return map[string]any{"params": map[string]any{"docbuilder": map[string]any{"template": map[string]any{
+			"type": typ, "name": typ, "output_path": typ + "/{{ .Slug }}.md", "schema": schema,
+		}}}}
+	}
+	processed := []*pipeline.Document{
+		{Path: "content/repo/templates/adr.template.md", Repository: "repo",
+			FrontMatter: templateMeta("adr", map[string]any{"fields": []any{map[string]any{"key": "Slug", "type": "string"}}}),
+			Content:     "# ADR\n\n```markdown\n# {{ .Slug }}\n```\n"},
+		{Path: "content/repo/templates/broken.template.md", Repository: "repo",
+			FrontMatter: templateMeta("broken", "{not json"),
+			Content:     "```markdown\nbody\n```\n"},
+		{Path: "content/repo/guide.md", Repository: "repo", FrontMatter: map[string]any{"title": "Guide"}, Content: "# Guide\n"},
+	}
+	if err := g.writeTemplateIndex(processed); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// #nosec G304 -- test reads from its own temp dir
+	b, err := os.ReadFile(filepath.Join(g.BuildRoot(), templating.TemplateIndexFile))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var index templating.TemplateIndex
+	if err := json.Unmarshal(b, &index); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(index.Templates) != 1 {
+		t.Fatalf("expected only the valid template, got %+v", index.Templates)
+	}
+	got := index.Templates[0]
+	if got.Type != "adr" || got.Repository != "repo" || got.URL != "/repo/templates/adr.template" || got.Body != "# {{ .Slug }}" {
+		t.Fatalf("unexpected template entry: %+v", got)
+	}
+	schema, err := templating.ParseTemplateSchema(got.Page().Meta.Schema)
+	if err != nil || len(schema.Fields) != 1 || schema.Fields[0].Key != "Slug" {
+		t.Fatalf("unexpected schema %+v: %v", schema, err)
+	}
+}
+
+func TestWriteTemplateIndex_NoTemplates(t *testing.T) {
+	g := NewGenerator(&config.Config{}, t.TempDir())
+	if err := g.writeTemplateIndex([]*pipeline.Document{{Path: "content/repo/a.md", FrontMatter: map[string]any{}}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(g.BuildRoot(), templating.TemplateIndexFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected no template index, got err=%v", err)
+	}
+}
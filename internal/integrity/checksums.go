@@ -0,0 +1,252 @@
+// Package integrity protects a published site: subresource integrity attributes for
+// local scripts and stylesheets, a SHA256SUMS checksum file over every published file,
+// minisign/cosign signatures of that file, and verification of a deployed copy.
+package integrity
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumFile is the checksum file at the site root, in `sha256sum` format.
+const ChecksumFile = "SHA256SUMS"
+
+// Result is the outcome of verifying a site against its checksum file.
+type Result struct {
+	Files     int      `json:"files"`               // Files listed in the checksum file
+	Missing   []string `json:"missing,omitempty"`   // Listed but not published
+	Modified  []string `json:"modified,omitempty"`  // Published with a different hash
+	Untracked []string `json:"untracked,omitempty"` // Published but not listed (local directories only)
+	Signature string   `json:"signature,omitempty"` // Verified signature file, if any
+}
+
+// OK reports whether the site matches its checksum file.
+func (r *Result) OK() bool {
+	return len(r.Missing) == 0 && len(r.Modified) == 0 && len(r.Untracked) == 0
+}
+
+// WriteChecksums writes ChecksumFile to dir, listing the SHA-256 hash of every file
+// below it (sorted by path). Existing checksum and signature files are not listed.
+func WriteChecksums(dir string) error {
+	files, err := siteFiles(dir)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, rel := range files {
+		sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s  %s\n", sum, rel)
+	}
+	// #nosec G306 -- the checksum file is published with the site
+	return os.WriteFile(filepath.Join(dir, ChecksumFile), []byte(b.String()), 0o644)
+}
+
+// Verify checks a published site against its checksum file. target is a local site
+// directory or the http(s) URL of a deployed site; over HTTP only listed files can be
+// checked, so files added by the server are not detected. When sig is set, the
+// signature of the checksum file is verified first.
+func Verify(ctx context.Context, client *http.Client, target string, sig *SignatureCheck) (*Result, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		base, err := url.Parse(strings.TrimSuffix(target, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid site URL: %w", err)
+		}
+		return verifySite(ctx, sig, func(rel string) ([]byte, error) { return fetch(ctx, client, base, rel) }, nil)
+	}
+	read := func(rel string) ([]byte, error) {
+		// #nosec G304 -- rel is a checksum file entry, confined to the site directory by parseChecksums
+		data, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(rel)))
+		if os.IsNotExist(err) {
+			return nil, notFoundError{path: rel}
+		}
+		return data, err
+	}
+	return verifySite(ctx, sig, read, func() ([]string, error) { return siteFiles(target) })
+}
+
+// verifySite verifies the files returned by read; list returns every published file
+// when untracked files can be detected.
+func verifySite(ctx context.Context, sig *SignatureCheck, read func(rel string) ([]byte, error), list func() ([]string, error)) (*Result, error) {
+	data, err := read(ChecksumFile)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", ChecksumFile, err)
+	}
+	res := &Result{}
+	if sig != nil {
+		name := SignatureFile(sig.Tool)
+		signature, err := read(name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		if err := sig.verify(ctx, data, signature); err != nil {
+			return nil, err
+		}
+		res.Signature = name
+	}
+	sums, err := parseChecksums(data)
+	if err != nil {
+		return nil, err
+	}
+	res.Files = len(sums)
+	for _, rel := range sortedKeys(sums) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		body, err := read(rel)
+		if err != nil {
+			if isNotFound(err) {
+				res.Missing = append(res.Missing, rel)
+				continue
+			}
+			return nil, err
+		}
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != sums[rel] {
+			res.Modified = append(res.Modified, rel)
+		}
+	}
+	if list != nil {
+		files, err := list()
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range files {
+			if _, ok := sums[rel]; !ok {
+				res.Untracked = append(res.Untracked, rel)
+			}
+		}
+	}
+	return res, nil
+}
+
+// notFoundError reports a file listed in the checksum file that is not published.
+type notFoundError struct{ path string }
+
+func (e notFoundError) Error() string { return e.path + ": not found" }
+
+func isNotFound(err error) bool {
+	_, ok := err.(notFoundError)
+	return ok
+}
+
+// fetch downloads a site file relative to base.
+func fetch(ctx context.Context, client *http.Client, base *url.URL, rel string) ([]byte, error) {
+	ref, err := url.Parse(escapePath(rel))
+	if err != nil {
+		return nil, err
+	}
+	u := base.ResolveReference(ref)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Ask for the bytes as published, without a content encoding.
+	req.Header.Set("Accept-Encoding", "identity")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", u, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, notFoundError{path: rel}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetch %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// escapePath escapes each segment of a slash-separated relative path.
+func escapePath(rel string) string {
+	segments := strings.Split(rel, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// parseChecksums reads a `sha256sum` file ("<hex>  <path>" per line).
+func parseChecksums(data []byte) (map[string]string, error) {
+	sums := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		sum, rel, ok := strings.Cut(line, "  ")
+		if !ok || len(sum) != sha256.Size*2 || !localPath(rel) {
+			return nil, fmt.Errorf("%s line %d: malformed entry", ChecksumFile, n)
+		}
+		sums[rel] = strings.ToLower(sum)
+	}
+	return sums, sc.Err()
+}
+
+// localPath reports whether rel is a relative path that stays inside the site.
+func localPath(rel string) bool {
+	clean := path.Clean(rel)
+	return rel != "" && !path.IsAbs(rel) && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// siteFiles lists the regular files below dir as slash-separated relative paths,
+// without the checksum file and its signatures.
+func siteFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || !d.Type().IsRegular() {
+			return walkErr
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ChecksumFile || strings.HasPrefix(rel, ChecksumFile+".") {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func hashFile(file string) (string, error) {
+	// #nosec G304 -- file is below the site directory being hashed
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+package integrity
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func sri(content string) string {
+	sum := sha512.Sum384([]byte(content))
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestInjectSRI(t *testing.T) {
+	public := t.TempDir()
+	writeFile(t, filepath.Join(public, "js", "app.js"), "console.log(1)")
+	writeFile(t, filepath.Join(public, "css", "theme.css"), "body{}")
+	writeFile(t, filepath.Join(public, "guide", "index.html"), `<html><head>
+<script src="/docs/js/app.js?v=1"></script>
+<link rel=stylesheet href=../css/theme.css />
+<link rel="icon" href="/docs/favicon.ico">
+<script src="https://cdn.example.com/lib.js"></script>
+<script src="/docs/js/app.js" integrity="sha256-x"></script>
+<script src="/docs/missing.js"></script>
+</head></html>`)
+
+	n, err := InjectSRI(public, "/docs/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	data, err := os.ReadFile(filepath.Join(public, "guide", "index.html"))
+	require.NoError(t, err)
+	page := string(data)
+	assert.Contains(t, page, `<script src="/docs/js/app.js?v=1" integrity="`+sri("console.log(1)")+`"></script>`)
+	assert.Contains(t, page, `<link rel=stylesheet href=../css/theme.css integrity="`+sri("body{}")+`"/>`)
+	assert.Contains(t, page, `<link rel="icon" href="/docs/favicon.ico">`)
+	assert.Contains(t, page, `<script src="https://cdn.example.com/lib.js"></script>`)
+	assert.Contains(t, page, `integrity="sha256-x"></script>`)
+	assert.Contains(t, page, `<script src="/docs/missing.js"></script>`)
+}
+
+func TestChecksums_WriteAndVerify(t *testing.T) {
+	public := t.TempDir()
+	writeFile(t, filepath.Join(public, "index.html"), "home")
+	writeFile(t, filepath.Join(public, "guide", "index.html"), "guide")
+	writeFile(t, filepath.Join(public, "js", "app.js"), "app()")
+	require.NoError(t, WriteChecksums(public))
+
+	sums, err := os.ReadFile(filepath.Join(public, ChecksumFile))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(sums)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasSuffix(lines[0], "  guide/index.html"))
+
+	res, err := Verify(t.Context(), http.DefaultClient, public, nil)
+	require.NoError(t, err)
+	assert.True(t, res.OK())
+	assert.Equal(t, 3, res.Files)
+
+	// Served over HTTP, the same site verifies.
+	srv := httptest.NewServer(http.FileServer(http.Dir(public)))
+	defer srv.Close()
+	res, err = Verify(t.Context(), srv.Client(), srv.URL+"/", nil)
+	require.NoError(t, err)
+	assert.True(t, res.OK())
+
+	writeFile(t, filepath.Join(public, "guide", "index.html"), "tampered")
+	writeFile(t, filepath.Join(public, "extra.js"), "evil()")
+	require.NoError(t, os.Remove(filepath.Join(public, "js", "app.js")))
+	res, err = Verify(t.Context(), http.DefaultClient, public, nil)
+	require.NoError(t, err)
+	assert.False(t, res.OK())
+	assert.Equal(t, []string{"js/app.js"}, res.Missing)
+	assert.Equal(t, []string{"guide/index.html"}, res.Modified)
+	assert.Equal(t, []string{"extra.js"}, res.Untracked)
+
+	res, err = Verify(t.Context(), srv.Client(), srv.URL, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"js/app.js"}, res.Missing)
+	assert.Equal(t, []string{"guide/index.html"}, res.Modified)
+	assert.Empty(t, res.Untracked)
+}
+
+func TestParseChecksums_RejectsPathsOutsideTheSite(t *testing.T) {
+	sum := strings.Repeat("a", 64)
+	_, err := parseChecksums([]byte(sum + "  ../etc/passwd\n"))
+	require.Error(t, err)
+	_, err = parseChecksums([]byte(sum + "  /etc/passwd\n"))
+	require.Error(t, err)
+	sums, err := parseChecksums([]byte(sum + "  ..hidden/file\n"))
+	require.NoError(t, err)
+	assert.Contains(t, sums, "..hidden/file")
+}
+
+func TestSignAndVerifySignature(t *testing.T) {
+	public := t.TempDir()
+	writeFile(t, filepath.Join(public, "index.html"), "home")
+	require.NoError(t, WriteChecksums(public))
+
+	// A stand-in for minisign: -S copies the message (-m) to the signature (-x);
+	// -V succeeds when they are equal.
+	script := filepath.Join(t.TempDir(), "minisign.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+mode=$1; shift
+while [ $# -gt 0 ]; do case $1 in -m) m=$2; shift;; -x) x=$2; shift;; esac; shift; done
+case $mode in -S) cp "$m" "$x";; -V) cmp -s "$m" "$x";; esac
+`), 0o700)) // #nosec G306 -- test script must be executable
+
+	require.NoError(t, Sign(t.Context(), public, &config.SigningConfig{Tool: config.SignMinisign, Key: "key", Command: script}))
+	assert.FileExists(t, filepath.Join(public, "SHA256SUMS.minisig"))
+
+	check := &SignatureCheck{Tool: config.SignMinisign, Command: script, PublicKey: "key.pub"}
+	res, err := Verify(t.Context(), http.DefaultClient, public, check)
+	require.NoError(t, err)
+	assert.Equal(t, "SHA256SUMS.minisig", res.Signature)
+	assert.True(t, res.OK())
+
+	writeFile(t, filepath.Join(public, "SHA256SUMS.minisig"), "forged")
+	_, err = Verify(t.Context(), http.DefaultClient, public, check)
+	require.ErrorContains(t, err, "does not verify")
+}
@@ -0,0 +1,104 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// signTimeout bounds a single signing or verification run.
+const signTimeout = 2 * time.Minute
+
+// SignatureFile returns the name of the signature of ChecksumFile made with tool.
+func SignatureFile(tool config.SignTool) string {
+	if tool == config.SignCosign {
+		return ChecksumFile + ".sig"
+	}
+	return ChecksumFile + ".minisig"
+}
+
+// Sign signs the checksum file of dir, writing SignatureFile next to it.
+func Sign(ctx context.Context, dir string, s *config.SigningConfig) error {
+	sums := filepath.Join(dir, ChecksumFile)
+	sig := filepath.Join(dir, SignatureFile(s.Tool))
+	var args []string
+	switch s.Tool {
+	case config.SignCosign:
+		args = append([]string{"sign-blob", "--yes", "--key", s.Key, "--output-signature", sig}, s.Args...)
+		args = append(args, sums)
+	default:
+		args = append([]string{"-S", "-s", s.Key, "-m", sums, "-x", sig}, s.Args...)
+	}
+	if err := run(ctx, s.SignCommand(), args); err != nil {
+		return fmt.Errorf("sign %s with %s: %w", ChecksumFile, s.Tool, err)
+	}
+	return nil
+}
+
+// SignatureCheck verifies the signature of a checksum file with a public key.
+type SignatureCheck struct {
+	Tool      config.SignTool
+	Command   string // Executable (default: the tool name on PATH)
+	PublicKey string // Public key file
+}
+
+// verify checks signature over sums. The tools read files, so both are staged in a
+// temporary directory.
+func (c *SignatureCheck) verify(ctx context.Context, sums, signature []byte) error {
+	tmp, err := os.MkdirTemp("", "docbuilder-verify-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	sumsFile := filepath.Join(tmp, ChecksumFile)
+	sigFile := filepath.Join(tmp, SignatureFile(c.Tool))
+	if err := os.WriteFile(sumsFile, sums, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(sigFile, signature, 0o600); err != nil {
+		return err
+	}
+
+	var args []string
+	switch c.Tool {
+	case config.SignCosign:
+		args = []string{"verify-blob", "--key", c.PublicKey, "--signature", sigFile, sumsFile}
+	default:
+		args = []string{"-V", "-p", c.PublicKey, "-m", sumsFile, "-x", sigFile}
+	}
+	command := c.Command
+	if command == "" {
+		command = string(c.Tool)
+	}
+	if err := run(ctx, command, args); err != nil {
+		return fmt.Errorf("signature of %s does not verify: %w", ChecksumFile, err)
+	}
+	return nil
+}
+
+// run executes a signing tool, including its stderr in the error.
+func run(ctx context.Context, name string, args []string) error {
+	runCtx, cancel := context.WithTimeout(ctx, signTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, name, args...) // #nosec G204 -- signing command comes from the configuration or flags
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", signTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
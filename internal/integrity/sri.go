@@ -0,0 +1,145 @@
+package integrity
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// sriTagPattern matches script and link start tags.
+	sriTagPattern = regexp.MustCompile(`(?is)<(script|link)\b[^>]*>`)
+	// sriAttrPattern matches an attribute with a double-quoted, single-quoted or bare value.
+	sriAttrPattern = regexp.MustCompile(`(?is)\s([a-z][a-z0-9-]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+)
+
+// InjectSRI adds integrity="sha384-..." to the <script src> and stylesheet <link href>
+// tags of the HTML files below publicDir that reference files of the site. Tags that
+// already carry an integrity attribute and remote resources are left alone. basePath
+// is the URL path the site is served under (for example "/docs/"). It returns the
+// number of tags changed.
+func InjectSRI(publicDir, basePath string) (int, error) {
+	basePath = "/" + strings.Trim(basePath, "/")
+	hashes := make(map[string]string) // file -> integrity value; "" when not in the site
+	changed := 0
+	err := filepath.WalkDir(publicDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".html") {
+			return walkErr
+		}
+		// #nosec G304 -- p comes from walking the rendered site
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(publicDir, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		pageDir := path.Clean("/" + filepath.ToSlash(rel))
+		n := 0
+		out := sriTagPattern.ReplaceAllStringFunc(string(data), func(tag string) string {
+			ref := sriReference(tag)
+			if ref == "" {
+				return tag
+			}
+			file := resolveSiteFile(publicDir, pageDir, basePath, ref)
+			if file == "" {
+				return tag
+			}
+			value, seen := hashes[file]
+			if !seen {
+				value = integrityValue(file)
+				hashes[file] = value
+			}
+			if value == "" {
+				return tag
+			}
+			n++
+			return insertAttribute(tag, `integrity="`+value+`"`)
+		})
+		if n == 0 {
+			return nil
+		}
+		changed += n
+		// #nosec G306 -- rendered pages are public
+		return os.WriteFile(p, []byte(out), 0o644)
+	})
+	return changed, err
+}
+
+// sriReference returns the URL a script or stylesheet tag loads, or "" when the tag
+// needs no integrity attribute.
+func sriReference(tag string) string {
+	attrs := make(map[string]string)
+	for _, m := range sriAttrPattern.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(m[1])] = m[2] + m[3] + m[4]
+	}
+	if _, ok := attrs["integrity"]; ok {
+		return ""
+	}
+	var ref string
+	if strings.HasPrefix(strings.ToLower(tag), "<script") {
+		ref = attrs["src"]
+	} else if rels := strings.Fields(strings.ToLower(attrs["rel"])); slices.Contains(rels, "stylesheet") {
+		ref = attrs["href"]
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.Contains(ref, "://") || strings.HasPrefix(ref, "//") || strings.HasPrefix(ref, "data:") {
+		return ""
+	}
+	return ref
+}
+
+// resolveSiteFile maps a local URL to a file below publicDir, or "" when there is none.
+func resolveSiteFile(publicDir, pageDir, basePath, ref string) string {
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	var sitePath string
+	if strings.HasPrefix(ref, "/") {
+		sitePath = path.Clean(ref)
+		if basePath != "/" {
+			if sitePath != basePath && !strings.HasPrefix(sitePath, basePath+"/") {
+				return ""
+			}
+			sitePath = strings.TrimPrefix(sitePath, basePath)
+		}
+	} else {
+		sitePath = path.Join(pageDir, ref)
+	}
+	sitePath = strings.TrimPrefix(path.Clean("/"+sitePath), "/")
+	if sitePath == "" {
+		return ""
+	}
+	file := filepath.Join(publicDir, filepath.FromSlash(sitePath))
+	if info, err := os.Stat(file); err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+	return file
+}
+
+// integrityValue returns the SRI value of a file, or "" when it cannot be read.
+func integrityValue(file string) string {
+	// #nosec G304 -- file is below the rendered site
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	sum := sha512.Sum384(data)
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// insertAttribute adds attr before the end of a start tag.
+func insertAttribute(tag, attr string) string {
+	end := len(tag) - 1
+	if strings.HasSuffix(tag, "/>") {
+		end--
+	}
+	return strings.TrimRight(tag[:end], " \t\r\n") + " " + attr + tag[end:]
+}
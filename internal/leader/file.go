@@ -0,0 +1,124 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileLock stores the lease as JSON in a file on storage shared by all replicas.
+//
+// Updates are serialized with an advisory lock on a sibling "<path>.lock" file. The
+// guard file is never removed; the lock of a crashed replica is released with its file
+// descriptors, so there is no stale guard to take over.
+type FileLock struct {
+	path string
+}
+
+// NewFileLock returns a Lock backed by the lease file at path.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Describe implements Lock.
+func (f *FileLock) Describe() string { return "file " + f.path }
+
+// TryAcquireOrRenew implements Lock.
+func (f *FileLock) TryAcquireOrRenew(_ context.Context, identity string, duration time.Duration, now time.Time) (Record, bool, error) {
+	var (
+		result Record
+		held   bool
+	)
+	err := f.withGuard(func() error {
+		current, found, err := f.read()
+		if err != nil {
+			return err
+		}
+		var stored *Record
+		if found {
+			stored = &current
+		}
+		next, ok := stored.next(identity, duration, now)
+		if !ok {
+			result = next
+			return nil
+		}
+		if err := f.write(next); err != nil {
+			return err
+		}
+		result, held = next, true
+		return nil
+	})
+	return result, held, err
+}
+
+// Release implements Lock.
+func (f *FileLock) Release(_ context.Context, identity string) error {
+	return f.withGuard(func() error {
+		current, found, err := f.read()
+		if err != nil || !found || current.HolderIdentity != identity {
+			return err
+		}
+		current.HolderIdentity = ""
+		return f.write(current)
+	})
+}
+
+// withGuard runs fn while holding the lock on the guard file.
+func (f *FileLock) withGuard(fn func() error) error {
+	guard := f.path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o750); err != nil {
+		return fmt.Errorf("create lease directory: %w", err)
+	}
+	// #nosec G304 -- the guard path comes from the leader election configuration
+	file, err := os.OpenFile(guard, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("open lease guard: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	locked, err := tryLockFile(file)
+	if err != nil {
+		return fmt.Errorf("lock lease file: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("lease file %s is locked by another replica", f.path)
+	}
+	defer func() { _ = unlockFile(file) }()
+	return fn()
+}
+
+// read returns the stored record and whether the lease file exists.
+func (f *FileLock) read() (Record, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("read lease file: %w", err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return Record{}, false, fmt.Errorf("parse lease file %s: %w", f.path, err)
+	}
+	return record, true, nil
+}
+
+// write replaces the lease file atomically.
+func (f *FileLock) write(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write lease file: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("replace lease file: %w", err)
+	}
+	return nil
+}
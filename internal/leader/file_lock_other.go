@@ -0,0 +1,19 @@
+//go:build !unix
+
+package leader
+
+import (
+	"errors"
+	"os"
+)
+
+// errFileLockUnsupported is returned by the file backend on platforms without flock.
+var errFileLockUnsupported = errors.New("file leases are only supported on Unix platforms")
+
+func tryLockFile(*os.File) (bool, error) {
+	return false, errFileLockUnsupported
+}
+
+func unlockFile(*os.File) error {
+	return errFileLockUnsupported
+}
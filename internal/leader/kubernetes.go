@@ -0,0 +1,275 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// In-cluster service account files mounted into every pod.
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	microTimeLayout   = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// KubernetesLock stores the lease in a coordination.k8s.io/v1 Lease object, using the
+// pod's service account. Updates use the object's resourceVersion, so concurrent
+// writers conflict instead of overwriting each other.
+//
+// The service account needs get, create and update on leases in the namespace.
+type KubernetesLock struct {
+	client    *http.Client
+	baseURL   string
+	namespace string
+	name      string
+	token     func() (string, error)
+}
+
+// NewKubernetesLock returns a Lock for the Lease namespace/name using the in-cluster
+// API server address and service account. An empty namespace uses the pod's namespace.
+func NewKubernetesLock(namespace, name string) (*KubernetesLock, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("kubernetes leader election requires running in a cluster (KUBERNETES_SERVICE_HOST is not set)")
+	}
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("read pod namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	caData, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("read cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, errors.New("cluster CA certificate is invalid")
+	}
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+	// Projected service account tokens rotate, so the token is read for every request.
+	token := func() (string, error) {
+		data, err := os.ReadFile(serviceAccountDir + "/token")
+		if err != nil {
+			return "", fmt.Errorf("read service account token: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return newKubernetesLock("https://"+net.JoinHostPort(host, port), client, namespace, name, token), nil
+}
+
+func newKubernetesLock(baseURL string, client *http.Client, namespace, name string, token func() (string, error)) *KubernetesLock {
+	return &KubernetesLock{client: client, baseURL: baseURL, namespace: namespace, name: name, token: token}
+}
+
+// Describe implements Lock.
+func (k *KubernetesLock) Describe() string {
+	return "kubernetes lease " + k.namespace + "/" + k.name
+}
+
+// lease is the subset of the coordination.k8s.io/v1 Lease object used here.
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       *string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds *int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string  `json:"acquireTime,omitempty"`
+	RenewTime            string  `json:"renewTime,omitempty"`
+	LeaseTransitions     int     `json:"leaseTransitions,omitempty"`
+}
+
+func (s leaseSpec) record() Record {
+	var r Record
+	if s.HolderIdentity != nil {
+		r.HolderIdentity = *s.HolderIdentity
+	}
+	if s.LeaseDurationSeconds != nil {
+		r.LeaseDuration = time.Duration(*s.LeaseDurationSeconds) * time.Second
+	}
+	r.AcquireTime, _ = time.Parse(microTimeLayout, s.AcquireTime)
+	r.RenewTime, _ = time.Parse(microTimeLayout, s.RenewTime)
+	r.Transitions = s.LeaseTransitions
+	return r
+}
+
+func specFor(r Record) leaseSpec {
+	holder := r.HolderIdentity
+	seconds := max(int(r.LeaseDuration/time.Second), 1)
+	spec := leaseSpec{
+		HolderIdentity:       &holder,
+		LeaseDurationSeconds: &seconds,
+		LeaseTransitions:     r.Transitions,
+	}
+	if !r.AcquireTime.IsZero() {
+		spec.AcquireTime = r.AcquireTime.UTC().Format(microTimeLayout)
+	}
+	if !r.RenewTime.IsZero() {
+		spec.RenewTime = r.RenewTime.UTC().Format(microTimeLayout)
+	}
+	return spec
+}
+
+// errLeaseConflict reports a create or update that lost the race to another replica.
+var errLeaseConflict = errors.New("lease was modified by another replica")
+
+// TryAcquireOrRenew implements Lock.
+func (k *KubernetesLock) TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration, now time.Time) (Record, bool, error) {
+	current, found, err := k.get(ctx)
+	if err != nil {
+		return Record{}, false, err
+	}
+	var stored *Record
+	if found {
+		r := current.Spec.record()
+		stored = &r
+	}
+	next, ok := stored.next(identity, duration, now)
+	if !ok {
+		return next, false, nil
+	}
+
+	obj := lease{
+		APIVersion: "coordination.k8s.io/v1",
+		Kind:       "Lease",
+		Metadata:   leaseMetadata{Name: k.name, Namespace: k.namespace},
+		Spec:       specFor(next),
+	}
+	if found {
+		obj.Metadata.ResourceVersion = current.Metadata.ResourceVersion
+		err = k.send(ctx, http.MethodPut, k.leaseURL(), obj)
+	} else {
+		err = k.send(ctx, http.MethodPost, k.collectionURL(), obj)
+	}
+	if errors.Is(err, errLeaseConflict) {
+		if stored != nil {
+			return *stored, false, nil
+		}
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, err
+	}
+	return next, true, nil
+}
+
+// Release implements Lock.
+func (k *KubernetesLock) Release(ctx context.Context, identity string) error {
+	current, found, err := k.get(ctx)
+	if err != nil || !found {
+		return err
+	}
+	record := current.Spec.record()
+	if record.HolderIdentity != identity {
+		return nil
+	}
+	record.HolderIdentity = ""
+	record.LeaseDuration = time.Second
+	current.Spec = specFor(record)
+	err = k.send(ctx, http.MethodPut, k.leaseURL(), current)
+	if errors.Is(err, errLeaseConflict) {
+		return nil
+	}
+	return err
+}
+
+func (k *KubernetesLock) collectionURL() string {
+	return k.baseURL + "/apis/coordination.k8s.io/v1/namespaces/" + url.PathEscape(k.namespace) + "/leases"
+}
+
+func (k *KubernetesLock) leaseURL() string {
+	return k.collectionURL() + "/" + url.PathEscape(k.name)
+}
+
+// get fetches the Lease object; found is false when it does not exist yet.
+func (k *KubernetesLock) get(ctx context.Context) (lease, bool, error) {
+	var obj lease
+	resp, err := k.do(ctx, http.MethodGet, k.leaseURL(), nil)
+	if err != nil {
+		return obj, false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(&obj); err != nil {
+			return obj, false, fmt.Errorf("decode lease: %w", err)
+		}
+		return obj, true, nil
+	case http.StatusNotFound:
+		return obj, false, nil
+	default:
+		return obj, false, apiError(resp)
+	}
+}
+
+// send creates or updates the Lease object.
+func (k *KubernetesLock) send(ctx context.Context, method, target string, obj lease) error {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	resp, err := k.do(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusConflict:
+		return errLeaseConflict
+	default:
+		return apiError(resp)
+	}
+}
+
+func (k *KubernetesLock) do(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	token, err := k.token()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes API request: %w", err)
+	}
+	return resp, nil
+}
+
+func apiError(resp *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("kubernetes API returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+}
@@ -0,0 +1,119 @@
+package leader
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLeaseAPI serves a single Lease object with resourceVersion conflict checks.
+type fakeLeaseAPI struct {
+	mu      sync.Mutex
+	lease   *lease
+	version int
+}
+
+func (f *fakeLeaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	const collection = "/apis/coordination.k8s.io/v1/namespaces/docs/leases"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == collection+"/docbuilder":
+		if f.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.lease)
+	case r.Method == http.MethodPost && r.URL.Path == collection:
+		if f.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.store(w, r, http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == collection+"/docbuilder":
+		var obj lease
+		_ = json.NewDecoder(r.Body).Decode(&obj)
+		if f.lease == nil || obj.Metadata.ResourceVersion != f.lease.Metadata.ResourceVersion {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.save(w, obj, http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeLeaseAPI) store(w http.ResponseWriter, r *http.Request, status int) {
+	var obj lease
+	_ = json.NewDecoder(r.Body).Decode(&obj)
+	f.save(w, obj, status)
+}
+
+func (f *fakeLeaseAPI) save(w http.ResponseWriter, obj lease, status int) {
+	f.version++
+	obj.Metadata.ResourceVersion = strconv.Itoa(f.version)
+	f.lease = &obj
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func TestKubernetesLock(t *testing.T) {
+	api := &fakeLeaseAPI{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	token := func() (string, error) { return "test-token", nil }
+	lock := newKubernetesLock(srv.URL, srv.Client(), "docs", "docbuilder", token)
+	ctx := t.Context()
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	record, held, err := lock.TryAcquireOrRenew(ctx, "replica-a", 15*time.Second, now)
+	if err != nil || !held || record.HolderIdentity != "replica-a" {
+		t.Fatalf("create: record=%+v held=%v err=%v", record, held, err)
+	}
+	if got := *api.lease.Spec.HolderIdentity; got != "replica-a" || *api.lease.Spec.LeaseDurationSeconds != 15 {
+		t.Fatalf("unexpected stored lease: %+v", api.lease.Spec)
+	}
+
+	record, held, err = lock.TryAcquireOrRenew(ctx, "replica-b", 15*time.Second, now.Add(5*time.Second))
+	if err != nil || held || record.HolderIdentity != "replica-a" {
+		t.Fatalf("follower: record=%+v held=%v err=%v", record, held, err)
+	}
+
+	record, held, err = lock.TryAcquireOrRenew(ctx, "replica-a", 15*time.Second, now.Add(5*time.Second))
+	if err != nil || !held || !record.RenewTime.Equal(now.Add(5*time.Second)) {
+		t.Fatalf("renew: record=%+v held=%v err=%v", record, held, err)
+	}
+
+	record, held, err = lock.TryAcquireOrRenew(ctx, "replica-b", 15*time.Second, now.Add(30*time.Second))
+	if err != nil || !held || record.HolderIdentity != "replica-b" || api.lease.Spec.LeaseTransitions != 1 {
+		t.Fatalf("takeover: record=%+v held=%v err=%v", record, held, err)
+	}
+
+	if err := lock.Release(ctx, "replica-b"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if got := *api.lease.Spec.HolderIdentity; got != "" {
+		t.Fatalf("expected released lease, holder %q", got)
+	}
+}
+
+func TestKubernetesLockAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "leases is forbidden", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	lock := newKubernetesLock(srv.URL, srv.Client(), "docs", "docbuilder", func() (string, error) { return "t", nil })
+	if _, _, err := lock.TryAcquireOrRenew(t.Context(), "replica-a", 15*time.Second, time.Now()); err == nil {
+		t.Fatalf("expected error for forbidden lease access")
+	}
+}
@@ -0,0 +1,231 @@
+// Package leader elects one leader among daemon replicas using a shared lease.
+//
+// A lease names its holder and is valid until RenewTime+LeaseDuration. Replicas try
+// to acquire or renew the lease every retry period; the holder renews it, everyone
+// else takes it over once it has expired. A leader that cannot renew its lease
+// within the renew deadline steps down before the lease expires, so two replicas
+// never both believe they lead. The lease is stored by a Lock backend: a Kubernetes
+// Lease object or a file on shared storage.
+package leader
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Record is the lease state stored by a Lock.
+type Record struct {
+	HolderIdentity string        `json:"holder_identity"`
+	LeaseDuration  time.Duration `json:"lease_duration"`
+	AcquireTime    time.Time     `json:"acquire_time"`
+	RenewTime      time.Time     `json:"renew_time"`
+	Transitions    int           `json:"transitions"`
+}
+
+// Expired reports whether the lease is no longer valid at now.
+func (r Record) Expired(now time.Time) bool {
+	return r.HolderIdentity == "" || now.After(r.RenewTime.Add(r.LeaseDuration))
+}
+
+// next returns the record identity should store at now and whether identity may take
+// the lease: when it is free, expired or already held by identity.
+func (r *Record) next(identity string, duration time.Duration, now time.Time) (Record, bool) {
+	if r == nil {
+		return Record{HolderIdentity: identity, LeaseDuration: duration, AcquireTime: now, RenewTime: now}, true
+	}
+	if r.HolderIdentity == identity {
+		renewed := *r
+		renewed.LeaseDuration = duration
+		renewed.RenewTime = now
+		return renewed, true
+	}
+	if !r.Expired(now) {
+		return *r, false
+	}
+	return Record{
+		HolderIdentity: identity,
+		LeaseDuration:  duration,
+		AcquireTime:    now,
+		RenewTime:      now,
+		Transitions:    r.Transitions + 1,
+	}, true
+}
+
+// Lock stores the lease. Implementations make acquire-or-renew atomic across replicas.
+type Lock interface {
+	// TryAcquireOrRenew takes or renews the lease for identity. It returns the stored
+	// record and whether identity holds the lease.
+	TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration, now time.Time) (Record, bool, error)
+	// Release gives up the lease if identity holds it, so another replica can take over
+	// without waiting for it to expire.
+	Release(ctx context.Context, identity string) error
+	// Describe names the lease for logs, e.g. "kubernetes lease docs/docbuilder".
+	Describe() string
+}
+
+// Config configures an Elector.
+type Config struct {
+	Lock          Lock
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+
+	// OnStartedLeading is called when this replica becomes leader. Callbacks run on the
+	// election loop and must not block.
+	OnStartedLeading func()
+	// OnStoppedLeading is called when this replica loses or releases the lease.
+	OnStoppedLeading func()
+
+	Logger *slog.Logger
+}
+
+// Status describes the election as seen by one replica.
+type Status struct {
+	Identity    string    `json:"identity"`
+	Leader      string    `json:"leader,omitempty"`
+	IsLeader    bool      `json:"is_leader"`
+	Transitions int       `json:"transitions"`
+	RenewTime   time.Time `json:"renew_time,omitzero"`
+}
+
+// Elector runs the election loop for one replica.
+type Elector struct {
+	cfg Config
+	log *slog.Logger
+	now func() time.Time
+
+	mu          sync.RWMutex
+	leading     bool
+	lastRenewed time.Time
+	observed    Record
+}
+
+// NewElector validates cfg and returns an Elector.
+func NewElector(cfg Config) (*Elector, error) {
+	switch {
+	case cfg.Lock == nil:
+		return nil, errors.New("leader election lock is required")
+	case cfg.Identity == "":
+		return nil, errors.New("leader election identity is required")
+	case cfg.RetryPeriod <= 0 || cfg.RenewDeadline <= cfg.RetryPeriod || cfg.LeaseDuration <= cfg.RenewDeadline:
+		return nil, errors.New("leader election requires lease duration > renew deadline > retry period > 0")
+	}
+	log := cfg.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Elector{cfg: cfg, log: log, now: time.Now}, nil
+}
+
+// Run acquires and renews the lease until ctx is canceled, then releases it.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.RetryPeriod)
+	defer ticker.Stop()
+
+	e.log.Info("Leader election started",
+		slog.String("identity", e.cfg.Identity),
+		slog.String("lease", e.cfg.Lock.Describe()))
+	for {
+		e.tick(ctx)
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsLeader reports whether this replica currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leading
+}
+
+// Status returns this replica's view of the election.
+func (e *Elector) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	leader := e.observed.HolderIdentity
+	if e.observed.Expired(e.now()) {
+		leader = ""
+	}
+	return Status{
+		Identity:    e.cfg.Identity,
+		Leader:      leader,
+		IsLeader:    e.leading,
+		Transitions: e.observed.Transitions,
+		RenewTime:   e.observed.RenewTime,
+	}
+}
+
+func (e *Elector) tick(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+	now := e.now()
+	attemptCtx, cancel := context.WithTimeout(ctx, e.cfg.RetryPeriod)
+	record, held, err := e.cfg.Lock.TryAcquireOrRenew(attemptCtx, e.cfg.Identity, e.cfg.LeaseDuration, now)
+	cancel()
+
+	e.mu.Lock()
+	if err != nil {
+		deadlinePassed := e.leading && now.Sub(e.lastRenewed) >= e.cfg.RenewDeadline
+		e.mu.Unlock()
+		e.log.Warn("Leader election attempt failed", slog.String("identity", e.cfg.Identity), slog.String("error", err.Error()))
+		if deadlinePassed {
+			e.stepDown("renew deadline exceeded")
+		}
+		return
+	}
+	e.observed = record
+	started := held && !e.leading
+	stopped := !held && e.leading
+	if held {
+		e.leading = true
+		e.lastRenewed = now
+	}
+	e.mu.Unlock()
+
+	switch {
+	case started:
+		e.log.Info("Became leader", slog.String("identity", e.cfg.Identity), slog.Int("transitions", record.Transitions))
+		if e.cfg.OnStartedLeading != nil {
+			e.cfg.OnStartedLeading()
+		}
+	case stopped:
+		e.stepDown("lease taken by " + record.HolderIdentity)
+	}
+}
+
+func (e *Elector) stepDown(reason string) {
+	e.mu.Lock()
+	if !e.leading {
+		e.mu.Unlock()
+		return
+	}
+	e.leading = false
+	e.mu.Unlock()
+
+	e.log.Warn("Stopped leading", slog.String("identity", e.cfg.Identity), slog.String("reason", reason))
+	if e.cfg.OnStoppedLeading != nil {
+		e.cfg.OnStoppedLeading()
+	}
+}
+
+func (e *Elector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.RetryPeriod)
+	defer cancel()
+	if err := e.cfg.Lock.Release(ctx, e.cfg.Identity); err != nil {
+		e.log.Warn("Failed to release leader lease", slog.String("identity", e.cfg.Identity), slog.String("error", err.Error()))
+	}
+	e.stepDown("shutting down")
+}
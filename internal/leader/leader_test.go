@@ -0,0 +1,241 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testLease    = 300 * time.Millisecond
+	testDeadline = 200 * time.Millisecond
+	testRetry    = 20 * time.Millisecond
+)
+
+func TestRecordNext(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	held := &Record{HolderIdentity: "a", LeaseDuration: 15 * time.Second, AcquireTime: now, RenewTime: now, Transitions: 2}
+
+	for name, tc := range map[string]struct {
+		current  *Record
+		identity string
+		at       time.Time
+		wantOK   bool
+		want     Record
+	}{
+		"free lease":     {nil, "b", now, true, Record{HolderIdentity: "b", LeaseDuration: time.Minute, AcquireTime: now, RenewTime: now}},
+		"renew own":      {held, "a", now.Add(5 * time.Second), true, Record{HolderIdentity: "a", LeaseDuration: time.Minute, AcquireTime: now, RenewTime: now.Add(5 * time.Second), Transitions: 2}},
+		"held by other":  {held, "b", now.Add(5 * time.Second), false, *held},
+		"expired lease":  {held, "b", now.Add(16 * time.Second), true, Record{HolderIdentity: "b", LeaseDuration: time.Minute, AcquireTime: now.Add(16 * time.Second), RenewTime: now.Add(16 * time.Second), Transitions: 3}},
+		"released lease": {&Record{RenewTime: now, LeaseDuration: time.Second, Transitions: 2}, "b", now, true, Record{HolderIdentity: "b", LeaseDuration: time.Minute, AcquireTime: now, RenewTime: now, Transitions: 3}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, ok := tc.current.next(tc.identity, time.Minute, tc.at)
+			if ok != tc.wantOK || got != tc.want {
+				t.Fatalf("next() = %+v, %v; want %+v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewElectorValidatesConfig(t *testing.T) {
+	lock := NewFileLock(filepath.Join(t.TempDir(), "leader.json"))
+	if _, err := NewElector(Config{Lock: lock, Identity: "a", LeaseDuration: time.Second, RenewDeadline: 2 * time.Second, RetryPeriod: time.Millisecond}); err == nil {
+		t.Fatalf("expected error when renew deadline exceeds lease duration")
+	}
+	if _, err := NewElector(Config{Lock: lock, LeaseDuration: testLease, RenewDeadline: testDeadline, RetryPeriod: testRetry}); err == nil {
+		t.Fatalf("expected error without identity")
+	}
+}
+
+// replica runs an Elector and records its leadership callbacks.
+type replica struct {
+	elector *Elector
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu      sync.Mutex
+	started int
+	stopped int
+}
+
+func startReplica(t *testing.T, lock Lock, identity string) *replica {
+	t.Helper()
+	r := &replica{done: make(chan struct{})}
+	elector, err := NewElector(Config{
+		Lock:             lock,
+		Identity:         identity,
+		LeaseDuration:    testLease,
+		RenewDeadline:    testDeadline,
+		RetryPeriod:      testRetry,
+		OnStartedLeading: func() { r.mu.Lock(); r.started++; r.mu.Unlock() },
+		OnStoppedLeading: func() { r.mu.Lock(); r.stopped++; r.mu.Unlock() },
+	})
+	if err != nil {
+		t.Fatalf("NewElector: %v", err)
+	}
+	r.elector = elector
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	go func() {
+		defer close(r.done)
+		elector.Run(ctx)
+	}()
+	t.Cleanup(r.stop)
+	return r
+}
+
+func (r *replica) stop() {
+	r.cancel()
+	<-r.done
+}
+
+func (r *replica) counts() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.started, r.stopped
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestElectorSingleLeaderAndFailover(t *testing.T) {
+	lock := NewFileLock(filepath.Join(t.TempDir(), "leader.json"))
+	a := startReplica(t, lock, "replica-a")
+	waitFor(t, "replica-a to lead", a.elector.IsLeader)
+
+	b := startReplica(t, lock, "replica-b")
+	waitFor(t, "replica-b to observe the leader", func() bool { return b.elector.Status().Leader == "replica-a" })
+	if b.elector.IsLeader() {
+		t.Fatalf("replica-b must not lead while replica-a holds the lease")
+	}
+
+	// Stopping the leader releases the lease; the follower takes over.
+	a.stop()
+	if started, stopped := a.counts(); started != 1 || stopped != 1 {
+		t.Fatalf("replica-a callbacks: started=%d stopped=%d, want 1/1", started, stopped)
+	}
+	waitFor(t, "replica-b to lead", b.elector.IsLeader)
+	status := b.elector.Status()
+	if status.Leader != "replica-b" || status.Identity != "replica-b" || status.Transitions != 1 {
+		t.Fatalf("unexpected status after failover: %+v", status)
+	}
+}
+
+// flakyLock fails every attempt once broken is set.
+type flakyLock struct {
+	Lock
+
+	mu     sync.Mutex
+	broken bool
+}
+
+func (f *flakyLock) TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration, now time.Time) (Record, bool, error) {
+	f.mu.Lock()
+	broken := f.broken
+	f.mu.Unlock()
+	if broken {
+		return Record{}, false, errors.New("api unavailable")
+	}
+	return f.Lock.TryAcquireOrRenew(ctx, identity, duration, now)
+}
+
+func TestElectorStepsDownAfterRenewDeadline(t *testing.T) {
+	lock := &flakyLock{Lock: NewFileLock(filepath.Join(t.TempDir(), "leader.json"))}
+	a := startReplica(t, lock, "replica-a")
+	waitFor(t, "replica-a to lead", a.elector.IsLeader)
+
+	lock.mu.Lock()
+	lock.broken = true
+	lock.mu.Unlock()
+	waitFor(t, "replica-a to step down", func() bool { return !a.elector.IsLeader() })
+	if _, stopped := a.counts(); stopped != 1 {
+		t.Fatalf("expected OnStoppedLeading once, got %d", stopped)
+	}
+}
+
+func TestFileLockConcurrentTakeover(t *testing.T) {
+	const (
+		rounds   = 20
+		replicas = 16
+	)
+	for round := range rounds {
+		path := filepath.Join(t.TempDir(), "leader.json")
+		// A guard left behind by a crashed replica must not block, nor let two replicas in.
+		guard := path + ".lock"
+		if err := os.WriteFile(guard, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(guard, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			active, overlaps, leaders atomic.Int32
+			wg                        sync.WaitGroup
+		)
+		start := make(chan struct{})
+		now := time.Now()
+		for i := range replicas {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				lock := NewFileLock(path)
+				identity := fmt.Sprintf("replica-%d", i)
+				<-start
+				err := lock.withGuard(func() error {
+					if active.Add(1) > 1 {
+						overlaps.Add(1)
+					}
+					defer active.Add(-1)
+					current, found, err := lock.read()
+					if err != nil {
+						return err
+					}
+					var stored *Record
+					if found {
+						stored = &current
+					}
+					next, ok := stored.next(identity, time.Minute, now)
+					if !ok {
+						return nil
+					}
+					time.Sleep(5 * time.Millisecond)
+					if err := lock.write(next); err != nil {
+						return err
+					}
+					leaders.Add(1)
+					return nil
+				})
+				if err != nil && !strings.Contains(err.Error(), "locked by another replica") {
+					t.Errorf("%s: %v", identity, err)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		if n := overlaps.Load(); n != 0 {
+			t.Fatalf("round %d: guard held by several replicas at once %d times", round, n)
+		}
+		if n := leaders.Load(); n != 1 {
+			t.Fatalf("round %d: %d replicas acquired the lease, want 1", round, n)
+		}
+	}
+}
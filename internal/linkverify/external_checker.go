@@ -0,0 +1,353 @@
+package linkverify
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoExternalLinkReport is returned by LoadExternalLinkReport when no check has run yet.
+var ErrNoExternalLinkReport = errors.New("no external link report")
+
+// ExternalCheckerOptions configures an ExternalChecker.
+type ExternalCheckerOptions struct {
+	MaxConcurrent  int           // links checked in parallel
+	PerHost        int           // links of one host checked in parallel
+	RequestTimeout time.Duration // per request
+	CacheTTL       time.Duration // working links are not rechecked for this long
+	Ignore         []string      // URL prefixes that are not checked
+	ResultsPath    string        // JSON results, reloaded on start to keep the cache
+	PagePath       string        // HTML "broken links" page, rewritten after each run
+}
+
+// ExternalLinkResult is the outcome of checking one external URL.
+type ExternalLinkResult struct {
+	URL       string    `json:"url"`
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Broken    bool      `json:"broken"`
+	CheckedAt time.Time `json:"checked_at"`
+	Cached    bool      `json:"cached,omitempty"` // result of an earlier run, still within cache_ttl
+	Pages     []string  `json:"pages"`            // site paths of the pages linking to the URL
+}
+
+// ExternalLinkReport is the result of one run of the external link check.
+type ExternalLinkReport struct {
+	StartedAt  time.Time            `json:"started_at"`
+	FinishedAt time.Time            `json:"finished_at"`
+	Pages      int                  `json:"pages"`   // HTML pages scanned
+	Links      int                  `json:"links"`   // distinct external URLs
+	Checked    int                  `json:"checked"` // URLs requested in this run
+	Broken     int                  `json:"broken"`
+	Results    []ExternalLinkResult `json:"results"` // broken first, then by URL
+}
+
+// BrokenResults returns the results of broken links.
+func (r *ExternalLinkReport) BrokenResults() []ExternalLinkResult {
+	var broken []ExternalLinkResult
+	for _, res := range r.Results {
+		if res.Broken {
+			broken = append(broken, res)
+		}
+	}
+	return broken
+}
+
+// ExternalChecker periodically checks the external links of the published site. Unlike
+// VerificationService it runs on a schedule rather than after each build and keeps its
+// results on disk instead of in NATS.
+type ExternalChecker struct {
+	opts   ExternalCheckerOptions
+	client *http.Client
+
+	mu   sync.Mutex
+	last *ExternalLinkReport
+}
+
+// NewExternalChecker creates a checker, loading the results of the previous run from
+// opts.ResultsPath so that cached results survive restarts.
+func NewExternalChecker(opts ExternalCheckerOptions) *ExternalChecker {
+	c := &ExternalChecker{
+		opts: opts,
+		client: &http.Client{
+			Timeout:   opts.RequestTimeout,
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		},
+	}
+	if last, err := LoadExternalLinkReport(opts.ResultsPath); err == nil {
+		c.last = last
+	}
+	return c
+}
+
+// Last returns the report of the most recent run, or nil before the first run.
+func (c *ExternalChecker) Last() *ExternalLinkReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+// PagePath returns the path of the broken links page written after each run.
+func (c *ExternalChecker) PagePath() string { return c.opts.PagePath }
+
+// Run scans the HTML pages below publicDir for external links, checks every link not
+// cached from an earlier run and persists the results and the broken links page.
+// Links to the host of baseURL are internal and not checked.
+func (c *ExternalChecker) Run(ctx context.Context, publicDir, baseURL string) (*ExternalLinkReport, error) {
+	report := &ExternalLinkReport{StartedAt: time.Now().UTC()}
+	linkPages, err := c.collectExternalLinks(publicDir, baseURL, report)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := make(map[string]ExternalLinkResult)
+	if last := c.Last(); last != nil {
+		for _, res := range last.Results {
+			if !res.Broken && report.StartedAt.Sub(res.CheckedAt) < c.opts.CacheTTL {
+				cached[res.URL] = res
+			}
+		}
+	}
+
+	urls := slices.Sorted(maps.Keys(linkPages))
+	results := make([]ExternalLinkResult, len(urls))
+	global := make(chan struct{}, max(c.opts.MaxConcurrent, 1))
+	hosts := make(map[string]chan struct{})
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		if res, ok := cached[u]; ok {
+			res.Cached = true
+			res.Pages = linkPages[u]
+			results[i] = res
+			continue
+		}
+		report.Checked++
+		host := hostOf(u)
+		if hosts[host] == nil {
+			hosts[host] = make(chan struct{}, max(c.opts.PerHost, 1))
+		}
+		wg.Add(1)
+		go func(i int, u string, hostSem chan struct{}) {
+			defer wg.Done()
+			results[i] = ExternalLinkResult{URL: u, Pages: linkPages[u]}
+			select {
+			case hostSem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-hostSem }()
+			select {
+			case global <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-global }()
+			status, err := checkExternalURL(ctx, c.client, u)
+			results[i].Status = status
+			results[i].CheckedAt = time.Now().UTC()
+			if err != nil {
+				results[i].Broken = true
+				results[i].Error = err.Error()
+			}
+		}(i, u, hosts[host])
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("external link check canceled: %w", err)
+	}
+
+	for _, res := range results {
+		if res.Broken {
+			report.Broken++
+		}
+	}
+	slices.SortStableFunc(results, func(a, b ExternalLinkResult) int {
+		if a.Broken != b.Broken {
+			if a.Broken {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.URL, b.URL)
+	})
+	report.Links = len(results)
+	report.Results = results
+	report.FinishedAt = time.Now().UTC()
+
+	c.mu.Lock()
+	c.last = report
+	c.mu.Unlock()
+	return report, c.persist(report)
+}
+
+// collectExternalLinks maps each external http(s) URL linked from the pages below
+// publicDir to the site paths of the pages linking to it.
+func (c *ExternalChecker) collectExternalLinks(publicDir, baseURL string, report *ExternalLinkReport) (map[string][]string, error) {
+	linkPages := make(map[string][]string)
+	err := filepath.WalkDir(publicDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".html") {
+			return nil
+		}
+		links, err := ExtractLinks(p, baseURL)
+		if err != nil {
+			return err
+		}
+		report.Pages++
+		rel, _ := filepath.Rel(publicDir, p)
+		page := sitePath(filepath.ToSlash(rel))
+		for _, link := range links {
+			// Stylesheets, preconnect hints and feeds are theme plumbing, not content links.
+			if link.IsInternal || link.Tag == "link" || !ShouldVerifyLink(link) || isEditLink(link.URL) {
+				continue
+			}
+			u, err := url.Parse(link.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				continue
+			}
+			u.Fragment = ""
+			target := u.String()
+			if c.ignored(target) {
+				continue
+			}
+			if !slices.Contains(linkPages[target], page) {
+				linkPages[target] = append(linkPages[target], page)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan published site: %w", err)
+	}
+	return linkPages, nil
+}
+
+func (c *ExternalChecker) ignored(u string) bool {
+	for _, prefix := range c.opts.Ignore {
+		if prefix != "" && strings.HasPrefix(u, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// persist writes the results file and the broken links page.
+func (c *ExternalChecker) persist(report *ExternalLinkReport) error {
+	if c.opts.ResultsPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal external link report: %w", err)
+		}
+		if err := writeFileAtomic(c.opts.ResultsPath, data); err != nil {
+			return fmt.Errorf("write external link report: %w", err)
+		}
+	}
+	if c.opts.PagePath != "" {
+		var sb strings.Builder
+		if err := brokenLinksPage.Execute(&sb, report); err != nil {
+			return fmt.Errorf("render broken links page: %w", err)
+		}
+		if err := writeFileAtomic(c.opts.PagePath, []byte(sb.String())); err != nil {
+			return fmt.Errorf("write broken links page: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadExternalLinkReport reads a results file written by an ExternalChecker.
+func LoadExternalLinkReport(resultsPath string) (*ExternalLinkReport, error) {
+	if resultsPath == "" {
+		return nil, ErrNoExternalLinkReport
+	}
+	data, err := os.ReadFile(filepath.Clean(resultsPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoExternalLinkReport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read external link report: %w", err)
+	}
+	var report ExternalLinkReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse external link report: %w", err)
+	}
+	return &report, nil
+}
+
+func writeFileAtomic(p string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// sitePath turns an HTML file below public/ into the site path serving it.
+func sitePath(rel string) string {
+	if path.Base(rel) == "index.html" {
+		dir := path.Dir(rel)
+		if dir == "." {
+			return "/"
+		}
+		return "/" + dir + "/"
+	}
+	return "/" + rel
+}
+
+func hostOf(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+var brokenLinksPage = template.Must(template.New("broken-links").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Broken links</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem .6rem; text-align: left; vertical-align: top; }
+th { background: #f5f5f5; }
+td.status { white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>Broken links</h1>
+<p>Checked {{.FinishedAt.Format "2006-01-02 15:04:05 MST"}}: {{.Links}} external link(s) on {{.Pages}} page(s), {{.Checked}} requested in this run, {{.Broken}} broken.</p>
+{{- with .BrokenResults}}
+<table>
+<thead><tr><th>Link</th><th>Status</th><th>Error</th><th>Linked from</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td><a href="{{.URL}}">{{.URL}}</a></td><td class="status">{{if .Status}}{{.Status}}{{else}}-{{end}}</td><td>{{.Error}}</td><td>{{range $i, $p := .Pages}}{{if $i}}<br>{{end}}{{$p}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No broken links found.</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,110 @@
+package linkverify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func writePage(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExternalChecker_RunCachesAndLimitsConcurrency(t *testing.T) {
+	var requests, inFlight, maxInFlight atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if strings.HasPrefix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	public := t.TempDir()
+	writePage(t, filepath.Join(public, "index.html"), `<a href="`+srv.URL+`/ok/1">a</a><a href="`+srv.URL+`/missing#top">b</a>
+<a href="/guide/">internal</a><a href="https://docs.example.com/x/">own host</a><link rel="preconnect" href="`+srv.URL+`/missing/font">`)
+	writePage(t, filepath.Join(public, "guide", "index.html"), `<a href="`+srv.URL+`/ok/1">a</a><a href="`+srv.URL+`/ok/2">c</a>
+<a href="`+srv.URL+`/ok/3">d</a><a href="`+srv.URL+`/skip/me">ignored</a><img src="`+srv.URL+`/missing.png">`)
+
+	state := t.TempDir()
+	opts := ExternalCheckerOptions{
+		MaxConcurrent:  4,
+		PerHost:        2,
+		RequestTimeout: 5 * time.Second,
+		CacheTTL:       time.Hour,
+		Ignore:         []string{srv.URL + "/skip/"},
+		ResultsPath:    filepath.Join(state, "linkcheck.json"),
+		PagePath:       filepath.Join(state, "broken-links.html"),
+	}
+	checker := NewExternalChecker(opts)
+	report, err := checker.Run(context.Background(), public, "https://docs.example.com/")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Pages != 2 || report.Links != 5 || report.Checked != 5 || report.Broken != 2 {
+		t.Fatalf("unexpected totals: pages=%d links=%d checked=%d broken=%d", report.Pages, report.Links, report.Checked, report.Broken)
+	}
+	if got := report.Results[0]; !got.Broken || got.URL != srv.URL+"/missing" || got.Status != http.StatusNotFound || got.Pages[0] != "/" {
+		t.Fatalf("unexpected first result: %+v", got)
+	}
+	if got := report.Results[2]; got.URL != srv.URL+"/ok/1" || len(got.Pages) != 2 {
+		t.Fatalf("expected /ok/1 linked from two pages, got %+v", got)
+	}
+	if peak := maxInFlight.Load(); peak > 2 {
+		t.Fatalf("per-host limit exceeded: %d requests in flight", peak)
+	}
+
+	page, err := os.ReadFile(opts.PagePath)
+	if err != nil {
+		t.Fatalf("broken links page: %v", err)
+	}
+	if !strings.Contains(string(page), srv.URL+"/missing.png") || strings.Contains(string(page), srv.URL+"/ok/1") {
+		t.Fatalf("broken links page must list only broken links:\n%s", page)
+	}
+
+	// A new checker reloads the results: working links are cached, broken ones rechecked.
+	before := requests.Load()
+	report, err = NewExternalChecker(opts).Run(context.Background(), public, "https://docs.example.com/")
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if report.Checked != 2 || report.Broken != 2 {
+		t.Fatalf("expected only the broken links rechecked, got checked=%d broken=%d", report.Checked, report.Broken)
+	}
+	if !report.Results[2].Cached {
+		t.Fatalf("expected cached result for working link: %+v", report.Results[2])
+	}
+	if n := requests.Load() - before; n < 2 {
+		t.Fatalf("expected broken links to be requested again, got %d requests", n)
+	}
+}
+
+func TestLoadExternalLinkReport_Missing(t *testing.T) {
+	if _, err := LoadExternalLinkReport(filepath.Join(t.TempDir(), "none.json")); !errors.Is(err, ErrNoExternalLinkReport) {
+		t.Fatalf("expected ErrNoExternalLinkReport, got %v", err)
+	}
+}
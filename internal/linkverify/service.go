@@ -399,8 +399,14 @@ func publicDirForPage(page *PageMetadata) (string, error) {
 
 // checkExternalLink verifies an external link via HTTP request.
 func (s *VerificationService) checkExternalLink(ctx context.Context, linkURL string) (int, error) {
+	return checkExternalURL(ctx, s.httpClient, linkURL)
+}
+
+// checkExternalURL requests linkURL with client. Timeouts, rate limiting and
+// authentication errors are not reported as broken.
+func checkExternalURL(ctx context.Context, client *http.Client, linkURL string) (int, error) {
 	// First try HEAD (cheap, but some sites return false negatives for HEAD).
-	status, err := s.doExternalRequest(ctx, http.MethodHead, linkURL)
+	status, err := doExternalRequest(ctx, client, http.MethodHead, linkURL)
 	if err == nil {
 		return status, nil
 	}
@@ -420,7 +426,7 @@ func (s *VerificationService) checkExternalLink(ctx context.Context, linkURL str
 	// - Some servers mishandle HEAD on dynamic routes
 	switch status {
 	case http.StatusNotFound, http.StatusBadRequest:
-		statusGet, errGet := s.doExternalRequest(ctx, http.MethodGet, linkURL)
+		statusGet, errGet := doExternalRequest(ctx, client, http.MethodGet, linkURL)
 		if errGet == nil {
 			return statusGet, nil
 		}
@@ -436,7 +442,7 @@ func (s *VerificationService) checkExternalLink(ctx context.Context, linkURL str
 	}
 }
 
-func (s *VerificationService) doExternalRequest(ctx context.Context, method, linkURL string) (int, error) {
+func doExternalRequest(ctx context.Context, client *http.Client, method, linkURL string) (int, error) {
 	// URL fragments are not sent to servers; strip them to avoid confusing redirects/logging.
 	if u, parseErr := url.Parse(linkURL); parseErr == nil {
 		u.Fragment = ""
@@ -456,7 +462,7 @@ func (s *VerificationService) doExternalRequest(ctx context.Context, method, lin
 		req.Header.Set("Range", "bytes=0-1023")
 	}
 
-	resp, err := s.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return 0, fmt.Errorf("request failed: %w", err)
 	}
@@ -147,7 +147,11 @@ func (f *Fixer) fix(path string) (*FixResult, error) {
 	uidIssueCounts := make(map[string]int)
 	uidAliasIssueCounts := make(map[string]int)
 	fingerprintIssueCounts := make(map[string]int)
+	spellingTargets := make(map[string]struct{})
 	for _, issue := range result.Issues {
+		if issue.Rule == spellingRuleName {
+			spellingTargets[issue.FilePath] = struct{}{}
+		}
 		if issue.Severity != SeverityError {
 			continue
 		}
@@ -172,6 +176,9 @@ func (f *Fixer) fix(path string) (*FixResult, error) {
 	// Phase 2: add missing uid-based aliases (for files that already have valid uids).
 	f.applyUIDAliasesFixes(uidAliasTargets, uidAliasIssueCounts, fixResult, fingerprintTargets)
 
+	// Phase 2.5: correct misspellings with a single suggestion (before renames move files).
+	f.applySpellingFixes(spellingTargets, fixResult, fingerprintTargets)
+
 	// Phase 3: perform renames + link updates.
 	for filePath, issues := range fileIssues {
 		f.processFileWithIssues(filePath, issues, rootPath, fixResult, fingerprintTargets, fingerprintIssueCounts)
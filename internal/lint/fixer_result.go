@@ -11,6 +11,7 @@ type FixResult struct {
 	FilesRenamed  []RenameOperation
 	LinksUpdated  []LinkUpdate
 	Fingerprints  []FingerprintUpdate
+	SpellingFixes []SpellingFix
 	BrokenLinks   []BrokenLink // Links to non-existent files
 	HealSkipped   []BrokenLinkHealSkip
 	ErrorsFixed   int
@@ -88,7 +89,7 @@ func (fr *FixResult) HasErrors() bool {
 
 // HasChanges returns true if there are any fixes to apply.
 func (fr *FixResult) HasChanges() bool {
-	return len(fr.FilesRenamed) > 0 || len(fr.LinksUpdated) > 0 || len(fr.Fingerprints) > 0
+	return len(fr.FilesRenamed) > 0 || len(fr.LinksUpdated) > 0 || len(fr.Fingerprints) > 0 || len(fr.SpellingFixes) > 0
 }
 
 // CountAffectedFiles returns the number of unique files that will be modified.
@@ -110,6 +111,11 @@ func (fr *FixResult) CountAffectedFiles() int {
 		affected[fp.FilePath] = true
 	}
 
+	// Files with spelling corrections
+	for _, sf := range fr.SpellingFixes {
+		affected[sf.FilePath] = true
+	}
+
 	return len(affected)
 }
 
@@ -121,6 +127,9 @@ func (fr *FixResult) Summary() string {
 	b.WriteString(fmt.Sprintf("Errors fixed: %d\n", fr.ErrorsFixed))
 	b.WriteString(fmt.Sprintf("Fingerprints updated: %d\n", len(fr.Fingerprints)))
 	b.WriteString(fmt.Sprintf("Links updated: %d\n", len(fr.LinksUpdated)))
+	if len(fr.SpellingFixes) > 0 {
+		b.WriteString(fmt.Sprintf("Spelling corrected: %d\n", len(fr.SpellingFixes)))
+	}
 
 	if len(fr.BrokenLinks) > 0 {
 		b.WriteString(fmt.Sprintf("\nBroken links detected: %d\n", len(fr.BrokenLinks)))
@@ -211,11 +220,23 @@ func (fr *FixResult) PreviewChanges() string {
 		b.WriteString("\n")
 	}
 
+	// Spelling corrections section
+	if len(fr.SpellingFixes) > 0 {
+		b.WriteString("SPELLING CORRECTIONS:\n")
+		for _, sf := range fr.SpellingFixes {
+			b.WriteString(fmt.Sprintf("  • %s:%d: %s → %s\n", filepath.Base(sf.FilePath), sf.Line, sf.Word, sf.Replacement))
+		}
+		b.WriteString("\n")
+	}
+
 	// Statistics
 	b.WriteString("SUMMARY:\n")
 	b.WriteString(fmt.Sprintf("  • %d file%s will be renamed\n", len(fr.FilesRenamed), pluralize(len(fr.FilesRenamed))))
 	b.WriteString(fmt.Sprintf("  • %d link%s will be updated\n", len(fr.LinksUpdated), pluralize(len(fr.LinksUpdated))))
 	b.WriteString(fmt.Sprintf("  • %d file%s will have fingerprints updated\n", len(fr.Fingerprints), pluralize(len(fr.Fingerprints))))
+	if len(fr.SpellingFixes) > 0 {
+		b.WriteString(fmt.Sprintf("  • %d misspelling%s will be corrected\n", len(fr.SpellingFixes), pluralize(len(fr.SpellingFixes))))
+	}
 	if len(fr.BrokenLinks) > 0 {
 		b.WriteString(fmt.Sprintf("  • %d broken link%s detected\n", len(fr.BrokenLinks), pluralize(len(fr.BrokenLinks))))
 	}
@@ -276,6 +297,15 @@ func (fr *FixResult) DetailedPreview() string {
 		}
 	}
 
+	if len(fr.SpellingFixes) > 0 {
+		b.WriteString("[Spelling Corrections]\n")
+		for i, sf := range fr.SpellingFixes {
+			b.WriteString(fmt.Sprintf("%d. %s:%d\n", i+1, sf.FilePath, sf.Line))
+			b.WriteString(fmt.Sprintf("   Before: %s\n", sf.Word))
+			b.WriteString(fmt.Sprintf("   After:  %s\n\n", sf.Replacement))
+		}
+	}
+
 	b.WriteString(strings.Repeat("=", 60) + "\n")
 	b.WriteString(fmt.Sprintf("Total: %d file%s, %d link%s\n",
 		len(fr.FilesRenamed), pluralize(len(fr.FilesRenamed)),
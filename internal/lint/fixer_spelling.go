@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docmodel"
+	"git.home.luguber.info/inful/docbuilder/internal/markdown"
+)
+
+// SpellingFix represents a misspelled word replaced by its only correction.
+type SpellingFix struct {
+	FilePath    string
+	Line        int
+	Word        string
+	Replacement string
+}
+
+// applySpellingFixes replaces misspellings that have exactly one correction. Words with
+// several (or no) suggestions are left for the author.
+func (f *Fixer) applySpellingFixes(targets map[string]struct{}, fixResult *FixResult, fingerprintTargets map[string]struct{}) {
+	dict := f.linter.cfg.Spelling
+	if dict == nil || len(targets) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(targets))
+	for p := range targets {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		fixes, err := f.fixSpellingInFile(p, dict)
+		if err != nil {
+			fixResult.Errors = append(fixResult.Errors, err)
+			continue
+		}
+		if len(fixes) == 0 {
+			continue
+		}
+		fixResult.SpellingFixes = append(fixResult.SpellingFixes, fixes...)
+		fixResult.WarningsFixed += len(fixes)
+		// Corrections change content, so fingerprints must be refreshed.
+		fingerprintTargets[p] = struct{}{}
+	}
+}
+
+func (f *Fixer) fixSpellingInFile(filePath string, dict *SpellDictionary) ([]SpellingFix, error) {
+	doc, err := docmodel.ParseFile(filePath, docmodel.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("parse file for spelling fixes: %w", err)
+	}
+
+	var fixes []SpellingFix
+	var edits []markdown.Edit
+	for _, m := range findSpellingMistakes(string(doc.Body()), dict) {
+		if !m.fixable() {
+			continue
+		}
+		fixes = append(fixes, SpellingFix{
+			FilePath:    filePath,
+			Line:        doc.LineOffset() + m.Line,
+			Word:        m.Word,
+			Replacement: m.Suggestions[0],
+		})
+		edits = append(edits, markdown.Edit{
+			Start:       m.Offset,
+			End:         m.Offset + len(m.Word),
+			Replacement: []byte(m.Suggestions[0]),
+		})
+	}
+	if len(edits) == 0 || f.dryRun {
+		return fixes, nil
+	}
+
+	updated, err := doc.ApplyBodyEdits(edits)
+	if err != nil {
+		return nil, fmt.Errorf("apply spelling fixes to %s: %w", filePath, err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("stat file for spelling fixes: %w", err)
+	}
+	if err := os.WriteFile(filePath, updated, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("write file for spelling fixes: %w", err)
+	}
+	return fixes, nil
+}
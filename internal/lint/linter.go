@@ -26,6 +26,9 @@ func NewLinter(cfg *Config) *Linter {
 			&FilenameRule{},
 			&FrontmatterUIDRule{},
 			&FrontmatterFingerprintRule{},
+			&ContentPolicyRule{Resolve: cfg.ContentPolicy},
+			&SpellingRule{Dictionary: cfg.Spelling},
+			&HeadingAnchorRule{},
 			// Additional rules will be added here in future phases
 		},
 	}
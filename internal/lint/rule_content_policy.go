@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
+)
+
+const contentPolicyRuleName = "content-policy"
+
+// ContentPolicyRule reports shortcodes and raw HTML tags that the configured sanitize
+// policy escapes or strips during the build.
+type ContentPolicyRule struct {
+	Resolve sanitize.Resolver
+}
+
+func (r *ContentPolicyRule) Name() string {
+	return contentPolicyRuleName
+}
+
+func (r *ContentPolicyRule) AppliesTo(filePath string) bool {
+	return r.Resolve != nil && IsDocFile(filePath)
+}
+
+func (r *ContentPolicyRule) Check(filePath string) ([]Issue, error) {
+	// #nosec G304 -- filePath comes from controlled doc discovery/lint walk.
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	fm, body, had, _, splitErr := frontmatter.Split(data)
+	offset := 0
+	switch {
+	case splitErr != nil:
+		body = data
+	case had:
+		offset = 2 + bytes.Count(fm, []byte("\n"))
+	}
+
+	_, violations := sanitize.Apply(string(body), r.Resolve)
+	issues := make([]Issue, 0, len(violations))
+	for _, v := range violations {
+		markup := "<" + v.Name + ">"
+		if v.Kind == sanitize.KindShortcode {
+			markup = "{{< " + v.Name + " >}}"
+		}
+		issues = append(issues, Issue{
+			FilePath:    filePath,
+			Severity:    SeverityWarning,
+			Rule:        r.Name(),
+			Message:     fmt.Sprintf("%s %s is %s by the content policy", v.Kind, markup, actionVerb(v.Action)),
+			Explanation: "The configured sanitize policy does not allow this markup in the generated site.",
+			Fix:         "Remove the markup, or allow it in the sanitize section of the configuration.",
+			Line:        offset + v.Line,
+		})
+	}
+	return issues, nil
+}
+
+func actionVerb(a sanitize.Action) string {
+	if a == sanitize.Strip {
+		return "stripped"
+	}
+	return "escaped"
+}
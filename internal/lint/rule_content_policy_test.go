@@ -0,0 +1,38 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
+)
+
+func TestContentPolicyRule(t *testing.T) {
+	policy := &config.SanitizeConfig{
+		Shortcodes: &config.SanitizeRules{Default: config.SanitizeEscape, Allow: []string{"ref"}},
+		HTML:       &config.SanitizeRules{Strip: []string{"script"}},
+	}
+	rule := &ContentPolicyRule{Resolve: sanitize.FromConfig(policy)}
+
+	assert.True(t, rule.AppliesTo("docs/page.md"))
+	assert.False(t, rule.AppliesTo("docs/image.png"))
+	assert.False(t, (&ContentPolicyRule{}).AppliesTo("docs/page.md"), "no policy, no check")
+
+	path := filepath.Join(t.TempDir(), "page.md")
+	content := "---\ntitle: Page\n---\n# Page\n\n{{< ref \"other.md\" >}}\n{{< tabs >}}\n<script>x()</script>\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	issues, err := rule.Check(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, SeverityWarning, issues[0].Severity)
+	assert.Equal(t, 7, issues[0].Line)
+	assert.Equal(t, "shortcode {{< tabs >}} is escaped by the content policy", issues[0].Message)
+	assert.Equal(t, 8, issues[1].Line)
+	assert.Equal(t, "html <script> is stripped by the content policy", issues[1].Message)
+}
@@ -0,0 +1,148 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docmodel"
+	"git.home.luguber.info/inful/docbuilder/internal/markdown"
+)
+
+const duplicateAnchorsRuleName = "duplicate-anchors"
+
+// HeadingAnchorRule reports headings whose anchor collides with an earlier heading of the
+// same page, and links whose #fragment points at such a shared anchor. Hugo silently
+// numbers duplicate anchors ("setup-1"), so deep links to them land on the wrong heading
+// or break when headings are reordered.
+type HeadingAnchorRule struct{}
+
+func (r *HeadingAnchorRule) Name() string {
+	return duplicateAnchorsRuleName
+}
+
+func (r *HeadingAnchorRule) AppliesTo(filePath string) bool {
+	return IsDocFile(filePath)
+}
+
+func (r *HeadingAnchorRule) Check(filePath string) ([]Issue, error) {
+	doc, err := docmodel.ParseFile(filePath, docmodel.Options{})
+	if err != nil {
+		//nolint:nilerr // Malformed front matter is reported by the fingerprint rule.
+		return nil, nil
+	}
+
+	own := anchorsOf(doc)
+	var issues []Issue
+	for _, dup := range own.duplicates {
+		issues = append(issues, Issue{
+			FilePath: filePath,
+			Severity: SeverityWarning,
+			Rule:     r.Name(),
+			Message:  fmt.Sprintf("duplicate heading anchor #%s (first used on line %d)", dup.Base, doc.LineOffset()+dup.FirstLine),
+			Explanation: strings.Join([]string{
+				"Hugo serves this heading as #" + dup.ID + ", so links to #" + dup.Base + " open the first heading,",
+				"and links to #" + dup.ID + " break when headings are added or reordered.",
+			}, "\n"),
+			Fix:  fmt.Sprintf("Rename the heading, or give it a unique id: %s {#%s}", strings.Repeat("#", dup.Level)+" "+dup.Text, dup.Suggested),
+			Line: doc.LineOffset() + dup.Line,
+		})
+	}
+
+	refs, err := doc.LinkRefs()
+	if err != nil {
+		//nolint:nilerr // Unparseable links are reported by the broken link check.
+		return issues, nil
+	}
+	for _, ref := range refs {
+		if ref.Link.Kind == markdown.LinkKindImage {
+			continue
+		}
+		target, fragment, ok := strings.Cut(strings.TrimSpace(ref.Link.Destination), "#")
+		if !ok || fragment == "" || isExternalURL(target) || strings.Contains(target, ":") ||
+			isHugoShortcodeLinkTarget(target) || isUIDAliasLinkTarget(target) {
+			continue
+		}
+		anchors, name := own, "this page"
+		if target != "" {
+			resolved, rerr := resolveRelativePath(filePath, target)
+			if rerr != nil || !IsDocFile(resolved) || !fileExists(resolved) {
+				continue // missing targets are reported as broken links
+			}
+			targetDoc, perr := docmodel.ParseFile(resolved, docmodel.Options{})
+			if perr != nil {
+				continue
+			}
+			anchors, name = anchorsOf(targetDoc), filepath.Base(resolved)
+		}
+		shared := anchors.shared[strings.ToLower(fragment)]
+		if len(shared) < 2 {
+			continue
+		}
+		suggestions := make([]string, 0, len(shared))
+		for _, h := range shared {
+			suggestions = append(suggestions, "#"+h.suggested)
+		}
+		issues = append(issues, Issue{
+			FilePath: filePath,
+			Severity: SeverityWarning,
+			Rule:     r.Name(),
+			Message:  fmt.Sprintf("link to #%s is ambiguous: %d headings of %s share the anchor", fragment, len(shared), name),
+			Explanation: fmt.Sprintf("Headings on lines %s of %s produce the anchor #%s; Hugo numbers all but the first.",
+				joinLines(shared), name, shared[0].base),
+			Fix:  "Give the headings unique ids and link to one of " + strings.Join(suggestions, ", "),
+			Line: ref.FileLine,
+		})
+	}
+	return issues, nil
+}
+
+// sharedAnchor is a heading that shares its anchor with other headings of the page.
+type sharedAnchor struct {
+	line      int // file line
+	base      string
+	suggested string
+}
+
+// pageAnchors are the duplicate headings of a page, and for every anchor involved in a
+// collision (the shared anchor and the numbered ones), the headings that share it.
+type pageAnchors struct {
+	duplicates []markdown.DuplicateAnchor
+	shared     map[string][]sharedAnchor
+}
+
+func anchorsOf(doc *docmodel.ParsedDoc) pageAnchors {
+	headings := markdown.HeadingAnchors(string(doc.Body()))
+	pa := pageAnchors{duplicates: markdown.DuplicateAnchors(headings), shared: make(map[string][]sharedAnchor)}
+	if len(pa.duplicates) == 0 {
+		return pa
+	}
+	suggested := make(map[int]string, len(pa.duplicates))
+	bases := make(map[string]bool, len(pa.duplicates))
+	for _, dup := range pa.duplicates {
+		suggested[dup.Line] = dup.Suggested
+		bases[dup.Base] = true
+	}
+	for _, h := range headings {
+		if !bases[h.Base] {
+			continue
+		}
+		s := sharedAnchor{line: doc.LineOffset() + h.Line, base: h.Base, suggested: suggested[h.Line]}
+		if s.suggested == "" {
+			s.suggested = h.ID // the first heading keeps its anchor
+		}
+		pa.shared[h.Base] = append(pa.shared[h.Base], s)
+	}
+	for _, dup := range pa.duplicates {
+		pa.shared[dup.ID] = pa.shared[dup.Base]
+	}
+	return pa
+}
+
+func joinLines(shared []sharedAnchor) string {
+	lines := make([]string, len(shared))
+	for i, s := range shared {
+		lines[i] = fmt.Sprint(s.line)
+	}
+	return strings.Join(lines, ", ")
+}
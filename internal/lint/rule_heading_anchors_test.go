@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadingAnchorRule(t *testing.T) {
+	dir := t.TempDir()
+	guide := filepath.Join(dir, "guide.md")
+	require.NoError(t, os.WriteFile(guide, []byte("---\ntitle: Guide\n---\n# Guide\n\n## Install\n\n### Setup\n\n## Upgrade\n\n### Setup\n\nSee [setup](#setup).\n"), 0o600))
+	index := filepath.Join(dir, "index.md")
+	require.NoError(t, os.WriteFile(index, []byte("# Index\n\n[a](guide.md#setup-1) [b](guide.md#install) [c](missing.md#setup) [d](https://example.com/x#setup)\n"), 0o600))
+
+	rule := &HeadingAnchorRule{}
+	issues, err := rule.Check(guide)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, SeverityWarning, issues[0].Severity)
+	assert.Equal(t, 12, issues[0].Line)
+	assert.Equal(t, "duplicate heading anchor #setup (first used on line 8)", issues[0].Message)
+	assert.Equal(t, "Rename the heading, or give it a unique id: ### Setup {#upgrade-setup}", issues[0].Fix)
+
+	assert.Equal(t, 14, issues[1].Line)
+	assert.Equal(t, "link to #setup is ambiguous: 2 headings of this page share the anchor", issues[1].Message)
+	assert.Equal(t, "Give the headings unique ids and link to one of #setup, #upgrade-setup", issues[1].Fix)
+
+	issues, err = rule.Check(index)
+	require.NoError(t, err)
+	require.Len(t, issues, 1, "only the numbered anchor of the duplicate is ambiguous")
+	assert.Equal(t, 3, issues[0].Line)
+	assert.Equal(t, "link to #setup-1 is ambiguous: 2 headings of guide.md share the anchor", issues[0].Message)
+	assert.Contains(t, issues[0].Explanation, "Headings on lines 8, 12 of guide.md")
+}
@@ -0,0 +1,55 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docmodel"
+)
+
+const spellingRuleName = "spelling"
+
+// SpellingRule reports misspelled words in the prose of markdown files. Code blocks,
+// inline code, URLs and markup are not checked.
+type SpellingRule struct {
+	Dictionary *SpellDictionary
+}
+
+func (r *SpellingRule) Name() string {
+	return spellingRuleName
+}
+
+func (r *SpellingRule) AppliesTo(filePath string) bool {
+	return r.Dictionary != nil && IsDocFile(filePath)
+}
+
+func (r *SpellingRule) Check(filePath string) ([]Issue, error) {
+	doc, err := docmodel.ParseFile(filePath, docmodel.Options{})
+	if err != nil {
+		//nolint:nilerr // Malformed front matter is reported by the fingerprint rule.
+		return nil, nil
+	}
+
+	mistakes := findSpellingMistakes(string(doc.Body()), r.Dictionary)
+	issues := make([]Issue, 0, len(mistakes))
+	for _, m := range mistakes {
+		issue := Issue{
+			FilePath:    filePath,
+			Severity:    SeverityWarning,
+			Rule:        r.Name(),
+			Message:     fmt.Sprintf("possible misspelling %q", m.Word),
+			Explanation: "The word is not in the dictionary. Project words can be added to a custom dictionary (spellcheck.words or spellcheck.dictionaries in the configuration).",
+			Line:        doc.LineOffset() + m.Line,
+		}
+		switch {
+		case m.fixable():
+			issue.Fix = fmt.Sprintf("Replace with %q (docbuilder lint --fix)", m.Suggestions[0])
+		case len(m.Suggestions) > 0:
+			issue.Fix = "Did you mean: " + strings.Join(m.Suggestions, ", ") + "?"
+		default:
+			issue.Fix = "Correct the word, or add it to a custom dictionary."
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
@@ -0,0 +1,109 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpellDictionary_Check(t *testing.T) {
+	dict := NewSpellDictionary()
+
+	ok, suggestions := dict.Check("Recieve")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"Receive"}, suggestions)
+
+	ok, suggestions = dict.Check("wether")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"whether", "weather"}, suggestions)
+
+	ok, _ = dict.Check("frobnicate")
+	assert.True(t, ok, "without a word list only known misspellings are reported")
+
+	dict.AddWords("Teh")
+	ok, _ = dict.Check("teh")
+	assert.True(t, ok, "custom words override the built-in misspellings")
+
+	list := filepath.Join(t.TempDir(), "en.dic")
+	require.NoError(t, os.WriteFile(list, []byte("4\nbuild/S\nsite\nstatic/Y\ndocument\n"), 0o600))
+	require.NoError(t, dict.LoadWordlist(list))
+
+	for _, w := range []string{"builds", "Site", "documented", "static"} {
+		ok, _ = dict.Check(w)
+		assert.True(t, ok, w)
+	}
+	ok, suggestions = dict.Check("biuld")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"build"}, suggestions)
+	ok, suggestions = dict.Check("frobnicate")
+	assert.False(t, ok)
+	assert.Empty(t, suggestions)
+}
+
+func TestFindSpellingMistakes_SkipsCode(t *testing.T) {
+	body := strings.Join([]string{
+		"# Teh guide",
+		"",
+		"Use `teh` inline, see [teh link](teh/recieve.md) or https://example.com/teh.",
+		"",
+		"```bash",
+		"echo teh",
+		"```",
+		"",
+		"    indented teh",
+		"",
+		"A recieve_handler, TEH and tehRecieve are identifiers; a non-existant part is checked.",
+	}, "\n")
+
+	mistakes := findSpellingMistakes(body, NewSpellDictionary())
+	require.Len(t, mistakes, 3)
+	assert.Equal(t, "Teh", mistakes[0].Word)
+	assert.Equal(t, 1, mistakes[0].Line)
+	assert.Equal(t, "teh", mistakes[1].Word, "link text is prose")
+	assert.Equal(t, 3, mistakes[1].Line)
+	assert.Equal(t, "existant", mistakes[2].Word)
+	assert.Equal(t, 11, mistakes[2].Line)
+	assert.Equal(t, "existant", body[mistakes[2].Offset:mistakes[2].Offset+len("existant")])
+}
+
+func TestSpellingRule(t *testing.T) {
+	rule := &SpellingRule{Dictionary: NewSpellDictionary()}
+	assert.True(t, rule.AppliesTo("docs/page.md"))
+	assert.False(t, (&SpellingRule{}).AppliesTo("docs/page.md"), "no dictionary, no check")
+
+	path := filepath.Join(t.TempDir(), "page.md")
+	content := "---\ntitle: Page\n---\n# Page\n\nWe recieve updates wich matter.\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	issues, err := rule.Check(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, SeverityWarning, issues[0].Severity)
+	assert.Equal(t, 6, issues[0].Line)
+	assert.Equal(t, `possible misspelling "recieve"`, issues[0].Message)
+	assert.Contains(t, issues[0].Fix, `"receive"`)
+	assert.Equal(t, "Did you mean: which, witch?", issues[1].Fix)
+}
+
+func TestFixer_FixesUnambiguousMisspellings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "page.md")
+	content := "# Page\n\nTeh service will recieve events wich are `recieve`d.\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	fixer := NewFixer(NewLinter(&Config{Spelling: NewSpellDictionary()}), false, false)
+	result, err := fixer.Fix(path)
+	require.NoError(t, err)
+	require.Len(t, result.SpellingFixes, 2)
+	assert.Equal(t, "Teh", result.SpellingFixes[0].Word)
+	assert.Equal(t, "The", result.SpellingFixes[0].Replacement)
+
+	// #nosec G304 -- path is from test temp directory
+	updated, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(updated), "The service will receive events wich are `recieve`d.")
+	assert.Contains(t, string(updated), "fingerprint:", "corrected files get a fresh fingerprint")
+}
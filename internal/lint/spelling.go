@@ -0,0 +1,400 @@
+package lint
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+//go:embed wordlists/misspellings.txt
+var builtinMisspellings string
+
+// maxSpellingSuggestions limits the suggestions reported for an unknown word.
+const maxSpellingSuggestions = 5
+
+// SpellDictionary holds the words accepted by the spelling rule.
+//
+// Without a language word list only common misspellings (built in) are reported.
+// Once a word list is loaded, every word it does not contain is reported as well,
+// with suggestions from the word list. Custom dictionaries add accepted words in
+// both modes.
+type SpellDictionary struct {
+	words        map[string]struct{} // lower-cased accepted words
+	misspellings map[string][]string // lower-cased misspelling -> corrections
+	complete     bool                // a language word list is loaded
+}
+
+// NewSpellDictionary returns a dictionary with the built-in misspellings.
+func NewSpellDictionary() *SpellDictionary {
+	d := &SpellDictionary{
+		words:        make(map[string]struct{}),
+		misspellings: make(map[string][]string),
+	}
+	for _, line := range strings.Split(builtinMisspellings, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		d.misspellings[fields[0]] = strings.Split(strings.Join(fields[1:], " "), ",")
+	}
+	return d
+}
+
+// SpellDictionaryFromConfig builds the dictionary of the spelling rule, or returns nil
+// when spellcheck is disabled. Relative word lists and dictionaries are resolved against
+// configDir. A repository's spellcheck settings (repo may be nil) add its dictionaries,
+// relative to repoRoot, and words.
+func SpellDictionaryFromConfig(sc *config.SpellcheckConfig, configDir string, repo *config.RepositorySpellcheck, repoRoot string) (*SpellDictionary, error) {
+	if !sc.IsEnabled() {
+		return nil, nil
+	}
+	resolve := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(configDir, p)
+	}
+
+	dict := NewSpellDictionary()
+	for _, p := range sc.Wordlists {
+		if err := dict.LoadWordlist(resolve(p)); err != nil {
+			return nil, fmt.Errorf("spellcheck word list %s: %w", p, err)
+		}
+	}
+	for _, p := range sc.Dictionaries {
+		if err := dict.LoadDictionary(resolve(p)); err != nil {
+			return nil, fmt.Errorf("spellcheck dictionary %s: %w", p, err)
+		}
+	}
+	dict.AddWords(sc.Words...)
+
+	if repo != nil {
+		for _, p := range repo.Dictionaries {
+			if err := dict.LoadDictionary(filepath.Join(repoRoot, filepath.FromSlash(p))); err != nil {
+				return nil, fmt.Errorf("spellcheck dictionary %s: %w", p, err)
+			}
+		}
+		dict.AddWords(repo.Words...)
+	}
+	return dict, nil
+}
+
+// AddWords accepts words (case-insensitively).
+func (d *SpellDictionary) AddWords(words ...string) {
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			d.words[w] = struct{}{}
+		}
+	}
+}
+
+// LoadWordlist reads a language word list and enables reporting of unknown words.
+// Plain lists (one word per line, e.g. /usr/share/dict/words) and hunspell .dic
+// files are supported; hunspell affix flags are ignored.
+func (d *SpellDictionary) LoadWordlist(path string) error {
+	if err := d.load(path); err != nil {
+		return err
+	}
+	d.complete = true
+	return nil
+}
+
+// LoadDictionary reads a custom dictionary: a word list of additional accepted words.
+func (d *SpellDictionary) LoadDictionary(path string) error {
+	return d.load(path)
+}
+
+func (d *SpellDictionary) load(path string) error {
+	f, err := os.Open(path) // #nosec G304 -- dictionary paths come from the configuration
+	if err != nil {
+		return fmt.Errorf("open dictionary: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return d.read(f)
+}
+
+func (d *SpellDictionary) read(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	first := true
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		isCount := first && line != "" && strings.Trim(line, "0123456789") == ""
+		first = false
+		if line == "" || isCount || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// hunspell: "word/FLAGS\tmorphology"
+		word, _, _ := strings.Cut(strings.Fields(line)[0], "/")
+		d.AddWords(word)
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("read dictionary: %w", err)
+	}
+	return nil
+}
+
+// Check reports whether a word is spelled correctly and, if not, the suggested
+// replacements (possibly none).
+func (d *SpellDictionary) Check(word string) (bool, []string) {
+	lw := strings.ToLower(strings.ReplaceAll(word, "’", "'"))
+	if d.knows(lw) {
+		return true, nil
+	}
+	if corrections, ok := d.misspellings[lw]; ok {
+		return false, matchCase(word, corrections)
+	}
+	if !d.complete || d.knowsInflection(lw) {
+		return true, nil
+	}
+	return false, matchCase(word, d.suggest(lw))
+}
+
+func (d *SpellDictionary) knows(lw string) bool {
+	_, ok := d.words[lw]
+	return ok
+}
+
+// knowsInflection accepts regular inflections of known words, since word lists such as
+// hunspell dictionaries store stems only.
+func (d *SpellDictionary) knowsInflection(lw string) bool {
+	for _, r := range inflectionRules {
+		stem, ok := strings.CutSuffix(lw, r.suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		if d.knows(stem+r.stem) || r.doubled && len(stem) > 2 && stem[len(stem)-1] == stem[len(stem)-2] && d.knows(stem[:len(stem)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+var inflectionRules = []struct {
+	suffix, stem string
+	doubled      bool // "running" -> "run"
+}{
+	{"'s", "", false},
+	{"s", "", false}, {"es", "", false}, {"ies", "y", false},
+	{"ed", "", true}, {"ed", "e", false}, {"ied", "y", false},
+	{"ing", "", true}, {"ing", "e", false},
+	{"er", "", true}, {"er", "e", false}, {"est", "", true}, {"est", "e", false},
+	{"ly", "", false}, {"ily", "y", false},
+}
+
+// suggest returns the known words one edit (insertion, deletion, substitution or
+// transposition) away from lw.
+func (d *SpellDictionary) suggest(lw string) []string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	seen := make(map[string]bool)
+	add := func(c string) {
+		if !seen[c] && d.knows(c) {
+			seen[c] = true
+		}
+	}
+	for i := 0; i <= len(lw); i++ {
+		head, tail := lw[:i], lw[i:]
+		if tail != "" {
+			add(head + tail[1:])
+		}
+		if len(tail) > 1 {
+			add(head + tail[1:2] + tail[:1] + tail[2:])
+		}
+		for _, c := range letters {
+			add(head + string(c) + tail)
+			if tail != "" {
+				add(head + string(c) + tail[1:])
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for c := range seen {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	if len(out) > maxSpellingSuggestions {
+		out = out[:maxSpellingSuggestions]
+	}
+	return out
+}
+
+// matchCase capitalizes suggestions for a capitalized word.
+func matchCase(word string, suggestions []string) []string {
+	r, _ := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(r) {
+		return suggestions
+	}
+	out := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		sr, n := utf8.DecodeRuneInString(s)
+		out[i] = string(unicode.ToUpper(sr)) + s[n:]
+	}
+	return out
+}
+
+// spellingMistake is a misspelled word in a markdown body.
+type spellingMistake struct {
+	Line        int // 1-based line in the body
+	Offset      int // byte offset of the word in the body
+	Word        string
+	Suggestions []string
+}
+
+// fixable reports whether the mistake has exactly one correction.
+func (m spellingMistake) fixable() bool {
+	return len(m.Suggestions) == 1
+}
+
+var (
+	// spellMaskPatterns match markup whose text is not prose: link destinations,
+	// HTML tags, autolinks, Hugo shortcodes and bare URLs.
+	spellMaskPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\]\([^)]*\)`),
+		regexp.MustCompile(`<[^>]*>`),
+		regexp.MustCompile(`\{\{[<%].*?[>%]\}\}`),
+		regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`),
+	}
+	// spellTokenPattern matches whitespace-separated tokens.
+	spellTokenPattern = regexp.MustCompile(`\S+`)
+)
+
+// findSpellingMistakes checks the prose of a markdown body (front matter removed),
+// skipping fenced and indented code blocks, inline code, URLs and markup.
+func findSpellingMistakes(body string, dict *SpellDictionary) []spellingMistake {
+	var mistakes []spellingMistake
+	inCodeBlock, activeFence := false, ""
+	offset := 0
+	for i, line := range strings.SplitAfter(body, "\n") {
+		lineStart := offset
+		offset += len(line)
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock, activeFence = toggleSpellFence(inCodeBlock, activeFence, trimmed[:3])
+			continue
+		}
+		if inCodeBlock || strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		masked := maskNonProse(line)
+		for _, loc := range spellTokenPattern.FindAllStringIndex(masked, -1) {
+			for _, w := range spellWords(masked[loc[0]:loc[1]], loc[0]) {
+				ok, suggestions := dict.Check(w.text)
+				if ok {
+					continue
+				}
+				mistakes = append(mistakes, spellingMistake{
+					Line:        i + 1,
+					Offset:      lineStart + w.pos,
+					Word:        w.text,
+					Suggestions: suggestions,
+				})
+			}
+		}
+	}
+	return mistakes
+}
+
+func toggleSpellFence(inCodeBlock bool, activeFence, fence string) (bool, string) {
+	if !inCodeBlock {
+		return true, fence
+	}
+	if activeFence == fence {
+		return false, ""
+	}
+	return true, activeFence
+}
+
+// maskNonProse blanks out inline code and markup, keeping byte offsets intact.
+func maskNonProse(line string) string {
+	b := []byte(line)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			b[i] = ' '
+		}
+	}
+	// Inline code spans: a run of backticks up to the next run of the same length.
+	for i := 0; i < len(b); {
+		if b[i] != '`' {
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(b) && b[i+run] == '`' {
+			run++
+		}
+		closeRel := strings.Index(string(b[i+run:]), strings.Repeat("`", run))
+		if closeRel == -1 {
+			i += run
+			continue
+		}
+		end := i + run + closeRel + run
+		blank(i, end)
+		i = end
+	}
+	for _, re := range spellMaskPatterns {
+		for _, loc := range re.FindAllIndex(b, -1) {
+			blank(loc[0], loc[1])
+		}
+	}
+	return string(b)
+}
+
+type spellWord struct {
+	text string
+	pos  int
+}
+
+// spellWords splits a token into the words to check. Tokens that look like code,
+// paths, identifiers or numbers are skipped; hyphenated words are checked per part.
+func spellWords(token string, pos int) []spellWord {
+	start := strings.IndexFunc(token, unicode.IsLetter)
+	end := strings.LastIndexFunc(token, unicode.IsLetter)
+	if start < 0 {
+		return nil
+	}
+	_, size := utf8.DecodeRuneInString(token[end:])
+	core := token[start : end+size]
+	if strings.ContainsAny(core, "/\\@#$%&=+_.:;<>|{}[]()*~^\"0123456789") {
+		return nil
+	}
+	var words []spellWord
+	partStart := 0
+	for i := 0; i <= len(core); i++ {
+		if i < len(core) && core[i] != '-' {
+			continue
+		}
+		part := core[partStart:i]
+		if isProseWord(part) {
+			words = append(words, spellWord{text: part, pos: pos + start + partStart})
+		}
+		partStart = i + 1
+	}
+	return words
+}
+
+// isProseWord reports whether part is a word to check: letters and apostrophes only,
+// and neither an acronym (ALLCAPS) nor a mixed-case identifier (camelCase).
+func isProseWord(part string) bool {
+	if part == "" {
+		return false
+	}
+	for i, r := range part {
+		switch {
+		case r == '\'' || r == '’':
+		case !unicode.IsLetter(r):
+			return false
+		case unicode.IsUpper(r) && i > 0:
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,10 @@
 package lint
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"git.home.luguber.info/inful/docbuilder/internal/sanitize"
+)
 
 const (
 	docExtensionMarkdown     = ".md"
@@ -120,6 +124,12 @@ type Config struct {
 
 	// Yes automatically confirms fixes without prompting.
 	Yes bool
+
+	// ContentPolicy is the shortcode/raw HTML sanitize policy to check (nil disables the check).
+	ContentPolicy sanitize.Resolver
+
+	// Spelling is the dictionary checked by the spelling rule (nil disables the check).
+	Spelling *SpellDictionary
 }
 
 // IsDocFile returns true if the file is a documentation file.
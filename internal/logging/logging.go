@@ -0,0 +1,157 @@
+// Package logging builds the daemon's loggers from monitoring.logging.
+//
+// A Router owns the configured sinks (stderr, a rotated file, syslog) and hands out one
+// logger per component. Each component logger tags records with "component" and applies
+// the component's level, so git, hugo, daemon and http output can be tuned separately.
+// Components receive their logger through constructors (WithLogger/WithLogging); code
+// without an injected logger keeps using slog.Default, which the CLI points at the
+// router's default logger.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+// Component identifies a subsystem with its own log level.
+type Component string
+
+const (
+	ComponentDaemon Component = "daemon"
+	ComponentGit    Component = "git"
+	ComponentHugo   Component = "hugo"
+	ComponentHTTP   Component = "http"
+)
+
+// Router hands out component loggers writing to the configured sinks.
+type Router struct {
+	sink    slog.Handler
+	level   slog.Level
+	levels  map[Component]slog.Level
+	closers []io.Closer
+	// access holds the access log files of servers (monitoring.logging.access.servers).
+	access map[string]io.Writer
+}
+
+// New builds a router from the logging configuration. Records are written to stderr and
+// to the optional file and syslog sinks. verbose forces debug level for every component.
+func New(cfg config.MonitoringLogging, stderr io.Writer, verbose bool) (*Router, error) {
+	r := &Router{
+		level:  Level(cfg.Level),
+		levels: make(map[Component]slog.Level, len(cfg.Components)),
+	}
+	for name, lvl := range cfg.Components {
+		r.levels[Component(name)] = Level(lvl)
+	}
+	if verbose {
+		r.level = slog.LevelDebug
+		clear(r.levels)
+	}
+
+	sinks := []slog.Handler{newHandler(stderr, cfg.Format)}
+	if cfg.File != nil {
+		file, err := openRotatingFile(cfg.File.Path, cfg.File.MaxSizeBytes(), cfg.File.Backups())
+		if err != nil {
+			return nil, err
+		}
+		r.closers = append(r.closers, file)
+		sinks = append(sinks, newHandler(file, cfg.Format))
+	}
+	if cfg.Syslog != nil && cfg.Syslog.Enabled {
+		sys, err := newSyslogHandler(cfg.Syslog)
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("open syslog: %w", err)
+		}
+		r.closers = append(r.closers, sys)
+		sinks = append(sinks, sys)
+	}
+	if cfg.Access.IsEnabled() {
+		r.access = make(map[string]io.Writer, len(cfg.Access.Servers))
+		for server, f := range cfg.Access.Servers {
+			file, err := openRotatingFile(f.Path, f.MaxSizeBytes(), f.Backups())
+			if err != nil {
+				_ = r.Close()
+				return nil, fmt.Errorf("open %s access log: %w", server, err)
+			}
+			r.closers = append(r.closers, file)
+			r.access[server] = file
+		}
+	}
+	r.sink = observability.NewContextHandler(fanout(sinks))
+	return r, nil
+}
+
+// AccessLog returns the access log file of a server, or nil when the server has none
+// and its access records go to the http component logger.
+func (r *Router) AccessLog(server string) io.Writer {
+	if r == nil {
+		return nil
+	}
+	if w, ok := r.access[server]; ok {
+		return w
+	}
+	return nil
+}
+
+// Logger returns the logger of a component. A nil router returns slog.Default tagged
+// with the component, so constructors can accept an optional router.
+func (r *Router) Logger(c Component) *slog.Logger {
+	if r == nil {
+		return slog.Default().With("component", string(c))
+	}
+	lvl, ok := r.levels[c]
+	if !ok {
+		lvl = r.level
+	}
+	return slog.New(&levelHandler{level: lvl, next: r.sink}).With("component", string(c))
+}
+
+// Default returns the logger for records not attributed to a component.
+func (r *Router) Default() *slog.Logger {
+	if r == nil {
+		return slog.Default()
+	}
+	return slog.New(&levelHandler{level: r.level, next: r.sink})
+}
+
+// Close releases the file, syslog and access log sinks.
+func (r *Router) Close() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, c := range r.closers {
+		errs = append(errs, c.Close())
+	}
+	r.closers = nil
+	return errors.Join(errs...)
+}
+
+// Level maps a configured level to slog (unknown values mean info).
+func Level(l config.LogLevel) slog.Level {
+	switch l {
+	case config.LogLevelDebug:
+		return slog.LevelDebug
+	case config.LogLevelWarn:
+		return slog.LevelWarn
+	case config.LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// newHandler returns a sink handler; level filtering happens in the component layer.
+func newHandler(w io.Writer, format config.LogFormat) slog.Handler {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if format == config.LogFormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestRouterComponentLevels(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(config.MonitoringLogging{
+		Level:      config.LogLevelWarn,
+		Format:     config.LogFormatText,
+		Components: map[string]config.LogLevel{"git": config.LogLevelDebug},
+	}, &out, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	r.Logger(ComponentGit).Debug("git debug")
+	r.Logger(ComponentHugo).Info("hugo info")
+	r.Logger(ComponentHugo).Warn("hugo warn")
+
+	got := out.String()
+	if !strings.Contains(got, `msg="git debug" component=git`) {
+		t.Errorf("expected git debug record, got:\n%s", got)
+	}
+	if strings.Contains(got, "hugo info") {
+		t.Errorf("hugo info should be filtered by the global warn level, got:\n%s", got)
+	}
+	if !strings.Contains(got, `msg="hugo warn" component=hugo`) {
+		t.Errorf("expected hugo warn record, got:\n%s", got)
+	}
+}
+
+func TestRouterVerboseOverridesLevels(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(config.MonitoringLogging{
+		Level:      config.LogLevelError,
+		Components: map[string]config.LogLevel{"http": config.LogLevelError},
+	}, &out, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Logger(ComponentHTTP).Debug("request")
+	if !strings.Contains(out.String(), "level=DEBUG msg=request component=http") {
+		t.Errorf("expected debug record, got %q", out.String())
+	}
+}
+
+func TestRouterFileSinkRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "docbuilder.log")
+	var out bytes.Buffer
+	r, err := New(config.MonitoringLogging{
+		Level:  config.LogLevelInfo,
+		Format: config.LogFormatText,
+		File:   &config.LogFileConfig{Path: path, MaxBackups: 2},
+	}, &out, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	// Shrink the limit so a few records force rotation.
+	file := r.closers[0].(*rotatingFile)
+	file.maxSize = 200
+	logger := r.Logger(ComponentDaemon)
+	for range 10 {
+		logger.Info("a fairly long log message to fill the file quickly")
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, found %s.3", path)
+	}
+}
+
+func TestNilRouterFallsBackToDefault(t *testing.T) {
+	var r *Router
+	if r.Logger(ComponentGit) == nil || r.Default() == nil {
+		t.Fatal("nil router should return loggers")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRouterAccessLogFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access", "docs.log")
+	r, err := New(config.MonitoringLogging{
+		Access: &config.AccessLogConfig{
+			Enabled: true,
+			Servers: map[string]*config.LogFileConfig{"docs": {Path: path}},
+		},
+	}, &bytes.Buffer{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.AccessLog("admin") != nil {
+		t.Error("admin has no access log file")
+	}
+	w := r.AccessLog("docs")
+	if w == nil {
+		t.Fatal("expected docs access log file")
+	}
+	if _, err := w.Write([]byte("line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "line\n" {
+		t.Fatalf("access log = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"log/syslog"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// syslogHandler sends records to syslog with a priority matching the record level.
+// Messages use the text format without time and level, which syslog records itself.
+type syslogHandler struct {
+	w   *syslog.Writer
+	ops []func(slog.Handler) slog.Handler // WithAttrs/WithGroup calls, replayed per record
+}
+
+func newSyslogHandler(cfg *config.LogSyslogConfig) (*syslogHandler, error) {
+	w, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, cfg.EffectiveTag())
+	if err != nil {
+		return nil, err
+	}
+	return &syslogHandler{w: w}, nil
+}
+
+func (h *syslogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *syslogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	var th slog.Handler = slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	for _, op := range h.ops {
+		th = op(th)
+	}
+	if err := th.Handle(ctx, r); err != nil {
+		return err
+	}
+	msg := strings.TrimSuffix(buf.String(), "\n")
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.w.Err(msg)
+	case r.Level >= slog.LevelWarn:
+		return h.w.Warning(msg)
+	case r.Level >= slog.LevelInfo:
+		return h.w.Info(msg)
+	default:
+		return h.w.Debug(msg)
+	}
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *syslogHandler) with(op func(slog.Handler) slog.Handler) *syslogHandler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+	return &syslogHandler{w: h.w, ops: append(ops, op)}
+}
+
+// Close closes the syslog connection.
+func (h *syslogHandler) Close() error { return h.w.Close() }
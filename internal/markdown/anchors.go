@@ -0,0 +1,132 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HeadingAnchor is an ATX heading and the anchor Hugo generates for it.
+type HeadingAnchor struct {
+	Line  int    // 1-based line in the body
+	Level int    // 1-6
+	Text  string // Heading text without an explicit {#id}
+	Base  string // Anchor derived from the text, or the explicit id
+	ID    string // Anchor served by Hugo: Base, numbered ("setup-1") when it is already taken
+}
+
+// DuplicateAnchor is a heading whose anchor is already used by an earlier heading of
+// the same page. Hugo serves it as a numbered anchor, so deep links to Base always land
+// on the first heading.
+type DuplicateAnchor struct {
+	HeadingAnchor
+	FirstLine int    // Line of the heading that owns Base
+	Suggested string // Unique anchor to use as an explicit {#id}
+}
+
+// HeadingAnchors returns the ATX headings of a markdown body (front matter removed) with
+// the anchors Hugo generates for them: GitHub style (lowercased, punctuation dropped,
+// spaces as hyphens, duplicates numbered), or the explicit {#id} attribute. Headings in
+// fenced code blocks are skipped.
+func HeadingAnchors(body string) []HeadingAnchor {
+	var headings []HeadingAnchor
+	taken := make(map[string]struct{})
+	fence := ""
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		level := 0
+		for level < len(trimmed) && trimmed[level] == '#' {
+			level++
+		}
+		if level == 0 || level > 6 || (level < len(trimmed) && trimmed[level] != ' ') {
+			continue
+		}
+		h := HeadingAnchor{Line: i + 1, Level: level, Text: strings.TrimSpace(strings.TrimRight(trimmed[level:], "#"))}
+		if j := strings.LastIndex(h.Text, "{#"); j >= 0 && strings.HasSuffix(h.Text, "}") {
+			h.Base = strings.ToLower(h.Text[j+2 : len(h.Text)-1])
+			h.Text = strings.TrimSpace(h.Text[:j])
+		} else {
+			h.Base = Anchorize(h.Text)
+		}
+		if h.Base == "" {
+			continue
+		}
+		h.ID = uniqueAnchor(h.Base, taken)
+		taken[h.ID] = struct{}{}
+		headings = append(headings, h)
+	}
+	return headings
+}
+
+// Anchorize converts heading text to its GitHub-style anchor.
+func Anchorize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// DuplicateAnchors returns the headings whose anchor collides with an earlier heading,
+// each with a suggested unique anchor: the anchor of the enclosing heading joined with
+// its own ("install-configuration"), or the numbered anchor when that is taken too.
+func DuplicateAnchors(headings []HeadingAnchor) []DuplicateAnchor {
+	first := make(map[string]int, len(headings))
+	used := make(map[string]struct{}, len(headings))
+	for _, h := range headings {
+		used[h.ID] = struct{}{}
+	}
+
+	var dups []DuplicateAnchor
+	var parents []HeadingAnchor // enclosing headings, outermost first
+	for i, h := range headings {
+		for len(parents) > 0 && parents[len(parents)-1].Level >= h.Level {
+			parents = parents[:len(parents)-1]
+		}
+		if line, ok := first[h.Base]; ok {
+			suggested := h.ID
+			if len(parents) > 0 {
+				if candidate := parents[len(parents)-1].ID + "-" + h.Base; !isTaken(candidate, used) {
+					suggested = candidate
+				}
+			}
+			used[suggested] = struct{}{}
+			dups = append(dups, DuplicateAnchor{HeadingAnchor: headings[i], FirstLine: line, Suggested: suggested})
+		} else {
+			first[h.Base] = h.Line
+		}
+		parents = append(parents, h)
+	}
+	return dups
+}
+
+func isTaken(anchor string, used map[string]struct{}) bool {
+	_, ok := used[anchor]
+	return ok
+}
+
+func uniqueAnchor(base string, taken map[string]struct{}) string {
+	unique := base
+	for n := 1; ; n++ {
+		if _, exists := taken[unique]; !exists {
+			return unique
+		}
+		unique = base + "-" + strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadingAnchors_NumbersDuplicates(t *testing.T) {
+	body := "# Guide\n\n## Setup\n\n```md\n## Setup\n```\n\n## Usage\n\n### Setup\n\n## Custom {#Setup-Notes}\n"
+	headings := HeadingAnchors(body)
+	require.Len(t, headings, 5)
+	require.Equal(t, HeadingAnchor{Line: 3, Level: 2, Text: "Setup", Base: "setup", ID: "setup"}, headings[1])
+	require.Equal(t, HeadingAnchor{Line: 11, Level: 3, Text: "Setup", Base: "setup", ID: "setup-1"}, headings[3])
+	require.Equal(t, HeadingAnchor{Line: 13, Level: 2, Text: "Custom", Base: "setup-notes", ID: "setup-notes"}, headings[4])
+}
+
+func TestDuplicateAnchors_SuggestsParentQualifiedAnchor(t *testing.T) {
+	body := "# Guide\n\n## Install\n\n### Configuration\n\n## Upgrade\n\n### Configuration\n\n## Configuration\n"
+	dups := DuplicateAnchors(HeadingAnchors(body))
+	require.Len(t, dups, 2)
+
+	require.Equal(t, 9, dups[0].Line)
+	require.Equal(t, 5, dups[0].FirstLine)
+	require.Equal(t, "configuration-1", dups[0].ID)
+	require.Equal(t, "upgrade-configuration", dups[0].Suggested)
+
+	require.Equal(t, 11, dups[1].Line)
+	require.Equal(t, "guide-configuration", dups[1].Suggested)
+
+	require.Empty(t, DuplicateAnchors(HeadingAnchors("# A\n## B\n")))
+}
@@ -0,0 +1,21 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// RenderHTML renders a Markdown body (frontmatter already removed) to HTML with GitHub
+// Flavored Markdown extensions. Raw HTML in the source is omitted, so the result is safe
+// to embed in a page served by DocBuilder.
+func RenderHTML(body []byte) ([]byte, error) {
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	var buf bytes.Buffer
+	if err := md.Convert(body, &buf); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+	return buf.Bytes(), nil
+}
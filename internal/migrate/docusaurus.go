@@ -0,0 +1,227 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatterops"
+)
+
+// docusaurusConfigFiles are the configuration file names Docusaurus accepts, in lookup order.
+var docusaurusConfigFiles = []string{"docusaurus.config.js", "docusaurus.config.ts", "docusaurus.config.mjs", "docusaurus.config.cjs"}
+
+// The Docusaurus configuration is a JavaScript module, so settings are read with
+// patterns rather than evaluated. Only literal string values are recognized.
+var (
+	jsTitle          = jsTopLevelString("title")
+	jsTagline        = jsTopLevelString("tagline")
+	jsURL            = jsTopLevelString("url")
+	jsBaseURL        = jsTopLevelString("baseUrl")
+	jsOrganization   = jsTopLevelString("organizationName")
+	jsProject        = jsTopLevelString("projectName")
+	jsDocsPath       = regexp.MustCompile(`(?s)docs\s*:\s*\{[^{}]*?\bpath\s*:\s*['"]([^'"]+)['"]`)
+	jsBlogDisabled   = regexp.MustCompile(`\bblog\s*:\s*false`)
+	jsLocales        = regexp.MustCompile(`\blocales\s*:\s*\[([^\]]*)\]`)
+	jsDefaultMode    = regexp.MustCompile(`\bdefaultMode\s*:\s*['"](light|dark)['"]`)
+	jsDisableSwitch  = regexp.MustCompile(`\bdisableSwitch\s*:\s*true`)
+	jsPlugins        = regexp.MustCompile(`\bplugins\s*:\s*\[\s*[^\]\s]`)
+	jsEditURL        = regexp.MustCompile(`\beditUrl\s*:`)
+	jsNavbarLogo     = regexp.MustCompile(`\blogo\s*:\s*\{`)
+	jsFooter         = regexp.MustCompile(`\bfooter\s*:\s*\{`)
+	jsAlgolia        = regexp.MustCompile(`\balgolia\s*:\s*\{`)
+	jsSidebarsInline = regexp.MustCompile(`\bsidebarPath\s*:`)
+)
+
+// jsTopLevelString matches a string setting of the exported configuration object, which
+// is indented at most two spaces; nested settings with the same key are indented further.
+func jsTopLevelString(key string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^\s{0,2}` + key + `\s*:\s*['"` + "`" + `]([^'"` + "`" + `]*)['"` + "`" + `]`)
+}
+
+// docusaurusCategory is a _category_.json file.
+type docusaurusCategory struct {
+	Label    string   `json:"label"`
+	Position *float64 `json:"position"`
+}
+
+// Docusaurus plans the migration of the Docusaurus site in opts.Dir.
+func Docusaurus(opts Options) (*Result, error) {
+	var src []byte
+	var configName string
+	for _, name := range docusaurusConfigFiles {
+		data, err := os.ReadFile(filepath.Join(opts.Dir, name)) // #nosec G304 -- site configuration of the repository being migrated
+		if err == nil {
+			src, configName = data, name
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+	}
+	if configName == "" {
+		return nil, fmt.Errorf("no Docusaurus configuration (%s) in %s", strings.Join(docusaurusConfigFiles, ", "), opts.Dir)
+	}
+	text := string(src)
+
+	r := &Result{Source: SourceDocusaurus}
+	s := site{
+		title:       jsMatch(jsTitle, text),
+		description: jsMatch(jsTagline, text),
+	}
+	if u := jsMatch(jsURL, text); u != "" {
+		s.baseURL = strings.TrimRight(u, "/") + "/" + strings.TrimLeft(jsMatch(jsBaseURL, text), "/")
+	}
+	if org, project := jsMatch(jsOrganization, text), jsMatch(jsProject, text); org != "" && project != "" {
+		s.repoURL = "https://github.com/" + org + "/" + project + ".git"
+	}
+	docsDir := jsMatch(jsDocsPath, text)
+	if docsDir == "" {
+		docsDir = "docs"
+	}
+	docsDir = path.Clean(filepath.ToSlash(docsDir))
+	s.docsPaths = []string{docsDir}
+
+	switch {
+	case jsDisableSwitch.MatchString(text) && jsMatch(jsDefaultMode, text) == "dark":
+		s.themeVariant = []any{"zen-dark"}
+	case jsDisableSwitch.MatchString(text):
+		s.themeVariant = []any{"zen-light"}
+	}
+	r.reportDocusaurusFeatures(opts.Dir, text)
+
+	pages := pageSet{}
+	if err := r.docusaurusPages(opts.Dir, docsDir, pages); err != nil {
+		return nil, err
+	}
+	r.Pages = pages.list()
+	if err := r.finish(opts, s); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func jsMatch(re *regexp.Regexp, text string) string {
+	if m := re.FindStringSubmatch(text); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// reportDocusaurusFeatures reports configured features DocBuilder does not translate.
+func (r *Result) reportDocusaurusFeatures(dir, text string) {
+	if !jsBlogDisabled.MatchString(text) && dirExists(filepath.Join(dir, "blog")) {
+		r.unsupported("blog: DocBuilder has no blog; the posts in blog/ are not migrated")
+	}
+	if m := jsLocales.FindStringSubmatch(text); m != nil && strings.Count(m[1], ",") > 0 {
+		r.unsupported("i18n: DocBuilder builds a single language; translations are not migrated")
+	}
+	if fileExists(filepath.Join(dir, "versions.json")) {
+		r.unsupported("versioned docs: DocBuilder versions from branches and tags (see versioning in the configuration); versioned_docs/ is not migrated")
+	}
+	if jsSidebarsInline.MatchString(text) || fileExists(filepath.Join(dir, "sidebars.js")) || fileExists(filepath.Join(dir, "sidebars.ts")) {
+		r.unsupported("sidebars: hand-written sidebars are not translated; the order comes from sidebar_position and _category_.json")
+	}
+	if jsPlugins.MatchString(text) {
+		r.unsupported("plugins: Docusaurus plugins are not migrated")
+	}
+	if jsEditURL.MatchString(text) {
+		r.unsupported("editUrl: DocBuilder derives edit links from the forge; the custom URL is not kept")
+	}
+	if jsNavbarLogo.MatchString(text) {
+		r.unsupported("themeConfig.navbar.logo is not translated")
+	}
+	if jsFooter.MatchString(text) {
+		r.unsupported("themeConfig.footer is not translated")
+	}
+	if jsAlgolia.MatchString(text) {
+		r.unsupported("themeConfig.algolia: DocBuilder uses the built-in Relearn search")
+	}
+	if dirExists(filepath.Join(dir, "src", "pages")) {
+		r.unsupported("src/pages: standalone React pages are not migrated")
+	}
+}
+
+// docusaurusPages translates sidebar metadata: sidebar_position and sidebar_label front
+// matter become weight and linkTitle, and _category_.json labels and positions move to
+// the index page of their directory.
+func (r *Result) docusaurusPages(root, docsDir string, pages pageSet) error {
+	files, err := markdownFiles(root, docsDir, ".md", ".mdx")
+	if err != nil {
+		return fmt.Errorf("list pages: %w", err)
+	}
+	mdx := 0
+	for _, f := range files {
+		if strings.EqualFold(path.Ext(f), ".mdx") {
+			mdx++
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f))) // #nosec G304 -- page of the repository being migrated
+		if err != nil {
+			return fmt.Errorf("read %s: %w", f, err)
+		}
+		fields, _, _, _, err := frontmatterops.Read(content)
+		if err != nil {
+			r.unsupported("%s: unreadable front matter: %v", f, err)
+			continue
+		}
+		if v, ok := fields["sidebar_position"]; ok {
+			pages.set(f, "weight", v)
+			pages.get(f).Remove = append(pages.get(f).Remove, "sidebar_position")
+		}
+		if v, ok := fields["sidebar_label"]; ok {
+			pages.set(f, "linkTitle", v)
+			pages.get(f).Remove = append(pages.get(f).Remove, "sidebar_label")
+		}
+		if _, ok := fields["slug"]; ok {
+			r.unsupported("%s: slug is not translated; the URL follows the file path", f)
+		}
+	}
+	if mdx > 0 {
+		r.unsupported("%d MDX page(s): JSX components are not rendered; convert them to Markdown", mdx)
+	}
+
+	return filepath.WalkDir(filepath.Join(root, filepath.FromSlash(docsDir)), func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "_category_.json" {
+			return err
+		}
+		data, err := os.ReadFile(p) // #nosec G304 -- category file of the repository being migrated
+		if err != nil {
+			return fmt.Errorf("read %s: %w", p, err)
+		}
+		var cat docusaurusCategory
+		rel, _ := filepath.Rel(root, filepath.Dir(p))
+		if err := json.Unmarshal(data, &cat); err != nil {
+			r.unsupported("%s/_category_.json: %v", filepath.ToSlash(rel), err)
+			return nil
+		}
+		index, exists := sectionIndex(root, filepath.ToSlash(rel))
+		if cat.Position != nil {
+			pages.set(index, "weight", int(math.Round(*cat.Position)))
+		}
+		if cat.Label != "" {
+			pages.set(index, "linkTitle", cat.Label)
+			if !exists {
+				pages.set(index, "title", cat.Label)
+			}
+		}
+		pages.get(index).Create = !exists
+		return nil
+	})
+}
+
+func fileExists(p string) bool {
+	st, err := os.Stat(p)
+	return err == nil && !st.IsDir()
+}
+
+func dirExists(p string) bool {
+	st, err := os.Stat(p)
+	return err == nil && st.IsDir()
+}
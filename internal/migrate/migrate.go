@@ -0,0 +1,247 @@
+// Package migrate translates MkDocs and Docusaurus sites into DocBuilder configuration:
+// a site configuration, the repository-local .docbuilder.yaml and front matter that
+// reproduces the source navigation order. Features without a DocBuilder equivalent are
+// reported rather than silently dropped.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatterops"
+)
+
+// Sources supported by the migration.
+const (
+	SourceMkDocs     = "mkdocs"
+	SourceDocusaurus = "docusaurus"
+)
+
+// RepoConfigFile is the repository-local configuration written next to the docs.
+const RepoConfigFile = ".docbuilder.yaml"
+
+// Options describes the repository being migrated.
+type Options struct {
+	Dir     string // Repository root
+	Name    string // Repository name (default: directory name)
+	RepoURL string // Clone URL (default: taken from the source configuration)
+	Branch  string // Default branch (default: main)
+}
+
+// Result is a migration plan. Nothing is written until the caller applies it.
+type Result struct {
+	Source      string
+	Site        *config.Config // Site configuration: theme metadata, menus and the repository entry
+	Repo        *config.Config // Repository-local .docbuilder.yaml
+	Pages       []PageUpdate   // Front matter changes that translate the navigation
+	Unsupported []string       // Features without a DocBuilder equivalent
+}
+
+// PageUpdate sets and removes front matter fields of one page.
+type PageUpdate struct {
+	Path   string         // Relative to the repository root, slash-separated
+	Set    map[string]any // Fields to set (weight, linkTitle, title)
+	Remove []string       // Source-specific fields to drop
+	Create bool           // The page does not exist yet (a section index)
+}
+
+// site collects what the source configuration says about the site.
+type site struct {
+	title, description, baseURL, repoURL string
+	docsPaths                            []string
+	themeVariant                         []any
+	shortcuts                            []config.Menu
+}
+
+func (r *Result) unsupported(format string, args ...any) {
+	r.Unsupported = append(r.Unsupported, fmt.Sprintf(format, args...))
+}
+
+// finish builds the configurations of r from s.
+func (r *Result) finish(opts Options, s site) error {
+	repo := config.Repository{
+		Name:   opts.Name,
+		URL:    opts.RepoURL,
+		Branch: opts.Branch,
+		Paths:  s.docsPaths,
+	}
+	if repo.Name == "" {
+		repo.Name = filepath.Base(opts.Dir)
+	}
+	if repo.URL == "" {
+		repo.URL = s.repoURL
+	}
+	if repo.URL == "" {
+		return errors.New("repository URL is unknown: pass --url")
+	}
+	if repo.Branch == "" {
+		repo.Branch = "main"
+	}
+	title := s.title
+	if title == "" {
+		title = repo.Name + " Documentation"
+	}
+
+	r.Site = &config.Config{
+		Version:      "2.0",
+		Repositories: []config.Repository{repo},
+		Hugo:         config.HugoConfig{Title: title, Description: s.description, BaseURL: s.baseURL},
+		Output:       config.OutputConfig{Directory: "./site", Clean: true},
+	}
+	if s.themeVariant != nil {
+		r.Site.Hugo.Params = map[string]any{"themeVariant": s.themeVariant}
+	}
+	if len(s.shortcuts) > 0 {
+		r.Site.Hugo.Menu = map[string][]config.Menu{"shortcuts": s.shortcuts}
+	}
+	r.Repo = &config.Config{
+		Version:      "2.0",
+		Repositories: []config.Repository{repo},
+		Hugo:         config.HugoConfig{Title: title},
+		Output:       config.OutputConfig{Directory: "./site"},
+	}
+	sort.Slice(r.Pages, func(i, j int) bool { return r.Pages[i].Path < r.Pages[j].Path })
+	return nil
+}
+
+// SiteYAML returns the validated site configuration.
+func (r *Result) SiteYAML() ([]byte, error) {
+	return r.render(r.Site, "# DocBuilder site configuration migrated from "+r.Source+".\n")
+}
+
+// RepoYAML returns the validated repository-local configuration.
+func (r *Result) RepoYAML() ([]byte, error) {
+	return r.render(r.Repo, "# DocBuilder configuration for this repository, migrated from "+r.Source+".\n"+
+		"#   docbuilder build -c "+RepoConfigFile+"\n")
+}
+
+func (r *Result) render(cfg *config.Config, header string) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal configuration: %w", err)
+	}
+	if err := config.ValidateYAML(data); err != nil {
+		return nil, err
+	}
+	return append([]byte(header), data...), nil
+}
+
+// ApplyPages writes the front matter changes below dir and returns the pages written.
+func (r *Result) ApplyPages(dir string) ([]string, error) {
+	var written []string
+	for _, p := range r.Pages {
+		full := filepath.Join(dir, filepath.FromSlash(p.Path))
+		content, err := os.ReadFile(full) // #nosec G304 -- pages of the repository being migrated
+		switch {
+		case err == nil:
+		case errors.Is(err, os.ErrNotExist) && p.Create:
+			content = nil
+		default:
+			return written, fmt.Errorf("read %s: %w", p.Path, err)
+		}
+		fields, body, _, style, err := frontmatterops.Read(content)
+		if err != nil {
+			return written, fmt.Errorf("front matter of %s: %w", p.Path, err)
+		}
+		for _, k := range p.Remove {
+			delete(fields, k)
+		}
+		for k, v := range p.Set {
+			fields[k] = v
+		}
+		out, err := frontmatterops.Write(fields, body, true, style)
+		if err != nil {
+			return written, fmt.Errorf("write front matter of %s: %w", p.Path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+			return written, fmt.Errorf("create directory for %s: %w", p.Path, err)
+		}
+		// #nosec G306 -- documentation pages are meant to be committed and shared
+		if err := os.WriteFile(full, out, 0o644); err != nil {
+			return written, fmt.Errorf("write %s: %w", p.Path, err)
+		}
+		written = append(written, p.Path)
+	}
+	return written, nil
+}
+
+// pageSet accumulates page updates by path.
+type pageSet map[string]*PageUpdate
+
+func (ps pageSet) set(p, key string, value any) {
+	u := ps.get(p)
+	u.Set[key] = value
+}
+
+func (ps pageSet) get(p string) *PageUpdate {
+	u, ok := ps[p]
+	if !ok {
+		u = &PageUpdate{Path: p, Set: map[string]any{}}
+		ps[p] = u
+	}
+	return u
+}
+
+func (ps pageSet) list() []PageUpdate {
+	out := make([]PageUpdate, 0, len(ps))
+	for _, u := range ps {
+		if len(u.Set) > 0 || len(u.Remove) > 0 {
+			out = append(out, *u)
+		}
+	}
+	return out
+}
+
+// indexNames are the file names DocBuilder treats as a section index.
+var indexNames = []string{"_index.md", "index.md", "README.md", "readme.md"}
+
+// sectionIndex returns the index page of dir (slash-separated, relative to root), or the
+// _index.md to create when it has none.
+func sectionIndex(root, dir string) (p string, exists bool) {
+	for _, name := range indexNames {
+		candidate := path.Join(dir, name)
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(candidate))); err == nil {
+			return candidate, true
+		}
+	}
+	return path.Join(dir, "_index.md"), false
+}
+
+// isIndexPage reports whether p is a section index.
+func isIndexPage(p string) bool {
+	base := strings.ToLower(path.Base(p))
+	return base == "_index.md" || base == "index.md" || base == "readme.md"
+}
+
+// markdownFiles lists the files below dir (relative to root) with one of exts.
+func markdownFiles(root, dir string, exts ...string) ([]string, error) {
+	var files []string
+	base := filepath.Join(root, filepath.FromSlash(dir))
+	err := filepath.WalkDir(base, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		for _, ext := range exts {
+			if strings.EqualFold(filepath.Ext(p), ext) {
+				rel, err := filepath.Rel(root, p)
+				if err != nil {
+					return err
+				}
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,158 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatterops"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+}
+
+func readFrontMatter(t *testing.T, p string) map[string]any {
+	t.Helper()
+	content, err := os.ReadFile(p)
+	require.NoError(t, err)
+	fields, _, _, _, err := frontmatterops.Read(content)
+	require.NoError(t, err)
+	return fields
+}
+
+func hasUnsupported(r *Result, substr string) bool {
+	for _, u := range r.Unsupported {
+		if strings.Contains(u, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMkDocs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"mkdocs.yml": `site_name: Acme Docs
+site_url: !ENV [SITE_URL, "https://docs.acme.dev/"]
+repo_url: https://github.com/acme/widget
+theme:
+  name: material
+  palette:
+    - scheme: default
+    - scheme: slate
+plugins: [search, mkdocstrings]
+markdown_extensions:
+  - toc:
+      permalink: true
+  - admonition
+  - pymdownx.emoji:
+      emoji_index: !!python/name:material.extensions.emoji.twemoji
+nav:
+  - Home: index.md
+  - Guide:
+      - guide/install.md
+      - Usage: guide/usage.md
+  - Mixed:
+      - other.md
+      - guide/usage.md
+  - Releases: https://github.com/acme/widget/releases
+`,
+		"docs/index.md":         "# Home\n",
+		"docs/guide/install.md": "---\ntitle: Install\n---\n# Install\n",
+		"docs/guide/usage.md":   "# Usage\n",
+		"docs/other.md":         "# Other\n",
+		"docs/hidden.md":        "# Hidden\n",
+	})
+
+	r, err := MkDocs(filepath.Join(dir, "mkdocs.yml"), Options{Name: "widget"})
+	require.NoError(t, err)
+
+	require.Equal(t, "Acme Docs", r.Site.Hugo.Title)
+	require.Empty(t, r.Site.Hugo.BaseURL, "!ENV values are not resolved")
+	require.Nil(t, r.Site.Hugo.Params, "a light/dark palette keeps the default variants")
+	require.Equal(t, "https://github.com/acme/widget.git", r.Site.Repositories[0].URL)
+	require.Equal(t, []string{"docs"}, r.Site.Repositories[0].Paths)
+	require.Equal(t, "Releases", r.Site.Hugo.Menu["shortcuts"][0].Name)
+	for _, want := range []string{"site_url", `theme "material"`, "mkdocstrings", "admonition", "pymdownx.emoji", `"Mixed" does not match a directory`, "1 page(s) are not in nav"} {
+		require.True(t, hasUnsupported(r, want), "expected %q in %v", want, r.Unsupported)
+	}
+	require.False(t, hasUnsupported(r, "markdown_extensions: toc"))
+
+	_, err = r.SiteYAML()
+	require.NoError(t, err)
+	written, err := r.ApplyPages(dir)
+	require.NoError(t, err)
+	require.Contains(t, written, "docs/guide/_index.md")
+
+	index := readFrontMatter(t, filepath.Join(dir, "docs/guide/_index.md"))
+	require.Equal(t, "Guide", index["title"])
+	require.Equal(t, 2, index["weight"])
+	install := readFrontMatter(t, filepath.Join(dir, "docs/guide/install.md"))
+	require.Equal(t, "Install", install["title"])
+	require.Equal(t, 1, install["weight"])
+	usage := readFrontMatter(t, filepath.Join(dir, "docs/guide/usage.md"))
+	require.Equal(t, "Usage", usage["linkTitle"])
+}
+
+func TestDocusaurus(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"docusaurus.config.js": `module.exports = {
+  title: 'Widget',
+  tagline: 'Widgets for everyone',
+  url: 'https://widget.dev',
+  baseUrl: '/',
+  organizationName: 'acme',
+  projectName: 'widget',
+  presets: [['classic', { docs: { path: 'documentation' }, blog: false }]],
+  themeConfig: { navbar: { title: 'Navbar title' }, colorMode: { defaultMode: 'dark', disableSwitch: true } },
+};
+`,
+		"documentation/intro.md":                 "---\nsidebar_position: 2\nsidebar_label: Intro\n---\n# Introduction\n",
+		"documentation/tutorial/_category_.json": `{"label": "Tutorial", "position": 3}`,
+		"documentation/tutorial/README.md":       "# Tutorial\n",
+		"documentation/tutorial/first-steps.mdx": "import X from 'x';\n",
+		"documentation/tutorial/custom-slug.md":  "---\nslug: /start\n---\n# Start\n",
+	})
+
+	r, err := Docusaurus(Options{Dir: dir})
+	require.NoError(t, err)
+	require.Equal(t, "Widget", r.Site.Hugo.Title)
+	require.Equal(t, "https://widget.dev/", r.Site.Hugo.BaseURL)
+	require.Equal(t, []any{"zen-dark"}, r.Site.Hugo.Params["themeVariant"])
+	require.Equal(t, "https://github.com/acme/widget.git", r.Repo.Repositories[0].URL)
+	require.Equal(t, []string{"documentation"}, r.Repo.Repositories[0].Paths)
+	require.True(t, hasUnsupported(r, "1 MDX page(s)"))
+	require.True(t, hasUnsupported(r, "custom-slug.md: slug"))
+
+	_, err = r.RepoYAML()
+	require.NoError(t, err)
+	_, err = r.ApplyPages(dir)
+	require.NoError(t, err)
+
+	intro := readFrontMatter(t, filepath.Join(dir, "documentation/intro.md"))
+	require.Equal(t, map[string]any{"weight": 2, "linkTitle": "Intro"}, intro)
+	readme := readFrontMatter(t, filepath.Join(dir, "documentation/tutorial/README.md"))
+	require.Equal(t, 3, readme["weight"])
+	require.Equal(t, "Tutorial", readme["linkTitle"])
+	require.NotContains(t, readme, "title", "existing index pages keep their own title")
+}
+
+func TestMigrate_RequiresRepositoryURL(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"mkdocs.yml": "site_name: Docs\n"})
+	_, err := MkDocs(filepath.Join(dir, "mkdocs.yml"), Options{})
+	require.ErrorContains(t, err, "--url")
+
+	_, err = Docusaurus(Options{Dir: dir, RepoURL: "https://example.com/r.git"})
+	require.ErrorContains(t, err, "no Docusaurus configuration")
+}
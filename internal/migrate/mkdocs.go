@@ -0,0 +1,320 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// mkdocsFile is the part of mkdocs.yml the migration reads. Values are kept as nodes
+// because MkDocs configurations use tags (!ENV, !!python/name) that do not decode into
+// plain Go values.
+type mkdocsFile struct {
+	SiteName           yaml.Node `yaml:"site_name"`
+	SiteDescription    yaml.Node `yaml:"site_description"`
+	SiteURL            yaml.Node `yaml:"site_url"`
+	RepoURL            yaml.Node `yaml:"repo_url"`
+	EditURI            yaml.Node `yaml:"edit_uri"`
+	DocsDir            yaml.Node `yaml:"docs_dir"`
+	Copyright          yaml.Node `yaml:"copyright"`
+	Theme              yaml.Node `yaml:"theme"`
+	Nav                yaml.Node `yaml:"nav"`
+	Plugins            yaml.Node `yaml:"plugins"`
+	MarkdownExtensions yaml.Node `yaml:"markdown_extensions"`
+	Extra              yaml.Node `yaml:"extra"`
+	ExtraCSS           yaml.Node `yaml:"extra_css"`
+	ExtraJavaScript    yaml.Node `yaml:"extra_javascript"`
+}
+
+// mkdocsExtensions are Markdown extensions whose syntax Hugo and the Relearn theme
+// render without changes to the pages.
+var mkdocsExtensions = []string{
+	"abbr", "attr_list", "codehilite", "def_list", "fenced_code", "footnotes", "meta", "tables", "toc",
+	"pymdownx.arithmatex", "pymdownx.highlight", "pymdownx.inlinehilite", "pymdownx.superfences",
+}
+
+// mkdocsPlugins are plugins whose function DocBuilder provides itself.
+var mkdocsPlugins = []string{"search"}
+
+// MkDocs plans the migration of the MkDocs site configured by configPath. opts.Dir
+// defaults to the directory of configPath.
+func MkDocs(configPath string, opts Options) (*Result, error) {
+	data, err := os.ReadFile(configPath) // #nosec G304 -- user-supplied mkdocs.yml
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", configPath, err)
+	}
+	var mk mkdocsFile
+	if err := yaml.Unmarshal(data, &mk); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", configPath, err)
+	}
+	if opts.Dir == "" {
+		opts.Dir = filepath.Dir(configPath)
+	}
+
+	r := &Result{Source: SourceMkDocs}
+	s := site{
+		title:       r.scalar("site_name", &mk.SiteName),
+		description: r.scalar("site_description", &mk.SiteDescription),
+		baseURL:     r.scalar("site_url", &mk.SiteURL),
+		repoURL:     r.scalar("repo_url", &mk.RepoURL),
+	}
+	if s.repoURL != "" && !strings.HasSuffix(s.repoURL, ".git") {
+		s.repoURL = strings.TrimRight(s.repoURL, "/") + ".git"
+	}
+	docsDir := r.scalar("docs_dir", &mk.DocsDir)
+	if docsDir == "" {
+		docsDir = "docs"
+	}
+	docsDir = path.Clean(filepath.ToSlash(docsDir))
+	s.docsPaths = []string{docsDir}
+
+	if r.scalar("edit_uri", &mk.EditURI) != "" {
+		r.unsupported("edit_uri: DocBuilder derives edit links from the forge; the custom path is not kept")
+	}
+	if r.scalar("copyright", &mk.Copyright) != "" {
+		r.unsupported("copyright: the Relearn theme has no footer text setting; add it with a theme override")
+	}
+	s.themeVariant = r.mkdocsTheme(&mk.Theme)
+	r.namedEntries("plugins", &mk.Plugins, mkdocsPlugins)
+	r.namedEntries("markdown_extensions", &mk.MarkdownExtensions, mkdocsExtensions)
+	if !isEmpty(&mk.Extra) {
+		r.unsupported("extra: template variables are not carried over; move the ones the site needs to hugo.params")
+	}
+	if !isEmpty(&mk.ExtraCSS) || !isEmpty(&mk.ExtraJavaScript) {
+		r.unsupported("extra_css/extra_javascript: custom assets are not carried over")
+	}
+
+	pages := pageSet{}
+	if !isEmpty(&mk.Nav) {
+		nav := &mkdocsNav{r: r, root: opts.Dir, docsDir: docsDir, pages: pages, listed: map[string]bool{}}
+		nav.walk(&mk.Nav, docsDir, "nav")
+		s.shortcuts = nav.shortcuts
+		if err := nav.reportUnlisted(); err != nil {
+			return nil, err
+		}
+	}
+	r.Pages = pages.list()
+	if err := r.finish(opts, s); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// scalar returns the value of a plain scalar setting. Tagged values such as !ENV are
+// reported, since they cannot be resolved here.
+func (r *Result) scalar(key string, n *yaml.Node) string {
+	if isEmpty(n) {
+		return ""
+	}
+	if n.Kind != yaml.ScalarNode || strings.HasPrefix(n.Tag, "!") && !strings.HasPrefix(n.Tag, "!!") {
+		r.unsupported("%s: computed value (%s) is not translated; set it in the generated configuration", key, n.Tag)
+		return ""
+	}
+	return strings.TrimSpace(n.Value)
+}
+
+// mkdocsTheme reports theme settings and returns the Relearn variants matching the
+// Material palette, or nil to keep the default light/dark switch.
+func (r *Result) mkdocsTheme(n *yaml.Node) []any {
+	if isEmpty(n) {
+		return nil
+	}
+	name := n.Value
+	var theme map[string]yaml.Node
+	if n.Kind == yaml.MappingNode {
+		if err := n.Decode(&theme); err != nil {
+			r.unsupported("theme: %v", err)
+			return nil
+		}
+		nameNode := theme["name"]
+		name = nameNode.Value
+	}
+	if name != "" {
+		r.unsupported("theme %q: DocBuilder renders with the Relearn theme; only the color scheme is translated", name)
+	}
+	for _, key := range []string{"logo", "favicon", "custom_dir", "features", "font", "icon", "language"} {
+		if v, ok := theme[key]; ok && !isEmpty(&v) {
+			r.unsupported("theme.%s is not translated", key)
+		}
+	}
+	palette, ok := theme["palette"]
+	if !ok {
+		return nil
+	}
+	var schemes []string
+	collect := func(p *yaml.Node) {
+		var entry struct {
+			Scheme string `yaml:"scheme"`
+		}
+		if p.Decode(&entry) == nil && entry.Scheme != "" {
+			schemes = append(schemes, entry.Scheme)
+		}
+	}
+	if palette.Kind == yaml.SequenceNode {
+		for _, p := range palette.Content {
+			collect(p)
+		}
+	} else {
+		collect(&palette)
+	}
+	hasDark := slices.Contains(schemes, "slate")
+	hasLight := slices.ContainsFunc(schemes, func(s string) bool { return s != "slate" })
+	switch {
+	case hasDark && !hasLight:
+		return []any{"zen-dark"}
+	case hasLight && !hasDark:
+		return []any{"zen-light"}
+	}
+	return nil
+}
+
+// namedEntries reports the entries of a plugins or markdown_extensions list that
+// DocBuilder does not cover. Entries are names or single-key mappings with options.
+func (r *Result) namedEntries(key string, n *yaml.Node, supported []string) {
+	if n.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range n.Content {
+		name := item.Value
+		if item.Kind == yaml.MappingNode && len(item.Content) > 0 {
+			name = item.Content[0].Value
+		}
+		if name != "" && !slices.Contains(supported, name) {
+			r.unsupported("%s: %s is not supported", key, name)
+		}
+	}
+}
+
+func isEmpty(n *yaml.Node) bool {
+	return n.Kind == 0 || n.Tag == "!!null"
+}
+
+// mkdocsNav translates a nav tree into page weights and titles. DocBuilder builds its
+// navigation from directories, so a group becomes the index of the directory holding
+// its pages.
+type mkdocsNav struct {
+	r         *Result
+	root      string
+	docsDir   string
+	pages     pageSet
+	listed    map[string]bool
+	shortcuts []config.Menu
+}
+
+// walk handles the items of one nav level and returns the pages it references.
+func (m *mkdocsNav) walk(n *yaml.Node, parentDir, where string) []string {
+	if n.Kind != yaml.SequenceNode {
+		m.r.unsupported("%s: expected a list of entries", where)
+		return nil
+	}
+	var referenced []string
+	for i, item := range n.Content {
+		weight := i + 1
+		switch item.Kind {
+		case yaml.ScalarNode:
+			if p := m.page(item.Value, "", weight); p != "" {
+				referenced = append(referenced, p)
+			}
+		case yaml.MappingNode:
+			if len(item.Content) != 2 {
+				m.r.unsupported("%s: entry %d must have exactly one title", where, weight)
+				continue
+			}
+			title, value := item.Content[0].Value, item.Content[1]
+			switch value.Kind {
+			case yaml.ScalarNode:
+				if isURL(value.Value) {
+					m.shortcuts = append(m.shortcuts, config.Menu{Name: title, URL: value.Value, Weight: len(m.shortcuts) + 1})
+					continue
+				}
+				if p := m.page(value.Value, title, weight); p != "" {
+					referenced = append(referenced, p)
+				}
+			case yaml.SequenceNode:
+				referenced = append(referenced, m.group(value, title, weight, parentDir, where)...)
+			}
+		}
+	}
+	return referenced
+}
+
+// group translates a nav section. Its pages must share a directory below the parent's,
+// whose index then carries the section title and weight.
+func (m *mkdocsNav) group(n *yaml.Node, title string, weight int, parentDir, where string) []string {
+	children := m.walk(n, parentDir, where+" > "+title)
+	dir := commonDir(children)
+	switch {
+	case len(children) == 0:
+	case dir == "" || dir == parentDir || !strings.HasPrefix(dir, parentDir+"/"):
+		m.r.unsupported("nav section %q does not match a directory; DocBuilder builds the navigation from directories, so its pages stay in their own folders", title)
+	default:
+		index, exists := sectionIndex(m.root, dir)
+		m.pages.set(index, "weight", weight)
+		m.pages.set(index, "linkTitle", title)
+		if !exists {
+			m.pages.get(index).Create = true
+			m.pages.set(index, "title", title)
+		}
+		return append(children, index)
+	}
+	return children
+}
+
+// page records the weight (and nav title) of a page given relative to the docs dir.
+func (m *mkdocsNav) page(rel, title string, weight int) string {
+	p := path.Join(m.docsDir, rel)
+	if _, err := os.Stat(filepath.Join(m.root, filepath.FromSlash(p))); err != nil {
+		m.r.unsupported("nav entry %q: page not found", rel)
+		return ""
+	}
+	m.listed[p] = true
+	m.pages.set(p, "weight", weight)
+	if title != "" {
+		m.pages.set(p, "linkTitle", title)
+	}
+	return p
+}
+
+// reportUnlisted reports pages that MkDocs hides from the nav but DocBuilder shows.
+func (m *mkdocsNav) reportUnlisted() error {
+	files, err := markdownFiles(m.root, m.docsDir, ".md")
+	if err != nil {
+		return fmt.Errorf("list pages: %w", err)
+	}
+	unlisted := 0
+	for _, f := range files {
+		if !m.listed[f] && !isIndexPage(f) {
+			unlisted++
+		}
+	}
+	if unlisted > 0 {
+		m.r.unsupported("%d page(s) are not in nav; DocBuilder lists every page (set `hidden: true` in their front matter to hide them)", unlisted)
+	}
+	return nil
+}
+
+// commonDir returns the deepest directory containing all pages, treating a section
+// index as part of its own directory.
+func commonDir(pages []string) string {
+	if len(pages) == 0 {
+		return ""
+	}
+	dir := path.Dir(pages[0])
+	for _, p := range pages[1:] {
+		for dir != "." && p != dir && !strings.HasPrefix(p, dir+"/") {
+			dir = path.Dir(dir)
+		}
+	}
+	return dir
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
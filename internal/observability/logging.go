@@ -7,8 +7,9 @@ import (
 
 // LogContext holds structured logging context information.
 type LogContext struct {
-	BuildID string
-	Stage   string
+	BuildID   string
+	Stage     string
+	RequestID string
 }
 
 // contextKey is used for context values.
@@ -23,6 +24,23 @@ func WithBuildID(ctx context.Context, buildID string) context.Context {
 	return context.WithValue(ctx, logContextKey, lc)
 }
 
+// BuildID returns the build ID stored in the context, or "".
+func BuildID(ctx context.Context) string {
+	return extractLogContext(ctx).BuildID
+}
+
+// WithRequestID adds an HTTP request ID to the context.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	lc := extractLogContext(ctx)
+	lc.RequestID = requestID
+	return context.WithValue(ctx, logContextKey, lc)
+}
+
+// RequestID returns the request ID stored in the context, or "".
+func RequestID(ctx context.Context) string {
+	return extractLogContext(ctx).RequestID
+}
+
 // WithStage adds a stage name to the context.
 func WithStage(ctx context.Context, stage string) context.Context {
 	lc := extractLogContext(ctx)
@@ -49,10 +67,55 @@ func getLogAttrs(ctx context.Context) []slog.Attr {
 	if lc.Stage != "" {
 		attrs = append(attrs, slog.String("stage", lc.Stage))
 	}
+	if lc.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", lc.RequestID))
+	}
 
 	return attrs
 }
 
+// ContextHandler adds the build ID, stage and request ID stored in the context to every
+// record logged with a context (slog.InfoContext, Logger.Log, ...). Attributes already
+// present on the record are not repeated.
+type ContextHandler struct {
+	next slog.Handler
+}
+
+// NewContextHandler wraps a handler with context correlation attributes.
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{next: next}
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := getLogAttrs(ctx)
+	if len(attrs) > 0 {
+		r = r.Clone()
+		present := make(map[string]bool, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			present[a.Key] = true
+			return true
+		})
+		for _, a := range attrs {
+			if !present[a.Key] {
+				r.AddAttrs(a)
+			}
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name)}
+}
+
 // InfoContext logs an info message with context information.
 func InfoContext(ctx context.Context, msg string, attrs ...slog.Attr) {
 	logAttrs(ctx, slog.LevelInfo, msg, attrs)
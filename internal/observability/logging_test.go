@@ -3,6 +3,7 @@ package observability
 import (
 	"bytes"
 	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -187,3 +188,24 @@ func TestGetLogAttrsEmptyContext(t *testing.T) {
 		t.Errorf("expected 0 attrs, got %d", len(attrs))
 	}
 }
+
+func TestContextHandlerAddsCorrelationIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewContextHandler(slog.NewTextHandler(&buf, nil)))
+
+	ctx := WithRequestID(WithBuildID(t.Context(), "build-7"), "req-9")
+	logger.InfoContext(ctx, "hello")
+	// Attributes already on the record are not repeated.
+	logger.InfoContext(ctx, "again", slog.String("build.id", "build-7"))
+
+	output := buf.String()
+	if !strings.Contains(output, "msg=hello build.id=build-7 request_id=req-9") {
+		t.Errorf("missing correlation attributes:\n%s", output)
+	}
+	if strings.Count(output, "build.id=") != 2 {
+		t.Errorf("expected one build.id per record:\n%s", output)
+	}
+	if RequestID(ctx) != "req-9" {
+		t.Errorf("RequestID() = %q", RequestID(ctx))
+	}
+}
@@ -0,0 +1,52 @@
+package onboard
+
+import (
+	"net/url"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// RepositoryAuth returns the credentials for cloning repoURL: those of a configured
+// repository with the same URL, else those of a forge on the same host. It returns nil
+// when neither matches, and the repository is cloned anonymously.
+func RepositoryAuth(cfg *config.Config, repoURL string) *config.AuthConfig {
+	if cfg == nil {
+		return nil
+	}
+	for _, repo := range cfg.Repositories {
+		if repo.URL == repoURL {
+			return repo.Auth
+		}
+	}
+	host := urlHost(repoURL)
+	if host == "" {
+		return nil
+	}
+	for _, forge := range cfg.Forges {
+		if forge == nil || forge.Auth == nil {
+			continue
+		}
+		if urlHost(forge.BaseURL) == host || urlHost(forge.APIURL) == host {
+			return forge.Auth
+		}
+	}
+	return nil
+}
+
+// urlHost returns the lowercase host of an http(s)/ssh URL or an scp-like
+// "git@host:owner/repo.git" address.
+func urlHost(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "://") {
+		if u, err := url.Parse(raw); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+		return ""
+	}
+	if _, after, ok := strings.Cut(raw, "@"); ok {
+		host, _, _ := strings.Cut(after, ":")
+		return strings.ToLower(host)
+	}
+	return ""
+}
@@ -0,0 +1,286 @@
+// Package onboard qualifies candidate repositories before they are added to a
+// documentation portal. Analyze runs a fixed checklist against a working copy (docs
+// path, README, repository-local .docbuilder.yaml, lint) and scores the result;
+// CheckRemote clones a repository first.
+package onboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/git"
+	"git.home.luguber.info/inful/docbuilder/internal/lint"
+)
+
+// ConfigFile is the repository-local DocBuilder configuration.
+const ConfigFile = ".docbuilder.yaml"
+
+// defaultDocsPath is checked when neither the options nor .docbuilder.yaml name docs paths.
+const defaultDocsPath = "docs"
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn" // counts half of the check's weight
+	StatusFail Status = "fail"
+)
+
+// Check identifiers, in checklist order.
+const (
+	CheckDocsPath = "docs_path"
+	CheckReadme   = "readme"
+	CheckConfig   = "docbuilder_config"
+	CheckLint     = "lint"
+)
+
+// checkWeights are the points of each check; they add up to 100.
+var checkWeights = map[string]int{
+	CheckDocsPath: 35,
+	CheckReadme:   15,
+	CheckConfig:   20,
+	CheckLint:     30,
+}
+
+// Check is one item of the checklist.
+type Check struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status Status `json:"status"`
+	Weight int    `json:"weight"`
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"` // how to fix a failed or warned check
+}
+
+// Report is the scored checklist of a repository.
+type Report struct {
+	Repository string    `json:"repository"` // clone URL or local directory
+	Branch     string    `json:"branch,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	DocsPaths  []string  `json:"docs_paths"`
+	Score      int       `json:"score"` // 0-100
+	Ready      bool      `json:"ready"` // no check failed
+	Checks     []Check   `json:"checks"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// Options controls Analyze.
+type Options struct {
+	// DocsPaths overrides the docs paths (default: the paths of the first repository in
+	// .docbuilder.yaml, else "docs").
+	DocsPaths []string
+	// Linter lints the docs paths (default: the built-in rules).
+	Linter *lint.Linter
+}
+
+// Analyze runs the checklist against the working copy in dir.
+func Analyze(dir string, opts Options) (*Report, error) {
+	if st, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("repository directory: %w", err)
+	} else if !st.IsDir() {
+		return nil, fmt.Errorf("repository directory: %s is not a directory", dir)
+	}
+
+	configCheck, repoCfg := checkConfig(dir)
+	paths := opts.DocsPaths
+	if len(paths) == 0 && repoCfg != nil && len(repoCfg.Repositories) > 0 {
+		paths = repoCfg.Repositories[0].Paths
+	}
+	if len(paths) == 0 {
+		paths = []string{defaultDocsPath}
+	}
+
+	docsCheck, docsDirs := checkDocsPaths(dir, paths)
+	report := &Report{
+		Repository: dir,
+		DocsPaths:  paths,
+		Checks: []Check{
+			docsCheck,
+			checkReadme(dir),
+			configCheck,
+			checkLint(docsDirs, opts.Linter),
+		},
+		CheckedAt: time.Now().UTC(),
+	}
+	report.score()
+	return report, nil
+}
+
+// CheckRemote clones repo into a temporary directory below workDir and analyzes it.
+// The clone is removed afterwards.
+func CheckRemote(repo config.Repository, workDir string, opts Options) (*Report, error) {
+	if repo.Name == "" {
+		repo.Name = "candidate"
+	}
+	if len(opts.DocsPaths) == 0 && len(repo.Paths) > 0 {
+		opts.DocsPaths = repo.Paths
+	}
+	if err := os.MkdirAll(workDir, 0o750); err != nil {
+		return nil, fmt.Errorf("onboard work directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(workDir, "onboard-*")
+	if err != nil {
+		return nil, fmt.Errorf("onboard work directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	clone, err := git.NewClient(tmp).CloneRepoWithMetadata(repo)
+	if err != nil {
+		return nil, err
+	}
+	report, err := Analyze(clone.Path, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.Repository = repo.URL
+	report.Branch = repo.Branch
+	report.Commit = clone.CommitSHA
+	return report, nil
+}
+
+// score sums the earned weights and decides readiness.
+func (r *Report) score() {
+	r.Score, r.Ready = 0, true
+	for _, c := range r.Checks {
+		switch c.Status {
+		case StatusPass:
+			r.Score += c.Weight
+		case StatusWarn:
+			r.Score += c.Weight / 2
+		case StatusFail:
+			r.Ready = false
+		}
+	}
+}
+
+func newCheck(id, title string, status Status, detail, hint string) Check {
+	return Check{ID: id, Title: title, Status: status, Weight: checkWeights[id], Detail: detail, Hint: hint}
+}
+
+// checkDocsPaths requires every docs path to be a directory holding markdown, and returns
+// the directories that do.
+func checkDocsPaths(dir string, paths []string) (Check, []string) {
+	const title = "Documentation directory"
+	var found, missing, empty []string
+	for _, p := range paths {
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			missing = append(missing, p)
+			continue
+		}
+		full := filepath.Join(dir, filepath.FromSlash(p))
+		if st, err := os.Stat(full); err != nil || !st.IsDir() {
+			missing = append(missing, p)
+			continue
+		}
+		if countDocs(full) == 0 {
+			empty = append(empty, p)
+			continue
+		}
+		found = append(found, full)
+	}
+	switch {
+	case len(missing) > 0:
+		return newCheck(CheckDocsPath, title, StatusFail,
+			"missing docs path: "+strings.Join(missing, ", "),
+			"add the docs directory, e.g. with `docbuilder scaffold repo`"), found
+	case len(empty) > 0:
+		return newCheck(CheckDocsPath, title, StatusFail,
+			"no markdown files in: "+strings.Join(empty, ", "),
+			"add at least one markdown page to the docs directory"), found
+	default:
+		return newCheck(CheckDocsPath, title, StatusPass,
+			"found "+strings.Join(paths, ", "), ""), found
+	}
+}
+
+// countDocs counts the markdown files below dir.
+func countDocs(dir string) int {
+	n := 0
+	_ = filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && lint.IsDocFile(p) {
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
+// checkReadme requires a README at the repository root.
+func checkReadme(dir string) Check {
+	const title = "README"
+	entries, err := os.ReadDir(dir)
+	if err == nil {
+		for _, e := range entries {
+			name := strings.ToLower(e.Name())
+			if !e.IsDir() && (name == "readme" || strings.HasPrefix(name, "readme.")) {
+				return newCheck(CheckReadme, title, StatusPass, "found "+e.Name(), "")
+			}
+		}
+	}
+	return newCheck(CheckReadme, title, StatusFail, "no README at the repository root",
+		"add a README.md that describes the project and links to the docs")
+}
+
+// checkConfig loads .docbuilder.yaml. A missing file is a warning: the portal
+// configuration can describe the repository instead.
+func checkConfig(dir string) (Check, *config.Config) {
+	const title = "DocBuilder configuration"
+	path := filepath.Join(dir, ConfigFile)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return newCheck(CheckConfig, title, StatusWarn, ConfigFile+" not found",
+			"run `docbuilder scaffold repo` to generate it"), nil
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return newCheck(CheckConfig, title, StatusFail, ConfigFile+" is invalid: "+err.Error(),
+			"fix the configuration and check it with `docbuilder build -c "+ConfigFile+"`"), nil
+	}
+	return newCheck(CheckConfig, title, StatusPass, ConfigFile+" is valid", ""), cfg
+}
+
+// checkLint lints the docs directories: errors fail the check, warnings only warn.
+func checkLint(dirs []string, linter *lint.Linter) Check {
+	const title = "Lint"
+	if len(dirs) == 0 {
+		return newCheck(CheckLint, title, StatusFail, "no docs to lint", "")
+	}
+	if linter == nil {
+		linter = lint.NewLinter(&lint.Config{Format: "json"})
+	}
+	var files, errs, warnings int
+	var rules []string
+	for _, d := range dirs {
+		result, err := linter.LintPath(d)
+		if err != nil {
+			return newCheck(CheckLint, title, StatusFail, "lint failed: "+err.Error(), "")
+		}
+		files += result.FilesTotal
+		errs += result.ErrorCount()
+		warnings += result.WarningCount()
+		for _, issue := range result.Issues {
+			if issue.Severity >= lint.SeverityWarning && !slices.Contains(rules, issue.Rule) {
+				rules = append(rules, issue.Rule)
+			}
+		}
+	}
+	detail := fmt.Sprintf("%d files, %d errors, %d warnings", files, errs, warnings)
+	if len(rules) > 0 {
+		slices.Sort(rules)
+		detail += " (" + strings.Join(rules, ", ") + ")"
+	}
+	switch {
+	case errs > 0:
+		return newCheck(CheckLint, title, StatusFail, detail, "run `docbuilder lint --fix` in the repository")
+	case warnings > 0:
+		return newCheck(CheckLint, title, StatusWarn, detail, "run `docbuilder lint` in the repository")
+	default:
+		return newCheck(CheckLint, title, StatusPass, detail, "")
+	}
+}
@@ -0,0 +1,162 @@
+package onboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/lint"
+)
+
+const repoConfig = `version: "2.0"
+repositories:
+  - name: candidate
+    url: https://git.example.com/team/candidate.git
+    branch: main
+    paths: [documentation]
+hugo:
+  title: Candidate
+`
+
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+	return dir
+}
+
+// fixDocs applies the lint fixes (uid, fingerprint) that a prepared repository carries.
+func fixDocs(t *testing.T, dir string) {
+	t.Helper()
+	_, err := lint.NewFixer(lint.NewLinter(&lint.Config{Format: "json"}), false, true).Fix(dir)
+	require.NoError(t, err)
+}
+
+func checkByID(t *testing.T, report *Report, id string) Check {
+	t.Helper()
+	for _, c := range report.Checks {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("check %s missing from report", id)
+	return Check{}
+}
+
+func TestAnalyze_Ready(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"README.md":                    "# Candidate\n",
+		ConfigFile:                     repoConfig,
+		"documentation/index.md":       "# Candidate\n\nIntroduction.\n",
+		"documentation/guide/setup.md": "# Setup\n\nSteps.\n",
+	})
+	fixDocs(t, filepath.Join(dir, "documentation"))
+
+	report, err := Analyze(dir, Options{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"documentation"}, report.DocsPaths, "docs paths come from .docbuilder.yaml")
+	require.True(t, report.Ready)
+	require.Equal(t, 100, report.Score)
+	require.Len(t, report.Checks, 4)
+	for _, c := range report.Checks {
+		require.Equal(t, StatusPass, c.Status, "%s: %s", c.ID, c.Detail)
+	}
+}
+
+func TestAnalyze_MissingConfigWarns(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"readme":        "Candidate\n",
+		"docs/index.md": "# Candidate\n\nIntroduction.\n",
+	})
+	fixDocs(t, filepath.Join(dir, "docs"))
+
+	report, err := Analyze(dir, Options{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"docs"}, report.DocsPaths)
+	require.True(t, report.Ready, "a missing .docbuilder.yaml is only a warning")
+	require.Equal(t, StatusWarn, checkByID(t, report, CheckConfig).Status)
+	require.Equal(t, 90, report.Score)
+}
+
+func TestAnalyze_Failures(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		ConfigFile:        "version: [\n",
+		"guide/notes.txt": "not markdown",
+	})
+
+	report, err := Analyze(dir, Options{DocsPaths: []string{"guide", "../outside"}})
+	require.NoError(t, err)
+
+	require.False(t, report.Ready)
+	require.Equal(t, 0, report.Score)
+	docs := checkByID(t, report, CheckDocsPath)
+	require.Equal(t, StatusFail, docs.Status)
+	require.Contains(t, docs.Detail, "../outside")
+	require.Equal(t, StatusFail, checkByID(t, report, CheckReadme).Status)
+	require.Equal(t, StatusFail, checkByID(t, report, CheckConfig).Status)
+	require.Equal(t, StatusFail, checkByID(t, report, CheckLint).Status, "there are no docs to lint")
+	require.NotEmpty(t, checkByID(t, report, CheckReadme).Hint)
+}
+
+func TestAnalyze_LintErrorsFail(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"README.md":     "# Candidate\n",
+		"docs/index.md": "# Candidate\n\nIntroduction.\n",
+	})
+
+	report, err := Analyze(dir, Options{})
+	require.NoError(t, err)
+
+	lintCheck := checkByID(t, report, CheckLint)
+	require.Equal(t, StatusFail, lintCheck.Status)
+	require.Contains(t, lintCheck.Detail, "frontmatter-uid")
+	require.False(t, report.Ready)
+	require.Equal(t, 60, report.Score)
+}
+
+func TestAnalyze_EmptyDocsPath(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"README.md":      "# Candidate\n",
+		"docs/notes.txt": "not markdown",
+	})
+
+	report, err := Analyze(dir, Options{})
+	require.NoError(t, err)
+
+	docs := checkByID(t, report, CheckDocsPath)
+	require.Equal(t, StatusFail, docs.Status)
+	require.Contains(t, docs.Detail, "no markdown files")
+}
+
+func TestAnalyze_NotADirectory(t *testing.T) {
+	_, err := Analyze(filepath.Join(t.TempDir(), "missing"), Options{})
+	require.Error(t, err)
+}
+
+func TestRepositoryAuth(t *testing.T) {
+	repoAuth := &config.AuthConfig{Type: config.AuthTypeToken, Token: "repo"}
+	forgeAuth := &config.AuthConfig{Type: config.AuthTypeToken, Token: "forge"}
+	cfg := &config.Config{
+		Repositories: []config.Repository{{Name: "docs", URL: "https://git.example.com/team/docs.git", Auth: repoAuth}},
+		Forges: []*config.ForgeConfig{
+			{Name: "anon", BaseURL: "https://public.example.com"},
+			{Name: "internal", APIURL: "https://GIT.example.com/api/v1", Auth: forgeAuth},
+		},
+	}
+
+	require.Same(t, repoAuth, RepositoryAuth(cfg, "https://git.example.com/team/docs.git"))
+	require.Same(t, forgeAuth, RepositoryAuth(cfg, "https://git.example.com/team/other.git"))
+	require.Same(t, forgeAuth, RepositoryAuth(cfg, "git@git.example.com:team/other.git"))
+	require.Nil(t, RepositoryAuth(cfg, "https://public.example.com/team/other.git"))
+	require.Nil(t, RepositoryAuth(cfg, "https://elsewhere.example.com/x.git"))
+	require.Nil(t, RepositoryAuth(nil, "https://git.example.com/team/docs.git"))
+}
@@ -0,0 +1,80 @@
+// Package output abstracts the storage backing the generated site.
+//
+// The Hugo generator prepares, promotes and verifies its output through a Storage, and the
+// docs server reads the rendered site through the same Storage. Only the local filesystem
+// is implemented today; remote backends (S3, NFS gateways) implement the same interface so
+// callers never need backend-specific path handling.
+package output
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Storage is the set of operations docbuilder performs on its output location.
+// Paths are backend locations as produced by the generator (for example "site/public").
+type Storage interface {
+	// Name identifies the backend in logs.
+	Name() string
+	// MkdirAll creates a directory (and any parents) for build output.
+	MkdirAll(path string) error
+	// Stat reports information about a file or directory.
+	Stat(path string) (fs.FileInfo, error)
+	// Rename moves a file or directory to a new location.
+	Rename(from, to string) error
+	// RemoveAll deletes a path and everything below it; missing paths are not an error.
+	RemoveAll(path string) error
+	// CountFiles returns the number of regular files below a directory.
+	CountFiles(path string) (int, error)
+	// FileSystem exposes a directory for serving over HTTP.
+	FileSystem(path string) http.FileSystem
+}
+
+// Local stores output on the local filesystem.
+type Local struct{}
+
+// NewLocal returns the local filesystem backend.
+func NewLocal() Local { return Local{} }
+
+// Or returns s, or the local backend when s is nil.
+func Or(s Storage) Storage {
+	if s == nil {
+		return Local{}
+	}
+	return s
+}
+
+// Name implements Storage.
+func (Local) Name() string { return "local" }
+
+// MkdirAll implements Storage.
+func (Local) MkdirAll(path string) error { return os.MkdirAll(path, 0o750) }
+
+// Stat implements Storage.
+func (Local) Stat(path string) (fs.FileInfo, error) { return os.Stat(path) }
+
+// Rename implements Storage.
+func (Local) Rename(from, to string) error { return os.Rename(from, to) }
+
+// RemoveAll implements Storage.
+func (Local) RemoveAll(path string) error { return os.RemoveAll(path) }
+
+// CountFiles implements Storage.
+func (Local) CountFiles(path string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// FileSystem implements Storage.
+func (Local) FileSystem(path string) http.FileSystem { return http.Dir(path) }
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal_FileOperations(t *testing.T) {
+	root := t.TempDir()
+	s := Or(nil)
+	if s.Name() != "local" {
+		t.Fatalf("expected local default backend, got %q", s.Name())
+	}
+
+	stage := filepath.Join(root, "stage")
+	if err := s.MkdirAll(filepath.Join(stage, "public", "docs")); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, f := range []string{"public/index.html", "public/docs/index.html"} {
+		if err := os.WriteFile(filepath.Join(stage, f), []byte("<p>"+f+"</p>"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", f, err)
+		}
+	}
+
+	out := filepath.Join(root, "site")
+	if err := s.Rename(stage, out); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if _, err := s.Stat(stage); !os.IsNotExist(err) {
+		t.Fatalf("expected staging to be gone, got %v", err)
+	}
+
+	count, err := s.CountFiles(filepath.Join(out, "public"))
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 files, got %d (err=%v)", count, err)
+	}
+
+	f, err := s.FileSystem(filepath.Join(out, "public")).Open("/docs/index.html")
+	if err != nil {
+		t.Fatalf("open via http filesystem: %v", err)
+	}
+	body, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(body) != "<p>public/docs/index.html</p>" {
+		t.Fatalf("unexpected body %q", body)
+	}
+
+	if err := s.RemoveAll(out); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := s.RemoveAll(out); err != nil {
+		t.Fatalf("removing a missing path should succeed: %v", err)
+	}
+}
@@ -8,17 +8,15 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
-
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/daemon"
 	"git.home.luguber.info/inful/docbuilder/internal/docs"
 	"git.home.luguber.info/inful/docbuilder/internal/hugo"
 	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
+	"git.home.luguber.info/inful/docbuilder/internal/watch"
 )
 
 // buildStatus tracks the current build state for error display.
@@ -70,10 +68,11 @@ func StartLocalPreview(ctx context.Context, cfg *config.Config, port int, tempOu
 	}
 	defer func() { _ = watcher.Close() }()
 
-	rebuildReq, trigger := setupRebuildDebouncer()
-	startRebuildWorker(ctx, cfg, absDocs, previewDaemon, buildStat, rebuildReq)
+	rebuildReq := make(chan struct{}, 1)
+	site := newSiteTracker(filepath.Join(cfg.Output.Directory, "public"))
+	startRebuildWorker(ctx, cfg, absDocs, previewDaemon, buildStat, site, rebuildReq)
 
-	return runPreviewLoop(ctx, watcher, trigger, rebuildReq, httpServer, tempOutputDir)
+	return runPreviewLoop(ctx, watcher, rebuildReq, httpServer, tempOutputDir)
 }
 
 // validateAndResolveDocsDir validates and resolves the absolute path of the docs directory.
@@ -121,44 +120,21 @@ func startHTTPServer(ctx context.Context, cfg *config.Config, previewDaemon *dae
 	return httpServer, nil
 }
 
-// setupFileWatcher creates and configures the filesystem watcher.
-func setupFileWatcher(absDocs string) (*fsnotify.Watcher, error) {
-	watcher, err := fsnotify.NewWatcher()
+// setupFileWatcher creates a debounced watcher for the docs directory.
+func setupFileWatcher(absDocs string) (*watch.Watcher, error) {
+	watcher, err := watch.New(watch.Options{})
 	if err != nil {
-		return nil, fmt.Errorf("fsnotify: %w", err)
+		return nil, err
 	}
-	if err := addDirsRecursive(watcher, absDocs); err != nil {
+	if err := watcher.Add(absDocs); err != nil {
 		_ = watcher.Close()
 		return nil, err
 	}
 	return watcher, nil
 }
 
-// setupRebuildDebouncer creates rebuild channel and trigger function with debouncing.
-func setupRebuildDebouncer() (chan struct{}, func()) {
-	var mu sync.Mutex
-	var timer *time.Timer
-	rebuildReq := make(chan struct{}, 1)
-
-	trigger := func() {
-		mu.Lock()
-		defer mu.Unlock()
-		if timer != nil {
-			timer.Stop()
-		}
-		timer = time.AfterFunc(300*time.Millisecond, func() {
-			select {
-			case rebuildReq <- struct{}{}:
-			default:
-			}
-		})
-	}
-
-	return rebuildReq, trigger
-}
-
 // startRebuildWorker starts background goroutine to process rebuild requests.
-func startRebuildWorker(ctx context.Context, cfg *config.Config, absDocs string, previewDaemon *daemon.Daemon, buildStat *buildStatus, rebuildReq chan struct{}) {
+func startRebuildWorker(ctx context.Context, cfg *config.Config, absDocs string, previewDaemon *daemon.Daemon, buildStat *buildStatus, site *siteTracker, rebuildReq chan struct{}) {
 	var mu sync.Mutex
 	running := false
 	pending := false
@@ -181,7 +157,7 @@ func startRebuildWorker(ctx context.Context, cfg *config.Config, absDocs string,
 				running = true
 				mu.Unlock()
 
-				processRebuild(ctx, cfg, absDocs, previewDaemon, buildStat)
+				processRebuild(ctx, cfg, absDocs, previewDaemon, buildStat, site)
 
 				mu.Lock()
 				running = false
@@ -200,9 +176,11 @@ func startRebuildWorker(ctx context.Context, cfg *config.Config, absDocs string,
 	}()
 }
 
-// processRebuild performs the actual rebuild and notifies browsers.
-func processRebuild(ctx context.Context, cfg *config.Config, absDocs string, previewDaemon *daemon.Daemon, buildStat *buildStatus) {
+// processRebuild performs the actual rebuild and notifies browsers of the changed pages.
+// Recovering from a failed build reloads every page, since browsers may show the error page.
+func processRebuild(ctx context.Context, cfg *config.Config, absDocs string, previewDaemon *daemon.Daemon, buildStat *buildStatus, site *siteTracker) {
 	slog.Info("Change detected; rebuilding site")
+	hadError, _, _ := buildStat.GetStatus()
 	if err := buildFromLocal(ctx, cfg, absDocs); err != nil {
 		slog.Warn("rebuild failed", "error", err)
 		buildStat.setError(err)
@@ -211,30 +189,26 @@ func processRebuild(ctx context.Context, cfg *config.Config, absDocs string, pre
 		}
 	} else {
 		buildStat.setSuccess()
+		paths := site.changes()
+		if hadError {
+			paths = nil
+		}
 		if lr := previewDaemon.LiveReloadHub(); lr != nil {
-			lr.Broadcast(strconv.FormatInt(time.Now().UnixNano(), 10))
+			lr.BroadcastChanges(strconv.FormatInt(time.Now().UnixNano(), 10), paths)
 		}
 	}
 }
 
-// runPreviewLoop handles filesystem events and graceful shutdown.
-func runPreviewLoop(ctx context.Context, watcher *fsnotify.Watcher, trigger func(), rebuildReq chan struct{}, httpServer *httpserver.Server, tempOutputDir string) error {
-	for {
+// runPreviewLoop requests a rebuild for each debounced change and shuts down when ctx ends.
+func runPreviewLoop(ctx context.Context, watcher *watch.Watcher, rebuildReq chan struct{}, httpServer *httpserver.Server, tempOutputDir string) error {
+	_ = watcher.Run(ctx, func(change watch.Change) {
+		slog.Debug("Docs changed", "files", len(change.Paths))
 		select {
-		case <-ctx.Done():
-			return handleShutdown(ctx, httpServer, rebuildReq, tempOutputDir)
-		case ev, ok := <-watcher.Events:
-			if !ok {
-				return nil
-			}
-			handleFileEvent(watcher, ev, trigger)
-		case err, ok := <-watcher.Errors:
-			if !ok {
-				return nil
-			}
-			slog.Warn("watcher error", "error", err)
+		case rebuildReq <- struct{}{}:
+		default:
 		}
-	}
+	})
+	return handleShutdown(ctx, httpServer, rebuildReq, tempOutputDir)
 }
 
 // handleShutdown performs graceful shutdown cleanup.
@@ -264,61 +238,6 @@ func handleShutdown(ctx context.Context, httpServer *httpserver.Server, rebuildR
 	return nil
 }
 
-// handleFileEvent processes a filesystem event and triggers rebuild if needed.
-func handleFileEvent(watcher *fsnotify.Watcher, ev fsnotify.Event, trigger func()) {
-	// Skip events for hidden files, swap files, and temp files
-	if shouldIgnoreEvent(ev.Name) {
-		return
-	}
-	if ev.Op&fsnotify.Create == fsnotify.Create {
-		if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
-			_ = addDirsRecursive(watcher, ev.Name)
-		}
-	}
-	slog.Debug("File change detected", "path", ev.Name, "op", ev.Op.String())
-	trigger()
-}
-
-func addDirsRecursive(w *fsnotify.Watcher, root string) error {
-	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			if err := w.Add(path); err != nil {
-				slog.Warn("watch add failed", "dir", path, "error", err)
-			}
-		}
-		return nil
-	})
-}
-
-// shouldIgnoreEvent returns true for filesystem events that should not trigger rebuilds.
-func shouldIgnoreEvent(path string) bool {
-	base := filepath.Base(path)
-
-	// Ignore hidden files
-	if strings.HasPrefix(base, ".") {
-		return true
-	}
-
-	// Ignore editor temp/swap files
-	if strings.HasSuffix(base, "~") ||
-		strings.HasSuffix(base, ".swp") ||
-		strings.HasSuffix(base, ".swx") ||
-		strings.HasPrefix(base, ".#") ||
-		strings.HasPrefix(base, "#") && strings.HasSuffix(base, "#") {
-		return true
-	}
-
-	// Ignore common lock files
-	if base == ".DS_Store" || base == "Thumbs.db" {
-		return true
-	}
-
-	return false
-}
-
 func buildFromLocal(ctx context.Context, cfg *config.Config, docsPath string) error {
 	// Prepare discovery objects
 	repos := []config.Repository{{
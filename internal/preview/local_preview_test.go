@@ -30,11 +30,3 @@ func TestValidateAndResolveDocsDir_ReturnsAbsoluteDir(t *testing.T) {
 	require.NotEmpty(t, abs)
 	require.True(t, filepath.IsAbs(abs))
 }
-
-func TestShouldIgnoreEvent(t *testing.T) {
-	require.True(t, shouldIgnoreEvent("/tmp/.hidden.md"))
-	require.True(t, shouldIgnoreEvent("/tmp/#foo#"))
-	require.True(t, shouldIgnoreEvent("/tmp/foo.swp"))
-	require.True(t, shouldIgnoreEvent("/tmp/.DS_Store"))
-	require.False(t, shouldIgnoreEvent("/tmp/visible.md"))
-}
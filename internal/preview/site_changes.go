@@ -0,0 +1,96 @@
+package preview
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxScopedPaths caps the changed paths sent to LiveReload clients. Larger change sets
+// (e.g. a navigation change touching every page) are sent without paths, which makes
+// every client reload.
+const maxScopedPaths = 100
+
+// siteTracker remembers the rendered site between builds to report which URL paths changed.
+type siteTracker struct {
+	root string
+	last map[string]string // URL path -> content digest
+}
+
+func newSiteTracker(root string) *siteTracker {
+	return &siteTracker{root: root, last: snapshotSite(root)}
+}
+
+// changes snapshots the site and returns the URL paths added, modified or removed since
+// the previous call, sorted. It returns nil when the change set is too large to scope.
+func (t *siteTracker) changes() []string {
+	next := snapshotSite(t.root)
+	var changed []string
+	for p, digest := range next {
+		if t.last[p] != digest {
+			changed = append(changed, p)
+		}
+	}
+	for p := range t.last {
+		if _, ok := next[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	t.last = next
+	if len(changed) > maxScopedPaths {
+		return nil
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// snapshotSite digests every file below root, keyed by the URL path a browser requests.
+// Unreadable files are skipped; a missing root yields an empty snapshot.
+func snapshotSite(root string) map[string]string {
+	snap := map[string]string{}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		// #nosec G304 -- path comes from walking the rendered site directory
+		f, openErr := os.Open(path)
+		if openErr != nil {
+			return nil
+		}
+		defer func() { _ = f.Close() }()
+		h := sha256.New()
+		if _, copyErr := io.Copy(h, f); copyErr != nil {
+			return nil
+		}
+		snap[sitePath(rel)] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	return snap
+}
+
+// sitePath converts a root-relative file path to its URL path ("guide/index.html" -> "/guide/").
+func sitePath(rel string) string {
+	p := "/" + filepath.ToSlash(rel)
+	if p == "/index.html" || strings.HasSuffix(p, "/index.html") {
+		p = strings.TrimSuffix(p, "index.html")
+	}
+	return p
+}
+
+// renderedSiteRoot returns dir/public when it exists, otherwise dir.
+func renderedSiteRoot(dir string) string {
+	public := filepath.Join(dir, "public")
+	if st, err := os.Stat(public); err == nil && st.IsDir() {
+		return public
+	}
+	return dir
+}
@@ -0,0 +1,51 @@
+package preview
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeSiteFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestSiteTracker_ReportsChangedURLPaths(t *testing.T) {
+	root := t.TempDir()
+	writeSiteFile(t, root, "index.html", "home")
+	writeSiteFile(t, root, "guide/index.html", "guide")
+	writeSiteFile(t, root, "css/main.css", "body{}")
+	writeSiteFile(t, root, "old/index.html", "old")
+
+	site := newSiteTracker(root)
+	require.Empty(t, site.changes())
+
+	writeSiteFile(t, root, "guide/index.html", "guide v2")
+	writeSiteFile(t, root, "css/main.css", "body{color:red}")
+	writeSiteFile(t, root, "new/page.html", "new")
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "old")))
+
+	require.Equal(t, []string{"/css/main.css", "/guide/", "/new/page.html", "/old/"}, site.changes())
+	require.Empty(t, site.changes())
+}
+
+func TestSiteTracker_LargeChangeSetIsUnscoped(t *testing.T) {
+	root := t.TempDir()
+	site := newSiteTracker(root)
+	for i := range maxScopedPaths + 1 {
+		writeSiteFile(t, root, fmt.Sprintf("p/%d.html", i), "x")
+	}
+	require.Nil(t, site.changes())
+}
+
+func TestSitePath(t *testing.T) {
+	require.Equal(t, "/", sitePath("index.html"))
+	require.Equal(t, "/guide/", sitePath(filepath.Join("guide", "index.html")))
+	require.Equal(t, "/css/main.css", sitePath(filepath.Join("css", "main.css")))
+}
@@ -0,0 +1,63 @@
+package preview
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/daemon"
+	"git.home.luguber.info/inful/docbuilder/internal/server/httpserver"
+	"git.home.luguber.info/inful/docbuilder/internal/watch"
+)
+
+// ServeStatic serves the already-built site in cfg.Output.Directory until ctx is cancelled.
+// When watchDir is non-empty, changes below it notify connected browsers via LiveReload;
+// nothing is rebuilt.
+func ServeStatic(ctx context.Context, cfg *config.Config, watchDir string) error {
+	runtime := daemon.NewPreviewDaemon(cfg)
+	opts := httpserver.Options{}
+	var watcher *watch.Watcher
+	if watchDir != "" {
+		w, err := setupFileWatcher(watchDir)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = w.Close() }()
+		watcher = w
+		cfg.Build.LiveReload = true
+		opts.LiveReloadHub = runtime.LiveReloadHub()
+	}
+
+	httpServer := httpserver.New(cfg, runtime, opts)
+	if err := httpServer.StartStatic(ctx); err != nil {
+		return fmt.Errorf("failed to start HTTP server: %w", err)
+	}
+	slog.Info("Serving site", "docs_url", fmt.Sprintf("http://localhost:%d", cfg.Daemon.HTTP.DocsPort))
+
+	if watcher == nil {
+		<-ctx.Done()
+		return stopStaticServer(ctx, httpServer)
+	}
+
+	hub := runtime.LiveReloadHub()
+	site := newSiteTracker(renderedSiteRoot(cfg.Output.Directory))
+	_ = watcher.Run(ctx, func(watch.Change) {
+		paths := site.changes()
+		slog.Info("Change detected; reloading browsers", "paths", len(paths))
+		hub.BroadcastChanges(strconv.FormatInt(time.Now().UnixNano(), 10), paths)
+	})
+	return stopStaticServer(ctx, httpServer)
+}
+
+func stopStaticServer(ctx context.Context, httpServer *httpserver.Server) error {
+	slog.Info("Shutting down static server...")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Stop(shutdownCtx); err != nil {
+		slog.Warn("HTTP server shutdown error", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package proclimit runs external build processes (hugo, git) under the resource
+// limits of build.resources, so builds can share a host with other workloads.
+//
+// On Linux each process gets its own cgroup v2 group below the configured
+// cgroup_parent (or the daemon's own cgroup) with memory.max and cpu.weight set.
+// When no writable cgroup with the memory and cpu controllers is available, the
+// memory cap falls back to RLIMIT_DATA and the CPU weight is not applied. The nice
+// level is always set directly. Other platforms run processes unlimited.
+package proclimit
+
+import (
+	"bytes"
+	"os/exec"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// Run starts cmd under limits and waits for it to finish. A nil or empty limits runs
+// the command unchanged.
+func Run(cmd *exec.Cmd, limits *config.ResourceLimitsConfig) error {
+	if !limits.Enabled() {
+		return cmd.Run()
+	}
+	cleanup, err := start(cmd, limits)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return cmd.Wait()
+}
+
+// CombinedOutput is Run with stdout and stderr collected, like exec.Cmd.CombinedOutput.
+func CombinedOutput(cmd *exec.Cmd, limits *config.ResourceLimitsConfig) ([]byte, error) {
+	if !limits.Enabled() {
+		return cmd.CombinedOutput()
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := Run(cmd, limits)
+	return out.Bytes(), err
+}
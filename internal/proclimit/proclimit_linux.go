@@ -0,0 +1,176 @@
+//go:build linux
+
+package proclimit
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+)
+
+// cgroupRoot is the cgroup v2 mount point; replaced in tests.
+var cgroupRoot = "/sys/fs/cgroup"
+
+var (
+	cgroupSeq          atomic.Uint64
+	cgroupFallbackOnce sync.Once
+)
+
+func start(cmd *exec.Cmd, limits *config.ResourceLimitsConfig) (func(), error) {
+	cleanup := func() {}
+	cgroupDir, cgroupFD, err := createCgroup(limits)
+	if err != nil {
+		cgroupFallbackOnce.Do(func() {
+			slog.Warn("cgroup limits unavailable; using rlimits and nice only",
+				logfields.Error(err))
+		})
+	} else if cgroupDir != "" {
+		if cmd.SysProcAttr == nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{}
+		}
+		cmd.SysProcAttr.UseCgroupFD = true
+		cmd.SysProcAttr.CgroupFD = cgroupFD
+		cleanup = func() {
+			// The group is empty once the process exits; its descendants may linger briefly.
+			if rmErr := os.Remove(cgroupDir); rmErr != nil {
+				slog.Debug("Failed to remove process cgroup", logfields.Path(cgroupDir), logfields.Error(rmErr))
+			}
+		}
+	}
+
+	startErr := cmd.Start()
+	if cgroupFD >= 0 {
+		_ = unix.Close(cgroupFD)
+	}
+	if startErr != nil {
+		cleanup()
+		return nil, startErr
+	}
+
+	pid := cmd.Process.Pid
+	if cgroupDir == "" && limits.MaxMemoryBytes() > 0 {
+		// RLIMIT_RSS is not enforced by Linux; the data segment limit caps heap growth.
+		limit := uint64(limits.MaxMemoryBytes()) // #nosec G115 -- validated non-negative
+		if err := unix.Prlimit(pid, unix.RLIMIT_DATA, &unix.Rlimit{Cur: limit, Max: limit}, nil); err != nil {
+			slog.Warn("Failed to set process memory limit", slog.Int("pid", pid), logfields.Error(err))
+		}
+	}
+	if limits.Nice != 0 {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, pid, limits.Nice); err != nil {
+			slog.Warn("Failed to set process nice level", slog.Int("pid", pid), slog.Int("nice", limits.Nice), logfields.Error(err))
+		}
+	}
+	return cleanup, nil
+}
+
+// createCgroup creates a cgroup v2 group for one process with the memory and CPU
+// limits applied, and returns its directory and an open descriptor for clone3. It
+// returns fd -1 when the limits need no cgroup.
+func createCgroup(limits *config.ResourceLimitsConfig) (string, int, error) {
+	if limits.MaxMemoryBytes() == 0 && limits.CPUWeight() == 0 {
+		return "", -1, nil
+	}
+	parent, err := cgroupParent(limits.CgroupParent)
+	if err != nil {
+		return "", -1, err
+	}
+	dir := filepath.Join(parent, fmt.Sprintf("docbuilder-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(dir, 0o750); err != nil {
+		return "", -1, errors.WrapError(err, errors.CategoryFileSystem, "failed to create process cgroup").
+			WithContext("path", dir).
+			Build()
+	}
+	var settings [][2]string
+	if b := limits.MaxMemoryBytes(); b > 0 {
+		settings = append(settings, [2]string{"memory.max", strconv.FormatInt(b, 10)})
+		if _, err := os.Stat(filepath.Join(dir, "memory.swap.max")); err == nil {
+			settings = append(settings, [2]string{"memory.swap.max", "0"})
+		}
+	}
+	if w := limits.CPUWeight(); w > 0 {
+		settings = append(settings, [2]string{"cpu.weight", strconv.Itoa(w)})
+	}
+	for _, setting := range settings {
+		if err := os.WriteFile(filepath.Join(dir, setting[0]), []byte(setting[1]), 0o600); err != nil {
+			_ = os.Remove(dir)
+			return "", -1, errors.WrapError(err, errors.CategoryFileSystem, "failed to set process cgroup limit").
+				WithContext("path", dir).
+				WithContext("file", setting[0]).
+				Build()
+		}
+	}
+	fd, err := unix.Open(dir, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if err != nil {
+		_ = os.Remove(dir)
+		return "", -1, errors.WrapError(err, errors.CategoryFileSystem, "failed to open process cgroup").
+			WithContext("path", dir).
+			Build()
+	}
+	return dir, fd, nil
+}
+
+// cgroupParent resolves the directory in which process groups are created: the
+// configured cgroup_parent (relative to the cgroup v2 mount), or the daemon's own group.
+func cgroupParent(configured string) (string, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", errors.NewError(errors.CategoryFileSystem, "cgroup v2 is not mounted").
+			WithContext("path", cgroupRoot).
+			Build()
+	}
+	rel := configured
+	if rel == "" {
+		own, err := ownCgroup()
+		if err != nil {
+			return "", err
+		}
+		rel = own
+	}
+	parent := filepath.Join(cgroupRoot, filepath.Clean("/"+rel))
+	controllers, err := os.ReadFile(filepath.Join(parent, "cgroup.subtree_control"))
+	if err != nil {
+		return "", errors.WrapError(err, errors.CategoryFileSystem, "failed to read cgroup controllers").
+			WithContext("path", parent).
+			Build()
+	}
+	enabled := strings.Fields(string(controllers))
+	for _, want := range []string{"memory", "cpu"} {
+		if !slices.Contains(enabled, want) {
+			return "", errors.NewError(errors.CategoryFileSystem, "cgroup controller is not delegated").
+				WithContext("path", parent).
+				WithContext("controller", want).
+				Build()
+		}
+	}
+	return parent, nil
+}
+
+// ownCgroup returns the cgroup v2 path of the current process from /proc/self/cgroup.
+func ownCgroup() (string, error) {
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return "", errors.WrapError(err, errors.CategoryFileSystem, "failed to read own cgroup").Build()
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if path, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", errors.NewError(errors.CategoryFileSystem, "process is not in a cgroup v2 group").Build()
+}
@@ -0,0 +1,102 @@
+package proclimit
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func fakeCgroupRoot(t *testing.T, parent, controllers string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, parent)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte(controllers), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+	return root
+}
+
+func TestCreateCgroup_WritesLimits(t *testing.T) {
+	root := fakeCgroupRoot(t, "builds", "cpu memory\n")
+
+	dir, fd, err := createCgroup(&config.ResourceLimitsConfig{MaxMemoryMB: 256, CPUShares: 1024, CgroupParent: "/builds"})
+	if err != nil {
+		t.Fatalf("createCgroup: %v", err)
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	if filepath.Dir(dir) != filepath.Join(root, "builds") || !strings.HasPrefix(filepath.Base(dir), "docbuilder-") {
+		t.Fatalf("unexpected cgroup directory %s", dir)
+	}
+	for file, want := range map[string]string{"memory.max": "268435456", "cpu.weight": "39"} {
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if string(got) != want {
+			t.Fatalf("%s = %q, want %q", file, got, want)
+		}
+	}
+}
+
+func TestCreateCgroup_RequiresDelegatedControllers(t *testing.T) {
+	fakeCgroupRoot(t, "builds", "pids\n")
+
+	if _, _, err := createCgroup(&config.ResourceLimitsConfig{MaxMemoryMB: 256, CgroupParent: "/builds"}); err == nil || !strings.Contains(err.Error(), "not delegated") {
+		t.Fatalf("expected controller error, got %v", err)
+	}
+}
+
+func TestCreateCgroup_NotNeededForNiceOnly(t *testing.T) {
+	dir, fd, err := createCgroup(&config.ResourceLimitsConfig{Nice: 5})
+	if err != nil || dir != "" || fd != -1 {
+		t.Fatalf("expected no cgroup, got (%q, %d, %v)", dir, fd, err)
+	}
+}
+
+func TestRun_FallsBackToRlimitAndNice(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	cgroupRoot = t.TempDir() // no cgroup v2: limits fall back to rlimits
+	t.Cleanup(func() { cgroupRoot = "/sys/fs/cgroup" })
+
+	// The limits are applied right after start, so the child waits before reading them.
+	cmd := exec.Command("sh", "-c", `sleep 0.2; cut -d' ' -f19 /proc/$$/stat; grep 'Max data size' /proc/$$/limits`)
+	out, err := CombinedOutput(cmd, &config.ResourceLimitsConfig{MaxMemoryMB: 1024, Nice: 7})
+	if err != nil {
+		t.Fatalf("run: %v: %s", err, out)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output %q", out)
+	}
+	if lines[0] != "7" {
+		t.Fatalf("expected nice 7, got %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); len(fields) < 5 || fields[3] != "1073741824" {
+		t.Fatalf("expected a 1 GiB data limit, got %q", lines[1])
+	}
+}
+
+func TestRun_WithoutLimits(t *testing.T) {
+	out, err := CombinedOutput(exec.Command("sh", "-c", "echo ok"), nil)
+	if err != nil || strings.TrimSpace(string(out)) != "ok" {
+		t.Fatalf("unexpected result (%q, %v)", out, err)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package proclimit
+
+import (
+	"log/slog"
+	"os/exec"
+	"sync"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+var unsupportedOnce sync.Once
+
+func start(cmd *exec.Cmd, _ *config.ResourceLimitsConfig) (func(), error) {
+	unsupportedOnce.Do(func() {
+		slog.Warn("build.resources limits are only supported on Linux; running processes unlimited")
+	})
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return func() {}, nil
+}
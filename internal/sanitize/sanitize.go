@@ -0,0 +1,247 @@
+// Package sanitize applies a compatibility policy to Hugo shortcodes and raw HTML tags
+// in markdown, so content written for one theme or forge does not break an aggregated site.
+//
+// Each shortcode ({{< name >}}, {{% name %}}) and HTML tag (<name ...>, </name>) outside
+// fenced code blocks and inline code is looked up in the policy and either kept, escaped
+// (rendered literally) or stripped. Stripping <script> and <style> also removes their content.
+package sanitize
+
+import (
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// Kind distinguishes the markup a policy decision applies to.
+type Kind string
+
+const (
+	KindShortcode Kind = "shortcode"
+	KindHTML      Kind = "html"
+)
+
+// Action is the policy decision for one shortcode or HTML tag.
+type Action string
+
+const (
+	Allow  Action = "allow"
+	Escape Action = "escape"
+	Strip  Action = "strip"
+)
+
+// Resolver returns the action for a shortcode or HTML tag name (lowercased).
+type Resolver func(kind Kind, name string) Action
+
+// Violation records a shortcode or HTML tag the policy escaped or stripped.
+type Violation struct {
+	Line   int // 1-based line in the scanned content
+	Kind   Kind
+	Name   string
+	Action Action
+}
+
+// rawTextElements are removed together with their content when stripped.
+var rawTextElements = map[string]bool{"script": true, "style": true}
+
+// Apply rewrites content according to resolve and returns the result with the violations
+// found. With a nil resolver content is returned unchanged.
+func Apply(content string, resolve Resolver) (string, []Violation) {
+	if resolve == nil {
+		return content, nil
+	}
+	s := scanner{resolve: resolve}
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if processed, keep := s.line(line, i+1); keep {
+			out = append(out, processed)
+		}
+	}
+	return strings.Join(out, "\n"), s.violations
+}
+
+type scanner struct {
+	resolve    Resolver
+	violations []Violation
+	fence      string // open code fence marker
+	skipUntil  string // closing tag of a stripped raw text element
+}
+
+// line processes one line and reports whether it should be kept. Lines that only held
+// stripped markup are dropped.
+func (s *scanner) line(line string, lineNo int) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if s.fence != "" {
+		if strings.HasPrefix(trimmed, s.fence) {
+			s.fence = ""
+		}
+		return line, true
+	}
+	if s.skipUntil == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+		s.fence = trimmed[:3]
+		return line, true
+	}
+
+	var b strings.Builder
+	changed := false
+	for i := 0; i < len(line); {
+		if s.skipUntil != "" {
+			changed = true
+			end := strings.Index(strings.ToLower(line[i:]), s.skipUntil)
+			if end < 0 {
+				i = len(line)
+				continue
+			}
+			i += end + len(s.skipUntil)
+			s.skipUntil = ""
+			continue
+		}
+		switch {
+		case line[i] == '`':
+			n := runLength(line[i:], '`')
+			end := strings.Index(line[i+n:], line[i:i+n])
+			if end < 0 {
+				b.WriteString(line[i : i+n])
+				i += n
+				continue
+			}
+			b.WriteString(line[i : i+n+end+n])
+			i += n + end + n
+		case strings.HasPrefix(line[i:], "{{<") || strings.HasPrefix(line[i:], "{{%"):
+			n, repl, ok := s.shortcode(line[i:], lineNo)
+			if !ok {
+				b.WriteByte(line[i])
+				i++
+				continue
+			}
+			b.WriteString(repl)
+			changed = changed || repl != line[i:i+n]
+			i += n
+		case line[i] == '<':
+			n, repl, ok := s.htmlTag(line[i:], lineNo)
+			if !ok {
+				b.WriteByte(line[i])
+				i++
+				continue
+			}
+			b.WriteString(repl)
+			changed = changed || repl != line[i:i+n]
+			i += n
+		default:
+			b.WriteByte(line[i])
+			i++
+		}
+	}
+	if changed && strings.TrimSpace(b.String()) == "" && trimmed != "" {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// shortcode handles a shortcode at the start of text, returning the consumed length and replacement.
+func (s *scanner) shortcode(text string, lineNo int) (int, string, bool) {
+	delim := text[2] // '<' or '%'
+	closing := "%}}"
+	if delim == '<' {
+		closing = ">}}"
+	}
+	if strings.HasPrefix(text[3:], "/*") {
+		// Already escaped: {{</* ... */>}}
+		end := strings.Index(text, "*/"+closing)
+		if end < 0 {
+			return 0, "", false
+		}
+		n := end + len("*/"+closing)
+		return n, text[:n], true
+	}
+	end := strings.Index(text, closing)
+	if end < 0 {
+		return 0, "", false
+	}
+	n := end + len(closing)
+	inner := text[3:end]
+	name := strings.TrimPrefix(strings.TrimSpace(inner), "/")
+	if i := strings.IndexAny(name, " \t"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return 0, "", false
+	}
+
+	switch action := s.resolve(KindShortcode, name); action {
+	case Escape:
+		s.violations = append(s.violations, Violation{Line: lineNo, Kind: KindShortcode, Name: name, Action: action})
+		return n, "{{" + string(delim) + "/*" + inner + "*/" + closing, true
+	case Strip:
+		s.violations = append(s.violations, Violation{Line: lineNo, Kind: KindShortcode, Name: name, Action: action})
+		return n, "", true
+	default:
+		return n, text[:n], true
+	}
+}
+
+// htmlTag handles an HTML tag at the start of text, returning the consumed length and replacement.
+// Comments, autolinks and anything that is not a well-formed single-line tag are not tags.
+func (s *scanner) htmlTag(text string, lineNo int) (int, string, bool) {
+	i := 1
+	closingTag := i < len(text) && text[i] == '/'
+	if closingTag {
+		i++
+	}
+	start := i
+	for i < len(text) && (isASCIILetter(text[i]) || (i > start && (text[i] == '-' || (text[i] >= '0' && text[i] <= '9')))) {
+		i++
+	}
+	if i == start || i >= len(text) {
+		return 0, "", false
+	}
+	if c := text[i]; c != '>' && c != '/' && c != ' ' && c != '\t' {
+		return 0, "", false
+	}
+	end := strings.IndexByte(text[i:], '>')
+	if end < 0 {
+		return 0, "", false
+	}
+	n := i + end + 1
+	name := strings.ToLower(text[start:i])
+
+	switch action := s.resolve(KindHTML, name); action {
+	case Escape:
+		s.violations = append(s.violations, Violation{Line: lineNo, Kind: KindHTML, Name: name, Action: action})
+		return n, "&lt;" + text[1:n], true
+	case Strip:
+		s.violations = append(s.violations, Violation{Line: lineNo, Kind: KindHTML, Name: name, Action: action})
+		if !closingTag && rawTextElements[name] && !strings.HasSuffix(text[:n], "/>") {
+			s.skipUntil = "</" + name + ">"
+		}
+		return n, "", true
+	default:
+		return n, text[:n], true
+	}
+}
+
+func runLength(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// FromConfig returns the resolver for a configured policy, or nil when none is configured.
+func FromConfig(policy *config.SanitizeConfig) Resolver {
+	if !policy.Enabled() {
+		return nil
+	}
+	return func(kind Kind, name string) Action {
+		if kind == KindShortcode {
+			return Action(policy.Shortcodes.ActionFor(name))
+		}
+		return Action(policy.HTML.ActionFor(name))
+	}
+}
@@ -0,0 +1,67 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func policy(actions map[string]Action) Resolver {
+	return func(kind Kind, name string) Action {
+		if a, ok := actions[string(kind)+":"+name]; ok {
+			return a
+		}
+		return Allow
+	}
+}
+
+func TestApply(t *testing.T) {
+	resolve := policy(map[string]Action{
+		"shortcode:tabs":   Strip,
+		"shortcode:notice": Escape,
+		"html:script":      Strip,
+		"html:iframe":      Escape,
+		"html:font":        Strip,
+	})
+
+	content := "# Title\n\n" +
+		"{{< tabs >}}\nTab content\n{{< /tabs >}}\n" +
+		"{{% notice info %}}Careful{{% /notice %}}\n" +
+		"{{< ref \"other.md\" >}} and {{</* tabs */>}}\n" +
+		"<font color=\"red\">Red</font> <b>bold</b> <https://example.com>\n" +
+		"Before <script>\nalert(1)\n</script> after\n" +
+		"<iframe src=\"x\"></iframe>\n" +
+		"`{{< tabs >}}` and <!-- comment -->\n" +
+		"```\n<script>kept()</script>\n{{< tabs >}}\n```\n"
+
+	got, violations := Apply(content, resolve)
+
+	want := "# Title\n\n" +
+		"Tab content\n" +
+		"{{%/* notice info */%}}Careful{{%/* /notice */%}}\n" +
+		"{{< ref \"other.md\" >}} and {{</* tabs */>}}\n" +
+		"Red <b>bold</b> <https://example.com>\n" +
+		"Before \n after\n" +
+		"&lt;iframe src=\"x\">&lt;/iframe>\n" +
+		"`{{< tabs >}}` and <!-- comment -->\n" +
+		"```\n<script>kept()</script>\n{{< tabs >}}\n```\n"
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, []Violation{
+		{Line: 3, Kind: KindShortcode, Name: "tabs", Action: Strip},
+		{Line: 5, Kind: KindShortcode, Name: "tabs", Action: Strip},
+		{Line: 6, Kind: KindShortcode, Name: "notice", Action: Escape},
+		{Line: 6, Kind: KindShortcode, Name: "notice", Action: Escape},
+		{Line: 8, Kind: KindHTML, Name: "font", Action: Strip},
+		{Line: 8, Kind: KindHTML, Name: "font", Action: Strip},
+		{Line: 9, Kind: KindHTML, Name: "script", Action: Strip},
+		{Line: 12, Kind: KindHTML, Name: "iframe", Action: Escape},
+		{Line: 12, Kind: KindHTML, Name: "iframe", Action: Escape},
+	}, violations)
+}
+
+func TestApply_NilResolver(t *testing.T) {
+	got, violations := Apply("<script>x</script>", nil)
+	assert.Equal(t, "<script>x</script>", got)
+	assert.Empty(t, violations)
+}
@@ -0,0 +1,271 @@
+// Package scaffold generates a ready-to-aggregate documentation structure in a repository.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
+)
+
+//go:embed files
+var files embed.FS
+
+// CI providers supported by Options.CI.
+const (
+	CINone    = ""
+	CIGitHub  = "github"
+	CIGitLab  = "gitlab"
+	CIForgejo = "forgejo"
+)
+
+// CIProviders lists the accepted Options.CI values (excluding CINone).
+var CIProviders = []string{CIGitHub, CIGitLab, CIForgejo}
+
+// ConfigFile is the repository-local DocBuilder configuration written by Generate.
+const ConfigFile = ".docbuilder.yaml"
+
+// Options controls what Generate writes.
+type Options struct {
+	Dir      string // Repository root
+	Name     string // Repository name used in the generated configuration
+	Title    string // Site title
+	RepoURL  string // Clone URL used in the generated configuration
+	Branch   string // Default branch
+	CI       string // One of CIProviders, or CINone
+	Examples bool   // Generate an example ADR and guide from the bundled templates
+	Force    bool   // Overwrite existing files (examples are never overwritten)
+}
+
+// Result lists the files created and the files left untouched because they already existed.
+// Paths are relative to Options.Dir.
+type Result struct {
+	Written []string
+	Skipped []string
+}
+
+// renderedFile is a file produced from an embedded text/template.
+type renderedFile struct {
+	tmpl, out string
+	data      any
+}
+
+// section is a docs/ subdirectory created by the skeleton.
+type section struct {
+	dir, title, description string
+}
+
+var sections = []section{
+	{"guides", "Guides", "Task-oriented guides for using and operating this project."},
+	{"reference", "Reference", "Technical reference: configuration, APIs and commands."},
+	{"adr", "Architecture Decisions", "Architecture Decision Records (ADRs) for this project."},
+}
+
+// Generate writes the docs skeleton, configuration, lint config, bundled templates,
+// optional CI snippet and optional examples into opts.Dir.
+func Generate(opts Options) (*Result, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if st, err := os.Stat(opts.Dir); err != nil || !st.IsDir() {
+		return nil, fmt.Errorf("target directory does not exist: %s", opts.Dir)
+	}
+
+	res := &Result{}
+	data := map[string]any{
+		"Name":        opts.Name,
+		"Title":       opts.Title,
+		"RepoURL":     opts.RepoURL,
+		"Branch":      opts.Branch,
+		"Description": "Documentation for " + opts.Name + ".",
+	}
+
+	rendered := []renderedFile{
+		{"docbuilder.yaml.tmpl", ConfigFile, data},
+		{"pre-commit-config.yaml.tmpl", ".pre-commit-config.yaml", data},
+		{"index.md.tmpl", "docs/_index.md", data},
+	}
+	for _, s := range sections {
+		rendered = append(rendered, renderedFile{"index.md.tmpl", path.Join("docs", s.dir, "_index.md"), map[string]any{"Title": s.title, "Description": s.description}})
+	}
+	switch opts.CI {
+	case CIGitHub:
+		rendered = append(rendered, renderedFile{"ci/github.yml.tmpl", ".github/workflows/docs.yml", data})
+	case CIForgejo:
+		rendered = append(rendered, renderedFile{"ci/github.yml.tmpl", ".forgejo/workflows/docs.yml", data})
+	case CIGitLab:
+		rendered = append(rendered, renderedFile{"ci/gitlab.yml.tmpl", ".gitlab/docbuilder.gitlab-ci.yml", data})
+	}
+
+	for _, r := range rendered {
+		content, err := render(r.tmpl, r.data)
+		if err != nil {
+			return nil, err
+		}
+		if err := res.write(opts, r.out, content); err != nil {
+			return nil, err
+		}
+	}
+
+	templateSources, err := fs.Glob(files, "files/templates/*.template.md")
+	if err != nil {
+		return nil, fmt.Errorf("list bundled templates: %w", err)
+	}
+	for _, src := range templateSources {
+		content, err := files.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("read bundled template %s: %w", src, err)
+		}
+		if err := res.write(opts, path.Join("docs", "templates", path.Base(src)), content); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Examples {
+		if err := res.writeExamples(opts); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (o Options) validate() error {
+	switch {
+	case o.Dir == "":
+		return errors.New("target directory is required")
+	case strings.TrimSpace(o.Name) == "":
+		return errors.New("repository name is required")
+	case strings.TrimSpace(o.Title) == "":
+		return errors.New("title is required")
+	case strings.TrimSpace(o.RepoURL) == "":
+		return errors.New("repository URL is required")
+	case strings.TrimSpace(o.Branch) == "":
+		return errors.New("branch is required")
+	}
+	if o.CI != CINone && !slices.Contains(CIProviders, o.CI) {
+		return fmt.Errorf("unsupported CI provider %q (supported: %s)", o.CI, strings.Join(CIProviders, ", "))
+	}
+	return nil
+}
+
+// write creates rel under opts.Dir unless it exists and Force is unset.
+func (r *Result) write(opts Options, rel string, content []byte) error {
+	full := filepath.Join(opts.Dir, filepath.FromSlash(rel))
+	if _, err := os.Stat(full); err == nil && !opts.Force {
+		r.Skipped = append(r.Skipped, rel)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+		return fmt.Errorf("create directory for %s: %w", rel, err)
+	}
+	// #nosec G306 -- scaffolded repository files are meant to be committed and shared
+	if err := os.WriteFile(full, content, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	r.Written = append(r.Written, rel)
+	return nil
+}
+
+// examples are instantiated from the bundled templates with these inputs.
+var examples = []struct {
+	template string
+	inputs   map[string]string
+}{
+	{"adr", map[string]string{"Title": "Record architecture decisions", "Slug": "record-architecture-decisions"}},
+	{"guide", map[string]string{"Title": "Getting started", "Slug": "getting-started", "Category": "getting-started"}},
+}
+
+// writeExamples renders the example documents through the regular template pipeline.
+// An example is skipped when a document with its slug already exists in the target directory.
+func (r *Result) writeExamples(opts Options) error {
+	docsDir := filepath.Join(opts.Dir, "docs")
+	for _, ex := range examples {
+		content, err := files.ReadFile("files/templates/" + ex.template + ".template.md")
+		if err != nil {
+			return fmt.Errorf("read bundled template %s: %w", ex.template, err)
+		}
+		page, err := templating.ParseTemplateMarkdown(content)
+		if err != nil {
+			return fmt.Errorf("parse bundled template %s: %w", ex.template, err)
+		}
+		schema, err := templating.ParseTemplateSchema(page.Meta.Schema)
+		if err != nil {
+			return err
+		}
+		defaults, err := templating.ParseTemplateDefaults(page.Meta.Defaults)
+		if err != nil {
+			return err
+		}
+		inputs, err := templating.ResolveTemplateInputs(schema, defaults, ex.inputs, true, nil)
+		if err != nil {
+			return err
+		}
+		nextInSequence, err := sequenceResolver(page, docsDir)
+		if err != nil {
+			return err
+		}
+		outputPath, err := templating.RenderOutputPath(page.Meta.OutputPath, inputs, nextInSequence)
+		if err != nil {
+			return err
+		}
+
+		rel := path.Join("docs", filepath.ToSlash(outputPath))
+		existing, _ := filepath.Glob(filepath.Join(docsDir, filepath.Dir(outputPath), "*"+ex.inputs["Slug"]+".md"))
+		if len(existing) > 0 {
+			r.Skipped = append(r.Skipped, rel)
+			continue
+		}
+
+		body, err := templating.RenderTemplateBody(page.Body, inputs, nextInSequence)
+		if err != nil {
+			return err
+		}
+		if _, err := templating.WriteGeneratedFile(docsDir, outputPath, body+"\n"); err != nil {
+			return err
+		}
+		r.Written = append(r.Written, rel)
+	}
+	return nil
+}
+
+// sequenceResolver resolves nextInSequence for the template's own sequence definition.
+func sequenceResolver(page *templating.TemplatePage, docsDir string) (func(string) (int, error), error) {
+	def, err := templating.ParseSequenceDefinition(page.Meta.Sequence)
+	if err != nil && !errors.Is(err, templating.ErrNoSequenceDefinition) {
+		return nil, err
+	}
+	return func(name string) (int, error) {
+		if def == nil || def.Name != name {
+			return 0, fmt.Errorf("unknown sequence: %s", name)
+		}
+		return templating.ComputeNextInSequence(*def, docsDir)
+	}, nil
+}
+
+func render(name string, data any) ([]byte, error) {
+	tmpl, err := template.New(path.Base(name)).Funcs(template.FuncMap{"yaml": yamlString}).ParseFS(files, "files/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("parse scaffold template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render scaffold template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlString quotes a scalar for safe inclusion in generated YAML (JSON strings are valid YAML).
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,103 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func testOptions(dir string) Options {
+	return Options{
+		Dir:      dir,
+		Name:     "payments",
+		Title:    "Payments Documentation",
+		RepoURL:  "https://git.example.com/org/payments.git",
+		Branch:   "main",
+		CI:       CIGitHub,
+		Examples: true,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	res, err := Generate(testOptions(dir))
+	require.NoError(t, err)
+	require.Empty(t, res.Skipped)
+
+	for _, rel := range []string{
+		ConfigFile,
+		".pre-commit-config.yaml",
+		".github/workflows/docs.yml",
+		"docs/_index.md",
+		"docs/guides/_index.md",
+		"docs/templates/adr.template.md",
+		"docs/templates/guide.template.md",
+		"docs/adr/adr-001-record-architecture-decisions.md",
+		"docs/guides/getting-started.md",
+	} {
+		require.Contains(t, res.Written, rel)
+		require.FileExists(t, filepath.Join(dir, filepath.FromSlash(rel)))
+	}
+
+	cfg, err := config.Load(filepath.Join(dir, ConfigFile))
+	require.NoError(t, err)
+	require.Len(t, cfg.Repositories, 1)
+	require.Equal(t, "payments", cfg.Repositories[0].Name)
+	require.Equal(t, "https://git.example.com/org/payments.git", cfg.Repositories[0].URL)
+	require.Equal(t, "Payments Documentation", cfg.Hugo.Title)
+
+	// #nosec G304 -- test reads from its own temp dir
+	adr, err := os.ReadFile(filepath.Join(dir, "docs", "adr", "adr-001-record-architecture-decisions.md"))
+	require.NoError(t, err)
+	require.Contains(t, string(adr), "# Record architecture decisions")
+	require.NotContains(t, string(adr), "{{")
+}
+
+func TestGenerate_SkipsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Generate(testOptions(dir))
+	require.NoError(t, err)
+
+	res, err := Generate(testOptions(dir))
+	require.NoError(t, err)
+	require.Empty(t, res.Written)
+	require.Contains(t, res.Skipped, ConfigFile)
+	require.Contains(t, res.Skipped, "docs/adr/adr-002-record-architecture-decisions.md")
+
+	matches, err := filepath.Glob(filepath.Join(dir, "docs", "adr", "adr-*.md"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "examples must not be duplicated")
+}
+
+func TestGenerate_ForceOverwrites(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFile), []byte("old"), 0o600))
+
+	opts := testOptions(dir)
+	opts.Force = true
+	opts.Examples = false
+	res, err := Generate(opts)
+	require.NoError(t, err)
+	require.Contains(t, res.Written, ConfigFile)
+
+	// #nosec G304 -- test reads from its own temp dir
+	b, err := os.ReadFile(filepath.Join(dir, ConfigFile))
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(b), "payments"))
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	opts := testOptions(t.TempDir())
+	opts.CI = "jenkins"
+	_, err := Generate(opts)
+	require.ErrorContains(t, err, "unsupported CI provider")
+
+	opts = testOptions(filepath.Join(t.TempDir(), "missing"))
+	_, err = Generate(opts)
+	require.ErrorContains(t, err, "does not exist")
+}
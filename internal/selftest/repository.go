@@ -0,0 +1,39 @@
+package selftest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// fixtureCommitTime is the author and commit time of every fixture repository, so that
+// the generated content (git metadata included) does not depend on when the test runs.
+var fixtureCommitTime = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// createRepository extracts the embedded directory src to dir and commits it on main.
+func createRepository(src, dir string) error {
+	if err := extractTree(src, dir); err != nil {
+		return err
+	}
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("worktree: %w", err)
+	}
+	if err := wt.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+	sig := &object.Signature{Name: "DocBuilder Self-Test", Email: "selftest@docbuilder.invalid", When: fixtureCommitTime}
+	if _, err := wt.Commit("Self-test fixture", &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
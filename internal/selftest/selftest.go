@@ -0,0 +1,244 @@
+// Package selftest builds a bundled set of fixture repositories and compares the generated
+// Hugo project with golden snapshots shipped in the binary. It gives operators and CI a
+// one-command regression check after upgrades: the fixtures exercise discovery, content
+// transforms, index generation and the Hugo configuration, and any difference from the
+// release's snapshots is reported file by file.
+//
+// Snapshots cover hugo.yaml and the content/ tree. Values that change between runs
+// (dates, fingerprints, commit IDs and scratch paths) are normalized before comparison,
+// and Hugo is not run, so the result does not depend on an installed Hugo.
+package selftest
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+// fixturesFS holds one directory per fixture: config.yaml, repos/<name>/ (the content
+// of each repository, referenced by name from the configuration's url) and
+// snapshot.golden.
+//
+//go:embed all:fixtures
+var fixturesFS embed.FS
+
+// snapshotFile is the golden snapshot of a fixture.
+const snapshotFile = "snapshot.golden"
+
+// Options tunes a self-test run.
+type Options struct {
+	// Fixtures limits the run to these fixtures; empty runs all of them.
+	Fixtures []string
+	// WorkDir is the scratch directory for repositories and generated sites. Empty uses
+	// a temporary directory that is removed afterwards.
+	WorkDir string
+	// UpdateDir writes the snapshots produced by this binary to UpdateDir/<fixture>/
+	// instead of comparing them (for refreshing the bundled snapshots).
+	UpdateDir string
+}
+
+// Result is the outcome of a self-test run.
+type Result struct {
+	Fixtures []FixtureResult
+}
+
+// Passed reports whether every fixture built and matched its snapshot.
+func (r *Result) Passed() bool {
+	for i := range r.Fixtures {
+		if !r.Fixtures[i].Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// FixtureResult is the outcome of one fixture.
+type FixtureResult struct {
+	Name       string        `json:"name"`
+	Duration   time.Duration `json:"duration_ns"`
+	Error      string        `json:"error,omitempty"`      // Build or setup failure
+	Missing    []string      `json:"missing,omitempty"`    // Snapshot files the build did not produce
+	Unexpected []string      `json:"unexpected,omitempty"` // Files the build produced that the snapshot lacks
+	Changed    []Change      `json:"changed,omitempty"`    // Files whose normalized content differs
+	Updated    string        `json:"updated,omitempty"`    // Snapshot written (Options.UpdateDir)
+}
+
+// Passed reports whether the fixture built and matched its snapshot.
+func (f *FixtureResult) Passed() bool {
+	return f.Error == "" && len(f.Missing) == 0 && len(f.Unexpected) == 0 && len(f.Changed) == 0
+}
+
+// Change is the first difference within a file.
+type Change struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Want string `json:"want"`
+	Got  string `json:"got"`
+}
+
+// Fixtures returns the names of the bundled fixtures.
+func Fixtures() []string {
+	entries, err := fs.ReadDir(fixturesFS, "fixtures")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// Run builds the selected fixtures and compares (or, with Options.UpdateDir, writes)
+// their snapshots. An error is returned for invalid options; fixture failures are
+// reported in the Result.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	names := opts.Fixtures
+	if len(names) == 0 {
+		names = Fixtures()
+	}
+	for _, name := range names {
+		if !slices.Contains(Fixtures(), name) {
+			return nil, fmt.Errorf("unknown fixture %q (available: %v)", name, Fixtures())
+		}
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		tmp, err := os.MkdirTemp("", "docbuilder-selftest-")
+		if err != nil {
+			return nil, fmt.Errorf("create work directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		workDir = tmp
+	}
+
+	res := &Result{}
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		start := time.Now()
+		fr := runFixture(ctx, name, filepath.Join(workDir, name), opts.UpdateDir)
+		fr.Duration = time.Since(start)
+		res.Fixtures = append(res.Fixtures, fr)
+	}
+	return res, nil
+}
+
+func runFixture(ctx context.Context, name, dir, updateDir string) FixtureResult {
+	fr := FixtureResult{Name: name}
+	got, err := buildFixture(ctx, name, dir)
+	if err != nil {
+		fr.Error = err.Error()
+		return fr
+	}
+
+	if updateDir != "" {
+		target := filepath.Join(updateDir, name, snapshotFile)
+		if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+			fr.Error = err.Error()
+			return fr
+		}
+		if err := os.WriteFile(target, got, 0o600); err != nil {
+			fr.Error = err.Error()
+			return fr
+		}
+		fr.Updated = target
+		return fr
+	}
+
+	want, err := fixturesFS.ReadFile(path.Join("fixtures", name, snapshotFile))
+	if err != nil {
+		fr.Error = fmt.Sprintf("read snapshot: %v", err)
+		return fr
+	}
+	fr.Missing, fr.Unexpected, fr.Changed = compare(want, got)
+	return fr
+}
+
+// buildFixture materializes the fixture's repositories as git repositories below dir,
+// builds the site and returns its snapshot.
+func buildFixture(ctx context.Context, name, dir string) ([]byte, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("clean %s: %w", dir, err)
+	}
+	root := path.Join("fixtures", name)
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := extract(path.Join(root, "config.yaml"), configPath); err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("load fixture configuration: %w", err)
+	}
+
+	for i := range cfg.Repositories {
+		repo := &cfg.Repositories[i]
+		repoDir := filepath.Join(dir, "repos", repo.URL)
+		if err := createRepository(path.Join(root, "repos", repo.URL), repoDir); err != nil {
+			return nil, fmt.Errorf("create repository %s: %w", repo.Name, err)
+		}
+		repo.URL = repoDir
+	}
+	outputDir := filepath.Join(dir, "site")
+	cfg.Output.Directory = outputDir
+	cfg.Build.RenderMode = config.RenderModeNever
+
+	svc := build.NewBuildService().
+		WithHugoGeneratorFactory(func(c *config.Config, outDir string) build.HugoGenerator {
+			return hugo.NewGenerator(c, outDir)
+		})
+	result, err := svc.Run(ctx, build.BuildRequest{Config: cfg, OutputDir: outputDir})
+	if err != nil {
+		return nil, fmt.Errorf("build: %w", err)
+	}
+	if result.Status != build.BuildStatusSuccess {
+		return nil, fmt.Errorf("build finished with status %s", result.Status)
+	}
+	return snapshot(outputDir, dir)
+}
+
+// extract copies an embedded file to dst.
+func extract(src, dst string) error {
+	data, err := fixturesFS.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return fmt.Errorf("create directory for %s: %w", dst, err)
+	}
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", dst, err)
+	}
+	return nil
+}
+
+// extractTree copies an embedded directory to dst.
+func extractTree(src, dst string) error {
+	return fs.WalkDir(fixturesFS, src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := p[len(src)+1:]
+		if err := extract(p, filepath.Join(dst, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package selftest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun_MatchesSnapshots keeps the bundled snapshots in step with the build. When a
+// change to the output is intended, regenerate them:
+//
+//	go run ./cmd/docbuilder selftest --update internal/selftest/fixtures
+func TestRun_MatchesSnapshots(t *testing.T) {
+	res, err := Run(t.Context(), Options{WorkDir: t.TempDir()})
+	require.NoError(t, err)
+	require.Len(t, res.Fixtures, len(Fixtures()))
+	for _, f := range res.Fixtures {
+		require.True(t, f.Passed(), "fixture %s: %+v", f.Name, f)
+	}
+	require.True(t, res.Passed())
+}
+
+func TestRun_Update(t *testing.T) {
+	dir := t.TempDir()
+	res, err := Run(t.Context(), Options{Fixtures: []string{"basic"}, UpdateDir: dir})
+	require.NoError(t, err)
+	require.Len(t, res.Fixtures, 1)
+	require.Equal(t, filepath.Join(dir, "basic", snapshotFile), res.Fixtures[0].Updated)
+
+	written, err := os.ReadFile(res.Fixtures[0].Updated)
+	require.NoError(t, err)
+	bundled, err := fixturesFS.ReadFile("fixtures/basic/" + snapshotFile)
+	require.NoError(t, err)
+	require.Equal(t, string(bundled), string(written))
+}
+
+func TestRun_UnknownFixture(t *testing.T) {
+	_, err := Run(t.Context(), Options{Fixtures: []string{"nope"}})
+	require.ErrorContains(t, err, `unknown fixture "nope"`)
+}
+
+func TestCompare(t *testing.T) {
+	want := []byte(snapshotHeader + "=== hugo.yaml\ntitle: A\n=== content/a.md\nline 1\nline 2\n=== content/gone.md\nx\n")
+	got := []byte(snapshotHeader + "=== hugo.yaml\ntitle: A\n=== content/a.md\nline 1\nline two\n=== content/new.md\ny\n")
+
+	missing, unexpected, changed := compare(want, got)
+	require.Equal(t, []string{"content/gone.md"}, missing)
+	require.Equal(t, []string{"content/new.md"}, unexpected)
+	require.Equal(t, []Change{{Path: "content/a.md", Line: 2, Want: "line 2", Got: "line two"}}, changed)
+}
+
+func TestNormalizeFile(t *testing.T) {
+	page := "---\ntitle: Page\ndate: 2026-01-02T03:04:05Z\nfingerprint: abc\nsource_commit: 0123456789abcdef0123456789abcdef01234567\n---\n\nSee /work/dir/repo.\n"
+	out, err := normalizeFile("content/page.md", []byte(page), "/work/dir")
+	require.NoError(t, err)
+	require.Equal(t, "---\ndate: <normalized>\nfingerprint: <normalized>\nsource_commit: <commit>\ntitle: Page\n---\n\nSee $WORKDIR/repo.\n", out)
+
+	out, err = normalizeFile("content/image.png", []byte{0x89, 'P', 'N', 'G', 0}, "/work/dir")
+	require.NoError(t, err)
+	require.Contains(t, out, "sha256:")
+}
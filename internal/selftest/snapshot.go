@@ -0,0 +1,206 @@
+package selftest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatterops"
+)
+
+const (
+	snapshotHeader = "# DocBuilder self-test snapshot; regenerate with: docbuilder selftest --update DIR\n"
+	sectionPrefix  = "=== "
+	normalized     = "<normalized>"
+)
+
+// volatileKeys are front matter and configuration keys whose values change on every
+// build; their values are replaced when present.
+var volatileKeys = map[string]bool{
+	"build_date":  true,
+	"date":        true,
+	"expiryDate":  true,
+	"fingerprint": true,
+	"lastmod":     true,
+	"publishDate": true,
+}
+
+var (
+	commitID      = regexp.MustCompile(`\b[0-9a-f]{40}\b`)
+	workspacePath = regexp.MustCompile(`\S*docbuilder-\d{8}-\d{6}\S*`)
+)
+
+// snapshot renders the normalized hugo.yaml and content/ tree of the generated site as
+// one text document with a section per file.
+func snapshot(outputDir, workDir string) ([]byte, error) {
+	files := []string{"hugo.yaml"}
+	contentDir := filepath.Join(outputDir, "content")
+	err := filepath.WalkDir(contentDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outputDir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list generated content: %w", err)
+	}
+	sort.Strings(files[1:])
+
+	var b bytes.Buffer
+	b.WriteString(snapshotHeader)
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(rel))) // #nosec G304 -- file of the generated site
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", rel, err)
+		}
+		content, err := normalizeFile(rel, data, workDir)
+		if err != nil {
+			return nil, fmt.Errorf("normalize %s: %w", rel, err)
+		}
+		b.WriteString(sectionPrefix + rel + "\n")
+		b.WriteString(content)
+		if !strings.HasSuffix(content, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// normalizeFile returns the comparable form of a generated file: YAML and front matter
+// with volatile values replaced, other text with scratch paths and commit IDs replaced,
+// and binary files as their hash.
+func normalizeFile(rel string, data []byte, workDir string) (string, error) {
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return fmt.Sprintf("sha256:%x\n", sha256.Sum256(data)), nil
+	}
+	switch strings.ToLower(filepath.Ext(rel)) {
+	case ".yaml", ".yml":
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(normalizeValue(doc, workDir))
+		return string(out), err
+	case ".md", ".markdown":
+		// Unparsable front matter is compared as text.
+		if fields, body, had, _, err := frontmatterops.Read(data); err == nil && had {
+			fm, err := yaml.Marshal(normalizeValue(fields, workDir))
+			if err != nil {
+				return "", err
+			}
+			return "---\n" + string(fm) + "---\n" + normalizeText(string(body), workDir), nil
+		}
+	}
+	return normalizeText(string(data), workDir), nil
+}
+
+func normalizeValue(v any, workDir string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if volatileKeys[k] {
+				t[k] = normalized
+				continue
+			}
+			t[k] = normalizeValue(val, workDir)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = normalizeValue(t[i], workDir)
+		}
+		return t
+	case string:
+		return normalizeText(t, workDir)
+	case time.Time:
+		return normalized
+	default:
+		return v
+	}
+}
+
+func normalizeText(s, workDir string) string {
+	s = strings.ReplaceAll(s, workDir, "$WORKDIR")
+	s = workspacePath.ReplaceAllString(s, "$$WORKSPACE")
+	return commitID.ReplaceAllString(s, "<commit>")
+}
+
+// sections splits a snapshot into its files.
+func sections(snap []byte) (order []string, files map[string]string) {
+	files = map[string]string{}
+	var current string
+	var body strings.Builder
+	flush := func() {
+		if current != "" {
+			files[current] = body.String()
+			order = append(order, current)
+		}
+		body.Reset()
+	}
+	for _, line := range strings.SplitAfter(string(snap), "\n") {
+		if strings.HasPrefix(line, sectionPrefix) {
+			flush()
+			current = strings.TrimSpace(strings.TrimPrefix(line, sectionPrefix))
+			continue
+		}
+		if current != "" {
+			body.WriteString(line)
+		}
+	}
+	flush()
+	return order, files
+}
+
+// compare reports the files missing from got, the files only in got and the first
+// differing line of every file in both.
+func compare(want, got []byte) (missing, unexpected []string, changed []Change) {
+	wantOrder, wantFiles := sections(want)
+	gotOrder, gotFiles := sections(got)
+	for _, p := range wantOrder {
+		g, ok := gotFiles[p]
+		if !ok {
+			missing = append(missing, p)
+			continue
+		}
+		if g != wantFiles[p] {
+			changed = append(changed, firstDifference(p, wantFiles[p], g))
+		}
+	}
+	for _, p := range gotOrder {
+		if _, ok := wantFiles[p]; !ok {
+			unexpected = append(unexpected, p)
+		}
+	}
+	return missing, unexpected, changed
+}
+
+func firstDifference(p, want, got string) Change {
+	w, g := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; ; i++ {
+		var wl, gl string
+		if i < len(w) {
+			wl = w[i]
+		}
+		if i < len(g) {
+			gl = g[i]
+		}
+		if wl != gl || i >= len(w) || i >= len(g) {
+			return Change{Path: p, Line: i + 1, Want: wl, Got: gl}
+		}
+	}
+}
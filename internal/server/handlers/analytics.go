@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+const (
+	defaultTopPagesLimit = 20
+	maxTopPagesLimit     = 1000
+)
+
+// PageViewStore records and aggregates page views.
+type PageViewStore interface {
+	Record(urlPath string)
+	Top(n int) []analytics.PageCount
+	Totals() (total, other int64, since time.Time)
+}
+
+// AnalyticsHandlers serves aggregated page view analytics.
+type AnalyticsHandlers struct {
+	views        PageViewStore
+	errorAdapter *errors.HTTPErrorAdapter
+}
+
+// NewAnalyticsHandlers creates analytics handlers backed by the given page view store.
+func NewAnalyticsHandlers(views PageViewStore) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		views:        views,
+		errorAdapter: errors.NewHTTPErrorAdapter(slog.Default()),
+	}
+}
+
+// TopPagesResponse is the payload of the top pages endpoint.
+type TopPagesResponse struct {
+	Since          time.Time             `json:"since"`
+	TotalViews     int64                 `json:"total_views"`
+	UntrackedViews int64                 `json:"untracked_views"`
+	Pages          []analytics.PageCount `json:"pages"`
+}
+
+// HandleTopPages serves the most viewed pages since the daemon started.
+//
+// Query parameters:
+//   - limit: number of pages to return (default 20, max 1000)
+func (h *AnalyticsHandlers) HandleTopPages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	limit := defaultTopPagesLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxTopPagesLimit {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("limit must be between 1 and 1000").
+				WithContext("limit", raw).
+				Build())
+			return
+		}
+		limit = n
+	}
+
+	total, other, since := h.views.Totals()
+	resp := TopPagesResponse{Since: since, TotalViews: total, UntrackedViews: other, Pages: h.views.Top(limit)}
+	if err := writeJSONPretty(w, r, http.StatusOK, resp); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write analytics response").Build())
+	}
+}
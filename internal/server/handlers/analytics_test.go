@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
+)
+
+func TestAnalyticsHandlers_TopPages(t *testing.T) {
+	views := analytics.NewPageViews(10)
+	for _, p := range []string{"/a/", "/a/", "/b/", "/c/", "/c/", "/c/"} {
+		views.Record(p)
+	}
+	h := NewAnalyticsHandlers(views)
+
+	rec := httptest.NewRecorder()
+	h.HandleTopPages(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/top-pages?limit=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp TopPagesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.TotalViews != 6 || len(resp.Pages) != 2 || resp.Pages[0].Path != "/c/" || resp.Pages[1].Path != "/a/" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleTopPages(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/top-pages?limit=zero", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid limit, got %d", rec.Code)
+	}
+}
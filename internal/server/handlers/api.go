@@ -14,6 +14,7 @@ import (
 type APIHandlers struct {
 	config       *config.Config
 	daemon       DaemonAPIInterface
+	leader       LeaderStatusProvider
 	errorAdapter *errors.HTTPErrorAdapter
 }
 
@@ -23,6 +24,12 @@ type DaemonAPIInterface interface {
 	GetStartTime() time.Time
 }
 
+// LeaderStatusProvider reports leader election state. LeaderStatus returns nil when
+// leader election is disabled.
+type LeaderStatusProvider interface {
+	LeaderStatus() *responses.LeaderStatus
+}
+
 // NewAPIHandlers creates a new API handlers instance.
 func NewAPIHandlers(config *config.Config, daemon DaemonAPIInterface) *APIHandlers {
 	return &APIHandlers{
@@ -32,6 +39,12 @@ func NewAPIHandlers(config *config.Config, daemon DaemonAPIInterface) *APIHandle
 	}
 }
 
+// WithLeaderStatus sets the provider whose state is included in the daemon status.
+func (h *APIHandlers) WithLeaderStatus(provider LeaderStatusProvider) *APIHandlers {
+	h.leader = provider
+	return h
+}
+
 // HandleDocsStatus handles the documentation status endpoint.
 func (h *APIHandlers) HandleDocsStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -82,6 +95,9 @@ func (h *APIHandlers) HandleDaemonStatus(w http.ResponseWriter, r *http.Request)
 			QueueSize:        h.config.Daemon.Sync.QueueSize,
 		},
 	}
+	if h.leader != nil {
+		status.Leader = h.leader.LeaderStatus()
+	}
 
 	if err := writeJSONPretty(w, r, http.StatusOK, status); err != nil {
 		internalErr := errors.WrapError(err, errors.CategoryInternal, "failed to encode daemon status").
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/server/responses"
 )
 
 type stubDaemon struct{}
@@ -31,3 +33,38 @@ func TestHandleDocsStatus_OK(t *testing.T) {
 		t.Fatalf("expected application/json content type, got %q", ct)
 	}
 }
+
+type stubLeader struct{ status *responses.LeaderStatus }
+
+func (s stubLeader) LeaderStatus() *responses.LeaderStatus { return s.status }
+
+func TestHandleDaemonStatus_Leader(t *testing.T) {
+	cfg := &config.Config{Daemon: &config.DaemonConfig{}}
+	for name, tc := range map[string]struct {
+		provider LeaderStatusProvider
+		want     *responses.LeaderStatus
+	}{
+		"no provider":       {nil, nil},
+		"election disabled": {stubLeader{}, nil},
+		"follower": {
+			stubLeader{&responses.LeaderStatus{Identity: "docbuilder-1", Leader: "docbuilder-0", Transitions: 2}},
+			&responses.LeaderStatus{Identity: "docbuilder-1", Leader: "docbuilder-0", Transitions: 2},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := NewAPIHandlers(cfg, &stubDaemon{}).WithLeaderStatus(tc.provider)
+			rec := httptest.NewRecorder()
+			h.HandleDaemonStatus(rec, httptest.NewRequest(http.MethodGet, "/api/daemon/status", nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			var got responses.DaemonStatusResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if (got.Leader == nil) != (tc.want == nil) || (got.Leader != nil && *got.Leader != *tc.want) {
+				t.Fatalf("leader = %+v, want %+v", got.Leader, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+// Badge kinds served below /api/badges/<repo>/.
+const (
+	BadgeFreshness = "freshness"
+	BadgeCoverage  = "coverage"
+)
+
+// Badge colors (shields.io "flat" palette).
+const (
+	badgeGreen  = "#4c1"
+	badgeYellow = "#dfb317"
+	badgeRed    = "#e05d44"
+	badgeGrey   = "#9f9f9f"
+)
+
+// badgeCacheControl lets README image proxies cache badges for a few minutes.
+const badgeCacheControl = "public, max-age=300"
+
+// Badge is a rendered badge in the shields.io endpoint schema.
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// BadgeHandlers serves README badges computed from the repository statistics of the last build.
+type BadgeHandlers struct {
+	outputDir    func() string
+	cfg          *config.BadgesConfig
+	now          func() time.Time
+	errorAdapter *errors.HTTPErrorAdapter
+}
+
+// NewBadgeHandlers creates badge handlers reading from the directory returned by outputDir.
+func NewBadgeHandlers(outputDir func() string, cfg *config.BadgesConfig) *BadgeHandlers {
+	return &BadgeHandlers{
+		outputDir:    outputDir,
+		cfg:          cfg,
+		now:          time.Now,
+		errorAdapter: errors.NewHTTPErrorAdapter(slog.Default()),
+	}
+}
+
+// HandleBadge serves /api/badges/{repo}/{kind}, where kind is "freshness" (age of the
+// last docs update) or "coverage" (docs pages relative to the number of source files).
+// Unknown repositories get a grey "unknown" badge with status 404 so that embedded
+// images still render.
+//
+// Query parameters:
+//   - format: "svg" (default) or "json" (shields.io endpoint schema)
+func (h *BadgeHandlers) HandleBadge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	kind := r.PathValue("kind")
+	if kind != BadgeFreshness && kind != BadgeCoverage {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("badge").
+			WithContext("kind", kind).
+			WithContext("allowed_kinds", BadgeFreshness+", "+BadgeCoverage).
+			Build())
+		return
+	}
+
+	status := http.StatusOK
+	badge, found, err := h.badge(r.PathValue("repo"), kind)
+	if err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if !found {
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Cache-Control", badgeCacheControl)
+	if r.URL.Query().Get("format") == "json" {
+		if err := writeJSON(w, status, badge); err != nil {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write badge").Build())
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(renderBadgeSVG(badge)))
+}
+
+// badge computes the badge of a repository; found is false when the last build has no
+// statistics for it.
+func (h *BadgeHandlers) badge(repo, kind string) (Badge, bool, error) {
+	badge := Badge{SchemaVersion: 1, Label: "docs updated", Message: "unknown", Color: badgeGrey}
+	if kind == BadgeCoverage {
+		badge.Label = "docs coverage"
+	}
+
+	// #nosec G304 -- path is derived from the configured output directory
+	data, err := os.ReadFile(filepath.Join(h.outputDir(), hugo.RepositoryStatsFile))
+	if os.IsNotExist(err) {
+		return badge, false, nil
+	}
+	if err != nil {
+		return badge, false, errors.WrapError(err, errors.CategoryFileSystem, "failed to read repository stats").Build()
+	}
+	var stats hugo.RepositoryStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return badge, false, errors.WrapError(err, errors.CategoryInternal, "failed to parse repository stats").Build()
+	}
+	repoStats, ok := stats.Repositories[repo]
+	if !ok {
+		return badge, false, nil
+	}
+
+	switch kind {
+	case BadgeFreshness:
+		if repoStats.LastUpdated.IsZero() {
+			break
+		}
+		days := int(h.now().Sub(repoStats.LastUpdated).Hours() / 24)
+		badge.Message = formatAge(days)
+		switch {
+		case days <= h.cfg.FreshThreshold():
+			badge.Color = badgeGreen
+		case days < h.cfg.StaleThreshold():
+			badge.Color = badgeYellow
+		default:
+			badge.Color = badgeRed
+		}
+	case BadgeCoverage:
+		percent, ok := repoStats.Coverage(h.cfg.FilesPerPage())
+		if !ok {
+			break
+		}
+		badge.Message = fmt.Sprintf("%d%%", percent)
+		switch {
+		case percent >= 80:
+			badge.Color = badgeGreen
+		case percent >= 50:
+			badge.Color = badgeYellow
+		default:
+			badge.Color = badgeRed
+		}
+	}
+	return badge, true, nil
+}
+
+// formatAge renders an age in days the way README badges usually do.
+func formatAge(days int) string {
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "1 day ago"
+	case days < 60:
+		return fmt.Sprintf("%d days ago", days)
+	case days < 730:
+		return fmt.Sprintf("%d months ago", days/30)
+	default:
+		return fmt.Sprintf("%d years ago", days/365)
+	}
+}
+
+// renderBadgeSVG renders a flat two-part badge. Text widths are estimated from the
+// character count, which is close enough for the short labels used here.
+func renderBadgeSVG(b Badge) string {
+	const charWidth, padding = 7, 10
+	lw := len(b.Label)*charWidth + padding
+	mw := len(b.Message)*charWidth + padding
+	label, message := html.EscapeString(b.Label), html.EscapeString(b.Message)
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+<title>%[4]s: %[5]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>
+<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>
+</g>
+</svg>
+`, lw+mw, lw, mw, label, message, b.Color, lw/2, lw+mw/2)
+}
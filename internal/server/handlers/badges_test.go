@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+func serveBadge(t *testing.T, h *BadgeHandlers, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/badges/{repo}/{kind}", h.HandleBadge)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandleBadge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	sources, none := 40, 0
+	stats := hugo.RepositoryStats{Repositories: map[string]hugo.RepositoryDocStats{
+		"alpha": {Pages: 3, LastUpdated: now.AddDate(0, 0, -5), SourceFiles: &sources},
+		"beta":  {Pages: 2, LastUpdated: now.AddDate(-1, 0, 0), SourceFiles: &none},
+		"gamma": {Pages: 1, LastUpdated: now.AddDate(0, -2, 0)},
+	}}
+	b, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hugo.RepositoryStatsFile), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := NewBadgeHandlers(func() string { return dir }, &config.BadgesConfig{Enabled: true})
+	h.now = func() time.Time { return now }
+
+	for target, want := range map[string]Badge{
+		"/api/badges/alpha/freshness?format=json": {Label: "docs updated", Message: "5 days ago", Color: badgeGreen},
+		"/api/badges/gamma/freshness?format=json": {Label: "docs updated", Message: "2 months ago", Color: badgeYellow},
+		"/api/badges/beta/freshness?format=json":  {Label: "docs updated", Message: "12 months ago", Color: badgeRed},
+		"/api/badges/alpha/coverage?format=json":  {Label: "docs coverage", Message: "75%", Color: badgeYellow},
+		"/api/badges/beta/coverage?format=json":   {Label: "docs coverage", Message: "100%", Color: badgeGreen},
+		"/api/badges/gamma/coverage?format=json":  {Label: "docs coverage", Message: "unknown", Color: badgeGrey},
+	} {
+		rec := serveBadge(t, h, target)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+		var got Badge
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: unmarshal: %v", target, err)
+		}
+		want.SchemaVersion = 1
+		if got != want {
+			t.Fatalf("%s: got %+v, want %+v", target, got, want)
+		}
+	}
+
+	rec := serveBadge(t, h, "/api/badges/alpha/freshness")
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "image/svg+xml") {
+		t.Fatalf("expected SVG by default, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "docs updated: 5 days ago") {
+		t.Fatalf("unexpected SVG: %s", rec.Body.String())
+	}
+
+	rec = serveBadge(t, h, "/api/badges/unknown/freshness")
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "unknown") {
+		t.Fatalf("expected 404 with an unknown badge, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec = serveBadge(t, h, "/api/badges/alpha/stars"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unsupported badge kind, got %d", rec.Code)
+	}
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"encoding/json"
+	stdErrors "errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
@@ -19,6 +22,7 @@ type BuildHandlers struct {
 type DaemonBuildInterface interface {
 	TriggerDiscovery() string
 	TriggerBuild() string
+	TriggerScopedBuild(repositories []string, commits map[string]string) (string, error)
 	GetQueueLength() int
 	GetActiveJobs() int
 }
@@ -44,6 +48,8 @@ func (h *BuildHandlers) handleTriggerAction(w http.ResponseWriter, r *http.Reque
 
 	if h.daemon != nil {
 		jobID := triggerFunc()
+		// Request ID is added by the context-aware log handler, linking the request to the job.
+		slog.InfoContext(r.Context(), "Trigger accepted", slog.String("service", serviceName), slog.String("job_id", jobID))
 		response := &responses.TriggerResponse{
 			Status: "triggered",
 			JobID:  jobID,
@@ -66,9 +72,53 @@ func (h *BuildHandlers) HandleTriggerDiscovery(w http.ResponseWriter, r *http.Re
 	h.handleTriggerAction(w, r, "discovery", h.daemon.TriggerDiscovery, "failed to encode discovery trigger response")
 }
 
-// HandleTriggerBuild handles the build trigger endpoint.
+// maxTriggerBodyBytes bounds the optional JSON body of the build trigger endpoint.
+const maxTriggerBodyBytes = 64 << 10
+
+// triggerBuildRequest is the optional JSON body accepted by HandleTriggerBuild.
+type triggerBuildRequest struct {
+	// Repositories limits fetching to these repositories or monorepo sections.
+	Repositories []string `json:"repositories,omitempty"`
+	// Commits builds repositories (by name) from exact commit SHAs instead of their branch heads.
+	Commits map[string]string `json:"commits,omitempty"`
+}
+
+// HandleTriggerBuild handles the build trigger endpoint. A JSON body with
+// "repositories" requests a scoped build and "commits" pins repositories to exact
+// SHAs; an empty body rebuilds everything.
 func (h *BuildHandlers) HandleTriggerBuild(w http.ResponseWriter, r *http.Request) {
-	h.handleTriggerAction(w, r, "build", h.daemon.TriggerBuild, "failed to encode build trigger response")
+	var req triggerBuildRequest
+	if r.Method == http.MethodPost && r.Body != nil {
+		err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTriggerBodyBytes)).Decode(&req)
+		if err != nil && !stdErrors.Is(err, io.EOF) {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryValidation, "invalid build trigger payload").Build())
+			return
+		}
+	}
+	if len(req.Repositories) == 0 && len(req.Commits) == 0 {
+		h.handleTriggerAction(w, r, "build", h.daemon.TriggerBuild, "failed to encode build trigger response")
+		return
+	}
+
+	jobID, err := h.daemon.TriggerScopedBuild(req.Repositories, req.Commits)
+	if err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Trigger accepted",
+		slog.String("service", "build"),
+		slog.String("job_id", jobID),
+		slog.Any("repositories", req.Repositories),
+		slog.Any("commits", req.Commits))
+	response := &responses.TriggerResponse{
+		Status:       "triggered",
+		JobID:        jobID,
+		Repositories: req.Repositories,
+		Commits:      req.Commits,
+	}
+	if err := writeJSON(w, http.StatusOK, response); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to encode build trigger response").Build())
+	}
 }
 
 // HandleBuildStatus handles the build status endpoint.
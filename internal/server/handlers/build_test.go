@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/server/responses"
+)
+
+type stubBuildDaemon struct {
+	fullBuilds int
+	scope      []string
+	commits    map[string]string
+}
+
+func (s *stubBuildDaemon) TriggerDiscovery() string { return "discovery-1" }
+func (s *stubBuildDaemon) TriggerBuild() string {
+	s.fullBuilds++
+	return "build-1"
+}
+
+func (s *stubBuildDaemon) TriggerScopedBuild(repositories []string, commits map[string]string) (string, error) {
+	if slices.Contains(repositories, "unknown") {
+		return "", errors.ValidationError("build scope names unknown repositories").Build()
+	}
+	if _, ok := commits["unknown"]; ok {
+		return "", errors.ValidationError("commits name unknown repositories").Build()
+	}
+	s.scope = repositories
+	s.commits = commits
+	return "build-2", nil
+}
+func (s *stubBuildDaemon) GetQueueLength() int { return 0 }
+func (s *stubBuildDaemon) GetActiveJobs() int  { return 0 }
+
+func TestHandleTriggerBuild(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantJobID   string
+		wantScope   []string
+		wantCommits map[string]string
+		wantFull    int
+	}{
+		{name: "empty body builds everything", body: "", wantCode: http.StatusOK, wantJobID: "build-1", wantFull: 1},
+		{name: "empty scope builds everything", body: `{"repositories": []}`, wantCode: http.StatusOK, wantJobID: "build-1", wantFull: 1},
+		{name: "scoped build", body: `{"repositories": ["repo-a", "repo-b"]}`, wantCode: http.StatusOK, wantJobID: "build-2", wantScope: []string{"repo-a", "repo-b"}},
+		{name: "commit pinned build", body: `{"commits": {"repo-a": "0123456789abcdef0123456789abcdef01234567"}}`, wantCode: http.StatusOK, wantJobID: "build-2", wantCommits: map[string]string{"repo-a": "0123456789abcdef0123456789abcdef01234567"}},
+		{name: "commit for unknown repository", body: `{"commits": {"unknown": "0123456789abcdef0123456789abcdef01234567"}}`, wantCode: http.StatusBadRequest},
+		{name: "unknown repository", body: `{"repositories": ["unknown"]}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", body: `{"repositories":`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemon := &stubBuildDaemon{}
+			h := NewBuildHandlers(daemon)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/build/trigger", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.HandleTriggerBuild(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if daemon.fullBuilds != tt.wantFull {
+				t.Fatalf("expected %d full builds, got %d", tt.wantFull, daemon.fullBuilds)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var resp responses.TriggerResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.JobID != tt.wantJobID {
+				t.Fatalf("expected job %q, got %q", tt.wantJobID, resp.JobID)
+			}
+			if !slices.Equal(resp.Repositories, tt.wantScope) || !slices.Equal(daemon.scope, tt.wantScope) {
+				t.Fatalf("expected scope %v, got response %v and daemon %v", tt.wantScope, resp.Repositories, daemon.scope)
+			}
+			if !maps.Equal(resp.Commits, tt.wantCommits) || !maps.Equal(daemon.commits, tt.wantCommits) {
+				t.Fatalf("expected commits %v, got response %v and daemon %v", tt.wantCommits, resp.Commits, daemon.commits)
+			}
+		})
+	}
+}
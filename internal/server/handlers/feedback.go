@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/feedback"
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// maxFeedbackBodyBytes bounds the size of a feedback submission request body.
+const maxFeedbackBodyBytes = 16 * 1024
+
+// FeedbackStore persists and aggregates page feedback.
+type FeedbackStore interface {
+	Record(ctx context.Context, e feedback.Entry) error
+	Report(ctx context.Context, repository string) (*feedback.Report, error)
+}
+
+// FeedbackHandlers contains the page feedback submission and report handlers.
+type FeedbackHandlers struct {
+	store             FeedbackStore
+	maxComment        int
+	resolveRepository func(page string) string
+	errorAdapter      *errors.HTTPErrorAdapter
+}
+
+// NewFeedbackHandlers creates feedback handlers. resolveRepository maps a page path to
+// the repository it was generated from and may be nil.
+func NewFeedbackHandlers(store FeedbackStore, maxComment int, resolveRepository func(page string) string) *FeedbackHandlers {
+	return &FeedbackHandlers{
+		store:             store,
+		maxComment:        maxComment,
+		resolveRepository: resolveRepository,
+		errorAdapter:      errors.NewHTTPErrorAdapter(slog.Default()),
+	}
+}
+
+// feedbackRequest is the JSON body accepted by HandleSubmit.
+type feedbackRequest struct {
+	Page    string `json:"page"`
+	Helpful *bool  `json:"helpful"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// HandleSubmit records a "Was this page helpful?" rating posted by the docs site widget.
+func (h *FeedbackHandlers) HandleSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "POST").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	var req feedbackRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxFeedbackBodyBytes)).Decode(&req); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryValidation, "invalid feedback payload").Build())
+		return
+	}
+	req.Page = strings.TrimSpace(req.Page)
+	req.Comment = strings.TrimSpace(req.Comment)
+	switch {
+	case !strings.HasPrefix(req.Page, "/") || len(req.Page) > 2048:
+		h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("page must be a site path starting with '/'").
+			WithContext("page", req.Page).
+			Build())
+		return
+	case req.Helpful == nil:
+		h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("helpful is required").Build())
+		return
+	case len(req.Comment) > h.maxComment:
+		h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("comment too long").
+			WithContext("length", len(req.Comment)).
+			WithContext("max", h.maxComment).
+			Build())
+		return
+	}
+
+	entry := feedback.Entry{Page: req.Page, Helpful: *req.Helpful, Comment: req.Comment}
+	if h.resolveRepository != nil {
+		entry.Repository = h.resolveRepository(req.Page)
+	}
+	if err := h.store.Record(r.Context(), entry); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if err := writeJSON(w, http.StatusCreated, map[string]string{"status": "recorded"}); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write feedback response").Build())
+	}
+}
+
+// HandleReport serves feedback aggregated per repository and page.
+//
+// Query parameters:
+//   - repository: restrict the report to a single repository
+func (h *FeedbackHandlers) HandleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	report, err := h.store.Report(r.Context(), r.URL.Query().Get("repository"))
+	if err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if err := writeJSONPretty(w, r, http.StatusOK, report); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write feedback report").Build())
+	}
+}
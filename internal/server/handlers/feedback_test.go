@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/feedback"
+)
+
+func newTestFeedbackHandlers(t *testing.T) *FeedbackHandlers {
+	t.Helper()
+	store, err := feedback.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return NewFeedbackHandlers(store, 20, func(page string) string {
+		return strings.Split(strings.Trim(page, "/"), "/")[0]
+	})
+}
+
+func TestFeedbackHandlers_SubmitAndReport(t *testing.T) {
+	h := newTestFeedbackHandlers(t)
+
+	for _, body := range []string{
+		`{"page":"/alpha/intro/","helpful":true}`,
+		`{"page":"/alpha/intro/","helpful":false,"comment":"needs examples"}`,
+	} {
+		rec := httptest.NewRecorder()
+		h.HandleSubmit(rec, httptest.NewRequest(http.MethodPost, "/api/feedback", strings.NewReader(body)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.HandleReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/feedback?repository=alpha", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report feedback.Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if report.Total != 2 || len(report.Repositories) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	page := report.Repositories[0].Pages[0]
+	if page.Helpful != 1 || page.NotHelpful != 1 || len(page.Comments) != 1 {
+		t.Fatalf("unexpected page summary: %+v", page)
+	}
+}
+
+func TestFeedbackHandlers_SubmitValidation(t *testing.T) {
+	h := newTestFeedbackHandlers(t)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "", http.StatusBadRequest},
+		{"malformed json", http.MethodPost, `{`, http.StatusBadRequest},
+		{"relative page", http.MethodPost, `{"page":"alpha/","helpful":true}`, http.StatusBadRequest},
+		{"missing rating", http.MethodPost, `{"page":"/alpha/"}`, http.StatusBadRequest},
+		{"comment too long", http.MethodPost, `{"page":"/alpha/","helpful":false,"comment":"` + strings.Repeat("x", 21) + `"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.HandleSubmit(rec, httptest.NewRequest(tt.method, "/api/feedback", strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
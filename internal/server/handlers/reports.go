@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+// ReportHandlers serves build-time reports persisted in the output directory.
+type ReportHandlers struct {
+	outputDir    func() string
+	errorAdapter *errors.HTTPErrorAdapter
+}
+
+// NewReportHandlers creates report handlers reading from the directory returned by outputDir.
+func NewReportHandlers(outputDir func() string) *ReportHandlers {
+	return &ReportHandlers{
+		outputDir:    outputDir,
+		errorAdapter: errors.NewHTTPErrorAdapter(slog.Default()),
+	}
+}
+
+// HandleStalenessReport serves the staleness report of the last build.
+//
+// Query parameters:
+//   - repository: restrict the report to a single repository
+//   - format: "json" (default) or "markdown"
+func (h *ReportHandlers) HandleStalenessReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	// #nosec G304 -- path is derived from the configured output directory
+	data, err := os.ReadFile(filepath.Join(h.outputDir(), hugo.StalenessReportJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("staleness report").
+				WithContext("hint", "enable staleness in the configuration and run a build").
+				Build())
+			return
+		}
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryFileSystem, "failed to read staleness report").Build())
+		return
+	}
+	var report hugo.StalenessReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to parse staleness report").Build())
+		return
+	}
+
+	if repo := r.URL.Query().Get("repository"); repo != "" {
+		pages := report.Repositories[repo]
+		report.Repositories = map[string][]hugo.StalePage{}
+		report.StalePages = len(pages)
+		if len(pages) > 0 {
+			report.Repositories[repo] = pages
+		}
+	}
+
+	if r.URL.Query().Get("format") == "markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(report.Markdown()))
+		return
+	}
+
+	if err := writeJSONPretty(w, r, http.StatusOK, report); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write staleness report").Build())
+	}
+}
+
+// HandleGuardrailReport serves the content guardrail violations of the last build, including
+// builds that failed because of them.
+//
+// Query parameters:
+//   - repository: restrict the report to a single repository
+func (h *ReportHandlers) HandleGuardrailReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	// #nosec G304 -- path is derived from the configured output directory
+	data, err := os.ReadFile(filepath.Join(h.outputDir(), hugo.GuardrailReportJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("guardrail report").
+				WithContext("hint", "configure guardrails and run a build").
+				Build())
+			return
+		}
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryFileSystem, "failed to read guardrail report").Build())
+		return
+	}
+	var report hugo.GuardrailReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to parse guardrail report").Build())
+		return
+	}
+
+	if repo := r.URL.Query().Get("repository"); repo != "" {
+		violations := report.Violations[:0]
+		for _, v := range report.Violations {
+			if v.Repository == repo {
+				violations = append(violations, v)
+			}
+		}
+		report.Violations = violations
+	}
+
+	if err := writeJSONPretty(w, r, http.StatusOK, report); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write guardrail report").Build())
+	}
+}
+
+// HandleOwners serves the section -> owners map of the last build.
+//
+// Query parameters:
+//   - path: return only the most specific section containing this site path
+func (h *ReportHandlers) HandleOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	// #nosec G304 -- path is derived from the configured output directory
+	data, err := os.ReadFile(filepath.Join(h.outputDir(), hugo.OwnershipMapFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("ownership map").
+				WithContext("hint", "add a CODEOWNERS or DOCS_OWNERS file to a repository and run a build").
+				Build())
+			return
+		}
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryFileSystem, "failed to read ownership map").Build())
+		return
+	}
+	var owners hugo.OwnershipMap
+	if err := json.Unmarshal(data, &owners); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to parse ownership map").Build())
+		return
+	}
+
+	var payload any = owners
+	if sitePath := r.URL.Query().Get("path"); sitePath != "" {
+		section := owners.Lookup(sitePath)
+		if section == nil {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("no owners for path").
+				WithContext("path", sitePath).
+				Build())
+			return
+		}
+		payload = section
+	}
+
+	if err := writeJSONPretty(w, r, http.StatusOK, payload); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write ownership map").Build())
+	}
+}
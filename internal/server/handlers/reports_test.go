@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func writeStalenessReport(t *testing.T, dir string) {
+	t.Helper()
+	report := hugo.StalenessReport{
+		MaxAgeDays: 90,
+		TotalPages: 3,
+		StalePages: 2,
+		Repositories: map[string][]hugo.StalePage{
+			"alpha": {{URL: "/alpha/old/", Source: "docs/old.md", LastModified: "2024-01-01", AgeDays: 400}},
+			"beta":  {{URL: "/beta/legacy/", Source: "docs/legacy.md", LastModified: "2024-06-01", AgeDays: 200}},
+		},
+	}
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hugo.StalenessReportJSON), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestHandleStalenessReport_FilterByRepository(t *testing.T) {
+	dir := t.TempDir()
+	writeStalenessReport(t, dir)
+	h := NewReportHandlers(func() string { return dir })
+
+	rec := httptest.NewRecorder()
+	h.HandleStalenessReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/staleness?repository=beta", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got hugo.StalenessReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.StalePages != 1 || len(got.Repositories) != 1 || len(got.Repositories["beta"]) != 1 {
+		t.Fatalf("expected only beta pages, got %+v", got)
+	}
+}
+
+func TestHandleStalenessReport_Markdown(t *testing.T) {
+	dir := t.TempDir()
+	writeStalenessReport(t, dir)
+	h := NewReportHandlers(func() string { return dir })
+
+	rec := httptest.NewRecorder()
+	h.HandleStalenessReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/staleness?format=markdown", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "## alpha") || !strings.Contains(body, "| /beta/legacy/ | docs/legacy.md | 2024-06-01 | 200 |") {
+		t.Fatalf("unexpected markdown report:\n%s", body)
+	}
+}
+
+func TestHandleStalenessReport_Missing(t *testing.T) {
+	h := NewReportHandlers(func() string { return t.TempDir() })
+
+	rec := httptest.NewRecorder()
+	h.HandleStalenessReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/staleness", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestHandleGuardrailReport_FilterByRepository(t *testing.T) {
+	dir := t.TempDir()
+	report := hugo.GuardrailReport{Violations: []models.GuardrailViolation{
+		{Repository: "alpha", Limit: "max_pages", Value: 900, Threshold: 500, Action: "fail"},
+		{Repository: "beta", Limit: "max_asset_size_mb", Path: "docs/big.png", Value: 30 << 20, Threshold: 20 << 20, Action: "warn"},
+	}}
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hugo.GuardrailReportJSON), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := NewReportHandlers(func() string { return dir })
+
+	rec := httptest.NewRecorder()
+	h.HandleGuardrailReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/guardrails?repository=beta", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got hugo.GuardrailReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got.Violations) != 1 || got.Violations[0].Path != "docs/big.png" {
+		t.Fatalf("expected only beta violations, got %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	NewReportHandlers(func() string { return t.TempDir() }).
+		HandleGuardrailReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/guardrails", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without a report, got %d", rec.Code)
+	}
+}
+
+func TestHandleOwners_LookupPath(t *testing.T) {
+	dir := t.TempDir()
+	m := hugo.OwnershipMap{Sections: []hugo.SectionOwners{
+		{Section: "/alpha/", Repository: "alpha", Owners: []string{"@org/writers"}},
+		{Section: "/alpha/api/", Repository: "alpha", Owners: []string{"@org/api-team"}},
+	}}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hugo.OwnershipMapFile), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := NewReportHandlers(func() string { return dir })
+
+	rec := httptest.NewRecorder()
+	h.HandleOwners(rec, httptest.NewRequest(http.MethodGet, "/api/owners?path=/alpha/api/users/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got hugo.SectionOwners
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Section != "/alpha/api/" || len(got.Owners) != 1 || got.Owners[0] != "@org/api-team" {
+		t.Fatalf("unexpected section: %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleOwners(rec, httptest.NewRequest(http.MethodGet, "/api/owners?path=/beta/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unowned path, got %d", rec.Code)
+	}
+}
@@ -38,7 +38,9 @@ type StatusProvider interface {
 	GetBuildProjection() *eventstore.BuildHistoryProjection
 
 	GetLastDiscovery() *time.Time
+	GetNextDiscovery() *time.Time
 	GetDiscoveryResult() (*forge.DiscoveryResult, error)
+	GetDiscoveryCachedAt() (*time.Time, bool)
 }
 
 // DaemonStatus is rendered on the status page.
@@ -54,8 +56,19 @@ type StatusPageData struct {
 	SystemMetrics   SystemMetrics      `json:"system_metrics"`
 	LastUpdated     time.Time          `json:"last_updated"`
 	LastDiscovery   *time.Time         `json:"last_discovery,omitempty"`
+	NextDiscovery   *time.Time         `json:"next_discovery,omitempty"`
 	DiscoveryError  *string            `json:"discovery_error,omitempty"`
 	DiscoveryErrors map[string]string  `json:"discovery_errors,omitempty"`
+
+	// DiscoveryCachedAt is when the discovery result behind Repositories was stored;
+	// DiscoveryRestored is set while that result was loaded from persisted state
+	// and no discovery has completed since the daemon started.
+	DiscoveryCachedAt *time.Time `json:"discovery_cached_at,omitempty"`
+	DiscoveryRestored bool       `json:"discovery_restored,omitempty"`
+
+	// SyncBlackout is set while a blackout window (daemon.sync.blackout_windows) skips
+	// scheduled syncs. NextDiscovery already accounts for blackout windows and jitter.
+	SyncBlackout bool `json:"sync_blackout,omitempty"`
 }
 
 // Info holds basic daemon information.
@@ -167,6 +180,11 @@ func GenerateStatusData(_ context.Context, p StatusProvider) (*StatusPageData, e
 	if last := p.GetLastDiscovery(); last != nil {
 		data.LastDiscovery = last
 	}
+	data.NextDiscovery = p.GetNextDiscovery()
+	if cfg := p.GetConfig(); cfg != nil && cfg.Daemon != nil {
+		data.SyncBlackout = cfg.Daemon.Sync.InBlackout(data.LastUpdated)
+	}
+	data.DiscoveryCachedAt, data.DiscoveryRestored = p.GetDiscoveryCachedAt()
 	res, derr := p.GetDiscoveryResult()
 	if derr != nil {
 		es := derr.Error()
@@ -337,6 +355,7 @@ const statusHTMLTemplate = `<!DOCTYPE html>
                 <span class="status {{if eq .DaemonInfo.Status "running"}}running{{else}}stopped{{end}}">{{.DaemonInfo.Status}}</span>
                 Version {{.DaemonInfo.Version}} • Uptime: {{.DaemonInfo.Uptime}}
             </p>
+            {{if .NextDiscovery}}<p>Next sync: {{.NextDiscovery.Format "2006-01-02 15:04:05 MST"}}{{if .SyncBlackout}} • blackout window active{{end}}</p>{{end}}
         </div>
 
         <div class="metrics">
@@ -22,8 +22,11 @@ type fakeStatusProvider struct {
 	lastBuildTime  *time.Time
 	buildProj      *eventstore.BuildHistoryProjection
 	lastDiscovery  *time.Time
+	nextDiscovery  *time.Time
 	discoveryRes   *forge.DiscoveryResult
 	discoveryErr   error
+	cachedAt       *time.Time
+	restored       bool
 }
 
 func (f fakeStatusProvider) GetStatus() string { return f.status }
@@ -39,9 +42,11 @@ func (f fakeStatusProvider) GetBuildProjection() *eventstore.BuildHistoryProject
 	return f.buildProj
 }
 func (f fakeStatusProvider) GetLastDiscovery() *time.Time { return f.lastDiscovery }
+func (f fakeStatusProvider) GetNextDiscovery() *time.Time { return f.nextDiscovery }
 func (f fakeStatusProvider) GetDiscoveryResult() (*forge.DiscoveryResult, error) {
 	return f.discoveryRes, f.discoveryErr
 }
+func (f fakeStatusProvider) GetDiscoveryCachedAt() (*time.Time, bool) { return f.cachedAt, f.restored }
 
 func TestGenerateStatusData_BasicInfo(t *testing.T) {
 	p := fakeStatusProvider{
@@ -106,3 +111,40 @@ func TestGenerateStatusData_WithBuildProjection_ConvertsStagesAndPopulatesReport
 	require.Len(t, data.BuildStatus.LastBuildErrors, 1)
 	require.Len(t, data.BuildStatus.LastBuildWarnings, 2)
 }
+
+func TestGenerateStatusData_ReportsRestoredDiscoveryCache(t *testing.T) {
+	cachedAt := time.Now().Add(-2 * time.Hour)
+	p := fakeStatusProvider{
+		startTime:    time.Now().Add(-1 * time.Minute),
+		cfg:          &config.Config{Version: "2.0"},
+		discoveryRes: &forge.DiscoveryResult{Repositories: []*forge.Repository{{Name: "api", CloneURL: "https://example.com/api.git"}}},
+		cachedAt:     &cachedAt,
+		restored:     true,
+	}
+
+	data, err := GenerateStatusData(context.Background(), p)
+	require.NoError(t, err)
+	require.Len(t, data.Repositories, 1)
+	require.Equal(t, &cachedAt, data.DiscoveryCachedAt)
+	require.True(t, data.DiscoveryRestored)
+}
+
+func TestGenerateStatusData_ReportsSyncBlackout(t *testing.T) {
+	now := time.Now()
+	next := now.Add(3 * time.Hour)
+	p := fakeStatusProvider{
+		startTime: now.Add(-1 * time.Minute),
+		cfg: &config.Config{Version: "2.0", Daemon: &config.DaemonConfig{Sync: config.SyncConfig{
+			BlackoutWindows: []config.BlackoutWindow{{
+				Start: now.Add(-time.Minute).Format("15:04"),
+				End:   now.Add(2 * time.Minute).Format("15:04"),
+			}},
+		}}},
+		nextDiscovery: &next,
+	}
+
+	data, err := GenerateStatusData(context.Background(), p)
+	require.NoError(t, err)
+	require.True(t, data.SyncBlackout)
+	require.Equal(t, &next, data.NextDiscovery)
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
+)
+
+// TemplateHandlers serves the template index of the last build as JSON.
+type TemplateHandlers struct {
+	outputDir    func() string
+	token        string
+	errorAdapter *errors.HTTPErrorAdapter
+}
+
+// NewTemplateHandlers creates template API handlers reading from the directory returned by
+// outputDir. Requests must carry "Authorization: Bearer <token>".
+func NewTemplateHandlers(outputDir func() string, token string) *TemplateHandlers {
+	return &TemplateHandlers{
+		outputDir:    outputDir,
+		token:        token,
+		errorAdapter: errors.NewHTTPErrorAdapter(slog.Default()),
+	}
+}
+
+// HandleList serves available templates with their schemas, defaults and bodies.
+//
+// Query parameters:
+//   - type: restrict the response to templates of one type
+func (h *TemplateHandlers) HandleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "GET").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="docbuilder"`)
+		h.errorAdapter.WriteErrorResponse(w, r, errors.AuthError("invalid or missing template API token").Build())
+		return
+	}
+
+	index, ok := h.readIndex(w, r)
+	if !ok {
+		return
+	}
+
+	if typ := r.URL.Query().Get("type"); typ != "" {
+		filtered := index.Templates[:0]
+		for _, t := range index.Templates {
+			if t.Type == typ {
+				filtered = append(filtered, t)
+			}
+		}
+		index.Templates = filtered
+	}
+
+	if err := writeJSONPretty(w, r, http.StatusOK, index); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write template index").Build())
+	}
+}
+
+// TemplateRenderRequest is the body of POST /api/templates/render.
+type TemplateRenderRequest struct {
+	// Template is the type or URL of the template.
+	Template string `json:"template"`
+	// Repository selects among templates of the same type from several repositories.
+	Repository string `json:"repository,omitempty"`
+	// Fields are the values of schema fields, like "template new --set". Missing fields
+	// get their defaults.
+	Fields map[string]any `json:"fields,omitempty"`
+	// Sequences are the numbers to use for sequences (e.g. "adr"); others use their start.
+	Sequences map[string]int `json:"sequences,omitempty"`
+}
+
+// TemplateRenderResponse is a rendered template preview.
+type TemplateRenderResponse struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Repository string `json:"repository,omitempty"`
+	OutputPath string `json:"output_path"`
+	Content    string `json:"content"`
+}
+
+// HandleRender renders a template with the given field values and returns the markdown
+// and output path "template new" would write, without writing anything.
+func (h *TemplateHandlers) HandleRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		err := errors.ValidationError("invalid HTTP method").
+			WithContext("method", r.Method).
+			WithContext("allowed_method", "POST").
+			Build()
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="docbuilder"`)
+		h.errorAdapter.WriteErrorResponse(w, r, errors.AuthError("invalid or missing template API token").Build())
+		return
+	}
+
+	var req TemplateRenderRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTemplateRenderBytes)).Decode(&req); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("invalid template render request").
+			WithContext("error", err.Error()).
+			Build())
+		return
+	}
+	if req.Template == "" {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.ValidationError("template is required").Build())
+		return
+	}
+
+	index, ok := h.readIndex(w, r)
+	if !ok {
+		return
+	}
+	entry, err := findTemplate(index, req.Template, req.Repository)
+	if err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	resp, err := renderTemplate(entry, req)
+	if err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, err)
+		return
+	}
+	if err := writeJSONPretty(w, r, http.StatusOK, resp); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to write rendered template").Build())
+	}
+}
+
+// maxTemplateRenderBytes bounds the body of a render request.
+const maxTemplateRenderBytes = 1 << 20
+
+// findTemplate returns the template whose URL or type is name. Templates of one type from
+// several repositories need repository to tell them apart.
+func findTemplate(index *templating.TemplateIndex, name, repository string) (templating.IndexedTemplate, error) {
+	var matches []templating.IndexedTemplate
+	for _, t := range index.Templates {
+		if t.URL == name {
+			return t, nil
+		}
+		if t.Type == name && (repository == "" || t.Repository == repository) {
+			matches = append(matches, t)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return templating.IndexedTemplate{}, errors.NotFoundError("template").
+			WithContext("template", name).
+			WithContext("repository", repository).
+			Build()
+	case 1:
+		return matches[0], nil
+	default:
+		repos := make([]string, 0, len(matches))
+		for _, t := range matches {
+			repos = append(repos, t.Repository)
+		}
+		return templating.IndexedTemplate{}, errors.ValidationError("template type is ambiguous; set repository or use the template URL").
+			WithContext("template", name).
+			WithContext("repositories", strings.Join(repos, ", ")).
+			Build()
+	}
+}
+
+// renderTemplate renders the output path and body of a template like "template new"
+// with --defaults, taking sequence numbers from the request.
+func renderTemplate(entry templating.IndexedTemplate, req TemplateRenderRequest) (*TemplateRenderResponse, error) {
+	page := entry.Page()
+	invalid := func(msg string, err error) error {
+		return errors.ValidationError(msg).
+			WithContext("template", entry.Type).
+			WithContext("error", err.Error()).
+			Build()
+	}
+	schema, err := templating.ParseTemplateSchema(page.Meta.Schema)
+	if err != nil {
+		return nil, invalid("invalid template schema", err)
+	}
+	defaults, err := templating.ParseTemplateDefaults(page.Meta.Defaults)
+	if err != nil {
+		return nil, invalid("invalid template defaults", err)
+	}
+	sequences, err := templating.PageSequences(page)
+	if err != nil {
+		return nil, invalid("invalid template sequence", err)
+	}
+
+	overrides := make(map[string]string, len(req.Fields))
+	for key, value := range req.Fields {
+		overrides[key] = fieldInput(value)
+	}
+	inputs, err := templating.ResolveTemplateInputs(schema, defaults, overrides, true, nil)
+	if err != nil {
+		return nil, invalid("invalid template fields", err)
+	}
+	next := func(name string) (int, error) {
+		if n, ok := req.Sequences[name]; ok {
+			return n, nil
+		}
+		def, ok := sequences[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown sequence: %s", name)
+		}
+		return templating.FirstInSequence(def), nil
+	}
+
+	outputPath, err := templating.RenderOutputPath(page.Meta.OutputPath, inputs, next)
+	if err != nil {
+		return nil, invalid("failed to render template output path", err)
+	}
+	body, err := templating.RenderTemplateBody(page.Body, inputs, next)
+	if err != nil {
+		return nil, invalid("failed to render template", err)
+	}
+	return &TemplateRenderResponse{
+		Type:       entry.Type,
+		Name:       entry.Name,
+		Repository: entry.Repository,
+		OutputPath: outputPath,
+		Content:    body,
+	}, nil
+}
+
+// fieldInput converts a JSON field value to the text form of "template new --set":
+// lists are comma-separated.
+func fieldInput(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fieldInput(item))
+		}
+		return strings.Join(items, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// readIndex reads the template index of the last build, writing an error response
+// when it is missing or unreadable.
+func (h *TemplateHandlers) readIndex(w http.ResponseWriter, r *http.Request) (*templating.TemplateIndex, bool) {
+	// #nosec G304 -- path is derived from the configured output directory
+	data, err := os.ReadFile(filepath.Join(h.outputDir(), templating.TemplateIndexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			h.errorAdapter.WriteErrorResponse(w, r, errors.NotFoundError("template index").
+				WithContext("hint", "add *.template.md pages to a repository and run a build").
+				Build())
+			return nil, false
+		}
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryFileSystem, "failed to read template index").Build())
+		return nil, false
+	}
+	var index templating.TemplateIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		h.errorAdapter.WriteErrorResponse(w, r, errors.WrapError(err, errors.CategoryInternal, "failed to parse template index").Build())
+		return nil, false
+	}
+	return &index, true
+}
+
+// authorized reports whether the request carries the configured bearer token.
+func (h *TemplateHandlers) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(h.token)) == 1
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
+)
+
+func TestTemplateHandlers_HandleList(t *testing.T) {
+	dir := t.TempDir()
+	index := templating.TemplateIndex{Templates: []templating.IndexedTemplate{
+		{Type: "adr", Name: "ADR", OutputPath: "adr/{{ .Slug }}.md", URL: "/repo/adr.template/", Schema: json.RawMessage(`{"fields":[]}`), Body: "# ADR"},
+		{Type: "guide", Name: "Guide", OutputPath: "guides/{{ .Slug }}.md", URL: "/repo/guide.template/", Body: "# Guide"},
+	}}
+	b, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, templating.TemplateIndexFile), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := NewTemplateHandlers(func() string { return dir }, "s3cret")
+
+	tests := []struct {
+		name  string
+		auth  string
+		query string
+		want  int
+		count int
+	}{
+		{"missing token", "", "", http.StatusUnauthorized, 0},
+		{"wrong token", "Bearer nope", "", http.StatusUnauthorized, 0},
+		{"all templates", "Bearer s3cret", "", http.StatusOK, 2},
+		{"filtered by type", "Bearer s3cret", "?type=guide", http.StatusOK, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/templates"+tt.query, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			h.HandleList(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			var got templating.TemplateIndex
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if len(got.Templates) != tt.count {
+				t.Fatalf("expected %d templates, got %+v", tt.count, got.Templates)
+			}
+		})
+	}
+}
+
+func TestTemplateHandlers_MissingIndex(t *testing.T) {
+	h := NewTemplateHandlers(func() string { return t.TempDir() }, "s3cret")
+	req := httptest.NewRequest(http.MethodGet, "/api/templates", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	h.HandleList(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTemplateHandlers_HandleRender(t *testing.T) {
+	dir := t.TempDir()
+	index := templating.TemplateIndex{Templates: []templating.IndexedTemplate{
+		{
+			Type: "adr", Name: "ADR", Repository: "handbook", URL: "/handbook/adr.template/",
+			OutputPath: `adr/adr-{{ printf "%03d" (nextInSequence "adr") }}-{{ .Slug }}.md`,
+			Schema:     json.RawMessage(`{"fields":[{"key":"Title","type":"string","required":true},{"key":"Slug","type":"string","required":true},{"key":"Tags","type":"string_list"}]}`),
+			Body:       "# {{ .Title }}\n\nTags: {{ range .Tags }}{{ . }} {{ end }}\n",
+		},
+		{Type: "guide", Name: "Guide", Repository: "a", URL: "/a/guide.template/", OutputPath: "guide.md", Body: "# A"},
+		{Type: "guide", Name: "Guide", Repository: "b", URL: "/b/guide.template/", OutputPath: "guide.md", Body: "# B"},
+	}}
+	b, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, templating.TemplateIndexFile), b, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := NewTemplateHandlers(func() string { return dir }, "s3cret")
+	render := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/templates/render", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		rec := httptest.NewRecorder()
+		h.HandleRender(rec, req)
+		return rec
+	}
+
+	rec := render(`{"template": "adr", "fields": {"Title": "Use Go", "Slug": "use-go", "Tags": ["lang", "backend"]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got TemplateRenderResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.OutputPath != "adr/adr-001-use-go.md" || got.Content != "# Use Go\n\nTags: lang backend \n" || got.Repository != "handbook" {
+		t.Fatalf("unexpected render: %+v", got)
+	}
+
+	rec = render(`{"template": "/handbook/adr.template/", "fields": {"Title": "T", "Slug": "t"}, "sequences": {"adr": 12}}`)
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.OutputPath != "adr/adr-012-t.md" {
+		t.Fatalf("expected sequence from request, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = render(`{"template": "guide", "repository": "b"}`)
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Content != "# B" {
+		t.Fatalf("expected guide of repository b, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for body, want := range map[string]int{
+		`{"template": "adr", "fields": {"Title": "T"}}`: http.StatusBadRequest,
+		`{"template": "guide"}`:                         http.StatusBadRequest,
+		`{"template": "runbook"}`:                       http.StatusNotFound,
+		`{}`:                                            http.StatusBadRequest,
+	} {
+		if rec := render(body); rec.Code != want {
+			t.Fatalf("%s: expected %d, got %d: %s", body, want, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,20 +15,47 @@ import (
 	"git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 )
 
+// ForceBuildParam is the query parameter that bypasses changed-file filtering for
+// a single webhook delivery (for example "/webhooks/github?force=true").
+const ForceBuildParam = "force"
+
 // WebhookTrigger provides the interface for triggering webhook-based builds.
 type WebhookTrigger interface {
 	// TriggerWebhookBuild triggers a build for the given repository/branch.
 	//
 	// forgeName is the configured forge instance name (config.forges[].name). It
 	// is optional; callers may pass an empty string when the webhook endpoint is
-	// not namespaced by forge.
-	TriggerWebhookBuild(forgeName, repoFullName, branch string, changedFiles []string) string
+	// not namespaced by forge. commit is the pushed head commit when the payload
+	// carries one; the build then uses exactly that commit.
+	TriggerWebhookBuild(forgeName, repoFullName, branch, commit string, changedFiles []string) string
+}
+
+// WebhookBranchFilter restricts which branch pushes may trigger webhook builds.
+type WebhookBranchFilter interface {
+	// CheckWebhookBranch reports whether a push to branch may trigger a build.
+	// When it may not, reason says which allowlist rejected the branch.
+	CheckWebhookBranch(ctx context.Context, forgeName, repoFullName, branch string) (allowed bool, reason string)
+}
+
+// WebhookEventTrigger handles the webhook events that are not pushes.
+type WebhookEventTrigger interface {
+	// TriggerWebhookTagBuild requests a build for a created tag so that versioned sites
+	// publish the new version. It returns "" when the tag does not lead to a build.
+	TriggerWebhookTagBuild(forgeName, repoFullName, tag string) string
+	// TriggerWebhookReleaseBuild records (or, for a deleted release, removes) the
+	// release notes of a repository and requests a build that updates its releases section.
+	TriggerWebhookReleaseBuild(forgeName, repoFullName, action string, release forge.WebhookRelease) string
+	// RenameWebhookRepository moves the state of a renamed or transferred repository to
+	// its new name and URL, and requests the discovery or build that picks it up.
+	RenameWebhookRepository(forgeName, oldFullName string, repo *forge.Repository) string
 }
 
 // WebhookHandlers contains HTTP handlers for webhook integrations.
 type WebhookHandlers struct {
 	errorAdapter  *errors.HTTPErrorAdapter
 	trigger       WebhookTrigger
+	branchFilter  WebhookBranchFilter
+	eventTrigger  WebhookEventTrigger
 	forgeClients  map[string]forge.Client
 	webhookConfig map[string]*config.WebhookConfig
 }
@@ -41,6 +70,20 @@ func NewWebhookHandlers(trigger WebhookTrigger, forgeClients map[string]forge.Cl
 	}
 }
 
+// WithBranchFilter sets the filter consulted before a push requests a build.
+// A nil filter allows every branch.
+func (h *WebhookHandlers) WithBranchFilter(filter WebhookBranchFilter) *WebhookHandlers {
+	h.branchFilter = filter
+	return h
+}
+
+// WithEventTrigger sets the handler of tag, release and repository rename events.
+// Without one these events are acknowledged but trigger nothing.
+func (h *WebhookHandlers) WithEventTrigger(trigger WebhookEventTrigger) *WebhookHandlers {
+	h.eventTrigger = trigger
+	return h
+}
+
 // HandleForgeWebhook handles a webhook for a specific configured forge instance.
 //
 // The forgeName is the configured forge instance name (config.forges[].name),
@@ -164,16 +207,19 @@ func (h *WebhookHandlers) handleForgeWebhookWithValidation(w http.ResponseWriter
 		}
 	}
 
-	// Trigger build for the repository if event was parsed successfully
-	jobID := h.triggerBuildFromEvent(event, forgeName)
-
 	resp := map[string]any{
 		"status":    "received",
 		"timestamp": time.Now().UTC(),
 		"event":     eventType,
 		"source":    forgeName,
 	}
-	if jobID != "" {
+
+	// Trigger build for the repository if event was parsed successfully
+	if reason := h.branchSkipReason(r, event, forgeName); reason != "" {
+		resp["status"] = "skipped"
+		resp["reason"] = reason
+		resp["branch"] = eventBranch(event)
+	} else if jobID := h.dispatchEvent(r, event, forgeName); jobID != "" {
 		resp["build_job_id"] = jobID
 	}
 
@@ -221,26 +267,79 @@ func (h *WebhookHandlers) validateWebhookSignature(forgeName, signatureHeader st
 	return nil
 }
 
-// triggerBuildFromEvent triggers a build from a webhook event if valid.
-// Returns the job ID if a build was triggered, empty string otherwise.
-func (h *WebhookHandlers) triggerBuildFromEvent(event *forge.WebhookEvent, forgeName string) string {
-	if event == nil || event.Repository == nil || h.trigger == nil {
-		return ""
+// bypassPathFilter reports whether changed-file filtering is disabled for this
+// delivery, either per forge (webhook.build_all_pushes) or per request (?force=true).
+func (h *WebhookHandlers) bypassPathFilter(r *http.Request, forgeName string) bool {
+	if whCfg := h.webhookConfig[forgeName]; whCfg != nil && whCfg.BuildAllPushes {
+		return true
 	}
+	force, err := strconv.ParseBool(r.URL.Query().Get(ForceBuildParam))
+	return err == nil && force
+}
 
-	// Extract branch from event
-	branch := event.Branch
-	if branch == "" && len(event.Commits) > 0 {
-		// Try to extract from ref (e.g., "refs/heads/main" -> "main")
-		if ref, ok := event.Metadata["ref"]; ok {
-			if after, ok0 := strings.CutPrefix(ref, "refs/heads/"); ok0 {
-				branch = after
+// dispatchEvent hands a parsed event to the trigger for its type and returns the job ID
+// of the requested build, if any. Tag deletions and branch creations or deletions
+// never build.
+func (h *WebhookHandlers) dispatchEvent(r *http.Request, event *forge.WebhookEvent, forgeName string) string {
+	if event == nil || event.Repository == nil {
+		return ""
+	}
+	switch event.Type {
+	case forge.WebhookEventTag:
+		tag := firstNonEmpty(event.Metadata["tag"], event.Branch)
+		if h.eventTrigger == nil || event.Action == "deleted" || tag == "" {
+			return ""
+		}
+		return h.eventTrigger.TriggerWebhookTagBuild(forgeName, event.Repository.FullName, tag)
+	case forge.WebhookEventRelease:
+		if h.eventTrigger == nil || event.Release == nil || event.Metadata["draft"] == "true" {
+			return ""
+		}
+		return h.eventTrigger.TriggerWebhookReleaseBuild(forgeName, event.Repository.FullName, event.Action, *event.Release)
+	case forge.WebhookEventRepository:
+		if event.Action == forge.WebhookActionRenamed || event.Action == forge.WebhookActionTransferred {
+			from := event.Changes["full_name_from"]
+			if h.eventTrigger == nil || from == "" || from == event.Repository.FullName {
+				return ""
 			}
+			return h.eventTrigger.RenameWebhookRepository(forgeName, from, event.Repository)
 		}
+	case forge.WebhookEventBranch:
+		return ""
+	}
+	return h.triggerBuildFromEvent(event, forgeName, h.bypassPathFilter(r, forgeName))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// triggerBuildFromEvent triggers a build from a webhook event if valid.
+// Returns the job ID if a build was triggered, empty string otherwise.
+//
+// The changed-file list lets the daemon skip pushes that touch no docs paths.
+// When filtering is bypassed, or the payload lists only part of the pushed
+// commits, no file list is passed so the daemon always requests a build.
+func (h *WebhookHandlers) triggerBuildFromEvent(event *forge.WebhookEvent, forgeName string, bypassFilter bool) string {
+	if event == nil || event.Repository == nil || h.trigger == nil {
+		return ""
 	}
 
+	branch := eventBranch(event)
 	changedFiles := collectChangedFiles(event)
-	jobID := h.trigger.TriggerWebhookBuild(forgeName, event.Repository.FullName, branch, changedFiles)
+	if bypassFilter || commitListTruncated(event) {
+		slog.Debug("Webhook changed-file filter bypassed",
+			"forge", forgeName,
+			"repo", event.Repository.FullName,
+			"forced", bypassFilter)
+		changedFiles = nil
+	}
+	jobID := h.trigger.TriggerWebhookBuild(forgeName, event.Repository.FullName, branch, eventCommit(event), changedFiles)
 	if jobID != "" {
 		slog.Info("Webhook triggered build",
 			"forge", forgeName,
@@ -252,6 +351,66 @@ func (h *WebhookHandlers) triggerBuildFromEvent(event *forge.WebhookEvent, forge
 	return jobID
 }
 
+// branchSkipReason returns why the pushed branch may not trigger a build, or ""
+// when the event is not a push with a known repository or the branch is allowed.
+func (h *WebhookHandlers) branchSkipReason(r *http.Request, event *forge.WebhookEvent, forgeName string) string {
+	if h.branchFilter == nil || event == nil || event.Repository == nil || event.Type != forge.WebhookEventPush {
+		return ""
+	}
+	branch := eventBranch(event)
+	if branch == "" {
+		return ""
+	}
+	allowed, reason := h.branchFilter.CheckWebhookBranch(r.Context(), forgeName, event.Repository.FullName, branch)
+	if allowed {
+		return ""
+	}
+	slog.InfoContext(r.Context(), "Webhook push skipped (branch not allowed)",
+		"forge", forgeName,
+		"repo", event.Repository.FullName,
+		"branch", branch,
+		"reason", reason)
+	return reason
+}
+
+// eventBranch returns the pushed branch, falling back to the ref metadata.
+// eventCommit returns the pushed head commit of a push event, or "" when the payload
+// has none (for example a branch deletion).
+func eventCommit(event *forge.WebhookEvent) string {
+	if event == nil || event.Type != forge.WebhookEventPush {
+		return ""
+	}
+	sha := event.Metadata["head_commit"]
+	if !config.IsCommitSHA(sha) || strings.Trim(sha, "0") == "" {
+		return ""
+	}
+	return sha
+}
+
+func eventBranch(event *forge.WebhookEvent) string {
+	if event == nil {
+		return ""
+	}
+	branch := event.Branch
+	if branch == "" && len(event.Commits) > 0 {
+		// Try to extract from ref (e.g., "refs/heads/main" -> "main")
+		if ref, ok := event.Metadata["ref"]; ok {
+			if after, ok0 := strings.CutPrefix(ref, "refs/heads/"); ok0 {
+				branch = after
+			}
+		}
+	}
+	return branch
+}
+
+// commitListTruncated reports whether the payload carries fewer commits than were
+// pushed. GitLab and Forgejo cap the embedded commit list (20 by default) and
+// report the real count separately, so the file list would be incomplete.
+func commitListTruncated(event *forge.WebhookEvent) bool {
+	total, err := strconv.Atoi(event.Metadata["total_commits"])
+	return err == nil && total > len(event.Commits)
+}
+
 func collectChangedFiles(event *forge.WebhookEvent) []string {
 	if event == nil || len(event.Commits) == 0 {
 		return nil
@@ -0,0 +1,287 @@
+package httpserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+	"git.home.luguber.info/inful/docbuilder/internal/markdown"
+)
+
+const (
+	browserEditorPrefix = "/_editor/"
+	// browserEditorHeader must be present on preview and save requests. Browsers only send
+	// custom headers cross-origin after a CORS preflight, which this server never grants.
+	browserEditorHeader = "X-Docbuilder-Editor"
+	// browserEditorBaseHeader carries the hash of the content the editor was loaded with.
+	browserEditorBaseHeader = "X-Docbuilder-Editor-Base"
+	maxEditorBodyBytes      = 5 << 20
+)
+
+// handleBrowserEditor serves the in-browser markdown editor for preview mode.
+// URL format: /_editor/<relative-path-to-file>
+//
+//	GET  renders the editor page for the file
+//	POST renders the request body (markdown) to HTML for the live preview pane
+//	PUT  saves the request body back to the file; the preview watcher then rebuilds the site
+func (s *Server) handleBrowserEditor(w http.ResponseWriter, r *http.Request) {
+	// Check if the browser editor is enabled (requires --editor flag)
+	if s.cfg == nil || !s.cfg.Build.BrowserEditor {
+		http.Error(w, "Browser editor not enabled. Use --editor flag with preview command.", http.StatusNotFound)
+		return
+	}
+
+	// Like VS Code edit links, editing is only for preview mode (single local repository)
+	if s.cfg.Daemon != nil && s.cfg.Daemon.Storage.RepoCacheDir != "" {
+		s.log().Warn("Browser editor called in daemon mode - this endpoint is for preview mode only",
+			slog.String("path", r.URL.Path))
+		http.Error(w, "Browser editor is only available in preview mode", http.StatusNotImplemented)
+		return
+	}
+
+	absPath, err := s.resolveEditPath(r.URL.Path, browserEditorPrefix)
+	if err != nil {
+		writeEditError(w, "Browser editor", err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		err = s.serveEditorPage(w, r, absPath)
+	case http.MethodPost:
+		err = serveEditorPreview(w, r)
+	case http.MethodPut:
+		err = saveEditorContent(w, r, absPath)
+	default:
+		w.Header().Set("Allow", "GET, POST, PUT")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		writeEditError(w, "Browser editor", err)
+	}
+}
+
+func (s *Server) serveEditorPage(w http.ResponseWriter, r *http.Request, absPath string) error {
+	content, err := os.ReadFile(absPath) // #nosec G304 -- path validated by resolveEditPath
+	if err != nil {
+		return &editError{
+			message:    "Failed to read file",
+			statusCode: http.StatusInternalServerError,
+			logLevel:   "error",
+			logFields:  []any{slog.String("path", absPath), slog.String("error", err.Error())},
+		}
+	}
+
+	back := r.Referer()
+	if u, parseErr := url.Parse(back); back == "" || parseErr != nil || (u.Host != "" && u.Host != r.Host) {
+		back = "/"
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := editorPageTemplate.Execute(w, map[string]any{
+		"Path":    r.URL.Path[len(browserEditorPrefix):],
+		"Content": string(content),
+		"Hash":    contentHash(content),
+		"Back":    back,
+	}); err != nil {
+		s.log().Warn("Browser editor: failed to render page", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func serveEditorPreview(w http.ResponseWriter, r *http.Request) error {
+	body, err := readEditorRequest(w, r)
+	if err != nil {
+		return err
+	}
+	_, mdBody, _, _, err := frontmatter.Split(body)
+	if err != nil {
+		// Show unparseable front matter as part of the preview rather than failing.
+		mdBody = body
+	}
+	rendered, err := markdown.RenderHTML(mdBody)
+	if err != nil {
+		return &editError{
+			message:    "Failed to render preview",
+			statusCode: http.StatusUnprocessableEntity,
+			logLevel:   "warn",
+			logFields:  []any{slog.String("error", err.Error())},
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(rendered)
+	return nil
+}
+
+// saveEditorContent atomically replaces absPath with the request body. The save is
+// rejected with 409 Conflict when the file changed on disk since the editor loaded it.
+func saveEditorContent(w http.ResponseWriter, r *http.Request, absPath string) error {
+	body, err := readEditorRequest(w, r)
+	if err != nil {
+		return err
+	}
+
+	current, err := os.ReadFile(absPath) // #nosec G304 -- path validated by resolveEditPath
+	if err != nil {
+		return &editError{
+			message:    "Failed to read file",
+			statusCode: http.StatusInternalServerError,
+			logLevel:   "error",
+			logFields:  []any{slog.String("path", absPath), slog.String("error", err.Error())},
+		}
+	}
+	if base := r.Header.Get(browserEditorBaseHeader); base != contentHash(current) {
+		return &editError{
+			message:    "File changed on disk since it was opened; reload the editor",
+			statusCode: http.StatusConflict,
+			logLevel:   "warn",
+			logFields:  []any{slog.String("path", absPath)},
+		}
+	}
+
+	if err := writeFileAtomic(absPath, body); err != nil {
+		return &editError{
+			message:    "Failed to save file",
+			statusCode: http.StatusInternalServerError,
+			logLevel:   "error",
+			logFields:  []any{slog.String("path", absPath), slog.String("error", err.Error())},
+		}
+	}
+
+	slog.Info("Saved file from browser editor", slog.String("path", absPath), slog.Int("bytes", len(body)))
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set(browserEditorBaseHeader, contentHash(body))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// readEditorRequest enforces the same-origin checks for preview and save requests and
+// returns the (size-limited) request body.
+func readEditorRequest(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if r.Header.Get(browserEditorHeader) == "" {
+		return nil, &editError{
+			message:    "Missing " + browserEditorHeader + " header",
+			statusCode: http.StatusForbidden,
+			logLevel:   "warn",
+			logFields:  []any{slog.String("path", r.URL.Path)},
+		}
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			return nil, &editError{
+				message:    "Cross-origin requests are not allowed",
+				statusCode: http.StatusForbidden,
+				logLevel:   "warn",
+				logFields:  []any{slog.String("origin", origin)},
+			}
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEditorBodyBytes))
+	if err != nil {
+		return nil, &editError{
+			message:    "Request body too large or unreadable",
+			statusCode: http.StatusRequestEntityTooLarge,
+			logLevel:   "warn",
+			logFields:  []any{slog.String("error", err.Error())},
+		}
+	}
+	return body, nil
+}
+
+// writeFileAtomic writes content to a hidden temp file next to path (ignored by the
+// preview watcher) and renames it into place, preserving path's permissions.
+func writeFileAtomic(path string, content []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".docbuilder-editor-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+var editorPageTemplate = template.Must(template.New("editor").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Edit {{.Path}}</title>
+<style>
+body{margin:0;font-family:system-ui,sans-serif;display:flex;flex-direction:column;height:100vh}
+header{display:flex;gap:.75rem;align-items:center;padding:.5rem 1rem;border-bottom:1px solid #ddd}
+header code{flex:1}
+main{flex:1;display:flex;min-height:0}
+textarea{flex:1;padding:1rem;border:0;border-right:1px solid #ddd;font:14px/1.5 ui-monospace,monospace;resize:none}
+#preview{flex:1;padding:0 1.5rem;overflow:auto}
+#status{color:#666}
+</style>
+</head>
+<body>
+<header>
+<code>{{.Path}}</code>
+<span id="status"></span>
+<button id="save" type="button">Save</button>
+<a href="{{.Back}}">Back to page</a>
+</header>
+<main>
+<textarea id="source" spellcheck="false">{{.Content}}</textarea>
+<div id="preview"></div>
+</main>
+<script>
+(function(){
+  var base={{.Hash}}, dirty=false, timer=null;
+  var src=document.getElementById('source'), out=document.getElementById('preview'), status=document.getElementById('status');
+  function send(method){
+    return fetch(location.pathname,{method:method,headers:{'X-Docbuilder-Editor':'1','X-Docbuilder-Editor-Base':base,'Content-Type':'text/markdown; charset=utf-8'},body:src.value});
+  }
+  function preview(){
+    send('POST').then(function(r){return r.text();}).then(function(html){out.innerHTML=html;}).catch(function(){});
+  }
+  function save(){
+    status.textContent='Saving…';
+    send('PUT').then(function(r){
+      if(!r.ok){return r.text().then(function(t){throw new Error(t);});}
+      base=r.headers.get('X-Docbuilder-Editor-Base')||base; dirty=false;
+      status.textContent='Saved; rebuilding site';
+    }).catch(function(e){status.textContent='Save failed: '+e.message;});
+  }
+  src.addEventListener('input',function(){dirty=true;status.textContent='Unsaved changes';clearTimeout(timer);timer=setTimeout(preview,300);});
+  document.getElementById('save').addEventListener('click',save);
+  document.addEventListener('keydown',function(e){if((e.ctrlKey||e.metaKey)&&e.key==='s'){e.preventDefault();save();}});
+  window.addEventListener('beforeunload',function(e){if(dirty){e.preventDefault();e.returnValue='';}});
+  preview();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,116 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func newEditorTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	docsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(docsDir, "guide.md"), []byte("---\ntitle: Guide\n---\n# Hello\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		Build:        config.BuildConfig{BrowserEditor: true},
+		Repositories: []config.Repository{{URL: docsDir}},
+	}
+	return &Server{cfg: cfg}, docsDir
+}
+
+func editorRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(browserEditorHeader, "1")
+	return req
+}
+
+func TestHandleBrowserEditor_Gating(t *testing.T) {
+	srv := &Server{cfg: &config.Config{}}
+	w := httptest.NewRecorder()
+	srv.handleBrowserEditor(w, httptest.NewRequest(http.MethodGet, "/_editor/guide.md", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("disabled: status = %d, want 404", w.Code)
+	}
+
+	srv.cfg = &config.Config{
+		Build:  config.BuildConfig{BrowserEditor: true},
+		Daemon: &config.DaemonConfig{Storage: config.StorageConfig{RepoCacheDir: "/tmp/repos"}},
+	}
+	w = httptest.NewRecorder()
+	srv.handleBrowserEditor(w, httptest.NewRequest(http.MethodGet, "/_editor/guide.md", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("daemon mode: status = %d, want 501", w.Code)
+	}
+}
+
+func TestHandleBrowserEditor_PageAndPreview(t *testing.T) {
+	srv, _ := newEditorTestServer(t)
+
+	w := httptest.NewRecorder()
+	srv.handleBrowserEditor(w, httptest.NewRequest(http.MethodGet, "/_editor/guide.md", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "# Hello") {
+		t.Fatalf("page: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	srv.handleBrowserEditor(w, editorRequest(http.MethodPost, "/_editor/guide.md", "---\ntitle: x\n---\n# Hi <script>alert(1)</script>\n"))
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, "<h1>Hi") || strings.Contains(body, "<script>") || strings.Contains(body, "title:") {
+		t.Errorf("preview: status = %d, body = %s", w.Code, body)
+	}
+}
+
+func TestHandleBrowserEditor_Save(t *testing.T) {
+	srv, docsDir := newEditorTestServer(t)
+	path := filepath.Join(docsDir, "guide.md")
+	original, _ := os.ReadFile(path)
+
+	// Missing custom header and cross-origin requests are rejected.
+	w := httptest.NewRecorder()
+	srv.handleBrowserEditor(w, httptest.NewRequest(http.MethodPut, "/_editor/guide.md", strings.NewReader("x")))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("no header: status = %d, want 403", w.Code)
+	}
+	req := editorRequest(http.MethodPut, "/_editor/guide.md", "x")
+	req.Header.Set("Origin", "http://evil.example")
+	w = httptest.NewRecorder()
+	srv.handleBrowserEditor(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("cross origin: status = %d, want 403", w.Code)
+	}
+
+	// Stale base hash conflicts.
+	req = editorRequest(http.MethodPut, "/_editor/guide.md", "x")
+	req.Header.Set(browserEditorBaseHeader, contentHash([]byte("stale")))
+	w = httptest.NewRecorder()
+	srv.handleBrowserEditor(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("stale: status = %d, want 409", w.Code)
+	}
+
+	req = editorRequest(http.MethodPut, "/_editor/guide.md", "# Updated\n")
+	req.Header.Set(browserEditorBaseHeader, contentHash(original))
+	w = httptest.NewRecorder()
+	srv.handleBrowserEditor(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("save: status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if got, _ := os.ReadFile(path); string(got) != "# Updated\n" {
+		t.Errorf("saved content = %q", got)
+	}
+	if w.Header().Get(browserEditorBaseHeader) != contentHash([]byte("# Updated\n")) {
+		t.Error("response should carry the new base hash")
+	}
+	if st, _ := os.Stat(path); st.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600 preserved", st.Mode().Perm())
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(docsDir, ".docbuilder-editor-*")); len(leftovers) != 0 {
+		t.Errorf("temp files left behind: %v", leftovers)
+	}
+}
@@ -0,0 +1,202 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EditorOpener opens a validated local markdown file in an editor for /_edit/ links.
+type EditorOpener interface {
+	// Open opens absPath. A non-empty redirect is sent to the browser instead of returning
+	// to the referring page (used for editor URL schemes such as idea://).
+	Open(ctx context.Context, absPath string) (redirect string, err error)
+}
+
+const (
+	// DefaultEditor is the editor used when none is configured or chosen by the user.
+	DefaultEditor = "vscode"
+	// editorCookie stores the user's preferred editor, set via /_edit/<path>?editor=<name>.
+	editorCookie = "docbuilder_editor"
+	// editorPathPlaceholder is replaced by the absolute file path in editor command templates.
+	editorPathPlaceholder = "{path}"
+	// editorCommandWait is how long a command opener waits for the command to fail before
+	// assuming it is a long-running editor process and returning.
+	editorCommandWait = 2 * time.Second
+)
+
+// builtinEditorCommands are editor templates available without configuration.
+// "vscode" is handled by the VS Code IPC opener rather than a template.
+var builtinEditorCommands = map[string]string{
+	"jetbrains": "idea://open?file={path}",
+	"emacs":     "emacsclient -n {path}",
+	"sublime":   "subl {path}",
+}
+
+// ParseEditorCommand builds an opener from a template. Templates containing "://" are
+// URL schemes the browser is redirected to, with {path} query-escaped; anything else is
+// a command split on whitespace and executed without a shell, with {path} substituted.
+func ParseEditorCommand(template string) (EditorOpener, error) {
+	template = strings.TrimSpace(template)
+	if !strings.Contains(template, editorPathPlaceholder) {
+		return nil, fmt.Errorf("editor template %q must contain %s", template, editorPathPlaceholder)
+	}
+	if strings.Contains(template, "://") {
+		return urlEditorOpener{template: template}, nil
+	}
+	args := strings.Fields(template)
+	if args[0] == editorPathPlaceholder {
+		return nil, fmt.Errorf("editor template %q must start with a command", template)
+	}
+	return commandEditorOpener{args: args, wait: editorCommandWait}, nil
+}
+
+// EditorNames lists the built-in editors plus the configured ones, sorted.
+func EditorNames(custom map[string]string) []string {
+	names := []string{DefaultEditor}
+	for name := range builtinEditorCommands {
+		names = append(names, name)
+	}
+	for name := range custom {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// editorOpener returns the opener for name; configured templates take precedence over built-ins.
+func (s *Server) editorOpener(name string) (EditorOpener, bool) {
+	template, ok := s.cfg.Build.EditorCommands[name]
+	if !ok && name == DefaultEditor {
+		return vscodeEditorOpener{s: s}, true
+	}
+	if !ok {
+		template, ok = builtinEditorCommands[name]
+	}
+	if !ok {
+		return nil, false
+	}
+	opener, err := ParseEditorCommand(template)
+	if err != nil {
+		s.log().Warn("Edit handler: invalid editor template", slog.String("editor", name), slog.String("error", err.Error()))
+		return nil, false
+	}
+	return opener, true
+}
+
+// resolveEditorOpener picks the editor for a request: an explicit ?editor= (remembered in a
+// cookie), then the cookie, then the configured default, then VS Code.
+func (s *Server) resolveEditorOpener(w http.ResponseWriter, r *http.Request) (EditorOpener, string, error) {
+	if name := r.URL.Query().Get("editor"); name != "" {
+		opener, ok := s.editorOpener(name)
+		if !ok {
+			return nil, "", &editError{
+				message:    "Unknown editor: " + name,
+				statusCode: http.StatusBadRequest,
+				logLevel:   "warn",
+				logFields:  []any{slog.String("editor", name)},
+			}
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     editorCookie,
+			Value:    name,
+			Path:     "/",
+			MaxAge:   365 * 24 * 60 * 60,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		return opener, name, nil
+	}
+
+	if c, err := r.Cookie(editorCookie); err == nil {
+		if opener, ok := s.editorOpener(c.Value); ok {
+			return opener, c.Value, nil
+		}
+	}
+
+	name := s.cfg.Build.Editor
+	if name == "" {
+		name = DefaultEditor
+	}
+	opener, ok := s.editorOpener(name)
+	if !ok {
+		return nil, "", &editError{
+			message:    "Configured editor is not available: " + name,
+			statusCode: http.StatusInternalServerError,
+			logLevel:   "error",
+			logFields:  []any{slog.String("editor", name)},
+		}
+	}
+	return opener, name, nil
+}
+
+// vscodeEditorOpener opens files through the VS Code remote CLI and IPC socket.
+type vscodeEditorOpener struct{ s *Server }
+
+func (o vscodeEditorOpener) Open(ctx context.Context, absPath string) (string, error) {
+	return "", o.s.executeVSCodeOpen(ctx, absPath)
+}
+
+// urlEditorOpener redirects the browser to an editor URL scheme handled by the desktop.
+type urlEditorOpener struct{ template string }
+
+func (o urlEditorOpener) Open(_ context.Context, absPath string) (string, error) {
+	return strings.ReplaceAll(o.template, editorPathPlaceholder, url.QueryEscape(absPath)), nil
+}
+
+// commandEditorOpener runs a local editor command such as emacsclient.
+type commandEditorOpener struct {
+	args []string
+	wait time.Duration
+}
+
+func (o commandEditorOpener) Open(_ context.Context, absPath string) (string, error) {
+	args := make([]string, len(o.args))
+	for i, a := range o.args {
+		args[i] = strings.ReplaceAll(a, editorPathPlaceholder, absPath)
+	}
+
+	// Not bound to the request context: GUI editors may keep running after the response.
+	// #nosec G204 -- template comes from the preview command line, path validated by resolveEditPath
+	cmd := exec.Command(args[0], args[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return "", &editError{
+			message:    "Failed to start editor " + args[0],
+			statusCode: http.StatusInternalServerError,
+			logLevel:   "error",
+			logFields:  []any{slog.String("path", absPath), slog.String("error", err.Error())},
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", &editError{
+				message:    "Editor " + args[0] + " failed to open file",
+				statusCode: http.StatusInternalServerError,
+				logLevel:   "error",
+				logFields: []any{
+					slog.String("path", absPath),
+					slog.String("error", err.Error()),
+					slog.String("stderr", stderr.String()),
+				},
+			}
+		}
+	case <-time.After(o.wait):
+		// Still running: a foreground editor process. Wait reaps it when it exits.
+	}
+	return "", nil
+}
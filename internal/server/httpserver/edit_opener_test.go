@@ -0,0 +1,106 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestParseEditorCommand(t *testing.T) {
+	for _, tmpl := range []string{"", "code", "{path} --goto"} {
+		if _, err := ParseEditorCommand(tmpl); err == nil {
+			t.Errorf("ParseEditorCommand(%q) should fail", tmpl)
+		}
+	}
+
+	opener, err := ParseEditorCommand("idea://open?file={path}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirect, err := opener.Open(t.Context(), "/docs/a b.md")
+	if err != nil || redirect != "idea://open?file=%2Fdocs%2Fa+b.md" {
+		t.Errorf("url opener = %q, %v", redirect, err)
+	}
+}
+
+func TestCommandEditorOpener(t *testing.T) {
+	ok := commandEditorOpener{args: []string{"true", "{path}"}, wait: time.Second}
+	if _, err := ok.Open(t.Context(), "/tmp/x.md"); err != nil {
+		t.Errorf("successful command: %v", err)
+	}
+
+	failing := commandEditorOpener{args: []string{"false", "{path}"}, wait: time.Second}
+	if _, err := failing.Open(t.Context(), "/tmp/x.md"); err == nil {
+		t.Error("failing command should return an error")
+	}
+
+	// A long-running editor is left running once the wait elapses.
+	slow := commandEditorOpener{args: []string{"sh", "-c", "sleep 5", "{path}"}, wait: 50 * time.Millisecond}
+	if _, err := slow.Open(t.Context(), "/tmp/x.md"); err != nil {
+		t.Errorf("long-running command: %v", err)
+	}
+}
+
+func TestHandleEditLink_EditorSelection(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.md"), []byte("# Test"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv := &Server{cfg: &config.Config{
+		Build: config.BuildConfig{
+			VSCodeEditLinks: true,
+			Editor:          "emacs",
+			EditorCommands:  map[string]string{"emacs": "true {path}", "zed": "zed://file{path}"},
+		},
+		Repositories: []config.Repository{{URL: tmpDir}},
+	}}
+
+	// Configured default editor runs and returns to the page.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/_edit/test.md", nil)
+	req.Header.Set("Referer", "http://localhost:1316/docs/")
+	srv.handleEditLink(w, req)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "http://localhost:1316/docs/" {
+		t.Fatalf("default editor: %d %q", w.Code, w.Header().Get("Location"))
+	}
+
+	// ?editor= selects a URL-scheme editor and remembers it in a cookie.
+	w = httptest.NewRecorder()
+	srv.handleEditLink(w, httptest.NewRequest(http.MethodGet, "/_edit/test.md?editor=zed", nil))
+	if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "zed://file") {
+		t.Fatalf("Location = %q, want zed URL", loc)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != editorCookie || cookies[0].Value != "zed" {
+		t.Fatalf("cookies = %+v", cookies)
+	}
+
+	// The cookie is honoured on later requests.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/_edit/test.md", nil)
+	req.AddCookie(cookies[0])
+	srv.handleEditLink(w, req)
+	if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "zed://file") {
+		t.Errorf("cookie preference ignored: Location = %q", loc)
+	}
+
+	// Unknown editors are rejected.
+	w = httptest.NewRecorder()
+	srv.handleEditLink(w, httptest.NewRequest(http.MethodGet, "/_edit/test.md?editor=notepad", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown editor: status = %d, want 400", w.Code)
+	}
+}
+
+func TestEditorNames(t *testing.T) {
+	got := strings.Join(EditorNames(map[string]string{"zed": "zed://file{path}", "emacs": "emacsclient {path}"}), ",")
+	if got != "emacs,jetbrains,sublime,vscode,zed" {
+		t.Errorf("EditorNames = %s", got)
+	}
+}
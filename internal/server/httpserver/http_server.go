@@ -7,6 +7,9 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
@@ -28,6 +31,9 @@ type Server struct {
 	opts             Options
 	errorAdapter     *derrors.HTTPErrorAdapter
 
+	// staticRoot is the site directory served by StartStatic; empty in daemon mode.
+	staticRoot string
+
 	// VS Code edit link behavior dependencies (injected for tests).
 	vscodeFindCLI       func(context.Context) string
 	vscodeFindIPCSocket func() string
@@ -35,14 +41,32 @@ type Server struct {
 	// If nil, defaults are used. If empty slice, retries are disabled.
 	vscodeOpenBackoffs []time.Duration
 
+	// Webhook ingress restrictions per forge, built with the webhook mux.
+	webhookIngress []*webhookIngress
+	// fetchHookRanges fetches published forge webhook ranges (injected for tests).
+	fetchHookRanges func(ctx context.Context, apiURL string) ([]netip.Prefix, error)
+
+	// Page indexes behind the 404 page suggestions, reloaded after each build.
+	pageIndexes pageIndexCache
+
 	// Handler modules
 	monitoringHandlers *handlers.MonitoringHandlers
 	apiHandlers        *handlers.APIHandlers
 	buildHandlers      *handlers.BuildHandlers
 	webhookHandlers    *handlers.WebhookHandlers
+	reportHandlers     *handlers.ReportHandlers
+	feedbackHandlers   *handlers.FeedbackHandlers  // nil unless feedback is enabled
+	analyticsHandlers  *handlers.AnalyticsHandlers // nil unless analytics are enabled
+	templateHandlers   *handlers.TemplateHandlers  // nil unless the template API is enabled
+	badgeHandlers      *handlers.BadgeHandlers     // nil unless badges are enabled
 
 	// middleware chain
 	mchain func(http.Handler) http.Handler
+
+	// Connection tracking for shutdown draining, keyed by server name.
+	connMu   sync.Mutex
+	conns    map[string]*connTracker
+	draining atomic.Bool
 }
 
 // New constructs a new HTTP server wiring instance.
@@ -57,7 +81,7 @@ func New(cfg *config.Config, runtime Runtime, opts Options) *Server {
 	s := &Server{
 		cfg:                 cfg,
 		opts:                opts,
-		errorAdapter:        derrors.NewHTTPErrorAdapter(slog.Default()),
+		errorAdapter:        derrors.NewHTTPErrorAdapter(opts.logger()),
 		vscodeFindCLI:       findCodeCLI,
 		vscodeFindIPCSocket: findVSCodeIPCSocket,
 	}
@@ -66,16 +90,45 @@ func New(cfg *config.Config, runtime Runtime, opts Options) *Server {
 
 	// Initialize handler modules
 	s.monitoringHandlers = handlers.NewMonitoringHandlers(adapter)
-	s.apiHandlers = handlers.NewAPIHandlers(cfg, adapter)
+	s.apiHandlers = handlers.NewAPIHandlers(cfg, adapter).WithLeaderStatus(opts.LeaderStatus)
 	s.buildHandlers = handlers.NewBuildHandlers(adapter)
-	s.webhookHandlers = handlers.NewWebhookHandlers(adapter, opts.ForgeClients, opts.WebhookConfigs)
+	s.webhookHandlers = handlers.NewWebhookHandlers(adapter, opts.ForgeClients, opts.WebhookConfigs).
+		WithBranchFilter(opts.WebhookBranchFilter).
+		WithEventTrigger(opts.WebhookEventTrigger)
+	s.reportHandlers = handlers.NewReportHandlers(s.resolveOutputRoot)
+	if opts.FeedbackStore != nil && cfg.Daemon != nil && cfg.Daemon.Feedback.IsEnabled() {
+		s.feedbackHandlers = handlers.NewFeedbackHandlers(opts.FeedbackStore, cfg.Daemon.Feedback.CommentLimit(), s.resolveFeedbackRepository)
+	}
+	if opts.PageViews != nil {
+		s.analyticsHandlers = handlers.NewAnalyticsHandlers(opts.PageViews)
+	}
+	if cfg.Daemon != nil && cfg.Daemon.TemplateAPI.IsEnabled() {
+		s.templateHandlers = handlers.NewTemplateHandlers(s.resolveOutputRoot, cfg.Daemon.TemplateAPI.Token)
+	}
+	if cfg.Daemon != nil && cfg.Daemon.Badges.IsEnabled() {
+		s.badgeHandlers = handlers.NewBadgeHandlers(s.resolveOutputRoot, cfg.Daemon.Badges)
+	}
 
-	// Initialize middleware chain
-	s.mchain = smw.Chain(slog.Default(), s.errorAdapter)
+	// Initialize middleware chain; access logs replace the request log.
+	if s.accessLogConfig() != nil {
+		s.mchain = smw.RecoveryChain(opts.logger(), s.errorAdapter)
+	} else {
+		s.mchain = smw.Chain(opts.logger(), s.errorAdapter)
+	}
 
 	return s
 }
 
+// logger returns the http component logger.
+func (o Options) logger() *slog.Logger {
+	if o.Logger == nil {
+		return slog.Default()
+	}
+	return o.Logger
+}
+
+func (s *Server) log() *slog.Logger { return s.opts.logger() }
+
 type runtimeAdapter struct {
 	runtime Runtime
 }
@@ -89,8 +142,11 @@ func (a *runtimeAdapter) LastDiscoveryDurationSec() int { return a.runtime.LastD
 func (a *runtimeAdapter) LastBuildDurationSec() int     { return a.runtime.LastBuildDurationSec() }
 func (a *runtimeAdapter) TriggerDiscovery() string      { return a.runtime.TriggerDiscovery() }
 func (a *runtimeAdapter) TriggerBuild() string          { return a.runtime.TriggerBuild() }
-func (a *runtimeAdapter) TriggerWebhookBuild(forgeName, repoFullName, branch string, changedFiles []string) string {
-	return a.runtime.TriggerWebhookBuild(forgeName, repoFullName, branch, changedFiles)
+func (a *runtimeAdapter) TriggerScopedBuild(repositories []string, commits map[string]string) (string, error) {
+	return a.runtime.TriggerScopedBuild(repositories, commits)
+}
+func (a *runtimeAdapter) TriggerWebhookBuild(forgeName, repoFullName, branch, commit string, changedFiles []string) string {
+	return a.runtime.TriggerWebhookBuild(forgeName, repoFullName, branch, commit, changedFiles)
 }
 func (a *runtimeAdapter) GetQueueLength() int { return a.runtime.GetQueueLength() }
 
@@ -109,9 +165,12 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 	binds := []preBind{
 		{name: "docs", port: s.cfg.Daemon.HTTP.DocsPort},
-		{name: "webhook", port: s.cfg.Daemon.HTTP.WebhookPort},
 		{name: "admin", port: s.cfg.Daemon.HTTP.AdminPort},
 	}
+	// Serve-only (server role) instances never receive webhooks
+	if !s.opts.ServeOnly {
+		binds = append(binds, preBind{name: "webhook", port: s.cfg.Daemon.HTTP.WebhookPort})
+	}
 	// Add LiveReload port if LiveReload is enabled
 	if s.cfg.Build.LiveReload && s.opts.LiveReloadHub != nil {
 		binds = append(binds, preBind{name: "livereload", port: s.cfg.Daemon.HTTP.LiveReloadPort})
@@ -138,84 +197,96 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 
 	// All ports bound successfully – now start servers handing them their pre-bound listeners.
-	if err := s.startDocsServerWithListener(ctx, binds[0].ln); err != nil {
-		return fmt.Errorf("failed to start docs server: %w", err)
-	}
-	if err := s.startWebhookServerWithListener(ctx, binds[1].ln); err != nil {
-		return fmt.Errorf("failed to start webhook server: %w", err)
-	}
-	if err := s.startAdminServerWithListener(ctx, binds[2].ln); err != nil {
-		return fmt.Errorf("failed to start admin server: %w", err)
-	}
-
-	// Start LiveReload server if enabled
-	if s.cfg.Build.LiveReload && s.opts.LiveReloadHub != nil && len(binds) > 3 {
-		if err := s.startLiveReloadServerWithListener(ctx, binds[3].ln); err != nil {
-			return fmt.Errorf("failed to start livereload server: %w", err)
+	logAttrs := make([]any, 0, len(binds))
+	for _, b := range binds {
+		var err error
+		switch b.name {
+		case "docs":
+			err = s.startDocsServerWithListener(ctx, b.ln)
+		case "admin":
+			err = s.startAdminServerWithListener(ctx, b.ln)
+		case "webhook":
+			err = s.startWebhookServerWithListener(ctx, b.ln)
+		case "livereload":
+			err = s.startLiveReloadServerWithListener(ctx, b.ln)
 		}
-		slog.Info("HTTP servers started",
-			slog.Int("docs_port", s.cfg.Daemon.HTTP.DocsPort),
-			slog.Int("webhook_port", s.cfg.Daemon.HTTP.WebhookPort),
-			slog.Int("admin_port", s.cfg.Daemon.HTTP.AdminPort),
-			slog.Int("livereload_port", s.cfg.Daemon.HTTP.LiveReloadPort))
-	} else {
-		slog.Info("HTTP servers started",
-			slog.Int("docs_port", s.cfg.Daemon.HTTP.DocsPort),
-			slog.Int("webhook_port", s.cfg.Daemon.HTTP.WebhookPort),
-			slog.Int("admin_port", s.cfg.Daemon.HTTP.AdminPort))
+		if err != nil {
+			return fmt.Errorf("failed to start %s server: %w", b.name, err)
+		}
+		logAttrs = append(logAttrs, slog.Int(b.name+"_port", b.port))
 	}
+	s.log().Info("HTTP servers started", logAttrs...)
 	return nil
 }
 
-// Stop gracefully shuts down all HTTP servers.
+// Stop drains and shuts down all HTTP servers. New connections are refused at once;
+// in-flight requests and streams get daemon.http.drain_timeout to finish before the
+// remaining connections are closed. The admin server stops last so probes and metrics
+// stay reachable while the site servers drain.
 func (s *Server) Stop(ctx context.Context) error {
-	var errs []error
-
-	// Stop servers in reverse order
-	if s.liveReloadServer != nil {
-		if err := s.liveReloadServer.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("livereload server shutdown: %w", err))
-		}
+	s.draining.Store(true)
+	grace := (&config.HTTPConfig{}).DrainTimeoutValue()
+	if s.cfg.Daemon != nil {
+		grace = s.cfg.Daemon.HTTP.DrainTimeoutValue()
 	}
 
-	if s.adminServer != nil {
-		if err := s.adminServer.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("admin server shutdown: %w", err))
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	drain := func(name string, srv *http.Server) {
+		drainCtx, cancel := context.WithTimeout(ctx, grace)
+		defer cancel()
+		if _, err := s.drainServer(drainCtx, name, srv); err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("%s server shutdown: %w", name, err))
+			mu.Unlock()
 		}
 	}
 
-	if s.webhookServer != nil {
-		if err := s.webhookServer.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("webhook server shutdown: %w", err))
+	for name, srv := range map[string]*http.Server{"livereload": s.liveReloadServer, "webhook": s.webhookServer, "docs": s.docsServer} {
+		if srv == nil {
+			continue
 		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			drain(name, srv)
+		}()
 	}
+	wg.Wait()
 
-	if s.docsServer != nil {
-		if err := s.docsServer.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("docs server shutdown: %w", err))
-		}
+	if s.adminServer != nil {
+		drain("admin", s.adminServer)
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("shutdown errors: %v", errs)
+		return fmt.Errorf("shutdown errors: %w", errors.Join(errs...))
 	}
 
-	slog.Info("HTTP servers stopped")
+	s.log().Info("HTTP servers stopped")
 	return nil
 }
 
 // startServerWithListener launches an http.Server on a pre-bound listener or binds itself.
 // It standardizes goroutine startup and error logging across server types.
 func (s *Server) startServerWithListener(kind string, srv *http.Server, ln net.Listener) error {
+	s.trackConnections(kind, srv)
 	go func() {
 		var err error
-		if ln != nil {
+		switch {
+		case ln != nil && srv.TLSConfig != nil:
+			err = srv.ServeTLS(ln, "", "")
+		case ln != nil:
 			err = srv.Serve(ln)
-		} else {
+		case srv.TLSConfig != nil:
+			err = srv.ListenAndServeTLS("", "")
+		default:
 			err = srv.ListenAndServe()
 		}
 		if err != nil && err != http.ErrServerClosed {
-			slog.Error(fmt.Sprintf("%s server error", kind), "error", err)
+			s.log().Error(fmt.Sprintf("%s server error", kind), "error", err)
 		}
 	}()
 	return nil
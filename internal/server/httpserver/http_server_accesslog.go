@@ -0,0 +1,37 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	smw "git.home.luguber.info/inful/docbuilder/internal/server/middleware"
+)
+
+// accessLogConfig returns monitoring.logging.access, or nil when access logs are disabled.
+func (s *Server) accessLogConfig() *config.AccessLogConfig {
+	if s.cfg.Monitoring == nil || !s.cfg.Monitoring.Logging.Access.IsEnabled() {
+		return nil
+	}
+	return s.cfg.Monitoring.Logging.Access
+}
+
+// accessLog wraps the handler of the docs, webhook or admin server with access logging
+// when monitoring.logging.access is enabled.
+func (s *Server) accessLog(server string, next http.Handler) http.Handler {
+	cfg := s.accessLogConfig()
+	if cfg == nil {
+		return next
+	}
+	opts := smw.AccessLogOptions{
+		Server:          server,
+		Logger:          s.log(),
+		Format:          string(cfg.EffectiveFormat()),
+		Fields:          cfg.EffectiveFields(),
+		AssetSampleRate: cfg.SampleRate(),
+		AssetExtensions: cfg.EffectiveAssetExtensions(),
+	}
+	if s.opts.AccessLogSink != nil {
+		opts.Writer = s.opts.AccessLogSink(server)
+	}
+	return smw.AccessLog(opts)(next)
+}
@@ -2,11 +2,15 @@ package httpserver
 
 import (
 	"context"
+	"crypto/subtle"
 	"net"
 	"net/http"
-	"os"
 	"path/filepath"
 	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/apitoken"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	templating "git.home.luguber.info/inful/docbuilder/internal/templates"
 )
 
 func (s *Server) startAdminServerWithListener(_ context.Context, ln net.Listener) error {
@@ -18,6 +22,9 @@ func (s *Server) startAdminServerWithListener(_ context.Context, ln net.Listener
 	// Readiness endpoint: only ready when a rendered site exists under <output>/public
 	mux.HandleFunc("/ready", s.handleReadiness)
 	mux.HandleFunc("/readyz", s.handleReadiness) // Kubernetes-style alias
+	if len(s.siteRoutes()) > 0 {
+		mux.HandleFunc("/ready/sites", s.handleSitesReadiness)
+	}
 	// Add enhanced health check endpoint (if daemon is available)
 	if s.opts.EnhancedHealthHandle != nil {
 		mux.HandleFunc("/health/detailed", s.opts.EnhancedHealthHandle)
@@ -38,39 +45,149 @@ func (s *Server) startAdminServerWithListener(_ context.Context, ln net.Listener
 		}
 	}
 
-	// Administrative endpoints
-	mux.HandleFunc("/api/daemon/status", s.apiHandlers.HandleDaemonStatus)
-	mux.HandleFunc("/api/daemon/config", s.apiHandlers.HandleDaemonConfig)
-	mux.HandleFunc("/api/discovery/trigger", s.buildHandlers.HandleTriggerDiscovery)
-	mux.HandleFunc("/api/build/trigger", s.buildHandlers.HandleTriggerBuild)
-	mux.HandleFunc("/api/build/status", s.buildHandlers.HandleBuildStatus)
-	mux.HandleFunc("/api/repositories", s.buildHandlers.HandleRepositories)
+	// Administrative endpoints (protected by daemon.http.admin_token when set)
+	admin := s.requireAdminToken
+	mux.HandleFunc("/api/daemon/status", admin(s.apiHandlers.HandleDaemonStatus))
+	mux.HandleFunc("/api/daemon/config", admin(s.apiHandlers.HandleDaemonConfig))
+	if s.opts.ReloadHandle != nil {
+		mux.HandleFunc("/api/daemon/reload", admin(s.opts.ReloadHandle))
+	}
+	if s.opts.MaintenanceHandle != nil {
+		mux.HandleFunc("/api/daemon/maintenance", admin(s.opts.MaintenanceHandle))
+	}
+	if !s.opts.ServeOnly {
+		// Triggers and build status also accept scoped tokens (daemon.http.token_signing_key).
+		mux.HandleFunc("/api/discovery/trigger", s.requireScope(apitoken.ScopeDiscoveryTrigger, s.buildHandlers.HandleTriggerDiscovery))
+		mux.HandleFunc("/api/build/trigger", s.requireBuildToken(s.buildHandlers.HandleTriggerBuild))
+		mux.HandleFunc("/api/build/status", s.requireScope(apitoken.ScopeBuildStatus, s.buildHandlers.HandleBuildStatus))
+		mux.HandleFunc("/api/repositories", admin(s.buildHandlers.HandleRepositories))
+		if s.opts.BuildCooldownsHandle != nil {
+			mux.HandleFunc("/api/build/cooldowns", admin(s.opts.BuildCooldownsHandle))
+		}
+		if s.opts.SitesHandle != nil {
+			mux.HandleFunc("/api/sites", admin(s.opts.SitesHandle))
+			mux.HandleFunc("/api/sites/{site}", admin(s.opts.SiteHandle))
+			mux.HandleFunc("/api/sites/{site}/build", admin(s.opts.SiteBuildHandle))
+		}
+	}
+	if s.opts.DiscoveryPreviewHandle != nil {
+		mux.HandleFunc("/api/discovery/preview", admin(s.opts.DiscoveryPreviewHandle))
+	}
+	if s.opts.BuildReportHandle != nil {
+		mux.HandleFunc("/api/builds/{id}/report", admin(s.opts.BuildReportHandle))
+	}
+	if s.opts.LintHandle != nil {
+		mux.HandleFunc("/api/lint", s.requireScope(apitoken.ScopeLint, s.opts.LintHandle))
+	}
+	mux.HandleFunc("/api/reports/staleness", admin(s.reportHandlers.HandleStalenessReport))
+	mux.HandleFunc("/api/reports/guardrails", admin(s.reportHandlers.HandleGuardrailReport))
+	mux.HandleFunc("/api/owners", admin(s.reportHandlers.HandleOwners))
+	if s.opts.LinkCheckHandle != nil {
+		mux.HandleFunc("/api/linkcheck", admin(s.opts.LinkCheckHandle))
+	}
+	if s.opts.ReconciliationHandle != nil {
+		mux.HandleFunc("/api/reconciliation", admin(s.opts.ReconciliationHandle))
+	}
+	if s.opts.OnboardHandle != nil {
+		mux.HandleFunc("/api/onboard/check", admin(s.opts.OnboardHandle))
+	}
+	if s.opts.BrokenLinksPageHandle != nil {
+		mux.HandleFunc("/reports/broken-links", admin(s.opts.BrokenLinksPageHandle))
+	}
+	if s.feedbackHandlers != nil {
+		mux.HandleFunc("/api/reports/feedback", admin(s.feedbackHandlers.HandleReport))
+	}
+	if s.analyticsHandlers != nil {
+		mux.HandleFunc("/api/analytics/top-pages", admin(s.analyticsHandlers.HandleTopPages))
+	}
+	if s.templateHandlers != nil {
+		// The template API has its own token (daemon.template_api.token).
+		mux.HandleFunc(templating.TemplateAPIPath, s.templateHandlers.HandleList)
+		mux.HandleFunc(templating.TemplateRenderAPIPath, s.templateHandlers.HandleRender)
+	}
 
 	// Status page endpoint (HTML and JSON)
 	if s.opts.StatusHandle != nil {
-		mux.HandleFunc("/status", s.opts.StatusHandle)
+		mux.HandleFunc("/status", admin(s.opts.StatusHandle))
 	}
 
-	s.adminServer = &http.Server{Handler: s.mchain(mux), ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second, IdleTimeout: 120 * time.Second}
+	if s.opts.RPCHandler != nil {
+		mux.HandleFunc(s.opts.RPCPath, admin(withoutWriteDeadline(s.opts.RPCHandler)))
+	}
+
+	s.adminServer = &http.Server{Handler: s.accessLog("admin", s.mchain(s.rateLimit("admin", mux))), ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second, IdleTimeout: 120 * time.Second}
+	if s.opts.RPCHandler != nil {
+		// gRPC clients need HTTP/2; accept it over cleartext (h2c) next to HTTP/1.1.
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetUnencryptedHTTP2(true)
+		s.adminServer.Protocols = protocols
+	}
 	return s.startServerWithListener("admin", s.adminServer, ln)
 }
 
-func (s *Server) handleReadiness(w http.ResponseWriter, _ *http.Request) {
-	out := s.cfg.Output.Directory
+// withoutWriteDeadline lifts the admin server's write timeout for a handler, so
+// server-streaming RPCs can stay open.
+func withoutWriteDeadline(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		next.ServeHTTP(w, r)
+	}
+}
+
+// requireAdminToken wraps an admin endpoint so that it requires "Authorization: Bearer <token>"
+// when daemon.http.admin_token is configured. Health, readiness and metrics stay open for probes.
+func (s *Server) requireAdminToken(next http.HandlerFunc) http.HandlerFunc {
+	if s.cfg.Daemon == nil || s.cfg.Daemon.HTTP.AdminToken == "" {
+		return next
+	}
+	token := []byte(s.cfg.Daemon.HTTP.AdminToken)
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, ok := bearerToken(r)
+		if !ok || subtle.ConstantTimeCompare([]byte(got), token) != 1 {
+			s.rejectAdminRequest(w, r, "invalid or missing admin token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// resolveOutputRoot returns the absolute output directory (honoring output.base_directory).
+func (s *Server) resolveOutputRoot() string {
+	return resolveOutputRootFor(s.cfg.Output)
+}
+
+// resolveOutputRootFor returns the absolute output directory of an output configuration.
+func resolveOutputRootFor(cfg config.OutputConfig) string {
+	out := cfg.Directory
 	if out == "" {
 		out = defaultSiteDir
 	}
 	// Combine with base_directory if set and path is relative
-	if s.cfg.Output.BaseDirectory != "" && !filepath.IsAbs(out) {
-		out = filepath.Join(s.cfg.Output.BaseDirectory, out)
+	if cfg.BaseDirectory != "" && !filepath.IsAbs(out) {
+		out = filepath.Join(cfg.BaseDirectory, out)
 	}
 	if !filepath.IsAbs(out) {
 		if abs, err := filepath.Abs(out); err == nil {
 			out = abs
 		}
 	}
-	public := filepath.Join(out, "public")
-	if st, err := os.Stat(public); err == nil && st.IsDir() {
+	return out
+}
+
+// handleReadiness reports whether the site of the request host (the main site, or a
+// named site served on its own domain) has been rendered.
+func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	if s.Draining() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready: shutting down"))
+		return
+	}
+	out := s.cfg.Output
+	if site := s.siteForHost(requestHost(r)); site != nil {
+		out = site.output
+	}
+	if s.outputRendered(out) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ready"))
 		return
@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	open := New(&config.Config{Daemon: &config.DaemonConfig{}}, testRuntime{}, Options{})
+	rec := httptest.NewRecorder()
+	open.requireAdminToken(ok)(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("without admin_token endpoints stay open, got %d", rec.Code)
+	}
+
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{AdminToken: "secret"}}}
+	protected := New(cfg, testRuntime{}, Options{}).requireAdminToken(ok)
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/daemon/status", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		protected(rec, req)
+		if rec.Code != want {
+			t.Fatalf("Authorization %q: got %d, want %d", header, rec.Code, want)
+		}
+	}
+}
+
+func TestServeOnly_SkipsWebhookServerAndBuildEndpoints(t *testing.T) {
+	cfg := &config.Config{Daemon: &config.DaemonConfig{Role: config.DaemonRoleServer}}
+	cfg.Monitoring = &config.MonitoringConfig{Health: config.MonitoringHealth{Path: "/health"}}
+	srv := New(cfg, testRuntime{}, Options{ServeOnly: true})
+	if err := srv.Start(t.Context()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	if srv.webhookServer != nil {
+		t.Fatalf("serve-only instances must not start the webhook server")
+	}
+	for path, want := range map[string]int{
+		"/api/daemon/status":     http.StatusOK,
+		"/api/build/trigger":     http.StatusNotFound,
+		"/api/discovery/trigger": http.StatusNotFound,
+		"/api/repositories":      http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		srv.adminServer.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("GET %s: got %d, want %d", path, rec.Code, want)
+		}
+	}
+}
+
+func TestAdminServer_MountsRPCHandlerBehindAdminToken(t *testing.T) {
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{AdminToken: "secret", RPC: true}}}
+	cfg.Monitoring = &config.MonitoringConfig{Health: config.MonitoringHealth{Path: "/health"}}
+	rpc := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	srv := New(cfg, testRuntime{}, Options{RPCPath: "/docbuilder.v1.DaemonService/", RPCHandler: rpc})
+	if err := srv.Start(t.Context()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	if srv.adminServer.Protocols == nil || !srv.adminServer.Protocols.UnencryptedHTTP2() {
+		t.Fatalf("the admin server must accept cleartext HTTP/2 for gRPC clients")
+	}
+	for header, want := range map[string]int{"": http.StatusUnauthorized, "Bearer secret": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/docbuilder.v1.DaemonService/GetStatus", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		srv.adminServer.Handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("Authorization %q: got %d, want %d", header, rec.Code, want)
+		}
+	}
+}
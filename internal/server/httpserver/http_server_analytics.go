@@ -0,0 +1,30 @@
+package httpserver
+
+import "net/http"
+
+// countPageViews is a middleware that records successful GET requests for HTML pages.
+// Only the URL path is used: cookies, referrers and client addresses are ignored.
+func (s *Server) countPageViews(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !isHTMLPagePath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sw := &statusCapture{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if sw.status == http.StatusOK {
+			s.opts.PageViews.Record(r.URL.Path)
+		}
+	})
+}
+
+// statusCapture records the status code written by the wrapped handler.
+type statusCapture struct {
+	http.ResponseWriter
+	status int
+}
+
+func (c *statusCapture) WriteHeader(code int) {
+	c.status = code
+	c.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,41 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/analytics"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestCountPageViews(t *testing.T) {
+	views := analytics.NewPageViews(10)
+	srv := New(&config.Config{}, testRuntime{}, Options{PageViews: views})
+
+	handler := srv.countPageViews(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("<html><body></body></html>"))
+	}))
+
+	requests := []struct{ method, path string }{
+		{http.MethodGet, "/guide/"},
+		{http.MethodGet, "/guide/index.html"},
+		{http.MethodGet, "/missing/"},
+		{http.MethodGet, "/css/theme.css"},
+		{http.MethodHead, "/guide/"},
+	}
+	for _, req := range requests {
+		r := httptest.NewRequest(req.method, req.path, nil)
+		r.Header.Set("Referer", "https://example.com/")
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	top := views.Top(0)
+	if len(top) != 1 || top[0].Path != "/guide/" || top[0].Views != 2 {
+		t.Fatalf("expected only successful HTML GETs counted, got %+v", top)
+	}
+}
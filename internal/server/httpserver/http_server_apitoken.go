@@ -0,0 +1,116 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/apitoken"
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// maxScopedTriggerBody bounds the build trigger body read to check repository scopes.
+const maxScopedTriggerBody = 64 << 10
+
+// requireScope is requireAdminToken for endpoints that also accept a signed token
+// (daemon.http.token_signing_key) granting scope.
+func (s *Server) requireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return s.requireToken(next, func(claims *apitoken.Claims, _ *http.Request) bool {
+		return claims.Allows(scope)
+	})
+}
+
+// requireBuildToken guards the build trigger. Signed tokens limited to repositories
+// may only request builds scoped to those repositories.
+func (s *Server) requireBuildToken(next http.HandlerFunc) http.HandlerFunc {
+	return s.requireToken(next, func(claims *apitoken.Claims, r *http.Request) bool {
+		repos, ok := peekTriggerRepositories(r)
+		return ok && claims.AllowsBuild(repos)
+	})
+}
+
+// requireToken accepts the admin token, or a signed token for which allowed holds.
+func (s *Server) requireToken(next http.HandlerFunc, allowed func(*apitoken.Claims, *http.Request) bool) http.HandlerFunc {
+	if s.cfg.Daemon == nil || s.cfg.Daemon.HTTP.TokenSigningKey == "" {
+		return s.requireAdminToken(next)
+	}
+	adminToken := []byte(s.cfg.Daemon.HTTP.AdminToken)
+	key := []byte(s.cfg.Daemon.HTTP.TokenSigningKey)
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, ok := bearerToken(r)
+		if ok && subtle.ConstantTimeCompare([]byte(got), adminToken) == 1 {
+			next(w, r)
+			return
+		}
+		if !ok || !apitoken.LooksLikeToken(got) {
+			s.rejectAdminRequest(w, r, "invalid or missing admin token")
+			return
+		}
+		claims, err := apitoken.Verify(key, got, time.Now())
+		if errors.Is(err, apitoken.ErrExpired) {
+			s.rejectAdminRequest(w, r, "API token expired")
+			return
+		}
+		if err != nil {
+			s.rejectAdminRequest(w, r, "invalid or missing admin token")
+			return
+		}
+		if !allowed(claims, r) {
+			s.errorAdapter.WriteErrorResponse(w, r, derrors.NewError(derrors.CategoryAuth, "API token scope does not allow this operation").
+				WithCode(derrors.CodeAuthInsufficientScope).
+				WithSeverity(derrors.SeverityWarning).
+				WithContext("subject", claims.Subject).
+				WithContext("token_id", claims.ID).
+				WithContext("scopes", strings.Join(claims.Scopes, " ")).
+				Build())
+			return
+		}
+		s.log().Info("Scoped API token accepted",
+			slog.String("subject", claims.Subject),
+			slog.String("token_id", claims.ID),
+			slog.String("path", r.URL.Path))
+		next(w, r)
+	}
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(got), ok
+}
+
+// rejectAdminRequest answers 401 with a Bearer challenge.
+func (s *Server) rejectAdminRequest(w http.ResponseWriter, r *http.Request, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="docbuilder"`)
+	s.errorAdapter.WriteErrorResponse(w, r, derrors.AuthError(msg).Build())
+}
+
+// peekTriggerRepositories reads the repositories of a build trigger body and restores
+// the body for the handler. It reports false for bodies it cannot read or decode.
+func peekTriggerRepositories(r *http.Request) ([]string, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxScopedTriggerBody+1))
+	_ = r.Body.Close()
+	if err != nil || len(data) > maxScopedTriggerBody {
+		return nil, false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, true
+	}
+	var req struct {
+		Repositories []string `json:"repositories"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, false
+	}
+	return req.Repositories, true
+}
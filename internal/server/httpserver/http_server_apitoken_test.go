@@ -0,0 +1,101 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/apitoken"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestRequireBuildToken(t *testing.T) {
+	key := strings.Repeat("k", apitoken.MinKeyLength)
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{AdminToken: "secret", TokenSigningKey: key}}}
+	var gotBody string
+	handler := New(cfg, testRuntime{}, Options{}).requireBuildToken(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	})
+	issue := func(ttl time.Duration, scopes ...string) string {
+		token, _, err := apitoken.Issue([]byte(key), "ci", scopes, ttl, time.Now())
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		return token
+	}
+	repoToken := issue(time.Hour, apitoken.RepositoryScope("handbook"))
+
+	for name, tc := range map[string]struct {
+		token    string
+		body     string
+		want     int
+		wantCode derrors.ErrorCode
+	}{
+		"admin token":                 {"secret", "", http.StatusOK, ""},
+		"full build scope":            {issue(time.Hour, apitoken.ScopeBuildTrigger), "", http.StatusOK, ""},
+		"repository scope":            {repoToken, `{"repositories":["handbook"]}`, http.StatusOK, ""},
+		"repository scope, other":     {repoToken, `{"repositories":["handbook","api"]}`, http.StatusForbidden, derrors.CodeAuthInsufficientScope},
+		"repository scope, full":      {repoToken, "", http.StatusForbidden, derrors.CodeAuthInsufficientScope},
+		"discovery scope":             {issue(time.Hour, apitoken.ScopeDiscoveryTrigger), "", http.StatusForbidden, derrors.CodeAuthInsufficientScope},
+		"expired token":               {issue(time.Nanosecond, apitoken.ScopeBuildTrigger), "", http.StatusUnauthorized, derrors.CodeAuth},
+		"token signed with other key": {signWithOtherKey(t), "", http.StatusUnauthorized, derrors.CodeAuth},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotBody = ""
+			req := httptest.NewRequest(http.MethodPost, "/api/build/trigger", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tc.want, rec.Body.String())
+			}
+			if tc.want == http.StatusOK {
+				if gotBody != tc.body {
+					t.Fatalf("handler saw body %q, want %q", gotBody, tc.body)
+				}
+				return
+			}
+			var resp derrors.HTTPErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.ErrorCode != string(tc.wantCode) {
+				t.Fatalf("error_code = %q, want %q", resp.ErrorCode, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestRequireScope_WithoutSigningKeyUsesAdminToken(t *testing.T) {
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{AdminToken: "secret"}}}
+	handler := New(cfg, testRuntime{}, Options{}).requireScope(apitoken.ScopeBuildStatus, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	token, _, err := apitoken.Issue([]byte(strings.Repeat("k", apitoken.MinKeyLength)), "ci", []string{apitoken.ScopeBuildStatus}, time.Hour, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/build/status", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("signed tokens must be rejected without token_signing_key, got %d", rec.Code)
+	}
+}
+
+func signWithOtherKey(t *testing.T) string {
+	t.Helper()
+	token, _, err := apitoken.Issue([]byte(strings.Repeat("x", apitoken.MinKeyLength)), "ci", []string{apitoken.ScopeBuildTrigger}, time.Hour, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
@@ -0,0 +1,73 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/sitearchive"
+)
+
+// archivePathPrefix serves the offline site archives on the docs port:
+// <prefix> lists them (JSON, newest first), <prefix>latest downloads the newest one and
+// <prefix><name> downloads a retained one.
+const archivePathPrefix = "/_docbuilder/archive/"
+
+// archiveLatest is the stable download name of the newest archive.
+const archiveLatest = "latest"
+
+// handleArchive serves the archives of post_process.archive.
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dir := s.cfg.ArchiveDirectory()
+	archives, err := sitearchive.List(dir)
+	if err != nil {
+		s.log().Error("failed to list site archives", "dir", dir, "error", err)
+		http.Error(w, "archives unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, archivePathPrefix)
+	if name == "" {
+		type entry struct {
+			sitearchive.Archive
+			URL string `json:"url"`
+		}
+		list := make([]entry, 0, len(archives))
+		for _, a := range archives {
+			list = append(list, entry{Archive: a, URL: archivePathPrefix + a.Name})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		if err := json.NewEncoder(w).Encode(list); err != nil {
+			s.log().Error("failed to write archive list", "error", err)
+		}
+		return
+	}
+
+	// Only names from the listing are served, so the path cannot leave the directory.
+	var file string
+	if name == archiveLatest && len(archives) > 0 {
+		file = archives[0].Name
+	}
+	for _, a := range archives {
+		if a.Name == name {
+			file = a.Name
+			break
+		}
+	}
+	if file == "" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+file+`"`)
+	if name == archiveLatest {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	http.ServeFile(w, r, filepath.Join(dir, file))
+}
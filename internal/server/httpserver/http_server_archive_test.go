@@ -0,0 +1,56 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestHandleArchive(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"site-20260101T000000Z.zip", "site-20260102T000000Z.zip"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{PostProcess: &config.PostProcessConfig{Archive: &config.ArchiveConfig{Enabled: true, Directory: dir}}}
+	srv := New(cfg, testRuntime{}, Options{})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.handleArchive(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get(archivePathPrefix + "latest")
+	if rec.Code != http.StatusOK || rec.Body.String() != "site-20260102T000000Z.zip" {
+		t.Fatalf("latest: got %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="site-20260102T000000Z.zip"` {
+		t.Fatalf("unexpected Content-Disposition %q", got)
+	}
+
+	if rec = get(archivePathPrefix + "site-20260101T000000Z.zip"); rec.Body.String() != "site-20260101T000000Z.zip" {
+		t.Fatalf("named archive: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec = get(archivePathPrefix + "..%2Fsecret"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown archive, got %d", rec.Code)
+	}
+
+	rec = get(archivePathPrefix)
+	var list []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("list: %v (%s)", err, rec.Body.String())
+	}
+	if len(list) != 2 || list[0].URL != archivePathPrefix+"site-20260102T000000Z.zip" {
+		t.Fatalf("unexpected list %+v", list)
+	}
+}
@@ -0,0 +1,248 @@
+package httpserver
+
+import (
+	"compress/gzip"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// gzipETagSuffix marks the ETag of a response compressed on the fly so it never matches
+// the identity representation.
+const gzipETagSuffix = "-gzip"
+
+// minCompressSize is the smallest response (when its length is known) worth compressing.
+const minCompressSize = 1024
+
+// precompressedEncodings lists pre-compressed sibling files in order of preference.
+var precompressedEncodings = []struct{ encoding, ext string }{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// serveFile serves a file from root like http.FileServer, adding a strong ETag and
+// serving pre-compressed siblings (<file>.br, <file>.gz) to clients that accept them.
+// Conditional requests (If-None-Match, If-Modified-Since) are answered with 304 by
+// http.ServeContent inside the file server.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, root string) {
+	fsys := s.outputStorage().FileSystem(root)
+	name := r.URL.Path
+	if strings.HasSuffix(name, "/") {
+		name += "index.html"
+	}
+	info, ok := statFile(fsys, name)
+	// http.FileServer redirects ".../index.html" and directories without a slash; leave those alone.
+	if ok && !strings.HasSuffix(r.URL.Path, "/index.html") {
+		variant := s.pageVariant(r.URL.Path)
+		if variant == "" && s.servePrecompressed(w, r, fsys, name) {
+			return
+		}
+		w.Header().Set("ETag", strongETag(info, variant))
+	}
+	http.FileServer(fsys).ServeHTTP(w, r)
+}
+
+// servePrecompressed serves the first pre-compressed sibling of name the client accepts.
+func (s *Server) servePrecompressed(w http.ResponseWriter, r *http.Request, fsys http.FileSystem, name string) bool {
+	if r.Header.Get("Range") != "" {
+		return false
+	}
+	for _, enc := range precompressedEncodings {
+		if !acceptsEncoding(r, enc.encoding) {
+			continue
+		}
+		f, err := fsys.Open(name + enc.ext)
+		if err != nil {
+			continue
+		}
+		info, err := f.Stat()
+		if err != nil || info.IsDir() {
+			_ = f.Close()
+			continue
+		}
+		h := w.Header()
+		ctype := mime.TypeByExtension(path.Ext(name))
+		if ctype == "" {
+			ctype = "application/octet-stream"
+		}
+		h.Set("Content-Type", ctype)
+		h.Set("Content-Encoding", enc.encoding)
+		h.Add("Vary", "Accept-Encoding")
+		h.Set("ETag", strongETag(info, ""))
+		http.ServeContent(w, r, name, info.ModTime(), f)
+		_ = f.Close()
+		return true
+	}
+	return false
+}
+
+// pageVariant identifies the markup injected into HTML pages (maintenance banner, feedback
+// widget, LiveReload script) so cached pages are revalidated when the injection changes.
+// It is empty when nothing is injected into the response for urlPath.
+func (s *Server) pageVariant(urlPath string) string {
+	if !isHTMLPagePath(urlPath) {
+		return ""
+	}
+	var parts []string
+	if s.opts.Maintenance != nil {
+		if message, active := s.opts.Maintenance.MaintenanceBanner(); active {
+			parts = append(parts, "maintenance:"+message)
+		}
+	}
+	if s.feedbackHandlers != nil {
+		parts = append(parts, "feedback")
+	}
+	if s.cfg.Build.LiveReload && s.opts.LiveReloadHub != nil {
+		parts = append(parts, "livereload")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.Join(parts, "\n")))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// statFile returns the info of a regular file in fsys.
+func statFile(fsys http.FileSystem, name string) (fs.FileInfo, bool) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return nil, false
+	}
+	return info, true
+}
+
+// strongETag derives an ETag from a file's modification time and size, plus an optional variant.
+func strongETag(info fs.FileInfo, variant string) string {
+	tag := fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size())
+	if variant != "" {
+		tag += "-" + variant
+	}
+	return `"` + tag + `"`
+}
+
+// acceptsEncoding reports whether the request's Accept-Encoding allows encoding.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), encoding) {
+			continue
+		}
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
+
+// isCompressible reports whether a content type benefits from gzip compression.
+func isCompressible(contentType string) bool {
+	ctype, _, _ := strings.Cut(contentType, ";")
+	ctype = strings.TrimSpace(strings.ToLower(ctype))
+	switch {
+	case strings.HasPrefix(ctype, "text/"):
+		return true
+	case strings.HasSuffix(ctype, "+xml"), strings.HasSuffix(ctype, "+json"):
+		return true
+	}
+	switch ctype {
+	case "application/javascript", "application/json", "application/xml", "application/wasm":
+		return true
+	}
+	return false
+}
+
+// compressResponses is a middleware that gzips compressible responses on the fly for
+// clients that accept gzip. Responses that are already encoded (pre-compressed files),
+// partial content and small bodies pass through unchanged.
+func compressResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" || !acceptsEncoding(r, "gzip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Cached gzip representations carry a suffixed ETag; match them against the file's tag.
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		if inm := r.Header.Get("If-None-Match"); strings.Contains(inm, gzipETagSuffix+`"`) {
+			r.Header.Set("If-None-Match", strings.ReplaceAll(inm, gzipETagSuffix+`"`, `"`))
+			gw.revalidatesGzip = true
+		}
+		next.ServeHTTP(gw, r)
+		gw.finalize()
+	})
+}
+
+// gzipResponseWriter decides on the first WriteHeader or Write whether to compress the body.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz              *gzip.Writer
+	headerWritten   bool
+	revalidatesGzip bool // the client revalidates a gzip representation (suffixed If-None-Match)
+}
+
+func (g *gzipResponseWriter) WriteHeader(code int) {
+	if g.headerWritten {
+		return
+	}
+	g.headerWritten = true
+	h := g.Header()
+	h.Add("Vary", "Accept-Encoding")
+	switch {
+	case code == http.StatusNotModified && g.revalidatesGzip:
+		suffixETag(h)
+	case code == http.StatusOK && g.shouldCompress():
+		h.Del("Content-Length")
+		h.Del("Accept-Ranges")
+		h.Set("Content-Encoding", "gzip")
+		suffixETag(h)
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+	g.ResponseWriter.WriteHeader(code)
+}
+
+// suffixETag marks the response ETag as the gzip representation.
+func suffixETag(h http.Header) {
+	if etag := h.Get("ETag"); strings.HasSuffix(etag, `"`) {
+		h.Set("ETag", strings.TrimSuffix(etag, `"`)+gzipETagSuffix+`"`)
+	}
+}
+
+func (g *gzipResponseWriter) shouldCompress() bool {
+	h := g.Header()
+	if h.Get("Content-Encoding") != "" || !isCompressible(h.Get("Content-Type")) {
+		return false
+	}
+	if length, err := strconv.Atoi(h.Get("Content-Length")); err == nil && length < minCompressSize {
+		return false
+	}
+	return true
+}
+
+func (g *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !g.headerWritten {
+		if g.Header().Get("Content-Type") == "" {
+			g.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz != nil {
+		return g.gz.Write(b)
+	}
+	return g.ResponseWriter.Write(b)
+}
+
+// finalize flushes the gzip stream once the wrapped handler returns.
+func (g *gzipResponseWriter) finalize() {
+	if g.gz != nil {
+		_ = g.gz.Close()
+	}
+}
@@ -0,0 +1,116 @@
+package httpserver
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func newCompressionTestServer(t *testing.T, maintenance *maintenanceStub) (http.Handler, string) {
+	t.Helper()
+	root := t.TempDir()
+	page := "<html><body>" + strings.Repeat("<p>Aggregated documentation</p>", 200) + "</body></html>"
+	if err := os.WriteFile(filepath.Join(root, "index.html"), []byte(page), 0o600); err != nil {
+		t.Fatalf("write page: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "app.js"), []byte(strings.Repeat("console.log(1);", 200)), 0o600); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "app.js.br"), []byte("brotli-bytes"), 0o600); err != nil {
+		t.Fatalf("write brotli sibling: %v", err)
+	}
+	opts := Options{}
+	if maintenance != nil {
+		opts.Maintenance = maintenance
+	}
+	srv := New(&config.Config{Daemon: &config.DaemonConfig{}}, testRuntime{}, opts)
+	return srv.docsRootHandler(func() string { return root }), page
+}
+
+func serveRequest(h http.Handler, path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDocsServer_GzipAndETag(t *testing.T) {
+	h, page := newCompressionTestServer(t, nil)
+
+	rec := serveRequest(h, "/", map[string]string{"Accept-Encoding": "gzip, deflate"})
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip response, got %d %q", rec.Code, rec.Header().Get("Content-Encoding"))
+	}
+	if rec.Body.Len() >= len(page) {
+		t.Fatalf("expected compressed body smaller than %d bytes, got %d", len(page), rec.Body.Len())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if string(body) != page {
+		t.Fatalf("decompressed body does not match page")
+	}
+	etag := rec.Header().Get("ETag")
+	if !strings.HasSuffix(etag, `-gzip"`) || rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatalf("expected gzip ETag and Vary header, got %q %q", etag, rec.Header().Get("Vary"))
+	}
+
+	rec = serveRequest(h, "/", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+	if rec.Code != http.StatusNotModified || rec.Header().Get("ETag") != etag {
+		t.Fatalf("expected 304 with %q, got %d %q", etag, rec.Code, rec.Header().Get("ETag"))
+	}
+
+	plain := serveRequest(h, "/", nil)
+	if plain.Header().Get("Content-Encoding") != "" || plain.Body.String() != page {
+		t.Fatalf("expected identity response without Accept-Encoding")
+	}
+	rec = serveRequest(h, "/", map[string]string{"If-None-Match": plain.Header().Get("ETag")})
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for identity ETag, got %d", rec.Code)
+	}
+	rec = serveRequest(h, "/", map[string]string{"If-Modified-Since": plain.Header().Get("Last-Modified")})
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for If-Modified-Since, got %d", rec.Code)
+	}
+}
+
+func TestDocsServer_Precompressed(t *testing.T) {
+	h, _ := newCompressionTestServer(t, nil)
+
+	rec := serveRequest(h, "/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	if rec.Header().Get("Content-Encoding") != "br" || rec.Body.String() != "brotli-bytes" {
+		t.Fatalf("expected pre-compressed brotli file, got %q %q", rec.Header().Get("Content-Encoding"), rec.Body.String())
+	}
+	if !strings.Contains(rec.Header().Get("Content-Type"), "javascript") {
+		t.Fatalf("expected original content type, got %q", rec.Header().Get("Content-Type"))
+	}
+
+	rec = serveRequest(h, "/app.js", map[string]string{"Accept-Encoding": "gzip, br;q=0"})
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected on-the-fly gzip when brotli is refused, got %q", rec.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestDocsServer_ETagTracksMaintenanceBanner(t *testing.T) {
+	state := &maintenanceStub{message: "Upgrading"}
+	h, _ := newCompressionTestServer(t, state)
+
+	before := serveRequest(h, "/", nil).Header().Get("ETag")
+	state.active = true
+	rec := serveRequest(h, "/", map[string]string{"If-None-Match": before})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Upgrading") {
+		t.Fatalf("expected full page with banner after maintenance started, got %d", rec.Code)
+	}
+}
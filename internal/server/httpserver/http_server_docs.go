@@ -9,8 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
 )
 
 // parseHugoError extracts useful error information from Hugo build output.
@@ -68,12 +72,15 @@ func (s *Server) resolveAbsoluteOutputDir() string {
 
 // shouldShowStatusPage checks if we should show a status page instead of serving files.
 func (s *Server) shouldShowStatusPage(root string) bool {
+	if s.staticRoot != "" {
+		return false
+	}
 	out := s.resolveAbsoluteOutputDir()
 	if root != out {
 		return false
 	}
 
-	_, err := os.Stat(filepath.Join(out, "public"))
+	_, err := s.outputStorage().Stat(filepath.Join(out, "public"))
 	return os.IsNotExist(err)
 }
 
@@ -95,7 +102,7 @@ func (s *Server) handleStatusPage(w http.ResponseWriter, r *http.Request, root s
 	}
 
 	// For non-root paths, fall through to file server (will likely 404)
-	http.FileServer(http.Dir(root)).ServeHTTP(w, r)
+	http.FileServer(s.outputStorage().FileSystem(root)).ServeHTTP(w, r)
 }
 
 // renderBuildErrorPage renders an error page when build fails.
@@ -142,15 +149,61 @@ func (s *Server) startDocsServerWithListener(_ context.Context, ln net.Listener)
 	mux.HandleFunc("/healthz", s.monitoringHandlers.HandleHealthCheck) // Kubernetes-style alias
 	mux.HandleFunc("/ready", s.handleReadiness)
 	mux.HandleFunc("/readyz", s.handleReadiness) // Kubernetes-style alias
+	if len(s.siteRoutes()) > 0 {
+		mux.HandleFunc("/ready/sites", s.handleSitesReadiness)
+	}
+
+	// Local editor edit link handler for preview mode
+	mux.HandleFunc("/_edit/", s.handleEditLink)
+
+	// In-browser markdown editor for local preview mode
+	mux.HandleFunc(browserEditorPrefix, s.handleBrowserEditor)
 
-	// VS Code edit link handler for local preview mode
-	mux.HandleFunc("/_edit/", s.handleVSCodeEdit)
+	mux.Handle("/", s.mchain(s.siteRouter(s.docsRootHandler(s.resolveDocsRoot))))
 
+	// API endpoint for documentation status
+	mux.HandleFunc("/api/status", s.apiHandlers.HandleDocsStatus)
+
+	// Offline site archives
+	if s.cfg.SiteArchive() != nil {
+		mux.HandleFunc(archivePathPrefix, s.handleArchive)
+	}
+
+	// Page feedback widget and submission endpoint
+	if s.feedbackHandlers != nil {
+		mux.HandleFunc(feedbackScriptPath, s.handleFeedbackScript)
+		mux.HandleFunc("/api/feedback", s.feedbackHandlers.HandleSubmit)
+	}
+
+	// README badges (public so that they can be embedded from forges)
+	if s.badgeHandlers != nil {
+		mux.HandleFunc("/api/badges/{repo}/{kind}", s.badgeHandlers.HandleBadge)
+	}
+
+	// Docs server now uses standard timeouts since SSE moved to separate port
+	s.docsServer = &http.Server{Handler: s.accessLog("docs", mux), ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second, IdleTimeout: 120 * time.Second}
+	if err := s.configureDocsTLS(s.docsServer); err != nil {
+		return err
+	}
+	return s.startServerWithListener("docs", s.docsServer, ln)
+}
+
+// docsRootHandler builds the site handler shared by the daemon docs server and the
+// standalone static server: file serving (or a status page), LiveReload 404 fallback, the
+// 404 page with suggestions, Cache-Control headers and the optional analytics, feedback
+// and LiveReload middleware.
+func (s *Server) docsRootHandler(resolveRoot func() string) http.Handler {
+	return s.docsHandler(resolveRoot, nil)
+}
+
+// docsHandler is docsRootHandler with cache rules that take precedence over the
+// default Cache-Control headers.
+func (s *Server) docsHandler(resolveRoot func() string, cacheRules []config.CacheRule) http.Handler {
 	// Root handler dynamically chooses between the Hugo output directory and the rendered "public" folder.
 	// This lets us begin serving immediately (before a static render completes) while automatically
 	// switching to the fully rendered site once available—without restarting the daemon.
 	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		root := s.resolveDocsRoot()
+		root := resolveRoot()
 
 		// Check if we need to show a status page instead of serving files
 		if s.shouldShowStatusPage(root) {
@@ -158,7 +211,7 @@ func (s *Server) startDocsServerWithListener(_ context.Context, ln net.Listener)
 			return
 		}
 
-		http.FileServer(http.Dir(root)).ServeHTTP(w, r)
+		s.serveFile(w, r, root)
 	})
 
 	// Wrap with 404 fallback that redirects to nearest parent path on LiveReload
@@ -171,7 +224,7 @@ func (s *Server) startDocsServerWithListener(_ context.Context, ln net.Listener)
 		if rec.statusCode == http.StatusNotFound && r.Method == http.MethodGet {
 			// Check if this is a LiveReload-triggered reload via Cookie
 			if cookie, err := r.Cookie("docbuilder_lr_reload"); err == nil && cookie.Value == "1" {
-				root := s.resolveDocsRoot()
+				root := resolveRoot()
 				redirectPath := s.findNearestValidParent(root, r.URL.Path)
 				if redirectPath != "" && redirectPath != r.URL.Path {
 					// Clear the cookie and redirect
@@ -188,40 +241,70 @@ func (s *Server) startDocsServerWithListener(_ context.Context, ln net.Listener)
 			}
 		}
 
+		// Missing pages get the themed 404 page with suggestions
+		if rec.statusCode == http.StatusNotFound && wantsNotFoundPage(r) {
+			s.serveNotFoundPage(w, r, resolveRoot())
+			return
+		}
+
 		// If not redirecting, flush the captured response
 		rec.Flush()
 	})
 
 	// Wrap with Cache-Control headers for static assets
-	rootWithCaching := s.addCacheControlHeaders(rootWithFallback)
+	rootWithCaching := addCacheControlHeadersWithRules(rootWithFallback, cacheRules)
+	if s.opts.PageViews != nil {
+		rootWithCaching = s.countPageViews(rootWithCaching)
+	}
 
-	// Wrap with LiveReload injection middleware if enabled
+	// Wrap with feedback widget injection middleware if enabled
 	rootWithMiddleware := rootWithCaching
+	if s.feedbackHandlers != nil {
+		rootWithMiddleware = s.injectFeedbackScript(rootWithMiddleware)
+	}
+
+	// Wrap with maintenance banner injection middleware if the runtime supports it
+	if s.opts.Maintenance != nil {
+		rootWithMiddleware = s.injectMaintenanceBanner(rootWithMiddleware)
+	}
+
+	// Wrap with LiveReload injection middleware if enabled
 	if s.cfg.Build.LiveReload && s.opts.LiveReloadHub != nil {
-		rootWithMiddleware = s.injectLiveReloadScriptWithPort(rootWithCaching, s.cfg.Daemon.HTTP.LiveReloadPort)
+		rootWithMiddleware = s.injectLiveReloadScriptWithPort(rootWithMiddleware, s.cfg.Daemon.HTTP.LiveReloadPort)
 	}
 
-	mux.Handle("/", s.mchain(rootWithMiddleware))
+	// Compress the final response, after any markup was injected
+	rootWithMiddleware = compressResponses(rootWithMiddleware)
 
-	// API endpoint for documentation status
-	mux.HandleFunc("/api/status", s.apiHandlers.HandleDocsStatus)
+	// Answer configured redirects and vanity URLs before anything is served
+	if rc := s.cfg.Redirects; rc != nil && (len(rc.Rules) > 0 || rc.VanityEnabled()) {
+		rootWithMiddleware = s.redirectRules(rootWithMiddleware)
+	}
 
-	// Docs server now uses standard timeouts since SSE moved to separate port
-	s.docsServer = &http.Server{Handler: mux, ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second, IdleTimeout: 120 * time.Second}
-	return s.startServerWithListener("docs", s.docsServer, ln)
+	return rootWithMiddleware
+}
+
+// outputStorage returns the backend the site is served from.
+func (s *Server) outputStorage() output.Storage {
+	return output.Or(s.opts.OutputStorage)
 }
 
 // resolveDocsRoot picks the directory to serve. Preference order:
 // 1. <outputDir>/public if it exists (Hugo static render completed)
 // 2. <outputDir> (Hugo project scaffold / in-progress).
 func (s *Server) resolveDocsRoot() string {
-	out := s.cfg.Output.Directory
+	return s.resolveDocsRootFor(s.cfg.Output)
+}
+
+// resolveDocsRootFor picks the directory to serve for an output configuration.
+func (s *Server) resolveDocsRootFor(cfg config.OutputConfig) string {
+	out := cfg.Directory
 	if out == "" {
 		out = defaultSiteDir
 	}
 	// Combine with base_directory if set and path is relative
-	if s.cfg.Output.BaseDirectory != "" && !filepath.IsAbs(out) {
-		out = filepath.Join(s.cfg.Output.BaseDirectory, out)
+	if cfg.BaseDirectory != "" && !filepath.IsAbs(out) {
+		out = filepath.Join(cfg.BaseDirectory, out)
 	}
 	// Normalize to absolute path once; failures just return original path
 	if !filepath.IsAbs(out) {
@@ -232,8 +315,9 @@ func (s *Server) resolveDocsRoot() string {
 
 	// First, try the public directory (fully rendered site)
 	public := filepath.Join(out, "public")
-	if st, err := os.Stat(public); err == nil && st.IsDir() {
-		slog.Debug("Serving from primary public directory",
+	storage := s.outputStorage()
+	if st, err := storage.Stat(public); err == nil && st.IsDir() {
+		s.log().Debug("Serving from primary public directory",
 			slog.String("path", public),
 			slog.Time("modified", st.ModTime()))
 		return public
@@ -245,9 +329,9 @@ func (s *Server) resolveDocsRoot() string {
 	// atomic promotion. We also check "<output>_prev" for backward compatibility.
 	for _, prev := range []string{out + ".prev", out + "_prev"} {
 		prevPublic := filepath.Join(prev, "public")
-		if st, err := os.Stat(prevPublic); err == nil && st.IsDir() {
+		if st, err := storage.Stat(prevPublic); err == nil && st.IsDir() {
 			// Serve from previous backup to avoid empty responses during atomic rename
-			slog.Warn("Serving from backup directory - primary public missing",
+			s.log().Warn("Serving from backup directory - primary public missing",
 				slog.String("backup_path", prevPublic),
 				slog.String("expected_path", public),
 				slog.Time("backup_modified", st.ModTime()))
@@ -255,7 +339,7 @@ func (s *Server) resolveDocsRoot() string {
 		}
 	}
 
-	slog.Warn("No public directory found, serving from output root",
+	s.log().Warn("No public directory found, serving from output root",
 		slog.String("path", out),
 		slog.String("expected_public", public),
 		slog.String("expected_backup", out+".prev/public or "+out+"_prev/public"))
@@ -276,7 +360,7 @@ func (s *Server) findNearestValidParent(root, urlPath string) string {
 
 		// Check if this path exists as index.html
 		testPath := filepath.Join(root, urlPath, "index.html")
-		if _, err := os.Stat(testPath); err == nil {
+		if _, err := s.outputStorage().Stat(testPath); err == nil {
 			// Ensure path ends with / for directory-style URLs
 			if !strings.HasSuffix(urlPath, "/") {
 				urlPath += "/"
@@ -287,7 +371,7 @@ func (s *Server) findNearestValidParent(root, urlPath string) string {
 		// Also check direct file
 		if urlPath != "/" {
 			testPath = filepath.Join(root, urlPath)
-			if stat, err := os.Stat(testPath); err == nil && !stat.IsDir() {
+			if stat, err := s.outputStorage().Stat(testPath); err == nil && !stat.IsDir() {
 				return urlPath
 			}
 		}
@@ -303,11 +387,21 @@ func (s *Server) findNearestValidParent(root, urlPath string) string {
 // - HTML pages: no cache (to ensure content updates are immediately visible)
 // - Other assets: short cache (5 minutes).
 func (s *Server) addCacheControlHeaders(next http.Handler) http.Handler {
+	return addCacheControlHeadersWithRules(next, nil)
+}
+
+// addCacheControlHeadersWithRules adds the Cache-Control header of the first matching
+// rule, falling back to the defaults of addCacheControlHeaders.
+func addCacheControlHeadersWithRules(next http.Handler, rules []config.CacheRule) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 
-		// Set cache control header based on asset type
-		setCacheControlForPath(w, path)
+		// Set cache control header based on the site's rules or the asset type
+		if i := slices.IndexFunc(rules, func(rule config.CacheRule) bool { return rule.Matches(path) }); i >= 0 {
+			w.Header().Set("Cache-Control", rules[i].CacheControl)
+		} else {
+			setCacheControlForPath(w, path)
+		}
 
 		next.ServeHTTP(w, r)
 	})
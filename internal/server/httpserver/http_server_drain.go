@@ -0,0 +1,127 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DrainResult describes how one HTTP server drained its connections on shutdown.
+type DrainResult struct {
+	Server      string        // docs | webhook | admin | livereload
+	Connections int           // open connections when draining started
+	Forced      int           // connections still open when the grace period ran out
+	Duration    time.Duration // time spent draining
+}
+
+// DrainRecorder receives drain results when the HTTP servers stop (e.g. for metrics).
+type DrainRecorder interface {
+	RecordDrain(result DrainResult)
+}
+
+// connTracker counts a server's open connections through http.Server.ConnState.
+// Hijacked connections (WebSocket) leave the count; their handlers end through
+// RegisterOnShutdown hooks.
+type connTracker struct {
+	mu    sync.Mutex
+	conns map[net.Conn]http.ConnState
+}
+
+func newConnTracker() *connTracker {
+	return &connTracker{conns: make(map[net.Conn]http.ConnState)}
+}
+
+func (t *connTracker) track(c net.Conn, state http.ConnState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch state {
+	case http.StateClosed, http.StateHijacked:
+		delete(t.conns, c)
+	default:
+		t.conns[c] = state
+	}
+}
+
+// open returns the number of connections that are not closed or hijacked.
+func (t *connTracker) open() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.conns)
+}
+
+// trackConnections installs connection tracking on srv under name.
+func (s *Server) trackConnections(name string, srv *http.Server) {
+	tracker := newConnTracker()
+	s.connMu.Lock()
+	if s.conns == nil {
+		s.conns = make(map[string]*connTracker)
+	}
+	s.conns[name] = tracker
+	s.connMu.Unlock()
+	srv.ConnState = tracker.track
+}
+
+func (s *Server) openConnections(name string) int {
+	s.connMu.Lock()
+	tracker := s.conns[name]
+	s.connMu.Unlock()
+	if tracker == nil {
+		return 0
+	}
+	return tracker.open()
+}
+
+// Draining reports whether the servers are shutting down; readiness probes fail meanwhile.
+func (s *Server) Draining() bool { return s.draining.Load() }
+
+// configureDocsTLS serves the docs server over TLS with HTTP/2 when daemon.http.tls is set.
+func (s *Server) configureDocsTLS(srv *http.Server) error {
+	if s.cfg.Daemon == nil || !s.cfg.Daemon.HTTP.TLSEnabled() {
+		return nil
+	}
+	tlsCfg := s.cfg.Daemon.HTTP.TLS
+	cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load docs TLS certificate: %w", err)
+	}
+	srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	srv.Protocols = protocols
+	return nil
+}
+
+// drainServer stops srv from accepting connections and waits for in-flight requests until
+// ctx ends, then closes whatever is left.
+func (s *Server) drainServer(ctx context.Context, name string, srv *http.Server) (DrainResult, error) {
+	result := DrainResult{Server: name, Connections: s.openConnections(name)}
+	start := time.Now()
+	err := srv.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		result.Forced = s.openConnections(name)
+		err = srv.Close()
+	}
+	result.Duration = time.Since(start)
+	if s.opts.DrainRecorder != nil {
+		s.opts.DrainRecorder.RecordDrain(result)
+	}
+	attrs := []any{
+		slog.String("server", name),
+		slog.Int("connections", result.Connections),
+		slog.Duration("duration", result.Duration),
+	}
+	if result.Forced > 0 {
+		s.log().Warn("HTTP server drain grace period expired; closing remaining connections",
+			append(attrs, slog.Int("forced", result.Forced))...)
+	} else {
+		s.log().Debug("HTTP server drained", attrs...)
+	}
+	return result, err
+}
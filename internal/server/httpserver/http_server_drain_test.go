@@ -0,0 +1,192 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+type drainRecorderStub struct {
+	mu      sync.Mutex
+	results []DrainResult
+}
+
+func (d *drainRecorderStub) RecordDrain(result DrainResult) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.results = append(d.results, result)
+}
+
+// startSlowDocsServer serves a handler that blocks until release is closed.
+func startSlowDocsServer(t *testing.T, drainTimeout string, rec DrainRecorder) (*Server, string, chan struct{}, chan struct{}) {
+	t.Helper()
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{DrainTimeout: drainTimeout}}}
+	srv := New(cfg, testRuntime{}, Options{DrainRecorder: rec})
+	started, release := make(chan struct{}), make(chan struct{})
+	srv.docsServer = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}), ReadHeaderTimeout: time.Second}
+	ln, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if err := srv.startServerWithListener("docs", srv.docsServer, ln); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	return srv, "http://" + ln.Addr().String(), started, release
+}
+
+func TestStop_DrainsInFlightRequests(t *testing.T) {
+	rec := &drainRecorderStub{}
+	srv, url, started, release := startSlowDocsServer(t, "5s", rec)
+
+	status := make(chan int, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Stop(context.Background()) }()
+	time.Sleep(50 * time.Millisecond)
+	if !srv.Draining() {
+		t.Fatalf("expected server to report draining")
+	}
+	close(release)
+
+	if err := <-stopped; err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if got := <-status; got != http.StatusOK {
+		t.Fatalf("in-flight request should complete during drain, got status %d", got)
+	}
+	if len(rec.results) != 1 || rec.results[0].Server != "docs" || rec.results[0].Connections != 1 || rec.results[0].Forced != 0 {
+		t.Fatalf("unexpected drain results: %+v", rec.results)
+	}
+}
+
+func TestStop_ForcesConnectionsAfterGracePeriod(t *testing.T) {
+	rec := &drainRecorderStub{}
+	srv, url, started, release := startSlowDocsServer(t, "100ms", rec)
+	defer close(release)
+
+	go func() {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	start := time.Now()
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Stop should give up after the grace period, took %s", elapsed)
+	}
+	if len(rec.results) != 1 || rec.results[0].Forced != 1 {
+		t.Fatalf("expected one forced connection, got %+v", rec.results)
+	}
+}
+
+func TestReadiness_FailsWhileDraining(t *testing.T) {
+	srv := New(&config.Config{Output: config.OutputConfig{Directory: t.TempDir()}}, testRuntime{}, Options{})
+	if err := os.MkdirAll(filepath.Join(srv.resolveOutputRoot(), "public"), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	srv.draining.Store(true)
+	rec := httptest.NewRecorder()
+	srv.handleReadiness(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 while draining, got %d", rec.Code)
+	}
+}
+
+func TestDocsServer_ServesHTTP2OverTLS(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{
+		TLS: &config.HTTPTLSConfig{CertFile: certFile, KeyFile: keyFile},
+	}}}
+	srv := New(cfg, testRuntime{}, Options{})
+	ln, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if err := srv.startDocsServerWithListener(t.Context(), ln); err != nil {
+		t.Fatalf("start docs server: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- self-signed test certificate
+		ForceAttemptHTTP2: true,
+	}}
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://"+ln.Addr().String()+"/api/status", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET over TLS: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("expected HTTP/2, got %s", resp.Proto)
+	}
+}
+
+// writeTestCertificate writes a self-signed localhost certificate and key.
+func writeTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	return certFile, keyFile
+}
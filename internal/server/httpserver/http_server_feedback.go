@@ -0,0 +1,78 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+)
+
+// feedbackScriptPath serves the "Was this page helpful?" widget on the docs port.
+const feedbackScriptPath = "/_docbuilder/feedback.js"
+
+// feedbackScript renders a small rating widget at the end of the page content and posts
+// ratings (and an optional comment) to /api/feedback on the same origin.
+const feedbackScript = `(() => {
+  if (window.__DOCBUILDER_FEEDBACK__) return;
+  window.__DOCBUILDER_FEEDBACK__=true;
+  function send(helpful, comment){
+    return fetch('/api/feedback', {method:'POST', headers:{'Content-Type':'application/json'},
+      body: JSON.stringify({page: location.pathname, helpful: helpful, comment: comment||''})});
+  }
+  function mount(){
+    const host = document.querySelector('#body-inner') || document.querySelector('main') || document.body;
+    const box = document.createElement('div');
+    box.className = 'docbuilder-feedback';
+    box.style.cssText = 'margin:2rem 0;padding:1rem;border-top:1px solid rgba(128,128,128,.3);font-size:.9rem';
+    box.innerHTML = '<span>Was this page helpful?</span> <button type="button" data-helpful="1">Yes</button> <button type="button" data-helpful="0">No</button>';
+    box.querySelectorAll('button').forEach((b)=>{
+      b.addEventListener('click', ()=>{
+        const helpful = b.dataset.helpful === '1';
+        box.innerHTML = '<label>Anything we could improve? (optional)<br><textarea rows="3" style="width:100%" maxlength="2000"></textarea></label><br><button type="button">Send</button>';
+        box.querySelector('button').addEventListener('click', ()=>{
+          const comment = box.querySelector('textarea').value;
+          send(helpful, comment).then(()=>{ box.textContent = 'Thanks for your feedback!'; })
+            .catch(()=>{ box.textContent = 'Sorry, your feedback could not be sent.'; });
+        });
+      });
+    });
+    host.appendChild(box);
+  }
+  if (document.readyState === 'loading') document.addEventListener('DOMContentLoaded', mount); else mount();
+})();`
+
+// handleFeedbackScript serves the feedback widget script.
+func (s *Server) handleFeedbackScript(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	if _, err := w.Write([]byte(feedbackScript)); err != nil {
+		s.log().Error("failed to write feedback script", "error", err)
+	}
+}
+
+// injectFeedbackScript is a middleware that adds the feedback widget script to HTML responses.
+func (s *Server) injectFeedbackScript(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isHTMLPagePath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		injector := newScriptInjector(w, `<script defer src="`+feedbackScriptPath+`"></script>`)
+		next.ServeHTTP(injector, r)
+		injector.finalize()
+	})
+}
+
+// resolveFeedbackRepository attributes a page path to a configured repository by matching
+// the first path segments (repository, or forge namespace followed by repository).
+func (s *Server) resolveFeedbackRepository(page string) string {
+	if len(s.cfg.Repositories) == 1 {
+		return s.cfg.Repositories[0].Name
+	}
+	segments := strings.Split(strings.Trim(page, "/"), "/")
+	for i := 0; i < len(segments) && i < 2; i++ {
+		for j := range s.cfg.Repositories {
+			if strings.EqualFold(segments[i], s.cfg.Repositories[j].Name) {
+				return s.cfg.Repositories[j].Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/feedback"
+)
+
+func TestInjectFeedbackScript(t *testing.T) {
+	store, err := feedback.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	cfg := &config.Config{Daemon: &config.DaemonConfig{Feedback: &config.FeedbackConfig{Enabled: true}}}
+	srv := New(cfg, testRuntime{}, Options{FeedbackStore: store})
+	if srv.feedbackHandlers == nil {
+		t.Fatalf("expected feedback handlers when enabled")
+	}
+
+	page := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><body><p>Doc</p></body></html>"))
+	})
+	rec := httptest.NewRecorder()
+	srv.injectFeedbackScript(page).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/guide/", nil))
+	if !strings.Contains(rec.Body.String(), `<script defer src="`+feedbackScriptPath+`"></script></body>`) {
+		t.Fatalf("expected feedback script injected, got: %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.injectFeedbackScript(page).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/style.css", nil))
+	if strings.Contains(rec.Body.String(), feedbackScriptPath) {
+		t.Fatalf("expected no injection for non-HTML paths")
+	}
+}
+
+func TestFeedbackDisabledWithoutConfig(t *testing.T) {
+	store, err := feedback.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	srv := New(&config.Config{Daemon: &config.DaemonConfig{}}, testRuntime{}, Options{FeedbackStore: store})
+	if srv.feedbackHandlers != nil {
+		t.Fatalf("expected feedback handlers to be nil when feedback is not enabled")
+	}
+}
+
+func TestResolveFeedbackRepository(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.Repository{{Name: "alpha"}, {Name: "beta"}}}
+	srv := New(cfg, testRuntime{}, Options{})
+
+	tests := map[string]string{
+		"/alpha/guide/":        "alpha",
+		"/github/beta/intro/":  "beta",
+		"/unknown/alpha/deep/": "alpha",
+		"/other/page/":         "",
+	}
+	for page, want := range tests {
+		if got := srv.resolveFeedbackRepository(page); got != want {
+			t.Errorf("resolveFeedbackRepository(%q) = %q, want %q", page, got, want)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package httpserver
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"net"
 	"net/http"
 	"strings"
@@ -29,46 +28,87 @@ func (s *Server) startLiveReloadServerWithListener(_ context.Context, ln net.Lis
 		})
 	}
 
-	// LiveReload SSE endpoint
+	// LiveReload endpoints: WebSocket preferred by the client script, SSE as fallback
 	if s.opts.LiveReloadHub != nil {
 		mux.Handle("/livereload", corsMiddleware(s.opts.LiveReloadHub))
+		mux.Handle("/livereload/ws", s.opts.LiveReloadHub.WebSocketHandler())
 		mux.HandleFunc("/livereload.js", func(w http.ResponseWriter, _ *http.Request) {
 			// Add CORS headers for script loading
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
 			// Generate script that connects to this dedicated port
-			script := fmt.Sprintf(`(() => {
-  if (window.__DOCBUILDER_LR__) return;
-  window.__DOCBUILDER_LR__=true;
-  function connect(){
-    const es = new EventSource('http://localhost:%d/livereload');
-    let first=true; let current=null;
-    es.onmessage = (e)=>{ try { const p=JSON.parse(e.data); if(first){ current=p.hash; first=false; return;} if(p.hash && p.hash!==current){ console.log('[docbuilder] change detected, reloading'); document.cookie='docbuilder_lr_reload=1; path=/; max-age=5'; location.reload(); } } catch(_){} };
-    es.onerror = ()=>{ console.warn('[docbuilder] livereload error - retrying'); es.close(); setTimeout(connect,2000); };
-  }
-  connect();
-})();`, s.cfg.Daemon.HTTP.LiveReloadPort)
-			if _, err := w.Write([]byte(script)); err != nil {
-				slog.Error("failed to write livereload script", "error", err)
+			if _, err := w.Write([]byte(liveReloadClientScript(s.cfg.Daemon.HTTP.LiveReloadPort))); err != nil {
+				s.log().Error("failed to write livereload script", "error", err)
 			}
 		})
-		slog.Info("LiveReload dedicated server registered")
+		s.log().Info("LiveReload dedicated server registered")
 	}
 
 	// LiveReload server needs no timeouts for long-lived SSE connections
 	s.liveReloadServer = &http.Server{Handler: mux, ReadTimeout: 0, WriteTimeout: 0, IdleTimeout: 300 * time.Second}
+	// Shutdown does not wait for hijacked WebSockets and would wait out the grace period on
+	// SSE streams; end them as soon as draining starts.
+	if s.opts.LiveReloadHub != nil {
+		s.liveReloadServer.RegisterOnShutdown(s.opts.LiveReloadHub.Shutdown)
+	}
 	return s.startServerWithListener("livereload", s.liveReloadServer, ln)
 }
 
+// liveReloadClientScript returns the browser client for the LiveReload server on port.
+//
+// The client connects over WebSocket and falls back to SSE when the WebSocket cannot be
+// opened. Messages carry the changed site paths; the page reloads only when it, or an
+// asset it references, is among them. Messages without paths always reload.
+func liveReloadClientScript(port int) string {
+	return fmt.Sprintf(`(() => {
+  if (window.__DOCBUILDER_LR__) return;
+  window.__DOCBUILDER_LR__=true;
+  const base='localhost:%[1]d';
+  let current=null;
+  function norm(p){ p=p.replace(/index\.html$/,''); if(!p.endsWith('/') && !/\.[a-z0-9]+$/i.test(p)) p+='/'; return p; }
+  function affected(paths){
+    if(!paths || !paths.length) return true;
+    const page=norm(location.pathname);
+    const assets=new Set();
+    document.querySelectorAll('link[href],script[src],img[src]').forEach((el)=>{ try { assets.add(new URL(el.href||el.src, location.href).pathname); } catch(_){} });
+    return paths.some((p)=>norm(p)===page || assets.has(p));
+  }
+  function handle(data){
+    try {
+      const p=JSON.parse(data);
+      if(!p.hash) return;
+      if(current===null){ current=p.hash; return; }
+      if(p.hash===current) return;
+      current=p.hash;
+      if(!affected(p.paths)){ console.log('[docbuilder] change does not affect this page'); return; }
+      console.log('[docbuilder] change detected, reloading');
+      document.cookie='docbuilder_lr_reload=1; path=/; max-age=5';
+      location.reload();
+    } catch(_){}
+  }
+  function sse(){
+    const es = new EventSource('http://'+base+'/livereload');
+    es.onmessage = (e)=>handle(e.data);
+    es.onerror = ()=>{ console.warn('[docbuilder] livereload error - retrying'); es.close(); setTimeout(sse,2000); };
+  }
+  function ws(){
+    if(!('WebSocket' in window)){ sse(); return; }
+    let opened=false;
+    const sock = new WebSocket('ws://'+base+'/livereload/ws');
+    sock.onopen = ()=>{ opened=true; };
+    sock.onmessage = (e)=>handle(e.data);
+    sock.onclose = ()=>{ if(opened){ setTimeout(ws,2000); } else { console.warn('[docbuilder] websocket unavailable - using SSE'); sse(); } };
+  }
+  ws();
+})();`, port)
+}
+
 // injectLiveReloadScriptWithPort is a middleware that injects the LiveReload client script
 // into HTML responses, configured to connect to the specified port.
 func (s *Server) injectLiveReloadScriptWithPort(next http.Handler, port int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Only inject into HTML pages (not assets, API endpoints, etc.)
-		path := r.URL.Path
-		isHTMLPage := path == "/" || path == "" || strings.HasSuffix(path, "/") || strings.HasSuffix(path, ".html")
-
-		if !isHTMLPage {
+		if !isHTMLPagePath(r.URL.Path) {
 			// Not an HTML page, serve normally
 			next.ServeHTTP(w, r)
 			return
@@ -80,28 +120,37 @@ func (s *Server) injectLiveReloadScriptWithPort(next http.Handler, port int) htt
 	})
 }
 
-// liveReloadInjector wraps an http.ResponseWriter to inject the LiveReload client script
-// into HTML responses before </body> tag. Uses buffering with a size limit to prevent stalls.
-type liveReloadInjector struct {
+// isHTMLPagePath reports whether a request path likely resolves to an HTML page.
+func isHTMLPagePath(path string) bool {
+	return path == "/" || path == "" || strings.HasSuffix(path, "/") || strings.HasSuffix(path, ".html")
+}
+
+// scriptInjector wraps an http.ResponseWriter to inject a client script tag (LiveReload,
+// feedback widget) into HTML responses before </body> tag. Uses buffering with a size limit to prevent stalls.
+type scriptInjector struct {
 	http.ResponseWriter
 	statusCode    int
 	buffer        []byte
 	headerWritten bool
 	passthrough   bool
 	maxSize       int
-	port          int
+	scriptTag     string
+}
+
+func newLiveReloadInjectorWithPort(w http.ResponseWriter, _ *http.Request, port int) *scriptInjector {
+	return newScriptInjector(w, fmt.Sprintf(`<script async src="http://localhost:%d/livereload.js"></script>`, port))
 }
 
-func newLiveReloadInjectorWithPort(w http.ResponseWriter, _ *http.Request, port int) *liveReloadInjector {
-	return &liveReloadInjector{
+func newScriptInjector(w http.ResponseWriter, scriptTag string) *scriptInjector {
+	return &scriptInjector{
 		ResponseWriter: w,
 		statusCode:     http.StatusOK,
 		maxSize:        512 * 1024, // 512KB max - typical HTML page
-		port:           port,
+		scriptTag:      scriptTag,
 	}
 }
 
-func (l *liveReloadInjector) WriteHeader(code int) {
+func (l *scriptInjector) WriteHeader(code int) {
 	l.statusCode = code
 	// Don't write header yet unless in passthrough mode
 	if l.passthrough {
@@ -110,7 +159,7 @@ func (l *liveReloadInjector) WriteHeader(code int) {
 	}
 }
 
-func (l *liveReloadInjector) Write(data []byte) (int, error) {
+func (l *scriptInjector) Write(data []byte) (int, error) {
 	// Check Content-Type on first write
 	if !l.headerWritten && !l.passthrough && l.buffer == nil {
 		contentType := l.ResponseWriter.Header().Get("Content-Type")
@@ -153,7 +202,7 @@ func (l *liveReloadInjector) Write(data []byte) (int, error) {
 }
 
 // finalize must be called after the handler completes to inject the script.
-func (l *liveReloadInjector) finalize() {
+func (l *scriptInjector) finalize() {
 	if l.passthrough || len(l.buffer) == 0 {
 		if !l.headerWritten {
 			l.ResponseWriter.WriteHeader(l.statusCode)
@@ -163,8 +212,7 @@ func (l *liveReloadInjector) finalize() {
 
 	// Inject script before </body>
 	html := string(l.buffer)
-	script := fmt.Sprintf(`<script async src="http://localhost:%d/livereload.js"></script></body>`, l.port)
-	modified := strings.Replace(html, "</body>", script, 1)
+	modified := strings.Replace(html, "</body>", l.scriptTag+"</body>", 1)
 
 	l.ResponseWriter.Header().Del("Content-Length")
 	l.ResponseWriter.WriteHeader(l.statusCode)
@@ -0,0 +1,31 @@
+package httpserver
+
+import (
+	"html"
+	"net/http"
+)
+
+// maintenanceBannerStyle pins the banner to the top of the page above theme content.
+const maintenanceBannerStyle = "position:fixed;top:0;left:0;right:0;z-index:10000;padding:.5rem 1rem;" +
+	"background:#fff3cd;color:#664d03;border-bottom:1px solid #ffe69c;font-size:.9rem;text-align:center"
+
+// injectMaintenanceBanner is a middleware that adds the maintenance banner to HTML responses
+// while the daemon is in maintenance mode.
+func (s *Server) injectMaintenanceBanner(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message, active := s.opts.Maintenance.MaintenanceBanner()
+		if !active || !isHTMLPagePath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		injector := newScriptInjector(w, maintenanceBannerHTML(message))
+		next.ServeHTTP(injector, r)
+		injector.finalize()
+	})
+}
+
+// maintenanceBannerHTML renders the banner markup for message.
+func maintenanceBannerHTML(message string) string {
+	return `<div class="docbuilder-maintenance" role="status" style="` + maintenanceBannerStyle + `">` +
+		html.EscapeString(message) + `</div>`
+}
@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+type maintenanceStub struct {
+	message string
+	active  bool
+}
+
+func (m *maintenanceStub) MaintenanceBanner() (string, bool) { return m.message, m.active }
+
+func TestInjectMaintenanceBanner(t *testing.T) {
+	state := &maintenanceStub{message: "Upgrading <storage>", active: true}
+	srv := New(&config.Config{Daemon: &config.DaemonConfig{}}, testRuntime{}, Options{Maintenance: state})
+
+	page := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><body><p>Doc</p></body></html>"))
+	})
+	serve := func(path string) string {
+		rec := httptest.NewRecorder()
+		srv.injectMaintenanceBanner(page).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Body.String()
+	}
+
+	body := serve("/guide/")
+	if !strings.Contains(body, "Upgrading &lt;storage&gt;</div></body>") {
+		t.Fatalf("expected escaped maintenance banner injected, got: %s", body)
+	}
+	if body := serve("/style.css"); strings.Contains(body, "docbuilder-maintenance") {
+		t.Fatalf("expected no banner for non-HTML paths")
+	}
+
+	state.active = false
+	if body := serve("/guide/"); strings.Contains(body, "docbuilder-maintenance") {
+		t.Fatalf("expected no banner outside maintenance mode, got: %s", body)
+	}
+}
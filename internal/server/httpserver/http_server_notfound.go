@@ -0,0 +1,257 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+const (
+	// maxPageSuggestions bounds the close matches listed on the 404 page.
+	maxPageSuggestions = 5
+	// minSuggestionScore is the similarity (0-1) a page needs to be suggested.
+	minSuggestionScore = 0.6
+	// leafSuggestionWeight is the share of the last path segment in the similarity;
+	// the rest compares whole paths, which share their sections too often to decide alone.
+	leafSuggestionWeight = 0.7
+	// maxSuggestionPathRunes bounds the compared length of a requested path.
+	maxSuggestionPathRunes = 128
+	// maxNotFoundPageBytes bounds the themed 404.html that is read into memory.
+	maxNotFoundPageBytes = 1 << 20
+	// searchPagePath is the search results page of the Relearn theme.
+	searchPagePath = "/search/"
+)
+
+// wantsNotFoundPage reports whether a missing path is answered with the 404 page rather
+// than the file server's plain-text error: page requests, not missing assets.
+func wantsNotFoundPage(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	return isHTMLPagePath(r.URL.Path) || path.Ext(r.URL.Path) == ""
+}
+
+// serveNotFoundPage answers a missing page with the site's themed 404.html (or a plain
+// page when the site has none), listing close matches from the page index and a link
+// to the search page.
+func (s *Server) serveNotFoundPage(w http.ResponseWriter, r *http.Request, root string) {
+	pages := s.pageIndexes.load(s, root)
+	block := notFoundSuggestionsHTML(suggestPages(pages, r.URL.Path, maxPageSuggestions), searchLink(pages, r.URL.Path))
+
+	page, ok := s.readNotFoundPage(root)
+	if !ok {
+		page = []byte(`<!doctype html><html><head><meta charset="utf-8"><title>Page not found</title></head><body><h1>Page not found</h1><p>The page you requested does not exist.</p></body></html>`)
+	}
+	page = insertBeforeClosingTag(page, block, "</main>", "</body>")
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Del("X-Content-Type-Options")
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Cache-Control", "no-cache, must-revalidate")
+	w.WriteHeader(http.StatusNotFound)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(page)
+	}
+}
+
+// readNotFoundPage reads the 404.html rendered by the theme.
+func (s *Server) readNotFoundPage(root string) ([]byte, bool) {
+	f, err := s.outputStorage().FileSystem(root).Open("/404.html")
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, maxNotFoundPageBytes))
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// insertBeforeClosingTag inserts block before the first of the closing tags found in page.
+func insertBeforeClosingTag(page []byte, block string, tags ...string) []byte {
+	if block == "" {
+		return page
+	}
+	lower := bytes.ToLower(page)
+	for _, tag := range tags {
+		if i := bytes.Index(lower, []byte(tag)); i >= 0 {
+			out := make([]byte, 0, len(page)+len(block))
+			out = append(out, page[:i]...)
+			out = append(out, block...)
+			return append(out, page[i:]...)
+		}
+	}
+	return append(page, block...)
+}
+
+// notFoundSuggestionsHTML renders the suggestions and search link, or "" when there are neither.
+func notFoundSuggestionsHTML(suggestions []models.PageIndexEntry, search string) string {
+	if len(suggestions) == 0 && search == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`<div class="docbuilder-not-found">`)
+	if len(suggestions) > 0 {
+		b.WriteString(`<p>Were you looking for one of these pages?</p><ul>`)
+		for _, p := range suggestions {
+			title := p.Title
+			if title == "" {
+				title = p.Path
+			}
+			b.WriteString(`<li><a href="` + html.EscapeString(p.Path) + `">` + html.EscapeString(title) + `</a></li>`)
+		}
+		b.WriteString(`</ul>`)
+	}
+	if search != "" {
+		b.WriteString(`<p><a href="` + html.EscapeString(search) + `">Search the documentation</a></p>`)
+	}
+	b.WriteString(`</div>`)
+	return b.String()
+}
+
+// searchLink returns the search page URL for the words of the requested path, or ""
+// when the site has no search page.
+func searchLink(pages []models.PageIndexEntry, requested string) string {
+	i := sort.Search(len(pages), func(i int) bool { return pages[i].Path >= searchPagePath })
+	if i == len(pages) || pages[i].Path != searchPagePath {
+		return ""
+	}
+	words := strings.FieldsFunc(path.Base(normalizeSuggestionPath(requested)), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	})
+	if len(words) == 0 {
+		return searchPagePath
+	}
+	return searchPagePath + "?search-by=" + url.QueryEscape(strings.Join(words, " "))
+}
+
+// suggestPages returns up to limit pages whose path resembles the requested path, best first.
+// The last path segments weigh most, so pages moved to another section are found as well.
+func suggestPages(pages []models.PageIndexEntry, requested string, limit int) []models.PageIndexEntry {
+	want := normalizeSuggestionPath(requested)
+	if want == "" {
+		return nil
+	}
+	wantLeaf := path.Base(want)
+	type scored struct {
+		page  models.PageIndexEntry
+		score float64
+	}
+	var matches []scored
+	for _, p := range pages {
+		have := normalizeSuggestionPath(p.Path)
+		if have == "" || have == want {
+			continue
+		}
+		score := leafSuggestionWeight*similarity(wantLeaf, path.Base(have)) +
+			(1-leafSuggestionWeight)*similarity(want, have)
+		if score >= minSuggestionScore {
+			matches = append(matches, scored{page: p, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].page.Path < matches[j].page.Path
+	})
+	out := make([]models.PageIndexEntry, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		out = append(out, m.page)
+	}
+	return out
+}
+
+// normalizeSuggestionPath lowercases a site path and strips slashes, index.html and .html.
+func normalizeSuggestionPath(p string) string {
+	p = strings.ToLower(p)
+	p = strings.TrimSuffix(p, "index.html")
+	p = strings.TrimSuffix(p, ".html")
+	p = strings.Trim(p, "/")
+	if r := []rune(p); len(r) > maxSuggestionPathRunes {
+		p = string(r[:maxSuggestionPathRunes])
+	}
+	return p
+}
+
+// similarity is 1 minus the Levenshtein distance of a and b relative to the longer string.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// pageIndexCache keeps the parsed page index of each served root until the file changes.
+type pageIndexCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedPageIndex
+}
+
+type cachedPageIndex struct {
+	modTime time.Time
+	size    int64
+	pages   []models.PageIndexEntry
+}
+
+// load returns the pages of the index next to root (the site's public/ directory), or
+// nil when the build wrote none.
+func (c *pageIndexCache) load(s *Server, root string) []models.PageIndexEntry {
+	file := filepath.Join(filepath.Dir(root), models.PageIndexFile)
+	info, err := s.outputStorage().Stat(file)
+	if err != nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[file]; ok && e.modTime.Equal(info.ModTime()) && e.size == info.Size() {
+		return e.pages
+	}
+	f, err := s.outputStorage().FileSystem(filepath.Dir(root)).Open("/" + models.PageIndexFile)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+	var index models.PageIndex
+	if err := json.NewDecoder(f).Decode(&index); err != nil {
+		s.log().Warn("Failed to read page index", "path", file, "error", err)
+		return nil
+	}
+	sort.Slice(index.Pages, func(i, j int) bool { return index.Pages[i].Path < index.Pages[j].Path })
+	if c.entries == nil {
+		c.entries = make(map[string]cachedPageIndex)
+	}
+	c.entries[file] = cachedPageIndex{modTime: info.ModTime(), size: info.Size(), pages: index.Pages}
+	return index.Pages
+}
@@ -0,0 +1,115 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+func TestSuggestPages(t *testing.T) {
+	pages := []models.PageIndexEntry{
+		{Path: "/"},
+		{Path: "/guide/installation/", Title: "Installation"},
+		{Path: "/guide/configuration/", Title: "Configuration"},
+		{Path: "/reference/cli/", Title: "CLI"},
+		{Path: "/archive/installation/", Title: "Old installation"},
+	}
+
+	got := suggestPages(pages, "/guide/instalation/", 5)
+	if len(got) != 2 || got[0].Path != "/guide/installation/" || got[1].Path != "/archive/installation/" {
+		t.Fatalf("typo suggestions = %+v", got)
+	}
+	// A page moved to another section is found by its last segment.
+	if got := suggestPages(pages, "/docs/cli.html", 5); len(got) != 1 || got[0].Path != "/reference/cli/" {
+		t.Fatalf("moved page suggestions = %+v", got)
+	}
+	if got := suggestPages(pages, "/completely/unrelated/", 5); len(got) != 0 {
+		t.Fatalf("unexpected suggestions %+v", got)
+	}
+	if got := suggestPages(pages, "/guide/instalation/", 1); len(got) != 1 {
+		t.Fatalf("limit not applied: %+v", got)
+	}
+}
+
+func TestNotFoundPage(t *testing.T) {
+	out := t.TempDir()
+	public := filepath.Join(out, "public")
+	writeSite := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(public, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSite("index.html", "<html><body>home</body></html>")
+	writeSite("404.html", `<html><body><main id="body-inner"><h1>Not found</h1></main><footer>theme</footer></body></html>`)
+	index, err := json.Marshal(models.PageIndex{Pages: []models.PageIndexEntry{
+		{Path: "/guide/install/", Title: "Install <Guide>"},
+		{Path: "/search/", Title: "Search"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(out, models.PageIndexFile), index, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := New(&config.Config{Output: config.OutputConfig{Directory: out}}, testRuntime{}, Options{})
+	handler := srv.docsRootHandler(func() string { return public })
+
+	t.Run("themed page with suggestions", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/guide/instal/", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body := rec.Body.String()
+		for _, want := range []string{
+			`<h1>Not found</h1><div class="docbuilder-not-found">`,
+			`<a href="/guide/install/">Install &lt;Guide&gt;</a>`,
+			`<a href="/search/?search-by=instal">Search the documentation</a>`,
+			`</div></main><footer>theme</footer>`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("body missing %q:\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("missing assets keep the plain error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/css/missing.css", nil))
+		if rec.Code != http.StatusNotFound || strings.Contains(rec.Body.String(), "docbuilder-not-found") {
+			t.Fatalf("status = %d, body = %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("rebuilt index is reloaded", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(public, "404.html")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(out, models.PageIndexFile), []byte(`{"pages":[{"path":"/guide/installing/"},{"path":"/other/"}]}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/guide/install/", nil))
+		body := rec.Body.String()
+		if rec.Code != http.StatusNotFound || !strings.Contains(body, "<h1>Page not found</h1>") ||
+			!strings.Contains(body, `<a href="/guide/installing/">/guide/installing/</a>`) || strings.Contains(body, "search-by") {
+			t.Fatalf("status = %d, body = %s", rec.Code, body)
+		}
+	})
+}
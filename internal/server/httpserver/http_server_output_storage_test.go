@@ -0,0 +1,74 @@
+package httpserver
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// memStorage serves a fixed in-memory tree mounted at root, standing in for a remote backend.
+type memStorage struct {
+	root  string
+	files fstest.MapFS
+}
+
+func (m memStorage) rel(path string) string {
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "\x00"
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (memStorage) Name() string                   { return "memory" }
+func (memStorage) MkdirAll(string) error          { return nil }
+func (memStorage) Rename(string, string) error    { return nil }
+func (memStorage) RemoveAll(string) error         { return nil }
+func (memStorage) CountFiles(string) (int, error) { return 0, nil }
+func (m memStorage) Stat(path string) (fs.FileInfo, error) {
+	return fs.Stat(m.files, m.rel(path))
+}
+
+func (m memStorage) FileSystem(path string) http.FileSystem {
+	sub, err := fs.Sub(m.files, m.rel(path))
+	if err != nil {
+		return http.FS(fstest.MapFS{})
+	}
+	return http.FS(sub)
+}
+
+func TestDocsHandler_ServesFromOutputStorage(t *testing.T) {
+	out, err := filepath.Abs(filepath.Join(t.TempDir(), "site"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := memStorage{root: out, files: fstest.MapFS{
+		"public/index.html":       {Data: []byte("<html><body>Remote Home</body></html>")},
+		"public/guide/index.html": {Data: []byte("<html><body>Remote Guide</body></html>")},
+	}}
+	cfg := &config.Config{Output: config.OutputConfig{Directory: out}}
+	srv := New(cfg, testRuntime{}, Options{OutputStorage: storage})
+
+	if root := srv.resolveDocsRoot(); root != filepath.Join(out, "public") {
+		t.Fatalf("expected public dir from storage, got %s", root)
+	}
+
+	h := srv.docsRootHandler(srv.resolveDocsRoot)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/guide/", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Remote Guide") {
+		t.Fatalf("expected page from storage, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	readiness := httptest.NewRecorder()
+	srv.handleReadiness(readiness, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if readiness.Code != http.StatusOK {
+		t.Fatalf("expected ready from storage, got %d", readiness.Code)
+	}
+}
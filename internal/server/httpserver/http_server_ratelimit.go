@@ -0,0 +1,35 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	smw "git.home.luguber.info/inful/docbuilder/internal/server/middleware"
+)
+
+// ThrottleRecorder counts requests rejected by rate limiting (e.g. for metrics).
+type ThrottleRecorder interface {
+	RecordThrottled(server string)
+}
+
+// rateLimit wraps the handler of the webhook or admin server with per-client, per-endpoint
+// rate limiting when daemon.http.rate_limit is enabled.
+func (s *Server) rateLimit(server string, next http.Handler) http.Handler {
+	rl := s.cfg.Daemon.HTTP.RateLimit
+	if !rl.IsEnabled() {
+		return next
+	}
+	var limit config.RateLimit
+	switch server {
+	case "webhook":
+		limit = rl.WebhookLimit()
+	default:
+		limit = rl.AdminLimit()
+	}
+	limiter := smw.NewRateLimiter(limit.Rate, limit.Burst, rl.TrustProxyHeaders)
+	var onThrottle func()
+	if s.opts.ThrottleRecorder != nil {
+		onThrottle = func() { s.opts.ThrottleRecorder.RecordThrottled(server) }
+	}
+	return smw.RateLimit(limiter, s.errorAdapter, onThrottle)(next)
+}
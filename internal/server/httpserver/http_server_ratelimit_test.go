@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+type throttleRecorderStub struct{ servers []string }
+
+func (t *throttleRecorderStub) RecordThrottled(server string) { t.servers = append(t.servers, server) }
+
+func TestRateLimit_ThrottlesAdminRequests(t *testing.T) {
+	cfg := &config.Config{Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{
+		RateLimit: &config.RateLimitConfig{Enabled: true, Admin: config.RateLimit{Rate: 1, Burst: 2}},
+	}}}
+	rec := &throttleRecorderStub{}
+	srv := New(cfg, testRuntime{}, Options{ThrottleRecorder: rec})
+	h := srv.rateLimit("admin", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := make([]int, 0, 3)
+	for range 3 {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/build/trigger", nil))
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status codes %v", codes)
+	}
+	if len(rec.servers) != 1 || rec.servers[0] != "admin" {
+		t.Fatalf("expected one throttled admin request, got %v", rec.servers)
+	}
+}
+
+func TestRateLimit_DisabledByDefault(t *testing.T) {
+	srv := New(&config.Config{Daemon: &config.DaemonConfig{}}, testRuntime{}, Options{})
+	h := srv.rateLimit("webhook", http.NotFoundHandler())
+	for range 100 {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", nil))
+		if w.Code == http.StatusTooManyRequests {
+			t.Fatal("requests must not be throttled without daemon.http.rate_limit")
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+// redirectRules is a middleware that answers configured redirect rules and vanity repository
+// URLs before the request reaches the file server.
+func (s *Server) redirectRules(next http.Handler) http.Handler {
+	rc := s.cfg.Redirects
+	rules := make(map[string]config.RedirectRule, len(rc.Rules))
+	for _, rule := range rc.Rules {
+		rules[rule.From] = rule
+		// "/old/" also matches "/old" so a missing trailing slash does not fall through to a 404.
+		if trimmed := strings.TrimSuffix(rule.From, "/"); trimmed != "" && trimmed != rule.From {
+			if _, exists := rules[trimmed]; !exists {
+				rules[trimmed] = rule
+			}
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rule, ok := rules[r.URL.Path]; ok {
+			http.Redirect(w, r, withQuery(rule.To, r.URL.RawQuery), rule.StatusCode())
+			return
+		}
+		if rc.VanityEnabled() && strings.HasPrefix(r.URL.Path, rc.VanityPrefix) {
+			s.handleVanityURL(w, r, strings.Trim(strings.TrimPrefix(r.URL.Path, rc.VanityPrefix), "/"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleVanityURL redirects <vanity_prefix><repo> to the repository's index page as
+// recorded by the last build.
+func (s *Server) handleVanityURL(w http.ResponseWriter, r *http.Request, repo string) {
+	urls, err := hugo.ReadRepositoryURLs(s.resolveOutputRoot())
+	if err != nil && !os.IsNotExist(err) {
+		s.log().Warn("Failed to read repository URLs for vanity redirect", slog.String("error", err.Error()))
+	}
+	target, ok := urls[repo]
+	if !ok {
+		for name, u := range urls {
+			if strings.EqualFold(name, repo) {
+				target, ok = u, true
+				break
+			}
+		}
+	}
+	if repo == "" || !ok {
+		http.NotFound(w, r)
+		return
+	}
+	// Temporary: the repository index moves when namespacing or the repository set changes.
+	http.Redirect(w, r, withQuery(target, r.URL.RawQuery), http.StatusFound)
+}
+
+// withQuery appends the request query to a redirect target that has none of its own.
+func withQuery(target, rawQuery string) string {
+	if rawQuery == "" || strings.Contains(target, "?") {
+		return target
+	}
+	return target + "?" + rawQuery
+}
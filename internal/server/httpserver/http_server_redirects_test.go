@@ -0,0 +1,52 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+)
+
+func TestRedirectRules(t *testing.T) {
+	out := t.TempDir()
+	manifest := `{"version":1,"pages":{},"repositories":{"Payments":"/github/payments/"}}`
+	if err := os.WriteFile(filepath.Join(out, hugo.PageManifestFile), []byte(manifest), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: out},
+		Daemon: &config.DaemonConfig{},
+		Redirects: &config.RedirectsConfig{
+			Rules: []config.RedirectRule{
+				{From: "/old/", To: "/new/"},
+				{From: "/maintenance/", To: "https://status.example.com/", Status: http.StatusTemporaryRedirect},
+			},
+			VanityPrefix: "/r/",
+		},
+	}
+	srv := New(cfg, testRuntime{}, Options{})
+	files := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("file")) })
+	handler := srv.redirectRules(files)
+
+	for path, want := range map[string]struct {
+		status   int
+		location string
+	}{
+		"/old/":         {http.StatusMovedPermanently, "/new/"},
+		"/old?x=1":      {http.StatusMovedPermanently, "/new/?x=1"},
+		"/maintenance/": {http.StatusTemporaryRedirect, "https://status.example.com/"},
+		"/r/payments":   {http.StatusFound, "/github/payments/"},
+		"/r/unknown/":   {http.StatusNotFound, ""},
+		"/guide/":       {http.StatusOK, ""},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want.status || rec.Header().Get("Location") != want.location {
+			t.Fatalf("%s: got %d %q, want %d %q", path, rec.Code, rec.Header().Get("Location"), want.status, want.location)
+		}
+	}
+}
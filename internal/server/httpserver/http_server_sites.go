@@ -0,0 +1,123 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// siteRoute is a named site (daemon.sites) served by the docs server on its own host names.
+type siteRoute struct {
+	name       string
+	hosts      []string
+	output     config.OutputConfig
+	cacheRules []config.CacheRule
+}
+
+// siteReadiness is the readiness of one named site reported by /ready/sites.
+type siteReadiness struct {
+	Name  string   `json:"name"`
+	Hosts []string `json:"hosts"`
+	Ready bool     `json:"ready"`
+}
+
+// siteRoutes returns the named sites served by the docs server. Hosts of the main site
+// are left out, and a host claimed by several sites belongs to the first of them.
+func (s *Server) siteRoutes() []siteRoute {
+	if s.cfg.Daemon == nil || len(s.cfg.Daemon.Sites) == 0 {
+		return nil
+	}
+	claimed := []string{(&config.SiteConfig{BaseURL: s.cfg.Hugo.BaseURL}).SiteHost()}
+	var routes []siteRoute
+	for i := range s.cfg.Daemon.Sites {
+		site := &s.cfg.Daemon.Sites[i]
+		route := siteRoute{name: site.Name, output: s.cfg.Output, cacheRules: site.CacheRules}
+		route.output.Directory = site.OutputDirectory
+		for _, host := range site.Hosts() {
+			if !slices.Contains(claimed, host) {
+				route.hosts = append(route.hosts, host)
+				claimed = append(claimed, host)
+			}
+		}
+		if len(route.hosts) > 0 {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// siteForHost returns the named site served on host, or nil for the main site.
+func (s *Server) siteForHost(host string) *siteRoute {
+	routes := s.siteRoutes()
+	for i := range routes {
+		if slices.Contains(routes[i].hosts, host) {
+			return &routes[i]
+		}
+	}
+	return nil
+}
+
+// requestHost returns the lowercase host name of a request, without port.
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
+
+// siteRouter serves requests whose host matches the base URL or a domain of a named
+// site from that site's output directory, with the site's cache rules; other requests
+// go to main. Sites without host names of their own are not served by the daemon.
+func (s *Server) siteRouter(main http.Handler) http.Handler {
+	routes := s.siteRoutes()
+	if len(routes) == 0 {
+		return main
+	}
+	sites := make(map[string]http.Handler)
+	for _, route := range routes {
+		handler := s.docsHandler(func() string { return s.resolveDocsRootFor(route.output) }, route.cacheRules)
+		for _, host := range route.hosts {
+			sites[host] = handler
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if site, ok := sites[requestHost(r)]; ok {
+			site.ServeHTTP(w, r)
+			return
+		}
+		main.ServeHTTP(w, r)
+	})
+}
+
+// outputRendered reports whether a rendered site exists under <output>/public.
+func (s *Server) outputRendered(out config.OutputConfig) bool {
+	public := filepath.Join(resolveOutputRootFor(out), "public")
+	st, err := s.outputStorage().Stat(public)
+	return err == nil && st.IsDir()
+}
+
+// handleSitesReadiness reports the readiness of every named site served by the docs
+// server (GET /ready/sites). It answers 503 unless all of them have been rendered.
+func (s *Server) handleSitesReadiness(w http.ResponseWriter, _ *http.Request) {
+	routes := s.siteRoutes()
+	sites := make([]siteReadiness, 0, len(routes))
+	ready := !s.Draining()
+	for _, route := range routes {
+		rendered := s.outputRendered(route.output)
+		ready = ready && rendered
+		sites = append(sites, siteReadiness{Name: route.name, Hosts: route.hosts, Ready: rendered})
+	}
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"ready": ready, "sites": sites})
+}
@@ -0,0 +1,179 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func writeSiteIndex(t *testing.T, dir, content string) {
+	t.Helper()
+	public := filepath.Join(dir, "public")
+	if err := os.MkdirAll(public, 0o750); err != nil {
+		t.Fatalf("failed to create public directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(public, "index.html"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write index.html: %v", err)
+	}
+}
+
+// TestSiteRouterServesSitesByHost tests that named sites are served from their own
+// output directory when the request host matches their base URL.
+func TestSiteRouterServesSitesByHost(t *testing.T) {
+	mainDir := t.TempDir()
+	siteDir := t.TempDir()
+	writeSiteIndex(t, mainDir, "Main Site")
+	writeSiteIndex(t, siteDir, "Platform Site")
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: mainDir},
+		Hugo:   config.HugoConfig{BaseURL: "https://docs.example.com/"},
+		Daemon: &config.DaemonConfig{Sites: []config.SiteConfig{
+			{Name: "platform", BaseURL: "https://Platform.example.com/", OutputDirectory: siteDir, Domains: []string{"platform.docs.internal"}},
+			{Name: "same-host", BaseURL: "https://docs.example.com/", OutputDirectory: t.TempDir()},
+			{Name: "no-url", OutputDirectory: t.TempDir()},
+		}},
+	}
+	srv := New(cfg, testRuntime{}, Options{})
+	handler := srv.siteRouter(srv.docsRootHandler(srv.resolveDocsRoot))
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "docs.example.com", want: "Main Site"},
+		{host: "platform.example.com", want: "Platform Site"},
+		{host: "platform.example.com:8080", want: "Platform Site"},
+		{host: "platform.docs.internal", want: "Platform Site"},
+		{host: "unknown.example.com", want: "Main Site"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("host %s: expected status %d, got %d", tt.host, http.StatusOK, rec.Code)
+		}
+		body, _ := io.ReadAll(rec.Body)
+		if !strings.Contains(string(body), tt.want) {
+			t.Errorf("host %s: expected body to contain %q, got: %s", tt.host, tt.want, body)
+		}
+	}
+}
+
+// TestSiteRouterCacheRules tests that a site's cache rules take precedence over the
+// default Cache-Control headers on the site's hosts only.
+func TestSiteRouterCacheRules(t *testing.T) {
+	mainDir := t.TempDir()
+	siteDir := t.TempDir()
+	writeSiteIndex(t, mainDir, "Main Site")
+	writeSiteIndex(t, siteDir, "Platform Site")
+	for _, dir := range []string{mainDir, siteDir} {
+		for _, name := range []string{"style.css", "logo.png"} {
+			if err := os.WriteFile(filepath.Join(dir, "public", name), []byte("asset"), 0o600); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+	}
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: mainDir},
+		Daemon: &config.DaemonConfig{Sites: []config.SiteConfig{{
+			Name:            "platform",
+			OutputDirectory: siteDir,
+			Domains:         []string{"platform.example.com"},
+			CacheRules: []config.CacheRule{
+				{Path: "/", CacheControl: "public, max-age=60"},
+				{Path: "*.css", CacheControl: "no-store"},
+			},
+		}}},
+	}
+	srv := New(cfg, testRuntime{}, Options{})
+	handler := srv.siteRouter(srv.docsRootHandler(srv.resolveDocsRoot))
+
+	tests := []struct {
+		host string
+		path string
+		want string
+	}{
+		{host: "platform.example.com", path: "/", want: "public, max-age=60"},
+		{host: "platform.example.com", path: "/style.css", want: "no-store"},
+		{host: "platform.example.com", path: "/logo.png", want: "public, max-age=604800"},
+		{host: "docs.example.com", path: "/", want: "no-cache, must-revalidate"},
+		{host: "docs.example.com", path: "/style.css", want: "public, max-age=31536000, immutable"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Cache-Control"); got != tt.want {
+			t.Errorf("%s%s: expected Cache-Control %q, got %q", tt.host, tt.path, tt.want, got)
+		}
+	}
+}
+
+// TestSitesReadiness tests the readiness of named sites per host and on /ready/sites.
+func TestSitesReadiness(t *testing.T) {
+	mainDir := t.TempDir()
+	readyDir := t.TempDir()
+	writeSiteIndex(t, mainDir, "Main Site")
+	writeSiteIndex(t, readyDir, "Platform Site")
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: mainDir},
+		Daemon: &config.DaemonConfig{Sites: []config.SiteConfig{
+			{Name: "platform", OutputDirectory: readyDir, Domains: []string{"platform.example.com"}},
+			{Name: "sales", OutputDirectory: t.TempDir(), Domains: []string{"sales.example.com"}},
+			{Name: "internal", OutputDirectory: t.TempDir()},
+		}},
+	}
+	srv := New(cfg, testRuntime{}, Options{})
+
+	for host, want := range map[string]int{
+		"docs.example.com":     http.StatusOK,
+		"platform.example.com": http.StatusOK,
+		"sales.example.com":    http.StatusServiceUnavailable,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		srv.handleReadiness(rec, req)
+		if rec.Code != want {
+			t.Errorf("host %s: expected status %d, got %d", host, want, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleSitesReadiness(rec, httptest.NewRequest(http.MethodGet, "/ready/sites", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	var body struct {
+		Ready bool `json:"ready"`
+		Sites []struct {
+			Name  string   `json:"name"`
+			Hosts []string `json:"hosts"`
+			Ready bool     `json:"ready"`
+		} `json:"sites"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Ready || len(body.Sites) != 2 {
+		t.Fatalf("unexpected readiness: %+v", body)
+	}
+	if body.Sites[0].Name != "platform" || !body.Sites[0].Ready || body.Sites[1].Name != "sales" || body.Sites[1].Ready {
+		t.Errorf("unexpected site readiness: %+v", body.Sites)
+	}
+}
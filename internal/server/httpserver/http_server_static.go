@@ -0,0 +1,88 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StartStatic serves an already-built site from cfg.Output.Directory on the docs port,
+// without webhook or admin servers. The directory may be a Hugo output directory
+// (its public/ folder is served) or a rendered site root.
+//
+// Responses get the same Cache-Control headers and LiveReload 404 fallback as the daemon
+// docs server. The LiveReload server is started when cfg.Build.LiveReload is set and a hub
+// was provided in Options.
+func (s *Server) StartStatic(ctx context.Context) error {
+	if s.cfg.Daemon == nil {
+		return errors.New("daemon configuration required for HTTP servers")
+	}
+	root, err := resolveStaticRoot(s.cfg.Output.Directory)
+	if err != nil {
+		return err
+	}
+	s.staticRoot = root
+
+	liveReload := s.cfg.Build.LiveReload && s.opts.LiveReloadHub != nil
+	ports := []int{s.cfg.Daemon.HTTP.DocsPort}
+	if liveReload {
+		ports = append(ports, s.cfg.Daemon.HTTP.LiveReloadPort)
+	}
+	lc := net.ListenConfig{}
+	listeners := make([]net.Listener, 0, len(ports))
+	for _, port := range ports {
+		ln, err := lc.Listen(ctx, "tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return fmt.Errorf("http startup failed: port %d: %w", port, err)
+		}
+		listeners = append(listeners, ln)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.monitoringHandlers.HandleHealthCheck)
+	mux.HandleFunc("/healthz", s.monitoringHandlers.HandleHealthCheck)
+	mux.Handle("/", s.mchain(s.docsRootHandler(func() string { return s.staticRoot })))
+
+	s.docsServer = &http.Server{Handler: s.accessLog("docs", mux), ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second, IdleTimeout: 120 * time.Second}
+	if err := s.startServerWithListener("docs", s.docsServer, listeners[0]); err != nil {
+		return fmt.Errorf("failed to start docs server: %w", err)
+	}
+	if liveReload {
+		if err := s.startLiveReloadServerWithListener(ctx, listeners[1]); err != nil {
+			return fmt.Errorf("failed to start livereload server: %w", err)
+		}
+	}
+
+	s.log().Info("Static site server started",
+		slog.String("root", root),
+		slog.Int("docs_port", s.cfg.Daemon.HTTP.DocsPort),
+		slog.Bool("livereload", liveReload))
+	return nil
+}
+
+// resolveStaticRoot returns dir/public when it exists, otherwise dir itself.
+func resolveStaticRoot(dir string) (string, error) {
+	if dir == "" {
+		dir = defaultSiteDir
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolve site directory: %w", err)
+	}
+	if st, err := os.Stat(filepath.Join(abs, "public")); err == nil && st.IsDir() {
+		return filepath.Join(abs, "public"), nil
+	}
+	if st, err := os.Stat(abs); err != nil || !st.IsDir() {
+		return "", fmt.Errorf("site directory not found: %s", abs)
+	}
+	return abs, nil
+}
@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestResolveStaticRoot(t *testing.T) {
+	site := t.TempDir()
+	root, err := resolveStaticRoot(site)
+	if err != nil {
+		t.Fatalf("resolveStaticRoot: %v", err)
+	}
+	if root != site {
+		t.Errorf("root = %q, want %q", root, site)
+	}
+
+	public := filepath.Join(site, "public")
+	if err := os.MkdirAll(public, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	root, err = resolveStaticRoot(site)
+	if err != nil {
+		t.Fatalf("resolveStaticRoot: %v", err)
+	}
+	if root != public {
+		t.Errorf("root = %q, want %q", root, public)
+	}
+
+	if _, err := resolveStaticRoot(filepath.Join(site, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestStaticRootHandler(t *testing.T) {
+	site := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(site, "docs"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(site, "index.html"), []byte("<html><body>home</body></html>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(site, "docs", "index.html"), []byte("<html><body>docs</body></html>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{Directory: site},
+		Build:  config.BuildConfig{LiveReload: true},
+		Daemon: &config.DaemonConfig{HTTP: config.HTTPConfig{LiveReloadPort: 4242}},
+	}
+	srv := New(cfg, testRuntime{}, Options{LiveReloadHub: testLiveReloadHub{}})
+	srv.staticRoot = site
+	handler := srv.docsRootHandler(func() string { return srv.staticRoot })
+
+	t.Run("serves site without status page", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "home") {
+			t.Errorf("body = %q, want site index", body)
+		}
+		if !strings.Contains(body, "localhost:4242/livereload.js") {
+			t.Errorf("expected LiveReload script in %q", body)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != "no-cache, must-revalidate" {
+			t.Errorf("Cache-Control = %q", got)
+		}
+	})
+
+	t.Run("redirects LiveReload 404 to nearest parent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/docs/removed/", nil)
+		req.AddCookie(&http.Cookie{Name: "docbuilder_lr_reload", Value: "1"})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusTemporaryRedirect {
+			t.Fatalf("status = %d, want 307", rec.Code)
+		}
+		if loc := rec.Header().Get("Location"); loc != "/docs/" {
+			t.Errorf("Location = %q, want /docs/", loc)
+		}
+	})
+}
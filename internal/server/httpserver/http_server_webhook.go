@@ -22,6 +22,7 @@ func normalizeWebhookPath(p string) string {
 
 func (s *Server) webhookMux() (*http.ServeMux, error) {
 	mux := http.NewServeMux()
+	s.webhookIngress = nil
 
 	// Forge-specific webhook endpoints (configured per forge instance)
 	seen := map[string]string{}
@@ -43,9 +44,18 @@ func (s *Server) webhookMux() (*http.ServeMux, error) {
 
 		forgeName := forgeCfg.Name
 		forgeType := forgeCfg.Type
-		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		var handler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
 			s.webhookHandlers.HandleForgeWebhook(w, r, forgeName, forgeType)
-		})
+		}
+		ingress, err := newWebhookIngress(forgeCfg)
+		if err != nil {
+			return nil, err
+		}
+		if ingress != nil {
+			s.webhookIngress = append(s.webhookIngress, ingress)
+			handler = s.guardWebhook(ingress, handler)
+		}
+		mux.HandleFunc(path, handler)
 	}
 
 	// Generic webhook endpoint (no signature validation, no build triggering)
@@ -54,12 +64,16 @@ func (s *Server) webhookMux() (*http.ServeMux, error) {
 	return mux, nil
 }
 
-func (s *Server) startWebhookServerWithListener(_ context.Context, ln net.Listener) error {
+func (s *Server) startWebhookServerWithListener(ctx context.Context, ln net.Listener) error {
 	mux, err := s.webhookMux()
 	if err != nil {
 		return err
 	}
 
-	s.webhookServer = &http.Server{Handler: s.mchain(mux), ReadTimeout: 30 * time.Second, WriteTimeout: 10 * time.Second, IdleTimeout: 60 * time.Second}
+	s.webhookServer = &http.Server{Handler: s.accessLog("webhook", s.mchain(s.rateLimit("webhook", mux))), ReadTimeout: 30 * time.Second, WriteTimeout: 10 * time.Second, IdleTimeout: 60 * time.Second}
+	if err := s.configureWebhookTLS(s.webhookServer); err != nil {
+		return err
+	}
+	go s.refreshForgeRanges(ctx)
 	return s.startServerWithListener("webhook", s.webhookServer, ln)
 }
@@ -0,0 +1,198 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	smw "git.home.luguber.info/inful/docbuilder/internal/server/middleware"
+)
+
+// forgeRangesRefreshInterval is how often published forge webhook ranges are re-fetched.
+const forgeRangesRefreshInterval = time.Hour
+
+// webhookIngress restricts one forge's webhook endpoint by source address and client
+// certificate. Addresses are taken from the connection, never from proxy headers.
+type webhookIngress struct {
+	forge       string
+	apiURL      string
+	allowed     []netip.Prefix
+	forgeRanges bool
+	// published holds the last fetched forge ranges; nil until the first fetch succeeds.
+	published atomic.Pointer[[]netip.Prefix]
+	clientCAs *x509.CertPool
+	caPEM     []byte
+}
+
+// newWebhookIngress returns the ingress restrictions for a forge, or nil when it has none.
+func newWebhookIngress(forgeCfg *config.ForgeConfig) (*webhookIngress, error) {
+	wh := forgeCfg.Webhook
+	if !wh.IPRestricted() && !wh.MTLSEnabled() {
+		return nil, nil //nolint:nilnil // no restrictions configured
+	}
+	allowed, err := wh.AllowedPrefixes()
+	if err != nil {
+		return nil, fmt.Errorf("forge %q webhook allowed_ips: %w", forgeCfg.Name, err)
+	}
+	g := &webhookIngress{forge: forgeCfg.Name, apiURL: forgeCfg.APIURL, allowed: allowed, forgeRanges: wh.AllowForgeRanges}
+	if wh.MTLSEnabled() {
+		g.caPEM, err = os.ReadFile(wh.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("forge %q webhook client_ca: %w", forgeCfg.Name, err)
+		}
+		g.clientCAs = x509.NewCertPool()
+		if !g.clientCAs.AppendCertsFromPEM(g.caPEM) {
+			return nil, fmt.Errorf("forge %q webhook client_ca: no certificates in %s", forgeCfg.Name, wh.ClientCA)
+		}
+	}
+	return g, nil
+}
+
+// allowsAddr reports whether deliveries from addr are allowed. Until the forge ranges
+// have been fetched once, only the static allowlist applies.
+func (g *webhookIngress) allowsAddr(addr netip.Addr) bool {
+	if len(g.allowed) == 0 && !g.forgeRanges {
+		return true
+	}
+	addr = addr.Unmap()
+	for _, p := range g.allowed {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	if published := g.published.Load(); published != nil {
+		for _, p := range *published {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifiesClient reports whether the connection presented a client certificate signed
+// by this forge's client_ca.
+func (g *webhookIngress) verifiesClient(state *tls.ConnectionState) bool {
+	if g.clientCAs == nil {
+		return true
+	}
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return false
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         g.clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil
+}
+
+// guardWebhook rejects deliveries from sources the forge's ingress rules do not allow.
+func (s *Server) guardWebhook(g *webhookIngress, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client := smw.ClientIP(r, false)
+		reason := ""
+		if addr, err := netip.ParseAddr(client); err != nil || !g.allowsAddr(addr) {
+			reason = "source address not allowed"
+		} else if !g.verifiesClient(r.TLS) {
+			reason = "missing or untrusted client certificate"
+		}
+		if reason == "" {
+			next(w, r)
+			return
+		}
+		s.errorAdapter.WriteErrorResponse(w, r, derrors.NewError(derrors.CategoryAuth, "webhook source not allowed").
+			WithCode(derrors.CodeAuthForbiddenSource).
+			WithSeverity(derrors.SeverityWarning).
+			WithContext("forge", g.forge).
+			WithContext("client", client).
+			WithContext("reason", reason).
+			Build())
+	}
+}
+
+// configureWebhookTLS serves the webhook port over TLS with the daemon.http.tls
+// certificate when a forge requires client certificates. Certificates are requested
+// from every client and checked per forge endpoint.
+func (s *Server) configureWebhookTLS(srv *http.Server) error {
+	pool := x509.NewCertPool()
+	mtls := false
+	for _, g := range s.webhookIngress {
+		if g.clientCAs != nil {
+			pool.AppendCertsFromPEM(g.caPEM)
+			mtls = true
+		}
+	}
+	if !mtls {
+		return nil
+	}
+	tlsCfg := s.cfg.Daemon.HTTP.TLS
+	cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load webhook TLS certificate: %w", err)
+	}
+	srv.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	return nil
+}
+
+// refreshForgeRanges fetches the published webhook ranges of forges using
+// allow_forge_ranges, then again every forgeRangesRefreshInterval until ctx ends.
+// A failed fetch keeps the previous ranges.
+func (s *Server) refreshForgeRanges(ctx context.Context) {
+	var guards []*webhookIngress
+	for _, g := range s.webhookIngress {
+		if g.forgeRanges {
+			guards = append(guards, g)
+		}
+	}
+	if len(guards) == 0 {
+		return
+	}
+	fetch := s.fetchHookRanges
+	if fetch == nil {
+		fetch = forge.FetchGitHubHookRanges
+	}
+	refresh := func() {
+		for _, g := range guards {
+			ranges, err := fetch(ctx, g.apiURL)
+			if err != nil {
+				s.log().Warn("Failed to refresh forge webhook ranges; keeping previous ranges",
+					slog.String("forge", g.forge), "error", err)
+				continue
+			}
+			g.published.Store(&ranges)
+			s.log().Debug("Refreshed forge webhook ranges", slog.String("forge", g.forge), slog.Int("ranges", len(ranges)))
+		}
+	}
+
+	refresh()
+	ticker := time.NewTicker(forgeRangesRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newIngressTestServer(t *testing.T, wh *config.WebhookConfig, httpCfg config.HTTPConfig) *Server {
+	t.Helper()
+	cfg := &config.Config{
+		Forges: []*config.ForgeConfig{{Name: "gh", Type: config.ForgeGitHub, Webhook: wh}},
+		Daemon: &config.DaemonConfig{HTTP: httpCfg},
+	}
+	return New(cfg, &webhookRuntimeStub{}, Options{
+		ForgeClients:   map[string]forge.Client{"gh": forge.NewEnhancedMockForgeClient("gh", forge.TypeGitHub)},
+		WebhookConfigs: map[string]*config.WebhookConfig{"gh": wh},
+	})
+}
+
+func serveWebhookFrom(t *testing.T, mux http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/webhooks/github", bytes.NewBufferString(`{}`))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.RemoteAddr = remoteAddr
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestWebhookIngress_AllowedIPs(t *testing.T) {
+	srv := newIngressTestServer(t, &config.WebhookConfig{AllowedIPs: []string{"192.0.2.0/24", "2001:db8::1"}}, config.HTTPConfig{})
+	mux, err := srv.webhookMux()
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusAccepted, serveWebhookFrom(t, mux, "192.0.2.7:4711").Code)
+	require.Equal(t, http.StatusAccepted, serveWebhookFrom(t, mux, "[2001:db8::1]:4711").Code)
+
+	rr := serveWebhookFrom(t, mux, "198.51.100.7:4711")
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	var body derrors.HTTPErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	require.Equal(t, string(derrors.CodeAuthForbiddenSource), body.ErrorCode)
+}
+
+func TestWebhookIngress_ForgeRanges(t *testing.T) {
+	srv := newIngressTestServer(t, &config.WebhookConfig{AllowForgeRanges: true}, config.HTTPConfig{})
+	fetched := 0
+	srv.fetchHookRanges = func(context.Context, string) ([]netip.Prefix, error) {
+		fetched++
+		return []netip.Prefix{netip.MustParsePrefix("192.30.252.0/22")}, nil
+	}
+	mux, err := srv.webhookMux()
+	require.NoError(t, err)
+
+	// Nothing is allowed until the published ranges are known.
+	require.Equal(t, http.StatusForbidden, serveWebhookFrom(t, mux, "192.30.253.1:4711").Code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	srv.refreshForgeRanges(ctx)
+	require.Equal(t, 1, fetched)
+
+	require.Equal(t, http.StatusAccepted, serveWebhookFrom(t, mux, "192.30.253.1:4711").Code)
+	require.Equal(t, http.StatusForbidden, serveWebhookFrom(t, mux, "203.0.113.9:4711").Code)
+}
+
+func TestWebhookIngress_ClientCertificate(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	caPEM, clientCert := newTestClientCA(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	srv := newIngressTestServer(t, &config.WebhookConfig{ClientCA: caFile}, config.HTTPConfig{
+		TLS: &config.HTTPTLSConfig{CertFile: certFile, KeyFile: keyFile},
+	})
+	ln, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, srv.startWebhookServerWithListener(t.Context(), ln))
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	post := func(certs []tls.Certificate) int {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // #nosec G402 -- self-signed test certificate
+			Certificates:       certs,
+		}}}
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://"+ln.Addr().String()+"/webhooks/github", bytes.NewBufferString(`{}`))
+		req.Header.Set("X-GitHub-Event", "push")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusForbidden, post(nil))
+	require.Equal(t, http.StatusAccepted, post([]tls.Certificate{clientCert}))
+}
+
+// newTestClientCA returns a CA certificate (PEM) and a client certificate it signed.
+func newTestClientCA(t *testing.T) ([]byte, tls.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "webhook test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	clientTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "forge"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	clientDER, err := x509.CreateCertificate(rand.Reader, clientTmpl, caCert, &clientKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	return caPEM, tls.Certificate{Certificate: [][]byte{clientDER}, PrivateKey: clientKey}
+}
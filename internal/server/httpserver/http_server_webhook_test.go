@@ -3,8 +3,10 @@ package httpserver
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -14,10 +16,11 @@ import (
 )
 
 type webhookRuntimeStub struct {
-	called bool
-	forge  string
-	repo   string
-	branch string
+	called       bool
+	forge        string
+	repo         string
+	branch       string
+	changedFiles []string
 }
 
 func (r *webhookRuntimeStub) GetStatus() string             { return "running" }
@@ -29,13 +32,17 @@ func (r *webhookRuntimeStub) LastDiscoveryDurationSec() int { return 0 }
 func (r *webhookRuntimeStub) LastBuildDurationSec() int     { return 0 }
 func (r *webhookRuntimeStub) TriggerDiscovery() string      { return "" }
 func (r *webhookRuntimeStub) TriggerBuild() string          { return "" }
-func (r *webhookRuntimeStub) GetQueueLength() int           { return 0 }
+func (r *webhookRuntimeStub) TriggerScopedBuild([]string, map[string]string) (string, error) {
+	return "", nil
+}
+func (r *webhookRuntimeStub) GetQueueLength() int { return 0 }
 
-func (r *webhookRuntimeStub) TriggerWebhookBuild(forgeName, repoFullName, branch string, changedFiles []string) string {
+func (r *webhookRuntimeStub) TriggerWebhookBuild(forgeName, repoFullName, branch, _ string, changedFiles []string) string {
 	r.called = true
 	r.forge = forgeName
 	r.repo = repoFullName
 	r.branch = branch
+	r.changedFiles = changedFiles
 	return "job-123"
 }
 
@@ -93,3 +100,89 @@ func TestWebhookMux_ConfiguredForgePath_TriggersBuild(t *testing.T) {
 	require.Equal(t, "test-org/mock-repo", runtime.repo)
 	require.Equal(t, "main", runtime.branch)
 }
+
+func TestWebhookMux_ChangedFileFilterBypass(t *testing.T) {
+	forgeName := "company-github"
+	whPath := "/webhooks/github"
+
+	tests := []struct {
+		name           string
+		target         string
+		buildAllPushes bool
+		wantFiles      []string
+	}{
+		{name: "default passes changed files", target: whPath, wantFiles: []string{"docs/README.md", "docs/guide.md"}},
+		{name: "force query param", target: whPath + "?force=true", wantFiles: nil},
+		{name: "build_all_pushes config", target: whPath, buildAllPushes: true, wantFiles: nil},
+		{name: "invalid force value ignored", target: whPath + "?force=maybe", wantFiles: []string{"docs/README.md", "docs/guide.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			whCfg := &config.WebhookConfig{Path: whPath, BuildAllPushes: tt.buildAllPushes}
+			cfg := &config.Config{
+				Forges: []*config.ForgeConfig{{Name: forgeName, Type: config.ForgeGitHub, Webhook: whCfg}},
+				Daemon: &config.DaemonConfig{},
+			}
+
+			runtime := &webhookRuntimeStub{}
+			srv := New(cfg, runtime, Options{
+				ForgeClients:   map[string]forge.Client{forgeName: forge.NewEnhancedMockForgeClient(forgeName, forge.TypeGitHub)},
+				WebhookConfigs: map[string]*config.WebhookConfig{forgeName: whCfg},
+			})
+			mux, err := srv.webhookMux()
+			require.NoError(t, err)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, tt.target, bytes.NewBufferString(`{}`))
+			req.Header.Set("X-GitHub-Event", "push")
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusAccepted, rr.Code)
+			require.True(t, runtime.called)
+			require.Equal(t, tt.wantFiles, runtime.changedFiles)
+		})
+	}
+}
+
+type branchFilterStub struct{ allowed []string }
+
+func (f branchFilterStub) CheckWebhookBranch(_ context.Context, _, _, branch string) (bool, string) {
+	if slices.Contains(f.allowed, branch) {
+		return true, ""
+	}
+	return false, "forge_branch_not_allowed"
+}
+
+func TestWebhookMux_BranchNotAllowed_Skipped(t *testing.T) {
+	forgeName := "company-github"
+	whCfg := &config.WebhookConfig{Path: "/webhooks/github"}
+	cfg := &config.Config{
+		Forges: []*config.ForgeConfig{{Name: forgeName, Type: config.ForgeGitHub, Webhook: whCfg}},
+		Daemon: &config.DaemonConfig{},
+	}
+
+	runtime := &webhookRuntimeStub{}
+	srv := New(cfg, runtime, Options{
+		ForgeClients:        map[string]forge.Client{forgeName: forge.NewEnhancedMockForgeClient(forgeName, forge.TypeGitHub)},
+		WebhookConfigs:      map[string]*config.WebhookConfig{forgeName: whCfg},
+		WebhookBranchFilter: branchFilterStub{allowed: []string{"develop"}},
+	})
+	mux, err := srv.webhookMux()
+	require.NoError(t, err)
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/webhooks/github", bytes.NewBufferString(`{}`))
+	req.Header.Set("X-GitHub-Event", "push")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	require.False(t, runtime.called)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Equal(t, "skipped", body["status"])
+	require.Equal(t, "forge_branch_not_allowed", body["reason"])
+	require.Equal(t, "main", body["branch"])
+	require.NotContains(t, body, "build_job_id")
+}
@@ -1,11 +1,15 @@
 package httpserver
 
 import (
+	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/forge"
+	"git.home.luguber.info/inful/docbuilder/internal/output"
+	"git.home.luguber.info/inful/docbuilder/internal/server/handlers"
 )
 
 // Runtime is the minimal interface required by shared HTTP handlers.
@@ -22,13 +26,15 @@ type Runtime interface {
 
 	TriggerDiscovery() string
 	TriggerBuild() string
+	// TriggerScopedBuild triggers a build that only fetches the named repositories.
+	TriggerScopedBuild(repositories []string, commits map[string]string) (string, error)
 	// TriggerWebhookBuild triggers a build based on a webhook event.
 	//
 	// forgeName is optional; callers may pass an empty string when the request is
 	// not scoped to a specific configured forge instance. When forgeName is
 	// provided, it may be used to disambiguate repositories hosted on different
 	// forges.
-	TriggerWebhookBuild(forgeName, repoFullName, branch string, changedFiles []string) string
+	TriggerWebhookBuild(forgeName, repoFullName, branch, commit string, changedFiles []string) string
 	GetQueueLength() int
 }
 
@@ -37,9 +43,16 @@ type BuildStatus interface {
 	GetStatus() (hasError bool, err error, hasGoodBuild bool)
 }
 
-// LiveReloadHub supports the LiveReload SSE endpoint and broadcast notifications.
+// MaintenanceStatus reports whether the daemon is in maintenance mode and the banner
+// shown on documentation pages while it is.
+type MaintenanceStatus interface {
+	MaintenanceBanner() (message string, active bool)
+}
+
+// LiveReloadHub supports the LiveReload SSE and WebSocket endpoints and broadcast notifications.
 type LiveReloadHub interface {
-	http.Handler
+	http.Handler // SSE endpoint
+	WebSocketHandler() http.Handler
 	Broadcast(hash string)
 	Shutdown()
 }
@@ -49,15 +62,70 @@ type Options struct {
 	ForgeClients   map[string]forge.Client
 	WebhookConfigs map[string]*config.WebhookConfig
 
+	// ServeOnly starts only the docs and admin servers, without the webhook server or the
+	// build, discovery and reload admin endpoints (daemon.role: server).
+	ServeOnly bool
+
+	// Optional: branch allowlist check for webhook pushes (defaults to allowing every branch).
+	WebhookBranchFilter handlers.WebhookBranchFilter
+
+	// Optional: handler of tag, release and repository rename webhooks (defaults to ignoring them).
+	WebhookEventTrigger handlers.WebhookEventTrigger
+
 	// Optional: live reload support (preview mode).
 	LiveReloadHub LiveReloadHub
 
 	// Optional: build status tracker (preview mode).
 	BuildStatus BuildStatus
 
+	// Optional: leader election state reported on /api/daemon/status.
+	LeaderStatus handlers.LeaderStatusProvider
+
+	// Optional: maintenance mode state (enables the docs maintenance banner).
+	Maintenance MaintenanceStatus
+
+	// Optional: page feedback storage (enables the feedback widget and endpoints).
+	FeedbackStore handlers.FeedbackStore
+
+	// Optional: page view counters (enables analytics collection and endpoints).
+	PageViews handlers.PageViewStore
+
+	// Optional: backend the rendered site is read from (defaults to the local filesystem).
+	OutputStorage output.Storage
+
+	// Optional: receives connection drain results on shutdown.
+	DrainRecorder DrainRecorder
+
+	// Optional: counts requests rejected by daemon.http.rate_limit.
+	ThrottleRecorder ThrottleRecorder
+
+	// Optional: http component logger for request logs and handlers (defaults to slog.Default).
+	Logger *slog.Logger
+
+	// Optional: access log file of a server (monitoring.logging.access.servers). Servers
+	// without one log their access records through Logger.
+	AccessLogSink func(server string) io.Writer
+
+	// Optional: control API (daemon.http.rpc), mounted on the admin port below RPCPath.
+	RPCPath    string
+	RPCHandler http.Handler
+
 	// Optional: extra admin endpoints.
-	PrometheusHandler     http.Handler
-	DetailedMetricsHandle http.HandlerFunc
-	EnhancedHealthHandle  http.HandlerFunc
-	StatusHandle          http.HandlerFunc
+	PrometheusHandler      http.Handler
+	DetailedMetricsHandle  http.HandlerFunc
+	EnhancedHealthHandle   http.HandlerFunc
+	StatusHandle           http.HandlerFunc
+	ReloadHandle           http.HandlerFunc
+	DiscoveryPreviewHandle http.HandlerFunc
+	BuildReportHandle      http.HandlerFunc
+	MaintenanceHandle      http.HandlerFunc
+	BuildCooldownsHandle   http.HandlerFunc
+	LinkCheckHandle        http.HandlerFunc
+	BrokenLinksPageHandle  http.HandlerFunc
+	SitesHandle            http.HandlerFunc
+	SiteHandle             http.HandlerFunc
+	SiteBuildHandle        http.HandlerFunc
+	LintHandle             http.HandlerFunc
+	ReconciliationHandle   http.HandlerFunc
+	OnboardHandle          http.HandlerFunc
 }
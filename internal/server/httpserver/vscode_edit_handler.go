@@ -6,23 +6,24 @@ import (
 	"net/http"
 )
 
-// handleVSCodeEdit handles requests to open files in VS Code.
-// URL format: /_edit/<relative-path-to-file>
-// This handler opens the file in VS Code and redirects back to the referer.
-func (s *Server) handleVSCodeEdit(w http.ResponseWriter, r *http.Request) {
-	// Check if VS Code edit links are enabled (requires --vscode flag)
+// handleEditLink handles requests to open files in a local editor.
+// URL format: /_edit/<relative-path-to-file>[?editor=<name>]
+// This handler opens the file with the selected EditorOpener (VS Code by default) and
+// redirects back to the referer, or to the editor URL for URL-scheme editors.
+func (s *Server) handleEditLink(w http.ResponseWriter, r *http.Request) {
+	// Check if local edit links are enabled (requires --vscode or --edit-with flag)
 	if s.cfg == nil || !s.cfg.Build.VSCodeEditLinks {
-		slog.Warn("VS Code edit handler: feature not enabled - use --vscode flag",
+		s.log().Warn("Edit handler: feature not enabled - use --vscode or --edit-with flag",
 			slog.String("path", r.URL.Path))
-		http.Error(w, "VS Code edit links not enabled. Use --vscode flag with preview command.", http.StatusNotFound)
+		http.Error(w, "Local edit links not enabled. Use --vscode or --edit-with flag with preview command.", http.StatusNotFound)
 		return
 	}
 
-	// VS Code edit handler is only for preview mode (single local repository)
+	// The edit handler is only for preview mode (single local repository)
 	if s.cfg.Daemon != nil && s.cfg.Daemon.Storage.RepoCacheDir != "" {
-		slog.Warn("VS Code edit handler called in daemon mode - this endpoint is for preview mode only",
+		s.log().Warn("Edit handler called in daemon mode - this endpoint is for preview mode only",
 			slog.String("path", r.URL.Path))
-		http.Error(w, "VS Code edit links are only available in preview mode", http.StatusNotImplemented)
+		http.Error(w, "Local edit links are only available in preview mode", http.StatusNotImplemented)
 		return
 	}
 
@@ -33,13 +34,23 @@ func (s *Server) handleVSCodeEdit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Execute the VS Code open command
-	if err := s.executeVSCodeOpen(r.Context(), absPath); err != nil {
+	opener, editor, err := s.resolveEditorOpener(w, r)
+	if err != nil {
+		s.handleEditError(w, err)
+		return
+	}
+
+	redirect, err := opener.Open(r.Context(), absPath)
+	if err != nil {
 		s.handleEditError(w, err)
 		return
 	}
 
-	slog.Info("Opened file in VS Code", slog.String("path", absPath))
+	s.log().Info("Opened file in editor", slog.String("path", absPath), slog.String("editor", editor))
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
 
 	// Redirect back to the referer, or to home if no referer
 	referer := r.Referer()
@@ -49,7 +60,7 @@ func (s *Server) handleVSCodeEdit(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, referer, http.StatusSeeOther)
 }
 
-// editError represents an error from the VS Code edit handler with an HTTP status code.
+// editError represents an error from the edit handlers with an HTTP status code.
 type editError struct {
 	message    string
 	statusCode int
@@ -63,16 +74,21 @@ func (e *editError) Error() string {
 
 // handleEditError logs and responds with the appropriate error.
 func (s *Server) handleEditError(w http.ResponseWriter, err error) {
+	writeEditError(w, "Edit handler", err)
+}
+
+// writeEditError logs err with the handler name and writes the matching HTTP error.
+func writeEditError(w http.ResponseWriter, handler string, err error) {
 	var editErr *editError
 	if ok := errors.As(err, &editErr); ok {
 		if editErr.logLevel == "error" {
-			slog.Error("VS Code edit handler: "+editErr.message, editErr.logFields...)
+			slog.Error(handler+": "+editErr.message, editErr.logFields...)
 		} else {
-			slog.Warn("VS Code edit handler: "+editErr.message, editErr.logFields...)
+			slog.Warn(handler+": "+editErr.message, editErr.logFields...)
 		}
 		http.Error(w, editErr.message, editErr.statusCode)
 	} else {
-		slog.Error("VS Code edit handler: unexpected error", slog.String("error", err.Error()))
+		slog.Error(handler+": unexpected error", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
@@ -26,7 +26,7 @@ func TestHandleVSCodeEdit_FeatureDisabled(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/_edit/test.md", nil)
 	w := httptest.NewRecorder()
 
-	srv.handleVSCodeEdit(w, req)
+	srv.handleEditLink(w, req)
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status 404, got %d", w.Code)
@@ -53,7 +53,7 @@ func TestHandleVSCodeEdit_DaemonMode(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/_edit/test.md", nil)
 	w := httptest.NewRecorder()
 
-	srv.handleVSCodeEdit(w, req)
+	srv.handleEditLink(w, req)
 
 	if w.Code != http.StatusNotImplemented {
 		t.Errorf("Expected status 501, got %d", w.Code)
@@ -695,7 +695,7 @@ func TestHandleVSCodeEdit_Integration(t *testing.T) {
 	req.Header.Set("Referer", "http://localhost:1314/docs/")
 	w := httptest.NewRecorder()
 
-	srv.handleVSCodeEdit(w, req)
+	srv.handleEditLink(w, req)
 
 	if w.Code != http.StatusSeeOther {
 		t.Errorf("Expected 303 (success), got %d: %s", w.Code, w.Body.String())
@@ -8,10 +8,15 @@ import (
 	"strings"
 )
 
-// validateAndResolveEditPath extracts the file path from the URL and validates it.
+// validateAndResolveEditPath extracts the file path from a /_edit/ URL and validates it.
 func (s *Server) validateAndResolveEditPath(urlPath string) (string, error) {
+	return s.resolveEditPath(urlPath, "/_edit/")
+}
+
+// resolveEditPath extracts the docs-relative file path following editPrefix and validates
+// that it names a regular markdown file inside the preview docs directory.
+func (s *Server) resolveEditPath(urlPath, editPrefix string) (string, error) {
 	// Extract file path from URL
-	const editPrefix = "/_edit/"
 	if !strings.HasPrefix(urlPath, editPrefix) {
 		return "", &editError{
 			message:    "Invalid edit URL",
@@ -153,7 +158,7 @@ func (s *Server) getDocsDirectory() string {
 		}
 	}
 
-	slog.Debug("VS Code edit handler: using repository URL as docs dir",
+	s.log().Debug("VS Code edit handler: using repository URL as docs dir",
 		slog.String("docs_dir", docsDir))
 	return docsDir
 }
@@ -54,7 +54,7 @@ func (s *Server) executeVSCodeOpen(parentCtx context.Context, absPath string) er
 			lastStderr = ""
 			lastStdout = ""
 			if attempt < len(backoffs) {
-				slog.Warn("VS Code edit handler: IPC socket not found, retrying",
+				s.log().Warn("VS Code edit handler: IPC socket not found, retrying",
 					slog.String("path", absPath),
 					slog.Int("attempt", attempt+1),
 					slog.Int("max_attempts", len(backoffs)+1))
@@ -88,7 +88,7 @@ func (s *Server) executeVSCodeOpen(parentCtx context.Context, absPath string) er
 
 		stdoutStr, stderrStr, err := runCLI(ctx, codeCmd, []string{"--reuse-window", "--goto", absPath}, append(os.Environ(), "VSCODE_IPC_HOOK_CLI="+ipcSocket))
 
-		slog.Debug("VS Code edit handler: executing command",
+		s.log().Debug("VS Code edit handler: executing command",
 			slog.String("path", absPath),
 			slog.String("code_cli", codeCmd),
 			slog.String("ipc_socket", ipcSocket),
@@ -101,7 +101,7 @@ func (s *Server) executeVSCodeOpen(parentCtx context.Context, absPath string) er
 			lastStderr = stderrStr
 
 			if attempt < len(backoffs) && isRetriableVSCodeOpenFailure(err, lastStderr) {
-				slog.Warn("VS Code edit handler: failed to open file, retrying",
+				s.log().Warn("VS Code edit handler: failed to open file, retrying",
 					slog.String("path", absPath),
 					slog.String("code_cli", codeCmd),
 					slog.String("error", err.Error()),
@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+// Access log line formats.
+const (
+	AccessLogJSON = "json"
+	AccessLogCLF  = "clf"
+)
+
+// clfTimeLayout is the timestamp layout of the Common Log Format.
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogOptions configures AccessLog.
+type AccessLogOptions struct {
+	Server string // server name recorded in each entry (docs, webhook, admin)
+	// Writer receives one line per request in Format. When nil, requests are logged as
+	// "HTTP access" records of Logger with Fields as attributes.
+	Writer io.Writer
+	Logger *slog.Logger
+	Format string   // AccessLogJSON (default) or AccessLogCLF
+	Fields []string // JSON fields and logger attributes, in order
+	// AssetSampleRate is the fraction of successful requests for paths with one of
+	// AssetExtensions that is logged.
+	AssetSampleRate float64
+	AssetExtensions []string
+}
+
+// accessEntry describes a served request.
+type accessEntry struct {
+	start    time.Time
+	duration time.Duration
+	status   int
+	bytes    int64
+	req      *http.Request
+	id       string
+}
+
+// AccessLog returns middleware that writes one access log entry per request.
+func AccessLog(opts AccessLogOptions) func(http.Handler) http.Handler {
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			wrapped := &accessResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(wrapped, r)
+
+			status := wrapped.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if !opts.sampled(r, status) {
+				return
+			}
+			// The request ID is assigned inside the handler chain; it is echoed in the response.
+			id := w.Header().Get(RequestIDHeader)
+			if id == "" {
+				id = observability.RequestID(r.Context())
+			}
+			opts.write(accessEntry{
+				start:    start,
+				duration: time.Since(start),
+				status:   status,
+				bytes:    wrapped.bytes,
+				req:      r,
+				id:       id,
+			})
+		})
+	}
+}
+
+// sampled reports whether a request is logged. Only successful asset requests are sampled.
+func (o AccessLogOptions) sampled(r *http.Request, status int) bool {
+	if o.AssetSampleRate >= 1 || status >= http.StatusBadRequest {
+		return true
+	}
+	if !slices.Contains(o.AssetExtensions, strings.ToLower(path.Ext(r.URL.Path))) {
+		return true
+	}
+	return rand.Float64() < o.AssetSampleRate // #nosec G404 -- sampling, not security sensitive
+}
+
+func (o AccessLogOptions) write(e accessEntry) {
+	if o.Writer == nil {
+		attrs := make([]slog.Attr, 0, len(o.Fields))
+		for _, field := range o.Fields {
+			if field == "time" {
+				continue // the record carries its own time
+			}
+			attrs = append(attrs, slog.Any(field, o.field(e, field)))
+		}
+		o.Logger.LogAttrs(e.req.Context(), slog.LevelInfo, "HTTP access", attrs...)
+		return
+	}
+
+	var line []byte
+	if o.Format == AccessLogCLF {
+		line = clfLine(e)
+	} else {
+		line = o.jsonLine(e)
+	}
+	if _, err := o.Writer.Write(line); err != nil {
+		o.Logger.Warn("Failed to write access log", "server", o.Server, "error", err)
+	}
+}
+
+// field returns the value of a named field of an entry.
+func (o AccessLogOptions) field(e accessEntry, name string) any {
+	r := e.req
+	switch name {
+	case "time":
+		return e.start.Format(time.RFC3339Nano)
+	case "server":
+		return o.Server
+	case "remote_addr":
+		return r.RemoteAddr
+	case "method":
+		return r.Method
+	case "host":
+		return r.Host
+	case "path":
+		return r.URL.Path
+	case "query":
+		return r.URL.RawQuery
+	case "protocol":
+		return r.Proto
+	case "status":
+		return e.status
+	case "bytes":
+		return e.bytes
+	case "duration_ms":
+		return float64(e.duration.Microseconds()) / 1000
+	case "referer":
+		return r.Referer()
+	case "user_agent":
+		return r.UserAgent()
+	case "request_id":
+		return e.id
+	default:
+		return nil
+	}
+}
+
+// jsonLine renders the configured fields as a JSON object, keeping their order.
+func (o AccessLogOptions) jsonLine(e accessEntry) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range o.Fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(o.field(e, name))
+		if err != nil {
+			value = []byte("null")
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// clfLine renders an entry in the Common Log Format:
+// host ident authuser [date] "request" status bytes
+func clfLine(e accessEntry) []byte {
+	r := e.req
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil || host == "" {
+		host = "-"
+	}
+	user := "-"
+	if name, _, ok := r.BasicAuth(); ok && name != "" {
+		user = strings.ReplaceAll(name, " ", "_")
+	}
+	size := "-"
+	if e.bytes > 0 {
+		size = strconv.FormatInt(e.bytes, 10)
+	}
+	request := strconv.Quote(r.Method + " " + r.URL.RequestURI() + " " + r.Proto)
+	return []byte(host + " - " + user + " [" + e.start.Format(clfTimeLayout) + "] " +
+		request + " " + strconv.Itoa(e.status) + " " + size + "\n")
+}
+
+// accessResponseWriter captures the status code and response size.
+type accessResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (rw *accessResponseWriter) WriteHeader(code int) {
+	if rw.status == 0 {
+		rw.status = code
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *accessResponseWriter) Write(p []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// ReadFrom keeps the underlying writer's sendfile path for static files.
+func (rw *accessResponseWriter) ReadFrom(src io.Reader) (int64, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	n, err := io.Copy(rw.ResponseWriter, src)
+	rw.bytes += n
+	return n, err
+}
+
+// Flush supports streaming handlers.
+func (rw *accessResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (rw *accessResponseWriter) Unwrap() http.ResponseWriter { return rw.ResponseWriter }
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func serveAccessLogged(opts AccessLogOptions, target string, status int) {
+	h := AccessLog(opts)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-7")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = "192.0.2.10:51234"
+	req.Header.Set("User-Agent", "curl/8")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestAccessLogJSON(t *testing.T) {
+	var out bytes.Buffer
+	serveAccessLogged(AccessLogOptions{
+		Server: "docs",
+		Writer: &out,
+		Format: AccessLogJSON,
+		Fields: []string{"server", "path", "query", "status", "bytes", "user_agent", "request_id"},
+	}, "/guide/?q=1", http.StatusOK)
+
+	want := `{"server":"docs","path":"/guide/","query":"q=1","status":200,"bytes":5,"user_agent":"curl/8","request_id":"req-7"}` + "\n"
+	if out.String() != want {
+		t.Fatalf("access log =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestAccessLogCLF(t *testing.T) {
+	var out bytes.Buffer
+	serveAccessLogged(AccessLogOptions{Server: "docs", Writer: &out, Format: AccessLogCLF}, "/guide/?q=1", http.StatusNotFound)
+
+	re := regexp.MustCompile(`^192\.0\.2\.10 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /guide/\?q=1 HTTP/1\.1" 404 5\n$`)
+	if !re.MatchString(out.String()) {
+		t.Fatalf("unexpected CLF line %q", out.String())
+	}
+}
+
+func TestAccessLogSamplesAssets(t *testing.T) {
+	var out bytes.Buffer
+	opts := AccessLogOptions{
+		Writer:          &out,
+		Format:          AccessLogJSON,
+		Fields:          []string{"path", "status"},
+		AssetSampleRate: 0,
+		AssetExtensions: []string{".css", ".png"},
+	}
+	serveAccessLogged(opts, "/css/site.CSS", http.StatusOK)
+	serveAccessLogged(opts, "/img/missing.png", http.StatusNotFound)
+	serveAccessLogged(opts, "/guide/", http.StatusOK)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the failed asset and the page, got:\n%s", out.String())
+	}
+	var first struct{ Path string }
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.Path != "/img/missing.png" {
+		t.Fatalf("first entry %q (%v)", lines[0], err)
+	}
+}
+
+func TestAccessLogFallsBackToLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	serveAccessLogged(AccessLogOptions{
+		Server: "admin",
+		Logger: logger,
+		Fields: []string{"time", "server", "method", "status"},
+	}, "/api/daemon/status", http.StatusOK)
+
+	got := out.String()
+	if !strings.Contains(got, `msg="HTTP access" server=admin method=GET status=200`) {
+		t.Fatalf("unexpected access record %q", got)
+	}
+}
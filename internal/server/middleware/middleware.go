@@ -2,19 +2,73 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"log/slog"
 	"net/http"
+	"runtime/debug"
 	"time"
 
+	"git.home.luguber.info/inful/docbuilder/internal/crash"
 	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
 	"git.home.luguber.info/inful/docbuilder/internal/logfields"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
 )
 
-// Chain returns a middleware wrapper that applies logging and panic recovery around a handler.
+// RequestIDHeader carries the request ID of every request and response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs.
+const maxRequestIDLength = 128
+
+// Chain returns a middleware wrapper that assigns request IDs and applies logging and
+// panic recovery around a handler.
 func Chain(logger *slog.Logger, adapter *derrors.HTTPErrorAdapter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return loggingMiddleware(logger, panicRecoveryMiddleware(logger, adapter, next))
+		return requestIDMiddleware(loggingMiddleware(logger, panicRecoveryMiddleware(logger, adapter, next)))
+	}
+}
+
+// RecoveryChain is Chain without the request log, for servers that write access logs.
+func RecoveryChain(logger *slog.Logger, adapter *derrors.HTTPErrorAdapter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return requestIDMiddleware(panicRecoveryMiddleware(logger, adapter, next))
+	}
+}
+
+// requestIDMiddleware reuses a well-formed X-Request-ID from the client or generates one,
+// echoes it in the response and stores it in the request context for logs and errors.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(observability.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID accepts short IDs made of letters, digits and "-_.:".
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
 	}
+	return true
+}
+
+// newRequestID returns a random 128-bit hex ID.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
 }
 
 // loggingMiddleware logs method, path, status, duration, user agent, and remote addr.
@@ -30,7 +84,8 @@ func loggingMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 			logfields.Status(wrapped.statusCode),
 			slog.Duration("duration", duration),
 			logfields.UserAgent(r.UserAgent()),
-			logfields.RemoteAddr(r.RemoteAddr))
+			logfields.RemoteAddr(r.RemoteAddr),
+			logfields.RequestID(observability.RequestID(r.Context())))
 	})
 }
 
@@ -38,14 +93,19 @@ func loggingMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 func panicRecoveryMiddleware(logger *slog.Logger, adapter *derrors.HTTPErrorAdapter, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
-			if err := recover(); err != nil {
+			if rec := recover(); rec != nil {
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
 				logger.Error("HTTP handler panic",
-					"error", err,
+					"error", rec,
 					"path", r.URL.Path,
 					"method", r.Method,
-					"remote_addr", r.RemoteAddr)
+					"remote_addr", r.RemoteAddr,
+					logfields.RequestID(observability.RequestID(r.Context())))
 
-				panicErr := derrors.InternalError("internal server error").
+				cause := crash.Default().Recovered("http", "", rec, debug.Stack())
+				panicErr := derrors.WrapError(cause, derrors.CategoryInternal, "internal server error").
 					WithContext("path", r.URL.Path).
 					WithContext("method", r.Method).
 					Build()
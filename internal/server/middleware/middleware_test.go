@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+	"git.home.luguber.info/inful/docbuilder/internal/observability"
+)
+
+func TestChainRecoversHandlerPanic(t *testing.T) {
+	h := Chain(slog.Default(), derrors.NewHTTPErrorAdapter(nil))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("handler exploded")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/x", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	var body derrors.HTTPErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ErrorCode != string(derrors.CodeInternalPanic) {
+		t.Errorf("error_code = %q, want %q", body.ErrorCode, derrors.CodeInternalPanic)
+	}
+}
+
+func TestChainAssignsRequestID(t *testing.T) {
+	var seen string
+	h := Chain(slog.Default(), derrors.NewHTTPErrorAdapter(nil))(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = observability.RequestID(r.Context())
+	}))
+
+	t.Run("generated", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		id := rec.Header().Get(RequestIDHeader)
+		if len(id) != 32 || id != seen {
+			t.Fatalf("response id %q, context id %q", id, seen)
+		}
+	})
+
+	t.Run("passthrough", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, "ci-run-42:step.1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if got := rec.Header().Get(RequestIDHeader); got != "ci-run-42:step.1" || seen != got {
+			t.Fatalf("response id %q, context id %q", got, seen)
+		}
+	})
+
+	t.Run("malformed replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, "bad id\nwith newline")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if got := rec.Header().Get(RequestIDHeader); got == "bad id\nwith newline" || len(got) != 32 {
+			t.Fatalf("malformed id not replaced: %q", got)
+		}
+	})
+}
+
+func TestErrorResponseIncludesRequestID(t *testing.T) {
+	adapter := derrors.NewHTTPErrorAdapter(nil)
+	h := Chain(slog.Default(), adapter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adapter.WriteErrorResponse(w, r, derrors.NotFoundError("build report").Build())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/builds/x/report", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body derrors.HTTPErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.RequestID != "req-1" {
+		t.Errorf("request_id = %q, want req-1", body.RequestID)
+	}
+}
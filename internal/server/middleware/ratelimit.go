@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+// maxRateLimitBuckets bounds the tracked client/endpoint pairs; idle buckets are
+// evicted first when the limit is reached, then the least recently used.
+const maxRateLimitBuckets = 10000
+
+// unmatchedEndpoint is the endpoint of requests that match no route; they share one
+// bucket per client.
+const unmatchedEndpoint = "(unmatched)"
+
+// rateLimitSweepInterval is how often idle buckets are evicted.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimiter is a token bucket limiter keyed by client IP and endpoint.
+type RateLimiter struct {
+	rate       float64 // tokens per second
+	burst      float64
+	trustProxy bool
+	now        func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*list.Element // values are *tokenBucket
+	lru       *list.List               // most recently used first
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing rate requests per second per client and
+// endpoint, with bursts of up to burst requests. With trustProxy, clients are identified
+// by X-Forwarded-For or X-Real-IP instead of the connection address.
+func NewRateLimiter(rate float64, burst int, trustProxy bool) *RateLimiter {
+	return &RateLimiter{
+		rate:       rate,
+		burst:      float64(max(burst, 1)),
+		trustProxy: trustProxy,
+		now:        time.Now,
+		buckets:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Allow takes a token for key. When none is left it reports how long until one is.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	var b *tokenBucket
+	if elem, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(elem)
+		b = elem.Value.(*tokenBucket)
+	} else {
+		b = &tokenBucket{key: key, tokens: l.burst, last: now}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep evicts buckets that have refilled completely, at most once per interval unless
+// the bucket limit is reached. Callers hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	if len(l.buckets) < maxRateLimitBuckets && now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, elem := range l.buckets {
+		b := elem.Value.(*tokenBucket)
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			l.lru.Remove(elem)
+			delete(l.buckets, key)
+		}
+	}
+	// Under a flood of distinct clients nothing is idle; drop the least recently used
+	// buckets so that the buckets of active clients keep throttling them.
+	for len(l.buckets) >= maxRateLimitBuckets {
+		b := l.lru.Remove(l.lru.Back()).(*tokenBucket)
+		delete(l.buckets, b.key)
+	}
+}
+
+// ClientIP returns the address identifying the client of r.
+func (l *RateLimiter) ClientIP(r *http.Request) string { return ClientIP(r, l.trustProxy) }
+
+// ClientIP returns the client address of r. With trustProxy, X-Forwarded-For or X-Real-IP
+// take precedence over the connection address. Of X-Forwarded-For only the last entry,
+// the one appended by the trusted proxy, is used: earlier entries are sent by the client.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if fwd := r.Header.Values("X-Forwarded-For"); len(fwd) > 0 {
+			last := fwd[len(fwd)-1]
+			if i := strings.LastIndexByte(last, ','); i >= 0 {
+				last = last[i+1:]
+			}
+			if last = strings.TrimSpace(last); last != "" {
+				return last
+			}
+		}
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// routeMatcher finds the route pattern of a request; *http.ServeMux implements it.
+type routeMatcher interface {
+	Handler(r *http.Request) (http.Handler, string)
+}
+
+// endpoint returns the route pattern next serves r with, or unmatchedEndpoint. The raw
+// path is not used: clients could otherwise get a fresh bucket for every path they make up.
+func endpoint(next http.Handler, r *http.Request) string {
+	if r.Pattern != "" {
+		return r.Pattern
+	}
+	if mux, ok := next.(routeMatcher); ok {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedEndpoint
+}
+
+// RateLimit returns middleware that answers requests over the limit with 429 Too Many
+// Requests and a Retry-After header. Requests are limited per client and per route of
+// next when it is a *http.ServeMux. onThrottle, when set, is called for each rejected request.
+func RateLimit(limiter *RateLimiter, adapter *derrors.HTTPErrorAdapter, onThrottle func()) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := limiter.ClientIP(r)
+			allowed, wait := limiter.Allow(client + " " + endpoint(next, r))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if onThrottle != nil {
+				onThrottle()
+			}
+			retryAfter := max(1, int(math.Ceil(wait.Seconds())))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			adapter.WriteErrorResponse(w, r, derrors.NewError(derrors.CategoryRuntime, "too many requests").
+				WithCode(derrors.CodeRuntimeRateLimited).
+				WithSeverity(derrors.SeverityInfo).
+				RateLimit().
+				WithContext("client", client).
+				WithContext("path", r.URL.Path).
+				WithContext("retry_after_seconds", retryAfter).
+				Build())
+		})
+	}
+}
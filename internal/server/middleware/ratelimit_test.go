@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	derrors "git.home.luguber.info/inful/docbuilder/internal/foundation/errors"
+)
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(2, 3, false)
+	l.now = func() time.Time { return now }
+
+	for i := range 3 {
+		if ok, _ := l.Allow("a"); !ok {
+			t.Fatalf("request %d within burst was rejected", i+1)
+		}
+	}
+	ok, wait := l.Allow("a")
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("expected rejection with 500ms wait, got ok=%v wait=%s", ok, wait)
+	}
+	if ok, _ := l.Allow("b"); !ok {
+		t.Fatalf("other keys have their own bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.Allow("a"); !ok {
+		t.Fatalf("expected a refilled token after 500ms")
+	}
+}
+
+func TestRateLimit_Responds429(t *testing.T) {
+	throttled := 0
+	l := NewRateLimiter(1, 1, true)
+	h := RateLimit(l, derrors.NewHTTPErrorAdapter(nil), func() { throttled++ })(newRateLimitTestMux())
+	serve := func(path, client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("X-Forwarded-For", "198.51.100.7, "+client)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("/webhooks/github", "192.0.2.1"); rec.Code != http.StatusNoContent {
+		t.Fatalf("first request: got %d", rec.Code)
+	}
+	rec := serve("/webhooks/github", "192.0.2.1")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("expected 429 with Retry-After, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	var body derrors.HTTPErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ErrorCode != string(derrors.CodeRuntimeRateLimited) {
+		t.Fatalf("error_code = %q, want %q", body.ErrorCode, derrors.CodeRuntimeRateLimited)
+	}
+	if throttled != 1 {
+		t.Fatalf("expected one throttled request, got %d", throttled)
+	}
+
+	// Limits apply per client and per endpoint.
+	if rec := serve("/webhooks/github", "192.0.2.2"); rec.Code != http.StatusNoContent {
+		t.Fatalf("other client: got %d", rec.Code)
+	}
+	if rec := serve("/webhook", "192.0.2.1"); rec.Code != http.StatusNoContent {
+		t.Fatalf("other endpoint: got %d", rec.Code)
+	}
+}
+
+func newRateLimitTestMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	noContent := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }
+	mux.HandleFunc("/webhooks/{forge}", noContent)
+	mux.HandleFunc("/webhook", noContent)
+	return mux
+}
+
+func TestRateLimit_IgnoresSpoofedForwardedFor(t *testing.T) {
+	l := NewRateLimiter(1, 1, true)
+	h := RateLimit(l, derrors.NewHTTPErrorAdapter(nil), nil)(newRateLimitTestMux())
+
+	// The client makes up the leftmost entries; the proxy appends the address it saw.
+	for i, spoofed := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+		req.Header.Set("X-Forwarded-For", spoofed+", 192.0.2.1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if want := []int{http.StatusNoContent, http.StatusTooManyRequests}[i]; rec.Code != want {
+			t.Fatalf("request %d with X-Forwarded-For %q: got %d, want %d", i+1, req.Header.Get("X-Forwarded-For"), rec.Code, want)
+		}
+	}
+}
+
+func TestRateLimit_KeysOnRouteNotPath(t *testing.T) {
+	l := NewRateLimiter(1, 1, false)
+	h := RateLimit(l, derrors.NewHTTPErrorAdapter(nil), nil)(newRateLimitTestMux())
+	serve := func(path string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec.Code
+	}
+
+	// Paths of one route, and paths matching no route, share a bucket.
+	if code := serve("/webhooks/github"); code != http.StatusNoContent {
+		t.Fatalf("first request: got %d", code)
+	}
+	if code := serve("/webhooks/gitlab"); code != http.StatusTooManyRequests {
+		t.Fatalf("same route, other path: got %d, want 429", code)
+	}
+	if code := serve("/api/x1"); code != http.StatusNotFound {
+		t.Fatalf("first unmatched path: got %d, want 404", code)
+	}
+	if code := serve("/api/x2"); code != http.StatusTooManyRequests {
+		t.Fatalf("other unmatched path: got %d, want 429", code)
+	}
+}
+
+func TestRateLimiter_EvictsLeastRecentlyUsed(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(1, 1, false)
+	l.now = func() time.Time { return now }
+
+	if ok, _ := l.Allow("active"); !ok {
+		t.Fatal("first request was rejected")
+	}
+	for i := range maxRateLimitBuckets {
+		l.Allow(fmt.Sprintf("flood-%d", i))
+		if i%1000 == 0 {
+			// The throttled client keeps trying; its bucket stays recently used.
+			if ok, _ := l.Allow("active"); ok {
+				t.Fatalf("throttled client was allowed after %d flood buckets", i)
+			}
+		}
+	}
+	if len(l.buckets) > maxRateLimitBuckets || l.lru.Len() != len(l.buckets) {
+		t.Fatalf("buckets = %d, lru = %d; want at most %d", len(l.buckets), l.lru.Len(), maxRateLimitBuckets)
+	}
+	if ok, _ := l.Allow("active"); ok {
+		t.Fatal("throttled client was allowed after the bucket limit was reached")
+	}
+	if _, ok := l.buckets["flood-0"]; ok {
+		t.Fatal("least recently used bucket was not evicted")
+	}
+}
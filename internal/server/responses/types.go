@@ -20,6 +20,16 @@ type DaemonStatusResponse struct {
 	Uptime    float64             `json:"uptime"`
 	StartTime time.Time           `json:"start_time"`
 	Config    DaemonConfigSummary `json:"config"`
+	Leader    *LeaderStatus       `json:"leader,omitempty"`
+}
+
+// LeaderStatus reports leader election as seen by this replica.
+type LeaderStatus struct {
+	Identity    string     `json:"identity"`
+	Leader      string     `json:"leader,omitempty"`
+	IsLeader    bool       `json:"is_leader"`
+	Transitions int        `json:"transitions"`
+	RenewTime   *time.Time `json:"renew_time,omitempty"`
 }
 
 // DaemonConfigSummary represents a summary of daemon configuration.
@@ -32,8 +42,10 @@ type DaemonConfigSummary struct {
 
 // TriggerResponse represents the response for trigger operations.
 type TriggerResponse struct {
-	Status string `json:"status"`
-	JobID  string `json:"job_id"`
+	Status       string            `json:"status"`
+	JobID        string            `json:"job_id"`
+	Repositories []string          `json:"repositories,omitempty"` // build scope, when limited
+	Commits      map[string]string `json:"commits,omitempty"`      // requested commits by repository
 }
 
 // HealthResponse represents the health check API response.
@@ -0,0 +1,267 @@
+// Package sitearchive packages a rendered site into timestamped offline archives
+// (zip or tar.gz) with a file manifest, and lists and prunes the archives of a directory.
+package sitearchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+const (
+	// namePrefix starts every archive file name: site-<UTC timestamp>.<ext>.
+	namePrefix = "site-"
+	// timeLayout sorts lexically in time order.
+	timeLayout = "20060102T150405Z"
+	// siteDir holds the site files inside an archive, next to ManifestName.
+	siteDir = "site"
+	// ManifestName is the manifest file at the root of an archive.
+	ManifestName = "manifest.json"
+)
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Created           time.Time `json:"created"`
+	DocBuilderVersion string    `json:"docbuilder_version,omitempty"`
+	ConfigHash        string    `json:"config_hash,omitempty"`
+	Files             []File    `json:"files"`
+}
+
+// File is a site file in an archive; Path is relative to the site root.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Archive is an archive file in an archive directory.
+type Archive struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Bytes   int64     `json:"bytes"`
+}
+
+// Options describe an archive to write.
+type Options struct {
+	Format            config.ArchiveFormat
+	Created           time.Time
+	DocBuilderVersion string
+	ConfigHash        string
+}
+
+// Write packages siteRoot into a new archive in dir and returns its path. Site files
+// are stored below "site/" and the manifest is written last, once every file hash is
+// known. The archive appears atomically under its final name.
+func Write(siteRoot, dir string, opts Options) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("create archive directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".site-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create archive: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	var w archiveWriter
+	if opts.Format == config.ArchiveTarGz {
+		w = newTarGzWriter(tmp)
+	} else {
+		w = &zipWriter{zw: zip.NewWriter(tmp)}
+	}
+	manifest := Manifest{
+		Created:           opts.Created.UTC(),
+		DocBuilderVersion: opts.DocBuilderVersion,
+		ConfigHash:        opts.ConfigHash,
+		Files:             []File{},
+	}
+	err = filepath.WalkDir(siteRoot, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || !d.Type().IsRegular() {
+			return walkErr
+		}
+		rel, err := filepath.Rel(siteRoot, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f, err := addFile(w, path, filepath.ToSlash(rel), info)
+		if err != nil {
+			return fmt.Errorf("add %s: %w", rel, err)
+		}
+		manifest.Files = append(manifest.Files, f)
+		return nil
+	})
+	if err == nil {
+		err = addManifest(w, manifest)
+	}
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("write archive: %w", err)
+	}
+
+	final := filepath.Join(dir, Name(opts.Created, opts.Format))
+	if err := os.Rename(tmp.Name(), final); err != nil {
+		return "", fmt.Errorf("publish archive: %w", err)
+	}
+	return final, nil
+}
+
+// Name returns the file name of an archive created at t.
+func Name(t time.Time, format config.ArchiveFormat) string {
+	if format == "" {
+		format = config.ArchiveZip
+	}
+	return namePrefix + t.UTC().Format(timeLayout) + "." + string(format)
+}
+
+// List returns the archives in dir, newest first. A missing directory has none.
+func List(dir string) ([]Archive, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var archives []Archive
+	for _, e := range entries {
+		created, ok := parseName(e.Name())
+		if !ok || !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		archives = append(archives, Archive{Name: e.Name(), Created: created, Bytes: info.Size()})
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		if !archives[i].Created.Equal(archives[j].Created) {
+			return archives[i].Created.After(archives[j].Created)
+		}
+		return archives[i].Name > archives[j].Name
+	})
+	return archives, nil
+}
+
+// Prune removes all but the newest retain archives of dir and returns the removed names.
+func Prune(dir string, retain int) ([]string, error) {
+	archives, err := List(dir)
+	if err != nil || len(archives) <= retain {
+		return nil, err
+	}
+	var removed []string
+	for _, a := range archives[retain:] {
+		if err := os.Remove(filepath.Join(dir, a.Name)); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed = append(removed, a.Name)
+	}
+	return removed, nil
+}
+
+// parseName reports whether name is an archive file name and returns its creation time.
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, namePrefix) {
+		return time.Time{}, false
+	}
+	rest := strings.TrimPrefix(name, namePrefix)
+	for _, format := range []config.ArchiveFormat{config.ArchiveZip, config.ArchiveTarGz} {
+		if stamp, ok := strings.CutSuffix(rest, "."+string(format)); ok {
+			t, err := time.Parse(timeLayout, stamp)
+			return t, err == nil
+		}
+	}
+	return time.Time{}, false
+}
+
+func addFile(w archiveWriter, path, rel string, info fs.FileInfo) (File, error) {
+	// #nosec G304 -- path comes from walking the rendered site
+	src, err := os.Open(path)
+	if err != nil {
+		return File{}, err
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := w.Create(siteDir+"/"+rel, info.Size(), info.ModTime())
+	if err != nil {
+		return File{}, err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dst, h), src)
+	if err != nil {
+		return File{}, err
+	}
+	return File{Path: rel, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+func addManifest(w archiveWriter, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	dst, err := w.Create(ManifestName, int64(len(data)), m.Created)
+	if err != nil {
+		return err
+	}
+	_, err = dst.Write(data)
+	return err
+}
+
+// archiveWriter adds files to a zip or tar.gz stream.
+type archiveWriter interface {
+	Create(name string, size int64, modified time.Time) (io.Writer, error)
+	Close() error
+}
+
+type zipWriter struct{ zw *zip.Writer }
+
+func (z *zipWriter) Create(name string, _ int64, modified time.Time) (io.Writer, error) {
+	return z.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+}
+
+func (z *zipWriter) Close() error { return z.zw.Close() }
+
+type tarGzWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzWriter(w io.Writer) *tarGzWriter {
+	gz := gzip.NewWriter(w)
+	return &tarGzWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+func (t *tarGzWriter) Create(name string, size int64, modified time.Time) (io.Writer, error) {
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: size, ModTime: modified, Typeflag: tar.TypeReg}
+	if err := t.tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+	return t.tw, nil
+}
+
+func (t *tarGzWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gz.Close()
+}
@@ -0,0 +1,123 @@
+package sitearchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func writeSite(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"index.html":          "<h1>Home</h1>",
+		"guide/index.html":    "<h1>Guide</h1>",
+		"downloads/guide.pdf": "%PDF",
+		"css/theme/style.css": "body{}",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func TestWrite_ZipWithManifest(t *testing.T) {
+	site, dir := writeSite(t), t.TempDir()
+	created := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	file, err := Write(site, dir, Options{Format: config.ArchiveZip, Created: created, DocBuilderVersion: "1.2.3", ConfigHash: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "site-20260301T123000Z.zip"), file)
+
+	zr, err := zip.OpenReader(file)
+	require.NoError(t, err)
+	defer func() { _ = zr.Close() }()
+	names := map[string]*zip.File{}
+	for _, f := range zr.File {
+		names[f.Name] = f
+	}
+	assert.Contains(t, names, "site/guide/index.html")
+	require.Contains(t, names, ManifestName)
+
+	rc, err := names[ManifestName].Open()
+	require.NoError(t, err)
+	var m Manifest
+	require.NoError(t, json.NewDecoder(rc).Decode(&m))
+	_ = rc.Close()
+	assert.Equal(t, "1.2.3", m.DocBuilderVersion)
+	assert.Equal(t, "abc", m.ConfigHash)
+	require.Len(t, m.Files, 4)
+	assert.Equal(t, "css/theme/style.css", m.Files[0].Path)
+	assert.Equal(t, int64(6), m.Files[0].Size)
+	sum := sha256.Sum256([]byte("body{}"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), m.Files[0].SHA256)
+}
+
+func TestWrite_TarGz(t *testing.T) {
+	site, dir := writeSite(t), t.TempDir()
+	file, err := Write(site, dir, Options{Format: config.ArchiveTarGz, Created: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, ".gz", filepath.Ext(file))
+
+	f, err := os.Open(file) // #nosec G304 -- test archive
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[hdr.Name] = string(data)
+	}
+	assert.Equal(t, "<h1>Home</h1>", contents["site/index.html"])
+	assert.Contains(t, contents, ManifestName)
+}
+
+func TestListAndPrune(t *testing.T) {
+	site, dir := writeSite(t), t.TempDir()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		_, err := Write(site, dir, Options{Created: base.Add(time.Duration(i) * time.Hour)})
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600))
+
+	archives, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, archives, 4)
+	assert.Equal(t, "site-20260101T030000Z.zip", archives[0].Name)
+	assert.Positive(t, archives[0].Bytes)
+
+	removed, err := Prune(dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"site-20260101T010000Z.zip", "site-20260101T000000Z.zip"}, removed)
+	archives, err = List(dir)
+	require.NoError(t, err)
+	assert.Len(t, archives, 2)
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+
+	missing, err := List(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
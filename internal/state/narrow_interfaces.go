@@ -88,6 +88,34 @@ type DiscoveryRecorder interface {
 	RecordDiscovery(repoURL string, documentCount int)
 }
 
+// DiscoverySnapshotStore persists the daemon's most recent discovery result so it
+// survives restarts. The snapshot is opaque to the state package.
+type DiscoverySnapshotStore interface {
+	// SetDiscoverySnapshot stores the encoded discovery snapshot.
+	SetDiscoverySnapshot(data []byte)
+
+	// GetDiscoverySnapshot returns the stored snapshot, or nil if none exists.
+	GetDiscoverySnapshot() []byte
+}
+
+// RepositoryRenamer moves repository state to a new URL when a repository is renamed
+// or transferred on its forge.
+type RepositoryRenamer interface {
+	// RenameRepositoryState moves the entry of oldURL to newURL and name, keeping its
+	// history. It reports whether an entry was moved.
+	RenameRepositoryState(oldURL, newURL, name string) bool
+}
+
+// ReleaseNotesStore persists the forge releases recorded by the daemon. The encoded
+// releases are opaque to the state package.
+type ReleaseNotesStore interface {
+	// SetReleaseNotes stores the encoded releases.
+	SetReleaseNotes(data []byte)
+
+	// GetReleaseNotes returns the stored releases, or nil if none exist.
+	GetReleaseNotes() []byte
+}
+
 // DaemonStateManager is the aggregate interface for daemon state management.
 // It combines all the narrow interfaces into a single type for convenient type assertions.
 // Implemented by state.ServiceAdapter.
@@ -99,6 +127,9 @@ type DaemonStateManager interface {
 	RepositoryBuildCounter
 	ConfigurationStateStore
 	DiscoveryRecorder
+	DiscoverySnapshotStore
+	RepositoryRenamer
+	ReleaseNotesStore
 }
 
 // Compile-time verification that ServiceAdapter implements DaemonStateManager.
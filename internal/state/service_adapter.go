@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"sync"
 	"time"
@@ -138,6 +139,39 @@ func (a *ServiceAdapter) RemoveRepositoryState(url string) {
 	}
 }
 
+// --- RepositoryRenamer interface ---
+
+// RenameRepositoryState moves the entry of oldURL to newURL, keeping its counters,
+// hashes and last commit. An existing entry for newURL is replaced.
+func (a *ServiceAdapter) RenameRepositoryState(oldURL, newURL, name string) bool {
+	if oldURL == "" || newURL == "" || oldURL == newURL {
+		return false
+	}
+	ctx := context.Background()
+	store := a.service.GetRepositoryStore()
+	existing := store.GetByURL(ctx, oldURL)
+	if existing.IsErr() || existing.Unwrap().IsNone() {
+		return false
+	}
+	repo := *existing.Unwrap().Unwrap()
+	repo.URL = newURL
+	if name != "" {
+		repo.Name = name
+	}
+	repo.UpdatedAt = time.Now()
+
+	_ = store.Delete(ctx, newURL)
+	if res := store.Create(ctx, &repo); res.IsErr() {
+		slog.Warn("Failed to move repository state",
+			slog.String("from", oldURL),
+			slog.String("to", newURL),
+			slog.Any("error", res.UnwrapErr()))
+		return false
+	}
+	a.RemoveRepositoryState(oldURL)
+	return true
+}
+
 // --- RepositoryMetadataWriter interface ---
 
 // SetRepoDocumentCount sets the document count for a repository.
@@ -373,6 +407,76 @@ func (a *ServiceAdapter) GetLastGlobalDocFilesHash() string {
 	return ""
 }
 
+// --- DiscoverySnapshotStore interface ---
+
+// SetDiscoverySnapshot stores the encoded discovery snapshot. The snapshot is kept
+// as embedded JSON so the state file stays readable.
+func (a *ServiceAdapter) SetDiscoverySnapshot(data []byte) {
+	if len(data) == 0 || !json.Valid(data) {
+		return
+	}
+	ctx := context.Background()
+	store := a.service.GetConfigurationStore()
+	res := store.Set(ctx, "discovery_snapshot", json.RawMessage(data))
+	if res.IsErr() {
+		slog.Warn("Failed to persist discovery snapshot", slog.Any("error", res.UnwrapErr()))
+	}
+}
+
+// GetDiscoverySnapshot returns the stored discovery snapshot, or nil if none exists.
+func (a *ServiceAdapter) GetDiscoverySnapshot() []byte {
+	ctx := context.Background()
+	store := a.service.GetConfigurationStore()
+	result := store.Get(ctx, "discovery_snapshot")
+	if result.IsErr() {
+		return nil
+	}
+	opt := result.Unwrap()
+	if opt.IsNone() {
+		return nil
+	}
+	// Values loaded from disk are decoded into generic JSON values; re-encode them.
+	data, err := json.Marshal(opt.Unwrap())
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// --- ReleaseNotesStore interface ---
+
+// SetReleaseNotes stores the encoded releases as embedded JSON.
+func (a *ServiceAdapter) SetReleaseNotes(data []byte) {
+	if len(data) == 0 || !json.Valid(data) {
+		return
+	}
+	ctx := context.Background()
+	store := a.service.GetConfigurationStore()
+	res := store.Set(ctx, "release_notes", json.RawMessage(data))
+	if res.IsErr() {
+		slog.Warn("Failed to persist release notes", slog.Any("error", res.UnwrapErr()))
+	}
+}
+
+// GetReleaseNotes returns the stored releases, or nil if none exist.
+func (a *ServiceAdapter) GetReleaseNotes() []byte {
+	ctx := context.Background()
+	store := a.service.GetConfigurationStore()
+	result := store.Get(ctx, "release_notes")
+	if result.IsErr() {
+		return nil
+	}
+	opt := result.Unwrap()
+	if opt.IsNone() {
+		return nil
+	}
+	data, err := json.Marshal(opt.Unwrap())
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 // --- Additional methods used by Daemon ---
 
 // RecordDiscovery records a discovery operation for a repository.
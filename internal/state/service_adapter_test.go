@@ -154,6 +154,35 @@ func TestServiceAdapter(t *testing.T) {
 		// the operation completing without error is the main check
 	})
 
+	t.Run("RepositoryRenamer interface", func(t *testing.T) {
+		oldURL := "https://github.com/test/old-name"
+		newURL := "https://github.com/test/new-name"
+		adapter.SetRepoLastCommit(oldURL, "old-name", "main", "fedcba987654")
+
+		if !adapter.RenameRepositoryState(oldURL, newURL, "new-name") {
+			t.Fatal("RenameRepositoryState() should move an existing entry")
+		}
+		if got := adapter.GetRepoLastCommit(newURL); got != "fedcba987654" {
+			t.Errorf("Expected commit to move to the new URL, got: %q", got)
+		}
+		if adapter.GetRepository(oldURL) != nil {
+			t.Error("Old URL should have no state after the rename")
+		}
+		if adapter.RenameRepositoryState("https://github.com/test/missing", newURL, "x") {
+			t.Error("RenameRepositoryState() should report false for unknown URLs")
+		}
+	})
+
+	t.Run("ReleaseNotesStore interface", func(t *testing.T) {
+		if got := adapter.GetReleaseNotes(); got != nil {
+			t.Errorf("Expected no release notes, got: %s", got)
+		}
+		adapter.SetReleaseNotes([]byte(`{"test/repo":[{"tag":"v1.0.0"}]}`))
+		if got := string(adapter.GetReleaseNotes()); got != `{"test/repo":[{"tag":"v1.0.0"}]}` {
+			t.Errorf("Unexpected release notes: %s", got)
+		}
+	})
+
 	t.Run("DaemonStateManager compile-time verification", func(t *testing.T) {
 		// This test verifies at compile time that ServiceAdapter implements DaemonStateManager
 		var _ DaemonStateManager = adapter
@@ -228,3 +257,28 @@ func TestServiceAdapterSaveTimestamp(t *testing.T) {
 		t.Errorf("LastSaved() timestamp out of expected range")
 	}
 }
+
+func TestServiceAdapterDiscoverySnapshotSurvivesReopen(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	serviceResult := NewService(tmpDir)
+	if serviceResult.IsErr() {
+		t.Fatalf("Failed to create service: %v", serviceResult.UnwrapErr())
+	}
+	adapter := NewServiceAdapter(serviceResult.Unwrap())
+
+	if got := adapter.GetDiscoverySnapshot(); got != nil {
+		t.Fatalf("Expected no snapshot initially, got: %s", got)
+	}
+	adapter.SetDiscoverySnapshot([]byte(`{"result":{"repositories":[{"name":"api"}]},"updated_at":"2026-01-02T03:04:05Z"}`))
+
+	reopened := NewService(tmpDir)
+	if reopened.IsErr() {
+		t.Fatalf("Failed to reopen service: %v", reopened.UnwrapErr())
+	}
+	got := NewServiceAdapter(reopened.Unwrap()).GetDiscoverySnapshot()
+	want := `{"result":{"repositories":[{"name":"api"}]},"updated_at":"2026-01-02T03:04:05Z"}`
+	if string(got) != want {
+		t.Errorf("GetDiscoverySnapshot() after reopen = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package staticsource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// extraction summarizes an extracted archive.
+type extraction struct {
+	Files    int       // number of files written
+	Modified time.Time // latest modification time of the written files
+}
+
+func (e *extraction) add(modified time.Time) {
+	e.Files++
+	if modified.After(e.Modified) {
+		e.Modified = modified
+	}
+}
+
+// extractArchive extracts a zip, tar or gzip-compressed tar archive into dir, removing
+// strip leading path elements from every entry. The format is detected from the content.
+// When keep is set, only the entries whose (stripped) name it accepts are extracted.
+func extractArchive(archivePath, dir string, strip int, keep func(string) bool) (extraction, error) {
+	f, err := os.Open(archivePath) // #nosec G304 -- path inside the source cache
+	if err != nil {
+		return extraction{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	br := bufio.NewReader(f)
+	head, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(head, zipMagic):
+		return extractZip(archivePath, dir, strip, keep)
+	case bytes.HasPrefix(head, gzipMagic):
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return extraction{}, err
+		}
+		defer func() { _ = gz.Close() }()
+		return extractTar(gz, dir, strip, keep)
+	default:
+		return extractTar(br, dir, strip, keep)
+	}
+}
+
+func extractZip(archivePath, dir string, strip int, keep func(string) bool) (extraction, error) {
+	var ex extraction
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return ex, err
+	}
+	defer func() { _ = zr.Close() }()
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			continue // symlinks and devices are not extracted
+		}
+		name, ok := stripComponents(zf.Name, strip)
+		if !ok || (keep != nil && !keep(name)) {
+			continue
+		}
+		target, err := safeJoin(dir, name)
+		if err != nil {
+			return ex, err
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return ex, err
+		}
+		err = writeFile(target, rc)
+		_ = rc.Close()
+		if err != nil {
+			return ex, err
+		}
+		ex.add(zf.Modified)
+	}
+	return ex, nil
+}
+
+func extractTar(r io.Reader, dir string, strip int, keep func(string) bool) (extraction, error) {
+	var ex extraction
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return ex, nil
+		}
+		if err != nil {
+			return ex, fmt.Errorf("read tar: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue // directories are created on demand; links are not extracted
+		}
+		name, ok := stripComponents(hdr.Name, strip)
+		if !ok || (keep != nil && !keep(name)) {
+			continue
+		}
+		target, err := safeJoin(dir, name)
+		if err != nil {
+			return ex, err
+		}
+		if err := writeFile(target, tr); err != nil {
+			return ex, err
+		}
+		ex.add(hdr.ModTime)
+	}
+}
+
+// stripComponents removes strip leading elements from an archive entry name. It reports
+// false for entries that have no path left.
+func stripComponents(name string, strip int) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	parts := strings.Split(name, "/")
+	if name == "" || len(parts) <= strip {
+		return "", false
+	}
+	return strings.Join(parts[strip:], "/"), true
+}
+
+// safeJoin joins a slash-separated relative name to root and rejects names that would
+// leave root (zip-slip).
+func safeJoin(root, name string) (string, error) {
+	clean := path.Clean(filepath.ToSlash(name))
+	if clean == "." || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid path %q", name)
+	}
+	return filepath.Join(root, filepath.FromSlash(clean)), nil
+}
+
+func writeFile(target string, r io.Reader) error {
+	return writeFileLimited(target, r, maxDownloadBytes)
+}
+
+// writeFileLimited writes r to target. Content over limit bytes is an error rather than
+// a truncated file; the partial file is removed.
+func writeFileLimited(target string, r io.Reader, limit int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640) // #nosec G304 -- target checked by safeJoin
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(out, io.LimitReader(r, limit+1))
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > limit {
+		err = fmt.Errorf("file %s exceeds %d bytes", filepath.Base(target), limit)
+	}
+	if err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,422 @@
+// Package staticsource downloads static repositories (archives and single files served
+// over HTTP(S)) into the workspace so that they can be aggregated like git working copies.
+// It also downloads forge archives of git repositories fetched with "fetch: archive".
+//
+// Downloads are cached per repository below <workspace>/.sources/<name>: blobs are stored
+// by checksum next to a manifest recording their ETag and Last-Modified validators, so
+// later fetches are conditional requests, and pinned checksums skip the network entirely.
+package staticsource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// sourcesDir is the workspace directory holding the download caches of static repositories.
+const sourcesDir = ".sources"
+
+// maxDownloadBytes bounds the size of a single download (1 GiB).
+const maxDownloadBytes = 1 << 30
+
+// Result describes the working copy of a fetched static repository.
+type Result struct {
+	Path       string
+	PreDigest  string    // digest of the previous working copy; empty if there was none
+	Digest     string    // digest of the downloaded content (stands in for the commit SHA)
+	FetchedAt  time.Time // time the content was last downloaded or revalidated
+	Updated    bool      // true if the working copy was (re)assembled
+	Downloaded int       // number of downloads transferred (not served from the cache)
+	Modified   time.Time // latest modification time of the extracted files (git archives only)
+}
+
+// Fetcher downloads static repositories into a workspace.
+type Fetcher struct {
+	workspace string
+	client    *http.Client
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewFetcher creates a fetcher writing working copies and caches into workspace.
+func NewFetcher(workspace string) *Fetcher {
+	return &Fetcher{
+		workspace: workspace,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		logger:    slog.Default(),
+		now:       time.Now,
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for downloads.
+func (f *Fetcher) WithHTTPClient(client *http.Client) *Fetcher {
+	if client != nil {
+		f.client = client
+	}
+	return f
+}
+
+// WithLogger sets the logger (nil keeps the default logger).
+func (f *Fetcher) WithLogger(logger *slog.Logger) *Fetcher {
+	if logger != nil {
+		f.logger = logger
+	}
+	return f
+}
+
+// manifest records the downloads of a static repository's last successful fetch.
+type manifest struct {
+	Digest    string         `json:"digest"`
+	FetchedAt time.Time      `json:"fetched_at"`
+	Items     []manifestItem `json:"items"`
+	// Paths and Modified describe git archives: the extracted paths and the latest
+	// modification time of the extracted files.
+	Paths    []string  `json:"paths,omitempty"`
+	Modified time.Time `json:"modified,omitzero"`
+}
+
+// manifestItem is one cached download.
+type manifestItem struct {
+	URL          string `json:"url"`
+	SHA256       string `json:"sha256"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Fetch downloads the archive and files of a static repository and assembles its
+// working copy at <workspace>/<name>. Unchanged downloads leave the working copy as is.
+func (f *Fetcher) Fetch(ctx context.Context, repo config.Repository) (*Result, error) {
+	if !repo.IsStatic() || repo.Static == nil {
+		return nil, fmt.Errorf("repository %s is not a static source", repo.Name)
+	}
+	cacheDir := filepath.Join(f.workspace, sourcesDir, repo.Name)
+	repoPath := filepath.Join(f.workspace, repo.Name)
+	prev := readManifest(cacheDir)
+
+	res := &Result{Path: repoPath}
+	if prev != nil && dirExists(repoPath) {
+		res.PreDigest = prev.Digest
+		// Scoped builds render out-of-scope repositories from the cached working copy.
+		if repo.ReuseWorkingCopy {
+			res.Digest = prev.Digest
+			res.FetchedAt = prev.FetchedAt
+			return res, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(cacheDir, "blobs"), 0o750); err != nil {
+		return nil, fmt.Errorf("create source cache: %w", err)
+	}
+	next := &manifest{FetchedAt: f.now()}
+	s := repo.Static
+	if s.Archive != "" {
+		item, downloaded, err := f.download(ctx, cacheDir, repo, s.Archive, s.SHA256, prev)
+		if err != nil {
+			return nil, err
+		}
+		next.Items = append(next.Items, item)
+		if downloaded {
+			res.Downloaded++
+		}
+	}
+	for _, file := range s.Files {
+		item, downloaded, err := f.download(ctx, cacheDir, repo, file.URL, file.SHA256, prev)
+		if err != nil {
+			return nil, err
+		}
+		next.Items = append(next.Items, item)
+		if downloaded {
+			res.Downloaded++
+		}
+	}
+	next.Digest = digest(s, next.Items)
+	res.Digest = next.Digest
+	res.FetchedAt = next.FetchedAt
+
+	if res.PreDigest != next.Digest || !dirExists(repoPath) {
+		if err := assemble(cacheDir, repoPath, s, next.Items); err != nil {
+			return nil, err
+		}
+		res.Updated = true
+	}
+	if err := writeManifest(cacheDir, next); err != nil {
+		return nil, err
+	}
+	pruneBlobs(cacheDir, next.Items)
+
+	f.logger.Info("Fetched static source",
+		slog.String("repo", repo.Name),
+		slog.String("digest", shortDigest(next.Digest)),
+		slog.Int("downloaded", res.Downloaded),
+		slog.Int("cached", len(next.Items)-res.Downloaded),
+		slog.Bool("updated", res.Updated))
+	return res, nil
+}
+
+// download makes sure the content at rawURL is cached and returns its manifest entry.
+// A pinned checksum that is already cached skips the request; otherwise the previous
+// download's validators make the request conditional. It reports whether content was transferred.
+func (f *Fetcher) download(ctx context.Context, cacheDir string, repo config.Repository, rawURL, want string, prev *manifest) (manifestItem, bool, error) {
+	want = strings.ToLower(want)
+	cached := prev.item(rawURL)
+	if cached != nil && !blobExists(cacheDir, cached.SHA256) {
+		cached = nil
+	}
+	if want != "" {
+		if cached != nil && cached.SHA256 == want {
+			return *cached, false, nil
+		}
+		if blobExists(cacheDir, want) {
+			return manifestItem{URL: rawURL, SHA256: want}, false, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return manifestItem{}, false, fmt.Errorf("build request for %s: %w", rawURL, err)
+	}
+	applyAuth(req, repo.Auth)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return manifestItem{}, false, fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if want != "" && cached.SHA256 != want {
+			return manifestItem{}, false, checksumError(rawURL, want, cached.SHA256)
+		}
+		return *cached, false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return manifestItem{}, false, fmt.Errorf("download %s: HTTP %d", rawURL, resp.StatusCode)
+	}
+
+	sum, err := storeBlob(cacheDir, resp.Body)
+	if err != nil {
+		return manifestItem{}, false, fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	if want != "" && sum != want {
+		_ = os.Remove(blobPath(cacheDir, sum))
+		return manifestItem{}, false, checksumError(rawURL, want, sum)
+	}
+	return manifestItem{
+		URL:          rawURL,
+		SHA256:       sum,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, true, nil
+}
+
+func checksumError(rawURL, want, got string) error {
+	return fmt.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", rawURL, want, got)
+}
+
+// applyAuth adds token (bearer) or basic credentials to a download request.
+func applyAuth(req *http.Request, auth *config.AuthConfig) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case config.AuthTypeToken:
+		if auth.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		}
+	case config.AuthTypeBasic:
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+}
+
+// storeBlob writes body into the blob store and returns its SHA-256 checksum.
+func storeBlob(cacheDir string, body io.Reader) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Join(cacheDir, "blobs"), "download-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(body, maxDownloadBytes+1))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	if n > maxDownloadBytes {
+		return "", errors.New("download too large")
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if err := os.Rename(tmp.Name(), blobPath(cacheDir, sum)); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// assemble builds the working copy from the cached downloads in a temporary directory
+// and then replaces repoPath with it.
+func assemble(cacheDir, repoPath string, s *config.StaticSource, items []manifestItem) error {
+	tmp, err := os.MkdirTemp(cacheDir, "assemble-*")
+	if err != nil {
+		return fmt.Errorf("create working copy: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	i := 0
+	if s.Archive != "" {
+		if _, err := extractArchive(blobPath(cacheDir, items[0].SHA256), tmp, s.StripComponents, nil); err != nil {
+			return fmt.Errorf("extract %s: %w", s.Archive, err)
+		}
+		i = 1
+	}
+	for _, file := range s.Files {
+		if err := copyBlob(blobPath(cacheDir, items[i].SHA256), tmp, file.Destination()); err != nil {
+			return fmt.Errorf("write %s: %w", file.Destination(), err)
+		}
+		i++
+	}
+
+	return replaceWorkingCopy(tmp, repoPath)
+}
+
+// replaceWorkingCopy moves an assembled working copy into place, replacing repoPath.
+func replaceWorkingCopy(tmp, repoPath string) error {
+	if err := os.RemoveAll(repoPath); err != nil {
+		return fmt.Errorf("remove previous working copy: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0o750); err != nil {
+		return fmt.Errorf("create working copy: %w", err)
+	}
+	if err := os.Rename(tmp, repoPath); err != nil {
+		return fmt.Errorf("move working copy into place: %w", err)
+	}
+	return nil
+}
+
+// copyBlob writes a cached blob to dest (slash-separated, relative to root).
+func copyBlob(blob, root, dest string) error {
+	target, err := safeJoin(root, dest)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(blob) // #nosec G304 -- path inside the source cache
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	return writeFile(target, in)
+}
+
+// digest identifies the content of a working copy: the downloaded checksums together
+// with how they are laid out.
+func digest(s *config.StaticSource, items []manifestItem) string {
+	var lines []string
+	i := 0
+	if s.Archive != "" {
+		lines = append(lines, fmt.Sprintf("archive strip=%d %s", s.StripComponents, items[0].SHA256))
+		i = 1
+	}
+	files := make([]string, 0, len(s.Files))
+	for _, file := range s.Files {
+		files = append(files, fmt.Sprintf("file %s %s", file.Destination(), items[i].SHA256))
+		i++
+	}
+	sort.Strings(files)
+	lines = append(lines, files...)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func shortDigest(d string) string {
+	if len(d) > 12 {
+		return d[:12]
+	}
+	return d
+}
+
+func (m *manifest) item(rawURL string) *manifestItem {
+	if m == nil {
+		return nil
+	}
+	for i := range m.Items {
+		if m.Items[i].URL == rawURL {
+			return &m.Items[i]
+		}
+	}
+	return nil
+}
+
+func readManifest(cacheDir string) *manifest {
+	data, err := os.ReadFile(filepath.Join(cacheDir, "manifest.json")) // #nosec G304 -- path inside the source cache
+	if err != nil {
+		return nil
+	}
+	var m manifest
+	if json.Unmarshal(data, &m) != nil {
+		return nil
+	}
+	return &m
+}
+
+func writeManifest(cacheDir string, m *manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "manifest.json"), data, 0o600); err != nil {
+		return fmt.Errorf("write source manifest: %w", err)
+	}
+	return nil
+}
+
+// pruneBlobs removes cached downloads no longer referenced by the manifest.
+func pruneBlobs(cacheDir string, items []manifestItem) {
+	keep := make(map[string]bool, len(items))
+	for _, it := range items {
+		keep[it.SHA256] = true
+	}
+	entries, err := os.ReadDir(filepath.Join(cacheDir, "blobs"))
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !keep[e.Name()] {
+			_ = os.Remove(filepath.Join(cacheDir, "blobs", e.Name()))
+		}
+	}
+}
+
+func blobPath(cacheDir, sum string) string {
+	return filepath.Join(cacheDir, "blobs", sum)
+}
+
+func blobExists(cacheDir, sum string) bool {
+	fi, err := os.Stat(blobPath(cacheDir, sum))
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func dirExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
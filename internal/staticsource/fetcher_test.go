@@ -0,0 +1,179 @@
+package staticsource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func sha(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// server serves content by path with an ETag and counts full (200) responses.
+type server struct {
+	content map[string][]byte
+	full    atomic.Int32
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.content[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	etag := `"` + sha(body)[:16] + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.full.Add(1)
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write(body)
+}
+
+func staticRepo(s *config.StaticSource) config.Repository {
+	return config.Repository{Name: "api", Type: config.RepositoryTypeStatic, Static: s}
+}
+
+func TestFetch_ArchiveAndFilesAreCachedAndRevalidated(t *testing.T) {
+	srv := &server{content: map[string][]byte{
+		"/docs.tar.gz": tarGz(t, map[string]string{"export-1/guide/intro.md": "# Intro\n", "export-1/../../evil.md": "x"}),
+		"/openapi.md":  []byte("# API\n"),
+	}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	workspace := t.TempDir()
+	repo := staticRepo(&config.StaticSource{
+		Archive:         ts.URL + "/docs.tar.gz",
+		StripComponents: 1,
+		Files:           []config.StaticFile{{URL: ts.URL + "/openapi.md", Path: "reference/openapi.md"}},
+	})
+	f := NewFetcher(workspace)
+
+	res, err := f.Fetch(t.Context(), repo)
+	require.NoError(t, err)
+	require.True(t, res.Updated)
+	require.Empty(t, res.PreDigest)
+	require.Equal(t, 2, res.Downloaded)
+	data, err := os.ReadFile(filepath.Join(workspace, "api", "guide", "intro.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# Intro\n", string(data))
+	require.FileExists(t, filepath.Join(workspace, "api", "reference", "openapi.md"))
+	require.NoFileExists(t, filepath.Join(workspace, "evil.md"))
+
+	// Unchanged content: conditional requests, working copy left as is.
+	again, err := f.Fetch(t.Context(), repo)
+	require.NoError(t, err)
+	require.False(t, again.Updated)
+	require.Equal(t, 0, again.Downloaded)
+	require.Equal(t, res.Digest, again.Digest)
+	require.Equal(t, int32(2), srv.full.Load())
+
+	// Changed file: new digest and reassembled working copy.
+	srv.content["/openapi.md"] = []byte("# API v2\n")
+	changed, err := f.Fetch(t.Context(), repo)
+	require.NoError(t, err)
+	require.True(t, changed.Updated)
+	require.Equal(t, res.Digest, changed.PreDigest)
+	require.NotEqual(t, res.Digest, changed.Digest)
+	data, err = os.ReadFile(filepath.Join(workspace, "api", "reference", "openapi.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# API v2\n", string(data))
+}
+
+func TestFetch_PinnedChecksum(t *testing.T) {
+	archive := zipArchive(t, map[string]string{"index.md": "# Home\n"})
+	srv := &server{content: map[string][]byte{"/docs.zip": archive}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	workspace := t.TempDir()
+	f := NewFetcher(workspace)
+
+	_, err := f.Fetch(t.Context(), staticRepo(&config.StaticSource{Archive: ts.URL + "/docs.zip", SHA256: sha([]byte("other"))}))
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	repo := staticRepo(&config.StaticSource{Archive: ts.URL + "/docs.zip", SHA256: sha(archive)})
+	res, err := f.Fetch(t.Context(), repo)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(res.Path, "index.md"))
+
+	// A cached pinned checksum needs no request at all.
+	ts.Close()
+	require.NoError(t, os.RemoveAll(res.Path))
+	again, err := f.Fetch(t.Context(), repo)
+	require.NoError(t, err)
+	require.True(t, again.Updated)
+	require.Equal(t, res.Digest, again.Digest)
+	require.FileExists(t, filepath.Join(res.Path, "index.md"))
+}
+
+func TestStripComponentsAndSafeJoin(t *testing.T) {
+	name, ok := stripComponents("top/docs/a.md", 1)
+	require.True(t, ok)
+	require.Equal(t, "docs/a.md", name)
+	_, ok = stripComponents("top", 1)
+	require.False(t, ok)
+
+	_, err := safeJoin(t.TempDir(), "../outside.md")
+	require.Error(t, err)
+}
+
+func TestWriteFileLimited_RejectsOversizedContent(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeFileLimited(filepath.Join(dir, "fits.md"), strings.NewReader("12345"), 5))
+	data, err := os.ReadFile(filepath.Join(dir, "fits.md"))
+	require.NoError(t, err)
+	require.Equal(t, "12345", string(data))
+
+	target := filepath.Join(dir, "docs", "large.md")
+	err = writeFileLimited(target, strings.NewReader("123456"), 5)
+	require.ErrorContains(t, err, "exceeds 5 bytes")
+	require.NoFileExists(t, target)
+}
@@ -0,0 +1,117 @@
+package staticsource
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// GitArchive is a forge archive of one commit of a git repository, downloaded instead
+// of a clone (repository fetch mode "archive").
+type GitArchive struct {
+	URL    string   // Download URL of the commit's archive
+	Commit string   // Commit SHA the archive was created from
+	Paths  []string // Repository paths to extract (the docs paths)
+}
+
+// archiveMetadataDirs are extracted from git archives next to the docs paths and the
+// files at the repository root (CODEOWNERS, .docignore).
+var archiveMetadataDirs = []string{".github/", ".gitlab/"}
+
+// FetchGitArchive downloads a git archive and extracts its docs paths into the working
+// copy at <workspace>/<name>. Forge archives have a single top-level directory, which is
+// removed. The archive is cached by checksum like static sources, and a working copy of
+// the same commit and paths is reused without contacting the forge. The digest of the
+// result is the commit SHA.
+func (f *Fetcher) FetchGitArchive(ctx context.Context, repo config.Repository, a GitArchive) (*Result, error) {
+	cacheDir := filepath.Join(f.workspace, sourcesDir, repo.Name)
+	repoPath := filepath.Join(f.workspace, repo.Name)
+	prev := readManifest(cacheDir)
+
+	res := &Result{Path: repoPath}
+	if prev != nil && dirExists(repoPath) {
+		res.PreDigest = prev.Digest
+		if prev.Digest == a.Commit && slices.Equal(prev.Paths, a.Paths) {
+			res.Digest = prev.Digest
+			res.FetchedAt = prev.FetchedAt
+			res.Modified = prev.Modified
+			return res, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(cacheDir, "blobs"), 0o750); err != nil {
+		return nil, fmt.Errorf("create source cache: %w", err)
+	}
+	item, downloaded, err := f.download(ctx, cacheDir, repo, a.URL, "", prev)
+	if err != nil {
+		return nil, err
+	}
+	if downloaded {
+		res.Downloaded++
+	}
+
+	tmp, err := os.MkdirTemp(cacheDir, "assemble-*")
+	if err != nil {
+		return nil, fmt.Errorf("create working copy: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	ex, err := extractArchive(blobPath(cacheDir, item.SHA256), tmp, 1, archivePathFilter(a.Paths))
+	if err != nil {
+		return nil, fmt.Errorf("extract %s: %w", a.URL, err)
+	}
+	if err := replaceWorkingCopy(tmp, repoPath); err != nil {
+		return nil, err
+	}
+
+	next := &manifest{
+		Digest:    a.Commit,
+		FetchedAt: f.now(),
+		Items:     []manifestItem{item},
+		Paths:     a.Paths,
+		Modified:  ex.Modified,
+	}
+	if err := writeManifest(cacheDir, next); err != nil {
+		return nil, err
+	}
+	pruneBlobs(cacheDir, next.Items)
+
+	res.Digest = next.Digest
+	res.FetchedAt = next.FetchedAt
+	res.Modified = next.Modified
+	res.Updated = true
+	f.logger.Info("Fetched git archive",
+		slog.String("repo", repo.Name),
+		slog.String("commit", shortDigest(a.Commit)),
+		slog.Int("files", ex.Files),
+		slog.Bool("downloaded", downloaded))
+	return res, nil
+}
+
+// archivePathFilter accepts the entries below one of paths, files at the repository
+// root and the forge metadata directories.
+func archivePathFilter(paths []string) func(string) bool {
+	return func(name string) bool {
+		if !strings.Contains(name, "/") {
+			return true
+		}
+		for _, dir := range archiveMetadataDirs {
+			if strings.HasPrefix(name, dir) {
+				return true
+			}
+		}
+		for _, p := range paths {
+			p = path.Clean(filepath.ToSlash(p))
+			if p == "." || name == p || strings.HasPrefix(name, p+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
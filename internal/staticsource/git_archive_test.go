@@ -0,0 +1,61 @@
+package staticsource
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+func TestFetchGitArchive_ExtractsDocsPathsAndReusesCommit(t *testing.T) {
+	srv := &server{content: map[string][]byte{
+		"/tarball/aaa": tarGz(t, map[string]string{
+			"org-docs-aaa/CODEOWNERS":             "* @org/docs\n",
+			"org-docs-aaa/.github/CODEOWNERS":     "* @org/platform\n",
+			"org-docs-aaa/docs/guide/intro.md":    "# Intro\n",
+			"org-docs-aaa/src/main.go":            "package main\n",
+			"org-docs-aaa/documentation/other.md": "# Other\n",
+		}),
+		"/tarball/bbb": tarGz(t, map[string]string{"org-docs-bbb/docs/guide/intro.md": "# Intro v2\n"}),
+	}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	workspace := t.TempDir()
+	repo := config.Repository{Name: "docs", URL: "https://github.com/org/docs.git", Fetch: config.FetchModeArchive}
+	f := NewFetcher(workspace)
+	archive := GitArchive{URL: ts.URL + "/tarball/aaa", Commit: "aaa", Paths: []string{"docs"}}
+
+	res, err := f.FetchGitArchive(t.Context(), repo, archive)
+	require.NoError(t, err)
+	require.True(t, res.Updated)
+	require.Equal(t, "aaa", res.Digest)
+	require.False(t, res.Modified.IsZero())
+	root := filepath.Join(workspace, "docs")
+	require.FileExists(t, filepath.Join(root, "docs", "guide", "intro.md"))
+	require.FileExists(t, filepath.Join(root, "CODEOWNERS"))
+	require.FileExists(t, filepath.Join(root, ".github", "CODEOWNERS"))
+	require.NoFileExists(t, filepath.Join(root, "src", "main.go"))
+	require.NoFileExists(t, filepath.Join(root, "documentation", "other.md"))
+
+	// Same commit and paths: the working copy is reused without a request.
+	again, err := f.FetchGitArchive(t.Context(), repo, archive)
+	require.NoError(t, err)
+	require.False(t, again.Updated)
+	require.Equal(t, "aaa", again.PreDigest)
+	require.Equal(t, int32(1), srv.full.Load())
+
+	// New commit: the working copy is replaced.
+	next, err := f.FetchGitArchive(t.Context(), repo, GitArchive{URL: ts.URL + "/tarball/bbb", Commit: "bbb", Paths: []string{"docs"}})
+	require.NoError(t, err)
+	require.True(t, next.Updated)
+	require.Equal(t, "aaa", next.PreDigest)
+	data, err := os.ReadFile(filepath.Join(root, "docs", "guide", "intro.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# Intro v2\n", string(data))
+	require.NoFileExists(t, filepath.Join(root, "CODEOWNERS"))
+}
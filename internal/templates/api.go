@@ -0,0 +1,186 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TemplateIndexFile is the template catalogue written to the build output root and
+// served by the daemon template API.
+const TemplateIndexFile = "templates.json"
+
+// TemplateAPIPath is the daemon admin endpoint serving the template index.
+const TemplateAPIPath = "/api/templates"
+
+// TemplateRenderAPIPath is the daemon admin endpoint rendering a template preview.
+const TemplateRenderAPIPath = TemplateAPIPath + "/render"
+
+// ErrTemplateAPIUnauthorized is returned when the daemon rejects the template API token.
+var ErrTemplateAPIUnauthorized = errors.New("template API: unauthorized")
+
+// TemplateIndex lists every template found in a build.
+type TemplateIndex struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Templates   []IndexedTemplate `json:"templates"`
+}
+
+// IndexedTemplate is a template with its metadata decoded for JSON consumers.
+// Schema, Defaults and Sequence hold the same JSON documents as the
+// docbuilder:template.* meta tags.
+type IndexedTemplate struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	OutputPath  string          `json:"output_path"`
+	Repository  string          `json:"repository,omitempty"`
+	URL         string          `json:"url"` // Site path of the rendered template page
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Defaults    json.RawMessage `json:"defaults,omitempty"`
+	Sequence    json.RawMessage `json:"sequence,omitempty"`
+	Body        string          `json:"body"`
+}
+
+// NewIndexedTemplate converts a parsed template page for the index, rejecting
+// metadata that is not valid JSON.
+func NewIndexedTemplate(page *TemplatePage, url, repository string) (IndexedTemplate, error) {
+	entry := IndexedTemplate{
+		Type:        page.Meta.Type,
+		Name:        page.Meta.Name,
+		Description: page.Meta.Description,
+		OutputPath:  page.Meta.OutputPath,
+		Repository:  repository,
+		URL:         url,
+		Body:        page.Body,
+	}
+	for key, field := range map[string]struct {
+		raw string
+		dst *json.RawMessage
+	}{
+		"schema":   {page.Meta.Schema, &entry.Schema},
+		"defaults": {page.Meta.Defaults, &entry.Defaults},
+		"sequence": {page.Meta.Sequence, &entry.Sequence},
+	} {
+		if strings.TrimSpace(field.raw) == "" {
+			continue
+		}
+		if !json.Valid([]byte(field.raw)) {
+			return IndexedTemplate{}, fmt.Errorf("template %s: %s is not valid JSON", page.Meta.Type, key)
+		}
+		*field.dst = json.RawMessage(field.raw)
+	}
+	return entry, nil
+}
+
+// Page converts the indexed template back to a TemplatePage.
+func (t IndexedTemplate) Page() *TemplatePage {
+	return &TemplatePage{
+		Meta: TemplateMeta{
+			Type:        t.Type,
+			Name:        t.Name,
+			OutputPath:  t.OutputPath,
+			Description: t.Description,
+			Schema:      string(t.Schema),
+			Defaults:    string(t.Defaults),
+			Sequence:    string(t.Sequence),
+		},
+		Body: t.Body,
+	}
+}
+
+// APISource reads templates from the daemon template API.
+//
+// When the API cannot be reached or does not serve templates and Fallback is set,
+// discovery falls back to it (typically an HTTPSource scraping the rendered site).
+// Authentication failures are never masked by the fallback.
+type APISource struct {
+	DaemonURL string
+	Token     string
+	Client    *http.Client
+	Fallback  Source
+
+	index  map[string]IndexedTemplate
+	active Source
+}
+
+// Discover fetches the template index from the daemon.
+func (s *APISource) Discover(ctx context.Context) ([]TemplateLink, error) {
+	s.active = nil
+	links, err := s.discoverAPI(ctx)
+	if err == nil {
+		return links, nil
+	}
+	if s.Fallback == nil || errors.Is(err, ErrTemplateAPIUnauthorized) {
+		return nil, err
+	}
+	s.active = s.Fallback
+	return s.Fallback.Discover(ctx)
+}
+
+// Fetch returns a template listed by the last Discover call.
+func (s *APISource) Fetch(ctx context.Context, link TemplateLink) (*TemplatePage, error) {
+	if s.active != nil {
+		return s.active.Fetch(ctx, link)
+	}
+	entry, ok := s.index[link.URL]
+	if !ok {
+		return nil, fmt.Errorf("template not found: %s", link.URL)
+	}
+	return entry.Page(), nil
+}
+
+func (s *APISource) discoverAPI(ctx context.Context) ([]TemplateLink, error) {
+	client := s.Client
+	if client == nil {
+		client = NewTemplateHTTPClient()
+	}
+	root, err := validateTemplateURL(s.DaemonURL)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := *root
+	apiURL.Path = strings.TrimSuffix(apiURL.Path, "/") + TemplateAPIPath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", apiURL.String(), err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, ErrTemplateAPIUnauthorized
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("fetch %s: HTTP %d", apiURL.String(), resp.StatusCode)
+	}
+
+	var index TemplateIndex
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTemplateResponseBytes)).Decode(&index); err != nil {
+		return nil, fmt.Errorf("decode template index: %w", err)
+	}
+	if len(index.Templates) == 0 {
+		return nil, errors.New("no templates discovered")
+	}
+
+	s.index = make(map[string]IndexedTemplate, len(index.Templates))
+	links := make([]TemplateLink, 0, len(index.Templates))
+	for _, t := range index.Templates {
+		s.index[t.URL] = t
+		links = append(links, TemplateLink{Type: t.Type, URL: t.URL, Name: t.Name})
+	}
+	return links, nil
+}
@@ -0,0 +1,69 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubSource struct{ links []TemplateLink }
+
+func (s *stubSource) Discover(context.Context) ([]TemplateLink, error) { return s.links, nil }
+
+func (s *stubSource) Fetch(_ context.Context, link TemplateLink) (*TemplatePage, error) {
+	return &TemplatePage{Meta: TemplateMeta{Type: link.Type}, Body: "from fallback"}, nil
+}
+
+func TestAPISource_Discover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, TemplateAPIPath, r.URL.Path)
+		require.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(TemplateIndex{Templates: []IndexedTemplate{{
+			Type: "adr", Name: "ADR", OutputPath: "adr/{{ .Slug }}.md", URL: "/adr.template/",
+			Schema: json.RawMessage(`{"fields":[{"key":"Slug","type":"string"}]}`), Body: "# {{ .Slug }}",
+		}}})
+	}))
+	defer server.Close()
+
+	source := &APISource{DaemonURL: server.URL, Token: "tok", Fallback: &stubSource{}}
+	links, err := source.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []TemplateLink{{Type: "adr", URL: "/adr.template/", Name: "ADR"}}, links)
+
+	page, err := source.Fetch(context.Background(), links[0])
+	require.NoError(t, err)
+	require.Equal(t, "# {{ .Slug }}", page.Body)
+	schema, err := ParseTemplateSchema(page.Meta.Schema)
+	require.NoError(t, err)
+	require.Len(t, schema.Fields, 1)
+}
+
+func TestAPISource_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	fallback := &stubSource{links: []TemplateLink{{Type: "guide", URL: "https://docs.example.com/guide.template/"}}}
+	source := &APISource{DaemonURL: server.URL, Fallback: fallback}
+	links, err := source.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, fallback.links, links)
+
+	page, err := source.Fetch(context.Background(), links[0])
+	require.NoError(t, err)
+	require.Equal(t, "from fallback", page.Body)
+}
+
+func TestAPISource_UnauthorizedNotMasked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source := &APISource{DaemonURL: server.URL, Fallback: &stubSource{}}
+	_, err := source.Discover(context.Background())
+	require.ErrorIs(t, err, ErrTemplateAPIUnauthorized)
+}
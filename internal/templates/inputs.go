@@ -3,9 +3,11 @@ package templates
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FieldType defines the supported input field types for template schemas.
@@ -23,8 +25,23 @@ const (
 
 	// FieldTypeBool is a boolean value (true/false, yes/no, etc.).
 	FieldTypeBool FieldType = "bool"
+
+	// FieldTypeDate is a calendar date in YYYY-MM-DD form ("today" is accepted as input).
+	FieldTypeDate FieldType = "date"
+
+	// FieldTypeInt is a whole number.
+	FieldTypeInt FieldType = "int"
+
+	// FieldTypeMultiSelect is a comma-separated selection of one or more Options.
+	FieldTypeMultiSelect FieldType = "multi_select"
 )
 
+// dateLayout is the input and output format of FieldTypeDate values.
+const dateLayout = "2006-01-02"
+
+// now is the clock used to resolve "today" for date fields (overridable in tests).
+var now = time.Now
+
 // SchemaField represents a single input field in a template schema.
 type SchemaField struct {
 	// Key is the field identifier used in templates (e.g., "Title", "Slug").
@@ -36,8 +53,66 @@ type SchemaField struct {
 	// Required indicates whether the field must be provided.
 	Required bool `json:"required"`
 
-	// Options is required for FieldTypeStringEnum and lists valid choices.
+	// Options lists valid choices for FieldTypeStringEnum and FieldTypeMultiSelect.
 	Options []string `json:"options,omitempty"`
+
+	// Default is used when no value is provided. It is given in the same form as user
+	// input (e.g. "a,b" or ["a","b"] for lists) and validated like it.
+	Default any `json:"default,omitempty"`
+
+	// Pattern is an optional regular expression every text value must match. For list
+	// types each item is matched individually. Anchor it (^...$) to match whole values.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// validate checks the field definition, including its pattern and default.
+func (f SchemaField) validate() error {
+	if strings.TrimSpace(f.Key) == "" {
+		return errors.New("field key is required")
+	}
+	switch f.Type {
+	case FieldTypeString, FieldTypeStringEnum, FieldTypeStringList, FieldTypeBool,
+		FieldTypeDate, FieldTypeInt, FieldTypeMultiSelect:
+	default:
+		return fmt.Errorf("field %s: unsupported field type: %s", f.Key, f.Type)
+	}
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("field %s: invalid pattern: %w", f.Key, err)
+		}
+	}
+	if f.Default != nil {
+		if _, _, err := f.defaultValue(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultValue parses the field's Default like user input.
+func (f SchemaField) defaultValue() (any, bool, error) {
+	var input string
+	switch v := f.Default.(type) {
+	case nil:
+		return nil, false, nil
+	case string:
+		input = v
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		input = strings.Join(items, ",")
+	case []string:
+		input = strings.Join(v, ",")
+	default:
+		input = fmt.Sprint(v)
+	}
+	value, ok, err := parseInputValue(f, input)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid default for %s: %w", f.Key, err)
+	}
+	return value, ok, nil
 }
 
 // TemplateSchema describes all input fields required to instantiate a template.
@@ -60,11 +135,12 @@ type Prompter interface {
 // ResolveTemplateInputs resolves all template inputs by merging defaults, overrides, and prompts.
 //
 // The resolution order is:
-//  1. Apply defaults from template metadata
+//  1. Apply per-field schema defaults, then defaults from template metadata
 //  2. Apply overrides (from --set flags, highest precedence)
 //  3. If useDefaults is true, validate required fields and return
 //  4. Otherwise, prompt for missing fields using the Prompter
 //  5. Validate all required fields are present
+//  6. Set optional fields that are still unset to their type's zero value
 //
 // Parameters:
 //   - schema: The template schema defining all fields
@@ -93,6 +169,16 @@ func ResolveTemplateInputs(schema TemplateSchema, defaults map[string]any, overr
 		fieldsByKey[field.Key] = field
 	}
 
+	for _, field := range schema.Fields {
+		value, ok, err := field.defaultValue()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[field.Key] = value
+		}
+	}
+
 	for key, value := range defaults {
 		if value != nil {
 			result[key] = value
@@ -117,6 +203,7 @@ func ResolveTemplateInputs(schema TemplateSchema, defaults map[string]any, overr
 		if err := validateRequiredFields(schema, result); err != nil {
 			return nil, err
 		}
+		fillZeroValues(schema, result)
 		return result, nil
 	}
 
@@ -145,9 +232,30 @@ func ResolveTemplateInputs(schema TemplateSchema, defaults map[string]any, overr
 		return nil, err
 	}
 
+	fillZeroValues(schema, result)
 	return result, nil
 }
 
+// fillZeroValues sets declared fields that received no value to their type's zero value,
+// so optional fields can be referenced in templates rendered with missingkey=error.
+func fillZeroValues(schema TemplateSchema, values map[string]any) {
+	for _, field := range schema.Fields {
+		if _, ok := values[field.Key]; ok {
+			continue
+		}
+		switch field.Type {
+		case FieldTypeStringList, FieldTypeMultiSelect:
+			values[field.Key] = []string{}
+		case FieldTypeBool:
+			values[field.Key] = false
+		case FieldTypeInt:
+			values[field.Key] = 0
+		default:
+			values[field.Key] = ""
+		}
+	}
+}
+
 // validateRequiredFields ensures all required fields in the schema have values.
 func validateRequiredFields(schema TemplateSchema, values map[string]any) error {
 	for _, field := range schema.Fields {
@@ -165,7 +273,7 @@ func validateRequiredFields(schema TemplateSchema, values map[string]any) error
 // parseInputValue parses and validates user input according to the field type.
 //
 // Returns:
-//   - The parsed value (string, []string, or bool)
+//   - The parsed value (string, []string, bool, or int)
 //   - A boolean indicating if a value was provided (false for empty input)
 //   - An error if validation fails (e.g., invalid enum value, invalid boolean)
 func parseInputValue(field SchemaField, input string) (any, bool, error) {
@@ -176,25 +284,26 @@ func parseInputValue(field SchemaField, input string) (any, bool, error) {
 
 	switch field.Type {
 	case FieldTypeString, FieldTypeStringEnum:
-		if field.Type == FieldTypeStringEnum && len(field.Options) > 0 {
-			if slices.Contains(field.Options, value) {
-				return value, true, nil
-			}
-			return nil, false, fmt.Errorf("invalid value for %s", field.Key)
+		if field.Type == FieldTypeStringEnum && len(field.Options) > 0 && !slices.Contains(field.Options, value) {
+			return nil, false, fmt.Errorf("invalid value for %s: %q (allowed: %s)", field.Key, value, strings.Join(field.Options, ", "))
 		}
-		return value, true, nil
-	case FieldTypeStringList:
-		parts := strings.Split(value, ",")
-		items := make([]string, 0, len(parts))
-		for _, part := range parts {
-			item := strings.TrimSpace(part)
-			if item != "" {
-				items = append(items, item)
-			}
+		if err := matchPattern(field, value); err != nil {
+			return nil, false, err
 		}
+		return value, true, nil
+	case FieldTypeStringList, FieldTypeMultiSelect:
+		items := splitList(value)
 		if len(items) == 0 {
 			return nil, false, nil
 		}
+		for _, item := range items {
+			if field.Type == FieldTypeMultiSelect && len(field.Options) > 0 && !slices.Contains(field.Options, item) {
+				return nil, false, fmt.Errorf("invalid value for %s: %q (allowed: %s)", field.Key, item, strings.Join(field.Options, ", "))
+			}
+			if err := matchPattern(field, item); err != nil {
+				return nil, false, err
+			}
+		}
 		return items, true, nil
 	case FieldTypeBool:
 		parsed, err := strconv.ParseBool(strings.ToLower(value))
@@ -202,7 +311,50 @@ func parseInputValue(field SchemaField, input string) (any, bool, error) {
 			return nil, false, fmt.Errorf("invalid boolean for %s", field.Key)
 		}
 		return parsed, true, nil
+	case FieldTypeDate:
+		if strings.EqualFold(value, "today") {
+			return now().Format(dateLayout), true, nil
+		}
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid date for %s: %q (expected YYYY-MM-DD)", field.Key, value)
+		}
+		return parsed.Format(dateLayout), true, nil
+	case FieldTypeInt:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid integer for %s: %q", field.Key, value)
+		}
+		return parsed, true, nil
 	default:
 		return nil, false, fmt.Errorf("unsupported field type: %s", field.Type)
 	}
 }
+
+// splitList splits comma-separated input into trimmed, non-empty items.
+func splitList(value string) []string {
+	parts := strings.Split(value, ",")
+	items := make([]string, 0, len(parts))
+	for _, part := range parts {
+		item := strings.TrimSpace(part)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// matchPattern validates value against the field's Pattern, if any.
+func matchPattern(field SchemaField, value string) error {
+	if field.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(field.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern for %s: %w", field.Key, err)
+	}
+	if !re.MatchString(value) {
+		return fmt.Errorf("invalid value for %s: %q does not match pattern %s", field.Key, value, field.Pattern)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package templates
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -193,3 +194,103 @@ func TestParseInputValue_UnsupportedType(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unsupported field type")
 }
+
+func TestParseInputValue_Date(t *testing.T) {
+	field := SchemaField{Key: "Due", Type: FieldTypeDate}
+	value, hasValue, err := parseInputValue(field, "2026-03-01")
+	require.NoError(t, err)
+	require.True(t, hasValue)
+	require.Equal(t, "2026-03-01", value)
+
+	_, _, err = parseInputValue(field, "01/03/2026")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected YYYY-MM-DD")
+}
+
+func TestParseInputValue_Date_Today(t *testing.T) {
+	orig := now
+	now = func() time.Time { return time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = orig })
+
+	value, _, err := parseInputValue(SchemaField{Key: "Due", Type: FieldTypeDate}, "Today")
+	require.NoError(t, err)
+	require.Equal(t, "2026-05-04", value)
+}
+
+func TestParseInputValue_Int(t *testing.T) {
+	field := SchemaField{Key: "Priority", Type: FieldTypeInt}
+	value, _, err := parseInputValue(field, " 3 ")
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	_, _, err = parseInputValue(field, "high")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid integer for Priority")
+}
+
+func TestParseInputValue_MultiSelect(t *testing.T) {
+	field := SchemaField{Key: "Platforms", Type: FieldTypeMultiSelect, Options: []string{"linux", "macos", "windows"}}
+	value, _, err := parseInputValue(field, "linux, windows")
+	require.NoError(t, err)
+	require.Equal(t, []string{"linux", "windows"}, value)
+
+	_, _, err = parseInputValue(field, "linux,bsd")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"bsd" (allowed: linux, macos, windows)`)
+}
+
+func TestParseInputValue_Pattern(t *testing.T) {
+	field := SchemaField{Key: "Slug", Type: FieldTypeString, Pattern: "^[a-z0-9-]+$"}
+	_, _, err := parseInputValue(field, "valid-slug")
+	require.NoError(t, err)
+
+	_, _, err = parseInputValue(field, "Not A Slug")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match pattern")
+
+	list := SchemaField{Key: "Tags", Type: FieldTypeStringList, Pattern: "^[a-z]+$"}
+	_, _, err = parseInputValue(list, "docs, API")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"API"`)
+}
+
+func TestResolveTemplateInputs_FieldDefaults(t *testing.T) {
+	schema := TemplateSchema{
+		Fields: []SchemaField{
+			{Key: "Priority", Type: FieldTypeInt, Default: float64(2)},
+			{Key: "Platforms", Type: FieldTypeMultiSelect, Options: []string{"linux", "macos"}, Default: []any{"linux"}},
+			{Key: "Draft", Type: FieldTypeBool, Default: true},
+			{Key: "Owner", Type: FieldTypeString, Default: "docs-team"},
+		},
+	}
+
+	got, err := ResolveTemplateInputs(schema, map[string]any{"Owner": "platform"}, map[string]string{"Priority": "5"}, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5, got["Priority"])
+	require.Equal(t, []string{"linux"}, got["Platforms"])
+	require.Equal(t, true, got["Draft"])
+	require.Equal(t, "platform", got["Owner"], "template defaults take precedence over field defaults")
+}
+
+func TestResolveTemplateInputs_InvalidOverride(t *testing.T) {
+	schema := TemplateSchema{Fields: []SchemaField{{Key: "Due", Type: FieldTypeDate}}}
+	_, err := ResolveTemplateInputs(schema, nil, map[string]string{"Due": "tomorrow"}, true, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid date for Due")
+}
+
+func TestResolveTemplateInputs_OptionalFieldsZeroValued(t *testing.T) {
+	schema := TemplateSchema{Fields: []SchemaField{
+		{Key: "Title", Type: FieldTypeString, Required: true},
+		{Key: "DecisionMakers", Type: FieldTypeString},
+		{Key: "Tags", Type: FieldTypeStringList},
+	}}
+	got, err := ResolveTemplateInputs(schema, nil, map[string]string{"Title": "T"}, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, "", got["DecisionMakers"])
+	require.Equal(t, []string{}, got["Tags"])
+
+	body, err := RenderTemplateBody("{{ if .DecisionMakers }}{{ .DecisionMakers }}{{ else }}Team{{ end }}{{ range .Tags }}x{{ end }}", got, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Team", body)
+}
@@ -17,7 +17,8 @@ import (
 // Returns:
 //   - A parsed TemplateSchema with all fields
 //   - An empty TemplateSchema (no error) if raw is empty
-//   - An error if JSON is invalid
+//   - An error if JSON is invalid or a field has an unknown type, an invalid pattern,
+//     or a default that does not satisfy its own type and pattern
 //
 // Example:
 //
@@ -32,6 +33,11 @@ func ParseTemplateSchema(raw string) (TemplateSchema, error) {
 	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
 		return TemplateSchema{}, fmt.Errorf("parse template schema: %w", err)
 	}
+	for _, field := range schema.Fields {
+		if err := field.validate(); err != nil {
+			return TemplateSchema{}, fmt.Errorf("parse template schema: %w", err)
+		}
+	}
 	return schema, nil
 }
 
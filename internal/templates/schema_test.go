@@ -51,3 +51,35 @@ func TestParseTemplateDefaults_InvalidJSON(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "parse template defaults")
 }
+
+func TestParseTemplateSchema_ExtendedTypes(t *testing.T) {
+	raw := `{"fields":[
+		{"key":"Due","type":"date","default":"today"},
+		{"key":"Priority","type":"int","default":1},
+		{"key":"Platforms","type":"multi_select","options":["linux","macos"],"default":["linux"]},
+		{"key":"Slug","type":"string","required":true,"pattern":"^[a-z0-9-]+$"}
+	]}`
+
+	schema, err := ParseTemplateSchema(raw)
+	require.NoError(t, err)
+	require.Len(t, schema.Fields, 4)
+	require.Equal(t, FieldTypeMultiSelect, schema.Fields[2].Type)
+	require.Equal(t, "^[a-z0-9-]+$", schema.Fields[3].Pattern)
+}
+
+func TestParseTemplateSchema_InvalidFields(t *testing.T) {
+	tests := map[string]string{
+		"unknown type":    `{"fields":[{"key":"X","type":"color"}]}`,
+		"missing key":     `{"fields":[{"type":"string"}]}`,
+		"invalid pattern": `{"fields":[{"key":"X","type":"string","pattern":"("}]}`,
+		"invalid default": `{"fields":[{"key":"X","type":"int","default":"many"}]}`,
+		"default pattern": `{"fields":[{"key":"X","type":"string","pattern":"^a+$","default":"b"}]}`,
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTemplateSchema(raw)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "parse template schema")
+		})
+	}
+}
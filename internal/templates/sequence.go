@@ -80,6 +80,44 @@ func ParseSequenceDefinition(raw string) (*SequenceDefinition, error) {
 	return &def, nil
 }
 
+// adrSequence is the sequence of "adr" templates that do not define their own.
+var adrSequence = SequenceDefinition{
+	Name:  "adr",
+	Dir:   "adr",
+	Glob:  "adr-*.md",
+	Regex: "^adr-(\\d{3})-",
+	Width: 3,
+	Start: 1,
+}
+
+// PageSequences returns the sequences a template can use, by name: the one defined in
+// its metadata and, for ADR templates, the built-in "adr" sequence.
+func PageSequences(page *TemplatePage) (map[string]SequenceDefinition, error) {
+	defs := make(map[string]SequenceDefinition)
+	if page.Meta.Sequence != "" {
+		def, err := ParseSequenceDefinition(page.Meta.Sequence)
+		if err != nil {
+			if !errors.Is(err, ErrNoSequenceDefinition) {
+				return nil, err
+			}
+		} else if def != nil {
+			defs[def.Name] = *def
+		}
+	}
+	if _, ok := defs["adr"]; !ok && strings.EqualFold(page.Meta.Type, "adr") {
+		defs["adr"] = adrSequence
+	}
+	return defs, nil
+}
+
+// FirstInSequence returns the number a sequence starts at: Start, or 1 if it is not set.
+func FirstInSequence(def SequenceDefinition) int {
+	if def.Start > 0 {
+		return def.Start
+	}
+	return 1
+}
+
 // ComputeNextInSequence computes the next number in a sequence by scanning existing files.
 //
 // The function:
@@ -170,8 +208,5 @@ func ComputeNextInSequence(def SequenceDefinition, docsDir string) (int, error)
 		return maxValue + 1, nil
 	}
 
-	if def.Start > 0 {
-		return def.Start, nil
-	}
-	return 1, nil
+	return FirstInSequence(def), nil
 }
@@ -0,0 +1,115 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// templateFileSuffix identifies template source files in a directory tree.
+const templateFileSuffix = ".template.md"
+
+// Source provides templates to the template commands.
+//
+// Implementations return TemplateLinks from Discover whose URL is only meaningful
+// to the same source's Fetch.
+type Source interface {
+	Discover(ctx context.Context) ([]TemplateLink, error)
+	Fetch(ctx context.Context, link TemplateLink) (*TemplatePage, error)
+}
+
+// HTTPSource reads templates from a rendered documentation site.
+type HTTPSource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Discover fetches the site's template discovery page.
+func (s *HTTPSource) Discover(ctx context.Context) ([]TemplateLink, error) {
+	return FetchTemplateDiscovery(ctx, s.BaseURL, s.Client)
+}
+
+// Fetch fetches and parses a rendered template page.
+func (s *HTTPSource) Fetch(ctx context.Context, link TemplateLink) (*TemplatePage, error) {
+	return FetchTemplatePage(ctx, link.URL, s.Client)
+}
+
+// DirSource reads *.template.md files from local directories.
+//
+// Files are parsed with ParseTemplateMarkdown, so templates are authored exactly as
+// they are committed to a documentation repository; no site build is required.
+type DirSource struct {
+	Dirs []string
+}
+
+// NewDirSource creates a source scanning the given directories recursively.
+func NewDirSource(dirs ...string) *DirSource {
+	return &DirSource{Dirs: dirs}
+}
+
+// Discover lists every valid template file, sorted by type. Files that fail to parse
+// are reported as an error so broken templates are not silently hidden.
+func (s *DirSource) Discover(_ context.Context) ([]TemplateLink, error) {
+	var links []TemplateLink
+	for _, dir := range s.Dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if d.IsDir() {
+				if path != dir && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(d.Name(), templateFileSuffix) {
+				return nil
+			}
+			page, err := readTemplateFile(path)
+			if err != nil {
+				return err
+			}
+			links = append(links, TemplateLink{Type: page.Meta.Type, URL: path, Name: page.Meta.Name})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan templates in %s: %w", dir, err)
+		}
+	}
+	if len(links) == 0 {
+		return nil, errors.New("no templates found")
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].Type < links[j].Type })
+	return links, nil
+}
+
+// Fetch parses the template file referenced by link.URL.
+func (s *DirSource) Fetch(_ context.Context, link TemplateLink) (*TemplatePage, error) {
+	return readTemplateFile(link.URL)
+}
+
+func readTemplateFile(path string) (*TemplatePage, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("read template %s: %w", path, err)
+	}
+	if info.Size() > maxTemplateResponseBytes {
+		return nil, fmt.Errorf("template %s exceeds %d bytes", path, maxTemplateResponseBytes)
+	}
+	// #nosec G304 -- path comes from the configured template directory walk
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read template %s: %w", path, err)
+	}
+	page, err := ParseTemplateMarkdown(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", path, err)
+	}
+	return page, nil
+}
@@ -0,0 +1,85 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const adrTemplateSource = "---\n" +
+	"title: ADR Template\n" +
+	"params:\n" +
+	"  docbuilder:\n" +
+	"    template:\n" +
+	"      type: adr\n" +
+	"      name: Architecture Decision Record\n" +
+	"      output_path: 'adr/adr-{{ printf \"%03d\" (nextInSequence \"adr\") }}-{{ .Slug }}.md'\n" +
+	"      schema: '{\"fields\":[{\"key\":\"Slug\",\"type\":\"string\",\"required\":true}]}'\n" +
+	"      sequence:\n" +
+	"        name: adr\n" +
+	"        dir: adr\n" +
+	"        glob: adr-*.md\n" +
+	"        regex: ^adr-(\\d{3})-\n" +
+	"        width: 3\n" +
+	"---\n\n" +
+	"# ADR Template\n\n" +
+	"````markdown\n" +
+	"# {{ .Title }}\n\n" +
+	"```go\n" +
+	"```\n" +
+	"````\n"
+
+func TestParseTemplateMarkdown(t *testing.T) {
+	page, err := ParseTemplateMarkdown([]byte(adrTemplateSource))
+	require.NoError(t, err)
+	require.Equal(t, "adr", page.Meta.Type)
+	require.Equal(t, "Architecture Decision Record", page.Meta.Name)
+	require.Contains(t, page.Meta.OutputPath, `nextInSequence "adr"`)
+
+	def, err := ParseSequenceDefinition(page.Meta.Sequence)
+	require.NoError(t, err)
+	require.Equal(t, "adr", def.Name)
+	require.Equal(t, 3, def.Width)
+}
+
+func TestParseTemplateMarkdown_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no front matter":  "# Template\n",
+		"no template meta": "---\ntitle: x\n---\n```markdown\nbody\n```\n",
+		"missing body":     "---\ndocbuilder:\n  template:\n    type: a\n    name: A\n    output_path: a.md\n---\ntext\n",
+		"multiple bodies":  "---\ndocbuilder:\n  template:\n    type: a\n    name: A\n    output_path: a.md\n---\n```md\none\n```\n```md\ntwo\n```\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTemplateMarkdown([]byte(content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "adr"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "adr", "adr.template.md"), []byte(adrTemplateSource), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "ignored.template.md"), []byte("broken"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a template\n"), 0o600))
+
+	source := NewDirSource(dir)
+	links, err := source.Discover(context.Background())
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	require.Equal(t, "adr", links[0].Type)
+
+	page, err := source.Fetch(context.Background(), links[0])
+	require.NoError(t, err)
+	require.Equal(t, "# {{ .Title }}\n\n```go\n```", page.Body)
+}
+
+func TestDirSource_Empty(t *testing.T) {
+	_, err := NewDirSource(t.TempDir()).Discover(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git.home.luguber.info/inful/docbuilder/internal/frontmatter"
+)
+
+// markdownFenceOpen matches the opening line of a ```markdown / ```md fenced block.
+var markdownFenceOpen = regexp.MustCompile("^(`{3,}|~{3,})\\s*(markdown|md)\\s*$")
+
+// ParseTemplateMarkdown parses a template source file (*.template.md) as authored in a
+// documentation repository.
+//
+// This is the source-form counterpart of ParseTemplatePage: metadata is read from the
+// params.docbuilder.template front matter map (the same keys rendered into the
+// docbuilder:template.* meta tags) and the body from the single ```markdown fenced block.
+// Map-valued schema, defaults and sequence entries are encoded as JSON, matching the
+// rendered meta tag content.
+func ParseTemplateMarkdown(content []byte) (*TemplatePage, error) {
+	fm, body, had, _, err := frontmatter.Split(content)
+	if err != nil {
+		return nil, fmt.Errorf("split template front matter: %w", err)
+	}
+	if !had {
+		return nil, errors.New("template file missing front matter")
+	}
+	fields, err := frontmatter.ParseYAML(fm)
+	if err != nil {
+		return nil, fmt.Errorf("parse template front matter: %w", err)
+	}
+
+	return TemplatePageFromFrontMatter(fields, string(body))
+}
+
+// ErrNoTemplateMetadata reports front matter without a docbuilder.template map.
+var ErrNoTemplateMetadata = errors.New("template front matter missing params.docbuilder.template")
+
+// TemplatePageFromFrontMatter builds a template from already parsed front matter and the
+// markdown body of a template source page. It returns ErrNoTemplateMetadata when the
+// page is not a template.
+func TemplatePageFromFrontMatter(fields map[string]any, body string) (*TemplatePage, error) {
+	tmpl := templateParams(fields)
+	if tmpl == nil {
+		return nil, ErrNoTemplateMetadata
+	}
+
+	result := &TemplatePage{}
+	for key, dst := range map[string]*string{
+		"type":        &result.Meta.Type,
+		"name":        &result.Meta.Name,
+		"output_path": &result.Meta.OutputPath,
+		"description": &result.Meta.Description,
+		"schema":      &result.Meta.Schema,
+		"defaults":    &result.Meta.Defaults,
+		"sequence":    &result.Meta.Sequence,
+	} {
+		value, encErr := metaString(tmpl[key])
+		if encErr != nil {
+			return nil, fmt.Errorf("template metadata %s: %w", key, encErr)
+		}
+		*dst = value
+	}
+
+	missing := missingRequiredTemplateMeta(result.Meta)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required template metadata: %s", strings.Join(missing, ", "))
+	}
+
+	blocks := markdownFencedBlocks(body)
+	if len(blocks) == 0 {
+		return nil, errors.New("template file missing markdown code block")
+	}
+	if len(blocks) > 1 {
+		return nil, errors.New("template file contains multiple markdown code blocks")
+	}
+	result.Body = strings.TrimSpace(blocks[0])
+	return result, nil
+}
+
+// templateParams returns the docbuilder.template map from params (Hugo style) or the top level.
+func templateParams(fields map[string]any) map[string]any {
+	candidates := []any{fields["docbuilder"]}
+	if params, ok := fields["params"].(map[string]any); ok {
+		candidates = append([]any{params["docbuilder"]}, candidates...)
+	}
+	for _, c := range candidates {
+		db, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if tmpl, ok := db["template"].(map[string]any); ok {
+			return tmpl
+		}
+	}
+	return nil
+}
+
+// metaString converts a front matter value to its meta tag string form.
+func metaString(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+// markdownFencedBlocks returns the contents of every ```markdown / ```md fenced block.
+func markdownFencedBlocks(body string) []string {
+	var blocks []string
+	var current []string
+	fence := ""
+	for line := range strings.SplitSeq(body, "\n") {
+		trimmed := strings.TrimRight(line, "\r")
+		if fence == "" {
+			if m := markdownFenceOpen.FindStringSubmatch(strings.TrimSpace(trimmed)); m != nil {
+				fence = m[1]
+				current = current[:0]
+			}
+			continue
+		}
+		if closesFence(strings.TrimSpace(trimmed), fence) {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			fence = ""
+			continue
+		}
+		current = append(current, trimmed)
+	}
+	return blocks
+}
+
+// closesFence reports whether line closes a fence opened with fence: the same
+// character repeated at least as many times, and nothing else.
+func closesFence(line, fence string) bool {
+	return len(line) >= len(fence) && strings.Trim(line, fence[:1]) == ""
+}
@@ -0,0 +1,480 @@
+package forge
+
+import (
+	"crypto/sha1" // #nosec G505 -- fake commit IDs only, not a security use
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// Server serves a TestForge over the subset of the GitHub, GitLab or Forgejo REST API that
+// DocBuilder uses: organizations/groups, repository listing and lookup, branches, the
+// docs/.docignore existence checks and webhook registration. The real forge clients,
+// discovery and webhook handling can run against it without network access or credentials.
+//
+// Failure modes of the TestForge map to HTTP failures: FailModeAuth answers 401,
+// FailModeRateLimit 429, FailModeNotFound 404 and FailModeNetwork drops the connection.
+type Server struct {
+	forge *TestForge
+	token string
+	mux   *http.ServeMux
+
+	mu       sync.Mutex
+	hooks    []Hook
+	baseURL  string
+	requests int
+}
+
+// Hook is a webhook registered through the API or added with AddWebhook.
+type Hook struct {
+	Repository string   // Full name; empty receives events of every repository
+	URL        string   // Delivery target
+	Secret     string   // HMAC secret (GitHub, Forgejo) or token (GitLab)
+	Events     []string // As registered; informational
+}
+
+// NewServer serves tf. When token is non-empty, API requests must authenticate with it
+// (Authorization: Bearer <token>, token <token>, or GitLab's PRIVATE-TOKEN header).
+func NewServer(tf *TestForge, token string) *Server {
+	s := &Server{forge: tf, token: token, mux: http.NewServeMux()}
+	api := s.APIPath()
+	switch tf.Type() {
+	case config.ForgeGitLab:
+		s.mux.HandleFunc("GET "+api+"/groups", s.gitlabGroups)
+		s.mux.HandleFunc("GET "+api+"/groups/{group}/projects", s.gitlabGroupProjects)
+		s.mux.HandleFunc("GET "+api+"/projects/{project}", s.gitlabProject)
+		s.mux.HandleFunc("GET "+api+"/projects/{project}/repository/tree", s.gitlabTree)
+		s.mux.HandleFunc("GET "+api+"/projects/{project}/repository/branches", s.branches)
+		s.mux.HandleFunc("GET "+api+"/projects/{project}/repository/branches/{branch}", s.branch)
+		s.mux.HandleFunc("POST "+api+"/projects/{project}/hooks", s.registerHook)
+	default:
+		s.mux.HandleFunc("GET "+api+"/user/orgs", s.orgs)
+		s.mux.HandleFunc("GET "+api+"/user/repos", s.userRepos)
+		s.mux.HandleFunc("GET "+api+"/orgs/{org}/repos", s.orgRepos)
+		s.mux.HandleFunc("GET "+api+"/repos/{owner}/{repo}", s.repo)
+		s.mux.HandleFunc("GET "+api+"/repos/{owner}/{repo}/contents/{path...}", s.contents)
+		s.mux.HandleFunc("GET "+api+"/repos/{owner}/{repo}/branches", s.branches)
+		s.mux.HandleFunc("GET "+api+"/repos/{owner}/{repo}/branches/{branch}", s.branch)
+		s.mux.HandleFunc("POST "+api+"/repos/{owner}/{repo}/hooks", s.registerHook)
+	}
+	s.mux.HandleFunc("POST "+pushPath, s.simulatePush)
+	return s
+}
+
+// APIPath is the path of the API below the server URL: empty for GitHub (as on
+// api.github.com), /api/v4 for GitLab and /api/v1 for Forgejo.
+func (s *Server) APIPath() string {
+	switch s.forge.Type() {
+	case config.ForgeGitLab:
+		return "/api/v4"
+	case config.ForgeForgejo:
+		return "/api/v1"
+	default:
+		return ""
+	}
+}
+
+// ForgeConfig returns a forge configuration for the server listening at baseURL, with the
+// server's token and a webhook secret, ready for the real forge clients.
+func (s *Server) ForgeConfig(baseURL string) *config.ForgeConfig {
+	fc := s.forge.ToForgeConfig()
+	fc.APIURL = strings.TrimRight(baseURL, "/") + s.APIPath()
+	fc.BaseURL = strings.TrimRight(baseURL, "/")
+	if s.token != "" {
+		fc.Auth.Token = s.token
+	}
+	if s.forge.Type() == config.ForgeGitLab {
+		fc.Groups, fc.Organizations = fc.Organizations, nil
+	}
+	return fc
+}
+
+// Requests returns the number of API requests served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != pushPath {
+		s.mu.Lock()
+		s.requests++
+		if s.baseURL == "" {
+			s.baseURL = "http://" + r.Host
+		}
+		s.mu.Unlock()
+
+		if err := s.forge.simulate(); err != nil {
+			s.fail(w)
+			return
+		}
+		if !s.authorized(r) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// fail answers according to the forge's failure mode.
+func (s *Server) fail(w http.ResponseWriter) {
+	switch s.forge.failMode {
+	case FailModeAuth:
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+	case FailModeRateLimit:
+		w.Header().Set("Retry-After", "60")
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{"message": "API rate limit exceeded"})
+	case FailModeNotFound:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	default:
+		panic(http.ErrAbortHandler) // drop the connection
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	for _, prefix := range []string{"Bearer ", "token "} {
+		if strings.TrimPrefix(auth, prefix) == s.token && strings.HasPrefix(auth, prefix) {
+			return true
+		}
+	}
+	return r.Header.Get("PRIVATE-TOKEN") == s.token
+}
+
+func (s *Server) orgs(w http.ResponseWriter, r *http.Request) {
+	out := make([]map[string]any, 0, len(s.forge.organizations))
+	for i, org := range s.forge.organizations {
+		if s.forge.Type() == config.ForgeForgejo {
+			out = append(out, map[string]any{"id": i + 1, "username": org, "full_name": org, "description": "Test organization: " + org})
+		} else {
+			out = append(out, map[string]any{"id": i + 1, "login": org, "name": org, "description": "Test organization: " + org, "type": "Organization"})
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(r, out))
+}
+
+func (s *Server) orgRepos(w http.ResponseWriter, r *http.Request) {
+	s.writeRepos(w, r, s.reposOf(r.PathValue("org")))
+}
+
+// userRepos lists the repositories the (single) fake user can see: all of them.
+func (s *Server) userRepos(w http.ResponseWriter, r *http.Request) {
+	s.writeRepos(w, r, s.reposOf(""))
+}
+
+func (s *Server) writeRepos(w http.ResponseWriter, r *http.Request, repos []int) {
+	out := make([]map[string]any, 0, len(repos))
+	for _, i := range repos {
+		out = append(out, s.repoJSON(i))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, out))
+}
+
+func (s *Server) repo(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.repoJSON(i))
+}
+
+// contents answers the docs/ and .docignore existence checks.
+func (s *Server) contents(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if !ok || !s.hasPath(i, r.PathValue("path")) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, []any{})
+}
+
+func (s *Server) gitlabGroups(w http.ResponseWriter, r *http.Request) {
+	out := make([]map[string]any, 0, len(s.forge.organizations))
+	for i, group := range s.forge.organizations {
+		out = append(out, map[string]any{
+			"id": i + 1, "name": group, "path": group, "full_name": group, "full_path": group,
+			"description": "Test group: " + group, "kind": "group",
+		})
+	}
+	writeJSON(w, http.StatusOK, paginate(r, out))
+}
+
+// gitlabGroupProjects accepts the numeric group ID (as the GitLab API does) or its path.
+func (s *Server) gitlabGroupProjects(w http.ResponseWriter, r *http.Request) {
+	group := r.PathValue("group")
+	if id, err := strconv.Atoi(group); err == nil && id >= 1 && id <= len(s.forge.organizations) {
+		group = s.forge.organizations[id-1]
+	}
+	s.writeRepos(w, r, s.reposOf(group))
+}
+
+func (s *Server) gitlabProject(w http.ResponseWriter, r *http.Request) {
+	s.repo(w, r)
+}
+
+func (s *Server) gitlabTree(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if !ok || !s.hasPath(i, r.URL.Query().Get("path")) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Tree Not Found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, []any{})
+}
+
+func (s *Server) branches(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	repo := &s.forge.repositories[i]
+	out := make([]map[string]any, 0, len(repo.branches()))
+	for _, b := range repo.branches() {
+		out = append(out, s.branchJSON(repo, b))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, out))
+}
+
+func (s *Server) branch(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if ok {
+		repo := &s.forge.repositories[i]
+		for _, b := range repo.branches() {
+			if b == r.PathValue("branch") {
+				writeJSON(w, http.StatusOK, s.branchJSON(repo, b))
+				return
+			}
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Branch Not Found"})
+}
+
+func (s *Server) branchJSON(repo *TestRepository, branch string) map[string]any {
+	sha := CommitID(repo.FullName, branch)
+	switch s.forge.Type() {
+	case config.ForgeGitLab:
+		return map[string]any{"name": branch, "default": branch == repo.defaultBranch(), "commit": map[string]any{"id": sha}}
+	case config.ForgeForgejo:
+		return map[string]any{"name": branch, "commit": map[string]any{"id": sha}}
+	default:
+		return map[string]any{"name": branch, "protected": false, "commit": map[string]any{"sha": sha}}
+	}
+}
+
+// registerHook records a webhook in the shape each forge's API accepts.
+func (s *Server) registerHook(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	var body struct {
+		URL    string   `json:"url"`   // GitLab
+		Token  string   `json:"token"` // GitLab
+		Events []string `json:"events"`
+		Config struct {
+			URL    string `json:"url"`
+			Secret string `json:"secret"`
+		} `json:"config"`
+		PushEvents bool `json:"push_events"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": err.Error()})
+		return
+	}
+	hook := Hook{Repository: s.forge.repositories[i].FullName, URL: body.Config.URL, Secret: body.Config.Secret, Events: body.Events}
+	if s.forge.Type() == config.ForgeGitLab {
+		hook.URL, hook.Secret = body.URL, body.Token
+		if body.PushEvents {
+			hook.Events = []string{"push_events"}
+		}
+	}
+	if hook.URL == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "url is required"})
+		return
+	}
+	id := s.AddWebhook(hook)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": id, "active": true, "url": hook.URL})
+}
+
+// AddWebhook registers a delivery target and returns its ID.
+func (s *Server) AddWebhook(h Hook) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, h)
+	return len(s.hooks)
+}
+
+// Hooks returns the registered webhooks.
+func (s *Server) Hooks() []Hook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Hook(nil), s.hooks...)
+}
+
+// reposOf returns the indexes of the repositories owned by org, or all when org is empty.
+func (s *Server) reposOf(org string) []int {
+	var out []int
+	for i := range s.forge.repositories {
+		if org == "" || strings.HasPrefix(s.forge.repositories[i].FullName, org+"/") {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// lookup finds the repository named by the request: {owner}/{repo}, or GitLab's
+// {project} given as numeric ID or URL-encoded path.
+func (s *Server) lookup(r *http.Request) (int, bool) {
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if project := r.PathValue("project"); project != "" {
+		fullName = project
+		// Tolerate a path escaped twice (test-org%252Fdocs), as the GitLab client sends it.
+		if unescaped, err := url.PathUnescape(project); err == nil {
+			fullName = unescaped
+		}
+		if id, err := strconv.Atoi(project); err == nil {
+			return id - 1, id >= 1 && id <= len(s.forge.repositories)
+		}
+	}
+	return s.find(fullName)
+}
+
+func (s *Server) hasPath(i int, p string) bool {
+	repo := &s.forge.repositories[i]
+	switch strings.Trim(p, "/") {
+	case "docs":
+		return repo.HasDocs
+	case ".docignore":
+		return repo.HasDocIgnore
+	}
+	return false
+}
+
+// repoJSON renders repository i the way the forge's API does.
+func (s *Server) repoJSON(i int) map[string]any {
+	repo := &s.forge.repositories[i]
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	id := i + 1
+	updated := repo.UpdatedAt
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	cloneURL := repo.CloneURL
+	if cloneURL == "" {
+		cloneURL = s.url() + "/" + repo.FullName + ".git"
+	}
+	sshURL := repo.SSHURL
+	if sshURL == "" {
+		sshURL = "git@" + strings.TrimPrefix(strings.TrimPrefix(s.url(), "http://"), "https://") + ":" + repo.FullName + ".git"
+	}
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	if s.forge.Type() == config.ForgeGitLab {
+		visibility := "public"
+		if repo.Private {
+			visibility = "private"
+		}
+		return map[string]any{
+			"id": id, "name": repo.Name, "path": name,
+			"name_with_namespace": owner + " / " + repo.Name, "path_with_namespace": repo.FullName,
+			"description": repo.Description, "default_branch": repo.defaultBranch(),
+			"http_url_to_repo": cloneURL, "ssh_url_to_repo": sshURL, "web_url": s.url() + "/" + repo.FullName,
+			"visibility": visibility, "archived": repo.Archived, "last_activity_at": updated, "topics": topics,
+			"namespace": map[string]any{"id": s.orgID(owner), "name": owner, "path": owner, "kind": "group", "full_path": owner},
+		}
+	}
+	ownerJSON := map[string]any{"id": s.orgID(owner), "login": owner, "type": "Organization"}
+	if s.forge.Type() == config.ForgeForgejo {
+		ownerJSON = map[string]any{"id": s.orgID(owner), "username": owner, "full_name": owner}
+	}
+	return map[string]any{
+		"id": id, "name": repo.Name, "full_name": repo.FullName, "description": repo.Description,
+		"private": repo.Private, "fork": repo.Fork, "archived": repo.Archived,
+		"clone_url": cloneURL, "ssh_url": sshURL, "html_url": s.url() + "/" + repo.FullName,
+		"default_branch": repo.defaultBranch(), "language": repo.Language,
+		"updated_at": updated, "topics": topics, "owner": ownerJSON,
+	}
+}
+
+func (s *Server) orgID(org string) int {
+	for i, o := range s.forge.organizations {
+		if o == org {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// url is the base URL the server was last reached at.
+func (s *Server) url() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.baseURL == "" {
+		return "http://" + s.forge.name + ".invalid"
+	}
+	return s.baseURL
+}
+
+// CommitID returns the fake head commit of a branch: stable for the same repository and
+// branch, so tests can predict it.
+func CommitID(fullName, branch string) string {
+	sum := sha1.Sum([]byte(fullName + "@" + branch)) // #nosec G401 -- fake commit IDs only
+	return hex.EncodeToString(sum[:])
+}
+
+// paginate applies the page and per_page/limit query parameters to items.
+func paginate(r *http.Request, items []map[string]any) []map[string]any {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	size, _ := strconv.Atoi(q.Get("per_page"))
+	if size <= 0 {
+		size, _ = strconv.Atoi(q.Get("limit"))
+	}
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 30
+	}
+	start := (page - 1) * size
+	if start >= len(items) {
+		return []map[string]any{}
+	}
+	return items[start:min(start+size, len(items))]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (r *TestRepository) defaultBranch() string {
+	if r.DefaultBranch != "" {
+		return r.DefaultBranch
+	}
+	return "main"
+}
+
+func (r *TestRepository) branches() []string {
+	if len(r.Branches) > 0 {
+		return r.Branches
+	}
+	return []string{r.defaultBranch()}
+}
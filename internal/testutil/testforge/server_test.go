@@ -0,0 +1,190 @@
+package forge
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/forge"
+)
+
+// receivedHook is a webhook delivery captured by a test receiver.
+type receivedHook struct {
+	header http.Header
+	body   []byte
+}
+
+// startServer serves tf and returns a real forge client configured for it.
+func startServer(t *testing.T, tf *TestForge) (*Server, forge.Client) {
+	t.Helper()
+	srv := NewServer(tf, "secret-token")
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := forge.NewForgeClient(srv.ForgeConfig(ts.URL))
+	require.NoError(t, err)
+	return srv, client
+}
+
+func TestServer_RealClients(t *testing.T) {
+	for _, forgeType := range []config.ForgeType{config.ForgeGitHub, config.ForgeGitLab, config.ForgeForgejo} {
+		t.Run(string(forgeType), func(t *testing.T) {
+			tf := NewTestForge("fake", forgeType)
+			srv, client := startServer(t, tf)
+
+			orgs, err := client.ListOrganizations(t.Context())
+			require.NoError(t, err)
+			require.Len(t, orgs, 2)
+
+			repos, err := client.ListRepositories(t.Context(), []string{"test-org"})
+			require.NoError(t, err)
+			require.Len(t, repos, 4)
+
+			repo, err := client.GetRepository(t.Context(), "test-org", "docs-repo")
+			require.NoError(t, err)
+			require.Equal(t, "test-org/docs-repo", repo.FullName)
+			require.Equal(t, "main", repo.DefaultBranch)
+
+			require.NoError(t, client.CheckDocumentation(t.Context(), repo))
+			require.True(t, repo.HasDocs)
+			require.False(t, repo.HasDocIgnore)
+
+			var received []receivedHook
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received = append(received, receivedHook{header: r.Header.Clone(), body: body})
+			}))
+			t.Cleanup(receiver.Close)
+
+			require.NoError(t, client.RegisterWebhook(t.Context(), repo, receiver.URL))
+			hooks := srv.Hooks()
+			require.Len(t, hooks, 1)
+			require.Equal(t, "test-org/docs-repo", hooks[0].Repository)
+			require.Equal(t, "fake-webhook-secret", hooks[0].Secret)
+
+			deliveries, err := srv.Push(t.Context(), Push{Repository: "test-org/docs-repo", Modified: []string{"docs/index.md"}})
+			require.NoError(t, err)
+			require.Equal(t, []Delivery{{URL: receiver.URL, Status: http.StatusOK}}, deliveries)
+			require.Len(t, received, 1)
+
+			signature, event := received[0].header.Get("X-Hub-Signature-256"), received[0].header.Get("X-GitHub-Event")
+			switch forgeType {
+			case config.ForgeGitLab:
+				signature, event = received[0].header.Get("X-Gitlab-Token"), received[0].header.Get("X-Gitlab-Event")
+			case config.ForgeForgejo:
+				event = received[0].header.Get("X-Forgejo-Event")
+			}
+			require.True(t, client.ValidateWebhook(received[0].body, signature, "fake-webhook-secret"))
+			parsed, err := client.ParseWebhookEvent(received[0].body, event)
+			require.NoError(t, err)
+			require.Equal(t, "main", parsed.Branch)
+			require.Equal(t, "test-org/docs-repo", parsed.Repository.FullName)
+			require.Len(t, parsed.Commits, 1)
+			require.Equal(t, CommitID("test-org/docs-repo", "main"), parsed.Commits[0].ID)
+			require.Equal(t, []string{"docs/index.md"}, parsed.Commits[0].Modified)
+		})
+	}
+}
+
+func TestServer_Branches(t *testing.T) {
+	tf := NewTestForge("fake", config.ForgeGitHub)
+	tf.ClearRepositories()
+	tf.AddRepository(TestRepository{Name: "docs", FullName: "test-org/docs", Branches: []string{"main", "v1"}})
+	ts := httptest.NewServer(NewServer(tf, ""))
+	t.Cleanup(ts.Close)
+
+	var branches []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	getJSON(t, ts.URL+"/repos/test-org/docs/branches", http.StatusOK, &branches)
+	require.Len(t, branches, 2)
+	require.Equal(t, "v1", branches[1].Name)
+	require.Equal(t, CommitID("test-org/docs", "v1"), branches[1].Commit.SHA)
+
+	getJSON(t, ts.URL+"/repos/test-org/docs/branches/v2", http.StatusNotFound, nil)
+}
+
+func TestServer_GitLabBranchesAndPagination(t *testing.T) {
+	tf := NewTestForge("fake", config.ForgeGitLab)
+	ts := httptest.NewServer(NewServer(tf, ""))
+	t.Cleanup(ts.Close)
+
+	var branches []map[string]any
+	getJSON(t, ts.URL+"/api/v4/projects/test-org%2Fdocs-repo/repository/branches", http.StatusOK, &branches)
+	require.Len(t, branches, 1)
+	require.Equal(t, true, branches[0]["default"])
+
+	var page []map[string]any
+	getJSON(t, ts.URL+"/api/v4/groups/1/projects?per_page=3&page=2", http.StatusOK, &page)
+	require.Len(t, page, 1)
+	require.Equal(t, "test-org/private-docs", page[0]["path_with_namespace"])
+}
+
+func TestServer_FailModes(t *testing.T) {
+	tf := NewTestForge("fake", config.ForgeGitHub)
+	_, client := startServer(t, tf)
+
+	tf.SetFailMode(FailModeAuth)
+	_, err := client.ListOrganizations(t.Context())
+	require.Error(t, err)
+
+	tf.SetFailMode(FailModeNotFound)
+	_, err = client.GetRepository(t.Context(), "test-org", "docs-repo")
+	require.Error(t, err)
+
+	tf.SetFailMode(FailModeNone)
+	_, err = client.GetRepository(t.Context(), "test-org", "docs-repo")
+	require.NoError(t, err)
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	ts := httptest.NewServer(NewServer(NewTestForge("fake", config.ForgeForgejo), "secret-token"))
+	t.Cleanup(ts.Close)
+
+	getJSON(t, ts.URL+"/api/v1/user/orgs", http.StatusUnauthorized, nil)
+}
+
+func TestServer_PushEndpoint(t *testing.T) {
+	tf := NewTestForge("fake", config.ForgeGitHub)
+	srv := NewServer(tf, "")
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	received := make(chan http.Header, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+	}))
+	t.Cleanup(receiver.Close)
+	srv.AddWebhook(Hook{URL: receiver.URL, Secret: "s3cret"})
+
+	resp, err := http.Post(ts.URL+pushPath, "application/json", strings.NewReader(`{"repository":"test-org/api-docs","branch":"main"}`))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "push", (<-received).Get("X-GitHub-Event"))
+
+	resp2, err := http.Post(ts.URL+pushPath, "application/json", strings.NewReader(`{"repository":"test-org/missing"}`))
+	require.NoError(t, err)
+	defer func() { _ = resp2.Body.Close() }()
+	require.Equal(t, http.StatusNotFound, resp2.StatusCode)
+}
+
+func getJSON(t *testing.T, url string, status int, out any) {
+	t.Helper()
+	resp, err := http.Get(url) // #nosec G107 -- test server URL
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, status, resp.StatusCode)
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+}
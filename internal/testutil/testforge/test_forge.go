@@ -34,6 +34,7 @@ type TestRepository struct {
 	CloneURL      string
 	SSHURL        string
 	DefaultBranch string
+	Branches      []string // Served by the HTTP API; defaults to DefaultBranch
 	Description   string
 	Topics        []string
 	Language      string
@@ -162,6 +163,11 @@ func (tf *TestForge) AddRepository(repo TestRepository) {
 	tf.repositories = append(tf.repositories, repo)
 }
 
+// Repositories returns the repositories of the forge.
+func (tf *TestForge) Repositories() []TestRepository {
+	return append([]TestRepository(nil), tf.repositories...)
+}
+
 // AddOrganization adds a test organization.
 func (tf *TestForge) AddOrganization(org string) {
 	tf.organizations = append(tf.organizations, org)
@@ -0,0 +1,190 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// pushPath is the control endpoint that simulates a push: POST a Push as JSON and the
+// server delivers it to every webhook registered for the repository.
+const pushPath = "/_testforge/push"
+
+// Push describes a simulated push to a repository.
+type Push struct {
+	Repository string   `json:"repository"`         // Full name, e.g. test-org/docs-repo
+	Branch     string   `json:"branch,omitempty"`   // Default: the repository's default branch
+	Commit     string   `json:"commit,omitempty"`   // Default: CommitID(Repository, Branch)
+	Message    string   `json:"message,omitempty"`  // Default: "Update documentation"
+	Added      []string `json:"added,omitempty"`    // Paths added by the commit
+	Modified   []string `json:"modified,omitempty"` // Paths modified by the commit
+	Removed    []string `json:"removed,omitempty"`  // Paths removed by the commit
+}
+
+// Delivery is the outcome of delivering a push to one webhook.
+type Delivery struct {
+	URL    string `json:"url"`
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PushPayload renders p as the forge's push webhook: the event header to send, the JSON
+// body and the repository it refers to.
+func (s *Server) PushPayload(p Push) (event string, body []byte, err error) {
+	i, ok := s.find(p.Repository)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown repository %q", p.Repository)
+	}
+	repo := &s.forge.repositories[i]
+	if p.Branch == "" {
+		p.Branch = repo.defaultBranch()
+	}
+	if p.Commit == "" {
+		p.Commit = CommitID(repo.FullName, p.Branch)
+	}
+	if p.Message == "" {
+		p.Message = "Update documentation"
+	}
+	commit := map[string]any{
+		"id": p.Commit, "message": p.Message, "timestamp": time.Now().UTC(),
+		"author":    map[string]string{"name": "Test Forge", "email": "testforge@example.invalid"},
+		"committer": map[string]string{"name": "Test Forge", "email": "testforge@example.invalid"},
+		"added":     nonNil(p.Added), "modified": nonNil(p.Modified), "removed": nonNil(p.Removed),
+	}
+	ref := "refs/heads/" + p.Branch
+
+	var payload map[string]any
+	switch s.forge.Type() {
+	case config.ForgeGitLab:
+		event = "Push Hook"
+		payload = map[string]any{
+			"object_kind": "push", "event_name": "push", "ref": ref,
+			"checkout_sha": p.Commit, "project_id": i + 1, "project": s.repoJSON(i),
+			"commits": []any{commit}, "total_commits_count": 1,
+		}
+	case config.ForgeForgejo:
+		event = "push"
+		payload = map[string]any{
+			"ref": ref, "after": p.Commit, "repository": s.repoJSON(i),
+			"commits": []any{commit}, "head_commit": commit, "total_commits": 1,
+			"pusher": map[string]any{"id": 1, "username": "testforge", "full_name": "Test Forge"},
+		}
+	default:
+		event = "push"
+		payload = map[string]any{
+			"ref": ref, "after": p.Commit, "repository": s.repoJSON(i),
+			"commits": []any{commit}, "head_commit": commit,
+			"pusher": map[string]string{"name": "testforge", "email": "testforge@example.invalid"},
+		}
+	}
+	body, err = json.Marshal(payload)
+	return event, body, err
+}
+
+// DeliverPush sends the push webhook for p to hook, with the headers and signature the
+// forge uses, and returns the receiver's status code.
+func (s *Server) DeliverPush(ctx context.Context, hook Hook, p Push) (int, error) {
+	event, body, err := s.PushPayload(p)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch s.forge.Type() {
+	case config.ForgeGitLab:
+		req.Header.Set("X-Gitlab-Event", event)
+		if hook.Secret != "" {
+			req.Header.Set("X-Gitlab-Token", hook.Secret)
+		}
+	case config.ForgeForgejo:
+		req.Header.Set("X-Forgejo-Event", event)
+		req.Header.Set("X-Gitea-Event", event)
+		if hook.Secret != "" {
+			req.Header.Set("X-Hub-Signature-256", Sign(body, hook.Secret))
+		}
+	default:
+		req.Header.Set("X-GitHub-Event", event)
+		if hook.Secret != "" {
+			req.Header.Set("X-Hub-Signature-256", Sign(body, hook.Secret))
+		}
+	}
+	resp, err := http.DefaultClient.Do(req) // #nosec G107 G704 -- delivery target configured by the test or developer
+	if err != nil {
+		return 0, fmt.Errorf("deliver webhook to %s: %w", hook.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// Push delivers p to every webhook registered for its repository (or for all
+// repositories).
+func (s *Server) Push(ctx context.Context, p Push) ([]Delivery, error) {
+	if _, ok := s.find(p.Repository); !ok {
+		return nil, fmt.Errorf("unknown repository %q", p.Repository)
+	}
+	var out []Delivery
+	for _, hook := range s.Hooks() {
+		if hook.Repository != "" && hook.Repository != p.Repository {
+			continue
+		}
+		d := Delivery{URL: hook.URL}
+		status, err := s.DeliverPush(ctx, hook, p)
+		d.Status = status
+		if err != nil {
+			d.Error = err.Error()
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// simulatePush serves POST /_testforge/push.
+func (s *Server) simulatePush(w http.ResponseWriter, r *http.Request) {
+	var p Push
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	deliveries, err := s.Push(r.Context(), p)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"deliveries": nonNil(deliveries)})
+}
+
+// Sign returns the X-Hub-Signature-256 value of body for secret.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) find(fullName string) (int, bool) {
+	for i := range s.forge.repositories {
+		if s.forge.repositories[i].FullName == fullName {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -3,6 +3,7 @@ package versioning
 import (
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"git.home.luguber.info/inful/docbuilder/internal/config"
 	"git.home.luguber.info/inful/docbuilder/internal/git"
@@ -10,6 +11,8 @@ import (
 
 // ExpandRepositoriesWithVersions takes the base repository configuration and expands
 // it into multiple repositories if versioning is enabled, one per version.
+// Version discovery runs for up to versioning.concurrency repositories at once; the
+// expanded list keeps the configured repository order regardless of completion order.
 func ExpandRepositoriesWithVersions(gitClient *git.Client, cfg *config.Config) ([]config.Repository, error) {
 	// If versioning is disabled or not configured, return repos as-is
 	if cfg.Versioning == nil || !cfg.Versioning.Enabled || cfg.Versioning.DefaultBranchOnly {
@@ -17,80 +20,114 @@ func ExpandRepositoriesWithVersions(gitClient *git.Client, cfg *config.Config) (
 	}
 
 	versionManager := NewVersionManager(gitClient)
-	var expandedRepos []config.Repository
+	// Convert config.VersioningConfig to versioning.VersionConfig
+	versionConfig := &VersionConfig{
+		Strategy:    VersionStrategy(cfg.Versioning.Strategy),
+		MaxVersions: cfg.Versioning.MaxVersionsPerRepo,
+	}
+
+	// Add patterns if specified
+	if len(cfg.Versioning.BranchPatterns) > 0 {
+		versionConfig.BranchPatterns = cfg.Versioning.BranchPatterns
+	}
+	if len(cfg.Versioning.TagPatterns) > 0 {
+		versionConfig.TagPatterns = cfg.Versioning.TagPatterns
+	}
 
+	perRepo := make([][]config.Repository, len(cfg.Repositories))
+	workers := min(cfg.Versioning.EffectiveConcurrency(), len(cfg.Repositories))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				perRepo[i] = expandRepository(versionManager, versionConfig, cfg.Repositories[i], cfg.Versioning.Worktrees)
+			}
+		}()
+	}
 	for i := range cfg.Repositories {
-		repo := &cfg.Repositories[i]
-		// Convert config.VersioningConfig to versioning.VersionConfig
-		versionConfig := &VersionConfig{
-			Strategy:    VersionStrategy(cfg.Versioning.Strategy),
-			MaxVersions: cfg.Versioning.MaxVersionsPerRepo,
-		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-		// Add patterns if specified
-		if len(cfg.Versioning.BranchPatterns) > 0 {
-			versionConfig.BranchPatterns = cfg.Versioning.BranchPatterns
-		}
-		if len(cfg.Versioning.TagPatterns) > 0 {
-			versionConfig.TagPatterns = cfg.Versioning.TagPatterns
-		}
+	var expandedRepos []config.Repository
+	for _, repos := range perRepo {
+		expandedRepos = append(expandedRepos, repos...)
+	}
 
-		// Discover versions for this repository (pass repo for auth)
-		result, err := versionManager.DiscoverVersionsWithAuth(repo.URL, versionConfig, repo.Auth)
-		if err != nil {
-			slog.Warn("Failed to discover versions for repository, using single version",
-				"repo", repo.Name,
-				"error", err)
-			// Fallback to single version
-			expandedRepos = append(expandedRepos, *repo)
-			continue
-		}
+	slog.Info("Repository expansion complete",
+		"original", len(cfg.Repositories),
+		"expanded", len(expandedRepos),
+		"concurrency", workers)
 
-		// Create a repository entry for each discovered version
-		if len(result.Repository.Versions) == 0 {
-			slog.Warn("No versions found for repository, using default branch",
-				"repo", repo.Name)
-			expandedRepos = append(expandedRepos, *repo)
-			continue
-		}
+	return expandedRepos, nil
+}
 
-		slog.Info("Discovered versions for repository",
+// expandRepository discovers the versions of a single repository and returns one
+// repository entry per version, falling back to the repository itself.
+// With worktrees, versions are checked out from one shared clone of the repository.
+func expandRepository(versionManager *DefaultVersionManager, versionConfig *VersionConfig, repo config.Repository, worktrees bool) []config.Repository {
+	// Static sources have no branches or tags to discover.
+	if repo.IsStatic() {
+		return []config.Repository{repo}
+	}
+	// Discover versions for this repository (pass repo for auth)
+	result, err := versionManager.DiscoverVersionsWithAuth(repo.URL, versionConfig, repo.Auth)
+	if err != nil {
+		slog.Warn("Failed to discover versions for repository, using single version",
 			"repo", repo.Name,
-			"versions", len(result.Repository.Versions))
+			"error", err)
+		// Fallback to single version
+		return []config.Repository{repo}
+	}
 
-		for _, version := range result.Repository.Versions {
-			versionedRepo := *repo // Copy base config by dereferencing pointer
+	// Create a repository entry for each discovered version
+	if len(result.Repository.Versions) == 0 {
+		slog.Warn("No versions found for repository, using default branch",
+			"repo", repo.Name)
+		return []config.Repository{repo}
+	}
 
-			// Set version-specific fields
-			versionedRepo.Branch = version.Name // Use Name as branch/tag reference
-			versionedRepo.Version = version.DisplayName
-			versionedRepo.IsVersioned = true
-			versionedRepo.IsTag = (version.Type == VersionTypeTag)
+	slog.Info("Discovered versions for repository",
+		"repo", repo.Name,
+		"versions", len(result.Repository.Versions))
+
+	expanded := make([]config.Repository, 0, len(result.Repository.Versions))
+	for _, version := range result.Repository.Versions {
+		versionedRepo := repo // Copy base config
+
+		// Set version-specific fields
+		versionedRepo.Branch = version.Name // Use Name as branch/tag reference
+		versionedRepo.Version = version.DisplayName
+		versionedRepo.IsVersioned = true
+		versionedRepo.IsTag = (version.Type == VersionTypeTag)
+		if worktrees {
+			versionedRepo.WorktreeOf = repo.Name
+		}
 
-			slog.Debug("Creating versioned repository",
-				"name", repo.Name,
-				"version", version.Name,
-				"type", string(version.Type),
-				"is_tag", versionedRepo.IsTag)
+		slog.Debug("Creating versioned repository",
+			"name", repo.Name,
+			"version", version.Name,
+			"type", string(version.Type),
+			"is_tag", versionedRepo.IsTag)
 
-			// Update name to include version for uniqueness
-			versionedRepo.Name = fmt.Sprintf("%s-%s", repo.Name, version.DisplayName)
+		// Update name to include version for uniqueness
+		versionedRepo.Name = fmt.Sprintf("%s-%s", repo.Name, version.DisplayName)
 
-			// Add version metadata to tags
-			if versionedRepo.Tags == nil {
-				versionedRepo.Tags = make(map[string]string)
-			}
-			versionedRepo.Tags["version"] = version.DisplayName
-			versionedRepo.Tags["version_type"] = string(version.Type)
-			versionedRepo.Tags["base_repo"] = repo.Name
-
-			expandedRepos = append(expandedRepos, versionedRepo)
+		// Add version metadata to tags; copy so versions never share the base map
+		tags := make(map[string]string, len(repo.Tags)+3)
+		for k, v := range repo.Tags {
+			tags[k] = v
 		}
-	}
+		tags["version"] = version.DisplayName
+		tags["version_type"] = string(version.Type)
+		tags["base_repo"] = repo.Name
+		versionedRepo.Tags = tags
 
-	slog.Info("Repository expansion complete",
-		"original", len(cfg.Repositories),
-		"expanded", len(expandedRepos))
-
-	return expandedRepos, nil
+		expanded = append(expanded, versionedRepo)
+	}
+	return expanded
 }
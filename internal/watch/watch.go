@@ -0,0 +1,308 @@
+// Package watch reports filesystem changes below a set of root directories.
+//
+// Each root is watched recursively and debounced independently: a burst of events
+// under one root produces a single Change once the root has been quiet for the
+// debounce interval, listing every path touched during the burst. Directories that
+// are created, moved in or renamed are (re)watched automatically, and editor swap
+// files, hidden files and user-supplied ignore patterns never trigger a Change.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is used when Options.Debounce is zero.
+const DefaultDebounce = 300 * time.Millisecond
+
+// Options configures a Watcher.
+type Options struct {
+	// Debounce is the quiet period per root before a Change is emitted.
+	Debounce time.Duration
+	// Ignore lists glob patterns (filepath.Match syntax) matched against both the base
+	// name and the root-relative slash path. Matching directories are not watched.
+	Ignore []string
+}
+
+// Change is a debounced batch of events below a single root.
+type Change struct {
+	Root  string   // Root directory as passed to Add
+	Paths []string // Absolute paths touched during the burst, sorted
+}
+
+// Watcher watches root directories and emits debounced Changes.
+type Watcher struct {
+	fsw      *fsnotify.Watcher
+	debounce time.Duration
+	ignore   []string
+	changes  chan Change
+	done     chan struct{}
+	closeOne sync.Once
+
+	mu      sync.Mutex
+	roots   []string
+	pending map[string]map[string]struct{}
+	timers  map[string]*time.Timer
+}
+
+// ValidatePatterns reports the first malformed ignore pattern.
+func ValidatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := filepath.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid ignore pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// New creates a Watcher. Call Add for each root and Run to start delivering changes.
+func New(opts Options) (*Watcher, error) {
+	if err := ValidatePatterns(opts.Ignore); err != nil {
+		return nil, err
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("fsnotify: %w", err)
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	return &Watcher{
+		fsw:      fsw,
+		debounce: debounce,
+		ignore:   opts.Ignore,
+		changes:  make(chan Change, 16),
+		done:     make(chan struct{}),
+		pending:  map[string]map[string]struct{}{},
+		timers:   map[string]*time.Timer{},
+	}, nil
+}
+
+// Add watches root and all of its non-ignored subdirectories. Adding a root twice is a no-op.
+func (w *Watcher) Add(root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("resolve watch root: %w", err)
+	}
+	if st, statErr := os.Stat(abs); statErr != nil || !st.IsDir() {
+		return fmt.Errorf("watch root not found or not a directory: %s", abs)
+	}
+
+	w.mu.Lock()
+	if slices.Contains(w.roots, abs) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.roots = append(w.roots, abs)
+	w.mu.Unlock()
+
+	return w.addDirs(abs, abs, nil)
+}
+
+// Roots returns the watched root directories.
+func (w *Watcher) Roots() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.roots)
+}
+
+// Run processes filesystem events and calls onChange for each debounced Change until
+// ctx is cancelled or the watcher is closed. onChange is called from Run's goroutine.
+func (w *Watcher) Run(ctx context.Context, onChange func(Change)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done:
+			return nil
+		case change := <-w.changes:
+			onChange(change)
+		case ev, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(ev)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("watcher error", "error", err)
+		}
+	}
+}
+
+// Close stops watching and cancels pending changes.
+func (w *Watcher) Close() error {
+	w.closeOne.Do(func() { close(w.done) })
+	w.mu.Lock()
+	for root, t := range w.timers {
+		t.Stop()
+		delete(w.timers, root)
+	}
+	w.mu.Unlock()
+	return w.fsw.Close()
+}
+
+func (w *Watcher) handleEvent(ev fsnotify.Event) {
+	root := w.rootFor(ev.Name)
+	if root == "" || w.ignored(root, ev.Name) {
+		return
+	}
+
+	touched := []string{ev.Name}
+	switch {
+	case ev.Has(fsnotify.Create):
+		// New or moved-in directories must be watched, and files already inside them
+		// (e.g. a directory moved into place) count as changed.
+		if st, err := os.Stat(ev.Name); err == nil && st.IsDir() {
+			var files []string
+			if err := w.addDirs(root, ev.Name, &files); err != nil {
+				slog.Warn("watch add failed", "dir", ev.Name, "error", err)
+			}
+			touched = append(touched, files...)
+		}
+	case ev.Has(fsnotify.Rename), ev.Has(fsnotify.Remove):
+		// The old name is gone; the new name (if still below a root) arrives as Create.
+		_ = w.fsw.Remove(ev.Name)
+	}
+
+	slog.Debug("File change detected", "path", ev.Name, "op", ev.Op.String())
+	w.record(root, touched)
+}
+
+// record adds paths to root's pending batch and restarts its debounce timer.
+func (w *Watcher) record(root string, paths []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	set := w.pending[root]
+	if set == nil {
+		set = map[string]struct{}{}
+		w.pending[root] = set
+	}
+	for _, p := range paths {
+		set[p] = struct{}{}
+	}
+	if t := w.timers[root]; t != nil {
+		t.Stop()
+	}
+	w.timers[root] = time.AfterFunc(w.debounce, func() { w.flush(root) })
+}
+
+func (w *Watcher) flush(root string) {
+	w.mu.Lock()
+	set := w.pending[root]
+	delete(w.pending, root)
+	delete(w.timers, root)
+	w.mu.Unlock()
+	if len(set) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(set))
+	for p := range set {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	select {
+	case w.changes <- Change{Root: root, Paths: paths}:
+	case <-w.done:
+	}
+}
+
+// addDirs watches dir and its subdirectories. When files is non-nil, regular files
+// found during the walk are appended to it.
+func (w *Watcher) addDirs(root, dir string, files *[]string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if path != root && w.ignored(root, path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			if files != nil {
+				*files = append(*files, path)
+			}
+			return nil
+		}
+		if err := w.fsw.Add(path); err != nil {
+			slog.Warn("watch add failed", "dir", path, "error", err)
+		}
+		return nil
+	})
+}
+
+// rootFor returns the most specific watched root containing path.
+func (w *Watcher) rootFor(path string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	best := ""
+	for _, r := range w.roots {
+		if (path == r || strings.HasPrefix(path, r+string(filepath.Separator))) && len(r) > len(best) {
+			best = r
+		}
+	}
+	return best
+}
+
+func (w *Watcher) ignored(root, path string) bool {
+	if IsTransient(path) {
+		return true
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	base := filepath.Base(path)
+	for _, p := range w.ignore {
+		if ok, _ := filepath.Match(p, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransient reports whether path is a hidden, editor swap/backup or OS metadata file
+// that should never trigger a rebuild.
+func IsTransient(path string) bool {
+	base := filepath.Base(path)
+
+	// Hidden files and directories (including .git)
+	if strings.HasPrefix(base, ".") {
+		return true
+	}
+
+	// Editor temp/swap files
+	if strings.HasSuffix(base, "~") ||
+		strings.HasSuffix(base, ".swp") ||
+		strings.HasSuffix(base, ".swx") ||
+		strings.HasPrefix(base, "#") && strings.HasSuffix(base, "#") {
+		return true
+	}
+
+	// OS metadata
+	return base == "Thumbs.db"
+}
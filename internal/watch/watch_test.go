@@ -0,0 +1,156 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testDebounce = 50 * time.Millisecond
+
+func startWatcher(t *testing.T, opts Options, roots ...string) <-chan Change {
+	t.Helper()
+	w, err := New(opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, r := range roots {
+		if err := w.Add(r); err != nil {
+			t.Fatalf("Add(%s): %v", r, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Change, 16)
+	go func() { _ = w.Run(ctx, func(c Change) { out <- c }) }()
+	t.Cleanup(func() {
+		cancel()
+		_ = w.Close()
+	})
+	return out
+}
+
+func waitChange(t *testing.T, ch <-chan Change) Change {
+	t.Helper()
+	select {
+	case c := <-ch:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+		return Change{}
+	}
+}
+
+func expectNoChange(t *testing.T, ch <-chan Change) {
+	t.Helper()
+	select {
+	case c := <-ch:
+		t.Fatalf("unexpected change: %+v", c)
+	case <-time.After(4 * testDebounce):
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcher_DebouncesBurstPerRoot(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	changes := startWatcher(t, Options{Debounce: testDebounce}, a, b)
+
+	writeFile(t, filepath.Join(a, "one.md"), "1")
+	writeFile(t, filepath.Join(a, "two.md"), "2")
+	writeFile(t, filepath.Join(b, "three.md"), "3")
+
+	got := map[string]Change{}
+	for range 2 {
+		c := waitChange(t, changes)
+		got[c.Root] = c
+	}
+	if ca := got[a]; !slices.Contains(ca.Paths, filepath.Join(a, "one.md")) || !slices.Contains(ca.Paths, filepath.Join(a, "two.md")) {
+		t.Errorf("root a change = %+v, want one.md and two.md in a single batch", ca)
+	}
+	if cb := got[b]; !slices.Equal(cb.Paths, []string{filepath.Join(b, "three.md")}) {
+		t.Errorf("root b change = %+v", cb)
+	}
+	expectNoChange(t, changes)
+}
+
+func TestWatcher_IgnoresTransientAndPatterns(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "node_modules"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	changes := startWatcher(t, Options{Debounce: testDebounce, Ignore: []string{"*.tmp", "node_modules"}}, root)
+
+	writeFile(t, filepath.Join(root, ".hidden.md"), "x")
+	writeFile(t, filepath.Join(root, "doc.md.swp"), "x")
+	writeFile(t, filepath.Join(root, "build.tmp"), "x")
+	writeFile(t, filepath.Join(root, "node_modules", "pkg.js"), "x")
+	expectNoChange(t, changes)
+
+	writeFile(t, filepath.Join(root, "visible.md"), "x")
+	c := waitChange(t, changes)
+	if !slices.Equal(c.Paths, []string{filepath.Join(root, "visible.md")}) {
+		t.Errorf("paths = %v", c.Paths)
+	}
+}
+
+func TestWatcher_WatchesRenamedAndNewDirectories(t *testing.T) {
+	root, outside := t.TempDir(), t.TempDir()
+	changes := startWatcher(t, Options{Debounce: testDebounce}, root)
+
+	// A directory moved in from outside is watched and its files reported.
+	src := filepath.Join(outside, "guides")
+	if err := os.MkdirAll(src, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(src, "intro.md"), "x")
+	moved := filepath.Join(root, "guides")
+	if err := os.Rename(src, moved); err != nil {
+		t.Fatal(err)
+	}
+	c := waitChange(t, changes)
+	if !slices.Contains(c.Paths, filepath.Join(moved, "intro.md")) {
+		t.Errorf("paths = %v, want moved-in file", c.Paths)
+	}
+
+	// Renaming it keeps the new name watched.
+	renamed := filepath.Join(root, "howto")
+	if err := os.Rename(moved, renamed); err != nil {
+		t.Fatal(err)
+	}
+	waitChange(t, changes)
+	writeFile(t, filepath.Join(renamed, "next.md"), "x")
+	c = waitChange(t, changes)
+	if !slices.Contains(c.Paths, filepath.Join(renamed, "next.md")) {
+		t.Errorf("paths = %v, want file in renamed directory", c.Paths)
+	}
+}
+
+func TestNew_RejectsInvalidPattern(t *testing.T) {
+	if _, err := New(Options{Ignore: []string{"[abc"}}); err == nil {
+		t.Fatal("expected error for malformed pattern")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/tmp/.hidden.md": true,
+		"/tmp/#foo#":      true,
+		"/tmp/foo.swp":    true,
+		"/tmp/foo.md~":    true,
+		"/tmp/.DS_Store":  true,
+		"/tmp/Thumbs.db":  true,
+		"/tmp/visible.md": false,
+	} {
+		if got := IsTransient(path); got != want {
+			t.Errorf("IsTransient(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
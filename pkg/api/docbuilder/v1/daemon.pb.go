@@ -0,0 +1,667 @@
+// Control API of the DocBuilder daemon. It mirrors the admin HTTP API and is served on
+// the admin port when daemon.http.rpc is enabled, over the gRPC, gRPC-Web and Connect
+// protocols. Regenerate the Go code with `buf generate`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: docbuilder/v1/daemon.proto
+
+package docbuilderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{0}
+}
+
+type GetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Daemon state: stopped, starting, running, stopping or error.
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	UptimeSeconds float64                `protobuf:"fixed64,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// Builds currently running.
+	ActiveJobs int32 `protobuf:"varint,4,opt,name=active_jobs,json=activeJobs,proto3" json:"active_jobs,omitempty"`
+	// Builds waiting in the queue.
+	QueueLength int32 `protobuf:"varint,5,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+	// Whether this replica holds the build leadership (always true without leader election).
+	Leader        bool `protobuf:"varint,6,opt,name=leader,proto3" json:"leader,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetUptimeSeconds() float64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetActiveJobs() int32 {
+	if x != nil {
+		return x.ActiveJobs
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetQueueLength() int32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetLeader() bool {
+	if x != nil {
+		return x.Leader
+	}
+	return false
+}
+
+type TriggerBuildRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only fetch these repositories or monorepo sections; empty rebuilds everything.
+	Repositories []string `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	// Build repositories (by name) from exact commit SHAs instead of their branch heads.
+	Commits       map[string]string `protobuf:"bytes,2,rep,name=commits,proto3" json:"commits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerBuildRequest) Reset() {
+	*x = TriggerBuildRequest{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerBuildRequest) ProtoMessage() {}
+
+func (x *TriggerBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerBuildRequest.ProtoReflect.Descriptor instead.
+func (*TriggerBuildRequest) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TriggerBuildRequest) GetRepositories() []string {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+func (x *TriggerBuildRequest) GetCommits() map[string]string {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+type TriggerBuildResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the queued build; use it with GetBuildReport and WatchBuildEvents.
+	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerBuildResponse) Reset() {
+	*x = TriggerBuildResponse{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerBuildResponse) ProtoMessage() {}
+
+func (x *TriggerBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerBuildResponse.ProtoReflect.Descriptor instead.
+func (*TriggerBuildResponse) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TriggerBuildResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type TriggerDiscoveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerDiscoveryRequest) Reset() {
+	*x = TriggerDiscoveryRequest{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerDiscoveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerDiscoveryRequest) ProtoMessage() {}
+
+func (x *TriggerDiscoveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerDiscoveryRequest.ProtoReflect.Descriptor instead.
+func (*TriggerDiscoveryRequest) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{4}
+}
+
+type TriggerDiscoveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerDiscoveryResponse) Reset() {
+	*x = TriggerDiscoveryResponse{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerDiscoveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerDiscoveryResponse) ProtoMessage() {}
+
+func (x *TriggerDiscoveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerDiscoveryResponse.ProtoReflect.Descriptor instead.
+func (*TriggerDiscoveryResponse) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TriggerDiscoveryResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetBuildReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildReportRequest) Reset() {
+	*x = GetBuildReportRequest{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildReportRequest) ProtoMessage() {}
+
+func (x *GetBuildReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildReportRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildReportRequest) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBuildReportRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+type GetBuildReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The build report document (build-report.json schema).
+	ReportJson    []byte `protobuf:"bytes,1,opt,name=report_json,json=reportJson,proto3" json:"report_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildReportResponse) Reset() {
+	*x = GetBuildReportResponse{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildReportResponse) ProtoMessage() {}
+
+func (x *GetBuildReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildReportResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildReportResponse) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBuildReportResponse) GetReportJson() []byte {
+	if x != nil {
+		return x.ReportJson
+	}
+	return nil
+}
+
+type WatchBuildEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream events of this build; empty streams all builds.
+	BuildId       string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBuildEventsRequest) Reset() {
+	*x = WatchBuildEventsRequest{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBuildEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBuildEventsRequest) ProtoMessage() {}
+
+func (x *WatchBuildEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBuildEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchBuildEventsRequest) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchBuildEventsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+// BuildEvent is one build lifecycle event as recorded in the daemon's event store.
+type BuildEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	BuildId string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// Event type, e.g. BuildStarted, BuildCompleted, BuildFailed or BuildReportGenerated.
+	Type string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Event data as JSON; its fields depend on the type.
+	PayloadJson   []byte            `protobuf:"bytes,4,opt,name=payload_json,json=payloadJson,proto3" json:"payload_json,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildEvent) Reset() {
+	*x = BuildEvent{}
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildEvent) ProtoMessage() {}
+
+func (x *BuildEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_docbuilder_v1_daemon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildEvent.ProtoReflect.Descriptor instead.
+func (*BuildEvent) Descriptor() ([]byte, []int) {
+	return file_docbuilder_v1_daemon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BuildEvent) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *BuildEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BuildEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetPayloadJson() []byte {
+	if x != nil {
+		return x.PayloadJson
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_docbuilder_v1_daemon_proto protoreflect.FileDescriptor
+
+const file_docbuilder_v1_daemon_proto_rawDesc = "" +
+	"\n" +
+	"\x1adocbuilder/v1/daemon.proto\x12\rdocbuilder.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
+	"\x10GetStatusRequest\"\xe9\x01\n" +
+	"\x11GetStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12%\n" +
+	"\x0euptime_seconds\x18\x03 \x01(\x01R\ruptimeSeconds\x12\x1f\n" +
+	"\vactive_jobs\x18\x04 \x01(\x05R\n" +
+	"activeJobs\x12!\n" +
+	"\fqueue_length\x18\x05 \x01(\x05R\vqueueLength\x12\x16\n" +
+	"\x06leader\x18\x06 \x01(\bR\x06leader\"\xc0\x01\n" +
+	"\x13TriggerBuildRequest\x12\"\n" +
+	"\frepositories\x18\x01 \x03(\tR\frepositories\x12I\n" +
+	"\acommits\x18\x02 \x03(\v2/.docbuilder.v1.TriggerBuildRequest.CommitsEntryR\acommits\x1a:\n" +
+	"\fCommitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"-\n" +
+	"\x14TriggerBuildResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\x19\n" +
+	"\x17TriggerDiscoveryRequest\"1\n" +
+	"\x18TriggerDiscoveryResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"2\n" +
+	"\x15GetBuildReportRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\"9\n" +
+	"\x16GetBuildReportResponse\x12\x1f\n" +
+	"\vreport_json\x18\x01 \x01(\fR\n" +
+	"reportJson\"4\n" +
+	"\x17WatchBuildEventsRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\"\x90\x02\n" +
+	"\n" +
+	"BuildEvent\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12!\n" +
+	"\fpayload_json\x18\x04 \x01(\fR\vpayloadJson\x12C\n" +
+	"\bmetadata\x18\x05 \x03(\v2'.docbuilder.v1.BuildEvent.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xd5\x03\n" +
+	"\rDaemonService\x12N\n" +
+	"\tGetStatus\x12\x1f.docbuilder.v1.GetStatusRequest\x1a .docbuilder.v1.GetStatusResponse\x12W\n" +
+	"\fTriggerBuild\x12\".docbuilder.v1.TriggerBuildRequest\x1a#.docbuilder.v1.TriggerBuildResponse\x12c\n" +
+	"\x10TriggerDiscovery\x12&.docbuilder.v1.TriggerDiscoveryRequest\x1a'.docbuilder.v1.TriggerDiscoveryResponse\x12]\n" +
+	"\x0eGetBuildReport\x12$.docbuilder.v1.GetBuildReportRequest\x1a%.docbuilder.v1.GetBuildReportResponse\x12W\n" +
+	"\x10WatchBuildEvents\x12&.docbuilder.v1.WatchBuildEventsRequest\x1a\x19.docbuilder.v1.BuildEvent0\x01BKZIgit.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1;docbuilderv1b\x06proto3"
+
+var (
+	file_docbuilder_v1_daemon_proto_rawDescOnce sync.Once
+	file_docbuilder_v1_daemon_proto_rawDescData []byte
+)
+
+func file_docbuilder_v1_daemon_proto_rawDescGZIP() []byte {
+	file_docbuilder_v1_daemon_proto_rawDescOnce.Do(func() {
+		file_docbuilder_v1_daemon_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_docbuilder_v1_daemon_proto_rawDesc), len(file_docbuilder_v1_daemon_proto_rawDesc)))
+	})
+	return file_docbuilder_v1_daemon_proto_rawDescData
+}
+
+var file_docbuilder_v1_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_docbuilder_v1_daemon_proto_goTypes = []any{
+	(*GetStatusRequest)(nil),         // 0: docbuilder.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),        // 1: docbuilder.v1.GetStatusResponse
+	(*TriggerBuildRequest)(nil),      // 2: docbuilder.v1.TriggerBuildRequest
+	(*TriggerBuildResponse)(nil),     // 3: docbuilder.v1.TriggerBuildResponse
+	(*TriggerDiscoveryRequest)(nil),  // 4: docbuilder.v1.TriggerDiscoveryRequest
+	(*TriggerDiscoveryResponse)(nil), // 5: docbuilder.v1.TriggerDiscoveryResponse
+	(*GetBuildReportRequest)(nil),    // 6: docbuilder.v1.GetBuildReportRequest
+	(*GetBuildReportResponse)(nil),   // 7: docbuilder.v1.GetBuildReportResponse
+	(*WatchBuildEventsRequest)(nil),  // 8: docbuilder.v1.WatchBuildEventsRequest
+	(*BuildEvent)(nil),               // 9: docbuilder.v1.BuildEvent
+	nil,                              // 10: docbuilder.v1.TriggerBuildRequest.CommitsEntry
+	nil,                              // 11: docbuilder.v1.BuildEvent.MetadataEntry
+	(*timestamppb.Timestamp)(nil),    // 12: google.protobuf.Timestamp
+}
+var file_docbuilder_v1_daemon_proto_depIdxs = []int32{
+	12, // 0: docbuilder.v1.GetStatusResponse.start_time:type_name -> google.protobuf.Timestamp
+	10, // 1: docbuilder.v1.TriggerBuildRequest.commits:type_name -> docbuilder.v1.TriggerBuildRequest.CommitsEntry
+	12, // 2: docbuilder.v1.BuildEvent.time:type_name -> google.protobuf.Timestamp
+	11, // 3: docbuilder.v1.BuildEvent.metadata:type_name -> docbuilder.v1.BuildEvent.MetadataEntry
+	0,  // 4: docbuilder.v1.DaemonService.GetStatus:input_type -> docbuilder.v1.GetStatusRequest
+	2,  // 5: docbuilder.v1.DaemonService.TriggerBuild:input_type -> docbuilder.v1.TriggerBuildRequest
+	4,  // 6: docbuilder.v1.DaemonService.TriggerDiscovery:input_type -> docbuilder.v1.TriggerDiscoveryRequest
+	6,  // 7: docbuilder.v1.DaemonService.GetBuildReport:input_type -> docbuilder.v1.GetBuildReportRequest
+	8,  // 8: docbuilder.v1.DaemonService.WatchBuildEvents:input_type -> docbuilder.v1.WatchBuildEventsRequest
+	1,  // 9: docbuilder.v1.DaemonService.GetStatus:output_type -> docbuilder.v1.GetStatusResponse
+	3,  // 10: docbuilder.v1.DaemonService.TriggerBuild:output_type -> docbuilder.v1.TriggerBuildResponse
+	5,  // 11: docbuilder.v1.DaemonService.TriggerDiscovery:output_type -> docbuilder.v1.TriggerDiscoveryResponse
+	7,  // 12: docbuilder.v1.DaemonService.GetBuildReport:output_type -> docbuilder.v1.GetBuildReportResponse
+	9,  // 13: docbuilder.v1.DaemonService.WatchBuildEvents:output_type -> docbuilder.v1.BuildEvent
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_docbuilder_v1_daemon_proto_init() }
+func file_docbuilder_v1_daemon_proto_init() {
+	if File_docbuilder_v1_daemon_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_docbuilder_v1_daemon_proto_rawDesc), len(file_docbuilder_v1_daemon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_docbuilder_v1_daemon_proto_goTypes,
+		DependencyIndexes: file_docbuilder_v1_daemon_proto_depIdxs,
+		MessageInfos:      file_docbuilder_v1_daemon_proto_msgTypes,
+	}.Build()
+	File_docbuilder_v1_daemon_proto = out.File
+	file_docbuilder_v1_daemon_proto_goTypes = nil
+	file_docbuilder_v1_daemon_proto_depIdxs = nil
+}
@@ -0,0 +1,238 @@
+// Control API of the DocBuilder daemon. It mirrors the admin HTTP API and is served on
+// the admin port when daemon.http.rpc is enabled, over the gRPC, gRPC-Web and Connect
+// protocols. Regenerate the Go code with `buf generate`.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: docbuilder/v1/daemon.proto
+
+package docbuilderv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "git.home.luguber.info/inful/docbuilder/pkg/api/docbuilder/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// DaemonServiceName is the fully-qualified name of the DaemonService service.
+	DaemonServiceName = "docbuilder.v1.DaemonService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DaemonServiceGetStatusProcedure is the fully-qualified name of the DaemonService's GetStatus RPC.
+	DaemonServiceGetStatusProcedure = "/docbuilder.v1.DaemonService/GetStatus"
+	// DaemonServiceTriggerBuildProcedure is the fully-qualified name of the DaemonService's
+	// TriggerBuild RPC.
+	DaemonServiceTriggerBuildProcedure = "/docbuilder.v1.DaemonService/TriggerBuild"
+	// DaemonServiceTriggerDiscoveryProcedure is the fully-qualified name of the DaemonService's
+	// TriggerDiscovery RPC.
+	DaemonServiceTriggerDiscoveryProcedure = "/docbuilder.v1.DaemonService/TriggerDiscovery"
+	// DaemonServiceGetBuildReportProcedure is the fully-qualified name of the DaemonService's
+	// GetBuildReport RPC.
+	DaemonServiceGetBuildReportProcedure = "/docbuilder.v1.DaemonService/GetBuildReport"
+	// DaemonServiceWatchBuildEventsProcedure is the fully-qualified name of the DaemonService's
+	// WatchBuildEvents RPC.
+	DaemonServiceWatchBuildEventsProcedure = "/docbuilder.v1.DaemonService/WatchBuildEvents"
+)
+
+// DaemonServiceClient is a client for the docbuilder.v1.DaemonService service.
+type DaemonServiceClient interface {
+	// GetStatus reports the daemon state (GET /api/daemon/status, GET /api/build/status).
+	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
+	// TriggerBuild queues a build (POST /api/build/trigger).
+	TriggerBuild(context.Context, *connect.Request[v1.TriggerBuildRequest]) (*connect.Response[v1.TriggerBuildResponse], error)
+	// TriggerDiscovery starts forge discovery (POST /api/discovery/trigger).
+	TriggerDiscovery(context.Context, *connect.Request[v1.TriggerDiscoveryRequest]) (*connect.Response[v1.TriggerDiscoveryResponse], error)
+	// GetBuildReport returns the stored report of a build (GET /api/builds/{id}/report).
+	GetBuildReport(context.Context, *connect.Request[v1.GetBuildReportRequest]) (*connect.Response[v1.GetBuildReportResponse], error)
+	// WatchBuildEvents streams build lifecycle events as the daemon records them.
+	WatchBuildEvents(context.Context, *connect.Request[v1.WatchBuildEventsRequest]) (*connect.ServerStreamForClient[v1.BuildEvent], error)
+}
+
+// NewDaemonServiceClient constructs a client for the docbuilder.v1.DaemonService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDaemonServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DaemonServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	daemonServiceMethods := v1.File_docbuilder_v1_daemon_proto.Services().ByName("DaemonService").Methods()
+	return &daemonServiceClient{
+		getStatus: connect.NewClient[v1.GetStatusRequest, v1.GetStatusResponse](
+			httpClient,
+			baseURL+DaemonServiceGetStatusProcedure,
+			connect.WithSchema(daemonServiceMethods.ByName("GetStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		triggerBuild: connect.NewClient[v1.TriggerBuildRequest, v1.TriggerBuildResponse](
+			httpClient,
+			baseURL+DaemonServiceTriggerBuildProcedure,
+			connect.WithSchema(daemonServiceMethods.ByName("TriggerBuild")),
+			connect.WithClientOptions(opts...),
+		),
+		triggerDiscovery: connect.NewClient[v1.TriggerDiscoveryRequest, v1.TriggerDiscoveryResponse](
+			httpClient,
+			baseURL+DaemonServiceTriggerDiscoveryProcedure,
+			connect.WithSchema(daemonServiceMethods.ByName("TriggerDiscovery")),
+			connect.WithClientOptions(opts...),
+		),
+		getBuildReport: connect.NewClient[v1.GetBuildReportRequest, v1.GetBuildReportResponse](
+			httpClient,
+			baseURL+DaemonServiceGetBuildReportProcedure,
+			connect.WithSchema(daemonServiceMethods.ByName("GetBuildReport")),
+			connect.WithClientOptions(opts...),
+		),
+		watchBuildEvents: connect.NewClient[v1.WatchBuildEventsRequest, v1.BuildEvent](
+			httpClient,
+			baseURL+DaemonServiceWatchBuildEventsProcedure,
+			connect.WithSchema(daemonServiceMethods.ByName("WatchBuildEvents")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// daemonServiceClient implements DaemonServiceClient.
+type daemonServiceClient struct {
+	getStatus        *connect.Client[v1.GetStatusRequest, v1.GetStatusResponse]
+	triggerBuild     *connect.Client[v1.TriggerBuildRequest, v1.TriggerBuildResponse]
+	triggerDiscovery *connect.Client[v1.TriggerDiscoveryRequest, v1.TriggerDiscoveryResponse]
+	getBuildReport   *connect.Client[v1.GetBuildReportRequest, v1.GetBuildReportResponse]
+	watchBuildEvents *connect.Client[v1.WatchBuildEventsRequest, v1.BuildEvent]
+}
+
+// GetStatus calls docbuilder.v1.DaemonService.GetStatus.
+func (c *daemonServiceClient) GetStatus(ctx context.Context, req *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
+	return c.getStatus.CallUnary(ctx, req)
+}
+
+// TriggerBuild calls docbuilder.v1.DaemonService.TriggerBuild.
+func (c *daemonServiceClient) TriggerBuild(ctx context.Context, req *connect.Request[v1.TriggerBuildRequest]) (*connect.Response[v1.TriggerBuildResponse], error) {
+	return c.triggerBuild.CallUnary(ctx, req)
+}
+
+// TriggerDiscovery calls docbuilder.v1.DaemonService.TriggerDiscovery.
+func (c *daemonServiceClient) TriggerDiscovery(ctx context.Context, req *connect.Request[v1.TriggerDiscoveryRequest]) (*connect.Response[v1.TriggerDiscoveryResponse], error) {
+	return c.triggerDiscovery.CallUnary(ctx, req)
+}
+
+// GetBuildReport calls docbuilder.v1.DaemonService.GetBuildReport.
+func (c *daemonServiceClient) GetBuildReport(ctx context.Context, req *connect.Request[v1.GetBuildReportRequest]) (*connect.Response[v1.GetBuildReportResponse], error) {
+	return c.getBuildReport.CallUnary(ctx, req)
+}
+
+// WatchBuildEvents calls docbuilder.v1.DaemonService.WatchBuildEvents.
+func (c *daemonServiceClient) WatchBuildEvents(ctx context.Context, req *connect.Request[v1.WatchBuildEventsRequest]) (*connect.ServerStreamForClient[v1.BuildEvent], error) {
+	return c.watchBuildEvents.CallServerStream(ctx, req)
+}
+
+// DaemonServiceHandler is an implementation of the docbuilder.v1.DaemonService service.
+type DaemonServiceHandler interface {
+	// GetStatus reports the daemon state (GET /api/daemon/status, GET /api/build/status).
+	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
+	// TriggerBuild queues a build (POST /api/build/trigger).
+	TriggerBuild(context.Context, *connect.Request[v1.TriggerBuildRequest]) (*connect.Response[v1.TriggerBuildResponse], error)
+	// TriggerDiscovery starts forge discovery (POST /api/discovery/trigger).
+	TriggerDiscovery(context.Context, *connect.Request[v1.TriggerDiscoveryRequest]) (*connect.Response[v1.TriggerDiscoveryResponse], error)
+	// GetBuildReport returns the stored report of a build (GET /api/builds/{id}/report).
+	GetBuildReport(context.Context, *connect.Request[v1.GetBuildReportRequest]) (*connect.Response[v1.GetBuildReportResponse], error)
+	// WatchBuildEvents streams build lifecycle events as the daemon records them.
+	WatchBuildEvents(context.Context, *connect.Request[v1.WatchBuildEventsRequest], *connect.ServerStream[v1.BuildEvent]) error
+}
+
+// NewDaemonServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDaemonServiceHandler(svc DaemonServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	daemonServiceMethods := v1.File_docbuilder_v1_daemon_proto.Services().ByName("DaemonService").Methods()
+	daemonServiceGetStatusHandler := connect.NewUnaryHandler(
+		DaemonServiceGetStatusProcedure,
+		svc.GetStatus,
+		connect.WithSchema(daemonServiceMethods.ByName("GetStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceTriggerBuildHandler := connect.NewUnaryHandler(
+		DaemonServiceTriggerBuildProcedure,
+		svc.TriggerBuild,
+		connect.WithSchema(daemonServiceMethods.ByName("TriggerBuild")),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceTriggerDiscoveryHandler := connect.NewUnaryHandler(
+		DaemonServiceTriggerDiscoveryProcedure,
+		svc.TriggerDiscovery,
+		connect.WithSchema(daemonServiceMethods.ByName("TriggerDiscovery")),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceGetBuildReportHandler := connect.NewUnaryHandler(
+		DaemonServiceGetBuildReportProcedure,
+		svc.GetBuildReport,
+		connect.WithSchema(daemonServiceMethods.ByName("GetBuildReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceWatchBuildEventsHandler := connect.NewServerStreamHandler(
+		DaemonServiceWatchBuildEventsProcedure,
+		svc.WatchBuildEvents,
+		connect.WithSchema(daemonServiceMethods.ByName("WatchBuildEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/docbuilder.v1.DaemonService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DaemonServiceGetStatusProcedure:
+			daemonServiceGetStatusHandler.ServeHTTP(w, r)
+		case DaemonServiceTriggerBuildProcedure:
+			daemonServiceTriggerBuildHandler.ServeHTTP(w, r)
+		case DaemonServiceTriggerDiscoveryProcedure:
+			daemonServiceTriggerDiscoveryHandler.ServeHTTP(w, r)
+		case DaemonServiceGetBuildReportProcedure:
+			daemonServiceGetBuildReportHandler.ServeHTTP(w, r)
+		case DaemonServiceWatchBuildEventsProcedure:
+			daemonServiceWatchBuildEventsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDaemonServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDaemonServiceHandler struct{}
+
+func (UnimplementedDaemonServiceHandler) GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("docbuilder.v1.DaemonService.GetStatus is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) TriggerBuild(context.Context, *connect.Request[v1.TriggerBuildRequest]) (*connect.Response[v1.TriggerBuildResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("docbuilder.v1.DaemonService.TriggerBuild is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) TriggerDiscovery(context.Context, *connect.Request[v1.TriggerDiscoveryRequest]) (*connect.Response[v1.TriggerDiscoveryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("docbuilder.v1.DaemonService.TriggerDiscovery is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) GetBuildReport(context.Context, *connect.Request[v1.GetBuildReportRequest]) (*connect.Response[v1.GetBuildReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("docbuilder.v1.DaemonService.GetBuildReport is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) WatchBuildEvents(context.Context, *connect.Request[v1.WatchBuildEventsRequest], *connect.ServerStream[v1.BuildEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("docbuilder.v1.DaemonService.WatchBuildEvents is not implemented"))
+}
@@ -0,0 +1,107 @@
+package docbuilder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/build"
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+	"git.home.luguber.info/inful/docbuilder/internal/hugo"
+	"git.home.luguber.info/inful/docbuilder/internal/workspace"
+)
+
+// incrementalWorkspaceDir is the persistent workspace subdirectory of incremental builds;
+// it is the one the CLI uses, so both can share working copies.
+const incrementalWorkspaceDir = "docbuilder-working"
+
+// Status is the outcome of a build.
+type Status string
+
+const (
+	StatusSuccess  Status = "success"  // The site was generated
+	StatusFailed   Status = "failed"   // The build failed; see the returned error
+	StatusCanceled Status = "canceled" // The context was canceled
+)
+
+// BuildOptions tunes a build. The zero value builds into the configured output
+// directory from fresh clones.
+type BuildOptions struct {
+	// OutputDir overrides the configured output directory.
+	OutputDir string
+	// Incremental keeps working copies between builds and updates them instead of
+	// cloning again.
+	Incremental bool
+}
+
+// Result is the outcome of a build.
+type Result struct {
+	Status              Status
+	OutputDir           string // Directory the site was written to
+	Repositories        int    // Repositories processed
+	RepositoriesSkipped int    // Repositories that failed to clone or process
+	FilesProcessed      int    // Documentation files handled
+	Start               time.Time
+	End                 time.Time
+	Duration            time.Duration
+	Report              *Report // Detailed build report; nil when the build failed before generating
+}
+
+// Build clones the configured repositories, generates the Hugo site and, when enabled in
+// the configuration, renders it. A failed build returns its Result together with the
+// error. The configuration may be adjusted by the build (e.g. the clone strategy of an
+// incremental build) and should not be shared with concurrent builds.
+func Build(ctx context.Context, cfg *Config, opts BuildOptions) (*Result, error) {
+	if cfg == nil {
+		return nil, errors.New("docbuilder: config required")
+	}
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = cfg.OutputDir()
+	}
+	svc := build.NewBuildService().
+		WithHugoGeneratorFactory(func(c *config.Config, dir string) build.HugoGenerator {
+			return hugo.NewGenerator(c, dir)
+		})
+	if opts.Incremental {
+		svc = svc.WithWorkspaceFactory(func() *workspace.Manager {
+			return workspace.NewPersistentManager(cfg.cfg.Build.WorkspaceDir, incrementalWorkspaceDir)
+		})
+	}
+	res, err := svc.Run(ctx, build.BuildRequest{
+		Config:      cfg.cfg,
+		OutputDir:   outputDir,
+		Incremental: opts.Incremental,
+	})
+	if res == nil {
+		return nil, err
+	}
+	out := &Result{
+		Status:              statusOf(ctx, res.Status, err),
+		OutputDir:           res.OutputPath,
+		Repositories:        res.Repositories,
+		RepositoriesSkipped: res.RepositoriesSkipped,
+		FilesProcessed:      res.FilesProcessed,
+		Start:               res.StartTime,
+		End:                 res.EndTime,
+		Duration:            res.Duration,
+	}
+	if res.Report != nil {
+		out.Report = newReport(res.Report.SanitizedCopy())
+	}
+	return out, err
+}
+
+func statusOf(ctx context.Context, status build.BuildStatus, err error) Status {
+	if err != nil && ctx.Err() != nil {
+		return StatusCanceled
+	}
+	switch status {
+	case build.BuildStatusSuccess:
+		return StatusSuccess
+	case build.BuildStatusCancelled:
+		return StatusCanceled
+	default:
+		return StatusFailed
+	}
+}
@@ -0,0 +1,62 @@
+package docbuilder
+
+import (
+	"path/filepath"
+
+	"git.home.luguber.info/inful/docbuilder/internal/config"
+)
+
+// Config is a loaded and validated DocBuilder configuration. Its schema is the YAML
+// configuration file format documented in docs/reference/configuration.md.
+type Config struct {
+	cfg      *config.Config
+	warnings []string
+}
+
+// Repository describes a configured source repository.
+type Repository struct {
+	Name   string
+	URL    string
+	Branch string
+	Paths  []string // Documentation paths inside the repository
+}
+
+// LoadConfig reads, normalizes and validates a configuration file. Environment variables
+// in the file are expanded; .env files are not loaded.
+func LoadConfig(path string) (*Config, error) {
+	res, cfg, err := config.LoadWithResult(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{cfg: cfg, warnings: res.Warnings}, nil
+}
+
+// Warnings returns the non-fatal problems found while loading the configuration.
+func (c *Config) Warnings() []string {
+	return append([]string(nil), c.warnings...)
+}
+
+// OutputDir returns the configured output directory: output.directory, relative to
+// output.base_directory when that is set.
+func (c *Config) OutputDir() string {
+	if c.cfg.Output.BaseDirectory != "" && !filepath.IsAbs(c.cfg.Output.Directory) {
+		return filepath.Join(c.cfg.Output.BaseDirectory, c.cfg.Output.Directory)
+	}
+	return c.cfg.Output.Directory
+}
+
+// Repositories returns the statically configured repositories. Repositories found
+// through forge auto-discovery are not included.
+func (c *Config) Repositories() []Repository {
+	repos := make([]Repository, 0, len(c.cfg.Repositories))
+	for i := range c.cfg.Repositories {
+		r := &c.cfg.Repositories[i]
+		repos = append(repos, Repository{
+			Name:   r.Name,
+			URL:    r.URL,
+			Branch: r.Branch,
+			Paths:  append([]string(nil), r.Paths...),
+		})
+	}
+	return repos
+}
@@ -0,0 +1,47 @@
+package docbuilder
+
+import (
+	"context"
+
+	"git.home.luguber.info/inful/docbuilder/internal/docs"
+)
+
+// DocFile is a documentation file or asset found by Discover.
+type DocFile struct {
+	Path         string // Absolute path to the file
+	RelativePath string // Path relative to the repository's docs directory
+	Repository   string // Repository name, or the section name for monorepo sections
+	Forge        string // Forge namespace (empty unless the site is namespaced by forge)
+	Section      string // Directory of the file below the docs directory
+	Name         string // File name without extension
+	Extension    string // File extension, including the dot
+	IsAsset      bool   // True for images and other non-markdown files
+}
+
+// Discover finds the documentation files of the configured repositories in local
+// checkouts. repoPaths maps configured repository names to the directories they are
+// checked out in; only those repositories are searched and nothing is cloned.
+func Discover(ctx context.Context, cfg *Config, repoPaths map[string]string) ([]DocFile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	found, err := docs.NewDiscovery(cfg.cfg.Repositories, &cfg.cfg.Build).DiscoverDocs(repoPaths)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]DocFile, 0, len(found))
+	for i := range found {
+		f := &found[i]
+		files = append(files, DocFile{
+			Path:         f.Path,
+			RelativePath: f.RelativePath,
+			Repository:   f.Repository,
+			Forge:        f.Forge,
+			Section:      f.Section,
+			Name:         f.Name,
+			Extension:    f.Extension,
+			IsAsset:      f.IsAsset,
+		})
+	}
+	return files, nil
+}
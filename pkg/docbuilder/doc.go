@@ -0,0 +1,24 @@
+// Package docbuilder is the public Go API of DocBuilder. It lets other programs load a
+// configuration, discover documentation and run complete builds in-process instead of
+// shelling out to the docbuilder CLI.
+//
+// # Stability
+//
+// This package follows semantic versioning: exported identifiers are only removed or
+// changed incompatibly in a new major version. Everything below internal/ remains free
+// to change, so this package exposes its own types (Config, DocFile, Result, Report)
+// rather than the internal ones. New fields may be added to the exported structs in
+// minor releases; construct them with field names.
+//
+// A minimal embedding:
+//
+//	cfg, err := docbuilder.LoadConfig("config.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	res, err := docbuilder.Build(ctx, cfg, docbuilder.BuildOptions{OutputDir: "./site"})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(res.Status, res.Report.RenderedPages)
+package docbuilder
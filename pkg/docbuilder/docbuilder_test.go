@@ -0,0 +1,125 @@
+package docbuilder_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.home.luguber.info/inful/docbuilder/pkg/docbuilder"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestLoadConfigAndDiscover(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, cfgPath, `version: "2.0"
+repositories:
+  - name: guide
+    url: https://example.com/guide.git
+    branch: main
+    paths: [docs]
+output:
+  base_directory: `+dir+`
+  directory: site
+hugo:
+  title: SDK Test
+`)
+	cfg, err := docbuilder.LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got, want := cfg.OutputDir(), filepath.Join(dir, "site"); got != want {
+		t.Fatalf("OutputDir = %q, want %q", got, want)
+	}
+	repos := cfg.Repositories()
+	if len(repos) != 1 || repos[0].Name != "guide" || repos[0].Branch != "main" {
+		t.Fatalf("Repositories = %+v", repos)
+	}
+
+	checkout := filepath.Join(dir, "checkout")
+	writeFile(t, filepath.Join(checkout, "docs", "intro.md"), "# Intro\n")
+	writeFile(t, filepath.Join(checkout, "docs", "howto", "setup.md"), "# Setup\n")
+	files, err := docbuilder.Discover(context.Background(), cfg, map[string]string{"guide": checkout})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Discover found %d files, want 2: %+v", len(files), files)
+	}
+	for _, f := range files {
+		if f.Repository != "guide" || f.Extension != ".md" || f.IsAsset {
+			t.Fatalf("unexpected file %+v", f)
+		}
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	if _, err := docbuilder.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected an error for a missing configuration file")
+	}
+}
+
+func TestBuildCanceled(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, cfgPath, `version: "2.0"
+repositories:
+  - name: guide
+    url: https://example.invalid/guide.git
+hugo:
+  title: Canceled
+`)
+	cfg, err := docbuilder.LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out := filepath.Join(dir, "out")
+	res, err := docbuilder.Build(ctx, cfg, docbuilder.BuildOptions{OutputDir: out})
+	if err == nil {
+		t.Fatal("expected an error for a canceled build")
+	}
+	if res == nil || res.Status != docbuilder.StatusCanceled || res.OutputDir != out {
+		t.Fatalf("Result = %+v", res)
+	}
+}
+
+func TestBuildRequiresConfig(t *testing.T) {
+	if _, err := docbuilder.Build(context.Background(), nil, docbuilder.BuildOptions{}); err == nil {
+		t.Fatal("expected an error without a configuration")
+	}
+}
+
+func TestDecodeReport(t *testing.T) {
+	rep, err := docbuilder.DecodeReport([]byte(`{"schema_version":1,"outcome":"warning","repositories":2,"rendered_pages":7,"warnings":["w"],"changed_urls":["https://docs.example.com/a/"],"unknown":true}`))
+	if err != nil {
+		t.Fatalf("DecodeReport: %v", err)
+	}
+	if rep.Outcome != "warning" || rep.Repositories != 2 || rep.RenderedPages != 7 || len(rep.Warnings) != 1 || len(rep.ChangedURLs) != 1 {
+		t.Fatalf("Report = %+v", rep)
+	}
+	if _, err := docbuilder.DecodeReport([]byte(`{"schema_version":999}`)); err == nil {
+		t.Fatal("expected an error for an unsupported schema version")
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, docbuilder.ReportFile), `{"schema_version":1,"outcome":"success","files":3}`)
+	rep, err = docbuilder.ReadReport(dir)
+	if err != nil {
+		t.Fatalf("ReadReport: %v", err)
+	}
+	if rep.Files != 3 {
+		t.Fatalf("Files = %d, want 3", rep.Files)
+	}
+}
@@ -0,0 +1,83 @@
+package docbuilder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.home.luguber.info/inful/docbuilder/internal/hugo/models"
+)
+
+// ReportFile is the name of the JSON build report written to the output directory.
+const ReportFile = "build-report.json"
+
+// Report summarizes a build. It holds the stable subset of build-report.json; see
+// docs/reference/cli.md for the meaning of each field.
+type Report struct {
+	SchemaVersion       int                      `json:"schema_version"`
+	Outcome             string                   `json:"outcome"` // success, warning, failed or canceled
+	Start               time.Time                `json:"start"`
+	End                 time.Time                `json:"end"`
+	Repositories        int                      `json:"repositories"`
+	ClonedRepositories  int                      `json:"cloned_repositories"`
+	FailedRepositories  int                      `json:"failed_repositories"`
+	SkippedRepositories int                      `json:"skipped_repositories"`
+	Files               int                      `json:"files"`
+	RenderedPages       int                      `json:"rendered_pages"`
+	StaticRendered      bool                     `json:"static_rendered"`
+	Errors              []string                 `json:"errors"`
+	Warnings            []string                 `json:"warnings"`
+	StageDurations      map[string]time.Duration `json:"stage_durations"`
+	ChangedURLs         []string                 `json:"changed_urls,omitempty"`
+	SkipReason          string                   `json:"skip_reason,omitempty"`
+	DocFilesHash        string                   `json:"doc_files_hash,omitempty"`
+	ConfigHash          string                   `json:"config_hash,omitempty"`
+	DocBuilderVersion   string                   `json:"docbuilder_version,omitempty"`
+	HugoVersion         string                   `json:"hugo_version,omitempty"`
+}
+
+// ReadReport reads the build report of a previous build from its output directory.
+func ReadReport(outputDir string) (*Report, error) {
+	// #nosec G304 -- outputDir is chosen by the caller
+	data, err := os.ReadFile(filepath.Join(outputDir, ReportFile))
+	if err != nil {
+		return nil, fmt.Errorf("read build report: %w", err)
+	}
+	return DecodeReport(data)
+}
+
+// DecodeReport parses a build-report.json document. It rejects schema versions newer
+// than this version of the package understands.
+func DecodeReport(data []byte) (*Report, error) {
+	r, err := models.DecodeReport(data)
+	if err != nil {
+		return nil, err
+	}
+	return newReport(r), nil
+}
+
+func newReport(r *models.BuildReportSerializable) *Report {
+	return &Report{
+		SchemaVersion:       r.SchemaVersion,
+		Outcome:             r.Outcome,
+		Start:               r.Start,
+		End:                 r.End,
+		Repositories:        r.Repositories,
+		ClonedRepositories:  r.ClonedRepositories,
+		FailedRepositories:  r.FailedRepositories,
+		SkippedRepositories: r.SkippedRepositories,
+		Files:               r.Files,
+		RenderedPages:       r.RenderedPages,
+		StaticRendered:      r.StaticRendered,
+		Errors:              r.Errors,
+		Warnings:            r.Warnings,
+		StageDurations:      r.StageDurations,
+		ChangedURLs:         r.ChangedURLs,
+		SkipReason:          r.SkipReason,
+		DocFilesHash:        r.DocFilesHash,
+		ConfigHash:          r.ConfigHash,
+		DocBuilderVersion:   r.DocBuilderVersion,
+		HugoVersion:         r.HugoVersion,
+	}
+}